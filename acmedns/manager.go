@@ -0,0 +1,455 @@
+// Package acmedns obtains TLS certificates from an ACME certificate authority
+// by completing DNS-01 challenges, so no publicly reachable challenge listener
+// is required.
+package acmedns
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/acme"
+)
+
+const (
+	// DefaultRenewBefore is how long before its expiry a certificate is
+	// renewed.
+	DefaultRenewBefore = 30 * 24 * time.Hour
+
+	// checkInterval is the interval at which the expiry of the
+	// certificate is checked, and failed attempts to obtain one are
+	// retried.
+	checkInterval = time.Hour
+
+	// obtainTimeout is the maximum duration of a single attempt to obtain
+	// a certificate, including the hook invocations.
+	obtainTimeout = 10 * time.Minute
+
+	// accountKeyFile is the name of the file within the cache directory
+	// that holds the key of the ACME account.
+	accountKeyFile = "acme_dns_account.key"
+
+	// challengeType is the type of the ACME challenge that is completed.
+	challengeType = "dns-01"
+)
+
+var (
+	// ErrNoCertificate is returned by GetCertificate if no certificate
+	// has been obtained yet.
+	ErrNoCertificate = errors.New("no certificate obtained yet")
+)
+
+// Config holds the configuration of a Manager.
+type Config struct {
+	// DirectoryURL is the directory URL of the ACME server. If it is
+	// empty, Let's Encrypt's production server is used.
+	DirectoryURL string
+
+	// Domain is the domain to obtain a certificate for.
+	Domain string
+
+	// CacheDir is the directory the account key and the certificate are
+	// stored in.
+	CacheDir string
+
+	// Hook is the path to an executable that publishes and removes the
+	// DNS TXT records of the challenges. It is called with the arguments
+	// "present" or "cleanup", the name of the record and its value. When
+	// called with "present", it must only exit once the record can be
+	// resolved by the ACME server.
+	Hook string
+
+	// RenewBefore is how long before its expiry the certificate is
+	// renewed. If it is zero, DefaultRenewBefore is used.
+	RenewBefore time.Duration
+}
+
+// Manager obtains a certificate for a single domain and renews it before it
+// expires.
+type Manager struct {
+	started sync.Once
+	stopped sync.Once
+
+	cfg *Config
+
+	mu   sync.RWMutex
+	cert *tls.Certificate
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// New creates a new Manager from the given config.
+func New(cfg *Config) *Manager {
+	return &Manager{
+		cfg:  cfg,
+		quit: make(chan struct{}),
+	}
+}
+
+// Start loads the cached certificate, if any, and launches the goroutine that
+// obtains and renews the certificate.
+func (m *Manager) Start() error {
+	var startErr error
+	m.started.Do(func() {
+		if err := os.MkdirAll(m.cfg.CacheDir, 0700); err != nil {
+			startErr = err
+			return
+		}
+
+		cert, err := tls.LoadX509KeyPair(m.certPaths())
+		switch {
+		case os.IsNotExist(err):
+
+		case err != nil:
+			log.Warnf("Unable to load cached certificate for %v, "+
+				"obtaining a new one: %v", m.cfg.Domain, err)
+
+		default:
+			leaf := cert.Certificate[0]
+			cert.Leaf, err = x509.ParseCertificate(leaf)
+			if err != nil {
+				startErr = err
+				return
+			}
+			m.cert = &cert
+		}
+
+		m.wg.Add(1)
+		go m.renewHandler()
+	})
+
+	return startErr
+}
+
+// Stop stops renewing the certificate and aborts any ongoing attempt to
+// obtain one.
+func (m *Manager) Stop() {
+	m.stopped.Do(func() {
+		close(m.quit)
+		m.wg.Wait()
+	})
+}
+
+// GetCertificate returns the certificate for TLS handshakes that request the
+// domain of the manager. It is meant to be used as the GetCertificate
+// callback of a TLS config.
+func (m *Manager) GetCertificate(
+	hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+
+	if !strings.EqualFold(hello.ServerName, m.cfg.Domain) {
+		return nil, fmt.Errorf("no certificate for server name %q",
+			hello.ServerName)
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.cert == nil {
+		return nil, ErrNoCertificate
+	}
+
+	return m.cert, nil
+}
+
+// renewHandler obtains a certificate if there is none yet or the current one
+// is about to expire.
+//
+// NOTE: This method MUST be run as a goroutine.
+func (m *Manager) renewHandler() {
+	defer m.wg.Done()
+
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+
+	for {
+		if m.needsRenewal(time.Now()) {
+			log.Infof("Obtaining certificate for %v", m.cfg.Domain)
+
+			if err := m.obtain(); err != nil {
+				log.Errorf("Unable to obtain certificate for "+
+					"%v: %v", m.cfg.Domain, err)
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-m.quit:
+			return
+		}
+	}
+}
+
+// needsRenewal returns true if there is no certificate yet, or the current one
+// is due to be renewed at the given time.
+func (m *Manager) needsRenewal(now time.Time) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.cert == nil {
+		return true
+	}
+
+	renewBefore := m.cfg.RenewBefore
+	if renewBefore == 0 {
+		renewBefore = DefaultRenewBefore
+	}
+
+	return !now.Before(m.cert.Leaf.NotAfter.Add(-renewBefore))
+}
+
+// obtain requests a new certificate from the ACME server, completing the
+// DNS-01 challenges of the order through the hook.
+func (m *Manager) obtain() error {
+	ctx, cancel := context.WithTimeout(
+		context.Background(), obtainTimeout,
+	)
+	defer cancel()
+
+	// Abort the attempt if the manager is stopped.
+	go func() {
+		select {
+		case <-m.quit:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	accountKey, err := m.accountKey()
+	if err != nil {
+		return err
+	}
+
+	client := &acme.Client{
+		Key:          accountKey,
+		DirectoryURL: m.cfg.DirectoryURL,
+	}
+	_, err = client.Register(ctx, &acme.Account{}, acme.AcceptTOS)
+	if err != nil && err != acme.ErrAccountAlreadyExists {
+		return fmt.Errorf("unable to register account: %v", err)
+	}
+
+	order, err := client.AuthorizeOrder(ctx, acme.DomainIDs(m.cfg.Domain))
+	if err != nil {
+		return err
+	}
+	for _, authzURL := range order.AuthzURLs {
+		if err := m.authorize(ctx, client, authzURL); err != nil {
+			return err
+		}
+	}
+
+	order, err = client.WaitOrder(ctx, order.URI)
+	if err != nil {
+		return err
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+	csr, err := x509.CreateCertificateRequest(
+		rand.Reader, &x509.CertificateRequest{
+			Subject:  pkix.Name{CommonName: m.cfg.Domain},
+			DNSNames: []string{m.cfg.Domain},
+		}, key,
+	)
+	if err != nil {
+		return err
+	}
+
+	der, _, err := client.CreateOrderCert(ctx, order.FinalizeURL, csr, true)
+	if err != nil {
+		return err
+	}
+
+	cert, err := m.storeCertificate(der, key)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	m.cert = cert
+	m.mu.Unlock()
+
+	log.Infof("Obtained certificate for %v, expires at %v",
+		m.cfg.Domain, cert.Leaf.NotAfter)
+
+	return nil
+}
+
+// authorize completes the DNS-01 challenge of the given authorization, unless
+// it is already valid.
+func (m *Manager) authorize(ctx context.Context, client *acme.Client,
+	authzURL string) error {
+
+	authz, err := client.GetAuthorization(ctx, authzURL)
+	if err != nil {
+		return err
+	}
+	if authz.Status == acme.StatusValid {
+		return nil
+	}
+
+	var chal *acme.Challenge
+	for _, c := range authz.Challenges {
+		if c.Type == challengeType {
+			chal = c
+			break
+		}
+	}
+	if chal == nil {
+		return fmt.Errorf("no %v challenge offered for %v",
+			challengeType, authz.Identifier.Value)
+	}
+
+	record, err := client.DNS01ChallengeRecord(chal.Token)
+	if err != nil {
+		return err
+	}
+	name := "_acme-challenge." + authz.Identifier.Value
+
+	if err := m.runHook(ctx, "present", name, record); err != nil {
+		return err
+	}
+	defer func() {
+		// The record is removed even if the attempt was aborted.
+		err := m.runHook(context.Background(), "cleanup", name, record)
+		if err != nil {
+			log.Warnf("Unable to clean up challenge record: %v",
+				err)
+		}
+	}()
+
+	if _, err := client.Accept(ctx, chal); err != nil {
+		return err
+	}
+
+	_, err = client.WaitAuthorization(ctx, authz.URI)
+	return err
+}
+
+// runHook calls the hook to publish or remove the TXT record with the given
+// name and value.
+func (m *Manager) runHook(ctx context.Context, action, name,
+	value string) error {
+
+	cmd := exec.CommandContext(ctx, m.cfg.Hook, action, name, value)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("hook %v %v failed: %v: %s", action, name,
+			err, bytes.TrimSpace(out))
+	}
+
+	return nil
+}
+
+// accountKey loads the key of the ACME account from the cache directory, or
+// generates and stores a new one.
+func (m *Manager) accountKey() (crypto.Signer, error) {
+	path := filepath.Join(m.cfg.CacheDir, accountKeyFile)
+
+	keyPEM, err := ioutil.ReadFile(path)
+	switch {
+	case os.IsNotExist(err):
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+
+		keyPEM, err := encodeKey(key)
+		if err != nil {
+			return nil, err
+		}
+
+		return key, ioutil.WriteFile(path, keyPEM, 0600)
+
+	case err != nil:
+		return nil, err
+	}
+
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, fmt.Errorf("invalid account key in %v", path)
+	}
+
+	return x509.ParseECPrivateKey(block.Bytes)
+}
+
+// certPaths returns the paths of the certificate and the key files within the
+// cache directory.
+func (m *Manager) certPaths() (string, string) {
+	base := filepath.Join(m.cfg.CacheDir, m.cfg.Domain+".dns01")
+	return base + ".crt", base + ".key"
+}
+
+// storeCertificate writes the certificate chain and its key to the cache
+// directory and returns them as a TLS certificate.
+func (m *Manager) storeCertificate(der [][]byte,
+	key *ecdsa.PrivateKey) (*tls.Certificate, error) {
+
+	var certPEM bytes.Buffer
+	for _, b := range der {
+		err := pem.Encode(&certPEM, &pem.Block{
+			Type:  "CERTIFICATE",
+			Bytes: b,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	keyPEM, err := encodeKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := tls.X509KeyPair(certPEM.Bytes(), keyPEM)
+	if err != nil {
+		return nil, err
+	}
+	cert.Leaf, err = x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return nil, err
+	}
+
+	certPath, keyPath := m.certPaths()
+	if err := ioutil.WriteFile(keyPath, keyPEM, 0600); err != nil {
+		return nil, err
+	}
+	err = ioutil.WriteFile(certPath, certPEM.Bytes(), 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cert, nil
+}
+
+// encodeKey PEM encodes an ECDSA private key.
+func encodeKey(key *ecdsa.PrivateKey) ([]byte, error) {
+	keyBytes, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{
+		Type:  "EC PRIVATE KEY",
+		Bytes: keyBytes,
+	}), nil
+}
@@ -0,0 +1,133 @@
+package acmedns
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// selfSignedCert creates a self-signed certificate for the given domain that
+// expires at the given time.
+func selfSignedCert(t *testing.T, domain string,
+	notAfter time.Time) ([][]byte, *ecdsa.PrivateKey) {
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: domain},
+		DNSNames:     []string{domain},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(
+		rand.Reader, template, template, &key.PublicKey, key,
+	)
+	require.NoError(t, err)
+
+	return [][]byte{der}, key
+}
+
+// TestCachedCertificate asserts that a stored certificate is loaded from the
+// cache directory on startup and only served for the configured domain.
+func TestCachedCertificate(t *testing.T) {
+	t.Parallel()
+
+	cacheDir, err := ioutil.TempDir("", "acmedns")
+	require.NoError(t, err)
+	defer os.RemoveAll(cacheDir)
+
+	cfg := &Config{
+		Domain:   "node.example.com",
+		CacheDir: cacheDir,
+	}
+	notAfter := time.Now().Add(90 * 24 * time.Hour)
+	der, key := selfSignedCert(t, cfg.Domain, notAfter)
+
+	stored, err := New(cfg).storeCertificate(der, key)
+	require.NoError(t, err)
+
+	m := New(cfg)
+	require.NoError(t, m.Start())
+	defer m.Stop()
+
+	cert, err := m.GetCertificate(&tls.ClientHelloInfo{
+		ServerName: "NODE.example.com",
+	})
+	require.NoError(t, err)
+	require.Equal(t, stored.Certificate, cert.Certificate)
+
+	_, err = m.GetCertificate(&tls.ClientHelloInfo{
+		ServerName: "other.example.com",
+	})
+	require.Error(t, err)
+
+	// The certificate is only renewed once it is about to expire.
+	require.False(t, m.needsRenewal(time.Now()))
+	require.True(t, m.needsRenewal(notAfter.Add(-DefaultRenewBefore)))
+}
+
+// TestNoCertificate asserts that no certificate is served before one has been
+// obtained.
+func TestNoCertificate(t *testing.T) {
+	t.Parallel()
+
+	m := New(&Config{Domain: "node.example.com"})
+	require.True(t, m.needsRenewal(time.Now()))
+
+	_, err := m.GetCertificate(&tls.ClientHelloInfo{
+		ServerName: "node.example.com",
+	})
+	require.Equal(t, ErrNoCertificate, err)
+}
+
+// TestRunHook asserts that the hook is called with the action, the name and
+// the value of the challenge record, and that its failures are reported.
+func TestRunHook(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("hook script requires a POSIX shell")
+	}
+
+	dir, err := ioutil.TempDir("", "acmedns")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	argsPath := filepath.Join(dir, "args")
+	hookPath := filepath.Join(dir, "hook.sh")
+	script := "#!/bin/sh\n" +
+		"[ \"$1\" = fail ] && { echo unreachable; exit 1; }\n" +
+		"echo \"$@\" > " + argsPath + "\n"
+	require.NoError(t, ioutil.WriteFile(hookPath, []byte(script), 0700))
+
+	m := New(&Config{Hook: hookPath})
+	err = m.runHook(
+		context.Background(), "present", "_acme-challenge.example.com",
+		"token",
+	)
+	require.NoError(t, err)
+
+	args, err := ioutil.ReadFile(argsPath)
+	require.NoError(t, err)
+	require.Equal(t, "present _acme-challenge.example.com token\n",
+		string(args))
+
+	err = m.runHook(context.Background(), "fail", "name", "value")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unreachable")
+}
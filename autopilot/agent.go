@@ -58,6 +58,11 @@ type Config struct {
 	// when opening channels.
 	Constraints AgentConstraints
 
+	// MaxOpensPerDay is the maximum number of channels the agent will
+	// open within any 24 hour window. If zero, the number of channel
+	// opens per day isn't limited.
+	MaxOpensPerDay uint32
+
 	// TODO(roasbeef): add additional signals from fee rates and revenue of
 	// currently opened channels
 }
@@ -164,7 +169,12 @@ type Agent struct {
 	// This state is required as otherwise, we may go over our allotted
 	// channel limit, or open multiple channels to the same node.
 	pendingOpens map[NodeID]LocalChannel
-	pendingMtx   sync.Mutex
+
+	// recentOpens tracks the time at which the agent initiated the
+	// channel opens of the past day, used to enforce the daily open
+	// budget.
+	recentOpens map[NodeID]time.Time
+	pendingMtx  sync.Mutex
 
 	quit chan struct{}
 	wg   sync.WaitGroup
@@ -188,6 +198,7 @@ func New(cfg Config, initialState []LocalChannel) (*Agent, error) {
 		failedNodes:        make(map[NodeID]struct{}),
 		pendingConns:       make(map[NodeID]struct{}),
 		pendingOpens:       make(map[NodeID]LocalChannel),
+		recentOpens:        make(map[NodeID]time.Time),
 	}
 
 	for _, c := range initialState {
@@ -420,6 +431,10 @@ func (a *Agent) controller() {
 		a.totalBalance = newBalance
 	}
 
+	// budgetRefresh is set when the daily open budget is exhausted, and
+	// fires once the oldest open of the past day falls out of the window.
+	var budgetRefresh <-chan time.Time
+
 	// TODO(roasbeef): add 10-minute wake up timer
 	for {
 		select {
@@ -498,6 +513,14 @@ func (a *Agent) controller() {
 			log.Debugf("Heuristic %v updated, assessing need for "+
 				"more channels", upd.heuristic.Name())
 
+		// Space in the daily open budget has been freed up, so we
+		// might be able to open more channels.
+		case <-budgetRefresh:
+			log.Debugf("Daily channel open budget refreshed, " +
+				"assessing need for more channels")
+
+			budgetRefresh = nil
+
 		// The agent has been signalled to exit, so we'll bail out
 		// immediately.
 		case <-a.quit:
@@ -536,6 +559,25 @@ func (a *Agent) controller() {
 			continue
 		}
 
+		// If the number of channel opens per day is limited, we'll
+		// only open as many channels as are left in today's budget.
+		if a.cfg.MaxOpensPerDay != 0 {
+			remaining, wait := a.dailyOpenBudget(time.Now())
+			if remaining == 0 {
+				log.Debugf("Daily channel open budget of %v "+
+					"exhausted", a.cfg.MaxOpensPerDay)
+
+				if wait > 0 {
+					budgetRefresh = time.After(wait)
+				}
+				continue
+			}
+
+			if remaining < numChans {
+				numChans = remaining
+			}
+		}
+
 		log.Infof("Triggering attachment directive dispatch, "+
 			"total_funds=%v", a.totalBalance)
 
@@ -546,6 +588,43 @@ func (a *Agent) controller() {
 	}
 }
 
+// dailyOpenBudget returns the number of channels that can still be opened
+// without exceeding the daily open budget. Directives that are still
+// connecting to their peer count towards the budget. If the budget is
+// exhausted, the duration until the oldest open of the past day falls out of
+// the window is returned as well. It is zero if only pending connections
+// exhaust the budget.
+func (a *Agent) dailyOpenBudget(now time.Time) (uint32, time.Duration) {
+	a.pendingMtx.Lock()
+	defer a.pendingMtx.Unlock()
+
+	// Remove the opens that are older than a day, and find the oldest
+	// of the remaining ones.
+	windowStart := now.Add(-24 * time.Hour)
+	var oldest time.Time
+	for nID, openTime := range a.recentOpens {
+		if !openTime.After(windowStart) {
+			delete(a.recentOpens, nID)
+			continue
+		}
+
+		if oldest.IsZero() || openTime.Before(oldest) {
+			oldest = openTime
+		}
+	}
+
+	used := uint32(len(a.recentOpens) + len(a.pendingConns))
+	if used < a.cfg.MaxOpensPerDay {
+		return a.cfg.MaxOpensPerDay - used, 0
+	}
+
+	if oldest.IsZero() {
+		return 0, 0
+	}
+
+	return 0, oldest.Sub(windowStart)
+}
+
 // openChans queries the agent's heuristic for a set of channel candidates, and
 // attempts to open channels to them.
 func (a *Agent) openChans(availableFunds btcutil.Amount, numChans uint32,
@@ -832,6 +911,7 @@ func (a *Agent) executeDirective(directive AttachmentDirective) {
 		Balance: directive.ChanAmt,
 		Node:    nodeID,
 	}
+	a.recentOpens[nodeID] = time.Now()
 	a.pendingMtx.Unlock()
 
 	// We can then begin the funding workflow with this peer.
@@ -845,6 +925,7 @@ func (a *Agent) executeDirective(directive AttachmentDirective) {
 		// open a channel to them again.
 		a.pendingMtx.Lock()
 		delete(a.pendingOpens, nodeID)
+		delete(a.recentOpens, nodeID)
 		a.failedNodes[nodeID] = struct{}{}
 		a.pendingMtx.Unlock()
 
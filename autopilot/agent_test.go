@@ -1376,3 +1376,62 @@ func TestAgentChannelSizeAllocation(t *testing.T) {
 
 	checkChannelOpens(t, testCtx, expectedAllocation, numNewChannels)
 }
+
+// TestAgentDailyOpenBudget tests that opens of the past day and pending
+// connections count towards the daily open budget, and that the time until
+// the budget is refreshed is reported once it is exhausted.
+func TestAgentDailyOpenBudget(t *testing.T) {
+	t.Parallel()
+
+	agent, err := New(Config{MaxOpensPerDay: 3}, nil)
+	if err != nil {
+		t.Fatalf("unable to create agent: %v", err)
+	}
+
+	now := time.Unix(1600000000, 0)
+	node := func(i byte) NodeID {
+		return NodeID{i}
+	}
+
+	// Without any opens, the full budget is available.
+	remaining, wait := agent.dailyOpenBudget(now)
+	if remaining != 3 || wait != 0 {
+		t.Fatalf("expected full budget, got %v, %v", remaining, wait)
+	}
+
+	// An open older than a day doesn't count, while a recent one and a
+	// pending connection do.
+	agent.recentOpens[node(1)] = now.Add(-25 * time.Hour)
+	agent.recentOpens[node(2)] = now.Add(-20 * time.Hour)
+	agent.pendingConns[node(3)] = struct{}{}
+
+	remaining, wait = agent.dailyOpenBudget(now)
+	if remaining != 1 || wait != 0 {
+		t.Fatalf("expected one open left, got %v, %v", remaining, wait)
+	}
+	if _, ok := agent.recentOpens[node(1)]; ok {
+		t.Fatalf("expired open not removed")
+	}
+
+	// With the budget exhausted, the budget is refreshed once the oldest
+	// open is a day old.
+	agent.recentOpens[node(4)] = now.Add(-time.Hour)
+
+	remaining, wait = agent.dailyOpenBudget(now)
+	if remaining != 0 || wait != 4*time.Hour {
+		t.Fatalf("expected budget to be refreshed in 4h, got %v, %v",
+			remaining, wait)
+	}
+
+	// If only pending connections exhaust the budget, there's no open to
+	// wait for.
+	agent.recentOpens = make(map[NodeID]time.Time)
+	agent.pendingConns[node(5)] = struct{}{}
+	agent.pendingConns[node(6)] = struct{}{}
+
+	remaining, wait = agent.dailyOpenBudget(now)
+	if remaining != 0 || wait != 0 {
+		t.Fatalf("expected exhausted budget, got %v, %v", remaining,
+			wait)
+	}
+}
@@ -184,6 +184,7 @@ var (
 		NewPrefAttachment(),
 		NewExternalScoreAttachment(),
 		NewTopCentrality(),
+		NewRoutingDemand(),
 	}
 
 	// AvailableHeuristics is a map that holds the name of available
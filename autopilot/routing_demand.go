@@ -0,0 +1,138 @@
+package autopilot
+
+import (
+	"sync"
+
+	"github.com/btcsuite/btcutil"
+)
+
+// DemandSource is a function closure that returns the routing demand observed
+// by our node, expressed as the volume that was sent towards each node.
+type DemandSource func() (map[NodeID]btcutil.Amount, error)
+
+// RoutingDemand is an implementation of the AttachmentHeuristic interface
+// that scores nodes by the routing demand our node has observed towards them,
+// for instance through forwarding history and mission control, rather than by
+// their position in the graph alone. A node's demand is the volume observed
+// towards it directly, plus half of the volume observed towards each of its
+// channel neighbours, since a channel to the node gives us an additional route
+// towards them as well.
+type RoutingDemand struct {
+	// demandSource provides the observed routing demand. If it is nil,
+	// no node is scored.
+	demandSource DemandSource
+
+	sync.Mutex
+}
+
+// A compile time assertion to ensure RoutingDemand meets the
+// AttachmentHeuristic interface.
+var _ AttachmentHeuristic = (*RoutingDemand)(nil)
+
+// NewRoutingDemand constructs and returns a new RoutingDemand heuristic. The
+// heuristic won't score any nodes until a demand source is set.
+func NewRoutingDemand() *RoutingDemand {
+	return &RoutingDemand{}
+}
+
+// Name returns the name of the heuristic.
+//
+// NOTE: This is a part of the AttachmentHeuristic interface.
+func (d *RoutingDemand) Name() string {
+	return "routing_demand"
+}
+
+// SetDemandSource sets the source that is queried for the observed routing
+// demand every time nodes are scored.
+func (d *RoutingDemand) SetDemandSource(source DemandSource) {
+	d.Lock()
+	defer d.Unlock()
+
+	d.demandSource = source
+}
+
+// NodeScores will return a [0,1] normalized map of scores for the given nodes
+// except for the ones we already have channels with. The node with the
+// highest demand gets a score of 1.0, nodes without any observed demand
+// aren't returned.
+//
+// NOTE: This is a part of the AttachmentHeuristic interface.
+func (d *RoutingDemand) NodeScores(g ChannelGraph, chans []LocalChannel,
+	chanSize btcutil.Amount, nodes map[NodeID]struct{}) (
+	map[NodeID]*NodeScore, error) {
+
+	d.Lock()
+	source := d.demandSource
+	d.Unlock()
+
+	if source == nil {
+		log.Debugf("No routing demand source set, skipping scoring")
+		return nil, nil
+	}
+
+	observed, err := source()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a map of the existing peers for faster filtering.
+	existingPeers := make(map[NodeID]struct{})
+	for _, c := range chans {
+		existingPeers[c.Node] = struct{}{}
+	}
+
+	// Add up the direct and the neighbouring demand of every candidate,
+	// keeping track of the maximum so we can normalize the scores.
+	var maxDemand float64
+	demand := make(map[NodeID]float64)
+	err = g.ForEachNode(func(n Node) error {
+		nID := NodeID(n.PubKey())
+
+		// Skip nodes we aren't interested in, as well as nodes we
+		// already have a channel with.
+		if _, ok := nodes[nID]; !ok {
+			return nil
+		}
+		if _, ok := existingPeers[nID]; ok {
+			return nil
+		}
+
+		nodeDemand := float64(observed[nID])
+		err := n.ForEachChannel(func(e ChannelEdge) error {
+			peer := NodeID(e.Peer.PubKey())
+			nodeDemand += float64(observed[peer]) / 2
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if nodeDemand == 0 {
+			return nil
+		}
+
+		demand[nID] = nodeDemand
+		if nodeDemand > maxDemand {
+			maxDemand = nodeDemand
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Tracef("Routing demand observed towards %v nodes, %v candidates "+
+		"scored", len(observed), len(demand))
+
+	result := make(map[NodeID]*NodeScore, len(demand))
+	for nID, nodeDemand := range demand {
+		result[nID] = &NodeScore{
+			NodeID: nID,
+			Score:  nodeDemand / maxDemand,
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,138 @@
+package autopilot
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRoutingDemand tests that nodes are scored by the demand observed towards
+// them and their channel neighbours, and that existing peers are skipped.
+func TestRoutingDemand(t *testing.T) {
+	t.Parallel()
+
+	// Demand is observed towards nodes 3 and 8 of the test graph.
+	observed := map[int]btcutil.Amount{
+		3: 1000,
+		8: 400,
+	}
+
+	tests := []struct {
+		name string
+
+		// channelsWith are the nodes we already have a channel with.
+		channelsWith []int
+
+		// expected are the expected scores by node index.
+		expected map[int]float64
+	}{
+		{
+			// Node 3 has the highest direct demand, its
+			// neighbours get half of it. Node 6 neighbours node 8.
+			name: "no channels",
+			expected: map[int]float64{
+				0: 0.5,
+				2: 0.5,
+				3: 1.0,
+				4: 0.5,
+				5: 0.5,
+				6: 0.2,
+				8: 0.4,
+			},
+		},
+		{
+			// With node 3 being a peer already, the scores are
+			// normalized to the demand of its neighbours.
+			name:         "channel with top node",
+			channelsWith: []int{3},
+			expected: map[int]float64{
+				0: 1.0,
+				2: 1.0,
+				4: 1.0,
+				5: 1.0,
+				6: 0.4,
+				8: 0.8,
+			},
+		},
+	}
+
+	for _, chanGraph := range chanGraphs {
+		chanGraph := chanGraph
+
+		success := t.Run(chanGraph.name, func(t *testing.T) {
+			t.Parallel()
+
+			graph, cleanup, err := chanGraph.genFunc()
+			require.NoError(t, err, "unable to create graph")
+			if cleanup != nil {
+				defer cleanup()
+			}
+
+			graphNodes := buildTestGraph(
+				t, graph, centralityTestGraph,
+			)
+
+			demand := make(map[NodeID]btcutil.Amount)
+			for i, amt := range observed {
+				demand[NewNodeID(graphNodes[i])] = amt
+			}
+
+			nodes := make(map[NodeID]struct{})
+			for _, node := range graphNodes {
+				nodes[NewNodeID(node)] = struct{}{}
+			}
+
+			h := NewRoutingDemand()
+
+			// Without a demand source, no nodes are scored.
+			scores, err := h.NodeScores(graph, nil, 0, nodes)
+			require.NoError(t, err)
+			require.Empty(t, scores)
+
+			h.SetDemandSource(
+				func() (map[NodeID]btcutil.Amount, error) {
+					return demand, nil
+				},
+			)
+
+			for _, test := range tests {
+				var chans []LocalChannel
+				for _, i := range test.channelsWith {
+					chans = append(chans, LocalChannel{
+						Node: NewNodeID(graphNodes[i]),
+					})
+				}
+
+				expected := make(map[NodeID]*NodeScore)
+				for i, score := range test.expected {
+					nID := NewNodeID(graphNodes[i])
+					expected[nID] = &NodeScore{
+						NodeID: nID,
+						Score:  score,
+					}
+				}
+
+				scores, err := h.NodeScores(
+					graph, chans, btcutil.SatoshiPerBitcoin,
+					nodes,
+				)
+				require.NoError(t, err, test.name)
+				require.Equal(t, expected, scores, test.name)
+			}
+
+			// Errors of the demand source are passed on.
+			errSource := errors.New("source failure")
+			h.SetDemandSource(
+				func() (map[NodeID]btcutil.Amount, error) {
+					return nil, errSource
+				},
+			)
+			_, err = h.NodeScores(graph, nil, 0, nodes)
+			require.Equal(t, errSource, err)
+		})
+
+		require.True(t, success)
+	}
+}
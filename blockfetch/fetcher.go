@@ -0,0 +1,229 @@
+package blockfetch
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/btcsuite/btcd/blockchain"
+	"github.com/btcsuite/btcd/btcjson"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/peer"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/cryptomeow/lnd/btcpeer"
+)
+
+const (
+	// DefaultMaxPeers is the default number of peers we'll query for a
+	// block before giving up.
+	DefaultMaxPeers = 5
+
+	// DefaultTimeout is the default amount of time we'll wait for a single
+	// peer to complete the handshake and deliver a block.
+	DefaultTimeout = 30 * time.Second
+
+	// requiredServices are the services a peer needs to advertise for us
+	// to request historical blocks from it. Peers only advertising
+	// NODE_NETWORK_LIMITED are pruned themselves and can't be relied upon
+	// to serve blocks deeper than the last 288.
+	requiredServices = wire.SFNodeNetwork | wire.SFNodeWitness
+
+	// prunedBlockErrMsg is the error message bitcoind returns when a
+	// requested block has been pruned.
+	prunedBlockErrMsg = "pruned data"
+)
+
+var (
+	// ErrBlockNotFound is returned when none of the queried peers was
+	// able to deliver the requested block.
+	ErrBlockNotFound = errors.New("unable to fetch block from any peer")
+)
+
+// Config houses the parameters of a Fetcher.
+type Config struct {
+	// ChainParams are the parameters of the chain we're fetching blocks
+	// for.
+	ChainParams *chaincfg.Params
+
+	// GetPeers returns the addresses of the peers we can request blocks
+	// from, in order of preference. Usually these are the peers of the
+	// pruned backend itself.
+	GetPeers func() ([]string, error)
+
+	// Dial establishes a connection to the peer with the given address.
+	Dial func(addr string) (net.Conn, error)
+
+	// MaxPeers is the maximum number of peers we'll query for a single
+	// block.
+	MaxPeers int
+
+	// Timeout is the maximum amount of time we'll wait for a single peer
+	// to complete the handshake and deliver a block.
+	Timeout time.Duration
+}
+
+// Fetcher retrieves blocks directly from the bitcoin P2P network. It is used
+// as a fallback for pruned chain backends that are no longer able to serve
+// historical blocks themselves.
+type Fetcher struct {
+	cfg *Config
+}
+
+// New creates a new Fetcher from the given config.
+func New(cfg *Config) *Fetcher {
+	return &Fetcher{
+		cfg: cfg,
+	}
+}
+
+// FetchBlock requests the block with the given hash from the peers returned
+// by GetPeers, until one of them delivers a valid block.
+func (f *Fetcher) FetchBlock(hash *chainhash.Hash) (*wire.MsgBlock, error) {
+	addrs, err := f.cfg.GetPeers()
+	if err != nil {
+		return nil, fmt.Errorf("unable to query peers: %v", err)
+	}
+
+	if len(addrs) > f.cfg.MaxPeers {
+		addrs = addrs[:f.cfg.MaxPeers]
+	}
+
+	for _, addr := range addrs {
+		block, err := f.fetchFromPeer(addr, hash)
+		if err != nil {
+			log.Debugf("Unable to fetch block %v from peer %v: %v",
+				hash, addr, err)
+			continue
+		}
+
+		log.Infof("Fetched block %v from peer %v", hash, addr)
+
+		return block, nil
+	}
+
+	return nil, fmt.Errorf("%w %v", ErrBlockNotFound, hash)
+}
+
+// fetchFromPeer connects to the peer with the given address, requests the
+// block with the given hash and validates that the delivered block matches
+// it.
+func (f *Fetcher) fetchFromPeer(addr string,
+	hash *chainhash.Hash) (*wire.MsgBlock, error) {
+
+	var (
+		blocks   = make(chan *wire.MsgBlock, 1)
+		notFound = make(chan struct{}, 1)
+	)
+	listeners := peer.MessageListeners{
+		OnBlock: func(_ *peer.Peer, msg *wire.MsgBlock, _ []byte) {
+			if msg.BlockHash() != *hash {
+				return
+			}
+
+			select {
+			case blocks <- msg:
+			default:
+			}
+		},
+		OnNotFound: func(*peer.Peer, *wire.MsgNotFound) {
+			select {
+			case notFound <- struct{}{}:
+			default:
+			}
+		},
+	}
+
+	timeout := time.After(f.cfg.Timeout)
+	p, err := btcpeer.Connect(
+		addr, f.cfg.ChainParams, f.cfg.Dial, listeners, timeout,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer p.Close()
+
+	if p.Services()&requiredServices != requiredServices {
+		return nil, fmt.Errorf("peer doesn't serve historical blocks, "+
+			"services: %v", p.Services())
+	}
+
+	getData := wire.NewMsgGetData()
+	err = getData.AddInvVect(
+		wire.NewInvVect(wire.InvTypeWitnessBlock, hash),
+	)
+	if err != nil {
+		return nil, err
+	}
+	p.QueueMessage(getData, nil)
+
+	select {
+	case block := <-blocks:
+		if err := validateBlock(block); err != nil {
+			return nil, err
+		}
+
+		return block, nil
+
+	case <-notFound:
+		return nil, errors.New("block not found")
+
+	case <-p.Disconnected():
+		return nil, btcpeer.ErrDisconnected
+
+	case <-timeout:
+		return nil, errors.New("block request timed out")
+	}
+}
+
+// validateBlock makes sure the transactions of the block match the commitments
+// in its header. As the block was already matched against the requested hash,
+// this ensures the peer didn't tamper with its contents.
+func validateBlock(block *wire.MsgBlock) error {
+	if len(block.Transactions) == 0 {
+		return errors.New("block contains no transactions")
+	}
+
+	blk := btcutil.NewBlock(block)
+
+	merkles := blockchain.BuildMerkleTreeStore(blk.Transactions(), false)
+	merkleRoot := merkles[len(merkles)-1]
+	if !block.Header.MerkleRoot.IsEqual(merkleRoot) {
+		return fmt.Errorf("block merkle root mismatch, expected %v, "+
+			"got %v", block.Header.MerkleRoot, merkleRoot)
+	}
+
+	return blockchain.ValidateWitnessCommitment(blk)
+}
+
+// IsBlockPrunedErr returns true if the error was returned by a backend because
+// the requested block was pruned.
+func IsBlockPrunedErr(err error) bool {
+	var rpcErr *btcjson.RPCError
+	if errors.As(err, &rpcErr) {
+		return strings.Contains(rpcErr.Message, prunedBlockErrMsg)
+	}
+
+	return strings.Contains(err.Error(), prunedBlockErrMsg)
+}
+
+// GetBlock retrieves the block with the given hash through getBlock. If the
+// backend has pruned the block and a fetcher is given, the block is fetched
+// from the P2P network instead.
+func GetBlock(hash *chainhash.Hash,
+	getBlock func(*chainhash.Hash) (*wire.MsgBlock, error),
+	fetcher *Fetcher) (*wire.MsgBlock, error) {
+
+	block, err := getBlock(hash)
+	if err == nil || fetcher == nil || !IsBlockPrunedErr(err) {
+		return block, err
+	}
+
+	log.Debugf("Block %v was pruned by the backend, fetching it from "+
+		"peers", hash)
+
+	return fetcher.FetchBlock(hash)
+}
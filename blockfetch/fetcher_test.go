@@ -0,0 +1,261 @@
+package blockfetch
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/blockchain"
+	"github.com/btcsuite/btcd/btcjson"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/peer"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+)
+
+// testBlock returns a block containing only a coinbase transaction, with a
+// header that commits to it.
+func testBlock() *wire.MsgBlock {
+	coinbase := wire.NewMsgTx(1)
+	coinbase.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: wire.OutPoint{Index: wire.MaxPrevOutIndex},
+		SignatureScript:  []byte{0x01, 0x01},
+	})
+	coinbase.AddTxOut(&wire.TxOut{Value: 50, PkScript: []byte{0x51}})
+
+	block := &wire.MsgBlock{
+		Header: wire.BlockHeader{
+			Version:   1,
+			Timestamp: time.Unix(1600000000, 0),
+		},
+		Transactions: []*wire.MsgTx{coinbase},
+	}
+
+	merkles := blockchain.BuildMerkleTreeStore(
+		btcutil.NewBlock(block).Transactions(), false,
+	)
+	block.Header.MerkleRoot = *merkles[len(merkles)-1]
+
+	return block
+}
+
+// servePeer accepts a single connection on the listener, completes the
+// version handshake advertising the given services and answers all getdata
+// requests with the given block.
+//
+// NOTE: The peer package refuses connections between two of its peers within
+// the same process, so the remote side speaks the wire protocol directly.
+func servePeer(listener net.Listener, block *wire.MsgBlock,
+	services wire.ServiceFlag) {
+
+	conn, err := listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	var (
+		pver   = peer.MaxProtocolVersion
+		btcnet = chaincfg.RegressionNetParams.Net
+	)
+	readMsg := func() (wire.Message, error) {
+		_, msg, _, err := wire.ReadMessageWithEncodingN(
+			conn, pver, btcnet, wire.WitnessEncoding,
+		)
+		return msg, err
+	}
+	writeMsg := func(msg wire.Message) error {
+		_, err := wire.WriteMessageWithEncodingN(
+			conn, msg, pver, btcnet, wire.WitnessEncoding,
+		)
+		return err
+	}
+
+	if _, err := readMsg(); err != nil {
+		return
+	}
+
+	version := wire.NewMsgVersion(
+		&wire.NetAddress{}, &wire.NetAddress{}, 1, 0,
+	)
+	version.Services = services
+	if err := writeMsg(version); err != nil {
+		return
+	}
+	if err := writeMsg(wire.NewMsgVerAck()); err != nil {
+		return
+	}
+
+	for {
+		msg, err := readMsg()
+		if err != nil {
+			return
+		}
+
+		if _, ok := msg.(*wire.MsgGetData); ok {
+			if err := writeMsg(block); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// TestFetchBlock checks that blocks are only accepted from peers serving the
+// full chain, and only if they match the requested hash and header.
+func TestFetchBlock(t *testing.T) {
+	t.Parallel()
+
+	validBlock := testBlock()
+	validHash := validBlock.BlockHash()
+
+	// A block whose transactions don't match the merkle root in its
+	// header still has the requested hash.
+	tamperedBlock := testBlock()
+	tamperedBlock.Transactions[0].TxOut[0].Value = 100
+
+	testCases := []struct {
+		name      string
+		block     *wire.MsgBlock
+		services  wire.ServiceFlag
+		expectErr bool
+	}{
+		{
+			name:     "valid block",
+			block:    validBlock,
+			services: requiredServices,
+		},
+		{
+			name:      "pruned peer",
+			block:     validBlock,
+			services:  wire.SFNodeWitness,
+			expectErr: true,
+		},
+		{
+			name:      "tampered block",
+			block:     tamperedBlock,
+			services:  requiredServices,
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			if err != nil {
+				t.Fatalf("unable to listen: %v", err)
+			}
+			defer listener.Close()
+
+			go servePeer(listener, tc.block, tc.services)
+
+			fetcher := New(&Config{
+				ChainParams: &chaincfg.RegressionNetParams,
+				GetPeers: func() ([]string, error) {
+					return []string{
+						listener.Addr().String(),
+					}, nil
+				},
+				Dial: func(addr string) (net.Conn, error) {
+					return net.Dial("tcp", addr)
+				},
+				MaxPeers: DefaultMaxPeers,
+				Timeout:  5 * time.Second,
+			})
+
+			block, err := fetcher.FetchBlock(&validHash)
+			if tc.expectErr {
+				if !errors.Is(err, ErrBlockNotFound) {
+					t.Fatalf("expected ErrBlockNotFound, "+
+						"got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unable to fetch block: %v", err)
+			}
+			if block.BlockHash() != validHash {
+				t.Fatalf("expected block %v, got %v",
+					validHash, block.BlockHash())
+			}
+		})
+	}
+}
+
+// TestGetBlock checks that blocks are only fetched from peers if the backend
+// reports them as pruned.
+func TestGetBlock(t *testing.T) {
+	t.Parallel()
+
+	var (
+		block     = testBlock()
+		hash      = block.BlockHash()
+		prunedErr = &btcjson.RPCError{
+			Code:    -1,
+			Message: "Block not available (pruned data)",
+		}
+		otherErr = errors.New("connection refused")
+	)
+
+	// The fetcher doesn't know any peers, so any attempt to use it fails.
+	fetcher := New(&Config{
+		GetPeers: func() ([]string, error) {
+			return nil, nil
+		},
+		MaxPeers: DefaultMaxPeers,
+	})
+
+	testCases := []struct {
+		name        string
+		backendErr  error
+		fetcher     *Fetcher
+		expectedErr error
+	}{
+		{
+			name: "block available",
+		},
+		{
+			name:        "other backend error",
+			backendErr:  otherErr,
+			fetcher:     fetcher,
+			expectedErr: otherErr,
+		},
+		{
+			name:        "pruned without fetcher",
+			backendErr:  prunedErr,
+			expectedErr: prunedErr,
+		},
+		{
+			name:        "pruned with fetcher",
+			backendErr:  prunedErr,
+			fetcher:     fetcher,
+			expectedErr: ErrBlockNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			getBlock := func(*chainhash.Hash) (*wire.MsgBlock,
+				error) {
+
+				if tc.backendErr != nil {
+					return nil, tc.backendErr
+				}
+				return block, nil
+			}
+
+			_, err := GetBlock(&hash, getBlock, tc.fetcher)
+			if !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("expected error %v, got %v",
+					tc.expectedErr, err)
+			}
+		})
+	}
+}
@@ -569,7 +569,9 @@ justiceTxBroadcast:
 
 	// We'll now attempt to broadcast the transaction which finalized the
 	// channel's retribution against the cheating counter party.
-	label := labels.MakeLabel(labels.LabelTypeJusticeTransaction, nil)
+	label := labels.MakeChanPointLabel(
+		labels.LabelTypeJusticeTransaction, &breachInfo.chanPoint,
+	)
 	err = b.cfg.PublishTransaction(finalTx, label)
 	if err != nil {
 		brarLog.Errorf("Unable to broadcast justice tx: %v", err)
@@ -0,0 +1,103 @@
+package broadcast
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/peer"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/cryptomeow/lnd/btcpeer"
+)
+
+// PeerBroadcaster hands transactions directly to a node of the bitcoin P2P
+// network.
+type PeerBroadcaster struct {
+	addr        string
+	chainParams *chaincfg.Params
+	dial        func(addr string) (net.Conn, error)
+	timeout     time.Duration
+}
+
+// NewPeerBroadcaster creates a new PeerBroadcaster for the node with the
+// given address. Connections are established through dial, which allows the
+// caller to route them through Tor.
+func NewPeerBroadcaster(addr string, chainParams *chaincfg.Params,
+	dial func(addr string) (net.Conn, error),
+	timeout time.Duration) *PeerBroadcaster {
+
+	return &PeerBroadcaster{
+		addr:        addr,
+		chainParams: chainParams,
+		dial:        dial,
+		timeout:     timeout,
+	}
+}
+
+// Broadcast connects to the node, sends it the transaction and disconnects
+// again. As the P2P protocol has no way of telling whether the node accepted
+// the transaction, we only wait for the node to answer a ping sent after it,
+// which tells us that the transaction was received and processed.
+//
+// NOTE: This is part of the Broadcaster interface.
+func (b *PeerBroadcaster) Broadcast(tx *wire.MsgTx) error {
+	pong := make(chan uint64, 1)
+	listeners := peer.MessageListeners{
+		OnPong: func(_ *peer.Peer, msg *wire.MsgPong) {
+			select {
+			case pong <- msg.Nonce:
+			default:
+			}
+		},
+	}
+
+	timeout := time.After(b.timeout)
+	p, err := btcpeer.Connect(
+		b.addr, b.chainParams, b.dial, listeners, timeout,
+	)
+	if err != nil {
+		return err
+	}
+	defer p.Close()
+
+	p.QueueMessage(tx, nil)
+
+	nonce, err := wire.RandomUint64()
+	if err != nil {
+		return err
+	}
+	p.QueueMessage(wire.NewMsgPing(nonce), nil)
+
+	for {
+		select {
+		case n := <-pong:
+			// The peer also answers the pings it receives from
+			// the keep-alive of the btcd peer, so we wait for the
+			// one sent after the transaction.
+			if n != nonce {
+				continue
+			}
+
+			return nil
+
+		case <-p.Disconnected():
+			return btcpeer.ErrDisconnected
+
+		case <-timeout:
+			return errors.New("broadcast timed out")
+		}
+	}
+}
+
+// String returns a human readable identifier of the node.
+//
+// NOTE: This is part of the Broadcaster interface.
+func (b *PeerBroadcaster) String() string {
+	return fmt.Sprintf("peer %v", b.addr)
+}
+
+// Compile-time constraint to ensure PeerBroadcaster implements the
+// Broadcaster interface.
+var _ Broadcaster = (*PeerBroadcaster)(nil)
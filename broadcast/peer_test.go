@@ -0,0 +1,126 @@
+package broadcast
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/peer"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+)
+
+// servePeer accepts a single connection on the listener, completes the
+// version handshake, reports all received transactions and answers pings
+// unless ignorePings is set.
+//
+// NOTE: The peer package refuses connections between two of its peers within
+// the same process, so the remote side speaks the wire protocol directly.
+func servePeer(listener net.Listener, txs chan<- *wire.MsgTx,
+	ignorePings bool) {
+
+	conn, err := listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	var (
+		pver   = peer.MaxProtocolVersion
+		btcnet = chaincfg.RegressionNetParams.Net
+	)
+	readMsg := func() (wire.Message, error) {
+		_, msg, _, err := wire.ReadMessageWithEncodingN(
+			conn, pver, btcnet, wire.WitnessEncoding,
+		)
+		return msg, err
+	}
+	writeMsg := func(msg wire.Message) error {
+		_, err := wire.WriteMessageWithEncodingN(
+			conn, msg, pver, btcnet, wire.WitnessEncoding,
+		)
+		return err
+	}
+
+	if _, err := readMsg(); err != nil {
+		return
+	}
+
+	version := wire.NewMsgVersion(
+		&wire.NetAddress{}, &wire.NetAddress{}, 1, 0,
+	)
+	version.Services = wire.SFNodeNetwork | wire.SFNodeWitness
+	if err := writeMsg(version); err != nil {
+		return
+	}
+	if err := writeMsg(wire.NewMsgVerAck()); err != nil {
+		return
+	}
+
+	for {
+		msg, err := readMsg()
+		if err != nil {
+			return
+		}
+
+		switch msg := msg.(type) {
+		case *wire.MsgTx:
+			txs <- msg
+
+		case *wire.MsgPing:
+			if ignorePings {
+				continue
+			}
+
+			pong := wire.NewMsgPong(msg.Nonce)
+			if err := writeMsg(pong); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// TestPeerBroadcast checks that transactions are sent to the peer, and that
+// the broadcast only succeeds once the peer processed them.
+func TestPeerBroadcast(t *testing.T) {
+	t.Parallel()
+
+	tx := wire.NewMsgTx(2)
+	tx.AddTxIn(&wire.TxIn{})
+	tx.AddTxOut(&wire.TxOut{Value: 1000, PkScript: []byte{0x51}})
+
+	broadcast := func(ignorePings bool) (*wire.MsgTx, error) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer listener.Close()
+
+		txs := make(chan *wire.MsgTx, 1)
+		go servePeer(listener, txs, ignorePings)
+
+		dial := func(addr string) (net.Conn, error) {
+			return net.Dial("tcp", addr)
+		}
+		b := NewPeerBroadcaster(
+			listener.Addr().String(), &chaincfg.RegressionNetParams,
+			dial, time.Second,
+		)
+		err = b.Broadcast(tx)
+
+		select {
+		case received := <-txs:
+			return received, err
+		case <-time.After(time.Second):
+			return nil, err
+		}
+	}
+
+	received, err := broadcast(false)
+	require.NoError(t, err)
+	require.Equal(t, tx.TxHash(), received.TxHash())
+
+	// A peer that never confirms processing the transaction makes the
+	// broadcast time out.
+	_, err = broadcast(true)
+	require.EqualError(t, err, "broadcast timed out")
+}
@@ -0,0 +1,134 @@
+// Package broadcast publishes transactions through alternate backends in
+// addition to the chain backend of the wallet, so that a single backend that
+// censors a transaction or rejects it because of its mempool policy can't
+// prevent it from confirming.
+package broadcast
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/cryptomeow/lnd/lnwallet"
+)
+
+// Broadcaster hands transactions to the Bitcoin network through a backend
+// other than the chain backend of the wallet.
+type Broadcaster interface {
+	// Broadcast hands the transaction to the backend. A nil error means
+	// that the backend accepted the transaction for relay, to the extent
+	// the backend is able to tell.
+	Broadcast(tx *wire.MsgTx) error
+
+	// String returns a human readable identifier of the backend.
+	String() string
+}
+
+// Config holds the dependencies and settings of a Publisher.
+type Config struct {
+	// PublishTransaction publishes the transaction through the chain
+	// backend of the wallet.
+	PublishTransaction func(tx *wire.MsgTx, label string) error
+
+	// Broadcasters are the alternate backends transactions are handed to.
+	Broadcasters []Broadcaster
+
+	// Timeout is the timeout of a single broadcast attempt through an
+	// alternate backend.
+	Timeout time.Duration
+}
+
+// Publisher publishes transactions through the chain backend of the wallet
+// and a set of alternate backends.
+type Publisher struct {
+	cfg *Config
+}
+
+// NewPublisher creates a new Publisher from the given config.
+func NewPublisher(cfg *Config) *Publisher {
+	return &Publisher{
+		cfg: cfg,
+	}
+}
+
+// PublishTransaction publishes the transaction through the chain backend of
+// the wallet, and hands it to all alternate backends as well. If the chain
+// backend accepts the transaction, the alternate backends are used in the
+// background so the transaction propagates even if the chain backend doesn't
+// relay it. If the chain backend rejects the transaction, we wait for the
+// alternate backends, and only fail if none of them accepts it either.
+func (p *Publisher) PublishTransaction(tx *wire.MsgTx, label string) error {
+	err := p.cfg.PublishTransaction(tx, label)
+	if len(p.cfg.Broadcasters) == 0 {
+		return err
+	}
+
+	switch {
+	case err == nil:
+		go p.broadcast(tx)
+		return nil
+
+	// If the inputs of the transaction are already spent, handing it to
+	// other backends won't help.
+	case errors.Is(err, lnwallet.ErrDoubleSpend):
+		return err
+	}
+
+	log.Warnf("Chain backend rejected transaction %v, trying alternate "+
+		"broadcasters: %v", tx.TxHash(), err)
+
+	if !p.broadcast(tx) {
+		return err
+	}
+
+	return nil
+}
+
+// broadcast hands the transaction to all alternate backends concurrently. It
+// returns true as soon as one of them accepts the transaction, or false if
+// none of them did before the timeout.
+func (p *Publisher) broadcast(tx *wire.MsgTx) bool {
+	txid := tx.TxHash()
+
+	// The channel is buffered, so that the remaining backends don't block
+	// once we stopped waiting for them.
+	results := make(chan error, len(p.cfg.Broadcasters))
+	for _, b := range p.cfg.Broadcasters {
+		b := b
+		go func() {
+			err := b.Broadcast(tx)
+			if err != nil {
+				err = fmt.Errorf("%v: %v", b, err)
+			}
+			results <- err
+		}()
+	}
+
+	timeout := time.NewTimer(p.cfg.Timeout)
+	defer timeout.Stop()
+
+	for range p.cfg.Broadcasters {
+		select {
+		case err := <-results:
+			if err != nil {
+				log.Debugf("Unable to broadcast transaction "+
+					"%v through %v", txid, err)
+				continue
+			}
+
+			log.Infof("Broadcast transaction %v through alternate "+
+				"broadcaster", txid)
+
+			return true
+
+		case <-timeout.C:
+			log.Debugf("Timeout broadcasting transaction %v "+
+				"through alternate broadcasters", txid)
+
+			return false
+		}
+	}
+
+	return false
+}
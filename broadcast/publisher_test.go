@@ -0,0 +1,104 @@
+package broadcast
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/cryptomeow/lnd/lnwallet"
+	"github.com/stretchr/testify/require"
+)
+
+// mockBroadcaster is a Broadcaster that reports the transactions it is handed
+// and fails with a fixed error.
+type mockBroadcaster struct {
+	txs   chan *wire.MsgTx
+	err   error
+	delay time.Duration
+}
+
+func newMockBroadcaster(err error) *mockBroadcaster {
+	return &mockBroadcaster{
+		txs: make(chan *wire.MsgTx, 1),
+		err: err,
+	}
+}
+
+func (m *mockBroadcaster) Broadcast(tx *wire.MsgTx) error {
+	time.Sleep(m.delay)
+	m.txs <- tx
+
+	return m.err
+}
+
+func (m *mockBroadcaster) String() string {
+	return "mock"
+}
+
+// TestPublishTransaction asserts that transactions are handed to the
+// alternate broadcasters, and that the result of the chain backend is only
+// overridden if one of them accepted the transaction.
+func TestPublishTransaction(t *testing.T) {
+	t.Parallel()
+
+	var (
+		tx         = wire.NewMsgTx(2)
+		errBackend = errors.New("min relay fee not met")
+		errAlt     = errors.New("alternate failure")
+	)
+
+	newPublisher := func(backendErr error,
+		broadcasters ...Broadcaster) *Publisher {
+
+		return NewPublisher(&Config{
+			PublishTransaction: func(*wire.MsgTx, string) error {
+				return backendErr
+			},
+			Broadcasters: broadcasters,
+			Timeout:      time.Second,
+		})
+	}
+
+	// Without alternate broadcasters, the result of the chain backend is
+	// returned as is.
+	err := newPublisher(errBackend).PublishTransaction(tx, "")
+	require.Equal(t, errBackend, err)
+
+	// If the chain backend accepts the transaction, it is still handed to
+	// the alternate broadcasters in the background, and their failures
+	// don't matter.
+	failing := newMockBroadcaster(errAlt)
+	err = newPublisher(nil, failing).PublishTransaction(tx, "")
+	require.NoError(t, err)
+	require.Equal(t, tx, <-failing.txs)
+
+	// If the chain backend rejects the transaction, a single alternate
+	// broadcaster accepting it is enough.
+	failing = newMockBroadcaster(errAlt)
+	accepting := newMockBroadcaster(nil)
+	err = newPublisher(errBackend, failing, accepting).PublishTransaction(
+		tx, "",
+	)
+	require.NoError(t, err)
+	require.Equal(t, tx, <-accepting.txs)
+
+	// If none of them accepts it, the error of the chain backend is
+	// returned.
+	failing = newMockBroadcaster(errAlt)
+	err = newPublisher(errBackend, failing).PublishTransaction(tx, "")
+	require.Equal(t, errBackend, err)
+
+	// The same goes for alternate broadcasters that don't answer in time.
+	slow := newMockBroadcaster(nil)
+	slow.delay = 2 * time.Second
+	err = newPublisher(errBackend, slow).PublishTransaction(tx, "")
+	require.Equal(t, errBackend, err)
+
+	// Double spends are never handed to the alternate broadcasters.
+	accepting = newMockBroadcaster(nil)
+	err = newPublisher(lnwallet.ErrDoubleSpend, accepting).
+		PublishTransaction(tx, "")
+	require.Equal(t, lnwallet.ErrDoubleSpend, err)
+	require.Empty(t, accepting.txs)
+}
@@ -0,0 +1,72 @@
+package broadcast
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcjson"
+	"github.com/btcsuite/btcd/rpcclient"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// RPCBroadcaster hands transactions to an additional bitcoind node through
+// its RPC interface.
+type RPCBroadcaster struct {
+	client *rpcclient.Client
+	host   string
+}
+
+// NewRPCBroadcaster creates a new RPCBroadcaster for the bitcoind node with
+// the given connection config.
+func NewRPCBroadcaster(cfg rpcclient.ConnConfig) (*RPCBroadcaster, error) {
+	cfg.HTTPPostMode = true
+	cfg.DisableConnectOnNew = true
+	cfg.DisableTLS = true
+
+	client, err := rpcclient.New(&cfg, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RPCBroadcaster{
+		client: client,
+		host:   cfg.Host,
+	}, nil
+}
+
+// Broadcast sends the transaction to the bitcoind node. A transaction the
+// node already knows about is treated as accepted.
+//
+// NOTE: This is part of the Broadcaster interface.
+func (r *RPCBroadcaster) Broadcast(tx *wire.MsgTx) error {
+	_, err := r.client.SendRawTransaction(tx, true)
+	if err == nil || isKnownTxErr(err) {
+		return nil
+	}
+
+	return err
+}
+
+// String returns a human readable identifier of the bitcoind node.
+//
+// NOTE: This is part of the Broadcaster interface.
+func (r *RPCBroadcaster) String() string {
+	return fmt.Sprintf("bitcoind %v", r.host)
+}
+
+// isKnownTxErr returns true if the error returned by bitcoind indicates that
+// the transaction is already in its mempool or in the chain.
+func isKnownTxErr(err error) bool {
+	if rpcErr, ok := err.(*btcjson.RPCError); ok &&
+		rpcErr.Code == btcjson.ErrRPCTxAlreadyInChain {
+
+		return true
+	}
+
+	return strings.Contains(err.Error(), "txn-already-in-mempool") ||
+		strings.Contains(err.Error(), "txn-already-known")
+}
+
+// Compile-time constraint to ensure RPCBroadcaster implements the
+// Broadcaster interface.
+var _ Broadcaster = (*RPCBroadcaster)(nil)
@@ -278,6 +278,29 @@ func (c *Conn) SetWriteDeadline(t time.Time) error {
 	return c.conn.SetWriteDeadline(t)
 }
 
+// SetKeepAlive configures the TCP keepalive probes of the underlying
+// connection, which detect dead connections that don't close gracefully. A
+// positive period enables the probes at that interval, a negative one disables
+// them, and zero leaves the operating system defaults in place. Connections
+// that aren't direct TCP connections, such as those made through a Tor proxy,
+// are left untouched.
+func (c *Conn) SetKeepAlive(period time.Duration) error {
+	tcpConn, ok := c.conn.(*net.TCPConn)
+	if !ok || period == 0 {
+		return nil
+	}
+
+	if period < 0 {
+		return tcpConn.SetKeepAlive(false)
+	}
+
+	if err := tcpConn.SetKeepAlive(true); err != nil {
+		return err
+	}
+
+	return tcpConn.SetKeepAlivePeriod(period)
+}
+
 // RemotePub returns the remote peer's static public key.
 func (c *Conn) RemotePub() *btcec.PublicKey {
 	return c.noise.remoteStatic
@@ -0,0 +1,110 @@
+// Package btcpeer establishes short-lived connections to nodes of the bitcoin
+// P2P network, as used to broadcast transactions and fetch blocks without
+// going through the chain backend.
+package btcpeer
+
+import (
+	"errors"
+	"net"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/peer"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/cryptomeow/lnd/build"
+)
+
+var (
+	// ErrDisconnected is returned when the remote node disconnected
+	// before the handshake completed.
+	ErrDisconnected = errors.New("peer disconnected")
+
+	// ErrHandshakeTimeout is returned when the handshake didn't complete
+	// before the timeout.
+	ErrHandshakeTimeout = errors.New("handshake timed out")
+)
+
+// Peer is an outbound connection to a bitcoin node that completed the version
+// handshake.
+type Peer struct {
+	*peer.Peer
+
+	disconnected chan struct{}
+}
+
+// Connect dials the node with the given address through dial and completes
+// the version handshake with it. The passed listeners are notified of the
+// messages the node sends, except for its verack which is consumed by the
+// handshake. An error is returned if the handshake didn't complete before the
+// timeout fires. The returned peer must be closed by the caller.
+func Connect(addr string, chainParams *chaincfg.Params,
+	dial func(addr string) (net.Conn, error),
+	listeners peer.MessageListeners,
+	timeout <-chan time.Time) (*Peer, error) {
+
+	verAck := make(chan struct{}, 1)
+	listeners.OnVerAck = func(*peer.Peer, *wire.MsgVerAck) {
+		select {
+		case verAck <- struct{}{}:
+		default:
+		}
+	}
+
+	peerCfg := &peer.Config{
+		UserAgentName:    "lnd",
+		UserAgentVersion: build.Version(),
+		ChainParams:      chainParams,
+		Services:         wire.SFNodeWitness,
+		DisableRelayTx:   true,
+		Listeners:        listeners,
+	}
+
+	p, err := peer.NewOutboundPeer(peerCfg, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := dial(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	// We'll keep track of the node disconnecting, so that the caller
+	// doesn't need to wait for its timeout.
+	btcPeer := &Peer{
+		Peer:         p,
+		disconnected: make(chan struct{}),
+	}
+	go func() {
+		p.WaitForDisconnect()
+		close(btcPeer.disconnected)
+	}()
+
+	p.AssociateConnection(conn)
+
+	select {
+	case <-verAck:
+		return btcPeer, nil
+
+	case <-btcPeer.disconnected:
+		btcPeer.Close()
+		return nil, ErrDisconnected
+
+	case <-timeout:
+		btcPeer.Close()
+		return nil, ErrHandshakeTimeout
+	}
+}
+
+// Disconnected returns a channel that is closed once the connection to the
+// node is gone.
+func (p *Peer) Disconnected() <-chan struct{} {
+	return p.disconnected
+}
+
+// Close disconnects from the node and waits for the connection to be torn
+// down.
+func (p *Peer) Close() {
+	p.Disconnect()
+	<-p.disconnected
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/btcsuite/btclog"
 )
@@ -100,6 +101,23 @@ func NewSubLogger(subsystem string,
 // subsystem name.
 type SubLoggers map[string]btclog.Logger
 
+// SubsystemLogLevel describes the current log level of a subsystem logger.
+type SubsystemLogLevel struct {
+	// Subsystem is the name of the subsystem.
+	Subsystem string
+
+	// Level is the current log level of the subsystem logger.
+	Level btclog.Level
+
+	// RevertLevel is the log level the subsystem logger is reverted to at
+	// RevertTime, if its current log level is only temporary.
+	RevertLevel btclog.Level
+
+	// RevertTime is the time at which the log level is reverted. It is the
+	// zero time if the current log level is not temporary.
+	RevertTime time.Time
+}
+
 // LeveledSubLogger provides the ability to retrieve the subsystem loggers of
 // a logger and set their log levels individually or all at once.
 type LeveledSubLogger interface {
@@ -198,3 +216,24 @@ func validLogLevel(logLevel string) bool {
 	}
 	return false
 }
+
+// LogLevelName returns the name of the passed log level as it is accepted by
+// ParseAndSetDebugLevels.
+func LogLevelName(level btclog.Level) string {
+	switch level {
+	case btclog.LevelTrace:
+		return "trace"
+	case btclog.LevelDebug:
+		return "debug"
+	case btclog.LevelInfo:
+		return "info"
+	case btclog.LevelWarn:
+		return "warn"
+	case btclog.LevelError:
+		return "error"
+	case btclog.LevelCritical:
+		return "critical"
+	default:
+		return "off"
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"sync"
+	"time"
 
 	"github.com/btcsuite/btclog"
 	"github.com/jrick/logrotate/rotator"
@@ -25,6 +27,20 @@ type RotatingLogWriter struct {
 	logRotator *rotator.Rotator
 
 	subsystemLoggers SubLoggers
+
+	// reverts holds the pending reverts of all subsystem loggers whose log
+	// level was changed temporarily, keyed by their subsystem name.
+	reverts map[string]*logLevelRevert
+
+	revertMtx sync.Mutex
+}
+
+// logLevelRevert tracks a temporary log level of a subsystem logger, which is
+// reverted to its prior level once the timer fires.
+type logLevelRevert struct {
+	prevLevel  btclog.Level
+	revertTime time.Time
+	timer      *time.Timer
 }
 
 // A compile time check to ensure RotatingLogWriter implements the
@@ -46,6 +62,7 @@ func NewRotatingLogWriter() *RotatingLogWriter {
 		logWriter:        logWriter,
 		backendLog:       backendLog,
 		subsystemLoggers: SubLoggers{},
+		reverts:          make(map[string]*logLevelRevert),
 	}
 }
 
@@ -135,11 +152,99 @@ func (r *RotatingLogWriter) SetLogLevel(subsystemID string, logLevel string) {
 		return
 	}
 
+	// An explicitly set log level overrides any temporary log level, so
+	// we'll cancel its pending revert.
+	r.revertMtx.Lock()
+	defer r.revertMtx.Unlock()
+
+	if revert, ok := r.reverts[subsystemID]; ok {
+		revert.timer.Stop()
+		delete(r.reverts, subsystemID)
+	}
+
 	// Defaults to info if the log level is invalid.
 	level, _ := btclog.LevelFromString(logLevel)
 	logger.SetLevel(level)
 }
 
+// SetTemporaryLogLevel sets the logging level for the provided subsystem for
+// the given duration, after which the subsystem logger is reverted to the log
+// level it had before. Setting another temporary log level for the same
+// subsystem extends the duration, but still reverts to the original level.
+func (r *RotatingLogWriter) SetTemporaryLogLevel(subsystemID string,
+	logLevel string, duration time.Duration) error {
+
+	logger, ok := r.subsystemLoggers[subsystemID]
+	if !ok {
+		return fmt.Errorf("the specified subsystem [%v] is invalid -- "+
+			"supported subsystems are %v", subsystemID,
+			r.SupportedSubsystems())
+	}
+
+	if !validLogLevel(logLevel) {
+		return fmt.Errorf("the specified debug level [%v] is invalid",
+			logLevel)
+	}
+
+	r.revertMtx.Lock()
+	defer r.revertMtx.Unlock()
+
+	revert, ok := r.reverts[subsystemID]
+	if ok {
+		revert.timer.Stop()
+	} else {
+		revert = &logLevelRevert{
+			prevLevel: logger.Level(),
+		}
+		r.reverts[subsystemID] = revert
+	}
+
+	revert.revertTime = time.Now().Add(duration)
+	revert.timer = time.AfterFunc(duration, func() {
+		r.revertMtx.Lock()
+		defer r.revertMtx.Unlock()
+
+		// Only revert the log level if it wasn't changed again in the
+		// meantime.
+		if r.reverts[subsystemID] != revert {
+			return
+		}
+		delete(r.reverts, subsystemID)
+
+		logger.SetLevel(revert.prevLevel)
+	})
+
+	level, _ := btclog.LevelFromString(logLevel)
+	logger.SetLevel(level)
+
+	return nil
+}
+
+// SubsystemLogLevels returns the current log levels of all registered
+// subsystem loggers, sorted by their subsystem name.
+func (r *RotatingLogWriter) SubsystemLogLevels() []SubsystemLogLevel {
+	r.revertMtx.Lock()
+	defer r.revertMtx.Unlock()
+
+	subsystems := r.SupportedSubsystems()
+	levels := make([]SubsystemLogLevel, 0, len(subsystems))
+	for _, subsysID := range subsystems {
+		level := SubsystemLogLevel{
+			Subsystem: subsysID,
+			Level:     r.subsystemLoggers[subsysID].Level(),
+		}
+
+		if revert, ok := r.reverts[subsysID]; ok {
+			level.RevertLevel = revert.prevLevel
+			level.RevertTime = revert.revertTime
+		}
+
+		levels = append(levels, level)
+	}
+
+	return levels
+}
+
 // SetLogLevels sets the log level for all subsystem loggers to the passed
 // level. It also dynamically creates the subsystem loggers as needed, so it
 // can be used to initialize the logging system.
@@ -0,0 +1,88 @@
+package build
+
+import (
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btclog"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestLogWriter creates a log writer with two subsystem loggers, both at
+// the info level.
+func newTestLogWriter() *RotatingLogWriter {
+	r := NewRotatingLogWriter()
+	for _, subsystem := range []string{"BBBB", "AAAA"} {
+		r.RegisterSubLogger(subsystem, r.GenSubLogger(subsystem))
+	}
+	r.SetLogLevels("info")
+
+	return r
+}
+
+// TestSetTemporaryLogLevel asserts that a temporary log level is reverted to
+// the prior level once it expires, and that it is reported as such in the
+// meantime.
+func TestSetTemporaryLogLevel(t *testing.T) {
+	t.Parallel()
+
+	r := newTestLogWriter()
+
+	// Unknown subsystems and invalid levels are rejected.
+	err := r.SetTemporaryLogLevel("CCCC", "debug", time.Minute)
+	require.Error(t, err)
+	err = r.SetTemporaryLogLevel("AAAA", "verbose", time.Minute)
+	require.Error(t, err)
+
+	before := time.Now()
+	err = r.SetTemporaryLogLevel("AAAA", "debug", time.Minute)
+	require.NoError(t, err)
+
+	// Setting another temporary level extends the duration, but still
+	// reverts to the original level.
+	err = r.SetTemporaryLogLevel("AAAA", "trace", 100*time.Millisecond)
+	require.NoError(t, err)
+
+	levels := r.SubsystemLogLevels()
+	require.Len(t, levels, 2)
+	require.Equal(t, "AAAA", levels[0].Subsystem)
+	require.Equal(t, btclog.LevelTrace, levels[0].Level)
+	require.Equal(t, btclog.LevelInfo, levels[0].RevertLevel)
+	require.True(t, levels[0].RevertTime.After(before))
+	require.True(t, levels[0].RevertTime.Before(before.Add(time.Minute)))
+
+	// The other subsystem isn't affected.
+	require.Equal(t, "BBBB", levels[1].Subsystem)
+	require.Equal(t, btclog.LevelInfo, levels[1].Level)
+	require.True(t, levels[1].RevertTime.IsZero())
+
+	require.Eventually(t, func() bool {
+		levels := r.SubsystemLogLevels()
+		return levels[0].Level == btclog.LevelInfo &&
+			levels[0].RevertTime.IsZero()
+	}, time.Second, 10*time.Millisecond)
+}
+
+// TestSetLogLevelCancelsRevert asserts that an explicitly set log level
+// overrides a temporary one, so it is never reverted.
+func TestSetLogLevelCancelsRevert(t *testing.T) {
+	t.Parallel()
+
+	r := newTestLogWriter()
+
+	err := r.SetTemporaryLogLevel("AAAA", "debug", 50*time.Millisecond)
+	require.NoError(t, err)
+
+	r.SetLogLevel("AAAA", "warn")
+
+	levels := r.SubsystemLogLevels()
+	require.Equal(t, btclog.LevelWarn, levels[0].Level)
+	require.True(t, levels[0].RevertTime.IsZero())
+
+	// Wait for the timer of the temporary level to have fired, if it
+	// wasn't stopped.
+	time.Sleep(100 * time.Millisecond)
+
+	levels = r.SubsystemLogLevels()
+	require.Equal(t, btclog.LevelWarn, levels[0].Level)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/btcsuite/btcd/wire"
 	"github.com/btcsuite/btcutil"
 	"github.com/btcsuite/btcwallet/chain"
+	"github.com/cryptomeow/lnd/blockfetch"
 	"github.com/cryptomeow/lnd/chainntnfs"
 	"github.com/cryptomeow/lnd/queue"
 )
@@ -60,6 +61,11 @@ type BitcoindNotifier struct {
 	// which the transaction could have confirmed within the chain.
 	confirmHintCache chainntnfs.ConfirmHintCache
 
+	// blockFetcher is used to retrieve blocks from the P2P network that
+	// have been pruned by the bitcoind node. It is nil if the node isn't
+	// pruned.
+	blockFetcher *blockfetch.Fetcher
+
 	wg   sync.WaitGroup
 	quit chan struct{}
 }
@@ -68,12 +74,18 @@ type BitcoindNotifier struct {
 // time.
 var _ chainntnfs.ChainNotifier = (*BitcoindNotifier)(nil)
 
+// Ensure BitcoindNotifier implements the StatsReporter interface at compile time.
+var _ chainntnfs.StatsReporter = (*BitcoindNotifier)(nil)
+
 // New returns a new BitcoindNotifier instance. This function assumes the
 // bitcoind node detailed in the passed configuration is already running, and
-// willing to accept RPC requests and new zmq clients.
+// willing to accept RPC requests and new zmq clients. If the node is pruned, a
+// blockFetcher can be passed to retrieve historical blocks from the P2P
+// network.
 func New(chainConn *chain.BitcoindConn, chainParams *chaincfg.Params,
 	spendHintCache chainntnfs.SpendHintCache,
-	confirmHintCache chainntnfs.ConfirmHintCache) *BitcoindNotifier {
+	confirmHintCache chainntnfs.ConfirmHintCache,
+	blockFetcher *blockfetch.Fetcher) *BitcoindNotifier {
 
 	notifier := &BitcoindNotifier{
 		chainParams: chainParams,
@@ -85,6 +97,7 @@ func New(chainConn *chain.BitcoindConn, chainParams *chaincfg.Params,
 
 		spendHintCache:   spendHintCache,
 		confirmHintCache: confirmHintCache,
+		blockFetcher:     blockFetcher,
 
 		quit: make(chan struct{}),
 	}
@@ -521,7 +534,7 @@ func (b *BitcoindNotifier) confDetailsManually(confRequest chainntnfs.ConfReques
 					"with height %d", height)
 		}
 
-		block, err := b.chainConn.GetBlock(blockHash)
+		block, err := b.getBlock(blockHash)
 		if err != nil {
 			return nil, chainntnfs.TxNotFoundManually,
 				fmt.Errorf("unable to get block with hash "+
@@ -550,6 +563,14 @@ func (b *BitcoindNotifier) confDetailsManually(confRequest chainntnfs.ConfReques
 	return nil, chainntnfs.TxNotFoundManually, nil
 }
 
+// getBlock retrieves the block with the given hash from the bitcoind node,
+// falling back to the P2P network if the node has pruned it.
+func (b *BitcoindNotifier) getBlock(hash *chainhash.Hash) (*wire.MsgBlock,
+	error) {
+
+	return blockfetch.GetBlock(hash, b.chainConn.GetBlock, b.blockFetcher)
+}
+
 // handleBlockConnected applies a chain update for a new block. Any watched
 // transactions included this block will processed to either send notifications
 // now or after numConfirmations confs.
@@ -557,7 +578,7 @@ func (b *BitcoindNotifier) handleBlockConnected(block chainntnfs.BlockEpoch) err
 	// First, we'll fetch the raw block as we'll need to gather all the
 	// transactions to determine whether any are relevant to our registered
 	// clients.
-	rawBlock, err := b.chainConn.GetBlock(block.Hash)
+	rawBlock, err := b.getBlock(block.Hash)
 	if err != nil {
 		return fmt.Errorf("unable to get block: %v", err)
 	}
@@ -776,7 +797,7 @@ func (b *BitcoindNotifier) historicalSpendDetails(
 			return nil, fmt.Errorf("unable to retrieve hash for "+
 				"block with height %d: %v", height, err)
 		}
-		block, err := b.chainConn.GetBlock(blockHash)
+		block, err := b.getBlock(blockHash)
 		if err != nil {
 			return nil, fmt.Errorf("unable to retrieve block "+
 				"with hash %v: %v", blockHash, err)
@@ -844,6 +865,14 @@ func (b *BitcoindNotifier) RegisterConfirmationsNtfn(txid *chainhash.Hash,
 	}
 }
 
+// Stats returns the counters of the confirmation notifications registered
+// with the notifier.
+//
+// NOTE: This is part of the chainntnfs.StatsReporter interface.
+func (b *BitcoindNotifier) Stats() chainntnfs.NotifierStats {
+	return b.txNotifier.Stats()
+}
+
 // blockEpochRegistration represents a client's intent to receive a
 // notification with each newly connected block.
 type blockEpochRegistration struct {
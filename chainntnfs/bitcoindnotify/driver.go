@@ -6,15 +6,16 @@ import (
 
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/btcsuite/btcwallet/chain"
+	"github.com/cryptomeow/lnd/blockfetch"
 	"github.com/cryptomeow/lnd/chainntnfs"
 )
 
 // createNewNotifier creates a new instance of the ChainNotifier interface
 // implemented by BitcoindNotifier.
 func createNewNotifier(args ...interface{}) (chainntnfs.ChainNotifier, error) {
-	if len(args) != 4 {
+	if len(args) != 5 {
 		return nil, fmt.Errorf("incorrect number of arguments to "+
-			".New(...), expected 4, instead passed %v", len(args))
+			".New(...), expected 5, instead passed %v", len(args))
 	}
 
 	chainConn, ok := args[0].(*chain.BitcoindConn)
@@ -41,7 +42,17 @@ func createNewNotifier(args ...interface{}) (chainntnfs.ChainNotifier, error) {
 			"is incorrect, expected a chainntnfs.ConfirmHintCache")
 	}
 
-	return New(chainConn, chainParams, spendHintCache, confirmHintCache), nil
+	// The block fetcher is optional, so we also accept a nil value.
+	blockFetcher, ok := args[4].(*blockfetch.Fetcher)
+	if !ok && args[4] != nil {
+		return nil, errors.New("fifth argument to bitcoindnotify.New " +
+			"is incorrect, expected a *blockfetch.Fetcher")
+	}
+
+	return New(
+		chainConn, chainParams, spendHintCache, confirmHintCache,
+		blockFetcher,
+	), nil
 }
 
 // init registers a driver for the BtcdNotifier concrete implementation of the
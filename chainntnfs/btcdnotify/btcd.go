@@ -89,6 +89,9 @@ type BtcdNotifier struct {
 // Ensure BtcdNotifier implements the ChainNotifier interface at compile time.
 var _ chainntnfs.ChainNotifier = (*BtcdNotifier)(nil)
 
+// Ensure BtcdNotifier implements the StatsReporter interface at compile time.
+var _ chainntnfs.StatsReporter = (*BtcdNotifier)(nil)
+
 // New returns a new BtcdNotifier instance. This function assumes the btcd node
 // detailed in the passed configuration is already running, and willing to
 // accept new websockets clients.
@@ -899,6 +902,14 @@ func (b *BtcdNotifier) RegisterConfirmationsNtfn(txid *chainhash.Hash,
 	}
 }
 
+// Stats returns the counters of the confirmation notifications registered
+// with the notifier.
+//
+// NOTE: This is part of the chainntnfs.StatsReporter interface.
+func (b *BtcdNotifier) Stats() chainntnfs.NotifierStats {
+	return b.txNotifier.Stats()
+}
+
 // blockEpochRegistration represents a client's intent to receive a
 // notification with each newly connected block.
 type blockEpochRegistration struct {
@@ -132,7 +132,7 @@ func TestHistoricalConfDetailsTxIndex(t *testing.T) {
 
 	// We'll now confirm this transaction and re-attempt to retrieve its
 	// confirmation details.
-	if _, err := harness.Node.Generate(1); err != nil {
+	if _, err := harness.Client.Generate(1); err != nil {
 		t.Fatalf("unable to generate block: %v", err)
 	}
 
@@ -188,7 +188,7 @@ func TestHistoricalConfDetailsNoTxIndex(t *testing.T) {
 	// Now, we'll create a test transaction and attempt to retrieve its
 	// confirmation details. We'll note its broadcast height to use as the
 	// height hint when manually scanning the chain.
-	_, currentHeight, err := harness.Node.GetBestBlock()
+	_, currentHeight, err := harness.Client.GetBestBlock()
 	if err != nil {
 		t.Fatalf("unable to retrieve current height: %v", err)
 	}
@@ -219,7 +219,7 @@ func TestHistoricalConfDetailsNoTxIndex(t *testing.T) {
 
 	// We'll now confirm this transaction and re-attempt to retrieve its
 	// confirmation details.
-	if _, err := harness.Node.Generate(1); err != nil {
+	if _, err := harness.Client.Generate(1); err != nil {
 		t.Fatalf("unable to generate block: %v", err)
 	}
 
@@ -0,0 +1,338 @@
+package chainntnfs
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"github.com/cryptomeow/lnd/queue"
+)
+
+// epochHistorySize is the number of most recent blocks the BlockEpochFanout
+// keeps around to catch up subscribers that registered with a best block
+// behind the tip.
+const epochHistorySize = 144
+
+var (
+	// ErrFanoutNotStarted is returned by the BlockEpochFanout if a block
+	// epoch registration is attempted before it has been started.
+	ErrFanoutNotStarted = errors.New("block epoch fanout not started")
+)
+
+// fanoutEpochClient is a block epoch subscriber of the BlockEpochFanout.
+type fanoutEpochClient struct {
+	// epochQueue buffers the blocks of the subscriber, so a slow
+	// subscriber doesn't hold up the delivery to all others.
+	epochQueue *queue.ConcurrentQueue
+
+	// epochChan is the channel handed out to the subscriber.
+	epochChan chan *BlockEpoch
+
+	cancelOnce sync.Once
+	cancelChan chan struct{}
+
+	wg sync.WaitGroup
+}
+
+// BlockEpochFanout is a ChainNotifier that serves all block epoch
+// registrations from a single block epoch subscription at the wrapped
+// notifier. Each subscriber is handed its blocks in order through its own
+// buffered queue. Subscribers that register with a best block that is part of
+// the recent blocks known to the fanout are caught up from memory, while all
+// others are registered with the wrapped notifier directly, which is able to
+// catch them up from the chain backend. All other notifications are passed
+// through to the wrapped notifier.
+type BlockEpochFanout struct {
+	started int32 // To be used atomically.
+	stopped int32 // To be used atomically.
+
+	ChainNotifier
+
+	upstream *BlockEpochEvent
+
+	// mtx guards the subscribers and the block history.
+	mtx           sync.Mutex
+	clients       map[uint64]*fanoutEpochClient
+	clientCounter uint64
+	closed        bool
+
+	// history holds the most recent blocks delivered by the wrapped
+	// notifier, ordered by height. Its last entry is the current tip.
+	history []*BlockEpoch
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewBlockEpochFanout creates a new BlockEpochFanout that sources its blocks
+// from the given notifier.
+func NewBlockEpochFanout(notifier ChainNotifier) *BlockEpochFanout {
+	return &BlockEpochFanout{
+		ChainNotifier: notifier,
+		clients:       make(map[uint64]*fanoutEpochClient),
+		quit:          make(chan struct{}),
+	}
+}
+
+// Start registers the single block epoch subscription at the wrapped notifier
+// and waits for the current tip of the chain. The wrapped notifier must
+// already be started.
+//
+// NOTE: This is part of the ChainNotifier interface.
+func (f *BlockEpochFanout) Start() error {
+	if !atomic.CompareAndSwapInt32(&f.started, 0, 1) {
+		return nil
+	}
+
+	upstream, err := f.ChainNotifier.RegisterBlockEpochNtfn(nil)
+	if err != nil {
+		return err
+	}
+
+	// The wrapped notifier immediately delivers the current tip, which
+	// we'll need to serve the registrations of subscribers without a
+	// best block.
+	select {
+	case tip, ok := <-upstream.Epochs:
+		if !ok {
+			return ErrChainNotifierShuttingDown
+		}
+		f.mtx.Lock()
+		f.history = append(f.history, tip)
+		f.mtx.Unlock()
+
+	case <-f.quit:
+		upstream.Cancel()
+		return ErrChainNotifierShuttingDown
+	}
+
+	f.upstream = upstream
+
+	f.wg.Add(1)
+	go f.dispatchEpochs()
+
+	return nil
+}
+
+// Started returns true if this instance has been started, and false
+// otherwise.
+//
+// NOTE: This is part of the ChainNotifier interface.
+func (f *BlockEpochFanout) Started() bool {
+	return atomic.LoadInt32(&f.started) != 0
+}
+
+// Stop cancels the block epoch subscription at the wrapped notifier and closes
+// the channels of all subscribers. The wrapped notifier itself is not stopped.
+//
+// NOTE: This is part of the ChainNotifier interface.
+func (f *BlockEpochFanout) Stop() error {
+	if !atomic.CompareAndSwapInt32(&f.stopped, 0, 1) {
+		return nil
+	}
+
+	close(f.quit)
+	f.wg.Wait()
+
+	if f.upstream != nil {
+		f.upstream.Cancel()
+	}
+
+	f.closeClients()
+
+	return nil
+}
+
+// dispatchEpochs records each block delivered by the wrapped notifier and
+// hands it to all subscribers.
+//
+// NOTE: This MUST be run as a goroutine.
+func (f *BlockEpochFanout) dispatchEpochs() {
+	defer f.wg.Done()
+
+	for {
+		select {
+		case epoch, ok := <-f.upstream.Epochs:
+			if !ok {
+				Log.Infof("Block epoch subscription closed, " +
+					"closing all fanout subscribers")
+
+				f.closeClients()
+				return
+			}
+
+			f.mtx.Lock()
+			f.addToHistory(epoch)
+			for _, client := range f.clients {
+				select {
+				case client.epochQueue.ChanIn() <- epoch:
+				case <-f.quit:
+				}
+			}
+			f.mtx.Unlock()
+
+		case <-f.quit:
+			return
+		}
+	}
+}
+
+// addToHistory appends a new tip to the block history. Blocks at or above its
+// height were reorged out, so they're removed first.
+//
+// NOTE: The mtx MUST be held when calling this method.
+func (f *BlockEpochFanout) addToHistory(epoch *BlockEpoch) {
+	for len(f.history) > 0 &&
+		f.history[len(f.history)-1].Height >= epoch.Height {
+
+		f.history = f.history[:len(f.history)-1]
+	}
+
+	f.history = append(f.history, epoch)
+	if len(f.history) > epochHistorySize {
+		f.history = f.history[len(f.history)-epochHistorySize:]
+	}
+}
+
+// missedBlocks returns the blocks a subscriber with the given best block needs
+// to be caught up with. False is returned if the best block isn't part of the
+// block history.
+//
+// NOTE: The mtx MUST be held when calling this method.
+func (f *BlockEpochFanout) missedBlocks(
+	bestBlock *BlockEpoch) ([]*BlockEpoch, bool) {
+
+	if bestBlock.Hash == nil {
+		return nil, false
+	}
+
+	for i, epoch := range f.history {
+		if epoch.Height != bestBlock.Height {
+			continue
+		}
+		if *epoch.Hash != *bestBlock.Hash {
+			return nil, false
+		}
+
+		return f.history[i+1:], true
+	}
+
+	return nil, false
+}
+
+// RegisterBlockEpochNtfn returns a BlockEpochEvent which subscribes the caller
+// to receive notifications of each new block connected to the main chain. If
+// the caller passes in its best known block, it is caught up with all blocks
+// it missed. Otherwise, a notification for the current tip is dispatched
+// immediately.
+//
+// NOTE: This is part of the ChainNotifier interface.
+func (f *BlockEpochFanout) RegisterBlockEpochNtfn(
+	bestBlock *BlockEpoch) (*BlockEpochEvent, error) {
+
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	switch {
+	case f.closed:
+		return nil, ErrChainNotifierShuttingDown
+
+	case len(f.history) == 0:
+		return nil, ErrFanoutNotStarted
+	}
+
+	backlog := f.history[len(f.history)-1:]
+	if bestBlock != nil {
+		var ok bool
+		backlog, ok = f.missedBlocks(bestBlock)
+
+		// The subscriber is too far behind or on a stale branch, so
+		// we'll let the wrapped notifier catch it up.
+		if !ok {
+			Log.Debugf("Best block %v of block epoch subscriber "+
+				"unknown to fanout, registering with chain "+
+				"notifier", bestBlock.Height)
+
+			return f.ChainNotifier.RegisterBlockEpochNtfn(bestBlock)
+		}
+	}
+
+	f.clientCounter++
+	clientID := f.clientCounter
+
+	client := &fanoutEpochClient{
+		epochQueue: queue.NewConcurrentQueue(20),
+		epochChan:  make(chan *BlockEpoch, 20),
+		cancelChan: make(chan struct{}),
+	}
+	client.epochQueue.Start()
+
+	// Proxy the blocks added to the queue of the subscriber to its
+	// channel, ensuring all blocks are received in order.
+	client.wg.Add(1)
+	go func() {
+		defer client.wg.Done()
+
+		for {
+			select {
+			case item := <-client.epochQueue.ChanOut():
+				select {
+				case client.epochChan <- item.(*BlockEpoch):
+				case <-client.cancelChan:
+					return
+				}
+
+			case <-client.cancelChan:
+				return
+			}
+		}
+	}()
+
+	for _, epoch := range backlog {
+		client.epochQueue.ChanIn() <- epoch
+	}
+
+	f.clients[clientID] = client
+
+	return &BlockEpochEvent{
+		Epochs: client.epochChan,
+		Cancel: func() {
+			f.mtx.Lock()
+			_, ok := f.clients[clientID]
+			delete(f.clients, clientID)
+			f.mtx.Unlock()
+
+			if ok {
+				client.stop()
+			}
+		},
+	}, nil
+}
+
+// closeClients closes the channels of all subscribers and prevents any new
+// registrations.
+func (f *BlockEpochFanout) closeClients() {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	f.closed = true
+	for clientID, client := range f.clients {
+		client.stop()
+		delete(f.clients, clientID)
+	}
+}
+
+// stop stops the proxy goroutine and the queue of the subscriber and closes
+// its channel.
+func (c *fanoutEpochClient) stop() {
+	c.cancelOnce.Do(func() {
+		close(c.cancelChan)
+		c.wg.Wait()
+		c.epochQueue.Stop()
+		close(c.epochChan)
+	})
+}
+
+// A compile-time check to ensure BlockEpochFanout meets the ChainNotifier
+// interface.
+var _ ChainNotifier = (*BlockEpochFanout)(nil)
@@ -0,0 +1,147 @@
+package chainntnfs_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/cryptomeow/lnd/chainntnfs"
+)
+
+// expectEpoch asserts that the given block is the next one delivered on the
+// channel.
+func expectEpoch(t *testing.T, epochs <-chan *chainntnfs.BlockEpoch,
+	expected *chainntnfs.BlockEpoch) {
+
+	t.Helper()
+
+	select {
+	case epoch, ok := <-epochs:
+		if !ok {
+			t.Fatalf("epoch channel closed")
+		}
+		if epoch != expected {
+			t.Fatalf("expected block %v, got block %v",
+				expected.Height, epoch.Height)
+		}
+
+	case <-time.After(5 * time.Second):
+		t.Fatalf("block %v not received", expected.Height)
+	}
+}
+
+// TestBlockEpochFanout checks that all block epoch subscribers of the fanout
+// are served in order from a single subscription at the wrapped notifier, and
+// that subscribers are caught up with the blocks they missed.
+func TestBlockEpochFanout(t *testing.T) {
+	t.Parallel()
+
+	var (
+		notifier = &mockFailoverNotifier{}
+		fanout   = chainntnfs.NewBlockEpochFanout(notifier)
+		block1   = &chainntnfs.BlockEpoch{Hash: &chainhash.Hash{1}, Height: 1}
+		block2   = &chainntnfs.BlockEpoch{Hash: &chainhash.Hash{2}, Height: 2}
+		block3   = &chainntnfs.BlockEpoch{Hash: &chainhash.Hash{3}, Height: 3}
+		reorg2   = &chainntnfs.BlockEpoch{Hash: &chainhash.Hash{4}, Height: 2}
+	)
+
+	// Registrations are rejected until the fanout is started.
+	if _, err := fanout.RegisterBlockEpochNtfn(nil); err == nil {
+		t.Fatalf("expected registration to fail before start")
+	}
+
+	// The fanout waits for the current tip when starting, so we'll deliver
+	// it once the fanout subscribed to the notifier.
+	startErr := make(chan error, 1)
+	go func() {
+		startErr <- fanout.Start()
+	}()
+
+	var upstream chan *chainntnfs.BlockEpoch
+	for i := 0; i < 100 && upstream == nil; i++ {
+		notifier.mtx.Lock()
+		if len(notifier.epochChans) > 0 {
+			upstream = notifier.epochChans[0]
+		}
+		notifier.mtx.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+	}
+	if upstream == nil {
+		t.Fatalf("fanout didn't subscribe to the notifier")
+	}
+	upstream <- block1
+
+	if err := <-startErr; err != nil {
+		t.Fatalf("unable to start fanout: %v", err)
+	}
+	defer fanout.Stop()
+
+	// Subscribers without a best block receive the current tip right
+	// away.
+	first, err := fanout.RegisterBlockEpochNtfn(nil)
+	if err != nil {
+		t.Fatalf("unable to register epoch ntfn: %v", err)
+	}
+	second, err := fanout.RegisterBlockEpochNtfn(nil)
+	if err != nil {
+		t.Fatalf("unable to register epoch ntfn: %v", err)
+	}
+	expectEpoch(t, first.Epochs, block1)
+	expectEpoch(t, second.Epochs, block1)
+
+	// New blocks are delivered to all subscribers in order, even if one
+	// of them doesn't read its blocks in the meantime.
+	upstream <- block2
+	upstream <- block3
+	expectEpoch(t, first.Epochs, block2)
+	expectEpoch(t, first.Epochs, block3)
+	expectEpoch(t, second.Epochs, block2)
+	expectEpoch(t, second.Epochs, block3)
+
+	// A subscriber with a known best block is caught up from memory.
+	catchUp, err := fanout.RegisterBlockEpochNtfn(block1)
+	if err != nil {
+		t.Fatalf("unable to register epoch ntfn: %v", err)
+	}
+	expectEpoch(t, catchUp.Epochs, block2)
+	expectEpoch(t, catchUp.Epochs, block3)
+
+	// A canceled subscriber doesn't receive any further blocks.
+	second.Cancel()
+	if _, ok := <-second.Epochs; ok {
+		t.Fatalf("expected epoch channel to be closed")
+	}
+
+	// After a reorg, blocks of the stale branch are removed from the
+	// history, so a subscriber on it is registered with the notifier.
+	upstream <- reorg2
+	expectEpoch(t, first.Epochs, reorg2)
+	expectEpoch(t, catchUp.Epochs, reorg2)
+
+	if _, err := fanout.RegisterBlockEpochNtfn(block2); err != nil {
+		t.Fatalf("unable to register epoch ntfn: %v", err)
+	}
+	if _, err := fanout.RegisterBlockEpochNtfn(block1); err != nil {
+		t.Fatalf("unable to register epoch ntfn: %v", err)
+	}
+
+	notifier.mtx.Lock()
+	numUpstream := len(notifier.epochChans)
+	notifier.mtx.Unlock()
+	if numUpstream != 2 {
+		t.Fatalf("expected 2 registrations at the notifier, got %v",
+			numUpstream)
+	}
+
+	// Stopping the fanout closes the channels of all subscribers.
+	if err := fanout.Stop(); err != nil {
+		t.Fatalf("unable to stop fanout: %v", err)
+	}
+	for _, epochs := range []<-chan *chainntnfs.BlockEpoch{
+		first.Epochs, catchUp.Epochs,
+	} {
+		for range epochs {
+		}
+	}
+}
@@ -0,0 +1,835 @@
+package chainntnfs
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+)
+
+var (
+	// ErrNoHealthyBackend is returned by the FailoverNotifier if none of
+	// its backends passes its health check.
+	ErrNoHealthyBackend = errors.New("no healthy chain backend available")
+)
+
+// FailoverBackend is a single chain backend the FailoverNotifier is able to
+// source its notifications from.
+type FailoverBackend struct {
+	// Name is a human readable identifier of the backend used for logging.
+	Name string
+
+	// Connect establishes the connection to the backend and returns a new
+	// ChainNotifier backed by it, along with a function that closes the
+	// connection once the notifier is stopped. It is executed every time
+	// the backend becomes active, as a stopped notifier can't be started
+	// again, so backup backends don't need to be reachable at startup.
+	Connect func() (ChainNotifier, func(), error)
+
+	// HealthCheck returns a non-nil error if the backend is currently
+	// unable to serve notifications.
+	HealthCheck func() error
+
+	// notifier is the started ChainNotifier backed by this backend. It is
+	// nil while the backend isn't active.
+	notifier ChainNotifier
+
+	// disconnect closes the connection the notifier is backed by.
+	disconnect func()
+}
+
+// start connects to the backend and starts its notifier if that hasn't
+// happened yet.
+func (b *FailoverBackend) start() error {
+	if b.notifier != nil {
+		return nil
+	}
+
+	notifier, disconnect, err := b.Connect()
+	if err != nil {
+		return err
+	}
+	if err := notifier.Start(); err != nil {
+		disconnect()
+		return err
+	}
+
+	b.notifier = notifier
+	b.disconnect = disconnect
+
+	return nil
+}
+
+// stop stops the notifier of the backend if it was started and closes its
+// connection. The backend is connected to again the next time it becomes
+// active.
+func (b *FailoverBackend) stop() {
+	if b.notifier == nil {
+		return
+	}
+
+	if err := b.notifier.Stop(); err != nil {
+		Log.Errorf("Unable to stop notifier of chain backend %v: %v",
+			b.Name, err)
+	}
+	b.disconnect()
+
+	b.notifier = nil
+	b.disconnect = nil
+}
+
+// failoverConfReg is a confirmation registration of a client of the
+// FailoverNotifier.
+type failoverConfReg struct {
+	txid       *chainhash.Hash
+	pkScript   []byte
+	numConfs   uint32
+	heightHint uint32
+
+	// event is the event handed out to the client.
+	event *ConfirmationEvent
+
+	// upstream delivers the registration at a new backend after a
+	// failover.
+	upstream chan *ConfirmationEvent
+
+	cancelOnce sync.Once
+	cancel     chan struct{}
+}
+
+// failoverSpendReg is a spend registration of a client of the
+// FailoverNotifier.
+type failoverSpendReg struct {
+	outpoint   *wire.OutPoint
+	pkScript   []byte
+	heightHint uint32
+
+	// event is the event handed out to the client.
+	event *SpendEvent
+
+	// upstream delivers the registration at a new backend after a
+	// failover.
+	upstream chan *SpendEvent
+
+	cancelOnce sync.Once
+	cancel     chan struct{}
+}
+
+// failoverEpochReg is a block epoch registration of a client of the
+// FailoverNotifier.
+type failoverEpochReg struct {
+	// epochs is the channel handed out to the client.
+	epochs chan *BlockEpoch
+
+	// bestBlock is the last block delivered to the client, which is
+	// used to backfill any blocks missed during a failover.
+	bestBlockMtx sync.Mutex
+	bestBlock    *BlockEpoch
+
+	// upstream delivers the registration at a new backend after a
+	// failover.
+	upstream chan *BlockEpochEvent
+
+	cancelOnce sync.Once
+	cancel     chan struct{}
+}
+
+// FailoverNotifier is a ChainNotifier that sources its notifications from one
+// of several chain backends. Once the active backend fails its health check,
+// the notifier switches to the first healthy backend, in the order they were
+// given, and re-registers all outstanding notifications with it. Clients keep
+// receiving notifications on the events they originally registered, so a
+// failover is transparent to them.
+type FailoverNotifier struct {
+	started int32 // To be used atomically.
+	stopped int32 // To be used atomically.
+
+	// backendMtx guards the active backend and all registrations, and
+	// serializes failovers.
+	backendMtx sync.Mutex
+	backends   []*FailoverBackend
+	active     int
+
+	nextRegID  uint64
+	confRegs   map[uint64]*failoverConfReg
+	spendRegs  map[uint64]*failoverSpendReg
+	epochRegs  map[uint64]*failoverEpochReg
+	regsClosed bool
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewFailoverNotifier creates a new FailoverNotifier from the given backends.
+// The first backend is the primary one notifications are sourced from while
+// it is healthy.
+func NewFailoverNotifier(backends ...*FailoverBackend) *FailoverNotifier {
+	return &FailoverNotifier{
+		backends:  backends,
+		confRegs:  make(map[uint64]*failoverConfReg),
+		spendRegs: make(map[uint64]*failoverSpendReg),
+		epochRegs: make(map[uint64]*failoverEpochReg),
+		quit:      make(chan struct{}),
+	}
+}
+
+// Start starts the notifier of the primary backend.
+//
+// NOTE: This is part of the ChainNotifier interface.
+func (f *FailoverNotifier) Start() error {
+	if !atomic.CompareAndSwapInt32(&f.started, 0, 1) {
+		return nil
+	}
+
+	if len(f.backends) == 0 {
+		return ErrNoHealthyBackend
+	}
+
+	f.backendMtx.Lock()
+	defer f.backendMtx.Unlock()
+
+	return f.backends[f.active].start()
+}
+
+// Started returns true if this instance has been started, and false
+// otherwise.
+//
+// NOTE: This is part of the ChainNotifier interface.
+func (f *FailoverNotifier) Started() bool {
+	return atomic.LoadInt32(&f.started) != 0
+}
+
+// Stop stops the notifiers of all backends that were started and cancels all
+// outstanding registrations.
+//
+// NOTE: This is part of the ChainNotifier interface.
+func (f *FailoverNotifier) Stop() error {
+	if !atomic.CompareAndSwapInt32(&f.stopped, 0, 1) {
+		return nil
+	}
+
+	close(f.quit)
+	f.wg.Wait()
+
+	f.backendMtx.Lock()
+	defer f.backendMtx.Unlock()
+
+	// Now that all forwarding goroutines have exited, we can safely close
+	// the channels of all outstanding client events.
+	f.regsClosed = true
+	for _, reg := range f.confRegs {
+		close(reg.event.Confirmed)
+		close(reg.event.Updates)
+		close(reg.event.NegativeConf)
+		close(reg.event.Done)
+	}
+	for _, reg := range f.spendRegs {
+		close(reg.event.Spend)
+		close(reg.event.Reorg)
+		close(reg.event.Done)
+	}
+	for _, reg := range f.epochRegs {
+		close(reg.epochs)
+	}
+
+	for _, backend := range f.backends {
+		backend.stop()
+	}
+
+	return nil
+}
+
+// ActiveBackend returns the name of the backend notifications are currently
+// sourced from.
+func (f *FailoverNotifier) ActiveBackend() string {
+	f.backendMtx.Lock()
+	defer f.backendMtx.Unlock()
+
+	return f.backends[f.active].Name
+}
+
+// Stats returns the sum of the counters of all backends that are currently
+// connected.
+//
+// NOTE: This is part of the StatsReporter interface.
+func (f *FailoverNotifier) Stats() NotifierStats {
+	f.backendMtx.Lock()
+	defer f.backendMtx.Unlock()
+
+	var stats NotifierStats
+	for _, backend := range f.backends {
+		reporter, ok := backend.notifier.(StatsReporter)
+		if !ok {
+			continue
+		}
+
+		backendStats := reporter.Stats()
+		stats.ConfRegistrations += backendStats.ConfRegistrations
+		stats.SharedConfRegistrations +=
+			backendStats.SharedConfRegistrations
+		stats.HistoricalConfDispatches +=
+			backendStats.HistoricalConfDispatches
+	}
+
+	return stats
+}
+
+// CheckHealth runs the health check of the active backend. If it fails, the
+// notifier fails over to the first healthy backend. An error is only returned
+// if no healthy backend could be found.
+func (f *FailoverNotifier) CheckHealth() error {
+	f.backendMtx.Lock()
+	defer f.backendMtx.Unlock()
+
+	active := f.backends[f.active]
+	err := active.HealthCheck()
+	if err == nil {
+		return nil
+	}
+
+	Log.Warnf("Chain backend %v failed health check: %v", active.Name,
+		err)
+
+	for i, backend := range f.backends {
+		if i == f.active {
+			continue
+		}
+
+		if err := backend.HealthCheck(); err != nil {
+			Log.Debugf("Chain backend %v failed health check: %v",
+				backend.Name, err)
+			continue
+		}
+
+		if err := f.failover(i); err != nil {
+			Log.Errorf("Unable to fail over to chain backend %v: "+
+				"%v", backend.Name, err)
+			continue
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("%w: %v", ErrNoHealthyBackend, err)
+}
+
+// failover switches to the backend with the given index and moves all
+// outstanding registrations over to it. The notifier of the previously active
+// backend is stopped afterwards.
+//
+// NOTE: The backendMtx MUST be held when calling this method.
+func (f *FailoverNotifier) failover(index int) error {
+	backend := f.backends[index]
+	if err := backend.start(); err != nil {
+		return err
+	}
+
+	prevBackend := f.backends[f.active]
+	Log.Infof("Failing over chain notifications from %v to %v",
+		prevBackend.Name, backend.Name)
+
+	f.active = index
+	defer prevBackend.stop()
+
+	// Register all outstanding notifications with the new backend. Any
+	// registration that fails is retried on the next failover, as the
+	// forwarding goroutine will keep waiting for a new upstream event.
+	for id, reg := range f.confRegs {
+		event, err := backend.notifier.RegisterConfirmationsNtfn(
+			reg.txid, reg.pkScript, reg.numConfs, reg.heightHint,
+		)
+		if err != nil {
+			Log.Errorf("Unable to move confirmation registration "+
+				"%v to %v: %v", id, backend.Name, err)
+			continue
+		}
+
+		reg := reg
+		f.sendUpstream(func() bool {
+			select {
+			case reg.upstream <- event:
+				return true
+			case <-reg.cancel:
+			case <-f.quit:
+			}
+			return false
+		}, event.Cancel)
+	}
+
+	for id, reg := range f.spendRegs {
+		event, err := backend.notifier.RegisterSpendNtfn(
+			reg.outpoint, reg.pkScript, reg.heightHint,
+		)
+		if err != nil {
+			Log.Errorf("Unable to move spend registration %v to "+
+				"%v: %v", id, backend.Name, err)
+			continue
+		}
+
+		reg := reg
+		f.sendUpstream(func() bool {
+			select {
+			case reg.upstream <- event:
+				return true
+			case <-reg.cancel:
+			case <-f.quit:
+			}
+			return false
+		}, event.Cancel)
+	}
+
+	for id, reg := range f.epochRegs {
+		// Pass the best block we delivered to the client, so the new
+		// backend sends a backlog of any blocks we missed.
+		reg.bestBlockMtx.Lock()
+		bestBlock := reg.bestBlock
+		reg.bestBlockMtx.Unlock()
+
+		event, err := backend.notifier.RegisterBlockEpochNtfn(bestBlock)
+		if err != nil {
+			Log.Errorf("Unable to move block epoch registration "+
+				"%v to %v: %v", id, backend.Name, err)
+			continue
+		}
+
+		reg := reg
+		f.sendUpstream(func() bool {
+			select {
+			case reg.upstream <- event:
+				return true
+			case <-reg.cancel:
+			case <-f.quit:
+			}
+			return false
+		}, event.Cancel)
+	}
+
+	return nil
+}
+
+// sendUpstream hands a new upstream event to a forwarding goroutine without
+// blocking the caller, as the forwarding goroutine may currently be waiting on
+// its client. The send func must return false if the registration was canceled
+// or the notifier stopped before the event was handed over, in which case the
+// upstream event is canceled instead.
+func (f *FailoverNotifier) sendUpstream(send func() bool,
+	cancelUpstream func()) {
+
+	f.wg.Add(1)
+	go func() {
+		defer f.wg.Done()
+
+		if !send() {
+			cancelUpstream()
+		}
+	}()
+}
+
+// RegisterConfirmationsNtfn registers an intent to be notified once the
+// target txid/output script has reached numConfs confirmations on-chain.
+//
+// NOTE: This is part of the ChainNotifier interface.
+func (f *FailoverNotifier) RegisterConfirmationsNtfn(txid *chainhash.Hash,
+	pkScript []byte, numConfs, heightHint uint32) (*ConfirmationEvent,
+	error) {
+
+	f.backendMtx.Lock()
+	defer f.backendMtx.Unlock()
+
+	if f.regsClosed {
+		return nil, ErrChainNotifierShuttingDown
+	}
+
+	upstream, err := f.backends[f.active].notifier.RegisterConfirmationsNtfn(
+		txid, pkScript, numConfs, heightHint,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	id := f.nextRegID
+	f.nextRegID++
+
+	reg := &failoverConfReg{
+		txid:       txid,
+		pkScript:   pkScript,
+		numConfs:   numConfs,
+		heightHint: heightHint,
+		upstream:   make(chan *ConfirmationEvent),
+		cancel:     make(chan struct{}),
+	}
+	reg.event = NewConfirmationEvent(numConfs, func() {
+		f.cancelReg(&reg.cancelOnce, reg.cancel, func() {
+			delete(f.confRegs, id)
+		})
+	})
+	f.confRegs[id] = reg
+
+	f.wg.Add(1)
+	go f.forwardConf(reg, upstream)
+
+	return reg.event, nil
+}
+
+// forwardConf forwards the notifications of the current upstream event of a
+// confirmation registration to its client.
+//
+// NOTE: This MUST be run as a goroutine.
+func (f *FailoverNotifier) forwardConf(reg *failoverConfReg,
+	upstream *ConfirmationEvent) {
+
+	defer f.wg.Done()
+
+	var (
+		confirmed    = upstream.Confirmed
+		updates      = upstream.Updates
+		negativeConf = upstream.NegativeConf
+		done         = upstream.Done
+
+		// isConfirmed tracks whether the client was already notified
+		// of the confirmation, so we don't notify it twice if the new
+		// backend dispatches it again after a failover.
+		isConfirmed bool
+	)
+	for {
+		select {
+		case conf, ok := <-confirmed:
+			if !ok {
+				confirmed = nil
+				continue
+			}
+			if isConfirmed {
+				continue
+			}
+			isConfirmed = true
+
+			select {
+			case reg.event.Confirmed <- conf:
+			case <-reg.cancel:
+				upstream.Cancel()
+				return
+			case <-f.quit:
+				return
+			}
+
+		case numConfsLeft, ok := <-updates:
+			if !ok {
+				updates = nil
+				continue
+			}
+
+			select {
+			case reg.event.Updates <- numConfsLeft:
+			default:
+			}
+
+		case depth, ok := <-negativeConf:
+			if !ok {
+				negativeConf = nil
+				continue
+			}
+			isConfirmed = false
+
+			select {
+			case reg.event.NegativeConf <- depth:
+			default:
+			}
+
+		case _, ok := <-done:
+			if !ok {
+				done = nil
+				continue
+			}
+
+			select {
+			case reg.event.Done <- struct{}{}:
+			default:
+			}
+
+			// Nothing is dispatched for the registration anymore,
+			// so it is removed instead of being moved to the next
+			// backend on a failover.
+			reg.event.Cancel()
+			upstream.Cancel()
+
+			return
+
+		case newUpstream := <-reg.upstream:
+			upstream.Cancel()
+			upstream = newUpstream
+
+			confirmed = upstream.Confirmed
+			updates = upstream.Updates
+			negativeConf = upstream.NegativeConf
+			done = upstream.Done
+
+		case <-reg.cancel:
+			upstream.Cancel()
+			return
+
+		case <-f.quit:
+			return
+		}
+	}
+}
+
+// RegisterSpendNtfn registers an intent to be notified once the target
+// outpoint/output script has been spent by a transaction on-chain.
+//
+// NOTE: This is part of the ChainNotifier interface.
+func (f *FailoverNotifier) RegisterSpendNtfn(outpoint *wire.OutPoint,
+	pkScript []byte, heightHint uint32) (*SpendEvent, error) {
+
+	f.backendMtx.Lock()
+	defer f.backendMtx.Unlock()
+
+	if f.regsClosed {
+		return nil, ErrChainNotifierShuttingDown
+	}
+
+	upstream, err := f.backends[f.active].notifier.RegisterSpendNtfn(
+		outpoint, pkScript, heightHint,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	id := f.nextRegID
+	f.nextRegID++
+
+	reg := &failoverSpendReg{
+		outpoint:   outpoint,
+		pkScript:   pkScript,
+		heightHint: heightHint,
+		upstream:   make(chan *SpendEvent),
+		cancel:     make(chan struct{}),
+	}
+	reg.event = NewSpendEvent(func() {
+		f.cancelReg(&reg.cancelOnce, reg.cancel, func() {
+			delete(f.spendRegs, id)
+		})
+	})
+	f.spendRegs[id] = reg
+
+	f.wg.Add(1)
+	go f.forwardSpend(reg, upstream)
+
+	return reg.event, nil
+}
+
+// forwardSpend forwards the notifications of the current upstream event of a
+// spend registration to its client.
+//
+// NOTE: This MUST be run as a goroutine.
+func (f *FailoverNotifier) forwardSpend(reg *failoverSpendReg,
+	upstream *SpendEvent) {
+
+	defer f.wg.Done()
+
+	var (
+		spend = upstream.Spend
+		reorg = upstream.Reorg
+		done  = upstream.Done
+
+		// spender is the hash of the spending transaction the client
+		// was notified of, so we don't notify it twice if the new
+		// backend dispatches the same spend again after a failover.
+		spender *chainhash.Hash
+	)
+	for {
+		select {
+		case details, ok := <-spend:
+			if !ok {
+				spend = nil
+				continue
+			}
+			if spender != nil && *spender == *details.SpenderTxHash {
+				continue
+			}
+			spender = details.SpenderTxHash
+
+			select {
+			case reg.event.Spend <- details:
+			case <-reg.cancel:
+				upstream.Cancel()
+				return
+			case <-f.quit:
+				return
+			}
+
+		case _, ok := <-reorg:
+			if !ok {
+				reorg = nil
+				continue
+			}
+			spender = nil
+
+			select {
+			case reg.event.Reorg <- struct{}{}:
+			default:
+			}
+
+		case _, ok := <-done:
+			if !ok {
+				done = nil
+				continue
+			}
+
+			select {
+			case reg.event.Done <- struct{}{}:
+			default:
+			}
+
+			// Nothing is dispatched for the registration anymore,
+			// so it is removed instead of being moved to the next
+			// backend on a failover.
+			reg.event.Cancel()
+			upstream.Cancel()
+
+			return
+
+		case newUpstream := <-reg.upstream:
+			upstream.Cancel()
+			upstream = newUpstream
+
+			spend = upstream.Spend
+			reorg = upstream.Reorg
+			done = upstream.Done
+
+		case <-reg.cancel:
+			upstream.Cancel()
+			return
+
+		case <-f.quit:
+			return
+		}
+	}
+}
+
+// RegisterBlockEpochNtfn returns a BlockEpochEvent which subscribes the
+// caller to receive notifications, of each new block connected to the main
+// chain.
+//
+// NOTE: This is part of the ChainNotifier interface.
+func (f *FailoverNotifier) RegisterBlockEpochNtfn(
+	bestBlock *BlockEpoch) (*BlockEpochEvent, error) {
+
+	f.backendMtx.Lock()
+	defer f.backendMtx.Unlock()
+
+	if f.regsClosed {
+		return nil, ErrChainNotifierShuttingDown
+	}
+
+	upstream, err := f.backends[f.active].notifier.RegisterBlockEpochNtfn(
+		bestBlock,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	id := f.nextRegID
+	f.nextRegID++
+
+	reg := &failoverEpochReg{
+		epochs:    make(chan *BlockEpoch, 20),
+		bestBlock: bestBlock,
+		upstream:  make(chan *BlockEpochEvent),
+		cancel:    make(chan struct{}),
+	}
+	f.epochRegs[id] = reg
+
+	f.wg.Add(1)
+	go f.forwardEpochs(reg, upstream)
+
+	return &BlockEpochEvent{
+		Epochs: reg.epochs,
+		Cancel: func() {
+			f.cancelReg(&reg.cancelOnce, reg.cancel, func() {
+				delete(f.epochRegs, id)
+			})
+		},
+	}, nil
+}
+
+// forwardEpochs forwards the blocks of the current upstream event of a block
+// epoch registration to its client.
+//
+// NOTE: This MUST be run as a goroutine.
+func (f *FailoverNotifier) forwardEpochs(reg *failoverEpochReg,
+	upstream *BlockEpochEvent) {
+
+	defer f.wg.Done()
+
+	epochs := upstream.Epochs
+	for {
+		select {
+		case epoch, ok := <-epochs:
+			if !ok {
+				epochs = nil
+				continue
+			}
+
+			// Skip the block if the client was already notified of
+			// it by the previous backend.
+			reg.bestBlockMtx.Lock()
+			bestBlock := reg.bestBlock
+			reg.bestBlockMtx.Unlock()
+			if bestBlock != nil && bestBlock.Hash != nil &&
+				epoch.Hash != nil && *bestBlock.Hash == *epoch.Hash {
+
+				continue
+			}
+
+			select {
+			case reg.epochs <- epoch:
+			case <-reg.cancel:
+				upstream.Cancel()
+				return
+			case <-f.quit:
+				return
+			}
+
+			reg.bestBlockMtx.Lock()
+			reg.bestBlock = epoch
+			reg.bestBlockMtx.Unlock()
+
+		case newUpstream := <-reg.upstream:
+			upstream.Cancel()
+			upstream = newUpstream
+			epochs = upstream.Epochs
+
+		case <-reg.cancel:
+			upstream.Cancel()
+			return
+
+		case <-f.quit:
+			return
+		}
+	}
+}
+
+// cancelReg cancels a registration by closing its cancel channel once and
+// removing it from the set of outstanding registrations.
+func (f *FailoverNotifier) cancelReg(once *sync.Once, cancel chan struct{},
+	remove func()) {
+
+	once.Do(func() {
+		close(cancel)
+
+		f.backendMtx.Lock()
+		remove()
+		f.backendMtx.Unlock()
+	})
+}
+
+// A compile-time check to ensure FailoverNotifier meets the ChainNotifier
+// interface.
+var _ ChainNotifier = (*FailoverNotifier)(nil)
+
+// A compile-time check to ensure FailoverNotifier meets the StatsReporter
+// interface.
+var _ StatsReporter = (*FailoverNotifier)(nil)
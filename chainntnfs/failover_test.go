@@ -0,0 +1,560 @@
+package chainntnfs_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/cryptomeow/lnd/chainntnfs"
+)
+
+// mockFailoverNotifier is a ChainNotifier that records all registrations so
+// the test can dispatch notifications on them.
+type mockFailoverNotifier struct {
+	mtx         sync.Mutex
+	confEvents  []*chainntnfs.ConfirmationEvent
+	spendEvents []*chainntnfs.SpendEvent
+	epochChans  []chan *chainntnfs.BlockEpoch
+	bestBlocks  []*chainntnfs.BlockEpoch
+	started     bool
+	stopped     bool
+	canceled    int
+}
+
+func (m *mockFailoverNotifier) cancel() {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.canceled++
+}
+
+func (m *mockFailoverNotifier) numCanceled() int {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	return m.canceled
+}
+
+func (m *mockFailoverNotifier) RegisterConfirmationsNtfn(*chainhash.Hash,
+	[]byte, uint32, uint32) (*chainntnfs.ConfirmationEvent, error) {
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	event := chainntnfs.NewConfirmationEvent(1, m.cancel)
+	m.confEvents = append(m.confEvents, event)
+
+	return event, nil
+}
+
+func (m *mockFailoverNotifier) RegisterSpendNtfn(*wire.OutPoint, []byte,
+	uint32) (*chainntnfs.SpendEvent, error) {
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	event := chainntnfs.NewSpendEvent(m.cancel)
+	m.spendEvents = append(m.spendEvents, event)
+
+	return event, nil
+}
+
+func (m *mockFailoverNotifier) RegisterBlockEpochNtfn(
+	bestBlock *chainntnfs.BlockEpoch) (*chainntnfs.BlockEpochEvent, error) {
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	epochs := make(chan *chainntnfs.BlockEpoch, 10)
+	m.epochChans = append(m.epochChans, epochs)
+	m.bestBlocks = append(m.bestBlocks, bestBlock)
+
+	return &chainntnfs.BlockEpochEvent{
+		Epochs: epochs,
+		Cancel: func() {},
+	}, nil
+}
+
+// Start starts the notifier. Like the notifiers of the chain backends, a
+// stopped notifier can't be started again.
+func (m *mockFailoverNotifier) Start() error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if m.stopped {
+		return errors.New("notifier already stopped")
+	}
+
+	m.started = true
+	return nil
+}
+
+func (m *mockFailoverNotifier) Started() bool {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	return m.started
+}
+
+func (m *mockFailoverNotifier) Stop() error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.stopped = true
+	return nil
+}
+
+func (m *mockFailoverNotifier) Stopped() bool {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	return m.stopped
+}
+
+// TestFailoverNotifier checks that outstanding registrations are moved to a
+// healthy backend once the active backend fails its health check, and that
+// clients don't receive the same notification twice.
+func TestFailoverNotifier(t *testing.T) {
+	t.Parallel()
+
+	var (
+		primary       = &mockFailoverNotifier{}
+		backup        = &mockFailoverNotifier{}
+		primaryHealth error
+	)
+	notifier := chainntnfs.NewFailoverNotifier(
+		&chainntnfs.FailoverBackend{
+			Name: "primary",
+			Connect: func() (chainntnfs.ChainNotifier, func(),
+				error) {
+
+				return primary, func() {}, nil
+			},
+			HealthCheck: func() error {
+				return primaryHealth
+			},
+		},
+		&chainntnfs.FailoverBackend{
+			Name: "backup",
+			Connect: func() (chainntnfs.ChainNotifier, func(),
+				error) {
+
+				return backup, func() {}, nil
+			},
+			HealthCheck: func() error { return nil },
+		},
+	)
+	if err := notifier.Start(); err != nil {
+		t.Fatalf("unable to start notifier: %v", err)
+	}
+	defer notifier.Stop()
+
+	if !primary.Started() || backup.Started() {
+		t.Fatalf("expected only the primary backend to be started")
+	}
+
+	confEvent, err := notifier.RegisterConfirmationsNtfn(
+		&chainhash.Hash{1}, nil, 1, 1,
+	)
+	if err != nil {
+		t.Fatalf("unable to register conf ntfn: %v", err)
+	}
+	spendEvent, err := notifier.RegisterSpendNtfn(&wire.OutPoint{}, nil, 1)
+	if err != nil {
+		t.Fatalf("unable to register spend ntfn: %v", err)
+	}
+	epochEvent, err := notifier.RegisterBlockEpochNtfn(nil)
+	if err != nil {
+		t.Fatalf("unable to register epoch ntfn: %v", err)
+	}
+
+	// Deliver a block through the primary backend.
+	block1 := &chainntnfs.BlockEpoch{Hash: &chainhash.Hash{1}, Height: 1}
+	primary.epochChans[0] <- block1
+	select {
+	case epoch := <-epochEvent.Epochs:
+		if epoch != block1 {
+			t.Fatalf("expected block %v, got %v", block1, epoch)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("block not delivered")
+	}
+
+	// A healthy primary shouldn't trigger a failover.
+	if err := notifier.CheckHealth(); err != nil {
+		t.Fatalf("unexpected health check error: %v", err)
+	}
+	if notifier.ActiveBackend() != "primary" {
+		t.Fatalf("expected primary to be active")
+	}
+
+	// Once the primary fails its health check, we expect all
+	// registrations to be moved to the backup.
+	primaryHealth = errors.New("unreachable")
+	if err := notifier.CheckHealth(); err != nil {
+		t.Fatalf("unexpected health check error: %v", err)
+	}
+	if notifier.ActiveBackend() != "backup" {
+		t.Fatalf("expected backup to be active")
+	}
+	if !backup.Started() {
+		t.Fatalf("expected backup to be started")
+	}
+
+	backup.mtx.Lock()
+	if len(backup.confEvents) != 1 || len(backup.spendEvents) != 1 ||
+		len(backup.epochChans) != 1 {
+
+		backup.mtx.Unlock()
+		t.Fatalf("expected registrations to be moved to backup")
+	}
+	if backup.bestBlocks[0] != block1 {
+		backup.mtx.Unlock()
+		t.Fatalf("expected epoch registration to resume from %v",
+			block1)
+	}
+	backup.mtx.Unlock()
+
+	// The backup re-delivers the last block the client already knows
+	// about, followed by a new one. Only the new one should be delivered.
+	block2 := &chainntnfs.BlockEpoch{Hash: &chainhash.Hash{2}, Height: 2}
+	backup.epochChans[0] <- block1
+	backup.epochChans[0] <- block2
+	select {
+	case epoch := <-epochEvent.Epochs:
+		if epoch != block2 {
+			t.Fatalf("expected block %v, got %v", block2, epoch)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("block not delivered")
+	}
+
+	// Notifications dispatched by the backup should reach the client.
+	conf := &chainntnfs.TxConfirmation{BlockHeight: 2}
+	backup.confEvents[0].Confirmed <- conf
+	select {
+	case c := <-confEvent.Confirmed:
+		if c != conf {
+			t.Fatalf("expected conf %v, got %v", conf, c)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("confirmation not delivered")
+	}
+
+	spend := &chainntnfs.SpendDetail{SpenderTxHash: &chainhash.Hash{3}}
+	backup.spendEvents[0].Spend <- spend
+	select {
+	case s := <-spendEvent.Spend:
+		if s != spend {
+			t.Fatalf("expected spend %v, got %v", spend, s)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("spend not delivered")
+	}
+
+	// If no backend is healthy, the health check should fail.
+	notifier2 := chainntnfs.NewFailoverNotifier(
+		&chainntnfs.FailoverBackend{
+			Name: "primary",
+			Connect: func() (chainntnfs.ChainNotifier, func(),
+				error) {
+
+				return &mockFailoverNotifier{}, func() {}, nil
+			},
+			HealthCheck: func() error {
+				return errors.New("unreachable")
+			},
+		},
+	)
+	if err := notifier2.Start(); err != nil {
+		t.Fatalf("unable to start notifier: %v", err)
+	}
+	defer notifier2.Stop()
+
+	err = notifier2.CheckHealth()
+	if !errors.Is(err, chainntnfs.ErrNoHealthyBackend) {
+		t.Fatalf("expected ErrNoHealthyBackend, got %v", err)
+	}
+}
+
+// waitFor polls the condition until it holds, failing the test if it doesn't
+// within a second.
+func waitFor(t *testing.T, cond func() bool, msg string) {
+	t.Helper()
+
+	for start := time.Now(); !cond(); time.Sleep(10 * time.Millisecond) {
+		if time.Since(start) > time.Second {
+			t.Fatalf(msg)
+		}
+	}
+}
+
+// TestFailoverNotifierCleanup checks that completed registrations aren't moved
+// to the next backend, that the previously active backend is stopped after a
+// failover, and that an upstream event that is never handed over is canceled
+// once the notifier stops.
+func TestFailoverNotifierCleanup(t *testing.T) {
+	t.Parallel()
+
+	var (
+		primary       = &mockFailoverNotifier{}
+		backup        = &mockFailoverNotifier{}
+		primaryHealth error
+	)
+	notifier := chainntnfs.NewFailoverNotifier(
+		&chainntnfs.FailoverBackend{
+			Name: "primary",
+			Connect: func() (chainntnfs.ChainNotifier, func(),
+				error) {
+
+				return primary, func() {}, nil
+			},
+			HealthCheck: func() error {
+				return primaryHealth
+			},
+		},
+		&chainntnfs.FailoverBackend{
+			Name: "backup",
+			Connect: func() (chainntnfs.ChainNotifier, func(),
+				error) {
+
+				return backup, func() {}, nil
+			},
+			HealthCheck: func() error { return nil },
+		},
+	)
+	if err := notifier.Start(); err != nil {
+		t.Fatalf("unable to start notifier: %v", err)
+	}
+
+	confEvent, err := notifier.RegisterConfirmationsNtfn(
+		&chainhash.Hash{1}, nil, 1, 1,
+	)
+	if err != nil {
+		t.Fatalf("unable to register conf ntfn: %v", err)
+	}
+	_, err = notifier.RegisterSpendNtfn(&wire.OutPoint{}, nil, 1)
+	if err != nil {
+		t.Fatalf("unable to register spend ntfn: %v", err)
+	}
+
+	// Once the confirmation registration is done, it should be removed
+	// and its upstream event canceled.
+	primary.confEvents[0].Done <- struct{}{}
+	select {
+	case <-confEvent.Done:
+	case <-time.After(time.Second):
+		t.Fatalf("done not delivered")
+	}
+	waitFor(t, func() bool {
+		return primary.numCanceled() == 1
+	}, "upstream conf event not canceled")
+
+	// Dispatch two spends the client doesn't read, so the forwarding
+	// goroutine of the spend registration blocks on the second one.
+	upstreamSpend := primary.spendEvents[0].Spend
+	upstreamSpend <- &chainntnfs.SpendDetail{
+		SpenderTxHash: &chainhash.Hash{1},
+	}
+	waitFor(t, func() bool {
+		return len(upstreamSpend) == 0
+	}, "first spend not forwarded")
+	upstreamSpend <- &chainntnfs.SpendDetail{
+		SpenderTxHash: &chainhash.Hash{2},
+	}
+	waitFor(t, func() bool {
+		return len(upstreamSpend) == 0
+	}, "second spend not received")
+
+	primaryHealth = errors.New("unreachable")
+	if err := notifier.CheckHealth(); err != nil {
+		t.Fatalf("unexpected health check error: %v", err)
+	}
+	if !primary.Stopped() {
+		t.Fatalf("expected primary to be stopped after failover")
+	}
+
+	backup.mtx.Lock()
+	numConfs, numSpends := len(backup.confEvents), len(backup.spendEvents)
+	backup.mtx.Unlock()
+	if numConfs != 0 {
+		t.Fatalf("expected completed conf registration to be removed")
+	}
+	if numSpends != 1 {
+		t.Fatalf("expected spend registration to be moved to backup")
+	}
+
+	// The new upstream spend event can't be handed over, as the
+	// forwarding goroutine is still blocked on the client. Stopping the
+	// notifier must not wait for it, and cancel the upstream event.
+	stopped := make(chan error)
+	go func() {
+		stopped <- notifier.Stop()
+	}()
+	select {
+	case err := <-stopped:
+		if err != nil {
+			t.Fatalf("unable to stop notifier: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("notifier didn't stop")
+	}
+
+	if backup.numCanceled() != 1 {
+		t.Fatalf("expected upstream spend event of backup to be " +
+			"canceled")
+	}
+	if !backup.Stopped() {
+		t.Fatalf("expected backup to be stopped")
+	}
+}
+
+// mockFailoverBackend is a chain backend that creates a new mock notifier each
+// time it is connected to, and records whether its connections are closed.
+type mockFailoverBackend struct {
+	mtx         sync.Mutex
+	notifiers   []*mockFailoverNotifier
+	connections int
+	health      error
+}
+
+func (b *mockFailoverBackend) connect() (chainntnfs.ChainNotifier, func(),
+	error) {
+
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	notifier := &mockFailoverNotifier{}
+	b.notifiers = append(b.notifiers, notifier)
+	b.connections++
+
+	return notifier, func() {
+		b.mtx.Lock()
+		defer b.mtx.Unlock()
+
+		b.connections--
+	}, nil
+}
+
+func (b *mockFailoverBackend) healthCheck() error {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	return b.health
+}
+
+func (b *mockFailoverBackend) setHealth(err error) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	b.health = err
+}
+
+// lastNotifier returns the notifier the backend was last connected with, and
+// the number of its open connections.
+func (b *mockFailoverBackend) lastNotifier() (*mockFailoverNotifier, int) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	return b.notifiers[len(b.notifiers)-1], b.connections
+}
+
+// TestFailoverNotifierFailBack checks that a backend that was failed over from
+// is connected to with a new notifier when failing back to it, that the
+// connection of the backend that was failed over from is closed, and that
+// blocks are delivered after failing back.
+func TestFailoverNotifierFailBack(t *testing.T) {
+	t.Parallel()
+
+	primary := &mockFailoverBackend{}
+	backup := &mockFailoverBackend{}
+	notifier := chainntnfs.NewFailoverNotifier(
+		&chainntnfs.FailoverBackend{
+			Name:        "primary",
+			Connect:     primary.connect,
+			HealthCheck: primary.healthCheck,
+		},
+		&chainntnfs.FailoverBackend{
+			Name:        "backup",
+			Connect:     backup.connect,
+			HealthCheck: backup.healthCheck,
+		},
+	)
+	if err := notifier.Start(); err != nil {
+		t.Fatalf("unable to start notifier: %v", err)
+	}
+
+	epochEvent, err := notifier.RegisterBlockEpochNtfn(nil)
+	if err != nil {
+		t.Fatalf("unable to register epoch ntfn: %v", err)
+	}
+
+	// Fail over to the backup.
+	primary.setHealth(errors.New("unreachable"))
+	if err := notifier.CheckHealth(); err != nil {
+		t.Fatalf("unexpected health check error: %v", err)
+	}
+	if notifier.ActiveBackend() != "backup" {
+		t.Fatalf("expected backup to be active")
+	}
+
+	firstPrimary, primaryConns := primary.lastNotifier()
+	if !firstPrimary.Stopped() || primaryConns != 0 {
+		t.Fatalf("expected primary to be stopped and disconnected")
+	}
+
+	// Once the primary recovers and the backup fails, we expect to fail
+	// back to the primary with a new notifier.
+	primary.setHealth(nil)
+	backup.setHealth(errors.New("unreachable"))
+	if err := notifier.CheckHealth(); err != nil {
+		t.Fatalf("unexpected health check error: %v", err)
+	}
+	if notifier.ActiveBackend() != "primary" {
+		t.Fatalf("expected primary to be active")
+	}
+
+	backupNotifier, backupConns := backup.lastNotifier()
+	if !backupNotifier.Stopped() || backupConns != 0 {
+		t.Fatalf("expected backup to be stopped and disconnected")
+	}
+
+	secondPrimary, primaryConns := primary.lastNotifier()
+	if secondPrimary == firstPrimary {
+		t.Fatalf("expected a new notifier for the primary")
+	}
+	if !secondPrimary.Started() || primaryConns != 1 {
+		t.Fatalf("expected primary to be started and connected")
+	}
+
+	// A block dispatched by the new notifier of the primary should reach
+	// the client.
+	secondPrimary.mtx.Lock()
+	if len(secondPrimary.epochChans) != 1 {
+		secondPrimary.mtx.Unlock()
+		t.Fatalf("expected epoch registration to be moved to primary")
+	}
+	epochs := secondPrimary.epochChans[0]
+	secondPrimary.mtx.Unlock()
+
+	block := &chainntnfs.BlockEpoch{Hash: &chainhash.Hash{1}, Height: 1}
+	epochs <- block
+	select {
+	case epoch := <-epochEvent.Epochs:
+		if epoch != block {
+			t.Fatalf("expected block %v, got %v", block, epoch)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("block not delivered")
+	}
+
+	if err := notifier.Stop(); err != nil {
+		t.Fatalf("unable to stop notifier: %v", err)
+	}
+	if _, primaryConns := primary.lastNotifier(); primaryConns != 0 {
+		t.Fatalf("expected primary to be disconnected on stop")
+	}
+}
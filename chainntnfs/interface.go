@@ -149,6 +149,30 @@ type ChainNotifier interface {
 	Stop() error
 }
 
+// NotifierStats houses the counters of the confirmation registrations handled
+// by a ChainNotifier.
+type NotifierStats struct {
+	// ConfRegistrations is the total number of confirmation notifications
+	// registered.
+	ConfRegistrations uint64
+
+	// SharedConfRegistrations is the number of confirmation notifications
+	// that shared the backend subscription of a prior registration for
+	// the same txid/output script.
+	SharedConfRegistrations uint64
+
+	// HistoricalConfDispatches is the number of historical rescans
+	// dispatched for confirmation notifications.
+	HistoricalConfDispatches uint64
+}
+
+// StatsReporter is implemented by ChainNotifiers that keep track of the
+// notifications registered with them.
+type StatsReporter interface {
+	// Stats returns the current counters of the notifier.
+	Stats() NotifierStats
+}
+
 // TxConfirmation carries some additional block-level details of the exact
 // block that specified transactions was confirmed within.
 type TxConfirmation struct {
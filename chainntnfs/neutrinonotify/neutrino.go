@@ -80,6 +80,9 @@ type NeutrinoNotifier struct {
 // Ensure NeutrinoNotifier implements the ChainNotifier interface at compile time.
 var _ chainntnfs.ChainNotifier = (*NeutrinoNotifier)(nil)
 
+// Ensure NeutrinoNotifier implements the StatsReporter interface at compile time.
+var _ chainntnfs.StatsReporter = (*NeutrinoNotifier)(nil)
+
 // New creates a new instance of the NeutrinoNotifier concrete implementation
 // of the ChainNotifier interface.
 //
@@ -839,6 +842,14 @@ func (n *NeutrinoNotifier) RegisterConfirmationsNtfn(txid *chainhash.Hash,
 		return nil, err
 	}
 
+	// If the same txid/output script is already being watched for, our
+	// filter already matches it, so there's no need to update and rewind
+	// the rescan again. This is common as callers often register for
+	// different numbers of confirmations of the same transaction.
+	if ntfn.Shared && ntfn.HistoricalDispatch == nil {
+		return ntfn.Event, nil
+	}
+
 	// To determine whether this transaction has confirmed on-chain, we'll
 	// update our filter to watch for the transaction at tip and we'll also
 	// dispatch a historical rescan to determine if it has confirmed in the
@@ -896,6 +907,14 @@ func (n *NeutrinoNotifier) RegisterConfirmationsNtfn(txid *chainhash.Hash,
 	return ntfn.Event, nil
 }
 
+// Stats returns the counters of the confirmation notifications registered
+// with the notifier.
+//
+// NOTE: This is part of the chainntnfs.StatsReporter interface.
+func (n *NeutrinoNotifier) Stats() chainntnfs.NotifierStats {
+	return n.txNotifier.Stats()
+}
+
 // blockEpochRegistration represents a client's intent to receive a
 // notification with each newly connected block.
 type blockEpochRegistration struct {
@@ -288,6 +288,12 @@ type ConfRegistration struct {
 	// notification was registered. This can be used so that backends can
 	// request to be notified of confirmations from this point forwards.
 	Height uint32
+
+	// Shared is true if another notification for the same txid/output
+	// script was already registered and is still being tracked. In that
+	// case, the backend is already watching for the confirmation, so it
+	// doesn't need to be subscribed to it again.
+	Shared bool
 }
 
 // SpendRequest encapsulates a request for a spend notification of either an
@@ -459,6 +465,12 @@ type TxNotifier struct {
 	confClientCounter  uint64 // To be used atomically.
 	spendClientCounter uint64 // To be used atomically.
 
+	// The following counters track the confirmation registrations handled
+	// by the TxNotifier, and are exposed through Stats.
+	numConfRegistrations        uint64 // To be used atomically.
+	numSharedConfRegistrations  uint64 // To be used atomically.
+	numHistoricalConfDispatches uint64 // To be used atomically.
+
 	// currentHeight is the height of the tracked blockchain. It is used to
 	// determine the number of confirmations a tx has and ensure blocks are
 	// connected and disconnected in order.
@@ -630,12 +642,19 @@ func (n *TxNotifier) RegisterConf(txid *chainhash.Hash, pkScript []byte,
 	n.Lock()
 	defer n.Unlock()
 
-	confSet, ok := n.confNotifications[ntfn.ConfRequest]
-	if !ok {
+	atomic.AddUint64(&n.numConfRegistrations, 1)
+
+	confSet, shared := n.confNotifications[ntfn.ConfRequest]
+	if !shared {
 		// If this is the first registration for this request, construct
 		// a confSet to coalesce all notifications for the same request.
 		confSet = newConfNtfnSet()
 		n.confNotifications[ntfn.ConfRequest] = confSet
+	} else {
+		Log.Debugf("Sharing existing confirmation subscription for %v",
+			ntfn.ConfRequest)
+
+		atomic.AddUint64(&n.numSharedConfRegistrations, 1)
 	}
 	confSet.ntfns[ntfn.ConfID] = ntfn
 
@@ -660,6 +679,7 @@ func (n *TxNotifier) RegisterConf(txid *chainhash.Hash, pkScript []byte,
 			Event:              ntfn.Event,
 			HistoricalDispatch: nil,
 			Height:             n.currentHeight,
+			Shared:             shared,
 		}, nil
 
 	// A rescan is already in progress, return here to prevent dispatching
@@ -673,6 +693,7 @@ func (n *TxNotifier) RegisterConf(txid *chainhash.Hash, pkScript []byte,
 			Event:              ntfn.Event,
 			HistoricalDispatch: nil,
 			Height:             n.currentHeight,
+			Shared:             shared,
 		}, nil
 
 	// If no rescan has been dispatched, attempt to do so now.
@@ -696,6 +717,7 @@ func (n *TxNotifier) RegisterConf(txid *chainhash.Hash, pkScript []byte,
 			Event:              ntfn.Event,
 			HistoricalDispatch: nil,
 			Height:             n.currentHeight,
+			Shared:             shared,
 		}, nil
 	}
 
@@ -715,14 +737,30 @@ func (n *TxNotifier) RegisterConf(txid *chainhash.Hash, pkScript []byte,
 	// Set this confSet's status to pending, ensuring subsequent
 	// registrations don't also attempt a dispatch.
 	confSet.rescanStatus = rescanPending
+	atomic.AddUint64(&n.numHistoricalConfDispatches, 1)
 
 	return &ConfRegistration{
 		Event:              ntfn.Event,
 		HistoricalDispatch: dispatch,
 		Height:             n.currentHeight,
+		Shared:             shared,
 	}, nil
 }
 
+// Stats returns the counters of the confirmation registrations handled by the
+// TxNotifier.
+func (n *TxNotifier) Stats() NotifierStats {
+	return NotifierStats{
+		ConfRegistrations: atomic.LoadUint64(&n.numConfRegistrations),
+		SharedConfRegistrations: atomic.LoadUint64(
+			&n.numSharedConfRegistrations,
+		),
+		HistoricalConfDispatches: atomic.LoadUint64(
+			&n.numHistoricalConfDispatches,
+		),
+	}
+}
+
 // CancelConf cancels an existing request for a spend notification of an
 // outpoint/output script. The request is identified by its spend ID.
 func (n *TxNotifier) CancelConf(confRequest ConfRequest, confID uint64) {
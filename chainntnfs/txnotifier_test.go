@@ -877,10 +877,14 @@ func TestTxNotifierMultipleHistoricalConfRescans(t *testing.T) {
 	if ntfn1.HistoricalDispatch == nil {
 		t.Fatal("expected to receive historical dispatch request")
 	}
+	if ntfn1.Shared {
+		t.Fatal("expected first registration to not be shared")
+	}
 
 	// We'll register another confirmation notification for the same
 	// transaction. This should not request a historical confirmation rescan
-	// since the first one is still pending.
+	// since the first one is still pending, and should share the
+	// subscription of the first one.
 	ntfn2, err := n.RegisterConf(&chainntnfs.ZeroHash, testRawScript, 1, 1)
 	if err != nil {
 		t.Fatalf("unable to register spend ntfn: %v", err)
@@ -888,6 +892,9 @@ func TestTxNotifierMultipleHistoricalConfRescans(t *testing.T) {
 	if ntfn2.HistoricalDispatch != nil {
 		t.Fatal("received unexpected historical rescan request")
 	}
+	if !ntfn2.Shared {
+		t.Fatal("expected second registration to be shared")
+	}
 
 	// Finally, we'll mark the ongoing historical rescan as complete and
 	// register another notification. We should also expect not to see a
@@ -908,6 +915,20 @@ func TestTxNotifierMultipleHistoricalConfRescans(t *testing.T) {
 	if ntfn3.HistoricalDispatch != nil {
 		t.Fatal("received unexpected historical rescan request")
 	}
+	if !ntfn3.Shared {
+		t.Fatal("expected third registration to be shared")
+	}
+
+	// The stats of the notifier should reflect all three registrations,
+	// of which only the first one dispatched a historical rescan.
+	expectedStats := chainntnfs.NotifierStats{
+		ConfRegistrations:        3,
+		SharedConfRegistrations:  2,
+		HistoricalConfDispatches: 1,
+	}
+	if stats := n.Stats(); stats != expectedStats {
+		t.Fatalf("expected stats %v, got %v", expectedStats, stats)
+	}
 }
 
 // TestTxNotifierMultipleHistoricalRescans ensures that we don't attempt to
@@ -101,6 +101,7 @@ func ApplyLitecoinParams(params *BitcoinNetParams,
 	params.Net = bitcoinWire.BitcoinNet(litecoinParams.Net)
 	params.DefaultPort = litecoinParams.DefaultPort
 	params.CoinbaseMaturity = litecoinParams.CoinbaseMaturity
+	params.TargetTimePerBlock = litecoinParams.TargetTimePerBlock
 
 	copy(params.GenesisHash[:], litecoinParams.GenesisHash[:])
 
@@ -0,0 +1,68 @@
+package chainreg
+
+import (
+	"github.com/btcsuite/btcutil"
+	"github.com/cryptomeow/lnd/channeldb"
+	"github.com/cryptomeow/lnd/input"
+	"github.com/cryptomeow/lnd/lnwallet"
+)
+
+const (
+	// MaxBtcFundingAmount is a soft-limit of the maximum channel size
+	// currently accepted on the Bitcoin chain within the Lightning
+	// Protocol. This limit is defined in BOLT-0002, and serves as an
+	// initial precautionary limit while implementations are battle tested
+	// in the real world.
+	MaxBtcFundingAmount = btcutil.Amount(1<<24) - 1
+
+	// MaxLtcFundingAmount is a soft-limit of the maximum channel size
+	// currently accepted on the Litecoin chain within the Lightning
+	// Protocol.
+	MaxLtcFundingAmount = MaxBtcFundingAmount * BtcToLtcConversionRate
+)
+
+// ChainPolicy houses the chain specific values that are used when funding
+// channels on a chain.
+type ChainPolicy struct {
+	// DustLimit is the dust limit we use for our commitment transactions.
+	DustLimit btcutil.Amount
+
+	// MinRemoteDelay and MaxRemoteDelay are the extremes of the CSV delay
+	// we will require the remote to use for its commitment transaction.
+	// The actual delay we will require will be somewhere between these
+	// values, depending on channel size.
+	MinRemoteDelay uint16
+	MaxRemoteDelay uint16
+
+	// MaxFundingAmount is a soft-limit of the maximum size of non-wumbo
+	// channels.
+	MaxFundingAmount btcutil.Amount
+}
+
+// ChannelConstraints returns the default set of channel constraints that are
+// meant to be used when initially funding a channel on the chain.
+func (p ChainPolicy) ChannelConstraints() channeldb.ChannelConstraints {
+	return channeldb.ChannelConstraints{
+		DustLimit:        p.DustLimit,
+		MaxAcceptedHtlcs: input.MaxHTLCNumber / 2,
+	}
+}
+
+// ChainPolicies is a map of a chain to the policy used when funding channels
+// on that chain. Every chain that can be registered as the primary chain must
+// have an entry.
+var ChainPolicies = map[ChainCode]ChainPolicy{
+	BitcoinChain: {
+		DustLimit:        lnwallet.DefaultDustLimit(),
+		MinRemoteDelay:   144,
+		MaxRemoteDelay:   2016,
+		MaxFundingAmount: MaxBtcFundingAmount,
+	},
+
+	LitecoinChain: {
+		DustLimit:        DefaultLitecoinDustLimit,
+		MinRemoteDelay:   576,
+		MaxRemoteDelay:   8064,
+		MaxFundingAmount: MaxLtcFundingAmount,
+	},
+}
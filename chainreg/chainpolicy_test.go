@@ -0,0 +1,39 @@
+package chainreg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestChainPolicies asserts that every chain that can be registered has a
+// sane funding policy.
+func TestChainPolicies(t *testing.T) {
+	t.Parallel()
+
+	for _, chain := range []ChainCode{BitcoinChain, LitecoinChain} {
+		policy, ok := ChainPolicies[chain]
+		require.True(t, ok, "no policy for %v", chain)
+
+		require.NotZero(t, policy.DustLimit)
+		require.NotZero(t, policy.MinRemoteDelay)
+		require.LessOrEqual(
+			t, policy.MinRemoteDelay, policy.MaxRemoteDelay,
+		)
+		require.NotZero(t, policy.MaxFundingAmount)
+
+		constraints := policy.ChannelConstraints()
+		require.Equal(t, policy.DustLimit, constraints.DustLimit)
+	}
+
+	// The channel constraints of the chains are derived from their
+	// policies.
+	require.Equal(
+		t, ChainPolicies[LitecoinChain].ChannelConstraints(),
+		DefaultLtcChannelConstraints,
+	)
+	require.Equal(
+		t, MaxBtcFundingAmount*BtcToLtcConversionRate,
+		ChainPolicies[LitecoinChain].MaxFundingAmount,
+	)
+}
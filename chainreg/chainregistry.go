@@ -18,6 +18,7 @@ import (
 	"github.com/btcsuite/btcwallet/chain"
 	"github.com/btcsuite/btcwallet/wallet"
 	"github.com/lightninglabs/neutrino"
+	"github.com/cryptomeow/lnd/blockfetch"
 	"github.com/cryptomeow/lnd/chainntnfs"
 	"github.com/cryptomeow/lnd/chainntnfs/bitcoindnotify"
 	"github.com/cryptomeow/lnd/chainntnfs/btcdnotify"
@@ -30,6 +31,7 @@ import (
 	"github.com/cryptomeow/lnd/lnwallet"
 	"github.com/cryptomeow/lnd/lnwallet/btcwallet"
 	"github.com/cryptomeow/lnd/lnwallet/chainfee"
+	"github.com/cryptomeow/lnd/lnwallet/chanfunding"
 	"github.com/cryptomeow/lnd/lnwire"
 	"github.com/cryptomeow/lnd/routing/chainview"
 )
@@ -100,6 +102,24 @@ type Config struct {
 	// FeeURL defines the URL for fee estimation we will use. This field is
 	// optional.
 	FeeURL string
+
+	// FeeURLType is the name of the registered chainfee.EstimatorDriver
+	// that is used to query the FeeURL.
+	FeeURLType string
+
+	// FeeURLMaxRate is the highest fee rate we accept from the FeeURL.
+	// A value of zero disables this bound.
+	FeeURLMaxRate chainfee.SatPerKWeight
+
+	// CoinSelectionStrategy is the default strategy the wallet uses to
+	// order its coins when funding a channel.
+	CoinSelectionStrategy chanfunding.CoinSelectionStrategy
+
+	// NotifierOnly, if true, only sets up the ChainNotifier of the chain
+	// backend, without the wallet, signer and fee estimator. The height
+	// hints of the notifier aren't persisted then, so nothing is written
+	// to the channel databases.
+	NotifierOnly bool
 }
 
 const (
@@ -145,6 +165,12 @@ const (
 	// static estimators.
 	DefaultBitcoinStaticMinRelayFeeRate = chainfee.FeePerKwFloor
 
+	// DefaultSigNetStaticFeePerKW is the fee rate of 2 sat/vbyte expressed
+	// in sat/kw. It is used on signet instead of the bitcoin default, as
+	// signet blocks are rarely full and fee estimates are mostly
+	// unavailable.
+	DefaultSigNetStaticFeePerKW = chainfee.SatPerKWeight(500)
+
 	// DefaultLitecoinStaticFeePerKW is the fee rate of 200 sat/vbyte
 	// expressed in sat/kw.
 	DefaultLitecoinStaticFeePerKW = chainfee.SatPerKWeight(50000)
@@ -158,17 +184,11 @@ const (
 // meant to be used when initially funding a Bitcoin channel.
 //
 // TODO(halseth): make configurable at startup?
-var DefaultBtcChannelConstraints = channeldb.ChannelConstraints{
-	DustLimit:        lnwallet.DefaultDustLimit(),
-	MaxAcceptedHtlcs: input.MaxHTLCNumber / 2,
-}
+var DefaultBtcChannelConstraints = ChainPolicies[BitcoinChain].ChannelConstraints()
 
 // DefaultLtcChannelConstraints is the default set of channel constraints that are
 // meant to be used when initially funding a Litecoin channel.
-var DefaultLtcChannelConstraints = channeldb.ChannelConstraints{
-	DustLimit:        DefaultLitecoinDustLimit,
-	MaxAcceptedHtlcs: input.MaxHTLCNumber / 2,
-}
+var DefaultLtcChannelConstraints = ChainPolicies[LitecoinChain].ChannelConstraints()
 
 // ChainControl couples the three primary interfaces lnd utilizes for a
 // particular chain together. A single ChainControl instance will exist for all
@@ -186,6 +206,10 @@ type ChainControl struct {
 	// KeyRing represents a set of keys that we have the private keys to.
 	KeyRing keychain.SecretKeyRing
 
+	// KeyFamilyRegistry keeps track of the key families reserved by
+	// external applications deriving keys from our seed.
+	KeyFamilyRegistry keychain.KeyFamilyRegistry
+
 	// Wc is an abstraction over some basic wallet commands. This base set of commands
 	// will be provided to the Wallet *LightningWallet raw pointer below.
 	Wc lnwallet.WalletController
@@ -229,6 +253,10 @@ func NewChainControl(cfg *Config) (*ChainControl, error) {
 
 	cc := &ChainControl{}
 
+	// prunedBlockFetcher is set if the chain backend is a pruned node, to
+	// retrieve the historical blocks it no longer stores.
+	var prunedBlockFetcher *blockfetch.Fetcher
+
 	switch cfg.PrimaryChain() {
 	case BitcoinChain:
 		cc.RoutingPolicy = htlcswitch.ForwardingPolicy{
@@ -242,6 +270,12 @@ func NewChainControl(cfg *Config) (*ChainControl, error) {
 			DefaultBitcoinStaticFeePerKW,
 			DefaultBitcoinStaticMinRelayFeeRate,
 		)
+		if cfg.Bitcoin.SigNet {
+			cc.FeeEstimator = chainfee.NewStaticEstimator(
+				DefaultSigNetStaticFeePerKW,
+				DefaultBitcoinStaticMinRelayFeeRate,
+			)
+		}
 	case LitecoinChain:
 		cc.RoutingPolicy = htlcswitch.ForwardingPolicy{
 			MinHTLCOut:    cfg.Litecoin.MinHTLCOut,
@@ -278,13 +312,17 @@ func NewChainControl(cfg *Config) (*ChainControl, error) {
 		log.Infof("Height Hint Cache Queries disabled")
 	}
 
-	// Initialize the height hint cache within the chain directory.
-	hintCache, err := chainntnfs.NewHeightHintCache(
-		heightHintCacheConfig, cfg.LocalChanDB,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("unable to initialize height hint "+
-			"cache: %v", err)
+	// Initialize the height hint cache within the chain directory, unless
+	// nothing may be written to it.
+	var hintCache notifierHintCache = noopHintCache{}
+	if !cfg.NotifierOnly {
+		hintCache, err = chainntnfs.NewHeightHintCache(
+			heightHintCacheConfig, cfg.LocalChanDB,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to initialize height "+
+				"hint cache: %v", err)
+		}
 	}
 
 	// If spv mode is active, then we'll be using a distinct set of
@@ -366,12 +404,16 @@ func NewChainControl(cfg *Config) (*ChainControl, error) {
 
 		// Establish the connection to bitcoind and create the clients
 		// required for our relevant subsystems.
-		bitcoindConn, err := chain.NewBitcoindConn(
-			cfg.ActiveNetParams.Params, bitcoindHost,
-			bitcoindMode.RPCUser, bitcoindMode.RPCPass,
-			bitcoindMode.ZMQPubRawBlock, bitcoindMode.ZMQPubRawTx,
-			5*time.Second,
-		)
+		bitcoindCfg := &chain.BitcoindConfig{
+			ChainParams:     cfg.ActiveNetParams.Params,
+			Host:            bitcoindHost,
+			User:            bitcoindMode.RPCUser,
+			Pass:            bitcoindMode.RPCPass,
+			ZMQBlockHost:    bitcoindMode.ZMQPubRawBlock,
+			ZMQTxHost:       bitcoindMode.ZMQPubRawTx,
+			ZMQReadDeadline: 5 * time.Second,
+		}
+		bitcoindConn, err := chain.NewBitcoindConn(bitcoindCfg)
 		if err != nil {
 			return nil, err
 		}
@@ -381,14 +423,6 @@ func NewChainControl(cfg *Config) (*ChainControl, error) {
 				"%v", err)
 		}
 
-		cc.ChainNotifier = bitcoindnotify.New(
-			bitcoindConn, cfg.ActiveNetParams.Params, hintCache, hintCache,
-		)
-		cc.ChainView = chainview.NewBitcoindFilteredChainView(bitcoindConn)
-		walletConfig.ChainSource = bitcoindConn.NewBitcoindClient()
-
-		// If we're not in regtest mode, then we'll attempt to use a
-		// proper fee estimator for testnet.
 		rpcConfig := &rpcclient.ConnConfig{
 			Host:                 bitcoindHost,
 			User:                 bitcoindMode.RPCUser,
@@ -398,6 +432,42 @@ func NewChainControl(cfg *Config) (*ChainControl, error) {
 			DisableTLS:           true,
 			HTTPPostMode:         true,
 		}
+
+		// If the node is pruned, we'll fetch the historical blocks it
+		// no longer stores from its peers instead.
+		prunedBlockFetcher, err = newPrunedBlockFetcher(
+			cfg.ActiveNetParams.Params, *rpcConfig,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		// If any backup nodes are configured, we'll use a notifier
+		// that fails over to a backup once the primary node becomes
+		// unhealthy.
+		if len(bitcoindMode.Backups) > 0 {
+			cc.ChainNotifier, err = newBitcoindFailoverNotifier(
+				cfg.ActiveNetParams.Params, bitcoindMode,
+				bitcoindHost, bitcoindConn, prunedBlockFetcher,
+				hintCache,
+			)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			cc.ChainNotifier = bitcoindnotify.New(
+				bitcoindConn, cfg.ActiveNetParams.Params,
+				hintCache, hintCache, prunedBlockFetcher,
+			)
+		}
+
+		cc.ChainView = chainview.NewBitcoindFilteredChainView(
+			bitcoindConn, prunedBlockFetcher,
+		)
+		walletConfig.ChainSource = bitcoindConn.NewBitcoindClient()
+
+		// If we're not in regtest mode, then we'll attempt to use a
+		// proper fee estimator for testnet.
 		if cfg.Bitcoin.Active && !cfg.Bitcoin.RegTest {
 			log.Infof("Initializing bitcoind backed fee estimator in "+
 				"%s mode", bitcoindMode.EstimateMode)
@@ -405,11 +475,17 @@ func NewChainControl(cfg *Config) (*ChainControl, error) {
 			// Finally, we'll re-initialize the fee estimator, as
 			// if we're using bitcoind as a backend, then we can
 			// use live fee estimates, rather than a statically
-			// coded value.
-			fallBackFeeRate := chainfee.SatPerKVByte(25 * 1000)
+			// coded value. Fee estimates are mostly unavailable on
+			// signet, so we fall back to a lower fee rate there.
+			fallBackFeeRate := chainfee.SatPerKVByte(
+				25 * 1000,
+			).FeePerKWeight()
+			if cfg.Bitcoin.SigNet {
+				fallBackFeeRate = DefaultSigNetStaticFeePerKW
+			}
 			cc.FeeEstimator, err = chainfee.NewBitcoindEstimator(
 				*rpcConfig, bitcoindMode.EstimateMode,
-				fallBackFeeRate.FeePerKWeight(),
+				fallBackFeeRate,
 			)
 			if err != nil {
 				return nil, err
@@ -489,9 +565,20 @@ func NewChainControl(cfg *Config) (*ChainControl, error) {
 			DisableConnectOnNew:  true,
 			DisableAutoReconnect: false,
 		}
-		cc.ChainNotifier, err = btcdnotify.New(
-			rpcConfig, cfg.ActiveNetParams.Params, hintCache, hintCache,
-		)
+		// If any backup nodes are configured, we'll use a notifier
+		// that fails over to a backup once the primary node becomes
+		// unhealthy.
+		if len(btcdMode.BackupRPCHosts) > 0 {
+			cc.ChainNotifier, err = newBtcdFailoverNotifier(
+				cfg.ActiveNetParams, btcdMode.BackupRPCHosts,
+				*rpcConfig, hintCache,
+			)
+		} else {
+			cc.ChainNotifier, err = btcdnotify.New(
+				rpcConfig, cfg.ActiveNetParams.Params,
+				hintCache, hintCache,
+			)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -538,20 +625,43 @@ func NewChainControl(cfg *Config) (*ChainControl, error) {
 			homeChainConfig.Node)
 	}
 
+	// Without a wallet, nothing is signed or published, so we're done
+	// once the notifier is set up.
+	if cfg.NotifierOnly {
+		return cc, nil
+	}
+
+	// The fee estimator of our backend is kept, as it's still the source of
+	// the mempool state if it's overridden below.
+	backendEstimator := cc.FeeEstimator
+
 	// Override default fee estimator if an external service is specified.
 	if cfg.FeeURL != "" {
 		// Do not cache fees on regtest to make it easier to execute
 		// manual or automated test cases.
 		cacheFees := !cfg.Bitcoin.RegTest
 
-		log.Infof("Using external fee estimator %v: cached=%v",
-			cfg.FeeURL, cacheFees)
+		log.Infof("Using external fee estimator %v: type=%v, "+
+			"cached=%v", cfg.FeeURL, cfg.FeeURLType, cacheFees)
 
-		cc.FeeEstimator = chainfee.NewWebAPIEstimator(
-			chainfee.SparseConfFeeSource{
-				URL: cfg.FeeURL,
-			},
-			!cacheFees,
+		cc.FeeEstimator, err = chainfee.NewEstimator(
+			cfg.FeeURLType, cfg.FeeURL, !cacheFees,
+			cfg.FeeURLMaxRate,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// If the fee estimator of our backend is able to query the state of
+	// its mempool, we'll keep track of the mempool's fee floor, so we
+	// don't create transactions that won't be accepted into the mempool.
+	// This also applies to the estimates of an external service, which
+	// know nothing about the relay fee of our backend.
+	if source, ok := backendEstimator.(chainfee.MempoolInfoSource); ok {
+		cc.FeeEstimator = chainfee.NewMempoolMonitor(
+			cc.FeeEstimator, source,
+			chainfee.DefaultMempoolPollInterval,
 		)
 	}
 
@@ -569,18 +679,24 @@ func NewChainControl(cfg *Config) (*ChainControl, error) {
 	cc.MsgSigner = wc
 	cc.Signer = wc
 	cc.ChainIO = wc
+	if prunedBlockFetcher != nil {
+		cc.ChainIO = &prunedChainIO{
+			BlockChainIO: wc,
+			blockFetcher: prunedBlockFetcher,
+		}
+	}
 	cc.Wc = wc
 
 	// Select the default channel constraints for the primary chain.
-	channelConstraints := DefaultBtcChannelConstraints
-	if cfg.PrimaryChain() == LitecoinChain {
-		channelConstraints = DefaultLtcChannelConstraints
-	}
+	channelConstraints := ChainPolicies[cfg.PrimaryChain()].ChannelConstraints()
 
 	keyRing := keychain.NewBtcWalletKeyRing(
 		wc.InternalWallet(), cfg.ActiveNetParams.CoinType,
 	)
 	cc.KeyRing = keyRing
+	cc.KeyFamilyRegistry = keychain.NewWalletKeyFamilyRegistry(
+		wc.InternalWallet().Database(),
+	)
 
 	// Create, and start the lnwallet, which handles the core payment
 	// channel logic, and exposes control via proxy state machines.
@@ -594,6 +710,8 @@ func NewChainControl(cfg *Config) (*ChainControl, error) {
 		ChainIO:            cc.ChainIO,
 		DefaultConstraints: channelConstraints,
 		NetParams:          *cfg.ActiveNetParams.Params,
+
+		CoinSelectionStrategy: cfg.CoinSelectionStrategy,
 	}
 	lnWallet, err := lnwallet.NewLightningWallet(walletCfg)
 	if err != nil {
@@ -655,6 +773,8 @@ var (
 
 		BitcoinMainnetGenesis:  BitcoinChain,
 		LitecoinMainnetGenesis: LitecoinChain,
+
+		sigNetGenesisHash: BitcoinChain,
 	}
 
 	// ChainDNSSeeds is a map of a chain's hash to the set of DNS seeds
@@ -768,6 +888,11 @@ func (c *ChainRegistry) PrimaryChain() ChainCode {
 	return c.primaryChain
 }
 
+// PrimaryChainPolicy returns the funding policy of the primary chain.
+func (c *ChainRegistry) PrimaryChainPolicy() ChainPolicy {
+	return ChainPolicies[c.PrimaryChain()]
+}
+
 // ActiveChains returns a slice containing the active chains.
 func (c *ChainRegistry) ActiveChains() []ChainCode {
 	c.RLock()
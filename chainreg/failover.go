@@ -0,0 +1,235 @@
+package chainreg
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/rpcclient"
+	"github.com/btcsuite/btcwallet/chain"
+	"github.com/cryptomeow/lnd/blockfetch"
+	"github.com/cryptomeow/lnd/chainntnfs"
+	"github.com/cryptomeow/lnd/chainntnfs/bitcoindnotify"
+	"github.com/cryptomeow/lnd/chainntnfs/btcdnotify"
+	"github.com/cryptomeow/lnd/lncfg"
+)
+
+// rpcHealthCheck returns a health check for a chain backend that succeeds if
+// the backend responds to an RPC request for its best block.
+func rpcHealthCheck(rpcConfig rpcclient.ConnConfig) (func() error, error) {
+	// Use a separate HTTP POST client, so the health check doesn't depend
+	// on any long lived connection of the notifier.
+	rpcConfig.HTTPPostMode = true
+	rpcConfig.DisableConnectOnNew = true
+	rpcConfig.Endpoint = ""
+
+	client, err := rpcclient.New(&rpcConfig, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return func() error {
+		_, err := client.GetBestBlockHash()
+		return err
+	}, nil
+}
+
+// parseBitcoindBackup parses a bitcoind backup node given in the format
+// rpchost,zmqpubrawblock,zmqpubrawtx.
+func parseBitcoindBackup(backup string) (string, string, string, error) {
+	parts := strings.Split(backup, ",")
+	if len(parts) != 3 {
+		return "", "", "", fmt.Errorf("invalid bitcoind backup %q, "+
+			"expected format rpchost,zmqpubrawblock,zmqpubrawtx",
+			backup)
+	}
+
+	for _, part := range parts {
+		if strings.TrimSpace(part) == "" {
+			return "", "", "", fmt.Errorf("invalid bitcoind "+
+				"backup %q, empty field", backup)
+		}
+	}
+
+	return strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]),
+		strings.TrimSpace(parts[2]), nil
+}
+
+// newBitcoindFailoverNotifier creates a FailoverNotifier that sources its
+// notifications from the primary bitcoind node and fails over to the
+// configured backup nodes. The backups share the RPC credentials of the
+// primary node. The notifiers of the primary node are backed by the given
+// connection, which is shared with other subsystems and therefore remains
+// open when the notifier fails over.
+func newBitcoindFailoverNotifier(params *chaincfg.Params,
+	bitcoindMode *lncfg.Bitcoind, primaryHost string,
+	primaryConn *chain.BitcoindConn, blockFetcher *blockfetch.Fetcher,
+	hintCache notifierHintCache) (*chainntnfs.FailoverNotifier,
+	error) {
+
+	user, pass := bitcoindMode.RPCUser, bitcoindMode.RPCPass
+	primaryBackend, err := newFailoverBackend(
+		primaryHost, rpcclient.ConnConfig{
+			Host:       primaryHost,
+			User:       user,
+			Pass:       pass,
+			DisableTLS: true,
+		}, func() (chainntnfs.ChainNotifier, func(), error) {
+			notifier := bitcoindnotify.New(
+				primaryConn, params, hintCache, hintCache,
+				blockFetcher,
+			)
+
+			return notifier, func() {}, nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Backup hosts without an explicit port use the port of the primary
+	// node.
+	_, primaryPort, err := net.SplitHostPort(primaryHost)
+	if err != nil {
+		return nil, err
+	}
+
+	backends := []*chainntnfs.FailoverBackend{primaryBackend}
+	for _, backup := range bitcoindMode.Backups {
+		host, zmqBlockHost, zmqTxHost, err := parseBitcoindBackup(
+			backup,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if !strings.Contains(host, ":") {
+			host = net.JoinHostPort(host, primaryPort)
+		}
+
+		// Each notifier of a backup node is backed by its own
+		// connection, which is closed once the notifier is stopped.
+		connect := func() (chainntnfs.ChainNotifier, func(), error) {
+			conn, err := chain.NewBitcoindConn(
+				&chain.BitcoindConfig{
+					ChainParams:     params,
+					Host:            host,
+					User:            user,
+					Pass:            pass,
+					ZMQBlockHost:    zmqBlockHost,
+					ZMQTxHost:       zmqTxHost,
+					ZMQReadDeadline: 5 * time.Second,
+				},
+			)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			if err := conn.Start(); err != nil {
+				return nil, nil, fmt.Errorf("unable to connect "+
+					"to bitcoind: %v", err)
+			}
+
+			notifier := bitcoindnotify.New(
+				conn, params, hintCache, hintCache, nil,
+			)
+
+			return notifier, conn.Stop, nil
+		}
+
+		backend, err := newFailoverBackend(
+			host, rpcclient.ConnConfig{
+				Host:       host,
+				User:       user,
+				Pass:       pass,
+				DisableTLS: true,
+			}, connect,
+		)
+		if err != nil {
+			return nil, err
+		}
+		backends = append(backends, backend)
+	}
+
+	return chainntnfs.NewFailoverNotifier(backends...), nil
+}
+
+// newBtcdFailoverNotifier creates a FailoverNotifier that sources its
+// notifications from the primary btcd node and fails over to the given backup
+// hosts. The backups share the RPC credentials and certificate of the primary
+// node.
+func newBtcdFailoverNotifier(params BitcoinNetParams, backupHosts []string,
+	rpcConfig rpcclient.ConnConfig,
+	hintCache notifierHintCache) (*chainntnfs.FailoverNotifier,
+	error) {
+
+	primaryBackend, err := newFailoverBackend(
+		rpcConfig.Host, rpcConfig, newBtcdConnect(
+			rpcConfig, params.Params, hintCache,
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	backends := []*chainntnfs.FailoverBackend{primaryBackend}
+	for _, host := range backupHosts {
+		if !strings.Contains(host, ":") {
+			host = net.JoinHostPort(host, params.RPCPort)
+		}
+
+		backupConfig := rpcConfig
+		backupConfig.Host = host
+
+		backend, err := newFailoverBackend(
+			host, backupConfig, newBtcdConnect(
+				backupConfig, params.Params, hintCache,
+			),
+		)
+		if err != nil {
+			return nil, err
+		}
+		backends = append(backends, backend)
+	}
+
+	return chainntnfs.NewFailoverNotifier(backends...), nil
+}
+
+// newBtcdConnect returns a function that creates a new notifier for the btcd
+// node with the given RPC config. The notifier owns its RPC client, which is
+// disconnected once the notifier is stopped.
+func newBtcdConnect(rpcConfig rpcclient.ConnConfig, params *chaincfg.Params,
+	hintCache notifierHintCache) func() (chainntnfs.ChainNotifier, func(),
+	error) {
+
+	return func() (chainntnfs.ChainNotifier, func(), error) {
+		notifier, err := btcdnotify.New(
+			&rpcConfig, params, hintCache, hintCache,
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return notifier, func() {}, nil
+	}
+}
+
+// newFailoverBackend creates a FailoverBackend that is connected through the
+// given function once it becomes active, and whose health is determined by
+// querying the node's best block over RPC.
+func newFailoverBackend(name string, rpcConfig rpcclient.ConnConfig,
+	connect func() (chainntnfs.ChainNotifier, func(), error)) (
+	*chainntnfs.FailoverBackend, error) {
+
+	healthCheck, err := rpcHealthCheck(rpcConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &chainntnfs.FailoverBackend{
+		Name:        name,
+		Connect:     connect,
+		HealthCheck: healthCheck,
+	}, nil
+}
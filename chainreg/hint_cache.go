@@ -0,0 +1,65 @@
+package chainreg
+
+import (
+	"github.com/cryptomeow/lnd/chainntnfs"
+)
+
+// notifierHintCache caches both the spend and the confirm hints of the chain
+// notifiers.
+type notifierHintCache interface {
+	chainntnfs.SpendHintCache
+	chainntnfs.ConfirmHintCache
+}
+
+// noopHintCache is a hint cache that never stores any hints. It is used if
+// nothing may be written to the channel database, in which case the notifiers
+// always scan from the height hints of the requests.
+type noopHintCache struct{}
+
+// CommitSpendHint discards the spend hint.
+//
+// NOTE: This is part of the chainntnfs.SpendHintCache interface.
+func (noopHintCache) CommitSpendHint(uint32, ...chainntnfs.SpendRequest) error {
+	return nil
+}
+
+// QuerySpendHint always returns chainntnfs.ErrSpendHintNotFound.
+//
+// NOTE: This is part of the chainntnfs.SpendHintCache interface.
+func (noopHintCache) QuerySpendHint(chainntnfs.SpendRequest) (uint32, error) {
+	return 0, chainntnfs.ErrSpendHintNotFound
+}
+
+// PurgeSpendHint is a no-op, as no hints are stored.
+//
+// NOTE: This is part of the chainntnfs.SpendHintCache interface.
+func (noopHintCache) PurgeSpendHint(...chainntnfs.SpendRequest) error {
+	return nil
+}
+
+// CommitConfirmHint discards the confirm hint.
+//
+// NOTE: This is part of the chainntnfs.ConfirmHintCache interface.
+func (noopHintCache) CommitConfirmHint(uint32,
+	...chainntnfs.ConfRequest) error {
+
+	return nil
+}
+
+// QueryConfirmHint always returns chainntnfs.ErrConfirmHintNotFound.
+//
+// NOTE: This is part of the chainntnfs.ConfirmHintCache interface.
+func (noopHintCache) QueryConfirmHint(chainntnfs.ConfRequest) (uint32, error) {
+	return 0, chainntnfs.ErrConfirmHintNotFound
+}
+
+// PurgeConfirmHint is a no-op, as no hints are stored.
+//
+// NOTE: This is part of the chainntnfs.ConfirmHintCache interface.
+func (noopHintCache) PurgeConfirmHint(...chainntnfs.ConfRequest) error {
+	return nil
+}
+
+// A compile-time check to ensure noopHintCache implements the
+// notifierHintCache interface.
+var _ notifierHintCache = noopHintCache{}
@@ -0,0 +1,280 @@
+package chainreg
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/btcsuite/btcd/blockchain"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcwallet/walletdb"
+	"github.com/lightninglabs/neutrino/headerfs"
+)
+
+// sideloadBatchSize is the number of sideloaded headers we'll write to the
+// header stores at once.
+const sideloadBatchSize = 2000
+
+// ParseCheckpoints parses a list of checkpoints in height:hash format.
+func ParseCheckpoints(checkpoints []string) ([]chaincfg.Checkpoint, error) {
+	parsed := make([]chaincfg.Checkpoint, 0, len(checkpoints))
+	for _, checkpoint := range checkpoints {
+		split := strings.Split(checkpoint, ":")
+		if len(split) != 2 {
+			return nil, fmt.Errorf("checkpoint %v in unexpected "+
+				"format, expected format height:hash",
+				checkpoint)
+		}
+
+		height, err := strconv.ParseInt(split[0], 10, 32)
+		if err != nil || height <= 0 {
+			return nil, fmt.Errorf("invalid checkpoint height: %v",
+				split[0])
+		}
+
+		hash, err := chainhash.NewHashFromStr(split[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid checkpoint hash: %v",
+				err)
+		}
+
+		parsed = append(parsed, chaincfg.Checkpoint{
+			Height: int32(height),
+			Hash:   hash,
+		})
+	}
+
+	return parsed, nil
+}
+
+// MergeCheckpoints combines the default checkpoints of a chain with a set of
+// custom checkpoints. Custom checkpoints replace default checkpoints at the
+// same height. The returned checkpoints are sorted by height.
+func MergeCheckpoints(defaults,
+	custom []chaincfg.Checkpoint) []chaincfg.Checkpoint {
+
+	byHeight := make(map[int32]chaincfg.Checkpoint)
+	for _, checkpoint := range defaults {
+		byHeight[checkpoint.Height] = checkpoint
+	}
+	for _, checkpoint := range custom {
+		byHeight[checkpoint.Height] = checkpoint
+	}
+
+	merged := make([]chaincfg.Checkpoint, 0, len(byHeight))
+	for _, checkpoint := range byHeight {
+		merged = append(merged, checkpoint)
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i].Height < merged[j].Height
+	})
+
+	return merged
+}
+
+// SideloadNeutrinoHeaders loads block headers and their filter headers from
+// the given reader into neutrino's header stores, so the headers don't need to
+// be downloaded from the network. The data starts with the big endian uint32
+// height of the first header, followed by an 80 byte block header and a 32
+// byte filter header for each consecutive height.
+//
+// Headers we already know of must match the sideloaded ones. New block headers
+// must connect to our header chain, satisfy their proof of work and match the
+// checkpoints of the chain params. Neither the difficulty adjustments nor the
+// filter headers can be verified without the blocks themselves, so the data
+// must come from a trusted source. If a filter header assertion is given, the
+// sideloaded filter header at its height must match it.
+//
+// The number of new block headers is returned. This must be called before the
+// neutrino chain service is created.
+func SideloadNeutrinoHeaders(r io.Reader, dataDir string, db walletdb.DB,
+	params *chaincfg.Params,
+	assertion *headerfs.FilterHeader) (uint32, error) {
+
+	blockStore, err := headerfs.NewBlockHeaderStore(dataDir, db, params)
+	if err != nil {
+		return 0, err
+	}
+	filterStore, err := headerfs.NewFilterHeaderStore(
+		dataDir, db, headerfs.RegularFilter, params, nil,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	_, blockTip, err := blockStore.ChainTip()
+	if err != nil {
+		return 0, err
+	}
+	_, filterTip, err := filterStore.ChainTip()
+	if err != nil {
+		return 0, err
+	}
+
+	checkpoints := make(map[int32]*chainhash.Hash)
+	for _, checkpoint := range params.Checkpoints {
+		checkpoints[checkpoint.Height] = checkpoint.Hash
+	}
+
+	reader := bufio.NewReader(r)
+
+	var startHeight uint32
+	err = binary.Read(reader, binary.BigEndian, &startHeight)
+	if err != nil {
+		return 0, fmt.Errorf("unable to read start height: %v", err)
+	}
+
+	// The filter header chain never extends beyond the block header
+	// chain, so the sideloaded headers need to start right after the tip
+	// of the filter header chain at the latest.
+	if startHeight == 0 || startHeight > filterTip+1 {
+		return 0, fmt.Errorf("sideloaded headers start at height %v, "+
+			"but must start between height 1 and %v", startHeight,
+			filterTip+1)
+	}
+
+	prevHeader, err := blockStore.FetchHeaderByHeight(startHeight - 1)
+	if err != nil {
+		return 0, err
+	}
+	prevHash := prevHeader.BlockHash()
+
+	var (
+		blockBatch  []headerfs.BlockHeader
+		filterBatch []headerfs.FilterHeader
+		numNew      uint32
+	)
+	flush := func() error {
+		// Block headers need to be written first, as the filter header
+		// store indexes its headers by their block hash.
+		if len(blockBatch) > 0 {
+			err := blockStore.WriteHeaders(blockBatch...)
+			if err != nil {
+				return err
+			}
+			numNew += uint32(len(blockBatch))
+		}
+		if err := filterStore.WriteHeaders(filterBatch...); err != nil {
+			return err
+		}
+
+		blockBatch = nil
+		filterBatch = nil
+
+		return nil
+	}
+
+	for height := startHeight; ; height++ {
+		var header wire.BlockHeader
+		err := header.Deserialize(reader)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, fmt.Errorf("unable to read block header at "+
+				"height %v: %v", height, err)
+		}
+
+		var filterHash chainhash.Hash
+		if _, err := io.ReadFull(reader, filterHash[:]); err != nil {
+			return 0, fmt.Errorf("unable to read filter header at "+
+				"height %v: %v", height, err)
+		}
+
+		if header.PrevBlock != prevHash {
+			return 0, fmt.Errorf("block header at height %v "+
+				"doesn't connect to the header chain", height)
+		}
+		hash := header.BlockHash()
+
+		switch {
+		// We already know of this block header, so it needs to match
+		// the one we have.
+		case height <= blockTip:
+			known, err := blockStore.FetchHeaderByHeight(height)
+			if err != nil {
+				return 0, err
+			}
+			if known.BlockHash() != hash {
+				return 0, fmt.Errorf("block header at height "+
+					"%v conflicts with the header chain",
+					height)
+			}
+
+		default:
+			err := checkSideloadedHeader(
+				&header, height, params, checkpoints,
+			)
+			if err != nil {
+				return 0, err
+			}
+
+			blockBatch = append(blockBatch, headerfs.BlockHeader{
+				BlockHeader: &header,
+				Height:      height,
+			})
+		}
+
+		if assertion != nil && assertion.Height == height &&
+			assertion.FilterHash != filterHash {
+
+			return 0, fmt.Errorf("filter header at height %v "+
+				"doesn't match the asserted filter header",
+				height)
+		}
+
+		if height > filterTip {
+			filterBatch = append(filterBatch, headerfs.FilterHeader{
+				HeaderHash: hash,
+				FilterHash: filterHash,
+				Height:     height,
+			})
+		}
+
+		if len(filterBatch) >= sideloadBatchSize {
+			if err := flush(); err != nil {
+				return 0, err
+			}
+		}
+
+		prevHash = hash
+	}
+
+	if err := flush(); err != nil {
+		return 0, err
+	}
+
+	return numNew, nil
+}
+
+// checkSideloadedHeader checks that a new sideloaded block header satisfies
+// its proof of work and matches the checkpoint at its height, if any.
+func checkSideloadedHeader(header *wire.BlockHeader, height uint32,
+	params *chaincfg.Params, checkpoints map[int32]*chainhash.Hash) error {
+
+	hash := header.BlockHash()
+
+	target := blockchain.CompactToBig(header.Bits)
+	if target.Sign() <= 0 || target.Cmp(params.PowLimit) > 0 {
+		return fmt.Errorf("block header at height %v has an invalid "+
+			"target", height)
+	}
+	if blockchain.HashToBig(&hash).Cmp(target) > 0 {
+		return fmt.Errorf("block header at height %v doesn't satisfy "+
+			"its proof of work", height)
+	}
+
+	checkpoint, ok := checkpoints[int32(height)]
+	if ok && *checkpoint != hash {
+		return fmt.Errorf("block header at height %v doesn't match "+
+			"checkpoint %v", height, checkpoint)
+	}
+
+	return nil
+}
@@ -0,0 +1,183 @@
+package chainreg
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/blockchain"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcwallet/walletdb"
+	_ "github.com/btcsuite/btcwallet/walletdb/bdb" // Required to register the bdb walletdb implementation.
+	"github.com/cryptomeow/lnd/channeldb/kvdb"
+	"github.com/lightninglabs/neutrino/headerfs"
+	"github.com/stretchr/testify/require"
+)
+
+// mineHeaders returns a chain of regtest block headers with valid proof of
+// work on top of the given block hash.
+func mineHeaders(prevHash chainhash.Hash, num int) []wire.BlockHeader {
+	params := chaincfg.RegressionNetParams
+
+	headers := make([]wire.BlockHeader, 0, num)
+	for i := 0; i < num; i++ {
+		header := wire.BlockHeader{
+			Version:   1,
+			PrevBlock: prevHash,
+			Timestamp: time.Unix(int64(1600000000+i), 0),
+			Bits:      params.PowLimitBits,
+		}
+
+		target := blockchain.CompactToBig(header.Bits)
+		for {
+			hash := header.BlockHash()
+			if blockchain.HashToBig(&hash).Cmp(target) <= 0 {
+				break
+			}
+			header.Nonce++
+		}
+
+		headers = append(headers, header)
+		prevHash = header.BlockHash()
+	}
+
+	return headers
+}
+
+// serializeHeaders serializes the given headers in the sideload format,
+// starting at the given height. The filter header of each block is derived
+// from its height.
+func serializeHeaders(t *testing.T, startHeight uint32,
+	headers []wire.BlockHeader) []byte {
+
+	var b bytes.Buffer
+	require.NoError(t, binary.Write(&b, binary.BigEndian, startHeight))
+	for i, header := range headers {
+		require.NoError(t, header.Serialize(&b))
+
+		filterHash := chainhash.Hash{byte(startHeight + uint32(i))}
+		_, err := b.Write(filterHash[:])
+		require.NoError(t, err)
+	}
+
+	return b.Bytes()
+}
+
+// TestSideloadNeutrinoHeaders tests that sideloaded headers are appended to
+// neutrino's header stores, and that invalid headers are rejected.
+func TestSideloadNeutrinoHeaders(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "sideload")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	db, err := walletdb.Create(
+		"bdb", filepath.Join(dir, "neutrino.db"), true,
+		kvdb.DefaultDBTimeout,
+	)
+	require.NoError(t, err)
+	defer db.Close()
+
+	params := chaincfg.RegressionNetParams
+	headers := mineHeaders(*params.GenesisHash, 10)
+
+	sideload := func(startHeight uint32, headers []wire.BlockHeader,
+		params *chaincfg.Params) (uint32, error) {
+
+		data := serializeHeaders(t, startHeight, headers)
+		return SideloadNeutrinoHeaders(
+			bytes.NewReader(data), dir, db, params, nil,
+		)
+	}
+
+	// Headers that don't connect to the genesis block are rejected.
+	_, err = sideload(2, headers[1:], &params)
+	require.Error(t, err)
+
+	// A header that doesn't match a checkpoint is rejected.
+	checkpointParams := params
+	checkpointParams.Checkpoints = []chaincfg.Checkpoint{
+		{Height: 3, Hash: &chainhash.Hash{1}},
+	}
+	_, err = sideload(1, headers[:5], &checkpointParams)
+	require.Error(t, err)
+
+	// A header without valid proof of work is rejected.
+	invalid := append([]wire.BlockHeader{}, headers[:3]...)
+	invalid[2].Bits = 0x1d00ffff
+	_, err = sideload(1, invalid, &params)
+	require.Error(t, err)
+
+	// The first five headers with a matching checkpoint are accepted.
+	hash := headers[2].BlockHash()
+	checkpointParams.Checkpoints = []chaincfg.Checkpoint{
+		{Height: 3, Hash: &hash},
+	}
+	numNew, err := sideload(1, headers[:5], &checkpointParams)
+	require.NoError(t, err)
+	require.Equal(t, uint32(5), numNew)
+
+	// Sideloading overlapping headers only appends the new ones, while
+	// headers conflicting with known ones are rejected.
+	conflicting := mineHeaders(*params.GenesisHash, 1)
+	conflicting[0].Version = 2
+	_, err = sideload(1, conflicting, &params)
+	require.Error(t, err)
+
+	numNew, err = sideload(4, headers[3:], &params)
+	require.NoError(t, err)
+	require.Equal(t, uint32(5), numNew)
+
+	// Finally, the header stores should contain all headers.
+	blockStore, err := headerfs.NewBlockHeaderStore(dir, db, &params)
+	require.NoError(t, err)
+	filterStore, err := headerfs.NewFilterHeaderStore(
+		dir, db, headerfs.RegularFilter, &params, nil,
+	)
+	require.NoError(t, err)
+
+	tipHeader, tipHeight, err := blockStore.ChainTip()
+	require.NoError(t, err)
+	require.Equal(t, uint32(10), tipHeight)
+	require.Equal(t, headers[9].BlockHash(), tipHeader.BlockHash())
+
+	filterTip, filterTipHeight, err := filterStore.ChainTip()
+	require.NoError(t, err)
+	require.Equal(t, uint32(10), filterTipHeight)
+	require.Equal(t, chainhash.Hash{10}, *filterTip)
+}
+
+// TestMergeCheckpoints tests that custom checkpoints replace default ones at
+// the same height and that the result is sorted by height.
+func TestMergeCheckpoints(t *testing.T) {
+	t.Parallel()
+
+	custom, err := ParseCheckpoints([]string{
+		"30:" + chainhash.Hash{3}.String(),
+		"10:" + chainhash.Hash{4}.String(),
+	})
+	require.NoError(t, err)
+
+	defaults := []chaincfg.Checkpoint{
+		{Height: 10, Hash: &chainhash.Hash{1}},
+		{Height: 20, Hash: &chainhash.Hash{2}},
+	}
+	merged := MergeCheckpoints(defaults, custom)
+	require.Equal(t, []chaincfg.Checkpoint{
+		{Height: 10, Hash: &chainhash.Hash{4}},
+		{Height: 20, Hash: &chainhash.Hash{2}},
+		{Height: 30, Hash: &chainhash.Hash{3}},
+	}, merged)
+
+	_, err = ParseCheckpoints([]string{"10"})
+	require.Error(t, err)
+	_, err = ParseCheckpoints([]string{"0:" + chainhash.Hash{}.String()})
+	require.Error(t, err)
+}
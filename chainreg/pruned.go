@@ -0,0 +1,123 @@
+package chainreg
+
+import (
+	"encoding/json"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/rpcclient"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/cryptomeow/lnd/blockfetch"
+	"github.com/cryptomeow/lnd/lnwallet"
+)
+
+// bitcoindPeer is the subset of the peer information returned by bitcoind's
+// getpeerinfo call we need to select peers to fetch blocks from.
+type bitcoindPeer struct {
+	Addr     string `json:"addr"`
+	Services string `json:"services"`
+	Inbound  bool   `json:"inbound"`
+}
+
+// newPrunedBlockFetcher returns a blockfetch.Fetcher that retrieves blocks
+// pruned by the bitcoind node from the node's own peers. If the node isn't
+// pruned, nil is returned.
+func newPrunedBlockFetcher(params *chaincfg.Params,
+	rpcConfig rpcclient.ConnConfig) (*blockfetch.Fetcher, error) {
+
+	rpcConfig.HTTPPostMode = true
+	rpcConfig.DisableConnectOnNew = true
+
+	client, err := rpcclient.New(&rpcConfig, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := client.GetBlockChainInfo()
+	if err != nil {
+		return nil, err
+	}
+	if !info.Pruned {
+		return nil, nil
+	}
+
+	log.Infof("bitcoind node is pruned, historical blocks will be " +
+		"fetched from its peers")
+
+	getPeers := func() ([]string, error) {
+		return bitcoindFullNodePeers(client)
+	}
+
+	return blockfetch.New(&blockfetch.Config{
+		ChainParams: params,
+		GetPeers:    getPeers,
+		Dial: func(addr string) (net.Conn, error) {
+			return net.DialTimeout(
+				"tcp", addr, blockfetch.DefaultTimeout,
+			)
+		},
+		MaxPeers: blockfetch.DefaultMaxPeers,
+		Timeout:  blockfetch.DefaultTimeout,
+	}), nil
+}
+
+// bitcoindFullNodePeers returns the addresses of the outbound peers of the
+// bitcoind node that advertise to serve the full block chain.
+func bitcoindFullNodePeers(client *rpcclient.Client) ([]string, error) {
+	resp, err := client.RawRequest("getpeerinfo", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var peers []bitcoindPeer
+	if err := json.Unmarshal(resp, &peers); err != nil {
+		return nil, err
+	}
+
+	var addrs []string
+	for _, peer := range peers {
+		// The addresses of inbound peers usually don't accept
+		// connections, and onion addresses can't be dialed directly.
+		if peer.Inbound || strings.Contains(peer.Addr, ".onion") {
+			continue
+		}
+
+		services, err := strconv.ParseUint(peer.Services, 16, 64)
+		if err != nil {
+			continue
+		}
+		if wire.ServiceFlag(services)&wire.SFNodeNetwork == 0 {
+			continue
+		}
+
+		addrs = append(addrs, peer.Addr)
+	}
+
+	return addrs, nil
+}
+
+// prunedChainIO wraps a BlockChainIO so blocks that have been pruned by the
+// chain backend are fetched from the P2P network instead.
+type prunedChainIO struct {
+	lnwallet.BlockChainIO
+
+	blockFetcher *blockfetch.Fetcher
+}
+
+// GetBlock returns the block in the main chain identified by the given hash.
+//
+// NOTE: This is part of the lnwallet.BlockChainIO interface.
+func (p *prunedChainIO) GetBlock(blockHash *chainhash.Hash) (*wire.MsgBlock,
+	error) {
+
+	return blockfetch.GetBlock(
+		blockHash, p.BlockChainIO.GetBlock, p.blockFetcher,
+	)
+}
+
+// A compile-time assertion to ensure prunedChainIO implements the
+// lnwallet.BlockChainIO interface.
+var _ lnwallet.BlockChainIO = (*prunedChainIO)(nil)
@@ -0,0 +1,130 @@
+package chainreg
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"math/big"
+	"time"
+
+	bitcoinCfg "github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	bitcoinWire "github.com/btcsuite/btcd/wire"
+	"github.com/cryptomeow/lnd/keychain"
+)
+
+const (
+	// SigNetName is the name of all signet networks, regardless of their
+	// challenge.
+	SigNetName = "signet"
+
+	// sigNetPowLimitBits is the proof of work limit of signet in its
+	// compact form.
+	sigNetPowLimitBits = 0x1e0377ae
+)
+
+var (
+	// DefaultSigNetChallenge is the challenge script of the global default
+	// signet test network. Blocks of the network have to satisfy this
+	// 1-of-2 multisig script.
+	DefaultSigNetChallenge, _ = hex.DecodeString(
+		"512103ad5e0edad18cb1f0fc0d28a3d4f1f3e445640337489abb10404f2d1e" +
+			"086be430210359ef5021964fe22d6f8e05b2463c9540ce96883fe3b2" +
+			"78760f048f5189f2e6c452ae",
+	)
+
+	// sigNetPowLimit is the highest proof of work value a signet block
+	// can have.
+	sigNetPowLimit, _ = new(big.Int).SetString(
+		"00000377ae000000000000000000000000000000000000000000000000000000",
+		16,
+	)
+
+	// mainNetGenesisBlock is the genesis block of mainnet, whose coinbase
+	// transaction is shared by the genesis blocks of all other networks.
+	mainNetGenesisBlock = bitcoinCfg.MainNetParams.GenesisBlock
+
+	// sigNetGenesisBlock is the genesis block of all signet networks. It
+	// only differs from the genesis block of mainnet in its header.
+	sigNetGenesisBlock = bitcoinWire.MsgBlock{
+		Header: bitcoinWire.BlockHeader{
+			Version:    1,
+			PrevBlock:  chainhash.Hash{},
+			MerkleRoot: mainNetGenesisBlock.Header.MerkleRoot,
+			Timestamp:  time.Unix(1598918400, 0),
+			Bits:       sigNetPowLimitBits,
+			Nonce:      52613770,
+		},
+		Transactions: mainNetGenesisBlock.Transactions,
+	}
+
+	// sigNetGenesisHash is the hash of the signet genesis block.
+	sigNetGenesisHash = sigNetGenesisBlock.BlockHash()
+
+	// sigNetDNSSeeds are the DNS seeds of the global default signet.
+	sigNetDNSSeeds = []bitcoinCfg.DNSSeed{
+		{Host: "seed.signet.bitcoin.sprovoost.nl", HasFiltering: false},
+	}
+)
+
+// BitcoinSigNetParams contains parameters specific to the global default
+// signet test network.
+var BitcoinSigNetParams = NewBitcoinSigNetParams(DefaultSigNetChallenge)
+
+// NewBitcoinSigNetParams returns the parameters of the signet test network
+// that is defined by the given challenge script. All signet networks share
+// the same genesis block and address encodings, but each challenge results in
+// a distinct network magic, so nodes of different signets don't connect to
+// each other.
+func NewBitcoinSigNetParams(challenge []byte) BitcoinNetParams {
+	// All signet parameters that aren't specific to signet are the same as
+	// the ones of testnet3. We start from a copy of them, so the testnet3
+	// parameters aren't modified.
+	params := bitcoinCfg.TestNet3Params
+
+	params.Name = SigNetName
+	params.Net = sigNetMagic(challenge)
+	params.DefaultPort = "38333"
+	params.DNSSeeds = nil
+	if bytes.Equal(challenge, DefaultSigNetChallenge) {
+		params.DNSSeeds = sigNetDNSSeeds
+	}
+
+	params.GenesisBlock = &sigNetGenesisBlock
+	params.GenesisHash = &sigNetGenesisHash
+	params.PowLimit = sigNetPowLimit
+	params.PowLimitBits = sigNetPowLimitBits
+	params.BIP0034Height = 1
+	params.BIP0065Height = 1
+	params.BIP0066Height = 1
+	params.ReduceMinDifficulty = false
+	params.MinDiffReductionTime = 0
+	params.GenerateSupported = false
+	params.Checkpoints = nil
+
+	return BitcoinNetParams{
+		Params: &params,
+
+		// The RPC port follows the btcd convention of being two
+		// higher than the RPC port of bitcoind.
+		RPCPort:  "38334",
+		CoinType: keychain.CoinTypeTestnet,
+	}
+}
+
+// IsSigNet returns true if the given params correspond to a signet network.
+func IsSigNet(params *bitcoinCfg.Params) bool {
+	return params.Name == SigNetName
+}
+
+// sigNetMagic derives the network magic of the signet defined by the given
+// challenge script. The magic is the first four bytes of the double sha256
+// of the serialized challenge.
+func sigNetMagic(challenge []byte) bitcoinWire.BitcoinNet {
+	var b bytes.Buffer
+	_ = bitcoinWire.WriteVarBytes(&b, 0, challenge)
+
+	hash := chainhash.DoubleHashB(b.Bytes())
+
+	return bitcoinWire.BitcoinNet(binary.LittleEndian.Uint32(hash[:4]))
+}
@@ -0,0 +1,37 @@
+package chainreg
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSigNetParams tests that the parameters of the default signet match the
+// network magic and genesis hash of the global signet, and that a custom
+// challenge results in a different network magic.
+func TestSigNetParams(t *testing.T) {
+	t.Parallel()
+
+	params := BitcoinSigNetParams
+	require.Equal(t, wire.BitcoinNet(0x40cf030a), params.Net)
+	require.Equal(
+		t, "00000008819873e925422c1ff0f99f7cc9bbb232af63a077a480a3633b"+
+			"ee1ef6", params.GenesisHash.String(),
+	)
+	require.True(t, IsSigNet(params.Params))
+	require.NotEmpty(t, params.DNSSeeds)
+
+	// A custom signet shares the genesis block with the default signet,
+	// but has its own network magic and no DNS seeds.
+	custom := NewBitcoinSigNetParams([]byte{0x51})
+	require.NotEqual(t, params.Net, custom.Net)
+	require.Equal(t, params.GenesisHash, custom.GenesisHash)
+	require.True(t, IsSigNet(custom.Params))
+	require.Empty(t, custom.DNSSeeds)
+
+	// The testnet3 parameters the signet parameters are derived from must
+	// not be modified.
+	require.False(t, IsSigNet(BitcoinTestNetParams.Params))
+	require.Equal(t, wire.TestNet3, BitcoinTestNetParams.Net)
+}
@@ -36,7 +36,7 @@ func acceptAndIncrementCtr(rpc ChannelAcceptor, req *ChannelAcceptRequest,
 	ctr *uint32, success chan struct{}) {
 
 	result := rpc.Accept(req)
-	if !result {
+	if !result.Accept {
 		return
 	}
 
@@ -87,7 +87,9 @@ func TestRPCMultipleAcceptClients(t *testing.T) {
 
 	// demultiplexReq is a closure used to abstract the RPCAcceptor's request
 	// and response logic.
-	demultiplexReq := func(req *ChannelAcceptRequest) bool {
+	demultiplexReq := func(
+		req *ChannelAcceptRequest) *ChannelAcceptResponse {
+
 		respChan := make(chan lnrpc.ChannelAcceptResponse, 1)
 
 		newRequest := &requestInfo{
@@ -99,7 +101,7 @@ func TestRPCMultipleAcceptClients(t *testing.T) {
 		select {
 		case requests <- newRequest:
 		case <-quit:
-			return false
+			return NewChannelAcceptResponse(false)
 		}
 
 		// Receive the response and verify that the PendingChanId matches
@@ -110,15 +112,15 @@ func TestRPCMultipleAcceptClients(t *testing.T) {
 			pendingID := req.OpenChanMsg.PendingChannelID
 			if !bytes.Equal(pendingID[:], resp.PendingChanId) {
 				errChan <- struct{}{}
-				return false
+				return NewChannelAcceptResponse(false)
 			}
 
-			return resp.Accept
+			return NewChannelAcceptResponse(resp.Accept)
 		case <-time.After(defaultAcceptTimeout):
 			errChan <- struct{}{}
-			return false
+			return NewChannelAcceptResponse(false)
 		case <-quit:
-			return false
+			return NewChannelAcceptResponse(false)
 		}
 	}
 
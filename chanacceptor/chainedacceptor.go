@@ -43,17 +43,26 @@ func (c *ChainedAcceptor) RemoveAcceptor(id uint64) {
 }
 
 // Accept evaluates the results of all ChannelAcceptors in the acceptors map
-// and returns the conjunction of all these predicates.
+// and returns the conjunction of all these predicates. If several acceptors
+// override the number of confirmations required for the channel, the highest
+// value is used.
 //
 // NOTE: Part of the ChannelAcceptor interface.
-func (c *ChainedAcceptor) Accept(req *ChannelAcceptRequest) bool {
-	result := true
+func (c *ChainedAcceptor) Accept(
+	req *ChannelAcceptRequest) *ChannelAcceptResponse {
+
+	result := NewChannelAcceptResponse(true)
 
 	c.acceptorsMtx.RLock()
 	for _, acceptor := range c.acceptors {
 		// We call Accept first in case any acceptor (perhaps an RPCAcceptor)
 		// wishes to be notified about ChannelAcceptRequest.
-		result = acceptor.Accept(req) && result
+		resp := acceptor.Accept(req)
+		result.Accept = resp.Accept && result.Accept
+
+		if resp.MinAcceptDepth > result.MinAcceptDepth {
+			result.MinAcceptDepth = resp.MinAcceptDepth
+		}
 	}
 	c.acceptorsMtx.RUnlock()
 
@@ -16,10 +16,35 @@ type ChannelAcceptRequest struct {
 	// OpenChanMsg is the actual OpenChannel protocol message that the peer
 	// sent to us.
 	OpenChanMsg *lnwire.OpenChannel
+
+	// TrustedPeer is true if the requesting node is one of our trusted
+	// peers, whose channels only require a single confirmation.
+	TrustedPeer bool
+}
+
+// ChannelAcceptResponse is the decision of a ChannelAcceptor on a
+// ChannelAcceptRequest, along with any channel parameters the acceptor wishes
+// to override for the channel.
+type ChannelAcceptResponse struct {
+	// Accept indicates whether the channel should be accepted.
+	Accept bool
+
+	// MinAcceptDepth is the number of confirmations we require before we
+	// consider the channel open. If this is zero, the value derived from
+	// the channel size is used.
+	MinAcceptDepth uint16
+}
+
+// NewChannelAcceptResponse returns a response that accepts or rejects a
+// channel without overriding any of its parameters.
+func NewChannelAcceptResponse(accept bool) *ChannelAcceptResponse {
+	return &ChannelAcceptResponse{
+		Accept: accept,
+	}
 }
 
 // ChannelAcceptor is an interface that represents a predicate on the data
 // contained in ChannelAcceptRequest.
 type ChannelAcceptor interface {
-	Accept(req *ChannelAcceptRequest) bool
+	Accept(req *ChannelAcceptRequest) *ChannelAcceptResponse
 }
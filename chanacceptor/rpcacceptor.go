@@ -1,22 +1,129 @@
 package chanacceptor
 
+import "time"
+
+const (
+	// OutcomeAccept is the outcome of a request that the client of an
+	// RPCAcceptor accepted.
+	OutcomeAccept = "accept"
+
+	// OutcomeReject is the outcome of a request that the client of an
+	// RPCAcceptor rejected.
+	OutcomeReject = "reject"
+
+	// OutcomeTimeout is the outcome of a request that the client of an
+	// RPCAcceptor didn't respond to in time.
+	OutcomeTimeout = "timeout"
+
+	// OutcomeDisconnect is the outcome of a request whose client
+	// disconnected before responding.
+	OutcomeDisconnect = "disconnect"
+)
+
+// RPCRequest is a request that is handed to the client of an RPCAcceptor,
+// along with the channel the client's response is to be sent over.
+type RPCRequest struct {
+	// Request is the request the client decides on.
+	Request *ChannelAcceptRequest
+
+	// Response is the buffered channel the client's response is sent
+	// over.
+	Response chan *ChannelAcceptResponse
+}
+
+// VerdictConfig determines how long the client of an RPCAcceptor is given to
+// respond to a request, and the verdict that is returned if it doesn't.
+type VerdictConfig struct {
+	// Timeout is the time after which a request the client didn't respond
+	// to expires.
+	Timeout time.Duration
+
+	// DefaultAccept is the verdict returned for requests the client
+	// didn't respond to, so that a crashed or stuck client doesn't block
+	// all inbound channels.
+	DefaultAccept bool
+
+	// Observe is called with every request, its outcome and the time it
+	// took to reach it, unless the request was abandoned on shutdown.
+	Observe func(req *ChannelAcceptRequest, outcome string,
+		latency time.Duration)
+}
+
+// RequestVerdict hands the request to the client of an RPCAcceptor over the
+// requests channel and waits for the client's response. If the client doesn't
+// respond within the timeout, or disconnect is closed before it does, the
+// default verdict is returned. The channel is rejected if shutdown is closed.
+func (c *VerdictConfig) RequestVerdict(req *ChannelAcceptRequest,
+	requests chan<- *RPCRequest, disconnect,
+	shutdown <-chan struct{}) *ChannelAcceptResponse {
+
+	start := time.Now()
+	timeout := time.After(c.Timeout)
+
+	defaultVerdict := func(outcome string) *ChannelAcceptResponse {
+		c.Observe(req, outcome, time.Since(start))
+		return NewChannelAcceptResponse(c.DefaultAccept)
+	}
+
+	respChan := make(chan *ChannelAcceptResponse, 1)
+	rpcReq := &RPCRequest{
+		Request:  req,
+		Response: respChan,
+	}
+
+	// Send the request to the client.
+	select {
+	case requests <- rpcReq:
+	case <-timeout:
+		return defaultVerdict(OutcomeTimeout)
+	case <-disconnect:
+		return defaultVerdict(OutcomeDisconnect)
+	case <-shutdown:
+		return NewChannelAcceptResponse(false)
+	}
+
+	// Receive the response and return it. If no response has been received
+	// before the request expires, the default verdict is returned instead.
+	select {
+	case resp := <-respChan:
+		outcome := OutcomeReject
+		if resp.Accept {
+			outcome = OutcomeAccept
+		}
+		c.Observe(req, outcome, time.Since(start))
+
+		return resp
+	case <-timeout:
+		return defaultVerdict(OutcomeTimeout)
+	case <-disconnect:
+		return defaultVerdict(OutcomeDisconnect)
+	case <-shutdown:
+		return NewChannelAcceptResponse(false)
+	}
+}
+
 // RPCAcceptor represents the RPC-controlled variant of the ChannelAcceptor.
 // One RPCAcceptor allows one RPC client.
 type RPCAcceptor struct {
-	acceptClosure func(req *ChannelAcceptRequest) bool
+	acceptClosure func(req *ChannelAcceptRequest) *ChannelAcceptResponse
 }
 
 // Accept is a predicate on the ChannelAcceptRequest which is sent to the RPC
-// client who will respond with the ultimate decision. This assumes an accept
-// closure has been specified during creation.
+// client who will respond with the ultimate decision and any channel
+// parameters it wishes to override. This assumes an accept closure has been
+// specified during creation.
 //
 // NOTE: Part of the ChannelAcceptor interface.
-func (r *RPCAcceptor) Accept(req *ChannelAcceptRequest) bool {
+func (r *RPCAcceptor) Accept(
+	req *ChannelAcceptRequest) *ChannelAcceptResponse {
+
 	return r.acceptClosure(req)
 }
 
 // NewRPCAcceptor creates and returns an instance of the RPCAcceptor.
-func NewRPCAcceptor(closure func(*ChannelAcceptRequest) bool) *RPCAcceptor {
+func NewRPCAcceptor(closure func(req *ChannelAcceptRequest) (
+	resp *ChannelAcceptResponse)) *RPCAcceptor {
+
 	return &RPCAcceptor{
 		acceptClosure: closure,
 	}
@@ -0,0 +1,186 @@
+package chanacceptor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cryptomeow/lnd/lnwire"
+	"github.com/stretchr/testify/require"
+)
+
+// verdictTest is a test case for the verdicts returned by RequestVerdict.
+type verdictTest struct {
+	name string
+
+	// defaultAccept is the default verdict of the acceptor.
+	defaultAccept bool
+
+	// client handles the request the acceptor hands out, if it isn't
+	// nil, and is given the disconnect and shutdown channels to close.
+	client func(req *RPCRequest, disconnect, shutdown chan struct{})
+
+	// beforeRequest is called before the request is made, with the
+	// disconnect and shutdown channels to close.
+	beforeRequest func(disconnect, shutdown chan struct{})
+
+	// expectAccept is the verdict that is expected to be returned.
+	expectAccept bool
+
+	// expectOutcome is the outcome that is expected to be observed, or the
+	// empty string if none is.
+	expectOutcome string
+}
+
+// TestRequestVerdict asserts that the verdict of the client is returned if it
+// responds in time, that the default verdict is returned on timeouts and
+// disconnects, and that the outcome of each request is observed.
+func TestRequestVerdict(t *testing.T) {
+	t.Parallel()
+
+	respond := func(accept bool) func(*RPCRequest, chan struct{},
+		chan struct{}) {
+
+		return func(req *RPCRequest, _, _ chan struct{}) {
+			req.Response <- NewChannelAcceptResponse(accept)
+		}
+	}
+	noResponse := func(*RPCRequest, chan struct{}, chan struct{}) {}
+	disconnectClient := func(_ *RPCRequest, disconnect, _ chan struct{}) {
+		close(disconnect)
+	}
+
+	tests := []verdictTest{
+		{
+			name:          "client accepts",
+			client:        respond(true),
+			expectAccept:  true,
+			expectOutcome: OutcomeAccept,
+		},
+		{
+			name:          "client rejects",
+			defaultAccept: true,
+			client:        respond(false),
+			expectAccept:  false,
+			expectOutcome: OutcomeReject,
+		},
+		{
+			name:          "request not picked up, default accept",
+			defaultAccept: true,
+			expectAccept:  true,
+			expectOutcome: OutcomeTimeout,
+		},
+		{
+			name:          "request not picked up, default reject",
+			expectAccept:  false,
+			expectOutcome: OutcomeTimeout,
+		},
+		{
+			name:          "no response, default accept",
+			defaultAccept: true,
+			client:        noResponse,
+			expectAccept:  true,
+			expectOutcome: OutcomeTimeout,
+		},
+		{
+			name:          "no response, default reject",
+			client:        noResponse,
+			expectAccept:  false,
+			expectOutcome: OutcomeTimeout,
+		},
+		{
+			name:          "disconnected, default accept",
+			defaultAccept: true,
+			beforeRequest: func(disconnect, _ chan struct{}) {
+				close(disconnect)
+			},
+			expectAccept:  true,
+			expectOutcome: OutcomeDisconnect,
+		},
+		{
+			name:          "disconnect on request, default accept",
+			defaultAccept: true,
+			client:        disconnectClient,
+			expectAccept:  true,
+			expectOutcome: OutcomeDisconnect,
+		},
+		{
+			name:          "disconnect on request, default reject",
+			client:        disconnectClient,
+			expectAccept:  false,
+			expectOutcome: OutcomeDisconnect,
+		},
+		{
+			name:          "shutdown before request",
+			defaultAccept: true,
+			beforeRequest: func(_, shutdown chan struct{}) {
+				close(shutdown)
+			},
+			expectAccept: false,
+		},
+		{
+			name:          "shutdown before response",
+			defaultAccept: true,
+			client: func(_ *RPCRequest, _, shutdown chan struct{}) {
+				close(shutdown)
+			},
+			expectAccept: false,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			testRequestVerdict(t, test)
+		})
+	}
+}
+
+func testRequestVerdict(t *testing.T, test verdictTest) {
+	var (
+		requests   = make(chan *RPCRequest)
+		disconnect = make(chan struct{})
+		shutdown   = make(chan struct{})
+		outcomes   []string
+	)
+
+	req := &ChannelAcceptRequest{
+		Node: randKey(t),
+		OpenChanMsg: &lnwire.OpenChannel{
+			PendingChannelID: [32]byte{1},
+		},
+	}
+
+	cfg := &VerdictConfig{
+		Timeout:       100 * time.Millisecond,
+		DefaultAccept: test.defaultAccept,
+		Observe: func(observedReq *ChannelAcceptRequest,
+			outcome string, latency time.Duration) {
+
+			require.Equal(t, req, observedReq)
+			require.True(t, latency > 0)
+			outcomes = append(outcomes, outcome)
+		},
+	}
+
+	if test.client != nil {
+		go func() {
+			rpcReq := <-requests
+			require.Equal(t, req, rpcReq.Request)
+			test.client(rpcReq, disconnect, shutdown)
+		}()
+	}
+	if test.beforeRequest != nil {
+		test.beforeRequest(disconnect, shutdown)
+	}
+
+	resp := cfg.RequestVerdict(req, requests, disconnect, shutdown)
+	require.Equal(t, test.expectAccept, resp.Accept)
+
+	if test.expectOutcome == "" {
+		require.Empty(t, outcomes)
+		return
+	}
+	require.Equal(t, []string{test.expectOutcome}, outcomes)
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/btcsuite/btcd/wire"
+	"github.com/cryptomeow/lnd/channeldb"
 	"github.com/cryptomeow/lnd/clock"
 )
 
@@ -178,26 +179,29 @@ func (p *peerLog) addEvent(online bool, time time.Time) {
 	p.stagedEvent = event
 }
 
-// addChannel adds a channel to our log. If we have not tracked any online
-// events for our peer yet, we create one with our peer's current online state
-// so that we know the state that the peer had at channel start, which is
-// required to calculate uptime over the channel's lifetime.
-func (p *peerLog) addChannel(channelPoint wire.OutPoint) error {
+// addChannel adds a channel to our log, which was first seen at the time
+// provided. If this is the only channel we have with the peer, we add an event
+// with our peer's current online state so that we know the state that the
+// peer had at channel start, which is required to calculate uptime over the
+// channel's lifetime.
+func (p *peerLog) addChannel(channelPoint wire.OutPoint,
+	openedAt time.Time) error {
+
 	_, ok := p.channels[channelPoint]
 	if ok {
 		return fmt.Errorf("channel: %v already present", channelPoint)
 	}
 
-	openTime := p.clock.Now()
-	p.channels[channelPoint] = newChannelInfo(openTime)
+	p.channels[channelPoint] = newChannelInfo(openedAt)
 
-	// If we do not have any online events tracked for our peer (which is
-	// the case when we have no other channels open with the peer), we add
-	// an event with the peer's current online state so that we know that
-	// starting state for this peer when a channel was connected (which
-	// allows us to calculate uptime over the lifetime of the channel).
-	if len(p.onlineEvents) == 0 {
-		p.addEvent(p.online, openTime)
+	// If we have no other channels open with the peer, we add an event
+	// with the peer's current online state so that we know that starting
+	// state for this peer when a channel was connected (which allows us to
+	// calculate uptime over the lifetime of the channel). This also applies
+	// to channels that are added on startup with a restored history, since
+	// the peer can't have been online while we were offline.
+	if p.channelCount() == 1 {
+		p.addEvent(p.online, p.clock.Now())
 	}
 
 	return nil
@@ -250,6 +254,164 @@ func (p *peerLog) channelUptime(channelPoint wire.OutPoint) (time.Duration,
 	return now.Sub(channel.openedAt), uptime, nil
 }
 
+// channelUptimeInWindow returns the amount of time that a channel has been
+// monitored for within the window provided, and its uptime over this period.
+// A zero start or end time leaves the window open at that side.
+func (p *peerLog) channelUptimeInWindow(channelPoint wire.OutPoint, start,
+	end time.Time) (time.Duration, time.Duration, error) {
+
+	channel, ok := p.channels[channelPoint]
+	if !ok {
+		return 0, 0, ErrChannelNotFound
+	}
+
+	return p.uptimeInWindow(channel.openedAt, start, end)
+}
+
+// peerUptime returns the amount of time that the peer has been monitored for
+// within the window provided, and its uptime over this period. The peer is
+// monitored from the time we first saw one of our current channels with it.
+// A zero start or end time leaves the window open at that side.
+func (p *peerLog) peerUptime(start, end time.Time) (time.Duration,
+	time.Duration, error) {
+
+	if p.channelCount() == 0 {
+		return 0, 0, ErrChannelNotFound
+	}
+
+	var monitoredFrom time.Time
+	for _, channel := range p.channels {
+		if monitoredFrom.IsZero() ||
+			channel.openedAt.Before(monitoredFrom) {
+
+			monitoredFrom = channel.openedAt
+		}
+	}
+
+	return p.uptimeInWindow(monitoredFrom, start, end)
+}
+
+// uptimeInWindow clamps the window provided to the period between the time we
+// started monitoring and the present, and returns the duration of the clamped
+// window and the uptime over it.
+func (p *peerLog) uptimeInWindow(monitoredFrom, start,
+	end time.Time) (time.Duration, time.Duration, error) {
+
+	now := p.clock.Now()
+
+	if !start.IsZero() && !end.IsZero() && end.Before(start) {
+		return 0, 0, fmt.Errorf("end time: %v before start time: %v",
+			end, start)
+	}
+
+	if start.IsZero() || start.Before(monitoredFrom) {
+		start = monitoredFrom
+	}
+	if end.IsZero() || end.After(now) {
+		end = now
+	}
+
+	// If the window lies entirely outside of the period we monitored, we
+	// have nothing to report.
+	if !end.After(start) {
+		return 0, 0, nil
+	}
+
+	uptime, err := p.uptime(start, end)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return end.Sub(start), uptime, nil
+}
+
+// onlineHistory returns the online events that we have recorded for the peer,
+// including our staged event, in the form that is persisted to disk.
+func (p *peerLog) onlineHistory() []*channeldb.OnlineEvent {
+	events := p.listEvents()
+
+	history := make([]*channeldb.OnlineEvent, 0, len(events))
+	for _, event := range events {
+		history = append(history, &channeldb.OnlineEvent{
+			Timestamp: event.timestamp,
+			Online:    event.eventType == peerOnlineEvent,
+		})
+	}
+
+	return history
+}
+
+// restoreHistory replaces the online events of the peer with the history
+// provided, which was previously returned by onlineHistory.
+func (p *peerLog) restoreHistory(history []*channeldb.OnlineEvent) {
+	p.onlineEvents = make([]*event, 0, len(history))
+	p.stagedEvent = nil
+
+	for _, onlineEvent := range history {
+		eventType := peerOnlineEvent
+		if !onlineEvent.Online {
+			eventType = peerOfflineEvent
+		}
+
+		p.onlineEvents = append(p.onlineEvents, &event{
+			timestamp: onlineEvent.Timestamp,
+			eventType: eventType,
+		})
+	}
+}
+
+// historyStart returns the timestamp of the oldest online event that we have
+// recorded for the peer, or nil if we have no events.
+func (p *peerLog) historyStart() *time.Time {
+	events := p.listEvents()
+	if len(events) == 0 {
+		return nil
+	}
+
+	return &events[0].timestamp
+}
+
+// pruneHistory discards online events that are older than the cutoff. The
+// most recent event before the cutoff is kept and moved up to the cutoff so
+// that we still know the peer's state at that time. Channels that were opened
+// before the cutoff are treated as monitored since the cutoff.
+func (p *peerLog) pruneHistory(cutoff time.Time) {
+	// Find the most recent event that happened before the cutoff. Events
+	// are ordered by ascending timestamp.
+	lastBefore := -1
+	for i, event := range p.onlineEvents {
+		if !event.timestamp.Before(cutoff) {
+			break
+		}
+
+		lastBefore = i
+	}
+
+	switch {
+	// If even our staged event is older than the cutoff, it is the only
+	// event that we need to keep.
+	case p.stagedEvent != nil && p.stagedEvent.timestamp.Before(cutoff):
+		p.onlineEvents = nil
+		p.stagedEvent = &event{
+			timestamp: cutoff,
+			eventType: p.stagedEvent.eventType,
+		}
+
+	case lastBefore >= 0:
+		p.onlineEvents = p.onlineEvents[lastBefore:]
+		p.onlineEvents[0] = &event{
+			timestamp: cutoff,
+			eventType: p.onlineEvents[0].eventType,
+		}
+	}
+
+	for _, channel := range p.channels {
+		if channel.openedAt.Before(cutoff) {
+			channel.openedAt = cutoff
+		}
+	}
+}
+
 // getFlapCount returns the peer's flap count and the timestamp that we last
 // recorded a flap.
 func (p *peerLog) getFlapCount() (int, *time.Time) {
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/btcsuite/btcd/wire"
+	"github.com/cryptomeow/lnd/channeldb"
 	"github.com/cryptomeow/lnd/clock"
 	"github.com/stretchr/testify/require"
 )
@@ -58,7 +59,7 @@ func TestPeerLog(t *testing.T) {
 	chan1 := wire.OutPoint{
 		Index: 1,
 	}
-	require.NoError(t, peerLog.addChannel(chan1))
+	require.NoError(t, peerLog.addChannel(chan1, clock.Now()))
 	require.Equal(t, 1, peerLog.channelCount())
 	assertFlapCount(2, &lastFlap)
 
@@ -88,7 +89,7 @@ func TestPeerLog(t *testing.T) {
 	chan2 := wire.OutPoint{
 		Index: 2,
 	}
-	require.NoError(t, peerLog.addChannel(chan2))
+	require.NoError(t, peerLog.addChannel(chan2, clock.Now()))
 	require.Equal(t, 2, peerLog.channelCount())
 
 	// Progress our time again, so that our peer has now been offline for
@@ -140,7 +141,7 @@ func TestRateLimitAdd(t *testing.T) {
 
 	// Create a channel for our peer log, otherwise it will not track online
 	// events.
-	require.NoError(t, peerLog.addChannel(wire.OutPoint{}))
+	require.NoError(t, peerLog.addChannel(wire.OutPoint{}, mockedClock.Now()))
 
 	// First, we add an event to the event log. Since we have no previous
 	// events, we expect this event to staged immediately.
@@ -562,3 +563,197 @@ func TestUptime(t *testing.T) {
 		})
 	}
 }
+
+// TestUptimeInWindow tests calculation of channel and peer uptime within a
+// window, which is clamped to the period we monitored the channel or peer.
+func TestUptimeInWindow(t *testing.T) {
+	var (
+		fourHoursAgo  = testNow.Add(time.Hour * -4)
+		threeHoursAgo = testNow.Add(time.Hour * -3)
+		twoHoursAgo   = testNow.Add(time.Hour * -2)
+		oneHourAgo    = testNow.Add(time.Hour * -1)
+
+		chan1 = wire.OutPoint{Index: 1}
+		chan2 = wire.OutPoint{Index: 2}
+	)
+
+	// Our peer was online between four and two hours ago, we opened our
+	// first channel three hours ago and our second one an hour ago.
+	peerLog := &peerLog{
+		onlineEvents: []*event{
+			{
+				timestamp: fourHoursAgo,
+				eventType: peerOnlineEvent,
+			},
+			{
+				timestamp: twoHoursAgo,
+				eventType: peerOfflineEvent,
+			},
+		},
+		clock: clock.NewTestClock(testNow),
+		channels: map[wire.OutPoint]*channelInfo{
+			chan1: newChannelInfo(threeHoursAgo),
+			chan2: newChannelInfo(oneHourAgo),
+		},
+	}
+
+	tests := []struct {
+		name              string
+		channel           wire.OutPoint
+		start, end        time.Time
+		expectedMonitored time.Duration
+		expectedUptime    time.Duration
+		expectErr         bool
+	}{
+		{
+			name:              "open window",
+			channel:           chan1,
+			expectedMonitored: time.Hour * 3,
+			expectedUptime:    time.Hour,
+		},
+		{
+			name:              "window starts before open",
+			channel:           chan1,
+			start:             testNow.Add(time.Hour * -5),
+			end:               oneHourAgo,
+			expectedMonitored: time.Hour * 2,
+			expectedUptime:    time.Hour,
+		},
+		{
+			name:              "window starts after open",
+			channel:           chan1,
+			start:             testNow.Add(time.Minute * -150),
+			expectedMonitored: time.Minute * 150,
+			expectedUptime:    time.Minute * 30,
+		},
+		{
+			name:              "window ends in the future",
+			channel:           chan2,
+			end:               testNow.Add(time.Hour),
+			expectedMonitored: time.Hour,
+			expectedUptime:    0,
+		},
+		{
+			name:    "window before open",
+			channel: chan1,
+			start:   testNow.Add(time.Hour * -6),
+			end:     testNow.Add(time.Hour * -5),
+		},
+		{
+			name:      "end before start",
+			channel:   chan1,
+			start:     oneHourAgo,
+			end:       twoHoursAgo,
+			expectErr: true,
+		},
+		{
+			name:      "unknown channel",
+			channel:   wire.OutPoint{Index: 3},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			monitored, uptime, err := peerLog.channelUptimeInWindow(
+				test.channel, test.start, test.end,
+			)
+			require.Equal(t, test.expectErr, err != nil)
+			require.Equal(t, test.expectedMonitored, monitored)
+			require.Equal(t, test.expectedUptime, uptime)
+		})
+	}
+
+	// The peer is monitored since we opened our first channel with it.
+	monitored, uptime, err := peerLog.peerUptime(time.Time{}, time.Time{})
+	require.NoError(t, err)
+	require.Equal(t, time.Hour*3, monitored)
+	require.Equal(t, time.Hour, uptime)
+}
+
+// TestPruneHistory tests that events older than the cutoff are discarded,
+// while the peer's state at the cutoff is preserved.
+func TestPruneHistory(t *testing.T) {
+	var (
+		fourHoursAgo  = testNow.Add(time.Hour * -4)
+		threeHoursAgo = testNow.Add(time.Hour * -3)
+		twoHoursAgo   = testNow.Add(time.Hour * -2)
+		oneHourAgo    = testNow.Add(time.Hour * -1)
+
+		chan1 = wire.OutPoint{Index: 1}
+	)
+
+	peerLog := newPeerLog(clock.NewTestClock(testNow), 0, nil)
+	peerLog.restoreHistory([]*channeldb.OnlineEvent{
+		{
+			Timestamp: fourHoursAgo,
+			Online:    true,
+		},
+		{
+			Timestamp: twoHoursAgo,
+			Online:    false,
+		},
+		{
+			Timestamp: oneHourAgo,
+			Online:    true,
+		},
+	})
+	require.NoError(t, peerLog.addChannel(chan1, fourHoursAgo))
+	require.Equal(t, &fourHoursAgo, peerLog.historyStart())
+
+	// Prune all events before three hours ago. The peer was online at
+	// that time, so we expect our first event to be moved up to the
+	// cutoff, and our channel to be monitored since then.
+	peerLog.pruneHistory(threeHoursAgo)
+	require.Equal(t, []*channeldb.OnlineEvent{
+		{
+			Timestamp: threeHoursAgo,
+			Online:    true,
+		},
+		{
+			Timestamp: twoHoursAgo,
+			Online:    false,
+		},
+		{
+			Timestamp: oneHourAgo,
+			Online:    true,
+		},
+		{
+			Timestamp: testNow,
+			Online:    false,
+		},
+	}, peerLog.onlineHistory())
+
+	lifetime, uptime, err := peerLog.channelUptime(chan1)
+	require.NoError(t, err)
+	require.Equal(t, time.Hour*3, lifetime)
+	require.Equal(t, time.Hour*2, uptime)
+
+	// Prune all events before the present. We expect the online event
+	// from an hour ago to be moved up to the present, followed by the
+	// event that we staged when adding our channel.
+	peerLog.pruneHistory(testNow)
+	require.Equal(t, []*channeldb.OnlineEvent{
+		{
+			Timestamp: testNow,
+			Online:    true,
+		},
+		{
+			Timestamp: testNow,
+			Online:    false,
+		},
+	}, peerLog.onlineHistory())
+
+	// If even our staged event is older than the cutoff, it is the only
+	// event that we keep.
+	cutoff := testNow.Add(time.Hour)
+	peerLog.pruneHistory(cutoff)
+	require.Equal(t, []*channeldb.OnlineEvent{
+		{
+			Timestamp: cutoff,
+			Online:    false,
+		},
+	}, peerLog.onlineHistory())
+}
@@ -3,15 +3,18 @@
 // an event store which tracks events for each channel.
 //
 // Lifespan: the period that the channel has been known to the scoring system.
-// Note that lifespan may not equal the channel's full lifetime because data is
-// not currently persisted.
+// Note that lifespan may not equal the channel's full lifetime, because only
+// the peer's online history is persisted. Channels that exist on startup are
+// considered known since the start of the restored history of their peer.
 //
 // Uptime: the total time within a given period that the channel's remote peer
 // has been online.
 package chanfitness
 
 import (
+	"bytes"
 	"errors"
+	"sort"
 	"sync"
 	"time"
 
@@ -29,6 +32,10 @@ const (
 	// FlapCountFlushRate determines how often we write peer total flap
 	// count to disk.
 	FlapCountFlushRate = time.Hour
+
+	// DefaultHistoryRetention is the default amount of time that the
+	// online history of a peer is retained for.
+	DefaultHistoryRetention = time.Hour * 24 * 90
 )
 
 var (
@@ -59,6 +66,14 @@ type ChannelEventStore struct {
 	// peerRequests serves requests for information about a peer.
 	peerRequests chan peerRequest
 
+	// uptimeRequests serves requests for the uptime of a channel within a
+	// window.
+	uptimeRequests chan uptimeRequest
+
+	// reliabilityRequests serves requests for the least reliable peers
+	// within a window.
+	reliabilityRequests chan reliabilityRequest
+
 	quit chan struct{}
 
 	wg sync.WaitGroup
@@ -92,8 +107,20 @@ type Config struct {
 	ReadFlapCount func(route.Vertex) (*channeldb.FlapCount, error)
 
 	// FlapCountTicker is a ticker which controls how often we flush our
-	// peer's flap count to disk.
+	// peer's flap count and online history to disk.
 	FlapCountTicker ticker.Ticker
+
+	// WriteOnlineHistory records the online history for a set of peers on
+	// disk.
+	WriteOnlineHistory func(map[route.Vertex][]*channeldb.OnlineEvent) error
+
+	// ReadOnlineHistory gets the online history for a peer on disk.
+	ReadOnlineHistory func(route.Vertex) ([]*channeldb.OnlineEvent, error)
+
+	// HistoryRetention is the amount of time that the online history of a
+	// peer is retained for. Older events are discarded when the history
+	// is flushed to disk.
+	HistoryRetention time.Duration
 }
 
 // peerFlapCountMap is the map used to map peers to flap counts, declared here
@@ -122,6 +149,29 @@ type peerResponse struct {
 	err       error
 }
 
+type uptimeRequest struct {
+	peer         route.Vertex
+	channelPoint wire.OutPoint
+	start, end   time.Time
+	responseChan chan uptimeResponse
+}
+
+type uptimeResponse struct {
+	uptime *Uptime
+	err    error
+}
+
+type reliabilityRequest struct {
+	start, end   time.Time
+	numPeers     int
+	responseChan chan reliabilityResponse
+}
+
+type reliabilityResponse struct {
+	peers []*PeerReliability
+	err   error
+}
+
 // NewChannelEventStore initializes an event store with the config provided.
 // Note that this function does not start the main event loop, Start() must be
 // called.
@@ -131,7 +181,10 @@ func NewChannelEventStore(config *Config) *ChannelEventStore {
 		peers:            make(map[route.Vertex]peerMonitor),
 		chanInfoRequests: make(chan channelInfoRequest),
 		peerRequests:     make(chan peerRequest),
+		uptimeRequests:   make(chan uptimeRequest),
 		quit:             make(chan struct{}),
+
+		reliabilityRequests: make(chan reliabilityRequest),
 	}
 
 	return store
@@ -185,7 +238,7 @@ func (c *ChannelEventStore) Start() error {
 
 		// Add existing channels to the channel store with an initial
 		// peer online or offline event.
-		c.addChannel(ch.FundingOutpoint, peerKey)
+		c.restoreChannel(ch.FundingOutpoint, peerKey)
 	}
 
 	// Start a goroutine that consumes events from all subscriptions.
@@ -224,7 +277,31 @@ func (c *ChannelEventStore) addChannel(channelPoint wire.OutPoint,
 		return
 	}
 
-	if err := peerMonitor.addChannel(channelPoint); err != nil {
+	err = peerMonitor.addChannel(channelPoint, c.cfg.Clock.Now())
+	if err != nil {
+		log.Errorf("could not add channel: %v", err)
+	}
+}
+
+// restoreChannel adds a channel that already existed on startup. Since we
+// only record the online history of peers that we have channels with, the
+// channel is considered known since the start of its peer's restored history.
+func (c *ChannelEventStore) restoreChannel(channelPoint wire.OutPoint,
+	peer route.Vertex) {
+
+	peerMonitor, err := c.getPeerMonitor(peer)
+	if err != nil {
+		log.Error("could not create monitor: %v", err)
+		return
+	}
+
+	openedAt := c.cfg.Clock.Now()
+	if start := peerMonitor.historyStart(); start != nil {
+		openedAt = *start
+	}
+
+	err = peerMonitor.addChannel(channelPoint, openedAt)
+	if err != nil {
 		log.Errorf("could not add channel: %v", err)
 	}
 }
@@ -259,10 +336,25 @@ func (c *ChannelEventStore) getPeerMonitor(peer route.Vertex) (peerMonitor,
 		return nil, err
 	}
 
-	peerMonitor = newPeerLog(c.cfg.Clock, flapCount, lastFlap)
-	c.peers[peer] = peerMonitor
+	peerLog := newPeerLog(c.cfg.Clock, flapCount, lastFlap)
+
+	history, err := c.cfg.ReadOnlineHistory(peer)
+	switch err {
+	// If we do not have any records for this peer we start with an empty
+	// history.
+	case channeldb.ErrNoPeerBucket:
+
+	case nil:
+		peerLog.restoreHistory(history)
+
+	// Return if we get an unexpected error.
+	default:
+		return nil, err
+	}
+
+	c.peers[peer] = peerLog
 
-	return peerMonitor, nil
+	return peerLog, nil
 }
 
 // closeChannel records a closed time for a channel, and returns early is the
@@ -323,6 +415,11 @@ func (c *ChannelEventStore) consume(subscriptions *subscriptions) {
 			log.Errorf("error recording flap on shutdown: %v", err)
 		}
 
+		if err := c.recordOnlineHistory(true); err != nil {
+			log.Errorf("error recording online history on "+
+				"shutdown: %v", err)
+		}
+
 		c.wg.Done()
 	}()
 
@@ -398,12 +495,31 @@ func (c *ChannelEventStore) consume(subscriptions *subscriptions) {
 			)
 			req.responseChan <- resp
 
+		// Serve all requests for channel uptime within a window.
+		case req := <-c.uptimeRequests:
+			var resp uptimeResponse
+
+			resp.uptime, resp.err = c.getChanUptime(req)
+			req.responseChan <- resp
+
+		// Serve all requests for our least reliable peers.
+		case req := <-c.reliabilityRequests:
+			var resp reliabilityResponse
+
+			resp.peers, resp.err = c.leastReliablePeers(req)
+			req.responseChan <- resp
+
 		case <-c.cfg.FlapCountTicker.Ticks():
 			if err := c.recordFlapCount(); err != nil {
 				log.Errorf("could not record flap "+
 					"count: %v", err)
 			}
 
+			if err := c.recordOnlineHistory(false); err != nil {
+				log.Errorf("could not record online "+
+					"history: %v", err)
+			}
+
 		// Exit if the store receives the signal to shutdown.
 		case <-c.quit:
 			return
@@ -559,3 +675,210 @@ func (c *ChannelEventStore) recordFlapCount() error {
 
 	return c.cfg.WriteFlapCount(updates)
 }
+
+// recordOnlineHistory prunes the online history of each peer that we are
+// currently tracking to our retention period and records it on disk. Peers
+// without history are recorded too, so that stale history on disk is cleared.
+// On shutdown, an offline event is appended to the recorded history of peers
+// that are currently online, since they can't be reached while we are
+// offline.
+func (c *ChannelEventStore) recordOnlineHistory(shutdown bool) error {
+	now := c.cfg.Clock.Now()
+	cutoff := now.Add(-c.cfg.HistoryRetention)
+
+	updates := make(map[route.Vertex][]*channeldb.OnlineEvent)
+	for peer, monitor := range c.peers {
+		monitor.pruneHistory(cutoff)
+
+		history := monitor.onlineHistory()
+		if shutdown && len(history) > 0 && history[len(history)-1].Online {
+			history = append(history, &channeldb.OnlineEvent{
+				Timestamp: now,
+				Online:    false,
+			})
+		}
+
+		updates[peer] = history
+	}
+
+	log.Debugf("recording online history for: %v peers", len(updates))
+
+	return c.cfg.WriteOnlineHistory(updates)
+}
+
+// Uptime describes how long a channel or peer was monitored for within a
+// window, and how long the peer was online during that time.
+type Uptime struct {
+	// Monitored is the part of the window that we monitored the channel or
+	// peer for.
+	Monitored time.Duration
+
+	// Uptime is the amount of time that the peer was online while we
+	// monitored it.
+	Uptime time.Duration
+}
+
+// Percentage returns the uptime as a percentage of the monitored time. It
+// returns zero if we have not monitored the channel or peer at all.
+func (u *Uptime) Percentage() float64 {
+	if u.Monitored == 0 {
+		return 0
+	}
+
+	return float64(u.Uptime) / float64(u.Monitored) * 100
+}
+
+// PeerReliability describes the reliability of a peer within a window.
+type PeerReliability struct {
+	// Peer is the public key of the peer.
+	Peer route.Vertex
+
+	// Uptime is the uptime of the peer within the window.
+	Uptime
+
+	// FlapCount is the peer's current flap count.
+	FlapCount int
+}
+
+// GetChanUptime returns the uptime of a channel within the window provided. A
+// zero start or end time leaves the window open at that side, so zero values
+// for both return the uptime over the channel's lifespan.
+func (c *ChannelEventStore) GetChanUptime(channelPoint wire.OutPoint,
+	peer route.Vertex, start, end time.Time) (*Uptime, error) {
+
+	request := uptimeRequest{
+		peer:         peer,
+		channelPoint: channelPoint,
+		start:        start,
+		end:          end,
+		responseChan: make(chan uptimeResponse),
+	}
+
+	// Send a request for the channel's uptime to the main event loop, or
+	// return early with an error if the store has already received a
+	// shutdown signal.
+	select {
+	case c.uptimeRequests <- request:
+	case <-c.quit:
+		return nil, errShuttingDown
+	}
+
+	// Return the response we receive on the response channel or exit early
+	// if the store is instructed to exit.
+	select {
+	case resp := <-request.responseChan:
+		return resp.uptime, resp.err
+
+	case <-c.quit:
+		return nil, errShuttingDown
+	}
+}
+
+// getChanUptime gets the uptime of a channel within the requested window.
+func (c *ChannelEventStore) getChanUptime(req uptimeRequest) (*Uptime,
+	error) {
+
+	peerMonitor, ok := c.peers[req.peer]
+	if !ok {
+		return nil, ErrPeerNotFound
+	}
+
+	monitored, uptime, err := peerMonitor.channelUptimeInWindow(
+		req.channelPoint, req.start, req.end,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Uptime{
+		Monitored: monitored,
+		Uptime:    uptime,
+	}, nil
+}
+
+// LeastReliablePeers returns the peers that we have channels with, ranked by
+// ascending uptime percentage within the window provided. Peers with the same
+// uptime percentage are ranked by descending flap count. If numPeers is
+// non-zero, at most that many peers are returned. A zero start or end time
+// leaves the window open at that side.
+func (c *ChannelEventStore) LeastReliablePeers(start, end time.Time,
+	numPeers int) ([]*PeerReliability, error) {
+
+	request := reliabilityRequest{
+		start:        start,
+		end:          end,
+		numPeers:     numPeers,
+		responseChan: make(chan reliabilityResponse),
+	}
+
+	// Send a request for our peers' reliability to the main event loop,
+	// or return early with an error if the store has already received a
+	// shutdown signal.
+	select {
+	case c.reliabilityRequests <- request:
+	case <-c.quit:
+		return nil, errShuttingDown
+	}
+
+	// Return the response we receive on the response channel or exit early
+	// if the store is instructed to exit.
+	select {
+	case resp := <-request.responseChan:
+		return resp.peers, resp.err
+
+	case <-c.quit:
+		return nil, errShuttingDown
+	}
+}
+
+// leastReliablePeers ranks the peers that we have channels with by their
+// uptime within the requested window. Peers that we did not monitor within
+// the window are skipped.
+func (c *ChannelEventStore) leastReliablePeers(
+	req reliabilityRequest) ([]*PeerReliability, error) {
+
+	var peers []*PeerReliability
+	for peer, monitor := range c.peers {
+		if monitor.channelCount() == 0 {
+			continue
+		}
+
+		monitored, uptime, err := monitor.peerUptime(req.start, req.end)
+		if err != nil {
+			return nil, err
+		}
+
+		if monitored == 0 {
+			continue
+		}
+
+		flapCount, _ := monitor.getFlapCount()
+		peers = append(peers, &PeerReliability{
+			Peer: peer,
+			Uptime: Uptime{
+				Monitored: monitored,
+				Uptime:    uptime,
+			},
+			FlapCount: flapCount,
+		})
+	}
+
+	sort.Slice(peers, func(i, j int) bool {
+		iPct, jPct := peers[i].Percentage(), peers[j].Percentage()
+		if iPct != jPct {
+			return iPct < jPct
+		}
+
+		if peers[i].FlapCount != peers[j].FlapCount {
+			return peers[i].FlapCount > peers[j].FlapCount
+		}
+
+		return bytes.Compare(peers[i].Peer[:], peers[j].Peer[:]) < 0
+	})
+
+	if req.numPeers > 0 && len(peers) > req.numPeers {
+		peers = peers[:req.numPeers]
+	}
+
+	return peers, nil
+}
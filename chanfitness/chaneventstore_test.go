@@ -341,3 +341,120 @@ func TestFlapCount(t *testing.T) {
 
 	ctx.stop()
 }
+
+// TestLeastReliablePeers tests querying the store for channel uptime within a
+// window and for the peers with the lowest uptime.
+func TestLeastReliablePeers(t *testing.T) {
+	ctx := newChanEventStoreTestCtx(t)
+	ctx.start()
+
+	now := ctx.clock.Now()
+
+	// Create channels with two peers, and bring only the first one online.
+	// We query the flap count of the peer after the event so that we know
+	// that it was processed before we progress our clock.
+	onlinePeer, _, onlineChan := ctx.createChannel()
+	offlinePeer, _, _ := ctx.createChannel()
+
+	ctx.peerEvent(onlinePeer, true)
+	_, _, err := ctx.store.FlapCount(onlinePeer)
+	require.NoError(t, err)
+
+	now = now.Add(time.Hour)
+	ctx.clock.SetTime(now)
+
+	uptime, err := ctx.store.GetChanUptime(
+		onlineChan, onlinePeer, time.Time{}, time.Time{},
+	)
+	require.NoError(t, err)
+	require.Equal(t, &Uptime{
+		Monitored: time.Hour,
+		Uptime:    time.Hour,
+	}, uptime)
+	require.Equal(t, float64(100), uptime.Percentage())
+
+	// The peer that never came online is the least reliable one.
+	peers, err := ctx.store.LeastReliablePeers(time.Time{}, time.Time{}, 0)
+	require.NoError(t, err)
+	require.Equal(t, []*PeerReliability{
+		{
+			Peer: offlinePeer,
+			Uptime: Uptime{
+				Monitored: time.Hour,
+			},
+		},
+		{
+			Peer: onlinePeer,
+			Uptime: Uptime{
+				Monitored: time.Hour,
+				Uptime:    time.Hour,
+			},
+			FlapCount: 1,
+		},
+	}, peers)
+
+	// Limit our query to a single peer.
+	peers, err = ctx.store.LeastReliablePeers(time.Time{}, time.Time{}, 1)
+	require.NoError(t, err)
+	require.Len(t, peers, 1)
+	require.Equal(t, offlinePeer, peers[0].Peer)
+
+	ctx.stop()
+}
+
+// TestOnlineHistoryRestore tests that the online history of a peer is written
+// on shutdown and restored for channels that exist on startup.
+func TestOnlineHistoryRestore(t *testing.T) {
+	ctx := newChanEventStoreTestCtx(t)
+	ctx.start()
+
+	start := ctx.clock.Now()
+
+	peer, pubKey, channel := ctx.createChannel()
+	ctx.peerEvent(peer, true)
+	_, _, err := ctx.store.FlapCount(peer)
+	require.NoError(t, err)
+
+	// Shut down while our peer is online, an hour after opening our
+	// channel.
+	ctx.clock.SetTime(start.Add(time.Hour))
+	ctx.stop()
+
+	// We expect an offline event to be recorded at shutdown, since our
+	// peer can't be reached while we're offline.
+	require.Equal(t, []*channeldb.OnlineEvent{
+		{
+			Timestamp: start,
+			Online:    true,
+		},
+		{
+			Timestamp: start.Add(time.Hour),
+			Online:    false,
+		},
+	}, ctx.onlineHistories[peer])
+
+	// Restart an hour later with the same channel and history.
+	restarted := newChanEventStoreTestCtx(t)
+	restarted.onlineHistories = ctx.onlineHistories
+	restarted.clock.SetTime(start.Add(time.Hour * 2))
+	restarted.store.cfg.GetOpenChannels = func() ([]*channeldb.OpenChannel,
+		error) {
+
+		return []*channeldb.OpenChannel{
+			{
+				FundingOutpoint: channel,
+				IdentityPub:     pubKey,
+			},
+		}, nil
+	}
+	restarted.start()
+
+	// Our channel is known since the start of the restored history, and
+	// our peer was online for the first hour.
+	info, err := restarted.store.GetChanInfo(channel, peer)
+	require.NoError(t, err)
+	require.Equal(t, time.Hour*2, info.Lifetime)
+	require.Equal(t, time.Hour, info.Uptime)
+
+	restarted.stop()
+}
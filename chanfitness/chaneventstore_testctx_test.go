@@ -46,6 +46,11 @@ type chanEventStoreTestCtx struct {
 	// flapCountUpdates is a channel which receives new flap counts.
 	flapCountUpdates chan peerFlapCountMap
 
+	// onlineHistories stores our most recent set of online histories. It
+	// is only written by the store's main loop, so it must only be read
+	// once the store is stopped.
+	onlineHistories map[route.Vertex][]*channeldb.OnlineEvent
+
 	// stopped is closed when our test context is fully shutdown. It is
 	// used to prevent calling of functions which can only be called after
 	// shutdown.
@@ -62,7 +67,10 @@ func newChanEventStoreTestCtx(t *testing.T) *chanEventStoreTestCtx {
 		clock:               clock.NewTestClock(testNow),
 		flapUpdates:         make(peerFlapCountMap),
 		flapCountUpdates:    make(chan peerFlapCountMap),
-		stopped:             make(chan struct{}),
+		onlineHistories: make(
+			map[route.Vertex][]*channeldb.OnlineEvent,
+		),
+		stopped: make(chan struct{}),
 	}
 
 	cfg := &Config{
@@ -98,6 +106,26 @@ func newChanEventStoreTestCtx(t *testing.T) *chanEventStoreTestCtx {
 			return count, nil
 		},
 		FlapCountTicker: ticker.NewForce(FlapCountFlushRate),
+		WriteOnlineHistory: func(
+			updates map[route.Vertex][]*channeldb.OnlineEvent) error {
+
+			for peer, history := range updates {
+				testCtx.onlineHistories[peer] = history
+			}
+
+			return nil
+		},
+		ReadOnlineHistory: func(peer route.Vertex) (
+			[]*channeldb.OnlineEvent, error) {
+
+			history, ok := testCtx.onlineHistories[peer]
+			if !ok {
+				return nil, channeldb.ErrNoPeerBucket
+			}
+
+			return history, nil
+		},
+		HistoryRetention: DefaultHistoryRetention,
 	}
 
 	testCtx.store = NewChannelEventStore(cfg)
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/btcsuite/btcd/wire"
+	"github.com/cryptomeow/lnd/channeldb"
 )
 
 // peerMonitor is an interface implemented by entities that monitor our peers
@@ -12,8 +13,9 @@ type peerMonitor interface {
 	// event adds an online or offline event.
 	onlineEvent(online bool)
 
-	// addChannel adds a new channel.
-	addChannel(channelPoint wire.OutPoint) error
+	// addChannel adds a new channel that was first seen at the time
+	// provided.
+	addChannel(channelPoint wire.OutPoint, openedAt time.Time) error
 
 	// removeChannel removes a channel.
 	removeChannel(channelPoint wire.OutPoint) error
@@ -27,8 +29,30 @@ type peerMonitor interface {
 	channelUptime(channelPoint wire.OutPoint) (time.Duration,
 		time.Duration, error)
 
+	// channelUptimeInWindow looks up a channel and returns the amount of
+	// time that the channel has been monitored for within the window
+	// provided and its uptime over this period.
+	channelUptimeInWindow(channelPoint wire.OutPoint, start,
+		end time.Time) (time.Duration, time.Duration, error)
+
+	// peerUptime returns the amount of time that the peer has been
+	// monitored for within the window provided and its uptime over this
+	// period.
+	peerUptime(start, end time.Time) (time.Duration, time.Duration, error)
+
 	// getFlapCount returns the peer's flap count and the timestamp that we
 	// last recorded a flap, which may be nil if we have never recorded a
 	// flap for this peer.
 	getFlapCount() (int, *time.Time)
+
+	// onlineHistory returns the online events recorded for the peer in
+	// the form that is persisted to disk.
+	onlineHistory() []*channeldb.OnlineEvent
+
+	// historyStart returns the timestamp of the oldest online event
+	// recorded for the peer, or nil if there are none.
+	historyStart() *time.Time
+
+	// pruneHistory discards online events that are older than the cutoff.
+	pruneHistory(cutoff time.Time)
 }
@@ -0,0 +1,417 @@
+// Package chanmonitor watches the funding outputs of the channels stored in a
+// channel database and reports how they are closed on chain, without acting on
+// the closes. It is meant for a standby node that shares a replicated channel
+// database with another, active node, and holds no state of its own.
+package chanmonitor
+
+import (
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/cryptomeow/lnd/chainntnfs"
+	"github.com/cryptomeow/lnd/channeldb"
+	"github.com/cryptomeow/lnd/input"
+	"github.com/cryptomeow/lnd/lnwallet"
+)
+
+// DefaultRefreshInterval is the default interval at which the set of monitored
+// channels is refreshed from the channel database.
+const DefaultRefreshInterval = time.Minute
+
+// CloseType describes how the funding output of a channel was spent.
+type CloseType uint8
+
+const (
+	// CloseCooperative is a cooperative close of the channel.
+	CloseCooperative CloseType = iota
+
+	// CloseLocalForce is a force close using the latest local commitment.
+	CloseLocalForce
+
+	// CloseRemoteForce is a force close using the latest or the pending
+	// remote commitment.
+	CloseRemoteForce
+
+	// CloseBreach is a force close using a revoked remote commitment.
+	CloseBreach
+
+	// CloseFutureState is a force close using a remote commitment beyond
+	// the latest one known. This happens if the channel database the
+	// channel was loaded from lags behind the active node, or if the
+	// active node lost data.
+	CloseFutureState
+)
+
+// String returns a human readable name of the close type.
+func (c CloseType) String() string {
+	switch c {
+	case CloseCooperative:
+		return "cooperative"
+	case CloseLocalForce:
+		return "local_force"
+	case CloseRemoteForce:
+		return "remote_force"
+	case CloseBreach:
+		return "breach"
+	case CloseFutureState:
+		return "future_state"
+	default:
+		return "unknown"
+	}
+}
+
+// CloseEvent describes a detected spend of the funding output of a monitored
+// channel.
+type CloseEvent struct {
+	// ChanPoint is the funding outpoint of the channel.
+	ChanPoint wire.OutPoint
+
+	// Type is the detected type of the close.
+	Type CloseType
+
+	// CloseTxid is the hash of the transaction spending the funding
+	// output.
+	CloseTxid chainhash.Hash
+
+	// CloseHeight is the height at which the spending transaction
+	// confirmed.
+	CloseHeight uint32
+
+	// StateNum is the commitment height encoded in the spending
+	// transaction. It is zero for cooperative closes.
+	StateNum uint64
+
+	// KnownStateNum is the latest remote commitment height that was known
+	// when the close was detected.
+	KnownStateNum uint64
+
+	// DetectTime is the time at which the close was detected.
+	DetectTime time.Time
+}
+
+// Config holds the dependencies of the Monitor.
+type Config struct {
+	// FetchChannels returns the channels to monitor. It is called
+	// periodically, so channels opened by the active node are picked up.
+	FetchChannels func() ([]*channeldb.OpenChannel, error)
+
+	// Notifier is used to learn about spends of the funding outputs.
+	Notifier chainntnfs.ChainNotifier
+
+	// RefreshInterval is the interval at which the channels are fetched
+	// again. If zero, DefaultRefreshInterval is used.
+	RefreshInterval time.Duration
+}
+
+// watchedChannel is a channel whose funding output is watched.
+type watchedChannel struct {
+	// channel is the latest state of the channel that was fetched. It is
+	// protected by the mutex of the monitor.
+	channel *channeldb.OpenChannel
+
+	spend *chainntnfs.SpendEvent
+}
+
+// Monitor watches the funding outputs of a set of channels and records how
+// they are spent. It never writes to the channel database, signs or broadcasts
+// anything.
+type Monitor struct {
+	started sync.Once
+	stopped sync.Once
+
+	cfg *Config
+
+	// mu protects the watched channels, the closed channels and the
+	// recorded events.
+	mu      sync.Mutex
+	watched map[wire.OutPoint]*watchedChannel
+	closed  map[wire.OutPoint]struct{}
+	events  []*CloseEvent
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// New creates a new Monitor from the given config.
+func New(cfg *Config) *Monitor {
+	return &Monitor{
+		cfg:     cfg,
+		watched: make(map[wire.OutPoint]*watchedChannel),
+		closed:  make(map[wire.OutPoint]struct{}),
+		quit:    make(chan struct{}),
+	}
+}
+
+// Start fetches the channels to monitor, and launches the goroutine that
+// refreshes them periodically.
+func (m *Monitor) Start() error {
+	var startErr error
+	m.started.Do(func() {
+		log.Infof("Channel monitor starting")
+
+		if err := m.refresh(); err != nil {
+			startErr = err
+			return
+		}
+
+		m.wg.Add(1)
+		go m.refreshHandler()
+	})
+
+	return startErr
+}
+
+// Stop stops monitoring all channels.
+func (m *Monitor) Stop() {
+	m.stopped.Do(func() {
+		log.Infof("Channel monitor shutting down")
+
+		close(m.quit)
+		m.wg.Wait()
+
+		m.mu.Lock()
+		for _, w := range m.watched {
+			w.spend.Cancel()
+		}
+		m.mu.Unlock()
+	})
+}
+
+// NumChannels returns the number of channels whose funding outputs are
+// watched.
+func (m *Monitor) NumChannels() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.watched)
+}
+
+// Events returns the closes detected so far, oldest first.
+func (m *Monitor) Events() []CloseEvent {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	events := make([]CloseEvent, 0, len(m.events))
+	for _, event := range m.events {
+		events = append(events, *event)
+	}
+
+	return events
+}
+
+// refreshHandler periodically refreshes the set of monitored channels.
+//
+// NOTE: This method MUST be run as a goroutine.
+func (m *Monitor) refreshHandler() {
+	defer m.wg.Done()
+
+	interval := m.cfg.RefreshInterval
+	if interval == 0 {
+		interval = DefaultRefreshInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := m.refresh(); err != nil {
+				log.Errorf("Unable to refresh monitored "+
+					"channels: %v", err)
+			}
+
+		case <-m.quit:
+			return
+		}
+	}
+}
+
+// refresh fetches the channels to monitor. Newly found channels are watched
+// and the state of already watched channels is updated. Channels that are no
+// longer returned are still watched until their funding output is spent, as
+// the active node may have removed a channel it closed before the close was
+// detected here.
+func (m *Monitor) refresh() error {
+	channels, err := m.cfg.FetchChannels()
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, channel := range channels {
+		// Channels that were closed may still be returned until the
+		// active node resolved the close.
+		if _, ok := m.closed[channel.FundingOutpoint]; ok {
+			continue
+		}
+
+		if w, ok := m.watched[channel.FundingOutpoint]; ok {
+			w.channel = channel
+			continue
+		}
+
+		if err := m.watch(channel); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// watch registers for the spend of the funding output of the channel and
+// launches a goroutine that waits for it.
+//
+// NOTE: The mutex of the monitor MUST be held.
+func (m *Monitor) watch(channel *channeldb.OpenChannel) error {
+	localKey := channel.LocalChanCfg.MultiSigKey.PubKey
+	remoteKey := channel.RemoteChanCfg.MultiSigKey.PubKey
+	multiSigScript, err := input.GenMultiSigScript(
+		localKey.SerializeCompressed(), remoteKey.SerializeCompressed(),
+	)
+	if err != nil {
+		return err
+	}
+	pkScript, err := input.WitnessScriptHash(multiSigScript)
+	if err != nil {
+		return err
+	}
+
+	// As a height hint, we'll try to use the opening height, but if the
+	// channel isn't yet open, then we'll use the height it was broadcast
+	// at.
+	heightHint := channel.ShortChanID().BlockHeight
+	if heightHint == 0 {
+		heightHint = channel.FundingBroadcastHeight
+	}
+
+	spend, err := m.cfg.Notifier.RegisterSpendNtfn(
+		&channel.FundingOutpoint, pkScript, heightHint,
+	)
+	if err != nil {
+		return err
+	}
+
+	log.Debugf("Monitoring ChannelPoint(%v)", channel.FundingOutpoint)
+
+	w := &watchedChannel{
+		channel: channel,
+		spend:   spend,
+	}
+	m.watched[channel.FundingOutpoint] = w
+
+	m.wg.Add(1)
+	go m.closeObserver(channel.FundingOutpoint, w)
+
+	return nil
+}
+
+// closeObserver waits for the funding output of a channel to be spent and
+// records the close.
+//
+// NOTE: This method MUST be run as a goroutine.
+func (m *Monitor) closeObserver(chanPoint wire.OutPoint, w *watchedChannel) {
+	defer m.wg.Done()
+
+	var spend *chainntnfs.SpendDetail
+	select {
+	case detail, ok := <-w.spend.Spend:
+		if !ok {
+			return
+		}
+		spend = detail
+
+	case <-m.quit:
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// The channel is classified using its latest fetched state.
+	channel := w.channel
+	closeType, stateNum := classifySpend(channel, spend.SpendingTx)
+	event := &CloseEvent{
+		ChanPoint:     chanPoint,
+		Type:          closeType,
+		CloseTxid:     *spend.SpenderTxHash,
+		CloseHeight:   uint32(spend.SpendingHeight),
+		StateNum:      stateNum,
+		KnownStateNum: channel.RemoteCommitment.CommitHeight,
+		DetectTime:    time.Now(),
+	}
+	m.events = append(m.events, event)
+
+	// The channel is closed, so there's nothing left to watch.
+	delete(m.watched, chanPoint)
+	m.closed[chanPoint] = struct{}{}
+
+	switch closeType {
+	case CloseBreach, CloseFutureState:
+		log.Warnf("ChannelPoint(%v) closed on chain by %v at "+
+			"height %v: remote broadcast state #%v, latest known "+
+			"state is #%v", chanPoint, event.CloseTxid,
+			event.CloseHeight, stateNum, event.KnownStateNum)
+
+	default:
+		log.Infof("ChannelPoint(%v) closed on chain by %v at "+
+			"height %v (%v)", chanPoint, event.CloseTxid,
+			event.CloseHeight, closeType)
+	}
+}
+
+// classifySpend determines the type of the close of the channel by the given
+// transaction spending its funding output. It relies only on public data of
+// the channel state, so no keys are needed. For force closes, the commitment
+// height encoded in the transaction is returned as well.
+func classifySpend(channel *channeldb.OpenChannel,
+	spendTx *wire.MsgTx) (CloseType, uint64) {
+
+	localCommitTx := channel.LocalCommitment.CommitTx
+	if localCommitTx != nil && localCommitTx.TxHash() == spendTx.TxHash() {
+		return CloseLocalForce, channel.LocalCommitment.CommitHeight
+	}
+
+	// A cooperative close is characterized by having an input sequence
+	// number that's finalized. This won't happen with regular commitment
+	// transactions due to the state hint encoding scheme.
+	if spendTx.TxIn[0].Sequence == wire.MaxTxInSequenceNum {
+		return CloseCooperative, 0
+	}
+
+	obfuscator := stateHintObfuscator(channel)
+	stateNum := lnwallet.GetStateNumHint(spendTx, obfuscator)
+
+	remoteHeight := channel.RemoteCommitment.CommitHeight
+	switch {
+	case stateNum < remoteHeight:
+		return CloseBreach, stateNum
+
+	case stateNum <= remoteHeight+1:
+		return CloseRemoteForce, stateNum
+
+	default:
+		return CloseFutureState, stateNum
+	}
+}
+
+// stateHintObfuscator returns the obfuscator of the state numbers encoded in
+// the commitment transactions of the channel.
+func stateHintObfuscator(
+	channel *channeldb.OpenChannel) [lnwallet.StateHintSize]byte {
+
+	if channel.IsInitiator {
+		return lnwallet.DeriveStateHintObfuscator(
+			channel.LocalChanCfg.PaymentBasePoint.PubKey,
+			channel.RemoteChanCfg.PaymentBasePoint.PubKey,
+		)
+	}
+
+	return lnwallet.DeriveStateHintObfuscator(
+		channel.RemoteChanCfg.PaymentBasePoint.PubKey,
+		channel.LocalChanCfg.PaymentBasePoint.PubKey,
+	)
+}
@@ -0,0 +1,183 @@
+package chanmonitor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/cryptomeow/lnd/chainntnfs"
+	"github.com/cryptomeow/lnd/channeldb"
+	"github.com/cryptomeow/lnd/keychain"
+	"github.com/cryptomeow/lnd/lntest/mock"
+	"github.com/cryptomeow/lnd/lnwallet"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestChannel creates a channel with random keys whose latest local and
+// remote commitments are at the given height.
+func newTestChannel(t *testing.T, height uint64) *channeldb.OpenChannel {
+	newKey := func() keychain.KeyDescriptor {
+		priv, err := btcec.NewPrivateKey(btcec.S256())
+		require.NoError(t, err)
+
+		return keychain.KeyDescriptor{PubKey: priv.PubKey()}
+	}
+
+	channel := &channeldb.OpenChannel{
+		FundingOutpoint: wire.OutPoint{Index: 1},
+		IsInitiator:     true,
+		LocalChanCfg: channeldb.ChannelConfig{
+			MultiSigKey:      newKey(),
+			PaymentBasePoint: newKey(),
+		},
+		RemoteChanCfg: channeldb.ChannelConfig{
+			MultiSigKey:      newKey(),
+			PaymentBasePoint: newKey(),
+		},
+		RemoteCommitment: channeldb.ChannelCommitment{
+			CommitHeight: height,
+		},
+	}
+
+	// Our commitment pays to other scripts than the remote commitment of
+	// the same height, which we mimic by an additional output.
+	localCommitTx := newCommitTx(t, channel, height)
+	localCommitTx.AddTxOut(&wire.TxOut{Value: 1})
+	channel.LocalCommitment = channeldb.ChannelCommitment{
+		CommitHeight: height,
+		CommitTx:     localCommitTx,
+	}
+
+	return channel
+}
+
+// newCommitTx creates a commitment transaction of the channel that encodes the
+// given state number.
+func newCommitTx(t *testing.T, channel *channeldb.OpenChannel,
+	stateNum uint64) *wire.MsgTx {
+
+	tx := wire.NewMsgTx(2)
+	tx.AddTxIn(&wire.TxIn{PreviousOutPoint: channel.FundingOutpoint})
+	tx.AddTxOut(&wire.TxOut{Value: int64(stateNum) + 1000})
+
+	err := lnwallet.SetStateNumHint(
+		tx, stateNum, stateHintObfuscator(channel),
+	)
+	require.NoError(t, err)
+
+	return tx
+}
+
+// TestClassifySpend asserts that the spends of funding outputs are classified
+// by the state they commit to.
+func TestClassifySpend(t *testing.T) {
+	t.Parallel()
+
+	const height = 10
+	channel := newTestChannel(t, height)
+
+	coopTx := wire.NewMsgTx(2)
+	coopTx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: channel.FundingOutpoint,
+		Sequence:         wire.MaxTxInSequenceNum,
+	})
+
+	tests := []struct {
+		name          string
+		tx            *wire.MsgTx
+		expectedType  CloseType
+		expectedState uint64
+	}{
+		{
+			name:          "cooperative",
+			tx:            coopTx,
+			expectedType:  CloseCooperative,
+			expectedState: 0,
+		},
+		{
+			name:          "local force",
+			tx:            channel.LocalCommitment.CommitTx,
+			expectedType:  CloseLocalForce,
+			expectedState: height,
+		},
+		{
+			name:          "remote force",
+			tx:            newCommitTx(t, channel, height),
+			expectedType:  CloseRemoteForce,
+			expectedState: height,
+		},
+		{
+			name:          "remote force pending",
+			tx:            newCommitTx(t, channel, height+1),
+			expectedType:  CloseRemoteForce,
+			expectedState: height + 1,
+		},
+		{
+			name:          "breach",
+			tx:            newCommitTx(t, channel, height-3),
+			expectedType:  CloseBreach,
+			expectedState: height - 3,
+		},
+		{
+			name:          "future state",
+			tx:            newCommitTx(t, channel, height+5),
+			expectedType:  CloseFutureState,
+			expectedState: height + 5,
+		},
+	}
+
+	for _, test := range tests {
+		closeType, stateNum := classifySpend(channel, test.tx)
+		require.Equal(t, test.expectedType, closeType, test.name)
+		require.Equal(t, test.expectedState, stateNum, test.name)
+	}
+}
+
+// TestMonitorDetectsBreach asserts that the monitor records a spend of the
+// funding output of a fetched channel exactly once.
+func TestMonitorDetectsBreach(t *testing.T) {
+	t.Parallel()
+
+	channel := newTestChannel(t, 5)
+	notifier := &mock.ChainNotifier{
+		SpendChan: make(chan *chainntnfs.SpendDetail, 1),
+	}
+
+	m := New(&Config{
+		FetchChannels: func() ([]*channeldb.OpenChannel, error) {
+			return []*channeldb.OpenChannel{channel}, nil
+		},
+		Notifier: notifier,
+	})
+	require.NoError(t, m.Start())
+	defer m.Stop()
+
+	require.Equal(t, 1, m.NumChannels())
+
+	breachTx := newCommitTx(t, channel, 2)
+	breachTxid := breachTx.TxHash()
+	notifier.SpendChan <- &chainntnfs.SpendDetail{
+		SpentOutPoint:  &channel.FundingOutpoint,
+		SpenderTxHash:  &breachTxid,
+		SpendingTx:     breachTx,
+		SpendingHeight: 100,
+	}
+
+	require.Eventually(t, func() bool {
+		return len(m.Events()) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	event := m.Events()[0]
+	require.Equal(t, channel.FundingOutpoint, event.ChanPoint)
+	require.Equal(t, CloseBreach, event.Type)
+	require.Equal(t, breachTxid, event.CloseTxid)
+	require.Equal(t, uint32(100), event.CloseHeight)
+	require.Equal(t, uint64(2), event.StateNum)
+	require.Equal(t, uint64(5), event.KnownStateNum)
+
+	// The channel may still be returned until the active node resolved
+	// the close, but it must not be watched again.
+	require.NoError(t, m.refresh())
+	require.Equal(t, 0, m.NumChannels())
+}
@@ -66,6 +66,17 @@ var (
 	// shutdown script for the remote peer.
 	remoteUpfrontShutdownKey = []byte("remote-upfront-shutdown-key")
 
+	// chanTypeUpgradeKey can be accessed within the bucket for a channel
+	// (identified by its chanPoint). This key stores the channel type
+	// prior to an in-place upgrade of the channel, along with the
+	// commitment heights from which on the upgraded type is used.
+	chanTypeUpgradeKey = []byte("chan-type-upgrade-key")
+
+	// commitFeeRateBoundsKey can be accessed within the bucket for a
+	// channel (identified by its chanPoint). This key stores the minimum
+	// and maximum fee rate of our commitment set by the user, if any.
+	commitFeeRateBoundsKey = []byte("commit-fee-rate-bounds-key")
+
 	// chanCommitmentKey can be accessed within the sub-bucket for a
 	// particular channel. This key stores the up to date commitment state
 	// for a particular channel party. Appending a 0 to the end of this key
@@ -171,6 +182,15 @@ var (
 	// a height that we have not reached yet is made.
 	errHeightNotReached = fmt.Errorf("height requested greater than " +
 		"current commit height")
+
+	// ErrChanAlreadyUpgraded is returned when a caller attempts to upgrade
+	// the type of a channel that has already been upgraded.
+	ErrChanAlreadyUpgraded = fmt.Errorf("channel type already upgraded")
+
+	// ErrChanUpgradeHtlcs is returned when a caller attempts to upgrade
+	// the type of a channel that still has HTLCs on its commitments.
+	ErrChanUpgradeHtlcs = fmt.Errorf("cannot upgrade channel type with " +
+		"active HTLCs")
 )
 
 // ChannelType is an enum-like type that describes one of several possible
@@ -251,6 +271,31 @@ func (c ChannelType) IsFrozen() bool {
 	return c&FrozenBit == FrozenBit
 }
 
+// IsValidUpgrade returns true if a channel of type c can be upgraded in place
+// to the given type. An upgrade may only add the tweakless and anchor output
+// bits, and the anchor commitment format requires a tweakless commitment.
+func (c ChannelType) IsValidUpgrade(newType ChannelType) bool {
+	const upgradeBits = SingleFunderTweaklessBit | AnchorOutputsBit
+
+	switch {
+	case newType == c:
+		return false
+
+	// All bits that aren't related to the commitment format must be left
+	// untouched, and none of the current bits may be removed.
+	case newType&^upgradeBits != c&^upgradeBits:
+		return false
+
+	case newType&c != c:
+		return false
+
+	case newType.HasAnchors() && !newType.IsTweakless():
+		return false
+	}
+
+	return true
+}
+
 // ChannelConstraints represents a set of constraints meant to allow a node to
 // limit their exposure, enact flow control and ensure that all HTLCs are
 // economically relevant. This struct will be mirrored for both sides of the
@@ -663,12 +708,39 @@ type OpenChannel struct {
 	// was not set, the field is empty.
 	RemoteShutdownScript lnwire.DeliveryAddress
 
+	// PrevChanType is the type of the channel before its commitment
+	// format was upgraded in place. It is only meaningful if the channel
+	// has been upgraded, which can be checked with the IsUpgraded method.
+	PrevChanType ChannelType
+
+	// UpgradeLocalHeight is the height of our first commitment using the
+	// upgraded channel type. Our commitments below this height use
+	// PrevChanType. This is zero if the channel was never upgraded.
+	UpgradeLocalHeight uint64
+
+	// UpgradeRemoteHeight is the height of the remote party's first
+	// commitment using the upgraded channel type. Their commitments below
+	// this height use PrevChanType. This is zero if the channel was never
+	// upgraded.
+	UpgradeRemoteHeight uint64
+
 	// ThawHeight is the height when a frozen channel once again becomes a
 	// normal channel. If this is zero, then there're no restrictions on
 	// this channel. If the value is lower than 500,000, then it's
 	// interpreted as a relative height, or an absolute height otherwise.
 	ThawHeight uint32
 
+	// MinCommitFeeRate is the lowest fee rate in sat/kw that we'll propose
+	// for the commitment of a channel we initiated, regardless of the fee
+	// estimate. If this is zero, the fee rate isn't pinned from below.
+	MinCommitFeeRate btcutil.Amount
+
+	// MaxCommitFeeRate is the highest fee rate in sat/kw that we'll
+	// propose for the commitment of a channel we initiated, regardless of
+	// the fee estimate. If this is zero, the fee rate isn't pinned from
+	// above.
+	MaxCommitFeeRate btcutil.Amount
+
 	// TODO(roasbeef): eww
 	Db *DB
 
@@ -685,6 +757,144 @@ func (c *OpenChannel) ShortChanID() lnwire.ShortChannelID {
 	return c.ShortChannelID
 }
 
+// IsUpgraded returns true if the channel type was upgraded in place after the
+// channel was opened.
+func (c *OpenChannel) IsUpgraded() bool {
+	return c.UpgradeLocalHeight != 0
+}
+
+// LocalCommitType returns the channel type our commitment at the given height
+// was created with.
+func (c *OpenChannel) LocalCommitType(height uint64) ChannelType {
+	if c.IsUpgraded() && height < c.UpgradeLocalHeight {
+		return c.PrevChanType
+	}
+
+	return c.ChanType
+}
+
+// RemoteCommitType returns the channel type the remote party's commitment at
+// the given height was created with.
+func (c *OpenChannel) RemoteCommitType(height uint64) ChannelType {
+	if c.IsUpgraded() && height < c.UpgradeRemoteHeight {
+		return c.PrevChanType
+	}
+
+	return c.ChanType
+}
+
+// UpgradeChanType upgrades the commitment format of the channel in place to
+// the given channel type. The next commitments of both parties will be created
+// using the new type, while all prior commitments retain their original type.
+// The channel must not have any HTLCs or pending commitments when it is
+// upgraded, and it can only be upgraded once.
+func (c *OpenChannel) UpgradeChanType(newType ChannelType) error {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.IsUpgraded() {
+		return ErrChanAlreadyUpgraded
+	}
+	if !c.ChanType.IsValidUpgrade(newType) {
+		return fmt.Errorf("invalid channel type upgrade from %v to %v",
+			c.ChanType, newType)
+	}
+	if len(c.LocalCommitment.Htlcs) != 0 ||
+		len(c.RemoteCommitment.Htlcs) != 0 {
+
+		return ErrChanUpgradeHtlcs
+	}
+
+	if err := kvdb.Update(c.Db, func(tx kvdb.RwTx) error {
+		chanBucket, err := fetchChanBucketRw(
+			tx, c.IdentityPub, &c.FundingOutpoint, c.ChainHash,
+		)
+		if err != nil {
+			return err
+		}
+
+		// The upgrade must happen atomically with respect to any
+		// commitment the remote party may already have received.
+		pendingCommit := chanBucket.Get(commitDiffKey)
+		if pendingCommit != nil {
+			return fmt.Errorf("cannot upgrade channel type with " +
+				"pending remote commitment")
+		}
+
+		channel, err := fetchOpenChannel(chanBucket, &c.FundingOutpoint)
+		if err != nil {
+			return err
+		}
+
+		channel.PrevChanType = channel.ChanType
+		channel.ChanType = newType
+		channel.UpgradeLocalHeight = channel.LocalCommitment.CommitHeight + 1
+		channel.UpgradeRemoteHeight = channel.RemoteCommitment.CommitHeight + 1
+
+		return putChanInfo(chanBucket, channel)
+	}, func() {}); err != nil {
+		return err
+	}
+
+	c.PrevChanType = c.ChanType
+	c.ChanType = newType
+	c.UpgradeLocalHeight = c.LocalCommitment.CommitHeight + 1
+	c.UpgradeRemoteHeight = c.RemoteCommitment.CommitHeight + 1
+
+	return nil
+}
+
+// CommitFeeRateBounds returns the minimum and maximum fee rate in sat/kw that
+// the commitment fee rate is pinned to. A bound of zero isn't enforced.
+func (c *OpenChannel) CommitFeeRateBounds() (btcutil.Amount, btcutil.Amount) {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.MinCommitFeeRate, c.MaxCommitFeeRate
+}
+
+// SetCommitFeeRateBounds persists the minimum and maximum fee rate in sat/kw
+// that the commitment fee rate is pinned to. A bound of zero isn't enforced,
+// so setting both to zero removes the pinning.
+func (c *OpenChannel) SetCommitFeeRateBounds(minFeeRate,
+	maxFeeRate btcutil.Amount) error {
+
+	c.Lock()
+	defer c.Unlock()
+
+	if maxFeeRate != 0 && minFeeRate > maxFeeRate {
+		return fmt.Errorf("minimum commit fee rate %v exceeds "+
+			"maximum commit fee rate %v", minFeeRate, maxFeeRate)
+	}
+
+	if err := kvdb.Update(c.Db, func(tx kvdb.RwTx) error {
+		chanBucket, err := fetchChanBucketRw(
+			tx, c.IdentityPub, &c.FundingOutpoint, c.ChainHash,
+		)
+		if err != nil {
+			return err
+		}
+
+		if minFeeRate == 0 && maxFeeRate == 0 {
+			return chanBucket.Delete(commitFeeRateBoundsKey)
+		}
+
+		var b bytes.Buffer
+		if err := WriteElements(&b, minFeeRate, maxFeeRate); err != nil {
+			return err
+		}
+
+		return chanBucket.Put(commitFeeRateBoundsKey, b.Bytes())
+	}, func() {}); err != nil {
+		return err
+	}
+
+	c.MinCommitFeeRate = minFeeRate
+	c.MaxCommitFeeRate = maxFeeRate
+
+	return nil
+}
+
 // ChanStatus returns the current ChannelStatus of this channel.
 func (c *OpenChannel) ChanStatus() ChannelStatus {
 	c.RLock()
@@ -904,6 +1114,40 @@ func (c *OpenChannel) MarkAsOpen(openLoc lnwire.ShortChannelID) error {
 	return nil
 }
 
+// MarkAsPending moves a previously opened channel back into the pending state.
+// This is used when the funding transaction of the channel is reorged out of
+// the chain. The short channel ID is kept until the funding transaction
+// confirms again, and the channel is marked open once more through
+// MarkAsOpen.
+func (c *OpenChannel) MarkAsPending() error {
+	c.Lock()
+	defer c.Unlock()
+
+	if err := kvdb.Update(c.Db, func(tx kvdb.RwTx) error {
+		chanBucket, err := fetchChanBucket(
+			tx, c.IdentityPub, &c.FundingOutpoint, c.ChainHash,
+		)
+		if err != nil {
+			return err
+		}
+
+		channel, err := fetchOpenChannel(chanBucket, &c.FundingOutpoint)
+		if err != nil {
+			return err
+		}
+
+		channel.IsPending = true
+
+		return putOpenChannel(chanBucket.(kvdb.RwBucket), channel)
+	}, func() {}); err != nil {
+		return err
+	}
+
+	c.IsPending = true
+
+	return nil
+}
+
 // MarkDataLoss marks sets the channel status to LocalDataLoss and stores the
 // passed commitPoint for use to retrieve funds in case the remote force closes
 // the channel.
@@ -3149,6 +3393,23 @@ func putChanInfo(chanBucket kvdb.RwBucket, channel *OpenChannel) error {
 		return err
 	}
 
+	// If the channel type was upgraded in place, we'll also store the
+	// prior type and the heights from which on the new type is used.
+	if channel.IsUpgraded() {
+		var b bytes.Buffer
+		if err := WriteElements(&b,
+			channel.PrevChanType, channel.UpgradeLocalHeight,
+			channel.UpgradeRemoteHeight,
+		); err != nil {
+			return err
+		}
+
+		err := chanBucket.Put(chanTypeUpgradeKey, b.Bytes())
+		if err != nil {
+			return err
+		}
+	}
+
 	// Finally, add optional shutdown scripts for the local and remote peer if
 	// they are present.
 	if err := putOptionalUpfrontShutdownScript(
@@ -3321,6 +3582,29 @@ func fetchChanInfo(chanBucket kvdb.RBucket, channel *OpenChannel) error {
 
 	channel.Packager = NewChannelPackager(channel.ShortChannelID)
 
+	// Read the upgrade information if the channel type was upgraded in
+	// place.
+	if upgradeBytes := chanBucket.Get(chanTypeUpgradeKey); upgradeBytes != nil {
+		err := ReadElements(bytes.NewReader(upgradeBytes),
+			&channel.PrevChanType, &channel.UpgradeLocalHeight,
+			&channel.UpgradeRemoteHeight,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Read the commitment fee rate bounds if the user pinned them.
+	boundsBytes := chanBucket.Get(commitFeeRateBoundsKey)
+	if boundsBytes != nil {
+		err := ReadElements(bytes.NewReader(boundsBytes),
+			&channel.MinCommitFeeRate, &channel.MaxCommitFeeRate,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Finally, read the optional shutdown scripts.
 	if err := getOptionalUpfrontShutdownScript(
 		chanBucket, localUpfrontShutdownKey, &channel.LocalShutdownScript,
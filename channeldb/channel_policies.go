@@ -0,0 +1,468 @@
+package channeldb
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/cryptomeow/lnd/channeldb/kvdb"
+	"github.com/cryptomeow/lnd/lnwire"
+)
+
+var (
+	// channelPoliciesBucket is a top level bucket that stores the named
+	// policy templates that can be applied to our channels, and the history
+	// of the forwarding policies that were applied to our channels.
+	//
+	// channel-policies
+	//      |
+	//      |-- templates
+	//      |       |-- <name>: <policy template>
+	//      |
+	//      |-- history
+	//              |-- <timestamp>: <policy change>
+	channelPoliciesBucket = []byte("channel-policies")
+
+	// policyTemplatesBucket is a sub-bucket of the channel policies bucket
+	// that maps the name of a policy template to the serialized template.
+	policyTemplatesBucket = []byte("templates")
+
+	// policyHistoryBucket is a sub-bucket of the channel policies bucket
+	// that stores the policy changes of our channels. Each key is a
+	// timestamp (in nano seconds since the unix epoch) and the value a
+	// serialized PolicyChange.
+	policyHistoryBucket = []byte("history")
+
+	// ErrPolicyTemplateNotFound is returned when a policy template can't be
+	// found.
+	ErrPolicyTemplateNotFound = errors.New("policy template not found")
+
+	// ErrEmptyPolicyTemplateName is returned when a policy template without
+	// a name is stored.
+	ErrEmptyPolicyTemplateName = errors.New("policy template name must " +
+		"not be empty")
+)
+
+// PolicyTemplate is a named forwarding policy that can be applied to any
+// number of channels at once.
+type PolicyTemplate struct {
+	// Name uniquely identifies the template.
+	Name string
+
+	// BaseFee is the base fee in milli-satoshis that is charged for each
+	// forwarded htlc.
+	BaseFee lnwire.MilliSatoshi
+
+	// FeeRate is the proportional fee in millionths of the forwarded
+	// amount.
+	FeeRate uint32
+
+	// TimeLockDelta is the required htlc timelock delta.
+	TimeLockDelta uint32
+
+	// MaxHTLC is the maximum htlc size in milli-satoshis. If zero, the
+	// maximum htlc size of a channel is left unchanged.
+	MaxHTLC lnwire.MilliSatoshi
+
+	// MinHTLC is the minimum htlc size in milli-satoshis. If nil, the
+	// minimum htlc size of a channel is left unchanged.
+	MinHTLC *lnwire.MilliSatoshi
+}
+
+// serializePolicyTemplate writes out the target policy template to the passed
+// io.Writer. Note that the name isn't serialized as this will be the key within
+// the bucket.
+func serializePolicyTemplate(w io.Writer, t *PolicyTemplate) error {
+	var minHTLC lnwire.MilliSatoshi
+	if t.MinHTLC != nil {
+		minHTLC = *t.MinHTLC
+	}
+
+	return WriteElements(
+		w, t.BaseFee, t.FeeRate, t.TimeLockDelta, t.MaxHTLC,
+		t.MinHTLC != nil, minHTLC,
+	)
+}
+
+// deserializePolicyTemplate reads a policy template from the passed io.Reader.
+// The name is expected to be set by the caller.
+func deserializePolicyTemplate(r io.Reader) (*PolicyTemplate, error) {
+	var (
+		t          PolicyTemplate
+		hasMinHTLC bool
+		minHTLC    lnwire.MilliSatoshi
+	)
+	err := ReadElements(
+		r, &t.BaseFee, &t.FeeRate, &t.TimeLockDelta, &t.MaxHTLC,
+		&hasMinHTLC, &minHTLC,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if hasMinHTLC {
+		t.MinHTLC = &minHTLC
+	}
+
+	return &t, nil
+}
+
+// PutPolicyTemplate stores the given policy template, replacing any existing
+// template with the same name.
+func (d *DB) PutPolicyTemplate(template *PolicyTemplate) error {
+	if template.Name == "" {
+		return ErrEmptyPolicyTemplateName
+	}
+
+	var b bytes.Buffer
+	if err := serializePolicyTemplate(&b, template); err != nil {
+		return err
+	}
+
+	return kvdb.Update(d, func(tx kvdb.RwTx) error {
+		policies, err := tx.CreateTopLevelBucket(channelPoliciesBucket)
+		if err != nil {
+			return err
+		}
+
+		templates, err := policies.CreateBucketIfNotExists(
+			policyTemplatesBucket,
+		)
+		if err != nil {
+			return err
+		}
+
+		return templates.Put([]byte(template.Name), b.Bytes())
+	}, func() {})
+}
+
+// FetchPolicyTemplate returns the policy template with the given name.
+func (d *DB) FetchPolicyTemplate(name string) (*PolicyTemplate, error) {
+	var template *PolicyTemplate
+	err := kvdb.View(d, func(tx kvdb.RTx) error {
+		policies := tx.ReadBucket(channelPoliciesBucket)
+		if policies == nil {
+			return ErrPolicyTemplateNotFound
+		}
+
+		templates := policies.NestedReadBucket(policyTemplatesBucket)
+		if templates == nil {
+			return ErrPolicyTemplateNotFound
+		}
+
+		templateBytes := templates.Get([]byte(name))
+		if templateBytes == nil {
+			return ErrPolicyTemplateNotFound
+		}
+
+		var err error
+		template, err = deserializePolicyTemplate(
+			bytes.NewReader(templateBytes),
+		)
+		if err != nil {
+			return err
+		}
+		template.Name = name
+
+		return nil
+	}, func() {
+		template = nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+// FetchPolicyTemplates returns all stored policy templates, ordered by name.
+func (d *DB) FetchPolicyTemplates() ([]*PolicyTemplate, error) {
+	var templates []*PolicyTemplate
+	err := kvdb.View(d, func(tx kvdb.RTx) error {
+		policies := tx.ReadBucket(channelPoliciesBucket)
+		if policies == nil {
+			return nil
+		}
+
+		templateBucket := policies.NestedReadBucket(policyTemplatesBucket)
+		if templateBucket == nil {
+			return nil
+		}
+
+		return templateBucket.ForEach(func(k, v []byte) error {
+			template, err := deserializePolicyTemplate(
+				bytes.NewReader(v),
+			)
+			if err != nil {
+				return err
+			}
+			template.Name = string(k)
+
+			templates = append(templates, template)
+
+			return nil
+		})
+	}, func() {
+		templates = nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return templates, nil
+}
+
+// DeletePolicyTemplate deletes the policy template with the given name.
+func (d *DB) DeletePolicyTemplate(name string) error {
+	return kvdb.Update(d, func(tx kvdb.RwTx) error {
+		policies := tx.ReadWriteBucket(channelPoliciesBucket)
+		if policies == nil {
+			return ErrPolicyTemplateNotFound
+		}
+
+		templates := policies.NestedReadWriteBucket(policyTemplatesBucket)
+		if templates == nil {
+			return ErrPolicyTemplateNotFound
+		}
+
+		if templates.Get([]byte(name)) == nil {
+			return ErrPolicyTemplateNotFound
+		}
+
+		return templates.Delete([]byte(name))
+	}, func() {})
+}
+
+// PolicyChange is an entry in the policy history of our channels. It records
+// the forwarding policy that was applied to a channel, and why it was applied.
+type PolicyChange struct {
+	// Timestamp is the time at which the policy was applied. It uniquely
+	// identifies the change within the history.
+	Timestamp time.Time
+
+	// ChanPoint is the channel point of the channel the policy was applied
+	// to.
+	ChanPoint wire.OutPoint
+
+	// BaseFee is the base fee in milli-satoshis of the new policy.
+	BaseFee lnwire.MilliSatoshi
+
+	// FeeRate is the proportional fee in millionths of the new policy.
+	FeeRate uint32
+
+	// TimeLockDelta is the htlc timelock delta of the new policy.
+	TimeLockDelta uint32
+
+	// MinHTLC is the minimum htlc size in milli-satoshis of the new policy.
+	MinHTLC lnwire.MilliSatoshi
+
+	// MaxHTLC is the maximum htlc size in milli-satoshis of the new policy.
+	MaxHTLC lnwire.MilliSatoshi
+
+	// Template is the name of the policy template the policy was taken
+	// from. It is empty if the policy wasn't taken from a template.
+	Template string
+
+	// Reason is the reason for the change, as given by the operator.
+	Reason string
+}
+
+// serializePolicyChange writes out the target policy change to the passed
+// io.Writer. Note that the timestamp isn't serialized as this will be the key
+// within the bucket.
+func serializePolicyChange(w io.Writer, c *PolicyChange) error {
+	return WriteElements(
+		w, c.ChanPoint, c.BaseFee, c.FeeRate, c.TimeLockDelta,
+		c.MinHTLC, c.MaxHTLC, []byte(c.Template), []byte(c.Reason),
+	)
+}
+
+// deserializePolicyChange reads a policy change from the passed io.Reader. The
+// timestamp is expected to be set by the caller.
+func deserializePolicyChange(r io.Reader) (*PolicyChange, error) {
+	var (
+		c        PolicyChange
+		template []byte
+		reason   []byte
+	)
+	err := ReadElements(
+		r, &c.ChanPoint, &c.BaseFee, &c.FeeRate, &c.TimeLockDelta,
+		&c.MinHTLC, &c.MaxHTLC, &template, &reason,
+	)
+	if err != nil {
+		return nil, err
+	}
+	c.Template = string(template)
+	c.Reason = string(reason)
+
+	return &c, nil
+}
+
+// AddPolicyChanges adds the given changes to the policy history in a single
+// transaction. If an entry already exists for the timestamp of a change, the
+// timestamp is increased until a free slot is found. The timestamps the
+// changes were stored under are written back to the passed changes.
+func (d *DB) AddPolicyChanges(changes []*PolicyChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	timestamps := make([]time.Time, len(changes))
+	err := kvdb.Update(d, func(tx kvdb.RwTx) error {
+		policies, err := tx.CreateTopLevelBucket(channelPoliciesBucket)
+		if err != nil {
+			return err
+		}
+
+		history, err := policies.CreateBucketIfNotExists(
+			policyHistoryBucket,
+		)
+		if err != nil {
+			return err
+		}
+
+		for i, change := range changes {
+			var b bytes.Buffer
+			err := serializePolicyChange(&b, change)
+			if err != nil {
+				return err
+			}
+
+			// Find a free slot for the change. Changes that are
+			// applied at once commonly share their timestamp.
+			timestamp := change.Timestamp
+			var key [8]byte
+			for {
+				byteOrder.PutUint64(
+					key[:], uint64(timestamp.UnixNano()),
+				)
+				if history.Get(key[:]) == nil {
+					break
+				}
+
+				timestamp = time.Unix(
+					0, timestamp.UnixNano()+1,
+				)
+			}
+
+			if err := history.Put(key[:], b.Bytes()); err != nil {
+				return err
+			}
+			timestamps[i] = timestamp
+		}
+
+		return nil
+	}, func() {})
+	if err != nil {
+		return err
+	}
+
+	for i, change := range changes {
+		change.Timestamp = timestamps[i]
+	}
+
+	return nil
+}
+
+// PolicyHistoryQuery is a query for the policy history of our channels. It
+// allows a caller to retrieve the changes of a particular time slice,
+// optionally of a single channel, offset in that time slice, limiting the total
+// number of responses returned.
+type PolicyHistoryQuery struct {
+	// ChanPoint restricts the query to the changes of a single channel if
+	// set.
+	ChanPoint *wire.OutPoint
+
+	// StartTime is the start time of the time slice.
+	StartTime time.Time
+
+	// EndTime is the end time of the time slice.
+	EndTime time.Time
+
+	// IndexOffset is the offset within the time slice to start at.
+	IndexOffset uint32
+
+	// NumMaxChanges is the max number of changes to return.
+	NumMaxChanges uint32
+}
+
+// PolicyHistorySlice is the response to a PolicyHistoryQuery.
+type PolicyHistorySlice struct {
+	PolicyHistoryQuery
+
+	// Changes is the set of changes that answer the query.
+	Changes []*PolicyChange
+
+	// LastIndexOffset is the index of the last element in the set of
+	// returned changes. Callers can use this to resume their query.
+	LastIndexOffset uint32
+}
+
+// QueryPolicyHistory returns the policy changes of the time slice described by
+// the query, ordered by their timestamp.
+func (d *DB) QueryPolicyHistory(
+	q PolicyHistoryQuery) (PolicyHistorySlice, error) {
+
+	var resp PolicyHistorySlice
+	err := kvdb.View(d, func(tx kvdb.RTx) error {
+		policies := tx.ReadBucket(channelPoliciesBucket)
+		if policies == nil {
+			return nil
+		}
+
+		history := policies.NestedReadBucket(policyHistoryBucket)
+		if history == nil {
+			return nil
+		}
+
+		var startTime, endTime [8]byte
+		byteOrder.PutUint64(startTime[:], uint64(q.StartTime.UnixNano()))
+		byteOrder.PutUint64(endTime[:], uint64(q.EndTime.UnixNano()))
+
+		recordsToSkip := q.IndexOffset
+		cursor := history.ReadCursor()
+		k, v := cursor.Seek(startTime[:])
+		for ; k != nil && bytes.Compare(k, endTime[:]) <= 0; k, v = cursor.Next() {
+			if uint32(len(resp.Changes)) >= q.NumMaxChanges {
+				return nil
+			}
+
+			change, err := deserializePolicyChange(
+				bytes.NewReader(v),
+			)
+			if err != nil {
+				return err
+			}
+
+			if q.ChanPoint != nil && change.ChanPoint != *q.ChanPoint {
+				continue
+			}
+
+			if recordsToSkip > 0 {
+				recordsToSkip--
+				continue
+			}
+
+			change.Timestamp = time.Unix(
+				0, int64(byteOrder.Uint64(k)),
+			)
+
+			resp.Changes = append(resp.Changes, change)
+		}
+
+		return nil
+	}, func() {
+		resp = PolicyHistorySlice{
+			PolicyHistoryQuery: q,
+		}
+	})
+	if err != nil {
+		return PolicyHistorySlice{}, err
+	}
+
+	resp.LastIndexOffset = q.IndexOffset + uint32(len(resp.Changes))
+
+	return resp, nil
+}
@@ -0,0 +1,174 @@
+package channeldb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/cryptomeow/lnd/lnwire"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPolicyTemplates tests that policy templates can be stored, replaced,
+// fetched and deleted.
+func TestPolicyTemplates(t *testing.T) {
+	t.Parallel()
+
+	db, cleanup, err := MakeTestDB()
+	require.NoError(t, err)
+	defer cleanup()
+
+	// Without any templates, lookups fail and the list is empty.
+	_, err = db.FetchPolicyTemplate("low")
+	require.Equal(t, ErrPolicyTemplateNotFound, err)
+
+	templates, err := db.FetchPolicyTemplates()
+	require.NoError(t, err)
+	require.Empty(t, templates)
+
+	require.Equal(
+		t, ErrEmptyPolicyTemplateName,
+		db.PutPolicyTemplate(&PolicyTemplate{}),
+	)
+
+	minHTLC := lnwire.MilliSatoshi(1000)
+	low := &PolicyTemplate{
+		Name:          "low",
+		BaseFee:       0,
+		FeeRate:       10,
+		TimeLockDelta: 40,
+		MinHTLC:       &minHTLC,
+	}
+	high := &PolicyTemplate{
+		Name:          "high",
+		BaseFee:       1000,
+		FeeRate:       1000,
+		TimeLockDelta: 144,
+		MaxHTLC:       100000000,
+	}
+	require.NoError(t, db.PutPolicyTemplate(low))
+	require.NoError(t, db.PutPolicyTemplate(high))
+
+	template, err := db.FetchPolicyTemplate("low")
+	require.NoError(t, err)
+	require.Equal(t, low, template)
+
+	// Templates are listed by name.
+	templates, err = db.FetchPolicyTemplates()
+	require.NoError(t, err)
+	require.Equal(t, []*PolicyTemplate{high, low}, templates)
+
+	// Storing a template under an existing name replaces it.
+	high.FeeRate = 2000
+	require.NoError(t, db.PutPolicyTemplate(high))
+
+	template, err = db.FetchPolicyTemplate("high")
+	require.NoError(t, err)
+	require.Equal(t, high, template)
+
+	require.NoError(t, db.DeletePolicyTemplate("high"))
+	require.Equal(
+		t, ErrPolicyTemplateNotFound, db.DeletePolicyTemplate("high"),
+	)
+
+	templates, err = db.FetchPolicyTemplates()
+	require.NoError(t, err)
+	require.Equal(t, []*PolicyTemplate{low}, templates)
+}
+
+// TestPolicyHistory tests that policy changes can be added to the history and
+// queried by time range and channel.
+func TestPolicyHistory(t *testing.T) {
+	t.Parallel()
+
+	db, cleanup, err := MakeTestDB()
+	require.NoError(t, err)
+	defer cleanup()
+
+	query := PolicyHistoryQuery{
+		StartTime:     time.Unix(0, 0),
+		EndTime:       time.Unix(1000, 0),
+		NumMaxChanges: 10,
+	}
+
+	// An empty history should return no changes.
+	resp, err := db.QueryPolicyHistory(query)
+	require.NoError(t, err)
+	require.Empty(t, resp.Changes)
+
+	chanPoint1 := wire.OutPoint{Hash: [32]byte{1}, Index: 1}
+	chanPoint2 := wire.OutPoint{Hash: [32]byte{2}, Index: 0}
+
+	// Add a bulk change of two channels. Both are applied at the same
+	// time, so the timestamp of the second one is expected to be shifted
+	// by a nano second.
+	bulk := []*PolicyChange{
+		{
+			Timestamp:     time.Unix(100, 0),
+			ChanPoint:     chanPoint1,
+			BaseFee:       1000,
+			FeeRate:       100,
+			TimeLockDelta: 40,
+			MinHTLC:       1000,
+			MaxHTLC:       990000,
+			Template:      "default",
+			Reason:        "initial fees",
+		},
+		{
+			Timestamp:     time.Unix(100, 0),
+			ChanPoint:     chanPoint2,
+			BaseFee:       1000,
+			FeeRate:       100,
+			TimeLockDelta: 40,
+			MinHTLC:       1000,
+			MaxHTLC:       490000,
+			Template:      "default",
+			Reason:        "initial fees",
+		},
+	}
+	require.NoError(t, db.AddPolicyChanges(bulk))
+	require.Equal(t, time.Unix(100, 0), bulk[0].Timestamp)
+	require.Equal(t, time.Unix(100, 1), bulk[1].Timestamp)
+
+	single := []*PolicyChange{{
+		Timestamp:     time.Unix(200, 0),
+		ChanPoint:     chanPoint1,
+		BaseFee:       0,
+		FeeRate:       500,
+		TimeLockDelta: 80,
+		MinHTLC:       1000,
+		MaxHTLC:       990000,
+		Reason:        "channel depleted",
+	}}
+	require.NoError(t, db.AddPolicyChanges(single))
+
+	resp, err = db.QueryPolicyHistory(query)
+	require.NoError(t, err)
+	require.Equal(
+		t, []*PolicyChange{bulk[0], bulk[1], single[0]}, resp.Changes,
+	)
+	require.Equal(t, uint32(3), resp.LastIndexOffset)
+
+	// Only the changes of the first channel are returned if it is
+	// queried.
+	chanQuery := query
+	chanQuery.ChanPoint = &chanPoint1
+	resp, err = db.QueryPolicyHistory(chanQuery)
+	require.NoError(t, err)
+	require.Equal(t, []*PolicyChange{bulk[0], single[0]}, resp.Changes)
+
+	// The index offset skips changes of the queried channel.
+	chanQuery.IndexOffset = 1
+	resp, err = db.QueryPolicyHistory(chanQuery)
+	require.NoError(t, err)
+	require.Equal(t, []*PolicyChange{single[0]}, resp.Changes)
+	require.Equal(t, uint32(2), resp.LastIndexOffset)
+
+	// The time range and the max number of changes are respected.
+	rangeQuery := query
+	rangeQuery.EndTime = time.Unix(150, 0)
+	rangeQuery.NumMaxChanges = 1
+	resp, err = db.QueryPolicyHistory(rangeQuery)
+	require.NoError(t, err)
+	require.Equal(t, []*PolicyChange{bulk[0]}, resp.Changes)
+}
@@ -954,6 +954,189 @@ func TestFetchPendingChannels(t *testing.T) {
 	}
 }
 
+// TestMarkAsPending tests that an open channel can be moved back into the
+// pending state, and that it keeps its short channel ID until it is marked
+// open again.
+func TestMarkAsPending(t *testing.T) {
+	t.Parallel()
+
+	cdb, cleanUp, err := MakeTestDB()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+	defer cleanUp()
+
+	state := createTestChannel(t, cdb, openChannelOption())
+	shortChanID := state.ShortChanID()
+
+	if err := state.MarkAsPending(); err != nil {
+		t.Fatalf("unable to mark channel as pending: %v", err)
+	}
+	if !state.IsPending {
+		t.Fatalf("channel should be pending")
+	}
+
+	pendingChannels, err := cdb.FetchPendingChannels()
+	if err != nil {
+		t.Fatalf("unable to list pending channels: %v", err)
+	}
+	if len(pendingChannels) != 1 {
+		t.Fatalf("incorrect number of pending channels: expecting %v,"+
+			"got %v", 1, len(pendingChannels))
+	}
+	if pendingChannels[0].ShortChanID() != shortChanID {
+		t.Fatalf("short channel id changed: expected %v, got %v",
+			shortChanID, pendingChannels[0].ShortChanID())
+	}
+
+	// Once the funding transaction confirms at a new location, the channel
+	// is marked open with the new short channel ID.
+	newShortChanID := lnwire.ShortChannelID{
+		BlockHeight: shortChanID.BlockHeight + 1,
+		TxIndex:     shortChanID.TxIndex,
+		TxPosition:  shortChanID.TxPosition,
+	}
+	if err := pendingChannels[0].MarkAsOpen(newShortChanID); err != nil {
+		t.Fatalf("unable to mark channel as open: %v", err)
+	}
+
+	openChans, err := cdb.FetchOpenChannels(state.IdentityPub)
+	if err != nil {
+		t.Fatalf("unable to fetch open channels: %v", err)
+	}
+	if len(openChans) != 1 {
+		t.Fatalf("expected 1 open channel, got %v", len(openChans))
+	}
+	if openChans[0].ShortChanID() != newShortChanID {
+		t.Fatalf("expected short channel id %v, got %v",
+			newShortChanID, openChans[0].ShortChanID())
+	}
+}
+
+// TestUpgradeChanType tests that upgrading the type of a channel in place is
+// persisted, and that prior commitments retain their original type.
+func TestUpgradeChanType(t *testing.T) {
+	t.Parallel()
+
+	cdb, cleanUp, err := MakeTestDB()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+	defer cleanUp()
+
+	state := createTestChannel(t, cdb, openChannelOption())
+	prevType := state.ChanType
+	localHeight := state.LocalCommitment.CommitHeight
+	remoteHeight := state.RemoteCommitment.CommitHeight
+
+	// An upgrade that would remove a bit of the current type is rejected.
+	if err := state.UpgradeChanType(AnchorOutputsBit); err == nil {
+		t.Fatalf("expected invalid upgrade to fail")
+	}
+
+	newType := prevType | SingleFunderTweaklessBit | AnchorOutputsBit
+	if err := state.UpgradeChanType(newType); err != nil {
+		t.Fatalf("unable to upgrade channel type: %v", err)
+	}
+
+	openChans, err := cdb.FetchOpenChannels(state.IdentityPub)
+	if err != nil {
+		t.Fatalf("unable to fetch open channels: %v", err)
+	}
+	if len(openChans) != 1 {
+		t.Fatalf("expected 1 open channel, got %v", len(openChans))
+	}
+
+	for _, channel := range []*OpenChannel{state, openChans[0]} {
+		if channel.ChanType != newType {
+			t.Fatalf("expected type %v, got %v", newType,
+				channel.ChanType)
+		}
+		if !channel.IsUpgraded() {
+			t.Fatalf("expected channel to be upgraded")
+		}
+
+		// The current commitments were created before the upgrade,
+		// while all following commitments use the new type.
+		if channel.LocalCommitType(localHeight) != prevType {
+			t.Fatalf("expected local commitment of type %v",
+				prevType)
+		}
+		if channel.RemoteCommitType(remoteHeight) != prevType {
+			t.Fatalf("expected remote commitment of type %v",
+				prevType)
+		}
+		if channel.LocalCommitType(localHeight+1) != newType {
+			t.Fatalf("expected next local commitment of type %v",
+				newType)
+		}
+		if channel.RemoteCommitType(remoteHeight+1) != newType {
+			t.Fatalf("expected next remote commitment of type %v",
+				newType)
+		}
+	}
+
+	// A channel can only be upgraded once.
+	err = openChans[0].UpgradeChanType(newType | DualFunderBit)
+	if err != ErrChanAlreadyUpgraded {
+		t.Fatalf("expected ErrChanAlreadyUpgraded, got %v", err)
+	}
+}
+
+// TestCommitFeeRateBounds tests that the commitment fee rate bounds of a
+// channel are persisted and can be removed again.
+func TestCommitFeeRateBounds(t *testing.T) {
+	t.Parallel()
+
+	cdb, cleanUp, err := MakeTestDB()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+	defer cleanUp()
+
+	state := createTestChannel(t, cdb, openChannelOption())
+
+	assertBounds := func(minFeeRate, maxFeeRate btcutil.Amount) {
+		t.Helper()
+
+		openChans, err := cdb.FetchOpenChannels(state.IdentityPub)
+		if err != nil {
+			t.Fatalf("unable to fetch open channels: %v", err)
+		}
+
+		for _, channel := range []*OpenChannel{state, openChans[0]} {
+			minRate, maxRate := channel.CommitFeeRateBounds()
+			if minRate != minFeeRate || maxRate != maxFeeRate {
+				t.Fatalf("expected bounds %v-%v, got %v-%v",
+					minFeeRate, maxFeeRate, minRate,
+					maxRate)
+			}
+		}
+	}
+
+	// A minimum above the maximum is rejected.
+	if err := state.SetCommitFeeRateBounds(2000, 1000); err == nil {
+		t.Fatalf("expected invalid bounds to fail")
+	}
+	assertBounds(0, 0)
+
+	if err := state.SetCommitFeeRateBounds(1000, 2000); err != nil {
+		t.Fatalf("unable to set bounds: %v", err)
+	}
+	assertBounds(1000, 2000)
+
+	// Only a minimum can be set as well.
+	if err := state.SetCommitFeeRateBounds(1000, 0); err != nil {
+		t.Fatalf("unable to set bounds: %v", err)
+	}
+	assertBounds(1000, 0)
+
+	if err := state.SetCommitFeeRateBounds(0, 0); err != nil {
+		t.Fatalf("unable to remove bounds: %v", err)
+	}
+	assertBounds(0, 0)
+}
+
 func TestFetchClosedChannels(t *testing.T) {
 	t.Parallel()
 
@@ -1613,3 +1796,50 @@ func TestHasChanStatus(t *testing.T) {
 		})
 	}
 }
+
+// TestLogUpdatesEndorsement asserts that log updates that contain an add with
+// an endorsement signal can be read back, without the signal being stored.
+func TestLogUpdatesEndorsement(t *testing.T) {
+	t.Parallel()
+
+	endorsed := lnwire.Endorsed
+	add := &lnwire.UpdateAddHTLC{
+		ID:          1,
+		Amount:      1000,
+		Expiry:      100,
+		Endorsement: &endorsed,
+	}
+	settle := &lnwire.UpdateFulfillHTLC{
+		ID: 1,
+	}
+
+	var b bytes.Buffer
+	err := serializeLogUpdates(&b, []LogUpdate{
+		{LogIndex: 1, UpdateMsg: add},
+		{LogIndex: 2, UpdateMsg: settle},
+	})
+	if err != nil {
+		t.Fatalf("unable to serialize log updates: %v", err)
+	}
+
+	// The add we serialized must not be modified.
+	if add.Endorsement == nil {
+		t.Fatalf("endorsement of add was removed")
+	}
+
+	updates, err := deserializeLogUpdates(&b)
+	if err != nil {
+		t.Fatalf("unable to deserialize log updates: %v", err)
+	}
+
+	storedAdd := *add
+	storedAdd.Endorsement = nil
+	expected := []LogUpdate{
+		{LogIndex: 1, UpdateMsg: &storedAdd},
+		{LogIndex: 2, UpdateMsg: settle},
+	}
+	if !reflect.DeepEqual(expected, updates) {
+		t.Fatalf("log updates mismatch: expected %v, got %v",
+			spew.Sdump(expected), spew.Sdump(updates))
+	}
+}
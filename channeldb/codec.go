@@ -1,6 +1,7 @@
 package channeldb
 
 import (
+	"bytes"
 	"encoding/binary"
 	"fmt"
 	"io"
@@ -41,6 +42,26 @@ func readOutpoint(r io.Reader, o *wire.OutPoint) error {
 	return nil
 }
 
+// readMessage reads a message that was written by WriteElement. As messages
+// are stored without a length prefix, an add is limited to its fixed size
+// fields, so that it doesn't decode the data that follows it as TLV records.
+func readMessage(r io.Reader) (lnwire.Message, error) {
+	var mType [2]byte
+	if _, err := io.ReadFull(r, mType[:]); err != nil {
+		return nil, err
+	}
+
+	payload := r
+	msgType := lnwire.MessageType(binary.BigEndian.Uint16(mType[:]))
+	if msgType == lnwire.MsgUpdateAddHTLC {
+		payload = io.LimitReader(r, lnwire.UpdateAddHTLCBaseSize)
+	}
+
+	return lnwire.ReadMessage(
+		io.MultiReader(bytes.NewReader(mType[:]), payload), 0,
+	)
+}
+
 // UnknownElementType is an error returned when the codec is unable to encode or
 // decode a particular type.
 type UnknownElementType struct {
@@ -178,6 +199,17 @@ func WriteElement(w io.Writer, element interface{}) error {
 		}
 
 	case lnwire.Message:
+		// The optional TLV records of an add are only relevant on the
+		// wire, and can't be stored as messages aren't length
+		// prefixed. See readMessage.
+		if add, ok := e.(*lnwire.UpdateAddHTLC); ok &&
+			add.Endorsement != nil {
+
+			stripped := *add
+			stripped.Endorsement = nil
+			e = &stripped
+		}
+
 		if _, err := lnwire.WriteMessage(w, e, 0); err != nil {
 			return err
 		}
@@ -394,7 +426,7 @@ func ReadElement(r io.Reader, element interface{}) error {
 		*e = bytes
 
 	case *lnwire.Message:
-		msg, err := lnwire.ReadMessage(r, 0)
+		msg, err := readMessage(r)
 		if err != nil {
 			return err
 		}
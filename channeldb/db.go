@@ -10,13 +10,14 @@ import (
 
 	"github.com/btcsuite/btcd/btcec"
 	"github.com/btcsuite/btcd/wire"
-	"github.com/btcsuite/btcwallet/walletdb"
 	"github.com/go-errors/errors"
 	"github.com/cryptomeow/lnd/channeldb/kvdb"
 	mig "github.com/cryptomeow/lnd/channeldb/migration"
 	"github.com/cryptomeow/lnd/channeldb/migration12"
 	"github.com/cryptomeow/lnd/channeldb/migration13"
 	"github.com/cryptomeow/lnd/channeldb/migration16"
+	"github.com/cryptomeow/lnd/channeldb/migration20"
+	"github.com/cryptomeow/lnd/channeldb/migration21"
 	"github.com/cryptomeow/lnd/channeldb/migration_01_to_11"
 	"github.com/cryptomeow/lnd/clock"
 	"github.com/cryptomeow/lnd/lnwire"
@@ -170,6 +171,44 @@ var (
 			number:    18,
 			migration: mig.CreateTLB(peersBucket),
 		},
+		{
+			// Create a top level bucket which holds the audit log of
+			// inbound channel open attempts.
+			number:    19,
+			migration: mig.CreateTLB(openAttemptsBucket),
+		},
+		{
+			// Add the existing invoices that have a payment
+			// address to the payment address index, so that all of
+			// them can be looked up by their payment address.
+			number:    20,
+			migration: migration20.MigratePayAddrIndex,
+		},
+		{
+			// Create the hourly rollups of the existing forwarding
+			// log, which are used to summarize the forwards over
+			// large time ranges.
+			number:    21,
+			migration: migration21.MigrateForwardingRollups,
+		},
+		{
+			// Create a top level bucket which holds the policy
+			// templates and the policy history of our channels.
+			number:    22,
+			migration: mig.CreateTLB(channelPoliciesBucket),
+		},
+		{
+			// Create a top level bucket which holds the node-wide
+			// event log.
+			number:    23,
+			migration: mig.CreateTLB(nodeEventsBucket),
+		},
+		{
+			// Create a top level bucket which holds the alias short
+			// channel IDs of private channels.
+			number:    24,
+			migration: mig.CreateTLB(scidAliasBucket),
+		},
 	}
 
 	// Big endian is the preferred byte order, due to cursor scans over
@@ -187,46 +226,10 @@ type DB struct {
 	graph  *ChannelGraph
 	clock  clock.Clock
 	dryRun bool
-}
 
-// Update is a wrapper around walletdb.Update which calls into the extended
-// backend when available. This call is needed to be able to cast DB to
-// ExtendedBackend. The passed reset function is called before the start of the
-// transaction and can be used to reset intermediate state. As callers may
-// expect retries of the f closure (depending on the database backend used), the
-// reset function will be called before each retry respectively.
-func (db *DB) Update(f func(tx walletdb.ReadWriteTx) error, reset func()) error {
-	if v, ok := db.Backend.(kvdb.ExtendedBackend); ok {
-		return v.Update(f, reset)
-	}
-
-	reset()
-	return walletdb.Update(db, f)
-}
-
-// View is a wrapper around walletdb.View which calls into the extended
-// backend when available. This call is needed to be able to cast DB to
-// ExtendedBackend. The passed reset function is called before the start of the
-// transaction and can be used to reset intermediate state. As callers may
-// expect retries of the f closure (depending on the database backend used), the
-// reset function will be called before each retry respectively.
-func (db *DB) View(f func(tx walletdb.ReadTx) error, reset func()) error {
-	if v, ok := db.Backend.(kvdb.ExtendedBackend); ok {
-		return v.View(f, reset)
-	}
-
-	reset()
-	return walletdb.View(db, f)
-}
-
-// PrintStats calls into the extended backend if available. This call is needed
-// to be able to cast DB to ExtendedBackend.
-func (db *DB) PrintStats() string {
-	if v, ok := db.Backend.(kvdb.ExtendedBackend); ok {
-		return v.PrintStats()
-	}
-
-	return "unimplemented"
+	// scidAliases caches the alias short channel IDs we handed out for
+	// our private channels.
+	scidAliases *scidAliasCache
 }
 
 // Open opens or creates channeldb. Any necessary schemas migrations due
@@ -253,19 +256,24 @@ func Open(dbPath string, modifiers ...OptionModifier) (*DB, error) {
 // CreateWithBackend creates channeldb instance using the passed kvdb.Backend.
 // Any necessary schemas migrations due to updates will take place as necessary.
 func CreateWithBackend(backend kvdb.Backend, modifiers ...OptionModifier) (*DB, error) {
-	if err := initChannelDB(backend); err != nil {
-		return nil, err
-	}
-
 	opts := DefaultOptions()
 	for _, modifier := range modifiers {
 		modifier(&opts)
 	}
 
+	if opts.readOnly {
+		return openReadOnly(backend, &opts)
+	}
+
+	if err := initChannelDB(backend); err != nil {
+		return nil, err
+	}
+
 	chanDB := &DB{
-		Backend: backend,
-		clock:   opts.clock,
-		dryRun:  opts.dryRun,
+		Backend:     backend,
+		clock:       opts.clock,
+		dryRun:      opts.dryRun,
+		scidAliases: &scidAliasCache{},
 	}
 	chanDB.graph = newChannelGraph(
 		chanDB, opts.RejectCacheSize, opts.ChannelCacheSize,
@@ -289,10 +297,12 @@ var topLevelBuckets = [][]byte{
 	openChannelBucket,
 	closedChannelBucket,
 	forwardingLogBucket,
+	forwardingRollupBucket,
 	fwdPackagesKey,
 	invoiceBucket,
 	payAddrIndexBucket,
 	paymentsIndexBucket,
+	paymentIdempotencyIndexBucket,
 	peersBucket,
 	nodeInfoBucket,
 	nodeBucket,
@@ -301,6 +311,12 @@ var topLevelBuckets = [][]byte{
 	graphMetaBucket,
 	metaBucket,
 	closeSummaryBucket,
+	openAttemptsBucket,
+	channelPoliciesBucket,
+	nodeEventsBucket,
+	scidAliasBucket,
+	watchOnlyBucket,
+	peerFilterBucket,
 }
 
 // Wipe completely deletes all saved state within all used buckets within the
@@ -19,6 +19,15 @@ var (
 	// prior database version.
 	ErrDBReversion = fmt.Errorf("channel db cannot revert to prior version")
 
+	// ErrDBReadOnly is returned when attempting to write to a database that
+	// was opened read-only.
+	ErrDBReadOnly = fmt.Errorf("channel db is opened read-only")
+
+	// ErrDBNotMigrated is returned when a database that is opened
+	// read-only isn't at the latest version, as it can't be migrated then.
+	ErrDBNotMigrated = fmt.Errorf("channel db must be migrated before " +
+		"it can be opened read-only")
+
 	// ErrLinkNodesNotFound is returned when node info bucket hasn't been
 	// created.
 	ErrLinkNodesNotFound = fmt.Errorf("no link nodes exist")
@@ -51,6 +60,10 @@ var (
 	// multiple, distinct invoices.
 	ErrInvRefEquivocation = errors.New("inv ref matches multiple invoices")
 
+	// ErrInvRefNoPayHash is returned when an invoice is updated through an
+	// invoice reference that doesn't include a payment hash.
+	ErrInvRefNoPayHash = errors.New("inv ref has no payment hash")
+
 	// ErrNoPaymentsCreated is returned when bucket of payments hasn't been
 	// created.
 	ErrNoPaymentsCreated = fmt.Errorf("there are no existing payments")
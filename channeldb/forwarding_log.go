@@ -113,21 +113,31 @@ func (f *ForwardingLog) AddForwardingEvents(events []ForwardingEvent) error {
 
 	return kvdb.Batch(f.db.Backend, func(tx kvdb.RwTx) error {
 		// First, we'll fetch the bucket that stores our time series
-		// log.
+		// log, and the bucket that stores its rollups.
 		logBucket, err := tx.CreateTopLevelBucket(
 			forwardingLogBucket,
 		)
 		if err != nil {
 			return err
 		}
+		rollupBucket, err := tx.CreateTopLevelBucket(
+			forwardingRollupBucket,
+		)
+		if err != nil {
+			return err
+		}
 
-		// With the bucket obtained, we can now begin to write out the
-		// series of events.
-		for _, event := range events {
-			err := storeEvent(logBucket, event, timestamp[:])
+		// With the buckets obtained, we can now begin to write out the
+		// series of events and add them to their rollups.
+		for i := range events {
+			err := storeEvent(logBucket, events[i], timestamp[:])
 			if err != nil {
 				return err
 			}
+
+			if err := updateRollup(rollupBucket, &events[i]); err != nil {
+				return err
+			}
 		}
 
 		return nil
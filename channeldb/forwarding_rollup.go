@@ -0,0 +1,265 @@
+package channeldb
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/cryptomeow/lnd/channeldb/kvdb"
+	"github.com/cryptomeow/lnd/lnwire"
+)
+
+var (
+	// forwardingRollupBucket is the bucket that stores hourly rollups of
+	// the forwarding log. This allows summaries over large time ranges to
+	// be computed without reading every single forwarding event. Each key
+	// within the bucket is the start of an hour (in seconds since the unix
+	// epoch) followed by an outgoing channel ID, and the value the rollup
+	// of all forwards through that channel during that hour.
+	//
+	// maps: hourStart || outgoingChanID => forwardingRollup
+	forwardingRollupBucket = []byte("circuit-fwd-log-rollup")
+)
+
+const (
+	// RollupInterval is the length of the time slices that the forwarding
+	// log is aggregated into.
+	RollupInterval = time.Hour
+
+	// forwardingRollupKeySize is the size of the key of a rollup: 8 byte
+	// hour start || 8 byte outgoing chan ID.
+	forwardingRollupKeySize = 16
+)
+
+// ForwardingRollup is the aggregate of a set of forwarding events.
+type ForwardingRollup struct {
+	// NumForwards is the number of forwarding events.
+	NumForwards uint64
+
+	// AmtOut is the total amount of the outgoing HTLCs.
+	AmtOut lnwire.MilliSatoshi
+
+	// Fees is the total fee earned by the forwards.
+	Fees lnwire.MilliSatoshi
+}
+
+// addEvent adds a single forwarding event to the rollup.
+func (r *ForwardingRollup) addEvent(event *ForwardingEvent) {
+	r.NumForwards++
+	r.AmtOut += event.AmtOut
+	r.Fees += event.AmtIn - event.AmtOut
+}
+
+// merge adds the aggregate of another rollup to the rollup.
+func (r *ForwardingRollup) merge(other *ForwardingRollup) {
+	r.NumForwards += other.NumForwards
+	r.AmtOut += other.AmtOut
+	r.Fees += other.Fees
+}
+
+// ForwardingSummary is the summary of all forwards within a time range.
+type ForwardingSummary struct {
+	// Total is the aggregate of all forwards.
+	Total ForwardingRollup
+
+	// Channels holds the aggregate of the forwards per outgoing channel.
+	// Fees are attributed to the outgoing channel, as they're charged
+	// according to its policy.
+	Channels map[lnwire.ShortChannelID]*ForwardingRollup
+}
+
+// add adds a rollup of forwards through the given outgoing channel to the
+// summary.
+func (s *ForwardingSummary) add(chanID lnwire.ShortChannelID,
+	rollup *ForwardingRollup) {
+
+	s.Total.merge(rollup)
+
+	chanRollup, ok := s.Channels[chanID]
+	if !ok {
+		chanRollup = &ForwardingRollup{}
+		s.Channels[chanID] = chanRollup
+	}
+	chanRollup.merge(rollup)
+}
+
+// rollupStart returns the start of the rollup interval the given time falls
+// into.
+func rollupStart(t time.Time) time.Time {
+	secs := t.Unix()
+	interval := int64(RollupInterval / time.Second)
+
+	return time.Unix(secs-secs%interval, 0)
+}
+
+// rollupKey returns the key of the rollup of the outgoing channel within the
+// rollup interval starting at the given time.
+func rollupKey(start time.Time, chanID lnwire.ShortChannelID) []byte {
+	var key [forwardingRollupKeySize]byte
+	byteOrder.PutUint64(key[:8], uint64(start.Unix()))
+	byteOrder.PutUint64(key[8:], chanID.ToUint64())
+
+	return key[:]
+}
+
+// encodeForwardingRollup serializes a forwarding rollup.
+func encodeForwardingRollup(r *ForwardingRollup) ([]byte, error) {
+	var b bytes.Buffer
+	err := WriteElements(&b, r.NumForwards, r.AmtOut, r.Fees)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// decodeForwardingRollup deserializes a forwarding rollup.
+func decodeForwardingRollup(v []byte) (*ForwardingRollup, error) {
+	r := &ForwardingRollup{}
+	err := ReadElements(
+		bytes.NewReader(v), &r.NumForwards, &r.AmtOut, &r.Fees,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// updateRollup adds the forwarding event to the rollup of its outgoing channel
+// within the rollup interval of the event.
+func updateRollup(bucket kvdb.RwBucket, event *ForwardingEvent) error {
+	key := rollupKey(rollupStart(event.Timestamp), event.OutgoingChanID)
+
+	rollup := &ForwardingRollup{}
+	if v := bucket.Get(key); v != nil {
+		var err error
+		rollup, err = decodeForwardingRollup(v)
+		if err != nil {
+			return err
+		}
+	}
+	rollup.addEvent(event)
+
+	v, err := encodeForwardingRollup(rollup)
+	if err != nil {
+		return err
+	}
+
+	return bucket.Put(key, v)
+}
+
+// Summarize aggregates all forwards within the time range [start, end), in
+// total and per outgoing channel. The full rollup intervals within the range
+// are read from the stored rollups, only the forwards at the edges of the range
+// are read from the forwarding log itself.
+func (f *ForwardingLog) Summarize(start, end time.Time) (*ForwardingSummary,
+	error) {
+
+	var summary *ForwardingSummary
+	err := kvdb.View(f.db, func(tx kvdb.RTx) error {
+		// If no forwards were logged yet, the summary is empty.
+		logBucket := tx.ReadBucket(forwardingLogBucket)
+		if logBucket == nil {
+			return nil
+		}
+
+		// Without rollups, we fall back to reading the whole range
+		// from the log.
+		rollupBucket := tx.ReadBucket(forwardingRollupBucket)
+		if rollupBucket == nil {
+			return summarizeLog(logBucket, start, end, summary)
+		}
+
+		// Determine the full rollup intervals within the range. If
+		// there are none, the whole range is read from the log.
+		firstRollup := rollupStart(start)
+		if firstRollup.Before(start) {
+			firstRollup = firstRollup.Add(RollupInterval)
+		}
+		lastRollup := rollupStart(end)
+
+		if !firstRollup.Before(lastRollup) {
+			return summarizeLog(logBucket, start, end, summary)
+		}
+
+		err := summarizeLog(logBucket, start, firstRollup, summary)
+		if err != nil {
+			return err
+		}
+
+		err = summarizeRollups(
+			rollupBucket, firstRollup, lastRollup, summary,
+		)
+		if err != nil {
+			return err
+		}
+
+		return summarizeLog(logBucket, lastRollup, end, summary)
+	}, func() {
+		summary = &ForwardingSummary{
+			Channels: make(
+				map[lnwire.ShortChannelID]*ForwardingRollup,
+			),
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return summary, nil
+}
+
+// summarizeLog adds all forwarding events of the log within [start, end) to
+// the summary.
+func summarizeLog(logBucket kvdb.RBucket, start, end time.Time,
+	summary *ForwardingSummary) error {
+
+	var startKey, endKey [8]byte
+	byteOrder.PutUint64(startKey[:], uint64(start.UnixNano()))
+	byteOrder.PutUint64(endKey[:], uint64(end.UnixNano()))
+
+	cursor := logBucket.ReadCursor()
+	k, v := cursor.Seek(startKey[:])
+	for ; k != nil && bytes.Compare(k, endKey[:]) < 0; k, v = cursor.Next() {
+		readBuf := bytes.NewReader(v)
+		for readBuf.Len() != 0 {
+			var event ForwardingEvent
+			err := decodeForwardingEvent(readBuf, &event)
+			if err != nil {
+				return err
+			}
+
+			var rollup ForwardingRollup
+			rollup.addEvent(&event)
+			summary.add(event.OutgoingChanID, &rollup)
+		}
+	}
+
+	return nil
+}
+
+// summarizeRollups adds all stored rollups of the intervals within [start,
+// end) to the summary.
+func summarizeRollups(rollupBucket kvdb.RBucket, start, end time.Time,
+	summary *ForwardingSummary) error {
+
+	var startKey, endKey [8]byte
+	byteOrder.PutUint64(startKey[:], uint64(start.Unix()))
+	byteOrder.PutUint64(endKey[:], uint64(end.Unix()))
+
+	cursor := rollupBucket.ReadCursor()
+	k, v := cursor.Seek(startKey[:])
+	for ; k != nil && bytes.Compare(k[:8], endKey[:]) < 0; k, v = cursor.Next() {
+		rollup, err := decodeForwardingRollup(v)
+		if err != nil {
+			return err
+		}
+
+		chanID := lnwire.NewShortChanIDFromInt(
+			byteOrder.Uint64(k[8:forwardingRollupKeySize]),
+		)
+		summary.add(chanID, rollup)
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package channeldb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cryptomeow/lnd/lnwire"
+	"github.com/stretchr/testify/require"
+)
+
+// TestForwardingLogSummarize tests that summaries computed from the rollups
+// and the edges of the time range match the forwarding events within the
+// range.
+func TestForwardingLogSummarize(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := MakeTestDB()
+	require.NoError(t, err)
+	defer cleanUp()
+
+	log := db.ForwardingLog()
+
+	// Without any forwards, the summary is empty.
+	start := time.Unix(1600000000, 0)
+	summary, err := log.Summarize(start, start.Add(time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, ForwardingRollup{}, summary.Total)
+	require.Empty(t, summary.Channels)
+
+	// We'll add events spaced seven minutes apart over multiple hours,
+	// alternating between two outgoing channels.
+	chan1 := lnwire.NewShortChanIDFromInt(1)
+	chan2 := lnwire.NewShortChanIDFromInt(2)
+
+	numEvents := 100
+	events := make([]ForwardingEvent, numEvents)
+	for i := 0; i < numEvents; i++ {
+		outgoing := chan1
+		if i%2 == 1 {
+			outgoing = chan2
+		}
+
+		events[i] = ForwardingEvent{
+			Timestamp:      start.Add(time.Duration(i) * 7 * time.Minute),
+			IncomingChanID: lnwire.NewShortChanIDFromInt(3),
+			OutgoingChanID: outgoing,
+			AmtIn:          lnwire.MilliSatoshi(10000 + i),
+			AmtOut:         10000,
+		}
+	}
+	require.NoError(t, log.AddForwardingEvents(events))
+
+	// expectedSummary sums up the events within [from, to).
+	expectedSummary := func(from, to time.Time) *ForwardingSummary {
+		expected := &ForwardingSummary{
+			Channels: make(map[lnwire.ShortChannelID]*ForwardingRollup),
+		}
+		for i := range events {
+			ts := events[i].Timestamp
+			if ts.Before(from) || !ts.Before(to) {
+				continue
+			}
+
+			var rollup ForwardingRollup
+			rollup.addEvent(&events[i])
+			expected.add(events[i].OutgoingChanID, &rollup)
+		}
+
+		return expected
+	}
+
+	tests := []struct {
+		name     string
+		from, to time.Time
+	}{
+		{
+			name: "all events",
+			from: start,
+			to:   start.Add(24 * time.Hour),
+		},
+		{
+			name: "within a single rollup",
+			from: start.Add(5 * time.Minute),
+			to:   start.Add(40 * time.Minute),
+		},
+		{
+			name: "unaligned range",
+			from: start.Add(13 * time.Minute),
+			to:   start.Add(5*time.Hour + 17*time.Minute),
+		},
+		{
+			name: "aligned range",
+			from: rollupStart(start).Add(time.Hour),
+			to:   rollupStart(start).Add(4 * time.Hour),
+		},
+		{
+			name: "empty range",
+			from: start.Add(-time.Hour),
+			to:   start,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			summary, err := log.Summarize(test.from, test.to)
+			require.NoError(t, err)
+			require.Equal(
+				t, expectedSummary(test.from, test.to), summary,
+			)
+		})
+	}
+}
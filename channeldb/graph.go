@@ -119,6 +119,11 @@ var (
 	// edge's participants.
 	zombieBucket = []byte("zombie-index")
 
+	// compactZombieMarker is the value of a compacted entry in the zombie
+	// index, which no longer holds the public keys of the nodes of the
+	// zombie channel.
+	compactZombieMarker = [1]byte{0}
+
 	// disabledEdgePolicyBucket is a sub-bucket of the main edgeBucket bucket
 	// responsible for maintaining an index of disabled edge policies. Each
 	// entry exists within the bucket as follows:
@@ -942,7 +947,8 @@ func (c *ChannelGraph) PruneGraph(spentOutputs []*wire.OutPoint,
 		// Now that the graph has been pruned, we'll also attempt to
 		// prune any nodes that have had a channel closed within the
 		// latest block.
-		return c.pruneGraphNodes(nodes, edgeIndex)
+		_, err = c.pruneGraphNodes(nodes, edgeIndex)
+		return err
 	}, func() {
 		chansClosed = nil
 	})
@@ -961,9 +967,11 @@ func (c *ChannelGraph) PruneGraph(spentOutputs []*wire.OutPoint,
 // PruneGraphNodes is a garbage collection method which attempts to prune out
 // any nodes from the channel graph that are currently unconnected. This ensure
 // that we only maintain a graph of reachable nodes. In the event that a pruned
-// node gains more channels, it will be re-added back to the graph.
-func (c *ChannelGraph) PruneGraphNodes() error {
-	return kvdb.Update(c.db, func(tx kvdb.RwTx) error {
+// node gains more channels, it will be re-added back to the graph. The number
+// of pruned nodes is returned.
+func (c *ChannelGraph) PruneGraphNodes() (int, error) {
+	var numNodesPruned int
+	err := kvdb.Update(c.db, func(tx kvdb.RwTx) error {
 		nodes := tx.ReadWriteBucket(nodeBucket)
 		if nodes == nil {
 			return ErrGraphNodesNotFound
@@ -977,15 +985,25 @@ func (c *ChannelGraph) PruneGraphNodes() error {
 			return ErrGraphNoEdgesFound
 		}
 
-		return c.pruneGraphNodes(nodes, edgeIndex)
-	}, func() {})
+		var err error
+		numNodesPruned, err = c.pruneGraphNodes(nodes, edgeIndex)
+		return err
+	}, func() {
+		numNodesPruned = 0
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return numNodesPruned, nil
 }
 
 // pruneGraphNodes attempts to remove any nodes from the graph who have had a
 // channel closed within the current block. If the node still has existing
-// channels in the graph, this will act as a no-op.
+// channels in the graph, this will act as a no-op. The number of pruned nodes
+// is returned.
 func (c *ChannelGraph) pruneGraphNodes(nodes kvdb.RwBucket,
-	edgeIndex kvdb.RwBucket) error {
+	edgeIndex kvdb.RwBucket) (int, error) {
 
 	log.Trace("Pruning nodes from graph with no open channels")
 
@@ -993,7 +1011,7 @@ func (c *ChannelGraph) pruneGraphNodes(nodes kvdb.RwBucket,
 	// even if it no longer has any open channels.
 	sourceNode, err := c.sourceNode(nodes)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	// We'll use this map to keep count the number of references to a node
@@ -1015,7 +1033,7 @@ func (c *ChannelGraph) pruneGraphNodes(nodes kvdb.RwBucket,
 		return nil
 	})
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	// To ensure we never delete the source node, we'll start off by
@@ -1041,7 +1059,7 @@ func (c *ChannelGraph) pruneGraphNodes(nodes kvdb.RwBucket,
 		return nil
 	})
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	// Finally, we'll make a second pass over the set of nodes, and delete
@@ -1074,7 +1092,7 @@ func (c *ChannelGraph) pruneGraphNodes(nodes kvdb.RwBucket,
 			numNodesPruned)
 	}
 
-	return nil
+	return numNodesPruned, nil
 }
 
 // DisconnectBlockAtHeight is used to indicate that the block specified
@@ -3332,13 +3350,79 @@ func isZombieEdge(zombieIndex kvdb.RBucket,
 		return false, [33]byte{}, [33]byte{}
 	}
 
+	// Compact zombie markers don't store the public keys of the nodes.
 	var pubKey1, pubKey2 [33]byte
+	if len(v) < 66 {
+		return true, pubKey1, pubKey2
+	}
+
 	copy(pubKey1[:], v[:33])
 	copy(pubKey2[:], v[33:])
 
 	return true, pubKey1, pubKey2
 }
 
+// CompactZombieIndex strips the node public keys from all entries of the
+// zombie index, which reduces the size of each entry from 66 bytes to a single
+// byte. The compacted markers still prevent zombie channels from being
+// downloaded again through gossip, but updates for them can't be verified
+// anymore. The number of compacted entries is returned.
+func (c *ChannelGraph) CompactZombieIndex() (int, error) {
+	c.cacheMu.Lock()
+	defer c.cacheMu.Unlock()
+
+	var numCompacted int
+	err := kvdb.Update(c.db, func(tx kvdb.RwTx) error {
+		edges := tx.ReadWriteBucket(edgeBucket)
+		if edges == nil {
+			return nil
+		}
+		zombieIndex := edges.NestedReadWriteBucket(zombieBucket)
+		if zombieIndex == nil {
+			return nil
+		}
+
+		// We can't modify the bucket while iterating over it, so we
+		// first collect the keys of all entries that still hold the
+		// public keys.
+		var toCompact [][]byte
+		err := zombieIndex.ForEach(func(k, v []byte) error {
+			if len(v) < 66 {
+				return nil
+			}
+
+			key := make([]byte, len(k))
+			copy(key, k)
+			toCompact = append(toCompact, key)
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, k := range toCompact {
+			err := zombieIndex.Put(k, compactZombieMarker[:])
+			if err != nil {
+				return err
+			}
+		}
+
+		numCompacted = len(toCompact)
+		return nil
+	}, func() {
+		numCompacted = 0
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	// The reject cache holds the zombie status, but not the public keys of
+	// the nodes, so it doesn't need to be invalidated. The channel cache
+	// doesn't hold zombie edges.
+	return numCompacted, nil
+}
+
 // NumZombies returns the current number of zombie channels in the graph.
 func (c *ChannelGraph) NumZombies() (uint64, error) {
 	var numZombies uint64
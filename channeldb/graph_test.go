@@ -2387,7 +2387,7 @@ func TestPruneGraphNodes(t *testing.T) {
 	}
 
 	// We'll now initiate a around of graph pruning.
-	if err := graph.PruneGraphNodes(); err != nil {
+	if _, err := graph.PruneGraphNodes(); err != nil {
 		t.Fatalf("unable to prune graph nodes: %v", err)
 	}
 
@@ -3029,6 +3029,74 @@ func TestGraphZombieIndex(t *testing.T) {
 	assertNumZombies(t, graph, 0)
 }
 
+// TestGraphCompactZombieIndex ensures that compacted entries of the zombie
+// index still mark their channels as zombies, but no longer hold the public
+// keys of the nodes.
+func TestGraphCompactZombieIndex(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := MakeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create test database: %v", err)
+	}
+	graph := db.ChannelGraph()
+
+	node1, err := createTestVertex(db)
+	if err != nil {
+		t.Fatalf("unable to create test vertex: %v", err)
+	}
+	node2, err := createTestVertex(db)
+	if err != nil {
+		t.Fatalf("unable to create test vertex: %v", err)
+	}
+
+	// Compacting an empty index is a no-op.
+	numCompacted, err := graph.CompactZombieIndex()
+	if err != nil {
+		t.Fatalf("unable to compact zombie index: %v", err)
+	}
+	if numCompacted != 0 {
+		t.Fatalf("expected 0 compacted entries, got %v", numCompacted)
+	}
+
+	edge, _, _ := createChannelEdge(db, node1, node2)
+	if err := graph.AddChannelEdge(edge); err != nil {
+		t.Fatalf("unable to create channel edge: %v", err)
+	}
+	if err := graph.DeleteChannelEdges(edge.ChannelID); err != nil {
+		t.Fatalf("unable to mark edge as zombie: %v", err)
+	}
+
+	// The zombie entry should be compacted exactly once.
+	for _, expected := range []int{1, 0} {
+		numCompacted, err := graph.CompactZombieIndex()
+		if err != nil {
+			t.Fatalf("unable to compact zombie index: %v", err)
+		}
+		if numCompacted != expected {
+			t.Fatalf("expected %v compacted entries, got %v",
+				expected, numCompacted)
+		}
+	}
+
+	isZombie, pubKey1, pubKey2 := graph.IsZombieEdge(edge.ChannelID)
+	if !isZombie {
+		t.Fatal("expected edge to be marked as zombie")
+	}
+	if pubKey1 != ([33]byte{}) || pubKey2 != ([33]byte{}) {
+		t.Fatalf("expected no public keys for compacted zombie, got "+
+			"%x and %x", pubKey1, pubKey2)
+	}
+	assertNumZombies(t, graph, 1)
+
+	// Compacted zombies can still be marked as live.
+	if err := graph.MarkEdgeLive(edge.ChannelID); err != nil {
+		t.Fatalf("unable to mark edge as live: %v", err)
+	}
+	assertNumZombies(t, graph, 0)
+}
+
 // compareNodes is used to compare two LightningNodes while excluding the
 // Features struct, which cannot be compared as the semantics for reserializing
 // the featuresMap have not been defined.
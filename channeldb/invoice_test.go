@@ -39,7 +39,9 @@ func randInvoice(value lnwire.MilliSatoshi) (*Invoice, error) {
 			Value:           value,
 			Features:        emptyFeatures,
 		},
-		Htlcs: map[CircuitKey]*InvoiceHTLC{},
+		Htlcs:           map[CircuitKey]*InvoiceHTLC{},
+		MppTimeout:      time.Minute,
+		RequireApproval: true,
 	}
 	i.Memo = []byte("memo")
 
@@ -393,6 +395,47 @@ func TestInvRefEquivocation(t *testing.T) {
 	require.Error(t, err, ErrInvRefEquivocation)
 }
 
+// TestLookupInvoiceByAddr asserts that invoices can be looked up by their
+// payment address alone, and that such references can't be used to update an
+// invoice.
+func TestLookupInvoiceByAddr(t *testing.T) {
+	t.Parallel()
+
+	db, cleanup, err := MakeTestDB()
+	defer cleanup()
+	require.NoError(t, err, "unable to make test db")
+
+	invoice, err := randInvoice(1000)
+	require.NoError(t, err)
+
+	payHash := invoice.Terms.PaymentPreimage.Hash()
+	_, err = db.AddInvoice(invoice, payHash)
+	require.NoError(t, err)
+
+	// Looking up the invoice by its payment address should return the
+	// invoice that was added.
+	ref := InvoiceRefByAddr(invoice.Terms.PaymentAddr)
+	dbInvoice, err := db.LookupInvoice(ref)
+	require.NoError(t, err)
+	require.Equal(t, invoice.Terms.PaymentAddr, dbInvoice.Terms.PaymentAddr)
+	require.Equal(t, invoice.Terms.Value, dbInvoice.Terms.Value)
+
+	// An unknown payment address shouldn't match any invoice.
+	_, err = db.LookupInvoice(InvoiceRefByAddr([32]byte{0x01}))
+	require.Equal(t, ErrInvoiceNotFound, err)
+
+	// The blank payment address of legacy invoices is never looked up.
+	_, err = db.LookupInvoice(InvoiceRefByAddr(BlankPayAddr))
+	require.Equal(t, ErrInvoiceNotFound, err)
+
+	// Updating the invoice requires its payment hash.
+	nop := func(_ *Invoice) (*InvoiceUpdateDesc, error) {
+		return nil, nil
+	}
+	_, err = db.UpdateInvoice(ref, nop)
+	require.Equal(t, ErrInvRefNoPayHash, err)
+}
+
 // TestInvoiceCancelSingleHtlc tests that a single htlc can be canceled on the
 // invoice.
 func TestInvoiceCancelSingleHtlc(t *testing.T) {
@@ -466,6 +509,81 @@ func TestInvoiceCancelSingleHtlc(t *testing.T) {
 	}
 }
 
+// TestReissueInvoice tests that the payment request of an open invoice can be
+// replaced, and that the replaced payment requests are persisted.
+func TestReissueInvoice(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := MakeTestDB()
+	defer cleanUp()
+	require.NoError(t, err)
+
+	preimage := lntypes.Preimage{1}
+	paymentHash := preimage.Hash()
+
+	testInvoice := &Invoice{
+		CreationDate:   testNow,
+		PaymentRequest: []byte("payreq1"),
+		Htlcs:          map[CircuitKey]*InvoiceHTLC{},
+		Terms: ContractTerm{
+			Expiry:          time.Hour,
+			Value:           lnwire.NewMSatFromSatoshis(10000),
+			Features:        emptyFeatures,
+			PaymentPreimage: &preimage,
+		},
+	}
+	_, err = db.AddInvoice(testInvoice, paymentHash)
+	require.NoError(t, err)
+
+	reissue := func(prev, payReq string) (*Invoice, error) {
+		update := &InvoiceUpdateDesc{
+			Reissue: &InvoiceReissueDesc{
+				PrevPaymentRequest: []byte(prev),
+				PaymentRequest:     []byte(payReq),
+				CreationDate:       time.Unix(2, 0),
+				Expiry:             2 * time.Hour,
+			},
+		}
+
+		ref := InvoiceRefByHash(paymentHash)
+		return db.UpdateInvoice(ref,
+			func(invoice *Invoice) (*InvoiceUpdateDesc, error) {
+				return update, nil
+			})
+	}
+
+	// Reissuing with an outdated payment request must fail.
+	_, err = reissue("payreq0", "payreq2")
+	require.Equal(t, ErrPaymentRequestChanged, err)
+
+	_, err = reissue("payreq1", "payreq2")
+	require.NoError(t, err)
+	_, err = reissue("payreq2", "payreq3")
+	require.NoError(t, err)
+
+	// The new payment request, creation date and expiry must be persisted
+	// along with the replaced payment requests.
+	invoice, err := db.LookupInvoice(InvoiceRefByHash(paymentHash))
+	require.NoError(t, err)
+	require.Equal(t, []byte("payreq3"), invoice.PaymentRequest)
+	require.Equal(t, [][]byte{
+		[]byte("payreq1"), []byte("payreq2"),
+	}, invoice.PrevPaymentRequests)
+	require.Equal(t, time.Unix(2, 0), invoice.CreationDate)
+	require.Equal(t, 2*time.Hour, invoice.Terms.Expiry)
+	require.Equal(t, testInvoice.Terms.Value, invoice.Terms.Value)
+
+	// Once the invoice is settled, it can't be reissued anymore.
+	_, err = db.UpdateInvoice(
+		InvoiceRefByHash(paymentHash),
+		getUpdateInvoice(invoice.Terms.Value),
+	)
+	require.NoError(t, err)
+
+	_, err = reissue("payreq3", "payreq4")
+	require.Equal(t, ErrInvoiceAlreadySettled, err)
+}
+
 // TestInvoiceTimeSeries tests that newly added invoices invoices, as well as
 // settled invoices are added to the database are properly placed in the add
 // add or settle index which serves as an event time series.
@@ -1142,14 +1260,20 @@ func TestInvoiceRef(t *testing.T) {
 	// An InvoiceRef by hash should return the provided hash and a nil
 	// payment addr.
 	refByHash := InvoiceRefByHash(payHash)
-	require.Equal(t, payHash, refByHash.PayHash())
+	require.Equal(t, &payHash, refByHash.PayHash())
 	require.Equal(t, (*[32]byte)(nil), refByHash.PayAddr())
 
 	// An InvoiceRef by hash and addr should return the payment hash and
 	// payment addr passed to the constructor.
 	refByHashAndAddr := InvoiceRefByHashAndAddr(payHash, payAddr)
-	require.Equal(t, payHash, refByHashAndAddr.PayHash())
+	require.Equal(t, &payHash, refByHashAndAddr.PayHash())
 	require.Equal(t, &payAddr, refByHashAndAddr.PayAddr())
+
+	// An InvoiceRef by addr should return the payment addr and a nil
+	// payment hash.
+	refByAddr := InvoiceRefByAddr(payAddr)
+	require.Equal(t, (*lntypes.Hash)(nil), refByAddr.PayHash())
+	require.Equal(t, &payAddr, refByAddr.PayAddr())
 }
 
 // TestDeleteInvoices tests that deleting a list of invoices will succeed
@@ -8,6 +8,7 @@ import (
 	"io"
 	"time"
 
+	"github.com/btcsuite/btcd/wire"
 	"github.com/cryptomeow/lnd/channeldb/kvdb"
 	"github.com/cryptomeow/lnd/htlcswitch/hop"
 	"github.com/cryptomeow/lnd/lntypes"
@@ -106,6 +107,17 @@ var (
 	// ErrInvoicePreimageMismatch is returned when the preimage doesn't
 	// match the invoice hash.
 	ErrInvoicePreimageMismatch = errors.New("preimage does not match")
+
+	// ErrInvoiceNoPaymentRequest is returned when an attempt is made to
+	// reissue an invoice that has no payment request, such as a keysend
+	// invoice.
+	ErrInvoiceNoPaymentRequest = errors.New("invoice has no payment " +
+		"request")
+
+	// ErrPaymentRequestChanged is returned when an invoice is reissued
+	// while its payment request was replaced concurrently.
+	ErrPaymentRequestChanged = errors.New("payment request of invoice " +
+		"changed")
 )
 
 const (
@@ -142,21 +154,24 @@ const (
 	// prevents against the database being rolled back to an older
 	// format where the surrounding logic might assume a different set of
 	// fields are known.
-	memoType        tlv.Type = 0
-	payReqType      tlv.Type = 1
-	createTimeType  tlv.Type = 2
-	settleTimeType  tlv.Type = 3
-	addIndexType    tlv.Type = 4
-	settleIndexType tlv.Type = 5
-	preimageType    tlv.Type = 6
-	valueType       tlv.Type = 7
-	cltvDeltaType   tlv.Type = 8
-	expiryType      tlv.Type = 9
-	paymentAddrType tlv.Type = 10
-	featuresType    tlv.Type = 11
-	invStateType    tlv.Type = 12
-	amtPaidType     tlv.Type = 13
-	hodlInvoiceType tlv.Type = 14
+	memoType            tlv.Type = 0
+	payReqType          tlv.Type = 1
+	createTimeType      tlv.Type = 2
+	settleTimeType      tlv.Type = 3
+	addIndexType        tlv.Type = 4
+	settleIndexType     tlv.Type = 5
+	preimageType        tlv.Type = 6
+	valueType           tlv.Type = 7
+	cltvDeltaType       tlv.Type = 8
+	expiryType          tlv.Type = 9
+	paymentAddrType     tlv.Type = 10
+	featuresType        tlv.Type = 11
+	invStateType        tlv.Type = 12
+	amtPaidType         tlv.Type = 13
+	hodlInvoiceType     tlv.Type = 14
+	mppTimeoutType      tlv.Type = 15
+	prevPayReqsType     tlv.Type = 16
+	requireApprovalType tlv.Type = 17
 )
 
 // InvoiceRef is a composite identifier for invoices. Invoices can be referenced
@@ -168,7 +183,10 @@ type InvoiceRef struct {
 	// payHash is the payment hash of the target invoice. All invoices are
 	// currently indexed by payment hash. This value will be used as a
 	// fallback when no payment address is known.
-	payHash lntypes.Hash
+	//
+	// NOTE: This value may be nil if the invoice is only referenced by its
+	// payment address.
+	payHash *lntypes.Hash
 
 	// payAddr is the payment addr of the target invoice. Newer invoices
 	// (0.11 and up) are indexed by payment address in addition to payment
@@ -182,7 +200,16 @@ type InvoiceRef struct {
 // its payment hash.
 func InvoiceRefByHash(payHash lntypes.Hash) InvoiceRef {
 	return InvoiceRef{
-		payHash: payHash,
+		payHash: &payHash,
+	}
+}
+
+// InvoiceRefByAddr creates an InvoiceRef that queries for an invoice only by
+// its payment address. Such a reference can only be used to look up invoices,
+// as updating an invoice requires its payment hash.
+func InvoiceRefByAddr(payAddr [32]byte) InvoiceRef {
+	return InvoiceRef{
+		payAddr: &payAddr,
 	}
 }
 
@@ -193,14 +220,20 @@ func InvoiceRefByHashAndAddr(payHash lntypes.Hash,
 	payAddr [32]byte) InvoiceRef {
 
 	return InvoiceRef{
-		payHash: payHash,
+		payHash: &payHash,
 		payAddr: &payAddr,
 	}
 }
 
 // PayHash returns the target invoice's payment hash.
-func (r InvoiceRef) PayHash() lntypes.Hash {
-	return r.payHash
+//
+// NOTE: This value may be nil.
+func (r InvoiceRef) PayHash() *lntypes.Hash {
+	if r.payHash != nil {
+		hash := *r.payHash
+		return &hash
+	}
+	return nil
 }
 
 // PayAddr returns the optional payment address of the target invoice.
@@ -216,10 +249,17 @@ func (r InvoiceRef) PayAddr() *[32]byte {
 
 // String returns a human-readable representation of an InvoiceRef.
 func (r InvoiceRef) String() string {
-	if r.payAddr != nil {
-		return fmt.Sprintf("(pay_hash=%v, pay_addr=%x)", r.payHash, *r.payAddr)
+	switch {
+	case r.payHash != nil && r.payAddr != nil:
+		return fmt.Sprintf("(pay_hash=%v, pay_addr=%x)", *r.payHash,
+			*r.payAddr)
+
+	case r.payHash != nil:
+		return fmt.Sprintf("(pay_hash=%v)", *r.payHash)
+
+	default:
+		return fmt.Sprintf("(pay_addr=%x)", *r.payAddr)
 	}
-	return fmt.Sprintf("(pay_hash=%v)", r.payHash)
 }
 
 // ContractState describes the state the invoice is in.
@@ -354,9 +394,24 @@ type Invoice struct {
 	// htlcs may have been marked as canceled.
 	Htlcs map[CircuitKey]*InvoiceHTLC
 
+	// MppTimeout is the time after which the htlcs of an incomplete mpp
+	// set paying to this invoice are canceled back. If zero, the default
+	// of the invoice registry is used.
+	MppTimeout time.Duration
+
 	// HodlInvoice indicates whether the invoice should be held in the
 	// Accepted state or be settled right away.
 	HodlInvoice bool
+
+	// PrevPaymentRequests are the payment requests this invoice was
+	// issued with before it was reissued, oldest first. As they share the
+	// payment hash, any of them can still be used to pay the invoice.
+	PrevPaymentRequests [][]byte
+
+	// RequireApproval indicates whether the invoice should be held in the
+	// Accepted state until the received funds are explicitly approved or
+	// rejected. Unlike for hodl invoices, the preimage is known up front.
+	RequireApproval bool
 }
 
 // HtlcState defines the states an htlc paying to an invoice can be in.
@@ -442,6 +497,28 @@ type InvoiceUpdateDesc struct {
 	// AddHtlcs describes the newly accepted htlcs that need to be added to
 	// the invoice.
 	AddHtlcs map[CircuitKey]*HtlcAcceptDesc
+
+	// Reissue describes a new payment request that replaces the current
+	// one. If nil, the payment request is left unchanged.
+	Reissue *InvoiceReissueDesc
+}
+
+// InvoiceReissueDesc describes a new payment request for an open invoice. The
+// payment request must have the same payment hash, amount and payment address
+// as the one it replaces.
+type InvoiceReissueDesc struct {
+	// PrevPaymentRequest is the payment request that is replaced. The
+	// update fails if the invoice has another payment request by now.
+	PrevPaymentRequest []byte
+
+	// PaymentRequest is the new encoded payment request.
+	PaymentRequest []byte
+
+	// CreationDate is the creation date of the new payment request.
+	CreationDate time.Time
+
+	// Expiry is the expiry of the new payment request.
+	Expiry time.Duration
 }
 
 // InvoiceStateUpdateDesc describes an invoice-level state transition.
@@ -479,6 +556,10 @@ func validateInvoice(i *Invoice, paymentHash lntypes.Hash) error {
 	if i.Terms.PaymentPreimage == nil && !i.HodlInvoice {
 		return errors.New("non-hodl invoices must have a preimage")
 	}
+
+	if i.RequireApproval && i.HodlInvoice {
+		return errors.New("hodl invoices cannot require approval")
+	}
 	return nil
 }
 
@@ -692,16 +773,19 @@ func fetchInvoiceNumByRef(invoiceIndex, payAddrIndex kvdb.RBucket,
 	payAddr := ref.PayAddr()
 
 	var (
-		invoiceNumByHash = invoiceIndex.Get(payHash[:])
+		invoiceNumByHash []byte
 		invoiceNumByAddr []byte
 	)
-	if payAddr != nil {
-		// Only allow lookups for payment address if it is not a blank
-		// payment address, which is a special-cased value for legacy
-		// keysend invoices.
-		if *payAddr != BlankPayAddr {
-			invoiceNumByAddr = payAddrIndex.Get(payAddr[:])
-		}
+	if payHash != nil {
+		invoiceNumByHash = invoiceIndex.Get(payHash[:])
+	}
+
+	// Only allow lookups for payment address if it is not a blank payment
+	// address, which is a special-cased value for legacy keysend invoices.
+	// The index bucket may be missing if no invoices were added since it
+	// was introduced.
+	if payAddr != nil && *payAddr != BlankPayAddr && payAddrIndex != nil {
+		invoiceNumByAddr = payAddrIndex.Get(payAddr[:])
 	}
 
 	switch {
@@ -721,6 +805,11 @@ func fetchInvoiceNumByRef(invoiceIndex, payAddrIndex kvdb.RBucket,
 	case invoiceNumByHash != nil:
 		return invoiceNumByHash, nil
 
+	// If the invoice is only referenced by its payment address, return the
+	// invoice number found in the payment address index.
+	case invoiceNumByAddr != nil && payHash == nil:
+		return invoiceNumByAddr, nil
+
 	// Otherwise we don't know of the target invoice.
 	default:
 		return nil, ErrInvoiceNotFound
@@ -916,6 +1005,13 @@ func (d *DB) QueryInvoices(q InvoiceQuery) (InvoiceSlice, error) {
 func (d *DB) UpdateInvoice(ref InvoiceRef,
 	callback InvoiceUpdateCallback) (*Invoice, error) {
 
+	// The payment hash is needed to validate the preimage of a settle
+	// update, so references without one can't be used.
+	payHash := ref.PayHash()
+	if payHash == nil {
+		return nil, ErrInvRefNoPayHash
+	}
+
 	var updatedInvoice *Invoice
 	err := kvdb.Update(d, func(tx kvdb.RwTx) error {
 		invoices, err := tx.CreateTopLevelBucket(invoiceBucket)
@@ -945,9 +1041,8 @@ func (d *DB) UpdateInvoice(ref InvoiceRef,
 			return err
 
 		}
-		payHash := ref.PayHash()
 		updatedInvoice, err = d.updateInvoice(
-			payHash, invoices, settleIndex, invoiceNum,
+			*payHash, invoices, settleIndex, invoiceNum,
 			callback,
 		)
 
@@ -1132,6 +1227,18 @@ func serializeInvoice(w io.Writer, i *Invoice) error {
 		hodlInvoice = 1
 	}
 
+	mppTimeout := uint64(i.MppTimeout)
+
+	var requireApproval uint8
+	if i.RequireApproval {
+		requireApproval = 1
+	}
+
+	prevPayReqs, err := serializePayReqs(i.PrevPaymentRequests)
+	if err != nil {
+		return err
+	}
+
 	tlvStream, err := tlv.NewStream(
 		// Memo and payreq.
 		tlv.MakePrimitiveRecord(memoType, &i.Memo),
@@ -1156,6 +1263,9 @@ func serializeInvoice(w io.Writer, i *Invoice) error {
 		tlv.MakePrimitiveRecord(amtPaidType, &amtPaid),
 
 		tlv.MakePrimitiveRecord(hodlInvoiceType, &hodlInvoice),
+		tlv.MakePrimitiveRecord(mppTimeoutType, &mppTimeout),
+		tlv.MakePrimitiveRecord(prevPayReqsType, &prevPayReqs),
+		tlv.MakePrimitiveRecord(requireApprovalType, &requireApproval),
 	)
 	if err != nil {
 		return err
@@ -1251,17 +1361,20 @@ func fetchInvoice(invoiceNum []byte, invoices kvdb.RBucket) (Invoice, error) {
 
 func deserializeInvoice(r io.Reader) (Invoice, error) {
 	var (
-		preimageBytes [32]byte
-		value         uint64
-		cltvDelta     uint32
-		expiry        uint64
-		amtPaid       uint64
-		state         uint8
-		hodlInvoice   uint8
+		preimageBytes   [32]byte
+		value           uint64
+		cltvDelta       uint32
+		expiry          uint64
+		amtPaid         uint64
+		state           uint8
+		hodlInvoice     uint8
+		mppTimeout      uint64
+		requireApproval uint8
 
 		creationDateBytes []byte
 		settleDateBytes   []byte
 		featureBytes      []byte
+		prevPayReqs       []byte
 	)
 
 	var i Invoice
@@ -1289,6 +1402,9 @@ func deserializeInvoice(r io.Reader) (Invoice, error) {
 		tlv.MakePrimitiveRecord(amtPaidType, &amtPaid),
 
 		tlv.MakePrimitiveRecord(hodlInvoiceType, &hodlInvoice),
+		tlv.MakePrimitiveRecord(mppTimeoutType, &mppTimeout),
+		tlv.MakePrimitiveRecord(prevPayReqsType, &prevPayReqs),
+		tlv.MakePrimitiveRecord(requireApprovalType, &requireApproval),
 	)
 	if err != nil {
 		return i, err
@@ -1319,6 +1435,16 @@ func deserializeInvoice(r io.Reader) (Invoice, error) {
 	if hodlInvoice != 0 {
 		i.HodlInvoice = true
 	}
+	i.MppTimeout = time.Duration(mppTimeout)
+
+	if requireApproval != 0 {
+		i.RequireApproval = true
+	}
+
+	i.PrevPaymentRequests, err = deserializePayReqs(prevPayReqs)
+	if err != nil {
+		return i, err
+	}
 
 	err = i.CreationDate.UnmarshalBinary(creationDateBytes)
 	if err != nil {
@@ -1346,6 +1472,39 @@ func deserializeInvoice(r io.Reader) (Invoice, error) {
 	return i, err
 }
 
+// serializePayReqs serializes a list of payment requests into a single byte
+// slice, so it can be stored in a tlv record.
+func serializePayReqs(payReqs [][]byte) ([]byte, error) {
+	var b bytes.Buffer
+	for _, payReq := range payReqs {
+		if err := wire.WriteVarBytes(&b, 0, payReq); err != nil {
+			return nil, err
+		}
+	}
+
+	return b.Bytes(), nil
+}
+
+// deserializePayReqs reads a list of payment requests serialized by
+// serializePayReqs.
+func deserializePayReqs(b []byte) ([][]byte, error) {
+	var (
+		payReqs [][]byte
+		r       = bytes.NewReader(b)
+	)
+	for r.Len() > 0 {
+		payReq, err := wire.ReadVarBytes(
+			r, 0, MaxPaymentRequestSize, "payreq",
+		)
+		if err != nil {
+			return nil, err
+		}
+		payReqs = append(payReqs, payReq)
+	}
+
+	return payReqs, nil
+}
+
 // deserializeHtlcs reads a list of invoice htlcs from a reader and returns it
 // as a map.
 func deserializeHtlcs(r io.Reader) (map[CircuitKey]*InvoiceHTLC, error) {
@@ -1449,11 +1608,19 @@ func copyInvoice(src *Invoice) *Invoice {
 		Htlcs: make(
 			map[CircuitKey]*InvoiceHTLC, len(src.Htlcs),
 		),
-		HodlInvoice: src.HodlInvoice,
+		HodlInvoice:     src.HodlInvoice,
+		MppTimeout:      src.MppTimeout,
+		RequireApproval: src.RequireApproval,
 	}
 
 	dest.Terms.Features = src.Terms.Features.Clone()
 
+	for _, payReq := range src.PrevPaymentRequests {
+		dest.PrevPaymentRequests = append(
+			dest.PrevPaymentRequests, copySlice(payReq),
+		)
+	}
+
 	if src.Terms.PaymentPreimage != nil {
 		preimage := *src.Terms.PaymentPreimage
 		dest.Terms.PaymentPreimage = &preimage
@@ -1493,6 +1660,14 @@ func (d *DB) updateInvoice(hash lntypes.Hash, invoices, settleIndex kvdb.RwBucke
 
 	now := d.clock.Now()
 
+	// Replace the payment request if the update descriptor reissues the
+	// invoice.
+	if update.Reissue != nil {
+		if err := reissueInvoice(&invoice, update.Reissue); err != nil {
+			return nil, err
+		}
+	}
+
 	// Update invoice state if the update descriptor indicates an invoice
 	// state change.
 	if update.State != nil {
@@ -1603,6 +1778,48 @@ func (d *DB) updateInvoice(hash lntypes.Hash, invoices, settleIndex kvdb.RwBucke
 	return &invoice, nil
 }
 
+// reissueInvoice validates and applies a new payment request for an invoice.
+// The replaced payment request is kept in the list of previous payment
+// requests of the invoice.
+func reissueInvoice(invoice *Invoice, reissue *InvoiceReissueDesc) error {
+	switch invoice.State {
+	case ContractOpen:
+
+	case ContractAccepted:
+		return ErrInvoiceAlreadyAccepted
+
+	case ContractSettled:
+		return ErrInvoiceAlreadySettled
+
+	case ContractCanceled:
+		return ErrInvoiceAlreadyCanceled
+
+	default:
+		return fmt.Errorf("unknown state %v", invoice.State)
+	}
+
+	if len(invoice.PaymentRequest) == 0 {
+		return ErrInvoiceNoPaymentRequest
+	}
+	if !bytes.Equal(invoice.PaymentRequest, reissue.PrevPaymentRequest) {
+		return ErrPaymentRequestChanged
+	}
+	if len(reissue.PaymentRequest) > MaxPaymentRequestSize {
+		return fmt.Errorf("max length of payment request is %v, "+
+			"length provided was %v", MaxPaymentRequestSize,
+			len(reissue.PaymentRequest))
+	}
+
+	invoice.PrevPaymentRequests = append(
+		invoice.PrevPaymentRequests, invoice.PaymentRequest,
+	)
+	invoice.PaymentRequest = reissue.PaymentRequest
+	invoice.CreationDate = reissue.CreationDate
+	invoice.Terms.Expiry = reissue.Expiry
+
+	return nil
+}
+
 // updateInvoiceState validates and processes an invoice state update.
 func updateInvoiceState(invoice *Invoice, hash lntypes.Hash,
 	update InvoiceStateUpdateDesc) error {
@@ -4,10 +4,15 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	_ "github.com/btcsuite/btcwallet/walletdb/bdb" // Import to register backend.
 )
 
+// DefaultDBTimeout is the default timeout for opening a bbolt database. It
+// prevents waiting forever for the file lock held by another process.
+const DefaultDBTimeout = 60 * time.Second
+
 // fileExists returns true if the file exists, and false otherwise.
 func fileExists(path string) bool {
 	if _, err := os.Stat(path); err != nil {
@@ -35,9 +40,15 @@ func GetBoltBackend(path, name string, noFreeListSync bool) (Backend, error) {
 			}
 		}
 
-		db, err = Create(BoltBackendName, dbFilePath, noFreeListSync)
+		db, err = Create(
+			BoltBackendName, dbFilePath, noFreeListSync,
+			DefaultDBTimeout,
+		)
 	} else {
-		db, err = Open(BoltBackendName, dbFilePath, noFreeListSync)
+		db, err = Open(
+			BoltBackendName, dbFilePath, noFreeListSync,
+			DefaultDBTimeout,
+		)
 	}
 
 	if err != nil {
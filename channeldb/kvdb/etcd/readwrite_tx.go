@@ -55,6 +55,20 @@ func (tx *readWriteTx) Rollback() error {
 	return nil
 }
 
+// ForEachBucket iterates through all top level buckets.
+func (tx *readWriteTx) ForEachBucket(fn func(key []byte) error) error {
+	return rootBucket(tx).ForEach(func(key, val []byte) error {
+		// Top level keys with a value can't be part of a database
+		// written through walletdb, as only buckets can be created in
+		// the root.
+		if val != nil {
+			return walletdb.ErrInvalid
+		}
+
+		return fn(key)
+	})
+}
+
 // ReadWriteBucket opens the root bucket for read/write access.  If the
 // bucket described by the key does not exist, nil is returned.
 func (tx *readWriteTx) ReadWriteBucket(key []byte) walletdb.ReadWriteBucket {
@@ -14,12 +14,7 @@ import (
 // database backend used), the reset function will be called before each retry
 // respectively.
 func Update(db Backend, f func(tx RwTx) error, reset func()) error {
-	if extendedDB, ok := db.(ExtendedBackend); ok {
-		return extendedDB.Update(f, reset)
-	}
-
-	reset()
-	return walletdb.Update(db, f)
+	return db.Update(f, reset)
 }
 
 // View opens a database read transaction and executes the function f with the
@@ -30,22 +25,20 @@ func Update(db Backend, f func(tx RwTx) error, reset func()) error {
 // expect retries of the f closure (depending on the database backend used), the
 // reset function will be called before each retry respectively.
 func View(db Backend, f func(tx RTx) error, reset func()) error {
-	if extendedDB, ok := db.(ExtendedBackend); ok {
-		return extendedDB.View(f, reset)
-	}
-
-	// Since we know that walletdb simply calls into bbolt which never
-	// retries transactions, we'll call the reset function here before View.
-	reset()
-
-	return walletdb.View(db, f)
+	return db.View(f, reset)
 }
 
 // Batch is identical to the Update call, but it attempts to combine several
 // individual Update transactions into a single write database transaction on
 // an optimistic basis. This only has benefits if multiple goroutines call
-// Batch.
-var Batch = walletdb.Batch
+// Batch. Backends that don't support batching run a regular Update instead.
+func Batch(db Backend, f func(tx RwTx) error) error {
+	if batchDB, ok := db.(walletdb.BatchDB); ok {
+		return batchDB.Batch(f)
+	}
+
+	return walletdb.Update(db, f)
+}
 
 // Create initializes and opens a database for the specified type. The
 // arguments are specific to the database type driver. See the documentation
@@ -58,35 +51,6 @@ var Create = walletdb.Create
 // through read or read+write transactions.
 type Backend = walletdb.DB
 
-// ExtendedBackend is and interface that supports View and Update and also able
-// to collect database access patterns.
-type ExtendedBackend interface {
-	Backend
-
-	// PrintStats returns all collected stats pretty printed into a string.
-	PrintStats() string
-
-	// View opens a database read transaction and executes the function f
-	// with the transaction passed as a parameter. After f exits, the
-	// transaction is rolled back. If f errors, its error is returned, not a
-	// rollback error (if any occur). The passed reset function is called
-	// before the start of the transaction and can be used to reset
-	// intermediate state. As callers may expect retries of the f closure
-	// (depending on the database backend used), the reset function will be
-	//called before each retry respectively.
-	View(f func(tx walletdb.ReadTx) error, reset func()) error
-
-	// Update opens a database read/write transaction and executes the
-	// function f with the transaction passed as a parameter. After f exits,
-	// if f did not error, the transaction is committed. Otherwise, if f did
-	// error, the transaction is rolled back. If the rollback fails, the
-	// original error returned by f is still returned. If the commit fails,
-	// the commit error is returned. As callers may expect retries of the f
-	// closure (depending on the database backend used), the reset function
-	// will be called before each retry respectively.
-	Update(f func(tx walletdb.ReadWriteTx) error, reset func()) error
-}
-
 // Open opens an existing database for the specified type. The arguments are
 // specific to the database type driver. See the documentation for the database
 // driver for further details.
@@ -7,6 +7,8 @@ import (
 	"github.com/cryptomeow/lnd/channeldb/migration12"
 	"github.com/cryptomeow/lnd/channeldb/migration13"
 	"github.com/cryptomeow/lnd/channeldb/migration16"
+	"github.com/cryptomeow/lnd/channeldb/migration20"
+	"github.com/cryptomeow/lnd/channeldb/migration21"
 	"github.com/cryptomeow/lnd/channeldb/migration_01_to_11"
 )
 
@@ -35,4 +37,6 @@ func UseLogger(logger btclog.Logger) {
 	migration12.UseLogger(logger)
 	migration13.UseLogger(logger)
 	migration16.UseLogger(logger)
+	migration20.UseLogger(logger)
+	migration21.UseLogger(logger)
 }
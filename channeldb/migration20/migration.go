@@ -0,0 +1,145 @@
+package migration20
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+
+	"github.com/cryptomeow/lnd/channeldb/kvdb"
+	"github.com/cryptomeow/lnd/tlv"
+)
+
+var (
+	// invoiceBucket is the name of the bucket within the database that
+	// stores all data related to invoices no matter their final state.
+	invoiceBucket = []byte("invoices")
+
+	// payAddrIndexBucket is the name of the top-level bucket that maps
+	// payment addresses to their invoice number.
+	payAddrIndexBucket = []byte("pay-addr-index")
+
+	byteOrder = binary.BigEndian
+)
+
+const (
+	// paymentAddrType is the tlv type of the payment address within a
+	// serialized invoice.
+	paymentAddrType tlv.Type = 10
+)
+
+// blankPayAddr is the all-zero payment address of legacy invoices, which is
+// never indexed.
+var blankPayAddr [32]byte
+
+// payAddrEntry is a single entry that is added to the payment address index.
+type payAddrEntry struct {
+	payAddr    [32]byte
+	invoiceNum []byte
+}
+
+// MigratePayAddrIndex backfills the payment address index with the invoices
+// that have a payment address but were added before the index was created.
+// This allows all such invoices to be looked up by their payment address
+// directly.
+func MigratePayAddrIndex(tx kvdb.RwTx) error {
+	log.Infof("Migrating invoices to backfill payment address index")
+
+	invoices := tx.ReadWriteBucket(invoiceBucket)
+	if invoices == nil {
+		return nil
+	}
+
+	payAddrIndex, err := tx.CreateTopLevelBucket(payAddrIndexBucket)
+	if err != nil {
+		return err
+	}
+
+	// Collect the entries first, since the invoice bucket shouldn't be
+	// iterated while the database is modified.
+	var entries []payAddrEntry
+	err = invoices.ForEach(func(k, v []byte) error {
+		// A nil value indicates a nested bucket, such as the invoice
+		// indexes, which doesn't hold an invoice.
+		if v == nil {
+			return nil
+		}
+
+		payAddr, err := readPayAddr(v)
+		if err != nil {
+			return err
+		}
+
+		if payAddr == blankPayAddr {
+			return nil
+		}
+
+		invoiceNum := make([]byte, len(k))
+		copy(invoiceNum, k)
+
+		entries = append(entries, payAddrEntry{
+			payAddr:    payAddr,
+			invoiceNum: invoiceNum,
+		})
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	var numAdded int
+	for _, entry := range entries {
+		// Keep entries that are already present. Since payment
+		// addresses are random, a different invoice using the same
+		// address indicates that the address was reused, in which case
+		// the invoice that was indexed first is kept.
+		existing := payAddrIndex.Get(entry.payAddr[:])
+		if existing != nil {
+			if !bytes.Equal(existing, entry.invoiceNum) {
+				log.Warnf("Payment address %x of invoice %x "+
+					"already indexed, skipping",
+					entry.payAddr, entry.invoiceNum)
+			}
+
+			continue
+		}
+
+		err := payAddrIndex.Put(entry.payAddr[:], entry.invoiceNum)
+		if err != nil {
+			return err
+		}
+		numAdded++
+	}
+
+	log.Infof("Added %d invoices to payment address index", numAdded)
+
+	return nil
+}
+
+// readPayAddr reads the payment address from a serialized invoice. All other
+// fields of the invoice are skipped.
+func readPayAddr(invoiceBytes []byte) ([32]byte, error) {
+	var payAddr [32]byte
+
+	tlvStream, err := tlv.NewStream(
+		tlv.MakePrimitiveRecord(paymentAddrType, &payAddr),
+	)
+	if err != nil {
+		return payAddr, err
+	}
+
+	r := bytes.NewReader(invoiceBytes)
+
+	var bodyLen int64
+	err = binary.Read(r, byteOrder, &bodyLen)
+	if err != nil {
+		return payAddr, err
+	}
+
+	lr := io.LimitReader(r, bodyLen)
+	if err := tlvStream.Decode(lr); err != nil {
+		return payAddr, err
+	}
+
+	return payAddr, nil
+}
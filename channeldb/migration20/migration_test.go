@@ -0,0 +1,137 @@
+package migration20
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/cryptomeow/lnd/channeldb/kvdb"
+	"github.com/cryptomeow/lnd/channeldb/migtest"
+	"github.com/cryptomeow/lnd/tlv"
+)
+
+var (
+	hexStr = migtest.Hex
+
+	invoiceNum1 = hexStr("00000001")
+	invoiceNum2 = hexStr("00000002")
+	invoiceNum3 = hexStr("00000003")
+
+	payAddr1 = [32]byte{0x01}
+	payAddr2 = [32]byte{0x02}
+
+	// pre is the data in the invoices bucket before the migration. The
+	// second invoice is a legacy invoice without a payment address, and
+	// the third one is already present in the index.
+	pre = map[string]interface{}{
+		invoiceNum1: invoiceWithAddr(payAddr1),
+		invoiceNum2: invoiceWithAddr(blankPayAddr),
+		invoiceNum3: invoiceWithAddr(payAddr2),
+		"paymenthashes": map[string]interface{}{
+			hexStr("aa"): invoiceNum1,
+		},
+	}
+
+	// preIndex is the payment address index before the migration.
+	preIndex = map[string]interface{}{
+		string(payAddr2[:]): invoiceNum3,
+	}
+
+	// post is the expected payment address index after the migration.
+	post = map[string]interface{}{
+		string(payAddr1[:]): invoiceNum1,
+		string(payAddr2[:]): invoiceNum3,
+	}
+)
+
+// invoiceWithAddr serializes a minimal invoice that holds the given payment
+// address, along with another record that should be skipped.
+func invoiceWithAddr(payAddr [32]byte) string {
+	var memo = []byte("memo")
+
+	tlvStream, err := tlv.NewStream(
+		tlv.MakePrimitiveRecord(0, &memo),
+		tlv.MakePrimitiveRecord(paymentAddrType, &payAddr),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	var body bytes.Buffer
+	if err := tlvStream.Encode(&body); err != nil {
+		panic(err)
+	}
+
+	var b bytes.Buffer
+	err = binary.Write(&b, byteOrder, uint64(body.Len()))
+	if err != nil {
+		panic(err)
+	}
+	b.Write(body.Bytes())
+
+	return b.String()
+}
+
+// TestMigratePayAddrIndex asserts that the invoices with a payment address are
+// added to the payment address index.
+func TestMigratePayAddrIndex(t *testing.T) {
+	tests := []struct {
+		name     string
+		pre      map[string]interface{}
+		preIndex map[string]interface{}
+		post     map[string]interface{}
+	}{
+		{
+			name:     "migration ok",
+			pre:      pre,
+			preIndex: preIndex,
+			post:     post,
+		},
+		{
+			name:     "no invoices",
+			pre:      nil,
+			preIndex: nil,
+			post:     nil,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			// Before the migration we have an invoices bucket and
+			// a partially populated index.
+			before := func(tx kvdb.RwTx) error {
+				err := migtest.RestoreDB(
+					tx, invoiceBucket, test.pre,
+				)
+				if err != nil {
+					return err
+				}
+
+				return migtest.RestoreDB(
+					tx, payAddrIndexBucket, test.preIndex,
+				)
+			}
+
+			// After the migration, the invoices should be
+			// untouched and the index fully populated.
+			after := func(tx kvdb.RwTx) error {
+				err := migtest.VerifyDB(
+					tx, invoiceBucket, test.pre,
+				)
+				if err != nil {
+					return err
+				}
+
+				return migtest.VerifyDB(
+					tx, payAddrIndexBucket, test.post,
+				)
+			}
+
+			migtest.ApplyMigration(
+				t, before, after, MigratePayAddrIndex, false,
+			)
+		})
+	}
+}
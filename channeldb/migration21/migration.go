@@ -0,0 +1,118 @@
+package migration21
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/cryptomeow/lnd/channeldb/kvdb"
+)
+
+var (
+	// forwardingLogBucket is the bucket that stores the forwarding log.
+	// Each key within the bucket is a timestamp (in nano seconds since the
+	// unix epoch), and the value a slice of forwarding events for that
+	// timestamp.
+	forwardingLogBucket = []byte("circuit-fwd-log")
+
+	// forwardingRollupBucket is the bucket that stores hourly rollups of
+	// the forwarding log.
+	forwardingRollupBucket = []byte("circuit-fwd-log-rollup")
+
+	byteOrder = binary.BigEndian
+)
+
+const (
+	// forwardingEventSize is the size of a forwarding event: 8 byte
+	// incoming chan ID || 8 byte outgoing chan ID || 8 byte value in || 8
+	// byte value out.
+	forwardingEventSize = 32
+
+	// rollupInterval is the length of a rollup interval in seconds.
+	rollupInterval = 60 * 60
+)
+
+// rollupKey identifies the rollup of an outgoing channel within a rollup
+// interval.
+type rollupKey struct {
+	start  uint64
+	chanID uint64
+}
+
+// rollup is the aggregate of the forwards through an outgoing channel within a
+// rollup interval.
+type rollup struct {
+	numForwards uint64
+	amtOut      uint64
+	fees        uint64
+}
+
+// MigrateForwardingRollups creates the rollups of all forwarding events that
+// were logged before rollups were introduced.
+func MigrateForwardingRollups(tx kvdb.RwTx) error {
+	log.Infof("Migrating forwarding log to add rollups")
+
+	rollupBucket, err := tx.CreateTopLevelBucket(forwardingRollupBucket)
+	if err != nil {
+		return err
+	}
+
+	logBucket := tx.ReadWriteBucket(forwardingLogBucket)
+	if logBucket == nil {
+		return nil
+	}
+
+	rollups := make(map[rollupKey]*rollup)
+	err = logBucket.ForEach(func(k, v []byte) error {
+		if len(k) != 8 || len(v)%forwardingEventSize != 0 {
+			return fmt.Errorf("invalid forwarding event %x", k)
+		}
+
+		secs := byteOrder.Uint64(k) / 1e9
+		start := secs - secs%rollupInterval
+
+		for i := 0; i < len(v); i += forwardingEventSize {
+			event := v[i : i+forwardingEventSize]
+			outgoingChanID := byteOrder.Uint64(event[8:16])
+			amtIn := byteOrder.Uint64(event[16:24])
+			amtOut := byteOrder.Uint64(event[24:32])
+
+			key := rollupKey{start: start, chanID: outgoingChanID}
+			r, ok := rollups[key]
+			if !ok {
+				r = &rollup{}
+				rollups[key] = r
+			}
+
+			r.numForwards++
+			r.amtOut += amtOut
+			r.fees += amtIn - amtOut
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for key, r := range rollups {
+		var k [16]byte
+		byteOrder.PutUint64(k[:8], key.start)
+		byteOrder.PutUint64(k[8:], key.chanID)
+
+		var b bytes.Buffer
+		for _, value := range []uint64{r.numForwards, r.amtOut, r.fees} {
+			if err := binary.Write(&b, byteOrder, value); err != nil {
+				return err
+			}
+		}
+
+		if err := rollupBucket.Put(k[:], b.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	log.Infof("Created %d forwarding rollups", len(rollups))
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package migration21
+
+import (
+	"testing"
+
+	"github.com/cryptomeow/lnd/channeldb/kvdb"
+	"github.com/cryptomeow/lnd/channeldb/migtest"
+)
+
+var (
+	hexStr = migtest.Hex
+
+	// pre is the forwarding log before the migration. The first two
+	// events are forwarded through outgoing channel 2 within the same
+	// hour, the last one through channel 3 an hour later.
+	pre = map[string]interface{}{
+		// 1600000000s, 1000 msat in, 900 msat out.
+		hexStr("16345785d8a00000"): hexStr(
+			"0000000000000001" + "0000000000000002" +
+				"00000000000003e8" + "0000000000000384",
+		),
+		// 1600000060s, 500 msat in, 450 msat out.
+		hexStr("16345793d0e75800"): hexStr(
+			"0000000000000001" + "0000000000000002" +
+				"00000000000001f4" + "00000000000001c2",
+		),
+		// 1600003600s, 200 msat in, 190 msat out.
+		hexStr("16345acc0958a000"): hexStr(
+			"0000000000000001" + "0000000000000003" +
+				"00000000000000c8" + "00000000000000be",
+		),
+	}
+
+	// post is the expected rollup bucket after the migration. The hour
+	// of 1600000000s starts at 1599998400s (0x5f5e09c0).
+	post = map[string]interface{}{
+		hexStr("000000005f5e09c0" + "0000000000000002"): hexStr(
+			"0000000000000002" + "0000000000000546" +
+				"0000000000000096",
+		),
+		hexStr("000000005f5e17d0" + "0000000000000003"): hexStr(
+			"0000000000000001" + "00000000000000be" +
+				"000000000000000a",
+		),
+	}
+)
+
+// TestMigrateForwardingRollups asserts that the rollups of the existing
+// forwarding log are created.
+func TestMigrateForwardingRollups(t *testing.T) {
+	tests := []struct {
+		name string
+		pre  map[string]interface{}
+		post map[string]interface{}
+	}{
+		{
+			name: "migration ok",
+			pre:  pre,
+			post: post,
+		},
+		{
+			name: "no forwards",
+			pre:  nil,
+			post: nil,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			before := func(tx kvdb.RwTx) error {
+				return migtest.RestoreDB(
+					tx, forwardingLogBucket, test.pre,
+				)
+			}
+
+			// After the migration, the log should be untouched and
+			// the rollups created.
+			after := func(tx kvdb.RwTx) error {
+				err := migtest.VerifyDB(
+					tx, forwardingLogBucket, test.pre,
+				)
+				if err != nil {
+					return err
+				}
+
+				return migtest.VerifyDB(
+					tx, forwardingRollupBucket, test.post,
+				)
+			}
+
+			migtest.ApplyMigration(
+				t, before, after, MigrateForwardingRollups,
+				false,
+			)
+		})
+	}
+}
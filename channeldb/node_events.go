@@ -0,0 +1,332 @@
+package channeldb
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/cryptomeow/lnd/channeldb/kvdb"
+	"github.com/cryptomeow/lnd/lnwire"
+)
+
+var (
+	// nodeEventsBucket is a top level bucket that stores the node-wide
+	// event log. Each key is the big endian encoded sequence number of the
+	// event and the value a serialized NodeEvent. Sequence numbers are
+	// taken from the bucket's sequence, so they are never reused, even if
+	// old events are pruned from the log.
+	//
+	// node-events
+	//      |
+	//      |-- <seq num>: <node event>
+	nodeEventsBucket = []byte("node-events")
+)
+
+// NodeEventType identifies the kind of event that was written to the node
+// event log.
+type NodeEventType uint8
+
+const (
+	// NodeEventChannelPendingOpen is written when a channel enters the
+	// pending open state.
+	NodeEventChannelPendingOpen NodeEventType = 1
+
+	// NodeEventChannelOpen is written when a channel is fully open.
+	NodeEventChannelOpen NodeEventType = 2
+
+	// NodeEventChannelActive is written when a channel becomes active.
+	NodeEventChannelActive NodeEventType = 3
+
+	// NodeEventChannelInactive is written when a channel becomes inactive.
+	NodeEventChannelInactive NodeEventType = 4
+
+	// NodeEventChannelClosed is written when a channel is closed.
+	NodeEventChannelClosed NodeEventType = 5
+
+	// NodeEventPeerOnline is written when a peer comes online.
+	NodeEventPeerOnline NodeEventType = 6
+
+	// NodeEventPeerOffline is written when a peer goes offline.
+	NodeEventPeerOffline NodeEventType = 7
+
+	// NodeEventInvoiceAdded is written when a new invoice is added.
+	NodeEventInvoiceAdded NodeEventType = 8
+
+	// NodeEventInvoiceSettled is written when an invoice is settled.
+	NodeEventInvoiceSettled NodeEventType = 9
+
+	// NodeEventHtlcForward is written when an htlc is forwarded by the
+	// switch, which includes htlcs of our own payments.
+	NodeEventHtlcForward NodeEventType = 10
+
+	// NodeEventHtlcForwardFail is written when a forwarded htlc is failed
+	// by a downstream node.
+	NodeEventHtlcForwardFail NodeEventType = 11
+
+	// NodeEventHtlcLinkFail is written when an htlc is failed by one of
+	// our own links.
+	NodeEventHtlcLinkFail NodeEventType = 12
+
+	// NodeEventHtlcSettle is written when an htlc is settled.
+	NodeEventHtlcSettle NodeEventType = 13
+
+	// NodeEventReservationPruned is written when the reservation of a
+	// channel that was still being negotiated is pruned.
+	NodeEventReservationPruned NodeEventType = 14
+
+	// NodeEventFundingLockedStalled is written when the fundingLocked
+	// message of a confirmed channel repeatedly failed to be sent.
+	NodeEventFundingLockedStalled NodeEventType = 15
+)
+
+// String returns a human readable name of the event type.
+func (t NodeEventType) String() string {
+	switch t {
+	case NodeEventChannelPendingOpen:
+		return "ChannelPendingOpen"
+
+	case NodeEventChannelOpen:
+		return "ChannelOpen"
+
+	case NodeEventChannelActive:
+		return "ChannelActive"
+
+	case NodeEventChannelInactive:
+		return "ChannelInactive"
+
+	case NodeEventChannelClosed:
+		return "ChannelClosed"
+
+	case NodeEventPeerOnline:
+		return "PeerOnline"
+
+	case NodeEventPeerOffline:
+		return "PeerOffline"
+
+	case NodeEventInvoiceAdded:
+		return "InvoiceAdded"
+
+	case NodeEventInvoiceSettled:
+		return "InvoiceSettled"
+
+	case NodeEventHtlcForward:
+		return "HtlcForward"
+
+	case NodeEventHtlcForwardFail:
+		return "HtlcForwardFail"
+
+	case NodeEventHtlcLinkFail:
+		return "HtlcLinkFail"
+
+	case NodeEventHtlcSettle:
+		return "HtlcSettle"
+
+	case NodeEventReservationPruned:
+		return "ReservationPruned"
+
+	case NodeEventFundingLockedStalled:
+		return "FundingLockedStalled"
+
+	default:
+		return fmt.Sprintf("Unknown(%d)", uint8(t))
+	}
+}
+
+// NodeEvent is an entry in the node-wide event log. Only the fields that are
+// relevant for the type of the event are set, all others are left at their
+// zero value.
+type NodeEvent struct {
+	// SeqNum is the sequence number of the event. It is assigned when the
+	// event is added to the log and increases monotonically.
+	SeqNum uint64
+
+	// Timestamp is the time at which the event was added to the log.
+	Timestamp time.Time
+
+	// Type is the kind of the event.
+	Type NodeEventType
+
+	// ChanPoint is the channel point of the channel the event refers to.
+	// It is set for channel events.
+	ChanPoint wire.OutPoint
+
+	// PubKey is the compressed public key of the peer the event refers
+	// to. It is set for peer, reservation and funding locked stalled
+	// events.
+	PubKey [33]byte
+
+	// PaymentHash is the payment hash of the invoice the event refers to.
+	// It is set for invoice events.
+	PaymentHash [32]byte
+
+	// Amount is the value of an invoice for invoice add events, the amount
+	// paid for invoice settle events, the amount of the htlc on its
+	// outgoing channel (or the incoming channel for receives) for htlc
+	// events that carry amounts, and the channel capacity for reservation
+	// events.
+	Amount lnwire.MilliSatoshi
+
+	// IncomingCircuit identifies the incoming htlc of htlc events. Its
+	// channel ID is zero for htlcs of payments we sent.
+	IncomingCircuit CircuitKey
+
+	// OutgoingCircuit identifies the outgoing htlc of htlc events. Its
+	// channel ID is zero for htlcs we received.
+	OutgoingCircuit CircuitKey
+
+	// Reason gives additional details about the event, like the eviction
+	// reason of an offline peer, the failure of an htlc link fail event,
+	// the funding type of a pruned reservation or the number of failed
+	// attempts of a funding locked stalled event.
+	Reason string
+}
+
+// serializeNodeEvent writes out the target node event to the passed
+// io.Writer. Note that the sequence number isn't serialized as this will be
+// the key within the bucket.
+func serializeNodeEvent(w io.Writer, e *NodeEvent) error {
+	return WriteElements(
+		w, uint64(e.Timestamp.UnixNano()), uint8(e.Type), e.ChanPoint,
+		e.PubKey[:], e.PaymentHash, e.Amount,
+		e.IncomingCircuit.ChanID, e.IncomingCircuit.HtlcID,
+		e.OutgoingCircuit.ChanID, e.OutgoingCircuit.HtlcID,
+		[]byte(e.Reason),
+	)
+}
+
+// deserializeNodeEvent reads a node event from the passed io.Reader. The
+// sequence number is expected to be set by the caller.
+func deserializeNodeEvent(r io.Reader) (*NodeEvent, error) {
+	var (
+		e         NodeEvent
+		timestamp uint64
+		eventType uint8
+		pubKey    []byte
+		reason    []byte
+	)
+	err := ReadElements(
+		r, &timestamp, &eventType, &e.ChanPoint, &pubKey,
+		&e.PaymentHash, &e.Amount,
+		&e.IncomingCircuit.ChanID, &e.IncomingCircuit.HtlcID,
+		&e.OutgoingCircuit.ChanID, &e.OutgoingCircuit.HtlcID,
+		&reason,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(pubKey) != len(e.PubKey) {
+		return nil, fmt.Errorf("invalid node event pubkey length %d",
+			len(pubKey))
+	}
+	copy(e.PubKey[:], pubKey)
+
+	e.Timestamp = time.Unix(0, int64(timestamp))
+	e.Type = NodeEventType(eventType)
+	e.Reason = string(reason)
+
+	return &e, nil
+}
+
+// AddNodeEvent appends the given event to the node event log and writes the
+// sequence number it was assigned back to the event. If maxEvents is non-zero,
+// the oldest events are pruned so that at most maxEvents are kept in the log.
+func (d *DB) AddNodeEvent(event *NodeEvent, maxEvents uint64) error {
+	var seqNum uint64
+	err := kvdb.Update(d, func(tx kvdb.RwTx) error {
+		events, err := tx.CreateTopLevelBucket(nodeEventsBucket)
+		if err != nil {
+			return err
+		}
+
+		seqNum, err = events.NextSequence()
+		if err != nil {
+			return err
+		}
+
+		var b bytes.Buffer
+		if err := serializeNodeEvent(&b, event); err != nil {
+			return err
+		}
+
+		var key [8]byte
+		byteOrder.PutUint64(key[:], seqNum)
+		if err := events.Put(key[:], b.Bytes()); err != nil {
+			return err
+		}
+
+		if maxEvents == 0 || seqNum <= maxEvents {
+			return nil
+		}
+
+		// Collect all events that fell out of the retention window
+		// first, as we can't delete while iterating.
+		pruneBefore := seqNum - maxEvents + 1
+		var pruned [][]byte
+		cursor := events.ReadCursor()
+		for k, _ := cursor.First(); k != nil; k, _ = cursor.Next() {
+			if byteOrder.Uint64(k) >= pruneBefore {
+				break
+			}
+
+			pruned = append(pruned, append([]byte(nil), k...))
+		}
+
+		for _, k := range pruned {
+			if err := events.Delete(k); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}, func() {
+		seqNum = 0
+	})
+	if err != nil {
+		return err
+	}
+
+	event.SeqNum = seqNum
+
+	return nil
+}
+
+// FetchNodeEvents returns all events of the node event log with a sequence
+// number greater than afterSeqNum, ordered by their sequence number. Events
+// that were pruned from the log are not returned, so callers should check the
+// sequence number of the first returned event to detect gaps.
+func (d *DB) FetchNodeEvents(afterSeqNum uint64) ([]*NodeEvent, error) {
+	var events []*NodeEvent
+	err := kvdb.View(d, func(tx kvdb.RTx) error {
+		eventBucket := tx.ReadBucket(nodeEventsBucket)
+		if eventBucket == nil {
+			return nil
+		}
+
+		var start [8]byte
+		byteOrder.PutUint64(start[:], afterSeqNum+1)
+
+		cursor := eventBucket.ReadCursor()
+		k, v := cursor.Seek(start[:])
+		for ; k != nil; k, v = cursor.Next() {
+			event, err := deserializeNodeEvent(bytes.NewReader(v))
+			if err != nil {
+				return err
+			}
+			event.SeqNum = byteOrder.Uint64(k)
+
+			events = append(events, event)
+		}
+
+		return nil
+	}, func() {
+		events = nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
@@ -0,0 +1,86 @@
+package channeldb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/cryptomeow/lnd/lnwire"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNodeEvents tests that node events are assigned increasing sequence
+// numbers, can be fetched from a cursor and are pruned once the log exceeds
+// the maximum number of events.
+func TestNodeEvents(t *testing.T) {
+	t.Parallel()
+
+	db, cleanup, err := MakeTestDB()
+	require.NoError(t, err)
+	defer cleanup()
+
+	// Without any events, the log is empty.
+	events, err := db.FetchNodeEvents(0)
+	require.NoError(t, err)
+	require.Empty(t, events)
+
+	timestamp := time.Unix(0, 1000)
+	chanEvent := &NodeEvent{
+		Timestamp: timestamp,
+		Type:      NodeEventChannelOpen,
+		ChanPoint: wire.OutPoint{Index: 1},
+	}
+	peerEvent := &NodeEvent{
+		Timestamp: timestamp.Add(time.Second),
+		Type:      NodeEventPeerOffline,
+		PubKey:    [33]byte{2, 3},
+		Reason:    "ping timeout",
+	}
+	htlcEvent := &NodeEvent{
+		Timestamp: timestamp.Add(2 * time.Second),
+		Type:      NodeEventHtlcForward,
+		Amount:    1000,
+		IncomingCircuit: CircuitKey{
+			ChanID: lnwire.NewShortChanIDFromInt(1),
+			HtlcID: 2,
+		},
+		OutgoingCircuit: CircuitKey{
+			ChanID: lnwire.NewShortChanIDFromInt(3),
+			HtlcID: 4,
+		},
+	}
+	require.NoError(t, db.AddNodeEvent(chanEvent, 0))
+	require.NoError(t, db.AddNodeEvent(peerEvent, 0))
+	require.NoError(t, db.AddNodeEvent(htlcEvent, 0))
+	require.Equal(t, uint64(1), chanEvent.SeqNum)
+	require.Equal(t, uint64(2), peerEvent.SeqNum)
+	require.Equal(t, uint64(3), htlcEvent.SeqNum)
+
+	events, err = db.FetchNodeEvents(0)
+	require.NoError(t, err)
+	require.Equal(t, []*NodeEvent{chanEvent, peerEvent, htlcEvent}, events)
+
+	// Only events after the cursor are returned.
+	events, err = db.FetchNodeEvents(2)
+	require.NoError(t, err)
+	require.Equal(t, []*NodeEvent{htlcEvent}, events)
+
+	events, err = db.FetchNodeEvents(3)
+	require.NoError(t, err)
+	require.Empty(t, events)
+
+	// Adding an event with a limit of two events prunes the oldest two,
+	// but sequence numbers are never reused.
+	invoiceEvent := &NodeEvent{
+		Timestamp:   timestamp.Add(3 * time.Second),
+		Type:        NodeEventInvoiceSettled,
+		PaymentHash: [32]byte{1},
+		Amount:      2000,
+	}
+	require.NoError(t, db.AddNodeEvent(invoiceEvent, 2))
+	require.Equal(t, uint64(4), invoiceEvent.SeqNum)
+
+	events, err = db.FetchNodeEvents(0)
+	require.NoError(t, err)
+	require.Equal(t, []*NodeEvent{htlcEvent, invoiceEvent}, events)
+}
@@ -0,0 +1,495 @@
+package channeldb
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/cryptomeow/lnd/channeldb/kvdb"
+	"github.com/cryptomeow/lnd/lnwire"
+)
+
+var (
+	// openAttemptsBucket is a top level bucket that stores an audit log of
+	// the inbound channel open requests we've received, along with the
+	// decision we made for each of them. Each key within the bucket is a
+	// timestamp (in nano seconds since the unix epoch) and the value a
+	// serialized ChannelOpenAttempt.
+	//
+	// channel-open-attempts
+	//      |
+	//      |-- <timestamp>: <open attempt>
+	//      |-- <timestamp>: <open attempt>
+	//      |
+	//      |-- chan-point-index
+	//               |-- <chan point>: <timestamp>
+	openAttemptsBucket = []byte("channel-open-attempts")
+
+	// openAttemptsChanPointIndex is a sub-bucket of the open attempts
+	// bucket that maps the channel point of an accepted attempt to the
+	// timestamp it is stored under.
+	openAttemptsChanPointIndex = []byte("chan-point-index")
+
+	// openAttemptRetention is the age after which entries are removed from
+	// the audit log. Old entries are pruned whenever a new attempt is
+	// added, relative to the timestamp of the new attempt.
+	openAttemptRetention = 30 * 24 * time.Hour
+
+	// ErrOpenAttemptNotFound is returned when a channel open attempt can't
+	// be found in the audit log.
+	ErrOpenAttemptNotFound = errors.New("channel open attempt not found")
+)
+
+// AcceptorVerdict describes the verdict of the channel acceptor for an
+// inbound channel open attempt.
+type AcceptorVerdict uint8
+
+const (
+	// AcceptorNotConsulted indicates that the attempt was rejected before
+	// the channel acceptor was consulted.
+	AcceptorNotConsulted AcceptorVerdict = 0
+
+	// AcceptorAccepted indicates that the channel acceptor accepted the
+	// attempt.
+	AcceptorAccepted AcceptorVerdict = 1
+
+	// AcceptorRejected indicates that the channel acceptor rejected the
+	// attempt.
+	AcceptorRejected AcceptorVerdict = 2
+)
+
+// String returns a human readable version of the verdict.
+func (v AcceptorVerdict) String() string {
+	switch v {
+	case AcceptorNotConsulted:
+		return "NotConsulted"
+
+	case AcceptorAccepted:
+		return "Accepted"
+
+	case AcceptorRejected:
+		return "Rejected"
+
+	default:
+		return fmt.Sprintf("Unknown(%d)", uint8(v))
+	}
+}
+
+// ChannelOpenAttempt is an entry in the audit log of inbound channel open
+// requests. It records who attempted to open a channel with us, and why the
+// attempt was accepted or rejected.
+type ChannelOpenAttempt struct {
+	// Timestamp is the time at which the decision was made. It uniquely
+	// identifies the attempt within the log.
+	Timestamp time.Time
+
+	// PendingChanID is the pending channel ID the remote peer chose for
+	// the channel.
+	PendingChanID [32]byte
+
+	// Peer is the identity public key of the peer that attempted to open
+	// the channel.
+	Peer *btcec.PublicKey
+
+	// FundingAmt is the proposed capacity of the channel.
+	FundingAmt btcutil.Amount
+
+	// PushAmt is the amount the remote peer proposed to push to us.
+	PushAmt lnwire.MilliSatoshi
+
+	// Accepted is true if we responded to the attempt with an
+	// accept_channel message.
+	Accepted bool
+
+	// Reason is the reason the attempt was rejected. It is empty for
+	// accepted attempts.
+	Reason string
+
+	// AcceptorVerdict is the verdict of the channel acceptor.
+	AcceptorVerdict AcceptorVerdict
+
+	// ChanPoint is the channel point of the channel that resulted from an
+	// accepted attempt. It is nil until the remote peer sent us the
+	// funding outpoint.
+	ChanPoint *wire.OutPoint
+}
+
+// serializeOpenAttempt writes out the target open attempt to the passed
+// io.Writer. Note that the timestamp isn't serialized as this will be the key
+// within the bucket.
+func serializeOpenAttempt(w io.Writer, a *ChannelOpenAttempt) error {
+	err := WriteElements(
+		w, a.PendingChanID, a.Peer, a.FundingAmt, a.PushAmt,
+		a.Accepted, []byte(a.Reason), uint8(a.AcceptorVerdict),
+		a.ChanPoint != nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	if a.ChanPoint == nil {
+		return nil
+	}
+
+	return WriteElement(w, *a.ChanPoint)
+}
+
+// deserializeOpenAttempt reads an open attempt from the passed io.Reader. The
+// timestamp is expected to be set by the caller.
+func deserializeOpenAttempt(r io.Reader) (*ChannelOpenAttempt, error) {
+	var (
+		a            ChannelOpenAttempt
+		reason       []byte
+		verdict      uint8
+		hasChanPoint bool
+	)
+	err := ReadElements(
+		r, &a.PendingChanID, &a.Peer, &a.FundingAmt, &a.PushAmt,
+		&a.Accepted, &reason, &verdict, &hasChanPoint,
+	)
+	if err != nil {
+		return nil, err
+	}
+	a.Reason = string(reason)
+	a.AcceptorVerdict = AcceptorVerdict(verdict)
+
+	if hasChanPoint {
+		a.ChanPoint = &wire.OutPoint{}
+		if err := ReadElement(r, a.ChanPoint); err != nil {
+			return nil, err
+		}
+	}
+
+	return &a, nil
+}
+
+// AddChannelOpenAttempt adds a new entry to the audit log of inbound channel
+// open attempts. If an entry already exists for the attempt's timestamp, the
+// timestamp is increased until a free slot is found. The timestamp the attempt
+// was stored under is written back to the passed attempt. Entries that are
+// older than the retention period of the log are removed in the same
+// transaction. Rejected attempts are written in batches, as they may arrive in
+// bursts, while accepted attempts are written right away as the funding flow
+// waits for them.
+func (d *DB) AddChannelOpenAttempt(attempt *ChannelOpenAttempt) error {
+	var b bytes.Buffer
+	if err := serializeOpenAttempt(&b, attempt); err != nil {
+		return err
+	}
+
+	update := func(f func(tx kvdb.RwTx) error) error {
+		return kvdb.Update(d, f, func() {})
+	}
+	if !attempt.Accepted {
+		update = func(f func(tx kvdb.RwTx) error) error {
+			return kvdb.Batch(d.Backend, f)
+		}
+	}
+
+	var timestamp time.Time
+	err := update(func(tx kvdb.RwTx) error {
+		// The batch may be retried, so we always start from the
+		// attempt's original timestamp.
+		timestamp = attempt.Timestamp
+
+		attempts, err := tx.CreateTopLevelBucket(openAttemptsBucket)
+		if err != nil {
+			return err
+		}
+
+		err = pruneOpenAttempts(
+			attempts, timestamp.Add(-openAttemptRetention),
+		)
+		if err != nil {
+			return err
+		}
+
+		// Find a free slot for the attempt, in the unlikely case that
+		// two decisions were made in the same nano second.
+		var key [8]byte
+		for {
+			byteOrder.PutUint64(key[:], uint64(timestamp.UnixNano()))
+			if attempts.Get(key[:]) == nil {
+				break
+			}
+
+			timestamp = time.Unix(0, timestamp.UnixNano()+1)
+		}
+
+		return attempts.Put(key[:], b.Bytes())
+	})
+	if err != nil {
+		return err
+	}
+
+	attempt.Timestamp = timestamp
+
+	return nil
+}
+
+// pruneOpenAttempts removes all attempts that were made before the given
+// cutoff from the audit log, along with their channel point index entries.
+func pruneOpenAttempts(attempts kvdb.RwBucket, cutoff time.Time) error {
+	// Timestamps before the unix epoch can't be stored, so there is
+	// nothing to prune.
+	if cutoff.UnixNano() <= 0 {
+		return nil
+	}
+
+	var cutoffKey [8]byte
+	byteOrder.PutUint64(cutoffKey[:], uint64(cutoff.UnixNano()))
+
+	var (
+		expired    [][]byte
+		chanPoints []*wire.OutPoint
+	)
+	cursor := attempts.ReadCursor()
+	for k, v := cursor.First(); k != nil; k, v = cursor.Next() {
+		if v == nil || len(k) != 8 {
+			continue
+		}
+
+		// The keys are ordered by timestamp, so we can stop at the
+		// first attempt that is still within the retention period.
+		if bytes.Compare(k, cutoffKey[:]) >= 0 {
+			break
+		}
+
+		attempt, err := deserializeOpenAttempt(bytes.NewReader(v))
+		if err != nil {
+			return err
+		}
+		if attempt.ChanPoint != nil {
+			chanPoints = append(chanPoints, attempt.ChanPoint)
+		}
+
+		expired = append(expired, append([]byte(nil), k...))
+	}
+
+	for _, k := range expired {
+		if err := attempts.Delete(k); err != nil {
+			return err
+		}
+	}
+
+	index := attempts.NestedReadWriteBucket(openAttemptsChanPointIndex)
+	if index == nil {
+		return nil
+	}
+
+	for _, chanPoint := range chanPoints {
+		var chanPointBuf bytes.Buffer
+		if err := writeOutpoint(&chanPointBuf, chanPoint); err != nil {
+			return err
+		}
+
+		if err := index.Delete(chanPointBuf.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// IndexChannelOpenAttempt sets the channel point of the open attempt that is
+// stored under the given timestamp, and adds the attempt to the channel point
+// index.
+func (d *DB) IndexChannelOpenAttempt(timestamp time.Time,
+	chanPoint wire.OutPoint) error {
+
+	return kvdb.Update(d, func(tx kvdb.RwTx) error {
+		attempts := tx.ReadWriteBucket(openAttemptsBucket)
+		if attempts == nil {
+			return ErrOpenAttemptNotFound
+		}
+
+		var key [8]byte
+		byteOrder.PutUint64(key[:], uint64(timestamp.UnixNano()))
+
+		attemptBytes := attempts.Get(key[:])
+		if attemptBytes == nil {
+			return ErrOpenAttemptNotFound
+		}
+
+		attempt, err := deserializeOpenAttempt(
+			bytes.NewReader(attemptBytes),
+		)
+		if err != nil {
+			return err
+		}
+		attempt.ChanPoint = &chanPoint
+
+		var b bytes.Buffer
+		if err := serializeOpenAttempt(&b, attempt); err != nil {
+			return err
+		}
+		if err := attempts.Put(key[:], b.Bytes()); err != nil {
+			return err
+		}
+
+		index, err := attempts.CreateBucketIfNotExists(
+			openAttemptsChanPointIndex,
+		)
+		if err != nil {
+			return err
+		}
+
+		var chanPointBuf bytes.Buffer
+		if err := writeOutpoint(&chanPointBuf, &chanPoint); err != nil {
+			return err
+		}
+
+		return index.Put(chanPointBuf.Bytes(), key[:])
+	}, func() {})
+}
+
+// FetchChannelOpenAttempt returns the open attempt that resulted in the
+// channel with the given channel point.
+func (d *DB) FetchChannelOpenAttempt(
+	chanPoint wire.OutPoint) (*ChannelOpenAttempt, error) {
+
+	var attempt *ChannelOpenAttempt
+	err := kvdb.View(d, func(tx kvdb.RTx) error {
+		attempts := tx.ReadBucket(openAttemptsBucket)
+		if attempts == nil {
+			return ErrOpenAttemptNotFound
+		}
+
+		index := attempts.NestedReadBucket(openAttemptsChanPointIndex)
+		if index == nil {
+			return ErrOpenAttemptNotFound
+		}
+
+		var chanPointBuf bytes.Buffer
+		if err := writeOutpoint(&chanPointBuf, &chanPoint); err != nil {
+			return err
+		}
+
+		key := index.Get(chanPointBuf.Bytes())
+		if key == nil {
+			return ErrOpenAttemptNotFound
+		}
+
+		attemptBytes := attempts.Get(key)
+		if attemptBytes == nil {
+			return ErrOpenAttemptNotFound
+		}
+
+		var err error
+		attempt, err = deserializeOpenAttempt(
+			bytes.NewReader(attemptBytes),
+		)
+		if err != nil {
+			return err
+		}
+		attempt.Timestamp = time.Unix(0, int64(byteOrder.Uint64(key)))
+
+		return nil
+	}, func() {
+		attempt = nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return attempt, nil
+}
+
+// ChannelOpenAttemptQuery is a query for the audit log of inbound channel open
+// attempts. It allows a caller to retrieve the attempts of a particular time
+// slice, offset in that time slice, limiting the total number of responses
+// returned.
+type ChannelOpenAttemptQuery struct {
+	// StartTime is the start time of the time slice.
+	StartTime time.Time
+
+	// EndTime is the end time of the time slice.
+	EndTime time.Time
+
+	// IndexOffset is the offset within the time slice to start at.
+	IndexOffset uint32
+
+	// NumMaxAttempts is the max number of attempts to return.
+	NumMaxAttempts uint32
+}
+
+// ChannelOpenAttemptSlice is the response to a ChannelOpenAttemptQuery.
+type ChannelOpenAttemptSlice struct {
+	ChannelOpenAttemptQuery
+
+	// Attempts is the set of attempts that answer the query.
+	Attempts []*ChannelOpenAttempt
+
+	// LastIndexOffset is the index of the last element in the set of
+	// returned attempts. Callers can use this to resume their query.
+	LastIndexOffset uint32
+}
+
+// QueryChannelOpenAttempts returns the channel open attempts of the time slice
+// described by the query, ordered by their timestamp.
+func (d *DB) QueryChannelOpenAttempts(
+	q ChannelOpenAttemptQuery) (ChannelOpenAttemptSlice, error) {
+
+	var resp ChannelOpenAttemptSlice
+	err := kvdb.View(d, func(tx kvdb.RTx) error {
+		attempts := tx.ReadBucket(openAttemptsBucket)
+		if attempts == nil {
+			return nil
+		}
+
+		var startTime, endTime [8]byte
+		byteOrder.PutUint64(startTime[:], uint64(q.StartTime.UnixNano()))
+		byteOrder.PutUint64(endTime[:], uint64(q.EndTime.UnixNano()))
+
+		recordsToSkip := q.IndexOffset
+		cursor := attempts.ReadCursor()
+		k, v := cursor.Seek(startTime[:])
+		for ; k != nil && bytes.Compare(k, endTime[:]) <= 0; k, v = cursor.Next() {
+			if uint32(len(resp.Attempts)) >= q.NumMaxAttempts {
+				return nil
+			}
+
+			// The channel point index is a nested bucket and
+			// therefore has no value. Its key can't be mistaken for
+			// a timestamp as it isn't 8 bytes long.
+			if v == nil || len(k) != 8 {
+				continue
+			}
+
+			if recordsToSkip > 0 {
+				recordsToSkip--
+				continue
+			}
+
+			attempt, err := deserializeOpenAttempt(
+				bytes.NewReader(v),
+			)
+			if err != nil {
+				return err
+			}
+			attempt.Timestamp = time.Unix(
+				0, int64(byteOrder.Uint64(k)),
+			)
+
+			resp.Attempts = append(resp.Attempts, attempt)
+		}
+
+		return nil
+	}, func() {
+		resp = ChannelOpenAttemptSlice{
+			ChannelOpenAttemptQuery: q,
+		}
+	})
+	if err != nil {
+		return ChannelOpenAttemptSlice{}, err
+	}
+
+	resp.LastIndexOffset = q.IndexOffset + uint32(len(resp.Attempts))
+
+	return resp, nil
+}
@@ -0,0 +1,171 @@
+package channeldb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+)
+
+// TestChannelOpenAttempts tests that inbound channel open attempts can be
+// added to the audit log, indexed by their channel point and queried.
+func TestChannelOpenAttempts(t *testing.T) {
+	t.Parallel()
+
+	db, cleanup, err := MakeTestDB()
+	require.NoError(t, err)
+	defer cleanup()
+
+	// An empty log should return no attempts.
+	resp, err := db.QueryChannelOpenAttempts(ChannelOpenAttemptQuery{
+		StartTime:      time.Unix(0, 0),
+		EndTime:        time.Unix(1000, 0),
+		NumMaxAttempts: 10,
+	})
+	require.NoError(t, err)
+	require.Empty(t, resp.Attempts)
+
+	rejected := &ChannelOpenAttempt{
+		Timestamp:       time.Unix(100, 0),
+		PendingChanID:   [32]byte{1},
+		Peer:            pubKey,
+		FundingAmt:      20000,
+		Reason:          "channel too small",
+		AcceptorVerdict: AcceptorNotConsulted,
+	}
+	require.NoError(t, db.AddChannelOpenAttempt(rejected))
+
+	// The second attempt is made at the same time, so its timestamp is
+	// expected to be shifted by a nano second.
+	accepted := &ChannelOpenAttempt{
+		Timestamp:       time.Unix(100, 0),
+		PendingChanID:   [32]byte{2},
+		Peer:            pubKey,
+		FundingAmt:      500000,
+		PushAmt:         1000,
+		Accepted:        true,
+		AcceptorVerdict: AcceptorAccepted,
+	}
+	require.NoError(t, db.AddChannelOpenAttempt(accepted))
+	require.Equal(t, time.Unix(100, 1), accepted.Timestamp)
+
+	// Before the channel point is known, the attempt can't be looked up
+	// by it.
+	chanPoint := wire.OutPoint{Hash: [32]byte{3}, Index: 1}
+	_, err = db.FetchChannelOpenAttempt(chanPoint)
+	require.Equal(t, ErrOpenAttemptNotFound, err)
+
+	err = db.IndexChannelOpenAttempt(time.Unix(200, 0), chanPoint)
+	require.Equal(t, ErrOpenAttemptNotFound, err)
+
+	err = db.IndexChannelOpenAttempt(accepted.Timestamp, chanPoint)
+	require.NoError(t, err)
+	accepted.ChanPoint = &chanPoint
+
+	attempt, err := db.FetchChannelOpenAttempt(chanPoint)
+	require.NoError(t, err)
+	require.Equal(t, accepted, attempt)
+
+	// Querying the whole log should return both attempts in order.
+	resp, err = db.QueryChannelOpenAttempts(ChannelOpenAttemptQuery{
+		StartTime:      time.Unix(0, 0),
+		EndTime:        time.Unix(1000, 0),
+		NumMaxAttempts: 10,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []*ChannelOpenAttempt{rejected, accepted}, resp.Attempts)
+	require.EqualValues(t, 2, resp.LastIndexOffset)
+
+	// The offset and the max number of attempts should be respected.
+	resp, err = db.QueryChannelOpenAttempts(ChannelOpenAttemptQuery{
+		StartTime:      time.Unix(0, 0),
+		EndTime:        time.Unix(1000, 0),
+		IndexOffset:    1,
+		NumMaxAttempts: 10,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []*ChannelOpenAttempt{accepted}, resp.Attempts)
+
+	resp, err = db.QueryChannelOpenAttempts(ChannelOpenAttemptQuery{
+		StartTime:      time.Unix(0, 0),
+		EndTime:        time.Unix(1000, 0),
+		NumMaxAttempts: 1,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []*ChannelOpenAttempt{rejected}, resp.Attempts)
+	require.EqualValues(t, 1, resp.LastIndexOffset)
+
+	// Finally, a time slice that ends before the accepted attempt should
+	// only return the rejected one.
+	resp, err = db.QueryChannelOpenAttempts(ChannelOpenAttemptQuery{
+		StartTime:      time.Unix(0, 0),
+		EndTime:        time.Unix(100, 0),
+		NumMaxAttempts: 10,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []*ChannelOpenAttempt{rejected}, resp.Attempts)
+}
+
+// TestChannelOpenAttemptsRetention tests that attempts older than the
+// retention period are pruned from the audit log, along with their channel
+// point index entries, when a new attempt is added.
+func TestChannelOpenAttemptsRetention(t *testing.T) {
+	t.Parallel()
+
+	db, cleanup, err := MakeTestDB()
+	require.NoError(t, err)
+	defer cleanup()
+
+	old := &ChannelOpenAttempt{
+		Timestamp:       time.Unix(100, 0),
+		PendingChanID:   [32]byte{1},
+		Peer:            pubKey,
+		FundingAmt:      500000,
+		Accepted:        true,
+		AcceptorVerdict: AcceptorAccepted,
+	}
+	require.NoError(t, db.AddChannelOpenAttempt(old))
+
+	chanPoint := wire.OutPoint{Hash: [32]byte{3}, Index: 1}
+	err = db.IndexChannelOpenAttempt(old.Timestamp, chanPoint)
+	require.NoError(t, err)
+
+	// An attempt that is made exactly one retention period later doesn't
+	// prune the first one yet.
+	recent := &ChannelOpenAttempt{
+		Timestamp:       old.Timestamp.Add(openAttemptRetention),
+		PendingChanID:   [32]byte{2},
+		Peer:            pubKey,
+		FundingAmt:      20000,
+		Reason:          "channel too small",
+		AcceptorVerdict: AcceptorNotConsulted,
+	}
+	require.NoError(t, db.AddChannelOpenAttempt(recent))
+
+	_, err = db.FetchChannelOpenAttempt(chanPoint)
+	require.NoError(t, err)
+
+	// A later attempt moves the cutoff past the first attempt, which is
+	// expected to be removed from the log and the index.
+	latest := &ChannelOpenAttempt{
+		Timestamp:       recent.Timestamp.Add(time.Second),
+		PendingChanID:   [32]byte{3},
+		Peer:            pubKey,
+		FundingAmt:      20000,
+		Reason:          "channel too small",
+		AcceptorVerdict: AcceptorNotConsulted,
+	}
+	require.NoError(t, db.AddChannelOpenAttempt(latest))
+
+	_, err = db.FetchChannelOpenAttempt(chanPoint)
+	require.Equal(t, ErrOpenAttemptNotFound, err)
+
+	resp, err := db.QueryChannelOpenAttempts(ChannelOpenAttemptQuery{
+		StartTime:      time.Unix(0, 0),
+		EndTime:        latest.Timestamp,
+		NumMaxAttempts: 10,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []*ChannelOpenAttempt{recent, latest}, resp.Attempts)
+}
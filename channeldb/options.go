@@ -35,6 +35,10 @@ type Options struct {
 	// dryRun will fail to commit a successful migration when opening the
 	// database if set to true.
 	dryRun bool
+
+	// readOnly, if true, opens the database without initializing or
+	// migrating it, and refuses all writes to it.
+	readOnly bool
 }
 
 // DefaultOptions returns an Options populated with default values.
@@ -85,3 +89,12 @@ func OptionDryRunMigration(dryRun bool) OptionModifier {
 		o.dryRun = dryRun
 	}
 }
+
+// OptionReadOnly controls whether the database is opened read-only. A
+// read-only database is neither initialized nor migrated, so it must already
+// exist at the latest version, and all write transactions on it fail.
+func OptionReadOnly(readOnly bool) OptionModifier {
+	return func(o *Options) {
+		o.readOnly = readOnly
+	}
+}
@@ -22,6 +22,16 @@ var (
 	// ErrPaymentNotInitiated is returned if the payment wasn't initiated.
 	ErrPaymentNotInitiated = errors.New("payment isn't initiated")
 
+	// ErrPaymentKeyExists is returned if a payment is initiated with an
+	// idempotency key that was already used for the same payment hash.
+	ErrPaymentKeyExists = errors.New("payment with idempotency key " +
+		"already exists")
+
+	// ErrPaymentKeyMismatch is returned if a payment is initiated with an
+	// idempotency key that was already used for a different payment hash.
+	ErrPaymentKeyMismatch = errors.New("idempotency key already used " +
+		"for a different payment")
+
 	// ErrPaymentAlreadySucceeded is returned in the event we attempt to
 	// change the status of a payment already succeeded.
 	ErrPaymentAlreadySucceeded = errors.New("payment is already succeeded")
@@ -113,6 +123,33 @@ func (p *PaymentControl) InitPayment(paymentHash lntypes.Hash,
 		// from a previous execution of the batched db transaction.
 		updateErr = nil
 
+		// If the client supplied an idempotency key, we check whether
+		// it was used before. A retried request for the same payment
+		// must not initiate the payment again, whatever its status.
+		var keyIndex kvdb.RwBucket
+		if len(info.IdempotencyKey) > 0 {
+			var err error
+			keyIndex, err = tx.CreateTopLevelBucket(
+				paymentIdempotencyIndexBucket,
+			)
+			if err != nil {
+				return err
+			}
+
+			existingHash := keyIndex.Get(info.IdempotencyKey)
+			switch {
+			case existingHash == nil:
+
+			case bytes.Equal(existingHash, paymentHash[:]):
+				updateErr = ErrPaymentKeyExists
+				return nil
+
+			default:
+				updateErr = ErrPaymentKeyMismatch
+				return nil
+			}
+		}
+
 		bucket, err := createPaymentBucket(tx, paymentHash)
 		if err != nil {
 			return err
@@ -191,6 +228,15 @@ func (p *PaymentControl) InitPayment(paymentHash lntypes.Hash,
 			return err
 		}
 
+		// If the payment is retried after a failed attempt, we remove
+		// the idempotency key of the previous attempt, and store the
+		// key of this attempt in its place.
+		err = replaceIdempotencyKey(tx, bucket, keyIndex, paymentHash,
+			info.IdempotencyKey)
+		if err != nil {
+			return err
+		}
+
 		// We'll delete any lingering HTLCs to start with, in case we
 		// are initializing a payment that was attempted earlier, but
 		// left in a state where we could retry.
@@ -210,6 +256,36 @@ func (p *PaymentControl) InitPayment(paymentHash lntypes.Hash,
 	return updateErr
 }
 
+// replaceIdempotencyKey replaces the idempotency key of the payment in the
+// given bucket with the new key, updating the key index accordingly. If the new
+// key is empty, only the previous key is removed.
+func replaceIdempotencyKey(tx kvdb.RwTx, bucket, keyIndex kvdb.RwBucket,
+	paymentHash lntypes.Hash, key []byte) error {
+
+	if prevKey := bucket.Get(paymentIdempotencyKey); prevKey != nil {
+		prevIndex := tx.ReadWriteBucket(paymentIdempotencyIndexBucket)
+		if prevIndex != nil {
+			if err := prevIndex.Delete(prevKey); err != nil {
+				return err
+			}
+		}
+
+		if err := bucket.Delete(paymentIdempotencyKey); err != nil {
+			return err
+		}
+	}
+
+	if len(key) == 0 {
+		return nil
+	}
+
+	if err := bucket.Put(paymentIdempotencyKey, key); err != nil {
+		return err
+	}
+
+	return keyIndex.Put(key, paymentHash[:])
+}
+
 // paymentIndexTypeHash is a payment index type which indicates that we have
 // created an index of payment sequence number to payment hash.
 type paymentIndexType uint8
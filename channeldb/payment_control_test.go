@@ -281,6 +281,97 @@ func TestPaymentControlSwitchDoubleSend(t *testing.T) {
 	}
 }
 
+// fetchPaymentHashByKey returns the payment hash of the payment that was
+// initiated with the given idempotency key. ErrPaymentNotInitiated is returned
+// if no payment uses the key.
+func fetchPaymentHashByKey(db *DB, key []byte) (lntypes.Hash, error) {
+	var hash lntypes.Hash
+	err := kvdb.View(db, func(tx kvdb.RTx) error {
+		keyIndex := tx.ReadBucket(paymentIdempotencyIndexBucket)
+		if keyIndex == nil {
+			return ErrPaymentNotInitiated
+		}
+
+		hashBytes := keyIndex.Get(key)
+		if hashBytes == nil {
+			return ErrPaymentNotInitiated
+		}
+
+		copy(hash[:], hashBytes)
+
+		return nil
+	}, func() {})
+
+	return hash, err
+}
+
+// TestPaymentControlIdempotencyKey checks that payments can't be initiated
+// twice with the same idempotency key, and that the keys are indexed.
+func TestPaymentControlIdempotencyKey(t *testing.T) {
+	t.Parallel()
+
+	db, cleanup, err := MakeTestDB()
+	defer cleanup()
+	require.NoError(t, err, "unable to init db")
+
+	pControl := NewPaymentControl(db)
+
+	info, _, _, err := genInfo()
+	require.NoError(t, err)
+
+	key := []byte("key-1")
+	info.IdempotencyKey = key
+
+	// Initiating the payment stores the key with the payment.
+	require.NoError(t, pControl.InitPayment(info.PaymentHash, info))
+	assertPaymentInfo(t, pControl, info.PaymentHash, info, nil, nil)
+
+	hash, err := fetchPaymentHashByKey(db, key)
+	require.NoError(t, err)
+	require.Equal(t, info.PaymentHash, hash)
+
+	// A retried request with the same key is rejected with an error that
+	// allows the caller to track the existing payment.
+	err = pControl.InitPayment(info.PaymentHash, info)
+	require.Equal(t, ErrPaymentKeyExists, err)
+
+	// Another payment can't reuse the key.
+	otherInfo, _, _, err := genInfo()
+	require.NoError(t, err)
+	otherInfo.IdempotencyKey = key
+
+	err = pControl.InitPayment(otherInfo.PaymentHash, otherInfo)
+	require.Equal(t, ErrPaymentKeyMismatch, err)
+
+	// Even once the payment failed, it isn't retried with the same key.
+	_, err = pControl.Fail(info.PaymentHash, FailureReasonNoRoute)
+	require.NoError(t, err)
+
+	err = pControl.InitPayment(info.PaymentHash, info)
+	require.Equal(t, ErrPaymentKeyExists, err)
+
+	// Retrying the failed payment with a new key replaces the previous
+	// key.
+	newKey := []byte("key-2")
+	info.IdempotencyKey = newKey
+	require.NoError(t, pControl.InitPayment(info.PaymentHash, info))
+
+	_, err = fetchPaymentHashByKey(db, key)
+	require.Equal(t, ErrPaymentNotInitiated, err)
+
+	hash, err = fetchPaymentHashByKey(db, newKey)
+	require.NoError(t, err)
+	require.Equal(t, info.PaymentHash, hash)
+
+	// Deleting the payment removes its key from the index.
+	_, err = pControl.Fail(info.PaymentHash, FailureReasonNoRoute)
+	require.NoError(t, err)
+	require.NoError(t, db.DeletePayments())
+
+	_, err = fetchPaymentHashByKey(db, newKey)
+	require.Equal(t, ErrPaymentNotInitiated, err)
+}
+
 // TestPaymentControlSuccessesWithoutInFlight checks that the payment
 // control will disallow calls to Success when no payment is in flight.
 func TestPaymentControlSuccessesWithoutInFlight(t *testing.T) {
@@ -1019,3 +1110,125 @@ func assertNoIndex(t *testing.T, p *PaymentControl, seqNr uint64) {
 	_, err := fetchPaymentIndexEntry(t, p, seqNr)
 	require.Equal(t, errNoSequenceNrIndex, err)
 }
+
+// TestPaymentControlPrunePayments checks that PrunePayments only deletes the
+// payments or failed htlc attempts selected by the query.
+func TestPaymentControlPrunePayments(t *testing.T) {
+	t.Parallel()
+
+	db, cleanup, err := MakeTestDB()
+	require.NoError(t, err)
+	defer cleanup()
+
+	pControl := NewPaymentControl(db)
+
+	// Create a failed and a succeeded payment, each with a failed
+	// attempt, a failed payment and an in-flight payment. The payments
+	// are created a day apart, the oldest first.
+	const (
+		statusFailed = iota
+		statusSucceeded
+		statusInFlight
+	)
+	statuses := []int{
+		statusFailed, statusSucceeded, statusFailed, statusInFlight,
+	}
+	startTime := time.Unix(1600000000, 0)
+
+	var hashes []lntypes.Hash
+	for i, status := range statuses {
+		info, attempt, preimg, err := genInfo()
+		require.NoError(t, err)
+
+		info.CreationTime = startTime.Add(time.Duration(i) * 24 *
+			time.Hour)
+		require.NoError(t, pControl.InitPayment(info.PaymentHash, info))
+		hashes = append(hashes, info.PaymentHash)
+
+		_, err = pControl.RegisterAttempt(info.PaymentHash, attempt)
+		require.NoError(t, err)
+
+		if status == statusInFlight {
+			continue
+		}
+
+		_, err = pControl.FailAttempt(
+			info.PaymentHash, attempt.AttemptID,
+			&HTLCFailInfo{Reason: HTLCFailUnreadable},
+		)
+		require.NoError(t, err)
+
+		if status == statusFailed {
+			_, err = pControl.Fail(
+				info.PaymentHash, FailureReasonNoRoute,
+			)
+			require.NoError(t, err)
+			continue
+		}
+
+		attempt.AttemptID = 1
+		_, err = pControl.RegisterAttempt(info.PaymentHash, attempt)
+		require.NoError(t, err)
+		_, err = pControl.SettleAttempt(
+			info.PaymentHash, attempt.AttemptID,
+			&HTLCSettleInfo{Preimage: preimg},
+		)
+		require.NoError(t, err)
+	}
+
+	assertPayments := func(expected ...lntypes.Hash) {
+		t.Helper()
+
+		payments, err := db.FetchPayments()
+		require.NoError(t, err)
+		require.Len(t, payments, len(expected))
+		for i, payment := range payments {
+			require.Equal(t, expected[i], payment.Info.PaymentHash)
+		}
+	}
+
+	// Deleting the failed attempts keeps all payments, but removes the
+	// failed attempt of the succeeded payment.
+	numPruned, err := db.PrunePayments(PaymentPruneQuery{
+		FailedHtlcsOnly: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, numPruned)
+	assertPayments(hashes...)
+
+	payment, err := pControl.FetchPayment(hashes[1])
+	require.NoError(t, err)
+	require.Len(t, payment.HTLCs, 1)
+	require.NotNil(t, payment.HTLCs[0].Settle)
+	require.Equal(t, StatusSucceeded, payment.Status)
+
+	// Payments created after the cutoff are kept.
+	numPruned, err = db.PrunePayments(PaymentPruneQuery{
+		CreatedBefore: startTime.Add(time.Hour),
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, numPruned)
+	assertPayments(hashes[1:]...)
+
+	// The most recent payments are kept, even if they match the query.
+	numPruned, err = db.PrunePayments(PaymentPruneQuery{
+		FailedOnly: true,
+		KeepLast:   2,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 0, numPruned)
+	assertPayments(hashes[1:]...)
+
+	numPruned, err = db.PrunePayments(PaymentPruneQuery{
+		FailedOnly: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, numPruned)
+	assertPayments(hashes[1], hashes[3])
+
+	// The in-flight payment is never deleted.
+	numPruned, err = db.PrunePayments(PaymentPruneQuery{})
+	require.NoError(t, err)
+	require.Equal(t, 1, numPruned)
+	assertPayments(hashes[3])
+}
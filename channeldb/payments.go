@@ -93,6 +93,19 @@ var (
 	// store information about the reason a payment failed.
 	paymentFailInfoKey = []byte("payment-fail-info")
 
+	// paymentIdempotencyKey is a key used in the payment's sub-bucket to
+	// store the idempotency key the client supplied for the payment, if
+	// any.
+	paymentIdempotencyKey = []byte("payment-idempotency-key")
+
+	// paymentIdempotencyIndexBucket is the name of the top-level bucket
+	// within the database that maps the client supplied idempotency keys
+	// of payments to their payment hash.
+	// payment-idempotency-index
+	// 	|--<idempotency-key>: <payment hash>
+	// 	|--...
+	paymentIdempotencyIndexBucket = []byte("payment-idempotency-index")
+
 	// paymentsIndexBucket is the name of the top-level bucket within the
 	// database that stores an index of payment sequence numbers to its
 	// payment hash.
@@ -227,6 +240,12 @@ type PaymentCreationInfo struct {
 
 	// PaymentRequest is the full payment request, if any.
 	PaymentRequest []byte
+
+	// IdempotencyKey is an optional key supplied by the client that
+	// initiated the payment. Initiating another payment with the same key
+	// is rejected, which prevents a client that retries a request from
+	// paying twice.
+	IdempotencyKey []byte
 }
 
 // FetchPayments returns all sent payments found in the DB.
@@ -291,7 +310,19 @@ func fetchCreationInfo(bucket kvdb.RBucket) (*PaymentCreationInfo, error) {
 	}
 
 	r := bytes.NewReader(b)
-	return deserializePaymentCreationInfo(r)
+	info, err := deserializePaymentCreationInfo(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// The idempotency key is stored separately, so that the format of the
+	// creation info remains unchanged.
+	if key := bucket.Get(paymentIdempotencyKey); key != nil {
+		info.IdempotencyKey = make([]byte, len(key))
+		copy(info.IdempotencyKey, key)
+	}
+
+	return info, nil
 }
 
 func fetchPayment(bucket kvdb.RBucket) (*MPPayment, error) {
@@ -678,13 +709,51 @@ func fetchPaymentWithSequenceNumber(tx kvdb.RTx, paymentHash lntypes.Hash,
 
 // DeletePayments deletes all completed and failed payments from the DB.
 func (db *DB) DeletePayments() error {
-	return kvdb.Update(db, func(tx kvdb.RwTx) error {
+	_, err := db.PrunePayments(PaymentPruneQuery{})
+	return err
+}
+
+// PaymentPruneQuery selects the payments that are deleted by PrunePayments.
+// In-flight payments are never deleted.
+type PaymentPruneQuery struct {
+	// FailedOnly restricts pruning to failed payments.
+	FailedOnly bool
+
+	// FailedHtlcsOnly only deletes the failed htlc attempts of the
+	// selected payments, keeping the payments and their settled attempts.
+	FailedHtlcsOnly bool
+
+	// CreatedBefore restricts pruning to payments that were created
+	// before this time. The zero time selects payments of any age.
+	CreatedBefore time.Time
+
+	// KeepLast is the number of most recent payments that are kept,
+	// regardless of the other criteria.
+	KeepLast uint64
+}
+
+// PrunePayments deletes the completed payments selected by the query, or
+// their failed htlc attempts if FailedHtlcsOnly is set. The number of
+// payments that were deleted or had attempts deleted is returned.
+func (db *DB) PrunePayments(query PaymentPruneQuery) (int, error) {
+	var numPruned int
+	err := kvdb.Update(db, func(tx kvdb.RwTx) error {
 		payments := tx.ReadWriteBucket(paymentsRootBucket)
 		if payments == nil {
 			return nil
 		}
 
+		type storedPayment struct {
+			key     []byte
+			payment *MPPayment
+		}
+
 		var (
+			// storedPayments is the set of all payments, which we
+			// sort by sequence number to skip the most recent
+			// ones.
+			storedPayments []storedPayment
+
 			// deleteBuckets is the set of payment buckets we need
 			// to delete.
 			deleteBuckets [][]byte
@@ -692,9 +761,13 @@ func (db *DB) DeletePayments() error {
 			// deleteIndexes is the set of indexes pointing to these
 			// payments that need to be deleted.
 			deleteIndexes [][]byte
+
+			// deleteKeys is the set of idempotency keys of these
+			// payments that need to be removed from their index.
+			deleteKeys [][]byte
 		)
 		err := payments.ForEach(func(k, _ []byte) error {
-			bucket := payments.NestedReadWriteBucket(k)
+			bucket := payments.NestedReadBucket(k)
 			if bucket == nil {
 				// We only expect sub-buckets to be found in
 				// this top-level bucket.
@@ -702,21 +775,75 @@ func (db *DB) DeletePayments() error {
 					"payments bucket")
 			}
 
-			// If the status is InFlight, we cannot safely delete
-			// the payment information, so we return early.
-			paymentStatus, err := fetchPaymentStatus(bucket)
+			payment, err := fetchPayment(bucket)
 			if err != nil {
 				return err
 			}
 
+			// Copy the key, as the buckets are modified before it
+			// is used.
+			key := make([]byte, len(k))
+			copy(key, k)
+
+			storedPayments = append(storedPayments, storedPayment{
+				key:     key,
+				payment: payment,
+			})
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		// Skip the most recent payments that are always kept.
+		sort.Slice(storedPayments, func(i, j int) bool {
+			return storedPayments[i].payment.SequenceNum >
+				storedPayments[j].payment.SequenceNum
+		})
+		if uint64(len(storedPayments)) <= query.KeepLast {
+			return nil
+		}
+		storedPayments = storedPayments[query.KeepLast:]
+
+		for _, stored := range storedPayments {
+			payment := stored.payment
+
+			switch {
 			// If the status is InFlight, we cannot safely delete
-			// the payment information, so we return early.
-			if paymentStatus == StatusInFlight {
-				return nil
+			// the payment information, so we skip it.
+			case payment.Status == StatusInFlight:
+				continue
+
+			case query.FailedOnly && payment.Status != StatusFailed:
+				continue
+
+			case !query.CreatedBefore.IsZero() &&
+				!payment.Info.CreationTime.Before(
+					query.CreatedBefore,
+				):
+
+				continue
+			}
+
+			bucket := payments.NestedReadWriteBucket(stored.key)
+
+			// Only delete the failed attempts if requested.
+			if query.FailedHtlcsOnly {
+				deleted, err := deleteFailedHtlcs(bucket)
+				if err != nil {
+					return err
+				}
+				if deleted {
+					numPruned++
+				}
+
+				continue
 			}
 
 			// Add the bucket to the set of buckets we can delete.
-			deleteBuckets = append(deleteBuckets, k)
+			deleteBuckets = append(deleteBuckets, stored.key)
+			numPruned++
 
 			// Get all the sequence number associated with the
 			// payment, including duplicates.
@@ -727,10 +854,9 @@ func (db *DB) DeletePayments() error {
 
 			deleteIndexes = append(deleteIndexes, seqNrs...)
 
-			return nil
-		})
-		if err != nil {
-			return err
+			if key := bucket.Get(paymentIdempotencyKey); key != nil {
+				deleteKeys = append(deleteKeys, key)
+			}
 		}
 
 		for _, k := range deleteBuckets {
@@ -748,8 +874,62 @@ func (db *DB) DeletePayments() error {
 			}
 		}
 
+		// Finally, we remove the idempotency keys of the payments, so
+		// that they can be used again.
+		keyIndex := tx.ReadWriteBucket(paymentIdempotencyIndexBucket)
+		if keyIndex == nil {
+			return nil
+		}
+		for _, k := range deleteKeys {
+			if err := keyIndex.Delete(k); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}, func() {
+		numPruned = 0
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return numPruned, nil
+}
+
+// deleteFailedHtlcs deletes the failed htlc attempts of the payment found in
+// the given bucket, and returns whether any attempt was deleted.
+func deleteFailedHtlcs(bucket kvdb.RwBucket) (bool, error) {
+	htlcsBucket := bucket.NestedReadWriteBucket(paymentHtlcsBucket)
+	if htlcsBucket == nil {
+		return false, nil
+	}
+
+	var failedHtlcs [][]byte
+	err := htlcsBucket.ForEach(func(k, _ []byte) error {
+		htlcBucket := htlcsBucket.NestedReadBucket(k)
+		if htlcBucket == nil {
+			return fmt.Errorf("non bucket element in htlcs " +
+				"bucket")
+		}
+
+		if htlcBucket.Get(htlcFailInfoKey) != nil {
+			failedHtlcs = append(failedHtlcs, k)
+		}
+
 		return nil
-	}, func() {})
+	})
+	if err != nil {
+		return false, err
+	}
+
+	for _, k := range failedHtlcs {
+		if err := htlcsBucket.DeleteNestedBucket(k); err != nil {
+			return false, err
+		}
+	}
+
+	return len(failedHtlcs) > 0, nil
 }
 
 // fetchSequenceNumbers fetches all the sequence numbers associated with a
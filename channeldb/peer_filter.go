@@ -0,0 +1,104 @@
+package channeldb
+
+import (
+	"errors"
+
+	"github.com/cryptomeow/lnd/channeldb/kvdb"
+)
+
+var (
+	// peerFilterBucket is a top level bucket that stores the rules that
+	// were added at runtime to filter inbound peer connections. Each key
+	// within the bucket is the canonical form of a rule's network or AS
+	// number, and the value its action.
+	//
+	// peer-filter
+	//      |
+	//      |-- <rule>: <action>
+	//      |-- <rule>: <action>
+	peerFilterBucket = []byte("peer-filter")
+
+	// ErrPeerFilterRuleNotFound is returned when a peer filter rule that
+	// should be removed doesn't exist.
+	ErrPeerFilterRuleNotFound = errors.New("peer filter rule not found")
+)
+
+// PeerFilterAction is the action that a peer filter rule takes for matching
+// connections.
+type PeerFilterAction uint8
+
+const (
+	// PeerFilterDeny rejects matching connections.
+	PeerFilterDeny PeerFilterAction = 0
+
+	// PeerFilterAllow accepts matching connections, even if they also match
+	// a deny rule.
+	PeerFilterAllow PeerFilterAction = 1
+)
+
+// PeerFilterRule is a persisted rule to filter inbound peer connections.
+type PeerFilterRule struct {
+	// Rule is the canonical form of the network or AS number the rule
+	// matches, such as 10.0.0.0/8 or AS64496.
+	Rule string
+
+	// Action is the action the rule takes for matching connections.
+	Action PeerFilterAction
+}
+
+// PutPeerFilterRule stores a peer filter rule, replacing the action of an
+// existing rule for the same network or AS number.
+func (d *DB) PutPeerFilterRule(rule PeerFilterRule) error {
+	return kvdb.Update(d, func(tx kvdb.RwTx) error {
+		rules, err := tx.CreateTopLevelBucket(peerFilterBucket)
+		if err != nil {
+			return err
+		}
+
+		return rules.Put([]byte(rule.Rule), []byte{byte(rule.Action)})
+	}, func() {})
+}
+
+// DeletePeerFilterRule removes the peer filter rule for the given network or
+// AS number. ErrPeerFilterRuleNotFound is returned if there is none.
+func (d *DB) DeletePeerFilterRule(rule string) error {
+	return kvdb.Update(d, func(tx kvdb.RwTx) error {
+		rules := tx.ReadWriteBucket(peerFilterBucket)
+		if rules == nil || rules.Get([]byte(rule)) == nil {
+			return ErrPeerFilterRuleNotFound
+		}
+
+		return rules.Delete([]byte(rule))
+	}, func() {})
+}
+
+// FetchPeerFilterRules returns all persisted peer filter rules.
+func (d *DB) FetchPeerFilterRules() ([]PeerFilterRule, error) {
+	var rules []PeerFilterRule
+	err := kvdb.View(d, func(tx kvdb.RTx) error {
+		bucket := tx.ReadBucket(peerFilterBucket)
+		if bucket == nil {
+			return nil
+		}
+
+		return bucket.ForEach(func(k, v []byte) error {
+			if len(v) != 1 {
+				return errors.New("invalid peer filter rule")
+			}
+
+			rules = append(rules, PeerFilterRule{
+				Rule:   string(k),
+				Action: PeerFilterAction(v[0]),
+			})
+
+			return nil
+		})
+	}, func() {
+		rules = nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
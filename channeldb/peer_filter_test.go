@@ -0,0 +1,44 @@
+package channeldb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestPeerFilterRules tests that peer filter rules are stored, updated and
+// removed.
+func TestPeerFilterRules(t *testing.T) {
+	t.Parallel()
+
+	db, cleanup, err := MakeTestDB()
+	require.NoError(t, err)
+	defer cleanup()
+
+	rules, err := db.FetchPeerFilterRules()
+	require.NoError(t, err)
+	require.Empty(t, rules)
+
+	require.Equal(
+		t, ErrPeerFilterRuleNotFound,
+		db.DeletePeerFilterRule("10.0.0.0/8"),
+	)
+
+	deny := PeerFilterRule{Rule: "10.0.0.0/8", Action: PeerFilterDeny}
+	allow := PeerFilterRule{Rule: "AS64496", Action: PeerFilterAllow}
+	require.NoError(t, db.PutPeerFilterRule(deny))
+	require.NoError(t, db.PutPeerFilterRule(allow))
+
+	rules, err = db.FetchPeerFilterRules()
+	require.NoError(t, err)
+	require.Equal(t, []PeerFilterRule{deny, allow}, rules)
+
+	// Storing a rule for the same network replaces its action.
+	deny.Action = PeerFilterAllow
+	require.NoError(t, db.PutPeerFilterRule(deny))
+	require.NoError(t, db.DeletePeerFilterRule(allow.Rule))
+
+	rules, err = db.FetchPeerFilterRules()
+	require.NoError(t, err)
+	require.Equal(t, []PeerFilterRule{deny}, rules)
+}
@@ -20,15 +20,22 @@ var (
 	//      |
 	//      |-- <peer-pubkey>
 	//      |        |--flap-count-key: <ts><flap count>
+	//      |        |--online-history-key: <num events>[<ts><online>...]
 	//      |
 	//      |-- <peer-pubkey>
 	//      |        |--flap-count-key: <ts><flap count>
+	//      |        |--online-history-key: <num events>[<ts><online>...]
 	peersBucket = []byte("peers-bucket")
 
 	// flapCountKey is a key used in the peer pubkey sub-bucket that stores
 	// the timestamp of a peer's last flap count and its all time flap
 	// count.
 	flapCountKey = []byte("flap-count")
+
+	// onlineHistoryKey is a key used in the peer pubkey sub-bucket that
+	// stores the log of online and offline events that were recorded for
+	// the peer.
+	onlineHistoryKey = []byte("online-history")
 )
 
 var (
@@ -121,3 +128,111 @@ func (d *DB) ReadFlapCount(pubkey route.Vertex) (*FlapCount, error) {
 
 	return &flapCount, nil
 }
+
+// OnlineEvent is an entry in the online history of a peer.
+type OnlineEvent struct {
+	// Timestamp is the time at which the event was recorded.
+	Timestamp time.Time
+
+	// Online indicates whether the peer came online or went offline.
+	Online bool
+}
+
+// WriteOnlineHistories writes the online history for a set of peers to disk,
+// creating a bucket for the peer's pubkey if necessary. Note that this function
+// overwrites the current history of each peer.
+func (d *DB) WriteOnlineHistories(
+	histories map[route.Vertex][]*OnlineEvent) error {
+
+	return kvdb.Update(d, func(tx kvdb.RwTx) error {
+		peers := tx.ReadWriteBucket(peersBucket)
+
+		for peer, history := range histories {
+			peerBucket, err := peers.CreateBucketIfNotExists(
+				peer[:],
+			)
+			if err != nil {
+				return err
+			}
+
+			var b bytes.Buffer
+			err = WriteElement(&b, uint32(len(history)))
+			if err != nil {
+				return err
+			}
+
+			for _, event := range history {
+				err := serializeTime(&b, event.Timestamp)
+				if err != nil {
+					return err
+				}
+
+				if err := WriteElement(&b, event.Online); err != nil {
+					return err
+				}
+			}
+
+			err = peerBucket.Put(onlineHistoryKey, b.Bytes())
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}, func() {})
+}
+
+// ReadOnlineHistory reads the online history of a peer, failing with
+// ErrNoPeerBucket if the peer is not found. If no history is stored for the
+// peer, an empty history is returned.
+func (d *DB) ReadOnlineHistory(pubkey route.Vertex) ([]*OnlineEvent, error) {
+	var history []*OnlineEvent
+
+	if err := kvdb.View(d, func(tx kvdb.RTx) error {
+		peers := tx.ReadBucket(peersBucket)
+
+		peerBucket := peers.NestedReadBucket(pubkey[:])
+		if peerBucket == nil {
+			return ErrNoPeerBucket
+		}
+
+		historyBytes := peerBucket.Get(onlineHistoryKey)
+		if historyBytes == nil {
+			return nil
+		}
+
+		r := bytes.NewReader(historyBytes)
+
+		var numEvents uint32
+		if err := ReadElement(r, &numEvents); err != nil {
+			return err
+		}
+
+		history = make([]*OnlineEvent, 0, numEvents)
+		for i := uint32(0); i < numEvents; i++ {
+			var (
+				event OnlineEvent
+				err   error
+			)
+
+			event.Timestamp, err = deserializeTime(r)
+			if err != nil {
+				return err
+			}
+
+			if err := ReadElement(r, &event.Online); err != nil {
+				return err
+			}
+
+			history = append(history, &event)
+		}
+
+		return nil
+	}, func() {
+		history = nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return history, nil
+}
@@ -48,3 +48,54 @@ func TestFlapCount(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, peer2FlapCount, count)
 }
+
+// TestOnlineHistory tests lookup and writing of online histories to disk.
+func TestOnlineHistory(t *testing.T) {
+	db, cleanup, err := MakeTestDB()
+	require.NoError(t, err)
+	defer cleanup()
+
+	// Try to read the history of a peer that we have no records for.
+	_, err = db.ReadOnlineHistory(testPub)
+	require.Equal(t, ErrNoPeerBucket, err)
+
+	// A peer that only has a flap count recorded has an empty history.
+	err = db.WriteFlapCounts(map[route.Vertex]*FlapCount{
+		testPub: {Count: 1, LastFlap: time.Unix(100, 0)},
+	})
+	require.NoError(t, err)
+
+	history, err := db.ReadOnlineHistory(testPub)
+	require.NoError(t, err)
+	require.Empty(t, history)
+
+	peerHistory := []*OnlineEvent{
+		{
+			Timestamp: time.Unix(100, 23),
+			Online:    true,
+		},
+		{
+			Timestamp: time.Unix(200, 23),
+			Online:    false,
+		},
+	}
+
+	err = db.WriteOnlineHistories(map[route.Vertex][]*OnlineEvent{
+		testPub: peerHistory,
+	})
+	require.NoError(t, err)
+
+	history, err = db.ReadOnlineHistory(testPub)
+	require.NoError(t, err)
+	require.Equal(t, peerHistory, history)
+
+	// Writing the history again overwrites the previous one.
+	err = db.WriteOnlineHistories(map[route.Vertex][]*OnlineEvent{
+		testPub: peerHistory[1:],
+	})
+	require.NoError(t, err)
+
+	history, err = db.ReadOnlineHistory(testPub)
+	require.NoError(t, err)
+	require.Equal(t, peerHistory[1:], history)
+}
@@ -0,0 +1,67 @@
+package channeldb
+
+import (
+	"github.com/cryptomeow/lnd/channeldb/kvdb"
+)
+
+// readOnlyBackend wraps a database backend and refuses to start any write
+// transaction on it. As it doesn't implement walletdb.BatchDB, kvdb.Batch is
+// routed through Update as well.
+type readOnlyBackend struct {
+	kvdb.Backend
+}
+
+// BeginReadWriteTx always fails, as the database is opened read-only.
+//
+// NOTE: This is part of the walletdb.DB interface.
+func (b *readOnlyBackend) BeginReadWriteTx() (kvdb.RwTx, error) {
+	return nil, ErrDBReadOnly
+}
+
+// Update always fails, as the database is opened read-only.
+//
+// NOTE: This is part of the walletdb.DB interface.
+func (b *readOnlyBackend) Update(func(tx kvdb.RwTx) error, func()) error {
+	return ErrDBReadOnly
+}
+
+// openReadOnly opens the channel database on top of the passed backend without
+// initializing or migrating it. The database must thus already have been
+// created at the latest version.
+func openReadOnly(backend kvdb.Backend, opts *Options) (*DB, error) {
+	chanDB := &DB{
+		Backend:     &readOnlyBackend{Backend: backend},
+		clock:       opts.clock,
+		scidAliases: &scidAliasCache{},
+	}
+	chanDB.graph = newChannelGraph(
+		chanDB, opts.RejectCacheSize, opts.ChannelCacheSize,
+	)
+
+	meta, err := chanDB.FetchMeta(nil)
+	switch {
+	case err == ErrMetaNotFound:
+		backend.Close()
+		return nil, ErrNoChanDBExists
+
+	case err != nil:
+		backend.Close()
+		return nil, err
+	}
+
+	latestVersion := getLatestDBVersion(dbVersions)
+	log.Infof("Opening channel db read-only: latest_version=%v, "+
+		"db_version=%v", latestVersion, meta.DbVersionNumber)
+
+	switch {
+	case meta.DbVersionNumber > latestVersion:
+		backend.Close()
+		return nil, ErrDBReversion
+
+	case meta.DbVersionNumber < latestVersion:
+		backend.Close()
+		return nil, ErrDBNotMigrated
+	}
+
+	return chanDB, nil
+}
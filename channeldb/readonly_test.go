@@ -0,0 +1,100 @@
+package channeldb
+
+import (
+	"io/ioutil"
+	"os"
+	"sync/atomic"
+	"testing"
+
+	"github.com/cryptomeow/lnd/channeldb/kvdb"
+	"github.com/stretchr/testify/require"
+)
+
+// writeCountingBackend is a database backend that counts the write
+// transactions started on it.
+type writeCountingBackend struct {
+	kvdb.Backend
+
+	writeTxs int32
+}
+
+// BeginReadWriteTx counts the transaction and starts it on the wrapped
+// backend.
+func (b *writeCountingBackend) BeginReadWriteTx() (kvdb.RwTx, error) {
+	atomic.AddInt32(&b.writeTxs, 1)
+	return b.Backend.BeginReadWriteTx()
+}
+
+// TestOpenReadOnly asserts that a database that is opened read-only can be
+// read from, but that no write transaction is ever started on it.
+func TestOpenReadOnly(t *testing.T) {
+	t.Parallel()
+
+	cdb, cleanUp, err := MakeTestDB()
+	require.NoError(t, err)
+	defer cleanUp()
+
+	state := createTestChannel(t, cdb, openChannelOption())
+
+	// Open the database again read-only. The database isn't closed here,
+	// as that would close the backend it shares with cdb.
+	backend := &writeCountingBackend{Backend: cdb.Backend}
+	roDB, err := CreateWithBackend(backend, OptionReadOnly(true))
+	require.NoError(t, err)
+
+	channels, err := roDB.FetchAllChannels()
+	require.NoError(t, err)
+	require.Len(t, channels, 1)
+	require.Equal(t, state.FundingOutpoint, channels[0].FundingOutpoint)
+
+	// All writes are refused before a transaction is started.
+	node, err := createTestVertex(roDB)
+	require.NoError(t, err)
+	err = roDB.ChannelGraph().SetSourceNode(node)
+	require.Equal(t, ErrDBReadOnly, err)
+
+	err = roDB.PutMeta(&Meta{DbVersionNumber: 1})
+	require.Equal(t, ErrDBReadOnly, err)
+
+	require.Zero(t, atomic.LoadInt32(&backend.writeTxs))
+}
+
+// TestOpenReadOnlyVersion asserts that a database is only opened read-only if
+// it exists at the latest version, as it can neither be created nor migrated
+// then.
+func TestOpenReadOnlyVersion(t *testing.T) {
+	t.Parallel()
+
+	tempDir, err := ioutil.TempDir("", "channeldb")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	// An empty database isn't initialized.
+	backend, cleanUp, err := kvdb.GetTestBackend(tempDir, "cdb")
+	require.NoError(t, err)
+	defer cleanUp()
+
+	_, err = CreateWithBackend(
+		&writeCountingBackend{Backend: backend}, OptionReadOnly(true),
+	)
+	require.Equal(t, ErrNoChanDBExists, err)
+
+	// Databases at a prior version aren't migrated, and ones at a later
+	// version can't be opened either. As the backend is closed if the
+	// database can't be opened, a new one is created for each version.
+	latest := getLatestDBVersion(dbVersions)
+	versions := map[uint32]error{
+		1:          ErrDBNotMigrated,
+		latest + 1: ErrDBReversion,
+	}
+	for version, expErr := range versions {
+		cdb, cleanUp, err := MakeTestDB()
+		require.NoError(t, err)
+		defer cleanUp()
+
+		require.NoError(t, cdb.PutMeta(&Meta{DbVersionNumber: version}))
+
+		_, err = CreateWithBackend(cdb.Backend, OptionReadOnly(true))
+		require.Equal(t, expErr, err)
+	}
+}
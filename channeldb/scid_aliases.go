@@ -0,0 +1,314 @@
+package channeldb
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"sync"
+
+	"github.com/cryptomeow/lnd/channeldb/kvdb"
+	"github.com/cryptomeow/lnd/lnwire"
+)
+
+var (
+	// scidAliasBucket is a top level bucket that stores the alias short
+	// channel IDs of our private channels.
+	//
+	// scid-aliases
+	//      |
+	//      |-- local
+	//      |       |-- <base scid>: <alias scid>
+	//      |
+	//      |-- alias-index
+	//      |       |-- <alias scid>: <base scid>
+	//      |
+	//      |-- peer
+	//              |-- <base scid>: <alias scid>
+	scidAliasBucket = []byte("scid-aliases")
+
+	// localScidAliasBucket is a sub-bucket of the scid alias bucket that maps
+	// the real short channel ID of a channel to the alias we handed out
+	// to the peer.
+	localScidAliasBucket = []byte("local")
+
+	// scidAliasIndexBucket is a sub-bucket of the scid alias bucket that maps
+	// the aliases we handed out back to the real short channel IDs of
+	// their channels.
+	scidAliasIndexBucket = []byte("alias-index")
+
+	// peerScidAliasBucket is a sub-bucket of the scid alias bucket that maps
+	// the real short channel ID of a channel to the alias the peer handed
+	// out to us.
+	peerScidAliasBucket = []byte("peer")
+
+	// ErrScidAliasNotFound is returned when no alias is known for a short
+	// channel ID, or an alias is unknown.
+	ErrScidAliasNotFound = errors.New("scid alias not found")
+
+	// ErrNotScidAlias is returned when a peer alias is stored that isn't
+	// within the alias range.
+	ErrNotScidAlias = errors.New("short channel id is not an alias")
+)
+
+// scidAliasCache is an in-memory copy of the aliases we handed out, so that
+// htlcs addressed to them can be resolved without hitting the database. It is
+// loaded from the database on first use and kept up to date as aliases are
+// created.
+type scidAliasCache struct {
+	mu sync.Mutex
+
+	// loaded is true once the aliases have been read from the database.
+	loaded bool
+
+	// bases maps the aliases we handed out to the real short channel IDs
+	// of their channels.
+	bases map[lnwire.ShortChannelID]lnwire.ShortChannelID
+
+	// aliases maps the real short channel IDs of our private channels to
+	// the aliases we handed out for them.
+	aliases map[lnwire.ShortChannelID]lnwire.ShortChannelID
+}
+
+// loadScidAliases reads the aliases we handed out from the database into the
+// cache, unless they were read already. The caller must hold the cache mutex.
+func (d *DB) loadScidAliases() error {
+	if d.scidAliases.loaded {
+		return nil
+	}
+
+	bases := make(map[lnwire.ShortChannelID]lnwire.ShortChannelID)
+	aliases := make(map[lnwire.ShortChannelID]lnwire.ShortChannelID)
+	err := kvdb.View(d, func(tx kvdb.RTx) error {
+		aliasBucket := tx.ReadBucket(scidAliasBucket)
+		if aliasBucket == nil {
+			return nil
+		}
+
+		local := aliasBucket.NestedReadBucket(localScidAliasBucket)
+		if local == nil {
+			return nil
+		}
+
+		return local.ForEach(func(k, v []byte) error {
+			base := lnwire.NewShortChanIDFromInt(
+				byteOrder.Uint64(k),
+			)
+			alias := lnwire.NewShortChanIDFromInt(
+				byteOrder.Uint64(v),
+			)
+
+			bases[alias] = base
+			aliases[base] = alias
+
+			return nil
+		})
+	}, func() {
+		bases = make(map[lnwire.ShortChannelID]lnwire.ShortChannelID)
+		aliases = make(map[lnwire.ShortChannelID]lnwire.ShortChannelID)
+	})
+	if err != nil {
+		return err
+	}
+
+	d.scidAliases.bases = bases
+	d.scidAliases.aliases = aliases
+	d.scidAliases.loaded = true
+
+	return nil
+}
+
+// scidKey returns the database key of a short channel ID.
+func scidKey(scid lnwire.ShortChannelID) []byte {
+	var key [8]byte
+	byteOrder.PutUint64(key[:], scid.ToUint64())
+	return key[:]
+}
+
+// newScidAlias returns a random short channel ID within the alias range.
+func newScidAlias() (lnwire.ShortChannelID, error) {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return lnwire.ShortChannelID{}, err
+	}
+
+	numHeights := lnwire.AliasEndBlockHeight - lnwire.AliasStartBlockHeight
+	height := binary.BigEndian.Uint32(b[:4]) % numHeights
+
+	return lnwire.ShortChannelID{
+		BlockHeight: lnwire.AliasStartBlockHeight + height,
+		TxIndex:     binary.BigEndian.Uint32(b[4:]) & 0xFFFFFF,
+		TxPosition:  binary.BigEndian.Uint16(b[2:4]),
+	}, nil
+}
+
+// CreateScidAlias returns the alias we handed out for the channel with the
+// given short channel ID. If the channel doesn't have an alias yet, a new
+// random alias is created and stored.
+func (d *DB) CreateScidAlias(
+	base lnwire.ShortChannelID) (lnwire.ShortChannelID, error) {
+
+	d.scidAliases.mu.Lock()
+	defer d.scidAliases.mu.Unlock()
+
+	if err := d.loadScidAliases(); err != nil {
+		return lnwire.ShortChannelID{}, err
+	}
+
+	// If we already handed out an alias for this channel, we'll keep
+	// using it.
+	if alias, ok := d.scidAliases.aliases[base]; ok {
+		return alias, nil
+	}
+
+	var alias lnwire.ShortChannelID
+	err := kvdb.Update(d, func(tx kvdb.RwTx) error {
+		aliases, err := tx.CreateTopLevelBucket(scidAliasBucket)
+		if err != nil {
+			return err
+		}
+
+		local, err := aliases.CreateBucketIfNotExists(
+			localScidAliasBucket,
+		)
+		if err != nil {
+			return err
+		}
+
+		index, err := aliases.CreateBucketIfNotExists(
+			scidAliasIndexBucket,
+		)
+		if err != nil {
+			return err
+		}
+
+		// We draw random aliases until we find one that isn't in use
+		// yet.
+		for {
+			alias, err = newScidAlias()
+			if err != nil {
+				return err
+			}
+
+			if index.Get(scidKey(alias)) == nil {
+				break
+			}
+		}
+
+		baseKey := scidKey(base)
+		aliasKey := scidKey(alias)
+		if err := local.Put(baseKey, aliasKey); err != nil {
+			return err
+		}
+
+		return index.Put(aliasKey, baseKey)
+	}, func() {
+		alias = lnwire.ShortChannelID{}
+	})
+	if err != nil {
+		return lnwire.ShortChannelID{}, err
+	}
+
+	d.scidAliases.bases[alias] = base
+	d.scidAliases.aliases[base] = alias
+
+	return alias, nil
+}
+
+// LookupScidAlias returns the real short channel ID of the channel we handed
+// out the given alias for. The aliases are served from memory, so this can be
+// called for every forwarded htlc.
+func (d *DB) LookupScidAlias(
+	alias lnwire.ShortChannelID) (lnwire.ShortChannelID, error) {
+
+	d.scidAliases.mu.Lock()
+	defer d.scidAliases.mu.Unlock()
+
+	if err := d.loadScidAliases(); err != nil {
+		return lnwire.ShortChannelID{}, err
+	}
+
+	base, ok := d.scidAliases.bases[alias]
+	if !ok {
+		return lnwire.ShortChannelID{}, ErrScidAliasNotFound
+	}
+
+	return base, nil
+}
+
+// FetchScidAlias returns the alias we handed out for the channel with the
+// given short channel ID.
+func (d *DB) FetchScidAlias(
+	base lnwire.ShortChannelID) (lnwire.ShortChannelID, error) {
+
+	d.scidAliases.mu.Lock()
+	defer d.scidAliases.mu.Unlock()
+
+	if err := d.loadScidAliases(); err != nil {
+		return lnwire.ShortChannelID{}, err
+	}
+
+	alias, ok := d.scidAliases.aliases[base]
+	if !ok {
+		return lnwire.ShortChannelID{}, ErrScidAliasNotFound
+	}
+
+	return alias, nil
+}
+
+// PutPeerScidAlias stores the alias the peer handed out for the channel with
+// the given short channel ID.
+func (d *DB) PutPeerScidAlias(base, alias lnwire.ShortChannelID) error {
+	if !alias.IsAlias() {
+		return ErrNotScidAlias
+	}
+
+	return kvdb.Update(d, func(tx kvdb.RwTx) error {
+		aliases, err := tx.CreateTopLevelBucket(scidAliasBucket)
+		if err != nil {
+			return err
+		}
+
+		peer, err := aliases.CreateBucketIfNotExists(
+			peerScidAliasBucket,
+		)
+		if err != nil {
+			return err
+		}
+
+		return peer.Put(scidKey(base), scidKey(alias))
+	}, func() {})
+}
+
+// FetchPeerScidAlias returns the alias the peer handed out for the channel
+// with the given short channel ID.
+func (d *DB) FetchPeerScidAlias(
+	base lnwire.ShortChannelID) (lnwire.ShortChannelID, error) {
+
+	var alias lnwire.ShortChannelID
+	err := kvdb.View(d, func(tx kvdb.RTx) error {
+		aliases := tx.ReadBucket(scidAliasBucket)
+		if aliases == nil {
+			return ErrScidAliasNotFound
+		}
+
+		peer := aliases.NestedReadBucket(peerScidAliasBucket)
+		if peer == nil {
+			return ErrScidAliasNotFound
+		}
+
+		v := peer.Get(scidKey(base))
+		if v == nil {
+			return ErrScidAliasNotFound
+		}
+
+		alias = lnwire.NewShortChanIDFromInt(byteOrder.Uint64(v))
+		return nil
+	}, func() {
+		alias = lnwire.ShortChannelID{}
+	})
+	if err != nil {
+		return lnwire.ShortChannelID{}, err
+	}
+
+	return alias, nil
+}
@@ -0,0 +1,83 @@
+package channeldb
+
+import (
+	"testing"
+
+	"github.com/cryptomeow/lnd/lnwire"
+	"github.com/stretchr/testify/require"
+)
+
+// TestScidAliases tests that aliases are created once per channel, can be
+// resolved to their channel, and that peer aliases are stored.
+func TestScidAliases(t *testing.T) {
+	t.Parallel()
+
+	db, cleanup, err := MakeTestDB()
+	require.NoError(t, err)
+	defer cleanup()
+
+	base := lnwire.NewShortChanIDFromInt(1234)
+
+	// Unknown aliases can't be resolved.
+	_, err = db.LookupScidAlias(base)
+	require.Equal(t, ErrScidAliasNotFound, err)
+
+	// A new alias is created within the alias range, and the same alias is
+	// returned for subsequent calls.
+	alias, err := db.CreateScidAlias(base)
+	require.NoError(t, err)
+	require.True(t, alias.IsAlias())
+
+	sameAlias, err := db.CreateScidAlias(base)
+	require.NoError(t, err)
+	require.Equal(t, alias, sameAlias)
+
+	otherBase := lnwire.NewShortChanIDFromInt(5678)
+	otherAlias, err := db.CreateScidAlias(otherBase)
+	require.NoError(t, err)
+	require.NotEqual(t, alias, otherAlias)
+
+	// Both aliases resolve to their channels.
+	resolved, err := db.LookupScidAlias(alias)
+	require.NoError(t, err)
+	require.Equal(t, base, resolved)
+
+	resolved, err = db.LookupScidAlias(otherAlias)
+	require.NoError(t, err)
+	require.Equal(t, otherBase, resolved)
+
+	// The aliases we handed out can be fetched by their channels.
+	fetchedAlias, err := db.FetchScidAlias(base)
+	require.NoError(t, err)
+	require.Equal(t, alias, fetchedAlias)
+
+	_, err = db.FetchScidAlias(lnwire.NewShortChanIDFromInt(91011))
+	require.Equal(t, ErrScidAliasNotFound, err)
+
+	// The aliases are read back from the database once the cache is
+	// dropped, as happens on restart.
+	db.scidAliases.loaded = false
+
+	resolved, err = db.LookupScidAlias(alias)
+	require.NoError(t, err)
+	require.Equal(t, base, resolved)
+
+	sameAlias, err = db.CreateScidAlias(otherBase)
+	require.NoError(t, err)
+	require.Equal(t, otherAlias, sameAlias)
+
+	// Peer aliases must be within the alias range.
+	_, err = db.FetchPeerScidAlias(base)
+	require.Equal(t, ErrScidAliasNotFound, err)
+	require.Equal(t, ErrNotScidAlias, db.PutPeerScidAlias(base, otherBase))
+
+	peerAlias := lnwire.ShortChannelID{
+		BlockHeight: lnwire.AliasStartBlockHeight,
+		TxIndex:     1,
+	}
+	require.NoError(t, db.PutPeerScidAlias(base, peerAlias))
+
+	fetched, err := db.FetchPeerScidAlias(base)
+	require.NoError(t, err)
+	require.Equal(t, peerAlias, fetched)
+}
@@ -0,0 +1,589 @@
+package channeldb
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/cryptomeow/lnd/channeldb/kvdb"
+)
+
+var (
+	// watchOnlyBucket is a top level bucket that stores the output scripts
+	// that are watched without being able to spend from them, together
+	// with the outputs and transactions that were found for them.
+	//
+	// watch-only
+	//      |
+	//      |-- scripts
+	//      |       |-- <pk script>: <birth height><label>
+	//      |
+	//      |-- outputs
+	//      |       |-- <outpoint>: <watch-only output>
+	//      |
+	//      |-- txns
+	//      |       |-- <txid>: <watch-only tx>
+	//      |
+	//      |-- sync-tip: <height><block hash>
+	watchOnlyBucket = []byte("watch-only")
+
+	// watchedScriptsBucket is a sub-bucket of the watch-only bucket that
+	// maps each watched output script to its birth height and label.
+	watchedScriptsBucket = []byte("scripts")
+
+	// watchOnlyOutputsBucket is a sub-bucket of the watch-only bucket that
+	// stores all outputs paying to a watched script, spent or not.
+	watchOnlyOutputsBucket = []byte("outputs")
+
+	// watchOnlyTxnsBucket is a sub-bucket of the watch-only bucket that
+	// stores all transactions that pay to or spend from a watched script.
+	watchOnlyTxnsBucket = []byte("txns")
+
+	// watchOnlySyncTipKey is the key of the watch-only bucket that stores
+	// the height and hash of the last block that was scanned for the
+	// watched scripts.
+	watchOnlySyncTipKey = []byte("sync-tip")
+
+	// ErrEmptyWatchedScript is returned when an empty output script is
+	// added as a watched script.
+	ErrEmptyWatchedScript = errors.New("watched script must not be empty")
+)
+
+// WatchedScript is an output script that is watched for outputs paying to it
+// and spends from them, without the wallet being able to spend them.
+type WatchedScript struct {
+	// PkScript is the watched output script.
+	PkScript []byte
+
+	// Label is an optional label describing the script.
+	Label string
+
+	// BirthHeight is the height of the first block that may contain an
+	// output paying to the script.
+	BirthHeight uint32
+}
+
+// WatchOnlyOutput is an output paying to a watched script.
+type WatchOnlyOutput struct {
+	// OutPoint is the outpoint of the output.
+	OutPoint wire.OutPoint
+
+	// PkScript is the watched output script the output pays to.
+	PkScript []byte
+
+	// Value is the value of the output.
+	Value btcutil.Amount
+
+	// Height is the height of the block that contains the output.
+	Height uint32
+
+	// SpendingTx is the hash of the transaction that spent the output, or
+	// nil if it's unspent.
+	SpendingTx *chainhash.Hash
+
+	// SpendHeight is the height of the block that contains the spending
+	// transaction.
+	SpendHeight uint32
+}
+
+// WatchOnlyTx is a transaction that pays to or spends from a watched script.
+type WatchOnlyTx struct {
+	// Tx is the transaction itself.
+	Tx *wire.MsgTx
+
+	// BlockHash is the hash of the block that contains the transaction.
+	BlockHash chainhash.Hash
+
+	// Height is the height of the block that contains the transaction.
+	Height uint32
+
+	// Timestamp is the timestamp of the block that contains the
+	// transaction.
+	Timestamp time.Time
+
+	// Amount is the net amount the transaction added to the watched
+	// scripts, which is negative if more was spent from them than paid to
+	// them.
+	Amount btcutil.Amount
+}
+
+// WatchOnlyBlock holds everything a block changed about the watched scripts.
+type WatchOnlyBlock struct {
+	// Height is the height of the block.
+	Height uint32
+
+	// Hash is the hash of the block.
+	Hash chainhash.Hash
+
+	// Outputs are the new outputs paying to a watched script.
+	Outputs []*WatchOnlyOutput
+
+	// Spends maps the outpoints of watched outputs that were spent in the
+	// block to the hash of the spending transaction.
+	Spends map[wire.OutPoint]chainhash.Hash
+
+	// Txns are the transactions of the block that pay to or spend from a
+	// watched script.
+	Txns []*WatchOnlyTx
+}
+
+// serializeWatchOnlyOutput writes out the target output to the passed
+// io.Writer. The outpoint isn't serialized as it is the key within the bucket.
+func serializeWatchOnlyOutput(w io.Writer, o *WatchOnlyOutput) error {
+	var spendingTx chainhash.Hash
+	if o.SpendingTx != nil {
+		spendingTx = *o.SpendingTx
+	}
+
+	return WriteElements(
+		w, o.PkScript, o.Value, o.Height, o.SpendingTx != nil,
+		spendingTx, o.SpendHeight,
+	)
+}
+
+// deserializeWatchOnlyOutput reads an output from the passed io.Reader. The
+// outpoint is expected to be set by the caller.
+func deserializeWatchOnlyOutput(r io.Reader) (*WatchOnlyOutput, error) {
+	var (
+		o          WatchOnlyOutput
+		spent      bool
+		spendingTx chainhash.Hash
+	)
+	err := ReadElements(
+		r, &o.PkScript, &o.Value, &o.Height, &spent, &spendingTx,
+		&o.SpendHeight,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if spent {
+		o.SpendingTx = &spendingTx
+	}
+
+	return &o, nil
+}
+
+// serializeWatchOnlyTx writes out the target transaction to the passed
+// io.Writer.
+func serializeWatchOnlyTx(w io.Writer, t *WatchOnlyTx) error {
+	return WriteElements(
+		w, t.BlockHash, t.Height, uint64(t.Timestamp.Unix()),
+		int64(t.Amount), t.Tx,
+	)
+}
+
+// deserializeWatchOnlyTx reads a transaction from the passed io.Reader.
+func deserializeWatchOnlyTx(r io.Reader) (*WatchOnlyTx, error) {
+	var (
+		t         WatchOnlyTx
+		timestamp uint64
+		amount    int64
+	)
+	err := ReadElements(
+		r, &t.BlockHash, &t.Height, &timestamp, &amount, &t.Tx,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	t.Timestamp = time.Unix(int64(timestamp), 0)
+	t.Amount = btcutil.Amount(amount)
+
+	return &t, nil
+}
+
+// AddWatchedScripts stores the given scripts as watched scripts. Scripts that
+// are already watched are updated.
+func (d *DB) AddWatchedScripts(scripts []*WatchedScript) error {
+	return kvdb.Update(d, func(tx kvdb.RwTx) error {
+		watchOnly, err := tx.CreateTopLevelBucket(watchOnlyBucket)
+		if err != nil {
+			return err
+		}
+
+		scriptBucket, err := watchOnly.CreateBucketIfNotExists(
+			watchedScriptsBucket,
+		)
+		if err != nil {
+			return err
+		}
+
+		for _, script := range scripts {
+			if len(script.PkScript) == 0 {
+				return ErrEmptyWatchedScript
+			}
+
+			var b bytes.Buffer
+			err := WriteElements(
+				&b, script.BirthHeight, []byte(script.Label),
+			)
+			if err != nil {
+				return err
+			}
+
+			err = scriptBucket.Put(script.PkScript, b.Bytes())
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}, func() {})
+}
+
+// FetchWatchedScripts returns all watched scripts.
+func (d *DB) FetchWatchedScripts() ([]*WatchedScript, error) {
+	var scripts []*WatchedScript
+	err := kvdb.View(d, func(tx kvdb.RTx) error {
+		watchOnly := tx.ReadBucket(watchOnlyBucket)
+		if watchOnly == nil {
+			return nil
+		}
+
+		scriptBucket := watchOnly.NestedReadBucket(watchedScriptsBucket)
+		if scriptBucket == nil {
+			return nil
+		}
+
+		return scriptBucket.ForEach(func(k, v []byte) error {
+			script := &WatchedScript{
+				PkScript: append([]byte(nil), k...),
+			}
+
+			var label []byte
+			err := ReadElements(
+				bytes.NewReader(v), &script.BirthHeight, &label,
+			)
+			if err != nil {
+				return err
+			}
+			script.Label = string(label)
+
+			scripts = append(scripts, script)
+
+			return nil
+		})
+	}, func() {
+		scripts = nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return scripts, nil
+}
+
+// WatchOnlySyncTip returns the height and hash of the last block that was
+// scanned for the watched scripts. A zero height is returned if no block was
+// scanned yet.
+func (d *DB) WatchOnlySyncTip() (uint32, chainhash.Hash, error) {
+	var (
+		height uint32
+		hash   chainhash.Hash
+	)
+	err := kvdb.View(d, func(tx kvdb.RTx) error {
+		watchOnly := tx.ReadBucket(watchOnlyBucket)
+		if watchOnly == nil {
+			return nil
+		}
+
+		tip := watchOnly.Get(watchOnlySyncTipKey)
+		if tip == nil {
+			return nil
+		}
+
+		return ReadElements(bytes.NewReader(tip), &height, &hash)
+	}, func() {
+		height = 0
+		hash = chainhash.Hash{}
+	})
+	if err != nil {
+		return 0, chainhash.Hash{}, err
+	}
+
+	return height, hash, nil
+}
+
+// putWatchOnlySyncTip stores the height and hash of the last block that was
+// scanned for the watched scripts.
+func putWatchOnlySyncTip(watchOnly kvdb.RwBucket, height uint32,
+	hash chainhash.Hash) error {
+
+	var b bytes.Buffer
+	if err := WriteElements(&b, height, hash); err != nil {
+		return err
+	}
+
+	return watchOnly.Put(watchOnlySyncTipKey, b.Bytes())
+}
+
+// ApplyWatchOnlyBlock atomically stores the outputs and transactions a block
+// added for the watched scripts, marks the spent outputs as such, and
+// advances the sync tip to the block. Applying the same block twice has no
+// further effect.
+func (d *DB) ApplyWatchOnlyBlock(block *WatchOnlyBlock) error {
+	return kvdb.Update(d, func(tx kvdb.RwTx) error {
+		watchOnly, err := tx.CreateTopLevelBucket(watchOnlyBucket)
+		if err != nil {
+			return err
+		}
+
+		outputs, err := watchOnly.CreateBucketIfNotExists(
+			watchOnlyOutputsBucket,
+		)
+		if err != nil {
+			return err
+		}
+
+		txns, err := watchOnly.CreateBucketIfNotExists(
+			watchOnlyTxnsBucket,
+		)
+		if err != nil {
+			return err
+		}
+
+		for _, output := range block.Outputs {
+			if err := putWatchOnlyOutput(outputs, output); err != nil {
+				return err
+			}
+		}
+
+		for op, spendingTx := range block.Spends {
+			var k bytes.Buffer
+			if err := writeOutpoint(&k, &op); err != nil {
+				return err
+			}
+
+			v := outputs.Get(k.Bytes())
+			if v == nil {
+				continue
+			}
+
+			output, err := deserializeWatchOnlyOutput(
+				bytes.NewReader(v),
+			)
+			if err != nil {
+				return err
+			}
+			output.OutPoint = op
+
+			spendingTx := spendingTx
+			output.SpendingTx = &spendingTx
+			output.SpendHeight = block.Height
+
+			if err := putWatchOnlyOutput(outputs, output); err != nil {
+				return err
+			}
+		}
+
+		for _, txn := range block.Txns {
+			var b bytes.Buffer
+			if err := serializeWatchOnlyTx(&b, txn); err != nil {
+				return err
+			}
+
+			txHash := txn.Tx.TxHash()
+			if err := txns.Put(txHash[:], b.Bytes()); err != nil {
+				return err
+			}
+		}
+
+		return putWatchOnlySyncTip(watchOnly, block.Height, block.Hash)
+	}, func() {})
+}
+
+// putWatchOnlyOutput stores the given output in the outputs bucket.
+func putWatchOnlyOutput(outputs kvdb.RwBucket, output *WatchOnlyOutput) error {
+	var k bytes.Buffer
+	if err := writeOutpoint(&k, &output.OutPoint); err != nil {
+		return err
+	}
+
+	var v bytes.Buffer
+	if err := serializeWatchOnlyOutput(&v, output); err != nil {
+		return err
+	}
+
+	return outputs.Put(k.Bytes(), v.Bytes())
+}
+
+// RewindWatchOnly removes all outputs and transactions found in blocks above
+// the given height, marks all outputs spent above it as unspent again, and
+// sets the sync tip to the given block. This is used to rescan blocks after a
+// reorg or after scripts with an earlier birth height were added.
+func (d *DB) RewindWatchOnly(height uint32, hash chainhash.Hash) error {
+	return kvdb.Update(d, func(tx kvdb.RwTx) error {
+		watchOnly, err := tx.CreateTopLevelBucket(watchOnlyBucket)
+		if err != nil {
+			return err
+		}
+
+		outputs := watchOnly.NestedReadWriteBucket(watchOnlyOutputsBucket)
+		if outputs != nil {
+			var (
+				deleted [][]byte
+				updated []*WatchOnlyOutput
+			)
+			err := outputs.ForEach(func(k, v []byte) error {
+				output, err := deserializeWatchOnlyOutput(
+					bytes.NewReader(v),
+				)
+				if err != nil {
+					return err
+				}
+
+				switch {
+				case output.Height > height:
+					deleted = append(
+						deleted, append([]byte(nil), k...),
+					)
+
+				case output.SpendingTx != nil &&
+					output.SpendHeight > height:
+
+					err := readOutpoint(
+						bytes.NewReader(k), &output.OutPoint,
+					)
+					if err != nil {
+						return err
+					}
+
+					output.SpendingTx = nil
+					output.SpendHeight = 0
+					updated = append(updated, output)
+				}
+
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+
+			for _, k := range deleted {
+				if err := outputs.Delete(k); err != nil {
+					return err
+				}
+			}
+			for _, output := range updated {
+				err := putWatchOnlyOutput(outputs, output)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		txns := watchOnly.NestedReadWriteBucket(watchOnlyTxnsBucket)
+		if txns != nil {
+			var deleted [][]byte
+			err := txns.ForEach(func(k, v []byte) error {
+				txn, err := deserializeWatchOnlyTx(
+					bytes.NewReader(v),
+				)
+				if err != nil {
+					return err
+				}
+
+				if txn.Height > height {
+					deleted = append(
+						deleted, append([]byte(nil), k...),
+					)
+				}
+
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+
+			for _, k := range deleted {
+				if err := txns.Delete(k); err != nil {
+					return err
+				}
+			}
+		}
+
+		return putWatchOnlySyncTip(watchOnly, height, hash)
+	}, func() {})
+}
+
+// FetchWatchOnlyOutputs returns all outputs paying to a watched script, spent
+// or not.
+func (d *DB) FetchWatchOnlyOutputs() ([]*WatchOnlyOutput, error) {
+	var outputs []*WatchOnlyOutput
+	err := kvdb.View(d, func(tx kvdb.RTx) error {
+		watchOnly := tx.ReadBucket(watchOnlyBucket)
+		if watchOnly == nil {
+			return nil
+		}
+
+		outputBucket := watchOnly.NestedReadBucket(watchOnlyOutputsBucket)
+		if outputBucket == nil {
+			return nil
+		}
+
+		return outputBucket.ForEach(func(k, v []byte) error {
+			output, err := deserializeWatchOnlyOutput(
+				bytes.NewReader(v),
+			)
+			if err != nil {
+				return err
+			}
+
+			err = readOutpoint(bytes.NewReader(k), &output.OutPoint)
+			if err != nil {
+				return err
+			}
+
+			outputs = append(outputs, output)
+
+			return nil
+		})
+	}, func() {
+		outputs = nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return outputs, nil
+}
+
+// FetchWatchOnlyTxns returns all transactions that pay to or spend from a
+// watched script.
+func (d *DB) FetchWatchOnlyTxns() ([]*WatchOnlyTx, error) {
+	var txns []*WatchOnlyTx
+	err := kvdb.View(d, func(tx kvdb.RTx) error {
+		watchOnly := tx.ReadBucket(watchOnlyBucket)
+		if watchOnly == nil {
+			return nil
+		}
+
+		txnBucket := watchOnly.NestedReadBucket(watchOnlyTxnsBucket)
+		if txnBucket == nil {
+			return nil
+		}
+
+		return txnBucket.ForEach(func(_, v []byte) error {
+			txn, err := deserializeWatchOnlyTx(bytes.NewReader(v))
+			if err != nil {
+				return err
+			}
+
+			txns = append(txns, txn)
+
+			return nil
+		})
+	}, func() {
+		txns = nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return txns, nil
+}
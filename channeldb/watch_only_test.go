@@ -0,0 +1,134 @@
+package channeldb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+)
+
+// TestWatchOnly tests that watched scripts, their outputs and transactions are
+// stored, and that rewinding removes everything found above the rewind height.
+func TestWatchOnly(t *testing.T) {
+	t.Parallel()
+
+	db, cleanup, err := MakeTestDB()
+	require.NoError(t, err)
+	defer cleanup()
+
+	// Without any data, empty results are returned.
+	scripts, err := db.FetchWatchedScripts()
+	require.NoError(t, err)
+	require.Empty(t, scripts)
+
+	height, _, err := db.WatchOnlySyncTip()
+	require.NoError(t, err)
+	require.Zero(t, height)
+
+	require.Equal(
+		t, ErrEmptyWatchedScript,
+		db.AddWatchedScripts([]*WatchedScript{{}}),
+	)
+
+	script := &WatchedScript{
+		PkScript:    []byte{0x00, 0x14, 0x01},
+		Label:       "cold",
+		BirthHeight: 100,
+	}
+	require.NoError(t, db.AddWatchedScripts([]*WatchedScript{script}))
+
+	scripts, err = db.FetchWatchedScripts()
+	require.NoError(t, err)
+	require.Equal(t, []*WatchedScript{script}, scripts)
+
+	// Apply a block paying to the script, followed by a block spending
+	// the output.
+	fundingTx := wire.NewMsgTx(2)
+	fundingTx.AddTxIn(wire.NewTxIn(&wire.OutPoint{Index: 1}, []byte{1}, nil))
+	fundingTx.AddTxOut(wire.NewTxOut(1000, script.PkScript))
+	output := &WatchOnlyOutput{
+		OutPoint: wire.OutPoint{Hash: fundingTx.TxHash()},
+		PkScript: script.PkScript,
+		Value:    1000,
+		Height:   101,
+	}
+	fundingBlock := &WatchOnlyBlock{
+		Height:  101,
+		Hash:    chainhash.Hash{1},
+		Outputs: []*WatchOnlyOutput{output},
+		Txns: []*WatchOnlyTx{{
+			Tx:        fundingTx,
+			BlockHash: chainhash.Hash{1},
+			Height:    101,
+			Timestamp: time.Unix(1000, 0),
+			Amount:    1000,
+		}},
+	}
+	require.NoError(t, db.ApplyWatchOnlyBlock(fundingBlock))
+
+	spendTx := wire.NewMsgTx(2)
+	spendTx.AddTxIn(wire.NewTxIn(&output.OutPoint, []byte{1}, nil))
+	spendTx.AddTxOut(wire.NewTxOut(900, []byte{0x51}))
+	spendBlock := &WatchOnlyBlock{
+		Height: 102,
+		Hash:   chainhash.Hash{2},
+		Spends: map[wire.OutPoint]chainhash.Hash{
+			output.OutPoint: spendTx.TxHash(),
+		},
+		Txns: []*WatchOnlyTx{{
+			Tx:        spendTx,
+			BlockHash: chainhash.Hash{2},
+			Height:    102,
+			Timestamp: time.Unix(2000, 0),
+			Amount:    -1000,
+		}},
+	}
+	require.NoError(t, db.ApplyWatchOnlyBlock(spendBlock))
+
+	// Applying a block twice has no further effect.
+	require.NoError(t, db.ApplyWatchOnlyBlock(spendBlock))
+
+	height, hash, err := db.WatchOnlySyncTip()
+	require.NoError(t, err)
+	require.Equal(t, uint32(102), height)
+	require.Equal(t, chainhash.Hash{2}, hash)
+
+	outputs, err := db.FetchWatchOnlyOutputs()
+	require.NoError(t, err)
+	require.Len(t, outputs, 1)
+	require.Equal(t, spendTx.TxHash(), *outputs[0].SpendingTx)
+	require.Equal(t, uint32(102), outputs[0].SpendHeight)
+
+	txns, err := db.FetchWatchOnlyTxns()
+	require.NoError(t, err)
+	require.Len(t, txns, 2)
+
+	// Rewinding to the funding block marks the output as unspent again
+	// and removes the spending transaction.
+	require.NoError(t, db.RewindWatchOnly(101, chainhash.Hash{1}))
+
+	outputs, err = db.FetchWatchOnlyOutputs()
+	require.NoError(t, err)
+	require.Equal(t, []*WatchOnlyOutput{output}, outputs)
+
+	txns, err = db.FetchWatchOnlyTxns()
+	require.NoError(t, err)
+	require.Equal(t, fundingBlock.Txns, txns)
+
+	// Rewinding below the funding block removes everything.
+	require.NoError(t, db.RewindWatchOnly(100, chainhash.Hash{}))
+
+	outputs, err = db.FetchWatchOnlyOutputs()
+	require.NoError(t, err)
+	require.Empty(t, outputs)
+
+	txns, err = db.FetchWatchOnlyTxns()
+	require.NoError(t, err)
+	require.Empty(t, txns)
+
+	height, _, err = db.WatchOnlySyncTip()
+	require.NoError(t, err)
+	require.Equal(t, uint32(100), height)
+}
@@ -4,6 +4,7 @@ import (
 	"sync"
 
 	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
 	"github.com/cryptomeow/lnd/channeldb"
 	"github.com/cryptomeow/lnd/subscribe"
 )
@@ -65,6 +66,40 @@ type ClosedChannelEvent struct {
 	CloseSummary *channeldb.ChannelCloseSummary
 }
 
+// PrunedReservationEvent represents a new event where the reservation of a
+// channel that was still being negotiated was pruned by the zombie sweeper,
+// because it wasn't updated within its reservation timeout.
+type PrunedReservationEvent struct {
+	// PeerPubKey is the identity public key of the remote peer.
+	PeerPubKey [33]byte
+
+	// PendingChanID is the temporary channel ID of the channel.
+	PendingChanID [32]byte
+
+	// Capacity is the capacity of the channel.
+	Capacity btcutil.Amount
+
+	// Initiator is true if we initiated the channel.
+	Initiator bool
+
+	// Psbt is true if the channel was funded through a PSBT.
+	Psbt bool
+}
+
+// FundingLockedStalledEvent represents a new event where we repeatedly failed
+// to send the fundingLocked message of a confirmed channel to the remote peer,
+// so the channel establishment with the peer doesn't complete.
+type FundingLockedStalledEvent struct {
+	// ChannelPoint is the funding outpoint of the channel.
+	ChannelPoint wire.OutPoint
+
+	// PeerPubKey is the identity public key of the remote peer.
+	PeerPubKey [33]byte
+
+	// Attempts is the number of failed attempts to send the message.
+	Attempts uint32
+}
+
 // New creates a new channel notifier. The ChannelNotifier gets channel
 // events from peers and from the chain arbitrator, and dispatches them to
 // its clients.
@@ -153,6 +188,27 @@ func (c *ChannelNotifier) NotifyClosedChannelEvent(chanPoint wire.OutPoint) {
 	}
 }
 
+// NotifyPrunedReservationEvent notifies the channelEventNotifier goroutine that
+// the reservation of a pending channel was pruned.
+func (c *ChannelNotifier) NotifyPrunedReservationEvent(
+	event PrunedReservationEvent) {
+
+	if err := c.ntfnServer.SendUpdate(event); err != nil {
+		log.Warnf("Unable to send pruned reservation update: %v", err)
+	}
+}
+
+// NotifyFundingLockedStalledEvent notifies the channelEventNotifier goroutine
+// that the fundingLocked message of a channel repeatedly failed to be sent.
+func (c *ChannelNotifier) NotifyFundingLockedStalledEvent(
+	event FundingLockedStalledEvent) {
+
+	if err := c.ntfnServer.SendUpdate(event); err != nil {
+		log.Warnf("Unable to send funding locked stalled update: %v",
+			err)
+	}
+}
+
 // NotifyActiveLinkEvent notifies the channelEventNotifier goroutine that a
 // link has been added to the switch.
 func (c *ChannelNotifier) NotifyActiveLinkEvent(chanPoint wire.OutPoint) {
@@ -0,0 +1,182 @@
+package lnd
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cryptomeow/lnd/lnpeer"
+)
+
+const (
+	// maxOpenChanQueueTTL is the maximum time a channel open request can
+	// wait for its target peer to come online.
+	maxOpenChanQueueTTL = 24 * time.Hour
+)
+
+var (
+	// errQueuedOpenNotFound is returned when canceling a channel open
+	// request that isn't queued.
+	errQueuedOpenNotFound = errors.New("queued channel open not found")
+
+	// errQueuedOpenCanceled is returned to the caller of a queued channel
+	// open request that was canceled.
+	errQueuedOpenCanceled = errors.New("queued channel open canceled")
+)
+
+// queuedOpenChannel is a channel open request that waits for its target peer
+// to come online.
+type queuedOpenChannel struct {
+	// id uniquely identifies the request within the queue.
+	id uint64
+
+	// req is the channel open request that is started once the peer
+	// connects.
+	req *openChanReq
+
+	// queuedAt is the time at which the request was queued.
+	queuedAt time.Time
+
+	// expiry is the time at which the request fails if the peer hasn't
+	// connected by then.
+	expiry time.Time
+
+	// cancel is closed once the request is canceled.
+	cancel chan struct{}
+}
+
+// openChanQueue holds the channel open requests that wait for their target
+// peer to come online.
+type openChanQueue struct {
+	mu       sync.Mutex
+	nextID   uint64
+	requests map[uint64]*queuedOpenChannel
+}
+
+// newOpenChanQueue instantiates an empty queue of channel open requests.
+func newOpenChanQueue() *openChanQueue {
+	return &openChanQueue{
+		requests: make(map[uint64]*queuedOpenChannel),
+	}
+}
+
+// add queues the given request until the passed ttl expires.
+func (q *openChanQueue) add(req *openChanReq,
+	ttl time.Duration) *queuedOpenChannel {
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := time.Now()
+	queued := &queuedOpenChannel{
+		id:       q.nextID,
+		req:      req,
+		queuedAt: now,
+		expiry:   now.Add(ttl),
+		cancel:   make(chan struct{}),
+	}
+	q.requests[queued.id] = queued
+	q.nextID++
+
+	return queued
+}
+
+// remove removes the request with the given id from the queue.
+func (q *openChanQueue) remove(id uint64) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	delete(q.requests, id)
+}
+
+// cancel removes the request with the given id from the queue and signals its
+// cancellation.
+func (q *openChanQueue) cancel(id uint64) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	queued, ok := q.requests[id]
+	if !ok {
+		return errQueuedOpenNotFound
+	}
+
+	delete(q.requests, id)
+	close(queued.cancel)
+
+	return nil
+}
+
+// list returns all queued requests in the order they were queued.
+func (q *openChanQueue) list() []*queuedOpenChannel {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	queued := make([]*queuedOpenChannel, 0, len(q.requests))
+	for _, request := range q.requests {
+		queued = append(queued, request)
+	}
+	sort.Slice(queued, func(i, j int) bool {
+		return queued[i].id < queued[j].id
+	})
+
+	return queued
+}
+
+// queueOpenChannel waits for the target peer of the request to come online
+// and then starts the funding flow. If the peer doesn't connect before the
+// queue ttl of the request expires, or if the request is canceled, the
+// request fails.
+//
+// NOTE: This method MUST be run as a goroutine.
+func (s *server) queueOpenChannel(req *openChanReq) {
+	defer s.wg.Done()
+
+	var pubKey [33]byte
+	copy(pubKey[:], req.targetPubkey.SerializeCompressed())
+
+	queued := s.openChanQueue.add(req, req.queueTTL)
+	defer s.openChanQueue.remove(queued.id)
+
+	srvrLog.Infof("Queued channel open to offline peer %x for %v", pubKey,
+		req.queueTTL)
+
+	// The channel is buffered, so the notification doesn't block if we
+	// already gave up on the peer.
+	peerChan := make(chan lnpeer.Peer, 1)
+	s.NotifyWhenOnline(pubKey, peerChan)
+
+	timeout := time.NewTimer(req.queueTTL)
+	defer timeout.Stop()
+
+	select {
+	case <-peerChan:
+	case <-timeout.C:
+		req.err <- fmt.Errorf("peer %x did not come online within %v",
+			pubKey, req.queueTTL)
+		return
+	case <-queued.cancel:
+		req.err <- errQueuedOpenCanceled
+		return
+	case <-req.queueCancel:
+		req.err <- errQueuedOpenCanceled
+		return
+	case <-s.quit:
+		req.err <- ErrServerShuttingDown
+		return
+	}
+
+	srvrLog.Infof("Peer %x came online, starting queued channel open",
+		pubKey)
+
+	s.mu.RLock()
+	peer, ok := s.peersByPub[string(pubKey[:])]
+	s.mu.RUnlock()
+	if !ok {
+		req.err <- fmt.Errorf("peer %x is not online", pubKey)
+		return
+	}
+
+	s.openChannelWithPeer(peer, req)
+}
@@ -0,0 +1,62 @@
+package lnd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestOpenChanQueue asserts that queued channel open requests are listed in
+// the order they were queued until they are removed.
+func TestOpenChanQueue(t *testing.T) {
+	t.Parallel()
+
+	q := newOpenChanQueue()
+	require.Empty(t, q.list())
+
+	reqs := make([]*openChanReq, 3)
+	ids := make([]uint64, len(reqs))
+	for i := range reqs {
+		reqs[i] = &openChanReq{targetPubkey: alicePubKey}
+
+		queued := q.add(reqs[i], time.Minute)
+		require.Equal(
+			t, queued.queuedAt.Add(time.Minute), queued.expiry,
+		)
+		ids[i] = queued.id
+	}
+
+	queued := q.list()
+	require.Len(t, queued, len(reqs))
+	for i, request := range queued {
+		require.Equal(t, reqs[i], request.req)
+	}
+
+	q.remove(ids[1])
+	queued = q.list()
+	require.Len(t, queued, 2)
+	require.Equal(t, reqs[0], queued[0].req)
+	require.Equal(t, reqs[2], queued[1].req)
+}
+
+// TestOpenChanQueueCancel asserts that canceling a queued channel open
+// request removes it from the queue and signals its cancellation.
+func TestOpenChanQueueCancel(t *testing.T) {
+	t.Parallel()
+
+	q := newOpenChanQueue()
+	queued := q.add(&openChanReq{targetPubkey: alicePubKey}, time.Minute)
+
+	require.NoError(t, q.cancel(queued.id))
+	require.Empty(t, q.list())
+
+	select {
+	case <-queued.cancel:
+	default:
+		t.Fatalf("queued request not canceled")
+	}
+
+	// A request can only be canceled once.
+	require.Equal(t, errQueuedOpenNotFound, q.cancel(queued.id))
+}
@@ -177,7 +177,7 @@ func (c *chanDBRestorer) RestoreChansFromSingles(backups ...chanbackup.Single) e
 		default:
 			// Worst case: We have no height hint and start at
 			// block 1. Should only happen for SCBs in regtest,
-			// simnet and litecoin.
+			// simnet, signet and litecoin.
 			firstChanHeight = 1
 		}
 	}
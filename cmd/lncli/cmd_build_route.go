@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/cryptomeow/lnd/chainreg"
@@ -39,9 +40,55 @@ var buildRouteCommand = cli.Command{
 				"use for the first hop of the payment",
 			Value: 0,
 		},
+		cli.StringFlag{
+			Name: "chan_ids",
+			Usage: "comma separated short channel ids that must " +
+				"be taken to reach each hop, 0 to select any " +
+				"channel",
+		},
+		cli.StringFlag{
+			Name: "cltv_deltas",
+			Usage: "comma separated time lock deltas each hop " +
+				"applies when forwarding, 0 to use the " +
+				"channel policy",
+		},
+		cli.StringSliceFlag{
+			Name: "avoid_node",
+			Usage: "hex pubkey of a node the route must not " +
+				"pass through, can be specified multiple times",
+		},
+		cli.StringSliceFlag{
+			Name: "avoid_chan_id",
+			Usage: "short channel id of a channel that must not " +
+				"be selected, can be specified multiple times",
+		},
 	},
 }
 
+// parseHopUints parses a comma separated list that holds a value for every
+// hop.
+func parseHopUints(ctx *cli.Context, name string, numHops,
+	bitSize int) ([]uint64, error) {
+
+	values := strings.Split(ctx.String(name), ",")
+	if len(values) != numHops {
+		return nil, fmt.Errorf("expected %v values for %v, got %v",
+			numHops, name, len(values))
+	}
+
+	result := make([]uint64, len(values))
+	for i, value := range values {
+		var err error
+		result[i], err = strconv.ParseUint(value, 10, bitSize)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing %v: %v", name,
+				err)
+		}
+	}
+
+	return result, nil
+}
+
 func buildRoute(ctx *cli.Context) error {
 	conn := getClientConn(ctx, false)
 	defer conn.Close()
@@ -80,6 +127,54 @@ func buildRoute(ctx *cli.Context) error {
 		OutgoingChanId: ctx.Uint64("outgoing_chan_id"),
 	}
 
+	// Add the per hop constraints if any of them is set.
+	if ctx.IsSet("chan_ids") || ctx.IsSet("cltv_deltas") {
+		req.HopConstraints = make(
+			[]*routerrpc.HopConstraints, len(rpcHops),
+		)
+		for i := range req.HopConstraints {
+			req.HopConstraints[i] = &routerrpc.HopConstraints{}
+		}
+	}
+
+	if ctx.IsSet("chan_ids") {
+		chanIDs, err := parseHopUints(ctx, "chan_ids", len(rpcHops), 64)
+		if err != nil {
+			return err
+		}
+		for i, chanID := range chanIDs {
+			req.HopConstraints[i].ChanId = chanID
+		}
+	}
+
+	if ctx.IsSet("cltv_deltas") {
+		deltas, err := parseHopUints(
+			ctx, "cltv_deltas", len(rpcHops), 16,
+		)
+		if err != nil {
+			return err
+		}
+		for i, delta := range deltas {
+			req.HopConstraints[i].CltvDelta = uint32(delta)
+		}
+	}
+
+	for _, k := range ctx.StringSlice("avoid_node") {
+		pubkey, err := route.NewVertexFromStr(k)
+		if err != nil {
+			return fmt.Errorf("error parsing %v: %v", k, err)
+		}
+		req.AvoidNodes = append(req.AvoidNodes, pubkey[:])
+	}
+
+	for _, c := range ctx.StringSlice("avoid_chan_id") {
+		chanID, err := strconv.ParseUint(c, 10, 64)
+		if err != nil {
+			return fmt.Errorf("error parsing %v: %v", c, err)
+		}
+		req.AvoidChanIds = append(req.AvoidChanIds, chanID)
+	}
+
 	rpcCtx := context.Background()
 	route, err := client.BuildRoute(rpcCtx, req)
 	if err != nil {
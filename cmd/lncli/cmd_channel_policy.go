@@ -0,0 +1,274 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cryptomeow/lnd/lnrpc"
+	"github.com/urfave/cli"
+)
+
+var policyTemplateCommand = cli.Command{
+	Name:     "policytemplate",
+	Category: "Channels",
+	Usage:    "Manage named channel policy templates.",
+	Description: `
+	Policy templates are named channel policies that can be applied to all
+	channels, all channels with a peer, or a single channel at once with
+	'lncli updatechanpolicy --template=<name>'. Changing a template doesn't
+	update the channels it was applied to before.
+	`,
+	Subcommands: []cli.Command{
+		policyTemplateSetCommand,
+		policyTemplateListCommand,
+		policyTemplateDeleteCommand,
+	},
+}
+
+var policyTemplateSetCommand = cli.Command{
+	Name:      "set",
+	Usage:     "Store a policy template.",
+	ArgsUsage: "name base_fee_msat fee_rate time_lock_delta",
+	Description: `
+	Store a policy template under the given name. An existing template with
+	the same name is replaced.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "name",
+			Usage: "the name of the template",
+		},
+		cli.Int64Flag{
+			Name: "base_fee_msat",
+			Usage: "the base fee in milli-satoshis that will " +
+				"be charged for each forwarded HTLC, regardless " +
+				"of payment size",
+		},
+		cli.StringFlag{
+			Name: "fee_rate",
+			Usage: "the fee rate that will be charged " +
+				"proportionally based on the value of each " +
+				"forwarded HTLC, the lowest possible rate is 0 " +
+				"with a granularity of 0.000001 (millionths)",
+		},
+		cli.Int64Flag{
+			Name: "time_lock_delta",
+			Usage: "the CLTV delta that will be applied to all " +
+				"forwarded HTLCs",
+		},
+		cli.Uint64Flag{
+			Name: "min_htlc_msat",
+			Usage: "if set, the min HTLC size that will be applied " +
+				"to all forwarded HTLCs. If unset, the min HTLC " +
+				"is left unchanged.",
+		},
+		cli.Uint64Flag{
+			Name: "max_htlc_msat",
+			Usage: "if set, the max HTLC size that will be applied " +
+				"to all forwarded HTLCs. If unset, the max HTLC " +
+				"is left unchanged.",
+		},
+	},
+	Action: actionDecorator(policyTemplateSet),
+}
+
+func policyTemplateSet(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	args := ctx.Args()
+
+	var name string
+	switch {
+	case ctx.IsSet("name"):
+		name = ctx.String("name")
+	case args.Present():
+		name = args.First()
+		args = args.Tail()
+	default:
+		return fmt.Errorf("name argument missing")
+	}
+
+	// The policy is parsed the same way as for a policy update.
+	policy := &lnrpc.PolicyUpdateRequest{}
+	if _, err := parsePolicyArgs(ctx, args, policy); err != nil {
+		return err
+	}
+
+	req := &lnrpc.SetPolicyTemplateRequest{
+		Template: &lnrpc.PolicyTemplate{
+			Name:                 name,
+			BaseFeeMsat:          policy.BaseFeeMsat,
+			FeeRate:              policy.FeeRate,
+			TimeLockDelta:        policy.TimeLockDelta,
+			MaxHtlcMsat:          policy.MaxHtlcMsat,
+			MinHtlcMsat:          policy.MinHtlcMsat,
+			MinHtlcMsatSpecified: policy.MinHtlcMsatSpecified,
+		},
+	}
+
+	resp, err := client.SetPolicyTemplate(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var policyTemplateListCommand = cli.Command{
+	Name:   "list",
+	Usage:  "List all policy templates.",
+	Action: actionDecorator(policyTemplateList),
+}
+
+func policyTemplateList(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.ListPolicyTemplates(
+		ctxb, &lnrpc.ListPolicyTemplatesRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var policyTemplateDeleteCommand = cli.Command{
+	Name:      "delete",
+	Usage:     "Delete a policy template.",
+	ArgsUsage: "name",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "name",
+			Usage: "the name of the template to delete",
+		},
+	},
+	Action: actionDecorator(policyTemplateDelete),
+}
+
+func policyTemplateDelete(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var name string
+	switch {
+	case ctx.IsSet("name"):
+		name = ctx.String("name")
+	case ctx.Args().Present():
+		name = ctx.Args().First()
+	default:
+		return fmt.Errorf("name argument missing")
+	}
+
+	resp, err := client.DeletePolicyTemplate(
+		ctxb, &lnrpc.DeletePolicyTemplateRequest{
+			Name: name,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var policyHistoryCommand = cli.Command{
+	Name:     "policyhistory",
+	Category: "Channels",
+	Usage:    "Query the history of channel policy updates.",
+	Description: `
+	Query the forwarding policies that were applied to our channels over a
+	particular time range (--start_time and --end_time), including the
+	template each policy was taken from and the reason for the update. The
+	start and end times are meant to be expressed in seconds since the Unix
+	epoch, or as negative time ranges, e.g. "-3d". If --start_time isn't
+	provided, then the whole history is queried. If --end_time isn't
+	provided, then the current time is used.
+
+	The history of a single channel can be queried with the --chan_point
+	parameter.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "start_time",
+			Usage: "the starting time for the query " +
+				`as unix timestamp or relative e.g. "-1w"`,
+		},
+		cli.StringFlag{
+			Name: "end_time",
+			Usage: "the end time for the query " +
+				`as unix timestamp or relative e.g. "-1w"`,
+		},
+		cli.Int64Flag{
+			Name:  "index_offset",
+			Usage: "the number of changes to skip",
+		},
+		cli.Int64Flag{
+			Name:  "max_changes",
+			Usage: "the max number of changes to return",
+		},
+		cli.StringFlag{
+			Name: "chan_point",
+			Usage: "only return the changes of the channel with " +
+				"this channel point",
+		},
+	},
+	Action: actionDecorator(policyHistory),
+}
+
+func policyHistory(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var (
+		startTime, endTime uint64
+		err                error
+	)
+	now := time.Now()
+
+	if ctx.IsSet("start_time") {
+		startTime, err = parseTime(ctx.String("start_time"), now)
+		if err != nil {
+			return fmt.Errorf("unable to decode start_time: %v", err)
+		}
+	}
+
+	endTime = uint64(now.Unix())
+	if ctx.IsSet("end_time") {
+		endTime, err = parseTime(ctx.String("end_time"), now)
+		if err != nil {
+			return fmt.Errorf("unable to decode end_time: %v", err)
+		}
+	}
+
+	req := &lnrpc.ChannelPolicyHistoryRequest{
+		StartTime:     startTime,
+		EndTime:       endTime,
+		IndexOffset:   uint32(ctx.Int64("index_offset")),
+		NumMaxChanges: uint32(ctx.Int64("max_changes")),
+	}
+	if ctx.IsSet("chan_point") {
+		req.ChanPoint, err = parseChanPoint(ctx.String("chan_point"))
+		if err != nil {
+			return fmt.Errorf("unable to parse chan_point: %v", err)
+		}
+	}
+
+	resp, err := client.ChannelPolicyHistory(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+
+	"github.com/cryptomeow/lnd/lnrpc"
+	"github.com/urfave/cli"
+)
+
+var sendCustomCommand = cli.Command{
+	Name:     "sendcustom",
+	Category: "Peers",
+	Usage:    "Send a custom message to a connected peer.",
+	Description: `
+	Send a custom message of an experimental protocol extension to a
+	connected peer. The message type must be odd and in the custom range
+	(32768 and above).`,
+	ArgsUsage: "--peer=<pubkey> --type=<type> --data=<hex>",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "peer",
+			Usage: "the hex-encoded compressed public key of the " +
+				"peer to send the message to",
+		},
+		cli.Uint64Flag{
+			Name:  "type",
+			Usage: "the message type",
+		},
+		cli.StringFlag{
+			Name:  "data",
+			Usage: "the hex-encoded message payload",
+		},
+	},
+	Action: actionDecorator(sendCustom),
+}
+
+func sendCustom(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	peer, err := hex.DecodeString(ctx.String("peer"))
+	if err != nil {
+		return fmt.Errorf("unable to decode peer pubkey: %v", err)
+	}
+
+	data, err := hex.DecodeString(ctx.String("data"))
+	if err != nil {
+		return fmt.Errorf("unable to decode message data: %v", err)
+	}
+
+	resp, err := client.SendCustomMessage(
+		ctxb, &lnrpc.SendCustomMessageRequest{
+			Peer: peer,
+			Type: uint32(ctx.Uint64("type")),
+			Data: data,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var subscribeCustomCommand = cli.Command{
+	Name:     "subscribecustom",
+	Category: "Peers",
+	Usage: "Subscribe to the custom messages received from connected " +
+		"peers.",
+	Action: actionDecorator(subscribeCustom),
+}
+
+func subscribeCustom(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	stream, err := client.SubscribeCustomMessages(
+		ctxb, &lnrpc.SubscribeCustomMessagesRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		fmt.Printf("Received custom message from peer %x of type %d: "+
+			"%x\n", msg.Peer, msg.Type, msg.Data)
+	}
+}
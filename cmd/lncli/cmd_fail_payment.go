@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/cryptomeow/lnd/lnrpc/routerrpc"
+
+	"github.com/urfave/cli"
+)
+
+var failPaymentCommand = cli.Command{
+	Name:     "failpayment",
+	Category: "Payments",
+	Usage:    "Mark an in-flight payment as failed.",
+	Description: `
+	Mark an in-flight payment as failed, so that no further attempts can
+	be sent for it. This is meant to be used after sending shards with
+	sendtoroute --skip_temp_err once no more attempts will be made.
+	Attempts that are still in flight resolve normally.
+	`,
+	ArgsUsage: "payment_hash",
+	Action:    actionDecorator(failPayment),
+}
+
+func failPayment(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return cli.ShowCommandHelp(ctx, "failpayment")
+	}
+
+	hash, err := hex.DecodeString(ctx.Args().First())
+	if err != nil {
+		return fmt.Errorf("unable to decode payment hash: %v", err)
+	}
+
+	conn := getClientConn(ctx, false)
+	defer conn.Close()
+
+	client := routerrpc.NewRouterClient(conn)
+
+	req := &routerrpc.FailPaymentRequest{
+		PaymentHash: hash,
+	}
+	_, err = client.FailPayment(context.Background(), req)
+	return err
+}
@@ -63,6 +63,19 @@ var addInvoiceCommand = cli.Command{
 				"private channels in order to assist the " +
 				"payer in reaching you",
 		},
+		cli.Uint64Flag{
+			Name: "mpp_timeout",
+			Usage: "the time in seconds after which the htlcs of " +
+				"an incomplete multi-path payment are " +
+				"canceled back. If not specified, a timeout " +
+				"of 120 seconds is implied.",
+		},
+		cli.BoolFlag{
+			Name: "require_approval",
+			Usage: "hold the received funds until they are " +
+				"approved or rejected using approveinvoice " +
+				"or rejectinvoice",
+		},
 	},
 	Action: actionDecorator(addInvoice),
 }
@@ -115,6 +128,8 @@ func addInvoice(ctx *cli.Context) error {
 		FallbackAddr:    ctx.String("fallback_addr"),
 		Expiry:          ctx.Int64("expiry"),
 		Private:         ctx.Bool("private"),
+		MppTimeout:      ctx.Uint64("mpp_timeout"),
+		RequireApproval: ctx.Bool("require_approval"),
 	}
 
 	resp, err := client.AddInvoice(context.Background(), invoice)
@@ -178,6 +193,73 @@ func lookupInvoice(ctx *cli.Context) error {
 	return nil
 }
 
+var regenerateInvoiceCommand = cli.Command{
+	Name:     "regenerateinvoice",
+	Category: "Invoices",
+	Usage: "Re-issue an open invoice with fresh route hints and a new " +
+		"expiry.",
+	Description: `
+	Re-issue an open invoice with a new payment request. The payment hash,
+	amount and the other terms of the invoice are preserved, while the
+	route hints are selected anew and the expiry starts over. This is
+	useful if channels of the route hints of the invoice were closed since
+	it was created. The previous payment requests remain payable until the
+	invoice expires.`,
+	ArgsUsage: "rhash",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "rhash",
+			Usage: "the 32 byte payment hash of the invoice to " +
+				"regenerate, the hash should be a hex-encoded " +
+				"string",
+		},
+		cli.Int64Flag{
+			Name: "expiry",
+			Usage: "the invoice's expiry time in seconds. If not " +
+				"specified, the expiry of the current payment " +
+				"request is reused",
+		},
+	},
+	Action: actionDecorator(regenerateInvoice),
+}
+
+func regenerateInvoice(ctx *cli.Context) error {
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var (
+		rHash []byte
+		err   error
+	)
+
+	switch {
+	case ctx.IsSet("rhash"):
+		rHash, err = hex.DecodeString(ctx.String("rhash"))
+	case ctx.Args().Present():
+		rHash, err = hex.DecodeString(ctx.Args().First())
+	default:
+		return fmt.Errorf("rhash argument missing")
+	}
+
+	if err != nil {
+		return fmt.Errorf("unable to decode rhash argument: %v", err)
+	}
+
+	req := &lnrpc.RegenerateInvoiceRequest{
+		RHash:  rHash,
+		Expiry: ctx.Int64("expiry"),
+	}
+
+	resp, err := client.RegenerateInvoice(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
 var listInvoicesCommand = cli.Command{
 	Name:     "listinvoices",
 	Category: "Invoices",
@@ -286,3 +368,84 @@ func decodePayReq(ctx *cli.Context) error {
 	printRespJSON(resp)
 	return nil
 }
+
+var resolveLnurlCommand = cli.Command{
+	Name:     "resolvelnurl",
+	Category: "Invoices",
+	Usage:    "Resolve an lnurl-pay target to a payment request.",
+	Description: `
+	Resolve a bech32 encoded lnurl-pay or a lightning address of the form
+	user@domain to a payment request of the given amount, which can then be
+	paid with payinvoice.
+
+	If no amount is set, only the range of amounts accepted by the target
+	is returned.`,
+	ArgsUsage: "target [amt]",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "target",
+			Usage: "the lnurl or lightning address to resolve",
+		},
+		cli.Int64Flag{
+			Name:  "amt",
+			Usage: "the amount to pay in satoshis",
+		},
+		cli.Int64Flag{
+			Name:  "amt_msat",
+			Usage: "the amount to pay in millisatoshis",
+		},
+		cli.StringFlag{
+			Name: "comment",
+			Usage: "an optional comment to send along with the " +
+				"payment, if the target allows it",
+		},
+	},
+	Action: actionDecorator(resolveLnurl),
+}
+
+func resolveLnurl(ctx *cli.Context) error {
+	ctxb := context.Background()
+	args := ctx.Args()
+
+	var target string
+	switch {
+	case ctx.IsSet("target"):
+		target = ctx.String("target")
+	case args.Present():
+		target = args.First()
+		args = args.Tail()
+	default:
+		return fmt.Errorf("target argument missing")
+	}
+
+	var amtMsat int64
+	switch {
+	case ctx.IsSet("amt") && ctx.IsSet("amt_msat"):
+		return fmt.Errorf("only one of amt and amt_msat can be set")
+	case ctx.IsSet("amt"):
+		amtMsat = ctx.Int64("amt") * 1000
+	case ctx.IsSet("amt_msat"):
+		amtMsat = ctx.Int64("amt_msat")
+	case args.Present():
+		amt, err := strconv.ParseInt(args.First(), 10, 64)
+		if err != nil {
+			return fmt.Errorf("unable to decode amt: %v", err)
+		}
+		amtMsat = amt * 1000
+	}
+
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.ResolveLnurlPay(ctxb, &lnrpc.ResolveLnurlPayRequest{
+		Target:  target,
+		AmtMsat: amtMsat,
+		Comment: ctx.String("comment"),
+	})
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
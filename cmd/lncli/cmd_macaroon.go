@@ -48,6 +48,10 @@ var bakeMacaroonCommand = cli.Command{
 	The macaroon created by this command would only be allowed to use the
 	"lncli getinfo" and "lncli version" commands.
 
+	With the --observer flag, the new macaroon is an observer macaroon.
+	Observer macaroons can only be used to call RPCs that don't modify the
+	state of the daemon, regardless of the permissions they grant.
+
 	To get a list of all available URIs and permissions, use the
 	"lncli listpermissions" command.
 	`,
@@ -70,6 +74,11 @@ var bakeMacaroonCommand = cli.Command{
 			Name:  "root_key_id",
 			Usage: "the numerical root key ID used to create the macaroon",
 		},
+		cli.BoolFlag{
+			Name: "observer",
+			Usage: "if set, the macaroon can only be used to call " +
+				"RPCs that don't modify the state of the daemon",
+		},
 	},
 	Action: actionDecorator(bakeMacaroon),
 }
@@ -150,6 +159,7 @@ func bakeMacaroon(ctx *cli.Context) error {
 	req := &lnrpc.BakeMacaroonRequest{
 		Permissions: parsedPermissions,
 		RootKeyId:   rootKeyID,
+		Observer:    ctx.Bool("observer"),
 	}
 	resp, err := client.BakeMacaroon(context.Background(), req)
 	if err != nil {
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cryptomeow/lnd/lnrpc/routerrpc"
+	"github.com/cryptomeow/lnd/routing/route"
+	"github.com/urfave/cli"
+)
+
+var setMissionControlOverrideCommand = cli.Command{
+	Name:      "setmcoverride",
+	Category:  "Payments",
+	Usage:     "Mark a node or node pair as failed or succeeded.",
+	ArgsUsage: "node [to-node]",
+	Description: `
+	Manually mark a node, or the pair from node to to-node, as failed in
+	mission control, so that path finding avoids it immediately. With
+	--success, the node or pair is marked as succeeded instead. The effect
+	of the override decays with the given half-life.
+
+	Overrides are kept in memory only, and can be removed with
+	clearmcoverride.`,
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "success",
+			Usage: "mark the node or pair as succeeded",
+		},
+		cli.DurationFlag{
+			Name: "half_life",
+			Usage: "the time after which the override has lost " +
+				"half of its effect, e.g. 24h. If not set, " +
+				"the penalty half-life of mission control " +
+				"is used",
+		},
+	},
+	Action: actionDecorator(setMissionControlOverride),
+}
+
+func setMissionControlOverride(ctx *cli.Context) error {
+	args := ctx.Args()
+	if len(args) != 1 && len(args) != 2 {
+		return cli.ShowCommandHelp(ctx, "setmcoverride")
+	}
+
+	node, toNode, err := parseOverrideNodes(args)
+	if err != nil {
+		return err
+	}
+
+	conn := getClientConn(ctx, false)
+	defer conn.Close()
+
+	client := routerrpc.NewRouterClient(conn)
+
+	halfLife := ctx.Duration("half_life")
+	req := &routerrpc.SetMissionControlOverridesRequest{
+		Overrides: []*routerrpc.MissionControlOverride{{
+			Node:        node,
+			ToNode:      toNode,
+			Success:     ctx.Bool("success"),
+			HalfLifeSec: uint64(halfLife.Seconds()),
+		}},
+	}
+	rpcCtx := context.Background()
+	_, err = client.SetMissionControlOverrides(rpcCtx, req)
+	return err
+}
+
+var clearMissionControlOverrideCommand = cli.Command{
+	Name:      "clearmcoverride",
+	Category:  "Payments",
+	Usage:     "Remove mission control overrides.",
+	ArgsUsage: "[node [to-node]]",
+	Description: `
+	Remove the mission control override of a node, or of the pair from node
+	to to-node. If no node is given, all overrides are removed.`,
+	Action: actionDecorator(clearMissionControlOverride),
+}
+
+func clearMissionControlOverride(ctx *cli.Context) error {
+	args := ctx.Args()
+	if len(args) > 2 {
+		return cli.ShowCommandHelp(ctx, "clearmcoverride")
+	}
+
+	req := &routerrpc.ClearMissionControlOverridesRequest{}
+	if len(args) > 0 {
+		node, toNode, err := parseOverrideNodes(args)
+		if err != nil {
+			return err
+		}
+
+		req.Node = node
+		req.ToNode = toNode
+	}
+
+	conn := getClientConn(ctx, false)
+	defer conn.Close()
+
+	client := routerrpc.NewRouterClient(conn)
+
+	rpcCtx := context.Background()
+	_, err := client.ClearMissionControlOverrides(rpcCtx, req)
+	return err
+}
+
+// parseOverrideNodes parses the node and the optional destination node of a
+// mission control override.
+func parseOverrideNodes(args cli.Args) ([]byte, []byte, error) {
+	node, err := route.NewVertexFromStr(args.Get(0))
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid node key: %v", err)
+	}
+
+	if len(args) < 2 {
+		return node[:], nil, nil
+	}
+
+	toNode, err := route.NewVertexFromStr(args.Get(1))
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid to node key: %v", err)
+	}
+
+	return node[:], toNode[:], nil
+}
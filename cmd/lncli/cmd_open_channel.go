@@ -49,6 +49,14 @@ again here either in base64 PSBT or hex encoded raw wire TX format.
 Signed base64 encoded PSBT or hex encoded raw wire TX: `
 )
 
+// fundingAccountFlag is the flag that selects the wallet account whose coins
+// are used to fund a channel.
+var fundingAccountFlag = cli.StringFlag{
+	Name: "funding_account",
+	Usage: "(optional) the name of the wallet account to fund the " +
+		"channel from, the default account is used if not set",
+}
+
 // TODO(roasbeef): change default number of confirmations
 var openChannelCommand = cli.Command{
 	Name:     "openchannel",
@@ -62,6 +70,11 @@ var openChannelCommand = cli.Command{
 	setting its host:port via the --connect argument. For this to work,
 	the node_key must be provided, rather than the peer_id. This is optional.
 
+	If the peer is offline, the request can be queued until the peer comes
+	online by setting the --queue_ttl argument, instead of failing
+	immediately. The request is canceled if this command is interrupted.
+	Queued requests can be listed with listqueuedopens.
+
 	The channel will be initialized with local-amt satoshis local and push-amt
 	satoshis for the remote node. Note that specifying push-amt means you give that
 	amount to the remote node as part of the channel opening. Once the channel is open,
@@ -185,6 +198,29 @@ var openChannelCommand = cli.Command{
 			Usage: "(optional) the maximum value in msat that " +
 				"can be pending within the channel at any given time",
 		},
+		cli.StringFlag{
+			Name: "coin_selection_strategy",
+			Usage: "(optional) the strategy used to select the " +
+				"wallet's coins for the funding transaction, " +
+				"one of {largest, random, oldest}. If not set, " +
+				"the node's default strategy is used",
+		},
+		fundingAccountFlag,
+		cli.StringSliceFlag{
+			Name: "utxo",
+			Usage: "(optional) a wallet output of the form " +
+				"txid:index that must be used to fund the " +
+				"channel, can be set multiple times. If set, " +
+				"all of the given outputs are spent and no " +
+				"other outputs of the wallet are used",
+		},
+		cli.Uint64Flag{
+			Name: "queue_ttl",
+			Usage: "(optional) the maximum number of seconds to " +
+				"wait for the peer to come online if it is " +
+				"offline, instead of failing immediately, at " +
+				"most one day",
+		},
 	},
 	Action: actionDecorator(openChannel),
 }
@@ -215,6 +251,27 @@ func openChannel(ctx *cli.Context) error {
 		CloseAddress:               ctx.String("close_address"),
 		RemoteMaxValueInFlightMsat: ctx.Uint64("remote_max_value_in_flight_msat"),
 		MaxLocalCsv:                uint32(ctx.Uint64("max_local_csv")),
+		FundingAccount:             ctx.String(fundingAccountFlag.Name),
+		QueueTtl:                   uint32(ctx.Uint64("queue_ttl")),
+	}
+
+	if ctx.IsSet("coin_selection_strategy") {
+		strategy, err := parseCoinSelectionStrategy(
+			ctx.String("coin_selection_strategy"),
+		)
+		if err != nil {
+			return err
+		}
+		req.CoinSelectionStrategy = strategy
+	}
+
+	for _, utxo := range ctx.StringSlice("utxo") {
+		outpoint, err := NewProtoOutPoint(utxo)
+		if err != nil {
+			return fmt.Errorf("unable to parse utxo %v: %v", utxo,
+				err)
+		}
+		req.Outpoints = append(req.Outpoints, outpoint)
 	}
 
 	switch {
@@ -676,6 +733,27 @@ func checkPsbtFlags(req *lnrpc.OpenChannelRequest) error {
 	return nil
 }
 
+// parseCoinSelectionStrategy parses the human readable name of a coin
+// selection strategy into its RPC representation.
+func parseCoinSelectionStrategy(
+	strategy string) (lnrpc.CoinSelectionStrategy, error) {
+
+	switch strategy {
+	case "largest":
+		return lnrpc.CoinSelectionStrategy_STRATEGY_LARGEST, nil
+
+	case "random":
+		return lnrpc.CoinSelectionStrategy_STRATEGY_RANDOM, nil
+
+	case "oldest":
+		return lnrpc.CoinSelectionStrategy_STRATEGY_OLDEST, nil
+
+	default:
+		return 0, fmt.Errorf("unknown coin selection strategy: %v, "+
+			"must be one of {largest, random, oldest}", strategy)
+	}
+}
+
 // sendFundingState sends a single funding state step message by using a new
 // client connection. This is necessary if the whole funding flow takes longer
 // than the default macaroon timeout, then we cannot use a single client
@@ -727,3 +805,236 @@ func finalizeMsgFromString(tx string,
 		},
 	}, nil
 }
+
+var estimateOpenChannelCommand = cli.Command{
+	Name:      "estimateopenchannel",
+	Category:  "Channels",
+	Usage:     "Estimate the on-chain cost of opening a channel.",
+	ArgsUsage: "local-amt",
+	Description: `
+	Estimate the fee and change of the funding transaction of a channel
+	funded with local-amt satoshis from the wallet, for confirmation targets
+	of 1, 3 and 6 blocks. The wallet's coins are selected the same way as
+	for openchannel, but no coins are locked and no channel is opened. For
+	each target, it's also reported whether the wallet can afford the
+	channel.`,
+	Flags: []cli.Flag{
+		cli.IntFlag{
+			Name:  "local_amt",
+			Usage: "the number of satoshis the wallet should commit to the channel",
+		},
+		cli.Uint64Flag{
+			Name: "min_confs",
+			Usage: "(optional) the minimum number of confirmations " +
+				"each one of your outputs used for the funding " +
+				"transaction must satisfy",
+			Value: defaultUtxoMinConf,
+		},
+		cli.StringFlag{
+			Name: "coin_selection_strategy",
+			Usage: "(optional) the strategy used to select the " +
+				"wallet's coins for the funding transaction, " +
+				"one of {largest, random, oldest}. If not set, " +
+				"the node's default strategy is used",
+		},
+		fundingAccountFlag,
+	},
+	Action: actionDecorator(estimateOpenChannel),
+}
+
+func estimateOpenChannel(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var (
+		localAmt int64
+		err      error
+	)
+	switch {
+	case ctx.IsSet("local_amt"):
+		localAmt = int64(ctx.Int("local_amt"))
+
+	case ctx.Args().Present():
+		localAmt, err = strconv.ParseInt(ctx.Args().First(), 10, 64)
+		if err != nil {
+			return fmt.Errorf("unable to decode local amt: %v", err)
+		}
+
+	default:
+		return fmt.Errorf("local amt argument missing")
+	}
+
+	minConfs := int32(ctx.Uint64("min_confs"))
+	req := &lnrpc.EstimateOpenChannelRequest{
+		LocalFundingAmount: localAmt,
+		MinConfs:           minConfs,
+		SpendUnconfirmed:   minConfs == 0,
+		FundingAccount:     ctx.String(fundingAccountFlag.Name),
+	}
+
+	if ctx.IsSet("coin_selection_strategy") {
+		strategy, err := parseCoinSelectionStrategy(
+			ctx.String("coin_selection_strategy"),
+		)
+		if err != nil {
+			return err
+		}
+		req.CoinSelectionStrategy = strategy
+	}
+
+	resp, err := client.EstimateOpenChannel(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var describeChannelConstraintsCommand = cli.Command{
+	Name:      "describechannelconstraints",
+	Category:  "Channels",
+	Usage:     "Show the constraints proposed when opening a channel.",
+	ArgsUsage: "local-amt",
+	Description: `
+	Show the constraints that would be proposed to the peer when opening a
+	channel funded with local-amt satoshis: the channel reserve, CSV delay,
+	maximum number of htlcs, maximum value in flight and dust limit. If the
+	node_key of a connected peer is given, the commitment type of the
+	channel is determined from the features it signaled.`,
+	Flags: []cli.Flag{
+		cli.IntFlag{
+			Name:  "local_amt",
+			Usage: "the number of satoshis the wallet would commit to the channel",
+		},
+		cli.StringFlag{
+			Name: "node_key",
+			Usage: "(optional) the identity public key of a " +
+				"connected peer in hex format",
+		},
+	},
+	Action: actionDecorator(describeChannelConstraints),
+}
+
+func describeChannelConstraints(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var (
+		localAmt int64
+		err      error
+	)
+	switch {
+	case ctx.IsSet("local_amt"):
+		localAmt = int64(ctx.Int("local_amt"))
+
+	case ctx.Args().Present():
+		localAmt, err = strconv.ParseInt(ctx.Args().First(), 10, 64)
+		if err != nil {
+			return fmt.Errorf("unable to decode local amt: %v", err)
+		}
+
+	default:
+		return fmt.Errorf("local amt argument missing")
+	}
+
+	req := &lnrpc.DescribeChannelConstraintsRequest{
+		LocalFundingAmount: localAmt,
+	}
+
+	if ctx.IsSet("node_key") {
+		req.NodePubkey, err = hex.DecodeString(ctx.String("node_key"))
+		if err != nil {
+			return fmt.Errorf("unable to decode node public key: "+
+				"%v", err)
+		}
+	}
+
+	resp, err := client.DescribeChannelConstraints(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var listQueuedOpensCommand = cli.Command{
+	Name:     "listqueuedopens",
+	Category: "Channels",
+	Usage:    "List the channel opens waiting for their peer to connect.",
+	Description: `
+	List the channel open requests that were made with --queue_ttl while
+	the target peer was offline, and that are waiting for the peer to come
+	online.`,
+	Action: actionDecorator(listQueuedOpens),
+}
+
+func listQueuedOpens(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	req := &lnrpc.ListQueuedChannelOpensRequest{}
+	resp, err := client.ListQueuedChannelOpens(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var cancelQueuedOpenCommand = cli.Command{
+	Name:      "cancelqueuedopen",
+	Category:  "Channels",
+	Usage:     "Cancel a channel open waiting for its peer to connect.",
+	ArgsUsage: "id",
+	Description: `
+	Cancel the queued channel open request with the given id, as shown by
+	listqueuedopens. The request fails and no channel is opened.`,
+	Flags: []cli.Flag{
+		cli.Uint64Flag{
+			Name:  "id",
+			Usage: "the id of the queued channel open request",
+		},
+	},
+	Action: actionDecorator(cancelQueuedOpen),
+}
+
+func cancelQueuedOpen(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var (
+		id  uint64
+		err error
+	)
+	switch {
+	case ctx.IsSet("id"):
+		id = ctx.Uint64("id")
+
+	case ctx.Args().Present():
+		id, err = strconv.ParseUint(ctx.Args().First(), 10, 64)
+		if err != nil {
+			return fmt.Errorf("unable to decode id: %v", err)
+		}
+
+	default:
+		return fmt.Errorf("id argument missing")
+	}
+
+	req := &lnrpc.CancelQueuedChannelOpenRequest{
+		Id: id,
+	}
+	resp, err := client.CancelQueuedChannelOpen(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
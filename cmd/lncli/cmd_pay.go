@@ -65,6 +65,13 @@ var (
 		Value: 1,
 	}
 
+	idempotencyKeyFlag = cli.StringFlag{
+		Name: "idempotency_key",
+		Usage: "an optional key that identifies the payment request. " +
+			"Retrying a payment with the same key does not pay " +
+			"twice, but shows the updates of the existing payment",
+	}
+
 	jsonFlag = cli.BoolFlag{
 		Name: "json",
 		Usage: "if set, payment updates are printed as json " +
@@ -115,6 +122,7 @@ func paymentFlags() []cli.Flag {
 			Usage: "allow sending a circular payment to self",
 		},
 		dataFlag, inflightUpdatesFlag, maxPartsFlag, jsonFlag,
+		idempotencyKeyFlag,
 	}
 }
 
@@ -354,6 +362,10 @@ func sendPaymentRequest(ctx *cli.Context,
 
 	req.MaxParts = uint32(ctx.Uint(maxPartsFlag.Name))
 
+	if key := ctx.String(idempotencyKeyFlag.Name); key != "" {
+		req.IdempotencyKey = []byte(key)
+	}
+
 	// Parse custom data records.
 	data := ctx.String(dataFlag.Name)
 	if data != "" {
@@ -758,6 +770,12 @@ var sendToRouteCommand = cli.Command{
 			Usage: "a json array string in the format of the response " +
 				"of queryroutes that denotes which routes to use",
 		},
+		cli.BoolFlag{
+			Name: "skip_temp_err",
+			Usage: "only mark the payment as failed on a terminal " +
+				"error, so that further shards or attempts " +
+				"can be sent for it",
+		},
 	},
 	Action: sendToRoute,
 }
@@ -851,6 +869,7 @@ func sendToRoute(ctx *cli.Context) error {
 	req := &routerrpc.SendToRouteRequest{
 		PaymentHash: rHash,
 		Route:       route,
+		SkipTempErr: ctx.Bool("skip_temp_err"),
 	}
 
 	return sendToRouteRequest(ctx, req)
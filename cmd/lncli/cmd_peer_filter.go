@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+
+	"github.com/cryptomeow/lnd/lnrpc"
+	"github.com/urfave/cli"
+)
+
+var peerFilterCommand = cli.Command{
+	Name:     "peerfilter",
+	Category: "Peers",
+	Usage:    "Manage the filter for inbound peer connections.",
+	Description: `
+	Inbound peer connections are filtered by rules matching an IP address,
+	a CIDR network or an AS number of the form AS64496 of the remote
+	address. Rules matching AS numbers require lnd to be started with the
+	peerfilter.asnfile option.`,
+	Subcommands: []cli.Command{
+		addPeerFilterRuleCommand,
+		removePeerFilterRuleCommand,
+		listPeerFilterRulesCommand,
+	},
+}
+
+var addPeerFilterRuleCommand = cli.Command{
+	Name:  "add",
+	Usage: "Add a rule to deny or allow inbound connections.",
+	Description: `
+	Add a persisted rule that denies inbound connections matching it, or
+	allows them if the --allow flag is set. An allow rule takes precedence
+	over any matching deny rule. A previously added rule for the same
+	address, network or AS number is replaced.`,
+	ArgsUsage: "[--allow] rule",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "allow",
+			Usage: "allow instead of deny matching connections",
+		},
+	},
+	Action: actionDecorator(addPeerFilterRule),
+}
+
+func addPeerFilterRule(ctx *cli.Context) error {
+	// Display the command's help message if the number of arguments is not
+	// what we expect.
+	if ctx.NArg() != 1 {
+		return cli.ShowCommandHelp(ctx, "add")
+	}
+
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	action := lnrpc.PeerFilterAction_PEER_FILTER_DENY
+	if ctx.Bool("allow") {
+		action = lnrpc.PeerFilterAction_PEER_FILTER_ALLOW
+	}
+
+	resp, err := client.AddPeerFilterRule(
+		context.Background(), &lnrpc.AddPeerFilterRuleRequest{
+			Rule:   ctx.Args().First(),
+			Action: action,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var removePeerFilterRuleCommand = cli.Command{
+	Name:      "remove",
+	Usage:     "Remove a previously added rule.",
+	ArgsUsage: "rule",
+	Action:    actionDecorator(removePeerFilterRule),
+}
+
+func removePeerFilterRule(ctx *cli.Context) error {
+	// Display the command's help message if the number of arguments is not
+	// what we expect.
+	if ctx.NArg() != 1 {
+		return cli.ShowCommandHelp(ctx, "remove")
+	}
+
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.RemovePeerFilterRule(
+		context.Background(), &lnrpc.RemovePeerFilterRuleRequest{
+			Rule: ctx.Args().First(),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var listPeerFilterRulesCommand = cli.Command{
+	Name:   "list",
+	Usage:  "List the configured and added rules.",
+	Action: actionDecorator(listPeerFilterRules),
+}
+
+func listPeerFilterRules(ctx *cli.Context) error {
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.ListPeerFilterRules(
+		context.Background(), &lnrpc.ListPeerFilterRulesRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/cryptomeow/lnd/lnrpc/routerrpc"
+	"github.com/cryptomeow/lnd/routing/route"
+	"github.com/urfave/cli"
+)
+
+var probeRouteCommand = cli.Command{
+	Name:      "proberoute",
+	Category:  "Payments",
+	Usage:     "Probe routes to a destination before paying.",
+	ArgsUsage: "dest amt",
+	Description: `
+	Send probe payments with a random, unknown payment hash along candidate
+	routes to the destination to find out whether it can be reached with
+	the given amount (in satoshis) and at what fee. The results are
+	reported to mission control, so a subsequent payment benefits from
+	them.
+	`,
+	Flags: []cli.Flag{
+		cli.Int64Flag{
+			Name: "final_cltv_delta",
+			Usage: "number of blocks the last hop has to reveal " +
+				"the preimage",
+		},
+		cli.Int64Flag{
+			Name: "fee_limit",
+			Usage: "maximum fee allowed in satoshis for a probed " +
+				"route",
+		},
+		cli.Uint64Flag{
+			Name:  "max_probes",
+			Usage: "the maximum number of routes to probe",
+		},
+	},
+	Action: actionDecorator(probeRoute),
+}
+
+func probeRoute(ctx *cli.Context) error {
+	args := ctx.Args()
+
+	if len(args) != 2 {
+		return cli.ShowCommandHelp(ctx, "proberoute")
+	}
+
+	dest, err := route.NewVertexFromStr(args.Get(0))
+	if err != nil {
+		return fmt.Errorf("invalid dest key: %v", err)
+	}
+
+	amtSat, err := strconv.ParseInt(args.Get(1), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid amt: %v", err)
+	}
+
+	conn := getClientConn(ctx, false)
+	defer conn.Close()
+
+	client := routerrpc.NewRouterClient(conn)
+
+	req := &routerrpc.ProbeRouteRequest{
+		Dest:           dest[:],
+		AmtSat:         amtSat,
+		FinalCltvDelta: int32(ctx.Int64("final_cltv_delta")),
+		FeeLimitSat:    ctx.Int64("fee_limit"),
+		MaxProbes:      uint32(ctx.Uint64("max_probes")),
+	}
+	rpcCtx := context.Background()
+	response, err := client.ProbeRoute(rpcCtx, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(response)
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cryptomeow/lnd/lnrpc/routerrpc"
+	"github.com/urfave/cli"
+)
+
+var subscribeHtlcEventsCommand = cli.Command{
+	Name:     "subscribehtlcevents",
+	Category: "Payments",
+	Usage:    "Stream the htlc events of the switch.",
+	Description: `
+	Stream the forward, settle, forward failure and link failure events of
+	all htlcs that pass through our node as json until interrupted. The
+	events can be restricted to those of htlcs that arrived or left on a set
+	of channels and to a set of event types.
+	`,
+	Flags: []cli.Flag{
+		cli.StringSliceFlag{
+			Name: "chan_id",
+			Usage: "(optional) only stream the events of htlcs " +
+				"that arrived or left on the channel with this " +
+				"short channel id, can be set multiple times",
+		},
+		cli.StringSliceFlag{
+			Name: "event_type",
+			Usage: "(optional) only stream the events of htlcs " +
+				"that were part of a send, receive or " +
+				"forward, can be set multiple times",
+		},
+	},
+	Action: actionDecorator(subscribeHtlcEvents),
+}
+
+func subscribeHtlcEvents(ctx *cli.Context) error {
+	req := &routerrpc.SubscribeHtlcEventsRequest{}
+	for _, chanIDStr := range ctx.StringSlice("chan_id") {
+		chanID, err := strconv.ParseUint(chanIDStr, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid chan_id %v: %v", chanIDStr,
+				err)
+		}
+
+		req.ChanIds = append(req.ChanIds, chanID)
+	}
+
+	for _, eventTypeStr := range ctx.StringSlice("event_type") {
+		name := strings.ToUpper(eventTypeStr)
+		eventType, ok := routerrpc.HtlcEvent_EventType_value[name]
+		if !ok || eventType == 0 {
+			return fmt.Errorf("invalid event_type %v, must be one "+
+				"of send, receive or forward", eventTypeStr)
+		}
+
+		req.EventTypes = append(
+			req.EventTypes, routerrpc.HtlcEvent_EventType(eventType),
+		)
+	}
+
+	conn := getClientConn(ctx, false)
+	defer conn.Close()
+
+	client := routerrpc.NewRouterClient(conn)
+
+	stream, err := client.SubscribeHtlcEvents(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		printRespJSON(event)
+	}
+}
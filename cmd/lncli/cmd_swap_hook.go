@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/cryptomeow/lnd/lnrpc"
+	"github.com/urfave/cli"
+)
+
+var registerSwapHookCommand = cli.Command{
+	Name:     "registerswaphook",
+	Category: "Channels",
+	Usage: "Get notified when the local balance of channels exceeds a " +
+		"threshold.",
+	Description: `
+	Register a swap hook that is notified each time the local balance of
+	one of the given channels rises above its threshold. The notifications
+	are printed until the command is interrupted, which unregisters the
+	hook.
+
+	If --payment_macaroon is set, a macaroon that allows making payments
+	of at most 0.1 BTC each and 0.5 BTC in total is printed first. It is
+	revoked once the hook is unregistered.`,
+	ArgsUsage: "--threshold=<funding_txid:output_index>=<sat> " +
+		"[--threshold=...]",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "name",
+			Usage: "the name of the hook, used for logging",
+			Value: "lncli",
+		},
+		cli.StringSliceFlag{
+			Name: "threshold",
+			Usage: "a channel point and the local balance " +
+				"in satoshis above which the hook is " +
+				"notified, in the format " +
+				"funding_txid:output_index=sat, can be " +
+				"specified multiple times",
+		},
+		cli.BoolFlag{
+			Name: "payment_macaroon",
+			Usage: "bake a macaroon that allows making payments " +
+				"while the hook is registered",
+		},
+		cli.Uint64Flag{
+			Name: "macaroon_timeout",
+			Usage: "the number of seconds the payment macaroon " +
+				"is valid for, defaults to one day",
+		},
+	},
+	Action: actionDecorator(registerSwapHook),
+}
+
+func registerSwapHook(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	req := &lnrpc.SwapHookRequest{
+		Name:            ctx.String("name"),
+		PaymentMacaroon: ctx.Bool("payment_macaroon"),
+		MacaroonTimeout: ctx.Uint64("macaroon_timeout"),
+	}
+	for _, threshold := range ctx.StringSlice("threshold") {
+		split := strings.Split(threshold, "=")
+		if len(split) != 2 {
+			return fmt.Errorf("expecting threshold to be in " +
+				"format of: funding_txid:output_index=sat")
+		}
+
+		chanPoint, err := parseChanPoint(split[0])
+		if err != nil {
+			return err
+		}
+
+		sat, err := strconv.ParseInt(split[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("unable to decode threshold: %v", err)
+		}
+
+		req.Thresholds = append(req.Thresholds, &lnrpc.BalanceThreshold{
+			ChanPoint:       chanPoint,
+			LocalBalanceSat: sat,
+		})
+	}
+
+	stream, err := client.RegisterSwapHook(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	for {
+		update, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		printRespJSON(update)
+	}
+}
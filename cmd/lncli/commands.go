@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"encoding/csv"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -115,6 +116,14 @@ var newAddressCommand = cli.Command{
 	Generate a wallet new address. Address-types has to be one of:
 	    - p2wkh:  Pay to witness key hash
 	    - np2wkh: Pay to nested witness key hash`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "account",
+			Usage: "(optional) the name of the wallet account to " +
+				"derive the address from, the default account " +
+				"is used if not set",
+		},
+	},
 	Action: actionDecorator(newAddress),
 }
 
@@ -139,7 +148,8 @@ func newAddress(ctx *cli.Context) error {
 
 	ctxb := context.Background()
 	addr, err := client.NewAddress(ctxb, &lnrpc.NewAddressRequest{
-		Type: addrType,
+		Type:    addrType,
+		Account: ctx.String("account"),
 	})
 	if err != nil {
 		return err
@@ -149,6 +159,100 @@ func newAddress(ctx *cli.Context) error {
 	return nil
 }
 
+var importWatchOnlyCommand = cli.Command{
+	Name:      "importwatchonly",
+	Category:  "On-chain",
+	Usage:     "Watch addresses or scripts without their private keys.",
+	ArgsUsage: "address...",
+	Description: `
+	Add addresses or raw output scripts to the set of scripts that are
+	watched by the wallet without holding their private keys, for example
+	to monitor a cold wallet. The chain is scanned from the birth height
+	for outputs paying to them and transactions spending them. If no birth
+	height is set, only blocks after the current chain tip are scanned.
+
+	Watch-only utxos and transactions are listed by listunspent and
+	listchaintxns with the --include_watch_only flag, but are never used
+	to fund transactions.
+	`,
+	Flags: []cli.Flag{
+		cli.StringSliceFlag{
+			Name: "script",
+			Usage: "a hex encoded output script to watch, can " +
+				"be set multiple times",
+		},
+		cli.Uint64Flag{
+			Name: "birth_height",
+			Usage: "the height of the first block that may " +
+				"contain an output paying to the addresses " +
+				"or scripts",
+		},
+		cli.StringFlag{
+			Name:  "label",
+			Usage: "(optional) a label for the addresses and scripts",
+		},
+	},
+	Action: actionDecorator(importWatchOnly),
+}
+
+func importWatchOnly(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	req := &lnrpc.ImportWatchOnlyRequest{
+		Addresses:   ctx.Args(),
+		BirthHeight: uint32(ctx.Uint64("birth_height")),
+		Label:       ctx.String("label"),
+	}
+	for _, scriptHex := range ctx.StringSlice("script") {
+		script, err := hex.DecodeString(scriptHex)
+		if err != nil {
+			return fmt.Errorf("unable to decode script %v: %v",
+				scriptHex, err)
+		}
+		req.Scripts = append(req.Scripts, script)
+	}
+
+	if len(req.Addresses) == 0 && len(req.Scripts) == 0 {
+		return cli.ShowCommandHelp(ctx, "importwatchonly")
+	}
+
+	resp, err := client.ImportWatchOnly(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var listWatchOnlyCommand = cli.Command{
+	Name:     "listwatchonly",
+	Category: "On-chain",
+	Usage:    "List all watch-only addresses and scripts.",
+	Description: `
+	List all addresses and output scripts watched by the wallet without
+	their private keys, along with the height of the last block that was
+	scanned for them.
+	`,
+	Action: actionDecorator(listWatchOnly),
+}
+
+func listWatchOnly(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.ListWatchOnly(ctxb, &lnrpc.ListWatchOnlyRequest{})
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
 var estimateFeeCommand = cli.Command{
 	Name:      "estimatefee",
 	Category:  "On-chain",
@@ -200,6 +304,12 @@ var txLabelFlag = cli.StringFlag{
 	Usage: "(optional) a label for the transaction",
 }
 
+var spendAccountFlag = cli.StringFlag{
+	Name: "account",
+	Usage: "(optional) the name of the wallet account to spend the " +
+		"coins of, the default account is used if not set",
+}
+
 var sendCoinsCommand = cli.Command{
 	Name:      "sendcoins",
 	Category:  "On-chain",
@@ -250,6 +360,7 @@ var sendCoinsCommand = cli.Command{
 			Value: defaultUtxoMinConf,
 		},
 		txLabelFlag,
+		spendAccountFlag,
 	},
 	Action: actionDecorator(sendCoins),
 }
@@ -313,6 +424,7 @@ func sendCoins(ctx *cli.Context) error {
 		Label:            ctx.String(txLabelFlag.Name),
 		MinConfs:         minConfs,
 		SpendUnconfirmed: minConfs == 0,
+		Account:          ctx.String(spendAccountFlag.Name),
 	}
 	txid, err := client.SendCoins(ctxb, req)
 	if err != nil {
@@ -357,6 +469,11 @@ var listUnspentCommand = cli.Command{
 				"true and both min_confs and max_confs are " +
 				"non-zero. (default: false)",
 		},
+		cli.BoolFlag{
+			Name: "include_watch_only",
+			Usage: "also list the confirmed utxos paying to " +
+				"watch-only addresses and scripts",
+		},
 	},
 	Action: actionDecorator(listUnspent),
 }
@@ -418,8 +535,9 @@ func listUnspent(ctx *cli.Context) error {
 	defer cleanUp()
 
 	req := &lnrpc.ListUnspentRequest{
-		MinConfs: int32(minConfirms),
-		MaxConfs: int32(maxConfirms),
+		MinConfs:         int32(minConfirms),
+		MaxConfs:         int32(maxConfirms),
+		IncludeWatchOnly: ctx.Bool("include_watch_only"),
 	}
 	resp, err := client.ListUnspent(ctxb, req)
 	if err != nil {
@@ -476,6 +594,7 @@ var sendManyCommand = cli.Command{
 			Value: defaultUtxoMinConf,
 		},
 		txLabelFlag,
+		spendAccountFlag,
 	},
 	Action: actionDecorator(sendMany),
 }
@@ -505,6 +624,7 @@ func sendMany(ctx *cli.Context) error {
 		Label:            ctx.String(txLabelFlag.Name),
 		MinConfs:         minConfs,
 		SpendUnconfirmed: minConfs == 0,
+		Account:          ctx.String(spendAccountFlag.Name),
 	})
 	if err != nil {
 		return err
@@ -636,7 +756,9 @@ var closeChannelCommand = cli.Command{
 	In the case of a cooperative closure, one can manually set the fee to
 	be used for the closing transaction via either the --conf_target or
 	--sat_per_byte arguments. This will be the starting value used during
-	fee negotiation. This is optional.
+	fee negotiation. This is optional. The highest fee rate that will be
+	accepted during the negotiation can be set via the --max_fee_rate
+	argument.
 
 	In the case of a cooperative closure, one can manually set the address
 	to deliver funds to upon closure. This is optional, and may only be used
@@ -677,6 +799,12 @@ var closeChannelCommand = cli.Command{
 				"sat/byte that should be used when crafting " +
 				"the transaction",
 		},
+		cli.Uint64Flag{
+			Name: "max_fee_rate",
+			Usage: "(optional) the maximum fee rate expressed " +
+				"in sat/vbyte that will be accepted during " +
+				"the fee negotiation of a cooperative close",
+		},
 		cli.StringFlag{
 			Name: "delivery_addr",
 			Usage: "(optional) an address to deliver funds " +
@@ -710,6 +838,7 @@ func closeChannel(ctx *cli.Context) error {
 		TargetConf:      int32(ctx.Int64("conf_target")),
 		SatPerByte:      ctx.Int64("sat_per_byte"),
 		DeliveryAddress: ctx.String("delivery_addr"),
+		MaxFeePerVbyte:  ctx.Uint64("max_fee_rate"),
 	}
 
 	// After parsing the request, we'll spin up a goroutine that will
@@ -1014,6 +1143,140 @@ func promptForConfirmation(msg string) bool {
 	}
 }
 
+var previewForceCloseCommand = cli.Command{
+	Name:     "previewforceclose",
+	Category: "Channels",
+	Usage:    "Preview a force close of an existing channel.",
+	Description: `
+	Reports what a force close of an active channel would look like at the
+	current height, without signing or broadcasting anything. This includes
+	the fee of our commitment, the HTLC outputs at risk, the CSV and CLTV
+	time locks that delay the recovery of our funds, and the estimated fees
+	of the sweeps.
+
+	To view which funding_txids/output_indexes can be used for this command,
+	see the channel_point values within the listchannels command output.
+	The format for a channel_point is 'funding_txid:output_index'.`,
+	ArgsUsage: "funding_txid [output_index]",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "funding_txid",
+			Usage: "the txid of the channel's funding transaction",
+		},
+		cli.IntFlag{
+			Name: "output_index",
+			Usage: "the output index for the funding output of the funding " +
+				"transaction",
+		},
+		cli.Int64Flag{
+			Name: "conf_target",
+			Usage: "(optional) the number of blocks that the sweep " +
+				"fees are estimated for",
+		},
+	},
+	Action: actionDecorator(previewForceClose),
+}
+
+func previewForceClose(ctx *cli.Context) error {
+	ctxb := context.Background()
+
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	// Show command help if no arguments and flags were provided.
+	if ctx.NArg() == 0 && ctx.NumFlags() == 0 {
+		cli.ShowCommandHelp(ctx, "previewforceclose")
+		return nil
+	}
+
+	channelPoint, err := parseChannelPoint(ctx)
+	if err != nil {
+		return err
+	}
+
+	req := &lnrpc.PreviewForceCloseRequest{
+		ChannelPoint: channelPoint,
+		TargetConf:   int32(ctx.Int64("conf_target")),
+	}
+
+	resp, err := client.PreviewForceClose(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var pinCommitFeeCommand = cli.Command{
+	Name:     "pincommitfee",
+	Category: "Channels",
+	Usage:    "Pin the commitment fee rate of a channel.",
+	Description: `
+	Sets the minimum and maximum commitment fee rate in sat/kw of a channel
+	we initiated. The fee rate we propose to the remote party is kept within
+	these bounds, overriding the fee estimate. This avoids overpaying during
+	fee spikes or underpaying below relay minimums. A bound of 0 isn't
+	enforced, so setting both to 0 removes the pinning.
+
+	To view which funding_txids/output_indexes can be used for this command,
+	see the channel_point values within the listchannels command output.
+	The format for a channel_point is 'funding_txid:output_index'.`,
+	ArgsUsage: "funding_txid [output_index]",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "funding_txid",
+			Usage: "the txid of the channel's funding transaction",
+		},
+		cli.IntFlag{
+			Name: "output_index",
+			Usage: "the output index for the funding output of the funding " +
+				"transaction",
+		},
+		cli.Int64Flag{
+			Name:  "min_fee_per_kw",
+			Usage: "the minimum commitment fee rate in sat/kw",
+		},
+		cli.Int64Flag{
+			Name:  "max_fee_per_kw",
+			Usage: "the maximum commitment fee rate in sat/kw",
+		},
+	},
+	Action: actionDecorator(pinCommitFee),
+}
+
+func pinCommitFee(ctx *cli.Context) error {
+	ctxb := context.Background()
+
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	// Show command help if no arguments and flags were provided.
+	if ctx.NArg() == 0 && ctx.NumFlags() == 0 {
+		cli.ShowCommandHelp(ctx, "pincommitfee")
+		return nil
+	}
+
+	channelPoint, err := parseChannelPoint(ctx)
+	if err != nil {
+		return err
+	}
+
+	req := &lnrpc.PinCommitFeeRateRequest{
+		ChannelPoint: channelPoint,
+		MinFeePerKw:  ctx.Int64("min_fee_per_kw"),
+		MaxFeePerKw:  ctx.Int64("max_fee_per_kw"),
+	}
+
+	resp, err := client.PinCommitFeeRate(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
 var abandonChannelCommand = cli.Command{
 	Name:     "abandonchannel",
 	Category: "Channels",
@@ -1073,6 +1336,63 @@ func abandonChannel(ctx *cli.Context) error {
 	return nil
 }
 
+var upgradeChannelCommand = cli.Command{
+	Name:     "upgradechannel",
+	Category: "Channels",
+	Usage:    "Upgrades an existing channel to anchor outputs.",
+	Description: `
+	Upgrades the commitment format of an existing channel to anchor outputs
+	in place, without closing the channel. The remote peer must support
+	channel upgrades, and the channel must not have any pending HTLCs.
+
+	To view which funding_txids/output_indexes can be used for this command,
+	see the channel_point values within the listchannels command output.
+	The format for a channel_point is 'funding_txid:output_index'.`,
+	ArgsUsage: "funding_txid [output_index]",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "funding_txid",
+			Usage: "the txid of the channel's funding transaction",
+		},
+		cli.IntFlag{
+			Name: "output_index",
+			Usage: "the output index for the funding output of the funding " +
+				"transaction",
+		},
+	},
+	Action: actionDecorator(upgradeChannel),
+}
+
+func upgradeChannel(ctx *cli.Context) error {
+	ctxb := context.Background()
+
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	// Show command help if no arguments and flags were provided.
+	if ctx.NArg() == 0 && ctx.NumFlags() == 0 {
+		cli.ShowCommandHelp(ctx, "upgradechannel")
+		return nil
+	}
+
+	channelPoint, err := parseChannelPoint(ctx)
+	if err != nil {
+		return err
+	}
+
+	req := &lnrpc.UpgradeChannelRequest{
+		ChannelPoint: channelPoint,
+	}
+
+	resp, err := client.UpgradeChannel(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
 // parseChannelPoint parses a funding txid and output index from the command
 // line. Both named options as well as unnamed parameters are supported.
 func parseChannelPoint(ctx *cli.Context) (*lnrpc.ChannelPoint, error) {
@@ -1146,7 +1466,15 @@ var walletBalanceCommand = cli.Command{
 	Name:     "walletbalance",
 	Category: "Wallet",
 	Usage:    "Compute and display the wallet's current balance.",
-	Action:   actionDecorator(walletBalance),
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "account",
+			Usage: "(optional) the name of the wallet account to " +
+				"compute the balance of, the balance of all " +
+				"accounts is computed if not set",
+		},
+	},
+	Action: actionDecorator(walletBalance),
 }
 
 func walletBalance(ctx *cli.Context) error {
@@ -1154,7 +1482,9 @@ func walletBalance(ctx *cli.Context) error {
 	client, cleanUp := getClient(ctx)
 	defer cleanUp()
 
-	req := &lnrpc.WalletBalanceRequest{}
+	req := &lnrpc.WalletBalanceRequest{
+		Account: ctx.String("account"),
+	}
 	resp, err := client.WalletBalance(ctxb, req)
 	if err != nil {
 		return err
@@ -1229,6 +1559,27 @@ func getRecoveryInfo(ctx *cli.Context) error {
 	return nil
 }
 
+var getHealthCommand = cli.Command{
+	Name:   "gethealth",
+	Usage:  "Display the latest results of lnd's health checks.",
+	Action: actionDecorator(getHealth),
+}
+
+func getHealth(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	req := &lnrpc.GetHealthRequest{}
+	resp, err := client.GetHealth(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
 var pendingChannelsCommand = cli.Command{
 	Name:     "pendingchannels",
 	Category: "Channels",
@@ -1252,43 +1603,138 @@ func pendingChannels(ctx *cli.Context) error {
 	return nil
 }
 
-var listChannelsCommand = cli.Command{
-	Name:     "listchannels",
+var pendingReservationsCommand = cli.Command{
+	Name:     "pendingreservations",
 	Category: "Channels",
-	Usage:    "List all open channels.",
-	Flags: []cli.Flag{
-		cli.BoolFlag{
-			Name:  "active_only",
-			Usage: "only list channels which are currently active",
-		},
-		cli.BoolFlag{
-			Name:  "inactive_only",
-			Usage: "only list channels which are currently inactive",
-		},
-		cli.BoolFlag{
-			Name:  "public_only",
-			Usage: "only list channels which are currently public",
-		},
-		cli.BoolFlag{
-			Name:  "private_only",
-			Usage: "only list channels which are currently private",
-		},
-		cli.StringFlag{
-			Name: "peer",
-			Usage: "(optional) only display channels with a " +
-				"particular peer, accepts 66-byte, " +
-				"hex-encoded pubkeys",
-		},
-	},
-	Action: actionDecorator(listChannels),
+	Usage: "List the funding reservations of channels that are still " +
+		"being negotiated.",
+	Action: actionDecorator(pendingReservations),
 }
 
-func listChannels(ctx *cli.Context) error {
+func pendingReservations(ctx *cli.Context) error {
 	ctxb := context.Background()
 	client, cleanUp := getClient(ctx)
 	defer cleanUp()
 
-	peer := ctx.String("peer")
+	req := &lnrpc.ListPendingReservationsRequest{}
+	resp, err := client.ListPendingReservations(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var cancelReservationCommand = cli.Command{
+	Name:     "cancelreservation",
+	Category: "Channels",
+	Usage: "Cancel a funding reservation and fail the funding flow with " +
+		"the remote peer.",
+	ArgsUsage: "node_key pending_chan_id",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "node_key",
+			Usage: "the hex-encoded compressed public key of the " +
+				"remote peer",
+		},
+		cli.StringFlag{
+			Name: "pending_chan_id",
+			Usage: "the hex-encoded pending channel ID of the " +
+				"reservation to cancel",
+		},
+	},
+	Action: actionDecorator(cancelReservation),
+}
+
+func cancelReservation(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	args := ctx.Args()
+
+	var nodeKeyHex string
+	switch {
+	case ctx.IsSet("node_key"):
+		nodeKeyHex = ctx.String("node_key")
+	case args.Present():
+		nodeKeyHex = args.First()
+		args = args.Tail()
+	default:
+		return fmt.Errorf("node_key argument missing")
+	}
+	nodeKey, err := hex.DecodeString(nodeKeyHex)
+	if err != nil {
+		return fmt.Errorf("unable to decode node key: %v", err)
+	}
+
+	var pendingChanIDHex string
+	switch {
+	case ctx.IsSet("pending_chan_id"):
+		pendingChanIDHex = ctx.String("pending_chan_id")
+	case args.Present():
+		pendingChanIDHex = args.First()
+	default:
+		return fmt.Errorf("pending_chan_id argument missing")
+	}
+	pendingChanID, err := hex.DecodeString(pendingChanIDHex)
+	if err != nil {
+		return fmt.Errorf("unable to decode pending chan ID: %v", err)
+	}
+
+	req := &lnrpc.CancelPendingReservationRequest{
+		RemoteNodePub: nodeKey,
+		PendingChanId: pendingChanID,
+	}
+	resp, err := client.CancelPendingReservation(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var listChannelsCommand = cli.Command{
+	Name:     "listchannels",
+	Category: "Channels",
+	Usage:    "List all open channels.",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "active_only",
+			Usage: "only list channels which are currently active",
+		},
+		cli.BoolFlag{
+			Name:  "inactive_only",
+			Usage: "only list channels which are currently inactive",
+		},
+		cli.BoolFlag{
+			Name:  "public_only",
+			Usage: "only list channels which are currently public",
+		},
+		cli.BoolFlag{
+			Name:  "private_only",
+			Usage: "only list channels which are currently private",
+		},
+		cli.StringFlag{
+			Name: "peer",
+			Usage: "(optional) only display channels with a " +
+				"particular peer, accepts 66-byte, " +
+				"hex-encoded pubkeys",
+		},
+	},
+	Action: actionDecorator(listChannels),
+}
+
+func listChannels(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	peer := ctx.String("peer")
 
 	// If the user requested channels with a particular key, parse the
 	// provided pubkey.
@@ -1382,6 +1828,183 @@ func closedChannels(ctx *cli.Context) error {
 	return nil
 }
 
+// timeWindowFlags are the flags that select the time window of uptime queries.
+var timeWindowFlags = []cli.Flag{
+	cli.StringFlag{
+		Name: "start_time",
+		Usage: "(optional) the start of the time window as unix " +
+			`timestamp or relative e.g. "-1w", the window starts ` +
+			"when monitoring began if not set",
+	},
+	cli.StringFlag{
+		Name: "end_time",
+		Usage: "(optional) the end of the time window as unix " +
+			`timestamp or relative e.g. "-1w", the window ends at ` +
+			"the present if not set",
+	},
+}
+
+// parseTimeWindow parses the time window flags, returning zero for the bounds
+// that are not set.
+func parseTimeWindow(ctx *cli.Context) (int64, int64, error) {
+	var (
+		startTime, endTime uint64
+		err                error
+		now                = time.Now()
+	)
+
+	if ctx.IsSet("start_time") {
+		startTime, err = parseTime(ctx.String("start_time"), now)
+		if err != nil {
+			return 0, 0, fmt.Errorf("unable to decode start_time: "+
+				"%v", err)
+		}
+	}
+
+	if ctx.IsSet("end_time") {
+		endTime, err = parseTime(ctx.String("end_time"), now)
+		if err != nil {
+			return 0, 0, fmt.Errorf("unable to decode end_time: %v",
+				err)
+		}
+	}
+
+	return int64(startTime), int64(endTime), nil
+}
+
+var channelUptimeCommand = cli.Command{
+	Name:      "channeluptime",
+	Category:  "Channels",
+	Usage:     "Query the uptime of a channel within a time window.",
+	ArgsUsage: "chan_point",
+	Description: `
+	Query how long an open channel has been monitored for within a time
+	window, and how long its remote peer was online during that time. The
+	history of each peer is retained for 90 days.
+	`,
+	Flags: append([]cli.Flag{
+		cli.StringFlag{
+			Name: "chan_point",
+			Usage: "the channel point of the channel to query, " +
+				"in the form of txid:output_index",
+		},
+	}, timeWindowFlags...),
+	Action: actionDecorator(channelUptime),
+}
+
+func channelUptime(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var chanPointStr string
+	switch {
+	case ctx.IsSet("chan_point"):
+		chanPointStr = ctx.String("chan_point")
+	case ctx.Args().Present():
+		chanPointStr = ctx.Args().First()
+	default:
+		return cli.ShowCommandHelp(ctx, "channeluptime")
+	}
+
+	chanPoint, err := parseChanPoint(chanPointStr)
+	if err != nil {
+		return err
+	}
+
+	startTime, endTime, err := parseTimeWindow(ctx)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.ChannelUptime(ctxb, &lnrpc.ChannelUptimeRequest{
+		ChanPoint: chanPoint,
+		StartTime: startTime,
+		EndTime:   endTime,
+	})
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var leastReliablePeersCommand = cli.Command{
+	Name:     "unreliablepeers",
+	Category: "Peers",
+	Usage:    "List the least reliable peers within a time window.",
+	Description: `
+	List the peers that we have open channels with, ranked by ascending
+	uptime percentage within a time window. Peers with the same uptime are
+	ranked by descending flap count.
+	`,
+	Flags: append([]cli.Flag{
+		cli.Uint64Flag{
+			Name: "num_peers",
+			Usage: "(optional) the maximum number of peers to " +
+				"list, all peers are listed if not set",
+		},
+	}, timeWindowFlags...),
+	Action: actionDecorator(leastReliablePeers),
+}
+
+func leastReliablePeers(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	startTime, endTime, err := parseTimeWindow(ctx)
+	if err != nil {
+		return err
+	}
+
+	req := &lnrpc.LeastReliablePeersRequest{
+		StartTime: startTime,
+		EndTime:   endTime,
+		NumPeers:  uint32(ctx.Uint64("num_peers")),
+	}
+
+	resp, err := client.LeastReliablePeers(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var listMonitorEventsCommand = cli.Command{
+	Name:     "listmonitorevents",
+	Category: "Channels",
+	Usage:    "List the channel closes detected in monitor-only mode.",
+	Description: `
+	List the closes of the monitored channels that were detected on chain,
+	oldest first. This is only available if lnd runs in monitor-only mode,
+	in which it watches the channels of a replicated channel database
+	without acting on them.
+	`,
+	Action: actionDecorator(listMonitorEvents),
+}
+
+func listMonitorEvents(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	req := &lnrpc.ListMonitorEventsRequest{}
+	resp, err := client.ListMonitorEvents(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
 var describeGraphCommand = cli.Command{
 	Name:     "describegraph",
 	Category: "Graph",
@@ -1506,6 +2129,70 @@ func listPayments(ctx *cli.Context) error {
 	return nil
 }
 
+var prunePaymentsCommand = cli.Command{
+	Name:     "prunepayments",
+	Category: "Payments",
+	Usage:    "Delete old or failed outgoing payments.",
+	Description: `
+	Delete the completed outgoing payments, including the route and failure
+	of every htlc attempt, that are older than max_age or exceed the
+	keep_last most recent payments. In-flight payments are never deleted.
+
+	With --failed_htlcs_only, the payments and their settled attempts are
+	kept and only the failed htlc attempts are deleted.`,
+	Flags: []cli.Flag{
+		cli.DurationFlag{
+			Name: "max_age",
+			Usage: "only delete payments created longer ago than " +
+				"this duration, e.g. 720h",
+		},
+		cli.Uint64Flag{
+			Name: "keep_last",
+			Usage: "the number of most recent payments that are " +
+				"kept",
+		},
+		cli.BoolFlag{
+			Name:  "failed_only",
+			Usage: "only delete failed payments",
+		},
+		cli.BoolFlag{
+			Name: "failed_htlcs_only",
+			Usage: "only delete the failed htlc attempts of the " +
+				"payments",
+		},
+	},
+	Action: actionDecorator(prunePayments),
+}
+
+func prunePayments(ctx *cli.Context) error {
+	// Deleting all completed payments requires DeleteAllPayments, so at
+	// least one criterion must be given.
+	if !ctx.IsSet("max_age") && !ctx.IsSet("keep_last") &&
+		!ctx.Bool("failed_only") && !ctx.Bool("failed_htlcs_only") {
+
+		return fmt.Errorf("at least one of max_age, keep_last, " +
+			"failed_only or failed_htlcs_only required")
+	}
+
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	req := &lnrpc.PrunePaymentsRequest{
+		MaxAgeSec:          uint64(ctx.Duration("max_age").Seconds()),
+		KeepLast:           ctx.Uint64("keep_last"),
+		FailedPaymentsOnly: ctx.Bool("failed_only"),
+		FailedHtlcsOnly:    ctx.Bool("failed_htlcs_only"),
+	}
+
+	resp, err := client.PrunePayments(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
 var getChanInfoCommand = cli.Command{
 	Name:     "getchaninfo",
 	Category: "Graph",
@@ -1771,6 +2458,46 @@ func getNetworkInfo(ctx *cli.Context) error {
 	return nil
 }
 
+var pruneGraphCommand = cli.Command{
+	Name:     "prunegraph",
+	Category: "Channels",
+	Usage:    "Prune zombie channels and unconnected nodes from the graph.",
+	Description: `
+	Removes all zombie channels from the channel graph, using the configured
+	zombie pruning policy, along with all nodes that are left without any
+	channels. The number of removed channels and nodes is returned.
+
+	If --compact_zombies is set, the public keys of the nodes are removed
+	from the index of zombie channels afterwards. Compacted entries still
+	prevent zombie channels from being downloaded again through gossip.`,
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name: "compact_zombies",
+			Usage: "compact the zombie index after pruning, even " +
+				"if the compactzombies option isn't enabled",
+		},
+	},
+	Action: actionDecorator(pruneGraph),
+}
+
+func pruneGraph(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	req := &lnrpc.PruneGraphRequest{
+		CompactZombies: ctx.Bool("compact_zombies"),
+	}
+
+	resp, err := client.PruneGraph(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
 var debugLevelCommand = cli.Command{
 	Name:  "debuglevel",
 	Usage: "Set the debug level.",
@@ -1809,23 +2536,111 @@ func debugLevel(ctx *cli.Context) error {
 	return nil
 }
 
-var listChainTxnsCommand = cli.Command{
-	Name:     "listchaintxns",
-	Category: "On-chain",
-	Usage:    "List transactions from the wallet.",
+var subsystemLevelsCommand = cli.Command{
+	Name:      "subsystemlevels",
+	Usage:     "List and set the debug levels of individual subsystems.",
+	ArgsUsage: "[subsystem level]",
+	Description: `
+	Lists the current logging level of all subsystems. If a subsystem and a
+	level {trace, debug, info, warn, error, critical, off} are specified,
+	the logging level of the subsystem is changed first.
+
+	If revert_after is set, the logging level of the subsystem is only
+	changed temporarily, and reverted to its prior level after the given
+	duration. This can be used to enable trace logging for a single
+	subsystem for a short time, e.g.:
+
+	lncli subsystemlevels --revert_after=5m HSWC trace`,
 	Flags: []cli.Flag{
-		cli.Int64Flag{
-			Name: "start_height",
-			Usage: "the block height from which to list " +
-				"transactions, inclusive",
+		cli.StringFlag{
+			Name:  "subsystem",
+			Usage: "the subsystem to change the logging level of",
 		},
-		cli.Int64Flag{
-			Name: "end_height",
-			Usage: "the block height until which to list " +
-				"transactions, inclusive, to get transactions " +
+		cli.StringFlag{
+			Name:  "level",
+			Usage: "the new logging level of the subsystem",
+		},
+		cli.DurationFlag{
+			Name: "revert_after",
+			Usage: "if set, the duration after which the subsystem " +
+				"is reverted to its prior logging level",
+		},
+	},
+	Action: actionDecorator(subsystemLevels),
+}
+
+func subsystemLevels(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var (
+		args      = ctx.Args()
+		subsystem = ctx.String("subsystem")
+		level     = ctx.String("level")
+	)
+	if subsystem == "" && args.Present() {
+		subsystem = args.First()
+		args = args.Tail()
+	}
+	if level == "" && args.Present() {
+		level = args.First()
+	}
+
+	if subsystem != "" && level == "" {
+		return fmt.Errorf("level must be specified along with the " +
+			"subsystem")
+	}
+
+	revertAfter := ctx.Duration("revert_after")
+	if revertAfter != 0 && revertAfter < time.Second {
+		return fmt.Errorf("revert_after must be at least one second")
+	}
+
+	req := &lnrpc.SubsystemLogLevelsRequest{
+		Subsystem:      subsystem,
+		Level:          level,
+		RevertAfterSec: uint32(revertAfter.Seconds()),
+	}
+
+	resp, err := client.SubsystemLogLevels(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var listChainTxnsCommand = cli.Command{
+	Name:     "listchaintxns",
+	Category: "On-chain",
+	Usage:    "List transactions from the wallet.",
+	Flags: []cli.Flag{
+		cli.Int64Flag{
+			Name: "start_height",
+			Usage: "the block height from which to list " +
+				"transactions, inclusive",
+		},
+		cli.Int64Flag{
+			Name: "end_height",
+			Usage: "the block height until which to list " +
+				"transactions, inclusive, to get transactions " +
 				"until the chain tip, including unconfirmed, " +
 				"set this value to -1",
 		},
+		cli.StringFlag{
+			Name: "label_prefix",
+			Usage: "if set, only transactions with a label that " +
+				"starts with this prefix are listed, for " +
+				"example \"0:sweep\"",
+		},
+		cli.BoolFlag{
+			Name: "include_watch_only",
+			Usage: "also list the confirmed transactions paying " +
+				"to or spending from watch-only addresses and " +
+				"scripts",
+		},
 	},
 	Description: `
 	List all transactions an address of the wallet was involved in.
@@ -1838,7 +2653,9 @@ var listChainTxnsCommand = cli.Command{
 	To get all transactions until the chain tip, including unconfirmed
 	transactions (identifiable with BlockHeight=0), set end_height to -1.
 	By default, this call will get all transactions our wallet was involved
-	in, including unconfirmed transactions.
+	in, including unconfirmed transactions. The label_prefix flag can be
+	used to only list transactions with a matching label, such as all
+	sweeps (0:sweep) or all justice transactions (0:justicetx).
 `,
 	Action: actionDecorator(listChainTxns),
 }
@@ -1856,6 +2673,10 @@ func listChainTxns(ctx *cli.Context) error {
 	if ctx.IsSet("end_height") {
 		req.EndHeight = int32(ctx.Int64("end_height"))
 	}
+	if ctx.IsSet("label_prefix") {
+		req.LabelPrefix = ctx.String("label_prefix")
+	}
+	req.IncludeWatchOnly = ctx.Bool("include_watch_only")
 
 	resp, err := client.GetTransactions(ctxb, req)
 	if err != nil {
@@ -1888,6 +2709,109 @@ func stopDaemon(ctx *cli.Context) error {
 	return nil
 }
 
+var drainCommand = cli.Command{
+	Name:  "drain",
+	Usage: "Drain all HTLCs in flight, then shutdown the daemon.",
+	Description: `
+	Prepare the daemon for a maintenance restart without losing any HTLCs.
+	The daemon stops forwarding and sending new HTLCs, and rejects all
+	channel open requests. Once all HTLCs in flight have been resolved, or
+	the timeout expired, the daemon is gracefully shut down.`,
+	Flags: []cli.Flag{
+		cli.DurationFlag{
+			Name: "timeout",
+			Usage: "the maximum amount of time to wait for the " +
+				"htlcs in flight to be resolved",
+			Value: 5 * time.Minute,
+		},
+		cli.BoolFlag{
+			Name: "abort_on_timeout",
+			Usage: "if set, the daemon leaves drain mode instead of " +
+				"shutting down if there are still htlcs in " +
+				"flight after the timeout",
+		},
+	},
+	Action: actionDecorator(drainNode),
+}
+
+func drainNode(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	timeout := ctx.Duration("timeout")
+	if timeout < time.Second {
+		return fmt.Errorf("timeout must be at least one second")
+	}
+
+	req := &lnrpc.DrainNodeRequest{
+		TimeoutSec:     uint32(timeout.Seconds()),
+		AbortOnTimeout: ctx.Bool("abort_on_timeout"),
+	}
+
+	resp, err := client.DrainNode(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var reloadConfigCommand = cli.Command{
+	Name:  "reloadconfig",
+	Usage: "Reload the runtime configurable options of the daemon.",
+	Description: `
+	Re-read the daemon's configuration file and apply the options that can
+	be changed without a restart: the default routing policy of new
+	channels, minchansize, maxchansize, maxpendingchannels, the
+	reservation timeouts and wtclient.tower. All other options only take
+	effect after a restart. The names of the updated options are returned.`,
+	Action: actionDecorator(reloadConfig),
+}
+
+func reloadConfig(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.ReloadConfig(ctxb, &lnrpc.ReloadConfigRequest{})
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var rotateTLSCertCommand = cli.Command{
+	Name:  "rotatetlscert",
+	Usage: "Re-generate the TLS certificate of the daemon.",
+	Description: `
+	Re-generate the self-signed TLS certificate and key of the gRPC and REST
+	listeners without restarting the daemon. The files on disk are replaced
+	and new connections are served the new certificate, which is returned
+	so it can be handed to remote clients. Existing connections keep
+	working.`,
+	Action: actionDecorator(rotateTLSCert),
+}
+
+func rotateTLSCert(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.RotateTLSCertificate(
+		ctxb, &lnrpc.RotateTLSCertificateRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
 var signMessageCommand = cli.Command{
 	Name:      "signmessage",
 	Category:  "Wallet",
@@ -2003,7 +2927,27 @@ var feeReportCommand = cli.Command{
 	Usage:    "Display the current fee policies of all active channels.",
 	Description: `
 	Returns the current fee policies of all active channels.
-	Fee policies can be updated using the updatechanpolicy command.`,
+	Fee policies can be updated using the updatechanpolicy command.
+
+	In addition to the fee revenue of the past day, week and month, the
+	revenue within custom time windows can be reported with the --window
+	parameter, which can be given multiple times. A window is expressed as
+	start[,end], where both times are either seconds since the Unix epoch
+	or negative time ranges, e.g. "-3d,-1d". If the end is omitted, the
+	current time is used. With --per_channel, the revenue of each window is
+	also broken down per outgoing channel.`,
+	Flags: []cli.Flag{
+		cli.StringSliceFlag{
+			Name: "window",
+			Usage: "a time window to report the fee revenue for, " +
+				`as start[,end] e.g. "-1w,-1d"`,
+		},
+		cli.BoolFlag{
+			Name: "per_channel",
+			Usage: "report the revenue of each window per " +
+				"outgoing channel",
+		},
+	},
 	Action: actionDecorator(feeReport),
 }
 
@@ -2012,7 +2956,39 @@ func feeReport(ctx *cli.Context) error {
 	client, cleanUp := getClient(ctx)
 	defer cleanUp()
 
-	req := &lnrpc.FeeReportRequest{}
+	req := &lnrpc.FeeReportRequest{
+		PerChannel: ctx.Bool("per_channel"),
+	}
+
+	now := time.Now()
+	for _, window := range ctx.StringSlice("window") {
+		times := strings.Split(window, ",")
+		if len(times) > 2 {
+			return fmt.Errorf("invalid window %v, must be "+
+				"start[,end]", window)
+		}
+
+		startTime, err := parseTime(times[0], now)
+		if err != nil {
+			return fmt.Errorf("unable to decode window start: %v",
+				err)
+		}
+
+		var endTime uint64
+		if len(times) == 2 {
+			endTime, err = parseTime(times[1], now)
+			if err != nil {
+				return fmt.Errorf("unable to decode window "+
+					"end: %v", err)
+			}
+		}
+
+		req.Windows = append(req.Windows, &lnrpc.FeeReportWindow{
+			StartTime: startTime,
+			EndTime:   endTime,
+		})
+	}
+
 	resp, err := client.FeeReport(ctxb, req)
 	if err != nil {
 		return err
@@ -2025,15 +3001,21 @@ func feeReport(ctx *cli.Context) error {
 var updateChannelPolicyCommand = cli.Command{
 	Name:     "updatechanpolicy",
 	Category: "Channels",
-	Usage: "Update the channel policy for all channels, or a single " +
-		"channel.",
+	Usage: "Update the channel policy for all channels, all channels " +
+		"with a peer, or a single channel.",
 	ArgsUsage: "base_fee_msat fee_rate time_lock_delta " +
 		"[--max_htlc_msat=N] [channel_point]",
 	Description: `
-	Updates the channel policy for all channels, or just a particular channel
-	identified by its channel point. The update will be committed, and
-	broadcast to the rest of the network within the next batch.
-	Channel points are encoded as: funding_txid:output_index`,
+	Updates the channel policy for all channels, all channels with a
+	particular peer, or just a particular channel identified by its channel
+	point. The update will be committed, and broadcast to the rest of the
+	network within the next batch.
+	Channel points are encoded as: funding_txid:output_index
+
+	Instead of specifying the policy, it can be taken from a policy
+	template that was stored with 'lncli policytemplate set' by using the
+	--template flag. The update is recorded in the policy history of the
+	updated channels, along with the optional --reason.`,
 	Flags: []cli.Flag{
 		cli.Int64Flag{
 			Name: "base_fee_msat",
@@ -2071,6 +3053,23 @@ var updateChannelPolicyCommand = cli.Command{
 				"updated, if nil the policies for all channels " +
 				"will be updated. Takes the form of: txid:output_index",
 		},
+		cli.StringFlag{
+			Name: "peer",
+			Usage: "if set, the policies of all channels with the " +
+				"peer with this hex-encoded identity public key " +
+				"will be updated",
+		},
+		cli.StringFlag{
+			Name: "template",
+			Usage: "if set, the policy is taken from the policy " +
+				"template with this name instead of the " +
+				"arguments",
+		},
+		cli.StringFlag{
+			Name: "reason",
+			Usage: "an optional reason for the update that is " +
+				"recorded in the policy history",
+		},
 	},
 	Action: actionDecorator(updateChannelPolicy),
 }
@@ -2105,13 +3104,85 @@ func updateChannelPolicy(ctx *cli.Context) error {
 	client, cleanUp := getClient(ctx)
 	defer cleanUp()
 
+	args := ctx.Args()
+	req := &lnrpc.PolicyUpdateRequest{
+		Template: ctx.String("template"),
+		Reason:   ctx.String("reason"),
+	}
+
+	// If no template is used, the policy must be specified.
+	if req.Template == "" {
+		var err error
+		args, err = parsePolicyArgs(ctx, args, req)
+		if err != nil {
+			return err
+		}
+	}
+
+	var (
+		chanPoint    *lnrpc.ChannelPoint
+		chanPointStr string
+		err          error
+	)
+
+	switch {
+	case ctx.IsSet("chan_point"):
+		chanPointStr = ctx.String("chan_point")
+	case args.Present():
+		chanPointStr = args.First()
+	}
+
+	if chanPointStr != "" {
+		chanPoint, err = parseChanPoint(chanPointStr)
+		if err != nil {
+			return fmt.Errorf("unable to parse chan point: %v", err)
+		}
+	}
+
+	switch {
+	case chanPoint != nil && ctx.IsSet("peer"):
+		return fmt.Errorf("chan_point and peer cannot both be set")
+
+	case chanPoint != nil:
+		req.Scope = &lnrpc.PolicyUpdateRequest_ChanPoint{
+			ChanPoint: chanPoint,
+		}
+
+	case ctx.IsSet("peer"):
+		peer, err := hex.DecodeString(ctx.String("peer"))
+		if err != nil {
+			return fmt.Errorf("unable to decode peer: %v", err)
+		}
+		req.Scope = &lnrpc.PolicyUpdateRequest_Peer{
+			Peer: peer,
+		}
+
+	default:
+		req.Scope = &lnrpc.PolicyUpdateRequest_Global{
+			Global: true,
+		}
+	}
+
+	resp, err := client.UpdateChannelPolicy(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+// parsePolicyArgs parses the policy of a policy update from the flags or the
+// positional arguments into the request and returns the remaining arguments.
+func parsePolicyArgs(ctx *cli.Context, args cli.Args,
+	req *lnrpc.PolicyUpdateRequest) (cli.Args, error) {
+
 	var (
 		baseFee       int64
 		feeRate       float64
 		timeLockDelta int64
 		err           error
 	)
-	args := ctx.Args()
 
 	switch {
 	case ctx.IsSet("base_fee_msat"):
@@ -2119,11 +3190,12 @@ func updateChannelPolicy(ctx *cli.Context) error {
 	case args.Present():
 		baseFee, err = strconv.ParseInt(args.First(), 10, 64)
 		if err != nil {
-			return fmt.Errorf("unable to decode base_fee_msat: %v", err)
+			return nil, fmt.Errorf("unable to decode base_fee_msat: "+
+				"%v", err)
 		}
 		args = args.Tail()
 	default:
-		return fmt.Errorf("base_fee_msat argument missing")
+		return nil, fmt.Errorf("base_fee_msat argument missing")
 	}
 
 	switch {
@@ -2132,12 +3204,13 @@ func updateChannelPolicy(ctx *cli.Context) error {
 	case args.Present():
 		feeRate, err = strconv.ParseFloat(args.First(), 64)
 		if err != nil {
-			return fmt.Errorf("unable to decode fee_rate: %v", err)
+			return nil, fmt.Errorf("unable to decode fee_rate: "+
+				"%v", err)
 		}
 
 		args = args.Tail()
 	default:
-		return fmt.Errorf("fee_rate argument missing")
+		return nil, fmt.Errorf("fee_rate argument missing")
 	}
 
 	switch {
@@ -2146,63 +3219,26 @@ func updateChannelPolicy(ctx *cli.Context) error {
 	case args.Present():
 		timeLockDelta, err = strconv.ParseInt(args.First(), 10, 64)
 		if err != nil {
-			return fmt.Errorf("unable to decode time_lock_delta: %v",
-				err)
+			return nil, fmt.Errorf("unable to decode "+
+				"time_lock_delta: %v", err)
 		}
 
 		args = args.Tail()
 	default:
-		return fmt.Errorf("time_lock_delta argument missing")
-	}
-
-	var (
-		chanPoint    *lnrpc.ChannelPoint
-		chanPointStr string
-	)
-
-	switch {
-	case ctx.IsSet("chan_point"):
-		chanPointStr = ctx.String("chan_point")
-	case args.Present():
-		chanPointStr = args.First()
+		return nil, fmt.Errorf("time_lock_delta argument missing")
 	}
 
-	if chanPointStr != "" {
-		chanPoint, err = parseChanPoint(chanPointStr)
-		if err != nil {
-			return fmt.Errorf("unable to parse chan point: %v", err)
-		}
-	}
-
-	req := &lnrpc.PolicyUpdateRequest{
-		BaseFeeMsat:   baseFee,
-		FeeRate:       feeRate,
-		TimeLockDelta: uint32(timeLockDelta),
-		MaxHtlcMsat:   ctx.Uint64("max_htlc_msat"),
-	}
+	req.BaseFeeMsat = baseFee
+	req.FeeRate = feeRate
+	req.TimeLockDelta = uint32(timeLockDelta)
+	req.MaxHtlcMsat = ctx.Uint64("max_htlc_msat")
 
 	if ctx.IsSet("min_htlc_msat") {
 		req.MinHtlcMsat = ctx.Uint64("min_htlc_msat")
 		req.MinHtlcMsatSpecified = true
 	}
 
-	if chanPoint != nil {
-		req.Scope = &lnrpc.PolicyUpdateRequest_ChanPoint{
-			ChanPoint: chanPoint,
-		}
-	} else {
-		req.Scope = &lnrpc.PolicyUpdateRequest_Global{
-			Global: true,
-		}
-	}
-
-	resp, err := client.UpdateChannelPolicy(ctxb, req)
-	if err != nil {
-		return err
-	}
-
-	printRespJSON(resp)
-	return nil
+	return args, nil
 }
 
 var forwardingHistoryCommand = cli.Command{
@@ -2330,6 +3366,320 @@ func forwardingHistory(ctx *cli.Context) error {
 	return nil
 }
 
+var listOpenAttemptsCommand = cli.Command{
+	Name:     "listopenattempts",
+	Category: "Channels",
+	Usage:    "Query the audit log of inbound channel open requests.",
+	Description: `
+	Query the log of all inbound channel open requests over a particular
+	time range (--start_time and --end_time), including whether each
+	request was accepted, the reason it was rejected and the verdict of the
+	channel acceptor. The start and end times are meant to be expressed in
+	seconds since the Unix epoch, or as negative time ranges, e.g. "-3d".
+	If --start_time isn't provided, then 24 hours ago is used. If
+	--end_time isn't provided, then the current time is used.
+
+	Alternatively, the request that resulted in a specific channel can be
+	looked up with the --chan_point parameter.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "start_time",
+			Usage: "the starting time for the query " +
+				`as unix timestamp or relative e.g. "-1w"`,
+		},
+		cli.StringFlag{
+			Name: "end_time",
+			Usage: "the end time for the query " +
+				`as unix timestamp or relative e.g. "-1w"`,
+		},
+		cli.Int64Flag{
+			Name:  "index_offset",
+			Usage: "the number of attempts to skip",
+		},
+		cli.Int64Flag{
+			Name:  "max_attempts",
+			Usage: "the max number of attempts to return",
+		},
+		cli.StringFlag{
+			Name: "chan_point",
+			Usage: "only return the request that resulted in the " +
+				"channel with this channel point",
+		},
+	},
+	Action: actionDecorator(listOpenAttempts),
+}
+
+func listOpenAttempts(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var (
+		startTime, endTime uint64
+		err                error
+	)
+	now := time.Now()
+
+	if ctx.IsSet("start_time") {
+		startTime, err = parseTime(ctx.String("start_time"), now)
+		if err != nil {
+			return fmt.Errorf("unable to decode start_time: %v", err)
+		}
+	} else {
+		startTime = uint64(now.Add(-time.Hour * 24).Unix())
+	}
+
+	endTime = uint64(now.Unix())
+	if ctx.IsSet("end_time") {
+		endTime, err = parseTime(ctx.String("end_time"), now)
+		if err != nil {
+			return fmt.Errorf("unable to decode end_time: %v", err)
+		}
+	}
+
+	req := &lnrpc.ListChannelOpenAttemptsRequest{
+		StartTime:      startTime,
+		EndTime:        endTime,
+		IndexOffset:    uint32(ctx.Int64("index_offset")),
+		NumMaxAttempts: uint32(ctx.Int64("max_attempts")),
+	}
+	if ctx.IsSet("chan_point") {
+		req.ChanPoint, err = parseChanPoint(ctx.String("chan_point"))
+		if err != nil {
+			return fmt.Errorf("unable to parse chan_point: %v", err)
+		}
+	}
+
+	resp, err := client.ListChannelOpenAttempts(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var setPendingChanLimitCommand = cli.Command{
+	Name:      "setpendingchanlimit",
+	Category:  "Channels",
+	Usage:     "Override the maximum number of pending channels of a peer.",
+	ArgsUsage: "node-key [max-pending]",
+	Description: `
+	Override the maximum number of inbound pending channels the peer with
+	the given identity key may have with us, until the next restart. The
+	override takes precedence over the maxpendingchannels,
+	peermaxpendingchannels and trustedpeerunlimitedpending options. A
+	maximum of 0, or the --unlimited flag, doesn't limit the number of
+	pending channels of the peer.
+
+	The override can be removed again with the --remove flag.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "node_key",
+			Usage: "the identity public key of the peer in hex " +
+				"format",
+		},
+		cli.Uint64Flag{
+			Name: "max_pending",
+			Usage: "the maximum number of inbound pending " +
+				"channels of the peer",
+		},
+		cli.BoolFlag{
+			Name:  "unlimited",
+			Usage: "don't limit the number of pending channels",
+		},
+		cli.BoolFlag{
+			Name: "remove",
+			Usage: "remove the override, so that the configured " +
+				"limits apply to the peer again",
+		},
+	},
+	Action: actionDecorator(setPendingChanLimit),
+}
+
+func setPendingChanLimit(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var (
+		args = ctx.Args()
+		req  = &lnrpc.SetPeerPendingChanLimitRequest{
+			Remove: ctx.Bool("remove"),
+		}
+		err error
+	)
+
+	switch {
+	case ctx.IsSet("node_key"):
+		req.PubKey, err = hex.DecodeString(ctx.String("node_key"))
+	case args.Present():
+		req.PubKey, err = hex.DecodeString(args.First())
+		args = args.Tail()
+	default:
+		return fmt.Errorf("node key argument missing")
+	}
+	if err != nil {
+		return fmt.Errorf("unable to decode node key: %v", err)
+	}
+
+	switch {
+	// Neither removing the override nor lifting the limit requires a
+	// maximum.
+	case req.Remove, ctx.Bool("unlimited"):
+
+	case ctx.IsSet("max_pending"):
+		req.MaxPendingChannels = uint32(ctx.Uint64("max_pending"))
+
+	case args.Present():
+		maxPending, err := strconv.ParseUint(args.First(), 10, 32)
+		if err != nil {
+			return fmt.Errorf("unable to decode max_pending: %v",
+				err)
+		}
+		req.MaxPendingChannels = uint32(maxPending)
+
+	default:
+		return fmt.Errorf("max_pending argument missing")
+	}
+
+	resp, err := client.SetPeerPendingChanLimit(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var exportChanEventsCommand = cli.Command{
+	Name:     "exportchanevents",
+	Category: "Channels",
+	Usage:    "Export the balance-affecting events of all channels.",
+	Description: `
+	Export the events that changed the balance of our channels over a
+	particular time range (--start_time and --end_time) for bookkeeping.
+	This includes the settled htlcs of received and sent payments,
+	forwards and the fees they earned, the outputs of closing transactions
+	and the sweeps of channel outputs. The start and end times are meant to
+	be expressed in seconds since the Unix epoch, or as negative time
+	ranges, e.g. "-3d". If --start_time isn't provided, all events up to
+	the end time are exported. If --end_time isn't provided, then the
+	current time is used.
+
+	The events are printed as JSON by default, or as CSV with one event
+	per row if --format=csv is set. The export can be restricted to a
+	single channel with the --chan_point parameter.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "start_time",
+			Usage: "the starting time for the export " +
+				`as unix timestamp or relative e.g. "-1w"`,
+		},
+		cli.StringFlag{
+			Name: "end_time",
+			Usage: "the end time for the export " +
+				`as unix timestamp or relative e.g. "-1w"`,
+		},
+		cli.StringFlag{
+			Name: "chan_point",
+			Usage: "only export the events of the channel with " +
+				"this channel point",
+		},
+		cli.StringFlag{
+			Name:  "format",
+			Usage: "the output format, either json or csv",
+			Value: "json",
+		},
+	},
+	Action: actionDecorator(exportChanEvents),
+}
+
+func exportChanEvents(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var (
+		startTime, endTime uint64
+		err                error
+	)
+	now := time.Now()
+
+	if ctx.IsSet("start_time") {
+		startTime, err = parseTime(ctx.String("start_time"), now)
+		if err != nil {
+			return fmt.Errorf("unable to decode start_time: %v", err)
+		}
+	}
+
+	endTime = uint64(now.Unix())
+	if ctx.IsSet("end_time") {
+		endTime, err = parseTime(ctx.String("end_time"), now)
+		if err != nil {
+			return fmt.Errorf("unable to decode end_time: %v", err)
+		}
+	}
+
+	format := ctx.String("format")
+	if format != "json" && format != "csv" {
+		return fmt.Errorf("unknown format %v, must be json or csv",
+			format)
+	}
+
+	req := &lnrpc.ExportChannelEventsRequest{
+		StartTime: startTime,
+		EndTime:   endTime,
+	}
+	if ctx.IsSet("chan_point") {
+		req.ChanPoint, err = parseChanPoint(ctx.String("chan_point"))
+		if err != nil {
+			return fmt.Errorf("unable to parse chan_point: %v", err)
+		}
+	}
+
+	resp, err := client.ExportChannelEvents(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	if format == "json" {
+		printRespJSON(resp)
+		return nil
+	}
+
+	w := csv.NewWriter(os.Stdout)
+	err = w.Write([]string{
+		"timestamp", "type", "channel_point", "chan_id", "amount_msat",
+		"fee_msat", "txid", "payment_hash",
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, event := range resp.Events {
+		err := w.Write([]string{
+			strconv.FormatUint(event.Timestamp, 10),
+			event.Type.String(),
+			event.ChannelPoint,
+			strconv.FormatUint(event.ChanId, 10),
+			strconv.FormatInt(event.AmountMsat, 10),
+			strconv.FormatInt(event.FeeMsat, 10),
+			event.Txid,
+			event.PaymentHash,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
 var exportChanBackupCommand = cli.Command{
 	Name:     "exportchanbackup",
 	Category: "Channels",
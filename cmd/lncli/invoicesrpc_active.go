@@ -19,6 +19,9 @@ func invoicesCommands() []cli.Command {
 		cancelInvoiceCommand,
 		addHoldInvoiceCommand,
 		settleInvoiceCommand,
+		approveInvoiceCommand,
+		rejectInvoiceCommand,
+		listPendingApprovalsCommand,
 	}
 }
 
@@ -189,6 +192,13 @@ var addHoldInvoiceCommand = cli.Command{
 				"private channels in order to assist the " +
 				"payer in reaching you",
 		},
+		cli.Uint64Flag{
+			Name: "mpp_timeout",
+			Usage: "the time in seconds after which the htlcs of " +
+				"an incomplete multi-path payment are " +
+				"canceled back. If not specified, a timeout " +
+				"of 120 seconds is implied.",
+		},
 	},
 	Action: actionDecorator(addHoldInvoice),
 }
@@ -243,6 +253,7 @@ func addHoldInvoice(ctx *cli.Context) error {
 		FallbackAddr:    ctx.String("fallback_addr"),
 		Expiry:          ctx.Int64("expiry"),
 		Private:         ctx.Bool("private"),
+		MppTimeout:      ctx.Uint64("mpp_timeout"),
 	}
 
 	resp, err := client.AddHoldInvoice(context.Background(), invoice)
@@ -258,3 +269,102 @@ func addHoldInvoice(ctx *cli.Context) error {
 
 	return nil
 }
+
+var approveInvoiceCommand = cli.Command{
+	Name:     "approveinvoice",
+	Category: "Invoices",
+	Usage:    "Approve the funds received for an invoice.",
+	Description: `
+	Release the funds held for an accepted invoice that was created with
+	--require_approval, by settling the invoice.`,
+	ArgsUsage: "paymenthash",
+	Action:    actionDecorator(approveInvoice),
+}
+
+func approveInvoice(ctx *cli.Context) error {
+	if !ctx.Args().Present() {
+		return cli.ShowCommandHelp(ctx, "approveinvoice")
+	}
+
+	paymentHash, err := hex.DecodeString(ctx.Args().First())
+	if err != nil {
+		return fmt.Errorf("unable to parse payment hash: %v", err)
+	}
+
+	client, cleanUp := getInvoicesClient(ctx)
+	defer cleanUp()
+
+	req := &invoicesrpc.ApproveInvoiceMsg{
+		PaymentHash: paymentHash,
+	}
+
+	resp, err := client.ApproveInvoice(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var rejectInvoiceCommand = cli.Command{
+	Name:     "rejectinvoice",
+	Category: "Invoices",
+	Usage:    "Reject the funds received for an invoice.",
+	Description: `
+	Reject the funds held for an invoice that was created with
+	--require_approval, by canceling the invoice. The htlcs paying to the
+	invoice are canceled back to the sender.`,
+	ArgsUsage: "paymenthash",
+	Action:    actionDecorator(rejectInvoice),
+}
+
+func rejectInvoice(ctx *cli.Context) error {
+	if !ctx.Args().Present() {
+		return cli.ShowCommandHelp(ctx, "rejectinvoice")
+	}
+
+	paymentHash, err := hex.DecodeString(ctx.Args().First())
+	if err != nil {
+		return fmt.Errorf("unable to parse payment hash: %v", err)
+	}
+
+	client, cleanUp := getInvoicesClient(ctx)
+	defer cleanUp()
+
+	req := &invoicesrpc.RejectInvoiceMsg{
+		PaymentHash: paymentHash,
+	}
+
+	resp, err := client.RejectInvoice(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var listPendingApprovalsCommand = cli.Command{
+	Name:     "listpendingapprovals",
+	Category: "Invoices",
+	Usage:    "List the invoices of which the funds await approval.",
+	Action:   actionDecorator(listPendingApprovals),
+}
+
+func listPendingApprovals(ctx *cli.Context) error {
+	client, cleanUp := getInvoicesClient(ctx)
+	defer cleanUp()
+
+	req := &invoicesrpc.ListPendingApprovalsRequest{}
+	resp, err := client.ListPendingApprovals(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
@@ -304,42 +304,74 @@ func main() {
 		sendManyCommand,
 		sendCoinsCommand,
 		listUnspentCommand,
+		importWatchOnlyCommand,
+		listWatchOnlyCommand,
 		connectCommand,
 		disconnectCommand,
 		openChannelCommand,
+		estimateOpenChannelCommand,
+		describeChannelConstraintsCommand,
+		listQueuedOpensCommand,
+		cancelQueuedOpenCommand,
 		closeChannelCommand,
 		closeAllChannelsCommand,
+		previewForceCloseCommand,
+		pinCommitFeeCommand,
 		abandonChannelCommand,
+		upgradeChannelCommand,
 		listPeersCommand,
+		sendCustomCommand,
+		subscribeCustomCommand,
+		peerFilterCommand,
 		walletBalanceCommand,
 		channelBalanceCommand,
 		getInfoCommand,
 		getRecoveryInfoCommand,
+		getHealthCommand,
 		pendingChannelsCommand,
+		pendingReservationsCommand,
+		cancelReservationCommand,
 		sendPaymentCommand,
 		payInvoiceCommand,
 		sendToRouteCommand,
 		addInvoiceCommand,
 		lookupInvoiceCommand,
+		regenerateInvoiceCommand,
 		listInvoicesCommand,
 		listChannelsCommand,
 		closedChannelsCommand,
+		channelUptimeCommand,
+		leastReliablePeersCommand,
+		listMonitorEventsCommand,
+		registerSwapHookCommand,
 		listPaymentsCommand,
+		prunePaymentsCommand,
 		describeGraphCommand,
 		getNodeMetricsCommand,
 		getChanInfoCommand,
 		getNodeInfoCommand,
 		queryRoutesCommand,
 		getNetworkInfoCommand,
+		pruneGraphCommand,
 		debugLevelCommand,
+		subsystemLevelsCommand,
 		decodePayReqCommand,
+		resolveLnurlCommand,
 		listChainTxnsCommand,
 		stopCommand,
+		drainCommand,
+		reloadConfigCommand,
+		rotateTLSCertCommand,
 		signMessageCommand,
 		verifyMessageCommand,
 		feeReportCommand,
 		updateChannelPolicyCommand,
+		policyTemplateCommand,
+		policyHistoryCommand,
 		forwardingHistoryCommand,
+		listOpenAttemptsCommand,
+		setPendingChanLimitCommand,
+		exportChanEventsCommand,
 		exportChanBackupCommand,
 		verifyChanBackupCommand,
 		restoreChanBackupCommand,
@@ -8,6 +8,11 @@ func routerCommands() []cli.Command {
 		queryMissionControlCommand,
 		queryProbCommand,
 		resetMissionControlCommand,
+		setMissionControlOverrideCommand,
+		clearMissionControlOverrideCommand,
 		buildRouteCommand,
+		probeRouteCommand,
+		failPaymentCommand,
+		subscribeHtlcEventsCommand,
 	}
 }
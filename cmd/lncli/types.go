@@ -51,6 +51,7 @@ type Utxo struct {
 	PkScript      string            `json:"pk_script"`
 	OutPoint      OutPoint          `json:"outpoint"`
 	Confirmations int64             `json:"confirmations"`
+	Account       string            `json:"account"`
 }
 
 // NewUtxoFromProto creates a display Utxo from the Utxo proto. This filters out
@@ -64,5 +65,6 @@ func NewUtxoFromProto(utxo *lnrpc.Utxo) *Utxo {
 		PkScript:      utxo.PkScript,
 		OutPoint:      NewOutPointFromProto(utxo.Outpoint),
 		Confirmations: utxo.Confirmations,
+		Account:       utxo.Account,
 	}
 }
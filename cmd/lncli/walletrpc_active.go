@@ -9,7 +9,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"sort"
+	"strconv"
 
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/cryptomeow/lnd/lnrpc"
@@ -29,6 +31,29 @@ var (
 			finalizePsbtCommand,
 		},
 	}
+
+	// accountsCommand is a wallet subcommand that is responsible for
+	// account management operations.
+	accountsCommand = cli.Command{
+		Name:  "accounts",
+		Usage: "Interact with wallet accounts.",
+		Subcommands: []cli.Command{
+			listAccountsCommand,
+			createAccountCommand,
+		},
+	}
+
+	// keyFamiliesCommand is a wallet subcommand that is responsible for
+	// managing the key family ranges reserved by external applications.
+	keyFamiliesCommand = cli.Command{
+		Name:  "keyfamilies",
+		Usage: "Reserve key families for external applications.",
+		Subcommands: []cli.Command{
+			listKeyFamiliesCommand,
+			reserveKeyFamiliesCommand,
+			releaseKeyFamiliesCommand,
+		},
+	}
 )
 
 // walletCommands will return the set of commands to enable for walletrpc
@@ -46,8 +71,16 @@ func walletCommands() []cli.Command {
 				bumpCloseFeeCommand,
 				listSweepsCommand,
 				labelTxCommand,
+				leaseOutputCommand,
 				releaseOutputCommand,
+				listLeasesCommand,
+				labelOutputCommand,
+				freezeOutputCommand,
+				unfreezeOutputCommand,
 				psbtCommand,
+				accountsCommand,
+				rescanCommand,
+				keyFamiliesCommand,
 			},
 		},
 	}
@@ -448,7 +481,9 @@ var fundPsbtCommand = cli.Command{
 	returned.
 
 	After either selecting or verifying the inputs, all input UTXOs are
-	locked with an internal app ID.
+	locked with an internal app ID, or with the ID given by the --lease_id
+	flag. Coins are selected from, and change is sent to, the --account
+	unless a --change_address is given.
 
 	The 'outputs' flag decodes addresses and the amount to send respectively
 	in the following JSON format:
@@ -489,6 +524,22 @@ var fundPsbtCommand = cli.Command{
 			Usage: "a manual fee expressed in sat/vbyte that " +
 				"should be used when creating the transaction",
 		},
+		cli.StringFlag{
+			Name: "account",
+			Usage: "(optional) the name of the wallet account to " +
+				"select coins from and send change to, the " +
+				"default account is used if not set",
+		},
+		cli.StringFlag{
+			Name: "change_address",
+			Usage: "(optional) an address to send the change to " +
+				"instead of a new change address of the wallet",
+		},
+		cli.StringFlag{
+			Name: "lease_id",
+			Usage: "(optional) the hex encoded 32 byte ID to lock " +
+				"the inputs with instead of the internal ID",
+		},
 	},
 	Action: actionDecorator(fundPsbt),
 }
@@ -591,6 +642,16 @@ func fundPsbt(ctx *cli.Context) error {
 		}
 	}
 
+	req.Account = ctx.String("account")
+	req.ChangeAddress = ctx.String("change_address")
+	if ctx.IsSet("lease_id") {
+		leaseID, err := hex.DecodeString(ctx.String("lease_id"))
+		if err != nil {
+			return fmt.Errorf("error parsing lease ID: %v", err)
+		}
+		req.LeaseId = leaseID
+	}
+
 	walletClient, cleanUp := getWalletClient(ctx)
 	defer cleanUp()
 
@@ -695,6 +756,97 @@ func finalizePsbt(ctx *cli.Context) error {
 	return nil
 }
 
+// parseLockID parses the hex encoded lock ID of an output lease, falling back
+// to lnd's internal lock ID if none is given.
+func parseLockID(lockIDStr string) ([]byte, error) {
+	if lockIDStr == "" {
+		return walletrpc.LndInternalLockID[:], nil
+	}
+
+	lockID, err := hex.DecodeString(lockIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing lock ID: %v", err)
+	}
+	if len(lockID) != 32 {
+		return nil, fmt.Errorf("lock ID must be 32 bytes")
+	}
+
+	return lockID, nil
+}
+
+var leaseOutputCommand = cli.Command{
+	Name:      "leaseoutput",
+	Usage:     "Lock an output to exclude it from coin selection.",
+	ArgsUsage: "outpoint",
+	Description: `
+	The leaseoutput command locks an unspent output to the given lock ID,
+	excluding it from coin selection until the lease expires or the output
+	is released with the releaseoutput command. Leases are persisted across
+	restarts. Leasing an output again with the same lock ID changes the
+	expiration of its lease.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "outpoint",
+			Usage: "the output to lock",
+		},
+		cli.StringFlag{
+			Name: "lockid",
+			Usage: "the hex encoded 32 byte ID to lock the output " +
+				"to, the internal lnd lock ID is used if not set",
+		},
+		cli.Uint64Flag{
+			Name: "expiry",
+			Usage: "the number of seconds to lock the output for, " +
+				"the default of 10 minutes is used if not set",
+		},
+	},
+	Action: actionDecorator(leaseOutput),
+}
+
+func leaseOutput(ctx *cli.Context) error {
+	var (
+		args        = ctx.Args()
+		outpointStr string
+	)
+	switch {
+	case ctx.IsSet("outpoint"):
+		outpointStr = ctx.String("outpoint")
+	case args.Present():
+		outpointStr = args.First()
+	default:
+		return cli.ShowCommandHelp(ctx, "leaseoutput")
+	}
+
+	outpoint, err := NewProtoOutPoint(outpointStr)
+	if err != nil {
+		return fmt.Errorf("error parsing outpoint: %v", err)
+	}
+
+	lockID, err := parseLockID(ctx.String("lockid"))
+	if err != nil {
+		return err
+	}
+
+	req := &walletrpc.LeaseOutputRequest{
+		Outpoint:          outpoint,
+		Id:                lockID,
+		ExpirationSeconds: ctx.Uint64("expiry"),
+	}
+
+	walletClient, cleanUp := getWalletClient(ctx)
+	defer cleanUp()
+
+	response, err := walletClient.LeaseOutput(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(response)
+
+	return nil
+}
+
 var releaseOutputCommand = cli.Command{
 	Name:      "releaseoutput",
 	Usage:     "Release an output previously locked by lnd.",
@@ -703,26 +855,25 @@ var releaseOutputCommand = cli.Command{
 	The releaseoutput command unlocks an output, allowing it to be available
 	for coin selection if it remains unspent.
 
-	The internal lnd app lock ID is used when releasing the output.
-	Therefore only UTXOs locked by the fundpsbt command can currently be
-	released with this command.
+	The internal lnd app lock ID is used when releasing the output, unless
+	the lock ID the output was leased to is given.
 	`,
 	Flags: []cli.Flag{
 		cli.StringFlag{
 			Name:  "outpoint",
 			Usage: "the output to unlock",
 		},
+		cli.StringFlag{
+			Name: "lockid",
+			Usage: "the hex encoded 32 byte ID the output was " +
+				"locked to, the internal lnd lock ID is used " +
+				"if not set",
+		},
 	},
 	Action: actionDecorator(releaseOutput),
 }
 
 func releaseOutput(ctx *cli.Context) error {
-	// Display the command's help message if we do not have the expected
-	// number of arguments/flags.
-	if ctx.NArg() != 1 && ctx.NumFlags() != 1 {
-		return cli.ShowCommandHelp(ctx, "releaseoutput")
-	}
-
 	var (
 		args        = ctx.Args()
 		outpointStr string
@@ -733,16 +884,22 @@ func releaseOutput(ctx *cli.Context) error {
 	case args.Present():
 		outpointStr = args.First()
 	default:
-		return fmt.Errorf("outpoint argument missing")
+		return cli.ShowCommandHelp(ctx, "releaseoutput")
 	}
 
 	outpoint, err := NewProtoOutPoint(outpointStr)
 	if err != nil {
 		return fmt.Errorf("error parsing outpoint: %v", err)
 	}
+
+	lockID, err := parseLockID(ctx.String("lockid"))
+	if err != nil {
+		return err
+	}
+
 	req := &walletrpc.ReleaseOutputRequest{
 		Outpoint: outpoint,
-		Id:       walletrpc.LndInternalLockID[:],
+		Id:       lockID,
 	}
 
 	walletClient, cleanUp := getWalletClient(ctx)
@@ -757,3 +914,391 @@ func releaseOutput(ctx *cli.Context) error {
 
 	return nil
 }
+
+var listLeasesCommand = cli.Command{
+	Name:  "listleases",
+	Usage: "List all currently leased outputs.",
+	Description: `
+	The listleases command lists all outputs that are currently locked,
+	together with the lock ID they're leased to and the unix timestamp at
+	which their lease expires.
+	`,
+	Action: actionDecorator(listLeases),
+}
+
+func listLeases(ctx *cli.Context) error {
+	walletClient, cleanUp := getWalletClient(ctx)
+	defer cleanUp()
+
+	response, err := walletClient.ListLeases(
+		context.Background(), &walletrpc.ListLeasesRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(response)
+
+	return nil
+}
+
+var labelOutputCommand = cli.Command{
+	Name:      "labeloutput",
+	Usage:     "Add a label to an unspent output.",
+	ArgsUsage: "outpoint label",
+	Description: `
+	Add a label to an unspent output controlled by the wallet. If the output
+	already has a label, this call will fail unless the overwrite option is
+	set. The label is limited to 500 characters and is shown by the
+	listunspent command. Note that multi word labels must be contained in
+	quotation marks ("").
+	`,
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "overwrite",
+			Usage: "set to overwrite existing labels",
+		},
+	},
+	Action: actionDecorator(labelOutput),
+}
+
+func labelOutput(ctx *cli.Context) error {
+	// Display the command's help message if we do not have the expected
+	// number of arguments/flags.
+	if ctx.NArg() != 2 {
+		return cli.ShowCommandHelp(ctx, "labeloutput")
+	}
+
+	outpointStr := ctx.Args().Get(0)
+	outpoint, err := NewProtoOutPoint(outpointStr)
+	if err != nil {
+		return fmt.Errorf("error parsing outpoint: %v", err)
+	}
+
+	label := ctx.Args().Get(1)
+
+	walletClient, cleanUp := getWalletClient(ctx)
+	defer cleanUp()
+
+	_, err = walletClient.LabelOutput(
+		context.Background(), &walletrpc.LabelOutputRequest{
+			Outpoint:  outpoint,
+			Label:     label,
+			Overwrite: ctx.Bool("overwrite"),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Output: %v labelled with: %v\n", outpointStr, label)
+
+	return nil
+}
+
+var freezeOutputCommand = cli.Command{
+	Name:      "freezeoutput",
+	Usage:     "Exclude an unspent output from coin selection.",
+	ArgsUsage: "outpoint",
+	Description: `
+	The freezeoutput command freezes an unspent output controlled by the
+	wallet. Frozen outputs are never used for channel fundings, sweeps or
+	any other coin selection performed by lnd until they're unfrozen again
+	with the unfreezeoutput command. Freezes persist across restarts.
+	`,
+	Action: actionDecorator(freezeOutput),
+}
+
+func freezeOutput(ctx *cli.Context) error {
+	// Display the command's help message if we do not have the expected
+	// number of arguments/flags.
+	if ctx.NArg() != 1 {
+		return cli.ShowCommandHelp(ctx, "freezeoutput")
+	}
+
+	outpoint, err := NewProtoOutPoint(ctx.Args().First())
+	if err != nil {
+		return fmt.Errorf("error parsing outpoint: %v", err)
+	}
+
+	walletClient, cleanUp := getWalletClient(ctx)
+	defer cleanUp()
+
+	response, err := walletClient.FreezeOutput(
+		context.Background(), &walletrpc.FreezeOutputRequest{
+			Outpoint: outpoint,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(response)
+
+	return nil
+}
+
+var unfreezeOutputCommand = cli.Command{
+	Name:      "unfreezeoutput",
+	Usage:     "Make a frozen output available for coin selection again.",
+	ArgsUsage: "outpoint",
+	Description: `
+	The unfreezeoutput command unfreezes an output that was previously
+	frozen with the freezeoutput command.
+	`,
+	Action: actionDecorator(unfreezeOutput),
+}
+
+func unfreezeOutput(ctx *cli.Context) error {
+	// Display the command's help message if we do not have the expected
+	// number of arguments/flags.
+	if ctx.NArg() != 1 {
+		return cli.ShowCommandHelp(ctx, "unfreezeoutput")
+	}
+
+	outpoint, err := NewProtoOutPoint(ctx.Args().First())
+	if err != nil {
+		return fmt.Errorf("error parsing outpoint: %v", err)
+	}
+
+	walletClient, cleanUp := getWalletClient(ctx)
+	defer cleanUp()
+
+	response, err := walletClient.UnfreezeOutput(
+		context.Background(), &walletrpc.UnfreezeOutputRequest{
+			Outpoint: outpoint,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(response)
+
+	return nil
+}
+
+var listAccountsCommand = cli.Command{
+	Name:  "list",
+	Usage: "List all accounts of the wallet.",
+	Description: `
+	The list command lists all accounts of the wallet together with the
+	number of external and internal keys that were derived from each of
+	them.
+	`,
+	Action: actionDecorator(listAccounts),
+}
+
+func listAccounts(ctx *cli.Context) error {
+	walletClient, cleanUp := getWalletClient(ctx)
+	defer cleanUp()
+
+	response, err := walletClient.ListAccounts(
+		context.Background(), &walletrpc.ListAccountsRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(response)
+
+	return nil
+}
+
+var createAccountCommand = cli.Command{
+	Name:      "create",
+	Usage:     "Create a new wallet account.",
+	ArgsUsage: "name",
+	Description: `
+	The create command creates a new account with the given name. The
+	account can then be used to scope wallet balances, new addresses, sends
+	and channel funding by passing its name to the respective commands.
+	`,
+	Action: actionDecorator(createAccount),
+}
+
+func createAccount(ctx *cli.Context) error {
+	// Display the command's help message if we do not have the expected
+	// number of arguments/flags.
+	if ctx.NArg() != 1 {
+		return cli.ShowCommandHelp(ctx, "create")
+	}
+
+	walletClient, cleanUp := getWalletClient(ctx)
+	defer cleanUp()
+
+	response, err := walletClient.CreateAccount(
+		context.Background(), &walletrpc.CreateAccountRequest{
+			Name: ctx.Args().First(),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(response)
+
+	return nil
+}
+
+var rescanCommand = cli.Command{
+	Name:  "rescan",
+	Usage: "Scan the chain for transactions relevant to the wallet.",
+	Flags: []cli.Flag{
+		cli.Int64Flag{
+			Name:  "start_height",
+			Usage: "the height of the first block to scan",
+		},
+	},
+	Description: `
+	The rescan command scans the chain for transactions relevant to the
+	wallet, starting at the given height, and prints the progress of the
+	scan. This is useful after restoring a wallet whose birthday is far in
+	the past. Interrupting the command cancels the scan. Once all blocks
+	were scanned, the relevant transactions are added to the wallet.
+	`,
+	Action: actionDecorator(rescan),
+}
+
+func rescan(ctx *cli.Context) error {
+	if !ctx.IsSet("start_height") {
+		return cli.ShowCommandHelp(ctx, "rescan")
+	}
+
+	walletClient, cleanUp := getWalletClient(ctx)
+	defer cleanUp()
+
+	stream, err := walletClient.Rescan(
+		context.Background(), &walletrpc.RescanRequest{
+			StartHeight: int32(ctx.Int64("start_height")),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	for {
+		update, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		printRespJSON(update)
+	}
+}
+
+var listKeyFamiliesCommand = cli.Command{
+	Name:  "list",
+	Usage: "List all reserved key family ranges.",
+	Description: `
+	The list command lists the key family ranges reserved by external
+	applications, along with the first key family that can be reserved.
+	`,
+	Action: actionDecorator(listKeyFamilies),
+}
+
+func listKeyFamilies(ctx *cli.Context) error {
+	walletClient, cleanUp := getWalletClient(ctx)
+	defer cleanUp()
+
+	response, err := walletClient.ListKeyFamilyReservations(
+		context.Background(),
+		&walletrpc.ListKeyFamilyReservationsRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(response)
+
+	return nil
+}
+
+var reserveKeyFamiliesCommand = cli.Command{
+	Name:      "reserve",
+	Usage:     "Reserve a range of key families.",
+	ArgsUsage: "owner first_key_family last_key_family",
+	Description: `
+	The reserve command reserves the inclusive range of key families for
+	the exclusive use of the application with the given name, so keys it
+	derives through the signer and wallet RPCs don't collide with those of
+	lnd or other applications. The reservation is stored in the wallet
+	database. Reserving the exact range an owner already holds has no
+	effect.
+	`,
+	Action: actionDecorator(reserveKeyFamilies),
+}
+
+func reserveKeyFamilies(ctx *cli.Context) error {
+	// Display the command's help message if we do not have the expected
+	// number of arguments/flags.
+	if ctx.NArg() != 3 {
+		return cli.ShowCommandHelp(ctx, "reserve")
+	}
+
+	args := ctx.Args()
+	first, err := strconv.ParseInt(args.Get(1), 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid first key family: %v", err)
+	}
+	last, err := strconv.ParseInt(args.Get(2), 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid last key family: %v", err)
+	}
+
+	walletClient, cleanUp := getWalletClient(ctx)
+	defer cleanUp()
+
+	response, err := walletClient.ReserveKeyFamilies(
+		context.Background(), &walletrpc.ReserveKeyFamiliesRequest{
+			Owner:          args.First(),
+			FirstKeyFamily: int32(first),
+			LastKeyFamily:  int32(last),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(response)
+
+	return nil
+}
+
+var releaseKeyFamiliesCommand = cli.Command{
+	Name:      "release",
+	Usage:     "Release the key families reserved by an owner.",
+	ArgsUsage: "owner",
+	Description: `
+	The release command releases the key family range reserved by the
+	application with the given name, allowing it to be reserved again.
+	`,
+	Action: actionDecorator(releaseKeyFamilies),
+}
+
+func releaseKeyFamilies(ctx *cli.Context) error {
+	// Display the command's help message if we do not have the expected
+	// number of arguments/flags.
+	if ctx.NArg() != 1 {
+		return cli.ShowCommandHelp(ctx, "release")
+	}
+
+	walletClient, cleanUp := getWalletClient(ctx)
+	defer cleanUp()
+
+	response, err := walletClient.ReleaseKeyFamilies(
+		context.Background(), &walletrpc.ReleaseKeyFamiliesRequest{
+			Owner: ctx.Args().First(),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(response)
+
+	return nil
+}
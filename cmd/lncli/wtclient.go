@@ -25,6 +25,7 @@ func wtclientCommands() []cli.Command {
 				getTowerCommand,
 				statsCommand,
 				policyCommand,
+				candidatesCommand,
 			},
 		},
 	}
@@ -247,22 +248,36 @@ func stats(ctx *cli.Context) error {
 }
 
 var policyCommand = cli.Command{
-	Name:   "policy",
-	Usage:  "Display the active watchtower client policy configuration.",
+	Name:  "policy",
+	Usage: "Display the active watchtower client policy configuration.",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name: "anchor",
+			Usage: "display the policy of the client backing up " +
+				"channels with anchor outputs",
+		},
+	},
 	Action: actionDecorator(policy),
 }
 
 func policy(ctx *cli.Context) error {
 	// Display the command's help message if the number of arguments/flags
 	// is not what we expect.
-	if ctx.NArg() > 0 || ctx.NumFlags() > 0 {
+	if ctx.NArg() > 0 || ctx.NumFlags() > 1 {
 		return cli.ShowCommandHelp(ctx, "policy")
 	}
 
+	policyType := wtclientrpc.PolicyType_LEGACY
+	if ctx.Bool("anchor") {
+		policyType = wtclientrpc.PolicyType_ANCHOR
+	}
+
 	client, cleanUp := getWtclient(ctx)
 	defer cleanUp()
 
-	req := &wtclientrpc.PolicyRequest{}
+	req := &wtclientrpc.PolicyRequest{
+		PolicyType: policyType,
+	}
 	resp, err := client.Policy(context.Background(), req)
 	if err != nil {
 		return err
@@ -271,3 +286,33 @@ func policy(ctx *cli.Context) error {
 	printRespJSON(resp)
 	return nil
 }
+
+var candidatesCommand = cli.Command{
+	Name:  "candidates",
+	Usage: "Display the public watchtowers advertised in the graph.",
+	Description: "Lists the public altruist watchtowers discovered in " +
+		"the channel graph that accept sessions of the client's " +
+		"policy and haven't been registered yet. A candidate is " +
+		"only used once it's registered with `lncli wtclient add`.",
+	Action: actionDecorator(candidates),
+}
+
+func candidates(ctx *cli.Context) error {
+	// Display the command's help message if the number of arguments/flags
+	// is not what we expect.
+	if ctx.NArg() > 0 || ctx.NumFlags() > 0 {
+		return cli.ShowCommandHelp(ctx, "candidates")
+	}
+
+	client, cleanUp := getWtclient(ctx)
+	defer cleanUp()
+
+	req := &wtclientrpc.ListTowerCandidatesRequest{}
+	resp, err := client.ListTowerCandidates(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
@@ -5,6 +5,7 @@
 package lnd
 
 import (
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -18,6 +19,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcutil"
 	flags "github.com/jessevdk/go-flags"
 	"github.com/lightninglabs/neutrino"
@@ -26,34 +28,44 @@ import (
 	"github.com/cryptomeow/lnd/chainreg"
 	"github.com/cryptomeow/lnd/chanbackup"
 	"github.com/cryptomeow/lnd/channeldb"
+	"github.com/cryptomeow/lnd/contractcourt"
 	"github.com/cryptomeow/lnd/discovery"
 	"github.com/cryptomeow/lnd/htlcswitch"
 	"github.com/cryptomeow/lnd/htlcswitch/hodl"
 	"github.com/cryptomeow/lnd/input"
+	"github.com/cryptomeow/lnd/invoices"
 	"github.com/cryptomeow/lnd/lncfg"
+	"github.com/cryptomeow/lnd/lnrpc"
 	"github.com/cryptomeow/lnd/lnrpc/routerrpc"
 	"github.com/cryptomeow/lnd/lnrpc/signrpc"
+	"github.com/cryptomeow/lnd/lnwallet"
+	"github.com/cryptomeow/lnd/lnwallet/chainfee"
+	"github.com/cryptomeow/lnd/lnwallet/chanfunding"
+	"github.com/cryptomeow/lnd/lnwire"
 	"github.com/cryptomeow/lnd/routing"
+	"github.com/cryptomeow/lnd/routing/route"
 	"github.com/cryptomeow/lnd/tor"
+	"github.com/cryptomeow/lnd/watchtower"
 )
 
 const (
-	defaultDataDirname        = "data"
-	defaultChainSubDirname    = "chain"
-	defaultGraphSubDirname    = "graph"
-	defaultTowerSubDirname    = "watchtower"
-	defaultTLSCertFilename    = "tls.cert"
-	defaultTLSKeyFilename     = "tls.key"
-	defaultAdminMacFilename   = "admin.macaroon"
-	defaultReadMacFilename    = "readonly.macaroon"
-	defaultInvoiceMacFilename = "invoice.macaroon"
-	defaultLogLevel           = "info"
-	defaultLogDirname         = "logs"
-	defaultLogFilename        = "lnd.log"
-	defaultRPCPort            = 10009
-	defaultRESTPort           = 8080
-	defaultPeerPort           = 9735
-	defaultRPCHost            = "localhost"
+	defaultDataDirname         = "data"
+	defaultChainSubDirname     = "chain"
+	defaultGraphSubDirname     = "graph"
+	defaultTowerSubDirname     = "watchtower"
+	defaultTLSCertFilename     = "tls.cert"
+	defaultTLSKeyFilename      = "tls.key"
+	defaultAdminMacFilename    = "admin.macaroon"
+	defaultReadMacFilename     = "readonly.macaroon"
+	defaultInvoiceMacFilename  = "invoice.macaroon"
+	defaultObserverMacFilename = "observer.macaroon"
+	defaultLogLevel            = "info"
+	defaultLogDirname          = "logs"
+	defaultLogFilename         = "lnd.log"
+	defaultRPCPort             = 10009
+	defaultRESTPort            = 8080
+	defaultPeerPort            = 9735
+	defaultRPCHost             = "localhost"
 
 	defaultNoSeedBackup                  = false
 	defaultPaymentsExpirationGracePeriod = time.Duration(0)
@@ -76,14 +88,19 @@ const (
 	defaultTorV2PrivateKeyFilename = "v2_onion_private_key"
 	defaultTorV3PrivateKeyFilename = "v3_onion_private_key"
 
-	// minTimeLockDelta is the minimum timelock we require for incoming
-	// HTLCs on our channels.
-	minTimeLockDelta = routing.MinCLTVDelta
-
 	// defaultAcceptorTimeout is the time after which an RPCAcceptor will time
 	// out and return false if it hasn't yet received a response.
 	defaultAcceptorTimeout = 15 * time.Second
 
+	// defaultTLSRotateBefore is how long before its expiry the self-signed
+	// TLS certificate is rotated.
+	defaultTLSRotateBefore = 30 * 24 * time.Hour
+
+	// acceptorDefaultAccept and acceptorDefaultReject are the verdicts an
+	// RPCAcceptor can fall back to if it doesn't receive a response.
+	acceptorDefaultAccept = "accept"
+	acceptorDefaultReject = "reject"
+
 	defaultAlias = ""
 	defaultColor = "#3399FF"
 
@@ -108,15 +125,69 @@ const (
 	defaultDiskBackoff  = time.Minute
 	defaultDiskAttempts = 0
 
+	// Set defaults for a health check which ensures that the Tor
+	// controller used for our onion services is reachable. This check is
+	// only run if lnd manages onion services through the Tor controller.
+	defaultTorInterval = time.Minute
+	defaultTorTimeout  = time.Second * 5
+	defaultTorBackoff  = time.Second * 30
+	defaultTorAttempts = 3
+
+	// Set defaults for the health checks which ensure that configured
+	// remote services are reachable. These checks are only run for the
+	// addresses that are explicitly configured.
+	defaultRemoteInterval = time.Minute
+	defaultRemoteTimeout  = time.Second * 5
+	defaultRemoteBackoff  = time.Second * 30
+	defaultRemoteAttempts = 3
+
 	// defaultRemoteMaxHtlcs specifies the default limit for maximum
 	// concurrent HTLCs the remote party may add to commitment transactions.
 	// This value can be overridden with --default-remote-max-htlcs.
 	defaultRemoteMaxHtlcs = 483
 
+	// defaultReservationTimeout is the default length of idle time after
+	// which a pending channel reservation is released.
+	defaultReservationTimeout = 10 * time.Minute
+
+	// defaultFundingLockedAlertThreshold is the default number of failed
+	// attempts to send the fundingLocked message of a channel after which
+	// an event is sent to alert the operator.
+	defaultFundingLockedAlertThreshold = 10
+
+	// maxChanAnnouncementDelay is the maximum number of blocks the
+	// announcement of a channel can be delayed by, either randomly or by
+	// batching announcements. 2016 blocks is ~2 weeks.
+	maxChanAnnouncementDelay = 2016
+
+	// defaultCoinSelectionStrategy is the default strategy used to order
+	// the wallet's coins during channel funding coin selection.
+	defaultCoinSelectionStrategy = "largest"
+
+	// defaultHeldHtlcLimitPolicy is the default policy applied to htlcs
+	// that would exceed one of the held htlc limits.
+	defaultHeldHtlcLimitPolicy = "reject"
+
+	// defaultFeeURLType is the default format of the external fee
+	// estimation API that is queried when --feeurl is set.
+	defaultFeeURLType = chainfee.SparseConfEstimatorType
+
+	// defaultFeeURLMaxRate is the default highest fee rate in sat/vbyte we
+	// accept from an external fee estimation API.
+	defaultFeeURLMaxRate = 1000
+
 	// defaultMaxLocalCSVDelay is the maximum delay we accept on our
 	// commitment output.
 	// TODO(halseth): find a more scientific choice of value.
 	defaultMaxLocalCSVDelay = 10000
+
+	// defaultMinChanConfs is the default number of confirmations we
+	// require for small incoming channels.
+	defaultMinChanConfs = 3
+
+	// defaultMaxChanConfs is the default number of confirmations we
+	// require for large incoming channels.
+	defaultMaxChanConfs = 6
 )
 
 var (
@@ -181,18 +252,26 @@ type Config struct {
 	TLSAutoRefresh     bool     `long:"tlsautorefresh" description:"Re-generate TLS certificate and key if the IPs or domains are changed"`
 	TLSDisableAutofill bool     `long:"tlsdisableautofill" description:"Do not include the interface IPs or the system hostname in TLS certificate, use first --tlsextradomain as Common Name instead, if set"`
 
+	TLSRotateBefore time.Duration `long:"tlsrotatebefore" description:"Re-generate the TLS certificate and key this long before the certificate expires, without restarting lnd. Set to 0 to disable the automatic rotation."`
+
+	TLSExternalCert bool `long:"tlsexternalcert" description:"The TLS certificate and key at tlscertpath and tlskeypath are provided externally, for example issued by a certificate authority. They are never generated, refreshed or rotated by lnd, and are reloaded without a restart whenever the files change."`
+
 	NoMacaroons     bool          `long:"no-macaroons" description:"Disable macaroon authentication, can only be used if server is not listening on a public interface."`
 	AdminMacPath    string        `long:"adminmacaroonpath" description:"Path to write the admin macaroon for lnd's RPC and REST services if it doesn't exist"`
 	ReadMacPath     string        `long:"readonlymacaroonpath" description:"Path to write the read-only macaroon for lnd's RPC and REST services if it doesn't exist"`
 	InvoiceMacPath  string        `long:"invoicemacaroonpath" description:"Path to the invoice-only macaroon for lnd's RPC and REST services if it doesn't exist"`
+	ObserverMacPath string        `long:"observermacaroonpath" description:"Path to write the observer macaroon for lnd's RPC and REST services if it doesn't exist. The observer macaroon can only call RPCs that don't modify the state of the daemon"`
 	LogDir          string        `long:"logdir" description:"Directory to log output."`
 	MaxLogFiles     int           `long:"maxlogfiles" description:"Maximum logfiles to keep (0 for no rotation)"`
 	MaxLogFileSize  int           `long:"maxlogfilesize" description:"Maximum logfile size in MB"`
-	AcceptorTimeout time.Duration `long:"acceptortimeout" description:"Time after which an RPCAcceptor will time out and return false if it hasn't yet received a response"`
+	AcceptorTimeout time.Duration `long:"acceptortimeout" description:"Time after which an RPCAcceptor will time out and return the default verdict if it hasn't yet received a response"`
+	AcceptorDefault string        `long:"acceptordefault" description:"The verdict an RPCAcceptor returns for an inbound channel if its client doesn't respond within the acceptor timeout or disconnects before responding" choice:"reject" choice:"accept"`
 
-	LetsEncryptDir    string `long:"letsencryptdir" description:"The directory to store Let's Encrypt certificates within"`
-	LetsEncryptListen string `long:"letsencryptlisten" description:"The IP:port on which lnd will listen for Let's Encrypt challenges. Let's Encrypt will always try to contact on port 80. Often non-root processes are not allowed to bind to ports lower than 1024. This configuration option allows a different port to be used, but must be used in combination with port forwarding from port 80. This configuration can also be used to specify another IP address to listen on, for example an IPv6 address."`
-	LetsEncryptDomain string `long:"letsencryptdomain" description:"Request a Let's Encrypt certificate for this domain. Note that the certicate is only requested and stored when the first rpc connection comes in."`
+	LetsEncryptDir     string `long:"letsencryptdir" description:"The directory to store Let's Encrypt certificates within"`
+	LetsEncryptListen  string `long:"letsencryptlisten" description:"The IP:port on which lnd will listen for Let's Encrypt challenges. Let's Encrypt will always try to contact on port 80. Often non-root processes are not allowed to bind to ports lower than 1024. This configuration option allows a different port to be used, but must be used in combination with port forwarding from port 80. This configuration can also be used to specify another IP address to listen on, for example an IPv6 address."`
+	LetsEncryptDomain  string `long:"letsencryptdomain" description:"Request a Let's Encrypt certificate for this domain. Note that the certicate is only requested and stored when the first rpc connection comes in."`
+	LetsEncryptACMEURL string `long:"letsencryptacmeurl" description:"The directory URL of the ACME server to request the certificate from. Defaults to the production server of Let's Encrypt, but any ACME certificate authority can be used."`
+	LetsEncryptDNSHook string `long:"letsencryptdnshook" description:"The path to an executable that publishes DNS TXT records. If set, the certificate is requested at startup through the DNS-01 challenge instead of HTTP-01, so lnd doesn't need to be reachable on port 80. It is called with the arguments present or cleanup, the record name and the record value, and must only exit after presenting once the record can be resolved."`
 
 	// We'll parse these 'raw' string arguments into real net.Addrs in the
 	// loadConfig function. We need to expose the 'raw' strings so the
@@ -210,6 +289,8 @@ type Config struct {
 	ExternalIPs       []net.Addr
 	DisableListen     bool          `long:"nolisten" description:"Disable listening for incoming peer connections"`
 	DisableRest       bool          `long:"norest" description:"Disable REST API"`
+	WSPingInterval    time.Duration `long:"ws-ping-interval" description:"The ping interval for REST based WebSocket connections, set to 0 to disable sending ping messages from the server side. Valid time units are {s, m, h}."`
+	WSPongWait        time.Duration `long:"ws-pong-wait" description:"The time we wait for a pong response message on REST based WebSocket connections before the connection is closed as inactive. Valid time units are {s, m, h}."`
 	NAT               bool          `long:"nat" description:"Toggle NAT traversal support (using either UPnP or NAT-PMP) to automatically advertise your external IP address to the network -- NOTE this does not support devices behind multiple NATs"`
 	MinBackoff        time.Duration `long:"minbackoff" description:"Shortest backoff when reconnecting to persistent peers. Valid time units are {s, m, h}."`
 	MaxBackoff        time.Duration `long:"maxbackoff" description:"Longest backoff when reconnecting to persistent peers. Valid time units are {s, m, h}."`
@@ -226,7 +307,12 @@ type Config struct {
 	MaxPendingChannels int    `long:"maxpendingchannels" description:"The maximum number of incoming pending channels permitted per peer."`
 	BackupFilePath     string `long:"backupfilepath" description:"The target location of the channel backup file"`
 
-	FeeURL string `long:"feeurl" description:"Optional URL for external fee estimation. If no URL is specified, the method for fee estimation will depend on the chosen backend and network."`
+	FeeURL        string `long:"feeurl" description:"Optional URL for external fee estimation. If no URL is specified, the method for fee estimation will depend on the chosen backend and network."`
+	FeeURLType    string `long:"feeurltype" description:"The response format of the external fee estimation API set with --feeurl. One of {sparseconf, mempool}."`
+	FeeURLMaxRate uint64 `long:"feeurlmaxrate" description:"The highest fee rate in sat/vbyte accepted from the external fee estimation API. Higher estimates are clamped to this value. Set to 0 to disable."`
+
+	MaxFundingFeeRate      uint64        `long:"maxfundingfeerate" description:"The highest estimated chain fee rate in sat/vbyte at which we open channels. Channel opens are rejected while the estimate is above it. Set to 0 to disable."`
+	FundingFeeRetryTimeout time.Duration `long:"fundingfeeretrytimeout" description:"If set, channel opens that are rejected because of maxfundingfeerate are instead deferred for up to this duration, and retried automatically once the fee estimate drops below the maximum."`
 
 	Bitcoin      *lncfg.Chain    `group:"Bitcoin" namespace:"bitcoin"`
 	BtcdMode     *lncfg.Btcd     `group:"btcd" namespace:"btcd"`
@@ -247,6 +333,10 @@ type Config struct {
 
 	NoNetBootstrap bool `long:"nobootstrap" description:"If true, then automatic network bootstrapping will not be attempted."`
 
+	MonitorOnly bool `long:"monitoronly" description:"If true, the channel state is only loaded read-only to monitor the chain for breaches and closes of the channels, e.g. as a standby for another active node sharing a replicated channel database. The wallet isn't unlocked, no peer connections are made, nothing is signed or broadcast, and only the RPCs querying the channels and the detected closes and controlling the daemon are served. Requires monitoronlypasswordfile to be set."`
+
+	MonitorOnlyPasswordFile string `long:"monitoronlypasswordfile" description:"The path to a file containing the wallet password, which the macaroons are encrypted with. As the wallet isn't unlocked in monitor-only mode, it is read from this file to unlock the macaroon database."`
+
 	NoSeedBackup bool `long:"noseedbackup" description:"If true, NO SEED WILL BE EXPOSED -- EVER, AND THE WALLET WILL BE ENCRYPTED USING THE DEFAULT PASSPHRASE. THIS FLAG IS ONLY FOR TESTING AND SHOULD NEVER BE USED ON MAINNET."`
 
 	PaymentsExpirationGracePeriod time.Duration `long:"payments-expiration-grace-period" description:"A period to wait before force closing channels with outgoing htlcs that have timed-out and are a result of this node initiated payments."`
@@ -259,6 +349,10 @@ type Config struct {
 	Color                         string        `long:"color" description:"The color of the node in hex format (i.e. '#3399FF'). Used to customize node appearance in intelligence services"`
 	MinChanSize                   int64         `long:"minchansize" description:"The smallest channel size (in satoshis) that we should accept. Incoming channels smaller than this will be rejected"`
 	MaxChanSize                   int64         `long:"maxchansize" description:"The largest channel size (in satoshis) that we should accept. Incoming channels larger than this will be rejected"`
+	ReservationTimeout            time.Duration `long:"reservationtimeout" description:"The length of idle time after which a pending channel reservation of a channel we initiated is considered a zombie and gets released."`
+	RemoteReservationTimeout      time.Duration `long:"remotereservationtimeout" description:"The length of idle time after which a pending channel reservation of a channel the remote peer initiated is considered a zombie and gets released. If not set, reservationtimeout is used."`
+	PsbtReservationTimeout        time.Duration `long:"psbtreservationtimeout" description:"The length of idle time after which a pending channel reservation of a PSBT funded channel is considered a zombie and gets released. If not set, PSBT funded reservations are never released."`
+	FundingLockedAlertThreshold   uint32        `long:"fundinglockedalertthreshold" description:"The number of failed attempts to send the funding_locked message of a newly confirmed channel after which a channel event is sent, to alert about peers that never complete the channel establishment. Set to 0 to disable the alert."`
 
 	DefaultRemoteMaxHtlcs uint16 `long:"default-remote-max-htlcs" description:"The default max_htlc applied when opening or accepting channels. This value limits the number of concurrent HTLCs that the remote party can add to the commitment. The maximum possible value is 483."`
 
@@ -269,14 +363,40 @@ type Config struct {
 
 	RejectPush bool `long:"rejectpush" description:"If true, lnd will not accept channel opening requests with non-zero push amounts. This should prevent accidental pushes to merchant nodes."`
 
+	MaxChanAnnouncementDelay uint32 `long:"maxchanannouncementdelay" description:"The maximum number of blocks by which the announcement of a new public channel is delayed beyond its 6 confirmations. The actual delay is chosen randomly per channel, to reduce the timing correlation between funding and announcement. Set to 0 to announce channels as soon as possible."`
+	ChanAnnouncementBatch    uint32 `long:"chanannouncementbatch" description:"If set, new public channels are only announced at block heights that are a multiple of this value, so that channels funded around the same time are announced together."`
+
+	TrustedPeers []string `long:"trustedpeer" description:"The hex encoded identity pubkey of a trusted peer. Inbound channels from trusted peers only require a single confirmation, regardless of the channel size. A channel acceptor can still override the number of confirmations. Can be specified multiple times."`
+
+	PeerMaxPendingChannels      []string `long:"peermaxpendingchannels" description:"Overrides maxpendingchannels for a single peer, specified as <pubkey>:<max>. A maximum of 0 doesn't limit the number of pending channels of the peer. Can be specified multiple times."`
+	TrustedPeerUnlimitedPending bool     `long:"trustedpeerunlimitedpending" description:"If true, the number of incoming pending channels of trusted peers isn't limited by maxpendingchannels."`
+
 	RejectHTLC bool `long:"rejecthtlc" description:"If true, lnd will not forward any HTLCs that are meant as onward payments. This option will still allow lnd to send HTLCs and receive HTLCs but lnd won't be used as a hop."`
 
+	MaxDustExposure btcutil.Amount `long:"max-dust-exposure" description:"The maximum total amount in satoshis of dust HTLCs on either commitment transaction of a channel. Dust HTLCs don't have an output on the commitment, so their value goes to miners if the channel is force closed. New dust HTLCs, incoming or outgoing, that would exceed this amount are failed. Set to 0 to disable the limit."`
+
+	ReservedOutgoingHtlcSlots uint16 `long:"reserved-outgoing-htlc-slots" description:"The number of HTLC slots of each channel that forwarded HTLCs may not occupy, so they remain available for our own payments. Forwards over a channel are failed once fewer slots than this are left. Set to 0 to let forwards use all slots."`
+
+	ResolveOrphanedCircuits bool `long:"resolve-orphaned-circuits" description:"If true, the payment circuits found to be orphaned on startup are resolved: circuits whose incoming HTLC is already resolved are removed, and HTLCs forwarded over a channel that is now fully closed are settled or failed back upstream. Otherwise orphaned circuits are only logged."`
+
+	HtlcHoldAlarm time.Duration `long:"htlc-hold-alarm" description:"If non-zero, an alarm is raised for incoming HTLCs that are held on one of our channels for longer than this duration, as they occupy commitment slots and may be part of a channel jamming attempt. The alarm is logged and emitted as an HTLC event. Must be at least 1m. Set to 0 to disable the alarm."`
+
+	AutoFailHeldHtlcs bool `long:"htlc-hold-alarm-autofail" description:"If true, HTLCs paying to one of our invoices are canceled back once the hold alarm is raised for them. Only HTLCs of invoices that are still open, such as the parts of an incomplete multi-path payment, can be canceled. HTLCs of accepted hold invoices and forwarded HTLCs are never canceled."`
+
 	StaggerInitialReconnect bool `long:"stagger-initial-reconnect" description:"If true, will apply a randomized staggering between 0s and 30s when reconnecting to persistent peers on startup. The first 10 reconnections will be attempted instantly, regardless of the flag's value"`
 
 	MaxOutgoingCltvExpiry uint32 `long:"max-cltv-expiry" description:"The maximum number of blocks funds could be locked up for when forwarding payments."`
 
+	CoinSelectionStrategy string `long:"coin-selection-strategy" description:"The default strategy used to order the wallet's coins when funding a channel, unless overridden by the open channel request. One of {largest, random, oldest}."`
+
 	MaxChannelFeeAllocation float64 `long:"max-channel-fee-allocation" description:"The maximum percentage of total funds that can be allocated to a channel's commitment fee. This only applies for the initiator of the channel. Valid values are within [0.1, 1]."`
 
+	CommitFeeBufferMultiplier uint32 `long:"commit-fee-buffer-multiplier" description:"For channels we initiated, the multiple of the current commitment fee rate that must remain affordable. New outgoing HTLCs that would leave too little balance to pay the commitment fee at this rate are rejected, so that there's always headroom for a fee update. Set to 1 to disable the fee buffer."`
+
+	AnchorCPFPBudget int64 `long:"anchor-cpfp-budget" description:"DEPRECATED: Use sweeper.budget.anchor instead. The maximum fee in satoshis that will be paid to bump a force closed anchor commitment with HTLCs at risk via its anchor output, including commitments broadcast by the remote party. The anchor is swept with a fee rate targeting the expiry of the first HTLC at risk, capped by this budget. A value of 0 means the fee is only limited by the sweeper's maximum fee rate."`
+
+	LnurlResolve bool `long:"lnurl-resolve" description:"If true, the ResolveLnurlPay RPC may contact LNURL servers to resolve LNURL-pay targets and lightning addresses to payment requests on behalf of RPC clients. The requests are routed over Tor if Tor is active. Without Tor, servers that resolve to loopback, link-local or private addresses are refused. Otherwise lnd never contacts LNURL servers."`
+
 	DryRunMigration bool `long:"dry-run-migration" description:"If true, lnd will abort committing a migration if it would otherwise have been successful. This leaves the database unmodified, and still compatible with the previously active version of lnd."`
 
 	net tor.Net
@@ -291,12 +411,36 @@ type Config struct {
 
 	GcCanceledInvoicesOnTheFly bool `long:"gc-canceled-invoices-on-the-fly" description:"If true, we'll delete newly canceled invoices on the fly."`
 
+	MaxHeldHtlcsPerInvoice uint32 `long:"max-held-htlcs-per-invoice" description:"The maximum number of htlcs that are held on a single invoice at the same time, such as the parts of an incomplete multi-path payment or payments to a hold invoice. Set to 0 to disable the limit."`
+
+	MaxHeldHtlcs uint32 `long:"max-held-htlcs" description:"The maximum number of htlcs that are held across all invoices at the same time. Set to 0 to disable the limit."`
+
+	HeldHtlcLimitPolicy string `long:"held-htlc-limit-policy" description:"What to do with a new htlc that would exceed one of the held htlc limits. 'reject' fails the new htlc, 'cancel-oldest' cancels the oldest held htlc of an invoice that is still open to make room for it, and only fails the new htlc if there is none. One of {reject, cancel-oldest}."`
+
 	Routing *lncfg.Routing `group:"routing" namespace:"routing"`
 
+	Payments *lncfg.Payments `group:"payments" namespace:"payments"`
+
 	Workers *lncfg.Workers `group:"workers" namespace:"workers"`
 
 	Caches *lncfg.Caches `group:"caches" namespace:"caches"`
 
+	CloseAddress *lncfg.CloseAddress `group:"closeaddress" namespace:"closeaddress"`
+
+	Sweeper *lncfg.Sweeper `group:"sweeper" namespace:"sweeper"`
+
+	Webhook *lncfg.Webhook `group:"webhook" namespace:"webhook"`
+
+	Broadcast *lncfg.Broadcast `group:"broadcast" namespace:"broadcast"`
+
+	Ping *lncfg.Ping `group:"ping" namespace:"ping"`
+
+	RemoteFee *lncfg.RemoteFee `group:"remotefee" namespace:"remotefee"`
+
+	Bootstrap *lncfg.Bootstrap `group:"bootstrap" namespace:"bootstrap"`
+
+	PeerFilter *lncfg.PeerFilter `group:"peerfilter" namespace:"peerfilter"`
+
 	Prometheus lncfg.Prometheus `group:"prometheus" namespace:"prometheus"`
 
 	WtClient *lncfg.WtClient `group:"wtclient" namespace:"wtclient"`
@@ -326,6 +470,18 @@ type Config struct {
 
 	// ActiveNetParams contains parameters of the target chain.
 	ActiveNetParams chainreg.BitcoinNetParams
+
+	// coinSelectionStrategy is the parsed form of CoinSelectionStrategy.
+	coinSelectionStrategy chanfunding.CoinSelectionStrategy
+
+	// heldHtlcLimitPolicy is the parsed form of HeldHtlcLimitPolicy.
+	heldHtlcLimitPolicy invoices.HeldHtlcLimitPolicy
+
+	// trustedPeers is the parsed set of TrustedPeers.
+	trustedPeers map[route.Vertex]struct{}
+
+	// peerMaxPendingChannels is the parsed set of PeerMaxPendingChannels.
+	peerMaxPendingChannels map[route.Vertex]int
 }
 
 // DefaultConfig returns all default values for the Config struct.
@@ -337,12 +493,16 @@ func DefaultConfig() Config {
 		DebugLevel:        defaultLogLevel,
 		TLSCertPath:       defaultTLSCertPath,
 		TLSKeyPath:        defaultTLSKeyPath,
+		TLSRotateBefore:   defaultTLSRotateBefore,
 		LetsEncryptDir:    defaultLetsEncryptDir,
 		LetsEncryptListen: defaultLetsEncryptListen,
 		LogDir:            defaultLogDir,
 		MaxLogFiles:       defaultMaxLogFiles,
 		MaxLogFileSize:    defaultMaxLogFileSize,
 		AcceptorTimeout:   defaultAcceptorTimeout,
+		AcceptorDefault:   acceptorDefaultReject,
+		WSPingInterval:    lnrpc.DefaultPingInterval,
+		WSPongWait:        lnrpc.DefaultPongWait,
 		Bitcoin: &lncfg.Chain{
 			MinHTLCIn:     chainreg.DefaultBitcoinMinHTLCInMSat,
 			MinHTLCOut:    chainreg.DefaultBitcoinMinHTLCOutMSat,
@@ -350,6 +510,8 @@ func DefaultConfig() Config {
 			FeeRate:       chainreg.DefaultBitcoinFeeRate,
 			TimeLockDelta: chainreg.DefaultBitcoinTimeLockDelta,
 			MaxLocalDelay: defaultMaxLocalCSVDelay,
+			MinChanConfs:  defaultMinChanConfs,
+			MaxChanConfs:  defaultMaxChanConfs,
 			Node:          "btcd",
 		},
 		BtcdMode: &lncfg.Btcd{
@@ -369,6 +531,8 @@ func DefaultConfig() Config {
 			FeeRate:       chainreg.DefaultLitecoinFeeRate,
 			TimeLockDelta: chainreg.DefaultLitecoinTimeLockDelta,
 			MaxLocalDelay: defaultMaxLocalCSVDelay,
+			MinChanConfs:  defaultMinChanConfs,
+			MaxChanConfs:  defaultMaxChanConfs,
 			Node:          "ltcd",
 		},
 		LtcdMode: &lncfg.Btcd{
@@ -416,6 +580,8 @@ func DefaultConfig() Config {
 		Color:                         defaultColor,
 		MinChanSize:                   int64(minChanFundingSize),
 		MaxChanSize:                   int64(0),
+		ReservationTimeout:            defaultReservationTimeout,
+		FundingLockedAlertThreshold:   defaultFundingLockedAlertThreshold,
 		DefaultRemoteMaxHtlcs:         defaultRemoteMaxHtlcs,
 		NumGraphSyncPeers:             defaultMinPeers,
 		HistoricalSyncInterval:        discovery.DefaultHistoricalSyncInterval,
@@ -426,15 +592,43 @@ func DefaultConfig() Config {
 		},
 		net: &tor.ClearNet{},
 		Workers: &lncfg.Workers{
-			Read:  lncfg.DefaultReadWorkers,
-			Write: lncfg.DefaultWriteWorkers,
-			Sig:   lncfg.DefaultSigWorkers,
+			Read:       lncfg.DefaultReadWorkers,
+			Write:      lncfg.DefaultWriteWorkers,
+			Sig:        lncfg.DefaultSigWorkers,
+			Validation: routing.DefaultNumValidationWorkers,
 		},
 		Caches: &lncfg.Caches{
 			RejectCacheSize:  channeldb.DefaultRejectCacheSize,
 			ChannelCacheSize: channeldb.DefaultChannelCacheSize,
 		},
+		CloseAddress: &lncfg.CloseAddress{},
+		Sweeper: &lncfg.Sweeper{
+			Budget: contractcourt.DefaultBudgetConfig(),
+		},
+		Webhook: &lncfg.Webhook{
+			MaxRetries: lncfg.DefaultWebhookMaxRetries,
+			RetryDelay: lncfg.DefaultWebhookRetryDelay,
+			Timeout:    lncfg.DefaultWebhookTimeout,
+		},
+		Broadcast: &lncfg.Broadcast{
+			Timeout: lncfg.DefaultBroadcastTimeout,
+		},
+		Ping: &lncfg.Ping{
+			Interval:          lncfg.DefaultPingInterval,
+			MaxMissed:         lncfg.DefaultMaxMissedPings,
+			WriteStallTimeout: lncfg.DefaultWriteStallTimeout,
+		},
+		RemoteFee:  &lncfg.RemoteFee{},
+		Bootstrap:  &lncfg.Bootstrap{},
+		PeerFilter: &lncfg.PeerFilter{},
 		Prometheus: lncfg.DefaultPrometheus(),
+		Routing: &lncfg.Routing{
+			ChannelPruneExpiry: routing.DefaultChannelPruneExpiry,
+			GraphPruneInterval: lncfg.DefaultGraphPruneInterval,
+		},
+		Payments: &lncfg.Payments{
+			PruneInterval: lncfg.DefaultPaymentPruneInterval,
+		},
 		Watchtower: &lncfg.Watchtower{
 			TowerDir: defaultTowerDir,
 		},
@@ -454,13 +648,35 @@ func DefaultConfig() Config {
 					Backoff:  defaultDiskBackoff,
 				},
 			},
+			TorConnection: &lncfg.CheckConfig{
+				Interval: defaultTorInterval,
+				Timeout:  defaultTorTimeout,
+				Attempts: defaultTorAttempts,
+				Backoff:  defaultTorBackoff,
+			},
+			RemoteCheck: &lncfg.RemoteCheckConfig{
+				CheckConfig: &lncfg.CheckConfig{
+					Interval: defaultRemoteInterval,
+					Timeout:  defaultRemoteTimeout,
+					Attempts: defaultRemoteAttempts,
+					Backoff:  defaultRemoteBackoff,
+				},
+			},
 		},
-		MaxOutgoingCltvExpiry:   htlcswitch.DefaultMaxOutgoingCltvExpiry,
-		MaxChannelFeeAllocation: htlcswitch.DefaultMaxLinkFeeAllocation,
-		LogWriter:               build.NewRotatingLogWriter(),
-		DB:                      lncfg.DefaultDB(),
-		registeredChains:        chainreg.NewChainRegistry(),
-		ActiveNetParams:         chainreg.BitcoinTestNetParams,
+		MaxOutgoingCltvExpiry:     htlcswitch.DefaultMaxOutgoingCltvExpiry,
+		MaxDustExposure:           htlcswitch.DefaultMaxDustExposure,
+		MaxChannelFeeAllocation:   htlcswitch.DefaultMaxLinkFeeAllocation,
+		CommitFeeBufferMultiplier: lnwallet.DefaultFeeBufferMultiplier,
+		CoinSelectionStrategy:     defaultCoinSelectionStrategy,
+		MaxHeldHtlcsPerInvoice:    invoices.DefaultMaxHeldHtlcsPerInvoice,
+		MaxHeldHtlcs:              invoices.DefaultMaxHeldHtlcs,
+		HeldHtlcLimitPolicy:       defaultHeldHtlcLimitPolicy,
+		FeeURLType:                defaultFeeURLType,
+		FeeURLMaxRate:             defaultFeeURLMaxRate,
+		LogWriter:                 build.NewRotatingLogWriter(),
+		DB:                        lncfg.DefaultDB(),
+		registeredChains:          chainreg.NewChainRegistry(),
+		ActiveNetParams:           chainreg.BitcoinTestNetParams,
 	}
 }
 
@@ -490,24 +706,10 @@ func LoadConfig() (*Config, error) {
 		os.Exit(0)
 	}
 
-	// If the config file path has not been modified by the user, then we'll
-	// use the default config file path. However, if the user has modified
-	// their lnddir, then we should assume they intend to use the config
-	// file within it.
-	configFileDir := CleanAndExpandPath(preCfg.LndDir)
-	configFilePath := CleanAndExpandPath(preCfg.ConfigFile)
-	if configFileDir != DefaultLndDir {
-		if configFilePath == DefaultConfigFile {
-			configFilePath = filepath.Join(
-				configFileDir, lncfg.DefaultConfigFilename,
-			)
-		}
-	}
-
 	// Next, load any additional configuration options from the file.
 	var configFileError error
 	cfg := preCfg
-	if err := flags.IniParse(configFilePath, &cfg); err != nil {
+	if err := flags.IniParse(preCfg.configFilePath(), &cfg); err != nil {
 		// If it's a parsing related error, then we'll return
 		// immediately, otherwise we can proceed as possibly the config
 		// file doesn't exist which is OK.
@@ -540,6 +742,67 @@ func LoadConfig() (*Config, error) {
 	return cleanCfg, nil
 }
 
+// ReloadConfig re-reads the configuration file and the given command line
+// arguments of the running daemon and returns the resulting configuration. Only
+// the options that can be updated at runtime are validated, so callers must not
+// use any other option of the returned config.
+func ReloadConfig(activeCfg *Config, args []string) (*Config, error) {
+	cfg := DefaultConfig()
+	cfg.net = activeCfg.net
+
+	// Unlike on startup, the config file must exist and be readable, as
+	// we'd otherwise silently revert all options to their defaults.
+	if err := flags.IniParse(activeCfg.configFilePath(), &cfg); err != nil {
+		return nil, err
+	}
+
+	// The command line options still take precedence over the ones from
+	// the config file.
+	parser := flags.NewParser(&cfg, flags.Default)
+	if _, err := parser.ParseArgs(args); err != nil {
+		return nil, err
+	}
+
+	if err := validateFundingLimits(&cfg); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case activeCfg.Litecoin.Active:
+		chainPolicy := chainreg.ChainPolicies[chainreg.LitecoinChain]
+		err := cfg.Litecoin.Validate(chainPolicy.MinRemoteDelay)
+		if err != nil {
+			return nil, err
+		}
+
+	default:
+		chainPolicy := chainreg.ChainPolicies[chainreg.BitcoinChain]
+		err := cfg.Bitcoin.Validate(chainPolicy.MinRemoteDelay)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// The active chain can't be changed at runtime, so we validate the
+	// CLTV deltas against the block interval of the active one.
+	cfg.ActiveNetParams = activeCfg.ActiveNetParams
+	if err := validateCltvDeltas(&cfg); err != nil {
+		return nil, err
+	}
+
+	if _, err := parseTowerAddrs(&cfg); err != nil {
+		return nil, err
+	}
+
+	// Likewise, make sure the consolidation addresses of the sweeper are
+	// valid addresses of the active chain.
+	if _, err := parseConsolidationAddrs(&cfg); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
 // ValidateConfig check the given configuration to be sane. This makes sure no
 // illegal values or combination of values are set. All file system paths are
 // normalized. The cleaned up config is returned on success.
@@ -596,9 +859,14 @@ func ValidateConfig(cfg Config, usageMessage string) (*Config, error) {
 	cfg.TLSCertPath = CleanAndExpandPath(cfg.TLSCertPath)
 	cfg.TLSKeyPath = CleanAndExpandPath(cfg.TLSKeyPath)
 	cfg.LetsEncryptDir = CleanAndExpandPath(cfg.LetsEncryptDir)
+	cfg.LetsEncryptDNSHook = CleanAndExpandPath(cfg.LetsEncryptDNSHook)
 	cfg.AdminMacPath = CleanAndExpandPath(cfg.AdminMacPath)
 	cfg.ReadMacPath = CleanAndExpandPath(cfg.ReadMacPath)
 	cfg.InvoiceMacPath = CleanAndExpandPath(cfg.InvoiceMacPath)
+	cfg.ObserverMacPath = CleanAndExpandPath(cfg.ObserverMacPath)
+	cfg.MonitorOnlyPasswordFile = CleanAndExpandPath(
+		cfg.MonitorOnlyPasswordFile,
+	)
 	cfg.LogDir = CleanAndExpandPath(cfg.LogDir)
 	cfg.BtcdMode.Dir = CleanAndExpandPath(cfg.BtcdMode.Dir)
 	cfg.LtcdMode.Dir = CleanAndExpandPath(cfg.LtcdMode.Dir)
@@ -607,6 +875,7 @@ func ValidateConfig(cfg Config, usageMessage string) (*Config, error) {
 	cfg.Tor.PrivateKeyPath = CleanAndExpandPath(cfg.Tor.PrivateKeyPath)
 	cfg.Tor.WatchtowerKeyPath = CleanAndExpandPath(cfg.Tor.WatchtowerKeyPath)
 	cfg.Watchtower.TowerDir = CleanAndExpandPath(cfg.Watchtower.TowerDir)
+	cfg.PeerFilter.ASNFile = CleanAndExpandPath(cfg.PeerFilter.ASNFile)
 
 	// Create the lnd directory and all other sub directories if they don't
 	// already exist. This makes sure that directory trees are also created
@@ -616,7 +885,7 @@ func ValidateConfig(cfg Config, usageMessage string) (*Config, error) {
 		cfg.LetsEncryptDir, cfg.Watchtower.TowerDir,
 		filepath.Dir(cfg.TLSCertPath), filepath.Dir(cfg.TLSKeyPath),
 		filepath.Dir(cfg.AdminMacPath), filepath.Dir(cfg.ReadMacPath),
-		filepath.Dir(cfg.InvoiceMacPath),
+		filepath.Dir(cfg.InvoiceMacPath), filepath.Dir(cfg.ObserverMacPath),
 		filepath.Dir(cfg.Tor.PrivateKeyPath),
 		filepath.Dir(cfg.Tor.WatchtowerKeyPath),
 	}
@@ -678,36 +947,8 @@ func ValidateConfig(cfg Config, usageMessage string) (*Config, error) {
 		return nil, err
 	}
 
-	// Ensure that --maxchansize is properly handled when set by user.
-	// For non-Wumbo channels this limit remains 16777215 satoshis by default
-	// as specified in BOLT-02. For wumbo channels this limit is 1,000,000,000.
-	// satoshis (10 BTC). Always enforce --maxchansize explicitly set by user.
-	// If unset (marked by 0 value), then enforce proper default.
-	if cfg.MaxChanSize == 0 {
-		if cfg.ProtocolOptions.Wumbo() {
-			cfg.MaxChanSize = int64(MaxBtcFundingAmountWumbo)
-		} else {
-			cfg.MaxChanSize = int64(MaxBtcFundingAmount)
-		}
-	}
-
-	// Ensure that the user specified values for the min and max channel
-	// size make sense.
-	if cfg.MaxChanSize < cfg.MinChanSize {
-		return nil, fmt.Errorf("invalid channel size parameters: "+
-			"max channel size %v, must be no less than min chan size %v",
-			cfg.MaxChanSize, cfg.MinChanSize,
-		)
-	}
-
-	// Don't allow superflous --maxchansize greater than
-	// BOLT 02 soft-limit for non-wumbo channel
-	if !cfg.ProtocolOptions.Wumbo() && cfg.MaxChanSize > int64(MaxFundingAmount) {
-		return nil, fmt.Errorf("invalid channel size parameters: "+
-			"maximum channel size %v is greater than maximum non-wumbo"+
-			" channel size %v",
-			cfg.MaxChanSize, MaxFundingAmount,
-		)
+	if err := validateFundingLimits(&cfg); err != nil {
+		return nil, err
 	}
 
 	// Ensure a valid max channel fee allocation was set.
@@ -717,6 +958,118 @@ func ValidateConfig(cfg Config, usageMessage string) (*Config, error) {
 			cfg.MaxChannelFeeAllocation)
 	}
 
+	// The acceptor timeout must be positive, otherwise all inbound
+	// channels would immediately receive the default verdict.
+	if cfg.AcceptorTimeout <= 0 {
+		return nil, fmt.Errorf("invalid acceptor timeout: %v, must be "+
+			"positive", cfg.AcceptorTimeout)
+	}
+
+	// The deprecated anchor CPFP budget is used as the anchor budget of
+	// the sweeper, unless one is set already.
+	if cfg.AnchorCPFPBudget != 0 {
+		budget := cfg.Sweeper.Budget
+		if budget.Anchor != 0 || budget.AnchorRatio != 0 {
+			return nil, fmt.Errorf("anchor-cpfp-budget can't be " +
+				"combined with an anchor budget of the sweeper")
+		}
+
+		budget.Anchor = btcutil.Amount(cfg.AnchorCPFPBudget)
+	}
+
+	// Ensure the sweep budgets of force closed channels are sane.
+	if err := cfg.Sweeper.Budget.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Ensure a known coin selection strategy was set.
+	coinStrategy, err := chanfunding.ParseCoinSelectionStrategy(
+		cfg.CoinSelectionStrategy,
+	)
+	if err != nil {
+		return nil, err
+	}
+	cfg.coinSelectionStrategy = coinStrategy
+
+	// Parse the identity keys of all trusted peers.
+	cfg.trustedPeers = make(
+		map[route.Vertex]struct{}, len(cfg.TrustedPeers),
+	)
+	for _, trustedPeer := range cfg.TrustedPeers {
+		pubKey, err := route.NewVertexFromStr(trustedPeer)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted peer %v: %v",
+				trustedPeer, err)
+		}
+		cfg.trustedPeers[pubKey] = struct{}{}
+	}
+
+	// Parse the per peer overrides of the maximum number of pending
+	// channels.
+	cfg.peerMaxPendingChannels = make(
+		map[route.Vertex]int, len(cfg.PeerMaxPendingChannels),
+	)
+	for _, override := range cfg.PeerMaxPendingChannels {
+		parts := strings.Split(override, ":")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid peermaxpendingchannels "+
+				"%v: expected <pubkey>:<max>", override)
+		}
+
+		pubKey, err := route.NewVertexFromStr(parts[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid peermaxpendingchannels "+
+				"%v: %v", override, err)
+		}
+
+		maxPending, err := strconv.Atoi(parts[1])
+		if err != nil || maxPending < 0 {
+			return nil, fmt.Errorf("invalid peermaxpendingchannels "+
+				"%v: max must be a non-negative integer",
+				override)
+		}
+		cfg.peerMaxPendingChannels[pubKey] = maxPending
+	}
+
+	// Ensure a known held htlc limit policy was set.
+	heldHtlcPolicy, err := invoices.ParseHeldHtlcLimitPolicy(
+		cfg.HeldHtlcLimitPolicy,
+	)
+	if err != nil {
+		return nil, err
+	}
+	cfg.heldHtlcLimitPolicy = heldHtlcPolicy
+
+	// Ensure that forwards can still use some of the htlc slots of a
+	// channel.
+	if cfg.ReservedOutgoingHtlcSlots >= input.MaxHTLCNumber/2 {
+		return nil, fmt.Errorf("reserved-outgoing-htlc-slots must be "+
+			"below %v", input.MaxHTLCNumber/2)
+	}
+
+	// Ensure the htlc hold alarm isn't raised for htlcs that are only held
+	// briefly while they're forwarded or while a mpp set completes.
+	if cfg.HtlcHoldAlarm != 0 &&
+		cfg.HtlcHoldAlarm < htlcswitch.MinHtlcHoldAlarm {
+
+		return nil, fmt.Errorf("htlc-hold-alarm must be at least %v",
+			htlcswitch.MinHtlcHoldAlarm)
+	}
+
+	// Ensure the external fee estimation API is of a known type.
+	var knownFeeURLType bool
+	for _, estimatorType := range chainfee.SupportedEstimators() {
+		if cfg.FeeURLType == estimatorType {
+			knownFeeURLType = true
+			break
+		}
+	}
+	if !knownFeeURLType {
+		return nil, fmt.Errorf("unknown fee url type: %v, must be "+
+			"one of: %v", cfg.FeeURLType,
+			strings.Join(chainfee.SupportedEstimators(), ", "))
+	}
+
 	// Validate the Tor config parameters.
 	socks, err := lncfg.ParseAddressString(
 		cfg.Tor.SOCKS, strconv.Itoa(defaultTorSOCKSPort),
@@ -757,6 +1110,36 @@ func ValidateConfig(cfg Config, usageMessage string) (*Config, error) {
 		return nil, fmt.Errorf(str, funcName)
 	}
 
+	// In monitor-only mode, the node must not act on the channels or
+	// connect to any peers, so none of the subsystems that do may be
+	// enabled.
+	if cfg.MonitorOnly {
+		switch {
+		case cfg.Autopilot.Active:
+			return nil, errors.New("autopilot can't be active in " +
+				"monitor-only mode")
+		case cfg.Watchtower.Active:
+			return nil, errors.New("watchtower can't be active in " +
+				"monitor-only mode")
+		case cfg.WtClient.Active:
+			return nil, errors.New("watchtower client can't be " +
+				"active in monitor-only mode")
+
+		// The RPC server must remain authenticated, so the macaroons
+		// are unlocked with the wallet password read from a file, as
+		// the wallet isn't unlocked.
+		case cfg.NoMacaroons:
+			return nil, errors.New("no-macaroons can't be set in " +
+				"monitor-only mode")
+		case cfg.MonitorOnlyPasswordFile == "":
+			return nil, errors.New("monitoronlypasswordfile must " +
+				"be set in monitor-only mode")
+		}
+
+		cfg.DisableListen = true
+		cfg.NoNetBootstrap = true
+	}
+
 	switch {
 	case cfg.Tor.V2 && cfg.Tor.V3:
 		return nil, errors.New("either tor.v2 or tor.v3 can be set, " +
@@ -829,7 +1212,8 @@ func ValidateConfig(cfg Config, usageMessage string) (*Config, error) {
 			"litecoin.active must be set to 1 (true)", funcName)
 
 	case cfg.Litecoin.Active:
-		err := cfg.Litecoin.Validate(minTimeLockDelta, minLtcRemoteDelay)
+		chainPolicy := chainreg.ChainPolicies[chainreg.LitecoinChain]
+		err := cfg.Litecoin.Validate(chainPolicy.MinRemoteDelay)
 		if err != nil {
 			return nil, err
 		}
@@ -913,7 +1297,7 @@ func ValidateConfig(cfg Config, usageMessage string) (*Config, error) {
 		// Finally we'll register the litecoin chain as our current
 		// primary chain.
 		cfg.registeredChains.RegisterPrimaryChain(chainreg.LitecoinChain)
-		MaxFundingAmount = maxLtcFundingAmount
+		MaxFundingAmount = chainPolicy.MaxFundingAmount
 
 	case cfg.Bitcoin.Active:
 		// Multiple networks can't be selected simultaneously.  Count
@@ -936,10 +1320,31 @@ func ValidateConfig(cfg Config, usageMessage string) (*Config, error) {
 			numNets++
 			cfg.ActiveNetParams = chainreg.BitcoinSimNetParams
 		}
+		if cfg.Bitcoin.SigNet {
+			numNets++
+			cfg.ActiveNetParams = chainreg.BitcoinSigNetParams
+
+			// A custom challenge defines a different signet
+			// network.
+			if cfg.Bitcoin.SigNetChallenge != "" {
+				challenge, err := hex.DecodeString(
+					cfg.Bitcoin.SigNetChallenge,
+				)
+				if err != nil || len(challenge) == 0 {
+					return nil, fmt.Errorf("%s: invalid "+
+						"signet challenge %q", funcName,
+						cfg.Bitcoin.SigNetChallenge)
+				}
+
+				cfg.ActiveNetParams = chainreg.NewBitcoinSigNetParams(
+					challenge,
+				)
+			}
+		}
 		if numNets > 1 {
-			str := "%s: The mainnet, testnet, regtest, and " +
+			str := "%s: The mainnet, testnet, regtest, signet and " +
 				"simnet params can't be used together -- " +
-				"choose one of the four"
+				"choose one of the five"
 			err := fmt.Errorf(str, funcName)
 			return nil, err
 		}
@@ -948,19 +1353,32 @@ func ValidateConfig(cfg Config, usageMessage string) (*Config, error) {
 		// know how to initialize the daemon.
 		if numNets == 0 {
 			str := "%s: either --bitcoin.mainnet, or " +
-				"bitcoin.testnet, bitcoin.simnet, or bitcoin.regtest " +
-				"must be specified"
+				"bitcoin.testnet, bitcoin.simnet, bitcoin.regtest " +
+				"or bitcoin.signet must be specified"
+			err := fmt.Errorf(str, funcName)
+			return nil, err
+		}
+
+		if cfg.Bitcoin.SigNetChallenge != "" && !cfg.Bitcoin.SigNet {
+			str := "%s: bitcoin.signetchallenge can only be " +
+				"used with bitcoin.signet"
 			err := fmt.Errorf(str, funcName)
 			return nil, err
 		}
 
-		err := cfg.Bitcoin.Validate(minTimeLockDelta, minBtcRemoteDelay)
+		chainPolicy := chainreg.ChainPolicies[chainreg.BitcoinChain]
+		err := cfg.Bitcoin.Validate(chainPolicy.MinRemoteDelay)
 		if err != nil {
 			return nil, err
 		}
 
 		switch cfg.Bitcoin.Node {
 		case "btcd":
+			if cfg.Bitcoin.SigNet {
+				return nil, fmt.Errorf("%s: btcd does not "+
+					"support signet", funcName)
+			}
+
 			err := parseRPCParams(
 				cfg.Bitcoin, cfg.BtcdMode, chainreg.BitcoinChain, funcName,
 				cfg.ActiveNetParams,
@@ -1003,6 +1421,12 @@ func ValidateConfig(cfg Config, usageMessage string) (*Config, error) {
 		cfg.registeredChains.RegisterPrimaryChain(chainreg.BitcoinChain)
 	}
 
+	// With the active chain known, we can validate our CLTV deltas against
+	// its block interval.
+	if err := validateCltvDeltas(&cfg); err != nil {
+		return nil, fmt.Errorf("%s: %v", funcName, err)
+	}
+
 	// Ensure that the user didn't attempt to specify negative values for
 	// any of the autopilot params.
 	if cfg.Autopilot.MaxChannels < 0 {
@@ -1077,6 +1501,11 @@ func ValidateConfig(cfg Config, usageMessage string) (*Config, error) {
 			cfg.networkDir, defaultInvoiceMacFilename,
 		)
 	}
+	if cfg.ObserverMacPath == "" {
+		cfg.ObserverMacPath = filepath.Join(
+			cfg.networkDir, defaultObserverMacFilename,
+		)
+	}
 
 	// Similarly, if a custom back up file path wasn't specified, then
 	// we'll update the file location to match our set network directory.
@@ -1259,6 +1688,13 @@ func ValidateConfig(cfg Config, usageMessage string) (*Config, error) {
 			maxRemoteHtlcs)
 	}
 
+	// A watchtower can only be published in our node announcement if it
+	// is active.
+	if cfg.Watchtower.Publish && !cfg.Watchtower.Active {
+		return nil, fmt.Errorf("watchtower.publish requires " +
+			"watchtower.active")
+	}
+
 	// Validate the subconfigs for workers, caches, and the tower client.
 	err = lncfg.Validate(
 		cfg.Workers,
@@ -1266,11 +1702,31 @@ func ValidateConfig(cfg Config, usageMessage string) (*Config, error) {
 		cfg.WtClient,
 		cfg.DB,
 		cfg.HealthChecks,
+		cfg.Webhook,
+		cfg.Broadcast,
+		cfg.Ping,
+		cfg.RemoteFee,
+		cfg.Bootstrap,
+		cfg.PeerFilter,
+		cfg.Routing,
+		cfg.Payments,
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	// Transactions are only announced to P2P nodes through Tor, so the
+	// broadcast doesn't reveal our IP address as their origin.
+	if len(cfg.Broadcast.Peers) != 0 && !cfg.Tor.Active {
+		return nil, errors.New("broadcast.peer requires tor.active")
+	}
+
+	// Make sure the statically configured watchtowers can be parsed, so
+	// we don't fail only once the tower client is started.
+	if _, err := parseTowerAddrs(&cfg); err != nil {
+		return nil, err
+	}
+
 	// Finally, ensure that the user's color is correctly formatted,
 	// otherwise the server will not be able to start after the unlocking
 	// the wallet.
@@ -1283,6 +1739,180 @@ func ValidateConfig(cfg Config, usageMessage string) (*Config, error) {
 	return &cfg, err
 }
 
+// validateCltvDeltas validates the CLTV delta of the active chain, which is
+// used both for forwarding and as the final CLTV delta of our invoices, as well
+// as the block based timeouts of the daemon against the block interval of the
+// active chain.
+func validateCltvDeltas(cfg *Config) error {
+	limits := routing.NewCltvLimits(cfg.ActiveNetParams.TargetTimePerBlock)
+
+	chainCfg := cfg.Bitcoin
+	if cfg.Litecoin.Active {
+		chainCfg = cfg.Litecoin
+	}
+	if err := limits.ValidateDelta(chainCfg.TimeLockDelta); err != nil {
+		return fmt.Errorf("invalid timelockdelta: %v", err)
+	}
+
+	// If we're not the initiator of a pending channel, we forget about it
+	// once maxWaitNumBlocksFundingConf blocks have passed without the
+	// funding transaction confirming. The initiator needs enough time to
+	// get it confirmed before that happens.
+	err := limits.ValidateTimeout(
+		maxWaitNumBlocksFundingConf, minFundingConfWait,
+	)
+	if err != nil {
+		return fmt.Errorf("invalid funding confirmation timeout: %v",
+			err)
+	}
+
+	return nil
+}
+
+// validateFundingLimits validates the channel size and funding options that
+// can be updated at runtime, setting the default maximum channel size if none
+// was specified.
+func validateFundingLimits(cfg *Config) error {
+	// Ensure that --maxchansize is properly handled when set by user.
+	// For non-Wumbo channels this limit remains 16777215 satoshis by default
+	// as specified in BOLT-02. For wumbo channels this limit is 1,000,000,000.
+	// satoshis (10 BTC). Always enforce --maxchansize explicitly set by user.
+	// If unset (marked by 0 value), then enforce proper default.
+	if cfg.MaxChanSize == 0 {
+		if cfg.ProtocolOptions.Wumbo() {
+			cfg.MaxChanSize = int64(MaxBtcFundingAmountWumbo)
+		} else {
+			cfg.MaxChanSize = int64(MaxBtcFundingAmount)
+		}
+	}
+
+	// Ensure that the user specified values for the min and max channel
+	// size make sense.
+	if cfg.MaxChanSize < cfg.MinChanSize {
+		return fmt.Errorf("invalid channel size parameters: "+
+			"max channel size %v, must be no less than min chan size %v",
+			cfg.MaxChanSize, cfg.MinChanSize,
+		)
+	}
+
+	// Don't allow superflous --maxchansize greater than
+	// BOLT 02 soft-limit for non-wumbo channel
+	if !cfg.ProtocolOptions.Wumbo() && cfg.MaxChanSize > int64(MaxFundingAmount) {
+		return fmt.Errorf("invalid channel size parameters: "+
+			"maximum channel size %v is greater than maximum non-wumbo"+
+			" channel size %v",
+			cfg.MaxChanSize, MaxFundingAmount,
+		)
+	}
+
+	if cfg.MaxPendingChannels < 1 {
+		return fmt.Errorf("maxpendingchannels must be at least 1")
+	}
+
+	if cfg.ReservationTimeout <= 0 {
+		return fmt.Errorf("reservationtimeout must be positive")
+	}
+
+	if cfg.FundingFeeRetryTimeout < 0 {
+		return fmt.Errorf("fundingfeeretrytimeout must not be negative")
+	}
+
+	if cfg.RemoteReservationTimeout < 0 {
+		return fmt.Errorf("remotereservationtimeout must not be " +
+			"negative")
+	}
+
+	if cfg.PsbtReservationTimeout < 0 {
+		return fmt.Errorf("psbtreservationtimeout must not be negative")
+	}
+
+	if cfg.TLSRotateBefore < 0 {
+		return fmt.Errorf("tlsrotatebefore must not be negative")
+	}
+
+	if cfg.LetsEncryptDNSHook != "" && cfg.LetsEncryptDomain == "" {
+		return fmt.Errorf("letsencryptdnshook requires " +
+			"letsencryptdomain to be set")
+	}
+
+	if cfg.MaxChanAnnouncementDelay > maxChanAnnouncementDelay {
+		return fmt.Errorf("maxchanannouncementdelay must not exceed "+
+			"%d blocks", maxChanAnnouncementDelay)
+	}
+
+	if cfg.ChanAnnouncementBatch > maxChanAnnouncementDelay {
+		return fmt.Errorf("chanannouncementbatch must not exceed %d "+
+			"blocks", maxChanAnnouncementDelay)
+	}
+
+	return nil
+}
+
+// parseTowerAddrs parses the watchtower URIs set with --wtclient.tower.
+func parseTowerAddrs(cfg *Config) ([]*lnwire.NetAddress, error) {
+	towers := make([]*lnwire.NetAddress, 0, len(cfg.WtClient.Towers))
+	for _, uri := range cfg.WtClient.Towers {
+		addr, err := lncfg.ParseLNAddressString(
+			uri, strconv.Itoa(watchtower.DefaultPeerPort),
+			cfg.net.ResolveTCPAddr,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("invalid watchtower URI %v: %v",
+				uri, err)
+		}
+
+		towers = append(towers, addr)
+	}
+
+	return towers, nil
+}
+
+// parseConsolidationAddrs parses the consolidation addresses of the sweeper
+// into the scripts that pay to them.
+func parseConsolidationAddrs(cfg *Config) ([][]byte, error) {
+	scripts := make([][]byte, 0, len(cfg.Sweeper.ConsolidationAddrs))
+	for _, addrStr := range cfg.Sweeper.ConsolidationAddrs {
+		addr, err := btcutil.DecodeAddress(
+			addrStr, cfg.ActiveNetParams.Params,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("invalid consolidation address "+
+				"%v: %v", addrStr, err)
+		}
+		if !addr.IsForNet(cfg.ActiveNetParams.Params) {
+			return nil, fmt.Errorf("consolidation address %v is "+
+				"not for the active network", addrStr)
+		}
+
+		script, err := txscript.PayToAddrScript(addr)
+		if err != nil {
+			return nil, err
+		}
+
+		scripts = append(scripts, script)
+	}
+
+	return scripts, nil
+}
+
+// configFilePath returns the path of the config file to load. If the config
+// file path has not been modified by the user, then we'll use the default
+// config file path. However, if the user has modified their lnddir, then we
+// should assume they intend to use the config file within it.
+func (c *Config) configFilePath() string {
+	configFileDir := CleanAndExpandPath(c.LndDir)
+	configFilePath := CleanAndExpandPath(c.ConfigFile)
+	if configFileDir != DefaultLndDir {
+		if configFilePath == DefaultConfigFile {
+			configFilePath = filepath.Join(
+				configFileDir, lncfg.DefaultConfigFilename,
+			)
+		}
+	}
+
+	return configFilePath
+}
+
 // localDatabaseDir returns the default directory where the
 // local bolt db files are stored.
 func (c *Config) localDatabaseDir() string {
@@ -1564,6 +2194,8 @@ func extractBitcoindRPCParams(networkName string,
 		chainDir = "/testnet4/"
 	case "regtest":
 		chainDir = "/regtest/"
+	case "signet":
+		chainDir = "/signet/"
 	}
 
 	cookie, err := ioutil.ReadFile(dataDir + chainDir + ".cookie")
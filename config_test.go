@@ -0,0 +1,154 @@
+// +build !rpctest
+
+package lnd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/cryptomeow/lnd/chainreg"
+	"github.com/cryptomeow/lnd/lncfg"
+	"github.com/cryptomeow/lnd/tor"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestTowerURI returns the URI of a watchtower with a random key at the
+// given host.
+func newTestTowerURI(t *testing.T, host string) string {
+	priv, err := btcec.NewPrivateKey(btcec.S256())
+	require.NoError(t, err)
+
+	return fmt.Sprintf("%x@%v", priv.PubKey().SerializeCompressed(), host)
+}
+
+// newReloadTestConfig creates an active config whose config file is located
+// in a temporary directory, and returns it along with a function that removes
+// the directory.
+func newReloadTestConfig(t *testing.T) (*Config, func()) {
+	tempDir, err := ioutil.TempDir("", "lnd-reload")
+	require.NoError(t, err)
+
+	cfg := DefaultConfig()
+	cfg.LndDir = tempDir
+	cfg.ConfigFile = filepath.Join(tempDir, lncfg.DefaultConfigFilename)
+	cfg.ActiveNetParams = chainreg.BitcoinRegTestNetParams
+	cfg.net = &tor.ClearNet{}
+	cfg.registeredChains = chainreg.NewChainRegistry()
+
+	return &cfg, func() {
+		os.RemoveAll(tempDir)
+	}
+}
+
+// writeConfigFile replaces the config file of the active config with the
+// given content.
+func writeConfigFile(t *testing.T, cfg *Config, content string) {
+	err := ioutil.WriteFile(cfg.ConfigFile, []byte(content), 0600)
+	require.NoError(t, err)
+}
+
+// TestReloadConfig asserts that a reloaded config contains the options of the
+// config file, that command line options take precedence over them, and that
+// the options that can be updated at runtime are validated.
+func TestReloadConfig(t *testing.T) {
+	t.Parallel()
+
+	activeCfg, cleanUp := newReloadTestConfig(t)
+	defer cleanUp()
+
+	// Unlike on startup, a missing config file is an error.
+	_, err := ReloadConfig(activeCfg, nil)
+	require.Error(t, err)
+
+	towerURI := newTestTowerURI(t, "127.0.0.1")
+	writeConfigFile(t, activeCfg, fmt.Sprintf(`
+[Application Options]
+minchansize=30000
+maxpendingchannels=3
+reservationtimeout=5m
+
+[Bitcoin]
+bitcoin.basefee=2000
+
+[wtclient]
+wtclient.tower=%v
+`, towerURI))
+
+	newCfg, err := ReloadConfig(
+		activeCfg, []string{"--maxpendingchannels=4"},
+	)
+	require.NoError(t, err)
+
+	require.EqualValues(t, 30000, newCfg.MinChanSize)
+	require.Equal(t, 4, newCfg.MaxPendingChannels)
+	require.Equal(t, 5*time.Minute, newCfg.ReservationTimeout)
+	require.EqualValues(t, 2000, newCfg.Bitcoin.BaseFee)
+
+	// The default maximum channel size is set if none was specified.
+	require.EqualValues(t, MaxBtcFundingAmount, newCfg.MaxChanSize)
+
+	// The tower is reachable on the default port if none is specified.
+	towers, err := parseTowerAddrs(newCfg)
+	require.NoError(t, err)
+	require.Len(t, towers, 1)
+	require.Equal(t, towerURI+":9911", towers[0].String())
+
+	// The active config isn't modified by a reload.
+	require.EqualValues(t, minChanFundingSize, activeCfg.MinChanSize)
+}
+
+// TestReloadConfigInvalid asserts that invalid values of the options that can
+// be updated at runtime are rejected by a reload.
+func TestReloadConfigInvalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		configFile string
+		args       []string
+	}{{
+		name:       "unknown option",
+		configFile: "[Application Options]\nnotanoption=1\n",
+	}, {
+		name: "invalid command line option",
+		args: []string{"--maxpendingchannels=many"},
+	}, {
+		name:       "no pending channels",
+		configFile: "[Application Options]\nmaxpendingchannels=0\n",
+	}, {
+		name: "max below min channel size",
+		configFile: "[Application Options]\nminchansize=30000\n" +
+			"maxchansize=20000\n",
+	}, {
+		name:       "invalid tower",
+		configFile: "[wtclient]\nwtclient.tower=notakey@127.0.0.1\n",
+	}, {
+		name:       "time lock delta too small",
+		configFile: "[Bitcoin]\nbitcoin.timelockdelta=1\n",
+	}, {
+		name: "consolidation address of other network",
+		configFile: "[sweeper]\nsweeper.consolidation-addr=" +
+			"bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4\n",
+	}}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			activeCfg, cleanUp := newReloadTestConfig(t)
+			defer cleanUp()
+
+			writeConfigFile(t, activeCfg, test.configFile)
+
+			_, err := ReloadConfig(activeCfg, test.args)
+			require.Error(t, err)
+		})
+	}
+}
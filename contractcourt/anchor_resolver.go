@@ -111,6 +111,7 @@ func (c *anchorResolver) Resolve() (ContractResolver, error) {
 			Fee: sweep.FeePreference{
 				FeeRate: relayFeeRate,
 			},
+			ChanPoint: &c.ChanPoint,
 		},
 	)
 	if err != nil {
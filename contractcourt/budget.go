@@ -0,0 +1,186 @@
+package contractcourt
+
+import (
+	"fmt"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/cryptomeow/lnd/input"
+	"github.com/cryptomeow/lnd/lnwallet/chainfee"
+	"github.com/cryptomeow/lnd/sweep"
+)
+
+// BudgetConfig limits the fees that are paid to sweep the outputs of a force
+// closed channel. Each budget is either an absolute amount, or a ratio of the
+// value that is recovered by the sweep. If neither is set, the fees are only
+// limited by the maximum fee rate of the sweeper.
+type BudgetConfig struct {
+	// ToLocal is the maximum fee paid to sweep our commitment output.
+	ToLocal btcutil.Amount `long:"tolocal" description:"The maximum fee in satoshis that is paid to sweep our output of a force closed commitment, on both our own and the remote commitment. Can't be combined with tolocalratio."`
+
+	// ToLocalRatio is the maximum fee paid to sweep our commitment
+	// output, as a ratio of its value.
+	ToLocalRatio float64 `long:"tolocalratio" description:"The maximum fee that is paid to sweep our output of a force closed commitment, as a ratio of the value of the output between 0 and 1. Can't be combined with tolocal."`
+
+	// Htlc is the maximum fee paid to sweep a single HTLC output.
+	Htlc btcutil.Amount `long:"htlc" description:"The maximum fee in satoshis that is paid to sweep a single HTLC output of a force closed commitment. Can't be combined with htlcratio."`
+
+	// HtlcRatio is the maximum fee paid to sweep a single HTLC output, as
+	// a ratio of its value.
+	HtlcRatio float64 `long:"htlcratio" description:"The maximum fee that is paid to sweep a single HTLC output of a force closed commitment, as a ratio of the value of the HTLC between 0 and 1. Can't be combined with htlc."`
+
+	// Anchor is the maximum fee paid on top of the commitment fee to bump
+	// a commitment with HTLCs at risk via its anchor output.
+	Anchor btcutil.Amount `long:"anchor" description:"The maximum fee in satoshis that is paid on top of the commitment fee to bump a force closed anchor commitment with HTLCs at risk via its anchor output. Can't be combined with anchorratio."`
+
+	// AnchorRatio is the maximum fee paid on top of the commitment fee to
+	// bump a commitment via its anchor output, as a ratio of the value of
+	// the HTLCs at risk.
+	AnchorRatio float64 `long:"anchorratio" description:"The maximum fee that is paid on top of the commitment fee to bump a force closed anchor commitment via its anchor output, as a ratio of the value of the HTLCs at risk between 0 and 1. Can't be combined with anchor."`
+}
+
+// DefaultBudgetConfig returns the default budget config, which doesn't limit
+// the fees of any sweep.
+func DefaultBudgetConfig() *BudgetConfig {
+	return &BudgetConfig{}
+}
+
+// Validate checks that all budgets are sane.
+func (b *BudgetConfig) Validate() error {
+	err := validateBudget("tolocal", b.ToLocal, b.ToLocalRatio)
+	if err != nil {
+		return err
+	}
+
+	if err := validateBudget("htlc", b.Htlc, b.HtlcRatio); err != nil {
+		return err
+	}
+
+	return validateBudget("anchor", b.Anchor, b.AnchorRatio)
+}
+
+// validateBudget checks that a budget is either set as a non-negative amount
+// or as a ratio between 0 and 1, but not both.
+func validateBudget(name string, amt btcutil.Amount, ratio float64) error {
+	switch {
+	case amt < 0:
+		return fmt.Errorf("invalid %v budget: %v, must not be "+
+			"negative", name, amt)
+
+	case ratio < 0 || ratio > 1:
+		return fmt.Errorf("invalid %vratio budget: %v, must be "+
+			"between 0 and 1", name, ratio)
+
+	case amt != 0 && ratio != 0:
+		return fmt.Errorf("only one of %v and %vratio budgets can "+
+			"be set", name, name)
+	}
+
+	return nil
+}
+
+// budgetFor returns the budget for a sweep that recovers the given value. The
+// second return value is false if the budget isn't limited.
+func budgetFor(amt btcutil.Amount, ratio float64,
+	value btcutil.Amount) (btcutil.Amount, bool) {
+
+	switch {
+	case amt != 0:
+		return amt, true
+
+	case ratio != 0:
+		return btcutil.Amount(float64(value) * ratio), true
+
+	default:
+		return 0, false
+	}
+}
+
+// ToLocalBudget returns the maximum fee paid to sweep a commitment output of
+// the given value. The second return value is false if the fee isn't limited.
+func (b *BudgetConfig) ToLocalBudget(
+	value btcutil.Amount) (btcutil.Amount, bool) {
+
+	return budgetFor(b.ToLocal, b.ToLocalRatio, value)
+}
+
+// HtlcBudget returns the maximum fee paid to sweep an HTLC output of the given
+// value. The second return value is false if the fee isn't limited.
+func (b *BudgetConfig) HtlcBudget(
+	value btcutil.Amount) (btcutil.Amount, bool) {
+
+	return budgetFor(b.Htlc, b.HtlcRatio, value)
+}
+
+// AnchorBudget returns the maximum fee paid on top of the commitment fee to
+// bump a commitment with the given value of HTLCs at risk. The second return
+// value is false if the fee isn't limited.
+func (b *BudgetConfig) AnchorBudget(
+	valueAtRisk btcutil.Amount) (btcutil.Amount, bool) {
+
+	return budgetFor(b.Anchor, b.AnchorRatio, valueAtRisk)
+}
+
+// sweepBudgetFeeRate returns the highest fee rate of a transaction that sweeps
+// a single input with the given witness type to the wallet, while paying at
+// most the given budget in fees.
+func sweepBudgetFeeRate(witnessType input.WitnessType,
+	budget btcutil.Amount) (chainfee.SatPerKWeight, error) {
+
+	witnessSize, _, err := witnessType.SizeUpperBound()
+	if err != nil {
+		return 0, err
+	}
+
+	var weightEstimate input.TxWeightEstimator
+	weightEstimate.AddWitnessInput(witnessSize)
+	weightEstimate.AddP2WKHOutput()
+
+	return chainfee.SatPerKWeight(
+		budget * 1000 / btcutil.Amount(weightEstimate.Weight()),
+	), nil
+}
+
+// capFeePreference returns a fee preference that targets the given number of
+// blocks, unless the estimated fee rate for that target exceeds the maximum
+// fee rate. In that case the maximum fee rate is used instead, and the second
+// return value is true.
+func capFeePreference(estimator chainfee.Estimator, confTarget uint32,
+	maxFeeRate chainfee.SatPerKWeight) (sweep.FeePreference, bool, error) {
+
+	feeRate, err := estimator.EstimateFeePerKW(confTarget)
+	if err != nil {
+		return sweep.FeePreference{}, false, err
+	}
+
+	if feeRate > maxFeeRate {
+		return sweep.FeePreference{
+			FeeRate: maxFeeRate,
+		}, true, nil
+	}
+
+	return sweep.FeePreference{
+		ConfTarget: confTarget,
+	}, false, nil
+}
+
+// BudgetFeePreference returns the fee preference for a transaction that sweeps
+// a single input with the given witness type at the given conf target. If the
+// budget is limited, the fee rate is capped to pay at most the budget in fees.
+// The second return value is true if the fee rate was capped.
+func BudgetFeePreference(estimator chainfee.Estimator, confTarget uint32,
+	witnessType input.WitnessType, budget btcutil.Amount,
+	limited bool) (sweep.FeePreference, bool, error) {
+
+	if !limited {
+		return sweep.FeePreference{
+			ConfTarget: confTarget,
+		}, false, nil
+	}
+
+	maxFeeRate, err := sweepBudgetFeeRate(witnessType, budget)
+	if err != nil {
+		return sweep.FeePreference{}, false, err
+	}
+
+	return capFeePreference(estimator, confTarget, maxFeeRate)
+}
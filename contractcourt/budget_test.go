@@ -0,0 +1,116 @@
+package contractcourt
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/cryptomeow/lnd/input"
+	"github.com/cryptomeow/lnd/lnwallet/chainfee"
+	"github.com/cryptomeow/lnd/sweep"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBudgetConfigValidate asserts that invalid budgets are rejected.
+func TestBudgetConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		budget BudgetConfig
+		valid  bool
+	}{
+		{
+			name:  "default",
+			valid: true,
+		},
+		{
+			name: "amounts and ratios",
+			budget: BudgetConfig{
+				ToLocal:     1000,
+				HtlcRatio:   0.5,
+				AnchorRatio: 1,
+			},
+			valid: true,
+		},
+		{
+			name:   "negative amount",
+			budget: BudgetConfig{Htlc: -1},
+		},
+		{
+			name:   "negative ratio",
+			budget: BudgetConfig{ToLocalRatio: -0.1},
+		},
+		{
+			name:   "ratio above one",
+			budget: BudgetConfig{AnchorRatio: 1.1},
+		},
+		{
+			name: "amount and ratio",
+			budget: BudgetConfig{
+				Anchor:      1000,
+				AnchorRatio: 0.5,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			err := testCase.budget.Validate()
+			if testCase.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+// TestBudgetFeePreference asserts that the fee rate of a sweep is capped by
+// its budget.
+func TestBudgetFeePreference(t *testing.T) {
+	t.Parallel()
+
+	budget := BudgetConfig{
+		ToLocal:   1000,
+		HtlcRatio: 0.1,
+	}
+
+	amt, limited := budget.ToLocalBudget(50000)
+	require.True(t, limited)
+	require.Equal(t, btcutil.Amount(1000), amt)
+
+	amt, limited = budget.HtlcBudget(50000)
+	require.True(t, limited)
+	require.Equal(t, btcutil.Amount(5000), amt)
+
+	_, limited = budget.AnchorBudget(50000)
+	require.False(t, limited)
+
+	witnessType := input.CommitmentTimeLock
+	maxFeeRate, err := sweepBudgetFeeRate(witnessType, 1000)
+	require.NoError(t, err)
+
+	estimator := chainfee.NewStaticEstimator(maxFeeRate, 0)
+
+	// An unlimited budget and a budget that covers the estimated fee rate
+	// keep the conf target.
+	for _, limited := range []bool{false, true} {
+		feePref, capped, err := BudgetFeePreference(
+			estimator, 6, witnessType, 1000, limited,
+		)
+		require.NoError(t, err)
+		require.False(t, capped)
+		require.Equal(t, sweep.FeePreference{ConfTarget: 6}, feePref)
+	}
+
+	// A smaller budget caps the fee rate.
+	feePref, capped, err := BudgetFeePreference(
+		estimator, 6, witnessType, 500, true,
+	)
+	require.NoError(t, err)
+	require.True(t, capped)
+	require.Less(t, int64(feePref.FeeRate), int64(maxFeeRate))
+	require.Zero(t, feePref.ConfTarget)
+}
@@ -146,6 +146,11 @@ type ChainArbitratorConfig struct {
 	// Sweeper allows resolvers to sweep their final outputs.
 	Sweeper UtxoSweeper
 
+	// Budget limits the fees that are paid to sweep our commitment
+	// output, our HTLC outputs and the anchors of commitments with HTLCs
+	// at risk.
+	Budget BudgetConfig
+
 	// Registry is the invoice database that is used by resolvers to lookup
 	// preimages and settle invoices.
 	Registry Registry
@@ -237,7 +242,7 @@ type arbChannel struct {
 // commitment transactions.
 //
 // NOTE: Part of the ArbChannel interface.
-func (a *arbChannel) NewAnchorResolutions() ([]*lnwallet.AnchorResolution,
+func (a *arbChannel) NewAnchorResolutions() (*lnwallet.AnchorResolutions,
 	error) {
 
 	// Get a fresh copy of the database state to base the anchor resolutions
@@ -19,6 +19,7 @@ import (
 	"github.com/cryptomeow/lnd/labels"
 	"github.com/cryptomeow/lnd/lntypes"
 	"github.com/cryptomeow/lnd/lnwallet"
+	"github.com/cryptomeow/lnd/lnwallet/chainfee"
 	"github.com/cryptomeow/lnd/lnwire"
 	"github.com/cryptomeow/lnd/sweep"
 )
@@ -88,7 +89,7 @@ type ArbChannel interface {
 
 	// NewAnchorResolutions returns the anchor resolutions for currently
 	// valid commitment transactions.
-	NewAnchorResolutions() ([]*lnwallet.AnchorResolution, error)
+	NewAnchorResolutions() (*lnwallet.AnchorResolutions, error)
 }
 
 // ChannelArbitratorConfig contains all the functionality that the
@@ -1056,19 +1057,193 @@ func (c *ChannelArbitrator) stateStep(
 	return nextState, closeTx, nil
 }
 
-// sweepAnchors offers all given anchor resolutions to the sweeper. It requests
-// sweeping at the minimum fee rate. This fee rate can be upped manually by the
-// user via the BumpFee rpc.
-func (c *ChannelArbitrator) sweepAnchors(anchors []*lnwallet.AnchorResolution,
+// forEachHtlcAtRisk calls the given closure for all HTLCs that are at risk on
+// the commitment identified by the given HTLC set key. An outgoing HTLC is at
+// risk as we need to time it out on chain, an incoming HTLC is at risk if we
+// know its preimage and need to claim it on chain. Dust HTLCs are never at
+// risk, as they don't have an output on the commitment.
+func (c *ChannelArbitrator) forEachHtlcAtRisk(htlcSetKey HtlcSetKey,
+	cb func(channeldb.HTLC)) error {
+
+	htlcs, ok := c.activeHTLCs[htlcSetKey]
+	if !ok {
+		return nil
+	}
+
+	for _, htlc := range htlcs.outgoingHTLCs {
+		if htlc.OutputIndex < 0 {
+			continue
+		}
+
+		cb(htlc)
+	}
+
+	for _, htlc := range htlcs.incomingHTLCs {
+		if htlc.OutputIndex < 0 {
+			continue
+		}
+
+		preimageAvailable, err := c.isPreimageAvailable(htlc.RHash)
+		if err != nil {
+			return err
+		}
+		if !preimageAvailable {
+			continue
+		}
+
+		cb(htlc)
+	}
+
+	return nil
+}
+
+// findCommitmentDeadline returns the number of blocks until the first of the
+// HTLCs that are at risk on the commitment identified by the given HTLC set
+// key expires. The second return value is false if there are no HTLCs at risk.
+func (c *ChannelArbitrator) findCommitmentDeadline(heightHint uint32,
+	htlcSetKey HtlcSetKey) (uint32, bool, error) {
+
+	var (
+		deadlineHeight uint32
+		atRisk         bool
+	)
+	err := c.forEachHtlcAtRisk(htlcSetKey, func(htlc channeldb.HTLC) {
+		if !atRisk || htlc.RefundTimeout < deadlineHeight {
+			deadlineHeight = htlc.RefundTimeout
+			atRisk = true
+		}
+	})
+	if err != nil {
+		return 0, false, err
+	}
+
+	if !atRisk {
+		return 0, false, nil
+	}
+
+	// If we're already past the deadline, the commitment should confirm
+	// in the next block.
+	if deadlineHeight <= heightHint {
+		return 1, true, nil
+	}
+
+	return deadlineHeight - heightHint, true, nil
+}
+
+// htlcValueAtRisk returns the value of the HTLCs at risk on the commitment
+// identified by the given HTLC set key.
+func (c *ChannelArbitrator) htlcValueAtRisk(
+	htlcSetKey HtlcSetKey) (btcutil.Amount, error) {
+
+	var valueAtRisk btcutil.Amount
+	err := c.forEachHtlcAtRisk(htlcSetKey, func(htlc channeldb.HTLC) {
+		valueAtRisk += htlc.Amt.ToSatoshis()
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return valueAtRisk, nil
+}
+
+// anchorFeePreference returns the fee preference for sweeping the anchor of
+// the commitment identified by the given HTLC set key. If there are no HTLCs
+// at risk on that commitment, there is no rush to get it confirmed and the
+// default conf target is used. Otherwise, the conf target is set to the
+// deadline of the first HTLC at risk, while the fee rate is kept within our
+// anchor budget for the value at risk on the commitment.
+func (c *ChannelArbitrator) anchorFeePreference(
+	anchor *lnwallet.AnchorResolution, htlcSetKey HtlcSetKey,
+	heightHint uint32) (sweep.FeePreference, error) {
+
+	deadline, atRisk, err := c.findCommitmentDeadline(
+		heightHint, htlcSetKey,
+	)
+	if err != nil {
+		return sweep.FeePreference{}, err
+	}
+
+	if !atRisk {
+		return sweep.FeePreference{
+			ConfTarget: anchorSweepConfTarget,
+		}, nil
+	}
+
+	valueAtRisk, err := c.htlcValueAtRisk(htlcSetKey)
+	if err != nil {
+		return sweep.FeePreference{}, err
+	}
+
+	budget, limited := c.cfg.Budget.AnchorBudget(valueAtRisk)
+	if !limited {
+		return sweep.FeePreference{
+			ConfTarget: deadline,
+		}, nil
+	}
+
+	// The sweeper will pay for both the commitment and the anchor sweep
+	// to reach the fee rate of the package, so the fees we pay on top of
+	// the commitment fee are limited by the fee rate of the package.
+	maxFeeRate := anchorBudgetFeeRate(anchor, budget)
+	feePref, capped, err := capFeePreference(
+		c.cfg.FeeEstimator, deadline, maxFeeRate,
+	)
+	if err != nil {
+		return sweep.FeePreference{}, err
+	}
+
+	if capped {
+		log.Infof("ChannelArbitrator(%v): capping anchor sweep fee "+
+			"rate at %v due to anchor budget of %v",
+			c.cfg.ChanPoint, maxFeeRate, budget)
+	}
+
+	return feePref, nil
+}
+
+// anchorBudgetFeeRate returns the highest fee rate for the package of the
+// commitment transaction and the anchor sweep that can be reached by paying
+// at most the given budget on top of the commitment fee.
+func anchorBudgetFeeRate(anchor *lnwallet.AnchorResolution,
+	budget btcutil.Amount) chainfee.SatPerKWeight {
+
+	// The anchor sweep spends the anchor and a wallet input to pay for the
+	// fees, and sends the change back to the wallet.
+	var weightEstimate input.TxWeightEstimator
+	weightEstimate.AddWitnessInput(input.AnchorWitnessSize)
+	weightEstimate.AddP2WKHInput()
+	weightEstimate.AddP2WKHOutput()
+
+	packageWeight := anchor.CommitWeight + int64(weightEstimate.Weight())
+
+	return chainfee.SatPerKWeight(
+		(anchor.CommitFee + budget) * 1000 / btcutil.Amount(packageWeight),
+	)
+}
+
+// sweepAnchors offers all given anchor resolutions to the sweeper. This
+// includes the anchors on the remote commitments, so that a commitment that
+// was broadcast by the remote party can be bumped as well. The anchor of each
+// commitment is swept with a fee preference that targets the deadline of the
+// first HTLC at risk on that commitment, or at a relaxed conf target if there
+// are none. The fee rate can be upped manually by the user via the BumpFee
+// rpc.
+func (c *ChannelArbitrator) sweepAnchors(anchors *lnwallet.AnchorResolutions,
 	heightHint uint32) error {
 
 	// Use the chan id as the exclusive group. This prevents any of the
 	// anchors from being batched together.
 	exclusiveGroup := c.cfg.ShortChanID.ToUint64()
 
-	for _, anchor := range anchors {
+	// sweepWithDeadline is a helper closure that takes an anchor
+	// resolution and sweeps it with the fee preference derived from the
+	// HTLCs at risk on its commitment.
+	sweepWithDeadline := func(anchor *lnwallet.AnchorResolution,
+		htlcSetKey HtlcSetKey) error {
+
 		log.Debugf("ChannelArbitrator(%v): pre-confirmation sweep of "+
-			"anchor of tx %v", c.cfg.ChanPoint, anchor.CommitAnchor)
+			"anchor of %v commit tx %v", c.cfg.ChanPoint,
+			htlcSetKey, anchor.CommitAnchor)
 
 		// Prepare anchor output for sweeping.
 		anchorInput := input.MakeBaseInput(
@@ -1090,16 +1265,44 @@ func (c *ChannelArbitrator) sweepAnchors(anchors []*lnwallet.AnchorResolution,
 		// Also signal that this is a force sweep, so that the anchor
 		// will be swept even if it isn't economical purely based on the
 		// anchor value.
-		_, err := c.cfg.Sweeper.SweepInput(
+		feePref, err := c.anchorFeePreference(
+			anchor, htlcSetKey, heightHint,
+		)
+		if err != nil {
+			return err
+		}
+
+		_, err = c.cfg.Sweeper.SweepInput(
 			&anchorInput,
 			sweep.Params{
-				Fee: sweep.FeePreference{
-					ConfTarget: anchorSweepConfTarget,
-				},
+				Fee:            feePref,
 				Force:          true,
 				ExclusiveGroup: &exclusiveGroup,
+				ChanPoint:      &c.cfg.ChanPoint,
 			},
 		)
+
+		return err
+	}
+
+	// Sweep the anchor of each commitment we know of, using the HTLCs of
+	// that commitment to find its deadline.
+	commitAnchors := []struct {
+		anchor     *lnwallet.AnchorResolution
+		htlcSetKey HtlcSetKey
+	}{
+		{anchors.Local, LocalHtlcSet},
+		{anchors.Remote, RemoteHtlcSet},
+		{anchors.RemotePending, RemotePendingHtlcSet},
+	}
+	for _, commitAnchor := range commitAnchors {
+		if commitAnchor.anchor == nil {
+			continue
+		}
+
+		err := sweepWithDeadline(
+			commitAnchor.anchor, commitAnchor.htlcSetKey,
+		)
 		if err != nil {
 			return err
 		}
@@ -20,8 +20,11 @@ import (
 	"github.com/cryptomeow/lnd/clock"
 	"github.com/cryptomeow/lnd/input"
 	"github.com/cryptomeow/lnd/lntest/mock"
+	"github.com/cryptomeow/lnd/lntypes"
 	"github.com/cryptomeow/lnd/lnwallet"
+	"github.com/cryptomeow/lnd/lnwallet/chainfee"
 	"github.com/cryptomeow/lnd/lnwire"
+	"github.com/cryptomeow/lnd/sweep"
 )
 
 const (
@@ -401,7 +404,10 @@ func createTestChannelArbitrator(t *testing.T, log ArbitratorLog,
 			return nil, err
 		}
 		dbPath := filepath.Join(dbDir, "testdb")
-		db, err := kvdb.Create(kvdb.BoltBackendName, dbPath, true)
+		db, err := kvdb.Create(
+			kvdb.BoltBackendName, dbPath,
+			true, kvdb.DefaultDBTimeout,
+		)
 		if err != nil {
 			return nil, err
 		}
@@ -2126,8 +2132,13 @@ func TestChannelArbitratorAnchors(t *testing.T) {
 
 	// Setup two pre-confirmation anchor resolutions on the mock channel.
 	chanArb.cfg.Channel.(*mockChannel).anchorResolutions =
-		[]*lnwallet.AnchorResolution{
-			{}, {},
+		&lnwallet.AnchorResolutions{
+			Local: &lnwallet.AnchorResolution{
+				CommitAnchor: wire.OutPoint{Index: 1},
+			},
+			Remote: &lnwallet.AnchorResolution{
+				CommitAnchor: wire.OutPoint{Index: 2},
+			},
 		}
 
 	if err := chanArb.Start(); err != nil {
@@ -2293,12 +2304,16 @@ func assertResolverReport(t *testing.T, reports chan *channeldb.ResolverReport,
 }
 
 type mockChannel struct {
-	anchorResolutions []*lnwallet.AnchorResolution
+	anchorResolutions *lnwallet.AnchorResolutions
 }
 
-func (m *mockChannel) NewAnchorResolutions() ([]*lnwallet.AnchorResolution,
+func (m *mockChannel) NewAnchorResolutions() (*lnwallet.AnchorResolutions,
 	error) {
 
+	if m.anchorResolutions == nil {
+		return &lnwallet.AnchorResolutions{}, nil
+	}
+
 	return m.anchorResolutions, nil
 }
 
@@ -2309,3 +2324,280 @@ func (m *mockChannel) ForceCloseChan() (*lnwallet.LocalForceCloseSummary, error)
 	}
 	return summary, nil
 }
+
+// TestChannelArbitratorAnchorFeePreference asserts that anchors are swept with
+// a fee preference that targets the deadline of the first HTLC at risk, capped
+// by the anchor budget.
+func TestChannelArbitratorAnchorFeePreference(t *testing.T) {
+	t.Parallel()
+
+	preimage := lntypes.Preimage{1}
+	preimageDB := newMockWitnessBeacon()
+	preimageDB.lookupPreimage[preimage.Hash()] = preimage
+
+	const (
+		height      = 100
+		estimateFee = chainfee.SatPerKWeight(10000)
+	)
+	anchor := &lnwallet.AnchorResolution{
+		CommitFee:    5000,
+		CommitWeight: 1000,
+	}
+
+	// The incoming HTLC with the earliest expiry isn't at risk as we don't
+	// know its preimage, neither is the dust HTLC.
+	htlcs := map[HtlcSetKey]htlcSet{
+		LocalHtlcSet: {
+			incomingHTLCs: map[uint64]channeldb.HTLC{
+				0: {RefundTimeout: 110, OutputIndex: 2},
+				1: {
+					RHash:         preimage.Hash(),
+					Amt:           lnwire.NewMSatFromSatoshis(100000),
+					RefundTimeout: 130,
+					OutputIndex:   3,
+				},
+			},
+			outgoingHTLCs: map[uint64]channeldb.HTLC{
+				2: {RefundTimeout: 105, OutputIndex: -1},
+			},
+		},
+		RemoteHtlcSet: {
+			outgoingHTLCs: map[uint64]channeldb.HTLC{
+				3: {
+					Amt:           lnwire.NewMSatFromSatoshis(50000),
+					RefundTimeout: 140,
+					OutputIndex:   4,
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name         string
+		htlcs        map[HtlcSetKey]htlcSet
+		htlcSetKey   HtlcSetKey
+		height       uint32
+		budget       BudgetConfig
+		expectedPref sweep.FeePreference
+	}{
+		{
+			name:       "no htlcs at risk",
+			htlcSetKey: LocalHtlcSet,
+			height:     height,
+			expectedPref: sweep.FeePreference{
+				ConfTarget: anchorSweepConfTarget,
+			},
+		},
+		{
+			name:       "deadline of htlc at risk",
+			htlcs:      htlcs,
+			htlcSetKey: LocalHtlcSet,
+			height:     height,
+			expectedPref: sweep.FeePreference{
+				ConfTarget: 30,
+			},
+		},
+		{
+			name:       "deadline passed",
+			htlcs:      htlcs,
+			htlcSetKey: LocalHtlcSet,
+			height:     200,
+			expectedPref: sweep.FeePreference{
+				ConfTarget: 1,
+			},
+		},
+		{
+			name:       "within budget",
+			htlcs:      htlcs,
+			htlcSetKey: LocalHtlcSet,
+			height:     height,
+			budget:     BudgetConfig{Anchor: 100000},
+			expectedPref: sweep.FeePreference{
+				ConfTarget: 30,
+			},
+		},
+		{
+			name:       "budget exceeded",
+			htlcs:      htlcs,
+			htlcSetKey: LocalHtlcSet,
+			height:     height,
+			budget:     BudgetConfig{Anchor: 5000},
+			expectedPref: sweep.FeePreference{
+				FeeRate: anchorBudgetFeeRate(anchor, 5000),
+			},
+		},
+		{
+			name:       "within budget ratio",
+			htlcs:      htlcs,
+			htlcSetKey: LocalHtlcSet,
+			height:     height,
+			budget:     BudgetConfig{AnchorRatio: 1},
+			expectedPref: sweep.FeePreference{
+				ConfTarget: 30,
+			},
+		},
+		{
+			// The ratio applies to the value at risk on the
+			// commitment of the anchor.
+			name:       "budget ratio exceeded",
+			htlcs:      htlcs,
+			htlcSetKey: LocalHtlcSet,
+			height:     height,
+			budget:     BudgetConfig{AnchorRatio: 0.05},
+			expectedPref: sweep.FeePreference{
+				FeeRate: anchorBudgetFeeRate(anchor, 5000),
+			},
+		},
+		{
+			name:       "deadline of remote htlc at risk",
+			htlcs:      htlcs,
+			htlcSetKey: RemoteHtlcSet,
+			height:     height,
+			expectedPref: sweep.FeePreference{
+				ConfTarget: 40,
+			},
+		},
+		{
+			// The ratio applies to the value at risk on the
+			// commitment of the anchor.
+			name:       "remote budget ratio exceeded",
+			htlcs:      htlcs,
+			htlcSetKey: RemoteHtlcSet,
+			height:     height,
+			budget:     BudgetConfig{AnchorRatio: 0.05},
+			expectedPref: sweep.FeePreference{
+				FeeRate: anchorBudgetFeeRate(anchor, 2500),
+			},
+		},
+		{
+			name:       "no htlcs at risk on remote pending",
+			htlcs:      htlcs,
+			htlcSetKey: RemotePendingHtlcSet,
+			height:     height,
+			expectedPref: sweep.FeePreference{
+				ConfTarget: anchorSweepConfTarget,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			chanArb := &ChannelArbitrator{
+				cfg: ChannelArbitratorConfig{
+					ChainArbitratorConfig: ChainArbitratorConfig{
+						PreimageDB: preimageDB,
+						Registry:   &mockRegistry{},
+						FeeEstimator: chainfee.NewStaticEstimator(
+							estimateFee, 0,
+						),
+						Budget: testCase.budget,
+					},
+				},
+				activeHTLCs: testCase.htlcs,
+			}
+
+			feePref, err := chanArb.anchorFeePreference(
+				anchor, testCase.htlcSetKey, testCase.height,
+			)
+			if err != nil {
+				t.Fatalf("unable to get fee preference: %v", err)
+			}
+
+			if feePref != testCase.expectedPref {
+				t.Fatalf("expected fee preference %v, got %v",
+					testCase.expectedPref, feePref)
+			}
+		})
+	}
+
+	// The budget should limit the fees paid on top of the commitment fee.
+	feeRate := anchorBudgetFeeRate(anchor, 5000)
+	if feeRate >= estimateFee || feeRate <= 5000 {
+		t.Fatalf("unexpected budget fee rate: %v", feeRate)
+	}
+}
+
+// TestChannelArbitratorSweepAnchorsDeadline asserts that the anchor of each
+// commitment is swept with the deadline of the HTLCs at risk on that
+// commitment, including the remote and remote pending commitments.
+func TestChannelArbitratorSweepAnchorsDeadline(t *testing.T) {
+	t.Parallel()
+
+	const height = 100
+
+	// Only the remote commitments have HTLCs at risk, and the pending one
+	// has an HTLC that expires earlier.
+	htlcs := map[HtlcSetKey]htlcSet{
+		RemoteHtlcSet: {
+			outgoingHTLCs: map[uint64]channeldb.HTLC{
+				0: {RefundTimeout: 140, OutputIndex: 2},
+			},
+		},
+		RemotePendingHtlcSet: {
+			outgoingHTLCs: map[uint64]channeldb.HTLC{
+				0: {RefundTimeout: 140, OutputIndex: 2},
+				1: {RefundTimeout: 120, OutputIndex: 3},
+			},
+		},
+	}
+
+	anchors := &lnwallet.AnchorResolutions{
+		Local: &lnwallet.AnchorResolution{
+			CommitAnchor: wire.OutPoint{Index: 1},
+		},
+		Remote: &lnwallet.AnchorResolution{
+			CommitAnchor: wire.OutPoint{Index: 2},
+		},
+		RemotePending: &lnwallet.AnchorResolution{
+			CommitAnchor: wire.OutPoint{Index: 3},
+		},
+	}
+
+	sweeper := newMockSweeper()
+	chanArb := &ChannelArbitrator{
+		cfg: ChannelArbitratorConfig{
+			ChainArbitratorConfig: ChainArbitratorConfig{
+				PreimageDB: newMockWitnessBeacon(),
+				Registry:   &mockRegistry{},
+				Sweeper:    sweeper,
+			},
+		},
+		activeHTLCs: htlcs,
+	}
+
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- chanArb.sweepAnchors(anchors, height)
+	}()
+
+	for i := 0; i < 3; i++ {
+		select {
+		case <-sweeper.sweptInputs:
+		case <-time.After(defaultTimeout):
+			t.Fatalf("anchor %d not swept", i)
+		}
+	}
+	if err := <-errChan; err != nil {
+		t.Fatalf("unable to sweep anchors: %v", err)
+	}
+
+	expectedTargets := map[*lnwallet.AnchorResolution]uint32{
+		anchors.Local:         anchorSweepConfTarget,
+		anchors.Remote:        40,
+		anchors.RemotePending: 20,
+	}
+	for anchor, confTarget := range expectedTargets {
+		params := sweeper.sweptParams[anchor.CommitAnchor]
+		if params.Fee.ConfTarget != confTarget {
+			t.Fatalf("expected conf target %v for anchor %v, "+
+				"got %v", confTarget, anchor.CommitAnchor,
+				params.Fee.ConfTarget)
+		}
+		if !params.Force {
+			t.Fatalf("expected force sweep of anchor %v",
+				anchor.CommitAnchor)
+		}
+	}
+}
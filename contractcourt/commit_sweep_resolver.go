@@ -229,8 +229,30 @@ func (c *commitSweepResolver) Resolve() (ContractResolver, error) {
 	// sweeper.
 	c.log.Infof("sweeping commit output")
 
-	feePref := sweep.FeePreference{ConfTarget: commitOutputConfTarget}
-	resultChan, err := c.Sweeper.SweepInput(inp, sweep.Params{Fee: feePref})
+	// The fee rate is capped to keep the fees within our to_local
+	// budget.
+	budget, limited := c.Budget.ToLocalBudget(
+		btcutil.Amount(c.commitResolution.SelfOutputSignDesc.Output.Value),
+	)
+	feePref, capped, err := BudgetFeePreference(
+		c.FeeEstimator, commitOutputConfTarget, witnessType, budget,
+		limited,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if capped {
+		c.log.Infof("capping commit sweep fee rate at %v due to "+
+			"to_local budget of %v", feePref.FeeRate, budget)
+	}
+
+	// Outputs that were time locked are swept into the consolidation
+	// address set of the sweeper, if it has one.
+	resultChan, err := c.Sweeper.SweepInput(inp, sweep.Params{
+		Fee:         feePref,
+		Consolidate: isLocalCommitTx || isDelayedOutput,
+		ChanPoint:   &c.ChanPoint,
+	})
 	if err != nil {
 		c.log.Errorf("unable to sweep input: %v", err)
 
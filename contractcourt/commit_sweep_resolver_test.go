@@ -107,6 +107,10 @@ type mockSweeper struct {
 	updatedInputs chan wire.OutPoint
 	sweepTx       *wire.MsgTx
 	sweepErr      error
+
+	// sweptParams holds the params of each swept input. It is written
+	// before the input is sent on sweptInputs.
+	sweptParams map[wire.OutPoint]sweep.Params
 }
 
 func newMockSweeper() *mockSweeper {
@@ -114,12 +118,14 @@ func newMockSweeper() *mockSweeper {
 		sweptInputs:   make(chan input.Input),
 		updatedInputs: make(chan wire.OutPoint),
 		sweepTx:       &wire.MsgTx{},
+		sweptParams:   make(map[wire.OutPoint]sweep.Params),
 	}
 }
 
 func (s *mockSweeper) SweepInput(input input.Input, params sweep.Params) (
 	chan sweep.Result, error) {
 
+	s.sweptParams[*input.OutPoint()] = params
 	s.sweptInputs <- input
 
 	result := make(chan sweep.Result, 1)
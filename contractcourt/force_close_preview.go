@@ -0,0 +1,212 @@
+package contractcourt
+
+import (
+	"fmt"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/cryptomeow/lnd/channeldb"
+	"github.com/cryptomeow/lnd/input"
+	"github.com/cryptomeow/lnd/lnwallet"
+	"github.com/cryptomeow/lnd/lnwallet/chainfee"
+	"github.com/cryptomeow/lnd/sweep"
+)
+
+// HtlcPreview describes an HTLC on the commitment that would be broadcast by a
+// force close.
+type HtlcPreview struct {
+	// Htlc is the HTLC on our commitment.
+	Htlc channeldb.HTLC
+
+	// Dust indicates that the HTLC doesn't have an output on the
+	// commitment, so its value would go to fees.
+	Dust bool
+
+	// CltvWait is the number of blocks until an outgoing HTLC can be
+	// timed out on chain. Incoming HTLCs can be claimed as soon as we know
+	// their preimage, so it is always zero for them.
+	CltvWait uint32
+
+	// SecondLevelFee is the fee of the second-level transaction that
+	// spends the HTLC output, which is paid from the value of the HTLC.
+	SecondLevelFee btcutil.Amount
+
+	// SweepFee is the estimated fee of sweeping the output of the
+	// second-level transaction once its CSV delay expired.
+	SweepFee btcutil.Amount
+}
+
+// ForceClosePreview describes what a force close of a channel would look like
+// at a given height.
+type ForceClosePreview struct {
+	// BestHeight is the height the preview was created at.
+	BestHeight uint32
+
+	// CommitFee is the fee of our commitment transaction.
+	CommitFee btcutil.Amount
+
+	// CommitWeight is the estimated weight of our commitment transaction.
+	CommitWeight int64
+
+	// CommitFeeRate is the fee rate of our commitment transaction.
+	CommitFeeRate chainfee.SatPerKWeight
+
+	// ToLocal is the value of our commitment output. It is zero if the
+	// output would be dust.
+	ToLocal btcutil.Amount
+
+	// CsvDelay is the number of blocks our commitment output and the
+	// outputs of our second-level HTLC transactions are locked after they
+	// confirmed.
+	CsvDelay uint32
+
+	// ToLocalSweepFee is the estimated fee of sweeping our commitment
+	// output.
+	ToLocalSweepFee btcutil.Amount
+
+	// HasAnchor indicates that our commitment has an anchor output that
+	// can be used to bump its fee.
+	HasAnchor bool
+
+	// AnchorCPFPFee is the estimated fee of sweeping our anchor in order to
+	// bump the commitment to the sweep fee rate. It is zero if the
+	// commitment already pays at least the sweep fee rate.
+	AnchorCPFPFee btcutil.Amount
+
+	// SweepFeeRate is the fee rate the sweep fees were estimated at.
+	SweepFeeRate chainfee.SatPerKWeight
+
+	// Htlcs are the HTLCs on our commitment.
+	Htlcs []*HtlcPreview
+}
+
+// TotalSweepFee returns the sum of all estimated fees paid to recover our
+// funds after the commitment confirmed, including the anchor CPFP fee and the
+// fees of the second-level HTLC transactions.
+func (p *ForceClosePreview) TotalSweepFee() btcutil.Amount {
+	total := p.ToLocalSweepFee + p.AnchorCPFPFee
+	for _, htlc := range p.Htlcs {
+		total += htlc.SecondLevelFee + htlc.SweepFee
+	}
+
+	return total
+}
+
+// estimateSingleSweepFee estimates the fee of sweeping a single input with the
+// given witness type at the given fee rate.
+func estimateSingleSweepFee(witnessType input.WitnessType,
+	feeRate chainfee.SatPerKWeight) (btcutil.Amount, error) {
+
+	inp := input.MakeBaseInput(
+		&wire.OutPoint{}, witnessType, &input.SignDescriptor{}, 0, nil,
+	)
+	_, fee, err := sweep.EstimateSweepFee([]input.Input{&inp}, feeRate)
+
+	return fee, err
+}
+
+// PreviewForceClose returns what a force close of the channel would look like
+// at the given height, with the sweep fees estimated at the given fee rate.
+// Nothing is signed or broadcast.
+func PreviewForceClose(channel *channeldb.OpenChannel, bestHeight uint32,
+	sweepFeeRate chainfee.SatPerKWeight) (*ForceClosePreview, error) {
+
+	// A force close isn't allowed if we may have lost state, as our
+	// commitment may be outdated.
+	if channel.HasChanStatus(channeldb.ChanStatusLocalDataLoss) {
+		return nil, fmt.Errorf("cannot force close channel with "+
+			"state: %v", channel.ChanStatus())
+	}
+
+	commitment := channel.LocalCommitment
+	chanType := channel.LocalCommitType(commitment.CommitHeight)
+
+	preview := &ForceClosePreview{
+		BestHeight:    bestHeight,
+		CommitFee:     commitment.CommitFee,
+		CommitWeight:  lnwallet.CommitWeight(chanType),
+		CommitFeeRate: chainfee.SatPerKWeight(commitment.FeePerKw),
+		CsvDelay:      uint32(channel.LocalChanCfg.CsvDelay),
+		HasAnchor:     chanType.HasAnchors(),
+		SweepFeeRate:  sweepFeeRate,
+	}
+
+	for _, htlc := range commitment.Htlcs {
+		htlcPreview := &HtlcPreview{
+			Htlc: htlc,
+			Dust: htlc.OutputIndex < 0,
+		}
+		preview.Htlcs = append(preview.Htlcs, htlcPreview)
+
+		if htlcPreview.Dust {
+			continue
+		}
+		preview.CommitWeight += input.HTLCWeight
+
+		witnessType := input.HtlcAcceptedSuccessSecondLevel
+		if htlc.Incoming {
+			htlcPreview.SecondLevelFee = lnwallet.HtlcSuccessFee(
+				chanType, preview.CommitFeeRate,
+			)
+		} else {
+			witnessType = input.HtlcOfferedTimeoutSecondLevel
+			htlcPreview.SecondLevelFee = lnwallet.HtlcTimeoutFee(
+				chanType, preview.CommitFeeRate,
+			)
+
+			if htlc.RefundTimeout > bestHeight {
+				htlcPreview.CltvWait = htlc.RefundTimeout -
+					bestHeight
+			}
+		}
+
+		sweepFee, err := estimateSingleSweepFee(
+			witnessType, sweepFeeRate,
+		)
+		if err != nil {
+			return nil, err
+		}
+		htlcPreview.SweepFee = sweepFee
+	}
+
+	// Our commitment output is only present if it isn't dust.
+	toLocal := commitment.LocalBalance.ToSatoshis()
+	if toLocal >= channel.LocalChanCfg.DustLimit {
+		sweepFee, err := estimateSingleSweepFee(
+			input.CommitmentTimeLock, sweepFeeRate,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		preview.ToLocal = toLocal
+		preview.ToLocalSweepFee = sweepFee
+	}
+
+	// The anchor is only swept if the commitment needs to be bumped to
+	// reach the sweep fee rate. The sweep then pays for the difference of
+	// the commitment fee, and uses a wallet input to do so.
+	if preview.HasAnchor && sweepFeeRate > preview.CommitFeeRate {
+		anchor := input.MakeBaseInput(
+			&wire.OutPoint{}, input.CommitmentAnchor,
+			&input.SignDescriptor{}, 0, &input.TxInfo{
+				Fee:    preview.CommitFee,
+				Weight: preview.CommitWeight,
+			},
+		)
+		walletInput := input.MakeBaseInput(
+			&wire.OutPoint{}, input.WitnessKeyHash,
+			&input.SignDescriptor{}, 0, nil,
+		)
+
+		_, fee, err := sweep.EstimateSweepFee(
+			[]input.Input{&anchor, &walletInput}, sweepFeeRate,
+		)
+		if err != nil {
+			return nil, err
+		}
+		preview.AnchorCPFPFee = fee
+	}
+
+	return preview, nil
+}
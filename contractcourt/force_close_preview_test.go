@@ -0,0 +1,138 @@
+package contractcourt
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/cryptomeow/lnd/channeldb"
+	"github.com/cryptomeow/lnd/input"
+	"github.com/cryptomeow/lnd/lnwallet"
+	"github.com/cryptomeow/lnd/lnwallet/chainfee"
+	"github.com/cryptomeow/lnd/lnwire"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPreviewForceClose asserts that the preview of a force close reports the
+// outputs of our commitment along with their time locks and sweep fees.
+func TestPreviewForceClose(t *testing.T) {
+	t.Parallel()
+
+	const (
+		height         = 100
+		commitFeeRate  = chainfee.SatPerKWeight(2500)
+		sweepFeeRate   = chainfee.SatPerKWeight(10000)
+		csvDelay       = 144
+		dustLimit      = btcutil.Amount(573)
+		commitFee      = btcutil.Amount(4000)
+		localBalance   = btcutil.Amount(500000)
+		incomingAmount = btcutil.Amount(20000)
+		outgoingAmount = btcutil.Amount(30000)
+		dustAmount     = btcutil.Amount(100)
+	)
+
+	var (
+		incomingAmt = lnwire.NewMSatFromSatoshis(incomingAmount)
+		outgoingAmt = lnwire.NewMSatFromSatoshis(outgoingAmount)
+		dustAmt     = lnwire.NewMSatFromSatoshis(dustAmount)
+	)
+
+	chanType := channeldb.SingleFunderTweaklessBit |
+		channeldb.AnchorOutputsBit
+
+	channel := &channeldb.OpenChannel{
+		ChanType: chanType,
+		LocalChanCfg: channeldb.ChannelConfig{
+			ChannelConstraints: channeldb.ChannelConstraints{
+				CsvDelay:  csvDelay,
+				DustLimit: dustLimit,
+			},
+		},
+		LocalCommitment: channeldb.ChannelCommitment{
+			CommitFee:    commitFee,
+			FeePerKw:     btcutil.Amount(commitFeeRate),
+			LocalBalance: lnwire.NewMSatFromSatoshis(localBalance),
+			Htlcs: []channeldb.HTLC{
+				{
+					Incoming:      true,
+					Amt:           incomingAmt,
+					RefundTimeout: 120,
+					OutputIndex:   2,
+				},
+				{
+					Amt:           outgoingAmt,
+					RefundTimeout: 130,
+					OutputIndex:   3,
+				},
+				{
+					Amt:           dustAmt,
+					RefundTimeout: 90,
+					OutputIndex:   -1,
+				},
+			},
+		},
+	}
+
+	preview, err := PreviewForceClose(channel, height, sweepFeeRate)
+	require.NoError(t, err)
+
+	require.Equal(t, uint32(height), preview.BestHeight)
+	require.Equal(t, commitFee, preview.CommitFee)
+	require.Equal(t, commitFeeRate, preview.CommitFeeRate)
+	require.Equal(t, sweepFeeRate, preview.SweepFeeRate)
+	require.Equal(
+		t, input.AnchorCommitWeight+2*input.HTLCWeight,
+		int(preview.CommitWeight),
+	)
+	require.Equal(t, localBalance, preview.ToLocal)
+	require.Equal(t, uint32(csvDelay), preview.CsvDelay)
+	require.NotZero(t, preview.ToLocalSweepFee)
+	require.True(t, preview.HasAnchor)
+
+	// As the commitment pays a lower fee rate than the sweeps, the anchor
+	// needs to bump it, paying for both transactions.
+	commitBump := sweepFeeRate.FeeForWeight(preview.CommitWeight) -
+		commitFee
+	require.Greater(t, int64(preview.AnchorCPFPFee), int64(commitBump))
+
+	require.Len(t, preview.Htlcs, 3)
+
+	// The incoming HTLC can be claimed right away with a success
+	// transaction.
+	incoming := preview.Htlcs[0]
+	require.False(t, incoming.Dust)
+	require.Zero(t, incoming.CltvWait)
+	require.Equal(
+		t, lnwallet.HtlcSuccessFee(chanType, commitFeeRate),
+		incoming.SecondLevelFee,
+	)
+	require.NotZero(t, incoming.SweepFee)
+
+	// The outgoing HTLC can only be timed out once it expired.
+	outgoing := preview.Htlcs[1]
+	require.False(t, outgoing.Dust)
+	require.Equal(t, uint32(30), outgoing.CltvWait)
+	require.Equal(
+		t, lnwallet.HtlcTimeoutFee(chanType, commitFeeRate),
+		outgoing.SecondLevelFee,
+	)
+	require.NotZero(t, outgoing.SweepFee)
+
+	// Dust HTLCs have no output to sweep.
+	dust := preview.Htlcs[2]
+	require.True(t, dust.Dust)
+	require.Zero(t, dust.SecondLevelFee)
+	require.Zero(t, dust.SweepFee)
+
+	require.Equal(
+		t, preview.ToLocalSweepFee+preview.AnchorCPFPFee+
+			incoming.SecondLevelFee+incoming.SweepFee+
+			outgoing.SecondLevelFee+outgoing.SweepFee,
+		preview.TotalSweepFee(),
+	)
+
+	// If the commitment already pays the sweep fee rate, the anchor isn't
+	// swept.
+	preview, err = PreviewForceClose(channel, height, commitFeeRate)
+	require.NoError(t, err)
+	require.Zero(t, preview.AnchorCPFPFee)
+}
@@ -12,7 +12,6 @@ import (
 	"github.com/cryptomeow/lnd/input"
 	"github.com/cryptomeow/lnd/labels"
 	"github.com/cryptomeow/lnd/lnwallet"
-	"github.com/cryptomeow/lnd/sweep"
 )
 
 // htlcSuccessResolver is a resolver that's capable of sweeping an incoming
@@ -133,12 +132,27 @@ func (h *htlcSuccessResolver) Resolve() (ContractResolver, error) {
 			// implementation is complete.
 			//
 			// TODO: Use time-based sweeper and result chan.
-			var err error
+			//
+			// The fee rate is capped to keep the fees within our
+			// htlc budget.
+			budget, limited := h.Budget.HtlcBudget(
+				h.htlc.Amt.ToSatoshis(),
+			)
+			feePref, capped, err := BudgetFeePreference(
+				h.FeeEstimator, sweepConfTarget,
+				inp.WitnessType(), budget, limited,
+			)
+			if err != nil {
+				return nil, err
+			}
+			if capped {
+				log.Infof("%T(%x): capping sweep fee rate at "+
+					"%v due to htlc budget of %v", h,
+					h.htlc.RHash[:], feePref.FeeRate, budget)
+			}
+
 			h.sweepTx, err = h.Sweeper.CreateSweepTx(
-				[]input.Input{&inp},
-				sweep.FeePreference{
-					ConfTarget: sweepConfTarget,
-				}, 0,
+				[]input.Input{&inp}, feePref, 0,
 			)
 			if err != nil {
 				return nil, err
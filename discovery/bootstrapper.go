@@ -272,6 +272,18 @@ func (c *ChannelGraphBootstrapper) Name() string {
 	return "Authenticated Channel Graph"
 }
 
+// DNSResolver resolves the DNS records that DNS seeds are queried for.
+type DNSResolver interface {
+	// LookupHost performs DNS resolution on a given host and returns its
+	// addresses.
+	LookupHost(host string) ([]string, error)
+
+	// LookupSRV tries to resolve an SRV query of the given service,
+	// protocol, and domain name.
+	LookupSRV(service, proto, name string,
+		timeout time.Duration) (string, []*net.SRV, error)
+}
+
 // DNSSeedBootstrapper as an implementation of the NetworkPeerBootstrapper
 // interface which implements peer bootstrapping via a special DNS seed as
 // defined in BOLT-0010. For further details concerning Lightning's current DNS
@@ -288,6 +300,9 @@ type DNSSeedBootstrapper struct {
 	dnsSeeds [][2]string
 	net      tor.Net
 
+	// resolver is used to query the DNS seeds.
+	resolver DNSResolver
+
 	// timeout is the maximum amount of time a dial will wait for a connect to
 	// complete.
 	timeout time.Duration
@@ -303,11 +318,18 @@ var _ NetworkPeerBootstrapper = (*ChannelGraphBootstrapper)(nil)
 // of passed DNS seeds should come in pairs, with the second host name to be
 // used as a fallback for manual TCP resolution in the case of an error
 // receiving the UDP response. The second host should return a single A record
-// with the IP address of the authoritative name server.
+// with the IP address of the authoritative name server. The seeds are queried
+// with the passed resolver, which is usually the passed net itself.
 func NewDNSSeedBootstrapper(
-	seeds [][2]string, net tor.Net,
+	seeds [][2]string, net tor.Net, resolver DNSResolver,
 	timeout time.Duration) NetworkPeerBootstrapper {
-	return &DNSSeedBootstrapper{dnsSeeds: seeds, net: net, timeout: timeout}
+
+	return &DNSSeedBootstrapper{
+		dnsSeeds: seeds,
+		net:      net,
+		resolver: resolver,
+		timeout:  timeout,
+	}
 }
 
 // fallBackSRVLookup attempts to manually query for SRV records we need to
@@ -325,7 +347,7 @@ func (d *DNSSeedBootstrapper) fallBackSRVLookup(soaShim string,
 
 	// First, we'll lookup the IP address of the server that will act as
 	// our shim.
-	addrs, err := d.net.LookupHost(soaShim)
+	addrs, err := d.resolver.LookupHost(soaShim)
 	if err != nil {
 		return nil, err
 	}
@@ -395,7 +417,7 @@ search:
 		// obtain a random sample of the encoded public keys of nodes.
 		// We use the lndLookupSRV function for this task.
 		primarySeed := dnsSeedTuple[0]
-		_, addrs, err := d.net.LookupSRV(
+		_, addrs, err := d.resolver.LookupSRV(
 			"nodes", "tcp", primarySeed, d.timeout,
 		)
 		if err != nil {
@@ -446,7 +468,7 @@ search:
 			// matching bech32 encoded node key. We use the
 			// lndLookup function for this task.
 			bechNodeHost := nodeSrv.Target
-			addrs, err := d.net.LookupHost(bechNodeHost)
+			addrs, err := d.resolver.LookupHost(bechNodeHost)
 			if err != nil {
 				return nil, err
 			}
@@ -0,0 +1,186 @@
+package discovery
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/cryptomeow/lnd/tor"
+	"github.com/miekg/dns"
+)
+
+// dohContentType is the media type of DNS messages sent over HTTPS as defined
+// in RFC 8484.
+const dohContentType = "application/dns-message"
+
+// DoHResolver is a DNSResolver that sends DNS queries to a DNS-over-HTTPS
+// resolver as defined in RFC 8484. As the queries are sent over a regular
+// https connection, they can be routed through a proxy like Tor, which doesn't
+// support the UDP queries of the system resolver.
+type DoHResolver struct {
+	url    string
+	client *http.Client
+}
+
+// A compile time assertion to ensure that DoHResolver meets the DNSResolver
+// interface.
+var _ DNSResolver = (*DoHResolver)(nil)
+
+// NewDoHResolver returns a new DNS-over-HTTPS resolver that queries the
+// resolver at the given https URL. Connections to the resolver are established
+// with the passed dial function, so the name of its host is never resolved
+// locally if the dial function routes connections through Tor.
+func NewDoHResolver(url string, dial tor.DialFunc,
+	timeout time.Duration) *DoHResolver {
+
+	transport := &http.Transport{
+		DialContext: func(_ context.Context, network,
+			address string) (net.Conn, error) {
+
+			return dial(network, address, timeout)
+		},
+		TLSHandshakeTimeout: timeout,
+	}
+
+	return newDoHResolver(url, &http.Client{
+		Transport: transport,
+		Timeout:   timeout,
+	})
+}
+
+// newDoHResolver returns a new DNS-over-HTTPS resolver that uses the given
+// http client.
+func newDoHResolver(url string, client *http.Client) *DoHResolver {
+	return &DoHResolver{
+		url:    url,
+		client: client,
+	}
+}
+
+// query sends a query for the records of the given type of a name to the
+// resolver and returns the records of the answer section of its response.
+func (r *DoHResolver) query(ctx context.Context, name string,
+	qtype uint16) ([]dns.RR, error) {
+
+	// The ID of the query is zero, as recommended by RFC 8484, so the
+	// responses can be cached by the resolver.
+	msg := new(dns.Msg).SetQuestion(dns.Fqdn(name), qtype)
+	msg.Id = 0
+	packed, err := msg.Pack()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(
+		http.MethodPost, r.url, bytes.NewReader(packed),
+	)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", dohContentType)
+	req.Header.Set("Accept", dohContentType)
+
+	resp, err := r.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("dns-over-https request failed: %v",
+			err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("dns-over-https resolver returned "+
+			"status %v", resp.Status)
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, dns.MaxMsgSize))
+	if err != nil {
+		return nil, err
+	}
+
+	reply := new(dns.Msg)
+	if err := reply.Unpack(body); err != nil {
+		return nil, fmt.Errorf("invalid dns-over-https response: %v",
+			err)
+	}
+
+	if reply.Rcode != dns.RcodeSuccess {
+		return nil, fmt.Errorf("unable to query %v records of %v: %v",
+			dns.TypeToString[qtype], name,
+			dns.RcodeToString[reply.Rcode])
+	}
+
+	return reply.Answer, nil
+}
+
+// LookupHost resolves the IPv4 addresses of a host, or its IPv6 addresses if
+// it doesn't have any IPv4 address.
+//
+// NOTE: Part of the DNSResolver interface.
+func (r *DoHResolver) LookupHost(host string) ([]string, error) {
+	if ip := net.ParseIP(host); ip != nil {
+		return []string{host}, nil
+	}
+
+	var addrs []string
+	for _, qtype := range []uint16{dns.TypeA, dns.TypeAAAA} {
+		answer, err := r.query(context.Background(), host, qtype)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, rr := range answer {
+			switch rr := rr.(type) {
+			case *dns.A:
+				addrs = append(addrs, rr.A.String())
+			case *dns.AAAA:
+				addrs = append(addrs, rr.AAAA.String())
+			}
+		}
+
+		if len(addrs) > 0 {
+			return addrs, nil
+		}
+	}
+
+	return nil, errors.New("no addresses found for " + host)
+}
+
+// LookupSRV resolves an SRV query of the given service, protocol, and domain
+// name.
+//
+// NOTE: Part of the DNSResolver interface.
+func (r *DoHResolver) LookupSRV(service, proto, name string,
+	timeout time.Duration) (string, []*net.SRV, error) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	host := fmt.Sprintf("_%s._%s.%s", service, proto, name)
+	answer, err := r.query(ctx, host, dns.TypeSRV)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var rrs []*net.SRV
+	for _, rr := range answer {
+		srv, ok := rr.(*dns.SRV)
+		if !ok {
+			continue
+		}
+
+		rrs = append(rrs, &net.SRV{
+			Target:   srv.Target,
+			Port:     srv.Port,
+			Priority: srv.Priority,
+			Weight:   srv.Weight,
+		})
+	}
+
+	return "", rrs, nil
+}
@@ -0,0 +1,101 @@
+package discovery
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcutil/bech32"
+	"github.com/cryptomeow/lnd/tor"
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDoHSeedBootstrapper asserts that peers are bootstrapped from a DNS seed
+// that is queried through a DNS-over-HTTPS resolver.
+func TestDoHSeedBootstrapper(t *testing.T) {
+	t.Parallel()
+
+	privKey, err := btcec.NewPrivateKey(btcec.S256())
+	require.NoError(t, err)
+
+	data, err := bech32.ConvertBits(
+		privKey.PubKey().SerializeCompressed(), 8, 5, true,
+	)
+	require.NoError(t, err)
+	bechNode, err := bech32.Encode("ln", data)
+	require.NoError(t, err)
+	nodeHost := bechNode + ".seed.example.com."
+
+	server := httptest.NewTLSServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodPost, r.Method)
+			require.Equal(
+				t, dohContentType, r.Header.Get("Content-Type"),
+			)
+
+			body, err := ioutil.ReadAll(r.Body)
+			require.NoError(t, err)
+
+			var msg dns.Msg
+			require.NoError(t, msg.Unpack(body))
+			question := msg.Question[0]
+
+			reply := new(dns.Msg).SetReply(&msg)
+			hdr := dns.RR_Header{
+				Name:   question.Name,
+				Rrtype: question.Qtype,
+				Class:  dns.ClassINET,
+			}
+			switch {
+			case question.Name == "_nodes._tcp.seed.example.com." &&
+				question.Qtype == dns.TypeSRV:
+
+				reply.Answer = append(reply.Answer, &dns.SRV{
+					Hdr:    hdr,
+					Target: nodeHost,
+					Port:   9735,
+				})
+
+			case question.Name == nodeHost &&
+				question.Qtype == dns.TypeA:
+
+				reply.Answer = append(reply.Answer, &dns.A{
+					Hdr: hdr,
+					A:   net.ParseIP("1.2.3.4"),
+				})
+
+			default:
+				reply.Rcode = dns.RcodeNameError
+			}
+
+			packed, err := reply.Pack()
+			require.NoError(t, err)
+
+			w.Header().Set("Content-Type", dohContentType)
+			_, err = w.Write(packed)
+			require.NoError(t, err)
+		},
+	))
+	defer server.Close()
+
+	resolver := newDoHResolver(server.URL, server.Client())
+
+	// Hosts without records can't be resolved.
+	_, err = resolver.LookupHost("unknown.example.com")
+	require.Error(t, err)
+
+	bootstrapper := NewDNSSeedBootstrapper(
+		[][2]string{{"seed.example.com", ""}}, &tor.ClearNet{},
+		resolver, time.Second,
+	)
+	addrs, err := bootstrapper.SampleNodeAddrs(1, nil)
+	require.NoError(t, err)
+	require.Len(t, addrs, 1)
+	require.True(t, addrs[0].IdentityKey.IsEqual(privKey.PubKey()))
+	require.Equal(t, "1.2.3.4:9735", addrs[0].Address.String())
+}
@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
-	"runtime"
 	"sync"
 	"time"
 
@@ -229,6 +228,13 @@ type Config struct {
 	// This prevents ranges with old start times from causing us to dump the
 	// graph on connect.
 	IgnoreHistoricalFilters bool
+
+	// NumValidationWorkers is the maximum number of announcements that
+	// are processed concurrently. Announcements that depend on each other,
+	// such as a channel update and the announcement of its channel, are
+	// still processed in the order they were received. If zero,
+	// routing.DefaultNumValidationWorkers is used.
+	NumValidationWorkers int
 }
 
 // AuthenticatedGossiper is a subsystem which is responsible for receiving
@@ -904,9 +910,11 @@ func (d *AuthenticatedGossiper) networkHandler() {
 
 	// We'll use this validation to ensure that we process jobs in their
 	// dependency order during parallel validation.
-	validationBarrier := routing.NewValidationBarrier(
-		runtime.NumCPU()*4, d.quit,
-	)
+	numWorkers := d.cfg.NumValidationWorkers
+	if numWorkers <= 0 {
+		numWorkers = routing.DefaultNumValidationWorkers
+	}
+	validationBarrier := routing.NewValidationBarrier(numWorkers, d.quit)
 
 	for {
 		select {
@@ -1858,7 +1866,10 @@ func (d *AuthenticatedGossiper) processNetworkAnnouncement(
 			// before marking it live, we'll make sure it has been
 			// signed by the correct party. The least-significant
 			// bit in the flag on the channel update tells us which
-			// edge is being updated.
+			// edge is being updated. Compacted zombie entries don't
+			// store the keys of the nodes, so their updates can't
+			// be verified until the channel announcement comes
+			// through again.
 			var pubKey *btcec.PublicKey
 			switch {
 			case msg.ChannelFlags&lnwire.ChanUpdateDirection == 0:
@@ -1867,19 +1878,24 @@ func (d *AuthenticatedGossiper) processNetworkAnnouncement(
 				pubKey, _ = chanInfo.NodeKey2()
 			}
 
-			err := routing.VerifyChannelUpdateSignature(msg, pubKey)
-			if err != nil {
-				err := fmt.Errorf("unable to verify channel "+
-					"update signature: %v", err)
-				log.Error(err)
-				nMsg.err <- err
-				return nil
+			if pubKey != nil {
+				err := routing.VerifyChannelUpdateSignature(
+					msg, pubKey,
+				)
+				if err != nil {
+					err := fmt.Errorf("unable to verify "+
+						"channel update signature: %v",
+						err)
+					log.Error(err)
+					nMsg.err <- err
+					return nil
+				}
 			}
 
 			// With the signature valid, we'll proceed to mark the
 			// edge as live and wait for the channel announcement to
 			// come through again.
-			err = d.cfg.Router.MarkEdgeLive(msg.ShortChannelID)
+			err := d.cfg.Router.MarkEdgeLive(msg.ShortChannelID)
 			if err != nil {
 				err := fmt.Errorf("unable to remove edge with "+
 					"chan_id=%v from zombie index: %v",
@@ -0,0 +1,498 @@
+// Package eventbus provides a node-wide event log. Channel, peer, invoice and
+// htlc events are written to a persistent log in which every event is assigned
+// a monotonically increasing sequence number. Clients can subscribe to the
+// log and pass the sequence number of the last event they received to replay
+// all events they missed, for example while they were disconnected.
+package eventbus
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/cryptomeow/lnd/channeldb"
+	"github.com/cryptomeow/lnd/channelnotifier"
+	"github.com/cryptomeow/lnd/clock"
+	"github.com/cryptomeow/lnd/htlcswitch"
+	"github.com/cryptomeow/lnd/lnwire"
+	"github.com/cryptomeow/lnd/peernotifier"
+	"github.com/cryptomeow/lnd/subscribe"
+	"github.com/cryptomeow/lnd/zpay32"
+)
+
+// DefaultMaxEvents is the default number of events that are retained in the
+// event log. Older events are pruned when new events are added.
+const DefaultMaxEvents = 100000
+
+// ErrEventBusShuttingDown is returned when a subscription is requested while
+// the event bus is shutting down.
+var ErrEventBusShuttingDown = errors.New("event bus shutting down")
+
+// Config provides the event bus with the event sources it records and the
+// functions required to persist them.
+type Config struct {
+	// SubscribeChannelEvents provides a subscription client which provides
+	// a stream of channel events.
+	SubscribeChannelEvents func() (subscribe.Subscription, error)
+
+	// SubscribePeerEvents provides a subscription client which provides a
+	// stream of peer online/offline events.
+	SubscribePeerEvents func() (subscribe.Subscription, error)
+
+	// SubscribeHtlcEvents provides a subscription client which provides a
+	// stream of htlc events.
+	SubscribeHtlcEvents func() (subscribe.Subscription, error)
+
+	// SubscribeInvoices provides a subscription to newly added and settled
+	// invoices.
+	SubscribeInvoices func() (*InvoiceSubscription, error)
+
+	// AddEvent persists the given event in the event log and assigns it
+	// its sequence number.
+	AddEvent func(*channeldb.NodeEvent) error
+
+	// FetchEvents returns all events of the event log with a sequence
+	// number greater than the given one.
+	FetchEvents func(afterSeqNum uint64) ([]*channeldb.NodeEvent, error)
+
+	// ChainParams are the parameters of the active chain, which are
+	// required to decode the payment hash of invoices that don't have a
+	// preimage yet.
+	ChainParams *chaincfg.Params
+
+	// Clock is the time source that the event bus uses to timestamp
+	// events, provided here for ease of testing.
+	Clock clock.Clock
+}
+
+// InvoiceSubscription is a subscription to newly added and settled invoices.
+type InvoiceSubscription struct {
+	// NewInvoices delivers all newly added invoices.
+	NewInvoices <-chan *channeldb.Invoice
+
+	// SettledInvoices delivers all newly settled invoices.
+	SettledInvoices <-chan *channeldb.Invoice
+
+	// Cancel cancels the subscription.
+	Cancel func()
+}
+
+// EventBus records all channel, peer, invoice and htlc events of the node in
+// a persistent event log and dispatches them to its subscribers.
+type EventBus struct {
+	started sync.Once
+	stopped sync.Once
+
+	cfg *Config
+
+	ntfnServer *subscribe.Server
+
+	// writeMtx serializes writing events to the log and dispatching them
+	// to the notification server. This ensures that live events are
+	// delivered in order, and that a new subscription can read the backlog
+	// of the log without missing or duplicating any live event.
+	writeMtx sync.Mutex
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// Subscription is a subscription to the event log. It consists of the
+// backlog of events the subscriber missed, and a client that delivers all new
+// events as *channeldb.NodeEvent.
+type Subscription struct {
+	// Backlog holds all events after the cursor of the subscriber that
+	// were already written to the log when the subscription was created.
+	// They should be processed before any update of the client.
+	Backlog []*channeldb.NodeEvent
+
+	*subscribe.Client
+}
+
+// New creates a new event bus. Note that this function does not start
+// recording events, Start() must be called.
+func New(cfg *Config) *EventBus {
+	return &EventBus{
+		cfg:        cfg,
+		ntfnServer: subscribe.NewServer(),
+		quit:       make(chan struct{}),
+	}
+}
+
+// Start subscribes to all event sources and starts recording their events.
+func (b *EventBus) Start() error {
+	var err error
+	b.started.Do(func() {
+		log.Info("EventBus starting")
+		err = b.start()
+	})
+	return err
+}
+
+// start subscribes to all event sources and launches the goroutine that
+// consumes their events. If any subscription fails, all existing
+// subscriptions are canceled.
+func (b *EventBus) start() error {
+	if err := b.ntfnServer.Start(); err != nil {
+		return err
+	}
+
+	channelClient, err := b.cfg.SubscribeChannelEvents()
+	if err != nil {
+		return err
+	}
+
+	peerClient, err := b.cfg.SubscribePeerEvents()
+	if err != nil {
+		channelClient.Cancel()
+		return err
+	}
+
+	htlcClient, err := b.cfg.SubscribeHtlcEvents()
+	if err != nil {
+		channelClient.Cancel()
+		peerClient.Cancel()
+		return err
+	}
+
+	invoiceClient, err := b.cfg.SubscribeInvoices()
+	if err != nil {
+		channelClient.Cancel()
+		peerClient.Cancel()
+		htlcClient.Cancel()
+		return err
+	}
+
+	b.wg.Add(1)
+	go b.consume(channelClient, peerClient, htlcClient, invoiceClient)
+
+	return nil
+}
+
+// Stop signals the event bus for a graceful shutdown.
+func (b *EventBus) Stop() {
+	b.stopped.Do(func() {
+		log.Info("Stopping EventBus")
+
+		close(b.quit)
+		b.wg.Wait()
+
+		if err := b.ntfnServer.Stop(); err != nil {
+			log.Warnf("Unable to stop notification server: %v", err)
+		}
+	})
+}
+
+// SubscribeEvents returns a subscription to the event log. If afterSeqNum is
+// non-zero, the subscription's backlog contains all events with a greater
+// sequence number that are still retained in the log. Subscribers should
+// compare the sequence number of the first event they receive with their
+// cursor to detect events that were already pruned.
+func (b *EventBus) SubscribeEvents(afterSeqNum uint64) (*Subscription,
+	error) {
+
+	select {
+	case <-b.quit:
+		return nil, ErrEventBusShuttingDown
+	default:
+	}
+
+	// We hold the write mutex while we subscribe and read the backlog, so
+	// that every event is either part of the backlog or delivered by the
+	// client, but never both.
+	b.writeMtx.Lock()
+	defer b.writeMtx.Unlock()
+
+	client, err := b.ntfnServer.Subscribe()
+	if err != nil {
+		return nil, err
+	}
+
+	var backlog []*channeldb.NodeEvent
+	if afterSeqNum != 0 {
+		backlog, err = b.cfg.FetchEvents(afterSeqNum)
+		if err != nil {
+			client.Cancel()
+			return nil, err
+		}
+	}
+
+	return &Subscription{
+		Backlog: backlog,
+		Client:  client,
+	}, nil
+}
+
+// consume records the events of all sources until the event bus is stopped.
+//
+// NOTE: This MUST be run as a goroutine.
+func (b *EventBus) consume(channelClient, peerClient,
+	htlcClient subscribe.Subscription,
+	invoiceClient *InvoiceSubscription) {
+
+	defer b.wg.Done()
+	defer channelClient.Cancel()
+	defer peerClient.Cancel()
+	defer htlcClient.Cancel()
+	defer invoiceClient.Cancel()
+
+	for {
+		var event *channeldb.NodeEvent
+		select {
+		case e, ok := <-channelClient.Updates():
+			if !ok {
+				log.Warnf("channel event subscription closed")
+				return
+			}
+			event = channelEvent(e)
+
+		case e, ok := <-peerClient.Updates():
+			if !ok {
+				log.Warnf("peer event subscription closed")
+				return
+			}
+			event = peerEvent(e)
+
+		case e, ok := <-htlcClient.Updates():
+			if !ok {
+				log.Warnf("htlc event subscription closed")
+				return
+			}
+			event = htlcEvent(e)
+
+		case invoice := <-invoiceClient.NewInvoices:
+			event = b.invoiceEvent(
+				channeldb.NodeEventInvoiceAdded, invoice,
+			)
+
+		case invoice := <-invoiceClient.SettledInvoices:
+			event = b.invoiceEvent(
+				channeldb.NodeEventInvoiceSettled, invoice,
+			)
+
+		case <-b.quit:
+			return
+		}
+
+		// Not all events of our sources are recorded.
+		if event == nil {
+			continue
+		}
+
+		b.record(event)
+	}
+}
+
+// record timestamps the event, writes it to the event log and dispatches it
+// to all subscribers.
+func (b *EventBus) record(event *channeldb.NodeEvent) {
+	b.writeMtx.Lock()
+	defer b.writeMtx.Unlock()
+
+	event.Timestamp = b.cfg.Clock.Now()
+	if err := b.cfg.AddEvent(event); err != nil {
+		log.Errorf("Unable to add %v event to log: %v", event.Type,
+			err)
+		return
+	}
+
+	log.Tracef("Recorded %v event with sequence number %v", event.Type,
+		event.SeqNum)
+
+	if err := b.ntfnServer.SendUpdate(event); err != nil {
+		log.Warnf("Unable to send %v event update: %v", event.Type,
+			err)
+	}
+}
+
+// channelEvent converts an event of the channel notifier into a node event.
+// It returns nil for events that aren't recorded.
+func channelEvent(e interface{}) *channeldb.NodeEvent {
+	switch event := e.(type) {
+	case channelnotifier.PendingOpenChannelEvent:
+		return &channeldb.NodeEvent{
+			Type:      channeldb.NodeEventChannelPendingOpen,
+			ChanPoint: *event.ChannelPoint,
+		}
+
+	case channelnotifier.OpenChannelEvent:
+		if event.Channel == nil {
+			return nil
+		}
+
+		return &channeldb.NodeEvent{
+			Type:      channeldb.NodeEventChannelOpen,
+			ChanPoint: event.Channel.FundingOutpoint,
+		}
+
+	case channelnotifier.ActiveChannelEvent:
+		return &channeldb.NodeEvent{
+			Type:      channeldb.NodeEventChannelActive,
+			ChanPoint: *event.ChannelPoint,
+		}
+
+	case channelnotifier.InactiveChannelEvent:
+		return &channeldb.NodeEvent{
+			Type:      channeldb.NodeEventChannelInactive,
+			ChanPoint: *event.ChannelPoint,
+		}
+
+	case channelnotifier.ClosedChannelEvent:
+		if event.CloseSummary == nil {
+			return nil
+		}
+
+		return &channeldb.NodeEvent{
+			Type:      channeldb.NodeEventChannelClosed,
+			ChanPoint: event.CloseSummary.ChanPoint,
+		}
+
+	case channelnotifier.PrunedReservationEvent:
+		reason := "wallet funded"
+		if event.Psbt {
+			reason = "psbt funded"
+		}
+
+		return &channeldb.NodeEvent{
+			Type:   channeldb.NodeEventReservationPruned,
+			PubKey: event.PeerPubKey,
+			Amount: lnwire.NewMSatFromSatoshis(event.Capacity),
+			Reason: reason,
+		}
+
+	case channelnotifier.FundingLockedStalledEvent:
+		return &channeldb.NodeEvent{
+			Type:      channeldb.NodeEventFundingLockedStalled,
+			ChanPoint: event.ChannelPoint,
+			PubKey:    event.PeerPubKey,
+			Reason: fmt.Sprintf(
+				"%v failed attempts", event.Attempts,
+			),
+		}
+
+	// Active link events are an implementation detail of the switch that
+	// is always followed by an active channel event.
+	case channelnotifier.ActiveLinkEvent:
+		return nil
+
+	default:
+		log.Warnf("Unexpected channel event type: %T", e)
+		return nil
+	}
+}
+
+// peerEvent converts an event of the peer notifier into a node event.
+func peerEvent(e interface{}) *channeldb.NodeEvent {
+	switch event := e.(type) {
+	case peernotifier.PeerOnlineEvent:
+		return &channeldb.NodeEvent{
+			Type:   channeldb.NodeEventPeerOnline,
+			PubKey: event.PubKey,
+		}
+
+	case peernotifier.PeerOfflineEvent:
+		return &channeldb.NodeEvent{
+			Type:   channeldb.NodeEventPeerOffline,
+			PubKey: event.PubKey,
+			Reason: event.EvictionReason,
+		}
+
+	default:
+		log.Warnf("Unexpected peer event type: %T", e)
+		return nil
+	}
+}
+
+// htlcEvent converts an event of the htlc notifier into a node event.
+func htlcEvent(e interface{}) *channeldb.NodeEvent {
+	switch event := e.(type) {
+	case *htlcswitch.ForwardingEvent:
+		return &channeldb.NodeEvent{
+			Type:            channeldb.NodeEventHtlcForward,
+			Amount:          htlcAmount(event.HtlcInfo),
+			IncomingCircuit: event.IncomingCircuit,
+			OutgoingCircuit: event.OutgoingCircuit,
+		}
+
+	case *htlcswitch.ForwardingFailEvent:
+		return &channeldb.NodeEvent{
+			Type:            channeldb.NodeEventHtlcForwardFail,
+			IncomingCircuit: event.IncomingCircuit,
+			OutgoingCircuit: event.OutgoingCircuit,
+		}
+
+	case *htlcswitch.LinkFailEvent:
+		var reason string
+		if event.LinkError != nil {
+			reason = event.LinkError.Error()
+		}
+
+		return &channeldb.NodeEvent{
+			Type:            channeldb.NodeEventHtlcLinkFail,
+			Amount:          htlcAmount(event.HtlcInfo),
+			IncomingCircuit: event.IncomingCircuit,
+			OutgoingCircuit: event.OutgoingCircuit,
+			Reason:          reason,
+		}
+
+	case *htlcswitch.SettleEvent:
+		return &channeldb.NodeEvent{
+			Type:            channeldb.NodeEventHtlcSettle,
+			IncomingCircuit: event.IncomingCircuit,
+			OutgoingCircuit: event.OutgoingCircuit,
+		}
+
+	default:
+		log.Warnf("Unexpected htlc event type: %T", e)
+		return nil
+	}
+}
+
+// htlcAmount returns the outgoing amount of an htlc, or the incoming amount
+// if the htlc doesn't have an outgoing channel, as is the case for receives.
+func htlcAmount(info htlcswitch.HtlcInfo) lnwire.MilliSatoshi {
+	if info.OutgoingAmt != 0 {
+		return info.OutgoingAmt
+	}
+
+	return info.IncomingAmt
+}
+
+// invoiceEvent converts an added or settled invoice into a node event.
+func (b *EventBus) invoiceEvent(eventType channeldb.NodeEventType,
+	invoice *channeldb.Invoice) *channeldb.NodeEvent {
+
+	event := &channeldb.NodeEvent{
+		Type:   eventType,
+		Amount: invoice.Terms.Value,
+	}
+	if eventType == channeldb.NodeEventInvoiceSettled {
+		event.Amount = invoice.AmtPaid
+	}
+
+	// The payment hash isn't stored with the invoice. For invoices that
+	// have a preimage we can derive it, hold invoices that weren't settled
+	// yet only have it in their payment request.
+	switch {
+	case invoice.Terms.PaymentPreimage != nil:
+		event.PaymentHash = invoice.Terms.PaymentPreimage.Hash()
+
+	case len(invoice.PaymentRequest) > 0:
+		payReq, err := zpay32.Decode(
+			string(invoice.PaymentRequest), b.cfg.ChainParams,
+		)
+		if err != nil {
+			log.Warnf("Unable to decode payment request of "+
+				"invoice with add index %v: %v",
+				invoice.AddIndex, err)
+			break
+		}
+
+		if payReq.PaymentHash != nil {
+			event.PaymentHash = *payReq.PaymentHash
+		}
+	}
+
+	return event
+}
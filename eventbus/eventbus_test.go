@@ -0,0 +1,203 @@
+package eventbus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/cryptomeow/lnd/channeldb"
+	"github.com/cryptomeow/lnd/channelnotifier"
+	"github.com/cryptomeow/lnd/clock"
+	"github.com/cryptomeow/lnd/htlcswitch"
+	"github.com/cryptomeow/lnd/lntypes"
+	"github.com/cryptomeow/lnd/lnwire"
+	"github.com/cryptomeow/lnd/peernotifier"
+	"github.com/cryptomeow/lnd/subscribe"
+	"github.com/stretchr/testify/require"
+)
+
+// testCtx holds the event sources of an event bus under test.
+type testCtx struct {
+	t *testing.T
+
+	bus *EventBus
+
+	channelEvents *subscribe.Server
+	peerEvents    *subscribe.Server
+	htlcEvents    *subscribe.Server
+
+	newInvoices     chan *channeldb.Invoice
+	settledInvoices chan *channeldb.Invoice
+}
+
+// newTestCtx creates and starts an event bus that is backed by a test
+// database and test event sources.
+func newTestCtx(t *testing.T) (*testCtx, func()) {
+	db, cleanupDB, err := channeldb.MakeTestDB()
+	require.NoError(t, err)
+
+	ctx := &testCtx{
+		t:               t,
+		channelEvents:   subscribe.NewServer(),
+		peerEvents:      subscribe.NewServer(),
+		htlcEvents:      subscribe.NewServer(),
+		newInvoices:     make(chan *channeldb.Invoice),
+		settledInvoices: make(chan *channeldb.Invoice),
+	}
+	require.NoError(t, ctx.channelEvents.Start())
+	require.NoError(t, ctx.peerEvents.Start())
+	require.NoError(t, ctx.htlcEvents.Start())
+
+	ctx.bus = New(&Config{
+		SubscribeChannelEvents: func() (subscribe.Subscription, error) {
+			return ctx.channelEvents.Subscribe()
+		},
+		SubscribePeerEvents: func() (subscribe.Subscription, error) {
+			return ctx.peerEvents.Subscribe()
+		},
+		SubscribeHtlcEvents: func() (subscribe.Subscription, error) {
+			return ctx.htlcEvents.Subscribe()
+		},
+		SubscribeInvoices: func() (*InvoiceSubscription, error) {
+			return &InvoiceSubscription{
+				NewInvoices:     ctx.newInvoices,
+				SettledInvoices: ctx.settledInvoices,
+				Cancel:          func() {},
+			}, nil
+		},
+		AddEvent: func(event *channeldb.NodeEvent) error {
+			return db.AddNodeEvent(event, DefaultMaxEvents)
+		},
+		FetchEvents: db.FetchNodeEvents,
+		ChainParams: &chaincfg.RegressionNetParams,
+		Clock:       clock.NewTestClock(time.Unix(1000, 0)),
+	})
+	require.NoError(t, ctx.bus.Start())
+
+	cleanup := func() {
+		ctx.bus.Stop()
+		_ = ctx.channelEvents.Stop()
+		_ = ctx.peerEvents.Stop()
+		_ = ctx.htlcEvents.Stop()
+		cleanupDB()
+	}
+
+	return ctx, cleanup
+}
+
+// receiveEvent waits for the next event of the given subscription and asserts
+// its sequence number and type.
+func (c *testCtx) receiveEvent(sub *Subscription, seqNum uint64,
+	eventType channeldb.NodeEventType) *channeldb.NodeEvent {
+
+	select {
+	case update := <-sub.Updates():
+		event, ok := update.(*channeldb.NodeEvent)
+		require.True(c.t, ok)
+		require.Equal(c.t, seqNum, event.SeqNum)
+		require.Equal(c.t, eventType, event.Type)
+
+		return event
+
+	case <-time.After(time.Second):
+		c.t.Fatalf("event %v not received", seqNum)
+		return nil
+	}
+}
+
+// TestEventBus tests that events of all sources are recorded with increasing
+// sequence numbers, and that subscribers can replay the events they missed.
+func TestEventBus(t *testing.T) {
+	ctx, cleanup := newTestCtx(t)
+	defer cleanup()
+
+	sub, err := ctx.bus.SubscribeEvents(0)
+	require.NoError(t, err)
+	defer sub.Cancel()
+	require.Empty(t, sub.Backlog)
+
+	// Active link events aren't recorded, so the active channel event is
+	// the first event in the log.
+	chanPoint := wire.OutPoint{Index: 1}
+	require.NoError(t, ctx.channelEvents.SendUpdate(
+		channelnotifier.ActiveLinkEvent{ChannelPoint: &chanPoint},
+	))
+	require.NoError(t, ctx.channelEvents.SendUpdate(
+		channelnotifier.ActiveChannelEvent{ChannelPoint: &chanPoint},
+	))
+	event := ctx.receiveEvent(sub, 1, channeldb.NodeEventChannelActive)
+	require.Equal(t, chanPoint, event.ChanPoint)
+	require.Equal(t, time.Unix(1000, 0), event.Timestamp)
+
+	pubKey := [33]byte{2, 1}
+	require.NoError(t, ctx.peerEvents.SendUpdate(
+		peernotifier.PeerOfflineEvent{
+			PubKey:         pubKey,
+			EvictionReason: "ping timeout",
+		},
+	))
+	event = ctx.receiveEvent(sub, 2, channeldb.NodeEventPeerOffline)
+	require.Equal(t, pubKey, event.PubKey)
+	require.Equal(t, "ping timeout", event.Reason)
+
+	htlcKey := htlcswitch.HtlcKey{
+		IncomingCircuit: channeldb.CircuitKey{
+			ChanID: lnwire.NewShortChanIDFromInt(1),
+			HtlcID: 2,
+		},
+		OutgoingCircuit: channeldb.CircuitKey{
+			ChanID: lnwire.NewShortChanIDFromInt(3),
+			HtlcID: 4,
+		},
+	}
+	require.NoError(t, ctx.htlcEvents.SendUpdate(
+		&htlcswitch.ForwardingEvent{
+			HtlcKey: htlcKey,
+			HtlcInfo: htlcswitch.HtlcInfo{
+				IncomingAmt: 1100,
+				OutgoingAmt: 1000,
+			},
+			HtlcEventType: htlcswitch.HtlcEventTypeForward,
+		},
+	))
+	event = ctx.receiveEvent(sub, 3, channeldb.NodeEventHtlcForward)
+	require.Equal(t, htlcKey.IncomingCircuit, event.IncomingCircuit)
+	require.Equal(t, htlcKey.OutgoingCircuit, event.OutgoingCircuit)
+	require.Equal(t, lnwire.MilliSatoshi(1000), event.Amount)
+
+	preimage := lntypes.Preimage{1, 2, 3}
+	invoice := &channeldb.Invoice{
+		Terms: channeldb.ContractTerm{
+			Value:           2000,
+			PaymentPreimage: &preimage,
+		},
+		AmtPaid: 2100,
+	}
+	ctx.settledInvoices <- invoice
+	event = ctx.receiveEvent(sub, 4, channeldb.NodeEventInvoiceSettled)
+	require.Equal(t, [32]byte(preimage.Hash()), event.PaymentHash)
+	require.Equal(t, lnwire.MilliSatoshi(2100), event.Amount)
+
+	// A subscriber that reconnects with a cursor first receives all events
+	// after the cursor as backlog, followed by new events.
+	replaySub, err := ctx.bus.SubscribeEvents(2)
+	require.NoError(t, err)
+	defer replaySub.Cancel()
+
+	require.Len(t, replaySub.Backlog, 2)
+	require.Equal(t, uint64(3), replaySub.Backlog[0].SeqNum)
+	require.Equal(
+		t, channeldb.NodeEventHtlcForward, replaySub.Backlog[0].Type,
+	)
+	require.Equal(t, uint64(4), replaySub.Backlog[1].SeqNum)
+	require.Equal(
+		t, channeldb.NodeEventInvoiceSettled, replaySub.Backlog[1].Type,
+	)
+
+	require.NoError(t, ctx.peerEvents.SendUpdate(
+		peernotifier.PeerOnlineEvent{PubKey: pubKey},
+	))
+	ctx.receiveEvent(sub, 5, channeldb.NodeEventPeerOnline)
+	ctx.receiveEvent(replaySub, 5, channeldb.NodeEventPeerOnline)
+}
@@ -85,6 +85,12 @@ func newManager(cfg Config, desc setDesc) (*Manager, error) {
 		if cfg.NoAnchors {
 			raw.Unset(lnwire.AnchorsOptional)
 			raw.Unset(lnwire.AnchorsRequired)
+
+			// Channels can only be upgraded to anchor
+			// commitments, so there's nothing to upgrade to
+			// without them.
+			raw.Unset(lnwire.ChannelUpgradeOptional)
+			raw.Unset(lnwire.ChannelUpgradeRequired)
 		}
 		if cfg.NoWumbo {
 			raw.Unset(lnwire.WumboChannelsOptional)
@@ -2,6 +2,7 @@ package lnd
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"encoding/binary"
 	"fmt"
 	"sync"
@@ -19,6 +20,7 @@ import (
 	"github.com/cryptomeow/lnd/chanacceptor"
 	"github.com/cryptomeow/lnd/channeldb"
 	"github.com/cryptomeow/lnd/channeldb/kvdb"
+	"github.com/cryptomeow/lnd/channelnotifier"
 	"github.com/cryptomeow/lnd/discovery"
 	"github.com/cryptomeow/lnd/htlcswitch"
 	"github.com/cryptomeow/lnd/input"
@@ -28,9 +30,11 @@ import (
 	"github.com/cryptomeow/lnd/lnrpc"
 	"github.com/cryptomeow/lnd/lnwallet"
 	"github.com/cryptomeow/lnd/lnwallet/chainfee"
+	"github.com/cryptomeow/lnd/lnwallet/chancloser"
 	"github.com/cryptomeow/lnd/lnwallet/chanfunding"
 	"github.com/cryptomeow/lnd/lnwire"
 	"github.com/cryptomeow/lnd/routing"
+	"github.com/cryptomeow/lnd/routing/route"
 	"golang.org/x/crypto/salsa20"
 )
 
@@ -38,45 +42,47 @@ const (
 	// TODO(roasbeef): tune
 	msgBufferSize = 50
 
-	// minBtcRemoteDelay and maxBtcRemoteDelay is the extremes of the
-	// Bitcoin CSV delay we will require the remote to use for its
-	// commitment transaction. The actual delay we will require will be
-	// somewhere between these values, depending on channel size.
-	minBtcRemoteDelay uint16 = 144
-	maxBtcRemoteDelay uint16 = 2016
-
-	// minLtcRemoteDelay and maxLtcRemoteDelay is the extremes of the
-	// Litecoin CSV delay we will require the remote to use for its
-	// commitment transaction. The actual delay we will require will be
-	// somewhere between these values, depending on channel size.
-	minLtcRemoteDelay uint16 = 576
-	maxLtcRemoteDelay uint16 = 8064
-
 	// maxWaitNumBlocksFundingConf is the maximum number of blocks to wait
 	// for the funding transaction to be confirmed before forgetting
 	// channels that aren't initiated by us. 2016 blocks is ~2 weeks.
 	maxWaitNumBlocksFundingConf = 2016
 
+	// minFundingConfWait is the minimum time the funding transaction of a
+	// channel we didn't initiate is given to confirm before we forget the
+	// channel. maxWaitNumBlocksFundingConf must correspond to at least
+	// this duration on the active chain.
+	minFundingConfWait = 24 * time.Hour
+
+	// trustedPeerNumConfs is the number of confirmations we require for
+	// channels extended to us by trusted peers. Channels can't be used
+	// before their funding transaction confirmed, so this is the lowest
+	// possible value.
+	trustedPeerNumConfs = 1
+
 	// minChanFundingSize is the smallest channel that we'll allow to be
 	// created over the RPC interface.
 	minChanFundingSize = btcutil.Amount(20000)
 
 	// MaxBtcFundingAmount is a soft-limit of the maximum channel size
 	// currently accepted on the Bitcoin chain within the Lightning
-	// Protocol. This limit is defined in BOLT-0002, and serves as an
-	// initial precautionary limit while implementations are battle tested
-	// in the real world.
-	MaxBtcFundingAmount = btcutil.Amount(1<<24) - 1
+	// Protocol.
+	MaxBtcFundingAmount = chainreg.MaxBtcFundingAmount
+
+	// maxRejectedOpenAttempts is the maximum number of rejected inbound
+	// channel open attempts of a single peer that are recorded in the
+	// audit log within openAttemptWindowSize. Any further rejections
+	// within the window are only logged, so a peer can't fill up our
+	// database by spamming open_channel messages.
+	maxRejectedOpenAttempts = 10
+
+	// openAttemptWindowSize is the duration of the window in which at most
+	// maxRejectedOpenAttempts rejections per peer are recorded.
+	openAttemptWindowSize = time.Hour
 
 	// MaxBtcFundingAmountWumbo is a soft-limit on the maximum size of wumbo
 	// channels. This limit is 10 BTC and is the only thing standing between
 	// you and limitless channel size (apart from 21 million cap)
 	MaxBtcFundingAmountWumbo = btcutil.Amount(1000000000)
-
-	// maxLtcFundingAmount is a soft-limit of the maximum channel size
-	// currently accepted on the Litecoin chain within the Lightning
-	// Protocol.
-	maxLtcFundingAmount = MaxBtcFundingAmount * chainreg.BtcToLtcConversionRate
 )
 
 var (
@@ -85,8 +91,9 @@ var (
 	// defined in BOLT-0002, and serves as an initial precautionary limit
 	// while implementations are battle tested in the real world.
 	//
-	// At the moment, this value depends on which chain is active. It is set
-	// to the value under the Bitcoin chain as default.
+	// At the moment, this value depends on which chain is active and is
+	// taken from the policy of the primary chain. It is set to the value
+	// under the Bitcoin chain as default.
 	//
 	// TODO(roasbeef): add command line param to modify
 	MaxFundingAmount = MaxBtcFundingAmount
@@ -108,9 +115,45 @@ var (
 	errUpfrontShutdownScriptNotSupported = errors.New("peer does not support" +
 		"option upfront shutdown script")
 
+	// ErrWalletNotSynced is returned when attempting to open a channel
+	// before the wallet has synced to the tip of the chain.
+	ErrWalletNotSynced = errors.New("channels cannot be created before " +
+		"the wallet is fully synced")
+
+	// ErrReservationTimeout is returned when a pending reservation is
+	// pruned because the peer didn't progress the funding flow in time.
+	ErrReservationTimeout = errors.New("reservation timed out waiting " +
+		"for peer")
+
+	// ErrPsbtReservationTimeout is returned when a pending PSBT funding
+	// reservation is pruned because the funding transaction wasn't
+	// provided in time.
+	ErrPsbtReservationTimeout = errors.New("PSBT reservation timed out " +
+		"waiting for funding transaction")
+
+	// ErrFundingFeeTooHigh is returned when a channel open is rejected
+	// because the estimated chain fee rate exceeds our maximum.
+	ErrFundingFeeTooHigh = errors.New("estimated chain fee rate exceeds " +
+		"the maximum funding fee rate")
+
 	zeroID [32]byte
 )
 
+// remoteFundingError is the error returned to the caller when the remote peer
+// fails the funding flow by sending us an error message.
+type remoteFundingError struct {
+	peerKey *btcec.PublicKey
+	msg     *lnwire.Error
+}
+
+// Error returns a human readable version of the remote funding error.
+//
+// NOTE: Part of the error interface.
+func (e *remoteFundingError) Error() string {
+	return fmt.Sprintf("received funding error from %x: %v",
+		e.peerKey.SerializeCompressed(), e.msg.Error())
+}
+
 // reservationWithCtx encapsulates a pending channel reservation. This wrapper
 // struct is used internally within the funding manager to track and progress
 // the funding workflow initiated by incoming/outgoing methods from the target
@@ -135,6 +178,16 @@ type reservationWithCtx struct {
 	// maxLocalCsv is the maximum csv we will accept from the remote.
 	maxLocalCsv uint16
 
+	// openAttempt is the entry of an accepted inbound channel open request
+	// in the audit log. It is nil for channels we initiated.
+	openAttempt *channeldb.ChannelOpenAttempt
+
+	// initiator is true if we initiated the channel.
+	initiator bool
+
+	// createdAt is the time the reservation was created.
+	createdAt time.Time
+
 	updateMtx   sync.RWMutex
 	lastUpdated time.Time
 
@@ -167,6 +220,11 @@ func (r *reservationWithCtx) updateTimestamp() {
 type initFundingMsg struct {
 	peer lnpeer.Peer
 	*openChanReq
+
+	// feeRetryDeadline is the time until which the request may be
+	// deferred while the fee estimate exceeds our maximum. It is set once
+	// the request is deferred for the first time.
+	feeRetryDeadline time.Time
 }
 
 // fundingMsg is sent by the ProcessFundingMsg function and packages a
@@ -280,6 +338,11 @@ type fundingConfig struct {
 	// process to determine how many confirmations we'll require.
 	NumRequiredConfs func(btcutil.Amount, lnwire.MilliSatoshi) uint16
 
+	// IsTrustedPeer returns true if the given peer is one of our trusted
+	// peers. Channels extended to us by a trusted peer only require
+	// trustedPeerNumConfs confirmations, regardless of their amount.
+	IsTrustedPeer func(*btcec.PublicKey) bool
+
 	// RequiredRemoteDelay is a function that maps the total amount in a
 	// proposed channel to the CSV delay that we'll require for the remote
 	// party. Naturally a larger channel should require a higher CSV delay
@@ -315,14 +378,65 @@ type fundingConfig struct {
 	// sub-systems.
 	ReportShortChanID func(wire.OutPoint) error
 
+	// SuspendChannel is called when the funding transaction of an open
+	// channel is reorged out of the chain. It should stop the channel
+	// from forwarding any new HTLCs until it is confirmed again.
+	SuspendChannel func(wire.OutPoint) error
+
+	// ResumeChannel is called once the funding transaction of a channel
+	// that was previously suspended by SuspendChannel confirms again.
+	ResumeChannel func(wire.OutPoint) error
+
+	// IsDraining returns true if the node is draining its HTLCs before
+	// shutting down. No new channels are opened while draining.
+	IsDraining func() bool
+
 	// ZombieSweeperInterval is the periodic time interval in which the
 	// zombie sweeper is run.
 	ZombieSweeperInterval time.Duration
 
 	// ReservationTimeout is the length of idle time that must pass before
-	// a reservation is considered a zombie.
+	// a reservation of a channel we initiated is considered a zombie.
 	ReservationTimeout time.Duration
 
+	// RemoteReservationTimeout is the length of idle time that must pass
+	// before a reservation of a channel the remote peer initiated is
+	// considered a zombie. If zero, ReservationTimeout is used.
+	RemoteReservationTimeout time.Duration
+
+	// PsbtReservationTimeout is the length of idle time that must pass
+	// before a reservation of a PSBT funded channel is considered a
+	// zombie. If zero, PSBT funded reservations are never pruned.
+	PsbtReservationTimeout time.Duration
+
+	// MaxFundingFeeRate is the highest estimated chain fee rate at which
+	// we open channels. If zero, the fee rate isn't limited.
+	MaxFundingFeeRate chainfee.SatPerKWeight
+
+	// FundingFeeRetryTimeout is the maximum time a channel open is
+	// deferred while the fee estimate exceeds MaxFundingFeeRate. If zero,
+	// the channel open is rejected immediately instead.
+	FundingFeeRetryTimeout time.Duration
+
+	// FundingFeeRetryInterval is the interval in which the fee estimate is
+	// polled while channel opens are deferred.
+	FundingFeeRetryInterval time.Duration
+
+	// FundingLockedRetryDelay is the time we wait before retrying to send
+	// the fundingLocked message after the first failed attempt. The delay
+	// is doubled after every subsequent failure, up to
+	// FundingLockedMaxRetryDelay.
+	FundingLockedRetryDelay time.Duration
+
+	// FundingLockedMaxRetryDelay is the maximum time we wait between two
+	// attempts to send the fundingLocked message.
+	FundingLockedMaxRetryDelay time.Duration
+
+	// FundingLockedAlertThreshold is the number of failed attempts to send
+	// the fundingLocked message of a channel after which the
+	// FundingLockedStalledEvent is sent. If zero, no event is sent.
+	FundingLockedAlertThreshold uint32
+
 	// MinChanSize is the smallest channel size that we'll accept as an
 	// inbound channel. We have such a parameter, as otherwise, nodes could
 	// flood us with very small channels that would never really be usable
@@ -338,10 +452,39 @@ type fundingConfig struct {
 	// allow for each peer.
 	MaxPendingChannels int
 
+	// PeerMaxPendingChannels overrides MaxPendingChannels for individual
+	// peers. A maximum of zero doesn't limit the number of pending
+	// channels of the peer.
+	PeerMaxPendingChannels map[route.Vertex]int
+
+	// TrustedPeerUnlimitedPending indicates that the number of pending
+	// channels of trusted peers isn't limited by MaxPendingChannels.
+	TrustedPeerUnlimitedPending bool
+
 	// RejectPush is set true if the fundingmanager should reject any
 	// incoming channels having a non-zero push amount.
 	RejectPush bool
 
+	// CreateScidAlias returns the alias short channel ID we hand out for
+	// the private channel with the given short channel ID, creating it if
+	// needed. Htlcs addressed to the alias are forwarded over the channel.
+	CreateScidAlias func(lnwire.ShortChannelID) (lnwire.ShortChannelID,
+		error)
+
+	// PutPeerScidAlias stores the alias short channel ID the peer handed
+	// out for the private channel with the given short channel ID.
+	PutPeerScidAlias func(base, alias lnwire.ShortChannelID) error
+
+	// MaxAnnouncementDelay is the maximum number of blocks by which the
+	// announcement of a public channel is delayed beyond the six
+	// confirmations it requires. The actual delay is chosen randomly for
+	// each channel.
+	MaxAnnouncementDelay uint32
+
+	// AnnouncementBatch, if non-zero, restricts the announcement of public
+	// channels to block heights that are a multiple of it.
+	AnnouncementBatch uint32
+
 	// MaxLocalCSVDelay is the maximum csv delay we will allow for our
 	// commit output. Channels that exceed this value will be failed.
 	MaxLocalCSVDelay uint16
@@ -355,6 +498,16 @@ type fundingConfig struct {
 	// the funding manager whether or not to accept the channel.
 	OpenChannelPredicate chanacceptor.ChannelAcceptor
 
+	// NotifyPrunedReservationEvent informs the ChannelNotifier when the
+	// zombie sweeper prunes a reservation.
+	NotifyPrunedReservationEvent func(channelnotifier.PrunedReservationEvent)
+
+	// NotifyFundingLockedStalledEvent informs the ChannelNotifier when
+	// sending the fundingLocked message of a channel failed
+	// FundingLockedAlertThreshold times.
+	NotifyFundingLockedStalledEvent func(
+		channelnotifier.FundingLockedStalledEvent)
+
 	// NotifyPendingOpenChannelEvent informs the ChannelNotifier when channels
 	// enter a pending state.
 	NotifyPendingOpenChannelEvent func(wire.OutPoint, *channeldb.OpenChannel)
@@ -363,6 +516,10 @@ type fundingConfig struct {
 	// is enabled.
 	EnableUpfrontShutdown bool
 
+	// CloseAddrPolicy is the policy that upfront shutdown scripts supplied
+	// by the user must adhere to. If it is nil, any script is allowed.
+	CloseAddrPolicy *chancloser.DeliveryAddrPolicy
+
 	// RegisteredChains keeps track of all chains that have been registered
 	// with the daemon.
 	RegisteredChains *chainreg.ChainRegistry
@@ -409,6 +566,15 @@ type fundingManager struct {
 	// goroutine safe.
 	resMtx sync.RWMutex
 
+	// cfgMtx guards the fields of the config that can be updated at
+	// runtime through UpdateConfig, as well as pendingChanOverrides.
+	cfgMtx sync.RWMutex
+
+	// pendingChanOverrides holds the maximum number of pending channels
+	// of individual peers that were set at runtime. They take precedence
+	// over the limits of the config.
+	pendingChanOverrides map[route.Vertex]int
+
 	// fundingMsgs is a channel that relays fundingMsg structs from
 	// external sub-systems using the ProcessFundingMsg call.
 	fundingMsgs chan *fundingMsg
@@ -434,10 +600,23 @@ type fundingManager struct {
 	handleFundingLockedMtx      sync.RWMutex
 	handleFundingLockedBarriers map[lnwire.ChannelID]struct{}
 
+	// openAttemptWindows tracks the number of rejected open attempts of
+	// each peer that were recorded in the audit log within the current
+	// rate limiting window.
+	openAttemptWindowsMtx sync.Mutex
+	openAttemptWindows    map[route.Vertex]*openAttemptWindow
+
 	quit chan struct{}
 	wg   sync.WaitGroup
 }
 
+// openAttemptWindow is the number of rejected open attempts of a peer that
+// were recorded in the audit log since the start of the window.
+type openAttemptWindow struct {
+	start time.Time
+	count int
+}
+
 // channelOpeningState represents the different states a channel can be in
 // between the funding transaction has been confirmed and the channel is
 // announced to the network and ready to be used.
@@ -467,6 +646,11 @@ var (
 	// of being opened.
 	channelOpeningStateBucket = []byte("channelOpeningState")
 
+	// fundingLockedRetriesBucket is the database bucket used to store the
+	// number of failed attempts to send the fundingLocked message of each
+	// channel, so the backoff between attempts survives restarts.
+	fundingLockedRetriesBucket = []byte("fundingLockedRetries")
+
 	// ErrChannelNotFound is an error returned when a channel is not known
 	// to us. In this case of the fundingManager, this error is returned
 	// when the channel in question is not considered being in an opening
@@ -488,6 +672,7 @@ func newFundingManager(cfg fundingConfig) (*fundingManager, error) {
 		localDiscoverySignals:       make(map[lnwire.ChannelID]chan struct{}),
 		handleFundingLockedBarriers: make(map[lnwire.ChannelID]struct{}),
 		queries:                     make(chan interface{}, 1),
+		pendingChanOverrides:        make(map[route.Vertex]int),
 		quit:                        make(chan struct{}),
 	}, nil
 }
@@ -516,6 +701,11 @@ func (f *fundingManager) start() error {
 		return err
 	}
 
+	_, bestHeight, err := f.cfg.Wallet.Cfg.ChainIO.GetBestBlock()
+	if err != nil {
+		return err
+	}
+
 	for _, channel := range allChannels {
 		chanID := lnwire.NewChanIDFromOutPoint(&channel.FundingOutpoint)
 
@@ -580,6 +770,17 @@ func (f *fundingManager) start() error {
 			}
 		}
 
+		// Channels that were opened recently enough for their funding
+		// transaction to still be reorged out of the chain are watched
+		// for reorgs. Pending channels are watched once they confirm.
+		if !channel.IsPending &&
+			channel.ShortChannelID.BlockHeight+
+				chainntnfs.ReorgSafetyLimit > uint32(bestHeight) {
+
+			f.wg.Add(1)
+			go f.watchFundingReorg(channel)
+		}
+
 		// We will restart the funding state machine for all channels,
 		// which will wait for the channel's funding transaction to be
 		// confirmed on the blockchain, and transmit the messages
@@ -826,6 +1027,11 @@ func (f *fundingManager) advanceFundingState(channel *channeldb.OpenChannel,
 	// If the channel is still pending we must wait for the funding
 	// transaction to confirm.
 	if channel.IsPending {
+		// A pending channel that already has a short channel ID was
+		// open before, but its funding transaction was reorged out of
+		// the chain.
+		reorged := channel.ShortChannelID != lnwire.ShortChannelID{}
+
 		err := f.advancePendingChannelState(channel, pendingChanID)
 		if err != nil {
 			fndgLog.Errorf("Unable to advance pending state of "+
@@ -833,6 +1039,22 @@ func (f *fundingManager) advanceFundingState(channel *channeldb.OpenChannel,
 				channel.FundingOutpoint, err)
 			return
 		}
+
+		// Now that the funding transaction is confirmed, a reorged
+		// channel can forward HTLCs again.
+		if reorged {
+			err := f.cfg.ResumeChannel(channel.FundingOutpoint)
+			if err != nil {
+				fndgLog.Errorf("Unable to resume "+
+					"ChannelPoint(%v): %v",
+					channel.FundingOutpoint, err)
+			}
+		}
+
+		// From now on we'll watch the funding transaction for being
+		// reorged out of the chain.
+		f.wg.Add(1)
+		go f.watchFundingReorg(channel)
 	}
 
 	// We create the state-machine object which wraps the database state.
@@ -1175,6 +1397,16 @@ func commitmentType(localFeatures,
 func (f *fundingManager) handleFundingOpen(peer lnpeer.Peer,
 	msg *lnwire.OpenChannel) {
 
+	// We don't accept any new channels while draining before shutdown. The
+	// remote peer can retry once we're back online.
+	if f.cfg.IsDraining() {
+		f.rejectFundingOpen(
+			peer, msg, channeldb.AcceptorNotConsulted,
+			lnwire.ErrNodeDraining,
+		)
+		return
+	}
+
 	// Check number of pending channels to be smaller than maximum allowed
 	// number and send ErrorGeneric to remote peer if condition is
 	// violated.
@@ -1203,8 +1435,8 @@ func (f *fundingManager) handleFundingOpen(peer lnpeer.Peer,
 	// the underlying intent anymore, unfortunately.
 	channels, err := f.cfg.Wallet.Cfg.Database.FetchOpenChannels(peerPubKey)
 	if err != nil {
-		f.failFundingFlow(
-			peer, msg.PendingChannelID, err,
+		f.rejectFundingOpen(
+			peer, msg, channeldb.AcceptorNotConsulted, err,
 		)
 		return
 	}
@@ -1221,11 +1453,18 @@ func (f *fundingManager) handleFundingOpen(peer lnpeer.Peer,
 		}
 	}
 
+	maxPendingChannels := f.maxPendingChannels(peer.IdentityKey())
+
+	f.cfgMtx.RLock()
+	minChanSize := f.cfg.MinChanSize
+	maxChanSize := f.cfg.MaxChanSize
+	f.cfgMtx.RUnlock()
+
 	// TODO(roasbeef): modify to only accept a _single_ pending channel per
 	// block unless white listed
-	if numPending >= f.cfg.MaxPendingChannels {
-		f.failFundingFlow(
-			peer, msg.PendingChannelID,
+	if maxPendingChannels != 0 && numPending >= maxPendingChannels {
+		f.rejectFundingOpen(
+			peer, msg, channeldb.AcceptorNotConsulted,
 			lnwire.ErrMaxPendingChannels,
 		)
 		return
@@ -1239,28 +1478,28 @@ func (f *fundingManager) handleFundingOpen(peer lnpeer.Peer,
 		if err != nil {
 			fndgLog.Errorf("unable to query wallet: %v", err)
 		}
-		f.failFundingFlow(
-			peer, msg.PendingChannelID,
+		f.rejectFundingOpen(
+			peer, msg, channeldb.AcceptorNotConsulted,
 			lnwire.ErrSynchronizingChain,
 		)
 		return
 	}
 
 	// Ensure that the remote party respects our maximum channel size.
-	if amt > f.cfg.MaxChanSize {
-		f.failFundingFlow(
-			peer, msg.PendingChannelID,
-			lnwallet.ErrChanTooLarge(amt, f.cfg.MaxChanSize),
+	if amt > maxChanSize {
+		f.rejectFundingOpen(
+			peer, msg, channeldb.AcceptorNotConsulted,
+			lnwallet.ErrChanTooLarge(amt, maxChanSize),
 		)
 		return
 	}
 
 	// We'll, also ensure that the remote party isn't attempting to propose
 	// a channel that's below our current min channel size.
-	if amt < f.cfg.MinChanSize {
-		f.failFundingFlow(
-			peer, msg.PendingChannelID,
-			lnwallet.ErrChanTooSmall(amt, btcutil.Amount(f.cfg.MinChanSize)),
+	if amt < minChanSize {
+		f.rejectFundingOpen(
+			peer, msg, channeldb.AcceptorNotConsulted,
+			lnwallet.ErrChanTooSmall(amt, btcutil.Amount(minChanSize)),
 		)
 		return
 	}
@@ -1268,8 +1507,8 @@ func (f *fundingManager) handleFundingOpen(peer lnpeer.Peer,
 	// If request specifies non-zero push amount and 'rejectpush' is set,
 	// signal an error.
 	if f.cfg.RejectPush && msg.PushAmount > 0 {
-		f.failFundingFlow(
-			peer, msg.PendingChannelID,
+		f.rejectFundingOpen(
+			peer, msg, channeldb.AcceptorNotConsulted,
 			lnwallet.ErrNonZeroPushAmount(),
 		)
 		return
@@ -1277,15 +1516,18 @@ func (f *fundingManager) handleFundingOpen(peer lnpeer.Peer,
 
 	// Send the OpenChannel request to the ChannelAcceptor to determine whether
 	// this node will accept the channel.
+	trustedPeer := f.cfg.IsTrustedPeer(peer.IdentityKey())
 	chanReq := &chanacceptor.ChannelAcceptRequest{
 		Node:        peer.IdentityKey(),
 		OpenChanMsg: msg,
+		TrustedPeer: trustedPeer,
 	}
 
-	if !f.cfg.OpenChannelPredicate.Accept(chanReq) {
-		f.failFundingFlow(
-			peer, msg.PendingChannelID,
-			fmt.Errorf("open channel request rejected"),
+	acceptResp := f.cfg.OpenChannelPredicate.Accept(chanReq)
+	if !acceptResp.Accept {
+		f.rejectFundingOpen(
+			peer, msg, channeldb.AcceptorRejected,
+			lnwire.ErrChanRejected,
 		)
 		return
 	}
@@ -1327,7 +1569,9 @@ func (f *fundingManager) handleFundingOpen(peer lnpeer.Peer,
 	reservation, err := f.cfg.Wallet.InitChannelReservation(req)
 	if err != nil {
 		fndgLog.Errorf("Unable to initialize reservation: %v", err)
-		f.failFundingFlow(peer, msg.PendingChannelID, err)
+		f.rejectFundingOpen(
+			peer, msg, channeldb.AcceptorAccepted, err,
+		)
 		return
 	}
 
@@ -1335,8 +1579,15 @@ func (f *fundingManager) handleFundingOpen(peer lnpeer.Peer,
 	// that we require before both of us consider the channel open. We'll
 	// use our mapping to derive the proper number of confirmations based on
 	// the amount of the channel, and also if any funds are being pushed to
-	// us.
+	// us. Channels of trusted peers only require a single confirmation. A
+	// channel acceptor may override this value for the channel.
 	numConfsReq := f.cfg.NumRequiredConfs(msg.FundingAmount, msg.PushAmount)
+	if trustedPeer {
+		numConfsReq = trustedPeerNumConfs
+	}
+	if acceptResp.MinAcceptDepth != 0 {
+		numConfsReq = acceptResp.MinAcceptDepth
+	}
 	reservation.SetNumConfsRequired(numConfsReq)
 
 	// We'll also validate and apply all the constraints the initiating
@@ -1354,7 +1605,9 @@ func (f *fundingManager) handleFundingOpen(peer lnpeer.Peer,
 	)
 	if err != nil {
 		fndgLog.Errorf("Unacceptable channel constraints: %v", err)
-		f.failFundingFlow(peer, msg.PendingChannelID, err)
+		f.rejectFundingOpen(
+			peer, msg, channeldb.AcceptorAccepted, err,
+		)
 		return
 	}
 
@@ -1365,7 +1618,10 @@ func (f *fundingManager) handleFundingOpen(peer lnpeer.Peer,
 	shutdown, err := getUpfrontShutdownScript(
 		f.cfg.EnableUpfrontShutdown, peer, nil,
 		func() (lnwire.DeliveryAddress, error) {
-			addr, err := f.cfg.Wallet.NewAddress(lnwallet.WitnessPubKey, false)
+			addr, err := f.cfg.Wallet.NewAddress(
+				lnwallet.WitnessPubKey, false,
+				lnwallet.DefaultAccountName,
+			)
 			if err != nil {
 				return nil, err
 			}
@@ -1373,8 +1629,8 @@ func (f *fundingManager) handleFundingOpen(peer lnpeer.Peer,
 		},
 	)
 	if err != nil {
-		f.failFundingFlow(
-			peer, msg.PendingChannelID,
+		f.rejectFundingOpen(
+			peer, msg, channeldb.AcceptorAccepted,
 			fmt.Errorf("getUpfrontShutdownScript error: %v", err),
 		)
 		return
@@ -1408,6 +1664,7 @@ func (f *fundingManager) handleFundingOpen(peer lnpeer.Peer,
 		remoteMaxValue: remoteMaxValue,
 		remoteMaxHtlcs: maxHtlcs,
 		maxLocalCsv:    f.cfg.MaxLocalCSVDelay,
+		createdAt:      time.Now(),
 		err:            make(chan error, 1),
 		peer:           peer,
 	}
@@ -1452,7 +1709,9 @@ func (f *fundingManager) handleFundingOpen(peer lnpeer.Peer,
 	err = reservation.ProcessSingleContribution(remoteContribution)
 	if err != nil {
 		fndgLog.Errorf("unable to add contribution reservation: %v", err)
-		f.failFundingFlow(peer, msg.PendingChannelID, err)
+		f.rejectFundingOpen(
+			peer, msg, channeldb.AcceptorAccepted, err,
+		)
 		return
 	}
 
@@ -1484,9 +1743,100 @@ func (f *fundingManager) handleFundingOpen(peer lnpeer.Peer,
 
 	if err := peer.SendMessage(true, &fundingAccept); err != nil {
 		fndgLog.Errorf("unable to send funding response to peer: %v", err)
-		f.failFundingFlow(peer, msg.PendingChannelID, err)
+		f.rejectFundingOpen(
+			peer, msg, channeldb.AcceptorAccepted, err,
+		)
 		return
 	}
+
+	// With the accept_channel message sent, we record the accepted attempt
+	// so we can add its channel point once the remote peer sends it.
+	resCtx.openAttempt = f.recordOpenAttempt(
+		peer, msg, channeldb.AcceptorAccepted, nil,
+	)
+}
+
+// rejectFundingOpen records the rejection of an inbound channel open request in
+// the audit log and fails the funding flow with the given error.
+func (f *fundingManager) rejectFundingOpen(peer lnpeer.Peer,
+	msg *lnwire.OpenChannel, verdict channeldb.AcceptorVerdict, err error) {
+
+	f.recordOpenAttempt(peer, msg, verdict, err)
+	f.failFundingFlow(peer, msg.PendingChannelID, err)
+}
+
+// recordOpenAttempt adds our decision for an inbound channel open request to
+// the audit log. The request was accepted if the passed error is nil. As the
+// log only serves informational purposes, failing to write to it doesn't abort
+// the funding flow. Rejections are only recorded up to a per peer rate limit.
+func (f *fundingManager) recordOpenAttempt(peer lnpeer.Peer,
+	msg *lnwire.OpenChannel, verdict channeldb.AcceptorVerdict,
+	err error) *channeldb.ChannelOpenAttempt {
+
+	now := time.Now()
+	peerKey := route.NewVertex(peer.IdentityKey())
+	if err != nil && !f.allowOpenAttemptRecord(peerKey, now) {
+		fndgLog.Debugf("Not recording rejected open attempt for "+
+			"pending_id(%x) of peer %v: rate limit exceeded",
+			msg.PendingChannelID[:], peerKey)
+		return nil
+	}
+
+	attempt := &channeldb.ChannelOpenAttempt{
+		Timestamp:       now,
+		PendingChanID:   msg.PendingChannelID,
+		Peer:            peer.IdentityKey(),
+		FundingAmt:      msg.FundingAmount,
+		PushAmt:         msg.PushAmount,
+		Accepted:        err == nil,
+		AcceptorVerdict: verdict,
+	}
+	if err != nil {
+		attempt.Reason = err.Error()
+	}
+
+	db := f.cfg.Wallet.Cfg.Database
+	if err := db.AddChannelOpenAttempt(attempt); err != nil {
+		fndgLog.Errorf("Unable to record open attempt for "+
+			"pending_id(%x): %v", msg.PendingChannelID[:], err)
+		return nil
+	}
+
+	return attempt
+}
+
+// allowOpenAttemptRecord returns true if another rejected open attempt of the
+// given peer may be recorded in the audit log at the given time.
+func (f *fundingManager) allowOpenAttemptRecord(peer route.Vertex,
+	now time.Time) bool {
+
+	f.openAttemptWindowsMtx.Lock()
+	defer f.openAttemptWindowsMtx.Unlock()
+
+	if f.openAttemptWindows == nil {
+		f.openAttemptWindows = make(map[route.Vertex]*openAttemptWindow)
+	}
+
+	// Remove the windows that expired, so we don't keep state for peers
+	// that stopped sending us requests.
+	for key, window := range f.openAttemptWindows {
+		if now.Sub(window.start) >= openAttemptWindowSize {
+			delete(f.openAttemptWindows, key)
+		}
+	}
+
+	window, ok := f.openAttemptWindows[peer]
+	if !ok {
+		window = &openAttemptWindow{start: now}
+		f.openAttemptWindows[peer] = window
+	}
+
+	if window.count >= maxRejectedOpenAttempts {
+		return false
+	}
+	window.count++
+
+	return true
 }
 
 // handleFundingAccept processes a response to the workflow initiation sent by
@@ -1826,6 +2176,18 @@ func (f *fundingManager) handleFundingCreated(peer lnpeer.Peer,
 	// from the set of active reservations.
 	f.deleteReservationCtx(peerKey, msg.PendingChannelID)
 
+	// Now that the channel point is known, we add it to the audit log
+	// entry of the open attempt.
+	if resCtx.openAttempt != nil {
+		err := f.cfg.Wallet.Cfg.Database.IndexChannelOpenAttempt(
+			resCtx.openAttempt.Timestamp, fundingOut,
+		)
+		if err != nil {
+			fndgLog.Errorf("Unable to index open attempt for "+
+				"ChannelPoint(%v): %v", fundingOut, err)
+		}
+	}
+
 	// If something goes wrong before the funding transaction is confirmed,
 	// we use this convenience method to delete the pending OpenChannel
 	// from the database.
@@ -2018,7 +2380,22 @@ func (f *fundingManager) handleFundingSigned(peer lnpeer.Peer,
 		)
 
 		err = f.cfg.PublishTransaction(fundingTx, label)
-		if err != nil {
+		switch {
+		// If the funding transaction doesn't pay enough fees to make
+		// it into the mempool right now, we'll hold on to it and retry
+		// broadcasting it as new blocks come in and the mempool
+		// clears.
+		case err == lnwallet.ErrMempoolFee:
+			fndgLog.Warnf("Funding tx for ChannelPoint(%v) doesn't "+
+				"meet the mempool fee floor, delaying broadcast",
+				completeChan.FundingOutpoint)
+
+			f.wg.Add(1)
+			go f.republishFundingTx(
+				completeChan.FundingOutpoint, fundingTx, label,
+			)
+
+		case err != nil:
 			fndgLog.Errorf("Unable to broadcast funding tx %x for "+
 				"ChannelPoint(%v): %v", fundingTxBuf.Bytes(),
 				completeChan.FundingOutpoint, err)
@@ -2089,6 +2466,69 @@ type confirmedChannel struct {
 	fundingTx *wire.MsgTx
 }
 
+// republishFundingTx retries broadcasting a funding transaction that was
+// rejected for not meeting the backend's mempool fee floor on every new block,
+// until it is either accepted, rejected for another reason, or
+// maxWaitNumBlocksFundingConf blocks have passed.
+//
+// NOTE: This MUST be run as a goroutine.
+func (f *fundingManager) republishFundingTx(chanPoint wire.OutPoint,
+	fundingTx *wire.MsgTx, label string) {
+
+	defer f.wg.Done()
+
+	blockEpochs, err := f.cfg.Notifier.RegisterBlockEpochNtfn(nil)
+	if err != nil {
+		fndgLog.Errorf("Unable to register for block epochs to "+
+			"rebroadcast funding tx for ChannelPoint(%v): %v",
+			chanPoint, err)
+		return
+	}
+	defer blockEpochs.Cancel()
+
+	// The first epoch we receive is the current best block, which we
+	// already attempted to broadcast the transaction at.
+	var numBlocks uint32
+	for {
+		select {
+		case _, ok := <-blockEpochs.Epochs:
+			if !ok {
+				return
+			}
+		case <-f.quit:
+			return
+		}
+
+		numBlocks++
+		if numBlocks == 1 {
+			continue
+		}
+		if numBlocks > maxWaitNumBlocksFundingConf {
+			fndgLog.Warnf("Giving up rebroadcasting funding tx for "+
+				"ChannelPoint(%v) after %v blocks", chanPoint,
+				maxWaitNumBlocksFundingConf)
+			return
+		}
+
+		err := f.cfg.PublishTransaction(fundingTx, label)
+		switch {
+		case err == lnwallet.ErrMempoolFee:
+			fndgLog.Debugf("Funding tx for ChannelPoint(%v) still "+
+				"doesn't meet the mempool fee floor", chanPoint)
+
+		case err != nil:
+			fndgLog.Errorf("Unable to rebroadcast funding tx for "+
+				"ChannelPoint(%v): %v", chanPoint, err)
+			return
+
+		default:
+			fndgLog.Infof("Broadcast delayed funding tx for "+
+				"ChannelPoint(%v)", chanPoint)
+			return
+		}
+	}
+}
+
 // waitForFundingWithTimeout is a wrapper around waitForFundingConfirmation and
 // waitForTimeout that will return ErrConfirmationTimeout if we are not the
 // channel initiator and the maxWaitNumBlocksFundingConf has passed from the
@@ -2106,8 +2546,10 @@ func (f *fundingManager) waitForFundingWithTimeout(
 
 	// If we are not the initiator, we have no money at stake and will
 	// timeout waiting for the funding transaction to confirm after a
-	// while.
-	if !ch.IsInitiator {
+	// while. This doesn't apply to channels that were already open before
+	// their funding transaction was reorged out of the chain, as they may
+	// carry funds of ours by now.
+	if !ch.IsInitiator && ch.ShortChannelID == (lnwire.ShortChannelID{}) {
 		f.wg.Add(1)
 		go f.waitForTimeout(ch, cancelChan, timeoutChan)
 	}
@@ -2241,6 +2683,82 @@ func (f *fundingManager) waitForFundingConfirmation(
 	}
 }
 
+// watchFundingReorg watches the funding transaction of an open channel for
+// being reorged out of the chain. If that happens, the channel is moved back
+// into the pending state and suspended from forwarding HTLCs, and the funding
+// state machine is restarted to wait for the funding transaction to confirm
+// again. The watch ends once the funding transaction is buried deeper than
+// chainntnfs.ReorgSafetyLimit, as the chain notifier won't report reorgs of
+// that depth.
+//
+// NOTE: This MUST be run as a goroutine.
+func (f *fundingManager) watchFundingReorg(channel *channeldb.OpenChannel) {
+	defer f.wg.Done()
+
+	fundingPoint := channel.FundingOutpoint
+	chanID := lnwire.NewChanIDFromOutPoint(&fundingPoint)
+
+	fundingScript, err := makeFundingScript(channel)
+	if err != nil {
+		fndgLog.Errorf("Unable to create funding script for "+
+			"ChannelPoint(%v): %v", fundingPoint, err)
+		return
+	}
+
+	// We only need a single confirmation, as we're only interested in
+	// the funding transaction being disconnected from the chain again.
+	txid := fundingPoint.Hash
+	confNtfn, err := f.cfg.Notifier.RegisterConfirmationsNtfn(
+		&txid, fundingScript, 1, channel.ShortChannelID.BlockHeight,
+	)
+	if err != nil {
+		fndgLog.Errorf("Unable to register for reorgs of "+
+			"ChannelPoint(%v): %v", fundingPoint, err)
+		return
+	}
+	defer confNtfn.Cancel()
+
+	// The notifier signals Done once the funding transaction is buried
+	// deeper than the reorg safety limit, after which it can't be reorged
+	// out anymore.
+	select {
+	case _, ok := <-confNtfn.NegativeConf:
+		if !ok {
+			return
+		}
+
+	case <-confNtfn.Done:
+		fndgLog.Debugf("Funding transaction of ChannelPoint(%v) is "+
+			"buried deep enough to be safe from reorgs",
+			fundingPoint)
+		return
+
+	case <-f.quit:
+		return
+	}
+
+	fndgLog.Warnf("Funding transaction of ChannelPoint(%v) with "+
+		"ShortChanID %v was reorged out of the chain, moving channel "+
+		"back to pending", fundingPoint, channel.ShortChannelID)
+
+	if err := channel.MarkAsPending(); err != nil {
+		fndgLog.Errorf("Unable to mark ChannelPoint(%v) as pending: %v",
+			fundingPoint, err)
+		return
+	}
+
+	if err := f.cfg.SuspendChannel(fundingPoint); err != nil {
+		fndgLog.Errorf("Unable to suspend ChannelPoint(%v): %v",
+			fundingPoint, err)
+	}
+
+	// With the channel pending again, we restart the funding state
+	// machine, which will wait for the funding transaction to confirm,
+	// resume the channel and go through the remaining opening steps.
+	f.wg.Add(1)
+	go f.advanceFundingState(channel, chanID, nil)
+}
+
 // waitForTimeout will close the timeout channel if maxWaitNumBlocksFundingConf
 // has passed from the broadcast height of the given channel. In case of error,
 // the error is sent on timeoutChan. The wait can be canceled by closing the
@@ -2382,7 +2900,13 @@ func (f *fundingManager) handleFundingConfirmation(
 	// from the peer.
 	f.localDiscoveryMtx.Lock()
 	if discoverySignal, ok := f.localDiscoverySignals[chanID]; ok {
-		close(discoverySignal)
+		// The signal is already closed if the funding transaction
+		// confirmed before, but was reorged out of the chain since.
+		select {
+		case <-discoverySignal:
+		default:
+			close(discoverySignal)
+		}
 	}
 	f.localDiscoveryMtx.Unlock()
 
@@ -2411,6 +2935,27 @@ func (f *fundingManager) sendFundingLocked(
 	}
 	fundingLockedMsg := lnwire.NewFundingLocked(chanID, nextRevocation)
 
+	// For private channels, we hand out an alias short channel ID, so the
+	// peer doesn't have to reveal the funding outpoint of the channel in
+	// the route hints of its invoices.
+	var aliasScid *lnwire.ShortChannelID
+	isPrivate := completeChan.ChannelFlags&lnwire.FFAnnounceChannel == 0
+	if isPrivate && f.cfg.CreateScidAlias != nil {
+		alias, err := f.cfg.CreateScidAlias(*shortChanID)
+		if err != nil {
+			return fmt.Errorf("unable to create scid alias: %v", err)
+		}
+		aliasScid = &alias
+	}
+
+	// Fetch the number of attempts that already failed, possibly before a
+	// restart, so we continue backing off where we left off.
+	attempts, err := f.getFundingLockedRetries(&completeChan.FundingOutpoint)
+	if err != nil {
+		return fmt.Errorf("unable to fetch fundingLocked retries: %v",
+			err)
+	}
+
 	// If the peer has disconnected before we reach this point, we will need
 	// to wait for him to come back online before sending the fundingLocked
 	// message. This is special for fundingLocked, since failing to send any
@@ -2421,8 +2966,26 @@ func (f *fundingManager) sendFundingLocked(
 	// where we might try to resend the fundingLocked message before the
 	// server has had the time to connect to the peer. We keep trying to
 	// send fundingLocked until we succeed, or the fundingManager is shut
-	// down.
+	// down, backing off exponentially between failed attempts.
+	//
+	// The first attempt after a restart is made right away, as the peer
+	// may well have reconnected in the meantime. Only subsequent attempts
+	// continue backing off from the persisted number of failures.
+	retrying := false
 	for {
+		if retrying {
+			delay := f.fundingLockedRetryDelay(attempts)
+
+			fndgLog.Debugf("Retrying to send FundingLocked for "+
+				"ChannelID(%v) in %v", chanID, delay)
+
+			select {
+			case <-time.After(delay):
+			case <-f.quit:
+				return ErrFundingManagerShuttingDown
+			}
+		}
+
 		connected := make(chan lnpeer.Peer, 1)
 		f.cfg.NotifyWhenOnline(peerKey, connected)
 
@@ -2436,19 +2999,88 @@ func (f *fundingManager) sendFundingLocked(
 		fndgLog.Infof("Peer(%x) is online, sending FundingLocked "+
 			"for ChannelID(%v)", peerKey, chanID)
 
-		if err := peer.SendMessage(true, fundingLockedMsg); err == nil {
+		// The alias is only sent to peers that understand it.
+		fundingLockedMsg.AliasScid = nil
+		if aliasScid != nil && peer.RemoteFeatures().HasFeature(
+			lnwire.ScidAliasOptional,
+		) {
+
+			fundingLockedMsg.AliasScid = aliasScid
+		}
+
+		err := peer.SendMessage(true, fundingLockedMsg)
+		if err == nil {
 			// Sending succeeded, we can break out and continue the
 			// funding flow.
 			break
 		}
 
-		fndgLog.Warnf("Unable to send fundingLocked to peer %x: %v. "+
-			"Will retry when online", peerKey, err)
+		retrying = true
+		attempts++
+		fndgLog.Warnf("Unable to send fundingLocked to peer %x "+
+			"(attempt %v): %v. Will retry when online", peerKey,
+			attempts, err)
+
+		err = f.saveFundingLockedRetries(
+			&completeChan.FundingOutpoint, attempts,
+		)
+		if err != nil {
+			fndgLog.Errorf("Unable to save fundingLocked retries "+
+				"of ChannelPoint(%v): %v",
+				completeChan.FundingOutpoint, err)
+		}
+
+		// Once the threshold is reached, we let the operator know
+		// that the channel establishment with this peer is stuck.
+		threshold := f.cfg.FundingLockedAlertThreshold
+		if threshold == 0 || attempts != threshold {
+			continue
+		}
+
+		fndgLog.Errorf("Failed to send FundingLocked for "+
+			"ChannelPoint(%v) to peer %x %v times",
+			completeChan.FundingOutpoint, peerKey, attempts)
+
+		f.cfg.NotifyFundingLockedStalledEvent(
+			channelnotifier.FundingLockedStalledEvent{
+				ChannelPoint: completeChan.FundingOutpoint,
+				PeerPubKey:   peerKey,
+				Attempts:     attempts,
+			},
+		)
+	}
+
+	// The message was delivered, so the retry counter isn't needed
+	// anymore.
+	if attempts > 0 {
+		err := f.deleteFundingLockedRetries(
+			&completeChan.FundingOutpoint,
+		)
+		if err != nil {
+			fndgLog.Errorf("Unable to delete fundingLocked retries "+
+				"of ChannelPoint(%v): %v",
+				completeChan.FundingOutpoint, err)
+		}
 	}
 
 	return nil
 }
 
+// fundingLockedRetryDelay returns the time to wait before the next attempt to
+// send the fundingLocked message, given the number of attempts that already
+// failed.
+func (f *fundingManager) fundingLockedRetryDelay(attempts uint32) time.Duration {
+	delay := f.cfg.FundingLockedRetryDelay
+	for i := uint32(1); i < attempts; i++ {
+		delay *= 2
+		if delay >= f.cfg.FundingLockedMaxRetryDelay {
+			return f.cfg.FundingLockedMaxRetryDelay
+		}
+	}
+
+	return delay
+}
+
 // addToRouterGraph sends a ChannelAnnouncement and a ChannelUpdate to the
 // gossiper so that the channel is added to the Router's internal graph.
 // These announcement messages are NOT broadcasted to the greater network,
@@ -2467,8 +3099,9 @@ func (f *fundingManager) addToRouterGraph(completeChan *channeldb.OpenChannel,
 
 	// We don't necessarily want to go as low as the remote party
 	// allows. Check it against our default forwarding policy.
-	if fwdMinHTLC < f.cfg.DefaultRoutingPolicy.MinHTLCOut {
-		fwdMinHTLC = f.cfg.DefaultRoutingPolicy.MinHTLCOut
+	defaultPolicy := f.defaultRoutingPolicy()
+	if fwdMinHTLC < defaultPolicy.MinHTLCOut {
+		fwdMinHTLC = defaultPolicy.MinHTLCOut
 	}
 
 	// We'll obtain the max HTLC value we can forward in our direction, as
@@ -2586,11 +3219,9 @@ func (f *fundingManager) annAfterSixConfs(completeChan *channeldb.OpenChannel,
 		}
 	} else {
 		// Otherwise, we'll wait until the funding transaction has
-		// reached 6 confirmations before announcing it.
-		numConfs := uint32(completeChan.NumConfsRequired)
-		if numConfs < 6 {
-			numConfs = 6
-		}
+		// reached 6 confirmations, plus any privacy delay, before
+		// announcing it.
+		numConfs := f.announcementConfs(completeChan, shortChanID)
 		txid := completeChan.FundingOutpoint.Hash
 		fndgLog.Debugf("Will announce channel %v after ChannelPoint"+
 			"(%v) has gotten %d confirmations",
@@ -2660,6 +3291,45 @@ func (f *fundingManager) annAfterSixConfs(completeChan *channeldb.OpenChannel,
 	return nil
 }
 
+// announcementConfs returns the number of confirmations the funding
+// transaction of a public channel needs before the channel is announced. This
+// is at least 6, plus a random delay of up to MaxAnnouncementDelay blocks. If
+// AnnouncementBatch is set, the confirmations are further increased until the
+// announcement falls on a block height that is a multiple of the batch size.
+func (f *fundingManager) announcementConfs(completeChan *channeldb.OpenChannel,
+	shortChanID *lnwire.ShortChannelID) uint32 {
+
+	numConfs := uint32(completeChan.NumConfsRequired)
+	if numConfs < 6 {
+		numConfs = 6
+	}
+
+	// The random delay is derived from our secret channel ID key and the
+	// funding outpoint, so it stays the same across restarts while not
+	// being predictable by others.
+	if f.cfg.MaxAnnouncementDelay > 0 {
+		var b bytes.Buffer
+		b.Write(f.chanIDKey[:])
+		_ = writeOutpoint(&b, &completeChan.FundingOutpoint)
+		h := sha256.Sum256(b.Bytes())
+
+		delay := binary.BigEndian.Uint64(h[:8]) %
+			(uint64(f.cfg.MaxAnnouncementDelay) + 1)
+		numConfs += uint32(delay)
+	}
+
+	// The funding transaction reaches numConfs confirmations at the height
+	// of its block plus numConfs - 1.
+	if batch := f.cfg.AnnouncementBatch; batch > 1 {
+		annHeight := shortChanID.BlockHeight + numConfs - 1
+		if rem := annHeight % batch; rem != 0 {
+			numConfs += batch - rem
+		}
+	}
+
+	return numConfs
+}
+
 // handleFundingLocked finalizes the channel funding process and enables the
 // channel to enter normal operating mode.
 func (f *fundingManager) handleFundingLocked(peer lnpeer.Peer,
@@ -2745,6 +3415,20 @@ func (f *fundingManager) handleFundingLocked(peer lnpeer.Peer,
 		return
 	}
 
+	// If the peer handed out an alias for this private channel, we store
+	// it so it can be used in the route hints of our invoices instead of
+	// the real short channel ID.
+	isPrivate := channel.ChannelFlags&lnwire.FFAnnounceChannel == 0
+	if msg.AliasScid != nil && isPrivate && f.cfg.PutPeerScidAlias != nil {
+		err := f.cfg.PutPeerScidAlias(
+			channel.ShortChannelID, *msg.AliasScid,
+		)
+		if err != nil {
+			fndgLog.Errorf("Unable to store scid alias %v of "+
+				"ChannelID(%v): %v", msg.AliasScid, chanID, err)
+		}
+	}
+
 	// Launch a defer so we _ensure_ that the channel barrier is properly
 	// closed even if the target peer is no longer online at this point.
 	defer func() {
@@ -2839,13 +3523,14 @@ func (f *fundingManager) newChanAnnouncement(localPubKey, remotePubKey,
 
 	// We announce the channel with the default values. Some of
 	// these values can later be changed by crafting a new ChannelUpdate.
+	defaultPolicy := f.defaultRoutingPolicy()
 	chanUpdateAnn := &lnwire.ChannelUpdate{
 		ShortChannelID: shortChanID,
 		ChainHash:      chainHash,
 		Timestamp:      uint32(time.Now().Unix()),
 		MessageFlags:   msgFlags,
 		ChannelFlags:   chanFlags,
-		TimeLockDelta:  uint16(f.cfg.DefaultRoutingPolicy.TimeLockDelta),
+		TimeLockDelta:  uint16(defaultPolicy.TimeLockDelta),
 
 		// We use the HtlcMinimumMsat that the remote party required us
 		// to use, as our ChannelUpdate will be used to carry HTLCs
@@ -2853,8 +3538,8 @@ func (f *fundingManager) newChanAnnouncement(localPubKey, remotePubKey,
 		HtlcMinimumMsat: fwdMinHTLC,
 		HtlcMaximumMsat: fwdMaxHTLC,
 
-		BaseFee: uint32(f.cfg.DefaultRoutingPolicy.BaseFee),
-		FeeRate: uint32(f.cfg.DefaultRoutingPolicy.FeeRate),
+		BaseFee: uint32(defaultPolicy.BaseFee),
+		FeeRate: uint32(defaultPolicy.FeeRate),
 	}
 
 	// With the channel update announcement constructed, we'll generate a
@@ -3013,6 +3698,84 @@ func (f *fundingManager) initFundingWorkflow(peer lnpeer.Peer, req *openChanReq)
 	}
 }
 
+// deferFundingMsg defers a channel open while the fee estimate exceeds our
+// maximum funding fee rate, or rejects it if no retries are configured or the
+// retry deadline of the request has passed.
+func (f *fundingManager) deferFundingMsg(msg *initFundingMsg,
+	feeRate chainfee.SatPerKWeight) {
+
+	if msg.feeRetryDeadline.IsZero() && f.cfg.FundingFeeRetryTimeout != 0 {
+		msg.feeRetryDeadline = time.Now().Add(
+			f.cfg.FundingFeeRetryTimeout,
+		)
+	}
+
+	feeErr := fmt.Errorf("%w: estimated %v, maximum %v",
+		ErrFundingFeeTooHigh, feeRate, f.cfg.MaxFundingFeeRate)
+
+	if !time.Now().Before(msg.feeRetryDeadline) {
+		msg.err <- feeErr
+		return
+	}
+
+	fndgLog.Infof("Deferring channel open to peer %x until %v: %v",
+		msg.peer.IdentityKey().SerializeCompressed(),
+		msg.feeRetryDeadline, feeErr)
+
+	f.wg.Add(1)
+	go f.waitForFeeDrop(msg, feeErr)
+}
+
+// waitForFeeDrop polls the fee estimate until it drops below our maximum
+// funding fee rate, and then hands the deferred channel open back to the
+// funding manager. If the retry deadline of the request passes before, the
+// channel open is rejected with the passed error.
+//
+// NOTE: This MUST be run as a goroutine.
+func (f *fundingManager) waitForFeeDrop(msg *initFundingMsg, feeErr error) {
+	defer f.wg.Done()
+
+	ticker := time.NewTicker(f.cfg.FundingFeeRetryInterval)
+	defer ticker.Stop()
+
+	deadline := time.NewTimer(time.Until(msg.feeRetryDeadline))
+	defer deadline.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			feeRate, err := f.cfg.FeeEstimator.EstimateFeePerKW(3)
+			if err != nil {
+				fndgLog.Warnf("Unable to estimate fee rate: %v",
+					err)
+				continue
+			}
+			if feeRate > f.cfg.MaxFundingFeeRate {
+				continue
+			}
+
+			fndgLog.Infof("Fee estimate dropped to %v, retrying "+
+				"deferred channel open to peer %x", feeRate,
+				msg.peer.IdentityKey().SerializeCompressed())
+
+			select {
+			case f.fundingRequests <- msg:
+			case <-f.quit:
+				msg.err <- ErrFundingManagerShuttingDown
+			}
+			return
+
+		case <-deadline.C:
+			msg.err <- feeErr
+			return
+
+		case <-f.quit:
+			msg.err <- ErrFundingManagerShuttingDown
+			return
+		}
+	}
+}
+
 // getUpfrontShutdownScript takes a user provided script and a getScript
 // function which can be used to generate an upfront shutdown script. If our
 // peer does not support the feature, this function will error if a non-zero
@@ -3078,13 +3841,7 @@ func (f *fundingManager) handleInitFundingMsg(msg *initFundingMsg) {
 	}
 
 	// We'll determine our dust limit depending on which chain is active.
-	var ourDustLimit btcutil.Amount
-	switch f.cfg.RegisteredChains.PrimaryChain() {
-	case chainreg.BitcoinChain:
-		ourDustLimit = lnwallet.DefaultDustLimit()
-	case chainreg.LitecoinChain:
-		ourDustLimit = chainreg.DefaultLitecoinDustLimit
-	}
+	ourDustLimit := f.cfg.RegisteredChains.PrimaryChainPolicy().DustLimit
 
 	fndgLog.Infof("Initiating fundingRequest(local_amt=%v "+
 		"(subtract_fees=%v), push_amt=%v, chain_hash=%v, peer=%x, "+
@@ -3092,6 +3849,12 @@ func (f *fundingManager) handleInitFundingMsg(msg *initFundingMsg) {
 		msg.pushAmt, msg.chainHash, peerKey.SerializeCompressed(),
 		ourDustLimit, msg.minConfs)
 
+	// We don't open any new channels while draining before shutdown.
+	if f.cfg.IsDraining() {
+		msg.err <- lnwire.ErrNodeDraining
+		return
+	}
+
 	// First, we'll query the fee estimator for a fee that should get the
 	// commitment transaction confirmed by the next few blocks (conf target
 	// of 3). We target the near blocks here to ensure that we'll be able
@@ -3102,6 +3865,14 @@ func (f *fundingManager) handleInitFundingMsg(msg *initFundingMsg) {
 		return
 	}
 
+	// If fees are spiking above our maximum, we either reject the channel
+	// open or defer it until they drop again.
+	maxFeeRate := f.cfg.MaxFundingFeeRate
+	if maxFeeRate != 0 && commitFeePerKw > maxFeeRate {
+		f.deferFundingMsg(msg, commitFeePerKw)
+		return
+	}
+
 	// We set the channel flags to indicate whether we want this channel to
 	// be announced to the network.
 	var channelFlags lnwire.FundingFlag
@@ -3128,6 +3899,20 @@ func (f *fundingManager) handleInitFundingMsg(msg *initFundingMsg) {
 		}
 	}
 
+	// Before we consider the user specified upfront shutdown script, we'll
+	// make sure it adheres to our close address policy, so we don't lock
+	// the channel's funds to a close address that we'll later refuse.
+	if f.cfg.CloseAddrPolicy != nil {
+		err := f.cfg.CloseAddrPolicy.Validate(
+			msg.openChanReq.shutdownScript,
+		)
+		if err != nil {
+			msg.err <- fmt.Errorf("invalid upfront shutdown "+
+				"script: %v", err)
+			return
+		}
+	}
+
 	// Check whether the peer supports upfront shutdown, and get an address
 	// which should be used (either a user specified address or a new
 	// address from the wallet if our node is configured to set shutdown
@@ -3138,6 +3923,7 @@ func (f *fundingManager) handleInitFundingMsg(msg *initFundingMsg) {
 		func() (lnwire.DeliveryAddress, error) {
 			addr, err := f.cfg.Wallet.NewAddress(
 				lnwallet.WitnessPubKey, false,
+				lnwallet.DefaultAccountName,
 			)
 			if err != nil {
 				return nil, err
@@ -3176,6 +3962,10 @@ func (f *fundingManager) handleInitFundingMsg(msg *initFundingMsg) {
 		MinConfs:         msg.minConfs,
 		CommitType:       commitType,
 		ChanFunder:       msg.chanFunder,
+
+		CoinSelectionStrategy: msg.coinSelectionStrategy,
+		Outpoints:             msg.outpoints,
+		Account:               msg.fundingAccount,
 	}
 
 	reservation, err := f.cfg.Wallet.InitChannelReservation(req)
@@ -3234,6 +4024,8 @@ func (f *fundingManager) handleInitFundingMsg(msg *initFundingMsg) {
 		remoteMaxHtlcs: maxHtlcs,
 		maxLocalCsv:    maxCSV,
 		reservation:    reservation,
+		initiator:      true,
+		createdAt:      time.Now(),
 		peer:           msg.peer,
 		updates:        msg.updates,
 		err:            msg.err,
@@ -3315,9 +4107,10 @@ func (f *fundingManager) handleErrorMsg(peer lnpeer.Peer,
 
 	// If we did indeed find the funding workflow, then we'll return the
 	// error back to the caller (if any), and cancel the workflow itself.
-	fundingErr := fmt.Errorf("received funding error from %x: %v",
-		peerKey.SerializeCompressed(), msg.Error(),
-	)
+	var fundingErr error = &remoteFundingError{
+		peerKey: peerKey,
+		msg:     msg,
+	}
 	fndgLog.Errorf(fundingErr.Error())
 
 	// If this was a PSBT funding flow, the remote likely timed out because
@@ -3331,11 +4124,173 @@ func (f *fundingManager) handleErrorMsg(peer lnpeer.Peer,
 	resCtx.err <- fundingErr
 }
 
+// fundingLimits holds the parameters of the funding manager that can be updated
+// at runtime without restarting it.
+type fundingLimits struct {
+	// DefaultRoutingPolicy is the default routing policy used when
+	// initially announcing channels.
+	DefaultRoutingPolicy htlcswitch.ForwardingPolicy
+
+	// MinChanSize is the smallest channel size that we'll accept as an
+	// inbound channel.
+	MinChanSize btcutil.Amount
+
+	// MaxChanSize is the largest channel size that we'll accept as an
+	// inbound channel.
+	MaxChanSize btcutil.Amount
+
+	// MaxPendingChannels is the maximum number of pending channels we
+	// allow for each peer.
+	MaxPendingChannels int
+
+	// PeerMaxPendingChannels overrides MaxPendingChannels for individual
+	// peers.
+	PeerMaxPendingChannels map[route.Vertex]int
+
+	// TrustedPeerUnlimitedPending indicates that the number of pending
+	// channels of trusted peers isn't limited by MaxPendingChannels.
+	TrustedPeerUnlimitedPending bool
+
+	// ReservationTimeout is the length of idle time that must pass before
+	// a reservation of a channel we initiated is considered a zombie.
+	ReservationTimeout time.Duration
+
+	// RemoteReservationTimeout is the length of idle time that must pass
+	// before a reservation of a channel the remote peer initiated is
+	// considered a zombie. If zero, ReservationTimeout is used.
+	RemoteReservationTimeout time.Duration
+
+	// PsbtReservationTimeout is the length of idle time that must pass
+	// before a reservation of a PSBT funded channel is considered a
+	// zombie. If zero, PSBT funded reservations are never pruned.
+	PsbtReservationTimeout time.Duration
+}
+
+// UpdateConfig updates the parameters of the funding manager that can be
+// changed at runtime. The new values apply to all funding flows that are
+// processed after this call.
+func (f *fundingManager) UpdateConfig(limits *fundingLimits) {
+	f.cfgMtx.Lock()
+	defer f.cfgMtx.Unlock()
+
+	f.cfg.DefaultRoutingPolicy = limits.DefaultRoutingPolicy
+	f.cfg.MinChanSize = limits.MinChanSize
+	f.cfg.MaxChanSize = limits.MaxChanSize
+	f.cfg.MaxPendingChannels = limits.MaxPendingChannels
+	f.cfg.PeerMaxPendingChannels = limits.PeerMaxPendingChannels
+	f.cfg.TrustedPeerUnlimitedPending = limits.TrustedPeerUnlimitedPending
+	f.cfg.ReservationTimeout = limits.ReservationTimeout
+	f.cfg.RemoteReservationTimeout = limits.RemoteReservationTimeout
+	f.cfg.PsbtReservationTimeout = limits.PsbtReservationTimeout
+}
+
+// currentLimits returns the parameters of the funding manager that can be
+// changed at runtime.
+func (f *fundingManager) currentLimits() *fundingLimits {
+	f.cfgMtx.RLock()
+	defer f.cfgMtx.RUnlock()
+
+	return &fundingLimits{
+		DefaultRoutingPolicy: f.cfg.DefaultRoutingPolicy,
+		MinChanSize:          f.cfg.MinChanSize,
+		MaxChanSize:          f.cfg.MaxChanSize,
+		MaxPendingChannels:   f.cfg.MaxPendingChannels,
+		ReservationTimeout:   f.cfg.ReservationTimeout,
+
+		RemoteReservationTimeout:    f.cfg.RemoteReservationTimeout,
+		PsbtReservationTimeout:      f.cfg.PsbtReservationTimeout,
+		PeerMaxPendingChannels:      f.cfg.PeerMaxPendingChannels,
+		TrustedPeerUnlimitedPending: f.cfg.TrustedPeerUnlimitedPending,
+	}
+}
+
+// maxPendingChannels returns the maximum number of pending channels we allow
+// for the given peer, or zero if the number isn't limited. Overrides set at
+// runtime take precedence over the per peer limits of the config, which in
+// turn take precedence over the unlimited mode of trusted peers.
+func (f *fundingManager) maxPendingChannels(peerKey *btcec.PublicKey) int {
+	peer := route.NewVertex(peerKey)
+
+	f.cfgMtx.RLock()
+	defer f.cfgMtx.RUnlock()
+
+	if maxPending, ok := f.pendingChanOverrides[peer]; ok {
+		return maxPending
+	}
+	if maxPending, ok := f.cfg.PeerMaxPendingChannels[peer]; ok {
+		return maxPending
+	}
+	if f.cfg.TrustedPeerUnlimitedPending && f.cfg.IsTrustedPeer(peerKey) {
+		return 0
+	}
+
+	return f.cfg.MaxPendingChannels
+}
+
+// SetMaxPendingChannels overrides the maximum number of pending channels we
+// allow for the given peer until the next restart. A maximum of zero doesn't
+// limit the number of pending channels of the peer.
+func (f *fundingManager) SetMaxPendingChannels(peer route.Vertex,
+	maxPending int) {
+
+	f.cfgMtx.Lock()
+	defer f.cfgMtx.Unlock()
+
+	f.pendingChanOverrides[peer] = maxPending
+}
+
+// ClearMaxPendingChannels removes the runtime override of the maximum number
+// of pending channels of the given peer. It returns false if there was no
+// such override.
+func (f *fundingManager) ClearMaxPendingChannels(peer route.Vertex) bool {
+	f.cfgMtx.Lock()
+	defer f.cfgMtx.Unlock()
+
+	_, ok := f.pendingChanOverrides[peer]
+	delete(f.pendingChanOverrides, peer)
+
+	return ok
+}
+
+// defaultRoutingPolicy returns the current default routing policy used when
+// initially announcing channels.
+func (f *fundingManager) defaultRoutingPolicy() htlcswitch.ForwardingPolicy {
+	f.cfgMtx.RLock()
+	defer f.cfgMtx.RUnlock()
+
+	return f.cfg.DefaultRoutingPolicy
+}
+
+// reservationTimeout returns the length of idle time after which the given
+// reservation is considered a zombie, depending on how the channel is funded
+// and who initiated it. A zero timeout means the reservation is never pruned.
+func (l *fundingLimits) reservationTimeout(
+	resCtx *reservationWithCtx) time.Duration {
+
+	switch {
+	// PSBT funding reservations are always initiated by us and require
+	// user interaction, so they are only pruned if explicitly configured.
+	// The remote peer is likely going to cancel them after some idle time
+	// anyway.
+	case resCtx.reservation.IsPsbt():
+		return l.PsbtReservationTimeout
+
+	case !resCtx.initiator && l.RemoteReservationTimeout != 0:
+		return l.RemoteReservationTimeout
+
+	default:
+		return l.ReservationTimeout
+	}
+}
+
 // pruneZombieReservations loops through all pending reservations and fails the
-// funding flow for any reservations that have not been updated since the
-// ReservationTimeout and are not locked waiting for the funding transaction.
+// funding flow for any reservations that have not been updated within their
+// reservation timeout and are not locked waiting for the funding transaction.
+// An event is sent for every pruned reservation, so integrations can retry the
+// failed channel opens.
 func (f *fundingManager) pruneZombieReservations() {
 	zombieReservations := make(pendingChannels)
+	limits := f.currentLimits()
 
 	f.resMtx.RLock()
 	for _, pendingReservations := range f.activeReservations {
@@ -3344,14 +4299,13 @@ func (f *fundingManager) pruneZombieReservations() {
 				continue
 			}
 
-			// We don't want to expire PSBT funding reservations.
-			// These reservations are always initiated by us and the
-			// remote peer is likely going to cancel them after some
-			// idle time anyway. So no need for us to also prune
-			// them.
+			timeout := limits.reservationTimeout(resCtx)
+			if timeout == 0 {
+				continue
+			}
+
 			sinceLastUpdate := time.Since(resCtx.lastUpdated)
-			isExpired := sinceLastUpdate > f.cfg.ReservationTimeout
-			if !resCtx.reservation.IsPsbt() && isExpired {
+			if sinceLastUpdate > timeout {
 				zombieReservations[pendingChanID] = resCtx
 			}
 		}
@@ -3359,12 +4313,121 @@ func (f *fundingManager) pruneZombieReservations() {
 	f.resMtx.RUnlock()
 
 	for pendingChanID, resCtx := range zombieReservations {
-		err := fmt.Errorf("reservation timed out waiting for peer "+
-			"(peer_id:%x, chan_id:%x)", resCtx.peer.IdentityKey(),
-			pendingChanID[:])
+		timeoutErr := ErrReservationTimeout
+		if resCtx.reservation.IsPsbt() {
+			timeoutErr = ErrPsbtReservationTimeout
+		}
+		err := fmt.Errorf("%w (peer_id:%x, chan_id:%x)", timeoutErr,
+			resCtx.peer.IdentityKey(), pendingChanID[:])
 		fndgLog.Warnf(err.Error())
 		f.failFundingFlow(resCtx.peer, pendingChanID, err)
+
+		var peerPubKey [33]byte
+		copy(
+			peerPubKey[:],
+			resCtx.peer.IdentityKey().SerializeCompressed(),
+		)
+		f.cfg.NotifyPrunedReservationEvent(
+			channelnotifier.PrunedReservationEvent{
+				PeerPubKey:    peerPubKey,
+				PendingChanID: pendingChanID,
+				Capacity:      resCtx.chanAmt,
+				Initiator:     resCtx.initiator,
+				Psbt:          resCtx.reservation.IsPsbt(),
+			},
+		)
+	}
+}
+
+// pendingReservation is a snapshot of an active funding reservation.
+type pendingReservation struct {
+	peerKey       *btcec.PublicKey
+	pendingChanID [32]byte
+	chanAmt       btcutil.Amount
+	initiator     bool
+	psbt          bool
+	locked        bool
+	createdAt     time.Time
+	lastUpdated   time.Time
+}
+
+// describeChannelConstraints returns the constraints we would propose to the
+// remote party when opening a channel of the given capacity, along with the
+// commitment type the channel would be created with given the features of both
+// parties.
+func (f *fundingManager) describeChannelConstraints(capacity btcutil.Amount,
+	localFeatures, remoteFeatures *lnwire.FeatureVector) (
+	*channeldb.ChannelConstraints, lnwallet.CommitmentType) {
+
+	// This mirrors the defaults that handleInitFundingMsg falls back to if
+	// the open channel request doesn't override them.
+	ourDustLimit := f.cfg.RegisteredChains.PrimaryChainPolicy().DustLimit
+	constraints := &channeldb.ChannelConstraints{
+		DustLimit: ourDustLimit,
+		ChanReserve: f.cfg.RequiredRemoteChanReserve(
+			capacity, ourDustLimit,
+		),
+		MaxPendingAmount: f.cfg.RequiredRemoteMaxValue(capacity),
+		MinHTLC:          f.cfg.DefaultMinHtlcIn,
+		MaxAcceptedHtlcs: f.cfg.RequiredRemoteMaxHTLCs(capacity),
+		CsvDelay:         f.cfg.RequiredRemoteDelay(capacity),
+	}
+
+	return constraints, commitmentType(localFeatures, remoteFeatures)
+}
+
+// pendingReservations returns a snapshot of all active funding reservations.
+func (f *fundingManager) pendingReservations() []*pendingReservation {
+	f.resMtx.RLock()
+	defer f.resMtx.RUnlock()
+
+	var reservations []*pendingReservation
+	for _, pendingReservations := range f.activeReservations {
+		for pendingChanID, resCtx := range pendingReservations {
+			resCtx.updateMtx.RLock()
+			lastUpdated := resCtx.lastUpdated
+			resCtx.updateMtx.RUnlock()
+
+			reservations = append(reservations, &pendingReservation{
+				peerKey:       resCtx.peer.IdentityKey(),
+				pendingChanID: pendingChanID,
+				chanAmt:       resCtx.chanAmt,
+				initiator:     resCtx.initiator,
+				psbt:          resCtx.reservation.IsPsbt(),
+				locked:        lastUpdated.IsZero(),
+				createdAt:     resCtx.createdAt,
+				lastUpdated:   lastUpdated,
+			})
+		}
 	}
+
+	return reservations
+}
+
+// cancelPendingReservation fails the funding flow of the reservation with the
+// given peer and pending channel ID, the same way the zombie sweeper does for
+// reservations that timed out. Reservations that are locked waiting for the
+// funding transaction can't be canceled.
+func (f *fundingManager) cancelPendingReservation(peerKey *btcec.PublicKey,
+	pendingChanID [32]byte) error {
+
+	resCtx, err := f.getReservationCtx(peerKey, pendingChanID)
+	if err != nil {
+		return err
+	}
+
+	if resCtx.isLocked() {
+		return fmt.Errorf("reservation (peer_id:%x, chan_id:%x) is "+
+			"locked waiting for the funding transaction",
+			peerKey.SerializeCompressed(), pendingChanID[:])
+	}
+
+	err = fmt.Errorf("reservation canceled by user (peer_id:%x, "+
+		"chan_id:%x)", peerKey.SerializeCompressed(), pendingChanID[:])
+	fndgLog.Infof(err.Error())
+	f.failFundingFlow(resCtx.peer, pendingChanID, err)
+
+	return nil
 }
 
 // cancelReservationCtx does all needed work in order to securely cancel the
@@ -3546,6 +4609,86 @@ func (f *fundingManager) getChannelOpeningState(chanPoint *wire.OutPoint) (
 	return state, &shortChanID, nil
 }
 
+// saveFundingLockedRetries saves the number of failed attempts to send the
+// fundingLocked message of the channel with the given chanPoint.
+func (f *fundingManager) saveFundingLockedRetries(chanPoint *wire.OutPoint,
+	attempts uint32) error {
+
+	return kvdb.Update(f.cfg.Wallet.Cfg.Database, func(tx kvdb.RwTx) error {
+		bucket, err := tx.CreateTopLevelBucket(
+			fundingLockedRetriesBucket,
+		)
+		if err != nil {
+			return err
+		}
+
+		var outpointBytes bytes.Buffer
+		if err := writeOutpoint(&outpointBytes, chanPoint); err != nil {
+			return err
+		}
+
+		var scratch [4]byte
+		byteOrder.PutUint32(scratch[:], attempts)
+
+		return bucket.Put(outpointBytes.Bytes(), scratch[:])
+	}, func() {})
+}
+
+// getFundingLockedRetries fetches the number of failed attempts to send the
+// fundingLocked message of the channel with the given chanPoint. Zero is
+// returned if no attempt failed yet.
+func (f *fundingManager) getFundingLockedRetries(chanPoint *wire.OutPoint) (
+	uint32, error) {
+
+	var attempts uint32
+	err := kvdb.View(f.cfg.Wallet.Cfg.Database, func(tx kvdb.RTx) error {
+		bucket := tx.ReadBucket(fundingLockedRetriesBucket)
+		if bucket == nil {
+			return nil
+		}
+
+		var outpointBytes bytes.Buffer
+		if err := writeOutpoint(&outpointBytes, chanPoint); err != nil {
+			return err
+		}
+
+		value := bucket.Get(outpointBytes.Bytes())
+		if value == nil {
+			return nil
+		}
+
+		attempts = byteOrder.Uint32(value)
+		return nil
+	}, func() {
+		attempts = 0
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return attempts, nil
+}
+
+// deleteFundingLockedRetries removes the number of failed attempts to send the
+// fundingLocked message of the channel with the given chanPoint.
+func (f *fundingManager) deleteFundingLockedRetries(
+	chanPoint *wire.OutPoint) error {
+
+	return kvdb.Update(f.cfg.Wallet.Cfg.Database, func(tx kvdb.RwTx) error {
+		bucket := tx.ReadWriteBucket(fundingLockedRetriesBucket)
+		if bucket == nil {
+			return nil
+		}
+
+		var outpointBytes bytes.Buffer
+		if err := writeOutpoint(&outpointBytes, chanPoint); err != nil {
+			return err
+		}
+
+		return bucket.Delete(outpointBytes.Bytes())
+	}, func() {})
+}
+
 // deleteChannelOpeningState removes any state for chanPoint from the database.
 func (f *fundingManager) deleteChannelOpeningState(chanPoint *wire.OutPoint) error {
 	return kvdb.Update(f.cfg.Wallet.Cfg.Database, func(tx kvdb.RwTx) error {
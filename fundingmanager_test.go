@@ -13,6 +13,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -36,7 +37,9 @@ import (
 	"github.com/cryptomeow/lnd/lntest/mock"
 	"github.com/cryptomeow/lnd/lnwallet"
 	"github.com/cryptomeow/lnd/lnwallet/chainfee"
+	"github.com/cryptomeow/lnd/lnwallet/chanfunding"
 	"github.com/cryptomeow/lnd/lnwire"
+	"github.com/cryptomeow/lnd/routing/route"
 	"github.com/stretchr/testify/require"
 )
 
@@ -109,9 +112,10 @@ var (
 )
 
 type mockNotifier struct {
-	oneConfChannel chan *chainntnfs.TxConfirmation
-	sixConfChannel chan *chainntnfs.TxConfirmation
-	epochChan      chan *chainntnfs.BlockEpoch
+	oneConfChannel      chan *chainntnfs.TxConfirmation
+	sixConfChannel      chan *chainntnfs.TxConfirmation
+	negativeConfChannel chan int32
+	epochChan           chan *chainntnfs.BlockEpoch
 }
 
 func (m *mockNotifier) RegisterConfirmationsNtfn(txid *chainhash.Hash,
@@ -120,10 +124,13 @@ func (m *mockNotifier) RegisterConfirmationsNtfn(txid *chainhash.Hash,
 	if numConfs == 6 {
 		return &chainntnfs.ConfirmationEvent{
 			Confirmed: m.sixConfChannel,
+			Cancel:    func() {},
 		}, nil
 	}
 	return &chainntnfs.ConfirmationEvent{
-		Confirmed: m.oneConfChannel,
+		Confirmed:    m.oneConfChannel,
+		NegativeConf: m.negativeConfChannel,
+		Cancel:       func() {},
 	}, nil
 }
 
@@ -156,8 +163,10 @@ func (m *mockNotifier) RegisterSpendNtfn(outpoint *wire.OutPoint, _ []byte,
 }
 
 type mockChanEvent struct {
-	openEvent        chan wire.OutPoint
-	pendingOpenEvent chan channelnotifier.PendingOpenChannelEvent
+	openEvent          chan wire.OutPoint
+	pendingOpenEvent   chan channelnotifier.PendingOpenChannelEvent
+	prunedReservations chan channelnotifier.PrunedReservationEvent
+	stalledEvents      chan channelnotifier.FundingLockedStalledEvent
 }
 
 func (m *mockChanEvent) NotifyOpenChannelEvent(outpoint wire.OutPoint) {
@@ -173,6 +182,18 @@ func (m *mockChanEvent) NotifyPendingOpenChannelEvent(outpoint wire.OutPoint,
 	}
 }
 
+func (m *mockChanEvent) NotifyPrunedReservationEvent(
+	event channelnotifier.PrunedReservationEvent) {
+
+	m.prunedReservations <- event
+}
+
+func (m *mockChanEvent) NotifyFundingLockedStalledEvent(
+	event channelnotifier.FundingLockedStalledEvent) {
+
+	m.stalledEvents <- event
+}
+
 type newChannelMsg struct {
 	channel *channeldb.OpenChannel
 	err     chan error
@@ -293,9 +314,10 @@ func createTestFundingManager(t *testing.T, privKey *btcec.PrivateKey,
 	estimator := chainfee.NewStaticEstimator(62500, 0)
 
 	chainNotifier := &mockNotifier{
-		oneConfChannel: make(chan *chainntnfs.TxConfirmation, 1),
-		sixConfChannel: make(chan *chainntnfs.TxConfirmation, 1),
-		epochChan:      make(chan *chainntnfs.BlockEpoch, 2),
+		oneConfChannel:      make(chan *chainntnfs.TxConfirmation, 1),
+		sixConfChannel:      make(chan *chainntnfs.TxConfirmation, 1),
+		negativeConfChannel: make(chan int32, 1),
+		epochChan:           make(chan *chainntnfs.BlockEpoch, 2),
 	}
 
 	sentMessages := make(chan lnwire.Message)
@@ -322,6 +344,13 @@ func createTestFundingManager(t *testing.T, privKey *btcec.PrivateKey,
 		pendingOpenEvent: make(
 			chan channelnotifier.PendingOpenChannelEvent, maxPending,
 		),
+		prunedReservations: make(
+			chan channelnotifier.PrunedReservationEvent, maxPending,
+		),
+		stalledEvents: make(
+			chan channelnotifier.FundingLockedStalledEvent,
+			maxPending,
+		),
 	}
 
 	dbDir := filepath.Join(tempTestDir, "cdb")
@@ -398,6 +427,9 @@ func createTestFundingManager(t *testing.T, privKey *btcec.PrivateKey,
 			pushAmt lnwire.MilliSatoshi) uint16 {
 			return 3
 		},
+		IsTrustedPeer: func(*btcec.PublicKey) bool {
+			return false
+		},
 		RequiredRemoteDelay: func(amt btcutil.Amount) uint16 {
 			return 4
 		},
@@ -424,6 +456,15 @@ func createTestFundingManager(t *testing.T, privKey *btcec.PrivateKey,
 		ReportShortChanID: func(wire.OutPoint) error {
 			return nil
 		},
+		SuspendChannel: func(wire.OutPoint) error {
+			return nil
+		},
+		ResumeChannel: func(wire.OutPoint) error {
+			return nil
+		},
+		IsDraining: func() bool {
+			return false
+		},
 		PublishTransaction: func(txn *wire.MsgTx, _ string) error {
 			publTxChan <- txn
 			return nil
@@ -439,7 +480,12 @@ func createTestFundingManager(t *testing.T, privKey *btcec.PrivateKey,
 		NotifyOpenChannelEvent:        evt.NotifyOpenChannelEvent,
 		OpenChannelPredicate:          chainedAcceptor,
 		NotifyPendingOpenChannelEvent: evt.NotifyPendingOpenChannelEvent,
+		NotifyPrunedReservationEvent:  evt.NotifyPrunedReservationEvent,
 		RegisteredChains:              chainreg.NewChainRegistry(),
+		CreateScidAlias:               cdb.CreateScidAlias,
+		PutPeerScidAlias:              cdb.PutPeerScidAlias,
+
+		NotifyFundingLockedStalledEvent: evt.NotifyFundingLockedStalledEvent,
 	}
 
 	for _, op := range options {
@@ -532,6 +578,7 @@ func recreateAliceFundingManager(t *testing.T, alice *testNode) {
 		},
 		DefaultMinHtlcIn:       5,
 		RequiredRemoteMaxValue: oldCfg.RequiredRemoteMaxValue,
+		IsTrustedPeer:          oldCfg.IsTrustedPeer,
 		PublishTransaction: func(txn *wire.MsgTx, _ string) error {
 			publishChan <- txn
 			return nil
@@ -542,6 +589,20 @@ func recreateAliceFundingManager(t *testing.T, alice *testNode) {
 		ZombieSweeperInterval: oldCfg.ZombieSweeperInterval,
 		ReservationTimeout:    oldCfg.ReservationTimeout,
 		OpenChannelPredicate:  chainedAcceptor,
+
+		RemoteReservationTimeout:     oldCfg.RemoteReservationTimeout,
+		PsbtReservationTimeout:       oldCfg.PsbtReservationTimeout,
+		NotifyPrunedReservationEvent: oldCfg.NotifyPrunedReservationEvent,
+		IsDraining:                   oldCfg.IsDraining,
+
+		CreateScidAlias:  oldCfg.CreateScidAlias,
+		PutPeerScidAlias: oldCfg.PutPeerScidAlias,
+
+		FundingLockedRetryDelay:     oldCfg.FundingLockedRetryDelay,
+		FundingLockedMaxRetryDelay:  oldCfg.FundingLockedMaxRetryDelay,
+		FundingLockedAlertThreshold: oldCfg.FundingLockedAlertThreshold,
+		NotifyFundingLockedStalledEvent: oldCfg.
+			NotifyFundingLockedStalledEvent,
 	})
 	if err != nil {
 		t.Fatalf("failed recreating aliceFundingManager: %v", err)
@@ -1213,6 +1274,16 @@ func TestFundingManagerNormalWorkflow(t *testing.T) {
 	assertErrorNotSent(t, alice.msgChan)
 	assertErrorNotSent(t, bob.msgChan)
 
+	// Bob should have recorded the accepted open attempt, indexed by the
+	// channel point of the new channel.
+	bobDB := bob.fundingMgr.cfg.Wallet.Cfg.Database
+	attempt, err := bobDB.FetchChannelOpenAttempt(*fundingOutPoint)
+	require.NoError(t, err)
+	require.True(t, attempt.Accepted)
+	require.Equal(t, channeldb.AcceptorAccepted, attempt.AcceptorVerdict)
+	require.Equal(t, capacity, attempt.FundingAmt)
+	require.True(t, attempt.Peer.IsEqual(alice.privKey.PubKey()))
+
 	// Notify that transaction was mined.
 	alice.mockNotifier.oneConfChannel <- &chainntnfs.TxConfirmation{
 		Tx: fundingTx,
@@ -1574,6 +1645,211 @@ func TestFundingManagerRestartBehavior(t *testing.T) {
 	assertNoChannelState(t, alice, bob, fundingOutPoint)
 }
 
+// TestFundingManagerFundingLockedRetries checks that failed attempts to send
+// the fundingLocked message are counted persistently, and that an event is
+// sent once the alert threshold is reached.
+func TestFundingManagerFundingLockedRetries(t *testing.T) {
+	t.Parallel()
+
+	alice, bob := setupFundingManagers(t, func(cfg *fundingConfig) {
+		cfg.FundingLockedRetryDelay = time.Millisecond
+		cfg.FundingLockedMaxRetryDelay = time.Millisecond
+		cfg.FundingLockedAlertThreshold = 2
+	})
+	defer tearDownFundingManagers(t, alice, bob)
+
+	// Run through the process of opening the channel, up until the funding
+	// transaction is broadcasted.
+	updateChan := make(chan *lnrpc.OpenStatusUpdate)
+	fundingOutPoint, fundingTx := openChannel(
+		t, alice, bob, 500000, 0, 1, updateChan, true,
+	)
+
+	// We let all attempts of Alice to send the fundingLocked message fail
+	// until told otherwise.
+	var failSend uint32 = 1
+	workingSendMessage := bob.sendMessage
+	bob.sendMessage = func(msg lnwire.Message) error {
+		if atomic.LoadUint32(&failSend) == 1 {
+			return fmt.Errorf("intentional error in SendToPeer")
+		}
+
+		return workingSendMessage(msg)
+	}
+
+	// Notify that transaction was mined.
+	alice.mockNotifier.oneConfChannel <- &chainntnfs.TxConfirmation{
+		Tx: fundingTx,
+	}
+	bob.mockNotifier.oneConfChannel <- &chainntnfs.TxConfirmation{
+		Tx: fundingTx,
+	}
+
+	assertFundingMsgSent(t, bob.msgChan, "FundingLocked")
+
+	// After the second failed attempt, Alice should alert about the stalled
+	// channel.
+	select {
+	case event := <-alice.mockChanEvent.stalledEvents:
+		require.Equal(t, *fundingOutPoint, event.ChannelPoint)
+		require.Equal(
+			t, bobPubKey.SerializeCompressed(), event.PeerPubKey[:],
+		)
+		require.Equal(t, uint32(2), event.Attempts)
+
+	case <-time.After(time.Second * 5):
+		t.Fatalf("funding locked stalled event not sent")
+	}
+
+	// The failed attempts are stored in the database.
+	attempts, err := alice.fundingMgr.getFundingLockedRetries(
+		fundingOutPoint,
+	)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, attempts, uint32(2))
+	assertDatabaseState(t, alice, fundingOutPoint, markedOpen)
+
+	// Once the peer accepts the message again, Alice sends it and the
+	// retry counter is removed.
+	atomic.StoreUint32(&failSend, 0)
+	assertFundingMsgSent(t, alice.msgChan, "FundingLocked")
+	assertDatabaseState(t, alice, fundingOutPoint, fundingLockedSent)
+
+	attempts, err = alice.fundingMgr.getFundingLockedRetries(
+		fundingOutPoint,
+	)
+	require.NoError(t, err)
+	require.Zero(t, attempts)
+
+	// The event is only sent once the threshold is reached, not for every
+	// failure after it.
+	select {
+	case event := <-alice.mockChanEvent.stalledEvents:
+		t.Fatalf("unexpected funding locked stalled event: %v", event)
+	default:
+	}
+}
+
+// TestFundingManagerFundingLockedRetriesRestart checks that the first attempt
+// to send the fundingLocked message isn't delayed by the backoff of attempts
+// that failed before a restart.
+func TestFundingManagerFundingLockedRetriesRestart(t *testing.T) {
+	t.Parallel()
+
+	alice, bob := setupFundingManagers(t, func(cfg *fundingConfig) {
+		cfg.FundingLockedRetryDelay = time.Hour
+		cfg.FundingLockedMaxRetryDelay = time.Hour
+	})
+	defer tearDownFundingManagers(t, alice, bob)
+
+	// Run through the process of opening the channel, up until the funding
+	// transaction is broadcasted.
+	updateChan := make(chan *lnrpc.OpenStatusUpdate)
+	fundingOutPoint, fundingTx := openChannel(
+		t, alice, bob, 500000, 0, 1, updateChan, true,
+	)
+
+	// Pretend a number of attempts to send the fundingLocked message
+	// already failed before a restart.
+	err := alice.fundingMgr.saveFundingLockedRetries(fundingOutPoint, 5)
+	require.NoError(t, err)
+
+	// Notify that transaction was mined.
+	alice.mockNotifier.oneConfChannel <- &chainntnfs.TxConfirmation{
+		Tx: fundingTx,
+	}
+	bob.mockNotifier.oneConfChannel <- &chainntnfs.TxConfirmation{
+		Tx: fundingTx,
+	}
+
+	// The message should be sent right away instead of after the delay,
+	// and the retry counter is removed.
+	assertFundingMsgSent(t, alice.msgChan, "FundingLocked")
+	assertDatabaseState(t, alice, fundingOutPoint, fundingLockedSent)
+
+	attempts, err := alice.fundingMgr.getFundingLockedRetries(
+		fundingOutPoint,
+	)
+	require.NoError(t, err)
+	require.Zero(t, attempts)
+}
+
+// TestFundingLockedRetryDelay checks that the delay between attempts to send
+// the fundingLocked message doubles up to its maximum.
+func TestFundingLockedRetryDelay(t *testing.T) {
+	t.Parallel()
+
+	f := &fundingManager{
+		cfg: &fundingConfig{
+			FundingLockedRetryDelay:    time.Second,
+			FundingLockedMaxRetryDelay: 10 * time.Second,
+		},
+	}
+
+	require.Equal(t, time.Second, f.fundingLockedRetryDelay(1))
+	require.Equal(t, 2*time.Second, f.fundingLockedRetryDelay(2))
+	require.Equal(t, 8*time.Second, f.fundingLockedRetryDelay(4))
+	require.Equal(t, 10*time.Second, f.fundingLockedRetryDelay(5))
+	require.Equal(t, 10*time.Second, f.fundingLockedRetryDelay(100))
+}
+
+// TestFundingManagerAnnouncementConfs checks that the announcement of public
+// channels can be delayed randomly and restricted to batch heights.
+func TestFundingManagerAnnouncementConfs(t *testing.T) {
+	t.Parallel()
+
+	f := &fundingManager{
+		cfg:       &fundingConfig{},
+		chanIDKey: [32]byte{1, 2, 3},
+	}
+	channel := &channeldb.OpenChannel{
+		FundingOutpoint:  wire.OutPoint{Index: 1},
+		NumConfsRequired: 3,
+	}
+	shortChanID := &lnwire.ShortChannelID{BlockHeight: 100}
+
+	// Without a delay, channels are announced after 6 confirmations, or
+	// the required number of confirmations if it is higher.
+	require.Equal(t, uint32(6), f.announcementConfs(channel, shortChanID))
+
+	channel.NumConfsRequired = 10
+	require.Equal(
+		t, uint32(10), f.announcementConfs(channel, shortChanID),
+	)
+	channel.NumConfsRequired = 3
+
+	// The random delay stays within its bounds and is the same for every
+	// call, so it survives restarts.
+	f.cfg.MaxAnnouncementDelay = 20
+	numConfs := f.announcementConfs(channel, shortChanID)
+	require.GreaterOrEqual(t, numConfs, uint32(6))
+	require.LessOrEqual(t, numConfs, uint32(26))
+	require.Equal(t, numConfs, f.announcementConfs(channel, shortChanID))
+
+	// With batching, the announcement happens at the next height that is
+	// a multiple of the batch size. Without a delay, the transaction
+	// confirmed at height 100 gets its 6th confirmation at height 105, so
+	// a batch size of 10 results in an announcement at height 110.
+	f.cfg.MaxAnnouncementDelay = 0
+	f.cfg.AnnouncementBatch = 10
+	require.Equal(
+		t, uint32(11), f.announcementConfs(channel, shortChanID),
+	)
+
+	// If the 6th confirmation is already at a batch height, the
+	// announcement isn't delayed.
+	f.cfg.AnnouncementBatch = 5
+	require.Equal(t, uint32(6), f.announcementConfs(channel, shortChanID))
+
+	// Both can be combined.
+	f.cfg.MaxAnnouncementDelay = 20
+	f.cfg.AnnouncementBatch = 10
+	numConfs = f.announcementConfs(channel, shortChanID)
+	require.Zero(t, (shortChanID.BlockHeight+numConfs-1)%10)
+	require.GreaterOrEqual(t, numConfs, uint32(6))
+	require.LessOrEqual(t, numConfs, uint32(35))
+}
+
 // TestFundingManagerOfflinePeer checks that the fundingManager waits for the
 // server to notify when the peer comes online, in case sending the
 // fundingLocked message fails the first time.
@@ -1779,6 +2055,122 @@ func TestFundingManagerPeerTimeoutAfterInitFunding(t *testing.T) {
 
 	// Alice's zombie reservation should have been pruned.
 	assertNumPendingReservations(t, alice, bobPubKey, 0)
+
+	// An event should have been sent for the pruned reservation.
+	select {
+	case event := <-alice.mockChanEvent.prunedReservations:
+		require.Equal(
+			t, bobPubKey.SerializeCompressed(), event.PeerPubKey[:],
+		)
+		require.Equal(t, btcutil.Amount(500000), event.Capacity)
+		require.True(t, event.Initiator)
+		require.False(t, event.Psbt)
+
+	case <-time.After(time.Second * 5):
+		t.Fatalf("pruned reservation event not sent")
+	}
+}
+
+// TestFundingManagerReservationTimeouts checks that the reservation timeout
+// depends on who initiated the channel.
+func TestFundingManagerReservationTimeouts(t *testing.T) {
+	t.Parallel()
+
+	local := &reservationWithCtx{
+		reservation: &lnwallet.ChannelReservation{},
+		initiator:   true,
+	}
+	remote := &reservationWithCtx{
+		reservation: &lnwallet.ChannelReservation{},
+	}
+
+	// Without a remote reservation timeout, the default timeout applies to
+	// all reservations.
+	limits := &fundingLimits{ReservationTimeout: time.Minute}
+	require.Equal(t, time.Minute, limits.reservationTimeout(local))
+	require.Equal(t, time.Minute, limits.reservationTimeout(remote))
+
+	limits.RemoteReservationTimeout = time.Second
+	require.Equal(t, time.Minute, limits.reservationTimeout(local))
+	require.Equal(t, time.Second, limits.reservationTimeout(remote))
+}
+
+// TestFundingManagerCancelPendingReservation checks that active reservations
+// are listed and that they can be canceled.
+func TestFundingManagerCancelPendingReservation(t *testing.T) {
+	t.Parallel()
+
+	alice, bob := setupFundingManagers(t)
+	defer tearDownFundingManagers(t, alice, bob)
+
+	// We will consume the channel updates as we go, so no buffering is needed.
+	updateChan := make(chan *lnrpc.OpenStatusUpdate)
+
+	// Create a funding request and start the workflow.
+	errChan := make(chan error, 1)
+	initReq := &openChanReq{
+		targetPubkey:    bob.privKey.PubKey(),
+		chainHash:       *fundingNetParams.GenesisHash,
+		localFundingAmt: 500000,
+		pushAmt:         lnwire.NewMSatFromSatoshis(0),
+		private:         false,
+		updates:         updateChan,
+		err:             errChan,
+	}
+
+	alice.fundingMgr.initFundingWorkflow(bob, initReq)
+
+	// Alice should have sent the OpenChannel message to Bob.
+	var aliceMsg lnwire.Message
+	select {
+	case aliceMsg = <-alice.msgChan:
+	case err := <-initReq.err:
+		t.Fatalf("error init funding workflow: %v", err)
+	case <-time.After(time.Second * 5):
+		t.Fatalf("alice did not send OpenChannel message")
+	}
+	openChannelReq, ok := aliceMsg.(*lnwire.OpenChannel)
+	require.True(t, ok, "expected OpenChannel, got %T", aliceMsg)
+
+	// Alice should list her reservation as a wallet funded channel she
+	// initiated.
+	reservations := alice.fundingMgr.pendingReservations()
+	require.Len(t, reservations, 1)
+	res := reservations[0]
+	require.True(t, res.peerKey.IsEqual(bobPubKey))
+	require.Equal(t, openChannelReq.PendingChannelID, res.pendingChanID)
+	require.Equal(t, btcutil.Amount(500000), res.chanAmt)
+	require.True(t, res.initiator)
+	require.False(t, res.psbt)
+	require.False(t, res.locked)
+
+	// Canceling an unknown reservation fails.
+	err := alice.fundingMgr.cancelPendingReservation(
+		bobPubKey, [32]byte{1},
+	)
+	require.Error(t, err)
+	assertNumPendingReservations(t, alice, bobPubKey, 1)
+
+	// Canceling the reservation fails the funding flow. This sends an
+	// error to Bob, so we cancel in a goroutine to not block on the
+	// message.
+	cancelErr := make(chan error, 1)
+	go func() {
+		cancelErr <- alice.fundingMgr.cancelPendingReservation(
+			bobPubKey, res.pendingChanID,
+		)
+	}()
+	assertErrorSent(t, alice.msgChan)
+	require.NoError(t, <-cancelErr)
+
+	select {
+	case <-errChan:
+	case <-time.After(time.Second * 5):
+		t.Fatalf("funding flow not failed")
+	}
+
+	assertNumPendingReservations(t, alice, bobPubKey, 0)
+	require.Empty(t, alice.fundingMgr.pendingReservations())
 }
 
 // TestFundingManagerPeerTimeoutAfterFundingOpen checks that the zombie sweeper
@@ -2327,6 +2719,10 @@ func TestFundingManagerPrivateChannel(t *testing.T) {
 	alice, bob := setupFundingManagers(t)
 	defer tearDownFundingManagers(t, alice, bob)
 
+	// Both peers support alias short channel IDs.
+	alice.remoteFeatures = []lnwire.FeatureBit{lnwire.ScidAliasOptional}
+	bob.remoteFeatures = []lnwire.FeatureBit{lnwire.ScidAliasOptional}
+
 	// We will consume the channel updates as we go, so no buffering is needed.
 	updateChan := make(chan *lnrpc.OpenStatusUpdate)
 
@@ -2382,6 +2778,26 @@ func TestFundingManagerPrivateChannel(t *testing.T) {
 	// channel.
 	assertHandleFundingLocked(t, alice, bob)
 
+	// Both sides handed out an alias for the private channel, which the
+	// other side stored to use it in its route hints.
+	require.NotNil(t, fundingLockedAlice.AliasScid)
+	require.True(t, fundingLockedAlice.AliasScid.IsAlias())
+	require.NotNil(t, fundingLockedBob.AliasScid)
+	require.True(t, fundingLockedBob.AliasScid.IsAlias())
+
+	channel, err := bob.fundingMgr.cfg.FindChannel(fundingLockedAlice.ChanID)
+	require.NoError(t, err)
+
+	bobDB := bob.fundingMgr.cfg.Wallet.Cfg.Database
+	peerAlias, err := bobDB.FetchPeerScidAlias(channel.ShortChannelID)
+	require.NoError(t, err)
+	require.Equal(t, *fundingLockedAlice.AliasScid, peerAlias)
+
+	aliceDB := alice.fundingMgr.cfg.Wallet.Cfg.Database
+	baseScid, err := aliceDB.LookupScidAlias(*fundingLockedAlice.AliasScid)
+	require.NoError(t, err)
+	require.Equal(t, channel.ShortChannelID, baseScid)
+
 	// Notify that six confirmations has been reached on funding transaction.
 	alice.mockNotifier.sixConfChannel <- &chainntnfs.TxConfirmation{
 		Tx: fundingTx,
@@ -3088,6 +3504,43 @@ func TestFundingManagerRejectPush(t *testing.T) {
 		t.Fatalf("expected ErrNonZeroPushAmount error, got \"%v\"",
 			err.Error())
 	}
+
+	// The rejection should have been recorded in Bob's audit log.
+	bobDB := bob.fundingMgr.cfg.Wallet.Cfg.Database
+	resp, dbErr := bobDB.QueryChannelOpenAttempts(
+		channeldb.ChannelOpenAttemptQuery{
+			StartTime:      time.Unix(0, 0),
+			EndTime:        time.Now(),
+			NumMaxAttempts: 10,
+		},
+	)
+	require.NoError(t, dbErr)
+	require.Len(t, resp.Attempts, 1)
+
+	attempt := resp.Attempts[0]
+	require.False(t, attempt.Accepted)
+	require.Equal(
+		t, channeldb.AcceptorNotConsulted, attempt.AcceptorVerdict,
+	)
+	require.Contains(t, attempt.Reason, "non-zero push amounts are disabled")
+	require.Equal(t, openChannelReq.PendingChannelID, attempt.PendingChanID)
+
+	// Further rejections of the same peer should only be recorded up to
+	// the rate limit.
+	for i := 0; i < maxRejectedOpenAttempts; i++ {
+		bob.fundingMgr.ProcessFundingMsg(openChannelReq, alice)
+		assertFundingMsgSent(t, bob.msgChan, "Error")
+	}
+
+	resp, dbErr = bobDB.QueryChannelOpenAttempts(
+		channeldb.ChannelOpenAttemptQuery{
+			StartTime:      time.Unix(0, 0),
+			EndTime:        time.Now(),
+			NumMaxAttempts: 2 * maxRejectedOpenAttempts,
+		},
+	)
+	require.NoError(t, dbErr)
+	require.Len(t, resp.Attempts, maxRejectedOpenAttempts)
 }
 
 // TestFundingManagerMaxConfs ensures that we don't accept a funding proposal
@@ -3159,6 +3612,85 @@ func TestFundingManagerMaxConfs(t *testing.T) {
 	}
 }
 
+// TestFundingManagerTrustedPeer ensures that channels extended to us by a
+// trusted peer only require a single confirmation, and that the trust is
+// surfaced in the channel acceptor request.
+func TestFundingManagerTrustedPeer(t *testing.T) {
+	t.Parallel()
+
+	alice, bob := setupFundingManagers(
+		t, func(cfg *fundingConfig) {
+			cfg.IsTrustedPeer = func(*btcec.PublicKey) bool {
+				return true
+			}
+		},
+	)
+	defer tearDownFundingManagers(t, alice, bob)
+
+	// Register an acceptor with Bob that records the requests it gets.
+	acceptReqs := make(chan *chanacceptor.ChannelAcceptRequest, 1)
+	recordReq := func(req *chanacceptor.ChannelAcceptRequest) (
+		resp *chanacceptor.ChannelAcceptResponse) {
+
+		acceptReqs <- req
+		return chanacceptor.NewChannelAcceptResponse(true)
+	}
+	predicate := bob.fundingMgr.cfg.OpenChannelPredicate
+	predicate.(*chanacceptor.ChainedAcceptor).AddAcceptor(
+		chanacceptor.NewRPCAcceptor(recordReq),
+	)
+
+	// Create a funding request and start the workflow.
+	updateChan := make(chan *lnrpc.OpenStatusUpdate)
+	errChan := make(chan error, 1)
+	initReq := &openChanReq{
+		targetPubkey:    bob.privKey.PubKey(),
+		chainHash:       *fundingNetParams.GenesisHash,
+		localFundingAmt: 500000,
+		pushAmt:         lnwire.NewMSatFromSatoshis(0),
+		private:         false,
+		updates:         updateChan,
+		err:             errChan,
+	}
+
+	alice.fundingMgr.initFundingWorkflow(bob, initReq)
+
+	// Alice should have sent the OpenChannel message to Bob.
+	var aliceMsg lnwire.Message
+	select {
+	case aliceMsg = <-alice.msgChan:
+	case err := <-initReq.err:
+		t.Fatalf("error init funding workflow: %v", err)
+	case <-time.After(time.Second * 5):
+		t.Fatalf("alice did not send OpenChannel message")
+	}
+
+	openChannelReq, ok := aliceMsg.(*lnwire.OpenChannel)
+	if !ok {
+		t.Fatalf("expected OpenChannel to be sent from alice, "+
+			"instead got %T", aliceMsg)
+	}
+
+	// Let Bob handle the init message.
+	bob.fundingMgr.ProcessFundingMsg(openChannelReq, alice)
+
+	select {
+	case req := <-acceptReqs:
+		require.True(t, req.TrustedPeer)
+	case <-time.After(time.Second * 5):
+		t.Fatalf("acceptor not consulted")
+	}
+
+	// Bob should only require a single confirmation instead of the three
+	// his NumRequiredConfs returns.
+	acceptChannelResponse := assertFundingMsgSent(
+		t, bob.msgChan, "AcceptChannel",
+	).(*lnwire.AcceptChannel)
+	require.EqualValues(
+		t, trustedPeerNumConfs, acceptChannelResponse.MinAcceptDepth,
+	)
+}
+
 // TestFundingManagerFundAll tests that we can initiate a funding request to
 // use the funds remaining in the wallet. This should produce a funding tx with
 // no change output.
@@ -3437,6 +3969,142 @@ func TestMaxChannelSizeConfig(t *testing.T) {
 	assertErrorSent(t, bob.msgChan)
 }
 
+// TestFundingManagerRejectDraining tests that no new channels are opened while
+// the node is draining before shutdown, neither by us nor by the remote peer.
+func TestFundingManagerRejectDraining(t *testing.T) {
+	t.Parallel()
+
+	alice, bob := setupFundingManagers(t)
+	defer tearDownFundingManagers(t, alice, bob)
+
+	// Let Bob enter drain mode. Any channel Alice attempts to open should
+	// be rejected by Bob.
+	bob.fundingMgr.cfg.IsDraining = func() bool {
+		return true
+	}
+
+	updateChan := make(chan *lnrpc.OpenStatusUpdate)
+	errChan := make(chan error, 1)
+	initReq := &openChanReq{
+		targetPubkey:    bob.privKey.PubKey(),
+		chainHash:       *fundingNetParams.GenesisHash,
+		localFundingAmt: 500000,
+		pushAmt:         lnwire.NewMSatFromSatoshis(0),
+		private:         false,
+		updates:         updateChan,
+		err:             errChan,
+	}
+
+	alice.fundingMgr.initFundingWorkflow(bob, initReq)
+	openChanMsg := expectOpenChannelMsg(t, alice.msgChan)
+	bob.fundingMgr.ProcessFundingMsg(openChanMsg, alice)
+	assertErrorSent(t, bob.msgChan)
+
+	// Now let Alice enter drain mode as well. This time, she shouldn't
+	// even initiate the funding flow.
+	alice.fundingMgr.cfg.IsDraining = func() bool {
+		return true
+	}
+
+	initReq.err = make(chan error, 1)
+	alice.fundingMgr.initFundingWorkflow(bob, initReq)
+
+	select {
+	case err := <-initReq.err:
+		require.Equal(t, lnwire.ErrNodeDraining, err)
+
+	case <-time.After(time.Second * 5):
+		t.Fatalf("alice did not reject funding request")
+	}
+}
+
+// TestFundingManagerUpdateConfig tests that updated funding limits are applied
+// to the funding flows that are started after the update.
+func TestFundingManagerUpdateConfig(t *testing.T) {
+	t.Parallel()
+
+	alice, bob := setupFundingManagers(t)
+	defer tearDownFundingManagers(t, alice, bob)
+
+	const chanAmt = 500000
+
+	// Raise Bob's minimum channel size above the size of the channel Alice
+	// will attempt to open.
+	limits := bob.fundingMgr.currentLimits()
+	limits.MinChanSize = chanAmt + 1
+	limits.DefaultRoutingPolicy.BaseFee = 1234
+	bob.fundingMgr.UpdateConfig(limits)
+	require.Equal(t, limits, bob.fundingMgr.currentLimits())
+	require.Equal(
+		t, limits.DefaultRoutingPolicy,
+		bob.fundingMgr.defaultRoutingPolicy(),
+	)
+
+	openChan := func() *lnwire.OpenChannel {
+		initReq := &openChanReq{
+			targetPubkey:    bob.privKey.PubKey(),
+			chainHash:       *fundingNetParams.GenesisHash,
+			localFundingAmt: chanAmt,
+			pushAmt:         lnwire.NewMSatFromSatoshis(0),
+			private:         false,
+			updates:         make(chan *lnrpc.OpenStatusUpdate),
+			err:             make(chan error, 1),
+		}
+		alice.fundingMgr.initFundingWorkflow(bob, initReq)
+
+		return expectOpenChannelMsg(t, alice.msgChan)
+	}
+
+	// Bob should reject the channel, as it's too small.
+	bob.fundingMgr.ProcessFundingMsg(openChan(), alice)
+	assertErrorSent(t, bob.msgChan)
+
+	// After lowering the minimum channel size again, Bob should accept the
+	// next channel.
+	limits.MinChanSize = chanAmt
+	bob.fundingMgr.UpdateConfig(limits)
+
+	bob.fundingMgr.ProcessFundingMsg(openChan(), alice)
+	assertFundingMsgSent(t, bob.msgChan, "AcceptChannel")
+}
+
+// TestDescribeChannelConstraints tests that the described channel constraints
+// match the ones proposed when opening a channel.
+func TestDescribeChannelConstraints(t *testing.T) {
+	t.Parallel()
+
+	alice, bob := setupFundingManagers(t)
+	defer tearDownFundingManagers(t, alice, bob)
+
+	const chanAmt = 500000
+
+	constraints, commitType := alice.fundingMgr.describeChannelConstraints(
+		chanAmt, bob.LocalFeatures(), bob.RemoteFeatures(),
+	)
+	require.EqualValues(t, lnwallet.CommitmentTypeLegacy, commitType)
+
+	initReq := &openChanReq{
+		targetPubkey:    bob.privKey.PubKey(),
+		chainHash:       *fundingNetParams.GenesisHash,
+		localFundingAmt: chanAmt,
+		pushAmt:         lnwire.NewMSatFromSatoshis(0),
+		private:         false,
+		updates:         make(chan *lnrpc.OpenStatusUpdate),
+		err:             make(chan error, 1),
+	}
+	alice.fundingMgr.initFundingWorkflow(bob, initReq)
+	openChannelReq := expectOpenChannelMsg(t, alice.msgChan)
+
+	require.Equal(t, &channeldb.ChannelConstraints{
+		DustLimit:        openChannelReq.DustLimit,
+		ChanReserve:      openChannelReq.ChannelReserve,
+		MaxPendingAmount: openChannelReq.MaxValueInFlight,
+		MinHTLC:          openChannelReq.HtlcMinimum,
+		MaxAcceptedHtlcs: openChannelReq.MaxAcceptedHTLCs,
+		CsvDelay:         openChannelReq.CsvDelay,
+	}, constraints)
+}
+
 // TestWumboChannelConfig tests that the funding manager will respect the wumbo
 // channel config param when creating or accepting new channels.
 func TestWumboChannelConfig(t *testing.T) {
@@ -3495,3 +4163,331 @@ func TestWumboChannelConfig(t *testing.T) {
 	bob.fundingMgr.ProcessFundingMsg(openChanMsg, alice)
 	assertFundingMsgSent(t, bob.msgChan, "AcceptChannel")
 }
+
+// TestFundingFailure asserts that the errors that fail a funding flow are
+// classified correctly.
+func TestFundingFailure(t *testing.T) {
+	t.Parallel()
+
+	peerKey := alicePrivKey.PubKey()
+	remoteErr := func(data string) error {
+		return &remoteFundingError{
+			peerKey: peerKey,
+			msg: &lnwire.Error{
+				Data: lnwire.ErrorData(data),
+			},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		err    error
+		code   lnrpc.FundingFailureCode
+		remote bool
+	}{
+		{
+			name: "unknown",
+			err:  errors.New("unknown"),
+			code: lnrpc.FundingFailureCode_UNKNOWN_FUNDING_FAILURE,
+		},
+		{
+			name: "reservation error",
+			err:  lnwallet.ErrCsvDelayTooLarge(10, 5),
+			code: lnrpc.FundingFailureCode_CONSTRAINTS_REJECTED,
+		},
+		{
+			name: "wallet not synced",
+			err:  ErrWalletNotSynced,
+			code: lnrpc.FundingFailureCode_CHAIN_NOT_SYNCED,
+		},
+		{
+			name: "fee rate too high",
+			err:  fmt.Errorf("%w: 10 sat/kw", ErrFundingFeeTooHigh),
+			code: lnrpc.FundingFailureCode_FEE_RATE_TOO_HIGH,
+		},
+		{
+			name: "reservation timeout",
+			err: fmt.Errorf("%w (peer_id:%x)",
+				ErrReservationTimeout, peerKey),
+			code: lnrpc.FundingFailureCode_RESERVATION_TIMEOUT,
+		},
+		{
+			name: "psbt reservation timeout",
+			err: fmt.Errorf("%w (peer_id:%x)",
+				ErrPsbtReservationTimeout, peerKey),
+			code: lnrpc.FundingFailureCode_PSBT_TIMEOUT,
+		},
+		{
+			name: "psbt remote canceled",
+			err: fmt.Errorf("%w: %v", chanfunding.ErrRemoteCanceled,
+				remoteErr("timeout")),
+			code:   lnrpc.FundingFailureCode_PSBT_TIMEOUT,
+			remote: true,
+		},
+		{
+			name:   "remote max pending",
+			err:    remoteErr(lnwire.ErrMaxPendingChannels.Error()),
+			code:   lnrpc.FundingFailureCode_MAX_PENDING_CHANNELS,
+			remote: true,
+		},
+		{
+			name:   "remote not synced",
+			err:    remoteErr(lnwire.ErrSynchronizingChain.Error()),
+			code:   lnrpc.FundingFailureCode_CHAIN_NOT_SYNCED,
+			remote: true,
+		},
+		{
+			name:   "remote acceptor rejected",
+			err:    remoteErr(lnwire.ErrChanRejected.Error()),
+			code:   lnrpc.FundingFailureCode_CHANNEL_REJECTED,
+			remote: true,
+		},
+		{
+			name:   "remote unknown",
+			err:    remoteErr("internal error"),
+			code:   lnrpc.FundingFailureCode_UNKNOWN_FUNDING_FAILURE,
+			remote: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			code, remote := fundingFailure(test.err)
+			require.Equal(t, test.code, code)
+			require.Equal(t, test.remote, remote)
+		})
+	}
+}
+
+// TestFundingManagerPeerMaxPendingChannels asserts that the per peer overrides
+// of the maximum number of pending channels take precedence in the expected
+// order.
+func TestFundingManagerPeerMaxPendingChannels(t *testing.T) {
+	t.Parallel()
+
+	alice := route.NewVertex(alicePubKey)
+	bob := route.NewVertex(bobPubKey)
+
+	trusted := false
+	f, err := newFundingManager(fundingConfig{
+		MaxPendingChannels: maxPending,
+		PeerMaxPendingChannels: map[route.Vertex]int{
+			bob: 5,
+		},
+		IsTrustedPeer: func(*btcec.PublicKey) bool {
+			return trusted
+		},
+	})
+	require.NoError(t, err)
+
+	// Without any overrides, the global limit applies.
+	require.Equal(t, maxPending, f.maxPendingChannels(alicePubKey))
+
+	// Trusted peers are only unlimited if the mode is enabled.
+	trusted = true
+	require.Equal(t, maxPending, f.maxPendingChannels(alicePubKey))
+
+	limits := f.currentLimits()
+	limits.TrustedPeerUnlimitedPending = true
+	f.UpdateConfig(limits)
+	require.Zero(t, f.maxPendingChannels(alicePubKey))
+
+	// The per peer limit of the config takes precedence over the
+	// unlimited mode of trusted peers.
+	require.Equal(t, 5, f.maxPendingChannels(bobPubKey))
+
+	// Overrides set at runtime take precedence over the config.
+	f.SetMaxPendingChannels(alice, 2)
+	f.SetMaxPendingChannels(bob, 0)
+	require.Equal(t, 2, f.maxPendingChannels(alicePubKey))
+	require.Zero(t, f.maxPendingChannels(bobPubKey))
+
+	// Once the override is removed, the config applies again.
+	require.True(t, f.ClearMaxPendingChannels(bob))
+	require.False(t, f.ClearMaxPendingChannels(bob))
+	require.Equal(t, 5, f.maxPendingChannels(bobPubKey))
+}
+
+// spikingFeeEstimator is a fee estimator that returns spikeFeeRate instead of
+// the estimates of the wrapped estimator while spiking.
+type spikingFeeEstimator struct {
+	chainfee.Estimator
+
+	spiking int32 // To be used atomically.
+}
+
+// spikeFeeRate is the fee rate returned by the spikingFeeEstimator while
+// spiking.
+const spikeFeeRate chainfee.SatPerKWeight = 100000
+
+// EstimateFeePerKW returns spikeFeeRate while spiking, or the estimate of the
+// wrapped estimator otherwise.
+func (e *spikingFeeEstimator) EstimateFeePerKW(
+	numBlocks uint32) (chainfee.SatPerKWeight, error) {
+
+	if atomic.LoadInt32(&e.spiking) == 1 {
+		return spikeFeeRate, nil
+	}
+
+	return e.Estimator.EstimateFeePerKW(numBlocks)
+}
+
+// TestFundingManagerMaxFundingFeeRate asserts that channel opens are rejected
+// while the fee estimate exceeds the maximum funding fee rate, or deferred
+// until it drops below it if retries are enabled.
+func TestFundingManagerMaxFundingFeeRate(t *testing.T) {
+	t.Parallel()
+
+	estimator := &spikingFeeEstimator{
+		Estimator: chainfee.NewStaticEstimator(62500, 0),
+		spiking:   1,
+	}
+	setup := func(retryTimeout time.Duration) (*testNode, *testNode) {
+		return setupFundingManagers(t, func(cfg *fundingConfig) {
+			cfg.FeeEstimator = estimator
+			cfg.MaxFundingFeeRate = 62500
+			cfg.FundingFeeRetryTimeout = retryTimeout
+			cfg.FundingFeeRetryInterval = time.Millisecond * 10
+		})
+	}
+	newInitReq := func(bob *testNode) *openChanReq {
+		return &openChanReq{
+			targetPubkey:    bob.privKey.PubKey(),
+			chainHash:       *fundingNetParams.GenesisHash,
+			localFundingAmt: 500000,
+			pushAmt:         lnwire.NewMSatFromSatoshis(0),
+			updates:         make(chan *lnrpc.OpenStatusUpdate),
+			err:             make(chan error, 1),
+		}
+	}
+
+	// Without retries, the channel open is rejected right away.
+	alice, bob := setup(0)
+	defer tearDownFundingManagers(t, alice, bob)
+
+	initReq := newInitReq(bob)
+	alice.fundingMgr.initFundingWorkflow(bob, initReq)
+
+	select {
+	case err := <-initReq.err:
+		require.True(t, errors.Is(err, ErrFundingFeeTooHigh))
+	case <-time.After(time.Second * 5):
+		t.Fatalf("channel open not rejected")
+	}
+
+	// With retries, the channel open is deferred until the fee estimate
+	// drops below the maximum.
+	alice, bob = setup(time.Minute)
+	defer tearDownFundingManagers(t, alice, bob)
+
+	initReq = newInitReq(bob)
+	alice.fundingMgr.initFundingWorkflow(bob, initReq)
+
+	select {
+	case msg := <-alice.msgChan:
+		t.Fatalf("unexpected message while fees spike: %T", msg)
+	case err := <-initReq.err:
+		t.Fatalf("unexpected error while fees spike: %v", err)
+	case <-time.After(time.Millisecond * 100):
+	}
+
+	atomic.StoreInt32(&estimator.spiking, 0)
+	expectOpenChannelMsg(t, alice.msgChan)
+}
+
+// TestFundingManagerFundingReorg checks that a channel whose funding
+// transaction is reorged out of the chain is moved back to pending and
+// suspended, and that the funding flow is restarted, resuming the channel once
+// the funding transaction confirms again.
+func TestFundingManagerFundingReorg(t *testing.T) {
+	t.Parallel()
+
+	var (
+		suspended = make(chan wire.OutPoint, 1)
+		resumed   = make(chan wire.OutPoint, 1)
+	)
+	alice, bob := setupFundingManagers(t, func(cfg *fundingConfig) {
+		cfg.SuspendChannel = func(op wire.OutPoint) error {
+			suspended <- op
+			return nil
+		}
+		cfg.ResumeChannel = func(op wire.OutPoint) error {
+			resumed <- op
+			return nil
+		}
+	})
+	defer tearDownFundingManagers(t, alice, bob)
+
+	updateChan := make(chan *lnrpc.OpenStatusUpdate)
+	localAmt := btcutil.Amount(500000)
+	fundingOutPoint, fundingTx := openChannel(
+		t, alice, bob, localAmt, 0, 1, updateChan, true,
+	)
+
+	// The funding transaction confirms in a block, which gives the channel
+	// a short channel ID.
+	conf := &chainntnfs.TxConfirmation{
+		Tx:          fundingTx,
+		BlockHeight: fundingBroadcastHeight + 1,
+	}
+	alice.mockNotifier.oneConfChannel <- conf
+	bob.mockNotifier.oneConfChannel <- conf
+
+	// Take the channel through the regular opening flow.
+	assertMarkedOpen(t, alice, bob, fundingOutPoint)
+	assertFundingMsgSent(t, alice.msgChan, "FundingLocked")
+	assertFundingMsgSent(t, bob.msgChan, "FundingLocked")
+	assertFundingLockedSent(t, alice, bob, fundingOutPoint)
+	assertChannelAnnouncements(t, alice, bob, localAmt, nil, nil)
+	assertAddedToRouterGraph(t, alice, bob, fundingOutPoint)
+	waitForOpenUpdate(t, updateChan)
+
+	// Nothing should be suspended or resumed so far.
+	select {
+	case op := <-suspended:
+		t.Fatalf("unexpected suspension of %v", op)
+	case op := <-resumed:
+		t.Fatalf("unexpected resumption of %v", op)
+	default:
+	}
+
+	// The funding transaction is now reorged out of the chain of Alice,
+	// which should suspend the channel and move it back to pending.
+	alice.mockNotifier.negativeConfChannel <- 1
+
+	select {
+	case op := <-suspended:
+		require.Equal(t, *fundingOutPoint, op)
+	case <-time.After(time.Second * 5):
+		t.Fatalf("channel not suspended")
+	}
+
+	aliceDB := alice.fundingMgr.cfg.Wallet.Cfg.Database
+	pending, err := aliceDB.FetchPendingChannels()
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	require.Equal(t, *fundingOutPoint, pending[0].FundingOutpoint)
+
+	// The restarted funding flow waits for the funding transaction to
+	// confirm again, after which the channel is resumed.
+	select {
+	case op := <-resumed:
+		t.Fatalf("unexpected resumption of %v", op)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	alice.mockNotifier.oneConfChannel <- conf
+
+	select {
+	case op := <-resumed:
+		require.Equal(t, *fundingOutPoint, op)
+	case <-time.After(time.Second * 5):
+		t.Fatalf("channel not resumed")
+	}
+
+	pending, err = aliceDB.FetchPendingChannels()
+	require.NoError(t, err)
+	require.Empty(t, pending)
+}
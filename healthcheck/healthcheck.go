@@ -92,6 +92,17 @@ func (m *Monitor) Stop() error {
 	return nil
 }
 
+// Results returns the latest result of every health check the monitor is
+// configured with, including the ones that are disabled.
+func (m *Monitor) Results() []Result {
+	results := make([]Result, 0, len(m.cfg.Checks))
+	for _, check := range m.cfg.Checks {
+		results = append(results, check.Result())
+	}
+
+	return results
+}
+
 // CreateCheck is a helper function that takes a function that produces an error
 // and wraps it in a function that returns its result on an error channel.
 // We do not wait group the goroutine running our checkFunc because we expect
@@ -133,6 +144,37 @@ type Observation struct {
 	// Backoff is the amount of time we back off between retries for failed
 	// checks.
 	Backoff time.Duration
+
+	// result is the result of the latest call of the health check.
+	result Result
+
+	resultMtx sync.Mutex
+}
+
+// Result describes the outcome of the latest call of a health check.
+type Result struct {
+	// Name is the name of the health check.
+	Name string
+
+	// Disabled is true if the health check is configured with zero
+	// attempts, meaning it is never run.
+	Disabled bool
+
+	// LastCheck is the time at which the latest call of the health check
+	// completed. It is the zero time if the check hasn't been run yet.
+	LastCheck time.Time
+
+	// LastSuccess is the time at which the health check last passed. It is
+	// the zero time if the check hasn't passed yet.
+	LastSuccess time.Time
+
+	// Err is the error returned by the latest call of the health check, or
+	// nil if it passed.
+	Err error
+
+	// ConsecutiveFailures is the number of calls that failed since the
+	// health check last passed.
+	ConsecutiveFailures int
 }
 
 // NewObservation creates an observation.
@@ -149,6 +191,36 @@ func NewObservation(name string, check func() error, interval,
 	}
 }
 
+// Result returns the result of the latest call of the health check.
+func (o *Observation) Result() Result {
+	o.resultMtx.Lock()
+	defer o.resultMtx.Unlock()
+
+	result := o.result
+	result.Name = o.Name
+	result.Disabled = o.Attempts == 0
+
+	return result
+}
+
+// recordResult records the outcome of a call of the health check.
+func (o *Observation) recordResult(err error) {
+	o.resultMtx.Lock()
+	defer o.resultMtx.Unlock()
+
+	now := time.Now()
+	o.result.LastCheck = now
+	o.result.Err = err
+
+	if err != nil {
+		o.result.ConsecutiveFailures++
+		return
+	}
+
+	o.result.LastSuccess = now
+	o.result.ConsecutiveFailures = 0
+}
+
 // String returns a string representation of an observation.
 func (o *Observation) String() string {
 	return o.Name
@@ -200,6 +272,10 @@ func (o *Observation) retryCheck(quit chan struct{}, shutdown shutdownFunc) {
 			return
 		}
 
+		// Record the outcome of this call, so it can be queried while
+		// we're still retrying.
+		o.recordResult(err)
+
 		// If our error is nil, we have passed our health check, so we
 		// can exit.
 		if err == nil {
@@ -132,6 +132,10 @@ func TestRetryCheck(t *testing.T) {
 		// expectedShutdown is true if we expect a shutdown to be
 		// triggered because all of our calls failed.
 		expectedShutdown bool
+
+		// expectedFailures is the number of consecutive failures we
+		// expect the result of the check to report.
+		expectedFailures int
 	}{
 		{
 			name:             "first call succeeds",
@@ -139,6 +143,7 @@ func TestRetryCheck(t *testing.T) {
 			attempts:         2,
 			timeout:          time.Hour,
 			expectedShutdown: false,
+			expectedFailures: 0,
 		},
 		{
 			name:             "first call fails",
@@ -146,6 +151,7 @@ func TestRetryCheck(t *testing.T) {
 			attempts:         1,
 			timeout:          time.Hour,
 			expectedShutdown: true,
+			expectedFailures: 1,
 		},
 		{
 			name:             "fail then recover",
@@ -153,6 +159,7 @@ func TestRetryCheck(t *testing.T) {
 			attempts:         2,
 			timeout:          time.Hour,
 			expectedShutdown: false,
+			expectedFailures: 0,
 		},
 		{
 			name:             "always fail",
@@ -160,6 +167,7 @@ func TestRetryCheck(t *testing.T) {
 			attempts:         2,
 			timeout:          time.Hour,
 			expectedShutdown: true,
+			expectedFailures: 2,
 		},
 		{
 			name:             "no calls",
@@ -167,6 +175,7 @@ func TestRetryCheck(t *testing.T) {
 			attempts:         0,
 			timeout:          time.Hour,
 			expectedShutdown: false,
+			expectedFailures: 0,
 		},
 		{
 			name:             "call times out",
@@ -174,6 +183,7 @@ func TestRetryCheck(t *testing.T) {
 			attempts:         1,
 			timeout:          1,
 			expectedShutdown: true,
+			expectedFailures: 1,
 		},
 	}
 
@@ -220,6 +230,13 @@ func TestRetryCheck(t *testing.T) {
 
 			require.Equal(t, test.expectedShutdown, shutdown,
 				"unexpected shutdown state")
+
+			result := observation.Result()
+			require.Equal(t, test.expectedFailures,
+				result.ConsecutiveFailures,
+				"unexpected consecutive failures")
+			require.Equal(t, test.attempts == 0, result.Disabled,
+				"unexpected disabled state")
 		})
 	}
 }
@@ -0,0 +1,21 @@
+package healthcheck
+
+import (
+	"net"
+	"time"
+)
+
+// ReachableCheck returns a health check function which asserts that a TCP
+// connection can be established to the given host:port within the timeout.
+// This can be used to monitor remote services that lnd depends on, such as a
+// Tor controller or a remote signer.
+func ReachableCheck(addr string, timeout time.Duration) func() error {
+	return func() error {
+		conn, err := net.DialTimeout("tcp", addr, timeout)
+		if err != nil {
+			return err
+		}
+
+		return conn.Close()
+	}
+}
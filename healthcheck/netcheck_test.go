@@ -0,0 +1,34 @@
+package healthcheck
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestReachableCheck tests that the reachability check passes for a listening
+// address, and fails once the listener is closed.
+func TestReachableCheck(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	check := ReachableCheck(listener.Addr().String(), timeout)
+
+	// Accept connections in the background so that our check is able to
+	// connect.
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.Close()
+		}
+	}()
+
+	require.NoError(t, check(), "expected address to be reachable")
+
+	require.NoError(t, listener.Close())
+	require.Error(t, check(), "expected address to be unreachable")
+}
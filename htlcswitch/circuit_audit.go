@@ -0,0 +1,254 @@
+package htlcswitch
+
+import (
+	"fmt"
+
+	"github.com/cryptomeow/lnd/channeldb"
+	"github.com/cryptomeow/lnd/contractcourt"
+	"github.com/cryptomeow/lnd/htlcswitch/hop"
+	"github.com/cryptomeow/lnd/lntypes"
+	"github.com/cryptomeow/lnd/lnwire"
+)
+
+// OrphanReason describes why a circuit can no longer be completed through the
+// regular flow of settles and fails.
+type OrphanReason uint8
+
+const (
+	// OrphanIncomingClosed indicates that the incoming channel of the
+	// circuit is fully closed, so no settle or fail can be sent upstream
+	// through the circuit anymore.
+	OrphanIncomingClosed OrphanReason = iota
+
+	// OrphanIncomingResolved indicates that the incoming htlc of the
+	// circuit was already settled or failed upstream, as its add was
+	// acked in, or removed together with, the forwarding package of the
+	// incoming channel.
+	OrphanIncomingResolved
+
+	// OrphanOutgoingClosed indicates that the circuit was forwarded over a
+	// channel that is fully closed without the circuit having been
+	// resolved, so no settle or fail will ever arrive for it.
+	OrphanOutgoingClosed
+)
+
+// String returns a human readable description of the orphan reason.
+func (r OrphanReason) String() string {
+	switch r {
+	case OrphanIncomingClosed:
+		return "incoming channel closed"
+
+	case OrphanIncomingResolved:
+		return "incoming htlc resolved"
+
+	case OrphanOutgoingClosed:
+		return "outgoing channel closed"
+
+	default:
+		return "unknown"
+	}
+}
+
+// OrphanedCircuit is a circuit found by the circuit audit that would remain
+// in the circuit map indefinitely if left alone.
+type OrphanedCircuit struct {
+	// Circuit is the orphaned circuit.
+	Circuit *PaymentCircuit
+
+	// Reason is the reason the circuit is orphaned.
+	Reason OrphanReason
+}
+
+// String returns a human readable description of the orphaned circuit.
+func (o *OrphanedCircuit) String() string {
+	outKey := "none"
+	if o.Circuit.HasKeystone() {
+		outKey = o.Circuit.OutKey().String()
+	}
+
+	return fmt.Sprintf("circuit %v -> %v for %x: %v", o.Circuit.Incoming,
+		outKey, o.Circuit.PaymentHash, o.Reason)
+}
+
+// classifyCircuits determines which of the given circuits are orphaned.
+// Channels in the open set still have a live link that processes its
+// forwarding packages, while those in the pending close set have their htlcs
+// resolved on-chain by the contract court. Channels in neither set are fully
+// closed.
+func classifyCircuits(circuits []*PaymentCircuit, open,
+	pendingClose map[lnwire.ShortChannelID]struct{},
+	fetchFwdPkgs func(lnwire.ShortChannelID) ([]*channeldb.FwdPkg,
+		error)) ([]*OrphanedCircuit, error) {
+
+	isClosed := func(chanID lnwire.ShortChannelID) bool {
+		_, isOpen := open[chanID]
+		_, isPendingClose := pendingClose[chanID]
+
+		return !isOpen && !isPendingClose
+	}
+
+	fwdPkgs := make(map[lnwire.ShortChannelID]map[uint64]*channeldb.FwdPkg)
+
+	var orphans []*OrphanedCircuit
+	for _, circuit := range circuits {
+		inChan := circuit.Incoming.ChanID
+
+		// Locally initiated payments have no incoming htlc, so only
+		// their outgoing channel needs to be checked.
+		_, isOpen := open[inChan]
+		switch {
+		case inChan == hop.Source:
+
+		case isClosed(inChan):
+			orphans = append(orphans, &OrphanedCircuit{
+				Circuit: circuit,
+				Reason:  OrphanIncomingClosed,
+			})
+			continue
+
+		// If the incoming channel is being closed, the contract court
+		// takes care of the incoming htlc.
+		case !isOpen:
+			continue
+
+		default:
+			pkgs, ok := fwdPkgs[inChan]
+			if !ok {
+				chanPkgs, err := fetchFwdPkgs(inChan)
+				if err != nil {
+					return nil, err
+				}
+
+				pkgs = make(map[uint64]*channeldb.FwdPkg)
+				for _, pkg := range chanPkgs {
+					pkgs[pkg.Height] = pkg
+				}
+				fwdPkgs[inChan] = pkgs
+			}
+
+			pkg, ok := pkgs[circuit.AddRef.Height]
+			if !ok || pkg.AckFilter.Contains(circuit.AddRef.Index) {
+				orphans = append(orphans, &OrphanedCircuit{
+					Circuit: circuit,
+					Reason:  OrphanIncomingResolved,
+				})
+				continue
+			}
+		}
+
+		if circuit.HasKeystone() && isClosed(circuit.Outgoing.ChanID) {
+			orphans = append(orphans, &OrphanedCircuit{
+				Circuit: circuit,
+				Reason:  OrphanOutgoingClosed,
+			})
+		}
+	}
+
+	return orphans, nil
+}
+
+// AuditCircuits checks the circuit map against the open and closed channels
+// and their forwarding packages, and returns all circuits that can't be
+// completed anymore.
+func (s *Switch) AuditCircuits() ([]*OrphanedCircuit, error) {
+	openChannels, err := s.cfg.DB.FetchAllOpenChannels()
+	if err != nil {
+		return nil, err
+	}
+
+	open := make(map[lnwire.ShortChannelID]struct{})
+	for _, channel := range openChannels {
+		open[channel.ShortChanID()] = struct{}{}
+	}
+
+	closedChannels, err := s.cfg.DB.FetchClosedChannels(true)
+	if err != nil {
+		return nil, err
+	}
+
+	pendingClose := make(map[lnwire.ShortChannelID]struct{})
+	for _, channel := range closedChannels {
+		pendingClose[channel.ShortChanID] = struct{}{}
+	}
+
+	return classifyCircuits(
+		s.circuits.Circuits(), open, pendingClose,
+		s.loadChannelFwdPkgs,
+	)
+}
+
+// ResolveOrphanedCircuits resolves the given orphaned circuits. Circuits whose
+// incoming htlc is resolved or closed are removed from the circuit map.
+// Circuits whose outgoing channel was closed are resolved as if the contract
+// court had delivered the outcome of the outgoing htlc: they are settled
+// upstream if the preimage was learned, and failed back otherwise, since a
+// fully closed channel no longer has any way to claim the htlc.
+//
+// NOTE: The switch must have been started.
+func (s *Switch) ResolveOrphanedCircuits(orphans []*OrphanedCircuit) error {
+	var staleKeys []CircuitKey
+	for _, orphan := range orphans {
+		circuit := orphan.Circuit
+
+		if orphan.Reason != OrphanOutgoingClosed {
+			log.Infof("Removing orphaned %v", orphan)
+
+			staleKeys = append(staleKeys, circuit.Incoming)
+			continue
+		}
+
+		msg := contractcourt.ResolutionMsg{
+			SourceChan: circuit.Outgoing.ChanID,
+			HtlcIndex:  circuit.Outgoing.HtlcID,
+		}
+
+		preimage, ok := s.cfg.PreimageCache.LookupPreimage(
+			lntypes.Hash(circuit.PaymentHash),
+		)
+		if ok {
+			preimageBytes := [32]byte(preimage)
+			msg.PreImage = &preimageBytes
+		} else {
+			msg.Failure = &lnwire.FailPermanentChannelFailure{}
+		}
+
+		log.Infof("Resolving orphaned %v, settle=%v", orphan, ok)
+
+		if err := s.ProcessContractResolution(msg); err != nil {
+			return err
+		}
+	}
+
+	if len(staleKeys) == 0 {
+		return nil
+	}
+
+	return s.circuits.DeleteCircuits(staleKeys...)
+}
+
+// auditCircuits reports all orphaned circuits, and resolves them if the switch
+// is configured to do so.
+func (s *Switch) auditCircuits() error {
+	orphans, err := s.AuditCircuits()
+	if err != nil {
+		return err
+	}
+
+	if len(orphans) == 0 {
+		return nil
+	}
+
+	for _, orphan := range orphans {
+		log.Warnf("Found orphaned %v", orphan)
+	}
+
+	if !s.cfg.ResolveOrphanedCircuits {
+		log.Warnf("Found %v orphaned circuits, enable "+
+			"resolve-orphaned-circuits to resolve them",
+			len(orphans))
+
+		return nil
+	}
+
+	return s.ResolveOrphanedCircuits(orphans)
+}
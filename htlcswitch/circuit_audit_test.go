@@ -0,0 +1,297 @@
+package htlcswitch
+
+import (
+	"crypto/sha256"
+	"testing"
+	"time"
+
+	"github.com/cryptomeow/lnd/channeldb"
+	"github.com/cryptomeow/lnd/htlcswitch/hop"
+	"github.com/cryptomeow/lnd/lnwire"
+	"github.com/stretchr/testify/require"
+)
+
+// TestClassifyCircuits tests that circuits are classified as orphaned based on
+// the state of their channels and the forwarding packages of their incoming
+// channel.
+func TestClassifyCircuits(t *testing.T) {
+	t.Parallel()
+
+	var (
+		openChan         = lnwire.NewShortChanIDFromInt(1)
+		pendingCloseChan = lnwire.NewShortChanIDFromInt(2)
+		closedChan       = lnwire.NewShortChanIDFromInt(3)
+		otherOpenChan    = lnwire.NewShortChanIDFromInt(4)
+	)
+
+	open := map[lnwire.ShortChannelID]struct{}{
+		openChan:      {},
+		otherOpenChan: {},
+	}
+	pendingClose := map[lnwire.ShortChannelID]struct{}{
+		pendingCloseChan: {},
+	}
+
+	// The open channel has a single forwarding package at height 5, in
+	// which the add at index 1 was already acked.
+	ackFilter := channeldb.NewPkgFilter(3)
+	ackFilter.Set(1)
+	fetchFwdPkgs := func(chanID lnwire.ShortChannelID) ([]*channeldb.FwdPkg,
+		error) {
+
+		if chanID != openChan {
+			return nil, nil
+		}
+
+		return []*channeldb.FwdPkg{{
+			Source:    openChan,
+			Height:    5,
+			AckFilter: ackFilter,
+		}}, nil
+	}
+
+	newCircuit := func(in, out lnwire.ShortChannelID, height uint64,
+		index uint16) *PaymentCircuit {
+
+		circuit := &PaymentCircuit{
+			AddRef: channeldb.AddRef{
+				Height: height,
+				Index:  index,
+			},
+			Incoming: CircuitKey{
+				ChanID: in,
+				HtlcID: uint64(index),
+			},
+		}
+		if out != (lnwire.ShortChannelID{}) {
+			circuit.Outgoing = &CircuitKey{
+				ChanID: out,
+			}
+		}
+
+		return circuit
+	}
+
+	var (
+		// A circuit forwarded between two open channels.
+		live = newCircuit(openChan, otherOpenChan, 5, 0)
+
+		// A circuit that hasn't been forwarded yet.
+		halfOpen = newCircuit(openChan, lnwire.ShortChannelID{}, 5, 2)
+
+		// A circuit whose add was acked.
+		acked = newCircuit(openChan, otherOpenChan, 5, 1)
+
+		// A circuit whose forwarding package was removed.
+		removedPkg = newCircuit(openChan, otherOpenChan, 4, 0)
+
+		// A circuit whose incoming channel is fully closed.
+		incomingClosed = newCircuit(closedChan, otherOpenChan, 5, 0)
+
+		// A circuit whose incoming channel is being closed.
+		incomingClosing = newCircuit(
+			pendingCloseChan, closedChan, 5, 0,
+		)
+
+		// A circuit whose outgoing channel is fully closed.
+		outgoingClosed = newCircuit(openChan, closedChan, 5, 0)
+
+		// A circuit whose outgoing channel is being closed.
+		outgoingClosing = newCircuit(openChan, pendingCloseChan, 5, 0)
+
+		// A local payment over a closed channel.
+		localClosed = newCircuit(hop.Source, closedChan, 0, 0)
+	)
+
+	orphans, err := classifyCircuits([]*PaymentCircuit{
+		live, halfOpen, acked, removedPkg, incomingClosed,
+		incomingClosing, outgoingClosed, outgoingClosing, localClosed,
+	}, open, pendingClose, fetchFwdPkgs)
+	require.NoError(t, err)
+
+	require.Equal(t, []*OrphanedCircuit{
+		{Circuit: acked, Reason: OrphanIncomingResolved},
+		{Circuit: removedPkg, Reason: OrphanIncomingResolved},
+		{Circuit: incomingClosed, Reason: OrphanIncomingClosed},
+		{Circuit: outgoingClosed, Reason: OrphanOutgoingClosed},
+		{Circuit: localClosed, Reason: OrphanOutgoingClosed},
+	}, orphans)
+}
+
+// TestSwitchResolveOrphanedCircuits tests that the switch removes orphaned
+// circuits on startup only if it's configured to resolve them.
+func TestSwitchResolveOrphanedCircuits(t *testing.T) {
+	t.Parallel()
+
+	db, err := initDB()
+	require.NoError(t, err)
+
+	s, err := initSwitchWithDB(testStartingHeight, db)
+	require.NoError(t, err)
+
+	// The circuit arrived on a channel that isn't known to the database,
+	// so it can never be completed.
+	_, err = s.circuits.CommitCircuits(&PaymentCircuit{
+		Incoming: CircuitKey{
+			ChanID: lnwire.NewShortChanIDFromInt(1),
+			HtlcID: 0,
+		},
+		ErrorEncrypter: NewMockObfuscator(),
+	})
+	require.NoError(t, err)
+
+	// Without resolving orphaned circuits, the circuit is only reported.
+	orphans, err := s.AuditCircuits()
+	require.NoError(t, err)
+	require.Len(t, orphans, 1)
+	require.Equal(t, OrphanIncomingClosed, orphans[0].Reason)
+
+	require.NoError(t, s.Start())
+	require.NoError(t, s.Stop())
+	require.Equal(t, 1, s.circuits.NumPending())
+
+	// Once enabled, the circuit is removed when the switch starts.
+	s, err = initSwitchWithDB(testStartingHeight, db)
+	require.NoError(t, err)
+	s.cfg.ResolveOrphanedCircuits = true
+
+	require.NoError(t, s.Start())
+	defer s.Stop()
+
+	require.Equal(t, 0, s.circuits.NumPending())
+
+	orphans, err = s.AuditCircuits()
+	require.NoError(t, err)
+	require.Empty(t, orphans)
+}
+
+// TestSwitchResolveOrphanedCircuitBranches tests that orphaned circuits are
+// settled upstream if the preimage is known, failed upstream if it isn't, and
+// removed without notifying the incoming link if their add was already acked.
+func TestSwitchResolveOrphanedCircuitBranches(t *testing.T) {
+	t.Parallel()
+
+	alicePeer, err := newMockServer(
+		t, "alice", testStartingHeight, nil, testDefaultDelta,
+	)
+	require.NoError(t, err)
+	bobPeer, err := newMockServer(
+		t, "bob", testStartingHeight, nil, testDefaultDelta,
+	)
+	require.NoError(t, err)
+
+	pCache := newMockPreimageCache()
+
+	s, err := initSwitchWithDB(testStartingHeight, nil)
+	require.NoError(t, err)
+	s.cfg.PreimageCache = pCache
+	require.NoError(t, s.Start())
+	defer s.Stop()
+
+	chanID1, chanID2, aliceChanID, bobChanID := genIDs()
+
+	aliceChannelLink := newMockChannelLink(
+		s, chanID1, aliceChanID, alicePeer, true,
+	)
+	bobChannelLink := newMockChannelLink(
+		s, chanID2, bobChanID, bobPeer, true,
+	)
+	require.NoError(t, s.AddLink(aliceChannelLink))
+	require.NoError(t, s.AddLink(bobChannelLink))
+
+	// forward forwards an htlc from alice to bob, and returns its circuit
+	// along with the preimage of its payment hash.
+	forward := func(htlcID uint64) (*PaymentCircuit, [32]byte) {
+		preimage, err := genPreimage()
+		require.NoError(t, err)
+
+		packet := &htlcPacket{
+			incomingChanID: aliceChannelLink.ShortChanID(),
+			incomingHTLCID: htlcID,
+			outgoingChanID: bobChannelLink.ShortChanID(),
+			obfuscator:     NewMockObfuscator(),
+			htlc: &lnwire.UpdateAddHTLC{
+				PaymentHash: sha256.Sum256(preimage[:]),
+				Amount:      1,
+			},
+		}
+		require.NoError(t, s.ForwardPackets(nil, packet))
+
+		select {
+		case <-bobChannelLink.packets:
+			err := bobChannelLink.completeCircuit(packet)
+			require.NoError(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("request was not propagated to destination")
+		}
+
+		circuit := s.circuits.LookupOpenCircuit(packet.outKey())
+		require.NotNil(t, circuit)
+
+		return circuit, preimage
+	}
+
+	settled, preimage := forward(0)
+	failed, _ := forward(1)
+	acked, _ := forward(2)
+	require.Equal(t, 3, s.circuits.NumOpen())
+
+	require.NoError(t, pCache.AddPreimages(preimage))
+
+	// assertResolved asserts that alice receives the resolution of the
+	// circuit, and that the circuit is then removed.
+	assertResolved := func(circuit *PaymentCircuit) *htlcPacket {
+		var pkt *htlcPacket
+		select {
+		case pkt = <-aliceChannelLink.packets:
+		case <-time.After(time.Second):
+			t.Fatal("resolution was not propagated to alice")
+		}
+		require.Equal(t, circuit.Incoming, pkt.inKey())
+
+		require.NoError(t, aliceChannelLink.completeCircuit(pkt))
+		require.Nil(t, s.circuits.LookupCircuit(circuit.Incoming))
+
+		return pkt
+	}
+
+	// The circuit of which the preimage is known is settled.
+	err = s.ResolveOrphanedCircuits([]*OrphanedCircuit{{
+		Circuit: settled,
+		Reason:  OrphanOutgoingClosed,
+	}})
+	require.NoError(t, err)
+
+	pkt := assertResolved(settled)
+	fulfill, ok := pkt.htlc.(*lnwire.UpdateFulfillHTLC)
+	require.True(t, ok, "expected settle, got %T", pkt.htlc)
+	require.Equal(t, preimage, fulfill.PaymentPreimage)
+
+	// The circuit of which the preimage isn't known is failed.
+	err = s.ResolveOrphanedCircuits([]*OrphanedCircuit{{
+		Circuit: failed,
+		Reason:  OrphanOutgoingClosed,
+	}})
+	require.NoError(t, err)
+
+	pkt = assertResolved(failed)
+	_, ok = pkt.htlc.(*lnwire.UpdateFailHTLC)
+	require.True(t, ok, "expected fail, got %T", pkt.htlc)
+
+	// The circuit whose add was already acked is only removed, as the
+	// incoming htlc was resolved before.
+	err = s.ResolveOrphanedCircuits([]*OrphanedCircuit{{
+		Circuit: acked,
+		Reason:  OrphanIncomingResolved,
+	}})
+	require.NoError(t, err)
+
+	require.Nil(t, s.circuits.LookupCircuit(acked.Incoming))
+	require.Equal(t, 0, s.circuits.NumOpen())
+
+	select {
+	case pkt := <-aliceChannelLink.packets:
+		t.Fatalf("unexpected packet for alice: %T", pkt.htlc)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -3,6 +3,7 @@ package htlcswitch
 import (
 	"bytes"
 	"fmt"
+	"sort"
 	"sync"
 
 	"github.com/davecgh/go-spew/spew"
@@ -129,6 +130,10 @@ type CircuitMap interface {
 	// NumOpen returns the number of circuits with HTLCs that have been
 	// forwarded via an outgoing link.
 	NumOpen() int
+
+	// Circuits returns all circuits added by CommitCircuits, including
+	// those that have been opened.
+	Circuits() []*PaymentCircuit
 }
 
 var (
@@ -953,3 +958,26 @@ func (cm *circuitMap) NumOpen() int {
 
 	return len(cm.opened)
 }
+
+// Circuits returns all circuits added by CommitCircuits, including those that
+// have been opened, ordered by their incoming circuit key.
+func (cm *circuitMap) Circuits() []*PaymentCircuit {
+	cm.mtx.RLock()
+	defer cm.mtx.RUnlock()
+
+	circuits := make([]*PaymentCircuit, 0, len(cm.pending))
+	for _, circuit := range cm.pending {
+		circuits = append(circuits, circuit)
+	}
+
+	sort.Slice(circuits, func(i, j int) bool {
+		a, b := circuits[i].Incoming, circuits[j].Incoming
+		if a.ChanID != b.ChanID {
+			return a.ChanID.ToUint64() < b.ChanID.ToUint64()
+		}
+
+		return a.HtlcID < b.HtlcID
+	})
+
+	return circuits
+}
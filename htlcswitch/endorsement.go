@@ -0,0 +1,125 @@
+package htlcswitch
+
+import "sync"
+
+// EndorsementOutcomes counts the forwarded HTLCs with the same endorsement
+// signal by their outcome.
+type EndorsementOutcomes struct {
+	// Forwarded is the number of HTLCs that were forwarded.
+	Forwarded uint64
+
+	// Settled is the number of forwarded HTLCs that were settled.
+	Settled uint64
+
+	// Failed is the number of forwarded HTLCs that were failed.
+	Failed uint64
+}
+
+// EndorsementStats correlates the experimental endorsement signal of the
+// HTLCs a peer offered us with the outcome of their forwards. HTLCs without a
+// signal aren't counted.
+type EndorsementStats struct {
+	// Endorsed holds the outcomes of the endorsed HTLCs.
+	Endorsed EndorsementOutcomes
+
+	// Unendorsed holds the outcomes of the unendorsed HTLCs.
+	Unendorsed EndorsementOutcomes
+}
+
+// outcomes returns the outcomes of the HTLCs with the given signal.
+func (s *EndorsementStats) outcomes(endorsed bool) *EndorsementOutcomes {
+	if endorsed {
+		return &s.Endorsed
+	}
+
+	return &s.Unendorsed
+}
+
+// endorsedForward is a forwarded HTLC whose outcome is still pending.
+type endorsedForward struct {
+	// peer is the peer that offered us the HTLC.
+	peer [33]byte
+
+	// endorsed is true if the peer endorsed the HTLC.
+	endorsed bool
+}
+
+// endorsementTracker tracks the outcomes of forwarded HTLCs per endorsement
+// signal and incoming peer. The in-flight forwards are only kept in memory,
+// so forwards that are still pending on restart aren't resolved.
+type endorsementTracker struct {
+	// inFlight maps the incoming circuit key of the forwarded HTLCs to
+	// their peer and signal.
+	inFlight map[CircuitKey]endorsedForward
+
+	// stats holds the endorsement stats of each peer.
+	stats map[[33]byte]*EndorsementStats
+
+	mu sync.Mutex
+}
+
+// newEndorsementTracker creates a new endorsement tracker.
+func newEndorsementTracker() *endorsementTracker {
+	return &endorsementTracker{
+		inFlight: make(map[CircuitKey]endorsedForward),
+		stats:    make(map[[33]byte]*EndorsementStats),
+	}
+}
+
+// forwarded records the forward of an HTLC offered to us by the given peer.
+func (t *endorsementTracker) forwarded(key CircuitKey, peer [33]byte,
+	endorsed bool) {
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// The same HTLC is only counted once, even if it is dispatched again.
+	if _, ok := t.inFlight[key]; ok {
+		return
+	}
+
+	t.inFlight[key] = endorsedForward{
+		peer:     peer,
+		endorsed: endorsed,
+	}
+
+	stats, ok := t.stats[peer]
+	if !ok {
+		stats = &EndorsementStats{}
+		t.stats[peer] = stats
+	}
+	stats.outcomes(endorsed).Forwarded++
+}
+
+// resolved records the outcome of a forwarded HTLC. HTLCs that weren't
+// recorded as forwarded are ignored.
+func (t *endorsementTracker) resolved(key CircuitKey, settled bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	fwd, ok := t.inFlight[key]
+	if !ok {
+		return
+	}
+	delete(t.inFlight, key)
+
+	outcomes := t.stats[fwd.peer].outcomes(fwd.endorsed)
+	if settled {
+		outcomes.Settled++
+	} else {
+		outcomes.Failed++
+	}
+}
+
+// peerStats returns a copy of the endorsement stats of all peers.
+func (t *endorsementTracker) peerStats() map[[33]byte]EndorsementStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stats := make(map[[33]byte]EndorsementStats, len(t.stats))
+	for peer, peerStats := range t.stats {
+		stats[peer] = *peerStats
+	}
+
+	return stats
+}
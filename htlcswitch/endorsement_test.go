@@ -0,0 +1,65 @@
+package htlcswitch
+
+import (
+	"testing"
+
+	"github.com/cryptomeow/lnd/lnwire"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEndorsementTracker asserts that the outcomes of forwarded htlcs are
+// tracked per incoming peer and endorsement signal.
+func TestEndorsementTracker(t *testing.T) {
+	t.Parallel()
+
+	var (
+		alice   = [33]byte{1}
+		bob     = [33]byte{2}
+		chanID  = lnwire.NewShortChanIDFromInt(1)
+		tracker = newEndorsementTracker()
+	)
+
+	key := func(htlcID uint64) CircuitKey {
+		return CircuitKey{ChanID: chanID, HtlcID: htlcID}
+	}
+
+	tracker.forwarded(key(0), alice, true)
+	tracker.forwarded(key(1), alice, true)
+	tracker.forwarded(key(2), alice, false)
+	tracker.forwarded(key(3), bob, false)
+
+	// A forward that is dispatched again is only counted once.
+	tracker.forwarded(key(0), alice, true)
+
+	tracker.resolved(key(0), true)
+	tracker.resolved(key(1), false)
+	tracker.resolved(key(3), true)
+
+	// Resolutions of htlcs that weren't tracked, or that were already
+	// resolved, are ignored.
+	tracker.resolved(key(4), true)
+	tracker.resolved(key(0), false)
+
+	stats := tracker.peerStats()
+	require.Equal(t, map[[33]byte]EndorsementStats{
+		alice: {
+			Endorsed: EndorsementOutcomes{
+				Forwarded: 2,
+				Settled:   1,
+				Failed:    1,
+			},
+			Unendorsed: EndorsementOutcomes{
+				Forwarded: 1,
+			},
+		},
+		bob: {
+			Unendorsed: EndorsementOutcomes{
+				Forwarded: 1,
+				Settled:   1,
+			},
+		},
+	}, stats)
+
+	// Only the pending forward is still in flight.
+	require.Len(t, tracker.inFlight, 1)
+}
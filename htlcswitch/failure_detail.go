@@ -54,6 +54,19 @@ const (
 	// OutgoingFailureForwardsDisabled is returned when the switch is
 	// configured to disallow forwards.
 	OutgoingFailureForwardsDisabled
+
+	// OutgoingFailureNodeDraining is returned when the switch doesn't
+	// accept new HTLCs because the node is draining before shutdown.
+	OutgoingFailureNodeDraining
+
+	// OutgoingFailureDustExposure is returned when a dust htlc would push
+	// the dust exposure of a channel beyond the configured maximum.
+	OutgoingFailureDustExposure
+
+	// OutgoingFailureReservedSlots is returned when forwarding a htlc
+	// would occupy one of the commitment slots of the outgoing channel
+	// that are reserved for our own payments.
+	OutgoingFailureReservedSlots
 )
 
 // FailureString returns the string representation of a failure detail.
@@ -91,6 +104,15 @@ func (fd OutgoingFailure) FailureString() string {
 	case OutgoingFailureForwardsDisabled:
 		return "node configured to disallow forwards"
 
+	case OutgoingFailureNodeDraining:
+		return "node draining before shutdown"
+
+	case OutgoingFailureDustExposure:
+		return "dust exposure of channel exceeded"
+
+	case OutgoingFailureReservedSlots:
+		return "remaining htlc slots reserved for local payments"
+
 	default:
 		return "unknown failure detail"
 	}
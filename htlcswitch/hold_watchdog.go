@@ -0,0 +1,226 @@
+package htlcswitch
+
+import (
+	"sync"
+	"time"
+
+	"github.com/cryptomeow/lnd/channeldb"
+	"github.com/cryptomeow/lnd/clock"
+	"github.com/cryptomeow/lnd/htlcswitch/hop"
+	"github.com/cryptomeow/lnd/invoices"
+	"github.com/cryptomeow/lnd/ticker"
+)
+
+const (
+	// MinHtlcHoldAlarm is the smallest htlc hold alarm duration that can be
+	// configured. Htlcs are regularly held for a few seconds while they're
+	// forwarded or while the remaining parts of a mpp payment arrive.
+	MinHtlcHoldAlarm = time.Minute
+
+	// holdScansPerAlarm is the number of times the incoming htlcs are
+	// scanned within the alarm duration. It determines how accurately the
+	// hold time of an htlc is measured.
+	holdScansPerAlarm = 10
+)
+
+// holdWatchdogConfig contains the configuration of the hold watchdog.
+type holdWatchdogConfig struct {
+	// AlarmDuration is the time an incoming htlc may be held before an
+	// alarm is raised for it.
+	AlarmDuration time.Duration
+
+	// AutoFail indicates whether htlcs paying to our own invoices are
+	// canceled back once the alarm is raised for them.
+	AutoFail bool
+
+	// FetchIncomingHtlcs returns all incoming htlcs that are currently
+	// locked in on our channels, keyed by their incoming circuit key.
+	FetchIncomingHtlcs func() (map[CircuitKey]channeldb.HTLC, error)
+
+	// LookupCircuit returns the circuit of a forwarded htlc, or nil if
+	// the htlc wasn't forwarded.
+	LookupCircuit func(CircuitKey) *PaymentCircuit
+
+	// CancelHeldHtlc cancels a htlc that is held by the invoice registry.
+	// The registry only permits this while the invoice is still open.
+	CancelHeldHtlc func(channeldb.CircuitKey,
+		invoices.FailResolutionResult) error
+
+	// Notifier is used to emit an event for every htlc held too long.
+	Notifier htlcNotifier
+
+	// Clock is the time source used to measure the hold time of htlcs.
+	Clock clock.Clock
+
+	// Ticker determines how often the incoming htlcs are scanned.
+	Ticker ticker.Ticker
+}
+
+// watchedHtlc describes an incoming htlc that is tracked by the hold
+// watchdog.
+type watchedHtlc struct {
+	// firstSeen is the time the htlc was first found by the watchdog.
+	firstSeen time.Time
+
+	// alarmed indicates whether an alarm was raised for the htlc already.
+	alarmed bool
+}
+
+// holdWatchdog regularly scans the incoming htlcs of our channels and raises an
+// alarm for those that are held for longer than the configured duration, as
+// they occupy commitment slots and may be part of a jamming attempt. Htlcs
+// paying to our own invoices can optionally be canceled back.
+//
+// NOTE: The hold time is measured from the first scan that found the htlc, so
+// htlcs that were added before a restart are considered to be new.
+type holdWatchdog struct {
+	cfg *holdWatchdogConfig
+
+	// htlcs contains all incoming htlcs found by the last scan.
+	htlcs map[CircuitKey]*watchedHtlc
+
+	wg   sync.WaitGroup
+	quit chan struct{}
+}
+
+// newHoldWatchdog creates a new hold watchdog.
+func newHoldWatchdog(cfg *holdWatchdogConfig) *holdWatchdog {
+	return &holdWatchdog{
+		cfg:   cfg,
+		htlcs: make(map[CircuitKey]*watchedHtlc),
+		quit:  make(chan struct{}),
+	}
+}
+
+// Start starts the watchdog.
+func (w *holdWatchdog) Start() {
+	log.Infof("Raising hold alarm for htlcs held longer than %v, "+
+		"auto-fail=%v", w.cfg.AlarmDuration, w.cfg.AutoFail)
+
+	w.wg.Add(1)
+	go w.watch()
+}
+
+// Stop stops the watchdog.
+func (w *holdWatchdog) Stop() {
+	close(w.quit)
+	w.wg.Wait()
+}
+
+// watch scans the incoming htlcs on every tick of the ticker.
+//
+// NOTE: This MUST be run as a goroutine.
+func (w *holdWatchdog) watch() {
+	defer w.wg.Done()
+
+	w.cfg.Ticker.Resume()
+	defer w.cfg.Ticker.Stop()
+
+	for {
+		select {
+		case <-w.cfg.Ticker.Ticks():
+			if err := w.scan(); err != nil {
+				log.Errorf("Unable to scan held htlcs: %v", err)
+			}
+
+		case <-w.quit:
+			return
+		}
+	}
+}
+
+// scan updates the set of tracked htlcs and raises an alarm for the htlcs that
+// reached the alarm duration.
+func (w *holdWatchdog) scan() error {
+	htlcs, err := w.cfg.FetchIncomingHtlcs()
+	if err != nil {
+		return err
+	}
+
+	// Forget about the htlcs that were resolved since the last scan.
+	for key := range w.htlcs {
+		if _, ok := htlcs[key]; !ok {
+			delete(w.htlcs, key)
+		}
+	}
+
+	now := w.cfg.Clock.Now()
+	for key, htlc := range htlcs {
+		watched, ok := w.htlcs[key]
+		if !ok {
+			w.htlcs[key] = &watchedHtlc{
+				firstSeen: now,
+			}
+			continue
+		}
+
+		holdTime := now.Sub(watched.firstSeen)
+		if watched.alarmed || holdTime < w.cfg.AlarmDuration {
+			continue
+		}
+
+		watched.alarmed = true
+		w.raiseAlarm(key, htlc, holdTime)
+	}
+
+	return nil
+}
+
+// raiseAlarm reports a htlc held too long and cancels it back if it pays to
+// one of our invoices and the watchdog is configured to do so.
+func (w *holdWatchdog) raiseAlarm(key CircuitKey, htlc channeldb.HTLC,
+	holdTime time.Duration) {
+
+	htlcKey := HtlcKey{
+		IncomingCircuit: key,
+		OutgoingCircuit: CircuitKey{
+			ChanID: hop.Exit,
+		},
+	}
+	info := HtlcInfo{
+		IncomingTimeLock: htlc.RefundTimeout,
+		IncomingAmt:      htlc.Amt,
+	}
+	eventType := HtlcEventTypeReceive
+
+	// Htlcs that are forwarded have a circuit, which is completed once
+	// the outgoing htlc was added.
+	circuit := w.cfg.LookupCircuit(key)
+	if circuit != nil {
+		eventType = HtlcEventTypeForward
+		info.OutgoingAmt = circuit.OutgoingAmount
+
+		if circuit.HasKeystone() {
+			htlcKey.OutgoingCircuit = *circuit.Outgoing
+		}
+	}
+
+	log.Warnf("Htlc %v (%v) held for %v, exceeding hold alarm of %v",
+		htlcKey, eventType, holdTime, w.cfg.AlarmDuration)
+
+	var canceled bool
+	if w.cfg.AutoFail && eventType == HtlcEventTypeReceive {
+		err := w.cfg.CancelHeldHtlc(
+			key, invoices.ResultHtlcHeldTooLong,
+		)
+		switch err {
+		case nil:
+			log.Infof("Canceled htlc %v held too long", htlcKey)
+			canceled = true
+
+		// The registry doesn't permit canceling the htlcs of invoices
+		// that are no longer open, such as accepted hold invoices.
+		case invoices.ErrHtlcNotHeld, invoices.ErrHtlcNotCancelable:
+			log.Debugf("Unable to cancel htlc %v held too long: "+
+				"%v", htlcKey, err)
+
+		default:
+			log.Errorf("Unable to cancel htlc %v held too long: "+
+				"%v", htlcKey, err)
+		}
+	}
+
+	w.cfg.Notifier.NotifyHoldAlarmEvent(
+		htlcKey, info, eventType, holdTime, canceled,
+	)
+}
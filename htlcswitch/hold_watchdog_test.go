@@ -0,0 +1,147 @@
+package htlcswitch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cryptomeow/lnd/channeldb"
+	"github.com/cryptomeow/lnd/clock"
+	"github.com/cryptomeow/lnd/invoices"
+	"github.com/cryptomeow/lnd/lnwire"
+	"github.com/stretchr/testify/require"
+)
+
+// holdAlarmNotifier is a htlc notifier that records the hold alarm events.
+type holdAlarmNotifier struct {
+	mockHTLCNotifier
+
+	events []*HoldAlarmEvent
+}
+
+func (n *holdAlarmNotifier) NotifyHoldAlarmEvent(key HtlcKey, info HtlcInfo,
+	eventType HtlcEventType, holdTime time.Duration, canceled bool) {
+
+	n.events = append(n.events, &HoldAlarmEvent{
+		HtlcKey:       key,
+		HtlcInfo:      info,
+		HtlcEventType: eventType,
+		HoldTime:      holdTime,
+		Canceled:      canceled,
+	})
+}
+
+// TestHoldWatchdog tests that an alarm is raised once for every incoming htlc
+// held too long, and that only htlcs paying to our invoices are canceled.
+func TestHoldWatchdog(t *testing.T) {
+	t.Parallel()
+
+	var (
+		chanID       = lnwire.NewShortChanIDFromInt(1)
+		outChanID    = lnwire.NewShortChanIDFromInt(2)
+		receiveKey   = CircuitKey{ChanID: chanID, HtlcID: 0}
+		forwardKey   = CircuitKey{ChanID: chanID, HtlcID: 1}
+		lateKey      = CircuitKey{ChanID: chanID, HtlcID: 2}
+		outgoingKey  = CircuitKey{ChanID: outChanID, HtlcID: 5}
+		startTime    = time.Unix(1000, 0)
+		testClock    = clock.NewTestClock(startTime)
+		notifier     = &holdAlarmNotifier{}
+		canceledKeys []CircuitKey
+	)
+
+	htlcs := map[CircuitKey]channeldb.HTLC{
+		receiveKey: {Amt: 1000, RefundTimeout: 100},
+		forwardKey: {Amt: 2000, RefundTimeout: 110},
+	}
+
+	watchdog := newHoldWatchdog(&holdWatchdogConfig{
+		AlarmDuration: time.Hour,
+		AutoFail:      true,
+		FetchIncomingHtlcs: func() (map[CircuitKey]channeldb.HTLC,
+			error) {
+
+			return htlcs, nil
+		},
+		LookupCircuit: func(key CircuitKey) *PaymentCircuit {
+			if key != forwardKey {
+				return nil
+			}
+
+			return &PaymentCircuit{
+				Incoming:       forwardKey,
+				Outgoing:       &outgoingKey,
+				OutgoingAmount: 1900,
+			}
+		},
+		CancelHeldHtlc: func(key channeldb.CircuitKey,
+			result invoices.FailResolutionResult) error {
+
+			require.Equal(t, invoices.ResultHtlcHeldTooLong, result)
+			canceledKeys = append(canceledKeys, key)
+
+			return nil
+		},
+		Notifier: notifier,
+		Clock:    testClock,
+	})
+
+	// The first scan starts tracking the htlcs.
+	require.NoError(t, watchdog.scan())
+	require.Empty(t, notifier.events)
+
+	// A new htlc arrives before the alarm duration passes.
+	testClock.SetTime(startTime.Add(30 * time.Minute))
+	htlcs[lateKey] = channeldb.HTLC{Amt: 3000}
+	require.NoError(t, watchdog.scan())
+	require.Empty(t, notifier.events)
+
+	// Once the alarm duration passed, an alarm is raised for the htlcs
+	// present since the first scan.
+	testClock.SetTime(startTime.Add(time.Hour))
+	require.NoError(t, watchdog.scan())
+	require.Len(t, notifier.events, 2)
+
+	events := make(map[CircuitKey]*HoldAlarmEvent)
+	for _, event := range notifier.events {
+		events[event.IncomingCircuit] = event
+	}
+
+	// Only the receive is canceled, the forward is reported with its
+	// outgoing circuit.
+	require.Equal(t, []CircuitKey{receiveKey}, canceledKeys)
+	require.Equal(t, &HoldAlarmEvent{
+		HtlcKey: HtlcKey{
+			IncomingCircuit: receiveKey,
+		},
+		HtlcInfo: HtlcInfo{
+			IncomingTimeLock: 100,
+			IncomingAmt:      1000,
+		},
+		HtlcEventType: HtlcEventTypeReceive,
+		HoldTime:      time.Hour,
+		Canceled:      true,
+	}, events[receiveKey])
+	require.Equal(t, &HoldAlarmEvent{
+		HtlcKey: HtlcKey{
+			IncomingCircuit: forwardKey,
+			OutgoingCircuit: outgoingKey,
+		},
+		HtlcInfo: HtlcInfo{
+			IncomingTimeLock: 110,
+			IncomingAmt:      2000,
+			OutgoingAmt:      1900,
+		},
+		HtlcEventType: HtlcEventTypeForward,
+		HoldTime:      time.Hour,
+	}, events[forwardKey])
+
+	// The alarm isn't raised again for the same htlcs, while the htlc
+	// that arrived later now reaches the alarm duration.
+	delete(htlcs, receiveKey)
+	testClock.SetTime(startTime.Add(90 * time.Minute))
+	require.NoError(t, watchdog.scan())
+	require.Len(t, notifier.events, 3)
+	require.Equal(t, lateKey, notifier.events[2].IncomingCircuit)
+
+	// Resolved htlcs are no longer tracked.
+	require.Len(t, watchdog.htlcs, 2)
+}
@@ -287,6 +287,33 @@ type SettleEvent struct {
 	Timestamp time.Time
 }
 
+// HoldAlarmEvent represents an incoming htlc that has been held on one of our
+// channels for longer than the configured alarm duration. Htlcs that are held
+// for a long time occupy commitment slots and liquidity, which may be a sign
+// of a channel jamming attempt.
+type HoldAlarmEvent struct {
+	// HtlcKey uniquely identifies the htlc. The outgoing circuit is only
+	// set for htlcs that were forwarded.
+	HtlcKey
+
+	// HtlcInfo contains details about the htlc.
+	HtlcInfo
+
+	// HtlcEventType classifies the event as part of a receive or a
+	// forward.
+	HtlcEventType
+
+	// HoldTime is the time the htlc has been held for.
+	HoldTime time.Duration
+
+	// Canceled is true if the htlc was canceled back through the invoice
+	// registry.
+	Canceled bool
+
+	// Timestamp is the time when the alarm was raised.
+	Timestamp time.Time
+}
+
 // NotifyForwardingEvent notifies the HtlcNotifier than a htlc has been
 // forwarded.
 //
@@ -372,6 +399,30 @@ func (h *HtlcNotifier) NotifySettleEvent(key HtlcKey, eventType HtlcEventType) {
 	}
 }
 
+// NotifyHoldAlarmEvent notifies the HtlcNotifier that an incoming htlc has been
+// held for longer than the configured alarm duration.
+//
+// Note this is part of the htlcNotifier interface.
+func (h *HtlcNotifier) NotifyHoldAlarmEvent(key HtlcKey, info HtlcInfo,
+	eventType HtlcEventType, holdTime time.Duration, canceled bool) {
+
+	event := &HoldAlarmEvent{
+		HtlcKey:       key,
+		HtlcInfo:      info,
+		HtlcEventType: eventType,
+		HoldTime:      holdTime,
+		Canceled:      canceled,
+		Timestamp:     h.now(),
+	}
+
+	log.Tracef("Notifying hold alarm event: %v over %v, %v", eventType,
+		key, info)
+
+	if err := h.ntfnServer.SendUpdate(event); err != nil {
+		log.Warnf("Unable to send hold alarm event: %v", err)
+	}
+}
+
 // newHtlc key returns a htlc key for the packet provided. If the packet
 // has a zero incoming channel ID, the packet is for one of our own sends,
 // which has the payment id stashed in the incoming htlc id. If this is the
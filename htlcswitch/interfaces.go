@@ -1,12 +1,15 @@
 package htlcswitch
 
 import (
+	"time"
+
 	"github.com/btcsuite/btcd/wire"
 	"github.com/cryptomeow/lnd/channeldb"
 	"github.com/cryptomeow/lnd/invoices"
 	"github.com/cryptomeow/lnd/lnpeer"
 	"github.com/cryptomeow/lnd/lntypes"
 	"github.com/cryptomeow/lnd/lnwallet"
+	"github.com/cryptomeow/lnd/lnwallet/chainfee"
 	"github.com/cryptomeow/lnd/lnwire"
 	"github.com/cryptomeow/lnd/record"
 )
@@ -62,6 +65,12 @@ type InvoiceDatabase interface {
 type ChannelLink interface {
 	// TODO(roasbeef): modify interface to embed mail boxes?
 
+	// Embed the dustHandler interface.
+	dustHandler
+
+	// Embed the slotHandler interface.
+	slotHandler
+
 	// HandleSwitchPacket handles the switch packets. This packets might be
 	// forwarded to us from another channel link in case the htlc update
 	// came from another peer or if the update was created by user
@@ -138,6 +147,16 @@ type ChannelLink interface {
 	// the channel link opened.
 	Peer() lnpeer.Peer
 
+	// UpgradeChannel negotiates an upgrade of the channel to the given
+	// channel type with the remote peer. The call blocks until the remote
+	// peer accepted or rejected the upgrade.
+	UpgradeChannel(channeldb.ChannelType) error
+
+	// PinCommitFeeRate persists the minimum and maximum fee rate the
+	// commitment fee rate of a channel we initiated is pinned to,
+	// overriding the fee estimate. A bound of zero isn't enforced.
+	PinCommitFeeRate(minFeeRate, maxFeeRate chainfee.SatPerKWeight) error
+
 	// EligibleToForward returns a bool indicating if the channel is able
 	// to actively accept requests to forward HTLC's. A channel may be
 	// active, but not able to forward HTLC's if it hasn't yet finalized
@@ -155,6 +174,31 @@ type ChannelLink interface {
 	Stop()
 }
 
+// dustHandler is an interface used exclusively by the Switch to evaluate the
+// dust exposure of a link.
+type dustHandler interface {
+	// getDustSum returns the total amount of the htlcs that are dust on
+	// the local or remote commitment transaction of the link.
+	getDustSum(remote bool) lnwire.MilliSatoshi
+
+	// isDust returns whether an incoming or outgoing htlc of the given
+	// amount is dust on the local or remote commitment transaction of the
+	// link.
+	isDust(amt lnwire.MilliSatoshi, incoming, remote bool) bool
+}
+
+// slotHandler is an interface used exclusively by the Switch to evaluate the
+// commitment slot usage of a link.
+type slotHandler interface {
+	// numOutgoingHtlcs returns the number of htlcs offered by us that
+	// occupy a commitment slot of the link.
+	numOutgoingHtlcs() int
+
+	// maxOutgoingHtlcs returns the maximum number of htlcs we may offer
+	// on the link at the same time.
+	maxOutgoingHtlcs() int
+}
+
 // ForwardingLog is an interface that represents a time series database which
 // keep track of all successfully completed payment circuits. Every few
 // seconds, the switch will collate and flush out all the successful payment
@@ -180,10 +224,11 @@ type TowerClient interface {
 	// state. If the method returns nil, the backup is guaranteed to be
 	// successful unless the tower is unavailable and client is force quit,
 	// or the justice transaction would create dust outputs when trying to
-	// abide by the negotiated policy. If the channel we're trying to back
-	// up doesn't have a tweak for the remote party's output, then
-	// isTweakless should be true.
-	BackupState(*lnwire.ChannelID, *lnwallet.BreachRetribution, bool) error
+	// abide by the negotiated policy. The channel type is the commitment
+	// type of the revoked state, which determines the scripts of its
+	// outputs.
+	BackupState(*lnwire.ChannelID, *lnwallet.BreachRetribution,
+		channeldb.ChannelType) error
 }
 
 // InterceptableHtlcForwarder is the interface to set the interceptor
@@ -282,4 +327,9 @@ type htlcNotifier interface {
 	// committed to as part of a forward or a receive to our node has been
 	// settled.
 	NotifySettleEvent(key HtlcKey, eventType HtlcEventType)
+
+	// NotifyHoldAlarmEvent notifies the HtlcNotifier that an incoming htlc
+	// has been held for longer than the configured alarm duration.
+	NotifyHoldAlarmEvent(key HtlcKey, info HtlcInfo,
+		eventType HtlcEventType, holdTime time.Duration, canceled bool)
 }
@@ -256,9 +256,14 @@ type ChannelLinkConfig struct {
 
 	// TowerClient is an optional engine that manages the signing,
 	// encrypting, and uploading of justice transactions to the daemon's
-	// configured set of watchtowers.
+	// configured set of watchtowers. It backs up states of channels
+	// without anchor outputs.
 	TowerClient TowerClient
 
+	// AnchorTowerClient is an optional engine like TowerClient that backs
+	// up states of channels with anchor outputs.
+	AnchorTowerClient TowerClient
+
 	// MaxOutgoingCltvExpiry is the maximum outgoing timelock that the link
 	// should accept for a forwarded HTLC. The value is relative to the
 	// current block height.
@@ -269,6 +274,17 @@ type ChannelLinkConfig struct {
 	// initiator of the channel.
 	MaxFeeAllocation float64
 
+	// RemoteFeePolicy bounds the commitment fee rates the remote party may
+	// set with update_fee. This only applies to channels initiated by the
+	// remote party.
+	RemoteFeePolicy RemoteFeePolicy
+
+	// ExperimentalEndorsement indicates whether the experimental
+	// endorsement signal is sent along with outgoing HTLCs. Forwarded
+	// HTLCs relay the signal of the incoming HTLC, while our own payments
+	// are endorsed.
+	ExperimentalEndorsement bool
+
 	// NotifyActiveLink allows the link to tell the ChannelNotifier when a
 	// link is first started.
 	NotifyActiveLink func(wire.OutPoint)
@@ -294,6 +310,16 @@ type localUpdateAddMsg struct {
 	err chan error
 }
 
+// upgradeChanMsg contains a locally initiated request to upgrade the channel
+// to a new channel type, and a channel that will receive the outcome of the
+// upgrade negotiation with the remote peer. The channel must be buffered to
+// prevent the link from blocking. The error channel is nil if the upgrade
+// proposal is retransmitted after a reconnection.
+type upgradeChanMsg struct {
+	chanType channeldb.ChannelType
+	err      chan error
+}
+
 // channelLink is the service which drives a channel's commitment update
 // state-machine. In the event that an HTLC needs to be propagated to another
 // link, the forward handler from config is used which sends HTLC to the
@@ -304,6 +330,7 @@ type channelLink struct {
 	started       int32
 	reestablished int32
 	shutdown      int32
+	upgrading     int32
 
 	// failed should be set to true in case a link error happens, making
 	// sure we don't process any more updates.
@@ -358,6 +385,23 @@ type channelLink struct {
 	// sent across.
 	localUpdateAdd chan *localUpdateAddMsg
 
+	// upgradeReq is a channel to which locally initiated channel upgrade
+	// requests are sent across.
+	upgradeReq chan *upgradeChanMsg
+
+	// feeBoundsUpdated is signaled when the user updated the commitment
+	// fee rate bounds of the channel.
+	feeBoundsUpdated chan struct{}
+
+	// remoteFees tracks the fee updates of the remote party that are
+	// outside of the bounds of the remote fee policy.
+	remoteFees remoteFeeMonitor
+
+	// pendingUpgrade is the channel upgrade we've proposed to the remote
+	// peer, and for which we're awaiting a reply. While set, no new
+	// updates are initiated by us.
+	pendingUpgrade *upgradeChanMsg
+
 	// htlcUpdates is a channel that we'll use to update outside
 	// sub-systems with the latest set of active HTLC's on our channel.
 	htlcUpdates chan *contractcourt.ContractUpdate
@@ -407,12 +451,17 @@ func NewChannelLink(cfg ChannelLinkConfig,
 		channel:     channel,
 		shortChanID: channel.ShortChanID(),
 		// TODO(roasbeef): just do reserve here?
-		htlcUpdates:    make(chan *contractcourt.ContractUpdate),
-		hodlMap:        make(map[channeldb.CircuitKey]hodlHtlc),
-		hodlQueue:      queue.NewConcurrentQueue(10),
-		log:            build.NewPrefixLog(logPrefix, log),
-		quit:           make(chan struct{}),
-		localUpdateAdd: make(chan *localUpdateAddMsg),
+		htlcUpdates:      make(chan *contractcourt.ContractUpdate),
+		hodlMap:          make(map[channeldb.CircuitKey]hodlHtlc),
+		hodlQueue:        queue.NewConcurrentQueue(10),
+		log:              build.NewPrefixLog(logPrefix, log),
+		quit:             make(chan struct{}),
+		localUpdateAdd:   make(chan *localUpdateAddMsg),
+		upgradeReq:       make(chan *upgradeChanMsg),
+		feeBoundsUpdated: make(chan struct{}, 1),
+		remoteFees: remoteFeeMonitor{
+			policy: cfg.RemoteFeePolicy,
+		},
 	}
 }
 
@@ -433,16 +482,18 @@ func (l *channelLink) Start() error {
 
 	l.log.Info("starting")
 
-	// If the config supplied watchtower client, ensure the channel is
-	// registered before trying to use it during operation.
-	// TODO(halseth): support anchor types for watchtower.
-	state := l.channel.State()
-	if l.cfg.TowerClient != nil && state.ChanType.HasAnchors() {
-		l.log.Warnf("Skipping tower registration for anchor " +
-			"channel type")
-	} else if l.cfg.TowerClient != nil && !state.ChanType.HasAnchors() {
-		err := l.cfg.TowerClient.RegisterChannel(l.ChanID())
-		if err != nil {
+	// If the config supplied watchtower clients, ensure the channel is
+	// registered before trying to use them during operation. The channel
+	// is registered with both clients, as a channel that was upgraded to
+	// anchors still needs to back up the states it revoked before.
+	for _, towerClient := range []TowerClient{
+		l.cfg.TowerClient, l.cfg.AnchorTowerClient,
+	} {
+		if towerClient == nil {
+			continue
+		}
+
+		if err := towerClient.RegisterChannel(l.ChanID()); err != nil {
 			return err
 		}
 	}
@@ -558,10 +609,20 @@ func (l *channelLink) WaitForShutdown() {
 // we know the remote party's next revocation point. Otherwise, we can't
 // initiate new channel state. We also require that the short channel ID not be
 // the all-zero source ID, meaning that the channel has had its ID finalized.
+// Finally, no HTLC's are forwarded while the channel type is being upgraded.
 func (l *channelLink) EligibleToForward() bool {
 	return l.channel.RemoteNextRevocation() != nil &&
 		l.ShortChanID() != hop.Source &&
-		l.isReestablished()
+		l.isReestablished() &&
+		!l.isUpgrading()
+}
+
+// isUpgrading returns true if an upgrade of the channel type is currently
+// being negotiated, or if the commitments of the new channel type haven't been
+// exchanged yet. During this time, we must not initiate any new updates.
+func (l *channelLink) isUpgrading() bool {
+	return atomic.LoadInt32(&l.upgrading) == 1 ||
+		l.channel.UpgradePending()
 }
 
 // isReestablished returns true if the link has successfully completed the
@@ -595,6 +656,43 @@ func (l *channelLink) sampleNetworkFee() (chainfee.SatPerKWeight, error) {
 	return feePerKw, nil
 }
 
+// checkRemoteFee checks a fee update of the remote party against the remote
+// fee policy. A warning is logged for every fee rate outside of the bounds. If
+// the maximum number of consecutive violations is reached, the link is failed
+// and the channel force closed, in which case false is returned.
+func (l *channelLink) checkRemoteFee(feeRate chainfee.SatPerKWeight) bool {
+	if !l.cfg.RemoteFeePolicy.enabled() {
+		return true
+	}
+
+	estimate, err := l.sampleNetworkFee()
+	if err != nil {
+		l.log.Errorf("unable to sample network fee to check remote "+
+			"fee update: %v", err)
+		return true
+	}
+
+	exceeded, err := l.remoteFees.observe(feeRate, estimate)
+	if err == nil {
+		return true
+	}
+
+	l.log.Warnf("remote fee update outside of bounds (%v consecutive): "+
+		"%v", l.remoteFees.violations, err)
+
+	if !exceeded {
+		return true
+	}
+
+	l.fail(LinkFailureError{
+		code:       ErrInvalidUpdate,
+		ForceClose: true,
+	}, "remote fee update outside of bounds %v consecutive times: %v",
+		l.remoteFees.violations, err)
+
+	return false
+}
+
 // shouldAdjustCommitFee returns true if we should update our commitment fee to
 // match that of the network fee. We'll only update our commitment fee if the
 // network fee is +/- 10% to our network fee.
@@ -616,6 +714,104 @@ func shouldAdjustCommitFee(netFee, chanFee chainfee.SatPerKWeight) bool {
 	}
 }
 
+// applyCommitFeeBounds returns the given fee rate limited to the commitment fee
+// rate bounds pinned by the user. A bound of zero isn't enforced.
+func applyCommitFeeBounds(feeRate, minFeeRate,
+	maxFeeRate chainfee.SatPerKWeight) chainfee.SatPerKWeight {
+
+	switch {
+	case maxFeeRate != 0 && feeRate > maxFeeRate:
+		return maxFeeRate
+
+	case minFeeRate != 0 && feeRate < minFeeRate:
+		return minFeeRate
+
+	default:
+		return feeRate
+	}
+}
+
+// adjustCommitFee samples the network fee and proposes a new commitment fee
+// rate to the remote party if our current fee rate deviates too much from it.
+// The network fee is overridden by the commitment fee rate bounds pinned by the
+// user, if any.
+func (l *channelLink) adjustCommitFee() {
+	// If we're not the initiator of the channel, don't we don't control
+	// the fees, so we can ignore this.
+	if !l.channel.IsInitiator() {
+		return
+	}
+
+	// We won't propose new fees while the channel type is being upgraded,
+	// as the upgrade requires the channel to be free of any updates.
+	if l.isUpgrading() {
+		return
+	}
+
+	// If we are the initiator, then we'll sample the current fee rate to
+	// get into the chain within 3 blocks.
+	netFee, err := l.sampleNetworkFee()
+	if err != nil {
+		l.log.Errorf("unable to sample network fee: %v", err)
+		return
+	}
+
+	// The fee rate bounds pinned by the user take precedence over the
+	// network fee.
+	minFee, maxPinnedFee := l.channel.CommitFeeRateBounds()
+	netFee = applyCommitFeeBounds(netFee, minFee, maxPinnedFee)
+
+	// We'll check to see if we should update the fee rate based on our
+	// current set fee rate. We'll cap the new fee rate to our max fee
+	// allocation. If our current fee rate is outside of the pinned
+	// bounds, we'll update it regardless of how small the difference is.
+	commitFee := l.channel.CommitFeeRate()
+	maxFee := l.channel.MaxFeeRate(l.cfg.MaxFeeAllocation)
+	newCommitFee := chainfee.SatPerKWeight(
+		math.Min(float64(netFee), float64(maxFee)),
+	)
+	if newCommitFee == commitFee {
+		return
+	}
+
+	outOfBounds := applyCommitFeeBounds(
+		commitFee, minFee, maxPinnedFee,
+	) != commitFee
+	if !outOfBounds && !shouldAdjustCommitFee(newCommitFee, commitFee) {
+		return
+	}
+
+	// If we do, then we'll send a new UpdateFee message to the remote
+	// party, to be locked in with a new update.
+	if err := l.updateChannelFee(newCommitFee); err != nil {
+		l.log.Errorf("unable to update fee rate: %v", err)
+	}
+}
+
+// PinCommitFeeRate persists the minimum and maximum fee rate our commitment
+// fee rate is pinned to, and adjusts the commitment fee rate right away if it
+// is outside of the new bounds. A bound of zero isn't enforced.
+//
+// NOTE: Part of the ChannelLink interface.
+func (l *channelLink) PinCommitFeeRate(minFeeRate,
+	maxFeeRate chainfee.SatPerKWeight) error {
+
+	err := l.channel.SetCommitFeeRateBounds(minFeeRate, maxFeeRate)
+	if err != nil {
+		return err
+	}
+
+	l.log.Infof("pinned commitment fee rate to min=%v, max=%v",
+		minFeeRate, maxFeeRate)
+
+	select {
+	case l.feeBoundsUpdated <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
 // createFailureWithUpdate retrieves this link's last channel update message and
 // passes it into the callback. It expects a fully populated failure message.
 func (l *channelLink) createFailureWithUpdate(
@@ -1044,6 +1240,20 @@ func (l *channelLink) htlcManager() {
 		go l.fwdPkgGarbager()
 	}
 
+	// If the channel type was upgraded before we went offline, but the
+	// commitments of the new type weren't exchanged yet, we'll retransmit
+	// our upgrade proposal to ensure both parties finish the upgrade.
+	if l.channel.UpgradePending() {
+		err := l.proposeUpgrade(&upgradeChanMsg{
+			chanType: l.channel.State().ChanType,
+		})
+		if err != nil {
+			l.fail(LinkFailureError{code: ErrInternalError},
+				"unable to resume channel upgrade: %v", err)
+			return
+		}
+	}
+
 	for {
 		// We must always check if we failed at some point processing
 		// the last update before processing the next.
@@ -1067,42 +1277,12 @@ func (l *channelLink) htlcManager() {
 		// fee to see if we should adjust our commitment fee.
 		case <-l.updateFeeTimer.C:
 			l.updateFeeTimer.Reset(l.randomFeeUpdateTimeout())
+			l.adjustCommitFee()
 
-			// If we're not the initiator of the channel, don't we
-			// don't control the fees, so we can ignore this.
-			if !l.channel.IsInitiator() {
-				continue
-			}
-
-			// If we are the initiator, then we'll sample the
-			// current fee rate to get into the chain within 3
-			// blocks.
-			netFee, err := l.sampleNetworkFee()
-			if err != nil {
-				l.log.Errorf("unable to sample network fee: %v",
-					err)
-				continue
-			}
-
-			// We'll check to see if we should update the fee rate
-			// based on our current set fee rate. We'll cap the new
-			// fee rate to our max fee allocation.
-			commitFee := l.channel.CommitFeeRate()
-			maxFee := l.channel.MaxFeeRate(l.cfg.MaxFeeAllocation)
-			newCommitFee := chainfee.SatPerKWeight(
-				math.Min(float64(netFee), float64(maxFee)),
-			)
-			if !shouldAdjustCommitFee(newCommitFee, commitFee) {
-				continue
-			}
-
-			// If we do, then we'll send a new UpdateFee message to
-			// the remote party, to be locked in with a new update.
-			if err := l.updateChannelFee(newCommitFee); err != nil {
-				l.log.Errorf("unable to update fee rate: %v",
-					err)
-				continue
-			}
+		// The commitment fee rate bounds were updated, so we'll check
+		// right away whether our commitment fee needs to be adjusted.
+		case <-l.feeBoundsUpdated:
+			l.adjustCommitFee()
 
 		// The underlying channel has notified us of a unilateral close
 		// carried out by the remote peer. In the case of such an
@@ -1145,6 +1325,10 @@ func (l *channelLink) htlcManager() {
 		case msg := <-l.localUpdateAdd:
 			msg.err <- l.handleDownstreamUpdateAdd(msg.pkt)
 
+		// A request to upgrade the channel type was received.
+		case req := <-l.upgradeReq:
+			l.handleUpgradeRequest(req)
+
 		// A message from the connected peer was just received. This
 		// indicates that we have a new incoming HTLC, either directly
 		// for us, or part of a multi-hop HTLC circuit.
@@ -1304,6 +1488,28 @@ func (l *channelLink) handleDownstreamUpdateAdd(pkt *htlcPacket) error {
 		return nil
 	}
 
+	// We can't add any HTLCs while the channel type is being upgraded, so
+	// we'll fail the packet back to the switch.
+	if l.isUpgrading() {
+		l.log.Warnf("Unable to handle downstream add HTLC: channel " +
+			"upgrade in progress")
+
+		l.mailBox.FailAdd(pkt)
+
+		return NewDetailedLinkError(
+			lnwire.NewTemporaryChannelFailure(nil),
+			OutgoingFailureDownstreamHtlcAdd,
+		)
+	}
+
+	// As the sender of our own payments, we endorse them.
+	if l.cfg.ExperimentalEndorsement && pkt.incomingChanID == hop.Source &&
+		htlc.Endorsement == nil {
+
+		endorsed := lnwire.Endorsed
+		htlc.Endorsement = &endorsed
+	}
+
 	// A new payment has been initiated via the downstream channel,
 	// so we add the new HTLC to our local log, then update the
 	// commitment chains.
@@ -1835,16 +2041,16 @@ func (l *channelLink) handleUpstreamMsg(msg lnwire.Message) {
 			return
 		}
 
-		// If we have a tower client, we'll proceed in backing up the
-		// state that was just revoked.
-		// TODO(halseth): support anchor types for watchtower.
+		// If we have a tower client for the commitment type of the
+		// state that was just revoked, we'll proceed in backing it up.
+		// The revoked state may have been created before the channel
+		// type was upgraded.
 		state := l.channel.State()
-		if l.cfg.TowerClient != nil && state.ChanType.HasAnchors() {
-			l.log.Warnf("Skipping tower backup for anchor " +
-				"channel type")
-		} else if l.cfg.TowerClient != nil && !state.ChanType.HasAnchors() {
+		revokedHeight := state.RemoteCommitment.CommitHeight - 1
+		chanType := state.RemoteCommitType(revokedHeight)
+		if towerClient := l.towerClient(chanType); towerClient != nil {
 			breachInfo, err := lnwallet.NewBreachRetribution(
-				state, state.RemoteCommitment.CommitHeight-1, 0,
+				state, revokedHeight, 0,
 			)
 			if err != nil {
 				l.fail(LinkFailureError{code: ErrInternalError},
@@ -1852,10 +2058,9 @@ func (l *channelLink) handleUpstreamMsg(msg lnwire.Message) {
 				return
 			}
 
-			chanType := l.channel.State().ChanType
 			chanID := l.ChanID()
-			err = l.cfg.TowerClient.BackupState(
-				&chanID, breachInfo, chanType.IsTweakless(),
+			err = towerClient.BackupState(
+				&chanID, breachInfo, chanType,
 			)
 			if err != nil {
 				l.fail(LinkFailureError{code: ErrInternalError},
@@ -1896,6 +2101,14 @@ func (l *channelLink) handleUpstreamMsg(msg lnwire.Message) {
 				"error receiving fee update: %v", err)
 			return
 		}
+
+		if !l.checkRemoteFee(fee) {
+			return
+		}
+
+	case *lnwire.ChannelUpgrade:
+		l.handleChannelUpgrade(msg)
+
 	case *lnwire.Error:
 		// Error received from remote, MUST fail channel, but should
 		// only print the contents of the error message if all
@@ -2099,6 +2312,7 @@ func (l *channelLink) UpdateShortChanID() (lnwire.ShortChannelID, error) {
 	l.log.Infof("updating to short_chan_id=%v for chan_id=%v", sid, chanID)
 
 	l.Lock()
+	oldSid := l.shortChanID
 	l.shortChanID = sid
 	l.Unlock()
 
@@ -2113,13 +2327,27 @@ func (l *channelLink) UpdateShortChanID() (lnwire.ShortChannelID, error) {
 	}()
 
 	// Now that the short channel ID has been properly updated, we can begin
-	// garbage collecting any forwarding packages we create.
-	l.wg.Add(1)
-	go l.fwdPkgGarbager()
+	// garbage collecting any forwarding packages we create. If the link
+	// already had a short channel ID, the garbage collector is already
+	// running.
+	if oldSid == hop.Source {
+		l.wg.Add(1)
+		go l.fwdPkgGarbager()
+	}
 
 	return sid, nil
 }
 
+// towerClient returns the watchtower client that backs up states of the given
+// commitment type, or nil if there is none.
+func (l *channelLink) towerClient(chanType channeldb.ChannelType) TowerClient {
+	if chanType.HasAnchors() {
+		return l.cfg.AnchorTowerClient
+	}
+
+	return l.cfg.TowerClient
+}
+
 // ChanID returns the channel ID for the channel link. The channel ID is a more
 // compact representation of a channel's full outpoint.
 //
@@ -2141,6 +2369,40 @@ func (l *channelLink) Bandwidth() lnwire.MilliSatoshi {
 	return l.channel.AvailableBalance()
 }
 
+// getDustSum returns the total amount of the htlcs that are dust on the local
+// or remote commitment transaction of the channel.
+//
+// NOTE: Part of the dustHandler interface.
+func (l *channelLink) getDustSum(remote bool) lnwire.MilliSatoshi {
+	return l.channel.DustSum(remote)
+}
+
+// isDust returns whether an incoming or outgoing htlc of the given amount is
+// dust on the local or remote commitment transaction of the channel.
+//
+// NOTE: Part of the dustHandler interface.
+func (l *channelLink) isDust(amt lnwire.MilliSatoshi, incoming,
+	remote bool) bool {
+
+	return l.channel.IsHtlcDust(amt, incoming, remote)
+}
+
+// numOutgoingHtlcs returns the number of htlcs offered by us that occupy a
+// commitment slot of the channel.
+//
+// NOTE: Part of the slotHandler interface.
+func (l *channelLink) numOutgoingHtlcs() int {
+	return l.channel.NumOutgoingHtlcs()
+}
+
+// maxOutgoingHtlcs returns the maximum number of htlcs we may offer on the
+// channel at the same time.
+//
+// NOTE: Part of the slotHandler interface.
+func (l *channelLink) maxOutgoingHtlcs() int {
+	return int(l.channel.State().LocalChanCfg.MaxAcceptedHtlcs)
+}
+
 // AttachMailBox updates the current mailbox used by this link, and hooks up
 // the mailbox's message and packet outboxes to the link's upstream and
 // downstream chans, respectively.
@@ -2399,6 +2661,218 @@ func (l *channelLink) HandleLocalAddPacket(pkt *htlcPacket) error {
 	}
 }
 
+// UpgradeChannel proposes to upgrade the channel to the given channel type to
+// the remote peer. The call blocks until the remote peer either accepted or
+// rejected the upgrade.
+//
+// NOTE: Part of the ChannelLink interface.
+func (l *channelLink) UpgradeChannel(chanType channeldb.ChannelType) error {
+	// Create a buffered result channel to prevent the link from blocking.
+	errChan := make(chan error, 1)
+
+	select {
+	case l.upgradeReq <- &upgradeChanMsg{
+		chanType: chanType,
+		err:      errChan,
+	}:
+	case <-l.quit:
+		return ErrLinkShuttingDown
+	}
+
+	select {
+	case err := <-errChan:
+		return err
+	case <-l.quit:
+		return ErrLinkShuttingDown
+	}
+}
+
+// handleUpgradeRequest validates a locally initiated channel upgrade request,
+// and proposes the upgrade to the remote peer if it's valid.
+func (l *channelLink) handleUpgradeRequest(req *upgradeChanMsg) {
+	chanType := l.channel.State().ChanType
+
+	switch {
+	case l.isUpgrading():
+		req.err <- ErrUpgradeInProgress
+		return
+
+	case !l.cfg.Peer.RemoteFeatures().HasFeature(
+		lnwire.ChannelUpgradeOptional,
+	):
+		req.err <- ErrUpgradeNotSupported
+		return
+
+	case !chanType.IsValidUpgrade(req.chanType):
+		req.err <- fmt.Errorf("invalid channel type upgrade from %v "+
+			"to %v", chanType, req.chanType)
+		return
+
+	case l.channel.OweCommitment(true) || l.channel.OweCommitment(false):
+		req.err <- lnwallet.ErrChanUpgradeNotClean
+		return
+	}
+
+	if err := l.proposeUpgrade(req); err != nil {
+		req.err <- err
+	}
+}
+
+// proposeUpgrade sends a ChannelUpgrade message for the requested channel
+// type to the remote peer, and marks the upgrade as pending until the remote
+// peer replies.
+func (l *channelLink) proposeUpgrade(req *upgradeChanMsg) error {
+	l.log.Infof("proposing channel upgrade to %v", req.chanType)
+
+	msg := lnwire.NewChannelUpgrade(
+		l.ChanID(), commitmentFeatures(req.chanType),
+	)
+	if err := l.cfg.Peer.SendMessage(false, msg); err != nil {
+		return err
+	}
+
+	l.pendingUpgrade = req
+	atomic.StoreInt32(&l.upgrading, 1)
+
+	return nil
+}
+
+// handleChannelUpgrade handles a ChannelUpgrade message received from the
+// remote peer. This is either the reply to an upgrade we proposed, or a new
+// upgrade proposal of the remote peer.
+func (l *channelLink) handleChannelUpgrade(msg *lnwire.ChannelUpgrade) {
+	chanType := l.channel.State().ChanType
+	newType, err := upgradedChanType(chanType, msg.CommitmentFeatures)
+
+	// If we proposed an upgrade ourselves, then this message is the reply
+	// of the remote peer. If it doesn't match our proposal, the upgrade
+	// was rejected.
+	if l.pendingUpgrade != nil {
+		req := l.pendingUpgrade
+		l.pendingUpgrade = nil
+		atomic.StoreInt32(&l.upgrading, 0)
+
+		sendResult := func(err error) {
+			if req.err != nil {
+				req.err <- err
+			}
+		}
+
+		if err != nil || newType != req.chanType {
+			l.log.Warnf("remote peer rejected channel upgrade to %v",
+				req.chanType)
+
+			sendResult(ErrUpgradeRejected)
+			return
+		}
+
+		// The upgrade was accepted, so we'll apply it ourselves unless
+		// this is a retransmission of an upgrade we've already applied.
+		if chanType != newType {
+			err := l.channel.UpgradeChanType(newType)
+			if err != nil {
+				sendResult(err)
+				l.fail(LinkFailureError{code: ErrInternalError},
+					"unable to upgrade channel: %v", err)
+				return
+			}
+		}
+
+		sendResult(nil)
+
+		// With the upgrade applied, we'll sign a new commitment of the
+		// upgraded type for the remote peer.
+		if l.channel.OweCommitment(true) {
+			l.updateCommitTxOrFail()
+		}
+
+		return
+	}
+
+	// Otherwise, the remote peer proposes an upgrade. If the channel
+	// already is of the proposed type, we accept it by echoing the
+	// proposal, which is needed if the remote peer retransmits its
+	// proposal after a reconnection.
+	if err == nil && newType != chanType {
+		localFeatures := l.cfg.Peer.LocalFeatures()
+		if localFeatures.HasFeature(lnwire.ChannelUpgradeOptional) {
+			err = l.channel.UpgradeChanType(newType)
+		} else {
+			err = ErrUpgradeNotSupported
+		}
+	}
+
+	// If we can't accept the upgrade, we'll reject it by replying with the
+	// commitment features of the current channel type.
+	reply := msg.CommitmentFeatures
+	if err != nil {
+		l.log.Warnf("rejecting channel upgrade proposed by remote "+
+			"peer: %v", err)
+
+		reply = commitmentFeatures(chanType)
+	}
+
+	err = l.cfg.Peer.SendMessage(
+		false, lnwire.NewChannelUpgrade(l.ChanID(), reply),
+	)
+	if err != nil {
+		l.log.Errorf("unable to reply to channel upgrade: %v", err)
+		return
+	}
+
+	if l.channel.OweCommitment(true) {
+		l.updateCommitTxOrFail()
+	}
+}
+
+// commitmentFeatures returns the commitment related feature bits that are
+// sent in a ChannelUpgrade message for the given channel type.
+func commitmentFeatures(chanType channeldb.ChannelType) *lnwire.RawFeatureVector {
+	features := lnwire.NewRawFeatureVector()
+	if chanType.IsTweakless() {
+		features.Set(lnwire.StaticRemoteKeyRequired)
+	}
+	if chanType.HasAnchors() {
+		features.Set(lnwire.AnchorsRequired)
+	}
+
+	return features
+}
+
+// upgradedChanType returns the channel type that results from applying the
+// commitment features of a ChannelUpgrade message to the current channel type.
+// An error is returned if the features contain any unknown bits.
+func upgradedChanType(chanType channeldb.ChannelType,
+	features *lnwire.RawFeatureVector) (channeldb.ChannelType, error) {
+
+	newType := chanType &^ (channeldb.SingleFunderTweaklessBit |
+		channeldb.AnchorOutputsBit)
+
+	if features.IsSet(lnwire.StaticRemoteKeyRequired) {
+		newType |= channeldb.SingleFunderTweaklessBit
+	}
+	if features.IsSet(lnwire.AnchorsRequired) {
+		newType |= channeldb.AnchorOutputsBit
+	}
+
+	// Make sure the received features don't contain any bits we don't
+	// understand, by comparing them against the features of the resulting
+	// channel type.
+	var expected, actual bytes.Buffer
+	if err := commitmentFeatures(newType).Encode(&expected); err != nil {
+		return 0, err
+	}
+	if err := features.Encode(&actual); err != nil {
+		return 0, err
+	}
+	if !bytes.Equal(expected.Bytes(), actual.Bytes()) {
+		return 0, fmt.Errorf("unknown commitment features in channel " +
+			"upgrade")
+	}
+
+	return newType, nil
+}
+
 // HandleChannelUpdate handles the htlc requests as settle/add/fail which sent
 // to us from remote peer we have a channel with.
 //
@@ -2725,6 +3199,9 @@ func (l *channelLink) processRemoteAdds(fwdPkg *channeldb.FwdPkg,
 					Amount:      fwdInfo.AmountToForward,
 					PaymentHash: pd.RHash,
 				}
+				if l.cfg.ExperimentalEndorsement {
+					addMsg.Endorsement = pd.Endorsement
+				}
 
 				// Finally, we'll encode the onion packet for
 				// the _next_ hop using the hop iterator
@@ -2767,6 +3244,9 @@ func (l *channelLink) processRemoteAdds(fwdPkg *channeldb.FwdPkg,
 				Amount:      fwdInfo.AmountToForward,
 				PaymentHash: pd.RHash,
 			}
+			if l.cfg.ExperimentalEndorsement {
+				addMsg.Endorsement = pd.Endorsement
+			}
 
 			// Finally, we'll encode the onion packet for the
 			// _next_ hop using the hop iterator decoded for the
@@ -2893,9 +3373,6 @@ func (l *channelLink) processExitHop(pd *lnwallet.PaymentDescriptor,
 		return nil
 	}
 
-	// Notify the invoiceRegistry of the exit hop htlc. If we crash right
-	// after this, this code will be re-executed after restart. We will
-	// receive back a resolution event.
 	invoiceHash := lntypes.Hash(pd.RHash)
 
 	circuitKey := channeldb.CircuitKey{
@@ -2903,6 +3380,29 @@ func (l *channelLink) processExitHop(pd *lnwallet.PaymentDescriptor,
 		HtlcID: pd.HtlcIndex,
 	}
 
+	// Just like the switch does for forwards, we don't accept any new
+	// payments while the node is draining before shutdown. Htlcs that the
+	// invoice registry already knows about are replays of payments we
+	// accepted before, so they are passed on to the registry to be
+	// resolved as usual.
+	if l.cfg.Switch.IsDraining() &&
+		!l.isKnownExitHopHtlc(invoiceHash, circuitKey) {
+
+		l.log.Debugf("rejecting incoming htlc(%x): node draining",
+			pd.RHash[:])
+
+		failure := NewDetailedLinkError(
+			&lnwire.FailTemporaryNodeFailure{},
+			OutgoingFailureNodeDraining,
+		)
+		l.sendHTLCError(pd, failure, obfuscator, true)
+
+		return nil
+	}
+
+	// Notify the invoiceRegistry of the exit hop htlc. If we crash right
+	// after this, this code will be re-executed after restart. We will
+	// receive back a resolution event.
 	event, err := l.cfg.Registry.NotifyExitHopHtlc(
 		invoiceHash, pd.Amount, pd.Timeout, int32(heightNow),
 		circuitKey, l.hodlQueue.ChanIn(), payload,
@@ -2928,6 +3428,20 @@ func (l *channelLink) processExitHop(pd *lnwallet.PaymentDescriptor,
 	return l.processHtlcResolution(event, htlc)
 }
 
+// isKnownExitHopHtlc returns true if the invoice registry already recorded
+// the htlc identified by the given circuit key on the invoice it pays to.
+func (l *channelLink) isKnownExitHopHtlc(hash lntypes.Hash,
+	circuitKey channeldb.CircuitKey) bool {
+
+	invoice, err := l.cfg.Registry.LookupInvoice(hash)
+	if err != nil {
+		return false
+	}
+
+	_, ok := invoice.Htlcs[circuitKey]
+	return ok
+}
+
 // settleHTLC settles the HTLC on the channel.
 func (l *channelLink) settleHTLC(preimage lntypes.Preimage,
 	pd *lnwallet.PaymentDescriptor) error {
@@ -1588,9 +1588,11 @@ func TestChannelLinkSingleHopMessageOrdering(t *testing.T) {
 
 type mockPeer struct {
 	sync.Mutex
-	disconnected bool
-	sentMsgs     chan lnwire.Message
-	quit         chan struct{}
+	disconnected   bool
+	sentMsgs       chan lnwire.Message
+	quit           chan struct{}
+	localFeatures  *lnwire.FeatureVector
+	remoteFeatures *lnwire.FeatureVector
 }
 
 func (m *mockPeer) QuitSignal() <-chan struct{} {
@@ -1629,10 +1631,10 @@ func (m *mockPeer) Address() net.Addr {
 	return nil
 }
 func (m *mockPeer) LocalFeatures() *lnwire.FeatureVector {
-	return nil
+	return m.localFeatures
 }
 func (m *mockPeer) RemoteFeatures() *lnwire.FeatureVector {
-	return nil
+	return m.remoteFeatures
 }
 
 func newSingleLinkTestHarness(chanAmt, chanReserve btcutil.Amount) (
@@ -3568,6 +3570,62 @@ func TestShouldAdjustCommitFee(t *testing.T) {
 	}
 }
 
+// TestApplyCommitFeeBounds tests that the fee rate proposed for the commitment
+// is limited to the bounds pinned by the user, while zero bounds are ignored.
+func TestApplyCommitFeeBounds(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		feeRate    chainfee.SatPerKWeight
+		minFeeRate chainfee.SatPerKWeight
+		maxFeeRate chainfee.SatPerKWeight
+		expected   chainfee.SatPerKWeight
+	}{
+		{
+			name:     "no bounds",
+			feeRate:  5000,
+			expected: 5000,
+		},
+		{
+			name:       "within bounds",
+			feeRate:    5000,
+			minFeeRate: 1000,
+			maxFeeRate: 10000,
+			expected:   5000,
+		},
+		{
+			name:       "below minimum",
+			feeRate:    253,
+			minFeeRate: 1000,
+			expected:   1000,
+		},
+		{
+			name:       "above maximum",
+			feeRate:    50000,
+			maxFeeRate: 10000,
+			expected:   10000,
+		},
+		{
+			name:       "pinned",
+			feeRate:    50000,
+			minFeeRate: 2000,
+			maxFeeRate: 2000,
+			expected:   2000,
+		},
+	}
+
+	for _, test := range tests {
+		feeRate := applyCommitFeeBounds(
+			test.feeRate, test.minFeeRate, test.maxFeeRate,
+		)
+		if feeRate != test.expected {
+			t.Fatalf("%v: expected fee rate %v, got %v", test.name,
+				test.expected, feeRate)
+		}
+	}
+}
+
 // TestChannelLinkShutdownDuringForward asserts that a link can be fully
 // stopped when it is trying to send synchronously through the switch. The
 // specific case this can occur is when a link forwards incoming Adds. We test
@@ -5567,6 +5625,49 @@ func TestChannelLinkCanceledInvoice(t *testing.T) {
 	}
 }
 
+// TestChannelLinkExitHopDraining tests that the link rejects payments to us
+// while the switch is draining.
+func TestChannelLinkExitHopDraining(t *testing.T) {
+	t.Parallel()
+
+	// Setup a alice-bob network.
+	alice, bob, cleanUp, err := createTwoClusterChannels(
+		btcutil.SatoshiPerBitcoin*3,
+		btcutil.SatoshiPerBitcoin*5)
+	if err != nil {
+		t.Fatalf("unable to create channel: %v", err)
+	}
+	defer cleanUp()
+
+	n := newTwoHopNetwork(t, alice.channel, bob.channel, testStartingHeight)
+	if err := n.start(); err != nil {
+		t.Fatal(err)
+	}
+	defer n.stop()
+
+	// Bob drains his node before the payment arrives.
+	n.bobServer.htlcSwitch.SetDraining(true)
+
+	amount := lnwire.NewMSatFromSatoshis(btcutil.SatoshiPerBitcoin)
+	htlcAmt, totalTimelock, hops := generateHops(amount, testStartingHeight,
+		n.bobChannelLink)
+
+	firstHop := n.bobChannelLink.ShortChanID()
+	_, err = makePayment(
+		n.aliceServer, n.bobServer, firstHop, hops, amount, htlcAmt,
+		totalTimelock,
+	).Wait(30 * time.Second)
+
+	rtErr, ok := err.(ClearTextError)
+	if !ok {
+		t.Fatalf("expected ClearTextError, but got %v", err)
+	}
+	_, ok = rtErr.WireMessage().(*lnwire.FailTemporaryNodeFailure)
+	if !ok {
+		t.Fatalf("expected temporary node failure, but got %v", err)
+	}
+}
+
 type hodlInvoiceTestCtx struct {
 	n                   *twoHopNetwork
 	startBandwidthAlice lnwire.MilliSatoshi
@@ -5837,6 +5938,97 @@ func TestChannelLinkHoldInvoiceRestart(t *testing.T) {
 	}
 }
 
+// TestChannelLinkHoldInvoiceRestartDraining asserts that a hodl htlc that was
+// accepted before the node started draining is still passed to the invoice
+// registry after a restart, instead of being failed by the link.
+func TestChannelLinkHoldInvoiceRestartDraining(t *testing.T) {
+	t.Parallel()
+
+	defer timeout(t)()
+
+	const (
+		chanAmt = btcutil.SatoshiPerBitcoin * 5
+	)
+
+	aliceLink, bobChannel, _, start, cleanUp, restore, err :=
+		newSingleLinkTestHarness(chanAmt, 0)
+	if err != nil {
+		t.Fatalf("unable to create link: %v", err)
+	}
+	defer cleanUp()
+
+	alice := newPersistentLinkHarness(
+		t, aliceLink, nil, restore,
+	)
+
+	if err := start(); err != nil {
+		t.Fatalf("unable to start test harness: %v", err)
+	}
+
+	var (
+		coreLink = alice.coreLink
+		registry = coreLink.cfg.Registry.(*mockInvoiceRegistry)
+	)
+
+	registry.settleChan = make(chan lntypes.Hash)
+
+	htlc, invoice := generateHtlcAndInvoice(t, 0)
+
+	// Convert into a hodl invoice and save the preimage for later.
+	preimage := invoice.Terms.PaymentPreimage
+	invoice.Terms.PaymentPreimage = nil
+	invoice.HodlInvoice = true
+
+	err = registry.AddInvoice(*invoice, htlc.PaymentHash)
+	if err != nil {
+		t.Fatalf("unable to add invoice to registry: %v", err)
+	}
+
+	ctx := linkTestContext{
+		t:          t,
+		aliceLink:  alice.link,
+		aliceMsgs:  alice.msgs,
+		bobChannel: bobChannel,
+	}
+
+	// Lock in htlc paying the hodl invoice.
+	ctx.sendHtlcBobToAlice(htlc)
+	ctx.sendCommitSigBobToAlice(1)
+	ctx.receiveRevAndAckAliceToBob()
+	ctx.receiveCommitSigAliceToBob(1)
+	ctx.sendRevAndAckBobToAlice()
+
+	<-registry.settleChan
+
+	// Start draining and restart the link. The htlc is replayed, but since
+	// the registry already accepted it, it must not be failed.
+	coreLink.cfg.Switch.SetDraining(true)
+
+	alice.restart(false)
+	ctx.aliceLink = alice.link
+	ctx.aliceMsgs = alice.msgs
+
+	// Expect htlc to be reprocessed by the registry.
+	<-registry.settleChan
+
+	err = registry.SettleHodlInvoice(*preimage)
+	if err != nil {
+		t.Fatalf("settle hodl invoice: %v", err)
+	}
+
+	// Expect alice to settle the htlc rather than fail it.
+	ctx.receiveSettleAliceToBob()
+	ctx.receiveCommitSigAliceToBob(0)
+
+	alice.link.Stop()
+
+	select {
+	case msg := <-alice.msgs:
+		t.Fatalf("did not expect message %T", msg)
+	default:
+	}
+}
+
 // TestChannelLinkRevocationWindowRegular asserts that htlcs paying to a regular
 // invoice are settled even if the revocation window gets exhausted.
 func TestChannelLinkRevocationWindowRegular(t *testing.T) {
@@ -6248,3 +6440,258 @@ func assertFailureCode(t *testing.T, err error, code lnwire.FailCode) {
 			code, rtErr.WireMessage().Code())
 	}
 }
+
+// upgradeFeatures returns a feature vector that signals support for channel
+// upgrades if upgradable is true.
+func upgradeFeatures(upgradable bool) *lnwire.FeatureVector {
+	raw := lnwire.NewRawFeatureVector()
+	if upgradable {
+		raw.Set(lnwire.ChannelUpgradeOptional)
+	}
+
+	return lnwire.NewFeatureVector(raw, lnwire.Features)
+}
+
+// newUpgradeTestContext creates a started single link test harness, of which
+// both peers support channel upgrades if upgradable is true.
+func newUpgradeTestContext(t *testing.T, upgradable bool) (*linkTestContext,
+	func()) {
+
+	const chanAmt = btcutil.SatoshiPerBitcoin * 5
+	aliceLink, bobChannel, _, start, cleanUp, _, err :=
+		newSingleLinkTestHarness(chanAmt, 0)
+	if err != nil {
+		t.Fatalf("unable to create link: %v", err)
+	}
+
+	alicePeer := aliceLink.(*channelLink).cfg.Peer.(*mockPeer)
+	alicePeer.localFeatures = upgradeFeatures(upgradable)
+	alicePeer.remoteFeatures = upgradeFeatures(upgradable)
+
+	if err := start(); err != nil {
+		cleanUp()
+		t.Fatalf("unable to start test harness: %v", err)
+	}
+
+	ctx := &linkTestContext{
+		t:          t,
+		aliceLink:  aliceLink,
+		aliceMsgs:  alicePeer.sentMsgs,
+		bobChannel: bobChannel,
+	}
+
+	return ctx, func() {
+		aliceLink.Stop()
+		cleanUp()
+	}
+}
+
+// receiveUpgradeAlice waits for Alice to send a ChannelUpgrade, and asserts
+// that it's for the given channel type.
+func (l *linkTestContext) receiveUpgradeAlice(
+	chanType channeldb.ChannelType) *lnwire.ChannelUpgrade {
+
+	l.t.Helper()
+
+	var msg lnwire.Message
+	select {
+	case msg = <-l.aliceMsgs:
+	case <-time.After(15 * time.Second):
+		l.t.Fatalf("did not receive message")
+	}
+
+	upgrade, ok := msg.(*lnwire.ChannelUpgrade)
+	if !ok {
+		l.t.Fatalf("expected ChannelUpgrade, got %T", msg)
+	}
+
+	features := commitmentFeatures(chanType)
+	if !reflect.DeepEqual(upgrade.CommitmentFeatures, features) {
+		l.t.Fatalf("expected upgrade to %v", chanType)
+	}
+
+	return upgrade
+}
+
+// exchangeUpgradedCommitments runs the commitment dance that exchanges the
+// first commitments of the upgraded channel type, and asserts that the
+// upgrade is complete.
+func (l *linkTestContext) exchangeUpgradedCommitments(
+	chanType channeldb.ChannelType) {
+
+	l.t.Helper()
+
+	l.receiveCommitSigAliceToBob(0)
+	l.sendRevAndAckBobToAlice()
+	l.sendCommitSigBobToAlice(0)
+	l.receiveRevAndAckAliceToBob()
+
+	channel := l.aliceLink.(*channelLink).channel
+	if channel.State().ChanType != chanType {
+		l.t.Fatalf("expected channel type %v, got %v", chanType,
+			channel.State().ChanType)
+	}
+	if channel.UpgradePending() || l.bobChannel.UpgradePending() {
+		l.t.Fatalf("expected upgrade to be complete")
+	}
+}
+
+// startUpgrade makes Alice propose an upgrade to the given channel type, and
+// returns a channel that receives the result.
+func startUpgrade(link ChannelLink,
+	chanType channeldb.ChannelType) <-chan error {
+
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- link.UpgradeChannel(chanType)
+	}()
+
+	return errChan
+}
+
+// waitUpgradeResult waits for the result of an upgrade proposed by Alice.
+func waitUpgradeResult(t *testing.T, errChan <-chan error) error {
+	t.Helper()
+
+	select {
+	case err := <-errChan:
+		return err
+	case <-time.After(15 * time.Second):
+		t.Fatalf("upgrade didn't complete")
+		return nil
+	}
+}
+
+// TestChannelLinkUpgradeAccepted tests that a channel upgrade proposed by the
+// link is applied once the remote peer accepts it, after which the first
+// commitments of the new channel type are exchanged.
+func TestChannelLinkUpgradeAccepted(t *testing.T) {
+	t.Parallel()
+
+	ctx, cleanUp := newUpgradeTestContext(t, true)
+	defer cleanUp()
+
+	anchorType := ctx.bobChannel.State().ChanType |
+		channeldb.AnchorOutputsBit
+
+	errChan := startUpgrade(ctx.aliceLink, anchorType)
+	upgrade := ctx.receiveUpgradeAlice(anchorType)
+
+	// While the upgrade is being negotiated, another upgrade can't be
+	// proposed.
+	err := ctx.aliceLink.UpgradeChannel(anchorType)
+	if err != ErrUpgradeInProgress {
+		t.Fatalf("expected ErrUpgradeInProgress, got %v", err)
+	}
+
+	// Bob accepts the upgrade by applying it and echoing the proposal.
+	if err := ctx.bobChannel.UpgradeChanType(anchorType); err != nil {
+		t.Fatalf("unable to upgrade bob's channel: %v", err)
+	}
+	ctx.aliceLink.HandleChannelUpdate(upgrade)
+
+	if err := waitUpgradeResult(t, errChan); err != nil {
+		t.Fatalf("unable to upgrade channel: %v", err)
+	}
+
+	ctx.exchangeUpgradedCommitments(anchorType)
+	ctx.assertNoMsgFromAlice(time.Second)
+}
+
+// TestChannelLinkUpgradeRejected tests that a channel upgrade proposed by the
+// link is abandoned without failing the link if the remote peer rejects it.
+func TestChannelLinkUpgradeRejected(t *testing.T) {
+	t.Parallel()
+
+	ctx, cleanUp := newUpgradeTestContext(t, true)
+	defer cleanUp()
+
+	chanType := ctx.bobChannel.State().ChanType
+	anchorType := chanType | channeldb.AnchorOutputsBit
+
+	errChan := startUpgrade(ctx.aliceLink, anchorType)
+	ctx.receiveUpgradeAlice(anchorType)
+
+	// Bob rejects the upgrade by replying with the features of the
+	// current channel type.
+	ctx.aliceLink.HandleChannelUpdate(lnwire.NewChannelUpgrade(
+		ctx.aliceLink.ChanID(), commitmentFeatures(chanType),
+	))
+
+	err := waitUpgradeResult(t, errChan)
+	if err != ErrUpgradeRejected {
+		t.Fatalf("expected ErrUpgradeRejected, got %v", err)
+	}
+
+	channel := ctx.aliceLink.(*channelLink).channel
+	if channel.State().ChanType != chanType {
+		t.Fatalf("expected channel type %v, got %v", chanType,
+			channel.State().ChanType)
+	}
+
+	// The link is still active, so the upgrade can be proposed again.
+	startUpgrade(ctx.aliceLink, anchorType)
+	ctx.receiveUpgradeAlice(anchorType)
+}
+
+// TestChannelLinkUpgradeRemoteProposal tests that the link accepts a channel
+// upgrade proposed by the remote peer only if it supports channel upgrades.
+func TestChannelLinkUpgradeRemoteProposal(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		upgradable bool
+	}{
+		{
+			name:       "accept",
+			upgradable: true,
+		},
+		{
+			name:       "reject",
+			upgradable: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			ctx, cleanUp := newUpgradeTestContext(
+				t, testCase.upgradable,
+			)
+			defer cleanUp()
+
+			chanType := ctx.bobChannel.State().ChanType
+			anchorType := chanType | channeldb.AnchorOutputsBit
+
+			ctx.aliceLink.HandleChannelUpdate(
+				lnwire.NewChannelUpgrade(
+					ctx.aliceLink.ChanID(),
+					commitmentFeatures(anchorType),
+				),
+			)
+
+			// If Alice doesn't support upgrades, she rejects the
+			// upgrade by replying with the current channel type.
+			if !testCase.upgradable {
+				ctx.receiveUpgradeAlice(chanType)
+				ctx.assertNoMsgFromAlice(time.Second)
+				return
+			}
+
+			// Otherwise, she echoes the proposal and signs the
+			// first commitment of the new type.
+			ctx.receiveUpgradeAlice(anchorType)
+
+			err := ctx.bobChannel.UpgradeChanType(anchorType)
+			if err != nil {
+				t.Fatalf("unable to upgrade bob's channel: %v",
+					err)
+			}
+
+			ctx.exchangeUpgradedCommitments(anchorType)
+			ctx.assertNoMsgFromAlice(time.Second)
+		})
+	}
+}
@@ -5,6 +5,20 @@ import "github.com/go-errors/errors"
 var (
 	// ErrLinkShuttingDown signals that the link is shutting down.
 	ErrLinkShuttingDown = errors.New("link shutting down")
+
+	// ErrUpgradeInProgress signals that the channel type is already being
+	// upgraded.
+	ErrUpgradeInProgress = errors.New("channel upgrade in progress")
+
+	// ErrUpgradeNotSupported signals that the remote peer doesn't support
+	// upgrading the channel type.
+	ErrUpgradeNotSupported = errors.New("channel upgrade not supported " +
+		"by remote peer")
+
+	// ErrUpgradeRejected signals that the remote peer rejected our
+	// proposal to upgrade the channel type.
+	ErrUpgradeRejected = errors.New("channel upgrade rejected by " +
+		"remote peer")
 )
 
 // errorCode encodes the possible types of errors that will make us fail the
@@ -60,6 +60,13 @@ type MailBox interface {
 	// delivery will be sent on.
 	PacketOutBox() chan *htlcPacket
 
+	// DustPackets returns the total amount of the Add packets in the
+	// mailbox for which the passed isDust function returns true.
+	DustPackets(isDust func(lnwire.MilliSatoshi) bool) lnwire.MilliSatoshi
+
+	// NumAddPackets returns the number of Add packets in the mailbox.
+	NumAddPackets() int
+
 	// Clears any pending wire messages from the inbox.
 	ResetMessages() error
 
@@ -610,6 +617,41 @@ func (m *memoryMailBox) AddPacket(pkt *htlcPacket) error {
 	return nil
 }
 
+// DustPackets returns the total amount of the Add packets in the mailbox for
+// which the passed isDust function returns true. The Adds remain in the
+// mailbox until they are acked, so this may include htlcs that were already
+// added to the channel.
+//
+// NOTE: This method is part of the MailBox interface.
+func (m *memoryMailBox) DustPackets(
+	isDust func(lnwire.MilliSatoshi) bool) lnwire.MilliSatoshi {
+
+	m.pktCond.L.Lock()
+	defer m.pktCond.L.Unlock()
+
+	var dustSum lnwire.MilliSatoshi
+	for e := m.addPkts.Front(); e != nil; e = e.Next() {
+		pkt := e.Value.(*pktWithExpiry).pkt
+		htlc, ok := pkt.htlc.(*lnwire.UpdateAddHTLC)
+		if ok && isDust(htlc.Amount) {
+			dustSum += htlc.Amount
+		}
+	}
+
+	return dustSum
+}
+
+// NumAddPackets returns the number of Add packets in the mailbox. Like with
+// DustPackets, this may include htlcs that were already added to the channel.
+//
+// NOTE: This method is part of the MailBox interface.
+func (m *memoryMailBox) NumAddPackets() int {
+	m.pktCond.L.Lock()
+	defer m.pktCond.L.Unlock()
+
+	return m.addPkts.Len()
+}
+
 // FailAdd fails an UpdateAddHTLC that exists within the mailbox, removing it
 // from the in-memory replay buffer. This will prevent the packet from being
 // delivered after the link restarts if the switch has remained online. The
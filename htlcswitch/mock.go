@@ -651,6 +651,20 @@ type mockChannelLink struct {
 	checkHtlcTransitResult *LinkError
 
 	checkHtlcForwardResult *LinkError
+
+	// dustLimit is the amount below which htlcs are considered dust on
+	// both commitments.
+	dustLimit lnwire.MilliSatoshi
+
+	// dustSum is the dust sum that is reported for both commitments.
+	dustSum lnwire.MilliSatoshi
+
+	// outgoingHtlcs is the number of htlcs that are reported to be
+	// offered by us.
+	outgoingHtlcs int
+
+	// maxHtlcs is the reported maximum number of htlcs we may offer.
+	maxHtlcs int
 }
 
 // completeCircuit is a helper method for adding the finalized payment circuit
@@ -714,6 +728,15 @@ func (f *mockChannelLink) HandleChannelUpdate(lnwire.Message) {
 
 func (f *mockChannelLink) UpdateForwardingPolicy(_ ForwardingPolicy) {
 }
+
+func (f *mockChannelLink) UpgradeChannel(channeldb.ChannelType) error {
+	return nil
+}
+
+func (f *mockChannelLink) PinCommitFeeRate(_, _ chainfee.SatPerKWeight) error {
+	return nil
+}
+
 func (f *mockChannelLink) CheckHtlcForward([32]byte, lnwire.MilliSatoshi,
 	lnwire.MilliSatoshi, uint32, uint32, uint32) *LinkError {
 
@@ -731,6 +754,24 @@ func (f *mockChannelLink) Stats() (uint64, lnwire.MilliSatoshi, lnwire.MilliSato
 	return 0, 0, 0
 }
 
+func (f *mockChannelLink) getDustSum(remote bool) lnwire.MilliSatoshi {
+	return f.dustSum
+}
+
+func (f *mockChannelLink) isDust(amt lnwire.MilliSatoshi, incoming,
+	remote bool) bool {
+
+	return amt < f.dustLimit
+}
+
+func (f *mockChannelLink) numOutgoingHtlcs() int {
+	return f.outgoingHtlcs
+}
+
+func (f *mockChannelLink) maxOutgoingHtlcs() int {
+	return f.maxHtlcs
+}
+
 func (f *mockChannelLink) AttachMailBox(mailBox MailBox) {
 	f.mailBox = mailBox
 	f.packets = mailBox.PacketOutBox()
@@ -912,6 +953,10 @@ func (m *mockCircuitMap) NumOpen() int {
 	return 0
 }
 
+func (m *mockCircuitMap) Circuits() []*PaymentCircuit {
+	return nil
+}
+
 type mockOnionErrorDecryptor struct {
 	sourceIdx int
 	message   []byte
@@ -945,3 +990,7 @@ func (h *mockHTLCNotifier) NotifyForwardingFailEvent(key HtlcKey,
 
 func (h *mockHTLCNotifier) NotifySettleEvent(key HtlcKey, eventType HtlcEventType) {
 }
+
+func (h *mockHTLCNotifier) NotifyHoldAlarmEvent(key HtlcKey, info HtlcInfo,
+	eventType HtlcEventType, holdTime time.Duration, canceled bool) {
+}
@@ -0,0 +1,78 @@
+package htlcswitch
+
+import (
+	"fmt"
+
+	"github.com/cryptomeow/lnd/lnwallet/chainfee"
+)
+
+// RemoteFeePolicy bounds the commitment fee rates the remote party may set
+// with update_fee on the channels it initiated. The bounds are relative to our
+// own fee estimate.
+type RemoteFeePolicy struct {
+	// MinFeeRatio is the lowest accepted fee rate as a ratio of our fee
+	// estimate. Zero disables the lower bound.
+	MinFeeRatio float64
+
+	// MaxFeeRatio is the highest accepted fee rate as a multiple of our
+	// fee estimate. Zero disables the upper bound.
+	MaxFeeRatio float64
+
+	// MaxViolations is the number of consecutive fee updates outside of
+	// the bounds after which the channel is force closed. Zero disables
+	// closing the channel, so violations are only logged.
+	MaxViolations uint32
+}
+
+// enabled returns true if any of the bounds is set.
+func (p *RemoteFeePolicy) enabled() bool {
+	return p.MinFeeRatio != 0 || p.MaxFeeRatio != 0
+}
+
+// checkFeeRate returns an error if the fee rate is outside of the bounds
+// derived from the given fee estimate.
+func (p *RemoteFeePolicy) checkFeeRate(feeRate,
+	estimate chainfee.SatPerKWeight) error {
+
+	minFeeRate := chainfee.SatPerKWeight(float64(estimate) * p.MinFeeRatio)
+	if p.MinFeeRatio != 0 && feeRate < minFeeRate {
+		return fmt.Errorf("fee rate %v is below the minimum of %v "+
+			"(estimate %v)", feeRate, minFeeRate, estimate)
+	}
+
+	maxFeeRate := chainfee.SatPerKWeight(float64(estimate) * p.MaxFeeRatio)
+	if p.MaxFeeRatio != 0 && feeRate > maxFeeRate {
+		return fmt.Errorf("fee rate %v is above the maximum of %v "+
+			"(estimate %v)", feeRate, maxFeeRate, estimate)
+	}
+
+	return nil
+}
+
+// remoteFeeMonitor counts the consecutive fee updates of the remote party that
+// are outside of the bounds of a RemoteFeePolicy.
+type remoteFeeMonitor struct {
+	policy RemoteFeePolicy
+
+	// violations is the number of consecutive fee updates outside of the
+	// bounds.
+	violations uint32
+}
+
+// observe records a fee update of the remote party. It returns true if the
+// maximum number of consecutive violations has been reached, along with the
+// reason the fee rate is outside of the bounds, or nil if it isn't.
+func (m *remoteFeeMonitor) observe(feeRate,
+	estimate chainfee.SatPerKWeight) (bool, error) {
+
+	err := m.policy.checkFeeRate(feeRate, estimate)
+	if err == nil {
+		m.violations = 0
+		return false, nil
+	}
+
+	m.violations++
+
+	return m.policy.MaxViolations != 0 &&
+		m.violations >= m.policy.MaxViolations, err
+}
@@ -0,0 +1,78 @@
+package htlcswitch
+
+import (
+	"testing"
+
+	"github.com/cryptomeow/lnd/lnwallet/chainfee"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRemoteFeeMonitor asserts that fee updates outside of the bounds of the
+// remote fee policy are detected, and that the channel is only failed once
+// the maximum number of consecutive violations is reached.
+func TestRemoteFeeMonitor(t *testing.T) {
+	t.Parallel()
+
+	const estimate = chainfee.SatPerKWeight(10000)
+
+	monitor := &remoteFeeMonitor{
+		policy: RemoteFeePolicy{
+			MinFeeRatio:   0.5,
+			MaxFeeRatio:   2,
+			MaxViolations: 3,
+		},
+	}
+	require.True(t, monitor.policy.enabled())
+
+	// Fee rates within the bounds, including the bounds themselves, are
+	// accepted.
+	for _, feeRate := range []chainfee.SatPerKWeight{5000, 10000, 20000} {
+		exceeded, err := monitor.observe(feeRate, estimate)
+		require.NoError(t, err)
+		require.False(t, exceeded)
+	}
+
+	// The first two violations are only reported.
+	exceeded, err := monitor.observe(4999, estimate)
+	require.Error(t, err)
+	require.False(t, exceeded)
+
+	exceeded, err = monitor.observe(20001, estimate)
+	require.Error(t, err)
+	require.False(t, exceeded)
+
+	// A fee rate within the bounds resets the count.
+	exceeded, err = monitor.observe(10000, estimate)
+	require.NoError(t, err)
+	require.False(t, exceeded)
+	require.Zero(t, monitor.violations)
+
+	// Three consecutive violations exceed the limit.
+	for i := 0; i < 2; i++ {
+		exceeded, err = monitor.observe(50000, estimate)
+		require.Error(t, err)
+		require.False(t, exceeded)
+	}
+	exceeded, err = monitor.observe(50000, estimate)
+	require.Error(t, err)
+	require.True(t, exceeded)
+
+	// Without a violation limit, the fee updates are only reported.
+	monitor = &remoteFeeMonitor{
+		policy: RemoteFeePolicy{
+			MaxFeeRatio: 2,
+		},
+	}
+	for i := 0; i < 10; i++ {
+		exceeded, err = monitor.observe(50000, estimate)
+		require.Error(t, err)
+		require.False(t, exceeded)
+	}
+
+	// Only the upper bound is set, so low fee rates are accepted.
+	exceeded, err = monitor.observe(1, estimate)
+	require.NoError(t, err)
+	require.False(t, exceeded)
+
+	require.False(t, (&RemoteFeePolicy{}).enabled())
+}
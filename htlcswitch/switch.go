@@ -18,6 +18,7 @@ import (
 	"github.com/cryptomeow/lnd/clock"
 	"github.com/cryptomeow/lnd/contractcourt"
 	"github.com/cryptomeow/lnd/htlcswitch/hop"
+	"github.com/cryptomeow/lnd/invoices"
 	"github.com/cryptomeow/lnd/lntypes"
 	"github.com/cryptomeow/lnd/lnwallet"
 	"github.com/cryptomeow/lnd/lnwallet/chainfee"
@@ -41,6 +42,10 @@ const (
 	// DefaultHTLCExpiry is the duration after which Adds will be cancelled
 	// if they could not get added to an outgoing commitment.
 	DefaultHTLCExpiry = time.Minute
+
+	// DefaultMaxDustExposure is the default maximum total amount of dust
+	// htlcs on either commitment transaction of a channel.
+	DefaultMaxDustExposure = btcutil.Amount(500000)
 )
 
 var (
@@ -71,6 +76,10 @@ var (
 	// ErrLocalAddFailed signals that the ADD htlc for a local payment
 	// failed to be processed.
 	ErrLocalAddFailed = errors.New("local add HTLC failed")
+
+	// ErrSwitchDraining signals that the switch doesn't accept any new
+	// HTLCs, because the node is draining before shutting down.
+	ErrSwitchDraining = errors.New("htlcswitch draining")
 )
 
 // plexPacket encapsulates switch packet and adds error channel to receive
@@ -110,6 +119,11 @@ type ChanClose struct {
 	// process for the cooperative closure transaction kicks off.
 	TargetFeePerKw chainfee.SatPerKWeight
 
+	// MaxFee is the highest fee rate the caller is willing to accept for
+	// the cooperative closure transaction. If it is zero, a default
+	// maximum is derived from the target fee rate.
+	MaxFee chainfee.SatPerKWeight
+
 	// DeliveryScript is an optional delivery script to pay funds out to.
 	DeliveryScript lnwire.DeliveryAddress
 
@@ -136,7 +150,8 @@ type Config struct {
 	LocalChannelClose func(pubKey []byte, request *ChanClose)
 
 	// DB is the channeldb instance that will be used to back the switch's
-	// persistent circuit map.
+	// persistent circuit map, and to resolve the alias short channel IDs
+	// of private channels.
 	DB *channeldb.DB
 
 	// SwitchPackager provides access to the forwarding packages of all
@@ -184,6 +199,19 @@ type Config struct {
 	// HTLCs that are not from the source hop.
 	RejectHTLC bool
 
+	// MaxDustExposure is the maximum total amount of dust htlcs on either
+	// commitment transaction of a channel. Dust htlcs aren't materialized
+	// as outputs, so their value goes to miners if the channel is force
+	// closed. New dust htlcs that would exceed this amount are failed. A
+	// value of zero disables the limit.
+	MaxDustExposure lnwire.MilliSatoshi
+
+	// ReservedOutgoingSlots is the number of commitment slots of each
+	// channel that forwards may not occupy, so that they remain available
+	// for our own payments. The slots are counted against the maximum
+	// number of htlcs we may offer on the channel.
+	ReservedOutgoingSlots uint16
+
 	// Clock is a time source for the switch.
 	Clock clock.Clock
 
@@ -192,6 +220,28 @@ type Config struct {
 	// will expiry this long after the Adds are added to a mailbox via
 	// AddPacket.
 	HTLCExpiry time.Duration
+
+	// PreimageCache is a global witness beacon that houses any new
+	// preimages discovered by other subsystems. It is used to settle
+	// orphaned circuits whose outgoing htlc was claimed on-chain.
+	PreimageCache contractcourt.WitnessBeacon
+
+	// ResolveOrphanedCircuits is true if the orphaned circuits found on
+	// startup should be resolved instead of only being reported.
+	ResolveOrphanedCircuits bool
+
+	// HtlcHoldAlarm is the time an incoming htlc may be held on one of our
+	// channels before an alarm is raised for it. A value of zero disables
+	// the alarm.
+	HtlcHoldAlarm time.Duration
+
+	// AutoFailHeldHtlcs indicates whether htlcs paying to our own invoices
+	// are canceled back once the hold alarm is raised for them.
+	AutoFailHeldHtlcs bool
+
+	// CancelHeldHtlc cancels a htlc that is held by the invoice registry.
+	CancelHeldHtlc func(channeldb.CircuitKey,
+		invoices.FailResolutionResult) error
 }
 
 // Switch is the central messaging bus for all incoming/outgoing HTLCs.
@@ -205,6 +255,7 @@ type Config struct {
 type Switch struct {
 	started  int32 // To be used atomically.
 	shutdown int32 // To be used atomically.
+	draining int32 // To be used atomically.
 
 	// bestHeight is the best known height of the main chain. The links will
 	// be used this information to govern decisions based on HTLC timeouts.
@@ -229,6 +280,14 @@ type Switch struct {
 	// forward the settle/fail htlc updates back to the add htlc initiator.
 	circuits CircuitMap
 
+	// holdWatchdog raises an alarm for incoming htlcs that are held too
+	// long. It is nil if the hold alarm is disabled.
+	holdWatchdog *holdWatchdog
+
+	// endorsements tracks the outcomes of the forwarded htlcs that carry
+	// an experimental endorsement signal.
+	endorsements *endorsementTracker
+
 	// mailOrchestrator manages the lifecycle of mailboxes used throughout
 	// the switch, and facilitates delayed delivery of packets to links that
 	// later come online.
@@ -255,6 +314,12 @@ type Switch struct {
 	// ChannelLink
 	forwardingIndex map[lnwire.ShortChannelID]ChannelLink
 
+	// suspendedLinks holds the channel IDs of links that may not be used to
+	// forward HTLCs, because the funding transaction of their channel was
+	// reorged out of the chain. Settles and fails are still delivered to
+	// these links, so HTLCs already in flight can be resolved.
+	suspendedLinks map[lnwire.ChannelID]struct{}
+
 	// interfaceIndex maps the compressed public key of a peer to all the
 	// channels that the switch maintains with that peer.
 	interfaceIndex map[[33]byte]map[lnwire.ChannelID]ChannelLink
@@ -309,10 +374,12 @@ func New(cfg Config, currentHeight uint32) (*Switch, error) {
 		forwardingIndex:   make(map[lnwire.ShortChannelID]ChannelLink),
 		interfaceIndex:    make(map[[33]byte]map[lnwire.ChannelID]ChannelLink),
 		pendingLinkIndex:  make(map[lnwire.ChannelID]ChannelLink),
+		suspendedLinks:    make(map[lnwire.ChannelID]struct{}),
 		networkResults:    newNetworkResultStore(cfg.DB),
 		htlcPlex:          make(chan *plexPacket),
 		chanCloseRequests: make(chan *ChanClose),
 		resolutionMsgs:    make(chan *resolutionMsg),
+		endorsements:      newEndorsementTracker(),
 		quit:              make(chan struct{}),
 	}
 
@@ -323,9 +390,57 @@ func New(cfg Config, currentHeight uint32) (*Switch, error) {
 		expiry:         s.cfg.HTLCExpiry,
 	})
 
+	if cfg.HtlcHoldAlarm != 0 {
+		s.holdWatchdog = newHoldWatchdog(&holdWatchdogConfig{
+			AlarmDuration:      cfg.HtlcHoldAlarm,
+			AutoFail:           cfg.AutoFailHeldHtlcs,
+			FetchIncomingHtlcs: s.fetchIncomingHtlcs,
+			LookupCircuit:      s.circuits.LookupCircuit,
+			CancelHeldHtlc:     cfg.CancelHeldHtlc,
+			Notifier:           cfg.HtlcNotifier,
+			Clock:              cfg.Clock,
+			Ticker: ticker.New(
+				cfg.HtlcHoldAlarm / holdScansPerAlarm,
+			),
+		})
+	}
+
 	return s, nil
 }
 
+// fetchIncomingHtlcs returns all incoming htlcs that are locked in on our
+// channels. The htlcs of channels that were force closed are skipped, as they
+// are resolved on-chain.
+func (s *Switch) fetchIncomingHtlcs() (map[CircuitKey]channeldb.HTLC, error) {
+	openChannels, err := s.cfg.DB.FetchAllOpenChannels()
+	if err != nil {
+		return nil, err
+	}
+
+	htlcs := make(map[CircuitKey]channeldb.HTLC)
+	for _, channel := range openChannels {
+		if channel.IsPending ||
+			channel.HasChanStatus(channeldb.ChanStatusCommitBroadcasted) {
+
+			continue
+		}
+
+		for _, htlc := range channel.LocalCommitment.Htlcs {
+			if !htlc.Incoming {
+				continue
+			}
+
+			key := CircuitKey{
+				ChanID: channel.ShortChanID(),
+				HtlcID: htlc.HtlcIndex,
+			}
+			htlcs[key] = htlc
+		}
+	}
+
+	return htlcs, nil
+}
+
 // resolutionMsg is a struct that wraps an existing ResolutionMsg with a done
 // channel. We'll use this channel to synchronize delivery of the message with
 // the caller.
@@ -453,6 +568,11 @@ func (s *Switch) CleanStore(keepPids map[uint64]struct{}) error {
 func (s *Switch) SendHTLC(firstHop lnwire.ShortChannelID, paymentID uint64,
 	htlc *lnwire.UpdateAddHTLC) error {
 
+	// We don't send any new payments while draining.
+	if s.IsDraining() {
+		return ErrSwitchDraining
+	}
+
 	// Generate and send new update packet, if error will be received on
 	// this stage it means that packet haven't left boundaries of our
 	// system and something wrong happened.
@@ -745,13 +865,15 @@ func (s *Switch) getLocalLink(pkt *htlcPacket, htlc *lnwire.UpdateAddHTLC) (
 	// Try to find links by node destination.
 	s.indexMtx.RLock()
 	link, err := s.getLinkByShortID(pkt.outgoingChanID)
-	s.indexMtx.RUnlock()
 	if err != nil {
+		s.indexMtx.RUnlock()
 		log.Errorf("Link %v not found", pkt.outgoingChanID)
 		return nil, NewLinkError(&lnwire.FailUnknownNextPeer{})
 	}
+	_, suspended := s.suspendedLinks[link.ChanID()]
+	s.indexMtx.RUnlock()
 
-	if !link.EligibleToForward() {
+	if !link.EligibleToForward() || suspended {
 		log.Errorf("Link %v is not available to forward",
 			pkt.outgoingChanID)
 
@@ -773,9 +895,81 @@ func (s *Switch) getLocalLink(pkt *htlcPacket, htlc *lnwire.UpdateAddHTLC) (
 			"satisfied", pkt.outgoingChanID)
 		return nil, htlcErr
 	}
+
+	if s.dustExceedsThreshold(link, htlc.Amount, false) {
+		log.Errorf("Link %v dust exposure exceeded for local "+
+			"forward", pkt.outgoingChanID)
+
+		return nil, NewDetailedLinkError(
+			lnwire.NewTemporaryChannelFailure(nil),
+			OutgoingFailureDustExposure,
+		)
+	}
+
 	return link, nil
 }
 
+// dustExceedsThreshold returns whether an incoming or outgoing htlc of the
+// given amount would push the dust exposure of either commitment transaction
+// of the link beyond the configured maximum. Incoming htlcs are already part
+// of the link's update logs, so their amount is only added for outgoing htlcs.
+// The Adds that are still queued in the link's mailbox are counted as well, as
+// they are going to be offered by us.
+func (s *Switch) dustExceedsThreshold(link ChannelLink,
+	amt lnwire.MilliSatoshi, incoming bool) bool {
+
+	if s.cfg.MaxDustExposure == 0 {
+		return false
+	}
+
+	mailbox := s.mailOrchestrator.GetOrCreateMailBox(
+		link.ChanID(), link.ShortChanID(),
+	)
+
+	for _, remote := range []bool{false, true} {
+		// If the htlc isn't dust on this commitment, it doesn't add to
+		// its dust exposure.
+		if !link.isDust(amt, incoming, remote) {
+			continue
+		}
+
+		dustSum := link.getDustSum(remote)
+		dustSum += mailbox.DustPackets(
+			func(pktAmt lnwire.MilliSatoshi) bool {
+				return link.isDust(pktAmt, false, remote)
+			},
+		)
+		if !incoming {
+			dustSum += amt
+		}
+
+		if dustSum > s.cfg.MaxDustExposure {
+			return true
+		}
+	}
+
+	return false
+}
+
+// slotsReserved returns whether offering another htlc on the link would leave
+// fewer commitment slots than are reserved for our own payments. The Adds that
+// are still queued in the link's mailbox are counted as well, as they are going
+// to be offered by us.
+func (s *Switch) slotsReserved(link ChannelLink) bool {
+	if s.cfg.ReservedOutgoingSlots == 0 {
+		return false
+	}
+
+	mailbox := s.mailOrchestrator.GetOrCreateMailBox(
+		link.ChanID(), link.ShortChanID(),
+	)
+
+	numHtlcs := link.numOutgoingHtlcs() + mailbox.NumAddPackets()
+	freeSlots := link.maxOutgoingHtlcs() - numHtlcs
+
+	return freeSlots <= int(s.cfg.ReservedOutgoingSlots)
+}
+
 // handleLocalResponse processes a Settle or Fail responding to a
 // locally-initiated payment. This is handled asynchronously to avoid blocking
 // the main event loop within the switch, as these operations can require
@@ -978,6 +1172,38 @@ func (s *Switch) handlePacketForward(packet *htlcPacket) error {
 			return s.failAddPacket(packet, failure)
 		}
 
+		// While the node is draining before shutdown, we don't accept
+		// any new HTLCs, but still forward the resolution of the HTLCs
+		// that are already in flight.
+		if s.IsDraining() {
+			failure := NewDetailedLinkError(
+				lnwire.NewTemporaryChannelFailure(nil),
+				OutgoingFailureNodeDraining,
+			)
+
+			return s.failAddPacket(packet, failure)
+		}
+
+		// Htlcs that are addressed to the alias of one of our private
+		// channels are forwarded over the channel the alias belongs
+		// to. The aliases are cached in memory by the database, so the
+		// lookup doesn't hit the disk.
+		if packet.outgoingChanID.IsAlias() {
+			baseScid, err := s.cfg.DB.LookupScidAlias(
+				packet.outgoingChanID,
+			)
+			if err != nil && err != channeldb.ErrScidAliasNotFound {
+				log.Errorf("Unable to look up scid alias %v: %v",
+					packet.outgoingChanID, err)
+			}
+			if err == nil {
+				log.Debugf("Resolved scid alias %v to channel %v",
+					packet.outgoingChanID, baseScid)
+
+				packet.outgoingChanID = baseScid
+			}
+		}
+
 		// Before we attempt to find a non-strict forwarding path for
 		// this htlc, check whether the htlc is being routed over the
 		// same incoming and outgoing channel. If our node does not
@@ -993,6 +1219,27 @@ func (s *Switch) handlePacketForward(packet *htlcPacket) error {
 		}
 
 		s.indexMtx.RLock()
+
+		// HTLCs offered to us over a suspended link can't be forwarded
+		// until the channel's funding transaction is confirmed again.
+		if s.isSuspended(packet.incomingChanID) {
+			s.indexMtx.RUnlock()
+
+			log.Debugf("Rejecting htlc from suspended link %v",
+				packet.incomingChanID)
+
+			linkError := NewDetailedLinkError(
+				lnwire.NewTemporaryChannelFailure(nil),
+				OutgoingFailureLinkNotEligible,
+			)
+
+			return s.failAddPacket(packet, linkError)
+		}
+
+		// The incoming link is looked up to check its dust exposure
+		// once the index lock is released.
+		incomingLink, _ := s.getLinkByShortID(packet.incomingChanID)
+
 		targetLink, err := s.getLinkByShortID(packet.outgoingChanID)
 		if err != nil {
 			s.indexMtx.RUnlock()
@@ -1011,8 +1258,34 @@ func (s *Switch) handlePacketForward(packet *htlcPacket) error {
 		}
 		targetPeerKey := targetLink.Peer().PubKey()
 		interfaceLinks, _ := s.getLinks(targetPeerKey)
+
+		// We'll also note which of these links are suspended, as they
+		// aren't eligible to forward the HTLC.
+		suspendedLinks := make(map[lnwire.ChannelID]struct{})
+		for _, link := range interfaceLinks {
+			if _, ok := s.suspendedLinks[link.ChanID()]; ok {
+				suspendedLinks[link.ChanID()] = struct{}{}
+			}
+		}
 		s.indexMtx.RUnlock()
 
+		// If the htlc is dust and the dust exposure of the incoming
+		// link is exceeded, we fail it back instead of forwarding it.
+		if incomingLink != nil && s.dustExceedsThreshold(
+			incomingLink, packet.incomingAmount, true,
+		) {
+
+			log.Debugf("Rejecting htlc from link %v: dust "+
+				"exposure exceeded", packet.incomingChanID)
+
+			linkError := NewDetailedLinkError(
+				lnwire.NewTemporaryChannelFailure(nil),
+				OutgoingFailureDustExposure,
+			)
+
+			return s.failAddPacket(packet, linkError)
+		}
+
 		// We'll keep track of any HTLC failures during the link
 		// selection process. This way we can return the error for
 		// precise link that the sender selected, while optimistically
@@ -1026,8 +1299,9 @@ func (s *Switch) handlePacketForward(packet *htlcPacket) error {
 			var failure *LinkError
 
 			// We'll skip any links that aren't yet eligible for
-			// forwarding.
-			if !link.EligibleToForward() {
+			// forwarding, or have been suspended.
+			_, suspended := suspendedLinks[link.ChanID()]
+			if !link.EligibleToForward() || suspended {
 				failure = NewDetailedLinkError(
 					&lnwire.FailUnknownNextPeer{},
 					OutgoingFailureLinkNotEligible,
@@ -1044,6 +1318,27 @@ func (s *Switch) handlePacketForward(packet *htlcPacket) error {
 				)
 			}
 
+			// A dust htlc can't be forwarded over a link that
+			// already has the maximum dust exposure.
+			if failure == nil && s.dustExceedsThreshold(
+				link, packet.amount, false,
+			) {
+
+				failure = NewDetailedLinkError(
+					lnwire.NewTemporaryChannelFailure(nil),
+					OutgoingFailureDustExposure,
+				)
+			}
+
+			// Forwards may not occupy the slots that are reserved
+			// for our own payments.
+			if failure == nil && s.slotsReserved(link) {
+				failure = NewDetailedLinkError(
+					lnwire.NewTemporaryChannelFailure(nil),
+					OutgoingFailureReservedSlots,
+				)
+			}
+
 			// If this link can forward the htlc, add it to the set
 			// of destinations.
 			if failure == nil {
@@ -1091,6 +1386,15 @@ func (s *Switch) handlePacketForward(packet *htlcPacket) error {
 		// what the best channel is.
 		destination := destinations[rand.Intn(len(destinations))]
 
+		// If the incoming peer signaled whether it endorses the htlc,
+		// we track the outcome of the forward for its signal.
+		if htlc.Endorsement != nil && incomingLink != nil {
+			s.endorsements.forwarded(
+				packet.inKey(), incomingLink.Peer().PubKey(),
+				htlc.Endorsement.IsEndorsed(),
+			)
+		}
+
 		// Send the packet to the destination channel link which
 		// manages the channel.
 		packet.outgoingChanID = destination.ShortChanID()
@@ -1111,6 +1415,8 @@ func (s *Switch) handlePacketForward(packet *htlcPacket) error {
 		}
 
 		fail, isFail := htlc.(*lnwire.UpdateFailHTLC)
+		s.endorsements.resolved(circuit.Incoming, !isFail)
+
 		if isFail && !packet.hasSource {
 			switch {
 			// No message to encrypt, locally sourced payment.
@@ -1444,10 +1750,12 @@ func (s *Switch) teardownCircuit(pkt *htlcPacket) error {
 // CloseLink creates and sends the close channel command to the target link
 // directing the specified closure type. If the closure type is CloseRegular,
 // targetFeePerKw parameter should be the ideal fee-per-kw that will be used as
-// a starting point for close negotiation. The deliveryScript parameter is an
-// optional parameter which sets a user specified script to close out to.
+// a starting point for close negotiation, and maxFee the highest fee-per-kw
+// that we'll accept. The deliveryScript parameter is an optional parameter
+// which sets a user specified script to close out to.
 func (s *Switch) CloseLink(chanPoint *wire.OutPoint,
-	closeType ChannelCloseType, targetFeePerKw chainfee.SatPerKWeight,
+	closeType ChannelCloseType, targetFeePerKw,
+	maxFee chainfee.SatPerKWeight,
 	deliveryScript lnwire.DeliveryAddress) (chan interface{}, chan error) {
 
 	// TODO(roasbeef) abstract out the close updates.
@@ -1459,6 +1767,7 @@ func (s *Switch) CloseLink(chanPoint *wire.OutPoint,
 		ChanPoint:      chanPoint,
 		Updates:        updateChan,
 		TargetFeePerKw: targetFeePerKw,
+		MaxFee:         maxFee,
 		DeliveryScript: deliveryScript,
 		Err:            errChan,
 	}
@@ -1777,6 +2086,18 @@ func (s *Switch) Start() error {
 		return err
 	}
 
+	// Once the responses of all forwarding packages have been delivered,
+	// the circuits that remain without any prospect of being completed
+	// are reported and resolved. A failing audit shouldn't prevent the
+	// switch from forwarding, so we only log the error.
+	if err := s.auditCircuits(); err != nil {
+		log.Errorf("unable to audit circuits: %v", err)
+	}
+
+	if s.holdWatchdog != nil {
+		s.holdWatchdog.Start()
+	}
+
 	return nil
 }
 
@@ -1941,6 +2262,10 @@ func (s *Switch) Stop() error {
 
 	s.wg.Wait()
 
+	if s.holdWatchdog != nil {
+		s.holdWatchdog.Stop()
+	}
+
 	// Wait until all active goroutines have finished exiting before
 	// stopping the mailboxes, otherwise the mailbox map could still be
 	// accessed and modified.
@@ -2050,11 +2375,15 @@ func (s *Switch) getLinkByShortID(chanID lnwire.ShortChannelID) (ChannelLink, er
 }
 
 // HasActiveLink returns true if the given channel ID has a link in the link
-// index AND the link is eligible to forward.
+// index AND the link is eligible to forward AND the link isn't suspended.
 func (s *Switch) HasActiveLink(chanID lnwire.ChannelID) bool {
 	s.indexMtx.RLock()
 	defer s.indexMtx.RUnlock()
 
+	if _, ok := s.suspendedLinks[chanID]; ok {
+		return false
+	}
+
 	if link, ok := s.linkIndex[chanID]; ok {
 		return link.EligibleToForward()
 	}
@@ -2107,6 +2436,94 @@ func (s *Switch) removeLink(chanID lnwire.ChannelID) ChannelLink {
 	return link
 }
 
+// SetDraining enables or disables drain mode. While draining, the switch
+// rejects all new HTLCs, both forwarded and locally initiated ones, so that
+// the HTLCs in flight can resolve before the node is shut down. Links also
+// reject new HTLCs that pay to us while the switch is draining.
+func (s *Switch) SetDraining(draining bool) {
+	var val int32
+	if draining {
+		val = 1
+	}
+
+	atomic.StoreInt32(&s.draining, val)
+}
+
+// IsDraining returns true if the switch is in drain mode.
+func (s *Switch) IsDraining() bool {
+	return atomic.LoadInt32(&s.draining) == 1
+}
+
+// EndorsementStats returns the endorsement stats of every peer that offered us
+// htlcs with an experimental endorsement signal, keyed by the peer's pubkey.
+func (s *Switch) EndorsementStats() map[[33]byte]EndorsementStats {
+	return s.endorsements.peerStats()
+}
+
+// SuspendLink prevents the link with the given channel ID from forwarding any
+// new HTLCs, without tearing it down. This is used when the funding
+// transaction of an open channel is reorged out of the chain. HTLCs that are
+// already in flight can still be settled or failed over the link. The
+// suspension also applies if the link is only added after this call.
+func (s *Switch) SuspendLink(chanID lnwire.ChannelID) {
+	s.indexMtx.Lock()
+	defer s.indexMtx.Unlock()
+
+	log.Infof("Suspending forwarding for ChannelLink(%v)", chanID)
+
+	s.suspendedLinks[chanID] = struct{}{}
+}
+
+// ResumeLink allows a previously suspended link to forward HTLCs again. If
+// the channel was confirmed at a different location in the chain, the link's
+// short channel ID is reloaded from disk and the forwarding index is updated
+// accordingly.
+func (s *Switch) ResumeLink(chanID lnwire.ChannelID) error {
+	s.indexMtx.Lock()
+	defer s.indexMtx.Unlock()
+
+	if _, ok := s.suspendedLinks[chanID]; !ok {
+		return nil
+	}
+	delete(s.suspendedLinks, chanID)
+
+	log.Infof("Resuming forwarding for ChannelLink(%v)", chanID)
+
+	// If the link isn't active at the moment, it will be added with its
+	// up to date short channel ID once it is.
+	link, ok := s.linkIndex[chanID]
+	if !ok {
+		return nil
+	}
+
+	oldShortChanID := link.ShortChanID()
+	shortChanID, err := link.UpdateShortChanID()
+	if err != nil {
+		return err
+	}
+
+	if shortChanID != oldShortChanID {
+		delete(s.forwardingIndex, oldShortChanID)
+		s.forwardingIndex[shortChanID] = link
+	}
+
+	return nil
+}
+
+// isSuspended returns true if the link with the given short channel ID has
+// been suspended.
+//
+// NOTE: This MUST be called with the indexMtx held.
+func (s *Switch) isSuspended(shortChanID lnwire.ShortChannelID) bool {
+	link, ok := s.forwardingIndex[shortChanID]
+	if !ok {
+		return false
+	}
+
+	_, ok = s.suspendedLinks[link.ChanID()]
+	return ok
+}
+
 // UpdateShortChanID updates the short chan ID for an existing channel. This is
 // required in the case of a re-org and re-confirmation or a channel, or in the
 // case that a link was added to the switch before its short chan ID was known.
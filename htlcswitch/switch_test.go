@@ -17,6 +17,7 @@ import (
 	"github.com/cryptomeow/lnd/lntypes"
 	"github.com/cryptomeow/lnd/lnwire"
 	"github.com/cryptomeow/lnd/ticker"
+	"github.com/stretchr/testify/require"
 )
 
 var zeroCircuit = channeldb.CircuitKey{}
@@ -397,6 +398,93 @@ func TestSwitchForward(t *testing.T) {
 	}
 }
 
+// TestSwitchForwardScidAlias checks that htlcs addressed to the alias of a
+// private channel are forwarded over the channel.
+func TestSwitchForwardScidAlias(t *testing.T) {
+	t.Parallel()
+
+	alicePeer, err := newMockServer(
+		t, "alice", testStartingHeight, nil, testDefaultDelta,
+	)
+	require.NoError(t, err)
+	bobPeer, err := newMockServer(
+		t, "bob", testStartingHeight, nil, testDefaultDelta,
+	)
+	require.NoError(t, err)
+
+	s, err := initSwitchWithDB(testStartingHeight, nil)
+	require.NoError(t, err)
+	require.NoError(t, s.Start())
+	defer s.Stop()
+
+	chanID1, chanID2, aliceChanID, bobChanID := genIDs()
+
+	aliceChannelLink := newMockChannelLink(
+		s, chanID1, aliceChanID, alicePeer, true,
+	)
+	bobChannelLink := newMockChannelLink(
+		s, chanID2, bobChanID, bobPeer, true,
+	)
+	require.NoError(t, s.AddLink(aliceChannelLink))
+	require.NoError(t, s.AddLink(bobChannelLink))
+
+	alias, err := s.cfg.DB.CreateScidAlias(bobChanID)
+	require.NoError(t, err)
+
+	// The htlc is addressed to the alias of Bob's channel, so it should be
+	// forwarded to Bob's link.
+	preimage, err := genPreimage()
+	require.NoError(t, err)
+	rhash := sha256.Sum256(preimage[:])
+	packet := &htlcPacket{
+		incomingChanID: aliceChannelLink.ShortChanID(),
+		incomingHTLCID: 0,
+		outgoingChanID: alias,
+		obfuscator:     NewMockObfuscator(),
+		htlc: &lnwire.UpdateAddHTLC{
+			PaymentHash: rhash,
+			Amount:      1,
+		},
+	}
+	require.NoError(t, s.ForwardPackets(nil, packet))
+
+	select {
+	case pkt := <-bobChannelLink.packets:
+		require.Equal(t, bobChanID, pkt.outgoingChanID)
+
+	case <-time.After(time.Second):
+		t.Fatal("request was not propagated to destination")
+	}
+
+	// An unknown alias can't be resolved, so the htlc is failed back.
+	unknownAlias := lnwire.ShortChannelID{
+		BlockHeight: lnwire.AliasStartBlockHeight,
+	}
+	if unknownAlias == alias {
+		unknownAlias.TxIndex++
+	}
+	packet = &htlcPacket{
+		incomingChanID: aliceChannelLink.ShortChanID(),
+		incomingHTLCID: 1,
+		outgoingChanID: unknownAlias,
+		obfuscator:     NewMockObfuscator(),
+		htlc: &lnwire.UpdateAddHTLC{
+			PaymentHash: rhash,
+			Amount:      1,
+		},
+	}
+	require.NoError(t, s.ForwardPackets(nil, packet))
+
+	select {
+	case pkt := <-aliceChannelLink.packets:
+		_, ok := pkt.htlc.(*lnwire.UpdateFailHTLC)
+		require.True(t, ok)
+
+	case <-time.After(time.Second):
+		t.Fatal("htlc was not failed back")
+	}
+}
+
 func TestSwitchForwardFailAfterFullAdd(t *testing.T) {
 	t.Parallel()
 
@@ -3297,3 +3385,282 @@ func TestSwitchHoldForward(t *testing.T) {
 	assertOutgoingLinkReceive(t, aliceChannelLink, true)
 	assertNumCircuits(t, s, 0, 0)
 }
+
+// TestSwitchDustExposure tests that dust htlcs are failed once they would
+// push the dust exposure of a link beyond the configured maximum.
+func TestSwitchDustExposure(t *testing.T) {
+	t.Parallel()
+
+	alicePeer, err := newMockServer(
+		t, "alice", testStartingHeight, nil, testDefaultDelta,
+	)
+	require.NoError(t, err)
+	bobPeer, err := newMockServer(
+		t, "bob", testStartingHeight, nil, testDefaultDelta,
+	)
+	require.NoError(t, err)
+
+	s, err := initSwitchWithDB(testStartingHeight, nil)
+	require.NoError(t, err)
+	s.cfg.MaxDustExposure = 1000
+	require.NoError(t, s.Start())
+	defer s.Stop()
+
+	chanID1, chanID2, aliceChanID, bobChanID := genIDs()
+
+	aliceChannelLink := newMockChannelLink(
+		s, chanID1, aliceChanID, alicePeer, true,
+	)
+	aliceChannelLink.dustLimit = 500
+	require.NoError(t, s.AddLink(aliceChannelLink))
+
+	bobChannelLink := newMockChannelLink(
+		s, chanID2, bobChanID, bobPeer, true,
+	)
+	bobChannelLink.dustLimit = 500
+	require.NoError(t, s.AddLink(bobChannelLink))
+
+	assertDustExposureErr := func(err error) {
+		t.Helper()
+
+		linkErr, ok := err.(*LinkError)
+		require.True(t, ok, "expected link error, got: %v", err)
+		require.Equal(
+			t, OutgoingFailureDustExposure, linkErr.FailureDetail,
+		)
+	}
+
+	// Send two local dust htlcs over Bob's link. As they're still queued
+	// in the mailbox, they both count towards the dust exposure, so the
+	// third one exceeds the maximum.
+	sendHTLC := func(id uint64, amt lnwire.MilliSatoshi) error {
+		return s.SendHTLC(bobChanID, id, &lnwire.UpdateAddHTLC{
+			PaymentHash: sha256.Sum256([]byte{byte(id)}),
+			Amount:      amt,
+		})
+	}
+	require.NoError(t, sendHTLC(0, 400))
+	require.NoError(t, sendHTLC(1, 400))
+	assertDustExposureErr(sendHTLC(2, 400))
+
+	// Htlcs that aren't dust are still sent.
+	require.NoError(t, sendHTLC(3, 600))
+
+	// Forwarded dust htlcs are subject to the same limit.
+	forwardHTLC := func(id uint64, amt lnwire.MilliSatoshi) {
+		packet := &htlcPacket{
+			incomingChanID: aliceChanID,
+			incomingHTLCID: id,
+			outgoingChanID: bobChanID,
+			obfuscator:     NewMockObfuscator(),
+			incomingAmount: amt,
+			amount:         amt,
+			htlc: &lnwire.UpdateAddHTLC{
+				PaymentHash: sha256.Sum256([]byte{byte(id)}),
+				Amount:      amt,
+			},
+		}
+		require.NoError(t, s.ForwardPackets(nil, packet))
+	}
+
+	assertForwardFailed := func() {
+		t.Helper()
+
+		select {
+		case pkt := <-aliceChannelLink.packets:
+			assertDustExposureErr(pkt.linkFailure)
+
+		case <-time.After(time.Second):
+			t.Fatal("htlc was not failed back")
+		}
+	}
+
+	// A forward that would exceed the dust exposure of Bob's link is
+	// failed back to Alice.
+	forwardHTLC(0, 300)
+	assertForwardFailed()
+
+	// A smaller one still fits and is queued in Bob's mailbox.
+	bobMailBox := s.mailOrchestrator.GetOrCreateMailBox(chanID2, bobChanID)
+	isDust := func(amt lnwire.MilliSatoshi) bool {
+		return bobChannelLink.isDust(amt, false, false)
+	}
+	forwardHTLC(1, 100)
+	require.Eventually(t, func() bool {
+		return bobMailBox.DustPackets(isDust) == 900
+	}, time.Second, 10*time.Millisecond)
+
+	// Finally, if the dust exposure of the incoming link is already
+	// exceeded, a dust htlc offered by Alice is failed back as well.
+	aliceChannelLink.dustSum = 1100
+	forwardHTLC(2, 50)
+	assertForwardFailed()
+}
+
+// TestSwitchReservedOutgoingSlots tests that forwards are failed once they
+// would occupy the htlc slots reserved for local payments, while local
+// payments can still use them.
+func TestSwitchReservedOutgoingSlots(t *testing.T) {
+	t.Parallel()
+
+	alicePeer, err := newMockServer(
+		t, "alice", testStartingHeight, nil, testDefaultDelta,
+	)
+	require.NoError(t, err)
+	bobPeer, err := newMockServer(
+		t, "bob", testStartingHeight, nil, testDefaultDelta,
+	)
+	require.NoError(t, err)
+
+	s, err := initSwitchWithDB(testStartingHeight, nil)
+	require.NoError(t, err)
+	s.cfg.ReservedOutgoingSlots = 2
+	require.NoError(t, s.Start())
+	defer s.Stop()
+
+	chanID1, chanID2, aliceChanID, bobChanID := genIDs()
+
+	aliceChannelLink := newMockChannelLink(
+		s, chanID1, aliceChanID, alicePeer, true,
+	)
+	require.NoError(t, s.AddLink(aliceChannelLink))
+
+	bobChannelLink := newMockChannelLink(
+		s, chanID2, bobChanID, bobPeer, true,
+	)
+	bobChannelLink.outgoingHtlcs = 2
+	bobChannelLink.maxHtlcs = 5
+	require.NoError(t, s.AddLink(bobChannelLink))
+
+	forwardHTLC := func(id uint64) {
+		packet := &htlcPacket{
+			incomingChanID: aliceChanID,
+			incomingHTLCID: id,
+			outgoingChanID: bobChanID,
+			obfuscator:     NewMockObfuscator(),
+			incomingAmount: 1000,
+			amount:         1000,
+			htlc: &lnwire.UpdateAddHTLC{
+				PaymentHash: sha256.Sum256([]byte{byte(id)}),
+				Amount:      1000,
+			},
+		}
+		require.NoError(t, s.ForwardPackets(nil, packet))
+	}
+
+	// With two of Bob's five slots in use, the first forward still leaves
+	// the two reserved slots available.
+	bobMailBox := s.mailOrchestrator.GetOrCreateMailBox(chanID2, bobChanID)
+	forwardHTLC(0)
+	require.Eventually(t, func() bool {
+		return bobMailBox.NumAddPackets() == 1
+	}, time.Second, 10*time.Millisecond)
+
+	// The next forward would occupy a reserved slot, so it's failed back
+	// to Alice.
+	forwardHTLC(1)
+	select {
+	case pkt := <-aliceChannelLink.packets:
+		require.NotNil(t, pkt.linkFailure)
+		require.Equal(
+			t, OutgoingFailureReservedSlots,
+			pkt.linkFailure.FailureDetail,
+		)
+
+	case <-time.After(time.Second):
+		t.Fatal("htlc was not failed back")
+	}
+
+	// Local payments can use the reserved slots.
+	err = s.SendHTLC(bobChanID, 0, &lnwire.UpdateAddHTLC{
+		PaymentHash: sha256.Sum256([]byte{2}),
+		Amount:      1000,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, bobMailBox.NumAddPackets())
+}
+
+// TestSwitchDraining tests that the switch rejects new forwards and local
+// payments while it's draining, and accepts them again once draining ends.
+func TestSwitchDraining(t *testing.T) {
+	t.Parallel()
+
+	alicePeer, err := newMockServer(
+		t, "alice", testStartingHeight, nil, testDefaultDelta,
+	)
+	require.NoError(t, err)
+	bobPeer, err := newMockServer(
+		t, "bob", testStartingHeight, nil, testDefaultDelta,
+	)
+	require.NoError(t, err)
+
+	s, err := initSwitchWithDB(testStartingHeight, nil)
+	require.NoError(t, err)
+	require.NoError(t, s.Start())
+	defer s.Stop()
+
+	chanID1, chanID2, aliceChanID, bobChanID := genIDs()
+
+	aliceChannelLink := newMockChannelLink(
+		s, chanID1, aliceChanID, alicePeer, true,
+	)
+	require.NoError(t, s.AddLink(aliceChannelLink))
+
+	bobChannelLink := newMockChannelLink(
+		s, chanID2, bobChanID, bobPeer, true,
+	)
+	require.NoError(t, s.AddLink(bobChannelLink))
+
+	forwardHTLC := func(id uint64) {
+		packet := &htlcPacket{
+			incomingChanID: aliceChanID,
+			incomingHTLCID: id,
+			outgoingChanID: bobChanID,
+			obfuscator:     NewMockObfuscator(),
+			incomingAmount: 1000,
+			amount:         1000,
+			htlc: &lnwire.UpdateAddHTLC{
+				PaymentHash: sha256.Sum256([]byte{byte(id)}),
+				Amount:      1000,
+			},
+		}
+		require.NoError(t, s.ForwardPackets(nil, packet))
+	}
+
+	require.False(t, s.IsDraining())
+
+	s.SetDraining(true)
+	require.True(t, s.IsDraining())
+
+	// Forwards are failed back to Alice while draining.
+	forwardHTLC(0)
+	select {
+	case pkt := <-aliceChannelLink.packets:
+		require.NotNil(t, pkt.linkFailure)
+		require.Equal(
+			t, OutgoingFailureNodeDraining,
+			pkt.linkFailure.FailureDetail,
+		)
+
+	case <-time.After(time.Second):
+		t.Fatal("htlc was not failed back")
+	}
+
+	// Local payments are rejected right away.
+	err = s.SendHTLC(bobChanID, 0, &lnwire.UpdateAddHTLC{
+		PaymentHash: sha256.Sum256([]byte{1}),
+		Amount:      1000,
+	})
+	require.Equal(t, ErrSwitchDraining, err)
+
+	// Once draining ends, forwards reach Bob again.
+	s.SetDraining(false)
+	require.False(t, s.IsDraining())
+
+	forwardHTLC(2)
+	select {
+	case <-bobChannelLink.packets:
+	case <-time.After(time.Second):
+		t.Fatal("htlc was not forwarded")
+	}
+}
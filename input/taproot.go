@@ -0,0 +1,175 @@
+package input
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/cryptomeow/lnd/internal/schnorr"
+)
+
+const (
+	// TapscriptLeafVersion is the leaf version of BIP-342 tapscript
+	// leaves.
+	TapscriptLeafVersion byte = 0xc0
+
+	// SigHashDefault is the BIP-341 sighash type that commits to all
+	// inputs and outputs just like SigHashAll, but produces 64 byte
+	// signatures as the sighash flag is omitted.
+	SigHashDefault txscript.SigHashType = 0x00
+
+	// sigHashMask masks out the anyone can pay flag of a sighash type.
+	sigHashMask = 0x1f
+
+	// tapscriptExtFlag is the BIP-341 ext_flag of tapscript spends.
+	tapscriptExtFlag = 1
+
+	// noCodeSeparator is the position committed to in the sighash if no
+	// OP_CODESEPARATOR was executed.
+	noCodeSeparator = 0xffffffff
+)
+
+// TapLeafHash returns the BIP-341 tapleaf hash of the given tapscript leaf.
+func TapLeafHash(script []byte) chainhash.Hash {
+	var leaf bytes.Buffer
+	leaf.WriteByte(TapscriptLeafVersion)
+
+	// Writing to a bytes.Buffer never fails.
+	_ = wire.WriteVarBytes(&leaf, 0, script)
+
+	return *schnorr.TaggedHash(schnorr.TagTapLeaf, leaf.Bytes())
+}
+
+// TaprootScriptSpendSigHash computes the BIP-341 signature hash of the input
+// at index idx of the transaction, spending the given tapscript leaf of a
+// taproot output. As the sighash commits to all outputs spent by the
+// transaction, prevOuts must hold them in the order of the inputs.
+func TaprootScriptSpendSigHash(tx *wire.MsgTx, idx int,
+	prevOuts []*wire.TxOut, leafScript []byte,
+	hashType txscript.SigHashType) ([]byte, error) {
+
+	leafHash := TapLeafHash(leafScript)
+
+	return taprootSigHash(tx, idx, prevOuts, hashType, &leafHash)
+}
+
+// taprootSigHash computes the BIP-341 signature hash of the input at index
+// idx of the transaction. If leafHash is nil, the sighash of a key path spend
+// is computed, otherwise the one of a spend of the given tapscript leaf.
+func taprootSigHash(tx *wire.MsgTx, idx int, prevOuts []*wire.TxOut,
+	hashType txscript.SigHashType, leafHash *chainhash.Hash) ([]byte,
+	error) {
+
+	switch hashType {
+	case SigHashDefault, txscript.SigHashAll, txscript.SigHashNone,
+		txscript.SigHashSingle, 0x81, 0x82, 0x83:
+
+	default:
+		return nil, fmt.Errorf("invalid taproot sighash type %v",
+			hashType)
+	}
+
+	if idx < 0 || idx >= len(tx.TxIn) {
+		return nil, fmt.Errorf("input index %d out of range, tx has "+
+			"%d inputs", idx, len(tx.TxIn))
+	}
+	if len(prevOuts) != len(tx.TxIn) {
+		return nil, fmt.Errorf("got %d previous outputs for %d inputs",
+			len(prevOuts), len(tx.TxIn))
+	}
+
+	var (
+		anyoneCanPay = hashType&txscript.SigHashAnyOneCanPay != 0
+		baseType     = hashType & sigHashMask
+	)
+
+	if baseType == txscript.SigHashSingle && idx >= len(tx.TxOut) {
+		return nil, fmt.Errorf("no output %d for SIGHASH_SINGLE", idx)
+	}
+
+	// All writes below go to a bytes.Buffer or a hash, which never fail,
+	// so we don't check their errors. The sighash epoch is prepended to
+	// the message.
+	var msg bytes.Buffer
+	msg.WriteByte(0x00)
+	msg.WriteByte(byte(hashType))
+
+	_ = binary.Write(&msg, binary.LittleEndian, tx.Version)
+	_ = binary.Write(&msg, binary.LittleEndian, tx.LockTime)
+
+	if !anyoneCanPay {
+		var prevOutPoints, amounts, scripts, sequences bytes.Buffer
+		for i, txIn := range tx.TxIn {
+			writeOutPoint(&prevOutPoints, &txIn.PreviousOutPoint)
+			prevOut := prevOuts[i]
+			_ = binary.Write(
+				&amounts, binary.LittleEndian, prevOut.Value,
+			)
+			_ = wire.WriteVarBytes(&scripts, 0, prevOut.PkScript)
+			_ = binary.Write(
+				&sequences, binary.LittleEndian, txIn.Sequence,
+			)
+		}
+
+		for _, b := range []bytes.Buffer{
+			prevOutPoints, amounts, scripts, sequences,
+		} {
+			digest := sha256.Sum256(b.Bytes())
+			msg.Write(digest[:])
+		}
+	}
+
+	if baseType != txscript.SigHashNone &&
+		baseType != txscript.SigHashSingle {
+
+		outputs := sha256.New()
+		for _, txOut := range tx.TxOut {
+			_ = wire.WriteTxOut(outputs, 0, 0, txOut)
+		}
+		msg.Write(outputs.Sum(nil))
+	}
+
+	// We never commit to an annex, so the spend type only consists of the
+	// extension flag.
+	var spendType byte
+	if leafHash != nil {
+		spendType = tapscriptExtFlag * 2
+	}
+	msg.WriteByte(spendType)
+
+	txIn := tx.TxIn[idx]
+	if anyoneCanPay {
+		writeOutPoint(&msg, &txIn.PreviousOutPoint)
+		_ = wire.WriteTxOut(&msg, 0, 0, prevOuts[idx])
+		_ = binary.Write(&msg, binary.LittleEndian, txIn.Sequence)
+	} else {
+		_ = binary.Write(&msg, binary.LittleEndian, uint32(idx))
+	}
+
+	if baseType == txscript.SigHashSingle {
+		output := sha256.New()
+		_ = wire.WriteTxOut(output, 0, 0, tx.TxOut[idx])
+		msg.Write(output.Sum(nil))
+	}
+
+	// Tapscript spends additionally commit to the leaf, the key version
+	// and the position of the last executed OP_CODESEPARATOR.
+	if leafHash != nil {
+		msg.Write(leafHash[:])
+		msg.WriteByte(0x00)
+		_ = binary.Write(&msg, binary.LittleEndian,
+			uint32(noCodeSeparator))
+	}
+
+	return schnorr.TaggedHash(schnorr.TagTapSighash, msg.Bytes())[:], nil
+}
+
+// writeOutPoint writes the wire serialization of the outpoint to the buffer.
+func writeOutPoint(b *bytes.Buffer, op *wire.OutPoint) {
+	b.Write(op.Hash[:])
+	_ = binary.Write(b, binary.LittleEndian, op.Index)
+}
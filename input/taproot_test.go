@@ -0,0 +1,156 @@
+package input
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTaprootSigHash checks the BIP-341 signature hashes of key and script
+// path spends against the implementation of btcd v0.24 for all sighash types.
+func TestTaprootSigHash(t *testing.T) {
+	t.Parallel()
+
+	tx := wire.NewMsgTx(2)
+	tx.LockTime = 500
+
+	var prevOuts []*wire.TxOut
+	for i := 0; i < 3; i++ {
+		tx.AddTxIn(&wire.TxIn{
+			PreviousOutPoint: wire.OutPoint{
+				Hash:  chainhash.DoubleHashH([]byte{byte(i)}),
+				Index: uint32(i),
+			},
+			Sequence: 0xfffffffd - uint32(i),
+		})
+		prevOuts = append(prevOuts, &wire.TxOut{
+			Value: int64(10000 * (i + 1)),
+			PkScript: append(
+				[]byte{0x51, 0x20},
+				bytes.Repeat([]byte{byte(i + 1)}, 32)...,
+			),
+		})
+	}
+	tx.AddTxOut(&wire.TxOut{
+		Value: 1000, PkScript: []byte{0x51, 0x20, 1, 2, 3},
+	})
+	tx.AddTxOut(&wire.TxOut{
+		Value: 2000, PkScript: []byte{0x00, 0x14, 4, 5, 6},
+	})
+
+	leafScript := []byte{0x20, 1, 2, 3, 0xac}
+	leafHash := TapLeafHash(leafScript)
+	require.Equal(
+		t, "5261e22f7d22d9f80743a564263ebebaa5e3202dbbc76efb"+
+			"b882d971c76a19ff", hex.EncodeToString(leafHash[:]),
+	)
+
+	vectors := []struct {
+		hashType   txscript.SigHashType
+		idx        int
+		keySpend   string
+		scriptPath string
+	}{
+		{
+			0x00, 0,
+			"202291064fed3288086a870e9cc48e1c493c95297054b15226e1009f3efbad1b",
+			"9d4eaa81ccd07f0b35ce273cc1cd644bfaef26e8a7c0abf03c1931ce4953ddab",
+		},
+		{
+			0x00, 1,
+			"dd2b733f05ae880721b4b7af3db42447424697b9441eb5bd99c133a3ac551ae7",
+			"3c0f48eaa724ad1733ddb69f7d0e7acad772a16a73f6c1fbaa38513fe922ead1",
+		},
+		{
+			0x01, 0,
+			"5a0dd8cea287e7d1e97a28079f0ddbaa813993119887a5797d1b5565b45942b8",
+			"c50bef4fcaa378628cb53357f6b18e4dbe082ed26d9ca81bddebc0da373d661e",
+		},
+		{
+			0x01, 1,
+			"8cd8b1cd9d4db98361ea6ae12347489202d83b6f0e6bbad90392da61a7b2d76e",
+			"39f03bdf064f6f12d8d7a48daea8aafbbeb55c7700635d180e69db517cbcc88e",
+		},
+		{
+			0x02, 0,
+			"aa7e3d46ed68e860ddfa03472354f4be377fc457d72587cf7943c47770bfcf9a",
+			"b69191c74238ee8dd24b40ee1a124212d7e6e4f5f17a1ef94720be711d0e89a0",
+		},
+		{
+			0x02, 1,
+			"abb31ee1c6345e61a32081d60af4b2b351ac07e523ceec38e0bfed6653accd4f",
+			"6b766cefd9c0147db809ce419c27e3e6f20e6ecb02a9349a8c36083cd63a6992",
+		},
+		{
+			0x03, 0,
+			"03ba411ffc93dfde585c521723aafb296a77770ae22921be2a73c50de36185bb",
+			"ba2948ed9bfb0bd3c3b7659801829829a6bea696cd13b911c6c3359a83c5b8ce",
+		},
+		{
+			0x03, 1,
+			"aa3cd41b30d6dd0022e35028efeb08195be15f85ef8954d99b1146aa1aecabcb",
+			"da7e8c0db9ccd3a0eddc03d22099433a2aebc5914dcf1af7fc02f56f785815ea",
+		},
+		{
+			0x81, 0,
+			"1c3ba0861bd106cc25c5fb612d30e5da4ca9a953f1e7216744d1d3b5226249b8",
+			"32459ae5126db95cfc0897aaf5fd45ffff1d9516d2dcf0466ad46aff7fd9137b",
+		},
+		{
+			0x81, 1,
+			"23e2ef7a0824a6a2ae0b24432a45fd640782b694601232000345e54d637bf3ba",
+			"b8ced11beb85311a639ed8e06d59be929abf3a1c857aad66a5267fc767b76bdf",
+		},
+		{
+			0x82, 0,
+			"eb80634e24565c3f7bc8d6ed822ed3691a0f9aaf5ee878144513632c425e3b51",
+			"386a5006409418e8290dcddfffb43d570cd9ac6dec76980fcd1cdc77d9c47228",
+		},
+		{
+			0x82, 1,
+			"ea8ddd1d19ba4503cf362a9fb72779a5379c0ef095ea55274741caac94d80e0a",
+			"dbad5eaeefad77dd7522ea140bdebb0a0274ce4a167441e34f047cc2f4c9c1c4",
+		},
+		{
+			0x83, 0,
+			"d89cec44119457476598d5902355f1dc04d62735fcbd1a835d45c078a8f42bbc",
+			"1423a3e12e8894f7c03a867712061fef62a7d71e05e03b206a2abb94e7f5993a",
+		},
+		{
+			0x83, 1,
+			"75249cf806880923f7b67ee24c78b5122fb7dc2dafa4b0636b50c4d798aabeed",
+			"30d7073b2b6f5fa28c9235a3f825c09b18eb241da48005101f58f31bdb597b57",
+		},
+	}
+
+	for _, v := range vectors {
+		sigHash, err := taprootSigHash(
+			tx, v.idx, prevOuts, v.hashType, nil,
+		)
+		require.NoError(t, err)
+		require.Equal(t, v.keySpend, hex.EncodeToString(sigHash))
+
+		sigHash, err = TaprootScriptSpendSigHash(
+			tx, v.idx, prevOuts, leafScript, v.hashType,
+		)
+		require.NoError(t, err)
+		require.Equal(t, v.scriptPath, hex.EncodeToString(sigHash))
+	}
+
+	// SIGHASH_SINGLE requires an output at the index of the input.
+	_, err := TaprootScriptSpendSigHash(
+		tx, 2, prevOuts, leafScript, txscript.SigHashSingle,
+	)
+	require.Error(t, err)
+
+	// The sighash commits to all previous outputs.
+	_, err = TaprootScriptSpendSigHash(
+		tx, 0, prevOuts[:2], leafScript, SigHashDefault,
+	)
+	require.Error(t, err)
+}
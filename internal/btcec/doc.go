@@ -0,0 +1,13 @@
+// Copyright (c) 2013-2014 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+// Package btcec is a copy of the parts of github.com/btcsuite/btcd/btcec/v2
+// v2.3.4 that the schnorr and musig2 packages depend on. It is a thin layer
+// over github.com/decred/dcrd/dcrec/secp256k1/v4.
+//
+// The btcec/v2 module can't be used directly, as it requires the standalone
+// chainhash module, which conflicts with the chainhash package of the btcd
+// version this module is pinned to. Once lnd moves to btcd v0.23 or later,
+// this package and its dependents should be replaced by the upstream ones.
+package btcec
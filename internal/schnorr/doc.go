@@ -0,0 +1,10 @@
+// Copyright (c) 2013-2017 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+// Package schnorr is a copy of github.com/btcsuite/btcd/btcec/v2/schnorr
+// v2.3.4, which implements BIP-340 schnorr signatures. Only the imports were
+// changed, and the tagged hash of the chainhash package was moved into
+// taggedhash.go. See the btcec package for why the module isn't used
+// directly.
+package schnorr
@@ -0,0 +1,7 @@
+// Copyright 2013-2022 The btcsuite developers
+
+// Package musig2 is a copy of github.com/btcsuite/btcd/btcec/v2/schnorr/musig2
+// v2.3.4, which implements the MuSig2 multi-signature scheme of BIP-327. Only
+// the imports were changed. See the btcec package for why the module isn't
+// used directly.
+package musig2
@@ -0,0 +1,251 @@
+package invoices
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cryptomeow/lnd/channeldb"
+	"github.com/cryptomeow/lnd/lntypes"
+)
+
+const (
+	// DefaultMaxHeldHtlcsPerInvoice is the default maximum number of htlcs
+	// that are held on a single invoice at the same time.
+	DefaultMaxHeldHtlcsPerInvoice = 100
+
+	// DefaultMaxHeldHtlcs is the default maximum number of htlcs that are
+	// held across all invoices at the same time.
+	DefaultMaxHeldHtlcs = 10000
+)
+
+var (
+	// ErrHtlcNotHeld is returned when canceling a htlc that isn't held by
+	// the registry.
+	ErrHtlcNotHeld = errors.New("htlc not held")
+
+	// ErrHtlcNotCancelable is returned when canceling a held htlc that
+	// can't be canceled individually, because its invoice is no longer
+	// open.
+	ErrHtlcNotCancelable = errors.New("htlc can't be canceled " +
+		"individually")
+)
+
+// HeldHtlcLimitPolicy determines how the registry deals with a new htlc that
+// would exceed one of the held htlc limits.
+type HeldHtlcLimitPolicy uint8
+
+const (
+	// HeldHtlcLimitReject rejects the new htlc.
+	HeldHtlcLimitReject HeldHtlcLimitPolicy = iota
+
+	// HeldHtlcLimitCancelOldest cancels the oldest held htlc that can be
+	// canceled individually to make room for the new htlc. Only htlcs of
+	// invoices that are still open, such as incomplete mpp sets, can be
+	// canceled individually. If there is no such htlc, the new htlc is
+	// rejected.
+	HeldHtlcLimitCancelOldest
+)
+
+// String returns a human readable representation of the policy.
+func (p HeldHtlcLimitPolicy) String() string {
+	switch p {
+	case HeldHtlcLimitReject:
+		return "reject"
+
+	case HeldHtlcLimitCancelOldest:
+		return "cancel-oldest"
+
+	default:
+		return "unknown"
+	}
+}
+
+// ParseHeldHtlcLimitPolicy parses the textual representation of a held htlc
+// limit policy.
+func ParseHeldHtlcLimitPolicy(s string) (HeldHtlcLimitPolicy, error) {
+	switch strings.ToLower(s) {
+	case "", "reject":
+		return HeldHtlcLimitReject, nil
+
+	case "cancel-oldest":
+		return HeldHtlcLimitCancelOldest, nil
+
+	default:
+		return 0, fmt.Errorf("unknown held htlc limit policy: %v, must "+
+			"be one of: reject, cancel-oldest", s)
+	}
+}
+
+// heldHtlc describes a htlc that is currently held by the registry.
+type heldHtlc struct {
+	// invoiceRef identifies the invoice the htlc pays to.
+	invoiceRef channeldb.InvoiceRef
+
+	// hash is the payment hash of the invoice the htlc pays to.
+	hash lntypes.Hash
+
+	// acceptTime is the time at which the htlc was accepted.
+	acceptTime time.Time
+
+	// cancelable indicates whether the htlc can be canceled individually,
+	// which is only the case while its invoice is open.
+	cancelable bool
+}
+
+// htlcEviction identifies a held htlc that is canceled to make room for a new
+// htlc.
+type htlcEviction struct {
+	// invoiceRef identifies the invoice the htlc pays to.
+	invoiceRef channeldb.InvoiceRef
+
+	// key is the circuit key of the htlc.
+	key channeldb.CircuitKey
+}
+
+// checkHeldHtlcLimits checks whether holding the new htlc described by ctx on
+// the given invoice exceeds one of the held htlc limits. If it does, depending
+// on the configured policy, either a fail resolution for the new htlc is
+// returned, or an older htlc that should be canceled to make room for it.
+//
+// NOTE: This method must be called with the registry lock held.
+func (i *InvoiceRegistry) checkHeldHtlcLimits(ctx *invoiceUpdateCtx,
+	inv *channeldb.Invoice, update *channeldb.InvoiceUpdateDesc) (
+	*htlcEviction, *HtlcFailResolution) {
+
+	cancelOldest := i.cfg.HeldHtlcLimitPolicy == HeldHtlcLimitCancelOldest
+
+	// Htlcs can only be canceled individually while the invoice is open.
+	// If the new htlc completes the set, the invoice moves on to the
+	// accepted state.
+	staysOpen := inv.State == channeldb.ContractOpen && update.State == nil
+
+	var (
+		numHeld    int
+		oldest     *channeldb.CircuitKey
+		oldestTime time.Time
+	)
+	for key, htlc := range inv.Htlcs {
+		if htlc.State != channeldb.HtlcStateAccepted {
+			continue
+		}
+
+		numHeld++
+		if oldest == nil || htlc.AcceptTime.Before(oldestTime) {
+			key := key
+			oldest = &key
+			oldestTime = htlc.AcceptTime
+		}
+	}
+
+	maxPerInvoice := i.cfg.MaxHeldHtlcsPerInvoice
+	if maxPerInvoice != 0 && numHeld >= maxPerInvoice {
+		ctx.log("per invoice held htlc limit reached")
+
+		if !cancelOldest || !staysOpen {
+			return nil, ctx.failRes(ResultHeldHtlcLimit)
+		}
+
+		// Canceling one of the htlcs of this invoice frees up a slot of
+		// the global limit as well.
+		return &htlcEviction{
+			invoiceRef: ctx.invoiceRef(),
+			key:        *oldest,
+		}, nil
+	}
+
+	maxHeld := i.cfg.MaxHeldHtlcs
+	if maxHeld == 0 || len(i.heldHtlcs) < maxHeld {
+		return nil, nil
+	}
+
+	ctx.log("global held htlc limit reached")
+
+	if !cancelOldest {
+		return nil, ctx.failRes(ResultHeldHtlcLimit)
+	}
+
+	// Find the oldest htlc that can be canceled individually. The htlcs
+	// of the invoice that is being paid are only eligible if it stays
+	// open.
+	var (
+		eviction     *htlcEviction
+		evictionTime time.Time
+	)
+	for key, htlc := range i.heldHtlcs {
+		if !htlc.cancelable {
+			continue
+		}
+
+		if htlc.hash == ctx.hash && !staysOpen {
+			continue
+		}
+
+		if eviction == nil || htlc.acceptTime.Before(evictionTime) {
+			eviction = &htlcEviction{
+				invoiceRef: htlc.invoiceRef,
+				key:        key,
+			}
+			evictionTime = htlc.acceptTime
+		}
+	}
+
+	if eviction == nil {
+		return nil, ctx.failRes(ResultHeldHtlcLimit)
+	}
+
+	return eviction, nil
+}
+
+// trackHeldHtlc records that the htlc described by ctx is held on the given
+// invoice.
+//
+// NOTE: This method must be called with the registry lock held.
+func (i *InvoiceRegistry) trackHeldHtlc(ctx *invoiceUpdateCtx,
+	invoice *channeldb.Invoice, acceptTime time.Time) {
+
+	i.heldHtlcs[ctx.circuitKey] = &heldHtlc{
+		invoiceRef: ctx.invoiceRef(),
+		hash:       ctx.hash,
+		acceptTime: acceptTime,
+		cancelable: invoice.State == channeldb.ContractOpen,
+	}
+
+	// Once the invoice is no longer open, none of its htlcs can be canceled
+	// individually anymore.
+	if invoice.State == channeldb.ContractOpen {
+		return
+	}
+
+	for key := range invoice.Htlcs {
+		if htlc, ok := i.heldHtlcs[key]; ok {
+			htlc.cancelable = false
+		}
+	}
+}
+
+// CancelHeldHtlc cancels a single htlc that is held by the registry, such as
+// a part of an incomplete mpp set that is held for too long. Only htlcs of
+// invoices that are still open can be canceled individually, the htlcs of
+// accepted invoices remain held until the invoice is settled or canceled.
+func (i *InvoiceRegistry) CancelHeldHtlc(key channeldb.CircuitKey,
+	result FailResolutionResult) error {
+
+	i.Lock()
+	defer i.Unlock()
+
+	htlc, ok := i.heldHtlcs[key]
+	if !ok {
+		return ErrHtlcNotHeld
+	}
+
+	if !htlc.cancelable {
+		return ErrHtlcNotCancelable
+	}
+
+	log.Debugf("Canceling held htlc %v on invoice %v: %v", key,
+		htlc.invoiceRef, result)
+
+	return i.cancelSingleHtlcLocked(htlc.invoiceRef, key, result)
+}
@@ -48,6 +48,12 @@ type InvoiceExpiryWatcher struct {
 	// invoice to expire.
 	expiryQueue queue.PriorityQueue
 
+	// expiries holds the latest expiry of each invoice in the expiry
+	// queue. An invoice may be added again with a new expiry when it is
+	// reissued, in which case the queue item with the previous expiry is
+	// skipped. It is only accessed by the main loop.
+	expiries map[lntypes.Hash]time.Time
+
 	// newInvoices channel is used to wake up the main loop when a new
 	// invoices is added.
 	newInvoices chan []*invoiceExpiry
@@ -62,6 +68,7 @@ type InvoiceExpiryWatcher struct {
 func NewInvoiceExpiryWatcher(clock clock.Clock) *InvoiceExpiryWatcher {
 	return &InvoiceExpiryWatcher{
 		clock:       clock,
+		expiries:    make(map[lntypes.Hash]time.Time),
 		newInvoices: make(chan []*invoiceExpiry),
 		quit:        make(chan struct{}),
 	}
@@ -162,6 +169,15 @@ func (ew *InvoiceExpiryWatcher) cancelNextExpiredInvoice() {
 		if !top.Expiry.Before(ew.clock.Now()) {
 			return
 		}
+		ew.expiryQueue.Pop()
+
+		// Skip the invoice if it was added again with another expiry
+		// in the meantime.
+		expiry, ok := ew.expiries[top.PaymentHash]
+		if !ok || !expiry.Equal(top.Expiry) {
+			return
+		}
+		delete(ew.expiries, top.PaymentHash)
 
 		// Don't force-cancel already accepted invoices. An exception to
 		// this are auto-generated keysend invoices. Because those move
@@ -176,8 +192,6 @@ func (ew *InvoiceExpiryWatcher) cancelNextExpiredInvoice() {
 			log.Errorf("Unable to cancel invoice: %v",
 				top.PaymentHash)
 		}
-
-		ew.expiryQueue.Pop()
 	}
 }
 
@@ -193,9 +207,14 @@ func (ew *InvoiceExpiryWatcher) mainLoop() {
 		pushInvoices := func(invoicesWithExpiry []*invoiceExpiry) {
 			for _, invoiceWithExpiry := range invoicesWithExpiry {
 				// Avoid pushing nil object to the heap.
-				if invoiceWithExpiry != nil {
-					ew.expiryQueue.Push(invoiceWithExpiry)
+				if invoiceWithExpiry == nil {
+					continue
 				}
+
+				ew.expiryQueue.Push(invoiceWithExpiry)
+
+				hash := invoiceWithExpiry.PaymentHash
+				ew.expiries[hash] = invoiceWithExpiry.Expiry
 			}
 		}
 
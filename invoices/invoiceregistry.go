@@ -27,6 +27,11 @@ var (
 	// ErrShuttingDown is returned when an operation failed because the
 	// invoice registry is shutting down.
 	ErrShuttingDown = errors.New("invoice registry shutting down")
+
+	// ErrInvoiceNoApproval is returned when the funds received for an
+	// invoice that doesn't require approval are attempted to be approved
+	// or rejected.
+	ErrInvoiceNoApproval = errors.New("invoice doesn't require approval")
 )
 
 const (
@@ -68,6 +73,18 @@ type RegistryConfig struct {
 	// KeysendHoldTime indicates for how long we want to accept and hold
 	// spontaneous keysend payments.
 	KeysendHoldTime time.Duration
+
+	// MaxHeldHtlcsPerInvoice is the maximum number of htlcs that are held
+	// on a single invoice at the same time. Zero means no limit.
+	MaxHeldHtlcsPerInvoice int
+
+	// MaxHeldHtlcs is the maximum number of htlcs that are held across all
+	// invoices at the same time. Zero means no limit.
+	MaxHeldHtlcs int
+
+	// HeldHtlcLimitPolicy determines what happens when a new htlc would
+	// exceed one of the held htlc limits.
+	HeldHtlcLimitPolicy HeldHtlcLimitPolicy
 }
 
 // htlcReleaseEvent describes an htlc auto-release event. It is used to release
@@ -126,6 +143,10 @@ type InvoiceRegistry struct {
 	// auto-released.
 	htlcAutoReleaseChan chan *htlcReleaseEvent
 
+	// heldHtlcs tracks all htlcs that are currently held, in order to
+	// enforce the held htlc limits.
+	heldHtlcs map[channeldb.CircuitKey]*heldHtlc
+
 	expiryWatcher *InvoiceExpiryWatcher
 
 	wg   sync.WaitGroup
@@ -150,6 +171,7 @@ func NewRegistry(cdb *channeldb.DB, expiryWatcher *InvoiceExpiryWatcher,
 		hodlReverseSubscriptions:  make(map[chan<- interface{}]map[channeldb.CircuitKey]struct{}),
 		cfg:                       cfg,
 		htlcAutoReleaseChan:       make(chan *htlcReleaseEvent),
+		heldHtlcs:                 make(map[channeldb.CircuitKey]*heldHtlc),
 		expiryWatcher:             expiryWatcher,
 		quit:                      make(chan struct{}),
 	}
@@ -377,7 +399,7 @@ func (i *InvoiceRegistry) invoiceEventLoop() {
 func (i *InvoiceRegistry) dispatchToSingleClients(event *invoiceEvent) {
 	// Dispatch to single invoice subscribers.
 	for _, client := range i.singleNotificationClients {
-		if client.invoiceRef.PayHash() != event.hash {
+		if *client.invoiceRef.PayHash() != event.hash {
 			continue
 		}
 
@@ -525,7 +547,7 @@ func (i *InvoiceRegistry) deliverSingleBacklogEvents(
 	}
 
 	err = client.notify(&invoiceEvent{
-		hash:    client.invoiceRef.PayHash(),
+		hash:    *client.invoiceRef.PayHash(),
 		invoice: &invoice,
 	})
 	if err != nil {
@@ -586,12 +608,26 @@ func (i *InvoiceRegistry) LookupInvoice(rHash lntypes.Hash) (channeldb.Invoice,
 	return i.cdb.LookupInvoice(ref)
 }
 
+// LookupInvoiceByRef looks up an invoice by the given reference, which allows
+// invoices to be found by their payment address as well.
+func (i *InvoiceRegistry) LookupInvoiceByRef(
+	ref channeldb.InvoiceRef) (channeldb.Invoice, error) {
+
+	return i.cdb.LookupInvoice(ref)
+}
+
 // startHtlcTimer starts a new timer via the invoice registry main loop that
 // cancels a single htlc on an invoice when the htlc hold duration has passed.
+// If no hold duration is given, the configured default is used.
 func (i *InvoiceRegistry) startHtlcTimer(invoiceRef channeldb.InvoiceRef,
-	key channeldb.CircuitKey, acceptTime time.Time) error {
+	key channeldb.CircuitKey, acceptTime time.Time,
+	holdDuration time.Duration) error {
 
-	releaseTime := acceptTime.Add(i.cfg.HtlcHoldDuration)
+	if holdDuration == 0 {
+		holdDuration = i.cfg.HtlcHoldDuration
+	}
+
+	releaseTime := acceptTime.Add(holdDuration)
 	event := &htlcReleaseEvent{
 		invoiceRef:  invoiceRef,
 		key:         key,
@@ -616,6 +652,15 @@ func (i *InvoiceRegistry) cancelSingleHtlc(invoiceRef channeldb.InvoiceRef,
 	i.Lock()
 	defer i.Unlock()
 
+	return i.cancelSingleHtlcLocked(invoiceRef, key, result)
+}
+
+// cancelSingleHtlcLocked is the internal implementation of cancelSingleHtlc
+// that should be executed inside the registry lock.
+func (i *InvoiceRegistry) cancelSingleHtlcLocked(
+	invoiceRef channeldb.InvoiceRef, key channeldb.CircuitKey,
+	result FailResolutionResult) error {
+
 	updateInvoice := func(invoice *channeldb.Invoice) (
 		*channeldb.InvoiceUpdateDesc, error) {
 
@@ -834,6 +879,7 @@ func (i *InvoiceRegistry) NotifyExitHopHtlc(rHash lntypes.Hash,
 		if r.autoRelease {
 			err := i.startHtlcTimer(
 				ctx.invoiceRef(), circuitKey, r.acceptTime,
+				r.holdDuration,
 			)
 			if err != nil {
 				return nil, err
@@ -866,6 +912,7 @@ func (i *InvoiceRegistry) notifyExitHopHtlcLocked(
 	var (
 		resolution        HtlcResolution
 		updateSubscribers bool
+		eviction          *htlcEviction
 	)
 	invoice, err := i.cdb.UpdateInvoice(
 		ctx.invoiceRef(),
@@ -877,6 +924,21 @@ func (i *InvoiceRegistry) notifyExitHopHtlcLocked(
 				return nil, err
 			}
 
+			// If the htlc is about to be held, make sure the held
+			// htlc limits aren't exceeded. Replayed htlcs have been
+			// held before already.
+			eviction = nil
+			acceptRes, ok := res.(*htlcAcceptResolution)
+			if ok && acceptRes.outcome != resultReplayToAccepted {
+				var failRes *HtlcFailResolution
+				eviction, failRes = i.checkHeldHtlcLimits(
+					ctx, inv, updateDesc,
+				)
+				if failRes != nil {
+					updateDesc, res = nil, failRes
+				}
+			}
+
 			// Only send an update if the invoice state was changed.
 			updateSubscribers = updateDesc != nil &&
 				updateDesc.State != nil
@@ -976,11 +1038,29 @@ func (i *InvoiceRegistry) notifyExitHopHtlcLocked(
 		// Accepted while the invoice is Open.
 		if invoice.State == channeldb.ContractOpen {
 			res.acceptTime = invoiceHtlc.AcceptTime
+			res.holdDuration = invoice.MppTimeout
 			res.autoRelease = true
 
 		}
 
 		i.hodlSubscribe(hodlChan, ctx.circuitKey)
+		i.trackHeldHtlc(ctx, invoice, invoiceHtlc.AcceptTime)
+
+		// If an older htlc needs to make room for this one, cancel it
+		// now that the new htlc is held.
+		if eviction != nil {
+			ctx.log(fmt.Sprintf("canceling htlc %v on invoice %v "+
+				"to stay within held htlc limits",
+				eviction.key, eviction.invoiceRef))
+
+			err := i.cancelSingleHtlcLocked(
+				eviction.invoiceRef, eviction.key,
+				ResultHeldHtlcLimit,
+			)
+			if err != nil {
+				return nil, err
+			}
+		}
 
 	default:
 		panic("unknown action")
@@ -1163,6 +1243,123 @@ func (i *InvoiceRegistry) cancelInvoiceImpl(payHash lntypes.Hash,
 	return nil
 }
 
+// ReissueInvoice replaces the payment request of an open invoice with the
+// passed one, which may carry new route hints and a new expiry. The invoice is
+// watched for the new expiry afterwards.
+func (i *InvoiceRegistry) ReissueInvoice(payHash lntypes.Hash,
+	reissue *channeldb.InvoiceReissueDesc) (*channeldb.Invoice, error) {
+
+	i.Lock()
+
+	ref := channeldb.InvoiceRefByHash(payHash)
+	log.Debugf("Invoice%v: reissuing invoice", ref)
+
+	updateInvoice := func(invoice *channeldb.Invoice) (
+		*channeldb.InvoiceUpdateDesc, error) {
+
+		return &channeldb.InvoiceUpdateDesc{
+			Reissue: reissue,
+		}, nil
+	}
+
+	invoice, err := i.cdb.UpdateInvoice(ref, updateInvoice)
+	if err != nil {
+		i.Unlock()
+		return nil, err
+	}
+
+	log.Debugf("Invoice%v: reissued with expiry %v", ref,
+		invoice.Terms.Expiry)
+
+	i.notifyClients(payHash, invoice, invoice.State)
+	i.Unlock()
+
+	// As with newly added invoices, the expiry watcher must not be called
+	// while holding the registry lock.
+	invoiceExpiryRef := makeInvoiceExpiry(payHash, invoice)
+	if invoiceExpiryRef != nil {
+		i.expiryWatcher.AddInvoices(invoiceExpiryRef)
+	}
+
+	return invoice, nil
+}
+
+// ApproveInvoice releases the funds that were received for an accepted
+// invoice that requires approval, by settling it with its preimage.
+func (i *InvoiceRegistry) ApproveInvoice(payHash lntypes.Hash) error {
+	invoice, err := i.lookupApprovalInvoice(payHash)
+	if err != nil {
+		return err
+	}
+
+	log.Debugf("Invoice%v: approving received funds",
+		channeldb.InvoiceRefByHash(payHash))
+
+	return i.SettleHodlInvoice(*invoice.Terms.PaymentPreimage)
+}
+
+// RejectInvoice rejects the funds that were received for an invoice that
+// requires approval, by canceling it. The htlcs paying to the invoice are
+// canceled back to the sender.
+func (i *InvoiceRegistry) RejectInvoice(payHash lntypes.Hash) error {
+	if _, err := i.lookupApprovalInvoice(payHash); err != nil {
+		return err
+	}
+
+	log.Debugf("Invoice%v: rejecting received funds",
+		channeldb.InvoiceRefByHash(payHash))
+
+	return i.cancelInvoiceImpl(payHash, true)
+}
+
+// lookupApprovalInvoice looks up the invoice with the given payment hash and
+// checks that it requires approval.
+func (i *InvoiceRegistry) lookupApprovalInvoice(
+	payHash lntypes.Hash) (*channeldb.Invoice, error) {
+
+	invoice, err := i.cdb.LookupInvoice(
+		channeldb.InvoiceRefByHash(payHash),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if !invoice.RequireApproval {
+		return nil, ErrInvoiceNoApproval
+	}
+
+	return &invoice, nil
+}
+
+// PendingApprovals returns all invoices that require approval and have been
+// paid, but of which the received funds haven't been approved or rejected yet.
+func (i *InvoiceRegistry) PendingApprovals() ([]channeldb.Invoice, error) {
+	var pending []channeldb.Invoice
+
+	reset := func() {
+		// Zero out our result in case of a retry of the surrounding
+		// transaction.
+		pending = nil
+	}
+
+	scanFunc := func(_ lntypes.Hash, invoice *channeldb.Invoice) error {
+		if invoice.RequireApproval &&
+			invoice.State == channeldb.ContractAccepted {
+
+			pending = append(pending, *invoice)
+		}
+
+		return nil
+	}
+
+	err := i.cdb.ScanInvoices(scanFunc, reset)
+	if err != nil && err != channeldb.ErrNoInvoicesCreated {
+		return nil, err
+	}
+
+	return pending, nil
+}
+
 // notifyClients notifies all currently registered invoice notification clients
 // of a newly added/settled invoice.
 func (i *InvoiceRegistry) notifyClients(hash lntypes.Hash,
@@ -1438,6 +1635,9 @@ func (i *InvoiceRegistry) SubscribeSingleInvoice(
 // notifyHodlSubscribers sends out the htlc resolution to all current
 // subscribers.
 func (i *InvoiceRegistry) notifyHodlSubscribers(htlcResolution HtlcResolution) {
+	// The htlc is resolved, so it is no longer held.
+	delete(i.heldHtlcs, htlcResolution.CircuitKey())
+
 	subscribers, ok := i.hodlSubscriptions[htlcResolution.CircuitKey()]
 	if !ok {
 		return
@@ -30,7 +30,7 @@ func TestSettleInvoice(t *testing.T) {
 	}
 	defer subscription.Cancel()
 
-	if subscription.invoiceRef.PayHash() != testInvoicePaymentHash {
+	if *subscription.invoiceRef.PayHash() != testInvoicePaymentHash {
 		t.Fatalf("expected subscription for provided hash")
 	}
 
@@ -245,7 +245,7 @@ func testCancelInvoice(t *testing.T, gc bool) {
 	}
 	defer subscription.Cancel()
 
-	if subscription.invoiceRef.PayHash() != testInvoicePaymentHash {
+	if *subscription.invoiceRef.PayHash() != testInvoicePaymentHash {
 		t.Fatalf("expected subscription for provided hash")
 	}
 
@@ -405,7 +405,7 @@ func TestSettleHoldInvoice(t *testing.T) {
 	}
 	defer subscription.Cancel()
 
-	if subscription.invoiceRef.PayHash() != testInvoicePaymentHash {
+	if *subscription.invoiceRef.PayHash() != testInvoicePaymentHash {
 		t.Fatalf("expected subscription for provided hash")
 	}
 
@@ -1014,6 +1014,48 @@ func TestMppPayment(t *testing.T) {
 	}
 }
 
+// TestMppTimeoutPerInvoice tests that the htlcs of an incomplete mpp set are
+// released after the timeout set on the invoice rather than the default.
+func TestMppTimeoutPerInvoice(t *testing.T) {
+	defer timeout()()
+
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	// Add an invoice with a timeout shorter than the 30 second default of
+	// the test registry.
+	invoice := *testInvoice
+	invoice.MppTimeout = 10 * time.Second
+
+	_, err := ctx.registry.AddInvoice(&invoice, testInvoicePaymentHash)
+	require.NoError(t, err)
+
+	mppPayload := &mockPayload{
+		mpp: record.NewMPP(testInvoiceAmt, [32]byte{}),
+	}
+
+	hodlChan := make(chan interface{}, 1)
+	resolution, err := ctx.registry.NotifyExitHopHtlc(
+		testInvoicePaymentHash, testInvoice.Terms.Value/2,
+		testHtlcExpiry, testCurrentHeight, getCircuitKey(10), hodlChan,
+		mppPayload,
+	)
+	require.NoError(t, err)
+	require.Nil(t, resolution)
+
+	ctx.clock.SetTime(testTime.Add(10 * time.Second))
+
+	select {
+	case msg := <-hodlChan:
+		failResolution, ok := msg.(*HtlcFailResolution)
+		require.True(t, ok, "expected fail resolution, got %T", msg)
+		require.Equal(t, ResultMppTimeout, failResolution.Outcome)
+
+	case <-time.After(testTimeout):
+		t.Fatal("htlc not released")
+	}
+}
+
 // Tests that invoices are canceled after expiration.
 func TestInvoiceExpiryWithRegistry(t *testing.T) {
 	t.Parallel()
@@ -1116,6 +1158,165 @@ func TestInvoiceExpiryWithRegistry(t *testing.T) {
 	}
 }
 
+// TestReissueInvoice tests that a reissued invoice is only canceled once its
+// new expiry has passed, and that subscribers are notified of the new payment
+// request.
+func TestReissueInvoice(t *testing.T) {
+	defer timeout()()
+
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	preimage := lntypes.Preimage{9}
+	payHash := preimage.Hash()
+	invoice := newTestInvoice(t, preimage, testTime, time.Hour)
+	_, err := ctx.registry.AddInvoice(invoice, payHash)
+	require.NoError(t, err)
+
+	subscription, err := ctx.registry.SubscribeSingleInvoice(payHash)
+	require.NoError(t, err)
+	defer subscription.Cancel()
+
+	// The first update is the current state of the invoice.
+	select {
+	case <-subscription.Updates:
+	case <-time.After(testTimeout):
+		t.Fatal("no initial update received")
+	}
+
+	reissued := newTestInvoice(t, preimage, testTime, 3*time.Hour)
+	_, err = ctx.registry.ReissueInvoice(
+		payHash, &channeldb.InvoiceReissueDesc{
+			PrevPaymentRequest: invoice.PaymentRequest,
+			PaymentRequest:     reissued.PaymentRequest,
+			CreationDate:       testTime,
+			Expiry:             3 * time.Hour,
+		},
+	)
+	require.NoError(t, err)
+
+	select {
+	case update := <-subscription.Updates:
+		require.Equal(t, reissued.PaymentRequest, update.PaymentRequest)
+		require.Equal(t, [][]byte{invoice.PaymentRequest},
+			update.PrevPaymentRequests)
+
+	case <-time.After(testTimeout):
+		t.Fatal("no update for reissued invoice received")
+	}
+
+	// The original expiry has passed, but the invoice must remain open.
+	ctx.clock.SetTime(testTime.Add(2 * time.Hour))
+	time.Sleep(100 * time.Millisecond)
+
+	dbInvoice, err := ctx.registry.LookupInvoice(payHash)
+	require.NoError(t, err)
+	require.Equal(t, channeldb.ContractOpen, dbInvoice.State)
+
+	// Once the new expiry has passed, the invoice is canceled.
+	ctx.clock.SetTime(testTime.Add(4 * time.Hour))
+
+	select {
+	case update := <-subscription.Updates:
+		require.Equal(t, channeldb.ContractCanceled, update.State)
+
+	case <-time.After(testTimeout):
+		t.Fatal("invoice not canceled")
+	}
+}
+
+// TestInvoiceApproval tests that payments to invoices that require approval
+// are held until the received funds are approved or rejected.
+func TestInvoiceApproval(t *testing.T) {
+	defer timeout()()
+
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	// Funds can only be approved for invoices that require it.
+	_, err := ctx.registry.AddInvoice(testInvoice, testInvoicePaymentHash)
+	require.NoError(t, err)
+
+	err = ctx.registry.ApproveInvoice(testInvoicePaymentHash)
+	require.Equal(t, ErrInvoiceNoApproval, err)
+
+	addInvoice := func(preimage lntypes.Preimage) lntypes.Hash {
+		invoice := newTestInvoice(t, preimage, testTime, 0)
+		invoice.RequireApproval = true
+
+		payHash := preimage.Hash()
+		_, err := ctx.registry.AddInvoice(invoice, payHash)
+		require.NoError(t, err)
+
+		return payHash
+	}
+
+	pay := func(payHash lntypes.Hash, htlcID uint64) chan interface{} {
+		hodlChan := make(chan interface{}, 1)
+		resolution, err := ctx.registry.NotifyExitHopHtlc(
+			payHash, testInvoiceAmount, testHtlcExpiry,
+			testCurrentHeight, getCircuitKey(htlcID), hodlChan,
+			testPayload,
+		)
+		require.NoError(t, err)
+		require.Nil(t, resolution, "expected htlc to be held")
+
+		return hodlChan
+	}
+
+	receive := func(hodlChan chan interface{}) interface{} {
+		select {
+		case resolution := <-hodlChan:
+			return resolution
+
+		case <-time.After(testTimeout):
+			t.Fatal("htlc not resolved")
+			return nil
+		}
+	}
+
+	// The funds of an open invoice can't be approved yet.
+	approveHash := addInvoice(lntypes.Preimage{21})
+	err = ctx.registry.ApproveInvoice(approveHash)
+	require.Equal(t, channeldb.ErrInvoiceStillOpen, err)
+
+	approveChan := pay(approveHash, 1)
+
+	rejectHash := addInvoice(lntypes.Preimage{22})
+	rejectChan := pay(rejectHash, 2)
+
+	// Both paid invoices are pending approval.
+	pending, err := ctx.registry.PendingApprovals()
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+
+	// Approving the funds settles the htlc.
+	require.NoError(t, ctx.registry.ApproveInvoice(approveHash))
+
+	settleResolution, ok := receive(approveChan).(*HtlcSettleResolution)
+	require.True(t, ok, "expected settle resolution")
+	require.Equal(t, lntypes.Preimage{21}, settleResolution.Preimage)
+
+	// Rejecting the funds cancels the htlc back.
+	require.NoError(t, ctx.registry.RejectInvoice(rejectHash))
+
+	failResolution, ok := receive(rejectChan).(*HtlcFailResolution)
+	require.True(t, ok, "expected fail resolution")
+	require.Equal(t, ResultCanceled, failResolution.Outcome)
+
+	invoice, err := ctx.registry.LookupInvoice(approveHash)
+	require.NoError(t, err)
+	require.Equal(t, channeldb.ContractSettled, invoice.State)
+
+	invoice, err = ctx.registry.LookupInvoice(rejectHash)
+	require.NoError(t, err)
+	require.Equal(t, channeldb.ContractCanceled, invoice.State)
+
+	pending, err = ctx.registry.PendingApprovals()
+	require.NoError(t, err)
+	require.Empty(t, pending)
+}
+
 // TestOldInvoiceRemovalOnStart tests that we'll attempt to remove old canceled
 // invoices upon start while keeping all settled ones.
 func TestOldInvoiceRemovalOnStart(t *testing.T) {
@@ -1190,3 +1391,172 @@ func TestOldInvoiceRemovalOnStart(t *testing.T) {
 	// registry start.
 	require.Equal(t, expected, response.Invoices)
 }
+
+// TestHeldHtlcLimits tests that the held htlc limits are enforced according
+// to the configured policy.
+func TestHeldHtlcLimits(t *testing.T) {
+	t.Run("reject per invoice", func(t *testing.T) {
+		testHeldHtlcLimits(t, 2, 0, HeldHtlcLimitReject)
+	})
+	t.Run("reject global", func(t *testing.T) {
+		testHeldHtlcLimits(t, 0, 2, HeldHtlcLimitReject)
+	})
+	t.Run("cancel oldest per invoice", func(t *testing.T) {
+		testHeldHtlcLimits(t, 2, 0, HeldHtlcLimitCancelOldest)
+	})
+	t.Run("cancel oldest global", func(t *testing.T) {
+		testHeldHtlcLimits(t, 0, 2, HeldHtlcLimitCancelOldest)
+	})
+}
+
+func testHeldHtlcLimits(t *testing.T, maxPerInvoice, maxHeld int,
+	policy HeldHtlcLimitPolicy) {
+
+	defer timeout()()
+
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	ctx.registry.cfg.MaxHeldHtlcsPerInvoice = maxPerInvoice
+	ctx.registry.cfg.MaxHeldHtlcs = maxHeld
+	ctx.registry.cfg.HeldHtlcLimitPolicy = policy
+
+	_, err := ctx.registry.AddInvoice(testInvoice, testInvoicePaymentHash)
+	require.NoError(t, err)
+
+	mppPayload := &mockPayload{
+		mpp: record.NewMPP(testInvoiceAmt, [32]byte{}),
+	}
+
+	// sendHtlc sends a quarter of the invoice amount, which keeps the
+	// invoice open.
+	sendHtlc := func(htlcID uint64) (chan interface{}, HtlcResolution) {
+		hodlChan := make(chan interface{}, 1)
+		resolution, err := ctx.registry.NotifyExitHopHtlc(
+			testInvoicePaymentHash, testInvoice.Terms.Value/4,
+			testHtlcExpiry, testCurrentHeight,
+			getCircuitKey(htlcID), hodlChan, mppPayload,
+		)
+		require.NoError(t, err)
+
+		return hodlChan, resolution
+	}
+
+	// The first two htlcs are held, with the first one being the oldest.
+	hodlChan1, resolution := sendHtlc(10)
+	require.Nil(t, resolution)
+
+	ctx.clock.SetTime(testTime.Add(time.Second))
+
+	_, resolution = sendHtlc(11)
+	require.Nil(t, resolution)
+
+	// Replays of held htlcs aren't affected by the limits.
+	_, resolution = sendHtlc(10)
+	require.Nil(t, resolution)
+
+	// The third htlc exceeds the limit.
+	_, resolution = sendHtlc(12)
+
+	if policy == HeldHtlcLimitReject {
+		failResolution, ok := resolution.(*HtlcFailResolution)
+		require.True(t, ok, "expected fail resolution, got %T",
+			resolution)
+		require.Equal(t, ResultHeldHtlcLimit, failResolution.Outcome)
+
+		return
+	}
+
+	// With the cancel oldest policy, the new htlc is held and the first
+	// htlc is canceled instead.
+	require.Nil(t, resolution)
+
+	select {
+	case msg := <-hodlChan1:
+		failResolution, ok := msg.(*HtlcFailResolution)
+		require.True(t, ok, "expected fail resolution, got %T", msg)
+		require.Equal(t, ResultHeldHtlcLimit, failResolution.Outcome)
+
+	case <-time.After(testTimeout):
+		t.Fatal("oldest htlc not canceled")
+	}
+
+	inv, err := ctx.registry.LookupInvoice(testInvoicePaymentHash)
+	require.NoError(t, err)
+	require.Equal(t, channeldb.HtlcStateCanceled,
+		inv.Htlcs[getCircuitKey(10)].State)
+	require.Equal(t, channeldb.HtlcStateAccepted,
+		inv.Htlcs[getCircuitKey(12)].State)
+}
+
+// TestCancelHeldHtlc tests that held htlcs can only be canceled individually
+// while their invoice is open.
+func TestCancelHeldHtlc(t *testing.T) {
+	defer timeout()()
+
+	ctx := newTestContext(t)
+	defer ctx.cleanup()
+
+	_, err := ctx.registry.AddInvoice(
+		testHodlInvoice, testInvoicePaymentHash,
+	)
+	require.NoError(t, err)
+
+	mppPayload := &mockPayload{
+		mpp: record.NewMPP(testInvoiceAmt, [32]byte{}),
+	}
+
+	sendHtlc := func(htlcID uint64, amt lnwire.MilliSatoshi) (
+		chan interface{}, HtlcResolution) {
+
+		hodlChan := make(chan interface{}, 1)
+		resolution, err := ctx.registry.NotifyExitHopHtlc(
+			testInvoicePaymentHash, amt, testHtlcExpiry,
+			testCurrentHeight, getCircuitKey(htlcID), hodlChan,
+			mppPayload,
+		)
+		require.NoError(t, err)
+
+		return hodlChan, resolution
+	}
+
+	// Htlcs that aren't held can't be canceled.
+	err = ctx.registry.CancelHeldHtlc(
+		getCircuitKey(10), ResultHtlcHeldTooLong,
+	)
+	require.Equal(t, ErrHtlcNotHeld, err)
+
+	// A part of an incomplete mpp set can be canceled.
+	hodlChan, resolution := sendHtlc(10, testInvoice.Terms.Value/4)
+	require.Nil(t, resolution)
+
+	err = ctx.registry.CancelHeldHtlc(
+		getCircuitKey(10), ResultHtlcHeldTooLong,
+	)
+	require.NoError(t, err)
+
+	select {
+	case msg := <-hodlChan:
+		failResolution, ok := msg.(*HtlcFailResolution)
+		require.True(t, ok, "expected fail resolution, got %T", msg)
+		require.Equal(t, ResultHtlcHeldTooLong, failResolution.Outcome)
+
+	case <-time.After(testTimeout):
+		t.Fatal("held htlc not canceled")
+	}
+
+	err = ctx.registry.CancelHeldHtlc(
+		getCircuitKey(10), ResultHtlcHeldTooLong,
+	)
+	require.Equal(t, ErrHtlcNotHeld, err)
+
+	// Once the set is complete, the hold invoice is accepted and its htlcs
+	// can no longer be canceled individually.
+	_, resolution = sendHtlc(11, testInvoice.Terms.Value)
+	require.Nil(t, resolution)
+
+	err = ctx.registry.CancelHeldHtlc(
+		getCircuitKey(11), ResultHtlcHeldTooLong,
+	)
+	require.Equal(t, ErrHtlcNotCancelable, err)
+}
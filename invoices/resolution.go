@@ -101,6 +101,10 @@ type htlcAcceptResolution struct {
 	// acceptTime is the time at which this htlc was accepted.
 	acceptTime time.Time
 
+	// holdDuration is the time after which the htlc is released if
+	// autoRelease is set. If zero, the registry's default is used.
+	holdDuration time.Duration
+
 	// outcome indicates the outcome of the invoice registry update.
 	outcome acceptResolutionResult
 }
@@ -105,6 +105,15 @@ const (
 	// ResultMppInProgress is returned when we are busy receiving a mpp
 	// payment.
 	ResultMppInProgress
+
+	// ResultHeldHtlcLimit is returned when a htlc is rejected, or an older
+	// htlc is canceled, because the limit of concurrently held htlcs was
+	// reached.
+	ResultHeldHtlcLimit
+
+	// ResultHtlcHeldTooLong is returned when a htlc is canceled because it
+	// was held for longer than the configured htlc hold alarm duration.
+	ResultHtlcHeldTooLong
 )
 
 // String returns a string representation of the result.
@@ -162,6 +171,12 @@ func (f FailResolutionResult) FailureString() string {
 	case ResultMppInProgress:
 		return "mpp reception in progress"
 
+	case ResultHeldHtlcLimit:
+		return "held htlc limit reached"
+
+	case ResultHtlcHeldTooLong:
+		return "htlc held too long"
+
 	default:
 		return "unknown failure resolution result"
 	}
@@ -186,8 +186,9 @@ func updateMpp(ctx *invoiceUpdateCtx,
 	}
 
 	// Check to see if we can settle or this is an hold invoice and
-	// we need to wait for the preimage.
-	if inv.HodlInvoice {
+	// we need to wait for the preimage. Invoices that require approval
+	// are held until the received funds are approved.
+	if inv.HodlInvoice || inv.RequireApproval {
 		update.State = &channeldb.InvoiceStateUpdateDesc{
 			NewState: channeldb.ContractAccepted,
 		}
@@ -273,8 +274,9 @@ func updateLegacy(ctx *invoiceUpdateCtx,
 	}
 
 	// Check to see if we can settle or this is an hold invoice and we need
-	// to wait for the preimage.
-	if inv.HodlInvoice {
+	// to wait for the preimage. Invoices that require approval are held
+	// until the received funds are approved.
+	if inv.HodlInvoice || inv.RequireApproval {
 		update.State = &channeldb.InvoiceStateUpdateDesc{
 			NewState: channeldb.ContractAccepted,
 		}
@@ -15,6 +15,7 @@ import (
 	"github.com/btcsuite/btcwallet/waddrmgr"
 	"github.com/btcsuite/btcwallet/wallet"
 	"github.com/btcsuite/btcwallet/walletdb"
+	"github.com/cryptomeow/lnd/channeldb/kvdb"
 	"github.com/davecgh/go-spew/spew"
 
 	_ "github.com/btcsuite/btcwallet/walletdb/bdb" // Required in order to create the default database.
@@ -62,7 +63,9 @@ func createTestBtcWallet(coinType uint32) (func(), *wallet.Wallet, error) {
 	if err != nil {
 		return nil, nil, err
 	}
-	loader := wallet.NewLoader(&chaincfg.SimNetParams, tempDir, true, 0)
+	loader := wallet.NewLoader(
+		&chaincfg.SimNetParams, tempDir, true, kvdb.DefaultDBTimeout, 0,
+	)
 
 	pass := []byte("test")
 
@@ -0,0 +1,240 @@
+package keychain
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/btcsuite/btcwallet/walletdb"
+)
+
+const (
+	// FirstReservableKeyFamily is the first key family that can be
+	// reserved by external applications. All families below it are kept
+	// for lnd's own derivations, including those added in the future.
+	FirstReservableKeyFamily KeyFamily = 1 << 16
+
+	// MaxKeyFamily is the last key family that can be derived. Key
+	// families are hardened BIP43 accounts, so their index must fit into
+	// 31 bits.
+	MaxKeyFamily KeyFamily = (1 << 31) - 1
+)
+
+var (
+	// keyFamilyReservationsBucket is the top-level bucket of the wallet
+	// database that stores all key family reservations, keyed by the name
+	// of their owner.
+	keyFamilyReservationsBucket = []byte("lnd-key-family-reservations")
+
+	// ErrKeyFamilyReserved is returned when a key family range overlaps
+	// with the range reserved by another owner.
+	ErrKeyFamilyReserved = errors.New("key family range overlaps with " +
+		"an existing reservation")
+
+	// ErrReservationNotFound is returned when releasing the key families
+	// of an owner that has no reservation.
+	ErrReservationNotFound = errors.New("key family reservation not found")
+)
+
+// KeyFamilyReservation is a range of key families that is reserved for the
+// exclusive use of an application, so that its derivations don't collide
+// with those of lnd or other applications deriving keys from the same seed.
+type KeyFamilyReservation struct {
+	// Owner is the unique name of the application holding the
+	// reservation.
+	Owner string
+
+	// First is the first key family of the reserved range.
+	First KeyFamily
+
+	// Last is the last key family of the reserved range, inclusive.
+	Last KeyFamily
+}
+
+// Contains returns true if the key family is part of the reserved range.
+func (r *KeyFamilyReservation) Contains(keyFam KeyFamily) bool {
+	return keyFam >= r.First && keyFam <= r.Last
+}
+
+// overlaps returns true if both reservations share at least one key family.
+func (r *KeyFamilyReservation) overlaps(other *KeyFamilyReservation) bool {
+	return r.First <= other.Last && other.First <= r.Last
+}
+
+// KeyFamilyRegistry keeps track of the key family ranges reserved by external
+// applications.
+type KeyFamilyRegistry interface {
+	// ReserveKeyFamilies reserves the given range of key families for the
+	// owner. Reserving the exact range an owner already holds has no
+	// effect, while any other overlap with an existing reservation fails
+	// with ErrKeyFamilyReserved.
+	ReserveKeyFamilies(owner string, first, last KeyFamily) (
+		*KeyFamilyReservation, error)
+
+	// ReleaseKeyFamilies releases the key families reserved by the owner.
+	ReleaseKeyFamilies(owner string) error
+
+	// KeyFamilyReservations returns all reservations, ordered by their
+	// first key family.
+	KeyFamilyReservations() ([]*KeyFamilyReservation, error)
+}
+
+// WalletKeyFamilyRegistry is a KeyFamilyRegistry that persists the
+// reservations within the database of the wallet that derives the keys, so
+// they're kept together with the seed they apply to.
+type WalletKeyFamilyRegistry struct {
+	db walletdb.DB
+}
+
+// NewWalletKeyFamilyRegistry creates a new key family registry backed by the
+// given wallet database.
+func NewWalletKeyFamilyRegistry(db walletdb.DB) *WalletKeyFamilyRegistry {
+	return &WalletKeyFamilyRegistry{
+		db: db,
+	}
+}
+
+// ReserveKeyFamilies reserves the given range of key families for the owner.
+//
+// NOTE: This is part of the KeyFamilyRegistry interface.
+func (w *WalletKeyFamilyRegistry) ReserveKeyFamilies(owner string, first,
+	last KeyFamily) (*KeyFamilyReservation, error) {
+
+	switch {
+	case owner == "":
+		return nil, fmt.Errorf("reservation owner must be set")
+
+	case first > last:
+		return nil, fmt.Errorf("first key family %v must not be above "+
+			"last key family %v", first, last)
+
+	case first < FirstReservableKeyFamily:
+		return nil, fmt.Errorf("key families below %v are reserved "+
+			"for lnd", FirstReservableKeyFamily)
+
+	case last > MaxKeyFamily:
+		return nil, fmt.Errorf("key families above %v can't be "+
+			"derived", MaxKeyFamily)
+	}
+
+	reservation := &KeyFamilyReservation{
+		Owner: owner,
+		First: first,
+		Last:  last,
+	}
+
+	err := walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) error {
+		bucket, err := tx.CreateTopLevelBucket(
+			keyFamilyReservationsBucket,
+		)
+		if err != nil {
+			return err
+		}
+
+		existing, err := fetchReservations(bucket)
+		if err != nil {
+			return err
+		}
+
+		for _, other := range existing {
+			if other.Owner == owner {
+				if *other == *reservation {
+					return nil
+				}
+
+				return fmt.Errorf("owner %v already reserved "+
+					"key families %v-%v", owner,
+					other.First, other.Last)
+			}
+
+			if reservation.overlaps(other) {
+				return ErrKeyFamilyReserved
+			}
+		}
+
+		var v [8]byte
+		binary.BigEndian.PutUint32(v[:4], uint32(first))
+		binary.BigEndian.PutUint32(v[4:], uint32(last))
+
+		return bucket.Put([]byte(owner), v[:])
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reservation, nil
+}
+
+// ReleaseKeyFamilies releases the key families reserved by the owner.
+//
+// NOTE: This is part of the KeyFamilyRegistry interface.
+func (w *WalletKeyFamilyRegistry) ReleaseKeyFamilies(owner string) error {
+	return walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) error {
+		bucket := tx.ReadWriteBucket(keyFamilyReservationsBucket)
+		if bucket == nil || bucket.Get([]byte(owner)) == nil {
+			return ErrReservationNotFound
+		}
+
+		return bucket.Delete([]byte(owner))
+	})
+}
+
+// KeyFamilyReservations returns all reservations, ordered by their first key
+// family.
+//
+// NOTE: This is part of the KeyFamilyRegistry interface.
+func (w *WalletKeyFamilyRegistry) KeyFamilyReservations() (
+	[]*KeyFamilyReservation, error) {
+
+	var reservations []*KeyFamilyReservation
+	err := walletdb.View(w.db, func(tx walletdb.ReadTx) error {
+		bucket := tx.ReadBucket(keyFamilyReservationsBucket)
+		if bucket == nil {
+			return nil
+		}
+
+		var err error
+		reservations, err = fetchReservations(bucket)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(reservations, func(i, j int) bool {
+		return reservations[i].First < reservations[j].First
+	})
+
+	return reservations, nil
+}
+
+// fetchReservations reads all reservations stored in the bucket.
+func fetchReservations(
+	bucket walletdb.ReadBucket) ([]*KeyFamilyReservation, error) {
+
+	var reservations []*KeyFamilyReservation
+	err := bucket.ForEach(func(k, v []byte) error {
+		if len(v) != 8 {
+			return fmt.Errorf("invalid key family reservation of "+
+				"owner %s", k)
+		}
+
+		reservations = append(reservations, &KeyFamilyReservation{
+			Owner: string(k),
+			First: KeyFamily(binary.BigEndian.Uint32(v[:4])),
+			Last:  KeyFamily(binary.BigEndian.Uint32(v[4:])),
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reservations, nil
+}
+
+// A compile-time check to ensure WalletKeyFamilyRegistry meets the
+// KeyFamilyRegistry interface.
+var _ KeyFamilyRegistry = (*WalletKeyFamilyRegistry)(nil)
@@ -0,0 +1,121 @@
+package keychain
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/btcsuite/btcwallet/walletdb"
+	"github.com/cryptomeow/lnd/channeldb/kvdb"
+)
+
+// TestWalletKeyFamilyRegistry tests that key family ranges can be reserved,
+// listed and released, and that overlapping ranges are rejected.
+func TestWalletKeyFamilyRegistry(t *testing.T) {
+	t.Parallel()
+
+	tempDir, err := ioutil.TempDir("", "keyfam-registry")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	db, err := walletdb.Create(
+		"bdb", filepath.Join(tempDir, "wallet.db"), true,
+		kvdb.DefaultDBTimeout,
+	)
+	if err != nil {
+		t.Fatalf("unable to create db: %v", err)
+	}
+	defer db.Close()
+
+	registry := NewWalletKeyFamilyRegistry(db)
+
+	// Nothing is reserved in a fresh database.
+	reservations, err := registry.KeyFamilyReservations()
+	if err != nil {
+		t.Fatalf("unable to fetch reservations: %v", err)
+	}
+	if len(reservations) != 0 {
+		t.Fatalf("expected no reservations, got %v", len(reservations))
+	}
+
+	first := FirstReservableKeyFamily
+
+	// Invalid ranges are rejected.
+	invalid := []struct {
+		owner       string
+		first, last KeyFamily
+	}{
+		{"", first, first},
+		{"app", first + 1, first},
+		{"app", KeyFamilyNodeKey, first},
+		{"app", first, MaxKeyFamily + 1},
+	}
+	for _, test := range invalid {
+		_, err := registry.ReserveKeyFamilies(
+			test.owner, test.first, test.last,
+		)
+		if err == nil {
+			t.Fatalf("expected reservation %v %v-%v to fail",
+				test.owner, test.first, test.last)
+		}
+	}
+
+	_, err = registry.ReserveKeyFamilies("b", first+10, first+19)
+	if err != nil {
+		t.Fatalf("unable to reserve key families: %v", err)
+	}
+	_, err = registry.ReserveKeyFamilies("a", first, first+9)
+	if err != nil {
+		t.Fatalf("unable to reserve key families: %v", err)
+	}
+
+	// Reserving the same range again is allowed, but any other range for
+	// the same owner or overlapping another owner is not.
+	_, err = registry.ReserveKeyFamilies("a", first, first+9)
+	if err != nil {
+		t.Fatalf("unable to repeat reservation: %v", err)
+	}
+	_, err = registry.ReserveKeyFamilies("a", first, first+5)
+	if err == nil {
+		t.Fatalf("expected second reservation of owner to fail")
+	}
+	_, err = registry.ReserveKeyFamilies("c", first+19, first+30)
+	if err != ErrKeyFamilyReserved {
+		t.Fatalf("expected ErrKeyFamilyReserved, got %v", err)
+	}
+
+	reservations, err = registry.KeyFamilyReservations()
+	if err != nil {
+		t.Fatalf("unable to fetch reservations: %v", err)
+	}
+	expected := []KeyFamilyReservation{
+		{Owner: "a", First: first, Last: first + 9},
+		{Owner: "b", First: first + 10, Last: first + 19},
+	}
+	if len(reservations) != len(expected) {
+		t.Fatalf("expected %v reservations, got %v", len(expected),
+			len(reservations))
+	}
+	for i, reservation := range reservations {
+		if *reservation != expected[i] {
+			t.Fatalf("expected reservation %v, got %v",
+				expected[i], *reservation)
+		}
+	}
+
+	// Once released, the range can be reserved by another owner.
+	if err := registry.ReleaseKeyFamilies("b"); err != nil {
+		t.Fatalf("unable to release key families: %v", err)
+	}
+	err = registry.ReleaseKeyFamilies("b")
+	if err != ErrReservationNotFound {
+		t.Fatalf("expected ErrReservationNotFound, got %v", err)
+	}
+	_, err = registry.ReserveKeyFamilies("c", first+19, first+30)
+	if err != nil {
+		t.Fatalf("unable to reserve released key families: %v", err)
+	}
+}
@@ -12,11 +12,19 @@
 // For version 0 we have the following optional data fields defined:
 // - shortchanid: the short channel ID that a transaction is associated with,
 //   with its value set to the uint64 short channel id.
+// - chanpoint: the channel point of the channel that a transaction is
+//   associated with, used when the short channel ID is not known or the
+//   channel was never confirmed.
 package labels
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
 	"github.com/btcsuite/btcwallet/wtxmgr"
 	"github.com/cryptomeow/lnd/lnwire"
 )
@@ -68,6 +76,10 @@ const (
 
 	// LabelTypeSweepTransaction is used to label sweeps.
 	LabelTypeSweepTransaction LabelType = "sweep"
+
+	// LabelTypeConsolidationSweep is used to label sweeps of matured
+	// time-locked outputs into the consolidation address set.
+	LabelTypeConsolidationSweep LabelType = "consolidationsweep"
 )
 
 // LabelField is used to tag a value within a label.
@@ -76,6 +88,9 @@ type LabelField string
 const (
 	// ShortChanID is used to tag short channel id values in our labels.
 	ShortChanID LabelField = "shortchanid"
+
+	// ChanPoint is used to tag channel point values in our labels.
+	ChanPoint LabelField = "chanpoint"
 )
 
 // MakeLabel creates a label with the provided type and short channel id. If
@@ -90,3 +105,95 @@ func MakeLabel(labelType LabelType, channelID *lnwire.ShortChannelID) string {
 	return fmt.Sprintf("%v:%v:%v-%v", LabelVersionZero, labelType,
 		ShortChanID, channelID.ToUint64())
 }
+
+// MakeChanPointLabel creates a label with the provided type and channel point.
+// If the channel point is not known, we simply return version:label_type.
+// Otherwise the label will also contain its value: chanpoint-{txid:index}.
+func MakeChanPointLabel(labelType LabelType, chanPoint *wire.OutPoint) string {
+	if chanPoint == nil {
+		return MakeLabel(labelType, nil)
+	}
+
+	return fmt.Sprintf("%v:%v:%v-%v", LabelVersionZero, labelType,
+		ChanPoint, chanPoint)
+}
+
+// ErrUnknownLabel is returned when a label wasn't created by lnd, or has an
+// unknown version.
+var ErrUnknownLabel = errors.New("unknown label format")
+
+// Label holds the parsed fields of a label of a transaction broadcast by lnd.
+type Label struct {
+	// Type is the type of the labelled transaction.
+	Type LabelType
+
+	// ShortChanID is the short channel id of the channel the transaction
+	// is associated with, if present in the label.
+	ShortChanID *lnwire.ShortChannelID
+
+	// ChanPoint is the channel point of the channel the transaction is
+	// associated with, if present in the label.
+	ChanPoint *wire.OutPoint
+}
+
+// ParseLabel parses a label created by MakeLabel or MakeChanPointLabel.
+// ErrUnknownLabel is returned for labels that weren't created by lnd, such as
+// the labels of transactions published through the api.
+func ParseLabel(label string) (*Label, error) {
+	parts := strings.Split(label, ":")
+	if len(parts) < 2 || parts[0] != fmt.Sprint(LabelVersionZero) {
+		return nil, ErrUnknownLabel
+	}
+
+	parsed := &Label{
+		Type: LabelType(parts[1]),
+	}
+
+	// The remaining parts are optional fields. As a channel point contains
+	// a colon itself, its output index is held in the part that follows
+	// it.
+	for i := 2; i < len(parts); i++ {
+		field := strings.SplitN(parts[i], "-", 2)
+		if len(field) != 2 {
+			return nil, ErrUnknownLabel
+		}
+
+		switch LabelField(field[0]) {
+		case ShortChanID:
+			chanID, err := strconv.ParseUint(field[1], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid short channel "+
+					"id: %v", err)
+			}
+
+			scid := lnwire.NewShortChanIDFromInt(chanID)
+			parsed.ShortChanID = &scid
+
+		case ChanPoint:
+			if i+1 >= len(parts) {
+				return nil, ErrUnknownLabel
+			}
+			i++
+
+			hash, err := chainhash.NewHashFromStr(field[1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid channel "+
+					"point: %v", err)
+			}
+
+			index, err := strconv.ParseUint(parts[i], 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid channel "+
+					"point: %v", err)
+			}
+
+			parsed.ChanPoint = wire.NewOutPoint(hash, uint32(index))
+
+		// Unknown fields are skipped, so that fields added in the
+		// future don't break parsing.
+		default:
+		}
+	}
+
+	return parsed, nil
+}
@@ -0,0 +1,136 @@
+package labels
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/cryptomeow/lnd/lnwire"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMakeLabel tests the creation of labels with and without the optional
+// channel fields.
+func TestMakeLabel(t *testing.T) {
+	t.Parallel()
+
+	chanID := lnwire.NewShortChanIDFromInt(123)
+	chanPoint := &wire.OutPoint{
+		Hash:  chainhash.Hash{1},
+		Index: 2,
+	}
+
+	tests := []struct {
+		name     string
+		label    string
+		expected string
+	}{
+		{
+			name:     "no short channel id",
+			label:    MakeLabel(LabelTypeChannelOpen, nil),
+			expected: "0:openchannel",
+		},
+		{
+			name:     "short channel id",
+			label:    MakeLabel(LabelTypeChannelClose, &chanID),
+			expected: "0:closechannel:shortchanid-123",
+		},
+		{
+			name: "no channel point",
+			label: MakeChanPointLabel(
+				LabelTypeJusticeTransaction, nil,
+			),
+			expected: "0:justicetx",
+		},
+		{
+			name: "channel point",
+			label: MakeChanPointLabel(
+				LabelTypeSweepTransaction, chanPoint,
+			),
+			expected: "0:sweep:chanpoint-" + chanPoint.String(),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, test.label)
+		})
+	}
+}
+
+// TestParseLabel tests that labels created by lnd are parsed back into their
+// fields, and that other labels are rejected.
+func TestParseLabel(t *testing.T) {
+	t.Parallel()
+
+	chanID := lnwire.NewShortChanIDFromInt(123)
+	chanPoint := &wire.OutPoint{
+		Hash:  chainhash.Hash{1},
+		Index: 2,
+	}
+
+	tests := []struct {
+		name     string
+		label    string
+		expected *Label
+		err      bool
+	}{
+		{
+			name:  "type only",
+			label: MakeLabel(LabelTypeChannelOpen, nil),
+			expected: &Label{
+				Type: LabelTypeChannelOpen,
+			},
+		},
+		{
+			name:  "short channel id",
+			label: MakeLabel(LabelTypeChannelClose, &chanID),
+			expected: &Label{
+				Type:        LabelTypeChannelClose,
+				ShortChanID: &chanID,
+			},
+		},
+		{
+			name: "channel point",
+			label: MakeChanPointLabel(
+				LabelTypeSweepTransaction, chanPoint,
+			),
+			expected: &Label{
+				Type:      LabelTypeSweepTransaction,
+				ChanPoint: chanPoint,
+			},
+		},
+		{
+			name:  "external label",
+			label: External,
+			err:   true,
+		},
+		{
+			name:  "unknown version",
+			label: "1:sweep",
+			err:   true,
+		},
+		{
+			name:  "truncated channel point",
+			label: "0:sweep:chanpoint-" + chanPoint.Hash.String(),
+			err:   true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			label, err := ParseLabel(test.label)
+			if test.err {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.expected, label)
+		})
+	}
+}
@@ -11,4 +11,5 @@ type AutoPilot struct {
 	Private        bool               `long:"private" description:"Whether the channels created by the autopilot agent should be private or not. Private channels won't be announced to the network."`
 	MinConfs       int32              `long:"minconfs" description:"The minimum number of confirmations each of your inputs in funding transactions created by the autopilot agent must have."`
 	ConfTarget     uint32             `long:"conftarget" description:"The confirmation target (in blocks) for channels opened by autopilot."`
+	MaxOpensPerDay uint32             `long:"maxopensperday" description:"The maximum number of channels the autopilot agent should open within any 24 hour window. 0 means no limit."`
 }
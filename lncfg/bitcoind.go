@@ -10,4 +10,6 @@ type Bitcoind struct {
 	ZMQPubRawBlock string `long:"zmqpubrawblock" description:"The address listening for ZMQ connections to deliver raw block notifications"`
 	ZMQPubRawTx    string `long:"zmqpubrawtx" description:"The address listening for ZMQ connections to deliver raw transaction notifications"`
 	EstimateMode   string `long:"estimatemode" description:"The fee estimate mode. Must be either ECONOMICAL or CONSERVATIVE."`
+
+	Backups []string `long:"backup" description:"A backup node that chain notifications fail over to if the chain backend health check fails, in the format rpchost,zmqpubrawblock,zmqpubrawtx. The backup must accept the same RPC credentials. Can be specified multiple times."`
 }
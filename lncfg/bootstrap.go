@@ -0,0 +1,62 @@
+package lncfg
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Bootstrap holds the configuration of the DNS seeds used to bootstrap peers.
+type Bootstrap struct {
+	DNSSeeds []string `long:"dnsseed" description:"An additional DNS seed to bootstrap peers from, in the form <seed>[,<soa-shim>]. The optional SOA shim is used to query the authoritative name server of the seed directly if the regular lookup fails. Can be set multiple times."`
+
+	DoHURL string `long:"dohurl" description:"The https URL of a DNS-over-HTTPS resolver that DNS seeds are queried with instead of the system resolver. If Tor is active, the resolver is reached through the proxy, which allows Tor-only nodes to bootstrap without leaking DNS queries to the clearnet."`
+}
+
+// Validate checks that the DNS seeds can be parsed and that the DNS-over-HTTPS
+// resolver URL is valid.
+func (b *Bootstrap) Validate() error {
+	if _, err := b.ParseDNSSeeds(); err != nil {
+		return err
+	}
+
+	if b.DoHURL == "" {
+		return nil
+	}
+
+	u, err := url.Parse(b.DoHURL)
+	if err != nil {
+		return fmt.Errorf("invalid bootstrap.dohurl: %v", err)
+	}
+	if u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("bootstrap.dohurl %v must be an https URL",
+			b.DoHURL)
+	}
+
+	return nil
+}
+
+// ParseDNSSeeds parses the configured DNS seeds into tuples of the seed and
+// its optional SOA shim.
+func (b *Bootstrap) ParseDNSSeeds() ([][2]string, error) {
+	seeds := make([][2]string, 0, len(b.DNSSeeds))
+	for _, seed := range b.DNSSeeds {
+		parts := strings.Split(seed, ",")
+		if len(parts) > 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("invalid bootstrap.dnsseed %q, "+
+				"expected <seed>[,<soa-shim>]", seed)
+		}
+
+		var tuple [2]string
+		for i, part := range parts {
+			tuple[i] = strings.TrimSpace(part)
+		}
+		seeds = append(seeds, tuple)
+	}
+
+	return seeds, nil
+}
+
+// Compile-time constraint to ensure Bootstrap implements the Validator
+// interface.
+var _ Validator = (*Bootstrap)(nil)
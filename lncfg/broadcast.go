@@ -0,0 +1,76 @@
+package lncfg
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+// DefaultBroadcastTimeout is the default timeout of a single broadcast attempt
+// through an alternate backend.
+const DefaultBroadcastTimeout = 30 * time.Second
+
+// Broadcast holds the configuration of the alternate backends that sweep and
+// justice transactions are published through in addition to the chain
+// backend.
+type Broadcast struct {
+	Bitcoind []string `long:"bitcoind" description:"An additional bitcoind node that sweep and justice transactions are sent to, in the format rpcuser:rpcpass@rpchost:rpcport. Can be specified multiple times."`
+
+	Peers []string `long:"peer" description:"A bitcoin P2P node that sweep and justice transactions are announced to, in the format host:port. Connections to these nodes are only made through Tor, so tor.active must be set. Can be specified multiple times."`
+
+	Timeout time.Duration `long:"timeout" description:"The timeout of a single broadcast attempt through an alternate backend."`
+}
+
+// ParseBroadcastBitcoind parses an additional bitcoind node given in the
+// format rpcuser:rpcpass@rpchost:rpcport.
+func ParseBroadcastBitcoind(node string) (string, string, string, error) {
+	errFormat := errors.New("invalid broadcast bitcoind node, expected " +
+		"format rpcuser:rpcpass@rpchost:rpcport")
+
+	at := strings.LastIndex(node, "@")
+	if at == -1 {
+		return "", "", "", errFormat
+	}
+
+	creds, host := node[:at], node[at+1:]
+	colon := strings.Index(creds, ":")
+	if colon == -1 {
+		return "", "", "", errFormat
+	}
+
+	if _, _, err := net.SplitHostPort(host); err != nil {
+		return "", "", "", errFormat
+	}
+
+	return creds[:colon], creds[colon+1:], host, nil
+}
+
+// Validate checks that the alternate backends can be parsed and that the
+// timeout is sane.
+func (b *Broadcast) Validate() error {
+	for _, node := range b.Bitcoind {
+		if _, _, _, err := ParseBroadcastBitcoind(node); err != nil {
+			return err
+		}
+	}
+
+	for _, peer := range b.Peers {
+		if _, _, err := net.SplitHostPort(peer); err != nil {
+			return fmt.Errorf("invalid broadcast peer %v: %v", peer,
+				err)
+		}
+	}
+
+	if b.Timeout <= 0 {
+		return fmt.Errorf("broadcast timeout must be positive, got %v",
+			b.Timeout)
+	}
+
+	return nil
+}
+
+// Compile-time constraint to ensure Broadcast implements the Validator
+// interface.
+var _ Validator = (*Broadcast)(nil)
@@ -8,4 +8,6 @@ type Btcd struct {
 	RPCPass    string `long:"rpcpass" default-mask:"-" description:"Password for RPC connections"`
 	RPCCert    string `long:"rpccert" description:"File containing the daemon's certificate file"`
 	RawRPCCert string `long:"rawrpccert" description:"The raw bytes of the daemon's PEM-encoded certificate chain which will be used to authenticate the RPC connection."`
+
+	BackupRPCHosts []string `long:"backuprpchost" description:"The rpc listening address of a backup node that chain notifications fail over to if the chain backend health check fails. The backup must accept the same RPC credentials and certificate. Can be specified multiple times."`
 }
@@ -3,6 +3,7 @@ package lncfg
 import (
 	"fmt"
 
+	"github.com/btcsuite/btcutil"
 	"github.com/cryptomeow/lnd/lnwire"
 )
 
@@ -17,8 +18,15 @@ type Chain struct {
 	TestNet3 bool `long:"testnet" description:"Use the test network"`
 	SimNet   bool `long:"simnet" description:"Use the simulation test network"`
 	RegTest  bool `long:"regtest" description:"Use the regression test network"`
+	SigNet   bool `long:"signet" description:"Use the signet test network"`
+
+	SigNetChallenge string `long:"signetchallenge" description:"The hex encoded challenge script of a custom signet network to connect to instead of the global default signet. Only used if signet is set."`
 
 	DefaultNumChanConfs int                 `long:"defaultchanconfs" description:"The default number of confirmations a channel must have before it's considered open. If this is not set, we will scale the value according to the channel size."`
+	MinChanConfs        uint16              `long:"minchanconfs" description:"The number of confirmations we require for incoming channels with a stake of at most minchanconfsamt. Only used if defaultchanconfs is not set."`
+	MaxChanConfs        uint16              `long:"maxchanconfs" description:"The number of confirmations we require for incoming channels with a stake of at least maxchanconfsamt. Only used if defaultchanconfs is not set."`
+	MinChanConfsAmt     btcutil.Amount      `long:"minchanconfsamt" description:"The stake in satoshis (channel capacity plus pushed amount) up to which incoming channels require minchanconfs confirmations. The number of confirmations is scaled linearly for stakes between minchanconfsamt and maxchanconfsamt. If this is not set, half of the maximum channel size is used."`
+	MaxChanConfsAmt     btcutil.Amount      `long:"maxchanconfsamt" description:"The stake in satoshis (channel capacity plus pushed amount) from which on incoming channels require maxchanconfs confirmations. If this is not set, the maximum channel size is used."`
 	DefaultRemoteDelay  int                 `long:"defaultremotedelay" description:"The default number of blocks we will require our channel counterparty to wait before accessing its funds in case of unilateral close. If this is not set, we will scale the value according to the channel size."`
 	MaxLocalDelay       uint16              `long:"maxlocaldelay" description:"The maximum blocks we will allow our funds to be timelocked before accessing its funds in case of unilateral close. If a peer proposes a value greater than this, we will reject the channel."`
 	MinHTLCIn           lnwire.MilliSatoshi `long:"minhtlc" description:"The smallest HTLC we are willing to accept on our channels, in millisatoshi"`
@@ -28,13 +36,9 @@ type Chain struct {
 	TimeLockDelta       uint32              `long:"timelockdelta" description:"The CLTV delta we will subtract from a forwarded HTLC's timelock value"`
 }
 
-// Validate performs validation on our chain config.
-func (c *Chain) Validate(minTimeLockDelta uint32, minDelay uint16) error {
-	if c.TimeLockDelta < minTimeLockDelta {
-		return fmt.Errorf("timelockdelta must be at least %v",
-			minTimeLockDelta)
-	}
-
+// Validate performs validation on our chain config. The CLTV deltas are
+// validated separately, as they depend on the block interval of the chain.
+func (c *Chain) Validate(minDelay uint16) error {
 	// Check that our max local delay isn't set below some reasonable
 	// minimum value. We do this to prevent setting an unreasonably low
 	// delay, which would mean that the node would accept no channels.
@@ -43,5 +47,63 @@ func (c *Chain) Validate(minTimeLockDelta uint32, minDelay uint16) error {
 			minDelay)
 	}
 
+	if c.MinChanConfs == 0 {
+		return fmt.Errorf("minchanconfs must be at least 1")
+	}
+	if c.MinChanConfs > c.MaxChanConfs {
+		return fmt.Errorf("minchanconfs (%v) must not be greater "+
+			"than maxchanconfs (%v)", c.MinChanConfs,
+			c.MaxChanConfs)
+	}
+
+	// The amount thresholds can only be checked against each other if
+	// both are set, as unset values are derived from the maximum channel
+	// size.
+	if c.MinChanConfsAmt < 0 || c.MaxChanConfsAmt < 0 {
+		return fmt.Errorf("minchanconfsamt and maxchanconfsamt must " +
+			"not be negative")
+	}
+	if c.MaxChanConfsAmt != 0 && c.MinChanConfsAmt >= c.MaxChanConfsAmt {
+		return fmt.Errorf("minchanconfsamt (%v) must be less than "+
+			"maxchanconfsamt (%v)", c.MinChanConfsAmt,
+			c.MaxChanConfsAmt)
+	}
+
 	return nil
 }
+
+// NumRequiredConfs returns the number of confirmations we require before an
+// incoming channel with the given stake is considered open. Stakes up to
+// MinChanConfsAmt require MinChanConfs confirmations, stakes of at least
+// MaxChanConfsAmt require MaxChanConfs confirmations, and the value is scaled
+// linearly for the stakes in between. Unset amount thresholds are derived from
+// the given maximum channel size.
+func (c *Chain) NumRequiredConfs(stake lnwire.MilliSatoshi,
+	maxChanSize btcutil.Amount) uint16 {
+
+	maxAmt := c.MaxChanConfsAmt
+	if maxAmt == 0 {
+		maxAmt = maxChanSize
+	}
+	minAmt := c.MinChanConfsAmt
+	if minAmt == 0 {
+		minAmt = maxAmt / 2
+	}
+
+	minStake := lnwire.NewMSatFromSatoshis(minAmt)
+	maxStake := lnwire.NewMSatFromSatoshis(maxAmt)
+
+	switch {
+	case stake <= minStake:
+		return c.MinChanConfs
+
+	case stake >= maxStake:
+		return c.MaxChanConfs
+	}
+
+	confRange := uint64(c.MaxChanConfs - c.MinChanConfs)
+	scaled := confRange * uint64(stake-minStake) /
+		uint64(maxStake-minStake)
+
+	return c.MinChanConfs + uint16(scaled)
+}
@@ -0,0 +1,95 @@
+package lncfg_test
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/cryptomeow/lnd/lncfg"
+	"github.com/cryptomeow/lnd/lnwire"
+)
+
+// TestNumRequiredConfs asserts that the number of confirmations required for
+// a channel is scaled linearly between the configured amount thresholds.
+func TestNumRequiredConfs(t *testing.T) {
+	const maxChanSize = btcutil.Amount(1000)
+
+	tests := []struct {
+		name     string
+		cfg      *lncfg.Chain
+		stake    btcutil.Amount
+		expected uint16
+	}{
+		{
+			name: "default thresholds below min",
+			cfg: &lncfg.Chain{
+				MinChanConfs: 3,
+				MaxChanConfs: 6,
+			},
+			stake:    100,
+			expected: 3,
+		},
+		{
+			name: "default thresholds scaled",
+			cfg: &lncfg.Chain{
+				MinChanConfs: 3,
+				MaxChanConfs: 6,
+			},
+			stake:    700,
+			expected: 4,
+		},
+		{
+			name: "default thresholds at max",
+			cfg: &lncfg.Chain{
+				MinChanConfs: 3,
+				MaxChanConfs: 6,
+			},
+			stake:    maxChanSize,
+			expected: 6,
+		},
+		{
+			name: "custom thresholds at min",
+			cfg: &lncfg.Chain{
+				MinChanConfs:    1,
+				MaxChanConfs:    11,
+				MinChanConfsAmt: 100,
+				MaxChanConfsAmt: 200,
+			},
+			stake:    100,
+			expected: 1,
+		},
+		{
+			name: "custom thresholds scaled",
+			cfg: &lncfg.Chain{
+				MinChanConfs:    1,
+				MaxChanConfs:    11,
+				MinChanConfsAmt: 100,
+				MaxChanConfsAmt: 200,
+			},
+			stake:    150,
+			expected: 6,
+		},
+		{
+			name: "custom thresholds above max",
+			cfg: &lncfg.Chain{
+				MinChanConfs:    1,
+				MaxChanConfs:    11,
+				MinChanConfsAmt: 100,
+				MaxChanConfsAmt: 200,
+			},
+			stake:    500,
+			expected: 11,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			stake := lnwire.NewMSatFromSatoshis(test.stake)
+			confs := test.cfg.NumRequiredConfs(stake, maxChanSize)
+			if confs != test.expected {
+				t.Fatalf("expected %v confs, got %v",
+					test.expected, confs)
+			}
+		})
+	}
+}
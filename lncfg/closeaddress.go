@@ -0,0 +1,9 @@
+package lncfg
+
+// CloseAddress holds the policies that user supplied cooperative close
+// addresses and upfront shutdown scripts must adhere to.
+type CloseAddress struct {
+	RejectLegacy bool `long:"reject-legacy" description:"Reject cooperative close addresses and upfront shutdown addresses that are legacy P2PKH addresses."`
+
+	WalletOnly bool `long:"wallet-only" description:"Only accept cooperative close addresses and upfront shutdown addresses that belong to the wallet of this node."`
+}
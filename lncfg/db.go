@@ -11,6 +11,11 @@ const (
 	dbName      = "channel.db"
 	BoltBackend = "bolt"
 	EtcdBackend = "etcd"
+
+	// towerClientPrefix is appended to the network name to form the key
+	// prefix of the watchtower client database within a replicated
+	// backend, which keeps it apart from the channel database.
+	towerClientPrefix = "-wtclient"
 )
 
 // DB holds database configuration for LND.
@@ -94,5 +99,19 @@ func (db *DB) GetBackends(ctx context.Context, dbPath string,
 	}, nil
 }
 
+// GetTowerClientBackend returns the replicated backend the watchtower client
+// database should be stored in. If no replicated backend is configured, nil is
+// returned and the client database is expected to be kept in its local bolt
+// file.
+func (db *DB) GetTowerClientBackend(ctx context.Context,
+	networkName string) (kvdb.Backend, error) {
+
+	if db.Backend != EtcdBackend {
+		return nil, nil
+	}
+
+	return kvdb.GetEtcdBackend(ctx, networkName+towerClientPrefix, db.Etcd)
+}
+
 // Compile-time constraint to ensure Workers implements the Validator interface.
 var _ Validator = (*DB)(nil)
@@ -3,6 +3,7 @@ package lncfg
 import (
 	"errors"
 	"fmt"
+	"net"
 	"time"
 )
 
@@ -26,6 +27,10 @@ type HealthCheckConfig struct {
 	ChainCheck *CheckConfig `group:"chainbackend" namespace:"chainbackend"`
 
 	DiskCheck *DiskCheckConfig `group:"diskspace" namespace:"diskspace"`
+
+	TorConnection *CheckConfig `group:"torconnection" namespace:"torconnection"`
+
+	RemoteCheck *RemoteCheckConfig `group:"remote" namespace:"remote"`
 }
 
 // Validate checks the values configured for our health checks.
@@ -44,6 +49,21 @@ func (h *HealthCheckConfig) Validate() error {
 		return errors.New("disk required ratio must be in [0:1)")
 	}
 
+	if err := h.TorConnection.validate("tor connection"); err != nil {
+		return err
+	}
+
+	if err := h.RemoteCheck.validate("remote"); err != nil {
+		return err
+	}
+
+	for _, addr := range h.RemoteCheck.Addresses {
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			return fmt.Errorf("invalid remote health check "+
+				"address %v: %v", addr, err)
+		}
+	}
+
 	return nil
 }
 
@@ -88,3 +108,11 @@ type DiskCheckConfig struct {
 
 	*CheckConfig
 }
+
+// RemoteCheckConfig contains configuration for ensuring that remote services
+// lnd depends on, such as a remote signer, are reachable.
+type RemoteCheckConfig struct {
+	Addresses []string `long:"address" description:"The host:port of a remote service that must be reachable over TCP. A separate health check is run for every address. Can be specified multiple times."`
+
+	*CheckConfig
+}
@@ -5,13 +5,16 @@ import "time"
 // Neutrino holds the configuration options for the daemon's connection to
 // neutrino.
 type Neutrino struct {
-	AddPeers           []string      `short:"a" long:"addpeer" description:"Add a peer to connect with at startup"`
-	ConnectPeers       []string      `long:"connect" description:"Connect only to the specified peers at startup"`
-	MaxPeers           int           `long:"maxpeers" description:"Max number of inbound and outbound peers"`
-	BanDuration        time.Duration `long:"banduration" description:"How long to ban misbehaving peers.  Valid time units are {s, m, h}.  Minimum 1 second"`
-	BanThreshold       uint32        `long:"banthreshold" description:"Maximum allowed ban score before disconnecting and banning misbehaving peers."`
-	FeeURL             string        `long:"feeurl" description:"DEPRECATED: Optional URL for fee estimation. If a URL is not specified, static fees will be used for estimation."`
-	AssertFilterHeader string        `long:"assertfilterheader" description:"Optional filter header in height:hash format to assert the state of neutrino's filter header chain on startup. If the assertion does not hold, then the filter header chain will be re-synced from the genesis block."`
-	UserAgentName      string        `long:"useragentname" description:"Used to help identify ourselves to other bitcoin peers"`
-	UserAgentVersion   string        `long:"useragentversion" description:"Used to help identify ourselves to other bitcoin peers"`
+	AddPeers             []string      `short:"a" long:"addpeer" description:"Add a peer to connect with at startup"`
+	ConnectPeers         []string      `long:"connect" description:"Connect only to the specified peers at startup"`
+	MaxPeers             int           `long:"maxpeers" description:"Max number of inbound and outbound peers"`
+	BanDuration          time.Duration `long:"banduration" description:"How long to ban misbehaving peers.  Valid time units are {s, m, h}.  Minimum 1 second"`
+	BanThreshold         uint32        `long:"banthreshold" description:"Maximum allowed ban score before disconnecting and banning misbehaving peers."`
+	FeeURL               string        `long:"feeurl" description:"DEPRECATED: Optional URL for fee estimation. If a URL is not specified, static fees will be used for estimation."`
+	AssertFilterHeader   string        `long:"assertfilterheader" description:"Optional filter header in height:hash format to assert the state of neutrino's filter header chain on startup. If the assertion does not hold, then the filter header chain will be re-synced from the genesis block."`
+	UserAgentName        string        `long:"useragentname" description:"Used to help identify ourselves to other bitcoin peers"`
+	UserAgentVersion     string        `long:"useragentversion" description:"Used to help identify ourselves to other bitcoin peers"`
+	Checkpoints          []string      `long:"checkpoint" description:"A trusted block checkpoint in height:hash format, used to speed up and secure the header sync. Checkpoints replace the built-in checkpoint at the same height, if any. Can be specified multiple times."`
+	NoDefaultCheckpoints bool          `long:"nodefaultcheckpoints" description:"Don't use the checkpoints built into the chain parameters, but only the ones specified with the checkpoint option."`
+	SideloadHeaders      string        `long:"sideloadheaders" description:"Path to a file with block headers and filter headers to load into the header chain on startup, instead of downloading them from the network. The file must come from a trusted source, as the filter headers can't be verified."`
 }
@@ -0,0 +1,49 @@
+package lncfg
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultPaymentPruneInterval is the default interval at which payments are
+// pruned according to the retention policy.
+const DefaultPaymentPruneInterval = time.Hour
+
+// Payments holds the retention policy of the payments stored in the
+// database, including the route and failure of every htlc attempt.
+type Payments struct {
+	MaxAge time.Duration `long:"maxage" description:"The age after which completed payments are deleted from the database. 0 disables the limit."`
+
+	MaxCount uint64 `long:"maxcount" description:"The number of most recent payments that are kept in the database. Older completed payments are deleted. 0 disables the limit."`
+
+	FailedOnly bool `long:"failedonly" description:"If true, only failed payments are deleted by the retention policy."`
+
+	FailedHtlcsOnly bool `long:"failedhtlcsonly" description:"If true, only the failed htlc attempts of the payments are deleted by the retention policy, keeping the payments and their settled attempts."`
+
+	PruneInterval time.Duration `long:"pruneinterval" description:"The interval at which payments are pruned according to the retention policy."`
+}
+
+// Active returns true if a retention limit is configured.
+func (p *Payments) Active() bool {
+	return p.MaxAge != 0 || p.MaxCount != 0
+}
+
+// Validate checks that the age limit isn't negative and that the prune
+// interval is positive.
+func (p *Payments) Validate() error {
+	if p.MaxAge < 0 {
+		return fmt.Errorf("payments maxage must not be negative, "+
+			"got %v", p.MaxAge)
+	}
+
+	if p.PruneInterval <= 0 {
+		return fmt.Errorf("payments pruneinterval must be positive, "+
+			"got %v", p.PruneInterval)
+	}
+
+	return nil
+}
+
+// Compile-time constraint to ensure Payments implements the Validator
+// interface.
+var _ Validator = (*Payments)(nil)
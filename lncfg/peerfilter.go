@@ -0,0 +1,54 @@
+package lncfg
+
+import (
+	"github.com/cryptomeow/lnd/channeldb"
+	"github.com/cryptomeow/lnd/peerfilter"
+)
+
+// PeerFilter holds the configuration of the filter for inbound peer
+// connections.
+type PeerFilter struct {
+	Allow []string `long:"allow" description:"An IP address, CIDR network or AS number of the form AS64496 that inbound connections are always accepted from, even if they match a deny rule. Can be set multiple times."`
+
+	Deny []string `long:"deny" description:"An IP address, CIDR network or AS number of the form AS64496 that inbound connections are rejected from. Can be set multiple times."`
+
+	AllowOnly bool `long:"allowonly" description:"Reject all inbound connections that don't match an allow rule."`
+
+	ASNFile string `long:"asnfile" description:"The path to a data file that maps CIDR networks to AS numbers, one network and AS number per line. It is required to filter connections by AS number."`
+}
+
+// Rules parses the configured allow and deny rules.
+func (p *PeerFilter) Rules() ([]*peerfilter.Rule, error) {
+	var rules []*peerfilter.Rule
+	for _, allow := range p.Allow {
+		rule, err := peerfilter.ParseRule(
+			allow, channeldb.PeerFilterAllow,
+		)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	for _, deny := range p.Deny {
+		rule, err := peerfilter.ParseRule(
+			deny, channeldb.PeerFilterDeny,
+		)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+// Validate checks that the configured rules can be parsed.
+func (p *PeerFilter) Validate() error {
+	_, err := p.Rules()
+	return err
+}
+
+// Compile-time constraint to ensure PeerFilter implements the Validator
+// interface.
+var _ Validator = (*PeerFilter)(nil)
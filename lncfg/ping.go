@@ -0,0 +1,53 @@
+package lncfg
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultPingInterval is the default interval at which pings are sent
+	// to each peer.
+	DefaultPingInterval = time.Minute
+
+	// DefaultMaxMissedPings is the default number of consecutive pings a
+	// peer may leave unanswered before it is disconnected.
+	DefaultMaxMissedPings = 3
+
+	// DefaultWriteStallTimeout is the default duration a single message
+	// may take to be written to a peer before the peer is disconnected.
+	DefaultWriteStallTimeout = 2 * time.Minute
+)
+
+// Ping holds the configuration of the ping policy, the detection of dead peers
+// and the keepalive and bandwidth of peer connections.
+type Ping struct {
+	Interval time.Duration `long:"interval" description:"The interval at which pings are sent to each peer."`
+
+	MaxMissed uint32 `long:"maxmissed" description:"The number of consecutive pings a peer may leave unanswered before it is disconnected. A ping counts as unanswered if no pong was received when the next ping is due. 0 disables the check."`
+
+	WriteStallTimeout time.Duration `long:"writestalltimeout" description:"The duration a single message may take to be written to a peer before the peer is disconnected. 0 disables the check."`
+
+	TCPKeepAlive time.Duration `long:"tcpkeepalive" description:"The interval at which TCP keepalive probes are sent on peer connections. 0 keeps the default of the operating system, a negative value disables the probes."`
+
+	GossipWriteRate uint64 `long:"gossipwriterate" description:"The maximum rate in bytes per second at which gossip and other low-priority messages are written to each peer. Channel updates are never delayed. 0 disables the limit."`
+}
+
+// Validate checks that the ping interval is positive and that the write stall
+// timeout isn't negative.
+func (p *Ping) Validate() error {
+	if p.Interval <= 0 {
+		return fmt.Errorf("ping interval must be positive, got %v",
+			p.Interval)
+	}
+
+	if p.WriteStallTimeout < 0 {
+		return fmt.Errorf("ping writestalltimeout must not be "+
+			"negative, got %v", p.WriteStallTimeout)
+	}
+
+	return nil
+}
+
+// Compile-time constraint to ensure Ping implements the Validator interface.
+var _ Validator = (*Ping)(nil)
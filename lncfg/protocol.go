@@ -17,6 +17,10 @@ type ProtocolOptions struct {
 	// (channels larger than 0.16 BTC) channels, which is the opposite of
 	// mini.
 	WumboChans bool `long:"wumbo-channels" description:"if set, then lnd will create and accept requests for channels larger chan 0.16 BTC"`
+
+	// Endorsement should be set if we want to send the experimental
+	// endorsement signal along with our outgoing HTLCs.
+	Endorsement bool `long:"experimental-endorsement" description:"EXPERIMENTAL: if set, the experimental endorsement signal is sent along with outgoing HTLCs. Forwarded HTLCs relay the signal of the incoming HTLC and our own payments are endorsed. The outcomes of the forwards are tracked per incoming peer and signal, and reported by listpeers."`
 }
 
 // Wumbo returns true if lnd should permit the creation and acceptance of wumbo
@@ -0,0 +1,43 @@
+package lncfg
+
+import "fmt"
+
+// RemoteFee holds the bounds on the commitment fee rates the remote party may
+// set with update_fee on the channels it initiated.
+type RemoteFee struct {
+	MinRatio float64 `long:"minratio" description:"The lowest commitment fee rate accepted from the remote party, as a ratio of our own fee estimate for confirmation within 3 blocks. A lower fee rate may prevent the commitment from confirming in time if the channel is force closed. Set to 0 to disable the lower bound."`
+
+	MaxRatio float64 `long:"maxratio" description:"The highest commitment fee rate accepted from the remote party, as a multiple of our own fee estimate for confirmation within 3 blocks. A higher fee rate makes HTLCs uneconomical and burns funds on a force close. Set to 0 to disable the upper bound."`
+
+	MaxViolations uint32 `long:"maxviolations" description:"The number of consecutive fee updates outside of the bounds after which the channel is force closed. Every fee update outside of the bounds is logged as a warning. Set to 0 to never close the channel."`
+}
+
+// Validate checks that the bounds are not negative and that the lower bound
+// doesn't exceed the upper bound.
+func (r *RemoteFee) Validate() error {
+	if r.MinRatio < 0 {
+		return fmt.Errorf("remotefee minratio must not be negative, "+
+			"got %v", r.MinRatio)
+	}
+
+	if r.MaxRatio < 0 {
+		return fmt.Errorf("remotefee maxratio must not be negative, "+
+			"got %v", r.MaxRatio)
+	}
+
+	if r.MaxRatio != 0 && r.MinRatio > r.MaxRatio {
+		return fmt.Errorf("remotefee minratio (%v) must not exceed "+
+			"maxratio (%v)", r.MinRatio, r.MaxRatio)
+	}
+
+	if r.MaxViolations != 0 && r.MinRatio == 0 && r.MaxRatio == 0 {
+		return fmt.Errorf("remotefee maxviolations requires minratio " +
+			"or maxratio to be set")
+	}
+
+	return nil
+}
+
+// Compile-time constraint to ensure RemoteFee implements the Validator
+// interface.
+var _ Validator = (*RemoteFee)(nil)
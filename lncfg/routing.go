@@ -1,6 +1,40 @@
 package lncfg
 
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultGraphPruneInterval is the default interval at which the channel graph
+// is examined for zombie channels.
+const DefaultGraphPruneInterval = time.Hour
+
 // Routing holds the configuration options for routing.
 type Routing struct {
 	AssumeChannelValid bool `long:"assumechanvalid" description:"Skip checking channel spentness during graph validation. This speedup comes at the risk of using an unvalidated view of the network for routing. (default: false)"`
+
+	ChannelPruneExpiry time.Duration `long:"chanpruneexpiry" description:"The duration after which a channel is considered a zombie and pruned from the graph if neither of its edges has been updated."`
+
+	GraphPruneInterval time.Duration `long:"graphpruneinterval" description:"The interval at which the channel graph is examined for zombie channels."`
+
+	CompactZombies bool `long:"compactzombies" description:"If true, the public keys of the nodes are removed from the index of pruned zombie channels. The compacted index still prevents zombie channels from being downloaded again through gossip, but updates resurrecting them can't be verified until their channel announcement is received again."`
 }
+
+// Validate checks that the zombie pruning expiry and interval are positive.
+func (r *Routing) Validate() error {
+	if r.ChannelPruneExpiry <= 0 {
+		return fmt.Errorf("routing chanpruneexpiry must be positive, "+
+			"got %v", r.ChannelPruneExpiry)
+	}
+
+	if r.GraphPruneInterval <= 0 {
+		return fmt.Errorf("routing graphpruneinterval must be "+
+			"positive, got %v", r.GraphPruneInterval)
+	}
+
+	return nil
+}
+
+// Compile-time constraint to ensure Routing implements the Validator
+// interface.
+var _ Validator = (*Routing)(nil)
@@ -0,0 +1,10 @@
+package lncfg
+
+import "github.com/cryptomeow/lnd/contractcourt"
+
+// Sweeper holds the configuration options for the sweeper.
+type Sweeper struct {
+	ConsolidationAddrs []string `long:"consolidation-addr" description:"An address of the consolidation address set that matured time-locked outputs of force closed channels (CSV delayed commitment outputs and second-level HTLC outputs) are swept to instead of the wallet. The addresses are used in turn, with the outputs of each sweep batched into a single transaction. Can be specified multiple times."`
+
+	Budget *contractcourt.BudgetConfig `group:"budget" namespace:"budget"`
+}
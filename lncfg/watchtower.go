@@ -9,5 +9,7 @@ type Watchtower struct {
 
 	TowerDir string `long:"towerdir" description:"Directory of the watchtower.db"`
 
+	Publish bool `long:"publish" description:"Advertise the URI and policy of the watchtower in our node announcement, so that watchtower clients can discover it as a public altruist tower"`
+
 	watchtower.Conf
 }
@@ -0,0 +1,74 @@
+package lncfg
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+const (
+	// DefaultWebhookMaxRetries is the default number of times the delivery
+	// of a webhook event is retried before it is given up on.
+	DefaultWebhookMaxRetries = 5
+
+	// DefaultWebhookRetryDelay is the default delay before the first retry
+	// of a failed webhook delivery. The delay is doubled for every
+	// subsequent retry.
+	DefaultWebhookRetryDelay = 5 * time.Second
+
+	// DefaultWebhookTimeout is the default timeout of a single webhook
+	// delivery attempt.
+	DefaultWebhookTimeout = 10 * time.Second
+)
+
+// Webhook holds the configuration of the outbound webhooks that channel and
+// peer events are posted to.
+type Webhook struct {
+	URLs []string `long:"url" description:"A URL that channel events (pending open, open, close, funding locked stalled) and peer events (online, offline) are posted to as JSON. Can be specified multiple times."`
+
+	Secret string `long:"secret" description:"The secret used to sign the body of each webhook request with HMAC-SHA256. The hex encoded signature is sent in the X-Lnd-Signature header. If not set, requests are not signed."`
+
+	MaxRetries int `long:"maxretries" description:"The number of times a failed delivery is retried before the event is dropped."`
+
+	RetryDelay time.Duration `long:"retrydelay" description:"The delay before the first retry of a failed delivery, doubled for every subsequent retry."`
+
+	Timeout time.Duration `long:"timeout" description:"The timeout of a single delivery attempt."`
+}
+
+// Validate checks that the webhook URLs can be parsed and that the retry
+// settings are sane.
+func (w *Webhook) Validate() error {
+	for _, rawURL := range w.URLs {
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			return fmt.Errorf("invalid webhook url %v: %v", rawURL,
+				err)
+		}
+
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return fmt.Errorf("webhook url %v must use http or "+
+				"https", rawURL)
+		}
+	}
+
+	if w.MaxRetries < 0 {
+		return fmt.Errorf("webhook maxretries must not be negative, "+
+			"got %v", w.MaxRetries)
+	}
+
+	if w.RetryDelay <= 0 {
+		return fmt.Errorf("webhook retrydelay must be positive, got %v",
+			w.RetryDelay)
+	}
+
+	if w.Timeout <= 0 {
+		return fmt.Errorf("webhook timeout must be positive, got %v",
+			w.Timeout)
+	}
+
+	return nil
+}
+
+// Compile-time constraint to ensure Webhook implements the Validator
+// interface.
+var _ Validator = (*Webhook)(nil)
@@ -27,6 +27,10 @@ type Workers struct {
 
 	// Sig is the maximum number of concurrent sig pool workers.
 	Sig int `long:"sig" description:"Maximum number of concurrent sig pool workers. This number should be proportional to the number of CPUs on the host."`
+
+	// Validation is the maximum number of gossip announcements that are
+	// validated concurrently.
+	Validation int `long:"validation" description:"Maximum number of gossip announcements that are validated concurrently. Validating a channel announcement requires retrieving its funding output from the chain backend, so on fast backends a higher number speeds up the initial graph sync. Announcements that depend on each other are still processed in the order they were received."`
 }
 
 // Validate checks the Workers configuration to ensure that the input values are
@@ -44,6 +48,10 @@ func (w *Workers) Validate() error {
 		return fmt.Errorf("number of sig workers (%d) must be "+
 			"positive", w.Sig)
 	}
+	if w.Validation <= 0 {
+		return fmt.Errorf("number of validation workers (%d) must be "+
+			"positive", w.Validation)
+	}
 
 	return nil
 }
@@ -12,9 +12,20 @@ type WtClient struct {
 	// watchtower client should send new backups to.
 	PrivateTowerURIs []string `long:"private-tower-uris" description:"(Deprecated) Specifies the URIs of private watchtowers to use in backing up revoked states. URIs must be of the form <pubkey>@<addr>. Only 1 URI is supported at this time, if none are provided the tower will not be enabled."`
 
+	// Towers specifies the lightning URIs of the towers the watchtower
+	// client should back up to in addition to the ones added at runtime.
+	// Towers removed from this list are removed from the client when the
+	// configuration is reloaded.
+	Towers []string `long:"tower" description:"Specifies the URI of a watchtower to back up revoked states to, in the form <pubkey>@<addr>. Can be set multiple times. The list of towers is updated when the configuration is reloaded."`
+
 	// SweepFeeRate specifies the fee rate in sat/byte to be used when
 	// constructing justice transactions sent to the tower.
 	SweepFeeRate uint64 `long:"sweep-fee-rate" description:"Specifies the fee rate in sat/byte to be used when constructing justice transactions sent to the watchtower."`
+
+	// FeeEscalation specifies whether the watchtower client should also
+	// send justice transactions paying higher fees to the tower, which it
+	// publishes if the justice transaction doesn't confirm in time.
+	FeeEscalation bool `long:"fee-escalation" description:"Whether to also sign justice transactions paying twice and four times the sweep fee rate, which the watchtower publishes if a justice transaction doesn't confirm in time. Only towers supporting fee escalation can be used."`
 }
 
 // Validate ensures the user has provided a valid configuration.
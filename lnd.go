@@ -20,6 +20,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/btcsuite/btcutil"
 	"github.com/btcsuite/btcwallet/wallet"
@@ -27,23 +28,27 @@ import (
 	proxy "github.com/grpc-ecosystem/grpc-gateway/runtime"
 	"github.com/lightninglabs/neutrino"
 	"github.com/lightninglabs/neutrino/headerfs"
+	"golang.org/x/crypto/acme"
 	"golang.org/x/crypto/acme/autocert"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"gopkg.in/macaroon-bakery.v2/bakery"
 	"gopkg.in/macaroon.v2"
 
+	"github.com/cryptomeow/lnd/acmedns"
 	"github.com/cryptomeow/lnd/autopilot"
 	"github.com/cryptomeow/lnd/build"
 	"github.com/cryptomeow/lnd/cert"
 	"github.com/cryptomeow/lnd/chainreg"
 	"github.com/cryptomeow/lnd/chanacceptor"
 	"github.com/cryptomeow/lnd/channeldb"
+	"github.com/cryptomeow/lnd/channeldb/kvdb"
 	"github.com/cryptomeow/lnd/keychain"
 	"github.com/cryptomeow/lnd/lncfg"
 	"github.com/cryptomeow/lnd/lnrpc"
 	"github.com/cryptomeow/lnd/lnwallet"
 	"github.com/cryptomeow/lnd/lnwallet/btcwallet"
+	"github.com/cryptomeow/lnd/lnwallet/chainfee"
 	"github.com/cryptomeow/lnd/macaroons"
 	"github.com/cryptomeow/lnd/signal"
 	"github.com/cryptomeow/lnd/tor"
@@ -270,7 +275,8 @@ func Main(cfg *Config, lisCfg ListenerCfg, shutdownChan <-chan struct{}) error {
 	defer cleanUp()
 
 	// Only process macaroons if --no-macaroons isn't set.
-	tlsCfg, restCreds, restProxyDest, cleanUp, err := getTLSConfig(cfg)
+	tlsCfg, restCreds, restProxyDest, tlsRotator, cleanUp, err :=
+		getTLSConfig(cfg)
 	if err != nil {
 		err := fmt.Errorf("unable to load TLS credentials: %v", err)
 		ltndLog.Error(err)
@@ -373,6 +379,30 @@ func Main(cfg *Config, lisCfg ListenerCfg, shutdownChan <-chan struct{}) error {
 		return getListeners()
 	}
 
+	// rpcListeners is a closure we'll hand to the rpc server, that will be
+	// called when it needs listeners for its GPRC server.
+	rpcListeners := func() ([]*ListenerWithSignal, func(), error) {
+		// If we have chosen to start with a dedicated listener for the
+		// rpc server, we return it directly.
+		if lisCfg.RPCListener != nil {
+			return []*ListenerWithSignal{lisCfg.RPCListener},
+				func() {}, nil
+		}
+
+		// Otherwise we'll return the regular listeners.
+		return getListeners()
+	}
+
+	// In monitor-only mode, the channels are only watched on chain, so
+	// neither the wallet is unlocked nor the server is created.
+	if cfg.MonitorOnly {
+		return runMonitorOnly(
+			cfg, localChanDB, remoteChanDB, neutrinoCS, serverOpts,
+			restDialOpts, restProxyDest, tlsCfg, rpcListeners,
+			shutdownChan,
+		)
+	}
+
 	// We wait until the user provides a password over RPC. In case lnd is
 	// started with the --noseedbackup flag, we use the default password
 	// for wallet encryption.
@@ -406,41 +436,14 @@ func Main(cfg *Config, lisCfg ListenerCfg, shutdownChan <-chan struct{}) error {
 
 	var macaroonService *macaroons.Service
 	if !cfg.NoMacaroons {
-		// Create the macaroon authentication/authorization service.
-		macaroonService, err = macaroons.NewService(
-			cfg.networkDir, "lnd", macaroons.IPLockChecker,
+		macaroonService, err = initMacaroonService(
+			ctx, cfg, privateWalletPw,
 		)
 		if err != nil {
-			err := fmt.Errorf("unable to set up macaroon "+
-				"authentication: %v", err)
 			ltndLog.Error(err)
 			return err
 		}
 		defer macaroonService.Close()
-
-		// Try to unlock the macaroon store with the private password.
-		err = macaroonService.CreateUnlock(&privateWalletPw)
-		if err != nil {
-			err := fmt.Errorf("unable to unlock macaroons: %v", err)
-			ltndLog.Error(err)
-			return err
-		}
-
-		// Create macaroon files for lncli to use if they don't exist.
-		if !fileExists(cfg.AdminMacPath) && !fileExists(cfg.ReadMacPath) &&
-			!fileExists(cfg.InvoiceMacPath) {
-
-			err = genMacaroons(
-				ctx, macaroonService, cfg.AdminMacPath,
-				cfg.ReadMacPath, cfg.InvoiceMacPath,
-			)
-			if err != nil {
-				err := fmt.Errorf("unable to create macaroons "+
-					"%v", err)
-				ltndLog.Error(err)
-				return err
-			}
-		}
 	}
 
 	// With the information parsed from the configuration, create valid
@@ -450,6 +453,9 @@ func Main(cfg *Config, lisCfg ListenerCfg, shutdownChan <-chan struct{}) error {
 	// When we create the chain control, we need storage for the height
 	// hints and also the wallet itself, for these two we want them to be
 	// replicated, so we'll pass in the remote channel DB instance.
+	feeURLMaxRate := chainfee.SatPerKVByte(
+		cfg.FeeURLMaxRate * 1000,
+	).FeePerKWeight()
 	chainControlCfg := &chainreg.Config{
 		Bitcoin:                     cfg.Bitcoin,
 		Litecoin:                    cfg.Litecoin,
@@ -470,6 +476,9 @@ func Main(cfg *Config, lisCfg ListenerCfg, shutdownChan <-chan struct{}) error {
 		NeutrinoCS:                  neutrinoCS,
 		ActiveNetParams:             cfg.ActiveNetParams,
 		FeeURL:                      cfg.FeeURL,
+		FeeURLType:                  cfg.FeeURLType,
+		FeeURLMaxRate:               feeURLMaxRate,
+		CoinSelectionStrategy:       cfg.coinSelectionStrategy,
 	}
 
 	activeChainControl, err := chainreg.NewChainControl(chainControlCfg)
@@ -509,7 +518,7 @@ func Main(cfg *Config, lisCfg ListenerCfg, shutdownChan <-chan struct{}) error {
 	var towerClientDB *wtdb.ClientDB
 	if cfg.WtClient.Active {
 		var err error
-		towerClientDB, err = wtdb.OpenClientDB(cfg.localDatabaseDir())
+		towerClientDB, err = openTowerClientDB(ctx, cfg)
 		if err != nil {
 			err := fmt.Errorf("unable to open watchtower client "+
 				"database: %v", err)
@@ -579,6 +588,7 @@ func Main(cfg *Config, lisCfg ListenerCfg, shutdownChan <-chan struct{}) error {
 			NewAddress: func() (btcutil.Address, error) {
 				return activeChainControl.Wallet.NewAddress(
 					lnwallet.WitnessPubKey, false,
+					lnwallet.DefaultAccountName,
 				)
 			},
 			NodeKeyECDH: keychain.NewPubKeyECDH(
@@ -657,26 +667,12 @@ func Main(cfg *Config, lisCfg ListenerCfg, shutdownChan <-chan struct{}) error {
 	}
 	defer atplManager.Stop()
 
-	// rpcListeners is a closure we'll hand to the rpc server, that will be
-	// called when it needs listeners for its GPRC server.
-	rpcListeners := func() ([]*ListenerWithSignal, func(), error) {
-		// If we have chosen to start with a dedicated listener for the
-		// rpc server, we return it directly.
-		if lisCfg.RPCListener != nil {
-			return []*ListenerWithSignal{lisCfg.RPCListener},
-				func() {}, nil
-		}
-
-		// Otherwise we'll return the regular listeners.
-		return getListeners()
-	}
-
 	// Initialize, and register our implementation of the gRPC interface
 	// exported by the rpcServer.
 	rpcServer, err := newRPCServer(
 		cfg, server, macaroonService, cfg.SubRPCServers, serverOpts,
 		restDialOpts, restProxyDest, atplManager, server.invoices,
-		tower, tlsCfg, rpcListeners, chainedAcceptor,
+		tower, tlsCfg, tlsRotator, rpcListeners, chainedAcceptor,
 	)
 	if err != nil {
 		err := fmt.Errorf("unable to create RPC server: %v", err)
@@ -768,21 +764,48 @@ func Main(cfg *Config, lisCfg ListenerCfg, shutdownChan <-chan struct{}) error {
 			return err
 		}
 		defer tower.Stop()
+
+		if cfg.Watchtower.Publish {
+			err := server.announceTower(
+				tower.PubKey(), tower.ExternalIPs(),
+			)
+			if err != nil {
+				err := fmt.Errorf("unable to publish "+
+					"watchtower: %v", err)
+				ltndLog.Error(err)
+				return err
+			}
+		}
 	}
 
 	// Wait for shutdown signal from either a graceful server stop or from
-	// the interrupt handler.
-	<-shutdownChan
-	return nil
+	// the interrupt handler. In the meantime, we'll reload the runtime
+	// configurable options whenever SIGHUP is received.
+	for {
+		select {
+		case <-signal.ReloadChannel():
+			if _, err := server.reloadConfig(); err != nil {
+				ltndLog.Errorf("Unable to reload config: %v", err)
+			}
+
+		case <-shutdownChan:
+			return nil
+		}
+	}
 }
 
 // getTLSConfig returns a TLS configuration for the gRPC server and credentials
-// and a proxy destination for the REST reverse proxy.
+// and a proxy destination for the REST reverse proxy, along with the rotator
+// serving the certificate at the configured path. The rotator is started and is
+// stopped by the returned cleanup function.
 func getTLSConfig(cfg *Config) (*tls.Config, *credentials.TransportCredentials,
-	string, func(), error) {
+	string, *tlsRotator, func(), error) {
+
+	// Ensure we create TLS key and certificate if they don't exist, unless
+	// they are provided externally.
+	if !cfg.TLSExternalCert && !fileExists(cfg.TLSCertPath) &&
+		!fileExists(cfg.TLSKeyPath) {
 
-	// Ensure we create TLS key and certificate if they don't exist.
-	if !fileExists(cfg.TLSCertPath) && !fileExists(cfg.TLSKeyPath) {
 		rpcsLog.Infof("Generating TLS certificates...")
 		err := cert.GenCertPair(
 			"lnd autogenerated cert", cfg.TLSCertPath,
@@ -790,7 +813,7 @@ func getTLSConfig(cfg *Config) (*tls.Config, *credentials.TransportCredentials,
 			cfg.TLSDisableAutofill, cert.DefaultAutogenValidity,
 		)
 		if err != nil {
-			return nil, nil, "", nil, err
+			return nil, nil, "", nil, nil, err
 		}
 		rpcsLog.Infof("Done generating TLS certificates")
 	}
@@ -799,7 +822,7 @@ func getTLSConfig(cfg *Config) (*tls.Config, *credentials.TransportCredentials,
 		cfg.TLSCertPath, cfg.TLSKeyPath,
 	)
 	if err != nil {
-		return nil, nil, "", nil, err
+		return nil, nil, "", nil, nil, err
 	}
 
 	// We check whether the certifcate we have on disk match the IPs and
@@ -807,30 +830,38 @@ func getTLSConfig(cfg *Config) (*tls.Config, *credentials.TransportCredentials,
 	// changed from when the certificate was created, we will refresh the
 	// certificate if auto refresh is active.
 	refresh := false
-	if cfg.TLSAutoRefresh {
+	if cfg.TLSAutoRefresh && !cfg.TLSExternalCert {
 		refresh, err = cert.IsOutdated(
 			parsedCert, cfg.TLSExtraIPs,
 			cfg.TLSExtraDomains, cfg.TLSDisableAutofill,
 		)
 		if err != nil {
-			return nil, nil, "", nil, err
+			return nil, nil, "", nil, nil, err
 		}
 	}
 
+	// An externally provided certificate is never replaced, even if it
+	// expired, so it is up to its provider to renew it.
+	expired := time.Now().After(parsedCert.NotAfter)
+	if cfg.TLSExternalCert && expired {
+		ltndLog.Warnf("External TLS certificate expired at %v",
+			parsedCert.NotAfter)
+	}
+
 	// If the certificate expired or it was outdated, delete it and the TLS
 	// key and generate a new pair.
-	if time.Now().After(parsedCert.NotAfter) || refresh {
+	if !cfg.TLSExternalCert && (expired || refresh) {
 		ltndLog.Info("TLS certificate is expired or outdated, " +
 			"generating a new one")
 
 		err := os.Remove(cfg.TLSCertPath)
 		if err != nil {
-			return nil, nil, "", nil, err
+			return nil, nil, "", nil, nil, err
 		}
 
 		err = os.Remove(cfg.TLSKeyPath)
 		if err != nil {
-			return nil, nil, "", nil, err
+			return nil, nil, "", nil, nil, err
 		}
 
 		rpcsLog.Infof("Renewing TLS certificates...")
@@ -840,25 +871,29 @@ func getTLSConfig(cfg *Config) (*tls.Config, *credentials.TransportCredentials,
 			cfg.TLSDisableAutofill, cert.DefaultAutogenValidity,
 		)
 		if err != nil {
-			return nil, nil, "", nil, err
+			return nil, nil, "", nil, nil, err
 		}
 		rpcsLog.Infof("Done renewing TLS certificates")
 
 		// Reload the certificate data.
-		certData, _, err = cert.LoadCert(
+		certData, parsedCert, err = cert.LoadCert(
 			cfg.TLSCertPath, cfg.TLSKeyPath,
 		)
 		if err != nil {
-			return nil, nil, "", nil, err
+			return nil, nil, "", nil, nil, err
 		}
 	}
 
+	// The certificate is served by the rotator on each handshake, so it
+	// can be rotated without restarting the listeners. For the same
+	// reason, the REST proxy pins the served certificate instead of the
+	// one on disk.
+	rotator := newTLSRotator(cfg, certData, parsedCert)
 	tlsCfg := cert.TLSConfFromCert(certData)
+	tlsCfg.Certificates = nil
+	tlsCfg.GetCertificate = rotator.GetCertificate
 
-	restCreds, err := credentials.NewClientTLSFromFile(cfg.TLSCertPath, "")
-	if err != nil {
-		return nil, nil, "", nil, err
-	}
+	restCreds := credentials.NewTLS(rotator.ClientTLSConfig())
 
 	restProxyDest := cfg.RPCListeners[0].String()
 	switch {
@@ -874,53 +909,41 @@ func getTLSConfig(cfg *Config) (*tls.Config, *credentials.TransportCredentials,
 	}
 
 	// If Let's Encrypt is enabled, instantiate autocert to request/renew
-	// the certificates.
+	// the certificates. If a DNS hook is set, the certificates are obtained
+	// through the DNS-01 challenge instead, which doesn't require a
+	// challenge listener.
 	cleanUp := func() {}
 	if cfg.LetsEncryptDomain != "" {
 		ltndLog.Infof("Using Let's Encrypt certificate for domain %v",
 			cfg.LetsEncryptDomain)
 
-		manager := autocert.Manager{
-			Cache:      autocert.DirCache(cfg.LetsEncryptDir),
-			Prompt:     autocert.AcceptTOS,
-			HostPolicy: autocert.HostWhitelist(cfg.LetsEncryptDomain),
-		}
-
-		srv := &http.Server{
-			Addr:    cfg.LetsEncryptListen,
-			Handler: manager.HTTPHandler(nil),
-		}
-		shutdownCompleted := make(chan struct{})
-		cleanUp = func() {
-			err := srv.Shutdown(context.Background())
-			if err != nil {
-				ltndLog.Errorf("Autocert listener shutdown "+
-					" error: %v", err)
-
-				return
+		var getACMECertificate func(*tls.ClientHelloInfo) (
+			*tls.Certificate, error)
+
+		if cfg.LetsEncryptDNSHook != "" {
+			manager := acmedns.New(&acmedns.Config{
+				DirectoryURL: cfg.LetsEncryptACMEURL,
+				Domain:       cfg.LetsEncryptDomain,
+				CacheDir:     cfg.LetsEncryptDir,
+				Hook:         cfg.LetsEncryptDNSHook,
+			})
+			if err := manager.Start(); err != nil {
+				return nil, nil, "", nil, nil, err
 			}
-			<-shutdownCompleted
-			ltndLog.Infof("Autocert challenge listener stopped")
-		}
-
-		go func() {
-			ltndLog.Infof("Autocert challenge listener started "+
-				"at %v", cfg.LetsEncryptListen)
 
-			err := srv.ListenAndServe()
-			if err != http.ErrServerClosed {
-				ltndLog.Errorf("autocert http: %v", err)
-			}
-			close(shutdownCompleted)
-		}()
+			cleanUp = manager.Stop
+			getACMECertificate = manager.GetCertificate
+		} else {
+			cleanUp, getACMECertificate = startAutocert(cfg)
+		}
 
 		getCertificate := func(h *tls.ClientHelloInfo) (
 			*tls.Certificate, error) {
 
-			lecert, err := manager.GetCertificate(h)
+			lecert, err := getACMECertificate(h)
 			if err != nil {
 				ltndLog.Errorf("GetCertificate: %v", err)
-				return &certData, nil
+				return rotator.GetCertificate(h)
 			}
 
 			return lecert, err
@@ -930,7 +953,65 @@ func getTLSConfig(cfg *Config) (*tls.Config, *credentials.TransportCredentials,
 		tlsCfg.GetCertificate = getCertificate
 	}
 
-	return tlsCfg, &restCreds, restProxyDest, cleanUp, nil
+	rotator.Start()
+	stopAutocert := cleanUp
+	cleanUp = func() {
+		rotator.Stop()
+		stopAutocert()
+	}
+
+	return tlsCfg, &restCreds, restProxyDest, rotator, cleanUp, nil
+}
+
+// startAutocert starts the listener for the HTTP-01 challenges of Let's
+// Encrypt, and returns a function to stop it along with the callback that
+// requests and renews the certificates.
+func startAutocert(cfg *Config) (func(),
+	func(*tls.ClientHelloInfo) (*tls.Certificate, error)) {
+
+	manager := &autocert.Manager{
+		Cache:      autocert.DirCache(cfg.LetsEncryptDir),
+		Prompt:     autocert.AcceptTOS,
+		HostPolicy: autocert.HostWhitelist(cfg.LetsEncryptDomain),
+	}
+
+	// Any ACME certificate authority can be used instead of Let's
+	// Encrypt.
+	if cfg.LetsEncryptACMEURL != "" {
+		manager.Client = &acme.Client{
+			DirectoryURL: cfg.LetsEncryptACMEURL,
+		}
+	}
+
+	srv := &http.Server{
+		Addr:    cfg.LetsEncryptListen,
+		Handler: manager.HTTPHandler(nil),
+	}
+	shutdownCompleted := make(chan struct{})
+	cleanUp := func() {
+		err := srv.Shutdown(context.Background())
+		if err != nil {
+			ltndLog.Errorf("Autocert listener shutdown "+
+				" error: %v", err)
+
+			return
+		}
+		<-shutdownCompleted
+		ltndLog.Infof("Autocert challenge listener stopped")
+	}
+
+	go func() {
+		ltndLog.Infof("Autocert challenge listener started "+
+			"at %v", cfg.LetsEncryptListen)
+
+		err := srv.ListenAndServe()
+		if err != http.ErrServerClosed {
+			ltndLog.Errorf("autocert http: %v", err)
+		}
+		close(shutdownCompleted)
+	}()
+
+	return cleanUp, manager.GetCertificate
 }
 
 // fileExists reports whether the named file or directory exists.
@@ -944,6 +1025,59 @@ func fileExists(name string) bool {
 	return true
 }
 
+// initMacaroonService creates the macaroon authentication/authorization
+// service, unlocks its root key store with the private wallet password and
+// creates the macaroon files for lncli to use if they don't exist.
+func initMacaroonService(ctx context.Context, cfg *Config,
+	privateWalletPw []byte) (*macaroons.Service, error) {
+
+	macaroonService, err := macaroons.NewService(
+		cfg.networkDir, "lnd", macaroons.IPLockChecker,
+		macaroons.ObserverChecker,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to set up macaroon "+
+			"authentication: %v", err)
+	}
+
+	// Try to unlock the macaroon store with the private password.
+	err = macaroonService.CreateUnlock(&privateWalletPw)
+	if err != nil {
+		macaroonService.Close()
+		return nil, fmt.Errorf("unable to unlock macaroons: %v", err)
+	}
+
+	// Create macaroon files for lncli to use if they don't exist.
+	if !fileExists(cfg.AdminMacPath) && !fileExists(cfg.ReadMacPath) &&
+		!fileExists(cfg.InvoiceMacPath) {
+
+		err = genMacaroons(
+			ctx, macaroonService, cfg.AdminMacPath,
+			cfg.ReadMacPath, cfg.InvoiceMacPath,
+		)
+		if err != nil {
+			macaroonService.Close()
+			return nil, fmt.Errorf("unable to create macaroons "+
+				"%v", err)
+		}
+	}
+
+	// The observer macaroon is created separately, so that it's also
+	// created for nodes that already have the other macaroon files.
+	if !fileExists(cfg.ObserverMacPath) {
+		err = genObserverMacaroon(
+			ctx, macaroonService, cfg.ObserverMacPath,
+		)
+		if err != nil {
+			macaroonService.Close()
+			return nil, fmt.Errorf("unable to create observer "+
+				"macaroon %v", err)
+		}
+	}
+
+	return macaroonService, nil
+}
+
 // genMacaroons generates three macaroon files; one admin-level, one for
 // invoice access and one read-only. These can also be used to generate more
 // granular macaroons.
@@ -1005,6 +1139,39 @@ func genMacaroons(ctx context.Context, svc *macaroons.Service,
 	return nil
 }
 
+// genObserverMacaroon generates the observer macaroon file. The observer
+// macaroon grants read access to all RPCs, and carries the observer caveat
+// which guarantees that it can't be used to call any RPC that modifies the
+// state of the daemon. This makes it suitable for dashboards and other
+// monitoring tools.
+func genObserverMacaroon(ctx context.Context, svc *macaroons.Service,
+	observerFile string) error {
+
+	mac, err := svc.NewMacaroon(
+		ctx, macaroons.DefaultRootKeyID, readPermissions...,
+	)
+	if err != nil {
+		return err
+	}
+
+	observerMac, err := macaroons.AddConstraints(
+		mac.M(), macaroons.ObserverConstraint(),
+	)
+	if err != nil {
+		return err
+	}
+	macBytes, err := observerMac.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	if err = ioutil.WriteFile(observerFile, macBytes, 0644); err != nil {
+		os.Remove(observerFile)
+		return err
+	}
+
+	return nil
+}
+
 // WalletUnlockParams holds the variables used to parameterize the unlocking of
 // lnd's wallet after it has already been created.
 type WalletUnlockParams struct {
@@ -1068,6 +1235,7 @@ func waitForWalletPassword(cfg *Config, restEndpoints []net.Addr,
 	macaroonFiles := []string{
 		filepath.Join(cfg.networkDir, macaroons.DBFilename),
 		cfg.AdminMacPath, cfg.ReadMacPath, cfg.InvoiceMacPath,
+		cfg.ObserverMacPath,
 	}
 	pwService := walletunlocker.New(
 		chainConfig.ChainDir, cfg.ActiveNetParams.Params, !cfg.SyncFreelist,
@@ -1169,7 +1337,7 @@ func waitForWalletPassword(cfg *Config, restEndpoints []net.Addr,
 		)
 		loader := wallet.NewLoader(
 			cfg.ActiveNetParams.Params, netDir, !cfg.SyncFreelist,
-			recoveryWindow,
+			kvdb.DefaultDBTimeout, recoveryWindow,
 		)
 
 		// With the seed, we can now use the wallet loader to create
@@ -1254,6 +1422,7 @@ func initializeDatabases(ctx context.Context,
 			channeldb.OptionSetRejectCacheSize(cfg.Caches.RejectCacheSize),
 			channeldb.OptionSetChannelCacheSize(cfg.Caches.ChannelCacheSize),
 			channeldb.OptionDryRunMigration(cfg.DryRunMigration),
+			channeldb.OptionReadOnly(cfg.MonitorOnly),
 		)
 		switch {
 		case err == channeldb.ErrDryRunMigrationOK:
@@ -1282,6 +1451,7 @@ func initializeDatabases(ctx context.Context,
 			channeldb.OptionSetRejectCacheSize(cfg.Caches.RejectCacheSize),
 			channeldb.OptionSetChannelCacheSize(cfg.Caches.ChannelCacheSize),
 			channeldb.OptionDryRunMigration(cfg.DryRunMigration),
+			channeldb.OptionReadOnly(cfg.MonitorOnly),
 		)
 		switch {
 		// As we want to allow both versions to get thru the dry run
@@ -1305,6 +1475,7 @@ func initializeDatabases(ctx context.Context,
 		remoteChanDB, err = channeldb.CreateWithBackend(
 			databaseBackends.RemoteDB,
 			channeldb.OptionDryRunMigration(cfg.DryRunMigration),
+			channeldb.OptionReadOnly(cfg.MonitorOnly),
 		)
 		switch {
 		case err == channeldb.ErrDryRunMigrationOK:
@@ -1335,6 +1506,34 @@ func initializeDatabases(ctx context.Context,
 	return localChanDB, remoteChanDB, cleanUp, nil
 }
 
+// openTowerClientDB opens the watchtower client database. If a replicated
+// database backend is active, the client database is stored there as well, and
+// an existing local client database is migrated into it first.
+func openTowerClientDB(ctx context.Context, cfg *Config) (*wtdb.ClientDB,
+	error) {
+
+	backend, err := cfg.DB.GetTowerClientBackend(ctx, cfg.networkName())
+	if err != nil {
+		return nil, fmt.Errorf("unable to obtain database backend: %v",
+			err)
+	}
+
+	// Without a replicated backend, the client database is kept in its
+	// own local bolt file.
+	if backend == nil {
+		return wtdb.OpenClientDB(cfg.localDatabaseDir())
+	}
+
+	err = wtdb.MigrateClientDB(cfg.localDatabaseDir(), backend)
+	if err != nil {
+		backend.Close()
+		return nil, fmt.Errorf("unable to migrate local database: %v",
+			err)
+	}
+
+	return wtdb.OpenClientDBWithBackend(backend)
+}
+
 // initNeutrinoBackend inits a new instance of the neutrino light client
 // backend given a target chain directory to store the chain state.
 func initNeutrinoBackend(cfg *Config, chainDir string) (*neutrino.ChainService,
@@ -1353,7 +1552,9 @@ func initNeutrinoBackend(cfg *Config, chainDir string) (*neutrino.ChainService,
 	}
 
 	dbName := filepath.Join(dbPath, "neutrino.db")
-	db, err := walletdb.Create("bdb", dbName, !cfg.SyncFreelist)
+	db, err := walletdb.Create(
+		"bdb", dbName, !cfg.SyncFreelist, kvdb.DefaultDBTimeout,
+	)
 	if err != nil {
 		return nil, nil, fmt.Errorf("unable to create neutrino "+
 			"database: %v", err)
@@ -1367,13 +1568,44 @@ func initNeutrinoBackend(cfg *Config, chainDir string) (*neutrino.ChainService,
 		return nil, nil, err
 	}
 
+	// Neutrino works with a copy of the chain params, so we'll apply the
+	// configured checkpoints to our own copy.
+	chainParams := *cfg.ActiveNetParams.Params
+	customCheckpoints, err := chainreg.ParseCheckpoints(
+		cfg.NeutrinoMode.Checkpoints,
+	)
+	if err != nil {
+		db.Close()
+		return nil, nil, err
+	}
+	defaultCheckpoints := chainParams.Checkpoints
+	if cfg.NeutrinoMode.NoDefaultCheckpoints {
+		defaultCheckpoints = nil
+	}
+	chainParams.Checkpoints = chainreg.MergeCheckpoints(
+		defaultCheckpoints, customCheckpoints,
+	)
+
+	// If a header file to sideload was specified, we'll load its headers
+	// into the header stores before neutrino starts syncing.
+	if cfg.NeutrinoMode.SideloadHeaders != "" {
+		err := sideloadNeutrinoHeaders(
+			cfg.NeutrinoMode.SideloadHeaders, dbPath, db,
+			&chainParams, headerStateAssertion,
+		)
+		if err != nil {
+			db.Close()
+			return nil, nil, err
+		}
+	}
+
 	// With the database open, we can now create an instance of the
 	// neutrino light client. We pass in relevant configuration parameters
 	// required.
 	config := neutrino.Config{
 		DataDir:      dbPath,
 		Database:     db,
-		ChainParams:  *cfg.ActiveNetParams.Params,
+		ChainParams:  chainParams,
 		AddPeers:     cfg.NeutrinoMode.AddPeers,
 		ConnectPeers: cfg.NeutrinoMode.ConnectPeers,
 		Dialer: func(addr net.Addr) (net.Conn, error) {
@@ -1430,6 +1662,31 @@ func initNeutrinoBackend(cfg *Config, chainDir string) (*neutrino.ChainService,
 	return neutrinoCS, cleanUp, nil
 }
 
+// sideloadNeutrinoHeaders loads the block headers and filter headers of the
+// given file into neutrino's header stores.
+func sideloadNeutrinoHeaders(path, dataDir string, db walletdb.DB,
+	params *chaincfg.Params, assertion *headerfs.FilterHeader) error {
+
+	f, err := os.Open(lncfg.CleanAndExpandPath(path))
+	if err != nil {
+		return fmt.Errorf("unable to open header file: %v", err)
+	}
+	defer f.Close()
+
+	ltndLog.Infof("Sideloading neutrino headers from %v", path)
+
+	numHeaders, err := chainreg.SideloadNeutrinoHeaders(
+		f, dataDir, db, params, assertion,
+	)
+	if err != nil {
+		return fmt.Errorf("unable to sideload headers: %v", err)
+	}
+
+	ltndLog.Infof("Sideloaded %v new neutrino headers", numHeaders)
+
+	return nil
+}
+
 // parseHeaderStateAssertion parses the user-specified neutrino header state
 // into a headerfs.FilterHeader.
 func parseHeaderStateAssertion(state string) (*headerfs.FilterHeader, error) {
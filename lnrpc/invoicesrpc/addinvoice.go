@@ -53,8 +53,30 @@ type AddInvoiceConfig struct {
 	// GenInvoiceFeatures returns a feature containing feature bits that
 	// should be advertised on freshly generated invoices.
 	GenInvoiceFeatures func() *lnwire.FeatureVector
+
+	// LookupInvoice is called to look up an invoice that is regenerated.
+	LookupInvoice func(paymentHash lntypes.Hash) (channeldb.Invoice, error)
+
+	// ReissueInvoice is called to replace the payment request of a
+	// regenerated invoice in the registry.
+	ReissueInvoice func(paymentHash lntypes.Hash,
+		reissue *channeldb.InvoiceReissueDesc) (*channeldb.Invoice,
+		error)
 }
 
+const (
+	// maxMppTimeout is the maximum time for which the htlcs of an
+	// incomplete multi-path payment can be held.
+	maxMppTimeout = 24 * time.Hour
+
+	// maxInvoiceExpiry is the maximum expiry of a payment request.
+	maxInvoiceExpiry = time.Hour * 24 * 365
+
+	// numMaxHophints is the maximum number of individual route hints of
+	// a payment request, to avoid creating overly large invoices.
+	numMaxHophints = 20
+)
+
 // AddInvoiceData contains the required data to create a new invoice.
 type AddInvoiceData struct {
 	// An optional memo to attach along with the invoice. Used for record
@@ -97,6 +119,15 @@ type AddInvoiceData struct {
 	// HodlInvoice signals that this invoice shouldn't be settled
 	// immediately upon receiving the payment.
 	HodlInvoice bool
+
+	// MppTimeout is the time after which the htlcs of an incomplete
+	// multi-path payment to this invoice are canceled back. If zero, the
+	// default timeout is used.
+	MppTimeout time.Duration
+
+	// RequireApproval signals that the funds received for this invoice
+	// should be held until they are explicitly approved or rejected.
+	RequireApproval bool
 }
 
 // AddInvoice attempts to add a new invoice to the invoice database. Any
@@ -201,19 +232,22 @@ func AddInvoice(ctx context.Context, cfg *AddInvoiceConfig,
 		// We'll ensure that the specified expiry is restricted to sane
 		// number of seconds. As a result, we'll reject an invoice with
 		// an expiry greater than 1 year.
-		maxExpiry := time.Hour * 24 * 365
-		expSeconds := invoice.Expiry
-
-		if float64(expSeconds) > maxExpiry.Seconds() {
-			return nil, nil, fmt.Errorf("expiry of %v seconds "+
-				"greater than max expiry of %v seconds",
-				float64(expSeconds), maxExpiry.Seconds())
+		if err := validateExpiry(invoice.Expiry); err != nil {
+			return nil, nil, err
 		}
 
 		expiry := time.Duration(invoice.Expiry) * time.Second
 		options = append(options, zpay32.Expiry(expiry))
 	}
 
+	// Restrict the mpp timeout, as htlcs of incomplete payments occupy
+	// slots in our channels while they're held.
+	if invoice.MppTimeout > maxMppTimeout {
+		return nil, nil, fmt.Errorf("mpp timeout of %v greater than "+
+			"max mpp timeout of %v", invoice.MppTimeout,
+			maxMppTimeout)
+	}
+
 	// If the description hash is set, then we add it do the list of options.
 	// If not, use the memo field as the payment request description.
 	if len(invoice.DescriptionHash) > 0 {
@@ -233,12 +267,14 @@ func AddInvoice(ctx context.Context, cfg *AddInvoiceConfig,
 		return nil, nil, fmt.Errorf("CLTV delta of %v is too large, max "+
 			"accepted is: %v", invoice.CltvExpiry, math.MaxUint16)
 	case invoice.CltvExpiry != 0:
-		// Disallow user-chosen final CLTV deltas below the required
-		// minimum.
-		if invoice.CltvExpiry < routing.MinCLTVDelta {
-			return nil, nil, fmt.Errorf("CLTV delta of %v must be "+
-				"greater than minimum of %v",
-				routing.MinCLTVDelta, invoice.CltvExpiry)
+		// Disallow user-chosen final CLTV deltas that are unsafe for
+		// the chain we're on.
+		cltvLimits := routing.NewCltvLimits(
+			cfg.ChainParams.TargetTimePerBlock,
+		)
+		err := cltvLimits.ValidateDelta(uint32(invoice.CltvExpiry))
+		if err != nil {
+			return nil, nil, err
 		}
 
 		options = append(options,
@@ -253,21 +289,12 @@ func AddInvoice(ctx context.Context, cfg *AddInvoiceConfig,
 	// we'll fetch all of our available private channels and create routing
 	// hints for them.
 	if invoice.Private {
-		openChannels, err := cfg.ChanDB.FetchAllChannels()
+		hopHints, err := privateHopHints(amtMSat, cfg)
 		if err != nil {
-			return nil, nil, fmt.Errorf("could not fetch all channels")
+			return nil, nil, err
 		}
 
-		if len(openChannels) > 0 {
-			// We'll restrict the number of individual route hints
-			// to 20 to avoid creating overly large invoices.
-			const numMaxHophints = 20
-			hopHints := selectHopHints(
-				amtMSat, cfg, openChannels, numMaxHophints,
-			)
-
-			options = append(options, hopHints...)
-		}
+		options = append(options, hopHints...)
 	}
 
 	// Set our desired invoice features and add them to our list of options.
@@ -313,7 +340,9 @@ func AddInvoice(ctx context.Context, cfg *AddInvoiceConfig,
 			PaymentAddr:     paymentAddr,
 			Features:        invoiceFeatures,
 		},
-		HodlInvoice: invoice.HodlInvoice,
+		HodlInvoice:     invoice.HodlInvoice,
+		MppTimeout:      invoice.MppTimeout,
+		RequireApproval: invoice.RequireApproval,
 	}
 
 	log.Tracef("[addinvoice] adding new invoice %v",
@@ -331,6 +360,140 @@ func AddInvoice(ctx context.Context, cfg *AddInvoiceConfig,
 	return &paymentHash, newInvoice, nil
 }
 
+// RegenerateInvoice reissues an open invoice with a new payment request. The
+// payment hash, amount, payment address and the other terms of the invoice are
+// preserved, while the route hints are selected anew and the expiry starts
+// over. This allows an invoice to be paid even if the channels of its route
+// hints were closed after it was created. If expiry is zero, the expiry of the
+// current payment request is reused.
+func RegenerateInvoice(ctx context.Context, cfg *AddInvoiceConfig,
+	paymentHash lntypes.Hash, expiry int64) (*channeldb.Invoice, error) {
+
+	invoice, err := cfg.LookupInvoice(paymentHash)
+	if err != nil {
+		return nil, err
+	}
+	if invoice.State != channeldb.ContractOpen {
+		return nil, fmt.Errorf("invoice in state %v can't be "+
+			"regenerated", invoice.State)
+	}
+	if len(invoice.PaymentRequest) == 0 {
+		return nil, channeldb.ErrInvoiceNoPaymentRequest
+	}
+
+	payReq, err := zpay32.Decode(
+		string(invoice.PaymentRequest), cfg.ChainParams,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	newExpiry := payReq.Expiry()
+	if expiry > 0 {
+		if err := validateExpiry(expiry); err != nil {
+			return nil, err
+		}
+		newExpiry = time.Duration(expiry) * time.Second
+	}
+
+	// Carry over all fields of the current payment request, except for
+	// the route hints and the expiry.
+	options := []func(*zpay32.Invoice){
+		zpay32.CLTVExpiry(payReq.MinFinalCLTVExpiry()),
+		zpay32.Expiry(newExpiry),
+		zpay32.Features(invoice.Terms.Features),
+	}
+	if payReq.MilliSat != nil {
+		options = append(options, zpay32.Amount(*payReq.MilliSat))
+	}
+	if payReq.DescriptionHash != nil {
+		options = append(
+			options, zpay32.DescriptionHash(*payReq.DescriptionHash),
+		)
+	}
+	if payReq.Description != nil {
+		options = append(
+			options, zpay32.Description(*payReq.Description),
+		)
+	}
+	if payReq.FallbackAddr != nil {
+		options = append(
+			options, zpay32.FallbackAddr(payReq.FallbackAddr),
+		)
+	}
+	if invoice.Terms.PaymentAddr != channeldb.BlankPayAddr {
+		options = append(
+			options, zpay32.PaymentAddr(invoice.Terms.PaymentAddr),
+		)
+	}
+
+	// Only invoices that were created with route hints get fresh ones, so
+	// we don't reveal private channels that weren't revealed before.
+	if len(payReq.RouteHints) > 0 {
+		hopHints, err := privateHopHints(invoice.Terms.Value, cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		options = append(options, hopHints...)
+	}
+
+	creationDate := time.Now()
+	newPayReq, err := zpay32.NewInvoice(
+		cfg.ChainParams, paymentHash, creationDate, options...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	payReqString, err := newPayReq.Encode(
+		zpay32.MessageSigner{
+			SignCompact: cfg.NodeSigner.SignDigestCompact,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// The payment request is only replaced if it wasn't replaced
+	// concurrently since we looked up the invoice.
+	return cfg.ReissueInvoice(paymentHash, &channeldb.InvoiceReissueDesc{
+		PrevPaymentRequest: invoice.PaymentRequest,
+		PaymentRequest:     []byte(payReqString),
+		CreationDate:       creationDate,
+		Expiry:             newPayReq.Expiry(),
+	})
+}
+
+// validateExpiry checks that the passed payment request expiry in seconds
+// doesn't exceed the maximum expiry.
+func validateExpiry(expiry int64) error {
+	if float64(expiry) > maxInvoiceExpiry.Seconds() {
+		return fmt.Errorf("expiry of %v seconds greater than max "+
+			"expiry of %v seconds", float64(expiry),
+			maxInvoiceExpiry.Seconds())
+	}
+
+	return nil
+}
+
+// privateHopHints returns route hints for our private channels that are able
+// to receive the passed amount.
+func privateHopHints(amtMSat lnwire.MilliSatoshi,
+	cfg *AddInvoiceConfig) ([]func(*zpay32.Invoice), error) {
+
+	openChannels, err := cfg.ChanDB.FetchAllChannels()
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch all channels")
+	}
+
+	if len(openChannels) == 0 {
+		return nil, nil
+	}
+
+	return selectHopHints(amtMSat, cfg, openChannels, numMaxHophints), nil
+}
+
 // chanCanBeHopHint returns true if the target channel is eligible to be a hop
 // hint.
 func chanCanBeHopHint(channel *channeldb.OpenChannel, cfg *AddInvoiceConfig) (
@@ -397,14 +560,39 @@ func chanCanBeHopHint(channel *channeldb.OpenChannel, cfg *AddInvoiceConfig) (
 	return remotePolicy, true
 }
 
+// hopHintChanID returns the short channel ID that is used for the passed
+// channel in hop hints. If the peer handed out an alias for the channel, the
+// alias is used, so the funding outpoint of the channel isn't revealed.
+func hopHintChanID(channel *channeldb.OpenChannel,
+	cfg *AddInvoiceConfig) lnwire.ShortChannelID {
+
+	shortChanID := channel.ShortChanID()
+	if cfg.ChanDB == nil {
+		return shortChanID
+	}
+
+	alias, err := cfg.ChanDB.FetchPeerScidAlias(shortChanID)
+	switch {
+	case err == channeldb.ErrScidAliasNotFound:
+		return shortChanID
+
+	case err != nil:
+		log.Errorf("Unable to fetch scid alias of channel %v: %v",
+			shortChanID, err)
+		return shortChanID
+	}
+
+	return alias
+}
+
 // addHopHint creates a hop hint out of the passed channel and channel policy.
 // The new hop hint is appended to the passed slice.
-func addHopHint(hopHints *[]func(*zpay32.Invoice),
+func addHopHint(hopHints *[]func(*zpay32.Invoice), cfg *AddInvoiceConfig,
 	channel *channeldb.OpenChannel, chanPolicy *channeldb.ChannelEdgePolicy) {
 
 	hopHint := zpay32.HopHint{
 		NodeID:      channel.IdentityPub,
-		ChannelID:   channel.ShortChanID().ToUint64(),
+		ChannelID:   hopHintChanID(channel, cfg).ToUint64(),
 		FeeBaseMSat: uint32(chanPolicy.FeeBaseMSat),
 		FeeProportionalMillionths: uint32(
 			chanPolicy.FeeProportionalMillionths,
@@ -446,7 +634,7 @@ func selectHopHints(amtMSat lnwire.MilliSatoshi, cfg *AddInvoiceConfig,
 
 		// Now that we now this channel use usable, add it as a hop
 		// hint and the indexes we'll use later.
-		addHopHint(&hopHints, channel, edgePolicy)
+		addHopHint(&hopHints, cfg, channel, edgePolicy)
 
 		hopHintChans[channel.FundingOutpoint] = struct{}{}
 		totalHintBandwidth += channel.LocalCommitment.RemoteBalance
@@ -490,7 +678,7 @@ func selectHopHints(amtMSat lnwire.MilliSatoshi, cfg *AddInvoiceConfig,
 
 		// Include the route hint in our set of options that will be
 		// used when creating the invoice.
-		addHopHint(&hopHints, channel, remotePolicy)
+		addHopHint(&hopHints, cfg, channel, remotePolicy)
 
 		// As we've just added a new hop hint, we'll accumulate it's
 		// available balance now to update our tally.
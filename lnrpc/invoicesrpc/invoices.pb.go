@@ -131,7 +131,12 @@ type AddHoldInvoiceRequest struct {
 	//invoice's destination.
 	RouteHints []*lnrpc.RouteHint `protobuf:"bytes,8,rep,name=route_hints,json=routeHints,proto3" json:"route_hints,omitempty"`
 	// Whether this invoice should include routing hints for private channels.
-	Private              bool     `protobuf:"varint,9,opt,name=private,proto3" json:"private,omitempty"`
+	Private bool `protobuf:"varint,9,opt,name=private,proto3" json:"private,omitempty"`
+	//
+	//The time in seconds after which the HTLCs of an incomplete multi-path
+	//payment to this invoice are canceled back. If not set, the default timeout
+	//of 120 seconds is used.
+	MppTimeout           uint64   `protobuf:"varint,11,opt,name=mpp_timeout,json=mppTimeout,proto3" json:"mpp_timeout,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -232,6 +237,13 @@ func (m *AddHoldInvoiceRequest) GetPrivate() bool {
 	return false
 }
 
+func (m *AddHoldInvoiceRequest) GetMppTimeout() uint64 {
+	if m != nil {
+		return m.MppTimeout
+	}
+	return 0
+}
+
 type AddHoldInvoiceResp struct {
 	//
 	//A bare-bones invoice for a payment within the Lightning Network.  With the
@@ -347,6 +359,219 @@ func (m *SettleInvoiceResp) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_SettleInvoiceResp proto.InternalMessageInfo
 
+type ApproveInvoiceMsg struct {
+	// Hash corresponding to the invoice of which to approve the funds.
+	PaymentHash          []byte   `protobuf:"bytes,1,opt,name=payment_hash,json=paymentHash,proto3" json:"payment_hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ApproveInvoiceMsg) Reset()         { *m = ApproveInvoiceMsg{} }
+func (m *ApproveInvoiceMsg) String() string { return proto.CompactTextString(m) }
+func (*ApproveInvoiceMsg) ProtoMessage()    {}
+func (*ApproveInvoiceMsg) Descriptor() ([]byte, []int) {
+	return fileDescriptor_090ab9c4958b987d, []int{6}
+}
+
+func (m *ApproveInvoiceMsg) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApproveInvoiceMsg.Unmarshal(m, b)
+}
+func (m *ApproveInvoiceMsg) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApproveInvoiceMsg.Marshal(b, m, deterministic)
+}
+func (m *ApproveInvoiceMsg) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApproveInvoiceMsg.Merge(m, src)
+}
+func (m *ApproveInvoiceMsg) XXX_Size() int {
+	return xxx_messageInfo_ApproveInvoiceMsg.Size(m)
+}
+func (m *ApproveInvoiceMsg) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApproveInvoiceMsg.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApproveInvoiceMsg proto.InternalMessageInfo
+
+func (m *ApproveInvoiceMsg) GetPaymentHash() []byte {
+	if m != nil {
+		return m.PaymentHash
+	}
+	return nil
+}
+
+type ApproveInvoiceResp struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ApproveInvoiceResp) Reset()         { *m = ApproveInvoiceResp{} }
+func (m *ApproveInvoiceResp) String() string { return proto.CompactTextString(m) }
+func (*ApproveInvoiceResp) ProtoMessage()    {}
+func (*ApproveInvoiceResp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_090ab9c4958b987d, []int{7}
+}
+
+func (m *ApproveInvoiceResp) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApproveInvoiceResp.Unmarshal(m, b)
+}
+func (m *ApproveInvoiceResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApproveInvoiceResp.Marshal(b, m, deterministic)
+}
+func (m *ApproveInvoiceResp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApproveInvoiceResp.Merge(m, src)
+}
+func (m *ApproveInvoiceResp) XXX_Size() int {
+	return xxx_messageInfo_ApproveInvoiceResp.Size(m)
+}
+func (m *ApproveInvoiceResp) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApproveInvoiceResp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApproveInvoiceResp proto.InternalMessageInfo
+
+type RejectInvoiceMsg struct {
+	// Hash corresponding to the invoice of which to reject the funds.
+	PaymentHash          []byte   `protobuf:"bytes,1,opt,name=payment_hash,json=paymentHash,proto3" json:"payment_hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RejectInvoiceMsg) Reset()         { *m = RejectInvoiceMsg{} }
+func (m *RejectInvoiceMsg) String() string { return proto.CompactTextString(m) }
+func (*RejectInvoiceMsg) ProtoMessage()    {}
+func (*RejectInvoiceMsg) Descriptor() ([]byte, []int) {
+	return fileDescriptor_090ab9c4958b987d, []int{8}
+}
+
+func (m *RejectInvoiceMsg) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RejectInvoiceMsg.Unmarshal(m, b)
+}
+func (m *RejectInvoiceMsg) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RejectInvoiceMsg.Marshal(b, m, deterministic)
+}
+func (m *RejectInvoiceMsg) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RejectInvoiceMsg.Merge(m, src)
+}
+func (m *RejectInvoiceMsg) XXX_Size() int {
+	return xxx_messageInfo_RejectInvoiceMsg.Size(m)
+}
+func (m *RejectInvoiceMsg) XXX_DiscardUnknown() {
+	xxx_messageInfo_RejectInvoiceMsg.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RejectInvoiceMsg proto.InternalMessageInfo
+
+func (m *RejectInvoiceMsg) GetPaymentHash() []byte {
+	if m != nil {
+		return m.PaymentHash
+	}
+	return nil
+}
+
+type RejectInvoiceResp struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RejectInvoiceResp) Reset()         { *m = RejectInvoiceResp{} }
+func (m *RejectInvoiceResp) String() string { return proto.CompactTextString(m) }
+func (*RejectInvoiceResp) ProtoMessage()    {}
+func (*RejectInvoiceResp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_090ab9c4958b987d, []int{9}
+}
+
+func (m *RejectInvoiceResp) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RejectInvoiceResp.Unmarshal(m, b)
+}
+func (m *RejectInvoiceResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RejectInvoiceResp.Marshal(b, m, deterministic)
+}
+func (m *RejectInvoiceResp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RejectInvoiceResp.Merge(m, src)
+}
+func (m *RejectInvoiceResp) XXX_Size() int {
+	return xxx_messageInfo_RejectInvoiceResp.Size(m)
+}
+func (m *RejectInvoiceResp) XXX_DiscardUnknown() {
+	xxx_messageInfo_RejectInvoiceResp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RejectInvoiceResp proto.InternalMessageInfo
+
+type ListPendingApprovalsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListPendingApprovalsRequest) Reset()         { *m = ListPendingApprovalsRequest{} }
+func (m *ListPendingApprovalsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListPendingApprovalsRequest) ProtoMessage()    {}
+func (*ListPendingApprovalsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_090ab9c4958b987d, []int{10}
+}
+
+func (m *ListPendingApprovalsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListPendingApprovalsRequest.Unmarshal(m, b)
+}
+func (m *ListPendingApprovalsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListPendingApprovalsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListPendingApprovalsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListPendingApprovalsRequest.Merge(m, src)
+}
+func (m *ListPendingApprovalsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListPendingApprovalsRequest.Size(m)
+}
+func (m *ListPendingApprovalsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListPendingApprovalsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListPendingApprovalsRequest proto.InternalMessageInfo
+
+type ListPendingApprovalsResponse struct {
+	// The invoices of which the received funds await approval.
+	Invoices             []*lnrpc.Invoice `protobuf:"bytes,1,rep,name=invoices,proto3" json:"invoices,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ListPendingApprovalsResponse) Reset()         { *m = ListPendingApprovalsResponse{} }
+func (m *ListPendingApprovalsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListPendingApprovalsResponse) ProtoMessage()    {}
+func (*ListPendingApprovalsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_090ab9c4958b987d, []int{11}
+}
+
+func (m *ListPendingApprovalsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListPendingApprovalsResponse.Unmarshal(m, b)
+}
+func (m *ListPendingApprovalsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListPendingApprovalsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListPendingApprovalsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListPendingApprovalsResponse.Merge(m, src)
+}
+func (m *ListPendingApprovalsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListPendingApprovalsResponse.Size(m)
+}
+func (m *ListPendingApprovalsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListPendingApprovalsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListPendingApprovalsResponse proto.InternalMessageInfo
+
+func (m *ListPendingApprovalsResponse) GetInvoices() []*lnrpc.Invoice {
+	if m != nil {
+		return m.Invoices
+	}
+	return nil
+}
+
 type SubscribeSingleInvoiceRequest struct {
 	// Hash corresponding to the (hold) invoice to subscribe to.
 	RHash                []byte   `protobuf:"bytes,2,opt,name=r_hash,json=rHash,proto3" json:"r_hash,omitempty"`
@@ -359,7 +584,7 @@ func (m *SubscribeSingleInvoiceRequest) Reset()         { *m = SubscribeSingleIn
 func (m *SubscribeSingleInvoiceRequest) String() string { return proto.CompactTextString(m) }
 func (*SubscribeSingleInvoiceRequest) ProtoMessage()    {}
 func (*SubscribeSingleInvoiceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_090ab9c4958b987d, []int{6}
+	return fileDescriptor_090ab9c4958b987d, []int{12}
 }
 
 func (m *SubscribeSingleInvoiceRequest) XXX_Unmarshal(b []byte) error {
@@ -394,46 +619,61 @@ func init() {
 	proto.RegisterType((*AddHoldInvoiceResp)(nil), "invoicesrpc.AddHoldInvoiceResp")
 	proto.RegisterType((*SettleInvoiceMsg)(nil), "invoicesrpc.SettleInvoiceMsg")
 	proto.RegisterType((*SettleInvoiceResp)(nil), "invoicesrpc.SettleInvoiceResp")
+	proto.RegisterType((*ApproveInvoiceMsg)(nil), "invoicesrpc.ApproveInvoiceMsg")
+	proto.RegisterType((*ApproveInvoiceResp)(nil), "invoicesrpc.ApproveInvoiceResp")
+	proto.RegisterType((*RejectInvoiceMsg)(nil), "invoicesrpc.RejectInvoiceMsg")
+	proto.RegisterType((*RejectInvoiceResp)(nil), "invoicesrpc.RejectInvoiceResp")
+	proto.RegisterType((*ListPendingApprovalsRequest)(nil), "invoicesrpc.ListPendingApprovalsRequest")
+	proto.RegisterType((*ListPendingApprovalsResponse)(nil), "invoicesrpc.ListPendingApprovalsResponse")
 	proto.RegisterType((*SubscribeSingleInvoiceRequest)(nil), "invoicesrpc.SubscribeSingleInvoiceRequest")
 }
 
 func init() { proto.RegisterFile("invoicesrpc/invoices.proto", fileDescriptor_090ab9c4958b987d) }
 
 var fileDescriptor_090ab9c4958b987d = []byte{
-	// 523 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x53, 0x4d, 0x6f, 0xd3, 0x40,
-	0x10, 0x95, 0xf3, 0xd5, 0x64, 0x92, 0xa6, 0x61, 0xa1, 0x95, 0x65, 0x29, 0x34, 0x98, 0x03, 0x81,
-	0x83, 0x43, 0x5b, 0x71, 0x83, 0x43, 0x41, 0x48, 0x01, 0xa9, 0x1c, 0x1c, 0xc1, 0x81, 0x8b, 0xb5,
-	0xb1, 0x17, 0x7b, 0xd5, 0xf5, 0x7a, 0xd9, 0xdd, 0x04, 0xfa, 0x17, 0xf9, 0x07, 0xfc, 0x1b, 0xe4,
-	0xf5, 0xa6, 0xb2, 0x43, 0xdb, 0xdb, 0xcc, 0x9b, 0xd9, 0xe7, 0xa7, 0xf7, 0xc6, 0xe0, 0x51, 0xbe,
-	0x2d, 0x68, 0x4c, 0x94, 0x14, 0xf1, 0x62, 0x57, 0x07, 0x42, 0x16, 0xba, 0x40, 0xc3, 0xda, 0xcc,
-	0x1b, 0x48, 0x11, 0x57, 0xb8, 0xff, 0x06, 0x26, 0x1f, 0x30, 0x8f, 0x09, 0xfb, 0x54, 0xcd, 0xaf,
-	0x54, 0x8a, 0x9e, 0xc1, 0x48, 0xe0, 0x9b, 0x9c, 0x70, 0x1d, 0x65, 0x58, 0x65, 0xae, 0x33, 0x73,
-	0xe6, 0xa3, 0x70, 0x68, 0xb1, 0x25, 0x56, 0x99, 0xff, 0x18, 0x1e, 0x35, 0x9e, 0x85, 0x44, 0x09,
-	0xff, 0x4f, 0x0b, 0x8e, 0x2f, 0x93, 0x64, 0x59, 0xb0, 0xe4, 0x16, 0xfe, 0xb9, 0x21, 0x4a, 0x23,
-	0x04, 0x9d, 0x9c, 0xe4, 0x85, 0x61, 0x1a, 0x84, 0xa6, 0x2e, 0x31, 0xc3, 0xde, 0x32, 0xec, 0xa6,
-	0x46, 0x4f, 0xa0, 0xbb, 0xc5, 0x6c, 0x43, 0xdc, 0xf6, 0xcc, 0x99, 0xb7, 0xc3, 0xaa, 0x41, 0x53,
-	0x00, 0x53, 0x44, 0xb9, 0xc2, 0xda, 0x05, 0x33, 0x1a, 0x18, 0xe4, 0x4a, 0x61, 0x8d, 0x5e, 0xc2,
-	0x24, 0x21, 0x2a, 0x96, 0x54, 0x68, 0x5a, 0xf0, 0x4a, 0x72, 0xc7, 0x90, 0x1e, 0xd5, 0xf0, 0x52,
-	0x36, 0x3a, 0x81, 0x1e, 0xf9, 0x2d, 0xa8, 0xbc, 0x71, 0xbb, 0x86, 0xc5, 0x76, 0xe8, 0x39, 0x1c,
-	0xfe, 0xc0, 0x8c, 0xad, 0x71, 0x7c, 0x1d, 0xe1, 0x24, 0x91, 0x6e, 0xcf, 0x08, 0x1d, 0xed, 0xc0,
-	0xcb, 0x24, 0x91, 0xe8, 0x14, 0x86, 0x31, 0xd3, 0xdb, 0xc8, 0x32, 0x1c, 0xcc, 0x9c, 0x79, 0x27,
-	0x84, 0x12, 0xfa, 0x58, 0xb1, 0x9c, 0xc1, 0x50, 0x16, 0x1b, 0x4d, 0xa2, 0x8c, 0x72, 0xad, 0xdc,
-	0xfe, 0xac, 0x3d, 0x1f, 0x9e, 0x4f, 0x02, 0xc6, 0x4b, 0xbb, 0xc3, 0x72, 0xb2, 0xa4, 0x5c, 0x87,
-	0x20, 0x77, 0xa5, 0x42, 0x2e, 0x1c, 0x08, 0x49, 0xb7, 0x58, 0x13, 0x77, 0x30, 0x73, 0xe6, 0xfd,
-	0x70, 0xd7, 0xfa, 0xef, 0x00, 0xed, 0x7b, 0xa9, 0x04, 0x7a, 0x01, 0x47, 0xbb, 0x68, 0x64, 0xe5,
-	0xad, 0xf5, 0x74, 0x6c, 0x61, 0xeb, 0xb8, 0x1f, 0xc0, 0x64, 0x45, 0xb4, 0x66, 0xa4, 0x96, 0xab,
-	0x07, 0x7d, 0x21, 0x09, 0xcd, 0x71, 0x4a, 0x6c, 0xa6, 0xb7, 0x7d, 0x19, 0x68, 0x63, 0xdf, 0x04,
-	0xfa, 0x16, 0xa6, 0xab, 0xcd, 0xba, 0xb4, 0x70, 0x4d, 0x56, 0x94, 0xa7, 0xb5, 0x69, 0x95, 0xeb,
-	0x31, 0xf4, 0x64, 0x54, 0x4b, 0xb1, 0x2b, 0x4b, 0x9b, 0x3f, 0x77, 0xfa, 0xce, 0xa4, 0x75, 0xfe,
-	0xb7, 0x05, 0x7d, 0xbb, 0xaf, 0xd0, 0x37, 0x38, 0xb9, 0x9b, 0x0a, 0xbd, 0x0a, 0x6a, 0xa7, 0x19,
-	0x3c, 0xf8, 0x3d, 0x6f, 0x6c, 0xcd, 0xb4, 0xf0, 0x6b, 0x07, 0x7d, 0x81, 0xc3, 0xc6, 0x21, 0xa2,
-	0x69, 0x83, 0x6e, 0xff, 0xb6, 0xbd, 0xa7, 0xf7, 0x8f, 0x8d, 0xc1, 0x5f, 0x61, 0xdc, 0xb4, 0x1d,
-	0xf9, 0x8d, 0x17, 0x77, 0xde, 0xb7, 0x77, 0xfa, 0xe0, 0x8e, 0x12, 0xa5, 0xcc, 0x86, 0xbd, 0x7b,
-	0x32, 0xf7, 0xa3, 0xda, 0x93, 0xf9, 0x5f, 0x32, 0xef, 0x2f, 0xbe, 0x9f, 0xa5, 0x54, 0x67, 0x9b,
-	0x75, 0x10, 0x17, 0xf9, 0x82, 0xd1, 0x34, 0xd3, 0x9c, 0xf2, 0x94, 0x13, 0xfd, 0xab, 0x90, 0xd7,
-	0x0b, 0xc6, 0x93, 0x85, 0x71, 0x6a, 0x51, 0xa3, 0x59, 0xf7, 0xcc, 0x2f, 0x7f, 0xf1, 0x2f, 0x00,
-	0x00, 0xff, 0xff, 0xc3, 0xb1, 0x8c, 0x5e, 0x28, 0x04, 0x00, 0x00,
+	// 658 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x55, 0x51, 0x6f, 0xd3, 0x30,
+	0x10, 0x56, 0xb6, 0xae, 0x6b, 0xaf, 0x5b, 0xd7, 0x99, 0x6d, 0x8a, 0x02, 0xdd, 0x4a, 0x78, 0xa0,
+	0x1b, 0xa2, 0x85, 0xa1, 0xf1, 0x04, 0x0f, 0x03, 0x21, 0x8d, 0x89, 0x21, 0xc8, 0x80, 0x07, 0x5e,
+	0xa2, 0x34, 0x31, 0xad, 0x59, 0x62, 0x1b, 0xdb, 0x2d, 0xec, 0x27, 0x23, 0xfe, 0x04, 0x8a, 0x93,
+	0x4c, 0x71, 0x68, 0x2b, 0xf6, 0x76, 0xfe, 0x3e, 0xdf, 0x77, 0xd7, 0xbb, 0xcf, 0x0d, 0x38, 0x84,
+	0xce, 0x18, 0x09, 0xb1, 0x14, 0x3c, 0x1c, 0x16, 0xf1, 0x80, 0x0b, 0xa6, 0x18, 0x6a, 0x95, 0x38,
+	0xa7, 0x29, 0x78, 0x98, 0xe1, 0xee, 0x09, 0x74, 0x5e, 0x07, 0x34, 0xc4, 0xf1, 0xdb, 0x8c, 0xbf,
+	0x90, 0x63, 0x74, 0x1f, 0x36, 0x78, 0x70, 0x9d, 0x60, 0xaa, 0xfc, 0x49, 0x20, 0x27, 0xb6, 0xd5,
+	0xb3, 0xfa, 0x1b, 0x5e, 0x2b, 0xc7, 0xce, 0x02, 0x39, 0x71, 0xef, 0xc0, 0xb6, 0x91, 0xe6, 0x61,
+	0xc9, 0xdd, 0x3f, 0x2b, 0xb0, 0x7b, 0x1a, 0x45, 0x67, 0x2c, 0x8e, 0x6e, 0xe0, 0x1f, 0x53, 0x2c,
+	0x15, 0x42, 0x50, 0x4b, 0x70, 0xc2, 0xb4, 0x52, 0xd3, 0xd3, 0x71, 0x8a, 0x69, 0xf5, 0x15, 0xad,
+	0xae, 0x63, 0xb4, 0x03, 0x6b, 0xb3, 0x20, 0x9e, 0x62, 0x7b, 0xb5, 0x67, 0xf5, 0x57, 0xbd, 0xec,
+	0x80, 0xba, 0x00, 0x3a, 0xf0, 0x13, 0x19, 0x28, 0x1b, 0x34, 0xd5, 0xd4, 0xc8, 0x85, 0x0c, 0x14,
+	0x3a, 0x84, 0x4e, 0x84, 0x65, 0x28, 0x08, 0x57, 0x84, 0xd1, 0xac, 0xe5, 0x9a, 0x16, 0xdd, 0x2a,
+	0xe1, 0x69, 0xdb, 0x68, 0x0f, 0xea, 0xf8, 0x17, 0x27, 0xe2, 0xda, 0x5e, 0xd3, 0x2a, 0xf9, 0x09,
+	0x3d, 0x80, 0xcd, 0x6f, 0x41, 0x1c, 0x8f, 0x82, 0xf0, 0xca, 0x0f, 0xa2, 0x48, 0xd8, 0x75, 0xdd,
+	0xe8, 0x46, 0x01, 0x9e, 0x46, 0x91, 0x40, 0x07, 0xd0, 0x0a, 0x63, 0x35, 0xf3, 0x73, 0x85, 0xf5,
+	0x9e, 0xd5, 0xaf, 0x79, 0x90, 0x42, 0x6f, 0x32, 0x95, 0xa7, 0xd0, 0x12, 0x6c, 0xaa, 0xb0, 0x3f,
+	0x21, 0x54, 0x49, 0xbb, 0xd1, 0x5b, 0xed, 0xb7, 0x8e, 0x3b, 0x83, 0x98, 0xa6, 0xe3, 0xf6, 0x52,
+	0xe6, 0x8c, 0x50, 0xe5, 0x81, 0x28, 0x42, 0x89, 0x6c, 0x58, 0xe7, 0x82, 0xcc, 0x02, 0x85, 0xed,
+	0x66, 0xcf, 0xea, 0x37, 0xbc, 0xe2, 0x98, 0x56, 0x4b, 0x38, 0xf7, 0x15, 0x49, 0x30, 0x9b, 0x2a,
+	0xbb, 0x95, 0x55, 0x4b, 0x38, 0xff, 0x94, 0x21, 0xee, 0x4b, 0x40, 0xd5, 0x61, 0x4b, 0x8e, 0x1e,
+	0xc2, 0x56, 0xb1, 0x3b, 0x91, 0x0d, 0x3f, 0x1f, 0x7a, 0x3b, 0x87, 0xf3, 0x95, 0xb8, 0x03, 0xe8,
+	0x5c, 0x62, 0xa5, 0x62, 0x5c, 0x5a, 0xbc, 0x03, 0x0d, 0x2e, 0x30, 0x49, 0x82, 0x31, 0xce, 0x97,
+	0x7e, 0x73, 0x4e, 0x37, 0x6e, 0xdc, 0xd7, 0x1b, 0x7f, 0x0e, 0xdb, 0xa7, 0x9c, 0x0b, 0x36, 0xc3,
+	0xb7, 0xb3, 0xcf, 0x0e, 0x20, 0x33, 0x4f, 0xab, 0x9d, 0x40, 0xc7, 0xc3, 0xdf, 0x71, 0xa8, 0x6e,
+	0xed, 0x45, 0x23, 0x4d, 0x6b, 0x75, 0xe1, 0xee, 0x3b, 0x22, 0xd5, 0x07, 0x4c, 0x23, 0x42, 0xc7,
+	0x59, 0xb1, 0x20, 0x96, 0xc5, 0xaf, 0x3f, 0x87, 0x7b, 0xf3, 0x69, 0xc9, 0x19, 0x95, 0x18, 0x1d,
+	0x41, 0xa3, 0x78, 0x30, 0xb6, 0xa5, 0xf7, 0xd8, 0xce, 0xf7, 0x58, 0x14, 0xb9, 0xe1, 0xdd, 0x17,
+	0xd0, 0xbd, 0x9c, 0x8e, 0x52, 0xa3, 0x8d, 0xf0, 0x25, 0xa1, 0xe3, 0xd2, 0x88, 0x32, 0xf7, 0xef,
+	0x42, 0x5d, 0xf8, 0x25, 0xaf, 0xaf, 0x89, 0xb4, 0xef, 0xf3, 0x5a, 0xc3, 0xea, 0xac, 0x1c, 0xff,
+	0xae, 0x41, 0x23, 0xbf, 0x2f, 0xd1, 0x17, 0xd8, 0x9b, 0x2f, 0x85, 0x8e, 0x06, 0xa5, 0x07, 0x3c,
+	0x58, 0x5a, 0xcf, 0xa9, 0xb4, 0xfa, 0xc4, 0x42, 0xef, 0x61, 0xd3, 0x78, 0xae, 0xa8, 0x6b, 0xc8,
+	0x55, 0xff, 0x01, 0x9c, 0xfd, 0xc5, 0xb4, 0x76, 0xd9, 0x67, 0x68, 0x9b, 0xde, 0x43, 0xae, 0x91,
+	0x31, 0xf7, 0x5f, 0xc0, 0x39, 0x58, 0x7a, 0x47, 0xf2, 0xb4, 0x4d, 0xc3, 0x63, 0x95, 0x36, 0xab,
+	0x7e, 0x75, 0xf6, 0x17, 0xd3, 0x5a, 0xef, 0x23, 0xb4, 0x4d, 0x9b, 0x21, 0x33, 0xe3, 0x1f, 0xef,
+	0x3a, 0x07, 0x4b, 0xf8, 0xa2, 0x45, 0xc3, 0x6c, 0x95, 0x16, 0xab, 0xfe, 0x75, 0xf6, 0x17, 0xd3,
+	0x5a, 0xef, 0x0a, 0x76, 0xe6, 0x19, 0x11, 0xf5, 0x8d, 0xbc, 0x25, 0x56, 0x76, 0x0e, 0xff, 0xe3,
+	0x66, 0xe6, 0xea, 0x57, 0x8f, 0xbf, 0x3e, 0x1a, 0x13, 0x35, 0x99, 0x8e, 0x06, 0x21, 0x4b, 0x86,
+	0xa1, 0xb8, 0xe6, 0x8a, 0x25, 0x98, 0xfd, 0x1c, 0xc6, 0x34, 0x1a, 0xc6, 0xb4, 0xfc, 0xd9, 0x10,
+	0x3c, 0x1c, 0xd5, 0xf5, 0x27, 0xe2, 0xd9, 0xdf, 0x01, 0x00, 0x0f, 0x5a, 0xc8, 0x6f, 0x58, 0x06,
+	0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -466,6 +706,20 @@ type InvoicesClient interface {
 	//SettleInvoice settles an accepted invoice. If the invoice is already
 	//settled, this call will succeed.
 	SettleInvoice(ctx context.Context, in *SettleInvoiceMsg, opts ...grpc.CallOption) (*SettleInvoiceResp, error)
+	//
+	//ApproveInvoice releases the funds received for an accepted invoice that
+	//requires approval, by settling it with its preimage.
+	ApproveInvoice(ctx context.Context, in *ApproveInvoiceMsg, opts ...grpc.CallOption) (*ApproveInvoiceResp, error)
+	//
+	//RejectInvoice rejects the funds received for an invoice that requires
+	//approval, by canceling it. The htlcs paying to the invoice are canceled
+	//back to the sender.
+	RejectInvoice(ctx context.Context, in *RejectInvoiceMsg, opts ...grpc.CallOption) (*RejectInvoiceResp, error)
+	//
+	//ListPendingApprovals returns all invoices that require approval and have
+	//been paid, but of which the received funds haven't been approved or
+	//rejected yet.
+	ListPendingApprovals(ctx context.Context, in *ListPendingApprovalsRequest, opts ...grpc.CallOption) (*ListPendingApprovalsResponse, error)
 }
 
 type invoicesClient struct {
@@ -535,6 +789,33 @@ func (c *invoicesClient) SettleInvoice(ctx context.Context, in *SettleInvoiceMsg
 	return out, nil
 }
 
+func (c *invoicesClient) ApproveInvoice(ctx context.Context, in *ApproveInvoiceMsg, opts ...grpc.CallOption) (*ApproveInvoiceResp, error) {
+	out := new(ApproveInvoiceResp)
+	err := c.cc.Invoke(ctx, "/invoicesrpc.Invoices/ApproveInvoice", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *invoicesClient) RejectInvoice(ctx context.Context, in *RejectInvoiceMsg, opts ...grpc.CallOption) (*RejectInvoiceResp, error) {
+	out := new(RejectInvoiceResp)
+	err := c.cc.Invoke(ctx, "/invoicesrpc.Invoices/RejectInvoice", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *invoicesClient) ListPendingApprovals(ctx context.Context, in *ListPendingApprovalsRequest, opts ...grpc.CallOption) (*ListPendingApprovalsResponse, error) {
+	out := new(ListPendingApprovalsResponse)
+	err := c.cc.Invoke(ctx, "/invoicesrpc.Invoices/ListPendingApprovals", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // InvoicesServer is the server API for Invoices service.
 type InvoicesServer interface {
 	//
@@ -555,6 +836,20 @@ type InvoicesServer interface {
 	//SettleInvoice settles an accepted invoice. If the invoice is already
 	//settled, this call will succeed.
 	SettleInvoice(context.Context, *SettleInvoiceMsg) (*SettleInvoiceResp, error)
+	//
+	//ApproveInvoice releases the funds received for an accepted invoice that
+	//requires approval, by settling it with its preimage.
+	ApproveInvoice(context.Context, *ApproveInvoiceMsg) (*ApproveInvoiceResp, error)
+	//
+	//RejectInvoice rejects the funds received for an invoice that requires
+	//approval, by canceling it. The htlcs paying to the invoice are canceled
+	//back to the sender.
+	RejectInvoice(context.Context, *RejectInvoiceMsg) (*RejectInvoiceResp, error)
+	//
+	//ListPendingApprovals returns all invoices that require approval and have
+	//been paid, but of which the received funds haven't been approved or
+	//rejected yet.
+	ListPendingApprovals(context.Context, *ListPendingApprovalsRequest) (*ListPendingApprovalsResponse, error)
 }
 
 // UnimplementedInvoicesServer can be embedded to have forward compatible implementations.
@@ -573,6 +868,15 @@ func (*UnimplementedInvoicesServer) AddHoldInvoice(ctx context.Context, req *Add
 func (*UnimplementedInvoicesServer) SettleInvoice(ctx context.Context, req *SettleInvoiceMsg) (*SettleInvoiceResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SettleInvoice not implemented")
 }
+func (*UnimplementedInvoicesServer) ApproveInvoice(ctx context.Context, req *ApproveInvoiceMsg) (*ApproveInvoiceResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveInvoice not implemented")
+}
+func (*UnimplementedInvoicesServer) RejectInvoice(ctx context.Context, req *RejectInvoiceMsg) (*RejectInvoiceResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RejectInvoice not implemented")
+}
+func (*UnimplementedInvoicesServer) ListPendingApprovals(ctx context.Context, req *ListPendingApprovalsRequest) (*ListPendingApprovalsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPendingApprovals not implemented")
+}
 
 func RegisterInvoicesServer(s *grpc.Server, srv InvoicesServer) {
 	s.RegisterService(&_Invoices_serviceDesc, srv)
@@ -653,6 +957,60 @@ func _Invoices_SettleInvoice_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Invoices_ApproveInvoice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveInvoiceMsg)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InvoicesServer).ApproveInvoice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/invoicesrpc.Invoices/ApproveInvoice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InvoicesServer).ApproveInvoice(ctx, req.(*ApproveInvoiceMsg))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Invoices_RejectInvoice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RejectInvoiceMsg)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InvoicesServer).RejectInvoice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/invoicesrpc.Invoices/RejectInvoice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InvoicesServer).RejectInvoice(ctx, req.(*RejectInvoiceMsg))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Invoices_ListPendingApprovals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPendingApprovalsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InvoicesServer).ListPendingApprovals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/invoicesrpc.Invoices/ListPendingApprovals",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InvoicesServer).ListPendingApprovals(ctx, req.(*ListPendingApprovalsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Invoices_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "invoicesrpc.Invoices",
 	HandlerType: (*InvoicesServer)(nil),
@@ -669,6 +1027,18 @@ var _Invoices_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SettleInvoice",
 			Handler:    _Invoices_SettleInvoice_Handler,
 		},
+		{
+			MethodName: "ApproveInvoice",
+			Handler:    _Invoices_ApproveInvoice_Handler,
+		},
+		{
+			MethodName: "RejectInvoice",
+			Handler:    _Invoices_RejectInvoice_Handler,
+		},
+		{
+			MethodName: "ListPendingApprovals",
+			Handler:    _Invoices_ListPendingApprovals_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
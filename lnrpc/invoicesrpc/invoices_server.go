@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"time"
 
 	"google.golang.org/grpc"
 	"gopkg.in/macaroon-bakery.v2/bakery"
@@ -58,6 +59,18 @@ var (
 			Entity: "invoices",
 			Action: "write",
 		}},
+		"/invoicesrpc.Invoices/ApproveInvoice": {{
+			Entity: "invoices",
+			Action: "write",
+		}},
+		"/invoicesrpc.Invoices/RejectInvoice": {{
+			Entity: "invoices",
+			Action: "write",
+		}},
+		"/invoicesrpc.Invoices/ListPendingApprovals": {{
+			Entity: "invoices",
+			Action: "read",
+		}},
 	}
 
 	// DefaultInvoicesMacFilename is the default name of the invoices
@@ -263,6 +276,75 @@ func (s *Server) CancelInvoice(ctx context.Context,
 	return &CancelInvoiceResp{}, nil
 }
 
+// ApproveInvoice releases the funds received for an accepted invoice that
+// requires approval. If the invoice is already settled, this call will
+// succeed.
+func (s *Server) ApproveInvoice(ctx context.Context,
+	in *ApproveInvoiceMsg) (*ApproveInvoiceResp, error) {
+
+	paymentHash, err := lntypes.MakeHash(in.PaymentHash)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.cfg.InvoiceRegistry.ApproveInvoice(paymentHash)
+	if err != nil && err != channeldb.ErrInvoiceAlreadySettled {
+		return nil, err
+	}
+
+	log.Infof("Approved funds of invoice %v", paymentHash)
+
+	return &ApproveInvoiceResp{}, nil
+}
+
+// RejectInvoice rejects the funds received for an invoice that requires
+// approval. If the invoice is already canceled, this call will succeed.
+func (s *Server) RejectInvoice(ctx context.Context,
+	in *RejectInvoiceMsg) (*RejectInvoiceResp, error) {
+
+	paymentHash, err := lntypes.MakeHash(in.PaymentHash)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.cfg.InvoiceRegistry.RejectInvoice(paymentHash)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("Rejected funds of invoice %v", paymentHash)
+
+	return &RejectInvoiceResp{}, nil
+}
+
+// ListPendingApprovals returns all invoices of which the received funds await
+// approval.
+func (s *Server) ListPendingApprovals(ctx context.Context,
+	in *ListPendingApprovalsRequest) (*ListPendingApprovalsResponse,
+	error) {
+
+	pending, err := s.cfg.InvoiceRegistry.PendingApprovals()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &ListPendingApprovalsResponse{
+		Invoices: make([]*lnrpc.Invoice, 0, len(pending)),
+	}
+	for i := range pending {
+		rpcInvoice, err := CreateRPCInvoice(
+			&pending[i], s.cfg.ChainParams,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Invoices = append(resp.Invoices, rpcInvoice)
+	}
+
+	return resp, nil
+}
+
 // AddHoldInvoice attempts to add a new hold invoice to the invoice database.
 // Any duplicated invoices are rejected, therefore all invoices *must* have a
 // unique payment hash.
@@ -300,6 +382,7 @@ func (s *Server) AddHoldInvoice(ctx context.Context,
 		Private:         invoice.Private,
 		HodlInvoice:     true,
 		Preimage:        nil,
+		MppTimeout:      time.Duration(invoice.MppTimeout) * time.Second,
 	}
 
 	_, dbInvoice, err := AddInvoice(ctx, addInvoiceCfg, addInvoiceData)
@@ -147,12 +147,20 @@ func CreateRPCInvoice(invoice *channeldb.Invoice,
 		Htlcs:           rpcHtlcs,
 		Features:        CreateRPCFeatures(invoice.Terms.Features),
 		IsKeysend:       len(invoice.PaymentRequest) == 0,
+		MppTimeout:      uint64(invoice.MppTimeout.Seconds()),
+		RequireApproval: invoice.RequireApproval,
 	}
 
 	if preimage != nil {
 		rpcInvoice.RPreimage = preimage[:]
 	}
 
+	for _, payReq := range invoice.PrevPaymentRequests {
+		rpcInvoice.PrevPaymentRequests = append(
+			rpcInvoice.PrevPaymentRequests, string(payReq),
+		)
+	}
+
 	return rpcInvoice, nil
 }
 
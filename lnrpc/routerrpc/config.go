@@ -59,12 +59,14 @@ func DefaultConfig() *Config {
 // GetRoutingConfig returns the routing config based on this sub server config.
 func GetRoutingConfig(cfg *Config) *RoutingConfig {
 	return &RoutingConfig{
-		AprioriHopProbability: cfg.AprioriHopProbability,
-		AprioriWeight:         cfg.AprioriWeight,
-		MinRouteProbability:   cfg.MinRouteProbability,
-		AttemptCost:           cfg.AttemptCost,
-		AttemptCostPPM:        cfg.AttemptCostPPM,
-		PenaltyHalfLife:       cfg.PenaltyHalfLife,
-		MaxMcHistory:          cfg.MaxMcHistory,
+		AprioriHopProbability:     cfg.AprioriHopProbability,
+		AprioriWeight:             cfg.AprioriWeight,
+		MinRouteProbability:       cfg.MinRouteProbability,
+		AttemptCost:               cfg.AttemptCost,
+		AttemptCostPPM:            cfg.AttemptCostPPM,
+		PenaltyHalfLife:           cfg.PenaltyHalfLife,
+		MaxMcHistory:              cfg.MaxMcHistory,
+		MaxPeerExposure:           cfg.MaxPeerExposure,
+		RequirePaymentInterceptor: cfg.RequirePaymentInterceptor,
 	}
 }
@@ -0,0 +1,189 @@
+package routerrpc
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cryptomeow/lnd/lnwire"
+	"github.com/cryptomeow/lnd/routing"
+)
+
+// paymentInterceptTimeout is the time the payment interceptor has to respond
+// to an intercepted payment before the payment is rejected.
+const paymentInterceptTimeout = time.Minute
+
+var (
+	// ErrNoPaymentInterceptor is returned when a payment is made while no
+	// payment interceptor is connected, but one is required.
+	ErrNoPaymentInterceptor = errors.New("no payment interceptor connected")
+
+	// ErrPaymentInterceptTimeout is returned when the payment interceptor
+	// doesn't respond to an intercepted payment in time.
+	ErrPaymentInterceptTimeout = errors.New("payment interceptor didn't " +
+		"respond in time")
+
+	// ErrPaymentInterceptorGone is returned when the payment interceptor
+	// disconnects before responding to an intercepted payment.
+	ErrPaymentInterceptorGone = errors.New("payment interceptor " +
+		"disconnected")
+)
+
+// paymentInterceptor is a helper struct that handles the lifecycle of a
+// payment interceptor streaming session. It is created when the stream opens
+// and disconnects when the stream closes.
+type paymentInterceptor struct {
+	// server is the Server reference.
+	server *Server
+
+	// stream is the bidirectional RPC stream.
+	stream Router_PaymentInterceptorServer
+
+	// sendMtx serializes the requests sent on the stream, as payments are
+	// intercepted concurrently.
+	sendMtx sync.Mutex
+
+	// mu protects the pending interceptions.
+	mu      sync.Mutex
+	nextID  uint64
+	pending map[uint64]chan *PaymentInterceptResponse
+
+	// quit is a channel that is closed when the stream is closed.
+	quit chan struct{}
+}
+
+// newPaymentInterceptor creates a new paymentInterceptor.
+func newPaymentInterceptor(server *Server,
+	stream Router_PaymentInterceptorServer) *paymentInterceptor {
+
+	return &paymentInterceptor{
+		server:  server,
+		stream:  stream,
+		pending: make(map[uint64]chan *PaymentInterceptResponse),
+		quit:    make(chan struct{}),
+	}
+}
+
+// run receives the responses of the client until the stream is closed.
+// Payments that are still waiting for a response are rejected then.
+func (p *paymentInterceptor) run() error {
+	defer close(p.quit)
+
+	for {
+		resp, err := p.stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		p.mu.Lock()
+		respChan, ok := p.pending[resp.InterceptId]
+		delete(p.pending, resp.InterceptId)
+		p.mu.Unlock()
+
+		if !ok {
+			log.Warnf("Payment interceptor responded to unknown "+
+				"interception %v", resp.InterceptId)
+			continue
+		}
+
+		// The channel is buffered, and each interception receives
+		// a single response.
+		respChan <- resp
+	}
+}
+
+// intercept sends the payment to the client and waits for its response.
+func (p *paymentInterceptor) intercept(
+	payment *routing.InterceptedPayment) (*routing.PaymentModification,
+	error) {
+
+	respChan := make(chan *PaymentInterceptResponse, 1)
+
+	p.mu.Lock()
+	id := p.nextID
+	p.nextID++
+	p.pending[id] = respChan
+	p.mu.Unlock()
+
+	defer func() {
+		p.mu.Lock()
+		delete(p.pending, id)
+		p.mu.Unlock()
+	}()
+
+	req := &PaymentInterceptRequest{
+		InterceptId:     id,
+		PaymentHash:     payment.PaymentHash[:],
+		Dest:            payment.Target[:],
+		AmtMsat:         uint64(payment.Amount),
+		FeeLimitMsat:    uint64(payment.FeeLimit),
+		CltvLimit:       payment.CltvLimit,
+		OutgoingChanIds: payment.OutgoingChannelIDs,
+		PaymentRequest:  string(payment.PaymentRequest),
+	}
+	if payment.Route != nil {
+		rpcRoute, err := p.server.cfg.RouterBackend.MarshallRoute(
+			payment.Route,
+		)
+		if err != nil {
+			return nil, err
+		}
+		req.Route = rpcRoute
+	}
+
+	p.sendMtx.Lock()
+	err := p.stream.Send(req)
+	p.sendMtx.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case resp := <-respChan:
+		return unmarshalPaymentInterceptResponse(resp)
+
+	case <-time.After(paymentInterceptTimeout):
+		return nil, ErrPaymentInterceptTimeout
+
+	case <-p.quit:
+		return nil, ErrPaymentInterceptorGone
+
+	case <-p.server.quit:
+		return nil, errServerShuttingDown
+	}
+}
+
+// unmarshalPaymentInterceptResponse converts the response of the client to
+// the decision of the payment interceptor.
+func unmarshalPaymentInterceptResponse(
+	resp *PaymentInterceptResponse) (*routing.PaymentModification, error) {
+
+	switch resp.Action {
+	case PaymentInterceptAction_PROCEED:
+
+	case PaymentInterceptAction_REJECT:
+		if resp.RejectReason == "" {
+			return nil, errors.New("rejected")
+		}
+		return nil, errors.New(resp.RejectReason)
+
+	default:
+		return nil, fmt.Errorf("unrecognized payment intercept action "+
+			"%v", resp.Action)
+	}
+
+	mod := &routing.PaymentModification{
+		OutgoingChannelIDs: resp.OutgoingChanIds,
+	}
+	if resp.FeeLimitMsat != 0 {
+		feeLimit := lnwire.MilliSatoshi(resp.FeeLimitMsat)
+		mod.FeeLimit = &feeLimit
+	}
+	if resp.CltvLimit != 0 {
+		cltvLimit := resp.CltvLimit
+		mod.CltvLimit = &cltvLimit
+	}
+
+	return mod, nil
+}
@@ -0,0 +1,137 @@
+package routerrpc
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/cryptomeow/lnd/lnwire"
+	"github.com/cryptomeow/lnd/routing"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// mockPaymentInterceptorStream is a payment interceptor stream whose requests
+// and responses are passed through channels.
+type mockPaymentInterceptorStream struct {
+	grpc.ServerStream
+
+	requests  chan *PaymentInterceptRequest
+	responses chan *PaymentInterceptResponse
+}
+
+func (m *mockPaymentInterceptorStream) Send(
+	req *PaymentInterceptRequest) error {
+
+	m.requests <- req
+	return nil
+}
+
+func (m *mockPaymentInterceptorStream) Recv() (*PaymentInterceptResponse,
+	error) {
+
+	resp, ok := <-m.responses
+	if !ok {
+		return nil, io.EOF
+	}
+
+	return resp, nil
+}
+
+// TestPaymentInterceptor asserts that intercepted payments are sent to the
+// client, and that its responses are applied.
+func TestPaymentInterceptor(t *testing.T) {
+	t.Parallel()
+
+	stream := &mockPaymentInterceptorStream{
+		requests:  make(chan *PaymentInterceptRequest),
+		responses: make(chan *PaymentInterceptResponse),
+	}
+	server := &Server{
+		cfg:  &Config{},
+		quit: make(chan struct{}),
+	}
+
+	// Without a connected interceptor, payments proceed unless one is
+	// required.
+	mod, err := server.interceptPayment(&routing.InterceptedPayment{})
+	require.NoError(t, err)
+	require.Nil(t, mod)
+
+	server.cfg.RequirePaymentInterceptor = true
+	_, err = server.interceptPayment(&routing.InterceptedPayment{})
+	require.Equal(t, ErrNoPaymentInterceptor, err)
+
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- server.PaymentInterceptor(stream)
+	}()
+
+	type result struct {
+		mod *routing.PaymentModification
+		err error
+	}
+	intercept := func(amt lnwire.MilliSatoshi) chan result {
+		results := make(chan result, 1)
+		go func() {
+			mod, err := server.interceptPayment(
+				&routing.InterceptedPayment{Amount: amt},
+			)
+			results <- result{mod, err}
+		}()
+
+		return results
+	}
+
+	// The interceptor is registered asynchronously, so we retry until the
+	// payment is sent to it.
+	var (
+		req     *PaymentInterceptRequest
+		results chan result
+	)
+	require.Eventually(t, func() bool {
+		results = intercept(1000)
+		select {
+		case req = <-stream.requests:
+			return true
+
+		case res := <-results:
+			require.Equal(t, ErrNoPaymentInterceptor, res.err)
+			return false
+		}
+	}, time.Second*5, time.Millisecond*10)
+
+	require.Equal(t, uint64(1000), req.AmtMsat)
+	stream.responses <- &PaymentInterceptResponse{
+		InterceptId:  req.InterceptId,
+		Action:       PaymentInterceptAction_PROCEED,
+		FeeLimitMsat: 50,
+	}
+	res := <-results
+	require.NoError(t, res.err)
+	require.Equal(t, lnwire.MilliSatoshi(50), *res.mod.FeeLimit)
+	require.Nil(t, res.mod.CltvLimit)
+
+	// A second interceptor can't connect.
+	err = server.PaymentInterceptor(stream)
+	require.Equal(t, ErrInterceptorAlreadyExists, err)
+
+	results = intercept(2000)
+	req = <-stream.requests
+	stream.responses <- &PaymentInterceptResponse{
+		InterceptId:  req.InterceptId,
+		Action:       PaymentInterceptAction_REJECT,
+		RejectReason: "amount too large",
+	}
+	res = <-results
+	require.EqualError(t, res.err, "amount too large")
+
+	// Payments waiting for a response are rejected once the interceptor
+	// disconnects.
+	results = intercept(3000)
+	<-stream.requests
+	close(stream.responses)
+	res = <-results
+	require.Equal(t, ErrPaymentInterceptorGone, res.err)
+	require.Equal(t, io.EOF, <-errChan)
+}
@@ -51,6 +51,11 @@ const (
 	FailureDetail_INVALID_KEYSEND         FailureDetail = 20
 	FailureDetail_MPP_IN_PROGRESS         FailureDetail = 21
 	FailureDetail_CIRCULAR_ROUTE          FailureDetail = 22
+	FailureDetail_NODE_DRAINING           FailureDetail = 23
+	FailureDetail_HELD_HTLC_LIMIT         FailureDetail = 24
+	FailureDetail_DUST_EXPOSURE           FailureDetail = 25
+	FailureDetail_HTLC_HELD_TOO_LONG      FailureDetail = 26
+	FailureDetail_RESERVED_HTLC_SLOTS     FailureDetail = 27
 )
 
 var FailureDetail_name = map[int32]string{
@@ -77,6 +82,11 @@ var FailureDetail_name = map[int32]string{
 	20: "INVALID_KEYSEND",
 	21: "MPP_IN_PROGRESS",
 	22: "CIRCULAR_ROUTE",
+	23: "NODE_DRAINING",
+	24: "HELD_HTLC_LIMIT",
+	25: "DUST_EXPOSURE",
+	26: "HTLC_HELD_TOO_LONG",
+	27: "RESERVED_HTLC_SLOTS",
 }
 
 var FailureDetail_value = map[string]int32{
@@ -103,6 +113,11 @@ var FailureDetail_value = map[string]int32{
 	"INVALID_KEYSEND":         20,
 	"MPP_IN_PROGRESS":         21,
 	"CIRCULAR_ROUTE":          22,
+	"NODE_DRAINING":           23,
+	"HELD_HTLC_LIMIT":         24,
+	"DUST_EXPOSURE":           25,
+	"HTLC_HELD_TOO_LONG":      26,
+	"RESERVED_HTLC_SLOTS":     27,
 }
 
 func (x FailureDetail) String() string {
@@ -197,6 +212,33 @@ func (ResolveHoldForwardAction) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_7a0613f69d37b0a5, []int{2}
 }
 
+type PaymentInterceptAction int32
+
+const (
+	// Let the payment proceed, restricted by the modifications if any.
+	PaymentInterceptAction_PROCEED PaymentInterceptAction = 0
+	// Reject the payment before anything is sent.
+	PaymentInterceptAction_REJECT PaymentInterceptAction = 1
+)
+
+var PaymentInterceptAction_name = map[int32]string{
+	0: "PROCEED",
+	1: "REJECT",
+}
+
+var PaymentInterceptAction_value = map[string]int32{
+	"PROCEED": 0,
+	"REJECT":  1,
+}
+
+func (x PaymentInterceptAction) String() string {
+	return proto.EnumName(PaymentInterceptAction_name, int32(x))
+}
+
+func (PaymentInterceptAction) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_7a0613f69d37b0a5, []int{3}
+}
+
 type HtlcEvent_EventType int32
 
 const (
@@ -225,7 +267,7 @@ func (x HtlcEvent_EventType) String() string {
 }
 
 func (HtlcEvent_EventType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_7a0613f69d37b0a5, []int{17, 0}
+	return fileDescriptor_7a0613f69d37b0a5, []int{28, 0}
 }
 
 type SendPaymentRequest struct {
@@ -320,7 +362,15 @@ type SendPaymentRequest struct {
 	//
 	//If set, only the final payment update is streamed back. Intermediate updates
 	//that show which htlcs are still in flight are suppressed.
-	NoInflightUpdates    bool     `protobuf:"varint,18,opt,name=no_inflight_updates,json=noInflightUpdates,proto3" json:"no_inflight_updates,omitempty"`
+	NoInflightUpdates bool `protobuf:"varint,18,opt,name=no_inflight_updates,json=noInflightUpdates,proto3" json:"no_inflight_updates,omitempty"`
+	//
+	//An optional key chosen by the client that identifies this payment request,
+	//with a maximum length of 64 bytes. The key is stored with the payment. If a
+	//payment with the same key and payment hash already exists, no new payment
+	//is initiated and the updates of the existing payment are streamed back
+	//instead. This allows a request to be retried safely after a network error.
+	//Using the same key for a different payment hash is rejected.
+	IdempotencyKey       []byte   `protobuf:"bytes,20,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -485,6 +535,13 @@ func (m *SendPaymentRequest) GetNoInflightUpdates() bool {
 	return false
 }
 
+func (m *SendPaymentRequest) GetIdempotencyKey() []byte {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return nil
+}
+
 type TrackPaymentRequest struct {
 	// The hash of the payment to look up.
 	PaymentHash []byte `protobuf:"bytes,1,opt,name=payment_hash,json=paymentHash,proto3" json:"payment_hash,omitempty"`
@@ -641,21 +698,237 @@ func (m *RouteFeeResponse) GetTimeLockDelay() int64 {
 	return 0
 }
 
+type ProbeRouteRequest struct {
+	// The destination to probe routes to.
+	Dest []byte `protobuf:"bytes,1,opt,name=dest,proto3" json:"dest,omitempty"`
+	// The amount of the probe payments in satoshis.
+	AmtSat int64 `protobuf:"varint,2,opt,name=amt_sat,json=amtSat,proto3" json:"amt_sat,omitempty"`
+	//
+	//The CLTV delta of the final hop. If not set, the default final CLTV delta
+	//is used.
+	FinalCltvDelta int32 `protobuf:"varint,3,opt,name=final_cltv_delta,json=finalCltvDelta,proto3" json:"final_cltv_delta,omitempty"`
+	//
+	//The maximum routing fee in satoshis that a probed route may carry. If not
+	//set, the routing fee isn't limited.
+	FeeLimitSat int64 `protobuf:"varint,4,opt,name=fee_limit_sat,json=feeLimitSat,proto3" json:"fee_limit_sat,omitempty"`
+	//
+	//The maximum number of candidate routes to probe. If not set, three routes
+	//are probed at most.
+	MaxProbes            uint32   `protobuf:"varint,5,opt,name=max_probes,json=maxProbes,proto3" json:"max_probes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ProbeRouteRequest) Reset()         { *m = ProbeRouteRequest{} }
+func (m *ProbeRouteRequest) String() string { return proto.CompactTextString(m) }
+func (*ProbeRouteRequest) ProtoMessage()    {}
+func (*ProbeRouteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7a0613f69d37b0a5, []int{4}
+}
+
+func (m *ProbeRouteRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProbeRouteRequest.Unmarshal(m, b)
+}
+func (m *ProbeRouteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProbeRouteRequest.Marshal(b, m, deterministic)
+}
+func (m *ProbeRouteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProbeRouteRequest.Merge(m, src)
+}
+func (m *ProbeRouteRequest) XXX_Size() int {
+	return xxx_messageInfo_ProbeRouteRequest.Size(m)
+}
+func (m *ProbeRouteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProbeRouteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProbeRouteRequest proto.InternalMessageInfo
+
+func (m *ProbeRouteRequest) GetDest() []byte {
+	if m != nil {
+		return m.Dest
+	}
+	return nil
+}
+
+func (m *ProbeRouteRequest) GetAmtSat() int64 {
+	if m != nil {
+		return m.AmtSat
+	}
+	return 0
+}
+
+func (m *ProbeRouteRequest) GetFinalCltvDelta() int32 {
+	if m != nil {
+		return m.FinalCltvDelta
+	}
+	return 0
+}
+
+func (m *ProbeRouteRequest) GetFeeLimitSat() int64 {
+	if m != nil {
+		return m.FeeLimitSat
+	}
+	return 0
+}
+
+func (m *ProbeRouteRequest) GetMaxProbes() uint32 {
+	if m != nil {
+		return m.MaxProbes
+	}
+	return 0
+}
+
+type ProbeResult struct {
+	// The route that was probed.
+	Route *lnrpc.Route `protobuf:"bytes,1,opt,name=route,proto3" json:"route,omitempty"`
+	// The failure the probe resolved with.
+	Failure *lnrpc.Failure `protobuf:"bytes,2,opt,name=failure,proto3" json:"failure,omitempty"`
+	// Whether the probe reached the destination.
+	ReachedDestination   bool     `protobuf:"varint,3,opt,name=reached_destination,json=reachedDestination,proto3" json:"reached_destination,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ProbeResult) Reset()         { *m = ProbeResult{} }
+func (m *ProbeResult) String() string { return proto.CompactTextString(m) }
+func (*ProbeResult) ProtoMessage()    {}
+func (*ProbeResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7a0613f69d37b0a5, []int{5}
+}
+
+func (m *ProbeResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProbeResult.Unmarshal(m, b)
+}
+func (m *ProbeResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProbeResult.Marshal(b, m, deterministic)
+}
+func (m *ProbeResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProbeResult.Merge(m, src)
+}
+func (m *ProbeResult) XXX_Size() int {
+	return xxx_messageInfo_ProbeResult.Size(m)
+}
+func (m *ProbeResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProbeResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProbeResult proto.InternalMessageInfo
+
+func (m *ProbeResult) GetRoute() *lnrpc.Route {
+	if m != nil {
+		return m.Route
+	}
+	return nil
+}
+
+func (m *ProbeResult) GetFailure() *lnrpc.Failure {
+	if m != nil {
+		return m.Failure
+	}
+	return nil
+}
+
+func (m *ProbeResult) GetReachedDestination() bool {
+	if m != nil {
+		return m.ReachedDestination
+	}
+	return false
+}
+
+type ProbeRouteResponse struct {
+	// Whether a route that reached the destination was found.
+	Reachable bool `protobuf:"varint,1,opt,name=reachable,proto3" json:"reachable,omitempty"`
+	//
+	//The routing fee of the route that reached the destination, expressed in
+	//milli-satoshis.
+	RoutingFeeMsat int64 `protobuf:"varint,2,opt,name=routing_fee_msat,json=routingFeeMsat,proto3" json:"routing_fee_msat,omitempty"`
+	// The total time lock of the route that reached the destination.
+	TimeLockDelay int64 `protobuf:"varint,3,opt,name=time_lock_delay,json=timeLockDelay,proto3" json:"time_lock_delay,omitempty"`
+	// The results of all probes that were sent, in order.
+	Probes               []*ProbeResult `protobuf:"bytes,4,rep,name=probes,proto3" json:"probes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ProbeRouteResponse) Reset()         { *m = ProbeRouteResponse{} }
+func (m *ProbeRouteResponse) String() string { return proto.CompactTextString(m) }
+func (*ProbeRouteResponse) ProtoMessage()    {}
+func (*ProbeRouteResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7a0613f69d37b0a5, []int{6}
+}
+
+func (m *ProbeRouteResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProbeRouteResponse.Unmarshal(m, b)
+}
+func (m *ProbeRouteResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProbeRouteResponse.Marshal(b, m, deterministic)
+}
+func (m *ProbeRouteResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProbeRouteResponse.Merge(m, src)
+}
+func (m *ProbeRouteResponse) XXX_Size() int {
+	return xxx_messageInfo_ProbeRouteResponse.Size(m)
+}
+func (m *ProbeRouteResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProbeRouteResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProbeRouteResponse proto.InternalMessageInfo
+
+func (m *ProbeRouteResponse) GetReachable() bool {
+	if m != nil {
+		return m.Reachable
+	}
+	return false
+}
+
+func (m *ProbeRouteResponse) GetRoutingFeeMsat() int64 {
+	if m != nil {
+		return m.RoutingFeeMsat
+	}
+	return 0
+}
+
+func (m *ProbeRouteResponse) GetTimeLockDelay() int64 {
+	if m != nil {
+		return m.TimeLockDelay
+	}
+	return 0
+}
+
+func (m *ProbeRouteResponse) GetProbes() []*ProbeResult {
+	if m != nil {
+		return m.Probes
+	}
+	return nil
+}
+
 type SendToRouteRequest struct {
 	// The payment hash to use for the HTLC.
 	PaymentHash []byte `protobuf:"bytes,1,opt,name=payment_hash,json=paymentHash,proto3" json:"payment_hash,omitempty"`
 	// Route that should be used to attempt to complete the payment.
-	Route                *lnrpc.Route `protobuf:"bytes,2,opt,name=route,proto3" json:"route,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+	Route *lnrpc.Route `protobuf:"bytes,2,opt,name=route,proto3" json:"route,omitempty"`
+	//
+	//Whether the payment should be marked as failed when a temporary error is
+	//returned from the given route. If set to true, the payment is only marked
+	//as failed on a terminal error, so that further shards or attempts can be
+	//sent for the same payment hash. The payment can be failed explicitly using
+	//FailPayment once no more attempts will be made.
+	SkipTempErr          bool     `protobuf:"varint,3,opt,name=skip_temp_err,json=skipTempErr,proto3" json:"skip_temp_err,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *SendToRouteRequest) Reset()         { *m = SendToRouteRequest{} }
 func (m *SendToRouteRequest) String() string { return proto.CompactTextString(m) }
 func (*SendToRouteRequest) ProtoMessage()    {}
 func (*SendToRouteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7a0613f69d37b0a5, []int{4}
+	return fileDescriptor_7a0613f69d37b0a5, []int{7}
 }
 
 func (m *SendToRouteRequest) XXX_Unmarshal(b []byte) error {
@@ -690,6 +963,13 @@ func (m *SendToRouteRequest) GetRoute() *lnrpc.Route {
 	return nil
 }
 
+func (m *SendToRouteRequest) GetSkipTempErr() bool {
+	if m != nil {
+		return m.SkipTempErr
+	}
+	return false
+}
+
 type SendToRouteResponse struct {
 	// The preimage obtained by making the payment.
 	Preimage []byte `protobuf:"bytes,1,opt,name=preimage,proto3" json:"preimage,omitempty"`
@@ -704,7 +984,7 @@ func (m *SendToRouteResponse) Reset()         { *m = SendToRouteResponse{} }
 func (m *SendToRouteResponse) String() string { return proto.CompactTextString(m) }
 func (*SendToRouteResponse) ProtoMessage()    {}
 func (*SendToRouteResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7a0613f69d37b0a5, []int{5}
+	return fileDescriptor_7a0613f69d37b0a5, []int{8}
 }
 
 func (m *SendToRouteResponse) XXX_Unmarshal(b []byte) error {
@@ -739,6 +1019,77 @@ func (m *SendToRouteResponse) GetFailure() *lnrpc.Failure {
 	return nil
 }
 
+type FailPaymentRequest struct {
+	// The hash of the payment to fail.
+	PaymentHash          []byte   `protobuf:"bytes,1,opt,name=payment_hash,json=paymentHash,proto3" json:"payment_hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FailPaymentRequest) Reset()         { *m = FailPaymentRequest{} }
+func (m *FailPaymentRequest) String() string { return proto.CompactTextString(m) }
+func (*FailPaymentRequest) ProtoMessage()    {}
+func (*FailPaymentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7a0613f69d37b0a5, []int{9}
+}
+
+func (m *FailPaymentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FailPaymentRequest.Unmarshal(m, b)
+}
+func (m *FailPaymentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FailPaymentRequest.Marshal(b, m, deterministic)
+}
+func (m *FailPaymentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FailPaymentRequest.Merge(m, src)
+}
+func (m *FailPaymentRequest) XXX_Size() int {
+	return xxx_messageInfo_FailPaymentRequest.Size(m)
+}
+func (m *FailPaymentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FailPaymentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FailPaymentRequest proto.InternalMessageInfo
+
+func (m *FailPaymentRequest) GetPaymentHash() []byte {
+	if m != nil {
+		return m.PaymentHash
+	}
+	return nil
+}
+
+type FailPaymentResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FailPaymentResponse) Reset()         { *m = FailPaymentResponse{} }
+func (m *FailPaymentResponse) String() string { return proto.CompactTextString(m) }
+func (*FailPaymentResponse) ProtoMessage()    {}
+func (*FailPaymentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7a0613f69d37b0a5, []int{10}
+}
+
+func (m *FailPaymentResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FailPaymentResponse.Unmarshal(m, b)
+}
+func (m *FailPaymentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FailPaymentResponse.Marshal(b, m, deterministic)
+}
+func (m *FailPaymentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FailPaymentResponse.Merge(m, src)
+}
+func (m *FailPaymentResponse) XXX_Size() int {
+	return xxx_messageInfo_FailPaymentResponse.Size(m)
+}
+func (m *FailPaymentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FailPaymentResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FailPaymentResponse proto.InternalMessageInfo
+
 type ResetMissionControlRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -749,7 +1100,7 @@ func (m *ResetMissionControlRequest) Reset()         { *m = ResetMissionControlR
 func (m *ResetMissionControlRequest) String() string { return proto.CompactTextString(m) }
 func (*ResetMissionControlRequest) ProtoMessage()    {}
 func (*ResetMissionControlRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7a0613f69d37b0a5, []int{6}
+	return fileDescriptor_7a0613f69d37b0a5, []int{11}
 }
 
 func (m *ResetMissionControlRequest) XXX_Unmarshal(b []byte) error {
@@ -780,7 +1131,7 @@ func (m *ResetMissionControlResponse) Reset()         { *m = ResetMissionControl
 func (m *ResetMissionControlResponse) String() string { return proto.CompactTextString(m) }
 func (*ResetMissionControlResponse) ProtoMessage()    {}
 func (*ResetMissionControlResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7a0613f69d37b0a5, []int{7}
+	return fileDescriptor_7a0613f69d37b0a5, []int{12}
 }
 
 func (m *ResetMissionControlResponse) XXX_Unmarshal(b []byte) error {
@@ -811,7 +1162,7 @@ func (m *QueryMissionControlRequest) Reset()         { *m = QueryMissionControlR
 func (m *QueryMissionControlRequest) String() string { return proto.CompactTextString(m) }
 func (*QueryMissionControlRequest) ProtoMessage()    {}
 func (*QueryMissionControlRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7a0613f69d37b0a5, []int{8}
+	return fileDescriptor_7a0613f69d37b0a5, []int{13}
 }
 
 func (m *QueryMissionControlRequest) XXX_Unmarshal(b []byte) error {
@@ -835,17 +1186,19 @@ var xxx_messageInfo_QueryMissionControlRequest proto.InternalMessageInfo
 // QueryMissionControlResponse contains mission control state.
 type QueryMissionControlResponse struct {
 	// Node pair-level mission control state.
-	Pairs                []*PairHistory `protobuf:"bytes,2,rep,name=pairs,proto3" json:"pairs,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+	Pairs []*PairHistory `protobuf:"bytes,2,rep,name=pairs,proto3" json:"pairs,omitempty"`
+	// The manual overrides of mission control.
+	Overrides            []*MissionControlOverride `protobuf:"bytes,3,rep,name=overrides,proto3" json:"overrides,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
 }
 
 func (m *QueryMissionControlResponse) Reset()         { *m = QueryMissionControlResponse{} }
 func (m *QueryMissionControlResponse) String() string { return proto.CompactTextString(m) }
 func (*QueryMissionControlResponse) ProtoMessage()    {}
 func (*QueryMissionControlResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7a0613f69d37b0a5, []int{9}
+	return fileDescriptor_7a0613f69d37b0a5, []int{14}
 }
 
 func (m *QueryMissionControlResponse) XXX_Unmarshal(b []byte) error {
@@ -873,73 +1226,313 @@ func (m *QueryMissionControlResponse) GetPairs() []*PairHistory {
 	return nil
 }
 
-// PairHistory contains the mission control state for a particular node pair.
-type PairHistory struct {
-	// The source node pubkey of the pair.
-	NodeFrom []byte `protobuf:"bytes,1,opt,name=node_from,json=nodeFrom,proto3" json:"node_from,omitempty"`
-	// The destination node pubkey of the pair.
-	NodeTo               []byte    `protobuf:"bytes,2,opt,name=node_to,json=nodeTo,proto3" json:"node_to,omitempty"`
-	History              *PairData `protobuf:"bytes,7,opt,name=history,proto3" json:"history,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+func (m *QueryMissionControlResponse) GetOverrides() []*MissionControlOverride {
+	if m != nil {
+		return m.Overrides
+	}
+	return nil
 }
 
-func (m *PairHistory) Reset()         { *m = PairHistory{} }
-func (m *PairHistory) String() string { return proto.CompactTextString(m) }
-func (*PairHistory) ProtoMessage()    {}
-func (*PairHistory) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7a0613f69d37b0a5, []int{10}
+type MissionControlOverride struct {
+	// The node that the override applies to.
+	Node []byte `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	//
+	//If set, the override only applies to the pair from node to to_node.
+	//Otherwise it applies to all pairs from and to node.
+	ToNode []byte `protobuf:"bytes,2,opt,name=to_node,json=toNode,proto3" json:"to_node,omitempty"`
+	// Whether the node or pair is marked as succeeded instead of failed.
+	Success bool `protobuf:"varint,3,opt,name=success,proto3" json:"success,omitempty"`
+	//
+	//The number of seconds after which the override has lost half of its
+	//effect. If zero, the penalty half-life of mission control is used.
+	HalfLifeSec uint64 `protobuf:"varint,4,opt,name=half_life_sec,json=halfLifeSec,proto3" json:"half_life_sec,omitempty"`
+	// The unix timestamp at which the override was set. Ignored when setting.
+	SetTime              int64    `protobuf:"varint,5,opt,name=set_time,json=setTime,proto3" json:"set_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *PairHistory) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_PairHistory.Unmarshal(m, b)
+func (m *MissionControlOverride) Reset()         { *m = MissionControlOverride{} }
+func (m *MissionControlOverride) String() string { return proto.CompactTextString(m) }
+func (*MissionControlOverride) ProtoMessage()    {}
+func (*MissionControlOverride) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7a0613f69d37b0a5, []int{15}
 }
-func (m *PairHistory) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_PairHistory.Marshal(b, m, deterministic)
+
+func (m *MissionControlOverride) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MissionControlOverride.Unmarshal(m, b)
 }
-func (m *PairHistory) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PairHistory.Merge(m, src)
+func (m *MissionControlOverride) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MissionControlOverride.Marshal(b, m, deterministic)
 }
-func (m *PairHistory) XXX_Size() int {
-	return xxx_messageInfo_PairHistory.Size(m)
+func (m *MissionControlOverride) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MissionControlOverride.Merge(m, src)
 }
-func (m *PairHistory) XXX_DiscardUnknown() {
-	xxx_messageInfo_PairHistory.DiscardUnknown(m)
+func (m *MissionControlOverride) XXX_Size() int {
+	return xxx_messageInfo_MissionControlOverride.Size(m)
+}
+func (m *MissionControlOverride) XXX_DiscardUnknown() {
+	xxx_messageInfo_MissionControlOverride.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_PairHistory proto.InternalMessageInfo
+var xxx_messageInfo_MissionControlOverride proto.InternalMessageInfo
 
-func (m *PairHistory) GetNodeFrom() []byte {
+func (m *MissionControlOverride) GetNode() []byte {
 	if m != nil {
-		return m.NodeFrom
+		return m.Node
 	}
 	return nil
 }
 
-func (m *PairHistory) GetNodeTo() []byte {
+func (m *MissionControlOverride) GetToNode() []byte {
 	if m != nil {
-		return m.NodeTo
+		return m.ToNode
 	}
 	return nil
 }
 
-func (m *PairHistory) GetHistory() *PairData {
+func (m *MissionControlOverride) GetSuccess() bool {
 	if m != nil {
-		return m.History
+		return m.Success
 	}
-	return nil
+	return false
 }
 
-type PairData struct {
-	// Time of last failure.
-	FailTime int64 `protobuf:"varint,1,opt,name=fail_time,json=failTime,proto3" json:"fail_time,omitempty"`
-	//
-	//Lowest amount that failed to forward rounded to whole sats. This may be
-	//set to zero if the failure is independent of amount.
-	FailAmtSat int64 `protobuf:"varint,2,opt,name=fail_amt_sat,json=failAmtSat,proto3" json:"fail_amt_sat,omitempty"`
-	//
-	//Lowest amount that failed to forward in millisats. This may be
+func (m *MissionControlOverride) GetHalfLifeSec() uint64 {
+	if m != nil {
+		return m.HalfLifeSec
+	}
+	return 0
+}
+
+func (m *MissionControlOverride) GetSetTime() int64 {
+	if m != nil {
+		return m.SetTime
+	}
+	return 0
+}
+
+type SetMissionControlOverridesRequest struct {
+	// The overrides to set, replacing previous overrides of the same nodes or
+	// pairs.
+	Overrides            []*MissionControlOverride `protobuf:"bytes,1,rep,name=overrides,proto3" json:"overrides,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *SetMissionControlOverridesRequest) Reset()         { *m = SetMissionControlOverridesRequest{} }
+func (m *SetMissionControlOverridesRequest) String() string { return proto.CompactTextString(m) }
+func (*SetMissionControlOverridesRequest) ProtoMessage()    {}
+func (*SetMissionControlOverridesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7a0613f69d37b0a5, []int{16}
+}
+
+func (m *SetMissionControlOverridesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetMissionControlOverridesRequest.Unmarshal(m, b)
+}
+func (m *SetMissionControlOverridesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetMissionControlOverridesRequest.Marshal(b, m, deterministic)
+}
+func (m *SetMissionControlOverridesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetMissionControlOverridesRequest.Merge(m, src)
+}
+func (m *SetMissionControlOverridesRequest) XXX_Size() int {
+	return xxx_messageInfo_SetMissionControlOverridesRequest.Size(m)
+}
+func (m *SetMissionControlOverridesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetMissionControlOverridesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetMissionControlOverridesRequest proto.InternalMessageInfo
+
+func (m *SetMissionControlOverridesRequest) GetOverrides() []*MissionControlOverride {
+	if m != nil {
+		return m.Overrides
+	}
+	return nil
+}
+
+type SetMissionControlOverridesResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetMissionControlOverridesResponse) Reset()         { *m = SetMissionControlOverridesResponse{} }
+func (m *SetMissionControlOverridesResponse) String() string { return proto.CompactTextString(m) }
+func (*SetMissionControlOverridesResponse) ProtoMessage()    {}
+func (*SetMissionControlOverridesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7a0613f69d37b0a5, []int{17}
+}
+
+func (m *SetMissionControlOverridesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetMissionControlOverridesResponse.Unmarshal(m, b)
+}
+func (m *SetMissionControlOverridesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetMissionControlOverridesResponse.Marshal(b, m, deterministic)
+}
+func (m *SetMissionControlOverridesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetMissionControlOverridesResponse.Merge(m, src)
+}
+func (m *SetMissionControlOverridesResponse) XXX_Size() int {
+	return xxx_messageInfo_SetMissionControlOverridesResponse.Size(m)
+}
+func (m *SetMissionControlOverridesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetMissionControlOverridesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetMissionControlOverridesResponse proto.InternalMessageInfo
+
+type ClearMissionControlOverridesRequest struct {
+	// The node of the override to remove. If empty, all overrides are removed.
+	Node []byte `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	// The destination node of the pair override to remove. If empty, the
+	// override of the node is removed.
+	ToNode               []byte   `protobuf:"bytes,2,opt,name=to_node,json=toNode,proto3" json:"to_node,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ClearMissionControlOverridesRequest) Reset()         { *m = ClearMissionControlOverridesRequest{} }
+func (m *ClearMissionControlOverridesRequest) String() string { return proto.CompactTextString(m) }
+func (*ClearMissionControlOverridesRequest) ProtoMessage()    {}
+func (*ClearMissionControlOverridesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7a0613f69d37b0a5, []int{18}
+}
+
+func (m *ClearMissionControlOverridesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ClearMissionControlOverridesRequest.Unmarshal(m, b)
+}
+func (m *ClearMissionControlOverridesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ClearMissionControlOverridesRequest.Marshal(b, m, deterministic)
+}
+func (m *ClearMissionControlOverridesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClearMissionControlOverridesRequest.Merge(m, src)
+}
+func (m *ClearMissionControlOverridesRequest) XXX_Size() int {
+	return xxx_messageInfo_ClearMissionControlOverridesRequest.Size(m)
+}
+func (m *ClearMissionControlOverridesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClearMissionControlOverridesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ClearMissionControlOverridesRequest proto.InternalMessageInfo
+
+func (m *ClearMissionControlOverridesRequest) GetNode() []byte {
+	if m != nil {
+		return m.Node
+	}
+	return nil
+}
+
+func (m *ClearMissionControlOverridesRequest) GetToNode() []byte {
+	if m != nil {
+		return m.ToNode
+	}
+	return nil
+}
+
+type ClearMissionControlOverridesResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ClearMissionControlOverridesResponse) Reset()         { *m = ClearMissionControlOverridesResponse{} }
+func (m *ClearMissionControlOverridesResponse) String() string { return proto.CompactTextString(m) }
+func (*ClearMissionControlOverridesResponse) ProtoMessage()    {}
+func (*ClearMissionControlOverridesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7a0613f69d37b0a5, []int{19}
+}
+
+func (m *ClearMissionControlOverridesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ClearMissionControlOverridesResponse.Unmarshal(m, b)
+}
+func (m *ClearMissionControlOverridesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ClearMissionControlOverridesResponse.Marshal(b, m, deterministic)
+}
+func (m *ClearMissionControlOverridesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClearMissionControlOverridesResponse.Merge(m, src)
+}
+func (m *ClearMissionControlOverridesResponse) XXX_Size() int {
+	return xxx_messageInfo_ClearMissionControlOverridesResponse.Size(m)
+}
+func (m *ClearMissionControlOverridesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClearMissionControlOverridesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ClearMissionControlOverridesResponse proto.InternalMessageInfo
+
+// PairHistory contains the mission control state for a particular node pair.
+type PairHistory struct {
+	// The source node pubkey of the pair.
+	NodeFrom []byte `protobuf:"bytes,1,opt,name=node_from,json=nodeFrom,proto3" json:"node_from,omitempty"`
+	// The destination node pubkey of the pair.
+	NodeTo               []byte    `protobuf:"bytes,2,opt,name=node_to,json=nodeTo,proto3" json:"node_to,omitempty"`
+	History              *PairData `protobuf:"bytes,7,opt,name=history,proto3" json:"history,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *PairHistory) Reset()         { *m = PairHistory{} }
+func (m *PairHistory) String() string { return proto.CompactTextString(m) }
+func (*PairHistory) ProtoMessage()    {}
+func (*PairHistory) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7a0613f69d37b0a5, []int{20}
+}
+
+func (m *PairHistory) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PairHistory.Unmarshal(m, b)
+}
+func (m *PairHistory) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PairHistory.Marshal(b, m, deterministic)
+}
+func (m *PairHistory) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PairHistory.Merge(m, src)
+}
+func (m *PairHistory) XXX_Size() int {
+	return xxx_messageInfo_PairHistory.Size(m)
+}
+func (m *PairHistory) XXX_DiscardUnknown() {
+	xxx_messageInfo_PairHistory.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PairHistory proto.InternalMessageInfo
+
+func (m *PairHistory) GetNodeFrom() []byte {
+	if m != nil {
+		return m.NodeFrom
+	}
+	return nil
+}
+
+func (m *PairHistory) GetNodeTo() []byte {
+	if m != nil {
+		return m.NodeTo
+	}
+	return nil
+}
+
+func (m *PairHistory) GetHistory() *PairData {
+	if m != nil {
+		return m.History
+	}
+	return nil
+}
+
+type PairData struct {
+	// Time of last failure.
+	FailTime int64 `protobuf:"varint,1,opt,name=fail_time,json=failTime,proto3" json:"fail_time,omitempty"`
+	//
+	//Lowest amount that failed to forward rounded to whole sats. This may be
+	//set to zero if the failure is independent of amount.
+	FailAmtSat int64 `protobuf:"varint,2,opt,name=fail_amt_sat,json=failAmtSat,proto3" json:"fail_amt_sat,omitempty"`
+	//
+	//Lowest amount that failed to forward in millisats. This may be
 	//set to zero if the failure is independent of amount.
 	FailAmtMsat int64 `protobuf:"varint,4,opt,name=fail_amt_msat,json=failAmtMsat,proto3" json:"fail_amt_msat,omitempty"`
 	// Time of last success.
@@ -957,7 +1550,7 @@ func (m *PairData) Reset()         { *m = PairData{} }
 func (m *PairData) String() string { return proto.CompactTextString(m) }
 func (*PairData) ProtoMessage()    {}
 func (*PairData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7a0613f69d37b0a5, []int{11}
+	return fileDescriptor_7a0613f69d37b0a5, []int{21}
 }
 
 func (m *PairData) XXX_Unmarshal(b []byte) error {
@@ -1036,7 +1629,7 @@ func (m *QueryProbabilityRequest) Reset()         { *m = QueryProbabilityRequest
 func (m *QueryProbabilityRequest) String() string { return proto.CompactTextString(m) }
 func (*QueryProbabilityRequest) ProtoMessage()    {}
 func (*QueryProbabilityRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7a0613f69d37b0a5, []int{12}
+	return fileDescriptor_7a0613f69d37b0a5, []int{22}
 }
 
 func (m *QueryProbabilityRequest) XXX_Unmarshal(b []byte) error {
@@ -1092,7 +1685,7 @@ func (m *QueryProbabilityResponse) Reset()         { *m = QueryProbabilityRespon
 func (m *QueryProbabilityResponse) String() string { return proto.CompactTextString(m) }
 func (*QueryProbabilityResponse) ProtoMessage()    {}
 func (*QueryProbabilityResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7a0613f69d37b0a5, []int{13}
+	return fileDescriptor_7a0613f69d37b0a5, []int{23}
 }
 
 func (m *QueryProbabilityResponse) XXX_Unmarshal(b []byte) error {
@@ -1143,7 +1736,17 @@ type BuildRouteRequest struct {
 	//
 	//A list of hops that defines the route. This does not include the source hop
 	//pubkey.
-	HopPubkeys           [][]byte `protobuf:"bytes,4,rep,name=hop_pubkeys,json=hopPubkeys,proto3" json:"hop_pubkeys,omitempty"`
+	HopPubkeys [][]byte `protobuf:"bytes,4,rep,name=hop_pubkeys,json=hopPubkeys,proto3" json:"hop_pubkeys,omitempty"`
+	//
+	//Optional constraints for every hop of the route. If set, the list must have
+	//the same length as hop_pubkeys.
+	HopConstraints []*HopConstraints `protobuf:"bytes,5,rep,name=hop_constraints,json=hopConstraints,proto3" json:"hop_constraints,omitempty"`
+	//
+	//A list of nodes that the route must not pass through.
+	AvoidNodes [][]byte `protobuf:"bytes,6,rep,name=avoid_nodes,json=avoidNodes,proto3" json:"avoid_nodes,omitempty"`
+	//
+	//A list of channels that must not be selected for the route.
+	AvoidChanIds         []uint64 `protobuf:"varint,7,rep,packed,name=avoid_chan_ids,json=avoidChanIds,proto3" json:"avoid_chan_ids,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1153,7 +1756,7 @@ func (m *BuildRouteRequest) Reset()         { *m = BuildRouteRequest{} }
 func (m *BuildRouteRequest) String() string { return proto.CompactTextString(m) }
 func (*BuildRouteRequest) ProtoMessage()    {}
 func (*BuildRouteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7a0613f69d37b0a5, []int{14}
+	return fileDescriptor_7a0613f69d37b0a5, []int{24}
 }
 
 func (m *BuildRouteRequest) XXX_Unmarshal(b []byte) error {
@@ -1202,6 +1805,93 @@ func (m *BuildRouteRequest) GetHopPubkeys() [][]byte {
 	return nil
 }
 
+func (m *BuildRouteRequest) GetHopConstraints() []*HopConstraints {
+	if m != nil {
+		return m.HopConstraints
+	}
+	return nil
+}
+
+func (m *BuildRouteRequest) GetAvoidNodes() [][]byte {
+	if m != nil {
+		return m.AvoidNodes
+	}
+	return nil
+}
+
+func (m *BuildRouteRequest) GetAvoidChanIds() []uint64 {
+	if m != nil {
+		return m.AvoidChanIds
+	}
+	return nil
+}
+
+type HopConstraints struct {
+	//
+	//The channel id of the channel that must be taken to reach this hop. If zero,
+	//the channel is selected from all channels between the hop and its
+	//predecessor.
+	ChanId uint64 `protobuf:"varint,1,opt,name=chan_id,json=chanId,proto3" json:"chan_id,omitempty"`
+	//
+	//The time lock delta this hop applies when forwarding. It can't be below the
+	//delta of the channel policy. For the final hop, it replaces
+	//final_cltv_delta. If zero, the delta of the channel policy is used.
+	CltvDelta uint32 `protobuf:"varint,2,opt,name=cltv_delta,json=cltvDelta,proto3" json:"cltv_delta,omitempty"`
+	//
+	//Custom tlv records that are added to the payload of this hop. Record types
+	//are required to be in the custom range >= 65536.
+	CustomRecords        map[uint64][]byte `protobuf:"bytes,3,rep,name=custom_records,json=customRecords,proto3" json:"custom_records,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *HopConstraints) Reset()         { *m = HopConstraints{} }
+func (m *HopConstraints) String() string { return proto.CompactTextString(m) }
+func (*HopConstraints) ProtoMessage()    {}
+func (*HopConstraints) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7a0613f69d37b0a5, []int{25}
+}
+
+func (m *HopConstraints) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HopConstraints.Unmarshal(m, b)
+}
+func (m *HopConstraints) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HopConstraints.Marshal(b, m, deterministic)
+}
+func (m *HopConstraints) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HopConstraints.Merge(m, src)
+}
+func (m *HopConstraints) XXX_Size() int {
+	return xxx_messageInfo_HopConstraints.Size(m)
+}
+func (m *HopConstraints) XXX_DiscardUnknown() {
+	xxx_messageInfo_HopConstraints.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HopConstraints proto.InternalMessageInfo
+
+func (m *HopConstraints) GetChanId() uint64 {
+	if m != nil {
+		return m.ChanId
+	}
+	return 0
+}
+
+func (m *HopConstraints) GetCltvDelta() uint32 {
+	if m != nil {
+		return m.CltvDelta
+	}
+	return 0
+}
+
+func (m *HopConstraints) GetCustomRecords() map[uint64][]byte {
+	if m != nil {
+		return m.CustomRecords
+	}
+	return nil
+}
+
 type BuildRouteResponse struct {
 	//
 	//Fully specified route that can be used to execute the payment.
@@ -1215,7 +1905,7 @@ func (m *BuildRouteResponse) Reset()         { *m = BuildRouteResponse{} }
 func (m *BuildRouteResponse) String() string { return proto.CompactTextString(m) }
 func (*BuildRouteResponse) ProtoMessage()    {}
 func (*BuildRouteResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7a0613f69d37b0a5, []int{15}
+	return fileDescriptor_7a0613f69d37b0a5, []int{26}
 }
 
 func (m *BuildRouteResponse) XXX_Unmarshal(b []byte) error {
@@ -1244,16 +1934,24 @@ func (m *BuildRouteResponse) GetRoute() *lnrpc.Route {
 }
 
 type SubscribeHtlcEventsRequest struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	//
+	//If set, only events of htlcs that arrived at or left our node on one of
+	//these short channel ids are delivered.
+	ChanIds []uint64 `protobuf:"varint,1,rep,packed,name=chan_ids,json=chanIds,proto3" json:"chan_ids,omitempty"`
+	//
+	//If set, only events of htlcs that were part of a send, receive or forward
+	//as given by these event types are delivered.
+	EventTypes           []HtlcEvent_EventType `protobuf:"varint,2,rep,packed,name=event_types,json=eventTypes,proto3,enum=routerrpc.HtlcEvent_EventType" json:"event_types,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
 }
 
 func (m *SubscribeHtlcEventsRequest) Reset()         { *m = SubscribeHtlcEventsRequest{} }
 func (m *SubscribeHtlcEventsRequest) String() string { return proto.CompactTextString(m) }
 func (*SubscribeHtlcEventsRequest) ProtoMessage()    {}
 func (*SubscribeHtlcEventsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7a0613f69d37b0a5, []int{16}
+	return fileDescriptor_7a0613f69d37b0a5, []int{27}
 }
 
 func (m *SubscribeHtlcEventsRequest) XXX_Unmarshal(b []byte) error {
@@ -1274,6 +1972,20 @@ func (m *SubscribeHtlcEventsRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_SubscribeHtlcEventsRequest proto.InternalMessageInfo
 
+func (m *SubscribeHtlcEventsRequest) GetChanIds() []uint64 {
+	if m != nil {
+		return m.ChanIds
+	}
+	return nil
+}
+
+func (m *SubscribeHtlcEventsRequest) GetEventTypes() []HtlcEvent_EventType {
+	if m != nil {
+		return m.EventTypes
+	}
+	return nil
+}
+
 //
 //HtlcEvent contains the htlc event that was processed. These are served on a
 //best-effort basis; events are not persisted, delivery is not guaranteed
@@ -1310,6 +2022,7 @@ type HtlcEvent struct {
 	//	*HtlcEvent_ForwardFailEvent
 	//	*HtlcEvent_SettleEvent
 	//	*HtlcEvent_LinkFailEvent
+	//	*HtlcEvent_HoldAlarmEvent
 	Event                isHtlcEvent_Event `protobuf_oneof:"event"`
 	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
 	XXX_unrecognized     []byte            `json:"-"`
@@ -1320,7 +2033,7 @@ func (m *HtlcEvent) Reset()         { *m = HtlcEvent{} }
 func (m *HtlcEvent) String() string { return proto.CompactTextString(m) }
 func (*HtlcEvent) ProtoMessage()    {}
 func (*HtlcEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7a0613f69d37b0a5, []int{17}
+	return fileDescriptor_7a0613f69d37b0a5, []int{28}
 }
 
 func (m *HtlcEvent) XXX_Unmarshal(b []byte) error {
@@ -1403,6 +2116,10 @@ type HtlcEvent_LinkFailEvent struct {
 	LinkFailEvent *LinkFailEvent `protobuf:"bytes,10,opt,name=link_fail_event,json=linkFailEvent,proto3,oneof"`
 }
 
+type HtlcEvent_HoldAlarmEvent struct {
+	HoldAlarmEvent *HoldAlarmEvent `protobuf:"bytes,11,opt,name=hold_alarm_event,json=holdAlarmEvent,proto3,oneof"`
+}
+
 func (*HtlcEvent_ForwardEvent) isHtlcEvent_Event() {}
 
 func (*HtlcEvent_ForwardFailEvent) isHtlcEvent_Event() {}
@@ -1411,6 +2128,8 @@ func (*HtlcEvent_SettleEvent) isHtlcEvent_Event() {}
 
 func (*HtlcEvent_LinkFailEvent) isHtlcEvent_Event() {}
 
+func (*HtlcEvent_HoldAlarmEvent) isHtlcEvent_Event() {}
+
 func (m *HtlcEvent) GetEvent() isHtlcEvent_Event {
 	if m != nil {
 		return m.Event
@@ -1446,6 +2165,13 @@ func (m *HtlcEvent) GetLinkFailEvent() *LinkFailEvent {
 	return nil
 }
 
+func (m *HtlcEvent) GetHoldAlarmEvent() *HoldAlarmEvent {
+	if x, ok := m.GetEvent().(*HtlcEvent_HoldAlarmEvent); ok {
+		return x.HoldAlarmEvent
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*HtlcEvent) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -1453,6 +2179,7 @@ func (*HtlcEvent) XXX_OneofWrappers() []interface{} {
 		(*HtlcEvent_ForwardFailEvent)(nil),
 		(*HtlcEvent_SettleEvent)(nil),
 		(*HtlcEvent_LinkFailEvent)(nil),
+		(*HtlcEvent_HoldAlarmEvent)(nil),
 	}
 }
 
@@ -1474,7 +2201,7 @@ func (m *HtlcInfo) Reset()         { *m = HtlcInfo{} }
 func (m *HtlcInfo) String() string { return proto.CompactTextString(m) }
 func (*HtlcInfo) ProtoMessage()    {}
 func (*HtlcInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7a0613f69d37b0a5, []int{18}
+	return fileDescriptor_7a0613f69d37b0a5, []int{29}
 }
 
 func (m *HtlcInfo) XXX_Unmarshal(b []byte) error {
@@ -1535,7 +2262,7 @@ func (m *ForwardEvent) Reset()         { *m = ForwardEvent{} }
 func (m *ForwardEvent) String() string { return proto.CompactTextString(m) }
 func (*ForwardEvent) ProtoMessage()    {}
 func (*ForwardEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7a0613f69d37b0a5, []int{19}
+	return fileDescriptor_7a0613f69d37b0a5, []int{30}
 }
 
 func (m *ForwardEvent) XXX_Unmarshal(b []byte) error {
@@ -1573,7 +2300,7 @@ func (m *ForwardFailEvent) Reset()         { *m = ForwardFailEvent{} }
 func (m *ForwardFailEvent) String() string { return proto.CompactTextString(m) }
 func (*ForwardFailEvent) ProtoMessage()    {}
 func (*ForwardFailEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7a0613f69d37b0a5, []int{20}
+	return fileDescriptor_7a0613f69d37b0a5, []int{31}
 }
 
 func (m *ForwardFailEvent) XXX_Unmarshal(b []byte) error {
@@ -1604,7 +2331,7 @@ func (m *SettleEvent) Reset()         { *m = SettleEvent{} }
 func (m *SettleEvent) String() string { return proto.CompactTextString(m) }
 func (*SettleEvent) ProtoMessage()    {}
 func (*SettleEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7a0613f69d37b0a5, []int{21}
+	return fileDescriptor_7a0613f69d37b0a5, []int{32}
 }
 
 func (m *SettleEvent) XXX_Unmarshal(b []byte) error {
@@ -1646,7 +2373,7 @@ func (m *LinkFailEvent) Reset()         { *m = LinkFailEvent{} }
 func (m *LinkFailEvent) String() string { return proto.CompactTextString(m) }
 func (*LinkFailEvent) ProtoMessage()    {}
 func (*LinkFailEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7a0613f69d37b0a5, []int{22}
+	return fileDescriptor_7a0613f69d37b0a5, []int{33}
 }
 
 func (m *LinkFailEvent) XXX_Unmarshal(b []byte) error {
@@ -1695,6 +2422,67 @@ func (m *LinkFailEvent) GetFailureString() string {
 	return ""
 }
 
+type HoldAlarmEvent struct {
+	// Info contains details about the htlc that is held too long.
+	Info *HtlcInfo `protobuf:"bytes,1,opt,name=info,proto3" json:"info,omitempty"`
+	// The number of seconds the incoming htlc has been held for.
+	HoldTimeSeconds uint64 `protobuf:"varint,2,opt,name=hold_time_seconds,json=holdTimeSeconds,proto3" json:"hold_time_seconds,omitempty"`
+	//
+	//Whether the htlc was canceled back. This is only possible for htlcs paying
+	//to an invoice of our node that is still open, and only happens if
+	//automatic failing of htlcs held too long is enabled.
+	Canceled             bool     `protobuf:"varint,3,opt,name=canceled,proto3" json:"canceled,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HoldAlarmEvent) Reset()         { *m = HoldAlarmEvent{} }
+func (m *HoldAlarmEvent) String() string { return proto.CompactTextString(m) }
+func (*HoldAlarmEvent) ProtoMessage()    {}
+func (*HoldAlarmEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7a0613f69d37b0a5, []int{34}
+}
+
+func (m *HoldAlarmEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HoldAlarmEvent.Unmarshal(m, b)
+}
+func (m *HoldAlarmEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HoldAlarmEvent.Marshal(b, m, deterministic)
+}
+func (m *HoldAlarmEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HoldAlarmEvent.Merge(m, src)
+}
+func (m *HoldAlarmEvent) XXX_Size() int {
+	return xxx_messageInfo_HoldAlarmEvent.Size(m)
+}
+func (m *HoldAlarmEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_HoldAlarmEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HoldAlarmEvent proto.InternalMessageInfo
+
+func (m *HoldAlarmEvent) GetInfo() *HtlcInfo {
+	if m != nil {
+		return m.Info
+	}
+	return nil
+}
+
+func (m *HoldAlarmEvent) GetHoldTimeSeconds() uint64 {
+	if m != nil {
+		return m.HoldTimeSeconds
+	}
+	return 0
+}
+
+func (m *HoldAlarmEvent) GetCanceled() bool {
+	if m != nil {
+		return m.Canceled
+	}
+	return false
+}
+
 type PaymentStatus struct {
 	// Current state the payment is in.
 	State PaymentState `protobuf:"varint,1,opt,name=state,proto3,enum=routerrpc.PaymentState" json:"state,omitempty"`
@@ -1713,7 +2501,7 @@ func (m *PaymentStatus) Reset()         { *m = PaymentStatus{} }
 func (m *PaymentStatus) String() string { return proto.CompactTextString(m) }
 func (*PaymentStatus) ProtoMessage()    {}
 func (*PaymentStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7a0613f69d37b0a5, []int{23}
+	return fileDescriptor_7a0613f69d37b0a5, []int{35}
 }
 
 func (m *PaymentStatus) XXX_Unmarshal(b []byte) error {
@@ -1769,7 +2557,7 @@ func (m *CircuitKey) Reset()         { *m = CircuitKey{} }
 func (m *CircuitKey) String() string { return proto.CompactTextString(m) }
 func (*CircuitKey) ProtoMessage()    {}
 func (*CircuitKey) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7a0613f69d37b0a5, []int{24}
+	return fileDescriptor_7a0613f69d37b0a5, []int{36}
 }
 
 func (m *CircuitKey) XXX_Unmarshal(b []byte) error {
@@ -1835,156 +2623,364 @@ type ForwardHtlcInterceptRequest struct {
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ForwardHtlcInterceptRequest) Reset()         { *m = ForwardHtlcInterceptRequest{} }
-func (m *ForwardHtlcInterceptRequest) String() string { return proto.CompactTextString(m) }
-func (*ForwardHtlcInterceptRequest) ProtoMessage()    {}
-func (*ForwardHtlcInterceptRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7a0613f69d37b0a5, []int{25}
+func (m *ForwardHtlcInterceptRequest) Reset()         { *m = ForwardHtlcInterceptRequest{} }
+func (m *ForwardHtlcInterceptRequest) String() string { return proto.CompactTextString(m) }
+func (*ForwardHtlcInterceptRequest) ProtoMessage()    {}
+func (*ForwardHtlcInterceptRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7a0613f69d37b0a5, []int{37}
+}
+
+func (m *ForwardHtlcInterceptRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ForwardHtlcInterceptRequest.Unmarshal(m, b)
+}
+func (m *ForwardHtlcInterceptRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ForwardHtlcInterceptRequest.Marshal(b, m, deterministic)
+}
+func (m *ForwardHtlcInterceptRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ForwardHtlcInterceptRequest.Merge(m, src)
+}
+func (m *ForwardHtlcInterceptRequest) XXX_Size() int {
+	return xxx_messageInfo_ForwardHtlcInterceptRequest.Size(m)
+}
+func (m *ForwardHtlcInterceptRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ForwardHtlcInterceptRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ForwardHtlcInterceptRequest proto.InternalMessageInfo
+
+func (m *ForwardHtlcInterceptRequest) GetIncomingCircuitKey() *CircuitKey {
+	if m != nil {
+		return m.IncomingCircuitKey
+	}
+	return nil
+}
+
+func (m *ForwardHtlcInterceptRequest) GetIncomingAmountMsat() uint64 {
+	if m != nil {
+		return m.IncomingAmountMsat
+	}
+	return 0
+}
+
+func (m *ForwardHtlcInterceptRequest) GetIncomingExpiry() uint32 {
+	if m != nil {
+		return m.IncomingExpiry
+	}
+	return 0
+}
+
+func (m *ForwardHtlcInterceptRequest) GetPaymentHash() []byte {
+	if m != nil {
+		return m.PaymentHash
+	}
+	return nil
+}
+
+func (m *ForwardHtlcInterceptRequest) GetOutgoingRequestedChanId() uint64 {
+	if m != nil {
+		return m.OutgoingRequestedChanId
+	}
+	return 0
+}
+
+func (m *ForwardHtlcInterceptRequest) GetOutgoingAmountMsat() uint64 {
+	if m != nil {
+		return m.OutgoingAmountMsat
+	}
+	return 0
+}
+
+func (m *ForwardHtlcInterceptRequest) GetOutgoingExpiry() uint32 {
+	if m != nil {
+		return m.OutgoingExpiry
+	}
+	return 0
+}
+
+func (m *ForwardHtlcInterceptRequest) GetCustomRecords() map[uint64][]byte {
+	if m != nil {
+		return m.CustomRecords
+	}
+	return nil
+}
+
+func (m *ForwardHtlcInterceptRequest) GetOnionBlob() []byte {
+	if m != nil {
+		return m.OnionBlob
+	}
+	return nil
+}
+
+//*
+//ForwardHtlcInterceptResponse enables the caller to resolve a previously hold
+//forward. The caller can choose either to:
+//- `Resume`: Execute the default behavior (usually forward).
+//- `Reject`: Fail the htlc backwards.
+//- `Settle`: Settle this htlc with a given preimage.
+type ForwardHtlcInterceptResponse struct {
+	//*
+	//The key of this forwarded htlc. It defines the incoming channel id and
+	//the index in this channel.
+	IncomingCircuitKey *CircuitKey `protobuf:"bytes,1,opt,name=incoming_circuit_key,json=incomingCircuitKey,proto3" json:"incoming_circuit_key,omitempty"`
+	// The resolve action for this intercepted htlc.
+	Action ResolveHoldForwardAction `protobuf:"varint,2,opt,name=action,proto3,enum=routerrpc.ResolveHoldForwardAction" json:"action,omitempty"`
+	// The preimage in case the resolve action is Settle.
+	Preimage             []byte   `protobuf:"bytes,3,opt,name=preimage,proto3" json:"preimage,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ForwardHtlcInterceptResponse) Reset()         { *m = ForwardHtlcInterceptResponse{} }
+func (m *ForwardHtlcInterceptResponse) String() string { return proto.CompactTextString(m) }
+func (*ForwardHtlcInterceptResponse) ProtoMessage()    {}
+func (*ForwardHtlcInterceptResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7a0613f69d37b0a5, []int{38}
+}
+
+func (m *ForwardHtlcInterceptResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ForwardHtlcInterceptResponse.Unmarshal(m, b)
+}
+func (m *ForwardHtlcInterceptResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ForwardHtlcInterceptResponse.Marshal(b, m, deterministic)
+}
+func (m *ForwardHtlcInterceptResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ForwardHtlcInterceptResponse.Merge(m, src)
+}
+func (m *ForwardHtlcInterceptResponse) XXX_Size() int {
+	return xxx_messageInfo_ForwardHtlcInterceptResponse.Size(m)
+}
+func (m *ForwardHtlcInterceptResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ForwardHtlcInterceptResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ForwardHtlcInterceptResponse proto.InternalMessageInfo
+
+func (m *ForwardHtlcInterceptResponse) GetIncomingCircuitKey() *CircuitKey {
+	if m != nil {
+		return m.IncomingCircuitKey
+	}
+	return nil
+}
+
+func (m *ForwardHtlcInterceptResponse) GetAction() ResolveHoldForwardAction {
+	if m != nil {
+		return m.Action
+	}
+	return ResolveHoldForwardAction_SETTLE
+}
+
+func (m *ForwardHtlcInterceptResponse) GetPreimage() []byte {
+	if m != nil {
+		return m.Preimage
+	}
+	return nil
+}
+
+type PaymentInterceptRequest struct {
+	// The ID of this interception, which the response must refer to.
+	InterceptId uint64 `protobuf:"varint,1,opt,name=intercept_id,json=interceptId,proto3" json:"intercept_id,omitempty"`
+	// The hash of the payment.
+	PaymentHash []byte `protobuf:"bytes,2,opt,name=payment_hash,json=paymentHash,proto3" json:"payment_hash,omitempty"`
+	// The identity pubkey of the payment recipient.
+	Dest []byte `protobuf:"bytes,3,opt,name=dest,proto3" json:"dest,omitempty"`
+	// The amount paid to the recipient in millisatoshis.
+	AmtMsat uint64 `protobuf:"varint,4,opt,name=amt_msat,json=amtMsat,proto3" json:"amt_msat,omitempty"`
+	// The maximum fee the payment may pay in millisatoshis.
+	FeeLimitMsat uint64 `protobuf:"varint,5,opt,name=fee_limit_msat,json=feeLimitMsat,proto3" json:"fee_limit_msat,omitempty"`
+	// The maximum time lock of the payment. Zero means no limit.
+	CltvLimit uint32 `protobuf:"varint,6,opt,name=cltv_limit,json=cltvLimit,proto3" json:"cltv_limit,omitempty"`
+	//
+	//The channels that are allowed for the first hop. If empty, any channel may
+	//be used.
+	OutgoingChanIds []uint64 `protobuf:"varint,7,rep,packed,name=outgoing_chan_ids,json=outgoingChanIds,proto3" json:"outgoing_chan_ids,omitempty"`
+	// The payment request the payment completes, if any.
+	PaymentRequest string `protobuf:"bytes,8,opt,name=payment_request,json=paymentRequest,proto3" json:"payment_request,omitempty"`
+	//
+	//The route the payment is sent along if the caller chose it. The fee limit,
+	//cltv limit and outgoing channel of such a payment are taken from the route.
+	Route                *lnrpc.Route `protobuf:"bytes,9,opt,name=route,proto3" json:"route,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *PaymentInterceptRequest) Reset()         { *m = PaymentInterceptRequest{} }
+func (m *PaymentInterceptRequest) String() string { return proto.CompactTextString(m) }
+func (*PaymentInterceptRequest) ProtoMessage()    {}
+func (*PaymentInterceptRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7a0613f69d37b0a5, []int{39}
 }
 
-func (m *ForwardHtlcInterceptRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ForwardHtlcInterceptRequest.Unmarshal(m, b)
+func (m *PaymentInterceptRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PaymentInterceptRequest.Unmarshal(m, b)
 }
-func (m *ForwardHtlcInterceptRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ForwardHtlcInterceptRequest.Marshal(b, m, deterministic)
+func (m *PaymentInterceptRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PaymentInterceptRequest.Marshal(b, m, deterministic)
 }
-func (m *ForwardHtlcInterceptRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ForwardHtlcInterceptRequest.Merge(m, src)
+func (m *PaymentInterceptRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PaymentInterceptRequest.Merge(m, src)
 }
-func (m *ForwardHtlcInterceptRequest) XXX_Size() int {
-	return xxx_messageInfo_ForwardHtlcInterceptRequest.Size(m)
+func (m *PaymentInterceptRequest) XXX_Size() int {
+	return xxx_messageInfo_PaymentInterceptRequest.Size(m)
 }
-func (m *ForwardHtlcInterceptRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ForwardHtlcInterceptRequest.DiscardUnknown(m)
+func (m *PaymentInterceptRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PaymentInterceptRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ForwardHtlcInterceptRequest proto.InternalMessageInfo
-
-func (m *ForwardHtlcInterceptRequest) GetIncomingCircuitKey() *CircuitKey {
-	if m != nil {
-		return m.IncomingCircuitKey
-	}
-	return nil
-}
+var xxx_messageInfo_PaymentInterceptRequest proto.InternalMessageInfo
 
-func (m *ForwardHtlcInterceptRequest) GetIncomingAmountMsat() uint64 {
+func (m *PaymentInterceptRequest) GetInterceptId() uint64 {
 	if m != nil {
-		return m.IncomingAmountMsat
+		return m.InterceptId
 	}
 	return 0
 }
 
-func (m *ForwardHtlcInterceptRequest) GetIncomingExpiry() uint32 {
+func (m *PaymentInterceptRequest) GetPaymentHash() []byte {
 	if m != nil {
-		return m.IncomingExpiry
+		return m.PaymentHash
 	}
-	return 0
+	return nil
 }
 
-func (m *ForwardHtlcInterceptRequest) GetPaymentHash() []byte {
+func (m *PaymentInterceptRequest) GetDest() []byte {
 	if m != nil {
-		return m.PaymentHash
+		return m.Dest
 	}
 	return nil
 }
 
-func (m *ForwardHtlcInterceptRequest) GetOutgoingRequestedChanId() uint64 {
+func (m *PaymentInterceptRequest) GetAmtMsat() uint64 {
 	if m != nil {
-		return m.OutgoingRequestedChanId
+		return m.AmtMsat
 	}
 	return 0
 }
 
-func (m *ForwardHtlcInterceptRequest) GetOutgoingAmountMsat() uint64 {
+func (m *PaymentInterceptRequest) GetFeeLimitMsat() uint64 {
 	if m != nil {
-		return m.OutgoingAmountMsat
+		return m.FeeLimitMsat
 	}
 	return 0
 }
 
-func (m *ForwardHtlcInterceptRequest) GetOutgoingExpiry() uint32 {
+func (m *PaymentInterceptRequest) GetCltvLimit() uint32 {
 	if m != nil {
-		return m.OutgoingExpiry
+		return m.CltvLimit
 	}
 	return 0
 }
 
-func (m *ForwardHtlcInterceptRequest) GetCustomRecords() map[uint64][]byte {
+func (m *PaymentInterceptRequest) GetOutgoingChanIds() []uint64 {
 	if m != nil {
-		return m.CustomRecords
+		return m.OutgoingChanIds
 	}
 	return nil
 }
 
-func (m *ForwardHtlcInterceptRequest) GetOnionBlob() []byte {
+func (m *PaymentInterceptRequest) GetPaymentRequest() string {
 	if m != nil {
-		return m.OnionBlob
+		return m.PaymentRequest
+	}
+	return ""
+}
+
+func (m *PaymentInterceptRequest) GetRoute() *lnrpc.Route {
+	if m != nil {
+		return m.Route
 	}
 	return nil
 }
 
-//*
-//ForwardHtlcInterceptResponse enables the caller to resolve a previously hold
-//forward. The caller can choose either to:
-//- `Resume`: Execute the default behavior (usually forward).
-//- `Reject`: Fail the htlc backwards.
-//- `Settle`: Settle this htlc with a given preimage.
-type ForwardHtlcInterceptResponse struct {
-	//*
-	//The key of this forwarded htlc. It defines the incoming channel id and
-	//the index in this channel.
-	IncomingCircuitKey *CircuitKey `protobuf:"bytes,1,opt,name=incoming_circuit_key,json=incomingCircuitKey,proto3" json:"incoming_circuit_key,omitempty"`
-	// The resolve action for this intercepted htlc.
-	Action ResolveHoldForwardAction `protobuf:"varint,2,opt,name=action,proto3,enum=routerrpc.ResolveHoldForwardAction" json:"action,omitempty"`
-	// The preimage in case the resolve action is Settle.
-	Preimage             []byte   `protobuf:"bytes,3,opt,name=preimage,proto3" json:"preimage,omitempty"`
+type PaymentInterceptResponse struct {
+	// The ID of the interception this response refers to.
+	InterceptId uint64 `protobuf:"varint,1,opt,name=intercept_id,json=interceptId,proto3" json:"intercept_id,omitempty"`
+	// The action to take for the payment.
+	Action PaymentInterceptAction `protobuf:"varint,2,opt,name=action,proto3,enum=routerrpc.PaymentInterceptAction" json:"action,omitempty"`
+	// The reason for rejecting the payment, returned to the payer.
+	RejectReason string `protobuf:"bytes,3,opt,name=reject_reason,json=rejectReason,proto3" json:"reject_reason,omitempty"`
+	//
+	//If non-zero, the fee limit of the payment is replaced. Payments along a
+	//route chosen by the caller are rejected if the route exceeds it.
+	FeeLimitMsat uint64 `protobuf:"varint,4,opt,name=fee_limit_msat,json=feeLimitMsat,proto3" json:"fee_limit_msat,omitempty"`
+	//
+	//If non-zero, the cltv limit of the payment is replaced. Payments along a
+	//route chosen by the caller are rejected if the route exceeds it.
+	CltvLimit uint32 `protobuf:"varint,5,opt,name=cltv_limit,json=cltvLimit,proto3" json:"cltv_limit,omitempty"`
+	//
+	//If non-empty, the channels that are allowed for the first hop are replaced.
+	//Payments along a route chosen by the caller are rejected if the route
+	//starts with another channel.
+	OutgoingChanIds      []uint64 `protobuf:"varint,6,rep,packed,name=outgoing_chan_ids,json=outgoingChanIds,proto3" json:"outgoing_chan_ids,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ForwardHtlcInterceptResponse) Reset()         { *m = ForwardHtlcInterceptResponse{} }
-func (m *ForwardHtlcInterceptResponse) String() string { return proto.CompactTextString(m) }
-func (*ForwardHtlcInterceptResponse) ProtoMessage()    {}
-func (*ForwardHtlcInterceptResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7a0613f69d37b0a5, []int{26}
+func (m *PaymentInterceptResponse) Reset()         { *m = PaymentInterceptResponse{} }
+func (m *PaymentInterceptResponse) String() string { return proto.CompactTextString(m) }
+func (*PaymentInterceptResponse) ProtoMessage()    {}
+func (*PaymentInterceptResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7a0613f69d37b0a5, []int{40}
 }
 
-func (m *ForwardHtlcInterceptResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ForwardHtlcInterceptResponse.Unmarshal(m, b)
+func (m *PaymentInterceptResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PaymentInterceptResponse.Unmarshal(m, b)
 }
-func (m *ForwardHtlcInterceptResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ForwardHtlcInterceptResponse.Marshal(b, m, deterministic)
+func (m *PaymentInterceptResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PaymentInterceptResponse.Marshal(b, m, deterministic)
 }
-func (m *ForwardHtlcInterceptResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ForwardHtlcInterceptResponse.Merge(m, src)
+func (m *PaymentInterceptResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PaymentInterceptResponse.Merge(m, src)
 }
-func (m *ForwardHtlcInterceptResponse) XXX_Size() int {
-	return xxx_messageInfo_ForwardHtlcInterceptResponse.Size(m)
+func (m *PaymentInterceptResponse) XXX_Size() int {
+	return xxx_messageInfo_PaymentInterceptResponse.Size(m)
 }
-func (m *ForwardHtlcInterceptResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ForwardHtlcInterceptResponse.DiscardUnknown(m)
+func (m *PaymentInterceptResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PaymentInterceptResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ForwardHtlcInterceptResponse proto.InternalMessageInfo
+var xxx_messageInfo_PaymentInterceptResponse proto.InternalMessageInfo
 
-func (m *ForwardHtlcInterceptResponse) GetIncomingCircuitKey() *CircuitKey {
+func (m *PaymentInterceptResponse) GetInterceptId() uint64 {
 	if m != nil {
-		return m.IncomingCircuitKey
+		return m.InterceptId
 	}
-	return nil
+	return 0
 }
 
-func (m *ForwardHtlcInterceptResponse) GetAction() ResolveHoldForwardAction {
+func (m *PaymentInterceptResponse) GetAction() PaymentInterceptAction {
 	if m != nil {
 		return m.Action
 	}
-	return ResolveHoldForwardAction_SETTLE
+	return PaymentInterceptAction_PROCEED
 }
 
-func (m *ForwardHtlcInterceptResponse) GetPreimage() []byte {
+func (m *PaymentInterceptResponse) GetRejectReason() string {
 	if m != nil {
-		return m.Preimage
+		return m.RejectReason
+	}
+	return ""
+}
+
+func (m *PaymentInterceptResponse) GetFeeLimitMsat() uint64 {
+	if m != nil {
+		return m.FeeLimitMsat
+	}
+	return 0
+}
+
+func (m *PaymentInterceptResponse) GetCltvLimit() uint32 {
+	if m != nil {
+		return m.CltvLimit
+	}
+	return 0
+}
+
+func (m *PaymentInterceptResponse) GetOutgoingChanIds() []uint64 {
+	if m != nil {
+		return m.OutgoingChanIds
 	}
 	return nil
 }
@@ -1993,23 +2989,36 @@ func init() {
 	proto.RegisterEnum("routerrpc.FailureDetail", FailureDetail_name, FailureDetail_value)
 	proto.RegisterEnum("routerrpc.PaymentState", PaymentState_name, PaymentState_value)
 	proto.RegisterEnum("routerrpc.ResolveHoldForwardAction", ResolveHoldForwardAction_name, ResolveHoldForwardAction_value)
+	proto.RegisterEnum("routerrpc.PaymentInterceptAction", PaymentInterceptAction_name, PaymentInterceptAction_value)
 	proto.RegisterEnum("routerrpc.HtlcEvent_EventType", HtlcEvent_EventType_name, HtlcEvent_EventType_value)
 	proto.RegisterType((*SendPaymentRequest)(nil), "routerrpc.SendPaymentRequest")
 	proto.RegisterMapType((map[uint64][]byte)(nil), "routerrpc.SendPaymentRequest.DestCustomRecordsEntry")
 	proto.RegisterType((*TrackPaymentRequest)(nil), "routerrpc.TrackPaymentRequest")
 	proto.RegisterType((*RouteFeeRequest)(nil), "routerrpc.RouteFeeRequest")
 	proto.RegisterType((*RouteFeeResponse)(nil), "routerrpc.RouteFeeResponse")
+	proto.RegisterType((*ProbeRouteRequest)(nil), "routerrpc.ProbeRouteRequest")
+	proto.RegisterType((*ProbeResult)(nil), "routerrpc.ProbeResult")
+	proto.RegisterType((*ProbeRouteResponse)(nil), "routerrpc.ProbeRouteResponse")
 	proto.RegisterType((*SendToRouteRequest)(nil), "routerrpc.SendToRouteRequest")
 	proto.RegisterType((*SendToRouteResponse)(nil), "routerrpc.SendToRouteResponse")
+	proto.RegisterType((*FailPaymentRequest)(nil), "routerrpc.FailPaymentRequest")
+	proto.RegisterType((*FailPaymentResponse)(nil), "routerrpc.FailPaymentResponse")
 	proto.RegisterType((*ResetMissionControlRequest)(nil), "routerrpc.ResetMissionControlRequest")
 	proto.RegisterType((*ResetMissionControlResponse)(nil), "routerrpc.ResetMissionControlResponse")
 	proto.RegisterType((*QueryMissionControlRequest)(nil), "routerrpc.QueryMissionControlRequest")
 	proto.RegisterType((*QueryMissionControlResponse)(nil), "routerrpc.QueryMissionControlResponse")
+	proto.RegisterType((*MissionControlOverride)(nil), "routerrpc.MissionControlOverride")
+	proto.RegisterType((*SetMissionControlOverridesRequest)(nil), "routerrpc.SetMissionControlOverridesRequest")
+	proto.RegisterType((*SetMissionControlOverridesResponse)(nil), "routerrpc.SetMissionControlOverridesResponse")
+	proto.RegisterType((*ClearMissionControlOverridesRequest)(nil), "routerrpc.ClearMissionControlOverridesRequest")
+	proto.RegisterType((*ClearMissionControlOverridesResponse)(nil), "routerrpc.ClearMissionControlOverridesResponse")
 	proto.RegisterType((*PairHistory)(nil), "routerrpc.PairHistory")
 	proto.RegisterType((*PairData)(nil), "routerrpc.PairData")
 	proto.RegisterType((*QueryProbabilityRequest)(nil), "routerrpc.QueryProbabilityRequest")
 	proto.RegisterType((*QueryProbabilityResponse)(nil), "routerrpc.QueryProbabilityResponse")
 	proto.RegisterType((*BuildRouteRequest)(nil), "routerrpc.BuildRouteRequest")
+	proto.RegisterType((*HopConstraints)(nil), "routerrpc.HopConstraints")
+	proto.RegisterMapType((map[uint64][]byte)(nil), "routerrpc.HopConstraints.CustomRecordsEntry")
 	proto.RegisterType((*BuildRouteResponse)(nil), "routerrpc.BuildRouteResponse")
 	proto.RegisterType((*SubscribeHtlcEventsRequest)(nil), "routerrpc.SubscribeHtlcEventsRequest")
 	proto.RegisterType((*HtlcEvent)(nil), "routerrpc.HtlcEvent")
@@ -2018,180 +3027,235 @@ func init() {
 	proto.RegisterType((*ForwardFailEvent)(nil), "routerrpc.ForwardFailEvent")
 	proto.RegisterType((*SettleEvent)(nil), "routerrpc.SettleEvent")
 	proto.RegisterType((*LinkFailEvent)(nil), "routerrpc.LinkFailEvent")
+	proto.RegisterType((*HoldAlarmEvent)(nil), "routerrpc.HoldAlarmEvent")
 	proto.RegisterType((*PaymentStatus)(nil), "routerrpc.PaymentStatus")
 	proto.RegisterType((*CircuitKey)(nil), "routerrpc.CircuitKey")
 	proto.RegisterType((*ForwardHtlcInterceptRequest)(nil), "routerrpc.ForwardHtlcInterceptRequest")
 	proto.RegisterMapType((map[uint64][]byte)(nil), "routerrpc.ForwardHtlcInterceptRequest.CustomRecordsEntry")
 	proto.RegisterType((*ForwardHtlcInterceptResponse)(nil), "routerrpc.ForwardHtlcInterceptResponse")
+	proto.RegisterType((*PaymentInterceptRequest)(nil), "routerrpc.PaymentInterceptRequest")
+	proto.RegisterType((*PaymentInterceptResponse)(nil), "routerrpc.PaymentInterceptResponse")
 }
 
 func init() { proto.RegisterFile("routerrpc/router.proto", fileDescriptor_7a0613f69d37b0a5) }
 
 var fileDescriptor_7a0613f69d37b0a5 = []byte{
-	// 2601 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x59, 0x4d, 0x73, 0xdb, 0xc6,
-	0xf9, 0x0f, 0x48, 0x88, 0x22, 0x1f, 0xbe, 0x08, 0x5a, 0x29, 0x16, 0xff, 0x94, 0x9d, 0x30, 0x4c,
-	0x62, 0x73, 0xfc, 0x4f, 0x64, 0x45, 0xed, 0xb4, 0x69, 0xf3, 0xd2, 0x50, 0x24, 0x64, 0xc1, 0xa6,
-	0x48, 0x66, 0x49, 0x39, 0x49, 0x73, 0xd8, 0x42, 0xe4, 0x52, 0x44, 0x05, 0x02, 0x2c, 0xb0, 0xb4,
-	0xa3, 0x63, 0x6f, 0x9d, 0x7e, 0x91, 0xde, 0xfa, 0x09, 0x3a, 0xd3, 0x1e, 0xfa, 0x3d, 0x7a, 0xed,
-	0xbd, 0x33, 0x3d, 0x77, 0xf6, 0x05, 0x20, 0x20, 0x51, 0x76, 0x3b, 0xed, 0xc5, 0x26, 0x7e, 0xcf,
-	0x6f, 0x9f, 0x7d, 0x76, 0x9f, 0xb7, 0xdd, 0x15, 0xdc, 0x0b, 0xfc, 0x25, 0xa3, 0x41, 0xb0, 0x18,
-	0x3f, 0x91, 0xbf, 0x0e, 0x16, 0x81, 0xcf, 0x7c, 0x54, 0x88, 0xf1, 0x5a, 0x21, 0x58, 0x8c, 0x25,
-	0xda, 0xf8, 0x47, 0x0e, 0xd0, 0x90, 0x7a, 0x93, 0x81, 0x7d, 0x3d, 0xa7, 0x1e, 0xc3, 0xf4, 0x37,
-	0x4b, 0x1a, 0x32, 0x84, 0x40, 0x9f, 0xd0, 0x90, 0x55, 0xb5, 0xba, 0xd6, 0x2c, 0x61, 0xf1, 0x1b,
-	0x19, 0x90, 0xb5, 0xe7, 0xac, 0x9a, 0xa9, 0x6b, 0xcd, 0x2c, 0xe6, 0x3f, 0xd1, 0xff, 0x41, 0xde,
-	0x9e, 0x33, 0x32, 0x0f, 0x6d, 0x56, 0x2d, 0x09, 0x78, 0xd3, 0x9e, 0xb3, 0xb3, 0xd0, 0x66, 0xe8,
-	0x3d, 0x28, 0x2d, 0xa4, 0x4a, 0x32, 0xb3, 0xc3, 0x59, 0x35, 0x2b, 0x14, 0x15, 0x15, 0x76, 0x6a,
-	0x87, 0x33, 0xd4, 0x04, 0x63, 0xea, 0x78, 0xb6, 0x4b, 0xc6, 0x2e, 0x7b, 0x49, 0x26, 0xd4, 0x65,
-	0x76, 0x55, 0xaf, 0x6b, 0xcd, 0x0d, 0x5c, 0x11, 0x78, 0xdb, 0x65, 0x2f, 0x3b, 0x1c, 0x45, 0x8f,
-	0x60, 0x2b, 0x52, 0x16, 0x48, 0x03, 0xab, 0x1b, 0x75, 0xad, 0x59, 0xc0, 0x95, 0x45, 0xda, 0xec,
-	0x47, 0xb0, 0xc5, 0x9c, 0x39, 0xf5, 0x97, 0x8c, 0x84, 0x74, 0xec, 0x7b, 0x93, 0xb0, 0x9a, 0x93,
-	0x1a, 0x15, 0x3c, 0x94, 0x28, 0x6a, 0x40, 0x79, 0x4a, 0x29, 0x71, 0x9d, 0xb9, 0xc3, 0x08, 0x37,
-	0x7f, 0x53, 0x98, 0x5f, 0x9c, 0x52, 0xda, 0xe5, 0xd8, 0xd0, 0x66, 0xe8, 0x03, 0xa8, 0xac, 0x38,
-	0x62, 0x8d, 0x65, 0x41, 0x2a, 0x45, 0x24, 0xb1, 0xd0, 0x03, 0x30, 0xfc, 0x25, 0xbb, 0xf4, 0x1d,
-	0xef, 0x92, 0x8c, 0x67, 0xb6, 0x47, 0x9c, 0x49, 0x35, 0x5f, 0xd7, 0x9a, 0xfa, 0xb1, 0x5e, 0xd5,
-	0x0e, 0x35, 0x5c, 0x89, 0xa4, 0xed, 0x99, 0xed, 0x59, 0x13, 0xf4, 0x18, 0xb6, 0x6f, 0xf2, 0xc3,
-	0xea, 0x4e, 0x3d, 0xdb, 0xd4, 0xf1, 0x56, 0x9a, 0x1a, 0xa2, 0x87, 0xb0, 0xe5, 0xda, 0x21, 0x23,
-	0x33, 0x7f, 0x41, 0x16, 0xcb, 0x8b, 0x2b, 0x7a, 0x5d, 0xad, 0x88, 0x7d, 0x2c, 0x73, 0xf8, 0xd4,
-	0x5f, 0x0c, 0x04, 0x88, 0x1e, 0x00, 0x88, 0x3d, 0x14, 0xa6, 0x56, 0x0b, 0x62, 0xc5, 0x05, 0x8e,
-	0x08, 0x33, 0xd1, 0x27, 0x50, 0x14, 0xbe, 0x27, 0x33, 0xc7, 0x63, 0x61, 0x15, 0xea, 0xd9, 0x66,
-	0xf1, 0xc8, 0x38, 0x70, 0x3d, 0x1e, 0x06, 0x98, 0x4b, 0x4e, 0x1d, 0x8f, 0x61, 0x08, 0xa2, 0x9f,
-	0x21, 0x9a, 0xc0, 0x0e, 0xf7, 0x39, 0x19, 0x2f, 0x43, 0xe6, 0xcf, 0x49, 0x40, 0xc7, 0x7e, 0x30,
-	0x09, 0xab, 0x45, 0x31, 0xf4, 0xc7, 0x07, 0x71, 0x28, 0x1d, 0xdc, 0x8e, 0x9d, 0x83, 0x0e, 0x0d,
-	0x59, 0x5b, 0x8c, 0xc3, 0x72, 0x98, 0xe9, 0xb1, 0xe0, 0x1a, 0x6f, 0x4f, 0x6e, 0xe2, 0xe8, 0x23,
-	0x40, 0xb6, 0xeb, 0xfa, 0xaf, 0x48, 0x48, 0xdd, 0x29, 0x51, 0xbe, 0xac, 0x6e, 0xd5, 0xb5, 0x66,
-	0x1e, 0x1b, 0x42, 0x32, 0xa4, 0xee, 0x54, 0xa9, 0x47, 0x3f, 0x81, 0xb2, 0xb0, 0x69, 0x4a, 0x6d,
-	0xb6, 0x0c, 0x68, 0x58, 0x35, 0xea, 0xd9, 0x66, 0xe5, 0x68, 0x5b, 0x2d, 0xe4, 0x44, 0xc2, 0xc7,
-	0x0e, 0xc3, 0x25, 0xce, 0x53, 0xdf, 0x21, 0xda, 0x87, 0xc2, 0xdc, 0xfe, 0x81, 0x2c, 0xec, 0x80,
-	0x85, 0xd5, 0xed, 0xba, 0xd6, 0x2c, 0xe3, 0xfc, 0xdc, 0xfe, 0x61, 0xc0, 0xbf, 0xd1, 0x01, 0xec,
-	0x78, 0x3e, 0x71, 0xbc, 0xa9, 0xeb, 0x5c, 0xce, 0x18, 0x59, 0x2e, 0x26, 0x36, 0xa3, 0x61, 0x15,
-	0x09, 0x1b, 0xb6, 0x3d, 0xdf, 0x52, 0x92, 0x73, 0x29, 0xa8, 0x75, 0xe0, 0xde, 0xfa, 0xf5, 0xf1,
-	0xf4, 0xe0, 0x0e, 0xe2, 0x19, 0xa3, 0x63, 0xfe, 0x13, 0xed, 0xc2, 0xc6, 0x4b, 0xdb, 0x5d, 0x52,
-	0x91, 0x32, 0x25, 0x2c, 0x3f, 0x7e, 0x9e, 0xf9, 0x54, 0x6b, 0xcc, 0x60, 0x67, 0x14, 0xd8, 0xe3,
-	0xab, 0x1b, 0x59, 0x77, 0x33, 0x69, 0xb4, 0xdb, 0x49, 0x73, 0x87, 0xbd, 0x99, 0x3b, 0xec, 0x6d,
-	0x7c, 0x09, 0x5b, 0xc2, 0xc3, 0x27, 0x94, 0xbe, 0x2e, 0xb7, 0xf7, 0x80, 0x67, 0xae, 0xc8, 0x04,
-	0x99, 0xdf, 0x39, 0x7b, 0xce, 0x93, 0xa0, 0x31, 0x01, 0x63, 0x35, 0x3e, 0x5c, 0xf8, 0x5e, 0x48,
-	0x79, 0xe2, 0xf2, 0x00, 0xe0, 0x11, 0xcc, 0x13, 0x44, 0xa4, 0x86, 0x26, 0x46, 0x55, 0x14, 0x7e,
-	0x42, 0xa9, 0x48, 0x8e, 0x87, 0x32, 0x1f, 0x89, 0xeb, 0x8f, 0xaf, 0x78, 0x86, 0xdb, 0xd7, 0x4a,
-	0x7d, 0x99, 0xc3, 0x5d, 0x7f, 0x7c, 0xd5, 0xe1, 0x60, 0xe3, 0x7b, 0x59, 0x84, 0x46, 0xbe, 0x98,
-	0xeb, 0x3f, 0xd8, 0x8e, 0x06, 0x6c, 0x88, 0x58, 0x14, 0x6a, 0x8b, 0x47, 0xa5, 0x64, 0x50, 0x63,
-	0x29, 0x6a, 0x7c, 0x0f, 0x3b, 0x29, 0xe5, 0x6a, 0x15, 0x35, 0xc8, 0x2f, 0x02, 0xea, 0xcc, 0xed,
-	0x4b, 0xaa, 0x34, 0xc7, 0xdf, 0xa8, 0x09, 0x9b, 0x53, 0xdb, 0x71, 0x97, 0x41, 0xa4, 0xb8, 0x12,
-	0x05, 0x99, 0x44, 0x71, 0x24, 0x6e, 0xdc, 0x87, 0x1a, 0xa6, 0x21, 0x65, 0x67, 0x4e, 0x18, 0x3a,
-	0xbe, 0xd7, 0xf6, 0x3d, 0x16, 0xf8, 0xae, 0x5a, 0x41, 0xe3, 0x01, 0xec, 0xaf, 0x95, 0x4a, 0x13,
-	0xf8, 0xe0, 0xaf, 0x97, 0x34, 0xb8, 0x5e, 0x3f, 0xf8, 0x6b, 0xd8, 0x5f, 0x2b, 0x55, 0xf6, 0x7f,
-	0x04, 0x1b, 0x0b, 0xdb, 0x09, 0xb8, 0xef, 0x79, 0x52, 0xde, 0x4b, 0x24, 0xe5, 0xc0, 0x76, 0x82,
-	0x53, 0x27, 0x64, 0x7e, 0x70, 0x8d, 0x25, 0xe9, 0x99, 0x9e, 0xd7, 0x8c, 0x4c, 0xe3, 0xf7, 0x1a,
-	0x14, 0x13, 0x42, 0x9e, 0x1a, 0x9e, 0x3f, 0xa1, 0x64, 0x1a, 0xf8, 0xf3, 0x68, 0x13, 0x38, 0x70,
-	0x12, 0xf8, 0x73, 0x1e, 0x13, 0x42, 0xc8, 0x7c, 0x15, 0xc0, 0x39, 0xfe, 0x39, 0xf2, 0xd1, 0xc7,
-	0xb0, 0x39, 0x93, 0x0a, 0x44, 0xd9, 0x2c, 0x1e, 0xed, 0xdc, 0x98, 0xbb, 0x63, 0x33, 0x1b, 0x47,
-	0x9c, 0x67, 0x7a, 0x3e, 0x6b, 0xe8, 0xcf, 0xf4, 0xbc, 0x6e, 0x6c, 0x3c, 0xd3, 0xf3, 0x1b, 0x46,
-	0xee, 0x99, 0x9e, 0xcf, 0x19, 0x9b, 0x8d, 0xbf, 0x6b, 0x90, 0x8f, 0xd8, 0xdc, 0x12, 0xbe, 0xa5,
-	0x84, 0xc7, 0x85, 0x0a, 0xa6, 0x3c, 0x07, 0x46, 0xce, 0x9c, 0xa2, 0x3a, 0x94, 0x84, 0x30, 0x1d,
-	0xa2, 0xc0, 0xb1, 0x96, 0x08, 0x53, 0x51, 0xcf, 0x23, 0x86, 0x88, 0x47, 0x5d, 0xd5, 0x73, 0x49,
-	0x89, 0x5a, 0x52, 0xb8, 0x1c, 0x8f, 0x69, 0x18, 0xca, 0x59, 0x36, 0x24, 0x45, 0x61, 0x62, 0xa2,
-	0x87, 0xb0, 0x15, 0x51, 0xa2, 0xb9, 0x72, 0x32, 0x5e, 0x15, 0xac, 0xa6, 0x6b, 0x82, 0x91, 0xe4,
-	0xcd, 0x57, 0x1d, 0xa4, 0xb2, 0x22, 0xf2, 0x49, 0xe5, 0xe2, 0x1b, 0xbf, 0x86, 0x3d, 0xe1, 0xca,
-	0x41, 0xe0, 0x5f, 0xd8, 0x17, 0x8e, 0xeb, 0xb0, 0xeb, 0x28, 0xc8, 0xf9, 0xc2, 0x03, 0x7f, 0x4e,
-	0xf8, 0xde, 0x46, 0x2e, 0xe0, 0x40, 0xcf, 0x9f, 0x50, 0xee, 0x02, 0xe6, 0x4b, 0x91, 0x72, 0x01,
-	0xf3, 0x85, 0x20, 0xd9, 0x79, 0xb3, 0xa9, 0xce, 0xdb, 0xb8, 0x82, 0xea, 0xed, 0xb9, 0x54, 0xcc,
-	0xd4, 0xa1, 0xb8, 0x58, 0xc1, 0x62, 0x3a, 0x0d, 0x27, 0xa1, 0xa4, 0x6f, 0x33, 0x6f, 0xf6, 0x6d,
-	0xe3, 0x0f, 0x1a, 0x6c, 0x1f, 0x2f, 0x1d, 0x77, 0x92, 0x4a, 0xdc, 0xa4, 0x75, 0x5a, 0xfa, 0x5c,
-	0xb0, 0xae, 0xe9, 0x67, 0xd6, 0x36, 0xfd, 0x8f, 0xd6, 0x34, 0xd6, 0xac, 0x68, 0xac, 0x99, 0x35,
-	0x6d, 0xf5, 0x5d, 0x28, 0xae, 0xba, 0x64, 0x58, 0xd5, 0xeb, 0xd9, 0x66, 0x09, 0xc3, 0x2c, 0x6a,
-	0x91, 0x61, 0xe3, 0x53, 0x40, 0x49, 0x43, 0xd5, 0x86, 0xc4, 0xf5, 0x43, 0xbb, 0xbb, 0x7e, 0xdc,
-	0x87, 0xda, 0x70, 0x79, 0x11, 0x8e, 0x03, 0xe7, 0x82, 0x9e, 0x32, 0x77, 0x6c, 0xbe, 0xa4, 0x1e,
-	0x0b, 0xa3, 0x2c, 0xfd, 0xa7, 0x0e, 0x85, 0x18, 0xe5, 0xe5, 0xd9, 0xf1, 0xc6, 0xfe, 0x3c, 0x32,
-	0xda, 0xa3, 0x2e, 0xb7, 0x5b, 0x36, 0x85, 0xed, 0x48, 0xd4, 0x96, 0x12, 0x6b, 0xc2, 0xf9, 0xa9,
-	0x45, 0x2a, 0x7e, 0x46, 0xf2, 0x93, 0x6b, 0x94, 0xfc, 0x26, 0x18, 0xb1, 0xfe, 0x19, 0x73, 0xc7,
-	0xf1, 0xa6, 0xe0, 0x4a, 0x84, 0x73, 0x63, 0x24, 0x33, 0xd6, 0x1c, 0x31, 0x75, 0xc9, 0x8c, 0x70,
-	0xc5, 0x7c, 0x0f, 0x4a, 0x3c, 0x1f, 0x42, 0x66, 0xcf, 0x17, 0xc4, 0x0b, 0x45, 0x5e, 0xe8, 0xb8,
-	0x18, 0x63, 0xbd, 0x10, 0x7d, 0x01, 0x40, 0xf9, 0xfa, 0x08, 0xbb, 0x5e, 0x50, 0x91, 0x12, 0x95,
-	0xa3, 0x77, 0x12, 0x81, 0x11, 0x6f, 0xc0, 0x81, 0xf8, 0x77, 0x74, 0xbd, 0xa0, 0xb8, 0x40, 0xa3,
-	0x9f, 0xe8, 0x4b, 0x28, 0x4f, 0xfd, 0xe0, 0x95, 0x1d, 0x4c, 0x88, 0x00, 0x55, 0xd9, 0xd8, 0x4b,
-	0x68, 0x38, 0x91, 0x72, 0x31, 0xfc, 0xf4, 0x2d, 0x5c, 0x9a, 0x26, 0xbe, 0xd1, 0x73, 0x40, 0xd1,
-	0x78, 0x91, 0xe5, 0x52, 0x49, 0x5e, 0x28, 0xd9, 0xbf, 0xad, 0x84, 0x17, 0xe9, 0x48, 0x91, 0x31,
-	0xbd, 0x81, 0xa1, 0xcf, 0xa0, 0x14, 0x52, 0xc6, 0x5c, 0xaa, 0xd4, 0x14, 0x84, 0x9a, 0x7b, 0xa9,
-	0x33, 0x0d, 0x17, 0x47, 0x1a, 0x8a, 0xe1, 0xea, 0x13, 0x1d, 0xc3, 0x96, 0xeb, 0x78, 0x57, 0x49,
-	0x33, 0x40, 0x8c, 0xaf, 0x26, 0xc6, 0x77, 0x1d, 0xef, 0x2a, 0x69, 0x43, 0xd9, 0x4d, 0x02, 0x8d,
-	0xcf, 0xa1, 0x10, 0xef, 0x12, 0x2a, 0xc2, 0xe6, 0x79, 0xef, 0x79, 0xaf, 0xff, 0x4d, 0xcf, 0x78,
-	0x0b, 0xe5, 0x41, 0x1f, 0x9a, 0xbd, 0x8e, 0xa1, 0x71, 0x18, 0x9b, 0x6d, 0xd3, 0x7a, 0x61, 0x1a,
-	0x19, 0xfe, 0x71, 0xd2, 0xc7, 0xdf, 0xb4, 0x70, 0xc7, 0xc8, 0x1e, 0x6f, 0xc2, 0x86, 0x98, 0xb7,
-	0xf1, 0x27, 0x0d, 0xf2, 0xc2, 0x83, 0xde, 0xd4, 0x47, 0xff, 0x0f, 0x71, 0x70, 0x89, 0xe2, 0xc6,
-	0x1b, 0xae, 0x88, 0xba, 0x32, 0x8e, 0x03, 0x66, 0xa4, 0x70, 0x4e, 0x8e, 0x43, 0x23, 0x26, 0x67,
-	0x24, 0x39, 0x12, 0xc4, 0xe4, 0xc7, 0x09, 0xcd, 0xa9, 0x92, 0xa3, 0xe3, 0xad, 0x48, 0x10, 0x55,
-	0xd8, 0xe4, 0xd9, 0x36, 0x55, 0x89, 0x13, 0x67, 0x5b, 0xc5, 0x6d, 0xfc, 0x14, 0x4a, 0x49, 0x9f,
-	0xa3, 0x47, 0xa0, 0x3b, 0xde, 0xd4, 0x57, 0x89, 0xb8, 0x73, 0x23, 0xb8, 0xf8, 0x22, 0xb1, 0x20,
-	0x34, 0x10, 0x18, 0x37, 0xfd, 0xdc, 0x28, 0x43, 0x31, 0xe1, 0xb4, 0xc6, 0xdf, 0x34, 0x28, 0xa7,
-	0x9c, 0xf0, 0x6f, 0x6b, 0x47, 0x5f, 0x40, 0xe9, 0x95, 0x13, 0x50, 0x92, 0x6c, 0xff, 0x95, 0xa3,
-	0x5a, 0xba, 0xfd, 0x47, 0xff, 0xb7, 0xfd, 0x09, 0xc5, 0x45, 0xce, 0x57, 0x00, 0xfa, 0x05, 0x54,
-	0xd4, 0x48, 0x32, 0xa1, 0xcc, 0x76, 0x5c, 0xb1, 0x55, 0x95, 0x54, 0x78, 0x28, 0x6e, 0x47, 0xc8,
-	0x71, 0x79, 0x9a, 0xfc, 0x44, 0x1f, 0xae, 0x14, 0x84, 0x2c, 0x70, 0xbc, 0x4b, 0xb1, 0x7f, 0x85,
-	0x98, 0x36, 0x14, 0x20, 0x6f, 0xe4, 0x65, 0x75, 0x78, 0x1c, 0x32, 0x9b, 0x2d, 0x43, 0xf4, 0x31,
-	0x6c, 0x84, 0xcc, 0x56, 0x95, 0xac, 0x92, 0xca, 0xad, 0x04, 0x91, 0x62, 0xc9, 0x4a, 0x9d, 0x7e,
-	0x32, 0xb7, 0x4e, 0x3f, 0x1b, 0xbc, 0x62, 0xc8, 0x2a, 0x5a, 0x3c, 0x42, 0x6a, 0xf1, 0xa7, 0xa3,
-	0x6e, 0xbb, 0xc5, 0x18, 0x9d, 0x2f, 0x18, 0x96, 0x04, 0xd5, 0xdd, 0xbe, 0x04, 0x68, 0x3b, 0xc1,
-	0x78, 0xe9, 0xb0, 0xe7, 0xf4, 0x9a, 0xf7, 0xac, 0xa8, 0x5c, 0xcb, 0xb2, 0x97, 0x1b, 0xcb, 0x12,
-	0xbd, 0x07, 0x9b, 0x51, 0x21, 0x92, 0xf5, 0x2d, 0x37, 0x13, 0x05, 0xa8, 0xf1, 0x67, 0x1d, 0xf6,
-	0x95, 0x4b, 0xa5, 0x37, 0x18, 0x0d, 0xc6, 0x74, 0x11, 0x1f, 0x8b, 0x9f, 0xc2, 0xee, 0xaa, 0xa8,
-	0xca, 0x89, 0x48, 0x74, 0xd4, 0x2e, 0x1e, 0xbd, 0x9d, 0x58, 0xe9, 0xca, 0x0c, 0x8c, 0xe2, 0x62,
-	0xbb, 0x32, 0xed, 0x30, 0xa1, 0xc8, 0x9e, 0xfb, 0x4b, 0x4f, 0x85, 0xa8, 0xac, 0x78, 0x68, 0x15,
-	0xce, 0x5c, 0x24, 0x22, 0xfa, 0x11, 0xc4, 0x41, 0x4e, 0xe8, 0x0f, 0x0b, 0x27, 0xb8, 0x16, 0xd5,
-	0xaf, 0xbc, 0x2a, 0xb7, 0xa6, 0x40, 0x6f, 0x9d, 0x55, 0x33, 0xb7, 0xcf, 0xaa, 0x9f, 0x41, 0x2d,
-	0xce, 0x0e, 0x75, 0x8d, 0xa5, 0x93, 0xb8, 0xb5, 0x6d, 0x0a, 0x1b, 0xf6, 0x22, 0x06, 0x8e, 0x08,
-	0xaa, 0xbf, 0x1d, 0xc2, 0x6e, 0x22, 0xb5, 0x56, 0xa6, 0xcb, 0x4c, 0x44, 0xab, 0xec, 0x4a, 0x9a,
-	0x1e, 0x8f, 0x50, 0xa6, 0xeb, 0xd2, 0xf4, 0x08, 0x56, 0xa6, 0xff, 0x0a, 0x2a, 0x37, 0xae, 0x79,
-	0x79, 0xe1, 0xf7, 0x9f, 0xdd, 0xae, 0xac, 0xeb, 0xdc, 0x73, 0xb0, 0xe6, 0xae, 0x57, 0x1e, 0xa7,
-	0xee, 0x79, 0x0f, 0x00, 0x7c, 0xcf, 0xf1, 0x3d, 0x72, 0xe1, 0xfa, 0x17, 0xa2, 0xe0, 0x96, 0x70,
-	0x41, 0x20, 0xc7, 0xae, 0x7f, 0x51, 0xfb, 0x0a, 0xd0, 0x7f, 0x79, 0x9f, 0xfa, 0x8b, 0x06, 0xf7,
-	0xd7, 0x9b, 0xa8, 0xfa, 0xfc, 0xff, 0x2c, 0x84, 0x3e, 0x83, 0x9c, 0x3d, 0x66, 0x8e, 0xef, 0xa9,
-	0xca, 0xf0, 0x7e, 0x62, 0x28, 0xa6, 0xa1, 0xef, 0xbe, 0xa4, 0xa7, 0xbe, 0x3b, 0x51, 0xc6, 0xb4,
-	0x04, 0x15, 0xab, 0x21, 0xa9, 0xa4, 0xcb, 0xa6, 0x93, 0xee, 0xf1, 0x6f, 0x75, 0x28, 0xa7, 0x2a,
-	0x43, 0xba, 0x35, 0x94, 0xa1, 0xd0, 0xeb, 0x93, 0x8e, 0x39, 0x6a, 0x59, 0x5d, 0x43, 0x43, 0x06,
-	0x94, 0xfa, 0x3d, 0xab, 0xdf, 0x23, 0x1d, 0xb3, 0xdd, 0xef, 0xf0, 0x26, 0xf1, 0x36, 0x6c, 0x77,
-	0xad, 0xde, 0x73, 0xd2, 0xeb, 0x8f, 0x88, 0xd9, 0xb5, 0x9e, 0x5a, 0xc7, 0x5d, 0xd3, 0xc8, 0xa2,
-	0x5d, 0x30, 0xfa, 0x3d, 0xd2, 0x3e, 0x6d, 0x59, 0x3d, 0x32, 0xb2, 0xce, 0xcc, 0xfe, 0xf9, 0xc8,
-	0xd0, 0x39, 0xca, 0xb3, 0x99, 0x98, 0xdf, 0xb6, 0x4d, 0xb3, 0x33, 0x24, 0x67, 0xad, 0x6f, 0x8d,
-	0x0d, 0x54, 0x85, 0x5d, 0xab, 0x37, 0x3c, 0x3f, 0x39, 0xb1, 0xda, 0x96, 0xd9, 0x1b, 0x91, 0xe3,
-	0x56, 0xb7, 0xd5, 0x6b, 0x9b, 0x46, 0x0e, 0xdd, 0x03, 0x64, 0xf5, 0xda, 0xfd, 0xb3, 0x41, 0xd7,
-	0x1c, 0x99, 0x24, 0x6a, 0x46, 0x9b, 0x68, 0x07, 0xb6, 0x84, 0x9e, 0x56, 0xa7, 0x43, 0x4e, 0x5a,
-	0x56, 0xd7, 0xec, 0x18, 0x79, 0x6e, 0x89, 0x62, 0x0c, 0x49, 0xc7, 0x1a, 0xb6, 0x8e, 0x39, 0x5c,
-	0xe0, 0x73, 0x5a, 0xbd, 0x17, 0x7d, 0xab, 0x6d, 0x92, 0x36, 0x57, 0xcb, 0x51, 0xe0, 0xe4, 0x08,
-	0x3d, 0xef, 0x75, 0x4c, 0x3c, 0x68, 0x59, 0x1d, 0xa3, 0x88, 0xf6, 0x61, 0x2f, 0x82, 0xcd, 0x6f,
-	0x07, 0x16, 0xfe, 0x8e, 0x8c, 0xfa, 0x7d, 0x32, 0xec, 0xf7, 0x7b, 0x46, 0x29, 0xa9, 0x89, 0xaf,
-	0xb6, 0x3f, 0x30, 0x7b, 0x46, 0x19, 0xed, 0xc1, 0xce, 0xd9, 0x60, 0x40, 0x22, 0x49, 0xb4, 0xd8,
-	0x0a, 0xa7, 0xb7, 0x3a, 0x1d, 0x6c, 0x0e, 0x87, 0xe4, 0xcc, 0x1a, 0x9e, 0xb5, 0x46, 0xed, 0x53,
-	0x63, 0x8b, 0x2f, 0x69, 0x68, 0x8e, 0xc8, 0xa8, 0x3f, 0x6a, 0x75, 0x57, 0xb8, 0xc1, 0x0d, 0x5a,
-	0xe1, 0x7c, 0xd2, 0x6e, 0xff, 0x1b, 0x63, 0x9b, 0x6f, 0x38, 0x87, 0xfb, 0x2f, 0x94, 0x89, 0x88,
-	0xaf, 0x5d, 0xb9, 0x27, 0x9a, 0xd3, 0xd8, 0xe1, 0xa0, 0xd5, 0x7b, 0xd1, 0xea, 0x5a, 0x1d, 0xf2,
-	0xdc, 0xfc, 0x4e, 0x34, 0xf3, 0x5d, 0x0e, 0x4a, 0xcb, 0xc8, 0x00, 0xf7, 0x9f, 0x72, 0x43, 0x8c,
-	0xb7, 0x11, 0x82, 0x4a, 0xdb, 0xc2, 0xed, 0xf3, 0x6e, 0x0b, 0x13, 0xdc, 0x3f, 0x1f, 0x99, 0xc6,
-	0xbd, 0xc7, 0x7f, 0xd4, 0xa0, 0x94, 0x2c, 0xd6, 0xdc, 0xeb, 0x56, 0x8f, 0x9c, 0x74, 0xad, 0xa7,
-	0xa7, 0x23, 0x19, 0x04, 0xc3, 0xf3, 0x36, 0x77, 0x99, 0xc9, 0x0f, 0x09, 0x08, 0x2a, 0x72, 0xd3,
-	0xe3, 0xc5, 0x66, 0xf8, 0x5c, 0x0a, 0xeb, 0xf5, 0x95, 0xde, 0x2c, 0x37, 0x5e, 0x81, 0x26, 0xc6,
-	0x7d, 0x6c, 0xe8, 0xe8, 0x03, 0xa8, 0x2b, 0x84, 0xfb, 0x15, 0x63, 0xb3, 0x3d, 0x22, 0x83, 0xd6,
-	0x77, 0x67, 0xdc, 0xed, 0x32, 0xc8, 0x86, 0xc6, 0x06, 0x7a, 0x17, 0xf6, 0x63, 0xd6, 0xba, 0xb8,
-	0x78, 0xfc, 0x39, 0x54, 0xef, 0x0a, 0x7a, 0x04, 0x90, 0x1b, 0x9a, 0xa3, 0x51, 0xd7, 0x94, 0x07,
-	0x9b, 0x13, 0x19, 0xb8, 0x00, 0x39, 0x6c, 0x0e, 0xcf, 0xcf, 0x4c, 0x23, 0x73, 0xf4, 0xd7, 0x3c,
-	0xe4, 0xc4, 0x49, 0x3b, 0x40, 0x5f, 0x41, 0x39, 0xf1, 0x92, 0xf4, 0xe2, 0x08, 0x3d, 0x78, 0xed,
-	0x1b, 0x53, 0x2d, 0xba, 0x8f, 0x2b, 0xf8, 0x50, 0x43, 0xc7, 0x50, 0x49, 0x3e, 0xa9, 0xbc, 0x38,
-	0x42, 0xc9, 0x03, 0xea, 0x9a, 0xd7, 0x96, 0x35, 0x3a, 0x9e, 0x83, 0x61, 0x86, 0xcc, 0x99, 0xf3,
-	0x3e, 0xa9, 0x1e, 0x3d, 0x50, 0x2d, 0x99, 0xe0, 0xe9, 0x97, 0x94, 0xda, 0xfe, 0x5a, 0x99, 0x2a,
-	0x39, 0x5f, 0xf3, 0x33, 0x49, 0xfc, 0xec, 0x70, 0x6b, 0x41, 0xe9, 0xb7, 0x8e, 0xda, 0x3b, 0x77,
-	0x89, 0xd5, 0x53, 0x41, 0xf6, 0x77, 0x19, 0xbe, 0xc6, 0x72, 0x42, 0xb6, 0x66, 0x97, 0x6e, 0x28,
-	0x5d, 0xd3, 0xb9, 0xd1, 0x04, 0x76, 0xd6, 0x3c, 0x49, 0xa0, 0x0f, 0xd3, 0x75, 0xec, 0x8e, 0x07,
-	0x8d, 0xda, 0xc3, 0x37, 0xd1, 0xd4, 0xe2, 0x27, 0xb0, 0xb3, 0xe6, 0xed, 0x22, 0x35, 0xcb, 0xdd,
-	0x2f, 0x1f, 0xa9, 0x59, 0x5e, 0xf7, 0x04, 0xf2, 0x3d, 0x18, 0x37, 0xaf, 0xba, 0xa8, 0x71, 0x73,
-	0xec, 0xed, 0x3b, 0x77, 0xed, 0xfd, 0xd7, 0x72, 0x94, 0x72, 0x0b, 0x60, 0x75, 0x61, 0x44, 0xf7,
-	0x13, 0x43, 0x6e, 0x5d, 0x78, 0x6b, 0x0f, 0xee, 0x90, 0x2a, 0x55, 0x23, 0xd8, 0x59, 0x73, 0x83,
-	0x4c, 0xed, 0xc6, 0xdd, 0x37, 0xcc, 0xda, 0xee, 0xba, 0x8b, 0xd6, 0xa1, 0x86, 0xce, 0x64, 0x80,
-	0x45, 0xcf, 0xa3, 0x6f, 0xc8, 0x98, 0xea, 0xfa, 0x03, 0xe1, 0x32, 0x14, 0xa1, 0x75, 0xa8, 0xa1,
-	0x3e, 0x94, 0x92, 0x59, 0xf2, 0xc6, 0xf4, 0x79, 0xa3, 0xc2, 0x29, 0x6c, 0xa5, 0x9a, 0xb1, 0x1f,
-	0xa0, 0x47, 0x6f, 0x3c, 0x52, 0xc8, 0x1d, 0x4b, 0x45, 0xc0, 0x6b, 0xce, 0x1e, 0x4d, 0xed, 0x50,
-	0x3b, 0xfe, 0xe4, 0x97, 0x4f, 0x2e, 0x1d, 0x36, 0x5b, 0x5e, 0x1c, 0x8c, 0xfd, 0xf9, 0x13, 0xf1,
-	0xfa, 0xe9, 0x39, 0xde, 0xa5, 0x47, 0xd9, 0x2b, 0x3f, 0xb8, 0x7a, 0xe2, 0x7a, 0x93, 0x27, 0x22,
-	0x0d, 0x9e, 0xc4, 0x2a, 0x2f, 0x72, 0xe2, 0x8f, 0x1f, 0x3f, 0xfa, 0x57, 0x00, 0x00, 0x00, 0xff,
-	0xff, 0x03, 0xef, 0xc7, 0x4a, 0x2c, 0x19, 0x00, 0x00,
+	// 3425 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x5a, 0x4b, 0x77, 0xdb, 0x48,
+	0x76, 0x6e, 0x90, 0x14, 0x1f, 0x97, 0x0f, 0x41, 0x25, 0xd9, 0x62, 0x53, 0x76, 0x0f, 0x0d, 0x7b,
+	0x6c, 0xc5, 0xe3, 0x96, 0x3d, 0x4a, 0x4e, 0x66, 0x26, 0x3d, 0xd3, 0x3d, 0x14, 0x09, 0x59, 0xb0,
+	0x29, 0x52, 0x5d, 0xa4, 0xdc, 0xdd, 0xe9, 0x05, 0x02, 0x81, 0x45, 0x13, 0x2d, 0x10, 0x60, 0x00,
+	0xd0, 0xb6, 0x56, 0x7d, 0xb2, 0xcb, 0xc9, 0xc9, 0xc9, 0x22, 0xeb, 0x9c, 0xfc, 0x83, 0x64, 0x95,
+	0x93, 0x55, 0xe7, 0x24, 0x3f, 0x20, 0xff, 0x21, 0x8b, 0x6c, 0xb2, 0xc9, 0x7f, 0xc8, 0xa9, 0x07,
+	0x5e, 0x22, 0xf4, 0xe8, 0x4c, 0x6f, 0x6c, 0xe2, 0xab, 0x5b, 0xb7, 0x6e, 0xdd, 0x77, 0x55, 0x09,
+	0xee, 0x7a, 0xee, 0x32, 0x20, 0x9e, 0xb7, 0x30, 0x9f, 0xf3, 0x5f, 0x7b, 0x0b, 0xcf, 0x0d, 0x5c,
+	0x54, 0x89, 0xf0, 0x56, 0xc5, 0x5b, 0x98, 0x1c, 0x55, 0xfe, 0xa1, 0x04, 0x68, 0x44, 0x9c, 0xc9,
+	0x89, 0x71, 0x31, 0x27, 0x4e, 0x80, 0xc9, 0x5f, 0x2e, 0x89, 0x1f, 0x20, 0x04, 0x85, 0x09, 0xf1,
+	0x83, 0xa6, 0xd4, 0x96, 0x76, 0x6b, 0x98, 0xfd, 0x46, 0x32, 0xe4, 0x8d, 0x79, 0xd0, 0xcc, 0xb5,
+	0xa5, 0xdd, 0x3c, 0xa6, 0x3f, 0xd1, 0xc7, 0x50, 0x36, 0xe6, 0x81, 0x3e, 0xf7, 0x8d, 0xa0, 0x59,
+	0x63, 0x70, 0xc9, 0x98, 0x07, 0xc7, 0xbe, 0x11, 0xa0, 0x07, 0x50, 0x5b, 0x70, 0x96, 0xfa, 0xcc,
+	0xf0, 0x67, 0xcd, 0x3c, 0x63, 0x54, 0x15, 0xd8, 0x91, 0xe1, 0xcf, 0xd0, 0x2e, 0xc8, 0x53, 0xcb,
+	0x31, 0x6c, 0xdd, 0xb4, 0x83, 0x77, 0xfa, 0x84, 0xd8, 0x81, 0xd1, 0x2c, 0xb4, 0xa5, 0xdd, 0x35,
+	0xdc, 0x60, 0x78, 0xd7, 0x0e, 0xde, 0xf5, 0x28, 0x8a, 0x9e, 0xc0, 0x7a, 0xc8, 0xcc, 0xe3, 0x02,
+	0x36, 0xd7, 0xda, 0xd2, 0x6e, 0x05, 0x37, 0x16, 0x69, 0xb1, 0x9f, 0xc0, 0x7a, 0x60, 0xcd, 0x89,
+	0xbb, 0x0c, 0x74, 0x9f, 0x98, 0xae, 0x33, 0xf1, 0x9b, 0x45, 0xce, 0x51, 0xc0, 0x23, 0x8e, 0x22,
+	0x05, 0xea, 0x53, 0x42, 0x74, 0xdb, 0x9a, 0x5b, 0x81, 0x4e, 0xc5, 0x2f, 0x31, 0xf1, 0xab, 0x53,
+	0x42, 0xfa, 0x14, 0x1b, 0x19, 0x01, 0x7a, 0x04, 0x8d, 0x98, 0x86, 0xed, 0xb1, 0xce, 0x88, 0x6a,
+	0x21, 0x11, 0xdb, 0xe8, 0x1e, 0xc8, 0xee, 0x32, 0x78, 0xeb, 0x5a, 0xce, 0x5b, 0xdd, 0x9c, 0x19,
+	0x8e, 0x6e, 0x4d, 0x9a, 0xe5, 0xb6, 0xb4, 0x5b, 0x38, 0x28, 0x34, 0xa5, 0x17, 0x12, 0x6e, 0x84,
+	0xa3, 0xdd, 0x99, 0xe1, 0x68, 0x13, 0xf4, 0x14, 0x36, 0x2e, 0xd3, 0xfb, 0xcd, 0xcd, 0x76, 0x7e,
+	0xb7, 0x80, 0xd7, 0xd3, 0xa4, 0x3e, 0x7a, 0x0c, 0xeb, 0xb6, 0xe1, 0x07, 0xfa, 0xcc, 0x5d, 0xe8,
+	0x8b, 0xe5, 0xd9, 0x39, 0xb9, 0x68, 0x36, 0x98, 0x1e, 0xeb, 0x14, 0x3e, 0x72, 0x17, 0x27, 0x0c,
+	0x44, 0xf7, 0x01, 0x98, 0x0e, 0x99, 0xa8, 0xcd, 0x0a, 0xdb, 0x71, 0x85, 0x22, 0x4c, 0x4c, 0xf4,
+	0x4b, 0xa8, 0x32, 0xdb, 0xeb, 0x33, 0xcb, 0x09, 0xfc, 0x26, 0xb4, 0xf3, 0xbb, 0xd5, 0x7d, 0x79,
+	0xcf, 0x76, 0xa8, 0x1b, 0x60, 0x3a, 0x72, 0x64, 0x39, 0x01, 0x06, 0x2f, 0xfc, 0xe9, 0xa3, 0x09,
+	0x6c, 0x52, 0x9b, 0xeb, 0xe6, 0xd2, 0x0f, 0xdc, 0xb9, 0xee, 0x11, 0xd3, 0xf5, 0x26, 0x7e, 0xb3,
+	0xca, 0xa6, 0xfe, 0xc9, 0x5e, 0xe4, 0x4a, 0x7b, 0xab, 0xbe, 0xb3, 0xd7, 0x23, 0x7e, 0xd0, 0x65,
+	0xf3, 0x30, 0x9f, 0xa6, 0x3a, 0x81, 0x77, 0x81, 0x37, 0x26, 0x97, 0x71, 0xf4, 0x0c, 0x90, 0x61,
+	0xdb, 0xee, 0x7b, 0xdd, 0x27, 0xf6, 0x54, 0x17, 0xb6, 0x6c, 0xae, 0xb7, 0xa5, 0xdd, 0x32, 0x96,
+	0xd9, 0xc8, 0x88, 0xd8, 0x53, 0xc1, 0x1e, 0xfd, 0x29, 0xd4, 0x99, 0x4c, 0x53, 0x62, 0x04, 0x4b,
+	0x8f, 0xf8, 0x4d, 0xb9, 0x9d, 0xdf, 0x6d, 0xec, 0x6f, 0x88, 0x8d, 0x1c, 0x72, 0xf8, 0xc0, 0x0a,
+	0x70, 0x8d, 0xd2, 0x89, 0x6f, 0x1f, 0xed, 0x40, 0x65, 0x6e, 0x7c, 0xd0, 0x17, 0x86, 0x17, 0xf8,
+	0xcd, 0x8d, 0xb6, 0xb4, 0x5b, 0xc7, 0xe5, 0xb9, 0xf1, 0xe1, 0x84, 0x7e, 0xa3, 0x3d, 0xd8, 0x74,
+	0x5c, 0xdd, 0x72, 0xa6, 0xb6, 0xf5, 0x76, 0x16, 0xe8, 0xcb, 0xc5, 0xc4, 0x08, 0x88, 0xdf, 0x44,
+	0x4c, 0x86, 0x0d, 0xc7, 0xd5, 0xc4, 0xc8, 0x29, 0x1f, 0xa0, 0x1e, 0x66, 0x4d, 0xc8, 0x7c, 0xe1,
+	0x06, 0xc4, 0x31, 0x2f, 0x74, 0x6a, 0x92, 0x2d, 0x66, 0x92, 0x46, 0x02, 0x7e, 0x4d, 0x2e, 0x5a,
+	0x3d, 0xb8, 0x9b, 0xad, 0x08, 0x1a, 0x47, 0x74, 0x1a, 0x0d, 0xad, 0x02, 0xa6, 0x3f, 0xd1, 0x16,
+	0xac, 0xbd, 0x33, 0xec, 0x25, 0x61, 0xb1, 0x55, 0xc3, 0xfc, 0xe3, 0xcf, 0x72, 0xbf, 0x96, 0x94,
+	0x19, 0x6c, 0x8e, 0x3d, 0xc3, 0x3c, 0xbf, 0x14, 0x9e, 0x97, 0xa3, 0x4b, 0x5a, 0x8d, 0xae, 0x2b,
+	0x36, 0x96, 0xbb, 0x62, 0x63, 0xca, 0xe7, 0xb0, 0xce, 0x5c, 0xe1, 0x90, 0x90, 0xeb, 0x92, 0xc0,
+	0x36, 0xd0, 0x10, 0x67, 0x21, 0xc3, 0x13, 0x41, 0xd1, 0x98, 0xd3, 0x68, 0x51, 0x26, 0x20, 0xc7,
+	0xf3, 0xfd, 0x85, 0xeb, 0xf8, 0x84, 0x46, 0x38, 0xf5, 0x14, 0xea, 0xea, 0x34, 0x92, 0x58, 0x0c,
+	0x49, 0x6c, 0x56, 0x43, 0xe0, 0x87, 0x84, 0xb0, 0x28, 0x7a, 0xcc, 0x03, 0x57, 0xb7, 0x5d, 0xf3,
+	0x9c, 0xa6, 0x02, 0xe3, 0x42, 0xb0, 0xaf, 0x53, 0xb8, 0xef, 0x9a, 0xe7, 0x3d, 0x0a, 0x2a, 0xff,
+	0x24, 0xc1, 0xc6, 0x89, 0xe7, 0x9e, 0x11, 0xb6, 0xd6, 0xff, 0x47, 0xd0, 0xcc, 0xb4, 0x93, 0xcf,
+	0x4c, 0x3b, 0x2b, 0x49, 0xa2, 0xb0, 0x9a, 0x24, 0xee, 0x03, 0x30, 0xe7, 0xa2, 0x32, 0xf9, 0x2c,
+	0x2b, 0xd5, 0x31, 0x75, 0x37, 0x26, 0xa4, 0xaf, 0xfc, 0xad, 0x04, 0x55, 0x2e, 0x2f, 0xf1, 0x97,
+	0x76, 0x80, 0x14, 0x58, 0x63, 0xb1, 0xc3, 0x44, 0xad, 0xee, 0xd7, 0x92, 0x41, 0x88, 0xf9, 0x10,
+	0xda, 0x85, 0xd2, 0xd4, 0xb0, 0xec, 0xa5, 0xc7, 0xfd, 0xa1, 0xba, 0xdf, 0x08, 0x3d, 0x9c, 0xa3,
+	0x38, 0x1c, 0x46, 0xcf, 0x61, 0xd3, 0x23, 0x86, 0x39, 0x23, 0x13, 0x9d, 0xee, 0xd9, 0x72, 0x8c,
+	0xc0, 0x72, 0x1d, 0xb6, 0x9b, 0x32, 0x46, 0x62, 0xa8, 0x17, 0x8f, 0x28, 0xff, 0x22, 0x01, 0x4a,
+	0xaa, 0x4f, 0xd8, 0xe9, 0x1e, 0x54, 0x18, 0xb1, 0x71, 0x66, 0x73, 0xc9, 0xca, 0x38, 0x06, 0x32,
+	0xad, 0x98, 0xbb, 0xad, 0x15, 0xf3, 0x19, 0x56, 0x44, 0x7b, 0x50, 0x14, 0x0a, 0x2b, 0xb0, 0x84,
+	0x72, 0x37, 0x91, 0x50, 0x12, 0xda, 0xc2, 0x82, 0x4a, 0xf9, 0x9e, 0xd7, 0xa8, 0xb1, 0x9b, 0xb2,
+	0xfa, 0x2d, 0x82, 0x20, 0x52, 0x77, 0xee, 0x6a, 0x75, 0x2b, 0x50, 0xf7, 0xcf, 0xad, 0x85, 0x1e,
+	0x90, 0xf9, 0x42, 0x27, 0x9e, 0x27, 0xd4, 0x57, 0xa5, 0xe0, 0x98, 0xcc, 0x17, 0xaa, 0xe7, 0x29,
+	0xdf, 0xc2, 0x66, 0x4a, 0x00, 0xa1, 0xb7, 0x16, 0x94, 0x17, 0x1e, 0xb1, 0xe6, 0xc6, 0x5b, 0x22,
+	0x56, 0x8f, 0xbe, 0x6f, 0x6f, 0x45, 0xe5, 0x57, 0x80, 0x28, 0xf6, 0xa3, 0x43, 0x5c, 0xb9, 0x03,
+	0x9b, 0xa9, 0x89, 0x5c, 0x2a, 0xe5, 0x1e, 0xb4, 0x30, 0xf1, 0x49, 0x70, 0x6c, 0xf9, 0xbe, 0xe5,
+	0x3a, 0x5d, 0xd7, 0x09, 0x3c, 0xd7, 0x16, 0x7c, 0x95, 0xfb, 0xb0, 0x93, 0x39, 0x1a, 0x4f, 0xfe,
+	0x72, 0x49, 0xbc, 0x8b, 0xec, 0xc9, 0x7f, 0x2f, 0xc1, 0x4e, 0xe6, 0xb0, 0x50, 0xc8, 0x33, 0x58,
+	0x5b, 0x18, 0x96, 0x47, 0xd3, 0xcc, 0x8a, 0x5d, 0x0d, 0xcb, 0x3b, 0xb2, 0xfc, 0xc0, 0xf5, 0x2e,
+	0x30, 0x27, 0x42, 0x5f, 0x40, 0xc5, 0x7d, 0x47, 0x3c, 0xcf, 0x9a, 0x10, 0xbf, 0x99, 0x67, 0x33,
+	0x1e, 0x24, 0x66, 0xa4, 0xd7, 0x18, 0x0a, 0x4a, 0x1c, 0xcf, 0x79, 0x55, 0x28, 0x4b, 0x72, 0x4e,
+	0xf9, 0x47, 0x09, 0xee, 0x66, 0xd3, 0xd2, 0xc4, 0xe0, 0xb8, 0x93, 0xd0, 0x38, 0xec, 0x37, 0x4d,
+	0x0c, 0x81, 0xab, 0x33, 0x98, 0xa7, 0xdb, 0x62, 0xe0, 0x0e, 0xe8, 0x40, 0x13, 0x4a, 0xfe, 0xd2,
+	0x34, 0x89, 0xef, 0x0b, 0x17, 0x08, 0x3f, 0xa9, 0x8b, 0xcc, 0x0c, 0x7b, 0xaa, 0xdb, 0xd6, 0x94,
+	0xd0, 0xc6, 0x82, 0x25, 0x82, 0x02, 0xae, 0x52, 0xb0, 0x6f, 0x4d, 0xc9, 0x88, 0x98, 0xb4, 0x17,
+	0xf2, 0x49, 0xa0, 0x53, 0x47, 0x67, 0x69, 0x20, 0x8f, 0x4b, 0x3e, 0x09, 0xc6, 0xd6, 0x9c, 0x28,
+	0x13, 0x78, 0x30, 0x22, 0x41, 0xb6, 0x88, 0x7e, 0x68, 0xef, 0x94, 0x32, 0xa4, 0x1f, 0xaf, 0x0c,
+	0xe5, 0x11, 0x28, 0xd7, 0xad, 0x22, 0xec, 0x8b, 0xe1, 0x61, 0xd7, 0x26, 0x86, 0x77, 0x83, 0x34,
+	0x3f, 0x46, 0x71, 0xca, 0x63, 0x78, 0x74, 0x3d, 0x4f, 0xb1, 0xf6, 0xdf, 0xd0, 0x64, 0x18, 0xbb,
+	0x01, 0x2d, 0xcc, 0x94, 0x9b, 0x3e, 0xf5, 0xdc, 0x79, 0x18, 0x3f, 0x14, 0x38, 0xf4, 0xdc, 0x39,
+	0x5d, 0x8d, 0x0d, 0x06, 0x6e, 0xb8, 0x1a, 0xfd, 0x1c, 0xbb, 0xe8, 0x53, 0x28, 0xcd, 0x38, 0x03,
+	0xd6, 0xb4, 0x55, 0xf7, 0x37, 0x2f, 0x79, 0x59, 0xcf, 0x08, 0x0c, 0x1c, 0xd2, 0xbc, 0x2a, 0x94,
+	0xf3, 0x72, 0xe1, 0x55, 0xa1, 0x5c, 0x90, 0xd7, 0x5e, 0x15, 0xca, 0x6b, 0x72, 0xf1, 0x55, 0xa1,
+	0x5c, 0x94, 0x4b, 0xca, 0xff, 0x48, 0x50, 0x0e, 0xa9, 0xa9, 0x24, 0x34, 0x1a, 0xb9, 0xf5, 0x78,
+	0x85, 0x2a, 0x53, 0x80, 0x9a, 0x0f, 0xb5, 0xa1, 0xc6, 0x06, 0xd3, 0xe5, 0x04, 0x28, 0xd6, 0xe1,
+	0x25, 0x85, 0x16, 0x8a, 0x90, 0x62, 0x9e, 0x2c, 0x14, 0x9c, 0x24, 0x6c, 0x88, 0x85, 0x3b, 0x25,
+	0x7d, 0xa4, 0x2a, 0x30, 0xb6, 0xd0, 0x63, 0x58, 0x0f, 0x49, 0xc2, 0xb5, 0x8a, 0x3c, 0x7d, 0x0a,
+	0xb8, 0x13, 0x55, 0xb0, 0x24, 0xdd, 0x3c, 0xee, 0x5f, 0x1b, 0x31, 0x21, 0x5d, 0x94, 0x6f, 0x5e,
+	0xf9, 0x0e, 0xb6, 0x59, 0xd0, 0xd2, 0xd4, 0x6a, 0x9c, 0x59, 0xb6, 0x15, 0x5c, 0x84, 0x76, 0xa6,
+	0x1b, 0xf7, 0xdc, 0xb9, 0x9e, 0x30, 0x76, 0x99, 0x02, 0x83, 0x6b, 0x23, 0x25, 0xd9, 0xf7, 0xe7,
+	0x53, 0x7d, 0xbf, 0x72, 0x0e, 0xcd, 0xd5, 0xb5, 0x44, 0x76, 0x68, 0x43, 0x75, 0x11, 0xc3, 0x6c,
+	0x39, 0x09, 0x27, 0xa1, 0xa4, 0x6d, 0x73, 0x37, 0xdb, 0x56, 0xf9, 0xd7, 0x1c, 0x6c, 0x1c, 0x2c,
+	0x2d, 0x7b, 0x92, 0xaa, 0x0b, 0x49, 0xe9, 0xa4, 0xf4, 0xa9, 0x24, 0xab, 0xf6, 0xe7, 0x32, 0x6b,
+	0xff, 0xb3, 0x8c, 0xb6, 0x3e, 0xcf, 0xda, 0xfa, 0x5c, 0x46, 0x53, 0xff, 0x33, 0xa8, 0xc6, 0x3d,
+	0x3a, 0xaf, 0x6a, 0x35, 0x0c, 0xb3, 0xb0, 0x41, 0xf7, 0xd1, 0x01, 0xac, 0x53, 0x02, 0xd3, 0x75,
+	0xfc, 0xc0, 0x33, 0x58, 0x1b, 0xbe, 0xc6, 0x62, 0xfc, 0xe3, 0xc4, 0x06, 0x8f, 0xdc, 0x45, 0x37,
+	0x26, 0xc0, 0x8d, 0x59, 0xea, 0x9b, 0x2e, 0x62, 0xbc, 0x73, 0xad, 0x09, 0xb3, 0x08, 0x3d, 0xd8,
+	0xb0, 0x45, 0x18, 0x44, 0xad, 0xe2, 0xa3, 0x5d, 0x68, 0x70, 0x82, 0xe8, 0x5c, 0x51, 0x6a, 0xe7,
+	0x85, 0xc4, 0x35, 0x36, 0x22, 0x0e, 0x16, 0xca, 0x7f, 0x4b, 0xd0, 0x48, 0xaf, 0x86, 0x76, 0xa0,
+	0x14, 0xee, 0x53, 0x8a, 0xf6, 0x59, 0x34, 0xf9, 0xfe, 0xc2, 0x03, 0x46, 0xac, 0xb1, 0x3a, 0x3f,
+	0x60, 0x70, 0x65, 0x8d, 0xa0, 0x71, 0xe9, 0xa0, 0xc0, 0xb3, 0xf9, 0xb3, 0x2b, 0x37, 0xb7, 0x97,
+	0x71, 0x40, 0xa8, 0x9b, 0x49, 0xac, 0xf5, 0x7b, 0x40, 0x7f, 0x60, 0xf3, 0xfc, 0x6b, 0x40, 0x49,
+	0xef, 0x10, 0x5e, 0x78, 0x8b, 0x16, 0x4c, 0xf9, 0x00, 0xad, 0xd1, 0xf2, 0xcc, 0x37, 0x3d, 0xeb,
+	0x8c, 0x1c, 0x05, 0xb6, 0xa9, 0xbe, 0x23, 0xd4, 0x22, 0xb1, 0x83, 0x45, 0x1a, 0x96, 0xd8, 0xc9,
+	0xad, 0x64, 0x8a, 0x13, 0xdb, 0x17, 0x50, 0x25, 0x94, 0x56, 0x0f, 0x2e, 0x16, 0x84, 0x97, 0xc1,
+	0xc6, 0xfe, 0x27, 0x49, 0x35, 0x84, 0xdc, 0xf6, 0xd8, 0xbf, 0xe3, 0x8b, 0x05, 0xc1, 0x40, 0xc2,
+	0x9f, 0xbe, 0xf2, 0x6f, 0x6b, 0x50, 0x89, 0x68, 0x68, 0x13, 0x6f, 0x39, 0xa6, 0x3b, 0x0f, 0xbd,
+	0xd0, 0x21, 0x76, 0x64, 0x20, 0xbc, 0x11, 0x0e, 0x75, 0xf9, 0x88, 0x36, 0xa1, 0xf4, 0x29, 0xaf,
+	0x15, 0xf4, 0x39, 0x4e, 0x9f, 0x74, 0x5a, 0x4e, 0xbf, 0x0b, 0x72, 0xc4, 0x7f, 0x16, 0xd8, 0x66,
+	0xe4, 0xe5, 0xb8, 0x11, 0xe2, 0x54, 0x18, 0x4e, 0x19, 0x71, 0x0e, 0x29, 0x79, 0x15, 0x8c, 0x62,
+	0x41, 0x50, 0x3e, 0x80, 0x1a, 0x4d, 0x70, 0x7e, 0x60, 0xcc, 0x17, 0xba, 0xc3, 0x7b, 0xe2, 0x02,
+	0xae, 0x46, 0xd8, 0xc0, 0x47, 0xbf, 0x03, 0x88, 0xb5, 0xc4, 0x72, 0xdc, 0xcd, 0x4a, 0xaa, 0x44,
+	0x4a, 0x42, 0x9f, 0x43, 0x7d, 0xea, 0x7a, 0xef, 0x0d, 0x6f, 0xa2, 0x33, 0x50, 0xd4, 0x81, 0xed,
+	0x04, 0x87, 0x43, 0x3e, 0xce, 0xa6, 0x1f, 0x7d, 0x84, 0x6b, 0xd3, 0xc4, 0x37, 0x7a, 0x0d, 0x28,
+	0x9c, 0xcf, 0xd2, 0x36, 0x67, 0x52, 0x66, 0x4c, 0x76, 0x56, 0x99, 0xd0, 0x1e, 0x2b, 0x64, 0x24,
+	0x4f, 0x2f, 0x61, 0xe8, 0x33, 0xa8, 0xf9, 0x24, 0x08, 0x6c, 0x22, 0xd8, 0x54, 0xda, 0xd2, 0xa5,
+	0xce, 0x67, 0xc4, 0x86, 0x43, 0x0e, 0x55, 0x3f, 0xfe, 0xa4, 0x69, 0xc1, 0xb6, 0x9c, 0xf3, 0xa4,
+	0x18, 0xc0, 0xe6, 0x37, 0x13, 0xf3, 0xfb, 0x96, 0x73, 0x9e, 0x94, 0xa1, 0x6e, 0x27, 0x01, 0xa4,
+	0x82, 0x3c, 0x73, 0xed, 0x89, 0x6e, 0xd8, 0x86, 0x37, 0x17, 0x4c, 0xaa, 0x6d, 0x69, 0x25, 0xb7,
+	0xd8, 0x93, 0x0e, 0xa5, 0x08, 0xb9, 0x34, 0x66, 0x29, 0x44, 0xf9, 0x2d, 0x54, 0x22, 0x65, 0xa3,
+	0x2a, 0x94, 0x4e, 0x07, 0xaf, 0x07, 0xc3, 0xaf, 0x06, 0xf2, 0x47, 0xa8, 0x0c, 0x85, 0x91, 0x3a,
+	0xe8, 0xc9, 0x12, 0x85, 0xb1, 0xda, 0x55, 0xb5, 0x37, 0xaa, 0x9c, 0xa3, 0x1f, 0x87, 0x43, 0xfc,
+	0x55, 0x07, 0xf7, 0xe4, 0xfc, 0x41, 0x09, 0xd6, 0xd8, 0xca, 0xca, 0x0f, 0x12, 0x94, 0x99, 0x23,
+	0x38, 0x53, 0x17, 0xfd, 0x02, 0x22, 0x1f, 0x65, 0x45, 0x8f, 0x9e, 0x0b, 0x98, 0xf3, 0xd6, 0x71,
+	0xe4, 0x77, 0x63, 0x81, 0x53, 0xe2, 0xc8, 0xc3, 0x22, 0x62, 0x9e, 0x6a, 0x22, 0xd7, 0x8b, 0x88,
+	0x9f, 0x26, 0x38, 0xa7, 0x4a, 0x51, 0x01, 0xaf, 0x87, 0x03, 0x61, 0xe5, 0x4d, 0xde, 0xb8, 0xa4,
+	0x2a, 0x74, 0xe2, 0xc6, 0x45, 0xd0, 0x2a, 0xbf, 0x82, 0x5a, 0xd2, 0x75, 0xd0, 0x13, 0x28, 0x58,
+	0xce, 0xd4, 0x6d, 0x4a, 0x2b, 0xd5, 0x28, 0xdc, 0x24, 0x66, 0x04, 0x0a, 0x02, 0xf9, 0xb2, 0xbb,
+	0x28, 0x75, 0xa8, 0x26, 0x6c, 0xaf, 0xfc, 0x97, 0x04, 0xf5, 0x94, 0x2d, 0x6f, 0xcd, 0x1d, 0xfd,
+	0x0e, 0x6a, 0xef, 0x2d, 0x8f, 0xe8, 0xc9, 0x13, 0x45, 0x63, 0xbf, 0x95, 0x3e, 0x51, 0x84, 0xff,
+	0x77, 0xdd, 0x09, 0xc1, 0x55, 0x4a, 0x2f, 0x00, 0xf4, 0x05, 0x34, 0xc4, 0x4c, 0x7d, 0x42, 0x02,
+	0xc3, 0xb2, 0x99, 0xaa, 0x1a, 0x29, 0x2f, 0x13, 0xb4, 0x3d, 0x36, 0x8e, 0xeb, 0xd3, 0xe4, 0x27,
+	0xfa, 0x79, 0xcc, 0xc0, 0x0f, 0x3c, 0xcb, 0x79, 0xcb, 0xf4, 0x57, 0x89, 0xc8, 0x46, 0x0c, 0x54,
+	0xfe, 0x8a, 0x95, 0x95, 0xa4, 0x5b, 0xdd, 0x7e, 0x8b, 0x4f, 0x61, 0x83, 0xb9, 0x31, 0x3b, 0x40,
+	0x86, 0x97, 0x77, 0x3c, 0x71, 0xad, 0xd3, 0x01, 0x6a, 0xfa, 0xf0, 0xf6, 0xae, 0x05, 0x65, 0xd3,
+	0x70, 0x4c, 0x62, 0x93, 0x89, 0x68, 0xd5, 0xa3, 0x6f, 0xda, 0x64, 0xd6, 0xc5, 0x89, 0x68, 0x14,
+	0x18, 0xc1, 0xd2, 0x47, 0x9f, 0xc2, 0x9a, 0x1f, 0x18, 0x22, 0xe1, 0x37, 0x52, 0x69, 0x22, 0x41,
+	0x48, 0x30, 0xa7, 0x4a, 0x1d, 0xea, 0x72, 0x2b, 0x87, 0xba, 0x35, 0x9a, 0xfc, 0xc2, 0x73, 0x2b,
+	0x12, 0x06, 0x38, 0x1a, 0xf7, 0xbb, 0x9d, 0x80, 0x1e, 0x20, 0x03, 0xcc, 0x09, 0x44, 0xe7, 0xf5,
+	0x39, 0x40, 0xd7, 0xf2, 0xcc, 0xa5, 0x15, 0xbc, 0x26, 0x17, 0xb4, 0x9f, 0x4a, 0x95, 0xd8, 0xa8,
+	0xbc, 0x6e, 0x43, 0x29, 0xcc, 0xa9, 0x7c, 0xc7, 0xc5, 0x19, 0xcb, 0xa5, 0xca, 0xbf, 0x17, 0x60,
+	0x47, 0xb8, 0x15, 0x57, 0x57, 0x40, 0x3c, 0x93, 0x2c, 0xa2, 0x43, 0xe2, 0x4b, 0xd8, 0x8a, 0xeb,
+	0x03, 0x5f, 0x48, 0x0f, 0xcb, 0x63, 0x75, 0xff, 0x4e, 0x62, 0xa7, 0xb1, 0x18, 0x18, 0x45, 0x75,
+	0x23, 0x16, 0xed, 0x45, 0x82, 0x91, 0x31, 0x77, 0x97, 0x8e, 0x08, 0x13, 0x9e, 0xbc, 0x51, 0x1c,
+	0x52, 0x74, 0x88, 0x45, 0x15, 0xbd, 0x08, 0x0b, 0x67, 0x90, 0x0f, 0x0b, 0xcb, 0xbb, 0x60, 0x89,
+	0xbc, 0x1e, 0x57, 0x0e, 0x95, 0xa1, 0x2b, 0x07, 0xd9, 0xdc, 0xea, 0x31, 0xfd, 0x33, 0x68, 0x45,
+	0x11, 0x2a, 0x2e, 0x78, 0x49, 0xd4, 0xc5, 0xb0, 0xec, 0x5e, 0xc0, 0xdb, 0x21, 0x05, 0x0e, 0x09,
+	0x44, 0xef, 0xf5, 0x02, 0xb6, 0x12, 0xe1, 0x1d, 0x8b, 0xce, 0xb3, 0x01, 0x8a, 0x23, 0x3c, 0x29,
+	0x7a, 0x34, 0x43, 0x88, 0x5e, 0xe0, 0xa2, 0x87, 0xb0, 0x10, 0xfd, 0x2f, 0x56, 0xfa, 0x9a, 0x32,
+	0xb3, 0xfb, 0x6f, 0x56, 0x8b, 0x44, 0x96, 0x79, 0x6e, 0x6e, 0x72, 0x68, 0x63, 0xe5, 0x3a, 0x96,
+	0xeb, 0xe8, 0x67, 0xb6, 0x7b, 0xc6, 0x6a, 0x47, 0x0d, 0x57, 0x18, 0x72, 0x60, 0xbb, 0x67, 0x3f,
+	0x41, 0x0f, 0xf4, 0x1f, 0x12, 0xdc, 0xcb, 0x16, 0x51, 0xb4, 0x43, 0x3f, 0x99, 0x0b, 0x7d, 0x06,
+	0x45, 0xc3, 0x64, 0xf7, 0x4f, 0x3c, 0x3b, 0x3d, 0x4c, 0x4c, 0xc5, 0xc4, 0x77, 0xed, 0x77, 0x84,
+	0xe6, 0x06, 0x21, 0x4c, 0x87, 0x91, 0x62, 0x31, 0x25, 0x15, 0x74, 0xf9, 0x74, 0xd0, 0x29, 0xff,
+	0x99, 0x83, 0x6d, 0x11, 0xa8, 0x2b, 0x01, 0xf0, 0x00, 0x6a, 0x56, 0x88, 0xc5, 0x71, 0x55, 0x8d,
+	0x30, 0xde, 0x8f, 0xdc, 0xe4, 0x7f, 0xe1, 0xfd, 0x61, 0x3e, 0x71, 0x7f, 0x98, 0x3c, 0x45, 0xf0,
+	0x62, 0x11, 0x9d, 0x22, 0x56, 0x1f, 0x06, 0x78, 0x98, 0xa4, 0x1f, 0x06, 0xd2, 0x97, 0xf2, 0xc5,
+	0xb8, 0x67, 0x66, 0x24, 0xd9, 0xef, 0x00, 0xa5, 0xec, 0x77, 0x80, 0x8c, 0xf7, 0x8f, 0x72, 0xe6,
+	0xfb, 0x47, 0xd4, 0xdb, 0x56, 0xae, 0xee, 0x6d, 0xff, 0x2e, 0x07, 0xcd, 0x55, 0x75, 0x0a, 0x6f,
+	0xb8, 0x85, 0x3e, 0x7f, 0x73, 0xc9, 0xce, 0x0f, 0x56, 0xf3, 0x69, 0xc4, 0xf7, 0x92, 0x95, 0x1f,
+	0x42, 0xdd, 0x23, 0xdf, 0x11, 0x93, 0x6e, 0xc3, 0xf0, 0xc5, 0x4d, 0x65, 0x05, 0xd7, 0x38, 0x88,
+	0x19, 0x96, 0xa1, 0xdd, 0xc2, 0x8d, 0xda, 0x5d, 0xbb, 0x95, 0x76, 0x8b, 0x99, 0xda, 0x7d, 0xfa,
+	0xbf, 0x05, 0xa8, 0xa7, 0xaa, 0x5f, 0xba, 0xfd, 0xa9, 0x43, 0x65, 0x30, 0xd4, 0x7b, 0xea, 0xb8,
+	0xa3, 0xf5, 0x65, 0x09, 0xc9, 0x50, 0x1b, 0x0e, 0xb4, 0xe1, 0x40, 0xef, 0xa9, 0xdd, 0x61, 0x8f,
+	0x36, 0x42, 0x77, 0x60, 0xa3, 0xaf, 0x0d, 0x5e, 0xeb, 0x83, 0xe1, 0x58, 0x57, 0xfb, 0xda, 0x4b,
+	0xed, 0xa0, 0xaf, 0xca, 0x79, 0xb4, 0x05, 0xf2, 0x70, 0xa0, 0x77, 0x8f, 0x3a, 0xda, 0x40, 0x1f,
+	0x6b, 0xc7, 0xea, 0xf0, 0x74, 0x2c, 0x17, 0x28, 0x4a, 0xab, 0x85, 0xae, 0x7e, 0xdd, 0x55, 0xd5,
+	0xde, 0x48, 0x3f, 0xee, 0x7c, 0x2d, 0xaf, 0xa1, 0x26, 0x6c, 0x69, 0x83, 0xd1, 0xe9, 0xe1, 0xa1,
+	0xd6, 0xd5, 0xd4, 0xc1, 0x58, 0x3f, 0xe8, 0xf4, 0x3b, 0x83, 0xae, 0x2a, 0x17, 0xd1, 0x5d, 0x40,
+	0xda, 0xa0, 0x3b, 0x3c, 0x3e, 0xe9, 0xab, 0x63, 0x55, 0x0f, 0x1b, 0xae, 0x12, 0xda, 0x84, 0x75,
+	0xc6, 0xa7, 0xd3, 0xeb, 0xe9, 0x87, 0x1d, 0xad, 0xaf, 0xf6, 0xe4, 0x32, 0x95, 0x44, 0x50, 0x8c,
+	0xf4, 0x9e, 0x36, 0xea, 0x1c, 0x50, 0xb8, 0x42, 0xd7, 0xd4, 0x06, 0x6f, 0x86, 0x5a, 0x57, 0xd5,
+	0xbb, 0x94, 0x2d, 0x45, 0x81, 0x12, 0x87, 0xe8, 0xe9, 0xa0, 0xa7, 0xe2, 0x93, 0x8e, 0xd6, 0x93,
+	0xab, 0x68, 0x07, 0xb6, 0x43, 0x58, 0xfd, 0xfa, 0x44, 0xc3, 0xdf, 0xe8, 0xe3, 0xe1, 0x50, 0x1f,
+	0x0d, 0x87, 0x03, 0xb9, 0x96, 0xe4, 0x44, 0x77, 0x3b, 0x3c, 0x51, 0x07, 0x72, 0x1d, 0x6d, 0xc3,
+	0xe6, 0xf1, 0xc9, 0x89, 0x1e, 0x8e, 0x84, 0x9b, 0x6d, 0x50, 0xf2, 0x4e, 0xaf, 0x87, 0xd5, 0xd1,
+	0x48, 0x3f, 0xd6, 0x46, 0xc7, 0x9d, 0x71, 0xf7, 0x48, 0x5e, 0xa7, 0x5b, 0x1a, 0xa9, 0x63, 0x7d,
+	0x3c, 0x1c, 0x77, 0xfa, 0x31, 0x2e, 0x53, 0x81, 0x62, 0x9c, 0x2e, 0xda, 0x1f, 0x7e, 0x25, 0x6f,
+	0x50, 0x85, 0x53, 0x78, 0xf8, 0x46, 0x88, 0x88, 0xe8, 0xde, 0x85, 0x79, 0xc2, 0x35, 0xe5, 0x4d,
+	0x0a, 0x6a, 0x83, 0x37, 0x9d, 0xbe, 0xd6, 0xd3, 0x5f, 0xab, 0xdf, 0xb0, 0x86, 0x75, 0x8b, 0x82,
+	0x5c, 0x32, 0xfd, 0x04, 0x0f, 0x5f, 0x52, 0x41, 0xe4, 0x3b, 0x08, 0x41, 0xa3, 0xab, 0xe1, 0xee,
+	0x69, 0xbf, 0x83, 0x75, 0x3c, 0x3c, 0x1d, 0xab, 0xf2, 0x5d, 0xb4, 0x01, 0xf5, 0xc1, 0xb0, 0xa7,
+	0xea, 0x3d, 0xdc, 0xd1, 0x06, 0xda, 0xe0, 0xa5, 0xbc, 0xcd, 0x34, 0xac, 0xf6, 0x7b, 0x3a, 0x53,
+	0x73, 0x5f, 0x3b, 0xd6, 0xc6, 0x72, 0x93, 0xd2, 0xf5, 0x4e, 0x47, 0x63, 0xaa, 0x9a, 0xe1, 0xe8,
+	0x14, 0xab, 0xf2, 0xc7, 0x74, 0x3b, 0x8c, 0x84, 0x11, 0x73, 0xb1, 0x07, 0x2f, 0xe5, 0x16, 0xd5,
+	0x0a, 0x56, 0x47, 0x2a, 0x7e, 0xa3, 0x0a, 0x1e, 0xa3, 0xfe, 0x70, 0x3c, 0x92, 0x77, 0x9e, 0xfe,
+	0xb3, 0x04, 0xb5, 0x64, 0xe3, 0x41, 0x3d, 0x4c, 0x1b, 0xe8, 0x87, 0x7d, 0xed, 0xe5, 0xd1, 0x98,
+	0x3b, 0xdc, 0xe8, 0xb4, 0x4b, 0xdd, 0x43, 0xa5, 0x4d, 0x37, 0x82, 0x06, 0x37, 0x70, 0xa4, 0xd8,
+	0x1c, 0x95, 0x4d, 0x60, 0x83, 0xa1, 0xd8, 0x43, 0x9e, 0x2a, 0x4a, 0x80, 0x2a, 0xc6, 0x43, 0x2c,
+	0x17, 0xd0, 0x23, 0x68, 0x0b, 0x84, 0xfa, 0x10, 0xc6, 0x6a, 0x77, 0xac, 0x9f, 0x74, 0xbe, 0x39,
+	0xa6, 0x2e, 0xc6, 0x1d, 0x7a, 0x24, 0xaf, 0xa1, 0x9f, 0xc1, 0x4e, 0x44, 0x95, 0xe5, 0x83, 0x4f,
+	0x7f, 0x0b, 0xcd, 0xab, 0x12, 0x38, 0x02, 0x28, 0x8e, 0xd4, 0xf1, 0xb8, 0xaf, 0xf2, 0x83, 0xc2,
+	0x21, 0x0f, 0x12, 0x80, 0x22, 0x56, 0x47, 0xa7, 0xc7, 0xaa, 0x9c, 0x7b, 0xfa, 0x4b, 0xb8, 0x9b,
+	0x9d, 0x16, 0x68, 0x98, 0x9d, 0xe0, 0x21, 0xdd, 0xa8, 0xfc, 0x11, 0x9f, 0xf2, 0x4a, 0xed, 0x8e,
+	0x65, 0x69, 0xff, 0x87, 0x1a, 0x14, 0x59, 0xce, 0xf2, 0xd0, 0xef, 0xa1, 0x9e, 0x78, 0x62, 0x7c,
+	0xb3, 0x8f, 0xee, 0x5f, 0xfb, 0xf8, 0xd8, 0x0a, 0x6f, 0xd9, 0x05, 0xfc, 0x42, 0x42, 0x07, 0xd0,
+	0x48, 0x3e, 0xa1, 0xbd, 0xd9, 0x47, 0xc9, 0xa3, 0x66, 0xc6, 0xeb, 0x5a, 0x06, 0x8f, 0xd7, 0x20,
+	0xab, 0x7e, 0x60, 0xcd, 0x69, 0x9b, 0x28, 0x1e, 0xb9, 0x50, 0x2b, 0x59, 0xdf, 0xd2, 0x2f, 0x67,
+	0xad, 0x9d, 0xcc, 0x31, 0x91, 0x63, 0x35, 0x80, 0xf8, 0x0d, 0x06, 0xdd, 0x5b, 0x79, 0xfb, 0x48,
+	0xdc, 0x65, 0xb5, 0xee, 0x5f, 0x31, 0x2a, 0x58, 0x7d, 0x09, 0xd5, 0xc4, 0xbb, 0xc4, 0x8a, 0x6e,
+	0xd2, 0x0f, 0x26, 0xad, 0x4f, 0xae, 0x1a, 0x16, 0xf7, 0xb3, 0xf9, 0xbf, 0xce, 0x51, 0x75, 0xd5,
+	0x13, 0x63, 0x19, 0x0a, 0xbf, 0xc4, 0x34, 0xa3, 0x07, 0x46, 0x7d, 0xa8, 0x26, 0x1e, 0x26, 0x52,
+	0x1c, 0x56, 0x5f, 0x3a, 0x5a, 0x9f, 0x5c, 0x35, 0x2c, 0x36, 0x39, 0x81, 0xcd, 0x8c, 0x17, 0x0b,
+	0xf4, 0xf3, 0x74, 0x7f, 0x71, 0xc5, 0x7b, 0x47, 0xeb, 0xf1, 0x4d, 0x64, 0xf1, 0x2a, 0x19, 0x2f,
+	0x1b, 0xa9, 0x55, 0xae, 0x7e, 0x18, 0x69, 0x3d, 0xbe, 0x89, 0x4c, 0xac, 0x72, 0x01, 0xad, 0xab,
+	0x2f, 0xe9, 0xd1, 0xb3, 0x94, 0xaa, 0x6f, 0x78, 0x31, 0x68, 0x7d, 0x7a, 0x4b, 0x6a, 0xb1, 0xf4,
+	0xf7, 0x70, 0xef, 0xba, 0x5b, 0x7a, 0xb4, 0x97, 0x6c, 0xf5, 0x6e, 0x7e, 0x22, 0x68, 0x3d, 0xbf,
+	0x35, 0xbd, 0x10, 0xe0, 0x5b, 0x90, 0x2f, 0x5f, 0x0d, 0x23, 0xe5, 0xb2, 0xde, 0x56, 0xef, 0xa8,
+	0x5b, 0x0f, 0xaf, 0xa5, 0x89, 0x83, 0x2a, 0xbe, 0xeb, 0x4b, 0x05, 0xd5, 0xca, 0x05, 0x71, 0xeb,
+	0xfe, 0x15, 0xa3, 0x82, 0xd5, 0x18, 0x36, 0x33, 0x2e, 0xff, 0x52, 0x9e, 0x70, 0xf5, 0xe5, 0x60,
+	0x6b, 0x2b, 0xeb, 0x1e, 0xeb, 0x85, 0x84, 0x8e, 0x79, 0xa8, 0x66, 0xc5, 0x44, 0x46, 0x1a, 0x6b,
+	0x66, 0x1f, 0x52, 0x97, 0x3e, 0x0b, 0xd2, 0x17, 0x12, 0x1a, 0x42, 0x2d, 0x99, 0xba, 0x6e, 0xcc,
+	0x69, 0x37, 0x32, 0x9c, 0xc2, 0x7a, 0xea, 0x80, 0xe0, 0x7a, 0xe8, 0xc9, 0x8d, 0xc7, 0x1c, 0xae,
+	0xb1, 0xd6, 0xe3, 0x1b, 0x09, 0x99, 0x10, 0xbb, 0x74, 0x1d, 0x03, 0xd0, 0xe5, 0x72, 0xe0, 0x7a,
+	0xe8, 0xe1, 0x35, 0x4d, 0x64, 0xb4, 0x8c, 0x72, 0x2d, 0x51, 0xb4, 0xc4, 0xc1, 0x2f, 0xfe, 0xfc,
+	0x8f, 0xde, 0x5a, 0xc1, 0x6c, 0x79, 0xb6, 0x67, 0xba, 0xf3, 0xe7, 0xa6, 0x77, 0xb1, 0x08, 0xdc,
+	0x39, 0x71, 0xdf, 0x3f, 0xb7, 0x9d, 0xc9, 0x73, 0xdb, 0x89, 0xff, 0x32, 0xca, 0x5b, 0x98, 0x67,
+	0x45, 0xf6, 0x77, 0x50, 0x7f, 0xfc, 0x7f, 0x03, 0x00, 0xbc, 0x6e, 0x28, 0x4a, 0x37, 0x25, 0x00,
+	0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -2220,6 +3284,16 @@ type RouterClient interface {
 	//may cost to send an HTLC to the target end destination.
 	EstimateRouteFee(ctx context.Context, in *RouteFeeRequest, opts ...grpc.CallOption) (*RouteFeeResponse, error)
 	//
+	//ProbeRoute sends probe payments with a random, unknown payment hash along
+	//candidate routes to the destination. Unlike EstimateRouteFee, this
+	//measures whether the destination can actually be reached with the given
+	//amount and what it costs to do so, before committing the real payment.
+	//Every probe result is reported to mission control, so subsequent probes
+	//and payments benefit from what was learned. A probe reached the
+	//destination if it was failed by the final node with an incorrect payment
+	//details failure. Probes are recorded as failed payments.
+	ProbeRoute(ctx context.Context, in *ProbeRouteRequest, opts ...grpc.CallOption) (*ProbeRouteResponse, error)
+	//
 	//Deprecated, use SendToRouteV2. SendToRoute attempts to make a payment via
 	//the specified route. This method differs from SendPayment in that it
 	//allows users to specify a full route manually. This can be used for
@@ -2233,6 +3307,12 @@ type RouterClient interface {
 	//swaps.
 	SendToRouteV2(ctx context.Context, in *SendToRouteRequest, opts ...grpc.CallOption) (*lnrpc.HTLCAttempt, error)
 	//
+	//FailPayment marks an in-flight payment as failed, so that no further
+	//attempts can be sent for it. This is meant to be used together with the
+	//skip_temp_err option of SendToRouteV2 once the caller gives up on a
+	//payment. Attempts that are still in flight resolve normally.
+	FailPayment(ctx context.Context, in *FailPaymentRequest, opts ...grpc.CallOption) (*FailPaymentResponse, error)
+	//
 	//ResetMissionControl clears all mission control state and starts with a clean
 	//slate.
 	ResetMissionControl(ctx context.Context, in *ResetMissionControlRequest, opts ...grpc.CallOption) (*ResetMissionControlResponse, error)
@@ -2241,6 +3321,17 @@ type RouterClient interface {
 	//It is a development feature.
 	QueryMissionControl(ctx context.Context, in *QueryMissionControlRequest, opts ...grpc.CallOption) (*QueryMissionControlResponse, error)
 	//
+	//SetMissionControlOverrides manually marks nodes or node pairs as failed or
+	//succeeded in mission control, to steer path finding immediately instead of
+	//waiting for payment results. The effect of an override decays over time.
+	//Overrides are kept in memory only and aren't affected by
+	//ResetMissionControl.
+	SetMissionControlOverrides(ctx context.Context, in *SetMissionControlOverridesRequest, opts ...grpc.CallOption) (*SetMissionControlOverridesResponse, error)
+	//
+	//ClearMissionControlOverrides removes the override of a node or node pair,
+	//or all overrides.
+	ClearMissionControlOverrides(ctx context.Context, in *ClearMissionControlOverridesRequest, opts ...grpc.CallOption) (*ClearMissionControlOverridesResponse, error)
+	//
 	//QueryProbability returns the current success probability estimate for a
 	//given node pair and amount.
 	QueryProbability(ctx context.Context, in *QueryProbabilityRequest, opts ...grpc.CallOption) (*QueryProbabilityResponse, error)
@@ -2251,7 +3342,8 @@ type RouterClient interface {
 	BuildRoute(ctx context.Context, in *BuildRouteRequest, opts ...grpc.CallOption) (*BuildRouteResponse, error)
 	//
 	//SubscribeHtlcEvents creates a uni-directional stream from the server to
-	//the client which delivers a stream of htlc events.
+	//the client which delivers a stream of htlc events, optionally filtered by
+	//channel and event type.
 	SubscribeHtlcEvents(ctx context.Context, in *SubscribeHtlcEventsRequest, opts ...grpc.CallOption) (Router_SubscribeHtlcEventsClient, error)
 	//
 	//Deprecated, use SendPaymentV2. SendPayment attempts to route a payment
@@ -2269,6 +3361,14 @@ type RouterClient interface {
 	//In case of interception, the htlc can be either settled, cancelled or
 	//resumed later by using the ResolveHoldForward endpoint.
 	HtlcInterceptor(ctx context.Context, opts ...grpc.CallOption) (Router_HtlcInterceptorClient, error)
+	//*
+	//PaymentInterceptor dispatches a bi-directional streaming RPC in which every
+	//outgoing payment, and every attempt along a route chosen by the caller, is
+	//sent to the client before anything is sent to the network. The client
+	//responds whether the payment may proceed, and can restrict its fee limit,
+	//cltv limit and outgoing channels. Only one interceptor can be connected at
+	//a time.
+	PaymentInterceptor(ctx context.Context, opts ...grpc.CallOption) (Router_PaymentInterceptorClient, error)
 }
 
 type routerClient struct {
@@ -2352,6 +3452,15 @@ func (c *routerClient) EstimateRouteFee(ctx context.Context, in *RouteFeeRequest
 	return out, nil
 }
 
+func (c *routerClient) ProbeRoute(ctx context.Context, in *ProbeRouteRequest, opts ...grpc.CallOption) (*ProbeRouteResponse, error) {
+	out := new(ProbeRouteResponse)
+	err := c.cc.Invoke(ctx, "/routerrpc.Router/ProbeRoute", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Deprecated: Do not use.
 func (c *routerClient) SendToRoute(ctx context.Context, in *SendToRouteRequest, opts ...grpc.CallOption) (*SendToRouteResponse, error) {
 	out := new(SendToRouteResponse)
@@ -2371,6 +3480,15 @@ func (c *routerClient) SendToRouteV2(ctx context.Context, in *SendToRouteRequest
 	return out, nil
 }
 
+func (c *routerClient) FailPayment(ctx context.Context, in *FailPaymentRequest, opts ...grpc.CallOption) (*FailPaymentResponse, error) {
+	out := new(FailPaymentResponse)
+	err := c.cc.Invoke(ctx, "/routerrpc.Router/FailPayment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *routerClient) ResetMissionControl(ctx context.Context, in *ResetMissionControlRequest, opts ...grpc.CallOption) (*ResetMissionControlResponse, error) {
 	out := new(ResetMissionControlResponse)
 	err := c.cc.Invoke(ctx, "/routerrpc.Router/ResetMissionControl", in, out, opts...)
@@ -2389,6 +3507,24 @@ func (c *routerClient) QueryMissionControl(ctx context.Context, in *QueryMission
 	return out, nil
 }
 
+func (c *routerClient) SetMissionControlOverrides(ctx context.Context, in *SetMissionControlOverridesRequest, opts ...grpc.CallOption) (*SetMissionControlOverridesResponse, error) {
+	out := new(SetMissionControlOverridesResponse)
+	err := c.cc.Invoke(ctx, "/routerrpc.Router/SetMissionControlOverrides", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routerClient) ClearMissionControlOverrides(ctx context.Context, in *ClearMissionControlOverridesRequest, opts ...grpc.CallOption) (*ClearMissionControlOverridesResponse, error) {
+	out := new(ClearMissionControlOverridesResponse)
+	err := c.cc.Invoke(ctx, "/routerrpc.Router/ClearMissionControlOverrides", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *routerClient) QueryProbability(ctx context.Context, in *QueryProbabilityRequest, opts ...grpc.CallOption) (*QueryProbabilityResponse, error) {
 	out := new(QueryProbabilityResponse)
 	err := c.cc.Invoke(ctx, "/routerrpc.Router/QueryProbability", in, out, opts...)
@@ -2536,6 +3672,37 @@ func (x *routerHtlcInterceptorClient) Recv() (*ForwardHtlcInterceptRequest, erro
 	return m, nil
 }
 
+func (c *routerClient) PaymentInterceptor(ctx context.Context, opts ...grpc.CallOption) (Router_PaymentInterceptorClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Router_serviceDesc.Streams[6], "/routerrpc.Router/PaymentInterceptor", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &routerPaymentInterceptorClient{stream}
+	return x, nil
+}
+
+type Router_PaymentInterceptorClient interface {
+	Send(*PaymentInterceptResponse) error
+	Recv() (*PaymentInterceptRequest, error)
+	grpc.ClientStream
+}
+
+type routerPaymentInterceptorClient struct {
+	grpc.ClientStream
+}
+
+func (x *routerPaymentInterceptorClient) Send(m *PaymentInterceptResponse) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *routerPaymentInterceptorClient) Recv() (*PaymentInterceptRequest, error) {
+	m := new(PaymentInterceptRequest)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // RouterServer is the server API for Router service.
 type RouterServer interface {
 	//
@@ -2552,6 +3719,16 @@ type RouterServer interface {
 	//may cost to send an HTLC to the target end destination.
 	EstimateRouteFee(context.Context, *RouteFeeRequest) (*RouteFeeResponse, error)
 	//
+	//ProbeRoute sends probe payments with a random, unknown payment hash along
+	//candidate routes to the destination. Unlike EstimateRouteFee, this
+	//measures whether the destination can actually be reached with the given
+	//amount and what it costs to do so, before committing the real payment.
+	//Every probe result is reported to mission control, so subsequent probes
+	//and payments benefit from what was learned. A probe reached the
+	//destination if it was failed by the final node with an incorrect payment
+	//details failure. Probes are recorded as failed payments.
+	ProbeRoute(context.Context, *ProbeRouteRequest) (*ProbeRouteResponse, error)
+	//
 	//Deprecated, use SendToRouteV2. SendToRoute attempts to make a payment via
 	//the specified route. This method differs from SendPayment in that it
 	//allows users to specify a full route manually. This can be used for
@@ -2565,6 +3742,12 @@ type RouterServer interface {
 	//swaps.
 	SendToRouteV2(context.Context, *SendToRouteRequest) (*lnrpc.HTLCAttempt, error)
 	//
+	//FailPayment marks an in-flight payment as failed, so that no further
+	//attempts can be sent for it. This is meant to be used together with the
+	//skip_temp_err option of SendToRouteV2 once the caller gives up on a
+	//payment. Attempts that are still in flight resolve normally.
+	FailPayment(context.Context, *FailPaymentRequest) (*FailPaymentResponse, error)
+	//
 	//ResetMissionControl clears all mission control state and starts with a clean
 	//slate.
 	ResetMissionControl(context.Context, *ResetMissionControlRequest) (*ResetMissionControlResponse, error)
@@ -2573,6 +3756,17 @@ type RouterServer interface {
 	//It is a development feature.
 	QueryMissionControl(context.Context, *QueryMissionControlRequest) (*QueryMissionControlResponse, error)
 	//
+	//SetMissionControlOverrides manually marks nodes or node pairs as failed or
+	//succeeded in mission control, to steer path finding immediately instead of
+	//waiting for payment results. The effect of an override decays over time.
+	//Overrides are kept in memory only and aren't affected by
+	//ResetMissionControl.
+	SetMissionControlOverrides(context.Context, *SetMissionControlOverridesRequest) (*SetMissionControlOverridesResponse, error)
+	//
+	//ClearMissionControlOverrides removes the override of a node or node pair,
+	//or all overrides.
+	ClearMissionControlOverrides(context.Context, *ClearMissionControlOverridesRequest) (*ClearMissionControlOverridesResponse, error)
+	//
 	//QueryProbability returns the current success probability estimate for a
 	//given node pair and amount.
 	QueryProbability(context.Context, *QueryProbabilityRequest) (*QueryProbabilityResponse, error)
@@ -2583,7 +3777,8 @@ type RouterServer interface {
 	BuildRoute(context.Context, *BuildRouteRequest) (*BuildRouteResponse, error)
 	//
 	//SubscribeHtlcEvents creates a uni-directional stream from the server to
-	//the client which delivers a stream of htlc events.
+	//the client which delivers a stream of htlc events, optionally filtered by
+	//channel and event type.
 	SubscribeHtlcEvents(*SubscribeHtlcEventsRequest, Router_SubscribeHtlcEventsServer) error
 	//
 	//Deprecated, use SendPaymentV2. SendPayment attempts to route a payment
@@ -2601,6 +3796,14 @@ type RouterServer interface {
 	//In case of interception, the htlc can be either settled, cancelled or
 	//resumed later by using the ResolveHoldForward endpoint.
 	HtlcInterceptor(Router_HtlcInterceptorServer) error
+	//*
+	//PaymentInterceptor dispatches a bi-directional streaming RPC in which every
+	//outgoing payment, and every attempt along a route chosen by the caller, is
+	//sent to the client before anything is sent to the network. The client
+	//responds whether the payment may proceed, and can restrict its fee limit,
+	//cltv limit and outgoing channels. Only one interceptor can be connected at
+	//a time.
+	PaymentInterceptor(Router_PaymentInterceptorServer) error
 }
 
 // UnimplementedRouterServer can be embedded to have forward compatible implementations.
@@ -2616,18 +3819,30 @@ func (*UnimplementedRouterServer) TrackPaymentV2(req *TrackPaymentRequest, srv R
 func (*UnimplementedRouterServer) EstimateRouteFee(ctx context.Context, req *RouteFeeRequest) (*RouteFeeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method EstimateRouteFee not implemented")
 }
+func (*UnimplementedRouterServer) ProbeRoute(ctx context.Context, req *ProbeRouteRequest) (*ProbeRouteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProbeRoute not implemented")
+}
 func (*UnimplementedRouterServer) SendToRoute(ctx context.Context, req *SendToRouteRequest) (*SendToRouteResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SendToRoute not implemented")
 }
 func (*UnimplementedRouterServer) SendToRouteV2(ctx context.Context, req *SendToRouteRequest) (*lnrpc.HTLCAttempt, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SendToRouteV2 not implemented")
 }
+func (*UnimplementedRouterServer) FailPayment(ctx context.Context, req *FailPaymentRequest) (*FailPaymentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FailPayment not implemented")
+}
 func (*UnimplementedRouterServer) ResetMissionControl(ctx context.Context, req *ResetMissionControlRequest) (*ResetMissionControlResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ResetMissionControl not implemented")
 }
 func (*UnimplementedRouterServer) QueryMissionControl(ctx context.Context, req *QueryMissionControlRequest) (*QueryMissionControlResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method QueryMissionControl not implemented")
 }
+func (*UnimplementedRouterServer) SetMissionControlOverrides(ctx context.Context, req *SetMissionControlOverridesRequest) (*SetMissionControlOverridesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMissionControlOverrides not implemented")
+}
+func (*UnimplementedRouterServer) ClearMissionControlOverrides(ctx context.Context, req *ClearMissionControlOverridesRequest) (*ClearMissionControlOverridesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearMissionControlOverrides not implemented")
+}
 func (*UnimplementedRouterServer) QueryProbability(ctx context.Context, req *QueryProbabilityRequest) (*QueryProbabilityResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method QueryProbability not implemented")
 }
@@ -2646,6 +3861,9 @@ func (*UnimplementedRouterServer) TrackPayment(req *TrackPaymentRequest, srv Rou
 func (*UnimplementedRouterServer) HtlcInterceptor(srv Router_HtlcInterceptorServer) error {
 	return status.Errorf(codes.Unimplemented, "method HtlcInterceptor not implemented")
 }
+func (*UnimplementedRouterServer) PaymentInterceptor(srv Router_PaymentInterceptorServer) error {
+	return status.Errorf(codes.Unimplemented, "method PaymentInterceptor not implemented")
+}
 
 func RegisterRouterServer(s *grpc.Server, srv RouterServer) {
 	s.RegisterService(&_Router_serviceDesc, srv)
@@ -2711,6 +3929,24 @@ func _Router_EstimateRouteFee_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Router_ProbeRoute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProbeRouteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouterServer).ProbeRoute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/routerrpc.Router/ProbeRoute",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouterServer).ProbeRoute(ctx, req.(*ProbeRouteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Router_SendToRoute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SendToRouteRequest)
 	if err := dec(in); err != nil {
@@ -2747,6 +3983,24 @@ func _Router_SendToRouteV2_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Router_FailPayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FailPaymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouterServer).FailPayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/routerrpc.Router/FailPayment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouterServer).FailPayment(ctx, req.(*FailPaymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Router_ResetMissionControl_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ResetMissionControlRequest)
 	if err := dec(in); err != nil {
@@ -2783,6 +4037,42 @@ func _Router_QueryMissionControl_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Router_SetMissionControlOverrides_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMissionControlOverridesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouterServer).SetMissionControlOverrides(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/routerrpc.Router/SetMissionControlOverrides",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouterServer).SetMissionControlOverrides(ctx, req.(*SetMissionControlOverridesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Router_ClearMissionControlOverrides_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClearMissionControlOverridesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouterServer).ClearMissionControlOverrides(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/routerrpc.Router/ClearMissionControlOverrides",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouterServer).ClearMissionControlOverrides(ctx, req.(*ClearMissionControlOverridesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Router_QueryProbability_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(QueryProbabilityRequest)
 	if err := dec(in); err != nil {
@@ -2908,6 +4198,32 @@ func (x *routerHtlcInterceptorServer) Recv() (*ForwardHtlcInterceptResponse, err
 	return m, nil
 }
 
+func _Router_PaymentInterceptor_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(RouterServer).PaymentInterceptor(&routerPaymentInterceptorServer{stream})
+}
+
+type Router_PaymentInterceptorServer interface {
+	Send(*PaymentInterceptRequest) error
+	Recv() (*PaymentInterceptResponse, error)
+	grpc.ServerStream
+}
+
+type routerPaymentInterceptorServer struct {
+	grpc.ServerStream
+}
+
+func (x *routerPaymentInterceptorServer) Send(m *PaymentInterceptRequest) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *routerPaymentInterceptorServer) Recv() (*PaymentInterceptResponse, error) {
+	m := new(PaymentInterceptResponse)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 var _Router_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "routerrpc.Router",
 	HandlerType: (*RouterServer)(nil),
@@ -2916,6 +4232,10 @@ var _Router_serviceDesc = grpc.ServiceDesc{
 			MethodName: "EstimateRouteFee",
 			Handler:    _Router_EstimateRouteFee_Handler,
 		},
+		{
+			MethodName: "ProbeRoute",
+			Handler:    _Router_ProbeRoute_Handler,
+		},
 		{
 			MethodName: "SendToRoute",
 			Handler:    _Router_SendToRoute_Handler,
@@ -2924,6 +4244,10 @@ var _Router_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SendToRouteV2",
 			Handler:    _Router_SendToRouteV2_Handler,
 		},
+		{
+			MethodName: "FailPayment",
+			Handler:    _Router_FailPayment_Handler,
+		},
 		{
 			MethodName: "ResetMissionControl",
 			Handler:    _Router_ResetMissionControl_Handler,
@@ -2932,6 +4256,14 @@ var _Router_serviceDesc = grpc.ServiceDesc{
 			MethodName: "QueryMissionControl",
 			Handler:    _Router_QueryMissionControl_Handler,
 		},
+		{
+			MethodName: "SetMissionControlOverrides",
+			Handler:    _Router_SetMissionControlOverrides_Handler,
+		},
+		{
+			MethodName: "ClearMissionControlOverrides",
+			Handler:    _Router_ClearMissionControlOverrides_Handler,
+		},
 		{
 			MethodName: "QueryProbability",
 			Handler:    _Router_QueryProbability_Handler,
@@ -2973,6 +4305,12 @@ var _Router_serviceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "PaymentInterceptor",
+			Handler:       _Router_PaymentInterceptor_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "routerrpc/router.proto",
 }
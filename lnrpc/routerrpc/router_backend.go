@@ -2,6 +2,7 @@ package routerrpc
 
 import (
 	"context"
+	"crypto/rand"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -47,6 +48,11 @@ type RouterBackend struct {
 		routeHints map[route.Vertex][]*channeldb.ChannelEdgePolicy,
 		finalExpiry uint16) (*route.Route, error)
 
+	// SendToRoute sends a single htlc along the given route, and returns
+	// the attempt once its outcome is known.
+	SendToRoute func(hash lntypes.Hash, rt *route.Route) (
+		*channeldb.HTLCAttempt, error)
+
 	MissionControl MissionControl
 
 	// ActiveNetParams are the network parameters of the primary network
@@ -95,6 +101,20 @@ type MissionControl interface {
 	// pair.
 	GetPairHistorySnapshot(fromNode,
 		toNode route.Vertex) routing.TimedPairResult
+
+	// SetOverride sets a manual override for a node or node pair.
+	SetOverride(override routing.MissionControlOverride)
+
+	// ClearOverride removes the manual override of a node, or of a node
+	// pair if toNode is non-nil. It returns false if there was no such
+	// override.
+	ClearOverride(node route.Vertex, toNode *route.Vertex) bool
+
+	// ClearOverrides removes all manual overrides.
+	ClearOverrides()
+
+	// GetOverrides returns all manual overrides.
+	GetOverrides() []routing.MissionControlOverride
 }
 
 // QueryRoutes attempts to query the daemons' Channel Router for a possible
@@ -504,6 +524,10 @@ func (r *RouterBackend) UnmarshallRoute(rpcroute *lnrpc.Route) (
 	return route, nil
 }
 
+// maxIdempotencyKeyLen is the maximum length of the idempotency key that a
+// client may supply with a payment.
+const maxIdempotencyKeyLen = 64
+
 // extractIntentFromSendRequest attempts to parse the SendRequest details
 // required to dispatch a client from the information presented by an RPC
 // client.
@@ -555,6 +579,13 @@ func (r *RouterBackend) extractIntentFromSendRequest(
 	}
 	payIntent.MaxParts = maxParts
 
+	// Take the idempotency key from the request, if any.
+	if len(rpcPayReq.IdempotencyKey) > maxIdempotencyKeyLen {
+		return nil, fmt.Errorf("idempotency key exceeds maximum "+
+			"length of %v bytes", maxIdempotencyKeyLen)
+	}
+	payIntent.IdempotencyKey = rpcPayReq.IdempotencyKey
+
 	// Take fee limit from request.
 	payIntent.FeeLimit, err = lnrpc.UnmarshallAmt(
 		rpcPayReq.FeeLimitSat, rpcPayReq.FeeLimitMsat,
@@ -871,7 +902,9 @@ func (r *RouterBackend) MarshalHTLCAttempt(
 		)
 
 		var err error
-		rpcAttempt.Failure, err = marshallHtlcFailure(htlc.Failure)
+		rpcAttempt.Failure, err = marshallHtlcFailure(
+			&htlc.Route, htlc.Failure,
+		)
 		if err != nil {
 			return nil, err
 		}
@@ -883,9 +916,10 @@ func (r *RouterBackend) MarshalHTLCAttempt(
 }
 
 // marshallHtlcFailure marshalls htlc fail info from the database to its rpc
-// representation.
-func marshallHtlcFailure(failure *channeldb.HTLCFailInfo) (*lnrpc.Failure,
-	error) {
+// representation. The route of the htlc is used to attribute the failure to
+// the node and channel it originated from.
+func marshallHtlcFailure(rt *route.Route,
+	failure *channeldb.HTLCFailInfo) (*lnrpc.Failure, error) {
 
 	rpcFailure := &lnrpc.Failure{
 		FailureSourceIndex: failure.FailureSourceIndex,
@@ -896,8 +930,11 @@ func marshallHtlcFailure(failure *channeldb.HTLCFailInfo) (*lnrpc.Failure,
 	case channeldb.HTLCFailUnknown:
 		rpcFailure.Code = lnrpc.Failure_UNKNOWN_FAILURE
 
+	// The source of an unreadable failure is unknown, so we can't
+	// attribute it to a node or channel.
 	case channeldb.HTLCFailUnreadable:
 		rpcFailure.Code = lnrpc.Failure_UNREADABLE_FAILURE
+		return rpcFailure, nil
 
 	case channeldb.HTLCFailInternal:
 		rpcFailure.Code = lnrpc.Failure_INTERNAL_FAILURE
@@ -912,9 +949,44 @@ func marshallHtlcFailure(failure *channeldb.HTLCFailInfo) (*lnrpc.Failure,
 		return nil, errors.New("unknown htlc failure reason")
 	}
 
+	attributeFailure(rt, int(failure.FailureSourceIndex), rpcFailure)
+
 	return rpcFailure, nil
 }
 
+// attributeFailure sets the public key of the node at the given position in
+// the route and the channel the failure is attributed to. For intermediate
+// nodes and the sender that's the outgoing channel. For the final node it is
+// the incoming channel, as it doesn't have an outgoing one.
+func attributeFailure(rt *route.Route, sourceIdx int,
+	rpcFailure *lnrpc.Failure) {
+
+	numHops := len(rt.Hops)
+	if numHops == 0 || sourceIdx > numHops {
+		return
+	}
+
+	switch {
+	case sourceIdx == 0:
+		rpcFailure.FailureSourcePubkey = hex.EncodeToString(
+			rt.SourcePubKey[:],
+		)
+		rpcFailure.FailureChanId = rt.Hops[0].ChannelID
+
+	case sourceIdx == numHops:
+		rpcFailure.FailureSourcePubkey = hex.EncodeToString(
+			rt.Hops[numHops-1].PubKeyBytes[:],
+		)
+		rpcFailure.FailureChanId = rt.Hops[numHops-1].ChannelID
+
+	default:
+		rpcFailure.FailureSourcePubkey = hex.EncodeToString(
+			rt.Hops[sourceIdx-1].PubKeyBytes[:],
+		)
+		rpcFailure.FailureChanId = rt.Hops[sourceIdx].ChannelID
+	}
+}
+
 // MarshalTimeNano converts a time.Time into its nanosecond representation. If
 // the time is zero, this method simply returns 0, since calling UnixNano() on a
 // zero-valued time is undefined.
@@ -972,6 +1044,10 @@ func marshallError(sendError error) (*lnrpc.Failure, error) {
 func marshallWireError(msg lnwire.FailureMessage,
 	response *lnrpc.Failure) error {
 
+	if msg != nil {
+		response.WireCode = uint32(msg.Code())
+	}
+
 	switch onionErr := msg.(type) {
 
 	case *lnwire.FailIncorrectDetails:
@@ -1066,6 +1142,8 @@ func marshallWireError(msg lnwire.FailureMessage,
 		return fmt.Errorf("cannot marshall failure %T", onionErr)
 	}
 
+	response.ChannelPolicy = marshallUpdatePolicy(response.ChannelUpdate)
+
 	return nil
 }
 
@@ -1092,6 +1170,32 @@ func marshallChannelUpdate(update *lnwire.ChannelUpdate) *lnrpc.ChannelUpdate {
 	}
 }
 
+// marshallUpdatePolicy extracts the routing policy from a channel update that
+// was included in a failure message, so callers don't need to interpret the
+// flags of the update themselves.
+func marshallUpdatePolicy(update *lnrpc.ChannelUpdate) *lnrpc.RoutingPolicy {
+	if update == nil {
+		return nil
+	}
+
+	chanFlags := lnwire.ChanUpdateChanFlags(update.ChannelFlags)
+	policy := &lnrpc.RoutingPolicy{
+		TimeLockDelta:    update.TimeLockDelta,
+		MinHtlc:          int64(update.HtlcMinimumMsat),
+		FeeBaseMsat:      int64(update.BaseFee),
+		FeeRateMilliMsat: int64(update.FeeRate),
+		Disabled:         chanFlags&lnwire.ChanUpdateDisabled != 0,
+		LastUpdate:       update.Timestamp,
+	}
+
+	msgFlags := lnwire.ChanUpdateMsgFlags(update.MessageFlags)
+	if msgFlags.HasMaxHtlc() {
+		policy.MaxHtlcMsat = update.HtlcMaximumMsat
+	}
+
+	return policy
+}
+
 // MarshallPayment marshall a payment to its rpc representation.
 func (r *RouterBackend) MarshallPayment(payment *channeldb.MPPayment) (
 	*lnrpc.Payment, error) {
@@ -1154,6 +1258,7 @@ func (r *RouterBackend) MarshallPayment(payment *channeldb.MPPayment) (
 		Htlcs:           htlcs,
 		PaymentIndex:    payment.SequenceNum,
 		FailureReason:   failureReason,
+		IdempotencyKey:  payment.Info.IdempotencyKey,
 	}, nil
 }
 
@@ -1209,3 +1314,120 @@ func marshallPaymentFailureReason(reason *channeldb.FailureReason) (
 
 	return 0, errors.New("unknown failure reason")
 }
+
+// defaultMaxProbes is the maximum number of routes ProbeRoute probes if the
+// caller doesn't specify a limit.
+const defaultMaxProbes = 3
+
+// ProbeRoute sends probe payments with a random payment hash along candidate
+// routes to the destination, until one of them reaches the destination or the
+// maximum number of probes is exhausted. The results of the probes are
+// reported to mission control by the router, so every probe steers path
+// finding for the next one.
+func (r *RouterBackend) ProbeRoute(ctx context.Context,
+	req *ProbeRouteRequest) (*ProbeRouteResponse, error) {
+
+	if len(req.Dest) != 33 {
+		return nil, errors.New("invalid length destination key")
+	}
+	var destNode route.Vertex
+	copy(destNode[:], req.Dest)
+
+	if req.AmtSat <= 0 {
+		return nil, errors.New("amount must be greater than zero")
+	}
+	amtMsat := lnwire.NewMSatFromSatoshis(btcutil.Amount(req.AmtSat))
+
+	feeLimit := lnwire.NewMSatFromSatoshis(btcutil.SatoshiPerBitcoin)
+	if req.FeeLimitSat != 0 {
+		feeLimit = lnwire.NewMSatFromSatoshis(
+			btcutil.Amount(req.FeeLimitSat),
+		)
+	}
+
+	finalCltvDelta := r.DefaultFinalCltvDelta
+	if req.FinalCltvDelta != 0 {
+		finalCltvDelta = uint16(req.FinalCltvDelta)
+	}
+
+	maxProbes := req.MaxProbes
+	if maxProbes == 0 {
+		maxProbes = defaultMaxProbes
+	}
+
+	mc := r.MissionControl
+	resp := &ProbeRouteResponse{}
+	for i := uint32(0); i < maxProbes; i++ {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		// Find the most promising route given what mission control
+		// learned from the previous probes. If there's none left,
+		// we return the results we have so far.
+		rt, err := r.FindRoute(
+			r.SelfNode, destNode, amtMsat,
+			&routing.RestrictParams{
+				FeeLimit:          feeLimit,
+				CltvLimit:         r.MaxTotalTimelock,
+				ProbabilitySource: mc.GetProbability,
+			}, nil, nil, finalCltvDelta,
+		)
+		if err != nil {
+			if len(resp.Probes) > 0 {
+				break
+			}
+			return nil, err
+		}
+
+		// The probe uses a random payment hash that the destination
+		// doesn't know the preimage for, so it will never settle.
+		var hash lntypes.Hash
+		if _, err := rand.Read(hash[:]); err != nil {
+			return nil, err
+		}
+
+		attempt, err := r.SendToRoute(hash, rt)
+		if attempt == nil {
+			return nil, err
+		}
+
+		rpcAttempt, err := r.MarshalHTLCAttempt(*attempt)
+		if err != nil {
+			return nil, err
+		}
+
+		result := &ProbeResult{
+			Route:              rpcAttempt.Route,
+			Failure:            rpcAttempt.Failure,
+			ReachedDestination: probeReachedDestination(attempt),
+		}
+		resp.Probes = append(resp.Probes, result)
+
+		if result.ReachedDestination {
+			resp.Reachable = true
+			resp.RoutingFeeMsat = int64(rt.TotalFees())
+			resp.TimeLockDelay = int64(rt.TotalTimeLock)
+			break
+		}
+	}
+
+	return resp, nil
+}
+
+// probeReachedDestination returns true if the probe htlc was failed by the
+// final node because it doesn't know the payment hash, which means the route
+// was able to carry the htlc all the way to the destination.
+func probeReachedDestination(attempt *channeldb.HTLCAttempt) bool {
+	failure := attempt.Failure
+	if failure == nil ||
+		int(failure.FailureSourceIndex) != len(attempt.Route.Hops) {
+
+		return false
+	}
+
+	_, ok := failure.Message.(*lnwire.FailIncorrectDetails)
+	return ok
+}
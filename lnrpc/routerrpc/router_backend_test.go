@@ -4,14 +4,18 @@ import (
 	"bytes"
 	"context"
 	"encoding/hex"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/btcsuite/btcutil"
 	"github.com/cryptomeow/lnd/channeldb"
+	"github.com/cryptomeow/lnd/lntypes"
 	"github.com/cryptomeow/lnd/lnwire"
 	"github.com/cryptomeow/lnd/record"
 	"github.com/cryptomeow/lnd/routing"
 	"github.com/cryptomeow/lnd/routing/route"
+	"github.com/stretchr/testify/require"
 
 	"github.com/cryptomeow/lnd/lnrpc"
 )
@@ -215,6 +219,201 @@ func testQueryRoutes(t *testing.T, useMissionControl bool, useMsat bool) {
 	}
 }
 
+// probeRouteTest is a test case of ProbeRoute.
+type probeRouteTest struct {
+	name string
+
+	// maxProbes is the maximum number of probes of the request.
+	maxProbes uint32
+
+	// routes is the number of routes found by path finding.
+	routes int
+
+	// failures are the failures of the consecutive probes.
+	failures []*channeldb.HTLCFailInfo
+
+	expectedErr    bool
+	expectedProbes int
+	reachable      bool
+}
+
+// TestProbeRoute asserts that ProbeRoute keeps probing routes until one of
+// them reaches the destination, the maximum number of probes is exhausted or
+// no route is left.
+func TestProbeRoute(t *testing.T) {
+	t.Parallel()
+
+	// A probe either fails at the first hop, or at the destination which
+	// doesn't know the payment hash.
+	probe := &channeldb.HTLCFailInfo{
+		Reason:             channeldb.HTLCFailMessage,
+		FailureSourceIndex: 1,
+		Message:            &lnwire.FailTemporaryChannelFailure{},
+	}
+	reachedDest := &channeldb.HTLCFailInfo{
+		Reason:             channeldb.HTLCFailMessage,
+		FailureSourceIndex: 2,
+		Message:            lnwire.NewFailIncorrectDetails(1000, 100),
+	}
+
+	tests := []probeRouteTest{
+		{
+			name:     "reachable",
+			routes:   3,
+			failures: []*channeldb.HTLCFailInfo{probe, reachedDest},
+
+			expectedProbes: 2,
+			reachable:      true,
+		},
+		{
+			name:      "max probes",
+			maxProbes: 2,
+			routes:    3,
+			failures: []*channeldb.HTLCFailInfo{
+				probe, probe, reachedDest,
+			},
+
+			expectedProbes: 2,
+		},
+		{
+			name:     "no more routes",
+			routes:   1,
+			failures: []*channeldb.HTLCFailInfo{probe, reachedDest},
+
+			expectedProbes: 1,
+		},
+		{
+			name:   "no route",
+			routes: 0,
+
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			testProbeRoute(t, test)
+		})
+	}
+
+	destNode, err := route.NewVertexFromStr(destKey)
+	require.NoError(t, err)
+
+	// Invalid requests are rejected before any route is probed.
+	backend := &RouterBackend{}
+	_, err = backend.ProbeRoute(
+		context.Background(), &ProbeRouteRequest{
+			Dest:   destNode[:1],
+			AmtSat: 1000,
+		},
+	)
+	require.Error(t, err)
+
+	_, err = backend.ProbeRoute(
+		context.Background(), &ProbeRouteRequest{
+			Dest: destNode[:],
+		},
+	)
+	require.Error(t, err)
+}
+
+func testProbeRoute(t *testing.T, test probeRouteTest) {
+	destNode, err := route.NewVertexFromStr(destKey)
+	require.NoError(t, err)
+
+	var found, sent int
+	findRoute := func(source, target route.Vertex,
+		amt lnwire.MilliSatoshi, _ *routing.RestrictParams,
+		_ record.CustomSet,
+		_ map[route.Vertex][]*channeldb.ChannelEdgePolicy,
+		finalExpiry uint16) (*route.Route, error) {
+
+		require.Equal(t, sourceKey, source)
+		require.Equal(t, destNode, target)
+		require.Equal(t, uint16(40), finalExpiry)
+
+		if found == test.routes {
+			return nil, errors.New("no route")
+		}
+		found++
+
+		hops := []*route.Hop{{
+			PubKeyBytes:      node1,
+			ChannelID:        1,
+			AmtToForward:     amt,
+			OutgoingTimeLock: 140,
+		}, {
+			PubKeyBytes:      target,
+			ChannelID:        2,
+			AmtToForward:     amt,
+			OutgoingTimeLock: 140,
+		}}
+
+		return route.NewRouteFromHops(amt, 144, source, hops)
+	}
+
+	var hashes []lntypes.Hash
+	sendToRoute := func(hash lntypes.Hash,
+		rt *route.Route) (*channeldb.HTLCAttempt, error) {
+
+		hashes = append(hashes, hash)
+		failure := test.failures[sent]
+		sent++
+
+		attempt := &channeldb.HTLCAttempt{
+			Failure: failure,
+		}
+		attempt.Route = *rt
+
+		return attempt, nil
+	}
+
+	backend := &RouterBackend{
+		SelfNode:    sourceKey,
+		FindRoute:   findRoute,
+		SendToRoute: sendToRoute,
+		FetchChannelCapacity: func(chanID uint64) (
+			btcutil.Amount, error) {
+
+			return 1, nil
+		},
+		MissionControl:        &mockMissionControl{},
+		DefaultFinalCltvDelta: 40,
+	}
+
+	resp, err := backend.ProbeRoute(
+		context.Background(), &ProbeRouteRequest{
+			Dest:      destNode[:],
+			AmtSat:    1000,
+			MaxProbes: test.maxProbes,
+		},
+	)
+	if test.expectedErr {
+		require.Error(t, err)
+		return
+	}
+	require.NoError(t, err)
+
+	require.Len(t, resp.Probes, test.expectedProbes)
+	require.Equal(t, test.reachable, resp.Reachable)
+	for i, probe := range resp.Probes {
+		require.Len(t, probe.Route.Hops, 2)
+
+		lastProbe := i == len(resp.Probes)-1
+		require.Equal(
+			t, test.reachable && lastProbe,
+			probe.ReachedDestination,
+		)
+	}
+
+	// Every probe is sent with a different payment hash.
+	require.Len(t, hashes, test.expectedProbes)
+	if len(hashes) > 1 {
+		require.NotEqual(t, hashes[0], hashes[1])
+	}
+}
+
 type mockMissionControl struct {
 }
 
@@ -238,6 +437,22 @@ func (m *mockMissionControl) GetPairHistorySnapshot(fromNode,
 	return routing.TimedPairResult{}
 }
 
+func (m *mockMissionControl) SetOverride(
+	override routing.MissionControlOverride) {
+}
+
+func (m *mockMissionControl) ClearOverride(node route.Vertex,
+	toNode *route.Vertex) bool {
+
+	return false
+}
+
+func (m *mockMissionControl) ClearOverrides() {}
+
+func (m *mockMissionControl) GetOverrides() []routing.MissionControlOverride {
+	return nil
+}
+
 type mppOutcome byte
 
 const (
@@ -357,3 +572,142 @@ func testUnmarshalMPP(t *testing.T, test unmarshalMPPTest) {
 		t.Fatalf("test case has non-standard outcome")
 	}
 }
+
+// TestMarshalHTLCAttemptFailure asserts that the failure of an htlc attempt is
+// attributed to the node and channel it originated from, and that the policy
+// of a channel update in the failure message is decoded.
+func TestMarshalHTLCAttemptFailure(t *testing.T) {
+	t.Parallel()
+
+	backend := &RouterBackend{
+		FetchChannelCapacity: func(chanID uint64) (btcutil.Amount, error) {
+			return 1000000, nil
+		},
+	}
+
+	rt := route.Route{
+		SourcePubKey: sourceKey,
+		Hops: []*route.Hop{
+			{ChannelID: 1, PubKeyBytes: node1},
+			{ChannelID: 2, PubKeyBytes: node2},
+		},
+	}
+
+	update := lnwire.ChannelUpdate{
+		ShortChannelID:  lnwire.NewShortChanIDFromInt(2),
+		Timestamp:       100,
+		MessageFlags:    lnwire.ChanUpdateOptionMaxHtlc,
+		ChannelFlags:    lnwire.ChanUpdateDisabled,
+		TimeLockDelta:   40,
+		HtlcMinimumMsat: 1000,
+		BaseFee:         1,
+		FeeRate:         10,
+		HtlcMaximumMsat: 500000,
+	}
+
+	testCases := []struct {
+		name           string
+		failure        channeldb.HTLCFailInfo
+		expectedPubkey route.Vertex
+		expectedChanID uint64
+		expectedCode   uint32
+		expectedPolicy *lnrpc.RoutingPolicy
+	}{
+		{
+			name: "sender",
+			failure: channeldb.HTLCFailInfo{
+				Reason:             channeldb.HTLCFailInternal,
+				FailureSourceIndex: 0,
+			},
+			expectedPubkey: sourceKey,
+			expectedChanID: 1,
+		},
+		{
+			name: "intermediate node",
+			failure: channeldb.HTLCFailInfo{
+				Reason: channeldb.HTLCFailMessage,
+				Message: lnwire.NewFeeInsufficient(
+					1000, update,
+				),
+				FailureSourceIndex: 1,
+			},
+			expectedPubkey: node1,
+			expectedChanID: 2,
+			expectedCode:   uint32(lnwire.CodeFeeInsufficient),
+			expectedPolicy: &lnrpc.RoutingPolicy{
+				TimeLockDelta:    40,
+				MinHtlc:          1000,
+				FeeBaseMsat:      1,
+				FeeRateMilliMsat: 10,
+				Disabled:         true,
+				MaxHtlcMsat:      500000,
+				LastUpdate:       100,
+			},
+		},
+		{
+			name: "final node",
+			failure: channeldb.HTLCFailInfo{
+				Reason: channeldb.HTLCFailMessage,
+				Message: lnwire.NewFailIncorrectDetails(
+					1000, 100,
+				),
+				FailureSourceIndex: 2,
+			},
+			expectedPubkey: node2,
+			expectedChanID: 2,
+			expectedCode: uint32(
+				lnwire.CodeIncorrectOrUnknownPaymentDetails,
+			),
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			failure := testCase.failure
+			failure.FailTime = time.Unix(1, 0)
+
+			attempt, err := backend.MarshalHTLCAttempt(
+				channeldb.HTLCAttempt{
+					HTLCAttemptInfo: channeldb.HTLCAttemptInfo{
+						Route: rt,
+					},
+					Failure: &failure,
+				},
+			)
+			require.NoError(t, err)
+			require.Equal(
+				t, lnrpc.HTLCAttempt_FAILED, attempt.Status,
+			)
+
+			rpcFailure := attempt.Failure
+			require.Equal(
+				t, hex.EncodeToString(testCase.expectedPubkey[:]),
+				rpcFailure.FailureSourcePubkey,
+			)
+			require.Equal(
+				t, testCase.expectedChanID, rpcFailure.FailureChanId,
+			)
+			require.Equal(t, testCase.expectedCode, rpcFailure.WireCode)
+			require.Equal(
+				t, testCase.expectedPolicy, rpcFailure.ChannelPolicy,
+			)
+		})
+	}
+
+	// An unreadable failure can't be attributed to any node.
+	attempt, err := backend.MarshalHTLCAttempt(channeldb.HTLCAttempt{
+		HTLCAttemptInfo: channeldb.HTLCAttemptInfo{
+			Route: rt,
+		},
+		Failure: &channeldb.HTLCFailInfo{
+			Reason: channeldb.HTLCFailUnreadable,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(
+		t, lnrpc.Failure_UNREADABLE_FAILURE, attempt.Failure.Code,
+	)
+	require.Empty(t, attempt.Failure.FailureSourcePubkey)
+	require.Zero(t, attempt.Failure.FailureChanId)
+}
@@ -5,9 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"math"
 	"os"
 	"path/filepath"
+	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/btcsuite/btcutil"
 	"github.com/grpc-ecosystem/grpc-gateway/runtime"
@@ -16,6 +19,7 @@ import (
 	"github.com/cryptomeow/lnd/lntypes"
 	"github.com/cryptomeow/lnd/lnwire"
 	"github.com/cryptomeow/lnd/macaroons"
+	"github.com/cryptomeow/lnd/record"
 	"github.com/cryptomeow/lnd/routing"
 	"github.com/cryptomeow/lnd/routing/route"
 
@@ -63,6 +67,10 @@ var (
 			Entity: "offchain",
 			Action: "write",
 		}},
+		"/routerrpc.Router/FailPayment": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
 		"/routerrpc.Router/SendToRoute": {{
 			Entity: "offchain",
 			Action: "write",
@@ -75,6 +83,10 @@ var (
 			Entity: "offchain",
 			Action: "read",
 		}},
+		"/routerrpc.Router/ProbeRoute": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
 		"/routerrpc.Router/QueryMissionControl": {{
 			Entity: "offchain",
 			Action: "read",
@@ -87,6 +99,14 @@ var (
 			Entity: "offchain",
 			Action: "write",
 		}},
+		"/routerrpc.Router/SetMissionControlOverrides": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
+		"/routerrpc.Router/ClearMissionControlOverrides": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
 		"/routerrpc.Router/BuildRoute": {{
 			Entity: "offchain",
 			Action: "read",
@@ -107,6 +127,10 @@ var (
 			Entity: "offchain",
 			Action: "write",
 		}},
+		"/routerrpc.Router/PaymentInterceptor": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
 	}
 
 	// DefaultRouterMacFilename is the default name of the router macaroon
@@ -124,6 +148,11 @@ type Server struct {
 
 	cfg *Config
 
+	// paymentInterceptor is the connected payment interceptor, if any. It
+	// is protected by the paymentInterceptorMtx.
+	paymentInterceptorMtx sync.Mutex
+	paymentInterceptor    *paymentInterceptor
+
 	quit chan struct{}
 }
 
@@ -199,6 +228,8 @@ func (s *Server) Start() error {
 		return nil
 	}
 
+	s.cfg.Router.SetPaymentInterceptor(s.interceptPayment)
+
 	return nil
 }
 
@@ -210,6 +241,8 @@ func (s *Server) Stop() error {
 		return nil
 	}
 
+	s.cfg.Router.SetPaymentInterceptor(nil)
+
 	close(s.quit)
 	return nil
 }
@@ -275,7 +308,25 @@ func (s *Server) SendPaymentV2(req *SendPaymentRequest,
 
 	err = s.cfg.Router.SendPaymentAsync(payment)
 	if err != nil {
+		// If the request is a retry of a payment that was already
+		// initiated with the same idempotency key, we stream back the
+		// updates of the existing payment.
+		if err == channeldb.ErrPaymentKeyExists {
+			log.Debugf("SendPayment for hash %x already initiated "+
+				"with idempotency key %x", payment.PaymentHash,
+				payment.IdempotencyKey)
+
+			return s.trackPayment(
+				payment.PaymentHash, stream,
+				req.NoInflightUpdates,
+			)
+		}
+
 		// Transform user errors to grpc code.
+		if err == channeldb.ErrPaymentKeyMismatch {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+
 		if err == channeldb.ErrPaymentInFlight ||
 			err == channeldb.ErrAlreadyPaid {
 
@@ -339,6 +390,15 @@ func (s *Server) EstimateRouteFee(ctx context.Context,
 	}, nil
 }
 
+// ProbeRoute sends probe payments with a random payment hash along candidate
+// routes to the destination, until one of them reaches the destination or the
+// maximum number of probes is exhausted.
+func (s *Server) ProbeRoute(ctx context.Context,
+	req *ProbeRouteRequest) (*ProbeRouteResponse, error) {
+
+	return s.cfg.RouterBackend.ProbeRoute(ctx, req)
+}
+
 // SendToRouteV2 sends a payment through a predefined route. The response of this
 // call contains structured error information.
 func (s *Server) SendToRouteV2(ctx context.Context,
@@ -363,8 +423,14 @@ func (s *Server) SendToRouteV2(ctx context.Context,
 	// the attempt return value and err are non-nil. This can happen when
 	// the attempt was already initiated before the error happened. In that
 	// case, we give precedence to the attempt information as stored in the
-	// db.
-	attempt, err := s.cfg.Router.SendToRoute(hash, route)
+	// db. If temporary errors are skipped, the payment is left in flight
+	// so that the caller can send further attempts for it.
+	var attempt *channeldb.HTLCAttempt
+	if req.SkipTempErr {
+		attempt, err = s.cfg.Router.SendToRouteSkipTempErr(hash, route)
+	} else {
+		attempt, err = s.cfg.Router.SendToRoute(hash, route)
+	}
 	if attempt != nil {
 		rpcAttempt, err := s.cfg.RouterBackend.MarshalHTLCAttempt(
 			*attempt,
@@ -378,6 +444,23 @@ func (s *Server) SendToRouteV2(ctx context.Context,
 	return nil, err
 }
 
+// FailPayment marks an in-flight payment as failed, so that no further
+// attempts can be sent for it.
+func (s *Server) FailPayment(ctx context.Context,
+	req *FailPaymentRequest) (*FailPaymentResponse, error) {
+
+	hash, err := lntypes.MakeHash(req.PaymentHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.cfg.Router.FailPayment(hash); err != nil {
+		return nil, err
+	}
+
+	return &FailPaymentResponse{}, nil
+}
+
 // ResetMissionControl clears all mission control state and starts with a clean
 // slate.
 func (s *Server) ResetMissionControl(ctx context.Context,
@@ -412,13 +495,116 @@ func (s *Server) QueryMissionControl(ctx context.Context,
 		rpcPairs = append(rpcPairs, &rpcPair)
 	}
 
+	overrides := s.cfg.RouterBackend.MissionControl.GetOverrides()
+	rpcOverrides := make([]*MissionControlOverride, 0, len(overrides))
+	for _, override := range overrides {
+		rpcOverride := &MissionControlOverride{
+			Node:        override.Node[:],
+			Success:     override.Success,
+			HalfLifeSec: uint64(override.HalfLife.Seconds()),
+			SetTime:     override.SetTime.Unix(),
+		}
+		if override.ToNode != nil {
+			rpcOverride.ToNode = override.ToNode[:]
+		}
+
+		rpcOverrides = append(rpcOverrides, rpcOverride)
+	}
+
 	response := QueryMissionControlResponse{
-		Pairs: rpcPairs,
+		Pairs:     rpcPairs,
+		Overrides: rpcOverrides,
 	}
 
 	return &response, nil
 }
 
+// SetMissionControlOverrides manually marks nodes or node pairs as failed or
+// succeeded in mission control.
+func (s *Server) SetMissionControlOverrides(ctx context.Context,
+	req *SetMissionControlOverridesRequest) (
+	*SetMissionControlOverridesResponse, error) {
+
+	// Validate all overrides before setting any of them.
+	overrides := make([]routing.MissionControlOverride, 0,
+		len(req.Overrides))
+	for _, rpcOverride := range req.Overrides {
+		node, err := route.NewVertexFromBytes(rpcOverride.Node)
+		if err != nil {
+			return nil, err
+		}
+
+		if rpcOverride.HalfLifeSec > math.MaxInt64/uint64(time.Second) {
+			return nil, fmt.Errorf("half-life of %v seconds too "+
+				"large", rpcOverride.HalfLifeSec)
+		}
+
+		override := routing.MissionControlOverride{
+			Node:    node,
+			Success: rpcOverride.Success,
+			HalfLife: time.Duration(rpcOverride.HalfLifeSec) *
+				time.Second,
+		}
+
+		if len(rpcOverride.ToNode) != 0 {
+			toNode, err := route.NewVertexFromBytes(
+				rpcOverride.ToNode,
+			)
+			if err != nil {
+				return nil, err
+			}
+			override.ToNode = &toNode
+		}
+
+		overrides = append(overrides, override)
+	}
+
+	for _, override := range overrides {
+		s.cfg.RouterBackend.MissionControl.SetOverride(override)
+	}
+
+	return &SetMissionControlOverridesResponse{}, nil
+}
+
+// ClearMissionControlOverrides removes the override of a node or node pair, or
+// all overrides.
+func (s *Server) ClearMissionControlOverrides(ctx context.Context,
+	req *ClearMissionControlOverridesRequest) (
+	*ClearMissionControlOverridesResponse, error) {
+
+	mc := s.cfg.RouterBackend.MissionControl
+
+	if len(req.Node) == 0 {
+		if len(req.ToNode) != 0 {
+			return nil, errors.New("node required with to_node")
+		}
+
+		mc.ClearOverrides()
+
+		return &ClearMissionControlOverridesResponse{}, nil
+	}
+
+	node, err := route.NewVertexFromBytes(req.Node)
+	if err != nil {
+		return nil, err
+	}
+
+	var toNode *route.Vertex
+	if len(req.ToNode) != 0 {
+		vertex, err := route.NewVertexFromBytes(req.ToNode)
+		if err != nil {
+			return nil, err
+		}
+		toNode = &vertex
+	}
+
+	if !mc.ClearOverride(node, toNode) {
+		return nil, errors.New("no such mission control override")
+	}
+
+	return &ClearMissionControlOverridesResponse{}, nil
+}
+
 // toRPCPairData marshalls mission control pair data to the rpc struct.
 func toRPCPairData(data *routing.TimedPairResult) *PairData {
 	rpcData := PairData{
@@ -564,9 +750,14 @@ func (s *Server) BuildRoute(ctx context.Context,
 		outgoingChan = &req.OutgoingChanId
 	}
 
+	constraints, err := unmarshallBuildRouteConstraints(req)
+	if err != nil {
+		return nil, err
+	}
+
 	// Build the route and return it to the caller.
 	route, err := s.cfg.Router.BuildRoute(
-		amt, hops, outgoingChan, req.FinalCltvDelta,
+		amt, hops, outgoingChan, req.FinalCltvDelta, constraints,
 	)
 	if err != nil {
 		return nil, err
@@ -584,11 +775,65 @@ func (s *Server) BuildRoute(ctx context.Context,
 	return routeResp, nil
 }
 
+// unmarshallBuildRouteConstraints converts the optional constraints of a
+// BuildRoute request. Nil is returned if the request has no constraints.
+func unmarshallBuildRouteConstraints(
+	req *BuildRouteRequest) (*routing.BuildRouteConstraints, error) {
+
+	if len(req.HopConstraints) == 0 && len(req.AvoidNodes) == 0 &&
+		len(req.AvoidChanIds) == 0 {
+
+		return nil, nil
+	}
+
+	constraints := &routing.BuildRouteConstraints{
+		AvoidNodes:    make(map[route.Vertex]struct{}),
+		AvoidChannels: make(map[uint64]struct{}),
+	}
+
+	for _, hop := range req.HopConstraints {
+		if hop.CltvDelta > math.MaxUint16 {
+			return nil, fmt.Errorf("cltv delta %v too large",
+				hop.CltvDelta)
+		}
+
+		var records record.CustomSet
+		if len(hop.CustomRecords) != 0 {
+			records = record.CustomSet(hop.CustomRecords)
+		}
+
+		constraints.Hops = append(
+			constraints.Hops, routing.HopConstraints{
+				ChannelID:     hop.ChanId,
+				CltvDelta:     uint16(hop.CltvDelta),
+				CustomRecords: records,
+			},
+		)
+	}
+
+	for _, node := range req.AvoidNodes {
+		vertex, err := route.NewVertexFromBytes(node)
+		if err != nil {
+			return nil, err
+		}
+		constraints.AvoidNodes[vertex] = struct{}{}
+	}
+
+	for _, chanID := range req.AvoidChanIds {
+		constraints.AvoidChannels[chanID] = struct{}{}
+	}
+
+	return constraints, nil
+}
+
 // SubscribeHtlcEvents creates a uni-directional stream from the server to
-// the client which delivers a stream of htlc events.
+// the client which delivers a stream of htlc events, optionally filtered by
+// channel and event type.
 func (s *Server) SubscribeHtlcEvents(req *SubscribeHtlcEventsRequest,
 	stream Router_SubscribeHtlcEventsServer) error {
 
+	filter := newHtlcEventFilter(req)
+
 	htlcClient, err := s.cfg.RouterBackend.SubscribeHtlcEvents()
 	if err != nil {
 		return err
@@ -603,6 +848,10 @@ func (s *Server) SubscribeHtlcEvents(req *SubscribeHtlcEventsRequest,
 				return err
 			}
 
+			if !filter.matches(rpcEvent) {
+				continue
+			}
+
 			if err := stream.Send(rpcEvent); err != nil {
 				return err
 			}
@@ -641,3 +890,55 @@ func (s *Server) HtlcInterceptor(stream Router_HtlcInterceptorServer) error {
 	// run the forward interceptor.
 	return newForwardInterceptor(s, stream).run()
 }
+
+// PaymentInterceptor is a bidirectional stream in which every outgoing payment
+// is sent to the caller, who decides whether it may proceed before anything is
+// sent to the network. Only one payment interceptor can be connected at a
+// time.
+func (s *Server) PaymentInterceptor(
+	stream Router_PaymentInterceptorServer) error {
+
+	interceptor := newPaymentInterceptor(s, stream)
+
+	s.paymentInterceptorMtx.Lock()
+	if s.paymentInterceptor != nil {
+		s.paymentInterceptorMtx.Unlock()
+		return ErrInterceptorAlreadyExists
+	}
+	s.paymentInterceptor = interceptor
+	s.paymentInterceptorMtx.Unlock()
+
+	log.Infof("Payment interceptor connected")
+
+	defer func() {
+		s.paymentInterceptorMtx.Lock()
+		s.paymentInterceptor = nil
+		s.paymentInterceptorMtx.Unlock()
+
+		log.Infof("Payment interceptor disconnected")
+	}()
+
+	return interceptor.run()
+}
+
+// interceptPayment hands an outgoing payment to the connected payment
+// interceptor. If none is connected, the payment proceeds unchanged unless a
+// payment interceptor is required.
+func (s *Server) interceptPayment(
+	payment *routing.InterceptedPayment) (*routing.PaymentModification,
+	error) {
+
+	s.paymentInterceptorMtx.Lock()
+	interceptor := s.paymentInterceptor
+	s.paymentInterceptorMtx.Unlock()
+
+	if interceptor == nil {
+		if s.cfg.RequirePaymentInterceptor {
+			return nil, ErrNoPaymentInterceptor
+		}
+
+		return nil, nil
+	}
+
+	return interceptor.intercept(payment)
+}
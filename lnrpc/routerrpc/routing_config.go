@@ -43,4 +43,13 @@ type RoutingConfig struct {
 	// MaxMcHistory defines the maximum number of payment results that
 	// are held on disk by mission control.
 	MaxMcHistory int `long:"maxmchistory" description:"the maximum number of payment results that are held on disk by mission control"`
+
+	// MaxPeerExposure is the maximum total amount of outgoing payments
+	// that may be in flight through a single first hop peer at the same
+	// time. A value of zero disables the limit.
+	MaxPeerExposure btcutil.Amount `long:"maxpeerexposure" description:"The maximum total amount in sats of outgoing payments that may be in flight through a single first hop peer at the same time. Set to zero to disable the limit."`
+
+	// RequirePaymentInterceptor rejects all outgoing payments while no
+	// payment interceptor is connected.
+	RequirePaymentInterceptor bool `long:"requirepaymentinterceptor" description:"Reject all outgoing payments while no payment interceptor is connected, so payments can't bypass the policy it enforces."`
 }
@@ -69,6 +69,19 @@ func rpcHtlcEvent(htlcEvent interface{}) (*HtlcEvent, error) {
 		eventType = e.HtlcEventType
 		timestamp = e.Timestamp
 
+	case *htlcswitch.HoldAlarmEvent:
+		event = &HtlcEvent_HoldAlarmEvent{
+			HoldAlarmEvent: &HoldAlarmEvent{
+				Info:            rpcInfo(e.HtlcInfo),
+				HoldTimeSeconds: uint64(e.HoldTime.Seconds()),
+				Canceled:        e.Canceled,
+			},
+		}
+
+		key = e.HtlcKey
+		eventType = e.HtlcEventType
+		timestamp = e.Timestamp
+
 	default:
 		return nil, fmt.Errorf("unknown event type: %T", e)
 	}
@@ -100,6 +113,50 @@ func rpcHtlcEvent(htlcEvent interface{}) (*HtlcEvent, error) {
 	return rpcEvent, nil
 }
 
+// htlcEventFilter restricts the htlc events delivered to a subscriber to those
+// of a set of channels and event types. An empty set matches all events.
+type htlcEventFilter struct {
+	chanIDs    map[uint64]struct{}
+	eventTypes map[HtlcEvent_EventType]struct{}
+}
+
+// newHtlcEventFilter creates a htlc event filter from a subscription request.
+func newHtlcEventFilter(req *SubscribeHtlcEventsRequest) *htlcEventFilter {
+	filter := &htlcEventFilter{
+		chanIDs:    make(map[uint64]struct{}),
+		eventTypes: make(map[HtlcEvent_EventType]struct{}),
+	}
+
+	for _, chanID := range req.ChanIds {
+		filter.chanIDs[chanID] = struct{}{}
+	}
+	for _, eventType := range req.EventTypes {
+		filter.eventTypes[eventType] = struct{}{}
+	}
+
+	return filter
+}
+
+// matches returns true if the event passes the filter. An event matches the
+// channel filter if either its incoming or its outgoing channel is part of
+// it.
+func (f *htlcEventFilter) matches(event *HtlcEvent) bool {
+	if len(f.eventTypes) > 0 {
+		if _, ok := f.eventTypes[event.EventType]; !ok {
+			return false
+		}
+	}
+
+	if len(f.chanIDs) == 0 {
+		return true
+	}
+
+	_, incoming := f.chanIDs[event.IncomingChannelId]
+	_, outgoing := f.chanIDs[event.OutgoingChannelId]
+
+	return incoming || outgoing
+}
+
 // rpcInfo returns a rpc struct containing the htlc information from the
 // switch's htlc info struct.
 func rpcInfo(info htlcswitch.HtlcInfo) *HtlcInfo {
@@ -194,6 +251,12 @@ func rpcFailureResolution(invoiceFailure invoices.FailResolutionResult) (
 	case invoices.ResultMppInProgress:
 		return FailureDetail_MPP_IN_PROGRESS, nil
 
+	case invoices.ResultHeldHtlcLimit:
+		return FailureDetail_HELD_HTLC_LIMIT, nil
+
+	case invoices.ResultHtlcHeldTooLong:
+		return FailureDetail_HTLC_HELD_TOO_LONG, nil
+
 	default:
 		return 0, fmt.Errorf("unknown fail resolution: %v",
 			invoiceFailure.FailureString())
@@ -238,6 +301,15 @@ func rpcOutgoingFailure(failureDetail htlcswitch.OutgoingFailure) (
 	case htlcswitch.OutgoingFailureForwardsDisabled:
 		return FailureDetail_FORWARDS_DISABLED, nil
 
+	case htlcswitch.OutgoingFailureNodeDraining:
+		return FailureDetail_NODE_DRAINING, nil
+
+	case htlcswitch.OutgoingFailureDustExposure:
+		return FailureDetail_DUST_EXPOSURE, nil
+
+	case htlcswitch.OutgoingFailureReservedSlots:
+		return FailureDetail_RESERVED_HTLC_SLOTS, nil
+
 	default:
 		return 0, fmt.Errorf("unknown outgoing failure "+
 			"detail: %v", failureDetail.FailureString())
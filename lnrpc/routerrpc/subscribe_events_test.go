@@ -0,0 +1,79 @@
+package routerrpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestHtlcEventFilter tests that htlc events are filtered by their channels
+// and event type.
+func TestHtlcEventFilter(t *testing.T) {
+	t.Parallel()
+
+	forward := &HtlcEvent{
+		IncomingChannelId: 1,
+		OutgoingChannelId: 2,
+		EventType:         HtlcEvent_FORWARD,
+	}
+	send := &HtlcEvent{
+		OutgoingChannelId: 3,
+		EventType:         HtlcEvent_SEND,
+	}
+
+	tests := []struct {
+		name     string
+		req      *SubscribeHtlcEventsRequest
+		expected []bool
+	}{
+		{
+			name:     "no filter",
+			req:      &SubscribeHtlcEventsRequest{},
+			expected: []bool{true, true},
+		},
+		{
+			name: "incoming channel",
+			req: &SubscribeHtlcEventsRequest{
+				ChanIds: []uint64{1},
+			},
+			expected: []bool{true, false},
+		},
+		{
+			name: "outgoing channels",
+			req: &SubscribeHtlcEventsRequest{
+				ChanIds: []uint64{2, 3},
+			},
+			expected: []bool{true, true},
+		},
+		{
+			name: "event type",
+			req: &SubscribeHtlcEventsRequest{
+				EventTypes: []HtlcEvent_EventType{
+					HtlcEvent_SEND,
+				},
+			},
+			expected: []bool{false, true},
+		},
+		{
+			name: "channel and event type",
+			req: &SubscribeHtlcEventsRequest{
+				ChanIds: []uint64{1},
+				EventTypes: []HtlcEvent_EventType{
+					HtlcEvent_SEND,
+				},
+			},
+			expected: []bool{false, false},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			filter := newHtlcEventFilter(test.req)
+
+			require.Equal(t, test.expected[0], filter.matches(forward))
+			require.Equal(t, test.expected[1], filter.matches(send))
+		})
+	}
+}
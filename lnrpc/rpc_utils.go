@@ -38,6 +38,7 @@ func RPCTransactionDetails(txns []*lnwallet.TransactionDetail) *TransactionDetai
 			DestAddresses:    destAddresses,
 			RawTxHex:         hex.EncodeToString(tx.RawTx),
 			Label:            tx.Label,
+			WatchOnly:        tx.WatchOnly,
 		}
 	}
 
@@ -0,0 +1,465 @@
+package signrpc
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+
+	btcecv2 "github.com/cryptomeow/lnd/internal/btcec"
+	"github.com/cryptomeow/lnd/internal/schnorr"
+	"github.com/cryptomeow/lnd/internal/schnorr/musig2"
+	"github.com/cryptomeow/lnd/keychain"
+)
+
+// MuSig2SessionID is the unique identifier of a MuSig2 signing session. It is
+// the SHA256 hash of the local public nonce of the session.
+type MuSig2SessionID [sha256.Size]byte
+
+// muSig2Tweaks is the set of tweaks that are applied to the combined key of a
+// MuSig2 signing session.
+type muSig2Tweaks struct {
+	// genericTweaks is a list of plain or x-only tweaks that are applied
+	// to the combined key in order.
+	genericTweaks []musig2.KeyTweakDesc
+
+	// taprootTweak indicates that the combined key is used as the
+	// internal key of a taproot output, and that the final key is tweaked
+	// with the taproot script root below.
+	taprootTweak bool
+
+	// taprootScriptRoot is the script root the combined key is tweaked
+	// with if taprootTweak is set. If it is empty, the BIP-0086 tweak of
+	// a key spend only output is applied instead.
+	taprootScriptRoot []byte
+}
+
+// keyAggOptions returns the key aggregation options of the tweaks.
+func (t *muSig2Tweaks) keyAggOptions() []musig2.KeyAggOption {
+	var opts []musig2.KeyAggOption
+	switch {
+	case t.taprootTweak && len(t.taprootScriptRoot) == 0:
+		opts = append(opts, musig2.WithBIP86KeyTweak())
+
+	case t.taprootTweak:
+		opts = append(
+			opts, musig2.WithTaprootKeyTweak(t.taprootScriptRoot),
+		)
+
+	case len(t.genericTweaks) > 0:
+		opts = append(opts, musig2.WithKeyTweaks(t.genericTweaks...))
+	}
+
+	return opts
+}
+
+// contextOptions returns the signing context options of the tweaks.
+func (t *muSig2Tweaks) contextOptions() []musig2.ContextOption {
+	var opts []musig2.ContextOption
+	switch {
+	case t.taprootTweak && len(t.taprootScriptRoot) == 0:
+		opts = append(opts, musig2.WithBip86TweakCtx())
+
+	case t.taprootTweak:
+		opts = append(
+			opts, musig2.WithTaprootTweakCtx(t.taprootScriptRoot),
+		)
+
+	case len(t.genericTweaks) > 0:
+		opts = append(
+			opts, musig2.WithTweakedContext(t.genericTweaks...),
+		)
+	}
+
+	return opts
+}
+
+// muSig2CombineKeys combines the public keys of all signers into a single
+// MuSig2 key and applies the given tweaks to it. The keys are sorted before
+// they are combined, so all signers arrive at the same key regardless of the
+// order they know them in.
+func muSig2CombineKeys(keys []*btcecv2.PublicKey,
+	tweaks *muSig2Tweaks) (*musig2.AggregateKey, error) {
+
+	if len(keys) < 2 {
+		return nil, fmt.Errorf("need at least two signers, got %d",
+			len(keys))
+	}
+	if tweaks.taprootTweak && len(tweaks.genericTweaks) > 0 {
+		return nil, fmt.Errorf("taproot and generic tweaks cannot be " +
+			"combined")
+	}
+
+	combinedKey, _, _, err := musig2.AggregateKeys(
+		keys, true, tweaks.keyAggOptions()...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to combine keys: %v", err)
+	}
+
+	return combinedKey, nil
+}
+
+// muSig2SessionInfo is the public information about a MuSig2 signing session.
+type muSig2SessionInfo struct {
+	// sessionID is the unique identifier of the session.
+	sessionID MuSig2SessionID
+
+	// publicNonce is our public nonce that has to be handed to all other
+	// signers.
+	publicNonce [musig2.PubNonceSize]byte
+
+	// combinedKey is the final, possibly tweaked, combined key of all
+	// signers.
+	combinedKey *btcecv2.PublicKey
+
+	// taprootInternalKey is the combined key before the taproot tweak
+	// was applied. It is only set if a taproot tweak is used.
+	taprootInternalKey *btcecv2.PublicKey
+
+	// haveAllNonces indicates whether the public nonces of all signers
+	// are known, which is required before we can sign.
+	haveAllNonces bool
+}
+
+// muSig2Session is a MuSig2 signing session along with its public
+// information.
+type muSig2Session struct {
+	muSig2SessionInfo
+
+	// session is the underlying MuSig2 signing session.
+	session *musig2.Session
+
+	// signed indicates whether we already created our partial signature.
+	signed bool
+}
+
+// muSig2Manager keeps track of the MuSig2 signing sessions created through
+// the signer RPC. The secret nonces of a session never leave the manager,
+// which ensures that they are never used to sign more than one message.
+type muSig2Manager struct {
+	keyRing keychain.SecretKeyRing
+
+	sessions map[MuSig2SessionID]*muSig2Session
+	mtx      sync.Mutex
+}
+
+// newMuSig2Manager creates a new MuSig2 session manager that signs with the
+// keys of the given key ring.
+func newMuSig2Manager(keyRing keychain.SecretKeyRing) *muSig2Manager {
+	return &muSig2Manager{
+		keyRing:  keyRing,
+		sessions: make(map[MuSig2SessionID]*muSig2Session),
+	}
+}
+
+// createSession creates a new signing session for the key at the given
+// locator, combined with the public keys of all signers. The public keys of
+// all signers, including our own, must be passed. The public nonces of the
+// other signers may already be passed if they are known.
+func (m *muSig2Manager) createSession(keyLoc keychain.KeyLocator,
+	signers []*btcecv2.PublicKey, tweaks *muSig2Tweaks,
+	otherNonces [][musig2.PubNonceSize]byte) (*muSig2SessionInfo, error) {
+
+	combinedKey, err := muSig2CombineKeys(signers, tweaks)
+	if err != nil {
+		return nil, err
+	}
+
+	// Our own key is part of the signers, so we can learn at most one
+	// nonce less than there are signers.
+	if len(otherNonces) > len(signers)-1 {
+		return nil, fmt.Errorf("got %d nonces for %d other signers",
+			len(otherNonces), len(signers)-1)
+	}
+
+	privKey, err := m.keyRing.DerivePrivKey(keychain.KeyDescriptor{
+		KeyLocator: keyLoc,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to derive signing key: %v", err)
+	}
+	signingKey, _ := btcecv2.PrivKeyFromBytes(privKey.Serialize())
+
+	ctxOpts := append(
+		[]musig2.ContextOption{musig2.WithKnownSigners(signers)},
+		tweaks.contextOptions()...,
+	)
+	muSigCtx, err := musig2.NewContext(signingKey, true, ctxOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create signing context: %v",
+			err)
+	}
+
+	session, err := muSigCtx.NewSession()
+	if err != nil {
+		return nil, fmt.Errorf("unable to create session: %v", err)
+	}
+
+	var haveAllNonces bool
+	for _, nonce := range otherNonces {
+		haveAllNonces, err = session.RegisterPubNonce(nonce)
+		if err != nil {
+			return nil, fmt.Errorf("unable to register nonce: %v",
+				err)
+		}
+	}
+
+	publicNonce := session.PublicNonce()
+	s := &muSig2Session{
+		muSig2SessionInfo: muSig2SessionInfo{
+			sessionID:     sha256.Sum256(publicNonce[:]),
+			publicNonce:   publicNonce,
+			combinedKey:   combinedKey.FinalKey,
+			haveAllNonces: haveAllNonces,
+		},
+		session: session,
+	}
+	if tweaks.taprootTweak {
+		s.taprootInternalKey = combinedKey.PreTweakedKey
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	// The nonces are generated randomly, so a collision of session IDs
+	// indicates a severe problem with the source of randomness.
+	if _, ok := m.sessions[s.sessionID]; ok {
+		return nil, fmt.Errorf("session %x already exists",
+			s.sessionID)
+	}
+	m.sessions[s.sessionID] = s
+
+	info := s.muSig2SessionInfo
+	return &info, nil
+}
+
+// registerNonces registers the public nonces of other signers with the
+// session. It returns true once the nonces of all signers are known.
+func (m *muSig2Manager) registerNonces(sessionID MuSig2SessionID,
+	nonces [][musig2.PubNonceSize]byte) (bool, error) {
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	s, ok := m.sessions[sessionID]
+	if !ok {
+		return false, fmt.Errorf("session %x not found", sessionID)
+	}
+
+	if s.haveAllNonces {
+		return false, fmt.Errorf("already have all nonces")
+	}
+
+	for _, nonce := range nonces {
+		haveAllNonces, err := s.session.RegisterPubNonce(nonce)
+		if err != nil {
+			return false, fmt.Errorf("unable to register nonce: %v",
+				err)
+		}
+		s.haveAllNonces = haveAllNonces
+	}
+
+	return s.haveAllNonces, nil
+}
+
+// sign creates our partial signature of the message. As the secret nonce of
+// the session is discarded afterwards, this can only be done once per
+// session. If cleanup is set, the session is removed afterwards, which is
+// useful if we aren't the one combining the signatures.
+func (m *muSig2Manager) sign(sessionID MuSig2SessionID, msg [32]byte,
+	cleanup bool) (*musig2.PartialSignature, error) {
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	s, ok := m.sessions[sessionID]
+	if !ok {
+		return nil, fmt.Errorf("session %x not found", sessionID)
+	}
+
+	if !s.haveAllNonces {
+		return nil, fmt.Errorf("nonces of all signers must be " +
+			"registered before signing")
+	}
+	if s.signed {
+		return nil, fmt.Errorf("session already signed")
+	}
+
+	partialSig, err := s.session.Sign(msg, musig2.WithSortedKeys())
+	if err != nil {
+		return nil, fmt.Errorf("unable to sign: %v", err)
+	}
+	s.signed = true
+
+	if cleanup {
+		delete(m.sessions, sessionID)
+	}
+
+	return partialSig, nil
+}
+
+// combineSig adds the partial signatures of other signers to the session.
+// Once the partial signatures of all signers are known, they are combined
+// into the final signature, which is returned along with true, and the
+// session is removed.
+func (m *muSig2Manager) combineSig(sessionID MuSig2SessionID,
+	partialSigs []*musig2.PartialSignature) (*schnorr.Signature, bool,
+	error) {
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	s, ok := m.sessions[sessionID]
+	if !ok {
+		return nil, false, fmt.Errorf("session %x not found", sessionID)
+	}
+
+	// Our own partial signature is needed to combine the signatures.
+	if !s.signed {
+		return nil, false, fmt.Errorf("session must be signed before " +
+			"combining signatures")
+	}
+
+	var haveAllSigs bool
+	for _, partialSig := range partialSigs {
+		var err error
+		haveAllSigs, err = s.session.CombineSig(partialSig)
+		if err != nil {
+			return nil, false, fmt.Errorf("unable to combine "+
+				"signatures: %v", err)
+		}
+	}
+
+	if !haveAllSigs {
+		return nil, false, nil
+	}
+
+	delete(m.sessions, sessionID)
+
+	return s.session.FinalSig(), true, nil
+}
+
+// cleanup removes the session along with its secret nonce.
+func (m *muSig2Manager) cleanup(sessionID MuSig2SessionID) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if _, ok := m.sessions[sessionID]; !ok {
+		return fmt.Errorf("session %x not found", sessionID)
+	}
+
+	delete(m.sessions, sessionID)
+
+	return nil
+}
+
+// parseMuSig2SessionID parses the session ID of an RPC request.
+func parseMuSig2SessionID(rawID []byte) (MuSig2SessionID, error) {
+	var sessionID MuSig2SessionID
+	if len(rawID) != len(sessionID) {
+		return sessionID, fmt.Errorf("session ID must be %d bytes, "+
+			"got %d", len(sessionID), len(rawID))
+	}
+	copy(sessionID[:], rawID)
+
+	return sessionID, nil
+}
+
+// parseMuSig2PubKeys parses the compressed public keys of the signers of an
+// RPC request.
+func parseMuSig2PubKeys(rawKeys [][]byte) ([]*btcecv2.PublicKey, error) {
+	keys := make([]*btcecv2.PublicKey, 0, len(rawKeys))
+	for _, rawKey := range rawKeys {
+		if len(rawKey) != btcecv2.PubKeyBytesLenCompressed {
+			return nil, fmt.Errorf("signer public keys must be %d "+
+				"bytes, got %d",
+				btcecv2.PubKeyBytesLenCompressed, len(rawKey))
+		}
+
+		key, err := btcecv2.ParsePubKey(rawKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signer public key: %v",
+				err)
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// parseMuSig2Nonces parses the public nonces of other signers of an RPC
+// request.
+func parseMuSig2Nonces(rawNonces [][]byte) ([][musig2.PubNonceSize]byte,
+	error) {
+
+	nonces := make([][musig2.PubNonceSize]byte, len(rawNonces))
+	for i, rawNonce := range rawNonces {
+		if len(rawNonce) != musig2.PubNonceSize {
+			return nil, fmt.Errorf("public nonces must be %d "+
+				"bytes, got %d", musig2.PubNonceSize,
+				len(rawNonce))
+		}
+		copy(nonces[i][:], rawNonce)
+	}
+
+	return nonces, nil
+}
+
+// parseMuSig2PartialSigs parses the partial signatures of other signers of an
+// RPC request.
+func parseMuSig2PartialSigs(rawSigs [][]byte) ([]*musig2.PartialSignature,
+	error) {
+
+	partialSigs := make([]*musig2.PartialSignature, len(rawSigs))
+	for i, rawSig := range rawSigs {
+		if len(rawSig) != 32 {
+			return nil, fmt.Errorf("partial signatures must be 32 "+
+				"bytes, got %d", len(rawSig))
+		}
+
+		partialSigs[i] = &musig2.PartialSignature{}
+		err := partialSigs[i].Decode(bytes.NewReader(rawSig))
+		if err != nil {
+			return nil, fmt.Errorf("invalid partial signature: %v",
+				err)
+		}
+	}
+
+	return partialSigs, nil
+}
+
+// parseMuSig2Tweaks parses the tweaks of the combined key of an RPC request.
+func parseMuSig2Tweaks(rpcTweaks []*TweakDesc,
+	taprootTweak *TaprootTweakDesc) (*muSig2Tweaks, error) {
+
+	tweaks := &muSig2Tweaks{}
+	for _, rpcTweak := range rpcTweaks {
+		if len(rpcTweak.Tweak) != 32 {
+			return nil, fmt.Errorf("tweaks must be 32 bytes, "+
+				"got %d", len(rpcTweak.Tweak))
+		}
+
+		tweak := musig2.KeyTweakDesc{IsXOnly: rpcTweak.IsXOnly}
+		copy(tweak.Tweak[:], rpcTweak.Tweak)
+		tweaks.genericTweaks = append(tweaks.genericTweaks, tweak)
+	}
+
+	if taprootTweak == nil {
+		return tweaks, nil
+	}
+
+	tweaks.taprootTweak = true
+	switch {
+	case taprootTweak.KeySpendOnly && len(taprootTweak.ScriptRoot) != 0:
+		return nil, fmt.Errorf("script root must be empty for key " +
+			"spend only outputs")
+
+	case !taprootTweak.KeySpendOnly && len(taprootTweak.ScriptRoot) != 32:
+		return nil, fmt.Errorf("script root must be 32 bytes, got %d",
+			len(taprootTweak.ScriptRoot))
+	}
+	tweaks.taprootScriptRoot = taprootTweak.ScriptRoot
+
+	return tweaks, nil
+}
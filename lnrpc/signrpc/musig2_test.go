@@ -0,0 +1,147 @@
+package signrpc
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	btcecv2 "github.com/cryptomeow/lnd/internal/btcec"
+	"github.com/cryptomeow/lnd/internal/schnorr/musig2"
+	"github.com/cryptomeow/lnd/keychain"
+	"github.com/cryptomeow/lnd/lntest/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestMuSig2Manager creates a session manager backed by a key ring with a
+// random key, and returns it along with the public key it signs with.
+func newTestMuSig2Manager(t *testing.T) (*muSig2Manager,
+	*btcecv2.PublicKey) {
+
+	privKey, err := btcec.NewPrivateKey(btcec.S256())
+	require.NoError(t, err)
+
+	pubKey, err := btcecv2.ParsePubKey(
+		privKey.PubKey().SerializeCompressed(),
+	)
+	require.NoError(t, err)
+
+	keyRing := &mock.SecretKeyRing{RootKey: privKey}
+
+	return newMuSig2Manager(keyRing), pubKey
+}
+
+// TestMuSig2Session runs a full MuSig2 signing session between two signers,
+// and asserts that the combined signature is valid for the combined key.
+func TestMuSig2Session(t *testing.T) {
+	t.Parallel()
+
+	var (
+		alice, aliceKey = newTestMuSig2Manager(t)
+		bob, bobKey     = newTestMuSig2Manager(t)
+		keyLoc          = keychain.KeyLocator{Family: 1}
+		msg             = sha256.Sum256([]byte("musig2"))
+		tweaks          = &muSig2Tweaks{
+			taprootTweak:      true,
+			taprootScriptRoot: make([]byte, 32),
+		}
+	)
+
+	// Both signers pass the keys in different orders, which must not
+	// matter.
+	aliceInfo, err := alice.createSession(
+		keyLoc, []*btcecv2.PublicKey{aliceKey, bobKey}, tweaks, nil,
+	)
+	require.NoError(t, err)
+	require.False(t, aliceInfo.haveAllNonces)
+	require.NotNil(t, aliceInfo.taprootInternalKey)
+
+	// Bob already knows the nonce of Alice when creating his session.
+	bobInfo, err := bob.createSession(
+		keyLoc, []*btcecv2.PublicKey{bobKey, aliceKey}, tweaks,
+		[][musig2.PubNonceSize]byte{aliceInfo.publicNonce},
+	)
+	require.NoError(t, err)
+	require.True(t, bobInfo.haveAllNonces)
+	require.True(t, aliceInfo.combinedKey.IsEqual(bobInfo.combinedKey))
+	require.True(t, aliceInfo.taprootInternalKey.IsEqual(
+		bobInfo.taprootInternalKey,
+	))
+
+	// The combined key matches the one returned without a session.
+	combinedKey, err := muSig2CombineKeys(
+		[]*btcecv2.PublicKey{aliceKey, bobKey}, tweaks,
+	)
+	require.NoError(t, err)
+	require.True(t, combinedKey.FinalKey.IsEqual(aliceInfo.combinedKey))
+
+	// Alice can't sign before she knows the nonce of Bob.
+	_, err = alice.sign(aliceInfo.sessionID, msg, false)
+	require.Error(t, err)
+
+	haveAll, err := alice.registerNonces(
+		aliceInfo.sessionID,
+		[][musig2.PubNonceSize]byte{bobInfo.publicNonce},
+	)
+	require.NoError(t, err)
+	require.True(t, haveAll)
+
+	// Bob signs and cleans up his session right away, as Alice combines
+	// the signatures.
+	bobSig, err := bob.sign(bobInfo.sessionID, msg, true)
+	require.NoError(t, err)
+	require.Error(t, bob.cleanup(bobInfo.sessionID))
+
+	// Alice can't combine the signatures before she signed herself.
+	_, _, err = alice.combineSig(
+		aliceInfo.sessionID, []*musig2.PartialSignature{bobSig},
+	)
+	require.Error(t, err)
+
+	_, err = alice.sign(aliceInfo.sessionID, msg, false)
+	require.NoError(t, err)
+
+	// The secret nonce is gone after signing, so signing again fails.
+	_, err = alice.sign(aliceInfo.sessionID, msg, false)
+	require.Error(t, err)
+
+	finalSig, haveAll, err := alice.combineSig(
+		aliceInfo.sessionID, []*musig2.PartialSignature{bobSig},
+	)
+	require.NoError(t, err)
+	require.True(t, haveAll)
+	require.True(t, finalSig.Verify(msg[:], aliceInfo.combinedKey))
+
+	// The session is removed once the signature is complete.
+	require.Error(t, alice.cleanup(aliceInfo.sessionID))
+}
+
+// TestMuSig2CombineKeys checks the validation of the keys and tweaks that are
+// combined.
+func TestMuSig2CombineKeys(t *testing.T) {
+	t.Parallel()
+
+	_, aliceKey := newTestMuSig2Manager(t)
+	_, bobKey := newTestMuSig2Manager(t)
+	keys := []*btcecv2.PublicKey{aliceKey, bobKey}
+
+	_, err := muSig2CombineKeys(keys[:1], &muSig2Tweaks{})
+	require.Error(t, err)
+
+	_, err = muSig2CombineKeys(keys, &muSig2Tweaks{
+		genericTweaks: []musig2.KeyTweakDesc{{IsXOnly: true}},
+		taprootTweak:  true,
+	})
+	require.Error(t, err)
+
+	// Without tweaks, the combined key is the same as the key it would be
+	// tweaked from.
+	untweaked, err := muSig2CombineKeys(keys, &muSig2Tweaks{})
+	require.NoError(t, err)
+
+	bip86, err := muSig2CombineKeys(keys, &muSig2Tweaks{
+		taprootTweak: true,
+	})
+	require.NoError(t, err)
+	require.True(t, untweaked.FinalKey.IsEqual(bip86.PreTweakedKey))
+	require.False(t, untweaked.FinalKey.IsEqual(bip86.FinalKey))
+}
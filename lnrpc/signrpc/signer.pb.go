@@ -24,6 +24,38 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+type SignMethod int32
+
+const (
+	//
+	//Specifies that a SegWit v0 (p2wkh, np2wkh, p2wsh) input script should be
+	//signed with an ECDSA signature.
+	SignMethod_SIGN_METHOD_WITNESS_V0 SignMethod = 0
+	//
+	//Specifies that the tapscript leaf in the witness_script of the sign
+	//descriptor should be signed with a schnorr signature, spending a SegWit v1
+	//(p2tr) output through its script path.
+	SignMethod_SIGN_METHOD_TAPROOT_SCRIPT_SPEND SignMethod = 1
+)
+
+var SignMethod_name = map[int32]string{
+	0: "SIGN_METHOD_WITNESS_V0",
+	1: "SIGN_METHOD_TAPROOT_SCRIPT_SPEND",
+}
+
+var SignMethod_value = map[string]int32{
+	"SIGN_METHOD_WITNESS_V0":           0,
+	"SIGN_METHOD_TAPROOT_SCRIPT_SPEND": 1,
+}
+
+func (x SignMethod) String() string {
+	return proto.EnumName(SignMethod_name, int32(x))
+}
+
+func (SignMethod) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_4ecd772f6c7ffacf, []int{0}
+}
+
 type KeyLocator struct {
 	// The family of key being identified.
 	KeyFamily int32 `protobuf:"varint,1,opt,name=key_family,json=keyFamily,proto3" json:"key_family,omitempty"`
@@ -217,10 +249,15 @@ type SignDescriptor struct {
 	Sighash uint32 `protobuf:"varint,7,opt,name=sighash,proto3" json:"sighash,omitempty"`
 	//
 	//The target input within the transaction that should be signed.
-	InputIndex           int32    `protobuf:"varint,8,opt,name=input_index,json=inputIndex,proto3" json:"input_index,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	InputIndex int32 `protobuf:"varint,8,opt,name=input_index,json=inputIndex,proto3" json:"input_index,omitempty"`
+	//
+	//The sign method specifies how the input should be signed. For taproot
+	//script spends, witness_script holds the tapscript leaf that is spent, and
+	//the prev_outputs of the request must be set.
+	SignMethod           SignMethod `protobuf:"varint,9,opt,name=sign_method,json=signMethod,proto3,enum=signrpc.SignMethod" json:"sign_method,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
 }
 
 func (m *SignDescriptor) Reset()         { *m = SignDescriptor{} }
@@ -297,14 +334,26 @@ func (m *SignDescriptor) GetInputIndex() int32 {
 	return 0
 }
 
+func (m *SignDescriptor) GetSignMethod() SignMethod {
+	if m != nil {
+		return m.SignMethod
+	}
+	return SignMethod_SIGN_METHOD_WITNESS_V0
+}
+
 type SignReq struct {
 	// The raw bytes of the transaction to be signed.
 	RawTxBytes []byte `protobuf:"bytes,1,opt,name=raw_tx_bytes,json=rawTxBytes,proto3" json:"raw_tx_bytes,omitempty"`
 	// A set of sign descriptors, for each input to be signed.
-	SignDescs            []*SignDescriptor `protobuf:"bytes,2,rep,name=sign_descs,json=signDescs,proto3" json:"sign_descs,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	SignDescs []*SignDescriptor `protobuf:"bytes,2,rep,name=sign_descs,json=signDescs,proto3" json:"sign_descs,omitempty"`
+	//
+	//The full list of UTXO information for each of the inputs being spent. This
+	//is required when spending a taproot output, as the signature commits to
+	//all outputs spent by the transaction.
+	PrevOutputs          []*TxOut `protobuf:"bytes,3,rep,name=prev_outputs,json=prevOutputs,proto3" json:"prev_outputs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *SignReq) Reset()         { *m = SignReq{} }
@@ -346,10 +395,18 @@ func (m *SignReq) GetSignDescs() []*SignDescriptor {
 	return nil
 }
 
+func (m *SignReq) GetPrevOutputs() []*TxOut {
+	if m != nil {
+		return m.PrevOutputs
+	}
+	return nil
+}
+
 type SignResp struct {
 	//
 	//A set of signatures realized in a fixed 64-byte format ordered in ascending
-	//input order.
+	//input order. Signatures of taproot script spends are 64-byte schnorr
+	//signatures, followed by the sighash type unless it is the default one.
 	RawSigs              [][]byte `protobuf:"bytes,1,rep,name=raw_sigs,json=rawSigs,proto3" json:"raw_sigs,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -774,264 +831,1201 @@ func (m *SharedKeyResponse) GetSharedKey() []byte {
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*KeyLocator)(nil), "signrpc.KeyLocator")
-	proto.RegisterType((*KeyDescriptor)(nil), "signrpc.KeyDescriptor")
-	proto.RegisterType((*TxOut)(nil), "signrpc.TxOut")
-	proto.RegisterType((*SignDescriptor)(nil), "signrpc.SignDescriptor")
-	proto.RegisterType((*SignReq)(nil), "signrpc.SignReq")
-	proto.RegisterType((*SignResp)(nil), "signrpc.SignResp")
-	proto.RegisterType((*InputScript)(nil), "signrpc.InputScript")
-	proto.RegisterType((*InputScriptResp)(nil), "signrpc.InputScriptResp")
-	proto.RegisterType((*SignMessageReq)(nil), "signrpc.SignMessageReq")
-	proto.RegisterType((*SignMessageResp)(nil), "signrpc.SignMessageResp")
-	proto.RegisterType((*VerifyMessageReq)(nil), "signrpc.VerifyMessageReq")
-	proto.RegisterType((*VerifyMessageResp)(nil), "signrpc.VerifyMessageResp")
-	proto.RegisterType((*SharedKeyRequest)(nil), "signrpc.SharedKeyRequest")
-	proto.RegisterType((*SharedKeyResponse)(nil), "signrpc.SharedKeyResponse")
+type TweakDesc struct {
+	// The 32-byte tweak that is applied to the combined key.
+	Tweak []byte `protobuf:"bytes,1,opt,name=tweak,proto3" json:"tweak,omitempty"`
+	//
+	//Whether the tweak is applied to the x-only version of the key, as done
+	//for taproot outputs, or to the full key.
+	IsXOnly              bool     `protobuf:"varint,2,opt,name=is_x_only,json=isXOnly,proto3" json:"is_x_only,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func init() { proto.RegisterFile("signrpc/signer.proto", fileDescriptor_4ecd772f6c7ffacf) }
+func (m *TweakDesc) Reset()         { *m = TweakDesc{} }
+func (m *TweakDesc) String() string { return proto.CompactTextString(m) }
+func (*TweakDesc) ProtoMessage()    {}
+func (*TweakDesc) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4ecd772f6c7ffacf, []int{14}
+}
 
-var fileDescriptor_4ecd772f6c7ffacf = []byte{
-	// 775 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x55, 0xed, 0x8e, 0xdb, 0x44,
-	0x14, 0x55, 0x12, 0x36, 0xc9, 0x5e, 0x27, 0xbb, 0xd9, 0x61, 0x55, 0xdc, 0x05, 0xc4, 0x62, 0xa9,
-	0x68, 0x2b, 0x41, 0x02, 0x01, 0x21, 0xc1, 0x2f, 0xb4, 0x54, 0xab, 0x56, 0x29, 0x6a, 0xe5, 0xac,
-	0xf8, 0xd1, 0x3f, 0x96, 0xe3, 0xdc, 0x3a, 0x23, 0x3b, 0xf6, 0xec, 0xcc, 0xb8, 0x8e, 0x9f, 0x83,
-	0x37, 0xe0, 0x99, 0x78, 0x20, 0x34, 0x1f, 0x71, 0xec, 0x14, 0x50, 0xf9, 0xb5, 0xbe, 0xc7, 0x77,
-	0xce, 0x3d, 0x7b, 0xce, 0x9d, 0x18, 0x2e, 0x05, 0x8d, 0x33, 0xce, 0xa2, 0x99, 0xfa, 0x8b, 0x7c,
-	0xca, 0x78, 0x2e, 0x73, 0x32, 0xb0, 0xa8, 0xf7, 0x1c, 0x60, 0x81, 0xd5, 0xcb, 0x3c, 0x0a, 0x65,
-	0xce, 0xc9, 0xe7, 0x00, 0x09, 0x56, 0xc1, 0xdb, 0x70, 0x4b, 0xd3, 0xca, 0xed, 0x5c, 0x77, 0x6e,
-	0x4e, 0xfc, 0xd3, 0x04, 0xab, 0x3b, 0x0d, 0x90, 0x4f, 0x41, 0x15, 0x01, 0xcd, 0xd6, 0xb8, 0x73,
-	0xbb, 0xfa, 0xed, 0x30, 0xc1, 0xea, 0x85, 0xaa, 0xbd, 0x10, 0xc6, 0x0b, 0xac, 0x9e, 0xa1, 0x88,
-	0x38, 0x65, 0x8a, 0xcc, 0x83, 0x31, 0x0f, 0xcb, 0x40, 0x9d, 0x58, 0x55, 0x12, 0x85, 0xe6, 0x1b,
-	0xf9, 0x0e, 0x0f, 0xcb, 0x05, 0x56, 0xb7, 0x0a, 0x22, 0x5f, 0xc3, 0x40, 0xbd, 0x4f, 0xf3, 0x48,
-	0xf3, 0x39, 0xf3, 0x8f, 0xa7, 0x56, 0xd9, 0xf4, 0x20, 0xcb, 0xef, 0x27, 0xfa, 0xd9, 0xfb, 0x19,
-	0x4e, 0xee, 0x77, 0xaf, 0x0a, 0x49, 0x2e, 0xe1, 0xe4, 0x5d, 0x98, 0x16, 0xa8, 0x29, 0x7b, 0xbe,
-	0x29, 0x94, 0x3c, 0x96, 0x04, 0x66, 0xbe, 0xa6, 0x1b, 0xf9, 0x43, 0x96, 0x2c, 0x75, 0xed, 0xfd,
-	0xd1, 0x85, 0xb3, 0x25, 0x8d, 0xb3, 0x86, 0xc0, 0xef, 0x40, 0xa9, 0x0f, 0xd6, 0x28, 0x22, 0x4d,
-	0xe4, 0xcc, 0x1f, 0x35, 0xa7, 0x1f, 0x3a, 0x7d, 0x25, 0x52, 0x95, 0xe4, 0x4b, 0x18, 0x09, 0x9a,
-	0xc5, 0x29, 0x06, 0xb2, 0xc4, 0x30, 0xb1, 0x53, 0x1c, 0x83, 0xdd, 0x2b, 0x48, 0xb5, 0xac, 0xf3,
-	0x62, 0x55, 0xb7, 0xf4, 0x4c, 0x8b, 0xc1, 0x4c, 0xcb, 0x13, 0x38, 0x2b, 0xa9, 0xcc, 0x50, 0x88,
-	0xbd, 0xda, 0x8f, 0x74, 0xd3, 0xd8, 0xa2, 0x46, 0x32, 0xf9, 0x0a, 0xfa, 0x79, 0x21, 0x59, 0x21,
-	0xdd, 0x13, 0xad, 0xee, 0xac, 0x56, 0xa7, 0x5d, 0xf0, 0xed, 0x5b, 0xe2, 0x82, 0x8a, 0x73, 0x13,
-	0x8a, 0x8d, 0x3b, 0xb8, 0xee, 0xdc, 0x8c, 0xfd, 0x7d, 0x49, 0xbe, 0x00, 0x87, 0x66, 0xac, 0x90,
-	0x36, 0xb2, 0xa1, 0x8e, 0x0c, 0x34, 0x64, 0x42, 0x8b, 0x60, 0xa0, 0x4c, 0xf1, 0xf1, 0x81, 0x5c,
-	0xc3, 0x48, 0xc5, 0x25, 0x77, 0xad, 0xb4, 0x80, 0x87, 0xe5, 0xfd, 0xce, 0x84, 0xf5, 0x23, 0x80,
-	0x12, 0xa0, 0x0d, 0x13, 0x6e, 0xf7, 0xba, 0x77, 0xe3, 0xcc, 0x3f, 0xa9, 0x35, 0xb5, 0xcd, 0xf5,
-	0x4f, 0x85, 0xad, 0x85, 0xf7, 0x04, 0x86, 0x66, 0x88, 0x60, 0xe4, 0x31, 0x0c, 0xd5, 0x14, 0x41,
-	0x63, 0x35, 0xa1, 0x77, 0x33, 0xf2, 0x07, 0x3c, 0x2c, 0x97, 0x34, 0x16, 0xde, 0x1d, 0x38, 0x2f,
-	0x94, 0x32, 0xfb, 0xdf, 0xbb, 0x30, 0xb0, 0x76, 0xec, 0x1b, 0x6d, 0xa9, 0xb6, 0x54, 0xd0, 0xb8,
-	0x1d, 0xb4, 0x1a, 0x67, 0x93, 0x7e, 0x09, 0xe7, 0x0d, 0x1e, 0x3d, 0xf5, 0x27, 0x18, 0x1b, 0x1f,
-	0xcc, 0x19, 0xc3, 0xe8, 0xcc, 0x2f, 0x6b, 0xf1, 0xcd, 0x03, 0x23, 0x7a, 0x28, 0x84, 0xf7, 0xda,
-	0xac, 0xcd, 0x6f, 0x28, 0x44, 0x18, 0xa3, 0x32, 0x6a, 0x02, 0xbd, 0xad, 0x88, 0xad, 0x3f, 0xea,
-	0xf1, 0x7f, 0x6e, 0xf1, 0x0c, 0xce, 0x5b, 0x8c, 0x82, 0x91, 0xcf, 0x40, 0xdb, 0x15, 0xca, 0x82,
-	0xa3, 0x25, 0x3e, 0x00, 0xde, 0x1b, 0x98, 0xfc, 0x8e, 0x9c, 0xbe, 0xad, 0xfe, 0x53, 0x44, 0x8b,
-	0xa3, 0x7b, 0xc4, 0x41, 0x1e, 0x41, 0x9f, 0x15, 0xab, 0x04, 0x2b, 0xbb, 0x8f, 0xb6, 0xf2, 0x9e,
-	0xc2, 0xc5, 0x11, 0xb7, 0x60, 0xf6, 0x7a, 0xd1, 0xb5, 0xa6, 0x1f, 0xfa, 0xa6, 0xf0, 0xfe, 0xec,
-	0xc0, 0x64, 0xb9, 0x09, 0x39, 0xae, 0x17, 0x58, 0xf9, 0xf8, 0x50, 0xa0, 0x90, 0xe4, 0x29, 0x4c,
-	0x90, 0x6d, 0x70, 0x8b, 0x3c, 0x4c, 0x03, 0x3b, 0xc1, 0x88, 0x3a, 0xaf, 0xf1, 0xd7, 0x1a, 0x26,
-	0xdf, 0x7e, 0x88, 0x4b, 0xb7, 0x5d, 0xb7, 0xb3, 0x77, 0xaa, 0x75, 0x41, 0x7b, 0x1f, 0x74, 0x41,
-	0xbd, 0x39, 0x5c, 0x34, 0x34, 0x0a, 0x96, 0x67, 0x02, 0xf5, 0xc2, 0x68, 0x30, 0x38, 0xc8, 0x3b,
-	0x15, 0xfb, 0xb6, 0xf9, 0x5f, 0x5d, 0xe8, 0x2f, 0xf5, 0xaf, 0x23, 0xf9, 0x01, 0xc6, 0xea, 0xe9,
-	0x95, 0xbe, 0x58, 0x7e, 0x58, 0x92, 0x49, 0x6b, 0xbf, 0x7d, 0x7c, 0xb8, 0xba, 0x38, 0x42, 0x04,
-	0x23, 0xbf, 0x00, 0xf9, 0x35, 0xdf, 0xb2, 0x42, 0x62, 0x73, 0x81, 0xdf, 0x3f, 0xea, 0xfe, 0xe3,
-	0xbe, 0x19, 0x06, 0xa7, 0xb1, 0x13, 0xa4, 0x7d, 0xab, 0x0e, 0xb1, 0x37, 0x18, 0x8e, 0x57, 0xe8,
-	0x0e, 0xc6, 0xad, 0x20, 0xc9, 0xe3, 0xba, 0xf5, 0x78, 0x79, 0xae, 0xae, 0xfe, 0xed, 0x95, 0x60,
-	0xe4, 0x39, 0x9c, 0x3f, 0x43, 0x4e, 0xdf, 0x61, 0x6d, 0x63, 0x83, 0xe9, 0x38, 0xfe, 0x06, 0xd3,
-	0x7b, 0xae, 0xdf, 0xce, 0xde, 0x7c, 0x13, 0x53, 0xb9, 0x29, 0x56, 0xd3, 0x28, 0xdf, 0xce, 0x52,
-	0x1a, 0x6f, 0x64, 0x46, 0xb3, 0x38, 0x43, 0x59, 0xe6, 0x3c, 0x99, 0xa5, 0xd9, 0x7a, 0x96, 0xd6,
-	0x5f, 0x26, 0xce, 0xa2, 0x55, 0x5f, 0x7f, 0x9b, 0xbe, 0xff, 0x3b, 0x00, 0x00, 0xff, 0xff, 0x7d,
-	0x28, 0x4a, 0xad, 0xb3, 0x06, 0x00, 0x00,
+func (m *TweakDesc) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TweakDesc.Unmarshal(m, b)
+}
+func (m *TweakDesc) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TweakDesc.Marshal(b, m, deterministic)
+}
+func (m *TweakDesc) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TweakDesc.Merge(m, src)
+}
+func (m *TweakDesc) XXX_Size() int {
+	return xxx_messageInfo_TweakDesc.Size(m)
+}
+func (m *TweakDesc) XXX_DiscardUnknown() {
+	xxx_messageInfo_TweakDesc.DiscardUnknown(m)
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+var xxx_messageInfo_TweakDesc proto.InternalMessageInfo
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (m *TweakDesc) GetTweak() []byte {
+	if m != nil {
+		return m.Tweak
+	}
+	return nil
+}
 
-// SignerClient is the client API for Signer service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type SignerClient interface {
-	//
-	//SignOutputRaw is a method that can be used to generated a signature for a
-	//set of inputs/outputs to a transaction. Each request specifies details
-	//concerning how the outputs should be signed, which keys they should be
-	//signed with, and also any optional tweaks. The return value is a fixed
-	//64-byte signature (the same format as we use on the wire in Lightning).
-	//
-	//If we are  unable to sign using the specified keys, then an error will be
-	//returned.
-	SignOutputRaw(ctx context.Context, in *SignReq, opts ...grpc.CallOption) (*SignResp, error)
-	//
-	//ComputeInputScript generates a complete InputIndex for the passed
-	//transaction with the signature as defined within the passed SignDescriptor.
-	//This method should be capable of generating the proper input script for
-	//both regular p2wkh output and p2wkh outputs nested within a regular p2sh
-	//output.
-	//
-	//Note that when using this method to sign inputs belonging to the wallet,
-	//the only items of the SignDescriptor that need to be populated are pkScript
-	//in the TxOut field, the value in that same field, and finally the input
-	//index.
-	ComputeInputScript(ctx context.Context, in *SignReq, opts ...grpc.CallOption) (*InputScriptResp, error)
-	//
-	//SignMessage signs a message with the key specified in the key locator. The
-	//returned signature is fixed-size LN wire format encoded.
-	//
-	//The main difference to SignMessage in the main RPC is that a specific key is
-	//used to sign the message instead of the node identity private key.
-	SignMessage(ctx context.Context, in *SignMessageReq, opts ...grpc.CallOption) (*SignMessageResp, error)
-	//
-	//VerifyMessage verifies a signature over a message using the public key
-	//provided. The signature must be fixed-size LN wire format encoded.
+func (m *TweakDesc) GetIsXOnly() bool {
+	if m != nil {
+		return m.IsXOnly
+	}
+	return false
+}
+
+type TaprootTweakDesc struct {
 	//
-	//The main difference to VerifyMessage in the main RPC is that the public key
-	//used to sign the message does not have to be a node known to the network.
-	VerifyMessage(ctx context.Context, in *VerifyMessageReq, opts ...grpc.CallOption) (*VerifyMessageResp, error)
+	//The root hash of the tapscript tree the combined key is tweaked with. It
+	//must be empty if key_spend_only is set.
+	ScriptRoot []byte `protobuf:"bytes,1,opt,name=script_root,json=scriptRoot,proto3" json:"script_root,omitempty"`
 	//
-	//DeriveSharedKey returns a shared secret key by performing Diffie-Hellman key
-	//derivation between the ephemeral public key in the request and the node's
-	//key specified in the key_desc parameter. Either a key locator or a raw
-	//public key is expected in the key_desc, if neither is supplied, defaults to
-	//the node's identity private key:
-	//P_shared = privKeyNode * ephemeralPubkey
-	//The resulting shared public key is serialized in the compressed format and
-	//hashed with sha256, resulting in the final key length of 256bit.
-	DeriveSharedKey(ctx context.Context, in *SharedKeyRequest, opts ...grpc.CallOption) (*SharedKeyResponse, error)
+	//Whether the output has no script path, in which case the BIP-0086 tweak
+	//is applied to the combined key.
+	KeySpendOnly         bool     `protobuf:"varint,2,opt,name=key_spend_only,json=keySpendOnly,proto3" json:"key_spend_only,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-type signerClient struct {
-	cc *grpc.ClientConn
+func (m *TaprootTweakDesc) Reset()         { *m = TaprootTweakDesc{} }
+func (m *TaprootTweakDesc) String() string { return proto.CompactTextString(m) }
+func (*TaprootTweakDesc) ProtoMessage()    {}
+func (*TaprootTweakDesc) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4ecd772f6c7ffacf, []int{15}
 }
 
-func NewSignerClient(cc *grpc.ClientConn) SignerClient {
-	return &signerClient{cc}
+func (m *TaprootTweakDesc) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TaprootTweakDesc.Unmarshal(m, b)
+}
+func (m *TaprootTweakDesc) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TaprootTweakDesc.Marshal(b, m, deterministic)
+}
+func (m *TaprootTweakDesc) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TaprootTweakDesc.Merge(m, src)
+}
+func (m *TaprootTweakDesc) XXX_Size() int {
+	return xxx_messageInfo_TaprootTweakDesc.Size(m)
+}
+func (m *TaprootTweakDesc) XXX_DiscardUnknown() {
+	xxx_messageInfo_TaprootTweakDesc.DiscardUnknown(m)
 }
 
-func (c *signerClient) SignOutputRaw(ctx context.Context, in *SignReq, opts ...grpc.CallOption) (*SignResp, error) {
-	out := new(SignResp)
-	err := c.cc.Invoke(ctx, "/signrpc.Signer/SignOutputRaw", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_TaprootTweakDesc proto.InternalMessageInfo
+
+func (m *TaprootTweakDesc) GetScriptRoot() []byte {
+	if m != nil {
+		return m.ScriptRoot
 	}
-	return out, nil
+	return nil
 }
 
-func (c *signerClient) ComputeInputScript(ctx context.Context, in *SignReq, opts ...grpc.CallOption) (*InputScriptResp, error) {
-	out := new(InputScriptResp)
-	err := c.cc.Invoke(ctx, "/signrpc.Signer/ComputeInputScript", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *TaprootTweakDesc) GetKeySpendOnly() bool {
+	if m != nil {
+		return m.KeySpendOnly
 	}
-	return out, nil
+	return false
 }
 
-func (c *signerClient) SignMessage(ctx context.Context, in *SignMessageReq, opts ...grpc.CallOption) (*SignMessageResp, error) {
-	out := new(SignMessageResp)
-	err := c.cc.Invoke(ctx, "/signrpc.Signer/SignMessage", in, out, opts...)
-	if err != nil {
-		return nil, err
+type MuSig2CombineKeysRequest struct {
+	//
+	//The 33-byte compressed public keys of all signing participants, including
+	//our own.
+	AllSignerPubkeys [][]byte `protobuf:"bytes,1,rep,name=all_signer_pubkeys,json=allSignerPubkeys,proto3" json:"all_signer_pubkeys,omitempty"`
+	//
+	//A series of generic tweaks that are applied to the combined key in order.
+	//They cannot be used together with a taproot tweak.
+	Tweaks []*TweakDesc `protobuf:"bytes,2,rep,name=tweaks,proto3" json:"tweaks,omitempty"`
+	//
+	//The optional taproot tweak that turns the combined key into the output key
+	//of a taproot output.
+	TaprootTweak         *TaprootTweakDesc `protobuf:"bytes,3,opt,name=taproot_tweak,json=taprootTweak,proto3" json:"taproot_tweak,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *MuSig2CombineKeysRequest) Reset()         { *m = MuSig2CombineKeysRequest{} }
+func (m *MuSig2CombineKeysRequest) String() string { return proto.CompactTextString(m) }
+func (*MuSig2CombineKeysRequest) ProtoMessage()    {}
+func (*MuSig2CombineKeysRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4ecd772f6c7ffacf, []int{16}
+}
+
+func (m *MuSig2CombineKeysRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MuSig2CombineKeysRequest.Unmarshal(m, b)
+}
+func (m *MuSig2CombineKeysRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MuSig2CombineKeysRequest.Marshal(b, m, deterministic)
+}
+func (m *MuSig2CombineKeysRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MuSig2CombineKeysRequest.Merge(m, src)
+}
+func (m *MuSig2CombineKeysRequest) XXX_Size() int {
+	return xxx_messageInfo_MuSig2CombineKeysRequest.Size(m)
+}
+func (m *MuSig2CombineKeysRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MuSig2CombineKeysRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MuSig2CombineKeysRequest proto.InternalMessageInfo
+
+func (m *MuSig2CombineKeysRequest) GetAllSignerPubkeys() [][]byte {
+	if m != nil {
+		return m.AllSignerPubkeys
 	}
-	return out, nil
+	return nil
 }
 
-func (c *signerClient) VerifyMessage(ctx context.Context, in *VerifyMessageReq, opts ...grpc.CallOption) (*VerifyMessageResp, error) {
-	out := new(VerifyMessageResp)
-	err := c.cc.Invoke(ctx, "/signrpc.Signer/VerifyMessage", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *MuSig2CombineKeysRequest) GetTweaks() []*TweakDesc {
+	if m != nil {
+		return m.Tweaks
 	}
-	return out, nil
+	return nil
 }
 
-func (c *signerClient) DeriveSharedKey(ctx context.Context, in *SharedKeyRequest, opts ...grpc.CallOption) (*SharedKeyResponse, error) {
-	out := new(SharedKeyResponse)
-	err := c.cc.Invoke(ctx, "/signrpc.Signer/DeriveSharedKey", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *MuSig2CombineKeysRequest) GetTaprootTweak() *TaprootTweakDesc {
+	if m != nil {
+		return m.TaprootTweak
 	}
-	return out, nil
+	return nil
 }
 
-// SignerServer is the server API for Signer service.
-type SignerServer interface {
-	//
-	//SignOutputRaw is a method that can be used to generated a signature for a
-	//set of inputs/outputs to a transaction. Each request specifies details
-	//concerning how the outputs should be signed, which keys they should be
-	//signed with, and also any optional tweaks. The return value is a fixed
-	//64-byte signature (the same format as we use on the wire in Lightning).
-	//
-	//If we are  unable to sign using the specified keys, then an error will be
-	//returned.
-	SignOutputRaw(context.Context, *SignReq) (*SignResp, error)
-	//
-	//ComputeInputScript generates a complete InputIndex for the passed
-	//transaction with the signature as defined within the passed SignDescriptor.
-	//This method should be capable of generating the proper input script for
-	//both regular p2wkh output and p2wkh outputs nested within a regular p2sh
-	//output.
-	//
-	//Note that when using this method to sign inputs belonging to the wallet,
-	//the only items of the SignDescriptor that need to be populated are pkScript
-	//in the TxOut field, the value in that same field, and finally the input
-	//index.
-	ComputeInputScript(context.Context, *SignReq) (*InputScriptResp, error)
-	//
-	//SignMessage signs a message with the key specified in the key locator. The
-	//returned signature is fixed-size LN wire format encoded.
-	//
-	//The main difference to SignMessage in the main RPC is that a specific key is
-	//used to sign the message instead of the node identity private key.
-	SignMessage(context.Context, *SignMessageReq) (*SignMessageResp, error)
-	//
-	//VerifyMessage verifies a signature over a message using the public key
-	//provided. The signature must be fixed-size LN wire format encoded.
+type MuSig2CombineKeysResponse struct {
 	//
-	//The main difference to VerifyMessage in the main RPC is that the public key
-	//used to sign the message does not have to be a node known to the network.
-	VerifyMessage(context.Context, *VerifyMessageReq) (*VerifyMessageResp, error)
+	//The combined public key, with all tweaks applied, in the 32-byte x-only
+	//format.
+	CombinedKey []byte `protobuf:"bytes,1,opt,name=combined_key,json=combinedKey,proto3" json:"combined_key,omitempty"`
 	//
-	//DeriveSharedKey returns a shared secret key by performing Diffie-Hellman key
-	//derivation between the ephemeral public key in the request and the node's
-	//key specified in the key_desc parameter. Either a key locator or a raw
-	//public key is expected in the key_desc, if neither is supplied, defaults to
-	//the node's identity private key:
-	//P_shared = privKeyNode * ephemeralPubkey
-	//The resulting shared public key is serialized in the compressed format and
-	//hashed with sha256, resulting in the final key length of 256bit.
-	DeriveSharedKey(context.Context, *SharedKeyRequest) (*SharedKeyResponse, error)
+	//The combined public key before the taproot tweak was applied, in the
+	//32-byte x-only format. It is only set if a taproot tweak was requested.
+	TaprootInternalKey   []byte   `protobuf:"bytes,2,opt,name=taproot_internal_key,json=taprootInternalKey,proto3" json:"taproot_internal_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-// UnimplementedSignerServer can be embedded to have forward compatible implementations.
-type UnimplementedSignerServer struct {
+func (m *MuSig2CombineKeysResponse) Reset()         { *m = MuSig2CombineKeysResponse{} }
+func (m *MuSig2CombineKeysResponse) String() string { return proto.CompactTextString(m) }
+func (*MuSig2CombineKeysResponse) ProtoMessage()    {}
+func (*MuSig2CombineKeysResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4ecd772f6c7ffacf, []int{17}
 }
 
-func (*UnimplementedSignerServer) SignOutputRaw(ctx context.Context, req *SignReq) (*SignResp, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SignOutputRaw not implemented")
+func (m *MuSig2CombineKeysResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MuSig2CombineKeysResponse.Unmarshal(m, b)
 }
-func (*UnimplementedSignerServer) ComputeInputScript(ctx context.Context, req *SignReq) (*InputScriptResp, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ComputeInputScript not implemented")
+func (m *MuSig2CombineKeysResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MuSig2CombineKeysResponse.Marshal(b, m, deterministic)
 }
-func (*UnimplementedSignerServer) SignMessage(ctx context.Context, req *SignMessageReq) (*SignMessageResp, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SignMessage not implemented")
+func (m *MuSig2CombineKeysResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MuSig2CombineKeysResponse.Merge(m, src)
 }
-func (*UnimplementedSignerServer) VerifyMessage(ctx context.Context, req *VerifyMessageReq) (*VerifyMessageResp, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method VerifyMessage not implemented")
+func (m *MuSig2CombineKeysResponse) XXX_Size() int {
+	return xxx_messageInfo_MuSig2CombineKeysResponse.Size(m)
 }
-func (*UnimplementedSignerServer) DeriveSharedKey(ctx context.Context, req *SharedKeyRequest) (*SharedKeyResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeriveSharedKey not implemented")
+func (m *MuSig2CombineKeysResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MuSig2CombineKeysResponse.DiscardUnknown(m)
 }
 
-func RegisterSignerServer(s *grpc.Server, srv SignerServer) {
-	s.RegisterService(&_Signer_serviceDesc, srv)
-}
+var xxx_messageInfo_MuSig2CombineKeysResponse proto.InternalMessageInfo
+
+func (m *MuSig2CombineKeysResponse) GetCombinedKey() []byte {
+	if m != nil {
+		return m.CombinedKey
+	}
+	return nil
+}
+
+func (m *MuSig2CombineKeysResponse) GetTaprootInternalKey() []byte {
+	if m != nil {
+		return m.TaprootInternalKey
+	}
+	return nil
+}
+
+type MuSig2SessionRequest struct {
+	// The key locator of the local key that is used for signing.
+	KeyLoc *KeyLocator `protobuf:"bytes,1,opt,name=key_loc,json=keyLoc,proto3" json:"key_loc,omitempty"`
+	//
+	//The 33-byte compressed public keys of all signing participants, including
+	//our own.
+	AllSignerPubkeys [][]byte `protobuf:"bytes,2,rep,name=all_signer_pubkeys,json=allSignerPubkeys,proto3" json:"all_signer_pubkeys,omitempty"`
+	//
+	//The 66-byte public nonces of the other signing participants, if they are
+	//already known.
+	OtherSignerPublicNonces [][]byte `protobuf:"bytes,3,rep,name=other_signer_public_nonces,json=otherSignerPublicNonces,proto3" json:"other_signer_public_nonces,omitempty"`
+	//
+	//A series of generic tweaks that are applied to the combined key in order.
+	//They cannot be used together with a taproot tweak.
+	Tweaks []*TweakDesc `protobuf:"bytes,4,rep,name=tweaks,proto3" json:"tweaks,omitempty"`
+	//
+	//The optional taproot tweak that turns the combined key into the output key
+	//of a taproot output.
+	TaprootTweak         *TaprootTweakDesc `protobuf:"bytes,5,opt,name=taproot_tweak,json=taprootTweak,proto3" json:"taproot_tweak,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *MuSig2SessionRequest) Reset()         { *m = MuSig2SessionRequest{} }
+func (m *MuSig2SessionRequest) String() string { return proto.CompactTextString(m) }
+func (*MuSig2SessionRequest) ProtoMessage()    {}
+func (*MuSig2SessionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4ecd772f6c7ffacf, []int{18}
+}
+
+func (m *MuSig2SessionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MuSig2SessionRequest.Unmarshal(m, b)
+}
+func (m *MuSig2SessionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MuSig2SessionRequest.Marshal(b, m, deterministic)
+}
+func (m *MuSig2SessionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MuSig2SessionRequest.Merge(m, src)
+}
+func (m *MuSig2SessionRequest) XXX_Size() int {
+	return xxx_messageInfo_MuSig2SessionRequest.Size(m)
+}
+func (m *MuSig2SessionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MuSig2SessionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MuSig2SessionRequest proto.InternalMessageInfo
+
+func (m *MuSig2SessionRequest) GetKeyLoc() *KeyLocator {
+	if m != nil {
+		return m.KeyLoc
+	}
+	return nil
+}
+
+func (m *MuSig2SessionRequest) GetAllSignerPubkeys() [][]byte {
+	if m != nil {
+		return m.AllSignerPubkeys
+	}
+	return nil
+}
+
+func (m *MuSig2SessionRequest) GetOtherSignerPublicNonces() [][]byte {
+	if m != nil {
+		return m.OtherSignerPublicNonces
+	}
+	return nil
+}
+
+func (m *MuSig2SessionRequest) GetTweaks() []*TweakDesc {
+	if m != nil {
+		return m.Tweaks
+	}
+	return nil
+}
+
+func (m *MuSig2SessionRequest) GetTaprootTweak() *TaprootTweakDesc {
+	if m != nil {
+		return m.TaprootTweak
+	}
+	return nil
+}
+
+type MuSig2SessionResponse struct {
+	// The unique identifier of the session.
+	SessionId []byte `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	//
+	//The combined public key, with all tweaks applied, in the 32-byte x-only
+	//format.
+	CombinedKey []byte `protobuf:"bytes,2,opt,name=combined_key,json=combinedKey,proto3" json:"combined_key,omitempty"`
+	//
+	//The combined public key before the taproot tweak was applied, in the
+	//32-byte x-only format. It is only set if a taproot tweak was requested.
+	TaprootInternalKey []byte `protobuf:"bytes,3,opt,name=taproot_internal_key,json=taprootInternalKey,proto3" json:"taproot_internal_key,omitempty"`
+	//
+	//Our 66-byte public nonce, which has to be handed to all other signing
+	//participants.
+	LocalPublicNonces []byte `protobuf:"bytes,4,opt,name=local_public_nonces,json=localPublicNonces,proto3" json:"local_public_nonces,omitempty"`
+	// Whether the public nonces of all signers are known.
+	HaveAllNonces        bool     `protobuf:"varint,5,opt,name=have_all_nonces,json=haveAllNonces,proto3" json:"have_all_nonces,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MuSig2SessionResponse) Reset()         { *m = MuSig2SessionResponse{} }
+func (m *MuSig2SessionResponse) String() string { return proto.CompactTextString(m) }
+func (*MuSig2SessionResponse) ProtoMessage()    {}
+func (*MuSig2SessionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4ecd772f6c7ffacf, []int{19}
+}
+
+func (m *MuSig2SessionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MuSig2SessionResponse.Unmarshal(m, b)
+}
+func (m *MuSig2SessionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MuSig2SessionResponse.Marshal(b, m, deterministic)
+}
+func (m *MuSig2SessionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MuSig2SessionResponse.Merge(m, src)
+}
+func (m *MuSig2SessionResponse) XXX_Size() int {
+	return xxx_messageInfo_MuSig2SessionResponse.Size(m)
+}
+func (m *MuSig2SessionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MuSig2SessionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MuSig2SessionResponse proto.InternalMessageInfo
+
+func (m *MuSig2SessionResponse) GetSessionId() []byte {
+	if m != nil {
+		return m.SessionId
+	}
+	return nil
+}
+
+func (m *MuSig2SessionResponse) GetCombinedKey() []byte {
+	if m != nil {
+		return m.CombinedKey
+	}
+	return nil
+}
+
+func (m *MuSig2SessionResponse) GetTaprootInternalKey() []byte {
+	if m != nil {
+		return m.TaprootInternalKey
+	}
+	return nil
+}
+
+func (m *MuSig2SessionResponse) GetLocalPublicNonces() []byte {
+	if m != nil {
+		return m.LocalPublicNonces
+	}
+	return nil
+}
+
+func (m *MuSig2SessionResponse) GetHaveAllNonces() bool {
+	if m != nil {
+		return m.HaveAllNonces
+	}
+	return false
+}
+
+type MuSig2RegisterNoncesRequest struct {
+	// The unique identifier of the session.
+	SessionId []byte `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// The 66-byte public nonces of other signing participants.
+	OtherSignerPublicNonces [][]byte `protobuf:"bytes,2,rep,name=other_signer_public_nonces,json=otherSignerPublicNonces,proto3" json:"other_signer_public_nonces,omitempty"`
+	XXX_NoUnkeyedLiteral    struct{} `json:"-"`
+	XXX_unrecognized        []byte   `json:"-"`
+	XXX_sizecache           int32    `json:"-"`
+}
+
+func (m *MuSig2RegisterNoncesRequest) Reset()         { *m = MuSig2RegisterNoncesRequest{} }
+func (m *MuSig2RegisterNoncesRequest) String() string { return proto.CompactTextString(m) }
+func (*MuSig2RegisterNoncesRequest) ProtoMessage()    {}
+func (*MuSig2RegisterNoncesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4ecd772f6c7ffacf, []int{20}
+}
+
+func (m *MuSig2RegisterNoncesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MuSig2RegisterNoncesRequest.Unmarshal(m, b)
+}
+func (m *MuSig2RegisterNoncesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MuSig2RegisterNoncesRequest.Marshal(b, m, deterministic)
+}
+func (m *MuSig2RegisterNoncesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MuSig2RegisterNoncesRequest.Merge(m, src)
+}
+func (m *MuSig2RegisterNoncesRequest) XXX_Size() int {
+	return xxx_messageInfo_MuSig2RegisterNoncesRequest.Size(m)
+}
+func (m *MuSig2RegisterNoncesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MuSig2RegisterNoncesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MuSig2RegisterNoncesRequest proto.InternalMessageInfo
+
+func (m *MuSig2RegisterNoncesRequest) GetSessionId() []byte {
+	if m != nil {
+		return m.SessionId
+	}
+	return nil
+}
+
+func (m *MuSig2RegisterNoncesRequest) GetOtherSignerPublicNonces() [][]byte {
+	if m != nil {
+		return m.OtherSignerPublicNonces
+	}
+	return nil
+}
+
+type MuSig2RegisterNoncesResponse struct {
+	// Whether the public nonces of all signers are known.
+	HaveAllNonces        bool     `protobuf:"varint,1,opt,name=have_all_nonces,json=haveAllNonces,proto3" json:"have_all_nonces,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MuSig2RegisterNoncesResponse) Reset()         { *m = MuSig2RegisterNoncesResponse{} }
+func (m *MuSig2RegisterNoncesResponse) String() string { return proto.CompactTextString(m) }
+func (*MuSig2RegisterNoncesResponse) ProtoMessage()    {}
+func (*MuSig2RegisterNoncesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4ecd772f6c7ffacf, []int{21}
+}
+
+func (m *MuSig2RegisterNoncesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MuSig2RegisterNoncesResponse.Unmarshal(m, b)
+}
+func (m *MuSig2RegisterNoncesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MuSig2RegisterNoncesResponse.Marshal(b, m, deterministic)
+}
+func (m *MuSig2RegisterNoncesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MuSig2RegisterNoncesResponse.Merge(m, src)
+}
+func (m *MuSig2RegisterNoncesResponse) XXX_Size() int {
+	return xxx_messageInfo_MuSig2RegisterNoncesResponse.Size(m)
+}
+func (m *MuSig2RegisterNoncesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MuSig2RegisterNoncesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MuSig2RegisterNoncesResponse proto.InternalMessageInfo
+
+func (m *MuSig2RegisterNoncesResponse) GetHaveAllNonces() bool {
+	if m != nil {
+		return m.HaveAllNonces
+	}
+	return false
+}
+
+type MuSig2SignRequest struct {
+	// The unique identifier of the session.
+	SessionId []byte `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// The 32-byte hash of the message to sign.
+	MessageDigest []byte `protobuf:"bytes,2,opt,name=message_digest,json=messageDigest,proto3" json:"message_digest,omitempty"`
+	//
+	//Whether the session should be removed after signing. This can be set if
+	//another participant combines the signatures.
+	Cleanup              bool     `protobuf:"varint,3,opt,name=cleanup,proto3" json:"cleanup,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MuSig2SignRequest) Reset()         { *m = MuSig2SignRequest{} }
+func (m *MuSig2SignRequest) String() string { return proto.CompactTextString(m) }
+func (*MuSig2SignRequest) ProtoMessage()    {}
+func (*MuSig2SignRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4ecd772f6c7ffacf, []int{22}
+}
+
+func (m *MuSig2SignRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MuSig2SignRequest.Unmarshal(m, b)
+}
+func (m *MuSig2SignRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MuSig2SignRequest.Marshal(b, m, deterministic)
+}
+func (m *MuSig2SignRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MuSig2SignRequest.Merge(m, src)
+}
+func (m *MuSig2SignRequest) XXX_Size() int {
+	return xxx_messageInfo_MuSig2SignRequest.Size(m)
+}
+func (m *MuSig2SignRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MuSig2SignRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MuSig2SignRequest proto.InternalMessageInfo
+
+func (m *MuSig2SignRequest) GetSessionId() []byte {
+	if m != nil {
+		return m.SessionId
+	}
+	return nil
+}
+
+func (m *MuSig2SignRequest) GetMessageDigest() []byte {
+	if m != nil {
+		return m.MessageDigest
+	}
+	return nil
+}
+
+func (m *MuSig2SignRequest) GetCleanup() bool {
+	if m != nil {
+		return m.Cleanup
+	}
+	return false
+}
+
+type MuSig2SignResponse struct {
+	// Our 32-byte partial signature.
+	LocalPartialSignature []byte   `protobuf:"bytes,1,opt,name=local_partial_signature,json=localPartialSignature,proto3" json:"local_partial_signature,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{} `json:"-"`
+	XXX_unrecognized      []byte   `json:"-"`
+	XXX_sizecache         int32    `json:"-"`
+}
+
+func (m *MuSig2SignResponse) Reset()         { *m = MuSig2SignResponse{} }
+func (m *MuSig2SignResponse) String() string { return proto.CompactTextString(m) }
+func (*MuSig2SignResponse) ProtoMessage()    {}
+func (*MuSig2SignResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4ecd772f6c7ffacf, []int{23}
+}
+
+func (m *MuSig2SignResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MuSig2SignResponse.Unmarshal(m, b)
+}
+func (m *MuSig2SignResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MuSig2SignResponse.Marshal(b, m, deterministic)
+}
+func (m *MuSig2SignResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MuSig2SignResponse.Merge(m, src)
+}
+func (m *MuSig2SignResponse) XXX_Size() int {
+	return xxx_messageInfo_MuSig2SignResponse.Size(m)
+}
+func (m *MuSig2SignResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MuSig2SignResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MuSig2SignResponse proto.InternalMessageInfo
+
+func (m *MuSig2SignResponse) GetLocalPartialSignature() []byte {
+	if m != nil {
+		return m.LocalPartialSignature
+	}
+	return nil
+}
+
+type MuSig2CombineSigRequest struct {
+	// The unique identifier of the session.
+	SessionId []byte `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// The 32-byte partial signatures of other signing participants.
+	OtherPartialSignatures [][]byte `protobuf:"bytes,2,rep,name=other_partial_signatures,json=otherPartialSignatures,proto3" json:"other_partial_signatures,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{} `json:"-"`
+	XXX_unrecognized       []byte   `json:"-"`
+	XXX_sizecache          int32    `json:"-"`
+}
+
+func (m *MuSig2CombineSigRequest) Reset()         { *m = MuSig2CombineSigRequest{} }
+func (m *MuSig2CombineSigRequest) String() string { return proto.CompactTextString(m) }
+func (*MuSig2CombineSigRequest) ProtoMessage()    {}
+func (*MuSig2CombineSigRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4ecd772f6c7ffacf, []int{24}
+}
+
+func (m *MuSig2CombineSigRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MuSig2CombineSigRequest.Unmarshal(m, b)
+}
+func (m *MuSig2CombineSigRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MuSig2CombineSigRequest.Marshal(b, m, deterministic)
+}
+func (m *MuSig2CombineSigRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MuSig2CombineSigRequest.Merge(m, src)
+}
+func (m *MuSig2CombineSigRequest) XXX_Size() int {
+	return xxx_messageInfo_MuSig2CombineSigRequest.Size(m)
+}
+func (m *MuSig2CombineSigRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MuSig2CombineSigRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MuSig2CombineSigRequest proto.InternalMessageInfo
+
+func (m *MuSig2CombineSigRequest) GetSessionId() []byte {
+	if m != nil {
+		return m.SessionId
+	}
+	return nil
+}
+
+func (m *MuSig2CombineSigRequest) GetOtherPartialSignatures() [][]byte {
+	if m != nil {
+		return m.OtherPartialSignatures
+	}
+	return nil
+}
+
+type MuSig2CombineSigResponse struct {
+	//
+	//Whether the partial signatures of all signers are known, and the final
+	//signature could be created.
+	HaveAllSignatures bool `protobuf:"varint,1,opt,name=have_all_signatures,json=haveAllSignatures,proto3" json:"have_all_signatures,omitempty"`
+	// The final 64-byte schnorr signature, if all signatures are known.
+	FinalSignature       []byte   `protobuf:"bytes,2,opt,name=final_signature,json=finalSignature,proto3" json:"final_signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MuSig2CombineSigResponse) Reset()         { *m = MuSig2CombineSigResponse{} }
+func (m *MuSig2CombineSigResponse) String() string { return proto.CompactTextString(m) }
+func (*MuSig2CombineSigResponse) ProtoMessage()    {}
+func (*MuSig2CombineSigResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4ecd772f6c7ffacf, []int{25}
+}
+
+func (m *MuSig2CombineSigResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MuSig2CombineSigResponse.Unmarshal(m, b)
+}
+func (m *MuSig2CombineSigResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MuSig2CombineSigResponse.Marshal(b, m, deterministic)
+}
+func (m *MuSig2CombineSigResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MuSig2CombineSigResponse.Merge(m, src)
+}
+func (m *MuSig2CombineSigResponse) XXX_Size() int {
+	return xxx_messageInfo_MuSig2CombineSigResponse.Size(m)
+}
+func (m *MuSig2CombineSigResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MuSig2CombineSigResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MuSig2CombineSigResponse proto.InternalMessageInfo
+
+func (m *MuSig2CombineSigResponse) GetHaveAllSignatures() bool {
+	if m != nil {
+		return m.HaveAllSignatures
+	}
+	return false
+}
+
+func (m *MuSig2CombineSigResponse) GetFinalSignature() []byte {
+	if m != nil {
+		return m.FinalSignature
+	}
+	return nil
+}
+
+type MuSig2CleanupRequest struct {
+	// The unique identifier of the session.
+	SessionId            []byte   `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MuSig2CleanupRequest) Reset()         { *m = MuSig2CleanupRequest{} }
+func (m *MuSig2CleanupRequest) String() string { return proto.CompactTextString(m) }
+func (*MuSig2CleanupRequest) ProtoMessage()    {}
+func (*MuSig2CleanupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4ecd772f6c7ffacf, []int{26}
+}
+
+func (m *MuSig2CleanupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MuSig2CleanupRequest.Unmarshal(m, b)
+}
+func (m *MuSig2CleanupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MuSig2CleanupRequest.Marshal(b, m, deterministic)
+}
+func (m *MuSig2CleanupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MuSig2CleanupRequest.Merge(m, src)
+}
+func (m *MuSig2CleanupRequest) XXX_Size() int {
+	return xxx_messageInfo_MuSig2CleanupRequest.Size(m)
+}
+func (m *MuSig2CleanupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MuSig2CleanupRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MuSig2CleanupRequest proto.InternalMessageInfo
+
+func (m *MuSig2CleanupRequest) GetSessionId() []byte {
+	if m != nil {
+		return m.SessionId
+	}
+	return nil
+}
+
+type MuSig2CleanupResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MuSig2CleanupResponse) Reset()         { *m = MuSig2CleanupResponse{} }
+func (m *MuSig2CleanupResponse) String() string { return proto.CompactTextString(m) }
+func (*MuSig2CleanupResponse) ProtoMessage()    {}
+func (*MuSig2CleanupResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4ecd772f6c7ffacf, []int{27}
+}
+
+func (m *MuSig2CleanupResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MuSig2CleanupResponse.Unmarshal(m, b)
+}
+func (m *MuSig2CleanupResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MuSig2CleanupResponse.Marshal(b, m, deterministic)
+}
+func (m *MuSig2CleanupResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MuSig2CleanupResponse.Merge(m, src)
+}
+func (m *MuSig2CleanupResponse) XXX_Size() int {
+	return xxx_messageInfo_MuSig2CleanupResponse.Size(m)
+}
+func (m *MuSig2CleanupResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MuSig2CleanupResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MuSig2CleanupResponse proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterEnum("signrpc.SignMethod", SignMethod_name, SignMethod_value)
+	proto.RegisterType((*KeyLocator)(nil), "signrpc.KeyLocator")
+	proto.RegisterType((*KeyDescriptor)(nil), "signrpc.KeyDescriptor")
+	proto.RegisterType((*TxOut)(nil), "signrpc.TxOut")
+	proto.RegisterType((*SignDescriptor)(nil), "signrpc.SignDescriptor")
+	proto.RegisterType((*SignReq)(nil), "signrpc.SignReq")
+	proto.RegisterType((*SignResp)(nil), "signrpc.SignResp")
+	proto.RegisterType((*InputScript)(nil), "signrpc.InputScript")
+	proto.RegisterType((*InputScriptResp)(nil), "signrpc.InputScriptResp")
+	proto.RegisterType((*SignMessageReq)(nil), "signrpc.SignMessageReq")
+	proto.RegisterType((*SignMessageResp)(nil), "signrpc.SignMessageResp")
+	proto.RegisterType((*VerifyMessageReq)(nil), "signrpc.VerifyMessageReq")
+	proto.RegisterType((*VerifyMessageResp)(nil), "signrpc.VerifyMessageResp")
+	proto.RegisterType((*SharedKeyRequest)(nil), "signrpc.SharedKeyRequest")
+	proto.RegisterType((*SharedKeyResponse)(nil), "signrpc.SharedKeyResponse")
+	proto.RegisterType((*TweakDesc)(nil), "signrpc.TweakDesc")
+	proto.RegisterType((*TaprootTweakDesc)(nil), "signrpc.TaprootTweakDesc")
+	proto.RegisterType((*MuSig2CombineKeysRequest)(nil), "signrpc.MuSig2CombineKeysRequest")
+	proto.RegisterType((*MuSig2CombineKeysResponse)(nil), "signrpc.MuSig2CombineKeysResponse")
+	proto.RegisterType((*MuSig2SessionRequest)(nil), "signrpc.MuSig2SessionRequest")
+	proto.RegisterType((*MuSig2SessionResponse)(nil), "signrpc.MuSig2SessionResponse")
+	proto.RegisterType((*MuSig2RegisterNoncesRequest)(nil), "signrpc.MuSig2RegisterNoncesRequest")
+	proto.RegisterType((*MuSig2RegisterNoncesResponse)(nil), "signrpc.MuSig2RegisterNoncesResponse")
+	proto.RegisterType((*MuSig2SignRequest)(nil), "signrpc.MuSig2SignRequest")
+	proto.RegisterType((*MuSig2SignResponse)(nil), "signrpc.MuSig2SignResponse")
+	proto.RegisterType((*MuSig2CombineSigRequest)(nil), "signrpc.MuSig2CombineSigRequest")
+	proto.RegisterType((*MuSig2CombineSigResponse)(nil), "signrpc.MuSig2CombineSigResponse")
+	proto.RegisterType((*MuSig2CleanupRequest)(nil), "signrpc.MuSig2CleanupRequest")
+	proto.RegisterType((*MuSig2CleanupResponse)(nil), "signrpc.MuSig2CleanupResponse")
+}
+
+func init() { proto.RegisterFile("signrpc/signer.proto", fileDescriptor_4ecd772f6c7ffacf) }
+
+var fileDescriptor_4ecd772f6c7ffacf = []byte{
+	// 1500 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x57, 0xdb, 0x72, 0xdb, 0x46,
+	0x12, 0x5d, 0x92, 0x96, 0x48, 0x35, 0x49, 0x89, 0x1a, 0xc9, 0x12, 0x0c, 0xdf, 0x28, 0x94, 0x65,
+	0xcb, 0x2e, 0x97, 0x64, 0x73, 0xbd, 0xae, 0xdd, 0x4d, 0x25, 0x15, 0x5b, 0x97, 0x58, 0x25, 0xeb,
+	0x52, 0x00, 0xcb, 0x4e, 0xfc, 0x82, 0x82, 0xc0, 0x31, 0x39, 0x45, 0x10, 0x80, 0x31, 0xa0, 0x28,
+	0xfc, 0x48, 0x5e, 0xf2, 0x96, 0x6f, 0xc8, 0x4f, 0xe4, 0x1b, 0xf2, 0x98, 0x1f, 0x49, 0xcd, 0x05,
+	0x57, 0x91, 0xb2, 0xfc, 0x44, 0x4c, 0x77, 0x4f, 0xf7, 0xe9, 0xd3, 0xd3, 0x3d, 0x43, 0x58, 0xa5,
+	0xa4, 0xef, 0x06, 0xbe, 0xbd, 0xc3, 0x7e, 0x71, 0xb0, 0xed, 0x07, 0x5e, 0xe8, 0xa1, 0xaa, 0x94,
+	0x6a, 0xef, 0x00, 0x8e, 0x70, 0xf4, 0xde, 0xb3, 0xad, 0xd0, 0x0b, 0xd0, 0x7d, 0x80, 0x21, 0x8e,
+	0xcc, 0xcf, 0xd6, 0x88, 0x38, 0x91, 0x52, 0x6a, 0x97, 0xb6, 0xe6, 0xf4, 0x85, 0x21, 0x8e, 0x0e,
+	0xb8, 0x00, 0xdd, 0x05, 0xb6, 0x30, 0x89, 0xdb, 0xc3, 0x97, 0x4a, 0x99, 0x6b, 0x6b, 0x43, 0x1c,
+	0x1d, 0xb2, 0xb5, 0x66, 0x41, 0xf3, 0x08, 0x47, 0x7b, 0x98, 0xda, 0x01, 0xf1, 0x99, 0x33, 0x0d,
+	0x9a, 0x81, 0x35, 0x31, 0xd9, 0x8e, 0xf3, 0x28, 0xc4, 0x94, 0xfb, 0x6b, 0xe8, 0xf5, 0xc0, 0x9a,
+	0x1c, 0xe1, 0xe8, 0x2d, 0x13, 0xa1, 0xe7, 0x50, 0x65, 0x7a, 0xc7, 0xb3, 0xb9, 0xbf, 0x7a, 0x67,
+	0x65, 0x5b, 0x22, 0xdb, 0x4e, 0x61, 0xe9, 0xf3, 0x43, 0xfe, 0xad, 0xfd, 0x1f, 0xe6, 0xba, 0x97,
+	0xa7, 0xe3, 0x10, 0xad, 0xc2, 0xdc, 0x85, 0xe5, 0x8c, 0x31, 0x77, 0x59, 0xd1, 0xc5, 0x82, 0xc1,
+	0xf3, 0x87, 0xa6, 0x88, 0xcf, 0xdd, 0x35, 0xf4, 0x9a, 0x3f, 0x34, 0xf8, 0x5a, 0xfb, 0xb3, 0x0c,
+	0x8b, 0x06, 0xe9, 0xbb, 0x19, 0x80, 0x2f, 0x81, 0xa1, 0x37, 0x7b, 0x98, 0xda, 0xdc, 0x51, 0xbd,
+	0xb3, 0x96, 0x8d, 0x9e, 0x5a, 0xea, 0xd5, 0xa1, 0x58, 0xa2, 0x0d, 0x68, 0x50, 0xe2, 0xf6, 0x1d,
+	0x6c, 0x86, 0x13, 0x6c, 0x0d, 0x65, 0x94, 0xba, 0x90, 0x75, 0x99, 0x88, 0x99, 0xf4, 0xbc, 0xf1,
+	0x79, 0x62, 0x52, 0x11, 0x26, 0x42, 0x26, 0x4c, 0x36, 0x61, 0x71, 0x42, 0x42, 0x17, 0x53, 0x1a,
+	0xa3, 0xbd, 0xc5, 0x8d, 0x9a, 0x52, 0x2a, 0x20, 0xa3, 0xc7, 0x30, 0xef, 0x8d, 0x43, 0x7f, 0x1c,
+	0x2a, 0x73, 0x1c, 0xdd, 0x62, 0x82, 0x8e, 0xb3, 0xa0, 0x4b, 0x2d, 0x52, 0x80, 0x95, 0x73, 0x60,
+	0xd1, 0x81, 0x52, 0x6d, 0x97, 0xb6, 0x9a, 0x7a, 0xbc, 0x44, 0x0f, 0xa1, 0x4e, 0x5c, 0x7f, 0x1c,
+	0xca, 0x92, 0xd5, 0x78, 0xc9, 0x80, 0x8b, 0x78, 0xd1, 0xd0, 0x2b, 0xa8, 0x33, 0x9f, 0xe6, 0x08,
+	0x87, 0x03, 0xaf, 0xa7, 0x2c, 0xb4, 0x4b, 0x5b, 0x8b, 0x99, 0x1a, 0x30, 0xc2, 0x8e, 0xb9, 0x4a,
+	0x07, 0x9a, 0x7c, 0x6b, 0xbf, 0x96, 0xa0, 0xca, 0x54, 0x3a, 0xfe, 0x82, 0xda, 0xd0, 0x60, 0x55,
+	0x0e, 0x2f, 0x73, 0x45, 0x86, 0xc0, 0x9a, 0x74, 0x2f, 0x45, 0x8d, 0x5f, 0x03, 0xdf, 0xcb, 0x79,
+	0xa6, 0x4a, 0xb9, 0x5d, 0xd9, 0xaa, 0x77, 0xd6, 0x73, 0x21, 0x32, 0x4c, 0x2f, 0x50, 0xb9, 0xa6,
+	0xe8, 0x25, 0x34, 0xfc, 0x00, 0x5f, 0x98, 0x22, 0x4b, 0xaa, 0x54, 0xda, 0x95, 0x29, 0x24, 0xd4,
+	0x99, 0xcd, 0xa9, 0x30, 0xd1, 0x36, 0xa1, 0x26, 0x70, 0x51, 0x1f, 0xdd, 0x81, 0x1a, 0x03, 0x46,
+	0x49, 0x9f, 0x81, 0xaa, 0x6c, 0x35, 0xf4, 0x6a, 0x60, 0x4d, 0x0c, 0xd2, 0xa7, 0xda, 0x01, 0xd4,
+	0x0f, 0x19, 0x07, 0x92, 0x67, 0x05, 0xaa, 0x92, 0xf8, 0xd8, 0x50, 0x2e, 0x59, 0x3f, 0x50, 0xd2,
+	0xcf, 0x1f, 0x29, 0x86, 0x50, 0x9e, 0xa9, 0xf7, 0xb0, 0x94, 0xf1, 0xc3, 0xa3, 0xfe, 0x0f, 0x9a,
+	0x82, 0x71, 0xb1, 0x47, 0x78, 0xac, 0x77, 0x56, 0x13, 0xd4, 0xd9, 0x0d, 0x0d, 0x92, 0x2e, 0xa8,
+	0x76, 0x26, 0x0e, 0xe8, 0x31, 0xa6, 0xd4, 0xea, 0x63, 0xc6, 0x6d, 0x0b, 0x2a, 0x23, 0xda, 0x97,
+	0x94, 0xb2, 0xcf, 0x6f, 0xec, 0x97, 0x1d, 0x58, 0xca, 0x79, 0xa4, 0x3e, 0xba, 0x07, 0x9c, 0x61,
+	0x2b, 0x1c, 0x07, 0x58, 0x3a, 0x4e, 0x05, 0xda, 0x27, 0x68, 0x7d, 0xc0, 0x01, 0xf9, 0x1c, 0x5d,
+	0x0b, 0x22, 0xe7, 0xa3, 0x5c, 0xf0, 0x81, 0xd6, 0x60, 0xde, 0x1f, 0x9f, 0x0f, 0x71, 0x24, 0x4f,
+	0xbe, 0x5c, 0x69, 0x4f, 0x61, 0xb9, 0xe0, 0x9b, 0xfa, 0xb2, 0x91, 0x49, 0x8f, 0xbb, 0xaf, 0xe9,
+	0x62, 0xa1, 0xfd, 0x5e, 0x82, 0x96, 0x31, 0xb0, 0x02, 0xdc, 0x3b, 0xc2, 0x91, 0x8e, 0xbf, 0x8c,
+	0x31, 0x0d, 0xd1, 0x53, 0x68, 0x61, 0x7f, 0x80, 0x47, 0x38, 0xb0, 0x1c, 0x53, 0x46, 0x10, 0xa0,
+	0x96, 0x12, 0xf9, 0x19, 0x17, 0xa3, 0x17, 0x37, 0x61, 0xe9, 0x6d, 0x59, 0x29, 0xc5, 0x4c, 0xe5,
+	0x46, 0x41, 0xe5, 0x46, 0xa3, 0x40, 0xeb, 0xc0, 0x72, 0x06, 0x23, 0xf5, 0x3d, 0x97, 0x62, 0x7e,
+	0x60, 0xb8, 0xd0, 0x4c, 0xe1, 0x2d, 0xd0, 0xd8, 0x4c, 0xfb, 0x1e, 0x16, 0xf8, 0x04, 0xe0, 0xb3,
+	0x64, 0x15, 0xe6, 0xc4, 0x84, 0x10, 0x66, 0x62, 0x81, 0x54, 0x58, 0x20, 0xd4, 0xbc, 0x34, 0x3d,
+	0xd7, 0x89, 0x38, 0xfa, 0x9a, 0x5e, 0x25, 0xf4, 0xe7, 0x53, 0xd7, 0x89, 0xb4, 0x5f, 0xa0, 0xd5,
+	0xb5, 0xfc, 0xc0, 0xf3, 0xc2, 0xd4, 0xcb, 0x43, 0xa8, 0x0b, 0x6c, 0x26, 0x93, 0xc7, 0xed, 0x27,
+	0x44, 0xba, 0xe7, 0x85, 0xe8, 0x11, 0x2c, 0xb2, 0xd4, 0xa8, 0x8f, 0xdd, 0x5e, 0xd6, 0x6b, 0x63,
+	0x88, 0x23, 0x83, 0x09, 0xb9, 0xeb, 0x3f, 0x4a, 0xa0, 0x1c, 0x8f, 0x0d, 0xd2, 0xef, 0xec, 0x7a,
+	0xa3, 0x73, 0xe2, 0xe2, 0x23, 0x1c, 0xd1, 0x98, 0xfa, 0xe7, 0x80, 0x2c, 0xc7, 0x31, 0xc5, 0x0d,
+	0x22, 0xb9, 0x8f, 0x7b, 0xa5, 0x65, 0x39, 0x8e, 0xc1, 0x15, 0x82, 0x7c, 0x8a, 0x9e, 0xc1, 0x3c,
+	0x4f, 0x25, 0xee, 0x75, 0x94, 0x76, 0x6c, 0x8c, 0x5a, 0x97, 0x16, 0xe8, 0x07, 0x68, 0x86, 0x22,
+	0xa3, 0xcc, 0xb4, 0xac, 0x77, 0xee, 0xa4, 0x5b, 0x0a, 0xf9, 0xea, 0x8d, 0x30, 0x23, 0xd1, 0x7c,
+	0xb8, 0x33, 0x05, 0xb5, 0x2c, 0xc6, 0x06, 0x34, 0x6c, 0x21, 0xce, 0x96, 0xa3, 0x1e, 0xcb, 0x8e,
+	0xf8, 0x49, 0x59, 0x8d, 0xe3, 0x13, 0x37, 0xc4, 0x81, 0x6b, 0x39, 0xdc, 0x54, 0x9c, 0x6a, 0x24,
+	0x75, 0x87, 0x52, 0xc5, 0x4a, 0xf8, 0x5b, 0x19, 0x56, 0x45, 0x48, 0x03, 0x53, 0x4a, 0x3c, 0x37,
+	0x25, 0x29, 0x39, 0x74, 0xa5, 0xaf, 0xb6, 0xe6, 0x0c, 0x4a, 0xcb, 0x33, 0x28, 0xfd, 0x0e, 0x54,
+	0x2f, 0x1c, 0xe0, 0x20, 0x63, 0xef, 0x10, 0xdb, 0x74, 0x3d, 0xd7, 0xc6, 0x62, 0x30, 0x36, 0xf4,
+	0x75, 0x6e, 0x91, 0xec, 0x73, 0x88, 0x7d, 0xc2, 0xd5, 0x99, 0x7a, 0xdc, 0xfa, 0xf6, 0x7a, 0xcc,
+	0x7d, 0x5b, 0x3d, 0xfe, 0x2e, 0xc1, 0xed, 0x02, 0x3b, 0x99, 0xce, 0x10, 0x22, 0x93, 0xf4, 0x92,
+	0xce, 0x10, 0x92, 0xc3, 0xde, 0x95, 0x5a, 0x95, 0x6f, 0x5e, 0xab, 0xca, 0xac, 0x5a, 0xa1, 0x6d,
+	0x58, 0x71, 0x3c, 0xdb, 0x72, 0x0a, 0x7c, 0x89, 0xcb, 0x76, 0x99, 0xab, 0x72, 0x4c, 0x3d, 0x86,
+	0xa5, 0x81, 0x75, 0x81, 0x4d, 0x56, 0x19, 0x69, 0x3b, 0xc7, 0x7b, 0xa5, 0xc9, 0xc4, 0x6f, 0x1c,
+	0x47, 0xd8, 0x69, 0x11, 0xdc, 0x15, 0x49, 0xea, 0xb8, 0x4f, 0x68, 0x88, 0x03, 0x21, 0x8f, 0x4f,
+	0xc2, 0x57, 0x52, 0xbd, 0xbe, 0x98, 0xe5, 0x6b, 0x8b, 0xa9, 0x1d, 0xc0, 0xbd, 0xe9, 0xa1, 0x25,
+	0xcd, 0x53, 0x52, 0x28, 0x4d, 0x4b, 0x81, 0xc2, 0xb2, 0xac, 0x93, 0xb8, 0xc7, 0x6f, 0x02, 0x7c,
+	0x13, 0x16, 0x47, 0x62, 0x76, 0x9b, 0x3d, 0xd2, 0xc7, 0x34, 0xbe, 0x11, 0x9b, 0x52, 0xba, 0xc7,
+	0x85, 0xec, 0x3a, 0xb5, 0x1d, 0x6c, 0xb9, 0x63, 0x9f, 0x97, 0xa6, 0xa6, 0xc7, 0x4b, 0xed, 0x3d,
+	0xa0, 0x6c, 0x50, 0x09, 0xf9, 0x35, 0xac, 0xcb, 0x2a, 0x59, 0x41, 0x48, 0x2c, 0xc7, 0x4c, 0xee,
+	0x12, 0x09, 0xe1, 0xb6, 0xa8, 0x94, 0xd0, 0x1a, 0xc9, 0x65, 0x15, 0xc0, 0x7a, 0xae, 0xf7, 0x0d,
+	0xd2, 0xbf, 0x61, 0x22, 0xff, 0x05, 0x45, 0x54, 0xe0, 0x4a, 0xc4, 0x98, 0xff, 0x35, 0xae, 0x2f,
+	0x86, 0x64, 0xb4, 0x29, 0x57, 0x63, 0xca, 0x3c, 0xb6, 0x61, 0x25, 0xa1, 0x3e, 0xe3, 0x50, 0xd0,
+	0xbf, 0x2c, 0xe9, 0x4f, 0x7d, 0xa1, 0x27, 0xb0, 0xf4, 0x99, 0xb8, 0xb9, 0x7c, 0x05, 0x9f, 0x8b,
+	0x5c, 0x9c, 0x26, 0xfa, 0x9f, 0x78, 0xe2, 0xec, 0x0a, 0x1e, 0x6f, 0x96, 0xa5, 0xb6, 0x0e, 0xb7,
+	0x0b, 0xdb, 0x04, 0xd0, 0x67, 0x27, 0x00, 0xe9, 0xc3, 0x0e, 0xa9, 0xb0, 0x66, 0x1c, 0xfe, 0x74,
+	0x62, 0x1e, 0xef, 0x77, 0xdf, 0x9d, 0xee, 0x99, 0x1f, 0x0f, 0xbb, 0x27, 0xfb, 0x86, 0x61, 0x7e,
+	0x78, 0xd1, 0xfa, 0x17, 0x7a, 0x04, 0xed, 0xac, 0xae, 0xfb, 0xe6, 0x4c, 0x3f, 0x3d, 0xed, 0x9a,
+	0xc6, 0xae, 0x7e, 0x78, 0xd6, 0x35, 0x8d, 0xb3, 0xfd, 0x93, 0xbd, 0x56, 0xa9, 0xf3, 0xd7, 0x3c,
+	0xcc, 0x8b, 0xa3, 0x8a, 0x5e, 0x41, 0x93, 0x7d, 0x89, 0xf7, 0x98, 0x6e, 0x4d, 0x50, 0x2b, 0xf7,
+	0xd0, 0xd3, 0xf1, 0x17, 0x75, 0xb9, 0x20, 0xa1, 0x3e, 0xfa, 0x11, 0xd0, 0xae, 0x37, 0xf2, 0xc7,
+	0x21, 0xce, 0x3e, 0xcb, 0xae, 0x6e, 0x55, 0xa6, 0xbe, 0xa2, 0x84, 0x87, 0x7a, 0xe6, 0xa5, 0x83,
+	0xd6, 0x0b, 0x2f, 0xd8, 0xf8, 0x31, 0xa3, 0x2a, 0xd3, 0x15, 0xd4, 0x47, 0x07, 0xd0, 0xcc, 0x3d,
+	0x4f, 0x50, 0x3a, 0xf3, 0x8a, 0x4f, 0x22, 0x55, 0x9d, 0xa5, 0xa2, 0x3e, 0x7a, 0x07, 0x4b, 0x7b,
+	0x38, 0x20, 0x17, 0x38, 0x79, 0x1c, 0x64, 0x3c, 0x15, 0x1f, 0x35, 0xaa, 0x3a, 0x4d, 0x25, 0xcf,
+	0xd3, 0x27, 0x58, 0xce, 0x9d, 0x35, 0x76, 0xb7, 0xa1, 0x8d, 0x64, 0xc3, 0xac, 0xdb, 0x5a, 0xd5,
+	0xae, 0x33, 0x91, 0xbe, 0xbb, 0xb0, 0x22, 0x95, 0x01, 0xb6, 0x42, 0x2c, 0x87, 0x35, 0xba, 0x5f,
+	0xd8, 0x9a, 0xbf, 0xe2, 0xd4, 0x07, 0xb3, 0xd4, 0xd2, 0xab, 0x0d, 0xab, 0xd3, 0x86, 0x13, 0x7a,
+	0x54, 0xd8, 0x37, 0x75, 0x6c, 0xaa, 0x9b, 0x5f, 0xb1, 0x92, 0x41, 0xf6, 0x01, 0xd2, 0x21, 0x82,
+	0xd4, 0x22, 0xa4, 0x74, 0x9c, 0xa9, 0x77, 0xa7, 0xea, 0xa4, 0x9b, 0x8f, 0xd0, 0x2a, 0x76, 0x32,
+	0x6a, 0x4f, 0x67, 0x2e, 0x1d, 0x2c, 0xea, 0xc6, 0x35, 0x16, 0xd2, 0xf1, 0x09, 0x34, 0x73, 0x6d,
+	0x77, 0x85, 0xd4, 0x7c, 0x17, 0xab, 0x0f, 0x66, 0xa9, 0x85, 0xbf, 0xb7, 0x4f, 0x3f, 0x3d, 0xe9,
+	0x93, 0x70, 0x30, 0x3e, 0xdf, 0xb6, 0xbd, 0xd1, 0x8e, 0x1d, 0x44, 0x7e, 0xe8, 0x8d, 0xb0, 0x37,
+	0xd9, 0x71, 0xdc, 0xde, 0x8e, 0x93, 0xfc, 0xb5, 0x0f, 0x7c, 0xfb, 0x7c, 0x9e, 0xff, 0xb9, 0xff,
+	0xf7, 0x3f, 0x03, 0x00, 0x26, 0x39, 0x57, 0x0a, 0xf4, 0x0f, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// SignerClient is the client API for Signer service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type SignerClient interface {
+	//
+	//SignOutputRaw is a method that can be used to generated a signature for a
+	//set of inputs/outputs to a transaction. Each request specifies details
+	//concerning how the outputs should be signed, which keys they should be
+	//signed with, and also any optional tweaks. The return value is a fixed
+	//64-byte signature (the same format as we use on the wire in Lightning).
+	//
+	//If we are  unable to sign using the specified keys, then an error will be
+	//returned.
+	SignOutputRaw(ctx context.Context, in *SignReq, opts ...grpc.CallOption) (*SignResp, error)
+	//
+	//ComputeInputScript generates a complete InputIndex for the passed
+	//transaction with the signature as defined within the passed SignDescriptor.
+	//This method should be capable of generating the proper input script for
+	//both regular p2wkh output and p2wkh outputs nested within a regular p2sh
+	//output.
+	//
+	//Note that when using this method to sign inputs belonging to the wallet,
+	//the only items of the SignDescriptor that need to be populated are pkScript
+	//in the TxOut field, the value in that same field, and finally the input
+	//index.
+	ComputeInputScript(ctx context.Context, in *SignReq, opts ...grpc.CallOption) (*InputScriptResp, error)
+	//
+	//SignMessage signs a message with the key specified in the key locator. The
+	//returned signature is fixed-size LN wire format encoded.
+	//
+	//The main difference to SignMessage in the main RPC is that a specific key is
+	//used to sign the message instead of the node identity private key.
+	SignMessage(ctx context.Context, in *SignMessageReq, opts ...grpc.CallOption) (*SignMessageResp, error)
+	//
+	//VerifyMessage verifies a signature over a message using the public key
+	//provided. The signature must be fixed-size LN wire format encoded.
+	//
+	//The main difference to VerifyMessage in the main RPC is that the public key
+	//used to sign the message does not have to be a node known to the network.
+	VerifyMessage(ctx context.Context, in *VerifyMessageReq, opts ...grpc.CallOption) (*VerifyMessageResp, error)
+	//
+	//DeriveSharedKey returns a shared secret key by performing Diffie-Hellman key
+	//derivation between the ephemeral public key in the request and the node's
+	//key specified in the key_desc parameter. Either a key locator or a raw
+	//public key is expected in the key_desc, if neither is supplied, defaults to
+	//the node's identity private key:
+	//P_shared = privKeyNode * ephemeralPubkey
+	//The resulting shared public key is serialized in the compressed format and
+	//hashed with sha256, resulting in the final key length of 256bit.
+	DeriveSharedKey(ctx context.Context, in *SharedKeyRequest, opts ...grpc.CallOption) (*SharedKeyResponse, error)
+	//
+	//MuSig2CombineKeys combines the given set of public keys into a single
+	//combined MuSig2 public key, applying the given tweaks. The keys are sorted
+	//before they are combined, so their order doesn't matter.
+	MuSig2CombineKeys(ctx context.Context, in *MuSig2CombineKeysRequest, opts ...grpc.CallOption) (*MuSig2CombineKeysResponse, error)
+	//
+	//MuSig2CreateSession creates a new MuSig2 signing session using the local
+	//key identified by the key locator. The secret nonce of the session never
+	//leaves the daemon, which makes sure it is only ever used to sign a single
+	//message.
+	MuSig2CreateSession(ctx context.Context, in *MuSig2SessionRequest, opts ...grpc.CallOption) (*MuSig2SessionResponse, error)
+	//
+	//MuSig2RegisterNonces registers the public nonces of other signing
+	//participants with a session.
+	MuSig2RegisterNonces(ctx context.Context, in *MuSig2RegisterNoncesRequest, opts ...grpc.CallOption) (*MuSig2RegisterNoncesResponse, error)
+	//
+	//MuSig2Sign creates a partial signature of the message using the local key
+	//of the session. The public nonces of all signers must be registered first.
+	//A session can only sign a single message.
+	MuSig2Sign(ctx context.Context, in *MuSig2SignRequest, opts ...grpc.CallOption) (*MuSig2SignResponse, error)
+	//
+	//MuSig2CombineSig adds the partial signatures of other signers to a session.
+	//Once the partial signatures of all signers are known, they are combined
+	//into the final schnorr signature and the session is removed.
+	MuSig2CombineSig(ctx context.Context, in *MuSig2CombineSigRequest, opts ...grpc.CallOption) (*MuSig2CombineSigResponse, error)
+	//
+	//MuSig2Cleanup removes a session along with its secret nonce. This should
+	//be called if a session is aborted.
+	MuSig2Cleanup(ctx context.Context, in *MuSig2CleanupRequest, opts ...grpc.CallOption) (*MuSig2CleanupResponse, error)
+}
+
+type signerClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewSignerClient(cc *grpc.ClientConn) SignerClient {
+	return &signerClient{cc}
+}
+
+func (c *signerClient) SignOutputRaw(ctx context.Context, in *SignReq, opts ...grpc.CallOption) (*SignResp, error) {
+	out := new(SignResp)
+	err := c.cc.Invoke(ctx, "/signrpc.Signer/SignOutputRaw", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *signerClient) ComputeInputScript(ctx context.Context, in *SignReq, opts ...grpc.CallOption) (*InputScriptResp, error) {
+	out := new(InputScriptResp)
+	err := c.cc.Invoke(ctx, "/signrpc.Signer/ComputeInputScript", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *signerClient) SignMessage(ctx context.Context, in *SignMessageReq, opts ...grpc.CallOption) (*SignMessageResp, error) {
+	out := new(SignMessageResp)
+	err := c.cc.Invoke(ctx, "/signrpc.Signer/SignMessage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *signerClient) VerifyMessage(ctx context.Context, in *VerifyMessageReq, opts ...grpc.CallOption) (*VerifyMessageResp, error) {
+	out := new(VerifyMessageResp)
+	err := c.cc.Invoke(ctx, "/signrpc.Signer/VerifyMessage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *signerClient) DeriveSharedKey(ctx context.Context, in *SharedKeyRequest, opts ...grpc.CallOption) (*SharedKeyResponse, error) {
+	out := new(SharedKeyResponse)
+	err := c.cc.Invoke(ctx, "/signrpc.Signer/DeriveSharedKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *signerClient) MuSig2CombineKeys(ctx context.Context, in *MuSig2CombineKeysRequest, opts ...grpc.CallOption) (*MuSig2CombineKeysResponse, error) {
+	out := new(MuSig2CombineKeysResponse)
+	err := c.cc.Invoke(ctx, "/signrpc.Signer/MuSig2CombineKeys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *signerClient) MuSig2CreateSession(ctx context.Context, in *MuSig2SessionRequest, opts ...grpc.CallOption) (*MuSig2SessionResponse, error) {
+	out := new(MuSig2SessionResponse)
+	err := c.cc.Invoke(ctx, "/signrpc.Signer/MuSig2CreateSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *signerClient) MuSig2RegisterNonces(ctx context.Context, in *MuSig2RegisterNoncesRequest, opts ...grpc.CallOption) (*MuSig2RegisterNoncesResponse, error) {
+	out := new(MuSig2RegisterNoncesResponse)
+	err := c.cc.Invoke(ctx, "/signrpc.Signer/MuSig2RegisterNonces", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *signerClient) MuSig2Sign(ctx context.Context, in *MuSig2SignRequest, opts ...grpc.CallOption) (*MuSig2SignResponse, error) {
+	out := new(MuSig2SignResponse)
+	err := c.cc.Invoke(ctx, "/signrpc.Signer/MuSig2Sign", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *signerClient) MuSig2CombineSig(ctx context.Context, in *MuSig2CombineSigRequest, opts ...grpc.CallOption) (*MuSig2CombineSigResponse, error) {
+	out := new(MuSig2CombineSigResponse)
+	err := c.cc.Invoke(ctx, "/signrpc.Signer/MuSig2CombineSig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *signerClient) MuSig2Cleanup(ctx context.Context, in *MuSig2CleanupRequest, opts ...grpc.CallOption) (*MuSig2CleanupResponse, error) {
+	out := new(MuSig2CleanupResponse)
+	err := c.cc.Invoke(ctx, "/signrpc.Signer/MuSig2Cleanup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SignerServer is the server API for Signer service.
+type SignerServer interface {
+	//
+	//SignOutputRaw is a method that can be used to generated a signature for a
+	//set of inputs/outputs to a transaction. Each request specifies details
+	//concerning how the outputs should be signed, which keys they should be
+	//signed with, and also any optional tweaks. The return value is a fixed
+	//64-byte signature (the same format as we use on the wire in Lightning).
+	//
+	//If we are  unable to sign using the specified keys, then an error will be
+	//returned.
+	SignOutputRaw(context.Context, *SignReq) (*SignResp, error)
+	//
+	//ComputeInputScript generates a complete InputIndex for the passed
+	//transaction with the signature as defined within the passed SignDescriptor.
+	//This method should be capable of generating the proper input script for
+	//both regular p2wkh output and p2wkh outputs nested within a regular p2sh
+	//output.
+	//
+	//Note that when using this method to sign inputs belonging to the wallet,
+	//the only items of the SignDescriptor that need to be populated are pkScript
+	//in the TxOut field, the value in that same field, and finally the input
+	//index.
+	ComputeInputScript(context.Context, *SignReq) (*InputScriptResp, error)
+	//
+	//SignMessage signs a message with the key specified in the key locator. The
+	//returned signature is fixed-size LN wire format encoded.
+	//
+	//The main difference to SignMessage in the main RPC is that a specific key is
+	//used to sign the message instead of the node identity private key.
+	SignMessage(context.Context, *SignMessageReq) (*SignMessageResp, error)
+	//
+	//VerifyMessage verifies a signature over a message using the public key
+	//provided. The signature must be fixed-size LN wire format encoded.
+	//
+	//The main difference to VerifyMessage in the main RPC is that the public key
+	//used to sign the message does not have to be a node known to the network.
+	VerifyMessage(context.Context, *VerifyMessageReq) (*VerifyMessageResp, error)
+	//
+	//DeriveSharedKey returns a shared secret key by performing Diffie-Hellman key
+	//derivation between the ephemeral public key in the request and the node's
+	//key specified in the key_desc parameter. Either a key locator or a raw
+	//public key is expected in the key_desc, if neither is supplied, defaults to
+	//the node's identity private key:
+	//P_shared = privKeyNode * ephemeralPubkey
+	//The resulting shared public key is serialized in the compressed format and
+	//hashed with sha256, resulting in the final key length of 256bit.
+	DeriveSharedKey(context.Context, *SharedKeyRequest) (*SharedKeyResponse, error)
+	//
+	//MuSig2CombineKeys combines the given set of public keys into a single
+	//combined MuSig2 public key, applying the given tweaks. The keys are sorted
+	//before they are combined, so their order doesn't matter.
+	MuSig2CombineKeys(context.Context, *MuSig2CombineKeysRequest) (*MuSig2CombineKeysResponse, error)
+	//
+	//MuSig2CreateSession creates a new MuSig2 signing session using the local
+	//key identified by the key locator. The secret nonce of the session never
+	//leaves the daemon, which makes sure it is only ever used to sign a single
+	//message.
+	MuSig2CreateSession(context.Context, *MuSig2SessionRequest) (*MuSig2SessionResponse, error)
+	//
+	//MuSig2RegisterNonces registers the public nonces of other signing
+	//participants with a session.
+	MuSig2RegisterNonces(context.Context, *MuSig2RegisterNoncesRequest) (*MuSig2RegisterNoncesResponse, error)
+	//
+	//MuSig2Sign creates a partial signature of the message using the local key
+	//of the session. The public nonces of all signers must be registered first.
+	//A session can only sign a single message.
+	MuSig2Sign(context.Context, *MuSig2SignRequest) (*MuSig2SignResponse, error)
+	//
+	//MuSig2CombineSig adds the partial signatures of other signers to a session.
+	//Once the partial signatures of all signers are known, they are combined
+	//into the final schnorr signature and the session is removed.
+	MuSig2CombineSig(context.Context, *MuSig2CombineSigRequest) (*MuSig2CombineSigResponse, error)
+	//
+	//MuSig2Cleanup removes a session along with its secret nonce. This should
+	//be called if a session is aborted.
+	MuSig2Cleanup(context.Context, *MuSig2CleanupRequest) (*MuSig2CleanupResponse, error)
+}
+
+// UnimplementedSignerServer can be embedded to have forward compatible implementations.
+type UnimplementedSignerServer struct {
+}
+
+func (*UnimplementedSignerServer) SignOutputRaw(ctx context.Context, req *SignReq) (*SignResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignOutputRaw not implemented")
+}
+func (*UnimplementedSignerServer) ComputeInputScript(ctx context.Context, req *SignReq) (*InputScriptResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ComputeInputScript not implemented")
+}
+func (*UnimplementedSignerServer) SignMessage(ctx context.Context, req *SignMessageReq) (*SignMessageResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignMessage not implemented")
+}
+func (*UnimplementedSignerServer) VerifyMessage(ctx context.Context, req *VerifyMessageReq) (*VerifyMessageResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyMessage not implemented")
+}
+func (*UnimplementedSignerServer) DeriveSharedKey(ctx context.Context, req *SharedKeyRequest) (*SharedKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeriveSharedKey not implemented")
+}
+func (*UnimplementedSignerServer) MuSig2CombineKeys(ctx context.Context, req *MuSig2CombineKeysRequest) (*MuSig2CombineKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MuSig2CombineKeys not implemented")
+}
+func (*UnimplementedSignerServer) MuSig2CreateSession(ctx context.Context, req *MuSig2SessionRequest) (*MuSig2SessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MuSig2CreateSession not implemented")
+}
+func (*UnimplementedSignerServer) MuSig2RegisterNonces(ctx context.Context, req *MuSig2RegisterNoncesRequest) (*MuSig2RegisterNoncesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MuSig2RegisterNonces not implemented")
+}
+func (*UnimplementedSignerServer) MuSig2Sign(ctx context.Context, req *MuSig2SignRequest) (*MuSig2SignResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MuSig2Sign not implemented")
+}
+func (*UnimplementedSignerServer) MuSig2CombineSig(ctx context.Context, req *MuSig2CombineSigRequest) (*MuSig2CombineSigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MuSig2CombineSig not implemented")
+}
+func (*UnimplementedSignerServer) MuSig2Cleanup(ctx context.Context, req *MuSig2CleanupRequest) (*MuSig2CleanupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MuSig2Cleanup not implemented")
+}
+
+func RegisterSignerServer(s *grpc.Server, srv SignerServer) {
+	s.RegisterService(&_Signer_serviceDesc, srv)
+}
 
 func _Signer_SignOutputRaw_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SignReq)
@@ -1123,6 +2117,114 @@ func _Signer_DeriveSharedKey_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Signer_MuSig2CombineKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MuSig2CombineKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SignerServer).MuSig2CombineKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/signrpc.Signer/MuSig2CombineKeys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignerServer).MuSig2CombineKeys(ctx, req.(*MuSig2CombineKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Signer_MuSig2CreateSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MuSig2SessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SignerServer).MuSig2CreateSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/signrpc.Signer/MuSig2CreateSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignerServer).MuSig2CreateSession(ctx, req.(*MuSig2SessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Signer_MuSig2RegisterNonces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MuSig2RegisterNoncesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SignerServer).MuSig2RegisterNonces(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/signrpc.Signer/MuSig2RegisterNonces",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignerServer).MuSig2RegisterNonces(ctx, req.(*MuSig2RegisterNoncesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Signer_MuSig2Sign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MuSig2SignRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SignerServer).MuSig2Sign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/signrpc.Signer/MuSig2Sign",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignerServer).MuSig2Sign(ctx, req.(*MuSig2SignRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Signer_MuSig2CombineSig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MuSig2CombineSigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SignerServer).MuSig2CombineSig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/signrpc.Signer/MuSig2CombineSig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignerServer).MuSig2CombineSig(ctx, req.(*MuSig2CombineSigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Signer_MuSig2Cleanup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MuSig2CleanupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SignerServer).MuSig2Cleanup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/signrpc.Signer/MuSig2Cleanup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignerServer).MuSig2Cleanup(ctx, req.(*MuSig2CleanupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Signer_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "signrpc.Signer",
 	HandlerType: (*SignerServer)(nil),
@@ -1147,6 +2249,30 @@ var _Signer_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeriveSharedKey",
 			Handler:    _Signer_DeriveSharedKey_Handler,
 		},
+		{
+			MethodName: "MuSig2CombineKeys",
+			Handler:    _Signer_MuSig2CombineKeys_Handler,
+		},
+		{
+			MethodName: "MuSig2CreateSession",
+			Handler:    _Signer_MuSig2CreateSession_Handler,
+		},
+		{
+			MethodName: "MuSig2RegisterNonces",
+			Handler:    _Signer_MuSig2RegisterNonces_Handler,
+		},
+		{
+			MethodName: "MuSig2Sign",
+			Handler:    _Signer_MuSig2Sign_Handler,
+		},
+		{
+			MethodName: "MuSig2CombineSig",
+			Handler:    _Signer_MuSig2CombineSig_Handler,
+		},
+		{
+			MethodName: "MuSig2Cleanup",
+			Handler:    _Signer_MuSig2Cleanup_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "signrpc/signer.proto",
@@ -16,6 +16,8 @@ import (
 	"github.com/btcsuite/btcd/wire"
 	"github.com/grpc-ecosystem/grpc-gateway/runtime"
 	"github.com/cryptomeow/lnd/input"
+	btcecv2 "github.com/cryptomeow/lnd/internal/btcec"
+	"github.com/cryptomeow/lnd/internal/schnorr"
 	"github.com/cryptomeow/lnd/keychain"
 	"github.com/cryptomeow/lnd/lnrpc"
 	"github.com/cryptomeow/lnd/lnwire"
@@ -68,6 +70,30 @@ var (
 			Entity: "signer",
 			Action: "generate",
 		}},
+		"/signrpc.Signer/MuSig2CombineKeys": {{
+			Entity: "signer",
+			Action: "read",
+		}},
+		"/signrpc.Signer/MuSig2CreateSession": {{
+			Entity: "signer",
+			Action: "generate",
+		}},
+		"/signrpc.Signer/MuSig2RegisterNonces": {{
+			Entity: "signer",
+			Action: "generate",
+		}},
+		"/signrpc.Signer/MuSig2Sign": {{
+			Entity: "signer",
+			Action: "generate",
+		}},
+		"/signrpc.Signer/MuSig2CombineSig": {{
+			Entity: "signer",
+			Action: "generate",
+		}},
+		"/signrpc.Signer/MuSig2Cleanup": {{
+			Entity: "signer",
+			Action: "generate",
+		}},
 	}
 
 	// DefaultSignerMacFilename is the default name of the signer macaroon
@@ -82,6 +108,10 @@ var (
 // backed by multiple distinct lnd across independent failure domains.
 type Server struct {
 	cfg *Config
+
+	// muSig2 keeps track of the MuSig2 signing sessions created through
+	// the RPC.
+	muSig2 *muSig2Manager
 }
 
 // A compile time check to ensure that Server fully implements the SignerServer
@@ -131,7 +161,8 @@ func New(cfg *Config) (*Server, lnrpc.MacaroonPerms, error) {
 	}
 
 	signerServer := &Server{
-		cfg: cfg,
+		cfg:    cfg,
+		muSig2: newMuSig2Manager(cfg.KeyRing),
 	}
 
 	return signerServer, macPermissions, nil
@@ -234,6 +265,16 @@ func (s *Server) SignOutputRaw(ctx context.Context, in *SignReq) (*SignResp, err
 
 	sigHashCache := txscript.NewTxSigHashes(&txToSign)
 
+	// Taproot signatures commit to all outputs spent by the transaction,
+	// so the caller has to provide them if any taproot input is signed.
+	prevOuts := make([]*wire.TxOut, 0, len(in.PrevOutputs))
+	for _, prevOut := range in.PrevOutputs {
+		prevOuts = append(prevOuts, &wire.TxOut{
+			Value:    prevOut.Value,
+			PkScript: prevOut.PkScript,
+		})
+	}
+
 	log.Debugf("Generating sigs for %v inputs: ", len(in.SignDescs))
 
 	// With the transaction deserialized, we'll now convert sign descs so
@@ -321,6 +362,22 @@ func (s *Server) SignOutputRaw(ctx context.Context, in *SignReq) (*SignResp, err
 		RawSigs: make([][]byte, numSigs),
 	}
 	for i, signDesc := range signDescs {
+		signMethod := in.SignDescs[i].SignMethod
+		if signMethod == SignMethod_SIGN_METHOD_TAPROOT_SCRIPT_SPEND {
+			sig, err := s.signTaprootScriptSpend(
+				&txToSign, prevOuts, signDesc,
+			)
+			if err != nil {
+				log.Errorf("unable to generate taproot sig "+
+					"for input #%v: %v", i, err)
+
+				return nil, err
+			}
+
+			resp.RawSigs[i] = sig
+			continue
+		}
+
 		sig, err := s.cfg.Signer.SignOutputRaw(&txToSign, signDesc)
 		if err != nil {
 			log.Errorf("unable to generate sig for input "+
@@ -335,6 +392,53 @@ func (s *Server) SignOutputRaw(ctx context.Context, in *SignReq) (*SignResp, err
 	return resp, nil
 }
 
+// signTaprootScriptSpend creates a schnorr signature for the spend of the
+// tapscript leaf found in the witness script of the sign descriptor. The
+// signature is returned in the format expected in the witness, so the sighash
+// type is appended unless it is the default one.
+func (s *Server) signTaprootScriptSpend(tx *wire.MsgTx,
+	prevOuts []*wire.TxOut, signDesc *input.SignDescriptor) ([]byte,
+	error) {
+
+	sigHash, err := input.TaprootScriptSpendSigHash(
+		tx, signDesc.InputIndex, prevOuts, signDesc.WitnessScript,
+		signDesc.HashType,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	privKey, err := s.cfg.KeyRing.DerivePrivKey(signDesc.KeyDesc)
+	if err != nil {
+		return nil, err
+	}
+
+	// The tweaks are applied just like the wallet does for SegWit v0
+	// inputs.
+	switch {
+	case signDesc.SingleTweak != nil:
+		privKey = input.TweakPrivKey(privKey, signDesc.SingleTweak)
+
+	case signDesc.DoubleTweak != nil:
+		privKey = input.DeriveRevocationPrivKey(
+			privKey, signDesc.DoubleTweak,
+		)
+	}
+
+	signingKey, _ := btcecv2.PrivKeyFromBytes(privKey.Serialize())
+	sig, err := schnorr.Sign(signingKey, sigHash)
+	if err != nil {
+		return nil, err
+	}
+
+	rawSig := sig.Serialize()
+	if signDesc.HashType != input.SigHashDefault {
+		rawSig = append(rawSig, byte(signDesc.HashType))
+	}
+
+	return rawSig, nil
+}
+
 // ComputeInputScript generates a complete InputIndex for the passed
 // transaction with the signature as defined within the passed SignDescriptor.
 // This method should be capable of generating the proper input script for both
@@ -372,6 +476,13 @@ func (s *Server) ComputeInputScript(ctx context.Context,
 
 	signDescs := make([]*input.SignDescriptor, 0, len(in.SignDescs))
 	for _, signDesc := range in.SignDescs {
+		// We only know how to create full witnesses for the SegWit v0
+		// outputs of our wallet.
+		if signDesc.SignMethod != SignMethod_SIGN_METHOD_WITNESS_V0 {
+			return nil, fmt.Errorf("sign method %v not supported",
+				signDesc.SignMethod)
+		}
+
 		// For this method, the only fields that we care about are the
 		// hash type, and the information concerning the output as we
 		// only know how to provide full witnesses for outputs that we
@@ -577,6 +688,192 @@ func (s *Server) DeriveSharedKey(_ context.Context, in *SharedKeyRequest) (
 	return &SharedKeyResponse{SharedKey: sharedKeyHash[:]}, nil
 }
 
+// MuSig2CombineKeys combines the given set of public keys into a single
+// combined MuSig2 public key, applying the given tweaks.
+func (s *Server) MuSig2CombineKeys(_ context.Context,
+	in *MuSig2CombineKeysRequest) (*MuSig2CombineKeysResponse, error) {
+
+	signers, err := parseMuSig2PubKeys(in.AllSignerPubkeys)
+	if err != nil {
+		return nil, err
+	}
+
+	tweaks, err := parseMuSig2Tweaks(in.Tweaks, in.TaprootTweak)
+	if err != nil {
+		return nil, err
+	}
+
+	combinedKey, err := muSig2CombineKeys(signers, tweaks)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &MuSig2CombineKeysResponse{
+		CombinedKey: schnorr.SerializePubKey(combinedKey.FinalKey),
+	}
+	if tweaks.taprootTweak {
+		resp.TaprootInternalKey = schnorr.SerializePubKey(
+			combinedKey.PreTweakedKey,
+		)
+	}
+
+	return resp, nil
+}
+
+// MuSig2CreateSession creates a new MuSig2 signing session using the local
+// key identified by the key locator.
+func (s *Server) MuSig2CreateSession(_ context.Context,
+	in *MuSig2SessionRequest) (*MuSig2SessionResponse, error) {
+
+	if in.KeyLoc == nil {
+		return nil, fmt.Errorf("missing key_loc")
+	}
+	keyLoc := keychain.KeyLocator{
+		Family: keychain.KeyFamily(in.KeyLoc.KeyFamily),
+		Index:  uint32(in.KeyLoc.KeyIndex),
+	}
+
+	signers, err := parseMuSig2PubKeys(in.AllSignerPubkeys)
+	if err != nil {
+		return nil, err
+	}
+
+	nonces, err := parseMuSig2Nonces(in.OtherSignerPublicNonces)
+	if err != nil {
+		return nil, err
+	}
+
+	tweaks, err := parseMuSig2Tweaks(in.Tweaks, in.TaprootTweak)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := s.muSig2.createSession(keyLoc, signers, tweaks, nonces)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &MuSig2SessionResponse{
+		SessionId:         info.sessionID[:],
+		CombinedKey:       schnorr.SerializePubKey(info.combinedKey),
+		LocalPublicNonces: info.publicNonce[:],
+		HaveAllNonces:     info.haveAllNonces,
+	}
+	if info.taprootInternalKey != nil {
+		resp.TaprootInternalKey = schnorr.SerializePubKey(
+			info.taprootInternalKey,
+		)
+	}
+
+	return resp, nil
+}
+
+// MuSig2RegisterNonces registers the public nonces of other signing
+// participants with a session.
+func (s *Server) MuSig2RegisterNonces(_ context.Context,
+	in *MuSig2RegisterNoncesRequest) (*MuSig2RegisterNoncesResponse,
+	error) {
+
+	sessionID, err := parseMuSig2SessionID(in.SessionId)
+	if err != nil {
+		return nil, err
+	}
+
+	nonces, err := parseMuSig2Nonces(in.OtherSignerPublicNonces)
+	if err != nil {
+		return nil, err
+	}
+
+	haveAllNonces, err := s.muSig2.registerNonces(sessionID, nonces)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MuSig2RegisterNoncesResponse{
+		HaveAllNonces: haveAllNonces,
+	}, nil
+}
+
+// MuSig2Sign creates a partial signature of the message using the local key
+// of the session.
+func (s *Server) MuSig2Sign(_ context.Context,
+	in *MuSig2SignRequest) (*MuSig2SignResponse, error) {
+
+	sessionID, err := parseMuSig2SessionID(in.SessionId)
+	if err != nil {
+		return nil, err
+	}
+
+	var digest [32]byte
+	if len(in.MessageDigest) != len(digest) {
+		return nil, fmt.Errorf("message digest must be %d bytes, "+
+			"got %d", len(digest), len(in.MessageDigest))
+	}
+	copy(digest[:], in.MessageDigest)
+
+	partialSig, err := s.muSig2.sign(sessionID, digest, in.Cleanup)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	if err := partialSig.Encode(&b); err != nil {
+		return nil, err
+	}
+
+	return &MuSig2SignResponse{
+		LocalPartialSignature: b.Bytes(),
+	}, nil
+}
+
+// MuSig2CombineSig adds the partial signatures of other signers to a session,
+// and combines them into the final signature once all of them are known.
+func (s *Server) MuSig2CombineSig(_ context.Context,
+	in *MuSig2CombineSigRequest) (*MuSig2CombineSigResponse, error) {
+
+	sessionID, err := parseMuSig2SessionID(in.SessionId)
+	if err != nil {
+		return nil, err
+	}
+
+	partialSigs, err := parseMuSig2PartialSigs(in.OtherPartialSignatures)
+	if err != nil {
+		return nil, err
+	}
+
+	finalSig, haveAllSigs, err := s.muSig2.combineSig(
+		sessionID, partialSigs,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &MuSig2CombineSigResponse{
+		HaveAllSignatures: haveAllSigs,
+	}
+	if haveAllSigs {
+		resp.FinalSignature = finalSig.Serialize()
+	}
+
+	return resp, nil
+}
+
+// MuSig2Cleanup removes a session along with its secret nonce.
+func (s *Server) MuSig2Cleanup(_ context.Context,
+	in *MuSig2CleanupRequest) (*MuSig2CleanupResponse, error) {
+
+	sessionID, err := parseMuSig2SessionID(in.SessionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.muSig2.cleanup(sessionID); err != nil {
+		return nil, err
+	}
+
+	return &MuSig2CleanupResponse{}, nil
+}
+
 // parseRawKeyBytes checks that the provided raw public key is valid and returns
 // the public key. A nil public key is returned if the length of the rawKeyBytes
 // is zero.
@@ -49,6 +49,10 @@ type Config struct {
 	// keys due to incoming client requests.
 	KeyRing keychain.KeyRing
 
+	// KeyFamilyRegistry keeps track of the key family ranges that external
+	// applications reserved for their own derivations.
+	KeyFamilyRegistry keychain.KeyFamilyRegistry
+
 	// Sweeper is the central batching engine of lnd. It is responsible for
 	// sweeping inputs in batches back into the wallet.
 	Sweeper *sweep.UtxoSweeper
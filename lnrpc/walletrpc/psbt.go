@@ -7,10 +7,14 @@ import (
 	"math"
 	"time"
 
+	"github.com/btcsuite/btcd/blockchain"
 	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
 	"github.com/btcsuite/btcutil/psbt"
+	"github.com/btcsuite/btcwallet/wallet/txrules"
 	"github.com/btcsuite/btcwallet/wtxmgr"
 	"github.com/cryptomeow/lnd/lnwallet"
+	"github.com/cryptomeow/lnd/lnwallet/chainfee"
 )
 
 const (
@@ -49,18 +53,21 @@ func verifyInputsUnspent(inputs []*wire.TxIn, utxos []*lnwallet.Utxo) error {
 }
 
 // lockInputs requests a lock lease for all inputs specified in a PSBT packet
-// by using the internal, static lock ID of lnd's wallet.
-func lockInputs(w lnwallet.WalletController, packet *psbt.Packet) ([]*utxoLock,
-	error) {
+// by using the given lock ID, which usually is the internal, static lock ID of
+// lnd's wallet.
+func lockInputs(w lnwallet.WalletController, packet *psbt.Packet,
+	lockID wtxmgr.LockID) ([]*utxoLock, error) {
 
 	locks := make([]*utxoLock, len(packet.UnsignedTx.TxIn))
 	for idx, rawInput := range packet.UnsignedTx.TxIn {
 		lock := &utxoLock{
-			lockID:   LndInternalLockID,
+			lockID:   lockID,
 			outpoint: rawInput.PreviousOutPoint,
 		}
 
-		expiration, err := w.LeaseOutput(lock.lockID, lock.outpoint)
+		expiration, err := w.LeaseOutput(
+			lock.lockID, lock.outpoint, 0,
+		)
 		if err != nil {
 			// If we run into a problem with locking one output, we
 			// should try to unlock those that we successfully
@@ -69,7 +76,7 @@ func lockInputs(w lnwallet.WalletController, packet *psbt.Packet) ([]*utxoLock,
 			for i := 0; i < idx; i++ {
 				op := locks[i].outpoint
 				if err := w.ReleaseOutput(
-					LndInternalLockID, op,
+					lockID, op,
 				); err != nil {
 
 					log.Errorf("could not release the "+
@@ -87,3 +94,45 @@ func lockInputs(w lnwallet.WalletController, packet *psbt.Packet) ([]*utxoLock,
 
 	return locks, nil
 }
+
+// replaceChangeOutput sends the change of a funded PSBT packet to the given
+// output script instead of the wallet's change address. If the new script is
+// larger, the change amount is reduced by the fee for the additional weight,
+// so the transaction still pays the given fee rate. The outputs are sorted
+// again afterwards and the new index of the change output is returned.
+func replaceChangeOutput(packet *psbt.Packet, changeIndex int32,
+	pkScript []byte, feeRate chainfee.SatPerKWeight) (int32, error) {
+
+	change := packet.UnsignedTx.TxOut[changeIndex]
+
+	// The output script is part of the non-witness data, so each
+	// additional byte accounts for a full vbyte.
+	extraBytes := len(pkScript) - len(change.PkScript)
+	if extraBytes > 0 {
+		extraWeight := int64(extraBytes * blockchain.WitnessScaleFactor)
+		change.Value -= int64(feeRate.FeeForWeight(extraWeight))
+	}
+	change.PkScript = pkScript
+
+	if txrules.IsDustOutput(change, txrules.DefaultRelayFeePerKb) {
+		return 0, fmt.Errorf("change of %v is dust when paying to "+
+			"the change address", btcutil.Amount(change.Value))
+	}
+
+	// The derivation info of the wallet's change address no longer
+	// applies to the output.
+	packet.Outputs[changeIndex] = psbt.POutput{}
+
+	// The new script may change the BIP 69 order of the outputs.
+	if err := psbt.InPlaceSort(packet); err != nil {
+		return 0, fmt.Errorf("could not sort PSBT: %v", err)
+	}
+
+	for idx, txOut := range packet.UnsignedTx.TxOut {
+		if txOut == change {
+			return int32(idx), nil
+		}
+	}
+
+	return 0, fmt.Errorf("change output not found after sorting")
+}
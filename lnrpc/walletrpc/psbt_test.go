@@ -0,0 +1,309 @@
+// +build walletrpc
+
+package walletrpc
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/btcsuite/btcutil/psbt"
+	"github.com/btcsuite/btcwallet/wtxmgr"
+	"github.com/cryptomeow/lnd/lnrpc"
+	"github.com/cryptomeow/lnd/lntest/mock"
+	"github.com/cryptomeow/lnd/lnwallet"
+	"github.com/cryptomeow/lnd/lnwallet/chainfee"
+	"github.com/stretchr/testify/require"
+)
+
+// mockCoinSelectionLocker runs the given function without locking anything.
+type mockCoinSelectionLocker struct{}
+
+func (m *mockCoinSelectionLocker) WithCoinSelectLock(f func() error) error {
+	return f()
+}
+
+// fundPsbtWallet is a wallet that funds PSBTs with a single input and a
+// change output of a fixed value, and records the accounts and lease IDs it
+// is used with.
+type fundPsbtWallet struct {
+	*mock.WalletController
+
+	// changeValue is the value of the change output the wallet adds.
+	changeValue int64
+
+	// accounts are the accounts that UTXOs were listed and PSBTs were
+	// funded from.
+	accounts []string
+
+	// leases are the IDs of the leases that were acquired.
+	leases []wtxmgr.LockID
+}
+
+// ListUnspentWitness records the account and returns the UTXOs of the mock.
+func (w *fundPsbtWallet) ListUnspentWitness(minConfs, maxConfs int32,
+	account string) ([]*lnwallet.Utxo, error) {
+
+	w.accounts = append(w.accounts, account)
+
+	return w.Utxos, nil
+}
+
+// FundPsbt records the account and funds the packet with the first UTXO of
+// the mock, adding a change output that is placed first.
+func (w *fundPsbtWallet) FundPsbt(packet *psbt.Packet,
+	_ chainfee.SatPerKWeight, account string) (int32, error) {
+
+	w.accounts = append(w.accounts, account)
+
+	packet.UnsignedTx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: w.Utxos[0].OutPoint,
+	})
+	packet.Inputs = append(packet.Inputs, psbt.PInput{})
+
+	packet.UnsignedTx.TxOut = append([]*wire.TxOut{{
+		Value:    w.changeValue,
+		PkScript: mock.CoinPkScript,
+	}}, packet.UnsignedTx.TxOut...)
+	packet.Outputs = append([]psbt.POutput{{
+		Bip32Derivation: []*psbt.Bip32Derivation{{
+			PubKey:    w.RootKey.PubKey().SerializeCompressed(),
+			Bip32Path: []uint32{0, 1},
+		}},
+	}}, packet.Outputs...)
+
+	return 0, nil
+}
+
+// LeaseOutput records the ID of the lease.
+func (w *fundPsbtWallet) LeaseOutput(id wtxmgr.LockID, op wire.OutPoint,
+	_ time.Duration) (time.Time, error) {
+
+	w.leases = append(w.leases, id)
+
+	return time.Now(), nil
+}
+
+// fundPsbtTest is a test case for the funding options of FundPsbt.
+type fundPsbtTest struct {
+	name string
+
+	// changeValue is the value of the change output the wallet adds.
+	changeValue int64
+
+	// account, changeAddress and leaseID are the funding options.
+	account       string
+	changeAddress string
+	leaseID       []byte
+
+	// expectErr is true if funding is expected to fail.
+	expectErr bool
+
+	// expectChangeScript is the expected script of the change output.
+	expectChangeScript []byte
+
+	// expectChangeValue is the expected value of the change output.
+	expectChangeValue int64
+
+	// expectLockID is the ID the inputs are expected to be leased with.
+	expectLockID wtxmgr.LockID
+}
+
+// TestFundPsbtOptions asserts that the account, change address and lease ID
+// options of FundPsbt are applied to the funded PSBT and its leases.
+func TestFundPsbtOptions(t *testing.T) {
+	t.Parallel()
+
+	params := &chaincfg.RegressionNetParams
+
+	// The P2WSH change address has a script that is 12 bytes larger than
+	// the P2WKH change script of the wallet, which is paid for at 2500
+	// sat/kw.
+	changeAddr, err := btcutil.NewAddressWitnessScriptHash(
+		make([]byte, 32), params,
+	)
+	require.NoError(t, err)
+	changeScript, err := txscript.PayToAddrScript(changeAddr)
+	require.NoError(t, err)
+
+	mainnetAddr, err := btcutil.NewAddressWitnessScriptHash(
+		make([]byte, 32), &chaincfg.MainNetParams,
+	)
+	require.NoError(t, err)
+
+	const extraFee = 12 * 4 * 2500 / 1000
+
+	leaseID := wtxmgr.LockID{1, 2, 3}
+
+	tests := []fundPsbtTest{
+		{
+			name:               "default options",
+			changeValue:        30000,
+			expectChangeScript: mock.CoinPkScript,
+			expectChangeValue:  30000,
+			expectLockID:       LndInternalLockID,
+		},
+		{
+			name:               "account",
+			changeValue:        30000,
+			account:            "imported",
+			expectChangeScript: mock.CoinPkScript,
+			expectChangeValue:  30000,
+			expectLockID:       LndInternalLockID,
+		},
+		{
+			name:               "change address",
+			changeValue:        30000,
+			changeAddress:      changeAddr.String(),
+			expectChangeScript: changeScript,
+			expectChangeValue:  30000 - extraFee,
+			expectLockID:       LndInternalLockID,
+		},
+		{
+			name:          "change address of other network",
+			changeValue:   30000,
+			changeAddress: mainnetAddr.String(),
+			expectErr:     true,
+		},
+		{
+			name:          "change to change address is dust",
+			changeValue:   400,
+			changeAddress: changeAddr.String(),
+			expectErr:     true,
+		},
+		{
+			name:               "lease ID",
+			changeValue:        30000,
+			leaseID:            leaseID[:],
+			expectChangeScript: mock.CoinPkScript,
+			expectChangeValue:  30000,
+			expectLockID:       leaseID,
+		},
+		{
+			name:        "invalid lease ID",
+			changeValue: 30000,
+			leaseID:     []byte{1, 2, 3},
+			expectErr:   true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			testFundPsbtOptions(t, params, test)
+		})
+	}
+}
+
+func testFundPsbtOptions(t *testing.T, params *chaincfg.Params,
+	test fundPsbtTest) {
+
+	recipient, err := btcutil.NewAddressWitnessPubKeyHash(
+		make([]byte, 20), params,
+	)
+	require.NoError(t, err)
+	recipientScript, err := txscript.PayToAddrScript(recipient)
+	require.NoError(t, err)
+
+	privKey, err := btcec.NewPrivateKey(btcec.S256())
+	require.NoError(t, err)
+
+	// The template spends a UTXO of the wallet, so that the UTXOs of the
+	// account are listed to verify it.
+	utxos := []*lnwallet.Utxo{{
+		AddressType: lnwallet.WitnessPubKey,
+		Value:       btcutil.SatoshiPerBitcoin,
+		PkScript:    mock.CoinPkScript,
+		OutPoint:    wire.OutPoint{Hash: chainhash.Hash{1}},
+	}, {
+		AddressType: lnwallet.WitnessPubKey,
+		Value:       btcutil.SatoshiPerBitcoin,
+		PkScript:    mock.CoinPkScript,
+		OutPoint:    wire.OutPoint{Hash: chainhash.Hash{2}},
+	}}
+
+	wallet := &fundPsbtWallet{
+		WalletController: &mock.WalletController{
+			RootKey: privKey,
+			Utxos:   utxos,
+		},
+		changeValue: test.changeValue,
+	}
+	w := &WalletKit{
+		cfg: &Config{
+			ChainParams:         params,
+			Wallet:              wallet,
+			CoinSelectionLocker: &mockCoinSelectionLocker{},
+		},
+	}
+
+	resp, err := w.FundPsbt(context.Background(), &FundPsbtRequest{
+		Template: &FundPsbtRequest_Raw{
+			Raw: &TxTemplate{
+				Inputs: []*lnrpc.OutPoint{{
+					TxidBytes:   utxos[1].OutPoint.Hash[:],
+					OutputIndex: utxos[1].OutPoint.Index,
+				}},
+				Outputs: map[string]uint64{
+					recipient.String(): 50000,
+				},
+			},
+		},
+		Fees: &FundPsbtRequest_SatPerVbyte{
+			SatPerVbyte: 10,
+		},
+		Account:       test.account,
+		ChangeAddress: test.changeAddress,
+		LeaseId:       test.leaseID,
+	})
+	if test.expectErr {
+		require.Error(t, err)
+		require.Empty(t, wallet.leases)
+		return
+	}
+	require.NoError(t, err)
+
+	// Both listing the UTXOs and funding used the account.
+	require.Equal(t, []string{test.account, test.account}, wallet.accounts)
+
+	packet, err := psbt.NewFromRawBytes(
+		bytes.NewReader(resp.FundedPsbt), false,
+	)
+	require.NoError(t, err)
+
+	txOuts := packet.UnsignedTx.TxOut
+	require.Len(t, txOuts, 2)
+
+	change := txOuts[resp.ChangeOutputIndex]
+	require.Equal(t, test.expectChangeScript, change.PkScript)
+	require.Equal(t, test.expectChangeValue, change.Value)
+	recipientOut := txOuts[1-resp.ChangeOutputIndex]
+	require.Equal(t, recipientScript, recipientOut.PkScript)
+
+	// The derivation info of the wallet's change address is only kept if
+	// the change goes to the wallet.
+	derivation := packet.Outputs[resp.ChangeOutputIndex].Bip32Derivation
+	if test.changeAddress != "" {
+		require.Empty(t, derivation)
+	} else {
+		require.Len(t, derivation, 1)
+	}
+
+	// Both inputs are leased with the expected ID.
+	require.Equal(t, []wtxmgr.LockID{
+		test.expectLockID, test.expectLockID,
+	}, wallet.leases)
+	require.Len(t, resp.LockedUtxos, 2)
+	for _, lease := range resp.LockedUtxos {
+		require.Equal(t, test.expectLockID[:], lease.Id)
+	}
+}
@@ -138,7 +138,11 @@ type ListUnspentRequest struct {
 	// The minimum number of confirmations to be included.
 	MinConfs int32 `protobuf:"varint,1,opt,name=min_confs,json=minConfs,proto3" json:"min_confs,omitempty"`
 	// The maximum number of confirmations to be included.
-	MaxConfs             int32    `protobuf:"varint,2,opt,name=max_confs,json=maxConfs,proto3" json:"max_confs,omitempty"`
+	MaxConfs int32 `protobuf:"varint,2,opt,name=max_confs,json=maxConfs,proto3" json:"max_confs,omitempty"`
+	//
+	//The name of the wallet account to list the unspent outputs of. If empty,
+	//the outputs of all accounts are listed.
+	Account              string   `protobuf:"bytes,3,opt,name=account,proto3" json:"account,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -183,6 +187,13 @@ func (m *ListUnspentRequest) GetMaxConfs() int32 {
 	return 0
 }
 
+func (m *ListUnspentRequest) GetAccount() string {
+	if m != nil {
+		return m.Account
+	}
+	return ""
+}
+
 type ListUnspentResponse struct {
 	// A list of utxos satisfying the specified number of confirmations.
 	Utxos                []*lnrpc.Utxo `protobuf:"bytes,1,rep,name=utxos,proto3" json:"utxos,omitempty"`
@@ -229,10 +240,14 @@ type LeaseOutputRequest struct {
 	//using this RPC which will be used to bound the output lease to.
 	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	// The identifying outpoint of the output being leased.
-	Outpoint             *lnrpc.OutPoint `protobuf:"bytes,2,opt,name=outpoint,proto3" json:"outpoint,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+	Outpoint *lnrpc.OutPoint `protobuf:"bytes,2,opt,name=outpoint,proto3" json:"outpoint,omitempty"`
+	//
+	//The number of seconds the output should be leased for. If not set, the
+	//output is leased for the default duration of 10 minutes.
+	ExpirationSeconds    uint64   `protobuf:"varint,3,opt,name=expiration_seconds,json=expirationSeconds,proto3" json:"expiration_seconds,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *LeaseOutputRequest) Reset()         { *m = LeaseOutputRequest{} }
@@ -274,6 +289,13 @@ func (m *LeaseOutputRequest) GetOutpoint() *lnrpc.OutPoint {
 	return nil
 }
 
+func (m *LeaseOutputRequest) GetExpirationSeconds() uint64 {
+	if m != nil {
+		return m.ExpirationSeconds
+	}
+	return 0
+}
+
 type LeaseOutputResponse struct {
 	//
 	//The absolute expiration of the output lease represented as a unix timestamp.
@@ -377,79 +399,652 @@ func (*ReleaseOutputResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_6cc6942ac78249e5, []int{5}
 }
 
-func (m *ReleaseOutputResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ReleaseOutputResponse.Unmarshal(m, b)
+func (m *ReleaseOutputResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReleaseOutputResponse.Unmarshal(m, b)
+}
+func (m *ReleaseOutputResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReleaseOutputResponse.Marshal(b, m, deterministic)
+}
+func (m *ReleaseOutputResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReleaseOutputResponse.Merge(m, src)
+}
+func (m *ReleaseOutputResponse) XXX_Size() int {
+	return xxx_messageInfo_ReleaseOutputResponse.Size(m)
+}
+func (m *ReleaseOutputResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReleaseOutputResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReleaseOutputResponse proto.InternalMessageInfo
+
+type ListLeasesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListLeasesRequest) Reset()         { *m = ListLeasesRequest{} }
+func (m *ListLeasesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListLeasesRequest) ProtoMessage()    {}
+func (*ListLeasesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6cc6942ac78249e5, []int{6}
+}
+
+func (m *ListLeasesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListLeasesRequest.Unmarshal(m, b)
+}
+func (m *ListLeasesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListLeasesRequest.Marshal(b, m, deterministic)
+}
+func (m *ListLeasesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListLeasesRequest.Merge(m, src)
+}
+func (m *ListLeasesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListLeasesRequest.Size(m)
+}
+func (m *ListLeasesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListLeasesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListLeasesRequest proto.InternalMessageInfo
+
+type ListLeasesResponse struct {
+	// The list of all currently leased outputs.
+	LockedUtxos          []*UtxoLease `protobuf:"bytes,1,rep,name=locked_utxos,json=lockedUtxos,proto3" json:"locked_utxos,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ListLeasesResponse) Reset()         { *m = ListLeasesResponse{} }
+func (m *ListLeasesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListLeasesResponse) ProtoMessage()    {}
+func (*ListLeasesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6cc6942ac78249e5, []int{7}
+}
+
+func (m *ListLeasesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListLeasesResponse.Unmarshal(m, b)
+}
+func (m *ListLeasesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListLeasesResponse.Marshal(b, m, deterministic)
+}
+func (m *ListLeasesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListLeasesResponse.Merge(m, src)
+}
+func (m *ListLeasesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListLeasesResponse.Size(m)
+}
+func (m *ListLeasesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListLeasesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListLeasesResponse proto.InternalMessageInfo
+
+func (m *ListLeasesResponse) GetLockedUtxos() []*UtxoLease {
+	if m != nil {
+		return m.LockedUtxos
+	}
+	return nil
+}
+
+type LabelOutputRequest struct {
+	// The identifying outpoint of the output being labeled.
+	Outpoint *lnrpc.OutPoint `protobuf:"bytes,1,opt,name=outpoint,proto3" json:"outpoint,omitempty"`
+	// The label to add to the output, limited to 500 characters.
+	Label string `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	// Whether to overwrite the existing label, if it is present.
+	Overwrite            bool     `protobuf:"varint,3,opt,name=overwrite,proto3" json:"overwrite,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LabelOutputRequest) Reset()         { *m = LabelOutputRequest{} }
+func (m *LabelOutputRequest) String() string { return proto.CompactTextString(m) }
+func (*LabelOutputRequest) ProtoMessage()    {}
+func (*LabelOutputRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6cc6942ac78249e5, []int{8}
+}
+
+func (m *LabelOutputRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LabelOutputRequest.Unmarshal(m, b)
+}
+func (m *LabelOutputRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LabelOutputRequest.Marshal(b, m, deterministic)
+}
+func (m *LabelOutputRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LabelOutputRequest.Merge(m, src)
+}
+func (m *LabelOutputRequest) XXX_Size() int {
+	return xxx_messageInfo_LabelOutputRequest.Size(m)
+}
+func (m *LabelOutputRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LabelOutputRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LabelOutputRequest proto.InternalMessageInfo
+
+func (m *LabelOutputRequest) GetOutpoint() *lnrpc.OutPoint {
+	if m != nil {
+		return m.Outpoint
+	}
+	return nil
+}
+
+func (m *LabelOutputRequest) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+func (m *LabelOutputRequest) GetOverwrite() bool {
+	if m != nil {
+		return m.Overwrite
+	}
+	return false
+}
+
+type LabelOutputResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LabelOutputResponse) Reset()         { *m = LabelOutputResponse{} }
+func (m *LabelOutputResponse) String() string { return proto.CompactTextString(m) }
+func (*LabelOutputResponse) ProtoMessage()    {}
+func (*LabelOutputResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6cc6942ac78249e5, []int{9}
+}
+
+func (m *LabelOutputResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LabelOutputResponse.Unmarshal(m, b)
+}
+func (m *LabelOutputResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LabelOutputResponse.Marshal(b, m, deterministic)
+}
+func (m *LabelOutputResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LabelOutputResponse.Merge(m, src)
+}
+func (m *LabelOutputResponse) XXX_Size() int {
+	return xxx_messageInfo_LabelOutputResponse.Size(m)
+}
+func (m *LabelOutputResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LabelOutputResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LabelOutputResponse proto.InternalMessageInfo
+
+type FreezeOutputRequest struct {
+	// The identifying outpoint of the output being frozen.
+	Outpoint             *lnrpc.OutPoint `protobuf:"bytes,1,opt,name=outpoint,proto3" json:"outpoint,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *FreezeOutputRequest) Reset()         { *m = FreezeOutputRequest{} }
+func (m *FreezeOutputRequest) String() string { return proto.CompactTextString(m) }
+func (*FreezeOutputRequest) ProtoMessage()    {}
+func (*FreezeOutputRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6cc6942ac78249e5, []int{10}
+}
+
+func (m *FreezeOutputRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FreezeOutputRequest.Unmarshal(m, b)
+}
+func (m *FreezeOutputRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FreezeOutputRequest.Marshal(b, m, deterministic)
+}
+func (m *FreezeOutputRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FreezeOutputRequest.Merge(m, src)
+}
+func (m *FreezeOutputRequest) XXX_Size() int {
+	return xxx_messageInfo_FreezeOutputRequest.Size(m)
+}
+func (m *FreezeOutputRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FreezeOutputRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FreezeOutputRequest proto.InternalMessageInfo
+
+func (m *FreezeOutputRequest) GetOutpoint() *lnrpc.OutPoint {
+	if m != nil {
+		return m.Outpoint
+	}
+	return nil
+}
+
+type FreezeOutputResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FreezeOutputResponse) Reset()         { *m = FreezeOutputResponse{} }
+func (m *FreezeOutputResponse) String() string { return proto.CompactTextString(m) }
+func (*FreezeOutputResponse) ProtoMessage()    {}
+func (*FreezeOutputResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6cc6942ac78249e5, []int{11}
+}
+
+func (m *FreezeOutputResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FreezeOutputResponse.Unmarshal(m, b)
+}
+func (m *FreezeOutputResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FreezeOutputResponse.Marshal(b, m, deterministic)
+}
+func (m *FreezeOutputResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FreezeOutputResponse.Merge(m, src)
+}
+func (m *FreezeOutputResponse) XXX_Size() int {
+	return xxx_messageInfo_FreezeOutputResponse.Size(m)
+}
+func (m *FreezeOutputResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FreezeOutputResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FreezeOutputResponse proto.InternalMessageInfo
+
+type UnfreezeOutputRequest struct {
+	// The identifying outpoint of the output being unfrozen.
+	Outpoint             *lnrpc.OutPoint `protobuf:"bytes,1,opt,name=outpoint,proto3" json:"outpoint,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *UnfreezeOutputRequest) Reset()         { *m = UnfreezeOutputRequest{} }
+func (m *UnfreezeOutputRequest) String() string { return proto.CompactTextString(m) }
+func (*UnfreezeOutputRequest) ProtoMessage()    {}
+func (*UnfreezeOutputRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6cc6942ac78249e5, []int{12}
+}
+
+func (m *UnfreezeOutputRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnfreezeOutputRequest.Unmarshal(m, b)
+}
+func (m *UnfreezeOutputRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnfreezeOutputRequest.Marshal(b, m, deterministic)
+}
+func (m *UnfreezeOutputRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnfreezeOutputRequest.Merge(m, src)
+}
+func (m *UnfreezeOutputRequest) XXX_Size() int {
+	return xxx_messageInfo_UnfreezeOutputRequest.Size(m)
+}
+func (m *UnfreezeOutputRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnfreezeOutputRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnfreezeOutputRequest proto.InternalMessageInfo
+
+func (m *UnfreezeOutputRequest) GetOutpoint() *lnrpc.OutPoint {
+	if m != nil {
+		return m.Outpoint
+	}
+	return nil
+}
+
+type UnfreezeOutputResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnfreezeOutputResponse) Reset()         { *m = UnfreezeOutputResponse{} }
+func (m *UnfreezeOutputResponse) String() string { return proto.CompactTextString(m) }
+func (*UnfreezeOutputResponse) ProtoMessage()    {}
+func (*UnfreezeOutputResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6cc6942ac78249e5, []int{13}
+}
+
+func (m *UnfreezeOutputResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnfreezeOutputResponse.Unmarshal(m, b)
+}
+func (m *UnfreezeOutputResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnfreezeOutputResponse.Marshal(b, m, deterministic)
+}
+func (m *UnfreezeOutputResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnfreezeOutputResponse.Merge(m, src)
+}
+func (m *UnfreezeOutputResponse) XXX_Size() int {
+	return xxx_messageInfo_UnfreezeOutputResponse.Size(m)
+}
+func (m *UnfreezeOutputResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnfreezeOutputResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnfreezeOutputResponse proto.InternalMessageInfo
+
+type KeyReq struct {
+	//
+	//Is the key finger print of the root pubkey that this request is targeting.
+	//This allows the WalletKit to possibly serve out keys for multiple HD chains
+	//via public derivation.
+	KeyFingerPrint int32 `protobuf:"varint,1,opt,name=key_finger_print,json=keyFingerPrint,proto3" json:"key_finger_print,omitempty"`
+	//
+	//The target key family to derive a key from. In other contexts, this is
+	//known as the "account".
+	KeyFamily            int32    `protobuf:"varint,2,opt,name=key_family,json=keyFamily,proto3" json:"key_family,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KeyReq) Reset()         { *m = KeyReq{} }
+func (m *KeyReq) String() string { return proto.CompactTextString(m) }
+func (*KeyReq) ProtoMessage()    {}
+func (*KeyReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6cc6942ac78249e5, []int{14}
+}
+
+func (m *KeyReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KeyReq.Unmarshal(m, b)
+}
+func (m *KeyReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KeyReq.Marshal(b, m, deterministic)
+}
+func (m *KeyReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KeyReq.Merge(m, src)
+}
+func (m *KeyReq) XXX_Size() int {
+	return xxx_messageInfo_KeyReq.Size(m)
+}
+func (m *KeyReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_KeyReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KeyReq proto.InternalMessageInfo
+
+func (m *KeyReq) GetKeyFingerPrint() int32 {
+	if m != nil {
+		return m.KeyFingerPrint
+	}
+	return 0
+}
+
+func (m *KeyReq) GetKeyFamily() int32 {
+	if m != nil {
+		return m.KeyFamily
+	}
+	return 0
+}
+
+type ReserveKeyFamiliesRequest struct {
+	// The unique name of the application reserving the key families.
+	Owner string `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	// The first key family of the range to reserve.
+	FirstKeyFamily int32 `protobuf:"varint,2,opt,name=first_key_family,json=firstKeyFamily,proto3" json:"first_key_family,omitempty"`
+	// The last key family of the range to reserve, inclusive.
+	LastKeyFamily        int32    `protobuf:"varint,3,opt,name=last_key_family,json=lastKeyFamily,proto3" json:"last_key_family,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReserveKeyFamiliesRequest) Reset()         { *m = ReserveKeyFamiliesRequest{} }
+func (m *ReserveKeyFamiliesRequest) String() string { return proto.CompactTextString(m) }
+func (*ReserveKeyFamiliesRequest) ProtoMessage()    {}
+func (*ReserveKeyFamiliesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6cc6942ac78249e5, []int{15}
+}
+
+func (m *ReserveKeyFamiliesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReserveKeyFamiliesRequest.Unmarshal(m, b)
+}
+func (m *ReserveKeyFamiliesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReserveKeyFamiliesRequest.Marshal(b, m, deterministic)
+}
+func (m *ReserveKeyFamiliesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReserveKeyFamiliesRequest.Merge(m, src)
+}
+func (m *ReserveKeyFamiliesRequest) XXX_Size() int {
+	return xxx_messageInfo_ReserveKeyFamiliesRequest.Size(m)
+}
+func (m *ReserveKeyFamiliesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReserveKeyFamiliesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReserveKeyFamiliesRequest proto.InternalMessageInfo
+
+func (m *ReserveKeyFamiliesRequest) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+func (m *ReserveKeyFamiliesRequest) GetFirstKeyFamily() int32 {
+	if m != nil {
+		return m.FirstKeyFamily
+	}
+	return 0
+}
+
+func (m *ReserveKeyFamiliesRequest) GetLastKeyFamily() int32 {
+	if m != nil {
+		return m.LastKeyFamily
+	}
+	return 0
+}
+
+type KeyFamilyReservation struct {
+	// The unique name of the application holding the reservation.
+	Owner string `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	// The first key family of the reserved range.
+	FirstKeyFamily int32 `protobuf:"varint,2,opt,name=first_key_family,json=firstKeyFamily,proto3" json:"first_key_family,omitempty"`
+	// The last key family of the reserved range, inclusive.
+	LastKeyFamily        int32    `protobuf:"varint,3,opt,name=last_key_family,json=lastKeyFamily,proto3" json:"last_key_family,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KeyFamilyReservation) Reset()         { *m = KeyFamilyReservation{} }
+func (m *KeyFamilyReservation) String() string { return proto.CompactTextString(m) }
+func (*KeyFamilyReservation) ProtoMessage()    {}
+func (*KeyFamilyReservation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6cc6942ac78249e5, []int{16}
+}
+
+func (m *KeyFamilyReservation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KeyFamilyReservation.Unmarshal(m, b)
+}
+func (m *KeyFamilyReservation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KeyFamilyReservation.Marshal(b, m, deterministic)
+}
+func (m *KeyFamilyReservation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KeyFamilyReservation.Merge(m, src)
+}
+func (m *KeyFamilyReservation) XXX_Size() int {
+	return xxx_messageInfo_KeyFamilyReservation.Size(m)
+}
+func (m *KeyFamilyReservation) XXX_DiscardUnknown() {
+	xxx_messageInfo_KeyFamilyReservation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KeyFamilyReservation proto.InternalMessageInfo
+
+func (m *KeyFamilyReservation) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+func (m *KeyFamilyReservation) GetFirstKeyFamily() int32 {
+	if m != nil {
+		return m.FirstKeyFamily
+	}
+	return 0
+}
+
+func (m *KeyFamilyReservation) GetLastKeyFamily() int32 {
+	if m != nil {
+		return m.LastKeyFamily
+	}
+	return 0
+}
+
+type ReleaseKeyFamiliesRequest struct {
+	// The name of the application whose reservation should be released.
+	Owner                string   `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReleaseKeyFamiliesRequest) Reset()         { *m = ReleaseKeyFamiliesRequest{} }
+func (m *ReleaseKeyFamiliesRequest) String() string { return proto.CompactTextString(m) }
+func (*ReleaseKeyFamiliesRequest) ProtoMessage()    {}
+func (*ReleaseKeyFamiliesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6cc6942ac78249e5, []int{17}
+}
+
+func (m *ReleaseKeyFamiliesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReleaseKeyFamiliesRequest.Unmarshal(m, b)
+}
+func (m *ReleaseKeyFamiliesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReleaseKeyFamiliesRequest.Marshal(b, m, deterministic)
+}
+func (m *ReleaseKeyFamiliesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReleaseKeyFamiliesRequest.Merge(m, src)
+}
+func (m *ReleaseKeyFamiliesRequest) XXX_Size() int {
+	return xxx_messageInfo_ReleaseKeyFamiliesRequest.Size(m)
+}
+func (m *ReleaseKeyFamiliesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReleaseKeyFamiliesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReleaseKeyFamiliesRequest proto.InternalMessageInfo
+
+func (m *ReleaseKeyFamiliesRequest) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+type ReleaseKeyFamiliesResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReleaseKeyFamiliesResponse) Reset()         { *m = ReleaseKeyFamiliesResponse{} }
+func (m *ReleaseKeyFamiliesResponse) String() string { return proto.CompactTextString(m) }
+func (*ReleaseKeyFamiliesResponse) ProtoMessage()    {}
+func (*ReleaseKeyFamiliesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6cc6942ac78249e5, []int{18}
+}
+
+func (m *ReleaseKeyFamiliesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReleaseKeyFamiliesResponse.Unmarshal(m, b)
+}
+func (m *ReleaseKeyFamiliesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReleaseKeyFamiliesResponse.Marshal(b, m, deterministic)
+}
+func (m *ReleaseKeyFamiliesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReleaseKeyFamiliesResponse.Merge(m, src)
+}
+func (m *ReleaseKeyFamiliesResponse) XXX_Size() int {
+	return xxx_messageInfo_ReleaseKeyFamiliesResponse.Size(m)
+}
+func (m *ReleaseKeyFamiliesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReleaseKeyFamiliesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReleaseKeyFamiliesResponse proto.InternalMessageInfo
+
+type ListKeyFamilyReservationsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListKeyFamilyReservationsRequest) Reset()         { *m = ListKeyFamilyReservationsRequest{} }
+func (m *ListKeyFamilyReservationsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListKeyFamilyReservationsRequest) ProtoMessage()    {}
+func (*ListKeyFamilyReservationsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6cc6942ac78249e5, []int{19}
+}
+
+func (m *ListKeyFamilyReservationsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListKeyFamilyReservationsRequest.Unmarshal(m, b)
 }
-func (m *ReleaseOutputResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ReleaseOutputResponse.Marshal(b, m, deterministic)
+func (m *ListKeyFamilyReservationsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListKeyFamilyReservationsRequest.Marshal(b, m, deterministic)
 }
-func (m *ReleaseOutputResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ReleaseOutputResponse.Merge(m, src)
+func (m *ListKeyFamilyReservationsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListKeyFamilyReservationsRequest.Merge(m, src)
 }
-func (m *ReleaseOutputResponse) XXX_Size() int {
-	return xxx_messageInfo_ReleaseOutputResponse.Size(m)
+func (m *ListKeyFamilyReservationsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListKeyFamilyReservationsRequest.Size(m)
 }
-func (m *ReleaseOutputResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ReleaseOutputResponse.DiscardUnknown(m)
+func (m *ListKeyFamilyReservationsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListKeyFamilyReservationsRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ReleaseOutputResponse proto.InternalMessageInfo
+var xxx_messageInfo_ListKeyFamilyReservationsRequest proto.InternalMessageInfo
 
-type KeyReq struct {
-	//
-	//Is the key finger print of the root pubkey that this request is targeting.
-	//This allows the WalletKit to possibly serve out keys for multiple HD chains
-	//via public derivation.
-	KeyFingerPrint int32 `protobuf:"varint,1,opt,name=key_finger_print,json=keyFingerPrint,proto3" json:"key_finger_print,omitempty"`
-	//
-	//The target key family to derive a key from. In other contexts, this is
-	//known as the "account".
-	KeyFamily            int32    `protobuf:"varint,2,opt,name=key_family,json=keyFamily,proto3" json:"key_family,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type ListKeyFamilyReservationsResponse struct {
+	// All reserved key family ranges, ordered by their first key family.
+	Reservations []*KeyFamilyReservation `protobuf:"bytes,1,rep,name=reservations,proto3" json:"reservations,omitempty"`
+	// The first key family that can be reserved by external applications.
+	FirstReservableKeyFamily int32    `protobuf:"varint,2,opt,name=first_reservable_key_family,json=firstReservableKeyFamily,proto3" json:"first_reservable_key_family,omitempty"`
+	XXX_NoUnkeyedLiteral     struct{} `json:"-"`
+	XXX_unrecognized         []byte   `json:"-"`
+	XXX_sizecache            int32    `json:"-"`
 }
 
-func (m *KeyReq) Reset()         { *m = KeyReq{} }
-func (m *KeyReq) String() string { return proto.CompactTextString(m) }
-func (*KeyReq) ProtoMessage()    {}
-func (*KeyReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6cc6942ac78249e5, []int{6}
+func (m *ListKeyFamilyReservationsResponse) Reset()         { *m = ListKeyFamilyReservationsResponse{} }
+func (m *ListKeyFamilyReservationsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListKeyFamilyReservationsResponse) ProtoMessage()    {}
+func (*ListKeyFamilyReservationsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6cc6942ac78249e5, []int{20}
 }
 
-func (m *KeyReq) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_KeyReq.Unmarshal(m, b)
+func (m *ListKeyFamilyReservationsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListKeyFamilyReservationsResponse.Unmarshal(m, b)
 }
-func (m *KeyReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_KeyReq.Marshal(b, m, deterministic)
+func (m *ListKeyFamilyReservationsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListKeyFamilyReservationsResponse.Marshal(b, m, deterministic)
 }
-func (m *KeyReq) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_KeyReq.Merge(m, src)
+func (m *ListKeyFamilyReservationsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListKeyFamilyReservationsResponse.Merge(m, src)
 }
-func (m *KeyReq) XXX_Size() int {
-	return xxx_messageInfo_KeyReq.Size(m)
+func (m *ListKeyFamilyReservationsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListKeyFamilyReservationsResponse.Size(m)
 }
-func (m *KeyReq) XXX_DiscardUnknown() {
-	xxx_messageInfo_KeyReq.DiscardUnknown(m)
+func (m *ListKeyFamilyReservationsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListKeyFamilyReservationsResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_KeyReq proto.InternalMessageInfo
+var xxx_messageInfo_ListKeyFamilyReservationsResponse proto.InternalMessageInfo
 
-func (m *KeyReq) GetKeyFingerPrint() int32 {
+func (m *ListKeyFamilyReservationsResponse) GetReservations() []*KeyFamilyReservation {
 	if m != nil {
-		return m.KeyFingerPrint
+		return m.Reservations
 	}
-	return 0
+	return nil
 }
 
-func (m *KeyReq) GetKeyFamily() int32 {
+func (m *ListKeyFamilyReservationsResponse) GetFirstReservableKeyFamily() int32 {
 	if m != nil {
-		return m.KeyFamily
+		return m.FirstReservableKeyFamily
 	}
 	return 0
 }
 
 type AddrRequest struct {
+	//
+	//The name of the wallet account to derive the p2wkh address from. If empty,
+	//the address is derived from the default account.
+	Account              string   `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -459,7 +1054,7 @@ func (m *AddrRequest) Reset()         { *m = AddrRequest{} }
 func (m *AddrRequest) String() string { return proto.CompactTextString(m) }
 func (*AddrRequest) ProtoMessage()    {}
 func (*AddrRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6cc6942ac78249e5, []int{7}
+	return fileDescriptor_6cc6942ac78249e5, []int{21}
 }
 
 func (m *AddrRequest) XXX_Unmarshal(b []byte) error {
@@ -480,6 +1075,13 @@ func (m *AddrRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_AddrRequest proto.InternalMessageInfo
 
+func (m *AddrRequest) GetAccount() string {
+	if m != nil {
+		return m.Account
+	}
+	return ""
+}
+
 type AddrResponse struct {
 	//
 	//The address encoded using a bech32 format.
@@ -493,7 +1095,7 @@ func (m *AddrResponse) Reset()         { *m = AddrResponse{} }
 func (m *AddrResponse) String() string { return proto.CompactTextString(m) }
 func (*AddrResponse) ProtoMessage()    {}
 func (*AddrResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6cc6942ac78249e5, []int{8}
+	return fileDescriptor_6cc6942ac78249e5, []int{22}
 }
 
 func (m *AddrResponse) XXX_Unmarshal(b []byte) error {
@@ -537,7 +1139,7 @@ func (m *Transaction) Reset()         { *m = Transaction{} }
 func (m *Transaction) String() string { return proto.CompactTextString(m) }
 func (*Transaction) ProtoMessage()    {}
 func (*Transaction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6cc6942ac78249e5, []int{9}
+	return fileDescriptor_6cc6942ac78249e5, []int{23}
 }
 
 func (m *Transaction) XXX_Unmarshal(b []byte) error {
@@ -589,7 +1191,7 @@ func (m *PublishResponse) Reset()         { *m = PublishResponse{} }
 func (m *PublishResponse) String() string { return proto.CompactTextString(m) }
 func (*PublishResponse) ProtoMessage()    {}
 func (*PublishResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6cc6942ac78249e5, []int{10}
+	return fileDescriptor_6cc6942ac78249e5, []int{24}
 }
 
 func (m *PublishResponse) XXX_Unmarshal(b []byte) error {
@@ -631,7 +1233,11 @@ type SendOutputsRequest struct {
 	// the transaction must satisfy.
 	MinConfs int32 `protobuf:"varint,4,opt,name=min_confs,json=minConfs,proto3" json:"min_confs,omitempty"`
 	// Whether unconfirmed outputs should be used as inputs for the transaction.
-	SpendUnconfirmed     bool     `protobuf:"varint,5,opt,name=spend_unconfirmed,json=spendUnconfirmed,proto3" json:"spend_unconfirmed,omitempty"`
+	SpendUnconfirmed bool `protobuf:"varint,5,opt,name=spend_unconfirmed,json=spendUnconfirmed,proto3" json:"spend_unconfirmed,omitempty"`
+	//
+	//The name of the wallet account to spend the coins of. If empty, only the
+	//coins of the default account are spent.
+	Account              string   `protobuf:"bytes,6,opt,name=account,proto3" json:"account,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -641,7 +1247,7 @@ func (m *SendOutputsRequest) Reset()         { *m = SendOutputsRequest{} }
 func (m *SendOutputsRequest) String() string { return proto.CompactTextString(m) }
 func (*SendOutputsRequest) ProtoMessage()    {}
 func (*SendOutputsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6cc6942ac78249e5, []int{11}
+	return fileDescriptor_6cc6942ac78249e5, []int{25}
 }
 
 func (m *SendOutputsRequest) XXX_Unmarshal(b []byte) error {
@@ -697,6 +1303,13 @@ func (m *SendOutputsRequest) GetSpendUnconfirmed() bool {
 	return false
 }
 
+func (m *SendOutputsRequest) GetAccount() string {
+	if m != nil {
+		return m.Account
+	}
+	return ""
+}
+
 type SendOutputsResponse struct {
 	//
 	//The serialized transaction sent out on the network.
@@ -710,7 +1323,7 @@ func (m *SendOutputsResponse) Reset()         { *m = SendOutputsResponse{} }
 func (m *SendOutputsResponse) String() string { return proto.CompactTextString(m) }
 func (*SendOutputsResponse) ProtoMessage()    {}
 func (*SendOutputsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6cc6942ac78249e5, []int{12}
+	return fileDescriptor_6cc6942ac78249e5, []int{26}
 }
 
 func (m *SendOutputsResponse) XXX_Unmarshal(b []byte) error {
@@ -751,7 +1364,7 @@ func (m *EstimateFeeRequest) Reset()         { *m = EstimateFeeRequest{} }
 func (m *EstimateFeeRequest) String() string { return proto.CompactTextString(m) }
 func (*EstimateFeeRequest) ProtoMessage()    {}
 func (*EstimateFeeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6cc6942ac78249e5, []int{13}
+	return fileDescriptor_6cc6942ac78249e5, []int{27}
 }
 
 func (m *EstimateFeeRequest) XXX_Unmarshal(b []byte) error {
@@ -793,7 +1406,7 @@ func (m *EstimateFeeResponse) Reset()         { *m = EstimateFeeResponse{} }
 func (m *EstimateFeeResponse) String() string { return proto.CompactTextString(m) }
 func (*EstimateFeeResponse) ProtoMessage()    {}
 func (*EstimateFeeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6cc6942ac78249e5, []int{14}
+	return fileDescriptor_6cc6942ac78249e5, []int{28}
 }
 
 func (m *EstimateFeeResponse) XXX_Unmarshal(b []byte) error {
@@ -856,7 +1469,7 @@ func (m *PendingSweep) Reset()         { *m = PendingSweep{} }
 func (m *PendingSweep) String() string { return proto.CompactTextString(m) }
 func (*PendingSweep) ProtoMessage()    {}
 func (*PendingSweep) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6cc6942ac78249e5, []int{15}
+	return fileDescriptor_6cc6942ac78249e5, []int{29}
 }
 
 func (m *PendingSweep) XXX_Unmarshal(b []byte) error {
@@ -950,7 +1563,7 @@ func (m *PendingSweepsRequest) Reset()         { *m = PendingSweepsRequest{} }
 func (m *PendingSweepsRequest) String() string { return proto.CompactTextString(m) }
 func (*PendingSweepsRequest) ProtoMessage()    {}
 func (*PendingSweepsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6cc6942ac78249e5, []int{16}
+	return fileDescriptor_6cc6942ac78249e5, []int{30}
 }
 
 func (m *PendingSweepsRequest) XXX_Unmarshal(b []byte) error {
@@ -984,7 +1597,7 @@ func (m *PendingSweepsResponse) Reset()         { *m = PendingSweepsResponse{} }
 func (m *PendingSweepsResponse) String() string { return proto.CompactTextString(m) }
 func (*PendingSweepsResponse) ProtoMessage()    {}
 func (*PendingSweepsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6cc6942ac78249e5, []int{17}
+	return fileDescriptor_6cc6942ac78249e5, []int{31}
 }
 
 func (m *PendingSweepsResponse) XXX_Unmarshal(b []byte) error {
@@ -1034,7 +1647,7 @@ func (m *BumpFeeRequest) Reset()         { *m = BumpFeeRequest{} }
 func (m *BumpFeeRequest) String() string { return proto.CompactTextString(m) }
 func (*BumpFeeRequest) ProtoMessage()    {}
 func (*BumpFeeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6cc6942ac78249e5, []int{18}
+	return fileDescriptor_6cc6942ac78249e5, []int{32}
 }
 
 func (m *BumpFeeRequest) XXX_Unmarshal(b []byte) error {
@@ -1093,7 +1706,7 @@ func (m *BumpFeeResponse) Reset()         { *m = BumpFeeResponse{} }
 func (m *BumpFeeResponse) String() string { return proto.CompactTextString(m) }
 func (*BumpFeeResponse) ProtoMessage()    {}
 func (*BumpFeeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6cc6942ac78249e5, []int{19}
+	return fileDescriptor_6cc6942ac78249e5, []int{33}
 }
 
 func (m *BumpFeeResponse) XXX_Unmarshal(b []byte) error {
@@ -1128,7 +1741,7 @@ func (m *ListSweepsRequest) Reset()         { *m = ListSweepsRequest{} }
 func (m *ListSweepsRequest) String() string { return proto.CompactTextString(m) }
 func (*ListSweepsRequest) ProtoMessage()    {}
 func (*ListSweepsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6cc6942ac78249e5, []int{20}
+	return fileDescriptor_6cc6942ac78249e5, []int{34}
 }
 
 func (m *ListSweepsRequest) XXX_Unmarshal(b []byte) error {
@@ -1170,7 +1783,7 @@ func (m *ListSweepsResponse) Reset()         { *m = ListSweepsResponse{} }
 func (m *ListSweepsResponse) String() string { return proto.CompactTextString(m) }
 func (*ListSweepsResponse) ProtoMessage()    {}
 func (*ListSweepsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6cc6942ac78249e5, []int{21}
+	return fileDescriptor_6cc6942ac78249e5, []int{35}
 }
 
 func (m *ListSweepsResponse) XXX_Unmarshal(b []byte) error {
@@ -1251,7 +1864,7 @@ func (m *ListSweepsResponse_TransactionIDs) Reset()         { *m = ListSweepsRes
 func (m *ListSweepsResponse_TransactionIDs) String() string { return proto.CompactTextString(m) }
 func (*ListSweepsResponse_TransactionIDs) ProtoMessage()    {}
 func (*ListSweepsResponse_TransactionIDs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6cc6942ac78249e5, []int{21, 0}
+	return fileDescriptor_6cc6942ac78249e5, []int{35, 0}
 }
 
 func (m *ListSweepsResponse_TransactionIDs) XXX_Unmarshal(b []byte) error {
@@ -1295,7 +1908,7 @@ func (m *LabelTransactionRequest) Reset()         { *m = LabelTransactionRequest
 func (m *LabelTransactionRequest) String() string { return proto.CompactTextString(m) }
 func (*LabelTransactionRequest) ProtoMessage()    {}
 func (*LabelTransactionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6cc6942ac78249e5, []int{22}
+	return fileDescriptor_6cc6942ac78249e5, []int{36}
 }
 
 func (m *LabelTransactionRequest) XXX_Unmarshal(b []byte) error {
@@ -1347,7 +1960,7 @@ func (m *LabelTransactionResponse) Reset()         { *m = LabelTransactionRespon
 func (m *LabelTransactionResponse) String() string { return proto.CompactTextString(m) }
 func (*LabelTransactionResponse) ProtoMessage()    {}
 func (*LabelTransactionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6cc6942ac78249e5, []int{23}
+	return fileDescriptor_6cc6942ac78249e5, []int{37}
 }
 
 func (m *LabelTransactionResponse) XXX_Unmarshal(b []byte) error {
@@ -1368,6 +1981,314 @@ func (m *LabelTransactionResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_LabelTransactionResponse proto.InternalMessageInfo
 
+type Account struct {
+	// The name of the account.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The number of the account, which is its index in the derivation path.
+	Number uint32 `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`
+	// The number of external addresses that were derived from the account.
+	ExternalKeyCount uint32 `protobuf:"varint,3,opt,name=external_key_count,json=externalKeyCount,proto3" json:"external_key_count,omitempty"`
+	// The number of internal (change) addresses that were derived from the
+	// account.
+	InternalKeyCount     uint32   `protobuf:"varint,4,opt,name=internal_key_count,json=internalKeyCount,proto3" json:"internal_key_count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Account) Reset()         { *m = Account{} }
+func (m *Account) String() string { return proto.CompactTextString(m) }
+func (*Account) ProtoMessage()    {}
+func (*Account) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6cc6942ac78249e5, []int{38}
+}
+
+func (m *Account) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Account.Unmarshal(m, b)
+}
+func (m *Account) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Account.Marshal(b, m, deterministic)
+}
+func (m *Account) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Account.Merge(m, src)
+}
+func (m *Account) XXX_Size() int {
+	return xxx_messageInfo_Account.Size(m)
+}
+func (m *Account) XXX_DiscardUnknown() {
+	xxx_messageInfo_Account.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Account proto.InternalMessageInfo
+
+func (m *Account) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Account) GetNumber() uint32 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+func (m *Account) GetExternalKeyCount() uint32 {
+	if m != nil {
+		return m.ExternalKeyCount
+	}
+	return 0
+}
+
+func (m *Account) GetInternalKeyCount() uint32 {
+	if m != nil {
+		return m.InternalKeyCount
+	}
+	return 0
+}
+
+type ListAccountsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListAccountsRequest) Reset()         { *m = ListAccountsRequest{} }
+func (m *ListAccountsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListAccountsRequest) ProtoMessage()    {}
+func (*ListAccountsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6cc6942ac78249e5, []int{39}
+}
+
+func (m *ListAccountsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAccountsRequest.Unmarshal(m, b)
+}
+func (m *ListAccountsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAccountsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListAccountsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAccountsRequest.Merge(m, src)
+}
+func (m *ListAccountsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListAccountsRequest.Size(m)
+}
+func (m *ListAccountsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAccountsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAccountsRequest proto.InternalMessageInfo
+
+type ListAccountsResponse struct {
+	// The accounts of the wallet.
+	Accounts             []*Account `protobuf:"bytes,1,rep,name=accounts,proto3" json:"accounts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ListAccountsResponse) Reset()         { *m = ListAccountsResponse{} }
+func (m *ListAccountsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListAccountsResponse) ProtoMessage()    {}
+func (*ListAccountsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6cc6942ac78249e5, []int{40}
+}
+
+func (m *ListAccountsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAccountsResponse.Unmarshal(m, b)
+}
+func (m *ListAccountsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAccountsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListAccountsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAccountsResponse.Merge(m, src)
+}
+func (m *ListAccountsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListAccountsResponse.Size(m)
+}
+func (m *ListAccountsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAccountsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAccountsResponse proto.InternalMessageInfo
+
+func (m *ListAccountsResponse) GetAccounts() []*Account {
+	if m != nil {
+		return m.Accounts
+	}
+	return nil
+}
+
+type CreateAccountRequest struct {
+	// The name of the new account, which must be unique.
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateAccountRequest) Reset()         { *m = CreateAccountRequest{} }
+func (m *CreateAccountRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateAccountRequest) ProtoMessage()    {}
+func (*CreateAccountRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6cc6942ac78249e5, []int{41}
+}
+
+func (m *CreateAccountRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateAccountRequest.Unmarshal(m, b)
+}
+func (m *CreateAccountRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateAccountRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateAccountRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateAccountRequest.Merge(m, src)
+}
+func (m *CreateAccountRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateAccountRequest.Size(m)
+}
+func (m *CreateAccountRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateAccountRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateAccountRequest proto.InternalMessageInfo
+
+func (m *CreateAccountRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type RescanRequest struct {
+	// The height of the first block to scan.
+	StartHeight          int32    `protobuf:"varint,1,opt,name=start_height,json=startHeight,proto3" json:"start_height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RescanRequest) Reset()         { *m = RescanRequest{} }
+func (m *RescanRequest) String() string { return proto.CompactTextString(m) }
+func (*RescanRequest) ProtoMessage()    {}
+func (*RescanRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6cc6942ac78249e5, []int{42}
+}
+
+func (m *RescanRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RescanRequest.Unmarshal(m, b)
+}
+func (m *RescanRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RescanRequest.Marshal(b, m, deterministic)
+}
+func (m *RescanRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RescanRequest.Merge(m, src)
+}
+func (m *RescanRequest) XXX_Size() int {
+	return xxx_messageInfo_RescanRequest.Size(m)
+}
+func (m *RescanRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RescanRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RescanRequest proto.InternalMessageInfo
+
+func (m *RescanRequest) GetStartHeight() int32 {
+	if m != nil {
+		return m.StartHeight
+	}
+	return 0
+}
+
+type RescanUpdate struct {
+	// The height of the first block that is scanned.
+	StartHeight int32 `protobuf:"varint,1,opt,name=start_height,json=startHeight,proto3" json:"start_height,omitempty"`
+	// The height of the last block that was scanned.
+	Height int32 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	// The height of the chain tip the scan ends at.
+	TipHeight int32 `protobuf:"varint,3,opt,name=tip_height,json=tipHeight,proto3" json:"tip_height,omitempty"`
+	// The percentage of blocks that were scanned, ranging from 0 to 100.
+	ProgressPercent float64 `protobuf:"fixed64,4,opt,name=progress_percent,json=progressPercent,proto3" json:"progress_percent,omitempty"`
+	//
+	//The height of the first block that contains a transaction relevant to the
+	//wallet, or zero if none was found yet.
+	FirstRelevantHeight int32 `protobuf:"varint,5,opt,name=first_relevant_height,json=firstRelevantHeight,proto3" json:"first_relevant_height,omitempty"`
+	//
+	//Set once all blocks were scanned and the relevant transactions were handed
+	//to the wallet. This is the last update of the stream.
+	Done                 bool     `protobuf:"varint,6,opt,name=done,proto3" json:"done,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RescanUpdate) Reset()         { *m = RescanUpdate{} }
+func (m *RescanUpdate) String() string { return proto.CompactTextString(m) }
+func (*RescanUpdate) ProtoMessage()    {}
+func (*RescanUpdate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6cc6942ac78249e5, []int{43}
+}
+
+func (m *RescanUpdate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RescanUpdate.Unmarshal(m, b)
+}
+func (m *RescanUpdate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RescanUpdate.Marshal(b, m, deterministic)
+}
+func (m *RescanUpdate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RescanUpdate.Merge(m, src)
+}
+func (m *RescanUpdate) XXX_Size() int {
+	return xxx_messageInfo_RescanUpdate.Size(m)
+}
+func (m *RescanUpdate) XXX_DiscardUnknown() {
+	xxx_messageInfo_RescanUpdate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RescanUpdate proto.InternalMessageInfo
+
+func (m *RescanUpdate) GetStartHeight() int32 {
+	if m != nil {
+		return m.StartHeight
+	}
+	return 0
+}
+
+func (m *RescanUpdate) GetHeight() int32 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *RescanUpdate) GetTipHeight() int32 {
+	if m != nil {
+		return m.TipHeight
+	}
+	return 0
+}
+
+func (m *RescanUpdate) GetProgressPercent() float64 {
+	if m != nil {
+		return m.ProgressPercent
+	}
+	return 0
+}
+
+func (m *RescanUpdate) GetFirstRelevantHeight() int32 {
+	if m != nil {
+		return m.FirstRelevantHeight
+	}
+	return 0
+}
+
+func (m *RescanUpdate) GetDone() bool {
+	if m != nil {
+		return m.Done
+	}
+	return false
+}
+
 type FundPsbtRequest struct {
 	// Types that are valid to be assigned to Template:
 	//	*FundPsbtRequest_Psbt
@@ -1376,17 +2297,32 @@ type FundPsbtRequest struct {
 	// Types that are valid to be assigned to Fees:
 	//	*FundPsbtRequest_TargetConf
 	//	*FundPsbtRequest_SatPerVbyte
-	Fees                 isFundPsbtRequest_Fees `protobuf_oneof:"fees"`
-	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
-	XXX_unrecognized     []byte                 `json:"-"`
-	XXX_sizecache        int32                  `json:"-"`
+	Fees isFundPsbtRequest_Fees `protobuf_oneof:"fees"`
+	//
+	//The name of the wallet account to select coins from and to send change to.
+	//If empty, the default account is used. Inputs specified in the template
+	//must belong to this account.
+	Account string `protobuf:"bytes,5,opt,name=account,proto3" json:"account,omitempty"`
+	//
+	//An optional address to send any change to instead of a new change address
+	//of the wallet. The change amount is reduced to pay for the size of the
+	//address' output script, so the requested fee rate is kept.
+	ChangeAddress string `protobuf:"bytes,6,opt,name=change_address,json=changeAddress,proto3" json:"change_address,omitempty"`
+	//
+	//An optional ID of 32 bytes to lock the inputs with, instead of the internal
+	//app ID. This allows applications to release the inputs with ReleaseOutput
+	//using their own ID.
+	LeaseId              []byte   `protobuf:"bytes,7,opt,name=lease_id,json=leaseId,proto3" json:"lease_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *FundPsbtRequest) Reset()         { *m = FundPsbtRequest{} }
 func (m *FundPsbtRequest) String() string { return proto.CompactTextString(m) }
 func (*FundPsbtRequest) ProtoMessage()    {}
 func (*FundPsbtRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6cc6942ac78249e5, []int{24}
+	return fileDescriptor_6cc6942ac78249e5, []int{44}
 }
 
 func (m *FundPsbtRequest) XXX_Unmarshal(b []byte) error {
@@ -1478,7 +2414,28 @@ func (m *FundPsbtRequest) GetSatPerVbyte() uint32 {
 	if x, ok := m.GetFees().(*FundPsbtRequest_SatPerVbyte); ok {
 		return x.SatPerVbyte
 	}
-	return 0
+	return 0
+}
+
+func (m *FundPsbtRequest) GetAccount() string {
+	if m != nil {
+		return m.Account
+	}
+	return ""
+}
+
+func (m *FundPsbtRequest) GetChangeAddress() string {
+	if m != nil {
+		return m.ChangeAddress
+	}
+	return ""
+}
+
+func (m *FundPsbtRequest) GetLeaseId() []byte {
+	if m != nil {
+		return m.LeaseId
+	}
+	return nil
 }
 
 // XXX_OneofWrappers is for the internal use of the proto package.
@@ -1511,7 +2468,7 @@ func (m *FundPsbtResponse) Reset()         { *m = FundPsbtResponse{} }
 func (m *FundPsbtResponse) String() string { return proto.CompactTextString(m) }
 func (*FundPsbtResponse) ProtoMessage()    {}
 func (*FundPsbtResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6cc6942ac78249e5, []int{25}
+	return fileDescriptor_6cc6942ac78249e5, []int{45}
 }
 
 func (m *FundPsbtResponse) XXX_Unmarshal(b []byte) error {
@@ -1575,7 +2532,7 @@ func (m *TxTemplate) Reset()         { *m = TxTemplate{} }
 func (m *TxTemplate) String() string { return proto.CompactTextString(m) }
 func (*TxTemplate) ProtoMessage()    {}
 func (*TxTemplate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6cc6942ac78249e5, []int{26}
+	return fileDescriptor_6cc6942ac78249e5, []int{46}
 }
 
 func (m *TxTemplate) XXX_Unmarshal(b []byte) error {
@@ -1628,7 +2585,7 @@ func (m *UtxoLease) Reset()         { *m = UtxoLease{} }
 func (m *UtxoLease) String() string { return proto.CompactTextString(m) }
 func (*UtxoLease) ProtoMessage()    {}
 func (*UtxoLease) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6cc6942ac78249e5, []int{27}
+	return fileDescriptor_6cc6942ac78249e5, []int{47}
 }
 
 func (m *UtxoLease) XXX_Unmarshal(b []byte) error {
@@ -1685,7 +2642,7 @@ func (m *FinalizePsbtRequest) Reset()         { *m = FinalizePsbtRequest{} }
 func (m *FinalizePsbtRequest) String() string { return proto.CompactTextString(m) }
 func (*FinalizePsbtRequest) ProtoMessage()    {}
 func (*FinalizePsbtRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6cc6942ac78249e5, []int{28}
+	return fileDescriptor_6cc6942ac78249e5, []int{48}
 }
 
 func (m *FinalizePsbtRequest) XXX_Unmarshal(b []byte) error {
@@ -1727,7 +2684,7 @@ func (m *FinalizePsbtResponse) Reset()         { *m = FinalizePsbtResponse{} }
 func (m *FinalizePsbtResponse) String() string { return proto.CompactTextString(m) }
 func (*FinalizePsbtResponse) ProtoMessage()    {}
 func (*FinalizePsbtResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6cc6942ac78249e5, []int{29}
+	return fileDescriptor_6cc6942ac78249e5, []int{49}
 }
 
 func (m *FinalizePsbtResponse) XXX_Unmarshal(b []byte) error {
@@ -1770,7 +2727,21 @@ func init() {
 	proto.RegisterType((*LeaseOutputResponse)(nil), "walletrpc.LeaseOutputResponse")
 	proto.RegisterType((*ReleaseOutputRequest)(nil), "walletrpc.ReleaseOutputRequest")
 	proto.RegisterType((*ReleaseOutputResponse)(nil), "walletrpc.ReleaseOutputResponse")
+	proto.RegisterType((*ListLeasesRequest)(nil), "walletrpc.ListLeasesRequest")
+	proto.RegisterType((*ListLeasesResponse)(nil), "walletrpc.ListLeasesResponse")
+	proto.RegisterType((*LabelOutputRequest)(nil), "walletrpc.LabelOutputRequest")
+	proto.RegisterType((*LabelOutputResponse)(nil), "walletrpc.LabelOutputResponse")
+	proto.RegisterType((*FreezeOutputRequest)(nil), "walletrpc.FreezeOutputRequest")
+	proto.RegisterType((*FreezeOutputResponse)(nil), "walletrpc.FreezeOutputResponse")
+	proto.RegisterType((*UnfreezeOutputRequest)(nil), "walletrpc.UnfreezeOutputRequest")
+	proto.RegisterType((*UnfreezeOutputResponse)(nil), "walletrpc.UnfreezeOutputResponse")
 	proto.RegisterType((*KeyReq)(nil), "walletrpc.KeyReq")
+	proto.RegisterType((*ReserveKeyFamiliesRequest)(nil), "walletrpc.ReserveKeyFamiliesRequest")
+	proto.RegisterType((*KeyFamilyReservation)(nil), "walletrpc.KeyFamilyReservation")
+	proto.RegisterType((*ReleaseKeyFamiliesRequest)(nil), "walletrpc.ReleaseKeyFamiliesRequest")
+	proto.RegisterType((*ReleaseKeyFamiliesResponse)(nil), "walletrpc.ReleaseKeyFamiliesResponse")
+	proto.RegisterType((*ListKeyFamilyReservationsRequest)(nil), "walletrpc.ListKeyFamilyReservationsRequest")
+	proto.RegisterType((*ListKeyFamilyReservationsResponse)(nil), "walletrpc.ListKeyFamilyReservationsResponse")
 	proto.RegisterType((*AddrRequest)(nil), "walletrpc.AddrRequest")
 	proto.RegisterType((*AddrResponse)(nil), "walletrpc.AddrResponse")
 	proto.RegisterType((*Transaction)(nil), "walletrpc.Transaction")
@@ -1789,6 +2760,12 @@ func init() {
 	proto.RegisterType((*ListSweepsResponse_TransactionIDs)(nil), "walletrpc.ListSweepsResponse.TransactionIDs")
 	proto.RegisterType((*LabelTransactionRequest)(nil), "walletrpc.LabelTransactionRequest")
 	proto.RegisterType((*LabelTransactionResponse)(nil), "walletrpc.LabelTransactionResponse")
+	proto.RegisterType((*Account)(nil), "walletrpc.Account")
+	proto.RegisterType((*ListAccountsRequest)(nil), "walletrpc.ListAccountsRequest")
+	proto.RegisterType((*ListAccountsResponse)(nil), "walletrpc.ListAccountsResponse")
+	proto.RegisterType((*CreateAccountRequest)(nil), "walletrpc.CreateAccountRequest")
+	proto.RegisterType((*RescanRequest)(nil), "walletrpc.RescanRequest")
+	proto.RegisterType((*RescanUpdate)(nil), "walletrpc.RescanUpdate")
 	proto.RegisterType((*FundPsbtRequest)(nil), "walletrpc.FundPsbtRequest")
 	proto.RegisterType((*FundPsbtResponse)(nil), "walletrpc.FundPsbtResponse")
 	proto.RegisterType((*TxTemplate)(nil), "walletrpc.TxTemplate")
@@ -1801,120 +2778,161 @@ func init() {
 func init() { proto.RegisterFile("walletrpc/walletkit.proto", fileDescriptor_6cc6942ac78249e5) }
 
 var fileDescriptor_6cc6942ac78249e5 = []byte{
-	// 1793 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x58, 0xef, 0x6e, 0x22, 0xc9,
-	0x11, 0x5f, 0x0c, 0xc6, 0x50, 0x80, 0x8d, 0x1b, 0xbc, 0x66, 0x59, 0xef, 0xd9, 0x3b, 0x97, 0xe4,
-	0x9c, 0xdc, 0x1d, 0x56, 0xbc, 0xba, 0xcb, 0x9e, 0x13, 0x45, 0xb1, 0xf1, 0x58, 0x58, 0x60, 0xf0,
-	0x35, 0x78, 0xad, 0x4d, 0x3e, 0x8c, 0x06, 0xa6, 0x6d, 0x8f, 0x0c, 0x33, 0x73, 0x33, 0x8d, 0x19,
-	0xf2, 0x29, 0x4f, 0x11, 0xe9, 0xa4, 0xbc, 0xc3, 0xbd, 0x40, 0x1e, 0x28, 0x8f, 0x11, 0xf5, 0x1f,
-	0x86, 0x1e, 0xc0, 0x7b, 0x8a, 0x72, 0x9f, 0x4c, 0xd7, 0xaf, 0xea, 0xd7, 0xd5, 0x55, 0x35, 0x5d,
-	0xd5, 0x86, 0x57, 0x13, 0x73, 0x38, 0x24, 0xd4, 0xf7, 0x06, 0x47, 0xe2, 0xd7, 0xa3, 0x4d, 0x6b,
-	0x9e, 0xef, 0x52, 0x17, 0x65, 0x23, 0xa8, 0x9a, 0xf5, 0xbd, 0x81, 0x90, 0x56, 0xcb, 0x81, 0x7d,
-	0xef, 0x30, 0x75, 0xf6, 0x97, 0xf8, 0x42, 0xaa, 0xb5, 0x01, 0xb5, 0xec, 0x80, 0xde, 0x38, 0x81,
-	0x47, 0x1c, 0x8a, 0xc9, 0x0f, 0x63, 0x12, 0x50, 0xf4, 0x1a, 0xb2, 0x23, 0xdb, 0x31, 0x06, 0xae,
-	0x73, 0x17, 0x54, 0x12, 0x07, 0x89, 0xc3, 0x75, 0x9c, 0x19, 0xd9, 0x4e, 0x9d, 0xad, 0x39, 0x68,
-	0x86, 0x12, 0x5c, 0x93, 0xa0, 0x19, 0x72, 0x50, 0x7b, 0x0f, 0xa5, 0x18, 0x5f, 0xe0, 0xb9, 0x4e,
-	0x40, 0xd0, 0x5b, 0x58, 0x1f, 0xd3, 0xd0, 0x65, 0x64, 0xc9, 0xc3, 0xdc, 0x71, 0xae, 0x36, 0x64,
-	0xae, 0xd4, 0x6e, 0x68, 0xe8, 0x62, 0x81, 0x68, 0xdf, 0x03, 0x6a, 0x11, 0x33, 0x20, 0x9d, 0x31,
-	0xf5, 0xc6, 0x91, 0x27, 0x9b, 0xb0, 0x66, 0x5b, 0xdc, 0x85, 0x3c, 0x5e, 0xb3, 0x2d, 0xf4, 0x25,
-	0x64, 0xdc, 0x31, 0xf5, 0x5c, 0xdb, 0xa1, 0x7c, 0xef, 0xdc, 0xf1, 0x96, 0xe4, 0xea, 0x8c, 0xe9,
-	0x35, 0x13, 0xe3, 0x48, 0x41, 0xfb, 0x06, 0x4a, 0x31, 0x4a, 0xe9, 0xcc, 0x67, 0x00, 0x24, 0xf4,
-	0x6c, 0xdf, 0xa4, 0xb6, 0xeb, 0x70, 0xee, 0x14, 0x56, 0x24, 0x5a, 0x17, 0xca, 0x98, 0x0c, 0x7f,
-	0x61, 0x5f, 0x76, 0x61, 0x67, 0x81, 0x54, 0x78, 0xa3, 0x7d, 0x0f, 0xe9, 0x26, 0x99, 0x62, 0xf2,
-	0x03, 0x3a, 0x84, 0xe2, 0x23, 0x99, 0x1a, 0x77, 0xb6, 0x73, 0x4f, 0x7c, 0xc3, 0xf3, 0x19, 0xaf,
-	0x08, 0xfe, 0xe6, 0x23, 0x99, 0x5e, 0x70, 0xf1, 0x35, 0x93, 0xa2, 0x37, 0x00, 0x5c, 0xd3, 0x1c,
-	0xd9, 0xc3, 0xa9, 0xcc, 0x41, 0x96, 0xe9, 0x70, 0x81, 0x56, 0x80, 0xdc, 0xa9, 0x65, 0xf9, 0xd2,
-	0x6f, 0x4d, 0x83, 0xbc, 0x58, 0xca, 0xf3, 0x23, 0x48, 0x99, 0x96, 0xe5, 0x73, 0xee, 0x2c, 0xe6,
-	0xbf, 0xb5, 0x13, 0xc8, 0xf5, 0x7c, 0xd3, 0x09, 0xcc, 0x01, 0x0b, 0x01, 0xda, 0x81, 0x34, 0x0d,
-	0x8d, 0x07, 0x12, 0xca, 0xe3, 0xae, 0xd3, 0xb0, 0x41, 0x42, 0x54, 0x86, 0xf5, 0xa1, 0xd9, 0x27,
-	0x43, 0xbe, 0x65, 0x16, 0x8b, 0x85, 0xf6, 0x2d, 0x6c, 0x5d, 0x8f, 0xfb, 0x43, 0x3b, 0x78, 0x88,
-	0xb6, 0xf8, 0x1c, 0x0a, 0x9e, 0x10, 0x19, 0xc4, 0xf7, 0xdd, 0xd9, 0x5e, 0x79, 0x29, 0xd4, 0x99,
-	0x4c, 0xfb, 0x77, 0x02, 0x50, 0x97, 0x38, 0x96, 0x08, 0x48, 0x30, 0x0b, 0xf3, 0x1e, 0x40, 0x60,
-	0x52, 0xc3, 0x23, 0xbe, 0xf1, 0x38, 0xe1, 0x86, 0x49, 0x9c, 0x09, 0x4c, 0x7a, 0x4d, 0xfc, 0xe6,
-	0x04, 0x1d, 0xc2, 0x86, 0x2b, 0xf4, 0x2b, 0x6b, 0xbc, 0x96, 0x36, 0x6b, 0xb2, 0xb0, 0x6b, 0xbd,
-	0xb0, 0x33, 0xa6, 0x78, 0x06, 0xcf, 0x9d, 0x4d, 0x2a, 0xce, 0xc6, 0x4b, 0x3b, 0xb5, 0x50, 0xda,
-	0x5f, 0xc2, 0x36, 0xab, 0x5b, 0xcb, 0x18, 0x3b, 0x4c, 0xc1, 0xf6, 0x47, 0xc4, 0xaa, 0xac, 0x1f,
-	0x24, 0x0e, 0x33, 0xb8, 0xc8, 0x81, 0x9b, 0xb9, 0x5c, 0xfb, 0x0a, 0x4a, 0x31, 0xef, 0xe5, 0xd1,
-	0x77, 0x20, 0xed, 0x9b, 0x13, 0x83, 0x46, 0xa1, 0xf3, 0xcd, 0x49, 0x2f, 0xd4, 0xbe, 0x01, 0xa4,
-	0x07, 0xd4, 0x1e, 0x99, 0x94, 0x5c, 0x10, 0x32, 0x3b, 0xeb, 0x3e, 0xe4, 0x18, 0xa1, 0x41, 0x4d,
-	0xff, 0x9e, 0xcc, 0xb2, 0x0d, 0x4c, 0xd4, 0xe3, 0x12, 0xed, 0x1d, 0x94, 0x62, 0x66, 0x72, 0x93,
-	0x4f, 0xc6, 0x48, 0xfb, 0x31, 0x09, 0xf9, 0x6b, 0xe2, 0x58, 0xb6, 0x73, 0xdf, 0x9d, 0x10, 0xe2,
-	0xc5, 0x2a, 0x35, 0xf1, 0x33, 0x95, 0x8a, 0xbe, 0x83, 0xfc, 0xc4, 0xa6, 0x0e, 0x09, 0x02, 0x83,
-	0x4e, 0x3d, 0xc2, 0x73, 0xbd, 0x79, 0xfc, 0xb2, 0x16, 0xdd, 0x2a, 0xb5, 0x5b, 0x01, 0xf7, 0xa6,
-	0x1e, 0xc1, 0xb9, 0xc9, 0x7c, 0xc1, 0xea, 0xd2, 0x1c, 0xb9, 0x63, 0x87, 0x1a, 0x81, 0x49, 0x79,
-	0xdc, 0x0b, 0x38, 0x2b, 0x24, 0x5d, 0x93, 0xa2, 0x03, 0xc8, 0xcf, 0xbc, 0xee, 0x4f, 0x29, 0xe1,
-	0xe1, 0x2f, 0x60, 0x10, 0x7e, 0x9f, 0x4d, 0x29, 0x41, 0x5f, 0x03, 0xea, 0xfb, 0xae, 0x69, 0x0d,
-	0xcc, 0x80, 0x1a, 0x26, 0xa5, 0x64, 0xe4, 0xd1, 0x80, 0x67, 0xa0, 0x80, 0xb7, 0x23, 0xe4, 0x54,
-	0x02, 0xe8, 0x18, 0x76, 0x1c, 0x12, 0x52, 0x63, 0x6e, 0xf3, 0x40, 0xec, 0xfb, 0x07, 0x5a, 0x49,
-	0x73, 0x8b, 0x12, 0x03, 0xcf, 0x66, 0x58, 0x83, 0x43, 0xcc, 0xc6, 0x17, 0xd1, 0x27, 0x96, 0xa1,
-	0x06, 0x3f, 0x23, 0x6c, 0x22, 0xb0, 0x1e, 0x65, 0x01, 0xbd, 0x83, 0x97, 0x73, 0x9b, 0xd8, 0x11,
-	0xb2, 0x0b, 0x46, 0xdd, 0xf9, 0x59, 0xca, 0xb0, 0x7e, 0xe7, 0xfa, 0x03, 0x52, 0xd9, 0xe0, 0x05,
-	0x24, 0x16, 0xda, 0x4b, 0x28, 0xab, 0xa9, 0x99, 0x55, 0xbd, 0x76, 0x0b, 0x3b, 0x0b, 0x72, 0x99,
-	0xea, 0x3f, 0xc3, 0xa6, 0x27, 0x00, 0x23, 0xe0, 0x88, 0xbc, 0x43, 0x77, 0x95, 0x84, 0xa8, 0x96,
-	0xb8, 0xe0, 0xa9, 0x3c, 0xda, 0x3f, 0x13, 0xb0, 0x79, 0x36, 0x1e, 0x79, 0x4a, 0xd5, 0xfd, 0x4f,
-	0xe5, 0xb0, 0x0f, 0x39, 0x11, 0x20, 0x1e, 0x2c, 0x5e, 0x0d, 0x05, 0x0c, 0x42, 0xc4, 0x42, 0xb4,
-	0x94, 0xd5, 0xe4, 0x52, 0x56, 0xa3, 0x48, 0xa4, 0xd4, 0x48, 0x6c, 0xc3, 0x56, 0xe4, 0x97, 0xbc,
-	0x0b, 0xbf, 0x86, 0x6d, 0xd6, 0x3d, 0x62, 0x91, 0x41, 0x15, 0xd8, 0x78, 0x22, 0x7e, 0xdf, 0x0d,
-	0x08, 0x77, 0x36, 0x83, 0x67, 0x4b, 0xed, 0x1f, 0x6b, 0xa2, 0x7b, 0x2d, 0x44, 0xac, 0x05, 0x25,
-	0x3a, 0xbf, 0xcb, 0x0c, 0x8b, 0x50, 0xd3, 0x1e, 0x06, 0xf2, 0xa4, 0xaf, 0xe4, 0x49, 0x95, 0xdb,
-	0xee, 0x5c, 0x28, 0x34, 0x5e, 0x60, 0x44, 0x97, 0xa4, 0xe8, 0x16, 0xb6, 0x54, 0x36, 0xdb, 0x0a,
-	0xe4, 0x65, 0xff, 0x95, 0x92, 0x80, 0x65, 0x2f, 0xd4, 0x0d, 0x2e, 0xcf, 0x19, 0xf9, 0xa6, 0x42,
-	0x73, 0x69, 0x05, 0xd5, 0xef, 0x60, 0x33, 0xae, 0x83, 0xbe, 0x58, 0xde, 0x8a, 0xe5, 0x3a, 0xbb,
-	0x68, 0x7a, 0x96, 0x81, 0xb4, 0xa8, 0x05, 0xcd, 0x84, 0xdd, 0x16, 0xbb, 0xd7, 0x14, 0xa6, 0x59,
-	0xdc, 0x10, 0xa4, 0x68, 0x18, 0x35, 0x2c, 0xfe, 0x7b, 0xf5, 0x05, 0x8e, 0xf6, 0x20, 0xeb, 0x3e,
-	0x11, 0x7f, 0xe2, 0xdb, 0x32, 0x7d, 0x19, 0x3c, 0x17, 0x68, 0x55, 0xa8, 0x2c, 0x6f, 0x21, 0x13,
-	0xf6, 0x53, 0x02, 0xb6, 0x2e, 0xc6, 0x8e, 0x75, 0x1d, 0xf4, 0xa3, 0x36, 0x59, 0x86, 0x94, 0x17,
-	0xf4, 0x45, 0x65, 0xe5, 0x1b, 0x2f, 0x30, 0x5f, 0xa1, 0xdf, 0x42, 0xd2, 0x37, 0x27, 0x32, 0x74,
-	0x3b, 0x4a, 0xe8, 0x7a, 0x61, 0x8f, 0x8c, 0xbc, 0xa1, 0x49, 0x49, 0xe3, 0x05, 0x66, 0x3a, 0xe8,
-	0x6d, 0xbc, 0xe2, 0x78, 0x3d, 0x35, 0x12, 0xb1, 0x9a, 0xfb, 0x15, 0x14, 0x66, 0x35, 0xf7, 0x34,
-	0xbf, 0x4a, 0x1a, 0x09, 0x9c, 0x13, 0x65, 0xf7, 0x81, 0x09, 0xcf, 0x00, 0x32, 0x54, 0x72, 0x9f,
-	0xa5, 0x21, 0x75, 0x47, 0x48, 0xa0, 0xfd, 0x2b, 0x01, 0xc5, 0xb9, 0xc7, 0xb2, 0x62, 0xf6, 0x21,
-	0x77, 0x37, 0x76, 0x2c, 0x62, 0x19, 0x73, 0xcf, 0x31, 0x08, 0x11, 0x53, 0x44, 0x35, 0x28, 0x0d,
-	0x1e, 0x4c, 0xe7, 0x9e, 0x18, 0xa2, 0xbb, 0x18, 0xb6, 0x63, 0x91, 0x50, 0x76, 0xde, 0x6d, 0x01,
-	0x89, 0x46, 0x70, 0xc9, 0x00, 0xf4, 0x07, 0xc8, 0x0f, 0xdd, 0xc1, 0x23, 0xb1, 0x0c, 0x31, 0xf6,
-	0x24, 0xf9, 0x27, 0x5b, 0x56, 0x8e, 0xcd, 0x46, 0x1f, 0x3e, 0x9c, 0xe0, 0x9c, 0xd0, 0xbc, 0xe1,
-	0x53, 0xd0, 0x4f, 0x09, 0x80, 0x79, 0x44, 0xd0, 0x17, 0x90, 0xb6, 0x1d, 0xde, 0xec, 0xc4, 0x47,
-	0xbf, 0xf4, 0x9d, 0x4a, 0x18, 0xfd, 0x69, 0xb1, 0x2d, 0x6a, 0x2b, 0x43, 0x5c, 0x93, 0xdd, 0x4a,
-	0x77, 0xa8, 0x3f, 0x8d, 0x5a, 0x65, 0xf5, 0x04, 0xf2, 0x2a, 0x80, 0x8a, 0x90, 0x7c, 0x24, 0x53,
-	0xd9, 0xb4, 0xd9, 0x4f, 0x56, 0x38, 0x4f, 0xe6, 0x70, 0x2c, 0xba, 0x41, 0x0a, 0x8b, 0xc5, 0xc9,
-	0xda, 0xfb, 0x84, 0xf6, 0x00, 0xd9, 0xe8, 0x2c, 0xff, 0xd7, 0x88, 0xb4, 0x30, 0x97, 0x25, 0x97,
-	0xe6, 0xb2, 0x6f, 0xa1, 0x74, 0x61, 0x3b, 0xe6, 0xd0, 0xfe, 0x3b, 0x51, 0xeb, 0xed, 0xe7, 0x92,
-	0xa7, 0x7d, 0x84, 0x72, 0xdc, 0x6e, 0x9e, 0x75, 0x3e, 0x0b, 0xc7, 0x0d, 0x85, 0x88, 0x67, 0xfd,
-	0x00, 0xf2, 0xac, 0x95, 0xdf, 0x31, 0x63, 0xd6, 0xd0, 0xd7, 0x84, 0x86, 0x6f, 0x4e, 0x38, 0x5f,
-	0x2f, 0xfc, 0xdd, 0x8f, 0x49, 0xc8, 0x29, 0xdd, 0x10, 0x95, 0x60, 0xeb, 0xa6, 0xdd, 0x6c, 0x77,
-	0x6e, 0xdb, 0xc6, 0xed, 0x65, 0xaf, 0xad, 0x77, 0xbb, 0xc5, 0x17, 0xa8, 0x02, 0xe5, 0x7a, 0xe7,
-	0xea, 0xea, 0xb2, 0x77, 0xa5, 0xb7, 0x7b, 0x46, 0xef, 0xf2, 0x4a, 0x37, 0x5a, 0x9d, 0x7a, 0xb3,
-	0x98, 0x40, 0xbb, 0x50, 0x52, 0x90, 0x76, 0xc7, 0x38, 0xd7, 0x5b, 0xa7, 0x1f, 0x8b, 0x6b, 0x68,
-	0x07, 0xb6, 0x15, 0x00, 0xeb, 0x1f, 0x3a, 0x4d, 0xbd, 0x98, 0x64, 0xfa, 0x8d, 0x5e, 0xab, 0x6e,
-	0x74, 0x2e, 0x2e, 0x74, 0xac, 0x9f, 0xcf, 0x80, 0x14, 0xdb, 0x82, 0x03, 0xa7, 0xf5, 0xba, 0x7e,
-	0xdd, 0x9b, 0x23, 0xeb, 0xe8, 0xd7, 0xf0, 0x36, 0x66, 0xc2, 0xb6, 0xef, 0xdc, 0xf4, 0x8c, 0xae,
-	0x5e, 0xef, 0xb4, 0xcf, 0x8d, 0x96, 0xfe, 0x41, 0x6f, 0x15, 0xd3, 0xe8, 0x37, 0xa0, 0xc5, 0x09,
-	0xba, 0x37, 0xf5, 0xba, 0xde, 0xed, 0xc6, 0xf5, 0x36, 0xd0, 0x3e, 0xbc, 0x5e, 0xf0, 0xe0, 0xaa,
-	0xd3, 0xd3, 0x67, 0xac, 0xc5, 0x0c, 0x3a, 0x80, 0xbd, 0x45, 0x4f, 0xb8, 0x86, 0xe4, 0x2b, 0x66,
-	0xd1, 0x1e, 0x54, 0xb8, 0x86, 0xca, 0x3c, 0xf3, 0x17, 0x50, 0x19, 0x8a, 0x32, 0x72, 0x46, 0x53,
-	0xff, 0x68, 0x34, 0x4e, 0xbb, 0x8d, 0x62, 0x0e, 0xbd, 0x86, 0xdd, 0xb6, 0xde, 0x65, 0x74, 0x4b,
-	0x60, 0x7e, 0x21, 0x58, 0xa7, 0xed, 0x7a, 0xa3, 0x83, 0x8b, 0x85, 0xe3, 0xff, 0x64, 0x20, 0x7b,
-	0xcb, 0xbf, 0x81, 0xa6, 0x4d, 0x51, 0x0b, 0x72, 0xca, 0xc3, 0x04, 0xbd, 0x59, 0xb8, 0xbc, 0xe3,
-	0x0f, 0xa0, 0xea, 0x67, 0xcf, 0xc1, 0x51, 0x8b, 0xc9, 0x29, 0x2f, 0x8b, 0x38, 0xdb, 0xd2, 0xc3,
-	0x21, 0xce, 0xb6, 0xe2, 0x41, 0x82, 0xa1, 0x10, 0x7b, 0x1b, 0xa0, 0x7d, 0xc5, 0x60, 0xd5, 0x53,
-	0xa4, 0x7a, 0xf0, 0xbc, 0x82, 0xe4, 0x3c, 0x81, 0xc2, 0x39, 0xf1, 0xed, 0x27, 0xd2, 0x26, 0x21,
-	0x6d, 0x92, 0x29, 0xda, 0x56, 0x4c, 0xc4, 0x83, 0xa3, 0xfa, 0x32, 0x1a, 0x9d, 0x9b, 0x64, 0x7a,
-	0x4e, 0x82, 0x81, 0x6f, 0x7b, 0xd4, 0xf5, 0xd1, 0x7b, 0xc8, 0x0a, 0x5b, 0x66, 0x57, 0x52, 0x95,
-	0x5a, 0xee, 0xc0, 0xa4, 0xae, 0xff, 0xac, 0xe5, 0x1f, 0x21, 0xc3, 0xf6, 0x63, 0xcf, 0x0d, 0xa4,
-	0x4e, 0x8c, 0xca, 0x73, 0xa4, 0xba, 0xbb, 0x24, 0x97, 0x2e, 0x37, 0x00, 0xc9, 0x77, 0x84, 0xfa,
-	0x14, 0x51, 0x69, 0x14, 0x79, 0xb5, 0xaa, 0xce, 0x3f, 0x0b, 0xcf, 0x8f, 0x16, 0xe4, 0x94, 0xd1,
-	0x3c, 0x96, 0x9e, 0xe5, 0x07, 0x47, 0x2c, 0x3d, 0xab, 0x26, 0xfa, 0x16, 0xe4, 0x94, 0x19, 0x3c,
-	0xc6, 0xb6, 0x3c, 0xd2, 0xc7, 0xd8, 0x56, 0x8d, 0xee, 0x18, 0x0a, 0xb1, 0x41, 0x2f, 0x96, 0xec,
-	0x55, 0xa3, 0x61, 0x2c, 0xd9, 0xab, 0x67, 0xc4, 0xbf, 0xc0, 0x86, 0x1c, 0xa5, 0xd0, 0x2b, 0x45,
-	0x39, 0x3e, 0xf6, 0xc5, 0x22, 0xb6, 0x30, 0x79, 0xa1, 0x4b, 0x80, 0xf9, 0x0c, 0x83, 0xf6, 0x9e,
-	0x19, 0x6d, 0x04, 0xcf, 0x9b, 0x4f, 0x0e, 0x3e, 0xe8, 0x6f, 0x50, 0x5c, 0x9c, 0x17, 0x90, 0xda,
-	0x8d, 0x9e, 0x99, 0x57, 0xaa, 0x9f, 0x7f, 0x52, 0x47, 0x92, 0xd7, 0x21, 0x33, 0xeb, 0xde, 0x48,
-	0x3d, 0xcf, 0xc2, 0x10, 0x52, 0x7d, 0xbd, 0x12, 0x93, 0x24, 0x1d, 0xc8, 0xab, 0x0d, 0x01, 0xa9,
-	0x29, 0x5b, 0xd1, 0x61, 0xaa, 0xfb, 0xcf, 0xe2, 0x82, 0xf0, 0xec, 0xf7, 0x7f, 0x3d, 0xba, 0xb7,
-	0xe9, 0xc3, 0xb8, 0x5f, 0x1b, 0xb8, 0xa3, 0xa3, 0x21, 0x7b, 0x67, 0x38, 0xb6, 0x73, 0xef, 0x10,
-	0x3a, 0x71, 0xfd, 0xc7, 0xa3, 0xa1, 0x63, 0x1d, 0xf1, 0xae, 0x77, 0x14, 0xf1, 0xf4, 0xd3, 0xfc,
-	0xff, 0x2f, 0xef, 0xfe, 0x1b, 0x00, 0x00, 0xff, 0xff, 0x88, 0xe3, 0xa5, 0xe6, 0xc8, 0x11, 0x00,
-	0x00,
+	// 2453 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x59, 0x4b, 0x73, 0xdb, 0xc8,
+	0xf1, 0x37, 0x45, 0x3d, 0xc8, 0x26, 0x29, 0x51, 0x43, 0x4a, 0xa2, 0x60, 0xad, 0x1e, 0xd8, 0xf5,
+	0xda, 0x5e, 0x7b, 0xe5, 0xff, 0x5f, 0xae, 0xdd, 0x78, 0x9d, 0x6c, 0x2a, 0x12, 0x45, 0x15, 0x55,
+	0xa2, 0x44, 0x05, 0xa4, 0xac, 0x72, 0xf6, 0x80, 0x82, 0x88, 0x91, 0x84, 0x12, 0x09, 0xc0, 0xc0,
+	0x50, 0x24, 0xf7, 0x90, 0xda, 0xaa, 0x1c, 0x73, 0xca, 0x25, 0x55, 0x5b, 0x95, 0x7b, 0x8e, 0x39,
+	0xe6, 0x9b, 0xe4, 0x92, 0x2f, 0x93, 0x9a, 0x07, 0x80, 0x01, 0x41, 0xda, 0xde, 0xdd, 0x54, 0x4e,
+	0xc4, 0xf4, 0xaf, 0xbb, 0xa7, 0xa7, 0x7b, 0xa6, 0xa7, 0xa7, 0x09, 0xeb, 0x03, 0xa3, 0xdb, 0xc5,
+	0xc4, 0x73, 0x3b, 0x2f, 0xf8, 0xd7, 0x9d, 0x45, 0x76, 0x5d, 0xcf, 0x21, 0x0e, 0xca, 0x86, 0x90,
+	0x92, 0xf5, 0xdc, 0x0e, 0xa7, 0x2a, 0x65, 0xdf, 0xba, 0xb1, 0x29, 0x3b, 0xfd, 0xc5, 0x1e, 0xa7,
+	0xaa, 0xb7, 0x80, 0x1a, 0x96, 0x4f, 0x2e, 0x6c, 0xdf, 0xc5, 0x36, 0xd1, 0xf0, 0xbb, 0x3e, 0xf6,
+	0x09, 0x7a, 0x08, 0xd9, 0x9e, 0x65, 0xeb, 0x1d, 0xc7, 0xbe, 0xf6, 0x2b, 0xa9, 0xed, 0xd4, 0x93,
+	0x39, 0x2d, 0xd3, 0xb3, 0xec, 0x2a, 0x1d, 0x33, 0xd0, 0x18, 0x0a, 0x70, 0x46, 0x80, 0xc6, 0x90,
+	0x83, 0x15, 0x58, 0x30, 0x3a, 0x1d, 0xa7, 0x6f, 0x93, 0x4a, 0x7a, 0x3b, 0xf5, 0x24, 0xab, 0x05,
+	0x43, 0xf5, 0x15, 0x94, 0x62, 0x33, 0xf9, 0xae, 0x63, 0xfb, 0x18, 0xed, 0xc0, 0x5c, 0x9f, 0x0c,
+	0x1d, 0x3a, 0x4d, 0xfa, 0x49, 0x6e, 0x2f, 0xb7, 0xdb, 0xa5, 0x46, 0xee, 0x5e, 0x90, 0xa1, 0xa3,
+	0x71, 0x44, 0xfd, 0x21, 0x05, 0xa8, 0x81, 0x0d, 0x1f, 0x37, 0xfb, 0xc4, 0xed, 0x87, 0x46, 0x2e,
+	0xc2, 0x8c, 0x65, 0x32, 0xeb, 0xf2, 0xda, 0x8c, 0x65, 0xa2, 0x67, 0x90, 0x71, 0xfa, 0xc4, 0x75,
+	0x2c, 0x9b, 0x30, 0xb3, 0x72, 0x7b, 0x4b, 0x42, 0x59, 0xb3, 0x4f, 0xce, 0x29, 0x59, 0x0b, 0x19,
+	0xd0, 0x97, 0x80, 0xf0, 0xd0, 0xb5, 0x3c, 0x83, 0x58, 0x8e, 0xad, 0xfb, 0xb8, 0xe3, 0xd8, 0xa6,
+	0xcf, 0x4c, 0x9e, 0xd5, 0x96, 0x23, 0xa4, 0xc5, 0x01, 0xf5, 0x2b, 0x28, 0xc5, 0x2c, 0x10, 0xc6,
+	0x6f, 0x02, 0x44, 0xbc, 0xcc, 0x94, 0x59, 0x4d, 0xa2, 0xa8, 0x2d, 0x28, 0x6b, 0xb8, 0xfb, 0xdf,
+	0x35, 0x5d, 0x5d, 0x83, 0x95, 0x31, 0xa5, 0xdc, 0x1a, 0xb5, 0x04, 0xcb, 0xd4, 0xc3, 0xcc, 0x50,
+	0x5f, 0x4c, 0xa5, 0x9e, 0x02, 0x92, 0x89, 0xc2, 0xf0, 0x5f, 0x41, 0xbe, 0xeb, 0x74, 0xee, 0xb0,
+	0xa9, 0xcb, 0xce, 0x2f, 0xef, 0x86, 0x3b, 0x87, 0x05, 0x80, 0x09, 0x69, 0x39, 0xce, 0x79, 0xc1,
+	0x62, 0xd1, 0x07, 0xd4, 0x30, 0xae, 0x70, 0x37, 0xbe, 0x1e, 0xd9, 0xfe, 0xd4, 0x87, 0x5c, 0x5f,
+	0x86, 0xb9, 0x2e, 0x55, 0xc1, 0x56, 0x9a, 0xd5, 0xf8, 0x00, 0x6d, 0x40, 0xd6, 0xb9, 0xc7, 0xde,
+	0xc0, 0xb3, 0x08, 0x66, 0x71, 0xc8, 0x68, 0x11, 0x41, 0x5d, 0x81, 0x52, 0x6c, 0x5a, 0xb1, 0xe2,
+	0x03, 0x28, 0x1d, 0x79, 0x18, 0x7f, 0x8f, 0x7f, 0xbe, 0x39, 0xea, 0x2a, 0x94, 0xe3, 0x3a, 0x84,
+	0xee, 0x43, 0x58, 0xb9, 0xb0, 0xaf, 0x7f, 0xa9, 0xf6, 0x0a, 0xac, 0x8e, 0x6b, 0x11, 0xfa, 0x7f,
+	0x0f, 0xf3, 0x27, 0x78, 0xa4, 0xe1, 0x77, 0xe8, 0x09, 0x14, 0xef, 0xf0, 0x48, 0xbf, 0xb6, 0xec,
+	0x1b, 0xec, 0xe9, 0xae, 0x17, 0x28, 0x9e, 0xd3, 0x16, 0xef, 0xf0, 0xe8, 0x88, 0x91, 0xcf, 0x29,
+	0x15, 0x7d, 0x02, 0xc0, 0x38, 0x8d, 0x9e, 0xd5, 0x1d, 0x89, 0xb3, 0x97, 0xa5, 0x3c, 0x8c, 0xa0,
+	0xfe, 0x29, 0x05, 0xeb, 0x1a, 0xf6, 0xb1, 0x77, 0x8f, 0x4f, 0x04, 0xd1, 0x0a, 0x77, 0x02, 0xf5,
+	0xbb, 0x33, 0xb0, 0xb1, 0xc7, 0x74, 0x67, 0x35, 0x3e, 0xa0, 0x93, 0x5f, 0x5b, 0x9e, 0x4f, 0xf4,
+	0x84, 0xe2, 0x45, 0x46, 0x0f, 0x14, 0x8d, 0xd0, 0xe7, 0xb0, 0xd4, 0x35, 0xe2, 0x8c, 0x69, 0xc6,
+	0x58, 0xe8, 0x1a, 0x12, 0x9f, 0xfa, 0x47, 0x28, 0x87, 0x03, 0x6e, 0x0d, 0x3b, 0x0c, 0xff, 0xb3,
+	0xf9, 0xff, 0x1f, 0xd6, 0xc5, 0xf9, 0xf8, 0x58, 0x27, 0xa8, 0x1b, 0xa0, 0x4c, 0x12, 0x11, 0x91,
+	0x52, 0x61, 0x9b, 0x1e, 0xa1, 0x49, 0x8b, 0x0a, 0x8f, 0xd9, 0xdf, 0x53, 0xb0, 0xf3, 0x1e, 0x26,
+	0x71, 0xec, 0xaa, 0x90, 0xf7, 0x24, 0xba, 0x38, 0x76, 0x5b, 0xd2, 0xb1, 0x9b, 0x24, 0xaf, 0xc5,
+	0x84, 0xd0, 0xb7, 0xf0, 0x90, 0x7b, 0x4c, 0x50, 0xaf, 0xba, 0x38, 0xe9, 0xbc, 0x0a, 0x63, 0xd1,
+	0x42, 0x8e, 0xc8, 0x3d, 0x8f, 0x21, 0xb7, 0x6f, 0x9a, 0x5e, 0xe0, 0x10, 0x29, 0x61, 0xa7, 0xe2,
+	0x09, 0x5b, 0x85, 0x3c, 0x67, 0x14, 0xc6, 0x23, 0x98, 0x35, 0x4c, 0x33, 0xf0, 0x1c, 0xfb, 0x56,
+	0x5f, 0x43, 0xae, 0xed, 0x19, 0xb6, 0x6f, 0x74, 0x58, 0x88, 0x57, 0x60, 0x9e, 0x0c, 0xf5, 0x5b,
+	0x3c, 0x14, 0xb9, 0x6d, 0x8e, 0x0c, 0xeb, 0x78, 0x38, 0xf9, 0xc4, 0xab, 0x5f, 0xc3, 0xd2, 0x79,
+	0xff, 0xaa, 0x6b, 0xf9, 0xb7, 0xe1, 0x14, 0x9f, 0x42, 0xc1, 0xe5, 0x24, 0x1d, 0x7b, 0x9e, 0x13,
+	0xcc, 0x95, 0x17, 0xc4, 0x1a, 0xa5, 0xa9, 0xff, 0x4a, 0x01, 0x6a, 0x61, 0xdb, 0xe4, 0xe7, 0x29,
+	0x8c, 0xec, 0x06, 0x80, 0x6f, 0x10, 0xdd, 0xc5, 0x9e, 0x7e, 0x37, 0x60, 0x82, 0x69, 0x2d, 0xe3,
+	0x1b, 0xe4, 0x1c, 0x7b, 0x27, 0x03, 0xf4, 0x04, 0x16, 0x1c, 0xce, 0x5f, 0x99, 0x61, 0x4e, 0x5f,
+	0xdc, 0x15, 0xf7, 0xe1, 0x6e, 0x7b, 0xd8, 0xec, 0x13, 0x2d, 0x80, 0x23, 0x63, 0xd3, 0x72, 0x7a,
+	0x8a, 0xdd, 0x88, 0xb3, 0x63, 0x37, 0xe2, 0x33, 0x58, 0xa6, 0x97, 0x9a, 0xa9, 0xf7, 0x6d, 0xca,
+	0x60, 0x79, 0x3d, 0x6c, 0x56, 0xe6, 0x58, 0x0e, 0x2b, 0x32, 0xe0, 0x22, 0xa2, 0xcb, 0x0e, 0x9f,
+	0x8f, 0x3b, 0xfc, 0x39, 0x94, 0x62, 0xeb, 0x12, 0x4e, 0x59, 0x81, 0x79, 0xcf, 0x18, 0xe8, 0x24,
+	0x74, 0xaa, 0x67, 0x0c, 0xda, 0x43, 0xf5, 0x2b, 0x40, 0x35, 0x9f, 0x58, 0x3d, 0x83, 0xe0, 0x23,
+	0x8c, 0x03, 0x2f, 0x6c, 0x41, 0x8e, 0x4e, 0xa5, 0x13, 0xc3, 0xbb, 0xc1, 0x41, 0x1a, 0x01, 0x4a,
+	0x6a, 0x33, 0x8a, 0xfa, 0x12, 0x4a, 0x31, 0x31, 0x31, 0xc9, 0x7b, 0xbd, 0xa7, 0xfe, 0x98, 0x86,
+	0xfc, 0x39, 0xb6, 0x4d, 0xcb, 0xbe, 0x69, 0x0d, 0x30, 0x76, 0x7f, 0x5a, 0xc2, 0xff, 0x06, 0xf2,
+	0x03, 0x8b, 0xd8, 0xd8, 0xf7, 0x75, 0x32, 0x72, 0x31, 0xdb, 0x05, 0x8b, 0x7b, 0xab, 0xd2, 0xae,
+	0xbf, 0xe4, 0x70, 0x7b, 0xe4, 0x62, 0x2d, 0x37, 0x88, 0x06, 0x34, 0xe1, 0x19, 0x3d, 0xea, 0x1c,
+	0xdd, 0x37, 0x78, 0x45, 0x51, 0xd0, 0xb2, 0x9c, 0xd2, 0x32, 0x08, 0xda, 0x86, 0x7c, 0x60, 0xf5,
+	0xd5, 0x88, 0x60, 0x16, 0x98, 0x82, 0x06, 0xdc, 0xee, 0x83, 0x11, 0xc1, 0xf4, 0x9e, 0xbf, 0xf2,
+	0x1c, 0xc3, 0xec, 0xd0, 0xcc, 0x61, 0x10, 0x82, 0x7b, 0x2e, 0xf1, 0x59, 0x6c, 0x0a, 0xda, 0x72,
+	0x88, 0xec, 0x0b, 0x00, 0xed, 0xc1, 0x8a, 0x8d, 0x87, 0x44, 0x8f, 0x64, 0x6e, 0xb1, 0x75, 0x73,
+	0xcb, 0x43, 0x55, 0xd0, 0x4a, 0x14, 0x3c, 0x08, 0xb0, 0x3a, 0x83, 0xa8, 0x8c, 0xc7, 0xbd, 0x8f,
+	0x4d, 0x5d, 0x76, 0x7e, 0x86, 0xcb, 0x84, 0x60, 0x35, 0x8c, 0x02, 0x7a, 0x09, 0xab, 0x91, 0x4c,
+	0x6c, 0x09, 0xd9, 0x31, 0xa1, 0x56, 0xb4, 0x96, 0x32, 0xcc, 0x5d, 0x3b, 0x5e, 0x07, 0x57, 0x16,
+	0xd8, 0xd6, 0xe2, 0x03, 0x7a, 0x7f, 0xc9, 0xa1, 0x09, 0x33, 0xd2, 0x25, 0xac, 0x8c, 0xd1, 0x45,
+	0xa8, 0x7f, 0x0b, 0x8b, 0x2e, 0x07, 0x74, 0x9f, 0x21, 0x22, 0x0d, 0xad, 0x49, 0x01, 0x91, 0x25,
+	0xb5, 0x82, 0x2b, 0xeb, 0x51, 0xff, 0x9a, 0x82, 0xc5, 0x83, 0x7e, 0xcf, 0x95, 0x76, 0xdd, 0x4f,
+	0xda, 0x0e, 0x5b, 0x90, 0xe3, 0x0e, 0x62, 0xce, 0x62, 0xbb, 0xa1, 0xa0, 0x01, 0x27, 0x51, 0x17,
+	0x25, 0xa2, 0x9a, 0x4e, 0x44, 0x35, 0xf4, 0xc4, 0xac, 0xec, 0x89, 0x65, 0x58, 0x0a, 0xed, 0x12,
+	0xa9, 0xfb, 0x4b, 0x5e, 0x12, 0xc5, 0x3c, 0x43, 0x4f, 0xe0, 0x3d, 0xf6, 0xae, 0x1c, 0x1f, 0x33,
+	0x63, 0x33, 0x5a, 0x30, 0x54, 0x7f, 0x98, 0x01, 0x24, 0xf3, 0x0b, 0x8f, 0x35, 0xa0, 0x44, 0xa2,
+	0x2c, 0xa7, 0x9b, 0x98, 0x18, 0x56, 0xd7, 0x17, 0x2b, 0x5d, 0x17, 0x2b, 0x95, 0xf2, 0xe0, 0x21,
+	0x67, 0xa8, 0x3f, 0xd0, 0x10, 0x49, 0x50, 0xd1, 0x25, 0x2c, 0xc9, 0xda, 0x2c, 0xd3, 0x17, 0x35,
+	0xdf, 0x73, 0x29, 0x00, 0x49, 0x2b, 0xe4, 0x09, 0x8e, 0x0f, 0xa9, 0xf2, 0x45, 0x49, 0xcd, 0xb1,
+	0xe9, 0x2b, 0xdf, 0xc0, 0x62, 0x9c, 0x07, 0x3d, 0x4e, 0x4e, 0x45, 0x63, 0x9d, 0x1d, 0x17, 0x3d,
+	0xc8, 0xc0, 0x3c, 0xdf, 0x0b, 0xaa, 0x01, 0x6b, 0xac, 0xd2, 0x92, 0x34, 0x05, 0x7e, 0x43, 0x30,
+	0x4b, 0x86, 0x61, 0xdd, 0xca, 0xbe, 0x7f, 0x56, 0x31, 0xa7, 0x40, 0x25, 0x39, 0x85, 0x08, 0xd8,
+	0x5f, 0x52, 0xb0, 0xb0, 0xcf, 0xf3, 0x21, 0x9d, 0xcf, 0x36, 0x7a, 0x38, 0xb8, 0x70, 0xe8, 0x37,
+	0x5a, 0x85, 0x79, 0xbb, 0xdf, 0xbb, 0xc2, 0x9e, 0xd8, 0x37, 0x62, 0x84, 0x9e, 0xd3, 0x7a, 0x9e,
+	0x60, 0xcf, 0x36, 0xba, 0xec, 0x32, 0x8c, 0x9e, 0x20, 0x05, 0xad, 0x18, 0x20, 0x27, 0x78, 0x54,
+	0x65, 0x9a, 0x9f, 0x03, 0xb2, 0xec, 0x04, 0x37, 0xcf, 0x1e, 0x45, 0xcb, 0x8e, 0x73, 0xb3, 0xe2,
+	0xd3, 0xf2, 0x89, 0x30, 0x2b, 0x3c, 0x60, 0x47, 0x50, 0x8e, 0x93, 0xc5, 0x6e, 0xd9, 0x85, 0x8c,
+	0xc8, 0xe8, 0xc1, 0xc9, 0x42, 0x52, 0x60, 0x05, 0xbb, 0x16, 0xf2, 0xa8, 0x5f, 0x40, 0xb9, 0xea,
+	0x61, 0x83, 0xe0, 0x00, 0x8a, 0xdc, 0x3d, 0xbe, 0x7c, 0x75, 0x0f, 0x0a, 0x1a, 0xf6, 0x3b, 0x46,
+	0x18, 0x93, 0x1d, 0xc8, 0xfb, 0xc4, 0xf0, 0xc2, 0x3c, 0xc5, 0x13, 0x7e, 0x8e, 0xd1, 0x78, 0x7e,
+	0x52, 0xff, 0x9d, 0x82, 0x3c, 0x17, 0xba, 0x70, 0x4d, 0x83, 0xe0, 0x8f, 0x90, 0xa1, 0x6e, 0x16,
+	0x20, 0x2f, 0x27, 0xc4, 0x88, 0xe6, 0x63, 0x62, 0xb9, 0x81, 0x20, 0x2f, 0xbf, 0xb2, 0xc4, 0x72,
+	0x85, 0xd8, 0x53, 0x28, 0xba, 0x9e, 0x73, 0xe3, 0xd1, 0x54, 0xef, 0x62, 0xaf, 0x83, 0x85, 0x57,
+	0x53, 0xda, 0x52, 0x40, 0x3f, 0xe7, 0x64, 0x9a, 0x35, 0x83, 0x2a, 0xa6, 0x8b, 0xef, 0x0d, 0x3b,
+	0xb4, 0x66, 0x8e, 0x29, 0x2d, 0x89, 0xfa, 0x85, 0x63, 0x42, 0x3d, 0x82, 0x59, 0xd3, 0xb1, 0x31,
+	0x4b, 0xc6, 0x19, 0x8d, 0x7d, 0xab, 0x7f, 0x9e, 0x81, 0xa5, 0xa3, 0xbe, 0x6d, 0x9e, 0xfb, 0x57,
+	0x24, 0x2a, 0xf2, 0x66, 0x5d, 0xff, 0x8a, 0x2f, 0x2c, 0x5f, 0x7f, 0xa0, 0xb1, 0x11, 0x7a, 0x0a,
+	0x69, 0xcf, 0x18, 0x88, 0xb3, 0xb6, 0x22, 0x85, 0xa4, 0x3d, 0x6c, 0xe3, 0x9e, 0xdb, 0x35, 0x08,
+	0xae, 0x3f, 0xd0, 0x28, 0x0f, 0xda, 0x89, 0xa7, 0x28, 0xb6, 0x8d, 0xea, 0xa9, 0x58, 0x92, 0xfa,
+	0x0c, 0x0a, 0x41, 0x92, 0xba, 0x8f, 0xee, 0x9e, 0x7a, 0x4a, 0xcb, 0xf1, 0x3c, 0xf5, 0x86, 0x12,
+	0xe5, 0xcb, 0x7e, 0x2e, 0x76, 0xd9, 0xa3, 0x47, 0xb0, 0xd8, 0xb9, 0x35, 0xec, 0x1b, 0xac, 0xd3,
+	0x42, 0x0a, 0xfb, 0xbe, 0xa8, 0x06, 0x0a, 0x9c, 0xba, 0xcf, 0x89, 0x68, 0x1d, 0x32, 0xac, 0x2e,
+	0xd5, 0x2d, 0x93, 0xa5, 0xfd, 0xbc, 0xb6, 0xc0, 0xc6, 0xc7, 0xe6, 0x01, 0x40, 0x86, 0x08, 0xbb,
+	0x0f, 0xe6, 0x61, 0xf6, 0x1a, 0x63, 0x5f, 0xfd, 0x5b, 0x0a, 0x8a, 0x91, 0x37, 0xc4, 0x86, 0xdc,
+	0x82, 0xdc, 0x75, 0xdf, 0x36, 0xb1, 0xa9, 0x47, 0x5e, 0xd1, 0x80, 0x93, 0x28, 0x23, 0xda, 0x85,
+	0x92, 0xb0, 0x85, 0x17, 0x41, 0xba, 0x65, 0x9b, 0x78, 0x28, 0x42, 0xbf, 0xcc, 0x21, 0x5e, 0x95,
+	0x1c, 0x53, 0x20, 0xf1, 0x7a, 0x4c, 0x7f, 0xec, 0xeb, 0xf1, 0x1f, 0x29, 0x80, 0xc8, 0xdb, 0xe8,
+	0x31, 0xcc, 0x5b, 0xb6, 0xdb, 0x0f, 0xcf, 0x49, 0xe2, 0xd2, 0x10, 0x30, 0xfa, 0xcd, 0x78, 0xf5,
+	0xa6, 0x4e, 0x0c, 0xdf, 0x2e, 0x37, 0xd2, 0xaf, 0xd9, 0xc4, 0x1b, 0x85, 0x15, 0x9d, 0xf2, 0x1a,
+	0xf2, 0x32, 0x80, 0x8a, 0x90, 0xbe, 0xc3, 0x23, 0x71, 0xae, 0xe8, 0x27, 0xcd, 0x62, 0xf7, 0x46,
+	0xb7, 0xcf, 0x4b, 0x93, 0x59, 0x8d, 0x0f, 0x5e, 0xcf, 0xbc, 0x4a, 0xa9, 0xb7, 0x90, 0x0d, 0xd7,
+	0xf2, 0xcb, 0x3a, 0x0e, 0xf1, 0x5e, 0x41, 0x3a, 0xd1, 0x2b, 0xf8, 0x1a, 0x4a, 0x47, 0x96, 0x6d,
+	0x74, 0xad, 0xef, 0xb1, 0xbc, 0x97, 0x3f, 0x14, 0x3c, 0xf5, 0x2d, 0x94, 0xe3, 0x72, 0x51, 0xd4,
+	0x59, 0xa7, 0x27, 0x2e, 0xc8, 0x49, 0x2c, 0xea, 0xdb, 0x90, 0xa7, 0x75, 0xe5, 0x35, 0x15, 0xa6,
+	0xd5, 0xe5, 0x0c, 0xe7, 0xf0, 0x8c, 0x01, 0xd3, 0xd7, 0x1e, 0x7e, 0xf1, 0x63, 0x1a, 0x72, 0x52,
+	0x69, 0x86, 0x4a, 0xb0, 0x74, 0x71, 0x76, 0x72, 0xd6, 0xbc, 0x3c, 0xd3, 0x2f, 0x8f, 0xdb, 0x67,
+	0xb5, 0x56, 0xab, 0xf8, 0x00, 0x55, 0xa0, 0x5c, 0x6d, 0x9e, 0x9e, 0x1e, 0xb7, 0x4f, 0x6b, 0x67,
+	0x6d, 0xbd, 0x7d, 0x7c, 0x5a, 0xd3, 0x1b, 0xcd, 0xea, 0x49, 0x31, 0x85, 0xd6, 0xa0, 0x24, 0x21,
+	0x67, 0x4d, 0xfd, 0xb0, 0xd6, 0xd8, 0x7f, 0x5b, 0x9c, 0x41, 0x2b, 0xb0, 0x2c, 0x01, 0x5a, 0xed,
+	0x4d, 0xf3, 0xa4, 0x56, 0x4c, 0x53, 0xfe, 0x7a, 0xbb, 0x51, 0xd5, 0x9b, 0x47, 0x47, 0x35, 0xad,
+	0x76, 0x18, 0x00, 0xb3, 0x74, 0x0a, 0x06, 0xec, 0x57, 0xab, 0xb5, 0xf3, 0x76, 0x84, 0xcc, 0xa1,
+	0x47, 0xb0, 0x13, 0x13, 0xa1, 0xd3, 0x37, 0x2f, 0xda, 0x7a, 0xab, 0x56, 0x6d, 0x9e, 0x1d, 0xea,
+	0x8d, 0xda, 0x9b, 0x5a, 0xa3, 0x38, 0x8f, 0x3e, 0x07, 0x35, 0xae, 0xa0, 0x75, 0x51, 0xad, 0xd6,
+	0x5a, 0xad, 0x38, 0xdf, 0x02, 0xda, 0x82, 0x87, 0x63, 0x16, 0x9c, 0x36, 0xdb, 0xb5, 0x40, 0x6b,
+	0x31, 0x83, 0xb6, 0x61, 0x63, 0xdc, 0x12, 0xc6, 0x21, 0xf4, 0x15, 0xb3, 0x68, 0x03, 0x2a, 0x8c,
+	0x43, 0xd6, 0x1c, 0xd8, 0x0b, 0xa8, 0x0c, 0x45, 0xe1, 0x39, 0xfd, 0xa4, 0xf6, 0x56, 0xaf, 0xef,
+	0xb7, 0xea, 0xc5, 0x1c, 0x7a, 0x08, 0x6b, 0x67, 0xb5, 0x16, 0x55, 0x97, 0x00, 0xf3, 0x63, 0xce,
+	0xda, 0x3f, 0xab, 0xd6, 0x9b, 0x5a, 0xb1, 0xb0, 0xf7, 0xcf, 0x25, 0xc8, 0x5e, 0xb2, 0x33, 0x70,
+	0x62, 0x11, 0xd4, 0x80, 0x9c, 0xd4, 0x5c, 0x43, 0x9f, 0x8c, 0x55, 0x12, 0xf1, 0xf6, 0x9e, 0xb2,
+	0x39, 0x0d, 0x0e, 0xeb, 0x9d, 0x9c, 0xd4, 0xed, 0x8a, 0x6b, 0x4b, 0x34, 0xb3, 0x94, 0xcd, 0x69,
+	0xb0, 0xd0, 0xa6, 0xd1, 0x3b, 0x4b, 0xea, 0x57, 0x21, 0xf9, 0xbd, 0x3b, 0xa9, 0x3d, 0xa6, 0x6c,
+	0x4f, 0x67, 0x10, 0x3a, 0x8f, 0x01, 0xa2, 0xae, 0x16, 0xda, 0x18, 0x5b, 0x4f, 0xac, 0x03, 0xa6,
+	0x7c, 0x32, 0x05, 0x95, 0x16, 0x1b, 0xb5, 0x96, 0xe2, 0x8b, 0x4d, 0x74, 0xba, 0x94, 0xcd, 0x69,
+	0xb0, 0xd0, 0xd6, 0x84, 0xbc, 0xdc, 0x4d, 0x42, 0x32, 0xff, 0x84, 0x56, 0x95, 0xb2, 0x35, 0x15,
+	0x17, 0x0a, 0x2f, 0x60, 0x31, 0xde, 0x40, 0x42, 0xb2, 0x77, 0x26, 0x76, 0xa8, 0x94, 0x9d, 0xf7,
+	0x70, 0x08, 0xb5, 0xaf, 0xa1, 0x70, 0x88, 0x3d, 0xeb, 0x1e, 0x9f, 0xe1, 0x21, 0x6d, 0x5a, 0xa0,
+	0xe5, 0x78, 0x13, 0x42, 0xc3, 0xef, 0x94, 0xd5, 0xf0, 0x89, 0x7c, 0x82, 0x47, 0x87, 0xd8, 0xef,
+	0x78, 0x96, 0x4b, 0x1c, 0x0f, 0xbd, 0x82, 0x2c, 0x97, 0xa5, 0x72, 0x25, 0x99, 0xa9, 0xe1, 0x74,
+	0x0c, 0xe2, 0x78, 0x53, 0x25, 0xbf, 0x03, 0x94, 0xec, 0x4f, 0xa1, 0xcf, 0x62, 0xe1, 0x9e, 0xd2,
+	0xbe, 0x52, 0x3e, 0xd4, 0x25, 0x41, 0x06, 0x20, 0xb1, 0x59, 0xa6, 0x2b, 0x9f, 0xd2, 0x16, 0x52,
+	0x1e, 0x7d, 0x80, 0x4b, 0x78, 0xed, 0x1e, 0xd6, 0xa7, 0x36, 0x79, 0xd0, 0xb3, 0xb1, 0x7d, 0xf6,
+	0xbe, 0x7e, 0x91, 0xf2, 0xfc, 0xe3, 0x98, 0xc5, 0xbc, 0xbf, 0x86, 0x0c, 0x8d, 0x13, 0x2d, 0x0a,
+	0x90, 0xfc, 0x6e, 0x96, 0x1a, 0x39, 0xca, 0x5a, 0x82, 0x1e, 0x6d, 0x49, 0xb9, 0x4e, 0x45, 0xe3,
+	0xa7, 0x7f, 0xac, 0xae, 0x55, 0xb6, 0xa6, 0xe2, 0x42, 0xe1, 0x21, 0x14, 0x62, 0x05, 0x6b, 0xec,
+	0x40, 0x4f, 0x2a, 0x65, 0x95, 0x09, 0x05, 0x30, 0xfa, 0x16, 0xe6, 0x79, 0x55, 0x8a, 0x2a, 0xf1,
+	0xf8, 0x47, 0xd5, 0xad, 0xb2, 0x96, 0x40, 0x78, 0x09, 0xfb, 0x7f, 0x29, 0x54, 0x07, 0x24, 0xba,
+	0x47, 0x72, 0x03, 0x4a, 0x76, 0x8e, 0x44, 0x57, 0x14, 0x89, 0x3e, 0xde, 0x74, 0x6a, 0x40, 0x4e,
+	0x6a, 0xbb, 0xc4, 0x12, 0x40, 0xb2, 0xcd, 0xa4, 0x6c, 0x4e, 0x83, 0x23, 0x6d, 0x52, 0x7f, 0x25,
+	0xa6, 0x2d, 0xd9, 0xae, 0x51, 0x36, 0xa7, 0xc1, 0x51, 0xee, 0x8c, 0x3d, 0xe2, 0x63, 0xae, 0x9e,
+	0xf4, 0xec, 0x57, 0xb6, 0xa7, 0x33, 0x08, 0x9d, 0xbf, 0x83, 0x05, 0xf1, 0x4c, 0x46, 0xeb, 0x12,
+	0x73, 0xfc, 0x49, 0xaf, 0x28, 0x93, 0xa0, 0x78, 0xf6, 0x15, 0x26, 0x6d, 0x4c, 0x79, 0xb6, 0x4e,
+	0xce, 0xbe, 0x63, 0xc6, 0x7c, 0x07, 0xc5, 0xf1, 0xb7, 0x20, 0x52, 0xc7, 0x73, 0x6c, 0xf2, 0x2d,
+	0xaa, 0x7c, 0xfa, 0x5e, 0x9e, 0xb0, 0xdd, 0x9a, 0x09, 0x8a, 0x61, 0x24, 0xaf, 0x67, 0xec, 0xbd,
+	0xa0, 0x3c, 0x9c, 0x88, 0x49, 0x19, 0x5d, 0xaa, 0xaf, 0xe2, 0x19, 0x3d, 0x59, 0xb0, 0x29, 0x5b,
+	0x53, 0x71, 0xae, 0xf0, 0xe0, 0xd9, 0x1f, 0x9e, 0xde, 0x58, 0xe4, 0xb6, 0x7f, 0xb5, 0xdb, 0x71,
+	0x7a, 0x2f, 0x3a, 0xde, 0xc8, 0x25, 0x4e, 0x0f, 0x3b, 0x83, 0x17, 0x5d, 0xdb, 0x7c, 0xc1, 0xca,
+	0xc7, 0x17, 0xa1, 0x86, 0xab, 0x79, 0xf6, 0x37, 0xdd, 0xcb, 0xff, 0x0c, 0x00, 0x87, 0xa2, 0x6d,
+	0x60, 0xef, 0x1b, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1946,6 +2964,26 @@ type WalletKitClient interface {
 	//originally lock the output.
 	ReleaseOutput(ctx context.Context, in *ReleaseOutputRequest, opts ...grpc.CallOption) (*ReleaseOutputResponse, error)
 	//
+	//ListLeases lists all currently leased outputs together with the ID they
+	//are leased to and the expiration of their lease.
+	ListLeases(ctx context.Context, in *ListLeasesRequest, opts ...grpc.CallOption) (*ListLeasesResponse, error)
+	//
+	//LabelOutput adds a label to an unspent output controlled by the wallet. If
+	//the output already has a label the call will fail unless the overwrite
+	//bool is set. Labels are persisted in the wallet database, must not be
+	//empty, and cannot exceed 500 characters.
+	LabelOutput(ctx context.Context, in *LabelOutputRequest, opts ...grpc.CallOption) (*LabelOutputResponse, error)
+	//
+	//FreezeOutput freezes an unspent output controlled by the wallet. Unlike a
+	//lease, a freeze doesn't expire and is persisted across restarts. Frozen
+	//outputs are never selected as inputs for channel fundings, sweeps or any
+	//other coin selection performed by lnd until they are unfrozen again.
+	FreezeOutput(ctx context.Context, in *FreezeOutputRequest, opts ...grpc.CallOption) (*FreezeOutputResponse, error)
+	//
+	//UnfreezeOutput unfreezes a previously frozen output, making it available
+	//for coin selection again.
+	UnfreezeOutput(ctx context.Context, in *UnfreezeOutputRequest, opts ...grpc.CallOption) (*UnfreezeOutputResponse, error)
+	//
 	//DeriveNextKey attempts to derive the *next* key within the key family
 	//(account in BIP43) specified. This method should return the next external
 	//child within this branch.
@@ -1955,9 +2993,38 @@ type WalletKitClient interface {
 	//KeyLocator.
 	DeriveKey(ctx context.Context, in *signrpc.KeyLocator, opts ...grpc.CallOption) (*signrpc.KeyDescriptor, error)
 	//
+	//ReserveKeyFamilies reserves a range of key families for the exclusive use
+	//of an external application, so its key derivations don't collide with
+	//those of lnd or other applications. Key families below
+	//first_reservable_key_family as returned by ListKeyFamilyReservations are
+	//kept for lnd. Reserving the exact range an owner already holds succeeds
+	//without any effect. The reservations are stored in the wallet database.
+	ReserveKeyFamilies(ctx context.Context, in *ReserveKeyFamiliesRequest, opts ...grpc.CallOption) (*KeyFamilyReservation, error)
+	//
+	//ReleaseKeyFamilies releases the key family range reserved by an owner.
+	ReleaseKeyFamilies(ctx context.Context, in *ReleaseKeyFamiliesRequest, opts ...grpc.CallOption) (*ReleaseKeyFamiliesResponse, error)
+	//
+	//ListKeyFamilyReservations lists all reserved key family ranges.
+	ListKeyFamilyReservations(ctx context.Context, in *ListKeyFamilyReservationsRequest, opts ...grpc.CallOption) (*ListKeyFamilyReservationsResponse, error)
+	//
 	//NextAddr returns the next unused address within the wallet.
 	NextAddr(ctx context.Context, in *AddrRequest, opts ...grpc.CallOption) (*AddrResponse, error)
 	//
+	//ListAccounts returns all accounts of the wallet. Accounts are derived from
+	//the wallet's seed, and are used to keep the funds of the wallet separated.
+	ListAccounts(ctx context.Context, in *ListAccountsRequest, opts ...grpc.CallOption) (*ListAccountsResponse, error)
+	//
+	//CreateAccount derives a new account with the given name from the wallet's
+	//seed. The account can hold both p2wkh and np2wkh outputs.
+	CreateAccount(ctx context.Context, in *CreateAccountRequest, opts ...grpc.CallOption) (*Account, error)
+	//
+	//Rescan scans the chain for transactions relevant to the wallet, starting at
+	//the given height, and streams the progress of the scan. This is useful after
+	//restoring a wallet whose birthday is far in the past. Closing the stream
+	//cancels the scan. Once all blocks were scanned, the relevant transactions
+	//are handed to the wallet and a final update with the done flag set is sent.
+	Rescan(ctx context.Context, in *RescanRequest, opts ...grpc.CallOption) (WalletKit_RescanClient, error)
+	//
 	//PublishTransaction attempts to publish the passed transaction to the
 	//network. Once this returns without an error, the wallet will continually
 	//attempt to re-broadcast the transaction on start up, until it enters the
@@ -2034,7 +3101,9 @@ type WalletKitClient interface {
 	//with the given fee rate, an error is returned.
 	//
 	//After either selecting or verifying the inputs, all input UTXOs are locked
-	//with an internal app ID.
+	//with an internal app ID, or with the lease ID given in the request. Coins
+	//are selected from, and change is sent to, the requested account unless a
+	//change address is given.
 	//
 	//NOTE: If this method returns without an error, it is the caller's
 	//responsibility to either spend the locked UTXOs (by finalizing and then
@@ -2091,6 +3160,42 @@ func (c *walletKitClient) ReleaseOutput(ctx context.Context, in *ReleaseOutputRe
 	return out, nil
 }
 
+func (c *walletKitClient) ListLeases(ctx context.Context, in *ListLeasesRequest, opts ...grpc.CallOption) (*ListLeasesResponse, error) {
+	out := new(ListLeasesResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.WalletKit/ListLeases", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletKitClient) LabelOutput(ctx context.Context, in *LabelOutputRequest, opts ...grpc.CallOption) (*LabelOutputResponse, error) {
+	out := new(LabelOutputResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.WalletKit/LabelOutput", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletKitClient) FreezeOutput(ctx context.Context, in *FreezeOutputRequest, opts ...grpc.CallOption) (*FreezeOutputResponse, error) {
+	out := new(FreezeOutputResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.WalletKit/FreezeOutput", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletKitClient) UnfreezeOutput(ctx context.Context, in *UnfreezeOutputRequest, opts ...grpc.CallOption) (*UnfreezeOutputResponse, error) {
+	out := new(UnfreezeOutputResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.WalletKit/UnfreezeOutput", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *walletKitClient) DeriveNextKey(ctx context.Context, in *KeyReq, opts ...grpc.CallOption) (*signrpc.KeyDescriptor, error) {
 	out := new(signrpc.KeyDescriptor)
 	err := c.cc.Invoke(ctx, "/walletrpc.WalletKit/DeriveNextKey", in, out, opts...)
@@ -2109,6 +3214,33 @@ func (c *walletKitClient) DeriveKey(ctx context.Context, in *signrpc.KeyLocator,
 	return out, nil
 }
 
+func (c *walletKitClient) ReserveKeyFamilies(ctx context.Context, in *ReserveKeyFamiliesRequest, opts ...grpc.CallOption) (*KeyFamilyReservation, error) {
+	out := new(KeyFamilyReservation)
+	err := c.cc.Invoke(ctx, "/walletrpc.WalletKit/ReserveKeyFamilies", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletKitClient) ReleaseKeyFamilies(ctx context.Context, in *ReleaseKeyFamiliesRequest, opts ...grpc.CallOption) (*ReleaseKeyFamiliesResponse, error) {
+	out := new(ReleaseKeyFamiliesResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.WalletKit/ReleaseKeyFamilies", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletKitClient) ListKeyFamilyReservations(ctx context.Context, in *ListKeyFamilyReservationsRequest, opts ...grpc.CallOption) (*ListKeyFamilyReservationsResponse, error) {
+	out := new(ListKeyFamilyReservationsResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.WalletKit/ListKeyFamilyReservations", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *walletKitClient) NextAddr(ctx context.Context, in *AddrRequest, opts ...grpc.CallOption) (*AddrResponse, error) {
 	out := new(AddrResponse)
 	err := c.cc.Invoke(ctx, "/walletrpc.WalletKit/NextAddr", in, out, opts...)
@@ -2118,6 +3250,56 @@ func (c *walletKitClient) NextAddr(ctx context.Context, in *AddrRequest, opts ..
 	return out, nil
 }
 
+func (c *walletKitClient) ListAccounts(ctx context.Context, in *ListAccountsRequest, opts ...grpc.CallOption) (*ListAccountsResponse, error) {
+	out := new(ListAccountsResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.WalletKit/ListAccounts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletKitClient) CreateAccount(ctx context.Context, in *CreateAccountRequest, opts ...grpc.CallOption) (*Account, error) {
+	out := new(Account)
+	err := c.cc.Invoke(ctx, "/walletrpc.WalletKit/CreateAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletKitClient) Rescan(ctx context.Context, in *RescanRequest, opts ...grpc.CallOption) (WalletKit_RescanClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_WalletKit_serviceDesc.Streams[0], "/walletrpc.WalletKit/Rescan", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &walletKitRescanClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type WalletKit_RescanClient interface {
+	Recv() (*RescanUpdate, error)
+	grpc.ClientStream
+}
+
+type walletKitRescanClient struct {
+	grpc.ClientStream
+}
+
+func (x *walletKitRescanClient) Recv() (*RescanUpdate, error) {
+	m := new(RescanUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *walletKitClient) PublishTransaction(ctx context.Context, in *Transaction, opts ...grpc.CallOption) (*PublishResponse, error) {
 	out := new(PublishResponse)
 	err := c.cc.Invoke(ctx, "/walletrpc.WalletKit/PublishTransaction", in, out, opts...)
@@ -2218,6 +3400,26 @@ type WalletKitServer interface {
 	//originally lock the output.
 	ReleaseOutput(context.Context, *ReleaseOutputRequest) (*ReleaseOutputResponse, error)
 	//
+	//ListLeases lists all currently leased outputs together with the ID they
+	//are leased to and the expiration of their lease.
+	ListLeases(context.Context, *ListLeasesRequest) (*ListLeasesResponse, error)
+	//
+	//LabelOutput adds a label to an unspent output controlled by the wallet. If
+	//the output already has a label the call will fail unless the overwrite
+	//bool is set. Labels are persisted in the wallet database, must not be
+	//empty, and cannot exceed 500 characters.
+	LabelOutput(context.Context, *LabelOutputRequest) (*LabelOutputResponse, error)
+	//
+	//FreezeOutput freezes an unspent output controlled by the wallet. Unlike a
+	//lease, a freeze doesn't expire and is persisted across restarts. Frozen
+	//outputs are never selected as inputs for channel fundings, sweeps or any
+	//other coin selection performed by lnd until they are unfrozen again.
+	FreezeOutput(context.Context, *FreezeOutputRequest) (*FreezeOutputResponse, error)
+	//
+	//UnfreezeOutput unfreezes a previously frozen output, making it available
+	//for coin selection again.
+	UnfreezeOutput(context.Context, *UnfreezeOutputRequest) (*UnfreezeOutputResponse, error)
+	//
 	//DeriveNextKey attempts to derive the *next* key within the key family
 	//(account in BIP43) specified. This method should return the next external
 	//child within this branch.
@@ -2227,9 +3429,38 @@ type WalletKitServer interface {
 	//KeyLocator.
 	DeriveKey(context.Context, *signrpc.KeyLocator) (*signrpc.KeyDescriptor, error)
 	//
+	//ReserveKeyFamilies reserves a range of key families for the exclusive use
+	//of an external application, so its key derivations don't collide with
+	//those of lnd or other applications. Key families below
+	//first_reservable_key_family as returned by ListKeyFamilyReservations are
+	//kept for lnd. Reserving the exact range an owner already holds succeeds
+	//without any effect. The reservations are stored in the wallet database.
+	ReserveKeyFamilies(context.Context, *ReserveKeyFamiliesRequest) (*KeyFamilyReservation, error)
+	//
+	//ReleaseKeyFamilies releases the key family range reserved by an owner.
+	ReleaseKeyFamilies(context.Context, *ReleaseKeyFamiliesRequest) (*ReleaseKeyFamiliesResponse, error)
+	//
+	//ListKeyFamilyReservations lists all reserved key family ranges.
+	ListKeyFamilyReservations(context.Context, *ListKeyFamilyReservationsRequest) (*ListKeyFamilyReservationsResponse, error)
+	//
 	//NextAddr returns the next unused address within the wallet.
 	NextAddr(context.Context, *AddrRequest) (*AddrResponse, error)
 	//
+	//ListAccounts returns all accounts of the wallet. Accounts are derived from
+	//the wallet's seed, and are used to keep the funds of the wallet separated.
+	ListAccounts(context.Context, *ListAccountsRequest) (*ListAccountsResponse, error)
+	//
+	//CreateAccount derives a new account with the given name from the wallet's
+	//seed. The account can hold both p2wkh and np2wkh outputs.
+	CreateAccount(context.Context, *CreateAccountRequest) (*Account, error)
+	//
+	//Rescan scans the chain for transactions relevant to the wallet, starting at
+	//the given height, and streams the progress of the scan. This is useful after
+	//restoring a wallet whose birthday is far in the past. Closing the stream
+	//cancels the scan. Once all blocks were scanned, the relevant transactions
+	//are handed to the wallet and a final update with the done flag set is sent.
+	Rescan(*RescanRequest, WalletKit_RescanServer) error
+	//
 	//PublishTransaction attempts to publish the passed transaction to the
 	//network. Once this returns without an error, the wallet will continually
 	//attempt to re-broadcast the transaction on start up, until it enters the
@@ -2306,7 +3537,9 @@ type WalletKitServer interface {
 	//with the given fee rate, an error is returned.
 	//
 	//After either selecting or verifying the inputs, all input UTXOs are locked
-	//with an internal app ID.
+	//with an internal app ID, or with the lease ID given in the request. Coins
+	//are selected from, and change is sent to, the requested account unless a
+	//change address is given.
 	//
 	//NOTE: If this method returns without an error, it is the caller's
 	//responsibility to either spend the locked UTXOs (by finalizing and then
@@ -2341,15 +3574,45 @@ func (*UnimplementedWalletKitServer) LeaseOutput(ctx context.Context, req *Lease
 func (*UnimplementedWalletKitServer) ReleaseOutput(ctx context.Context, req *ReleaseOutputRequest) (*ReleaseOutputResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ReleaseOutput not implemented")
 }
+func (*UnimplementedWalletKitServer) ListLeases(ctx context.Context, req *ListLeasesRequest) (*ListLeasesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLeases not implemented")
+}
+func (*UnimplementedWalletKitServer) LabelOutput(ctx context.Context, req *LabelOutputRequest) (*LabelOutputResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LabelOutput not implemented")
+}
+func (*UnimplementedWalletKitServer) FreezeOutput(ctx context.Context, req *FreezeOutputRequest) (*FreezeOutputResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FreezeOutput not implemented")
+}
+func (*UnimplementedWalletKitServer) UnfreezeOutput(ctx context.Context, req *UnfreezeOutputRequest) (*UnfreezeOutputResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnfreezeOutput not implemented")
+}
 func (*UnimplementedWalletKitServer) DeriveNextKey(ctx context.Context, req *KeyReq) (*signrpc.KeyDescriptor, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeriveNextKey not implemented")
 }
 func (*UnimplementedWalletKitServer) DeriveKey(ctx context.Context, req *signrpc.KeyLocator) (*signrpc.KeyDescriptor, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeriveKey not implemented")
 }
+func (*UnimplementedWalletKitServer) ReserveKeyFamilies(ctx context.Context, req *ReserveKeyFamiliesRequest) (*KeyFamilyReservation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReserveKeyFamilies not implemented")
+}
+func (*UnimplementedWalletKitServer) ReleaseKeyFamilies(ctx context.Context, req *ReleaseKeyFamiliesRequest) (*ReleaseKeyFamiliesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseKeyFamilies not implemented")
+}
+func (*UnimplementedWalletKitServer) ListKeyFamilyReservations(ctx context.Context, req *ListKeyFamilyReservationsRequest) (*ListKeyFamilyReservationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListKeyFamilyReservations not implemented")
+}
 func (*UnimplementedWalletKitServer) NextAddr(ctx context.Context, req *AddrRequest) (*AddrResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method NextAddr not implemented")
 }
+func (*UnimplementedWalletKitServer) ListAccounts(ctx context.Context, req *ListAccountsRequest) (*ListAccountsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAccounts not implemented")
+}
+func (*UnimplementedWalletKitServer) CreateAccount(ctx context.Context, req *CreateAccountRequest) (*Account, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAccount not implemented")
+}
+func (*UnimplementedWalletKitServer) Rescan(req *RescanRequest, srv WalletKit_RescanServer) error {
+	return status.Errorf(codes.Unimplemented, "method Rescan not implemented")
+}
 func (*UnimplementedWalletKitServer) PublishTransaction(ctx context.Context, req *Transaction) (*PublishResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PublishTransaction not implemented")
 }
@@ -2436,6 +3699,78 @@ func _WalletKit_ReleaseOutput_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WalletKit_ListLeases_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLeasesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletKitServer).ListLeases(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.WalletKit/ListLeases",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletKitServer).ListLeases(ctx, req.(*ListLeasesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletKit_LabelOutput_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LabelOutputRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletKitServer).LabelOutput(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.WalletKit/LabelOutput",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletKitServer).LabelOutput(ctx, req.(*LabelOutputRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletKit_FreezeOutput_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FreezeOutputRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletKitServer).FreezeOutput(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.WalletKit/FreezeOutput",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletKitServer).FreezeOutput(ctx, req.(*FreezeOutputRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletKit_UnfreezeOutput_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnfreezeOutputRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletKitServer).UnfreezeOutput(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.WalletKit/UnfreezeOutput",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletKitServer).UnfreezeOutput(ctx, req.(*UnfreezeOutputRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _WalletKit_DeriveNextKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(KeyReq)
 	if err := dec(in); err != nil {
@@ -2472,6 +3807,60 @@ func _WalletKit_DeriveKey_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WalletKit_ReserveKeyFamilies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReserveKeyFamiliesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletKitServer).ReserveKeyFamilies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.WalletKit/ReserveKeyFamilies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletKitServer).ReserveKeyFamilies(ctx, req.(*ReserveKeyFamiliesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletKit_ReleaseKeyFamilies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseKeyFamiliesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletKitServer).ReleaseKeyFamilies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.WalletKit/ReleaseKeyFamilies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletKitServer).ReleaseKeyFamilies(ctx, req.(*ReleaseKeyFamiliesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletKit_ListKeyFamilyReservations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListKeyFamilyReservationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletKitServer).ListKeyFamilyReservations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.WalletKit/ListKeyFamilyReservations",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletKitServer).ListKeyFamilyReservations(ctx, req.(*ListKeyFamilyReservationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _WalletKit_NextAddr_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AddrRequest)
 	if err := dec(in); err != nil {
@@ -2490,6 +3879,63 @@ func _WalletKit_NextAddr_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WalletKit_ListAccounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAccountsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletKitServer).ListAccounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.WalletKit/ListAccounts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletKitServer).ListAccounts(ctx, req.(*ListAccountsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletKit_CreateAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletKitServer).CreateAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.WalletKit/CreateAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletKitServer).CreateAccount(ctx, req.(*CreateAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletKit_Rescan_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RescanRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WalletKitServer).Rescan(m, &walletKitRescanServer{stream})
+}
+
+type WalletKit_RescanServer interface {
+	Send(*RescanUpdate) error
+	grpc.ServerStream
+}
+
+type walletKitRescanServer struct {
+	grpc.ServerStream
+}
+
+func (x *walletKitRescanServer) Send(m *RescanUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _WalletKit_PublishTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Transaction)
 	if err := dec(in); err != nil {
@@ -2668,6 +4114,22 @@ var _WalletKit_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ReleaseOutput",
 			Handler:    _WalletKit_ReleaseOutput_Handler,
 		},
+		{
+			MethodName: "ListLeases",
+			Handler:    _WalletKit_ListLeases_Handler,
+		},
+		{
+			MethodName: "LabelOutput",
+			Handler:    _WalletKit_LabelOutput_Handler,
+		},
+		{
+			MethodName: "FreezeOutput",
+			Handler:    _WalletKit_FreezeOutput_Handler,
+		},
+		{
+			MethodName: "UnfreezeOutput",
+			Handler:    _WalletKit_UnfreezeOutput_Handler,
+		},
 		{
 			MethodName: "DeriveNextKey",
 			Handler:    _WalletKit_DeriveNextKey_Handler,
@@ -2676,10 +4138,30 @@ var _WalletKit_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeriveKey",
 			Handler:    _WalletKit_DeriveKey_Handler,
 		},
+		{
+			MethodName: "ReserveKeyFamilies",
+			Handler:    _WalletKit_ReserveKeyFamilies_Handler,
+		},
+		{
+			MethodName: "ReleaseKeyFamilies",
+			Handler:    _WalletKit_ReleaseKeyFamilies_Handler,
+		},
+		{
+			MethodName: "ListKeyFamilyReservations",
+			Handler:    _WalletKit_ListKeyFamilyReservations_Handler,
+		},
 		{
 			MethodName: "NextAddr",
 			Handler:    _WalletKit_NextAddr_Handler,
 		},
+		{
+			MethodName: "ListAccounts",
+			Handler:    _WalletKit_ListAccounts_Handler,
+		},
+		{
+			MethodName: "CreateAccount",
+			Handler:    _WalletKit_CreateAccount_Handler,
+		},
 		{
 			MethodName: "PublishTransaction",
 			Handler:    _WalletKit_PublishTransaction_Handler,
@@ -2717,6 +4199,12 @@ var _WalletKit_serviceDesc = grpc.ServiceDesc{
 			Handler:    _WalletKit_FinalizePsbt_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Rescan",
+			Handler:       _WalletKit_Rescan_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "walletrpc/walletkit.proto",
 }
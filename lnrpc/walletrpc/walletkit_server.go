@@ -17,6 +17,7 @@ import (
 	"github.com/btcsuite/btcd/wire"
 	"github.com/btcsuite/btcutil"
 	"github.com/btcsuite/btcutil/psbt"
+	"github.com/btcsuite/btcwallet/waddrmgr"
 	"github.com/btcsuite/btcwallet/wtxmgr"
 	"github.com/grpc-ecosystem/grpc-gateway/runtime"
 	"github.com/cryptomeow/lnd/input"
@@ -38,6 +39,11 @@ const (
 	// to register ourselves, and we also require that the main
 	// SubServerConfigDispatcher instance recognize as the name of our
 	subServerName = "WalletKitRPC"
+
+	// maxLeaseDuration is the longest duration an output can be leased for
+	// through a single LeaseOutput call. Coordinators that need to hold an
+	// output for longer can extend the lease before it expires.
+	maxLeaseDuration = 30 * 24 * time.Hour
 )
 
 var (
@@ -72,10 +78,34 @@ var (
 			Entity: "address",
 			Action: "read",
 		}},
+		"/walletrpc.WalletKit/ReserveKeyFamilies": {{
+			Entity: "address",
+			Action: "write",
+		}},
+		"/walletrpc.WalletKit/ReleaseKeyFamilies": {{
+			Entity: "address",
+			Action: "write",
+		}},
+		"/walletrpc.WalletKit/ListKeyFamilyReservations": {{
+			Entity: "address",
+			Action: "read",
+		}},
 		"/walletrpc.WalletKit/NextAddr": {{
 			Entity: "address",
 			Action: "read",
 		}},
+		"/walletrpc.WalletKit/ListAccounts": {{
+			Entity: "onchain",
+			Action: "read",
+		}},
+		"/walletrpc.WalletKit/CreateAccount": {{
+			Entity: "onchain",
+			Action: "write",
+		}},
+		"/walletrpc.WalletKit/Rescan": {{
+			Entity: "onchain",
+			Action: "write",
+		}},
 		"/walletrpc.WalletKit/PublishTransaction": {{
 			Entity: "onchain",
 			Action: "write",
@@ -112,6 +142,22 @@ var (
 			Entity: "onchain",
 			Action: "write",
 		}},
+		"/walletrpc.WalletKit/ListLeases": {{
+			Entity: "onchain",
+			Action: "read",
+		}},
+		"/walletrpc.WalletKit/LabelOutput": {{
+			Entity: "onchain",
+			Action: "write",
+		}},
+		"/walletrpc.WalletKit/FreezeOutput": {{
+			Entity: "onchain",
+			Action: "write",
+		}},
+		"/walletrpc.WalletKit/UnfreezeOutput": {{
+			Entity: "onchain",
+			Action: "write",
+		}},
 		"/walletrpc.WalletKit/ListUnspent": {{
 			Entity: "onchain",
 			Action: "read",
@@ -271,7 +317,8 @@ func (w *WalletKit) RegisterWithRestServer(ctx context.Context,
 // scriptPubKey in hex and number of confirmations.  The result is filtered to
 // contain outputs whose number of confirmations is between a
 // minimum and maximum number of confirmations specified by the user, with 0
-// meaning unconfirmed.
+// meaning unconfirmed. If an account is specified, only its outputs are
+// returned.
 func (w *WalletKit) ListUnspent(ctx context.Context,
 	req *ListUnspentRequest) (*ListUnspentResponse, error) {
 
@@ -289,7 +336,9 @@ func (w *WalletKit) ListUnspent(ctx context.Context,
 	// be shown available to us.
 	var utxos []*lnwallet.Utxo
 	err = w.cfg.CoinSelectionLocker.WithCoinSelectLock(func() error {
-		utxos, err = w.cfg.Wallet.ListUnspentWitness(minConfs, maxConfs)
+		utxos, err = w.cfg.Wallet.ListUnspentWitness(
+			minConfs, maxConfs, req.Account,
+		)
 		return err
 	})
 	if err != nil {
@@ -307,10 +356,12 @@ func (w *WalletKit) ListUnspent(ctx context.Context,
 }
 
 // LeaseOutput locks an output to the given ID, preventing it from being
-// available for any future coin selection attempts. The absolute time of the
-// lock's expiration is returned. The expiration of the lock can be extended by
-// successive invocations of this call. Outputs can be unlocked before their
-// expiration through `ReleaseOutput`.
+// available for any future coin selection attempts. The output is leased for
+// the requested number of seconds, or for the wallet's default duration if
+// none is given. The absolute time of the lock's expiration is returned. The
+// expiration of the lock can be changed by successive invocations of this
+// call. Outputs can be unlocked before their expiration through
+// `ReleaseOutput`.
 //
 // If the output is not known, wtxmgr.ErrUnknownOutput is returned. If the
 // output has already been locked to a different ID, then
@@ -335,16 +386,24 @@ func (w *WalletKit) LeaseOutput(ctx context.Context,
 		return nil, errors.New("reserved id cannot be used")
 	}
 
-	op, err := unmarshallOutPoint(req.Outpoint)
+	op, err := lnrpc.UnmarshallOutPoint(req.Outpoint)
 	if err != nil {
 		return nil, err
 	}
 
+	if req.ExpirationSeconds > uint64(maxLeaseDuration/time.Second) {
+		return nil, fmt.Errorf("expiration must not exceed %v",
+			maxLeaseDuration)
+	}
+	duration := time.Duration(req.ExpirationSeconds) * time.Second
+
 	// Acquire the global coin selection lock to ensure there aren't any
 	// other concurrent processes attempting to lease the same UTXO.
 	var expiration time.Time
 	err = w.cfg.CoinSelectionLocker.WithCoinSelectLock(func() error {
-		expiration, err = w.cfg.Wallet.LeaseOutput(lockID, *op)
+		expiration, err = w.cfg.Wallet.LeaseOutput(
+			lockID, *op, duration,
+		)
 		return err
 	})
 	if err != nil {
@@ -368,7 +427,7 @@ func (w *WalletKit) ReleaseOutput(ctx context.Context,
 	var lockID wtxmgr.LockID
 	copy(lockID[:], req.Id)
 
-	op, err := unmarshallOutPoint(req.Outpoint)
+	op, err := lnrpc.UnmarshallOutPoint(req.Outpoint)
 	if err != nil {
 		return nil, err
 	}
@@ -385,6 +444,98 @@ func (w *WalletKit) ReleaseOutput(ctx context.Context,
 	return &ReleaseOutputResponse{}, nil
 }
 
+// ListLeases lists all currently leased outputs together with the ID they are
+// leased to and the expiration of their lease.
+func (w *WalletKit) ListLeases(ctx context.Context,
+	_ *ListLeasesRequest) (*ListLeasesResponse, error) {
+
+	leases, err := w.cfg.Wallet.ListLeasedOutputs()
+	if err != nil {
+		return nil, err
+	}
+
+	rpcLeases := make([]*UtxoLease, 0, len(leases))
+	for _, lease := range leases {
+		lockID := lease.LockID
+		rpcLeases = append(rpcLeases, &UtxoLease{
+			Id: lockID[:],
+			Outpoint: &lnrpc.OutPoint{
+				TxidBytes:   lease.Outpoint.Hash[:],
+				TxidStr:     lease.Outpoint.Hash.String(),
+				OutputIndex: lease.Outpoint.Index,
+			},
+			Expiration: uint64(lease.Expiration.Unix()),
+		})
+	}
+
+	return &ListLeasesResponse{
+		LockedUtxos: rpcLeases,
+	}, nil
+}
+
+// LabelOutput adds a label to an unspent output controlled by the wallet. If
+// the output already has a label the call will fail unless the overwrite bool
+// is set.
+func (w *WalletKit) LabelOutput(ctx context.Context,
+	req *LabelOutputRequest) (*LabelOutputResponse, error) {
+
+	op, err := lnrpc.UnmarshallOutPoint(req.Outpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	err = w.cfg.Wallet.LabelOutput(*op, req.Label, req.Overwrite)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LabelOutputResponse{}, nil
+}
+
+// FreezeOutput freezes an unspent output controlled by the wallet, excluding
+// it from any coin selection performed by lnd until it is unfrozen again.
+func (w *WalletKit) FreezeOutput(ctx context.Context,
+	req *FreezeOutputRequest) (*FreezeOutputResponse, error) {
+
+	op, err := lnrpc.UnmarshallOutPoint(req.Outpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	// Acquire the global coin selection lock to ensure the output isn't
+	// frozen while it's being considered by a concurrent coin selection.
+	err = w.cfg.CoinSelectionLocker.WithCoinSelectLock(func() error {
+		return w.cfg.Wallet.FreezeOutput(*op)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &FreezeOutputResponse{}, nil
+}
+
+// UnfreezeOutput unfreezes a previously frozen output, making it available for
+// coin selection again.
+func (w *WalletKit) UnfreezeOutput(ctx context.Context,
+	req *UnfreezeOutputRequest) (*UnfreezeOutputResponse, error) {
+
+	op, err := lnrpc.UnmarshallOutPoint(req.Outpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	// Acquire the global coin selection lock to maintain consistency as
+	// it's acquired when we initially froze the output.
+	err = w.cfg.CoinSelectionLocker.WithCoinSelectLock(func() error {
+		return w.cfg.Wallet.UnfreezeOutput(*op)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &UnfreezeOutputResponse{}, nil
+}
+
 // DeriveNextKey attempts to derive the *next* key within the key family
 // (account in BIP43) specified. This method should return the next external
 // child within this branch.
@@ -429,11 +580,90 @@ func (w *WalletKit) DeriveKey(ctx context.Context,
 	}, nil
 }
 
+// ReserveKeyFamilies reserves a range of key families for the exclusive use of
+// an external application.
+func (w *WalletKit) ReserveKeyFamilies(ctx context.Context,
+	req *ReserveKeyFamiliesRequest) (*KeyFamilyReservation, error) {
+
+	if req.FirstKeyFamily < 0 || req.LastKeyFamily < 0 {
+		return nil, fmt.Errorf("key families must not be negative")
+	}
+
+	reservation, err := w.cfg.KeyFamilyRegistry.ReserveKeyFamilies(
+		req.Owner, keychain.KeyFamily(req.FirstKeyFamily),
+		keychain.KeyFamily(req.LastKeyFamily),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("Reserved key families %v-%v for %v", reservation.First,
+		reservation.Last, reservation.Owner)
+
+	return marshallKeyFamilyReservation(reservation), nil
+}
+
+// ReleaseKeyFamilies releases the key family range reserved by an owner.
+func (w *WalletKit) ReleaseKeyFamilies(ctx context.Context,
+	req *ReleaseKeyFamiliesRequest) (*ReleaseKeyFamiliesResponse, error) {
+
+	err := w.cfg.KeyFamilyRegistry.ReleaseKeyFamilies(req.Owner)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("Released key families of %v", req.Owner)
+
+	return &ReleaseKeyFamiliesResponse{}, nil
+}
+
+// ListKeyFamilyReservations lists all reserved key family ranges.
+func (w *WalletKit) ListKeyFamilyReservations(ctx context.Context,
+	req *ListKeyFamilyReservationsRequest) (
+	*ListKeyFamilyReservationsResponse, error) {
+
+	reservations, err := w.cfg.KeyFamilyRegistry.KeyFamilyReservations()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &ListKeyFamilyReservationsResponse{
+		Reservations: make(
+			[]*KeyFamilyReservation, 0, len(reservations),
+		),
+		FirstReservableKeyFamily: int32(
+			keychain.FirstReservableKeyFamily,
+		),
+	}
+	for _, reservation := range reservations {
+		resp.Reservations = append(
+			resp.Reservations,
+			marshallKeyFamilyReservation(reservation),
+		)
+	}
+
+	return resp, nil
+}
+
+// marshallKeyFamilyReservation converts a key family reservation into its RPC
+// counterpart.
+func marshallKeyFamilyReservation(
+	reservation *keychain.KeyFamilyReservation) *KeyFamilyReservation {
+
+	return &KeyFamilyReservation{
+		Owner:          reservation.Owner,
+		FirstKeyFamily: int32(reservation.First),
+		LastKeyFamily:  int32(reservation.Last),
+	}
+}
+
 // NextAddr returns the next unused address within the wallet.
 func (w *WalletKit) NextAddr(ctx context.Context,
 	req *AddrRequest) (*AddrResponse, error) {
 
-	addr, err := w.cfg.Wallet.NewAddress(lnwallet.WitnessPubKey, false)
+	addr, err := w.cfg.Wallet.NewAddress(
+		lnwallet.WitnessPubKey, false, req.Account,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -443,6 +673,85 @@ func (w *WalletKit) NextAddr(ctx context.Context,
 	}, nil
 }
 
+// marshallAccount converts the properties of a wallet account into their RPC
+// representation.
+func marshallAccount(props *waddrmgr.AccountProperties) *Account {
+	return &Account{
+		Name:             props.AccountName,
+		Number:           props.AccountNumber,
+		ExternalKeyCount: props.ExternalKeyCount,
+		InternalKeyCount: props.InternalKeyCount,
+	}
+}
+
+// ListAccounts returns all accounts of the wallet.
+func (w *WalletKit) ListAccounts(ctx context.Context,
+	req *ListAccountsRequest) (*ListAccountsResponse, error) {
+
+	accounts, err := w.cfg.Wallet.ListAccounts()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &ListAccountsResponse{
+		Accounts: make([]*Account, 0, len(accounts)),
+	}
+	for _, props := range accounts {
+		resp.Accounts = append(resp.Accounts, marshallAccount(props))
+	}
+
+	return resp, nil
+}
+
+// CreateAccount derives a new account with the given name from the wallet's
+// seed.
+func (w *WalletKit) CreateAccount(ctx context.Context,
+	req *CreateAccountRequest) (*Account, error) {
+
+	if req.Name == "" {
+		return nil, fmt.Errorf("account name must be set")
+	}
+
+	props, err := w.cfg.Wallet.CreateAccount(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshallAccount(props), nil
+}
+
+// Rescan scans the chain for transactions relevant to the wallet, starting at
+// the given height, and streams the progress of the scan. The scan is canceled
+// once the client closes the stream.
+func (w *WalletKit) Rescan(req *RescanRequest,
+	updateStream WalletKit_RescanServer) error {
+
+	var sendErr error
+	progress := func(p *lnwallet.RescanProgress) {
+		if sendErr != nil {
+			return
+		}
+
+		sendErr = updateStream.Send(&RescanUpdate{
+			StartHeight:         p.StartHeight,
+			Height:              p.Height,
+			TipHeight:           p.TipHeight,
+			ProgressPercent:     p.Percent(),
+			FirstRelevantHeight: p.FirstRelevantHeight,
+			Done:                p.Done,
+		})
+	}
+
+	err := w.cfg.Wallet.Rescan(
+		req.StartHeight, progress, updateStream.Context().Done(),
+	)
+	if err != nil {
+		return err
+	}
+
+	return sendErr
+}
+
 // Attempts to publish the passed transaction to the network. Once this returns
 // without an error, the wallet will continually attempt to re-broadcast the
 // transaction on start up, until it enters the chain.
@@ -516,7 +825,8 @@ func (w *WalletKit) SendOutputs(ctx context.Context,
 	// Now that we have the outputs mapped, we can request that the wallet
 	// attempt to create this transaction.
 	tx, err := w.cfg.Wallet.SendOutputs(
-		outputsToCreate, chainfee.SatPerKWeight(req.SatPerKw), minConfs, label,
+		outputsToCreate, chainfee.SatPerKWeight(req.SatPerKw), minConfs,
+		label, req.Account,
 	)
 	if err != nil {
 		return nil, err
@@ -639,41 +949,6 @@ func (w *WalletKit) PendingSweeps(ctx context.Context,
 	}, nil
 }
 
-// unmarshallOutPoint converts an outpoint from its lnrpc type to its canonical
-// type.
-func unmarshallOutPoint(op *lnrpc.OutPoint) (*wire.OutPoint, error) {
-	if op == nil {
-		return nil, fmt.Errorf("empty outpoint provided")
-	}
-
-	var hash chainhash.Hash
-	switch {
-	case len(op.TxidBytes) == 0 && len(op.TxidStr) == 0:
-		fallthrough
-
-	case len(op.TxidBytes) != 0 && len(op.TxidStr) != 0:
-		return nil, fmt.Errorf("either TxidBytes or TxidStr must be " +
-			"specified, but not both")
-
-	// The hash was provided as raw bytes.
-	case len(op.TxidBytes) != 0:
-		copy(hash[:], op.TxidBytes)
-
-	// The hash was provided as a hex-encoded string.
-	case len(op.TxidStr) != 0:
-		h, err := chainhash.NewHashFromStr(op.TxidStr)
-		if err != nil {
-			return nil, err
-		}
-		hash = *h
-	}
-
-	return &wire.OutPoint{
-		Hash:  hash,
-		Index: op.OutputIndex,
-	}, nil
-}
-
 // BumpFee allows bumping the fee rate of an arbitrary input. A fee preference
 // can be expressed either as a specific fee rate or a delta of blocks in which
 // the output should be swept on-chain within. If a fee preference is not
@@ -683,7 +958,7 @@ func (w *WalletKit) BumpFee(ctx context.Context,
 	in *BumpFeeRequest) (*BumpFeeResponse, error) {
 
 	// Parse the outpoint from the request.
-	op, err := unmarshallOutPoint(in.Outpoint)
+	op, err := lnrpc.UnmarshallOutPoint(in.Outpoint)
 	if err != nil {
 		return nil, err
 	}
@@ -931,7 +1206,7 @@ func (w *WalletKit) FundPsbt(_ context.Context,
 
 		txIn := make([]*wire.OutPoint, len(tpl.Inputs))
 		for idx, in := range tpl.Inputs {
-			op, err := unmarshallOutPoint(in)
+			op, err := lnrpc.UnmarshallOutPoint(in)
 			if err != nil {
 				return nil, fmt.Errorf("error parsing "+
 					"outpoint: %v", err)
@@ -979,6 +1254,42 @@ func (w *WalletKit) FundPsbt(_ context.Context,
 			"specify either target_conf or set_per_vbyte")
 	}
 
+	// If the change should go to a custom address, we'll make sure it is
+	// valid before funding anything.
+	var changeScript []byte
+	if req.ChangeAddress != "" {
+		addr, err := btcutil.DecodeAddress(
+			req.ChangeAddress, w.cfg.ChainParams,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing change address "+
+				"%s for network %s: %v", req.ChangeAddress,
+				w.cfg.ChainParams.Name, err)
+		}
+		if !addr.IsForNet(w.cfg.ChainParams) {
+			return nil, fmt.Errorf("change address %s is not for "+
+				"network %s", req.ChangeAddress,
+				w.cfg.ChainParams.Name)
+		}
+		changeScript, err = txscript.PayToAddrScript(addr)
+		if err != nil {
+			return nil, fmt.Errorf("error getting pk script for "+
+				"change address %s: %v", req.ChangeAddress,
+				err)
+		}
+	}
+
+	// The inputs are locked with the internal lock ID unless the caller
+	// wants to manage the leases with its own ID.
+	lockID := LndInternalLockID
+	if len(req.LeaseId) > 0 {
+		if len(req.LeaseId) != len(lockID) {
+			return nil, fmt.Errorf("lease ID must be %v bytes",
+				len(lockID))
+		}
+		copy(lockID[:], req.LeaseId)
+	}
+
 	// The RPC parsing part is now over. Several of the following operations
 	// require us to hold the global coin selection lock so we do the rest
 	// of the tasks while holding the lock. The result is a list of locked
@@ -990,7 +1301,7 @@ func (w *WalletKit) FundPsbt(_ context.Context,
 		if len(packet.UnsignedTx.TxIn) > 0 {
 			// Get a list of all unspent witness outputs.
 			utxos, err := w.cfg.Wallet.ListUnspentWitness(
-				defaultMinConf, defaultMaxConf,
+				defaultMinConf, defaultMaxConf, req.Account,
 			)
 			if err != nil {
 				return err
@@ -1008,11 +1319,22 @@ func (w *WalletKit) FundPsbt(_ context.Context,
 		// We can now ask the wallet to fund the TX. This will not yet
 		// lock any coins but might still change the wallet DB by
 		// generating a new change address.
-		changeIndex, err = w.cfg.Wallet.FundPsbt(packet, feeSatPerKW)
+		changeIndex, err = w.cfg.Wallet.FundPsbt(
+			packet, feeSatPerKW, req.Account,
+		)
 		if err != nil {
 			return fmt.Errorf("wallet couldn't fund PSBT: %v", err)
 		}
 
+		if changeScript != nil && changeIndex >= 0 {
+			changeIndex, err = replaceChangeOutput(
+				packet, changeIndex, changeScript, feeSatPerKW,
+			)
+			if err != nil {
+				return err
+			}
+		}
+
 		// Make sure we can properly serialize the packet. If this goes
 		// wrong then something isn't right with the inputs and we
 		// probably shouldn't try to lock any of them.
@@ -1030,7 +1352,7 @@ func (w *WalletKit) FundPsbt(_ context.Context,
 		// happens in this function. If we ever need to do more after
 		// this function, we need to extract the rollback needs to be
 		// extracted into a defer.
-		locks, err = lockInputs(w.cfg.Wallet, packet)
+		locks, err = lockInputs(w.cfg.Wallet, packet, lockID)
 		if err != nil {
 			return fmt.Errorf("could not lock inputs: %v", err)
 		}
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/textproto"
 	"strings"
+	"time"
 
 	"github.com/btcsuite/btclog"
 	"github.com/gorilla/websocket"
@@ -31,6 +32,19 @@ const (
 	// additional header field and its value. We use the plus symbol because
 	// the default delimiters aren't allowed in the protocol names.
 	WebSocketProtocolDelimiter = "+"
+
+	// PingContent is the content of the ping message we send out. This is
+	// an arbitrary non-empty message that has no deeper meaning but should
+	// be sent back by the client in the pong message.
+	PingContent = "are you there?"
+
+	// DefaultPingInterval is the default number of seconds to wait between
+	// sending ping requests.
+	DefaultPingInterval = time.Second * 30
+
+	// DefaultPongWait is the default number of seconds to wait for a
+	// response to a ping.
+	DefaultPongWait = time.Second * 5
 )
 
 var (
@@ -53,11 +67,18 @@ var (
 
 // NewWebSocketProxy attempts to expose the underlying handler as a response-
 // streaming WebSocket stream with newline-delimited JSON as the content
-// encoding.
-func NewWebSocketProxy(h http.Handler, logger btclog.Logger) http.Handler {
+// encoding. If pingInterval and pongWait are both non-zero, the proxy regularly
+// sends ping messages to the client and closes the connection if no pong
+// response is received in time. This keeps long-lived subscriptions alive
+// through intermediaries that drop idle connections.
+func NewWebSocketProxy(h http.Handler, logger btclog.Logger,
+	pingInterval, pongWait time.Duration) http.Handler {
+
 	p := &WebsocketProxy{
-		backend: h,
-		logger:  logger,
+		backend:      h,
+		logger:       logger,
+		pingInterval: pingInterval,
+		pongWait:     pongWait,
 		upgrader: &websocket.Upgrader{
 			ReadBufferSize:  1024,
 			WriteBufferSize: 1024,
@@ -74,6 +95,14 @@ type WebsocketProxy struct {
 	backend  http.Handler
 	logger   btclog.Logger
 	upgrader *websocket.Upgrader
+
+	pingInterval time.Duration
+	pongWait     time.Duration
+}
+
+// pingPongEnabled returns true if a ping interval and a pong wait time are set.
+func (p *WebsocketProxy) pingPongEnabled() bool {
+	return p.pingInterval > 0 && p.pongWait > 0
 }
 
 // ServeHTTP handles the incoming HTTP request. If the request is an
@@ -94,7 +123,21 @@ func (p *WebsocketProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 func (p *WebsocketProxy) upgradeToWebSocketProxy(w http.ResponseWriter,
 	r *http.Request) {
 
-	conn, err := p.upgrader.Upgrade(w, r, nil)
+	// Allow certain headers to be forwarded, either from source headers
+	// or the special Sec-Websocket-Protocol header field. Browsers abort
+	// the connection if the server doesn't select one of the protocols
+	// they offered, so we echo back the one we used.
+	forwardedHeaders := make(http.Header)
+	protocol := forwardHeaders(r.Header, forwardedHeaders)
+
+	var responseHeader http.Header
+	if protocol != "" {
+		responseHeader = http.Header{
+			HeaderWebSocketProtocol: []string{protocol},
+		}
+	}
+
+	conn, err := p.upgrader.Upgrade(w, r, responseHeader)
 	if err != nil {
 		p.logger.Errorf("error upgrading websocket:", err)
 		return
@@ -119,9 +162,9 @@ func (p *WebsocketProxy) upgradeToWebSocketProxy(w http.ResponseWriter,
 		return
 	}
 
-	// Allow certain headers to be forwarded, either from source headers
-	// or the special Sec-Websocket-Protocol header field.
-	forwardHeaders(r.Header, request.Header)
+	for header := range forwardedHeaders {
+		request.Header.Set(header, forwardedHeaders.Get(header))
+	}
 
 	// Also allow the target request method to be overwritten, as all
 	// WebSocket establishment calls MUST be GET requests.
@@ -140,6 +183,53 @@ func (p *WebsocketProxy) upgradeToWebSocketProxy(w http.ResponseWriter,
 		p.backend.ServeHTTP(responseForwarder, request)
 	}()
 
+	// Ping write loop: Send a ping message regularly if ping/pong is
+	// enabled. Every pong we receive extends the read deadline of the
+	// connection, so an unresponsive client causes the read loop to fail
+	// and the connection to be closed.
+	if p.pingPongEnabled() {
+		readDeadline := func() time.Time {
+			return time.Now().Add(p.pingInterval + p.pongWait)
+		}
+
+		if err := conn.SetReadDeadline(readDeadline()); err != nil {
+			p.logger.Errorf("WS: could not set read deadline: %v",
+				err)
+			return
+		}
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(readDeadline())
+		})
+
+		go func() {
+			ticker := time.NewTicker(p.pingInterval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+
+				case <-ticker.C:
+				}
+
+				// WriteControl is safe to call concurrently
+				// with the write loop below.
+				err := conn.WriteControl(
+					websocket.PingMessage,
+					[]byte(PingContent),
+					time.Now().Add(p.pongWait),
+				)
+				if err != nil {
+					p.logger.Debugf("WS: error sending "+
+						"ping: %v", err)
+					cancelFn()
+					return
+				}
+			}
+		}()
+	}
+
 	// Read loop: Take messages from websocket and write to http request.
 	go func() {
 		defer cancelFn()
@@ -202,8 +292,10 @@ func (p *WebsocketProxy) upgradeToWebSocketProxy(w http.ResponseWriter,
 // forwardHeaders forwards certain allowed header fields from the source request
 // to the target request. Because browsers are limited in what header fields
 // they can send on the WebSocket setup call, we also allow additional fields to
-// be transported in the special Sec-Websocket-Protocol field.
-func forwardHeaders(source, target http.Header) {
+// be transported in the special Sec-Websocket-Protocol field. The protocol that
+// was used to transport such a field is returned, or an empty string if there
+// was none.
+func forwardHeaders(source, target http.Header) string {
 	// Forward allowed header fields directly.
 	for header := range source {
 		headerName := textproto.CanonicalMIMEHeaderKey(header)
@@ -217,18 +309,34 @@ func forwardHeaders(source, target http.Header) {
 	// requests. We need to allow them to submit the macaroon as a WS
 	// protocol, which is the only allowed header. Set any "protocols" we
 	// declare valid as header fields on the forwarded request.
-	protocol := source.Get(HeaderWebSocketProtocol)
-	for key := range defaultProtocolsToAllow {
-		if strings.HasPrefix(protocol, key) {
-			// The format is "<protocol name>+<value>". We know the
-			// protocol string starts with the name so we only need
-			// to set the value.
-			values := strings.Split(
-				protocol, WebSocketProtocolDelimiter,
+	// Clients may offer multiple protocols, either as a comma separated
+	// list or in multiple header fields.
+	var selectedProtocol string
+	for _, field := range source[HeaderWebSocketProtocol] {
+		for _, protocol := range strings.Split(field, ",") {
+			protocol = strings.TrimSpace(protocol)
+
+			// The format is "<protocol name>+<value>".
+			parts := strings.SplitN(
+				protocol, WebSocketProtocolDelimiter, 2,
 			)
-			target.Set(key, values[1])
+			if len(parts) != 2 {
+				continue
+			}
+
+			key := textproto.CanonicalMIMEHeaderKey(parts[0])
+			if !defaultProtocolsToAllow[key] {
+				continue
+			}
+
+			target.Set(key, parts[1])
+			if selectedProtocol == "" {
+				selectedProtocol = protocol
+			}
 		}
 	}
+
+	return selectedProtocol
 }
 
 // newRequestForwardingReader creates a new request forwarding pipe.
@@ -4,6 +4,7 @@ import (
 	"github.com/btcsuite/btclog"
 	"github.com/cryptomeow/lnd/lncfg"
 	"github.com/cryptomeow/lnd/watchtower/wtclient"
+	"github.com/cryptomeow/lnd/watchtower/wtdirectory"
 )
 
 // Config is the primary configuration struct for the watchtower RPC server. It
@@ -15,15 +16,25 @@ type Config struct {
 	// Active indicates if the watchtower client is enabled.
 	Active bool
 
-	// Client is the backing watchtower client that we'll interact with
-	// through the watchtower RPC subserver.
+	// Client is the backing watchtower client for channels without anchor
+	// outputs that we'll interact with through the watchtower RPC
+	// subserver.
 	Client wtclient.Client
 
+	// AnchorClient is the backing watchtower client for channels with
+	// anchor outputs that we'll interact with through the watchtower RPC
+	// subserver.
+	AnchorClient wtclient.Client
+
 	// Resolver is a custom resolver that will be used to resolve watchtower
 	// addresses to ensure we don't leak any information when running over
 	// non-clear networks, e.g. Tor, etc.
 	Resolver lncfg.TCPResolver
 
+	// Graph gives access to the channel graph, in which public watchtowers
+	// are discovered.
+	Graph wtdirectory.NodeSource
+
 	// Log is the logger instance we should log output to.
 	Log btclog.Logger
 }
@@ -14,6 +14,9 @@ import (
 	"github.com/cryptomeow/lnd/lnwire"
 	"github.com/cryptomeow/lnd/watchtower"
 	"github.com/cryptomeow/lnd/watchtower/wtclient"
+	"github.com/cryptomeow/lnd/watchtower/wtdb"
+	"github.com/cryptomeow/lnd/watchtower/wtdirectory"
+	"github.com/cryptomeow/lnd/watchtower/wtpolicy"
 	"google.golang.org/grpc"
 	"gopkg.in/macaroon-bakery.v2/bakery"
 )
@@ -55,6 +58,10 @@ var (
 			Entity: "offchain",
 			Action: "read",
 		}},
+		"/wtclientrpc.WatchtowerClient/ListTowerCandidates": {{
+			Entity: "offchain",
+			Action: "read",
+		}},
 	}
 
 	// ErrWtclientNotActive signals that RPC calls cannot be processed
@@ -149,6 +156,41 @@ func (c *WatchtowerClient) isActive() error {
 	return ErrWtclientNotActive
 }
 
+// clients returns the backing watchtower clients of channels without and with
+// anchor outputs respectively.
+func (c *WatchtowerClient) clients() []wtclient.Client {
+	clients := []wtclient.Client{c.cfg.Client}
+	if c.cfg.AnchorClient != nil {
+		clients = append(clients, c.cfg.AnchorClient)
+	}
+
+	return clients
+}
+
+// anchorSessionCandidates returns the IDs of the watchtowers that the client of
+// channels with anchor outputs considers for new sessions. The clients share
+// their database, so all other information about the towers is the same.
+func (c *WatchtowerClient) anchorSessionCandidates() (
+	map[wtdb.TowerID]struct{}, error) {
+
+	candidates := make(map[wtdb.TowerID]struct{})
+	if c.cfg.AnchorClient == nil {
+		return candidates, nil
+	}
+
+	towers, err := c.cfg.AnchorClient.RegisteredTowers()
+	if err != nil {
+		return nil, err
+	}
+	for _, tower := range towers {
+		if tower.ActiveSessionCandidate {
+			candidates[tower.ID] = struct{}{}
+		}
+	}
+
+	return candidates, nil
+}
+
 // AddTower adds a new watchtower reachable at the given address and considers
 // it for new sessions. If the watchtower already exists, then any new addresses
 // included will be considered when dialing it for session negotiations and
@@ -176,8 +218,10 @@ func (c *WatchtowerClient) AddTower(ctx context.Context,
 		IdentityKey: pubKey,
 		Address:     addr,
 	}
-	if err := c.cfg.Client.AddTower(towerAddr); err != nil {
-		return nil, err
+	for _, client := range c.clients() {
+		if err := client.AddTower(towerAddr); err != nil {
+			return nil, err
+		}
 	}
 
 	return &AddTowerResponse{}, nil
@@ -211,8 +255,10 @@ func (c *WatchtowerClient) RemoveTower(ctx context.Context,
 		}
 	}
 
-	if err := c.cfg.Client.RemoveTower(pubKey, addr); err != nil {
-		return nil, err
+	for _, client := range c.clients() {
+		if err := client.RemoveTower(pubKey, addr); err != nil {
+			return nil, err
+		}
 	}
 
 	return &RemoveTowerResponse{}, nil
@@ -231,6 +277,16 @@ func (c *WatchtowerClient) ListTowers(ctx context.Context,
 		return nil, err
 	}
 
+	anchorCandidates, err := c.anchorSessionCandidates()
+	if err != nil {
+		return nil, err
+	}
+	for _, tower := range towers {
+		if _, ok := anchorCandidates[tower.ID]; ok {
+			tower.ActiveSessionCandidate = true
+		}
+	}
+
 	rpcTowers := make([]*Tower, 0, len(towers))
 	for _, tower := range towers {
 		rpcTower := marshallTower(tower, req.IncludeSessions)
@@ -258,6 +314,14 @@ func (c *WatchtowerClient) GetTowerInfo(ctx context.Context,
 		return nil, err
 	}
 
+	anchorCandidates, err := c.anchorSessionCandidates()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := anchorCandidates[tower.ID]; ok {
+		tower.ActiveSessionCandidate = true
+	}
+
 	return marshallTower(tower, req.IncludeSessions), nil
 }
 
@@ -269,17 +333,23 @@ func (c *WatchtowerClient) Stats(ctx context.Context,
 		return nil, err
 	}
 
-	stats := c.cfg.Client.Stats()
-	return &StatsResponse{
-		NumBackups:           uint32(stats.NumTasksAccepted),
-		NumFailedBackups:     uint32(stats.NumTasksIneligible),
-		NumPendingBackups:    uint32(stats.NumTasksReceived),
-		NumSessionsAcquired:  uint32(stats.NumSessionsAcquired),
-		NumSessionsExhausted: uint32(stats.NumSessionsExhausted),
-	}, nil
+	// The statistics of both clients are summed up.
+	var resp StatsResponse
+	for _, client := range c.clients() {
+		stats := client.Stats()
+		resp.NumBackups += uint32(stats.NumTasksAccepted)
+		resp.NumFailedBackups += uint32(stats.NumTasksIneligible)
+		resp.NumPendingBackups += uint32(stats.NumTasksReceived)
+		resp.NumSessionsAcquired += uint32(stats.NumSessionsAcquired)
+		resp.NumSessionsExhausted += uint32(stats.NumSessionsExhausted)
+		resp.NumQueuedBackups += uint32(stats.NumTasksQueued)
+	}
+
+	return &resp, nil
 }
 
-// Policy returns the active watchtower client policy configuration.
+// Policy returns the active watchtower client policy configuration of the
+// requested channel type.
 func (c *WatchtowerClient) Policy(ctx context.Context,
 	req *PolicyRequest) (*PolicyResponse, error) {
 
@@ -287,13 +357,81 @@ func (c *WatchtowerClient) Policy(ctx context.Context,
 		return nil, err
 	}
 
-	policy := c.cfg.Client.Policy()
+	var policy wtpolicy.Policy
+	switch req.PolicyType {
+	case PolicyType_LEGACY:
+		policy = c.cfg.Client.Policy()
+
+	case PolicyType_ANCHOR:
+		if c.cfg.AnchorClient == nil {
+			return nil, errors.New("anchor watchtower client not " +
+				"active")
+		}
+		policy = c.cfg.AnchorClient.Policy()
+
+	default:
+		return nil, fmt.Errorf("unknown policy type: %v",
+			req.PolicyType)
+	}
+
 	return &PolicyResponse{
 		MaxUpdates:      uint32(policy.MaxUpdates),
 		SweepSatPerByte: uint32(policy.SweepFeeRate.FeePerKVByte() / 1000),
 	}, nil
 }
 
+// ListTowerCandidates returns the public watchtowers advertised in the channel
+// graph that accept sessions of the client's policy and aren't registered with
+// the client yet.
+func (c *WatchtowerClient) ListTowerCandidates(ctx context.Context,
+	req *ListTowerCandidatesRequest) (*ListTowerCandidatesResponse, error) {
+
+	if err := c.isActive(); err != nil {
+		return nil, err
+	}
+
+	candidates, err := wtdirectory.FindTowers(
+		c.cfg.Graph, c.cfg.Client.Policy().BlobType,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	towers, err := c.cfg.Client.RegisteredTowers()
+	if err != nil {
+		return nil, err
+	}
+	registered := make(map[[33]byte]struct{}, len(towers))
+	for _, tower := range towers {
+		var towerKey [33]byte
+		copy(towerKey[:], tower.IdentityKey.SerializeCompressed())
+		registered[towerKey] = struct{}{}
+	}
+
+	rpcCandidates := make([]*TowerCandidate, 0, len(candidates))
+	for _, candidate := range candidates {
+		var towerKey [33]byte
+		copy(towerKey[:], candidate.PubKey.SerializeCompressed())
+		if _, ok := registered[towerKey]; ok {
+			continue
+		}
+
+		rpcAddrs := make([]string, 0, len(candidate.Addresses))
+		for _, addr := range candidate.Addresses {
+			rpcAddrs = append(rpcAddrs, addr.String())
+		}
+
+		rpcCandidates = append(rpcCandidates, &TowerCandidate{
+			Pubkey:     towerKey[:],
+			Addresses:  rpcAddrs,
+			NodePubkey: candidate.NodeKey[:],
+			NodeAlias:  candidate.Alias,
+		})
+	}
+
+	return &ListTowerCandidatesResponse{Candidates: rpcCandidates}, nil
+}
+
 // marshallTower converts a client registered watchtower into its corresponding
 // RPC type.
 func marshallTower(tower *wtclient.RegisteredTower, includeSessions bool) *Tower {
@@ -24,6 +24,35 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+type PolicyType int32
+
+const (
+	// Selects the policy of the client backing up channels without anchor
+	// outputs.
+	PolicyType_LEGACY PolicyType = 0
+	// Selects the policy of the client backing up channels with anchor
+	// outputs.
+	PolicyType_ANCHOR PolicyType = 1
+)
+
+var PolicyType_name = map[int32]string{
+	0: "LEGACY",
+	1: "ANCHOR",
+}
+
+var PolicyType_value = map[string]int32{
+	"LEGACY": 0,
+	"ANCHOR": 1,
+}
+
+func (x PolicyType) String() string {
+	return proto.EnumName(PolicyType_name, int32(x))
+}
+
+func (PolicyType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_b5f4e7d95a641af2, []int{0}
+}
+
 type AddTowerRequest struct {
 	// The identifying public key of the watchtower to add.
 	Pubkey []byte `protobuf:"bytes,1,opt,name=pubkey,proto3" json:"pubkey,omitempty"`
@@ -512,7 +541,12 @@ type StatsResponse struct {
 	// The total number of new sessions made to watchtowers.
 	NumSessionsAcquired uint32 `protobuf:"varint,4,opt,name=num_sessions_acquired,json=numSessionsAcquired,proto3" json:"num_sessions_acquired,omitempty"`
 	// The total number of watchtower sessions that have been exhausted.
-	NumSessionsExhausted uint32   `protobuf:"varint,5,opt,name=num_sessions_exhausted,json=numSessionsExhausted,proto3" json:"num_sessions_exhausted,omitempty"`
+	NumSessionsExhausted uint32 `protobuf:"varint,5,opt,name=num_sessions_exhausted,json=numSessionsExhausted,proto3" json:"num_sessions_exhausted,omitempty"`
+	//
+	//The number of backups that are waiting to be processed by the client. The
+	//backups of revoked states with the most balance at risk are processed
+	//first, weighted by the time their channels haven't been backed up for.
+	NumQueuedBackups     uint32   `protobuf:"varint,6,opt,name=num_queued_backups,json=numQueuedBackups,proto3" json:"num_queued_backups,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -578,10 +612,20 @@ func (m *StatsResponse) GetNumSessionsExhausted() uint32 {
 	return 0
 }
 
+func (m *StatsResponse) GetNumQueuedBackups() uint32 {
+	if m != nil {
+		return m.NumQueuedBackups
+	}
+	return 0
+}
+
 type PolicyRequest struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	//
+	//The type of channels whose client policy should be returned.
+	PolicyType           PolicyType `protobuf:"varint,1,opt,name=policy_type,json=policyType,proto3,enum=wtclientrpc.PolicyType" json:"policy_type,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
 }
 
 func (m *PolicyRequest) Reset()         { *m = PolicyRequest{} }
@@ -609,6 +653,13 @@ func (m *PolicyRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_PolicyRequest proto.InternalMessageInfo
 
+func (m *PolicyRequest) GetPolicyType() PolicyType {
+	if m != nil {
+		return m.PolicyType
+	}
+	return PolicyType_LEGACY
+}
+
 type PolicyResponse struct {
 	//
 	//The maximum number of updates each session we negotiate with watchtowers
@@ -662,7 +713,146 @@ func (m *PolicyResponse) GetSweepSatPerByte() uint32 {
 	return 0
 }
 
+type ListTowerCandidatesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListTowerCandidatesRequest) Reset()         { *m = ListTowerCandidatesRequest{} }
+func (m *ListTowerCandidatesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListTowerCandidatesRequest) ProtoMessage()    {}
+func (*ListTowerCandidatesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5f4e7d95a641af2, []int{13}
+}
+
+func (m *ListTowerCandidatesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListTowerCandidatesRequest.Unmarshal(m, b)
+}
+func (m *ListTowerCandidatesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListTowerCandidatesRequest.Marshal(b, m, deterministic)
+}
+func (m *ListTowerCandidatesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListTowerCandidatesRequest.Merge(m, src)
+}
+func (m *ListTowerCandidatesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListTowerCandidatesRequest.Size(m)
+}
+func (m *ListTowerCandidatesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListTowerCandidatesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListTowerCandidatesRequest proto.InternalMessageInfo
+
+type TowerCandidate struct {
+	// The identifying public key of the watchtower.
+	Pubkey []byte `protobuf:"bytes,1,opt,name=pubkey,proto3" json:"pubkey,omitempty"`
+	// The list of addresses the watchtower is reachable over.
+	Addresses []string `protobuf:"bytes,2,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	// The identity public key of the node that advertises the watchtower.
+	NodePubkey []byte `protobuf:"bytes,3,opt,name=node_pubkey,json=nodePubkey,proto3" json:"node_pubkey,omitempty"`
+	// The alias of the node that advertises the watchtower.
+	NodeAlias            string   `protobuf:"bytes,4,opt,name=node_alias,json=nodeAlias,proto3" json:"node_alias,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TowerCandidate) Reset()         { *m = TowerCandidate{} }
+func (m *TowerCandidate) String() string { return proto.CompactTextString(m) }
+func (*TowerCandidate) ProtoMessage()    {}
+func (*TowerCandidate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5f4e7d95a641af2, []int{14}
+}
+
+func (m *TowerCandidate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TowerCandidate.Unmarshal(m, b)
+}
+func (m *TowerCandidate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TowerCandidate.Marshal(b, m, deterministic)
+}
+func (m *TowerCandidate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TowerCandidate.Merge(m, src)
+}
+func (m *TowerCandidate) XXX_Size() int {
+	return xxx_messageInfo_TowerCandidate.Size(m)
+}
+func (m *TowerCandidate) XXX_DiscardUnknown() {
+	xxx_messageInfo_TowerCandidate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TowerCandidate proto.InternalMessageInfo
+
+func (m *TowerCandidate) GetPubkey() []byte {
+	if m != nil {
+		return m.Pubkey
+	}
+	return nil
+}
+
+func (m *TowerCandidate) GetAddresses() []string {
+	if m != nil {
+		return m.Addresses
+	}
+	return nil
+}
+
+func (m *TowerCandidate) GetNodePubkey() []byte {
+	if m != nil {
+		return m.NodePubkey
+	}
+	return nil
+}
+
+func (m *TowerCandidate) GetNodeAlias() string {
+	if m != nil {
+		return m.NodeAlias
+	}
+	return ""
+}
+
+type ListTowerCandidatesResponse struct {
+	// The list of public watchtowers that can be added to the client.
+	Candidates           []*TowerCandidate `protobuf:"bytes,1,rep,name=candidates,proto3" json:"candidates,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ListTowerCandidatesResponse) Reset()         { *m = ListTowerCandidatesResponse{} }
+func (m *ListTowerCandidatesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListTowerCandidatesResponse) ProtoMessage()    {}
+func (*ListTowerCandidatesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5f4e7d95a641af2, []int{15}
+}
+
+func (m *ListTowerCandidatesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListTowerCandidatesResponse.Unmarshal(m, b)
+}
+func (m *ListTowerCandidatesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListTowerCandidatesResponse.Marshal(b, m, deterministic)
+}
+func (m *ListTowerCandidatesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListTowerCandidatesResponse.Merge(m, src)
+}
+func (m *ListTowerCandidatesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListTowerCandidatesResponse.Size(m)
+}
+func (m *ListTowerCandidatesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListTowerCandidatesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListTowerCandidatesResponse proto.InternalMessageInfo
+
+func (m *ListTowerCandidatesResponse) GetCandidates() []*TowerCandidate {
+	if m != nil {
+		return m.Candidates
+	}
+	return nil
+}
+
 func init() {
+	proto.RegisterEnum("wtclientrpc.PolicyType", PolicyType_name, PolicyType_value)
 	proto.RegisterType((*AddTowerRequest)(nil), "wtclientrpc.AddTowerRequest")
 	proto.RegisterType((*AddTowerResponse)(nil), "wtclientrpc.AddTowerResponse")
 	proto.RegisterType((*RemoveTowerRequest)(nil), "wtclientrpc.RemoveTowerRequest")
@@ -676,55 +866,68 @@ func init() {
 	proto.RegisterType((*StatsResponse)(nil), "wtclientrpc.StatsResponse")
 	proto.RegisterType((*PolicyRequest)(nil), "wtclientrpc.PolicyRequest")
 	proto.RegisterType((*PolicyResponse)(nil), "wtclientrpc.PolicyResponse")
+	proto.RegisterType((*ListTowerCandidatesRequest)(nil), "wtclientrpc.ListTowerCandidatesRequest")
+	proto.RegisterType((*TowerCandidate)(nil), "wtclientrpc.TowerCandidate")
+	proto.RegisterType((*ListTowerCandidatesResponse)(nil), "wtclientrpc.ListTowerCandidatesResponse")
 }
 
 func init() { proto.RegisterFile("wtclientrpc/wtclient.proto", fileDescriptor_b5f4e7d95a641af2) }
 
 var fileDescriptor_b5f4e7d95a641af2 = []byte{
-	// 682 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x55, 0x4d, 0x6f, 0xd3, 0x40,
-	0x10, 0x95, 0x1b, 0x12, 0xd2, 0x49, 0xda, 0xa4, 0x1b, 0x5a, 0x19, 0x53, 0x48, 0xf0, 0x29, 0x7c,
-	0x28, 0x11, 0x2d, 0x48, 0x9c, 0x2a, 0xda, 0x42, 0x2b, 0x24, 0x90, 0x22, 0x17, 0x04, 0xe2, 0x80,
-	0xb5, 0xb1, 0xb7, 0x89, 0x55, 0x7b, 0xed, 0x7a, 0xd7, 0x4d, 0xf2, 0xa3, 0xf8, 0x19, 0xfc, 0x00,
-	0xfe, 0x0d, 0x47, 0xe4, 0xf5, 0xda, 0xb1, 0x1b, 0x47, 0x1c, 0xe0, 0x16, 0xcf, 0x7b, 0xfb, 0x3c,
-	0x7e, 0xf3, 0x32, 0x0b, 0xda, 0x8c, 0x5b, 0xae, 0x43, 0x28, 0x0f, 0x03, 0x6b, 0x98, 0xfe, 0x1e,
-	0x04, 0xa1, 0xcf, 0x7d, 0xd4, 0xc8, 0x61, 0xfa, 0x29, 0xb4, 0x8e, 0x6d, 0xfb, 0x93, 0x3f, 0x23,
-	0xa1, 0x41, 0xae, 0x23, 0xc2, 0x38, 0xda, 0x83, 0x5a, 0x10, 0x8d, 0xaf, 0xc8, 0x42, 0x55, 0x7a,
-	0x4a, 0xbf, 0x69, 0xc8, 0x27, 0xa4, 0xc2, 0x5d, 0x6c, 0xdb, 0x21, 0x61, 0x4c, 0xdd, 0xe8, 0x29,
-	0xfd, 0x4d, 0x23, 0x7d, 0xd4, 0x11, 0xb4, 0x97, 0x22, 0x2c, 0xf0, 0x29, 0x23, 0xfa, 0x19, 0x20,
-	0x83, 0x78, 0xfe, 0x0d, 0xf9, 0x47, 0xed, 0x5d, 0xe8, 0x14, 0x74, 0xa4, 0xfc, 0x57, 0xe8, 0x9c,
-	0x13, 0x2e, 0x6a, 0xef, 0xe9, 0xa5, 0xff, 0x37, 0xfd, 0x27, 0xd0, 0x76, 0xa8, 0xe5, 0x46, 0x36,
-	0x31, 0x19, 0x61, 0xcc, 0xf1, 0x69, 0xf2, 0xa2, 0xba, 0xd1, 0x92, 0xf5, 0x0b, 0x59, 0xd6, 0x7f,
-	0x28, 0xd0, 0x14, 0xba, 0xb2, 0x82, 0xba, 0xd0, 0xa0, 0x91, 0x67, 0x8e, 0xb1, 0x75, 0x15, 0x05,
-	0x4c, 0x08, 0x6f, 0x19, 0x40, 0x23, 0xef, 0x24, 0xa9, 0xa0, 0x01, 0x74, 0x62, 0x42, 0x40, 0xa8,
-	0xed, 0xd0, 0x49, 0x46, 0xdc, 0x10, 0xc4, 0x1d, 0x1a, 0x79, 0xa3, 0x04, 0x49, 0xf9, 0x5d, 0x68,
-	0x78, 0x78, 0x9e, 0xf1, 0x2a, 0x89, 0xa0, 0x87, 0xe7, 0x29, 0xe1, 0x19, 0x20, 0x36, 0x23, 0x24,
-	0x30, 0x19, 0xe6, 0x66, 0x40, 0x42, 0x73, 0xbc, 0xe0, 0x44, 0xbd, 0x23, 0x78, 0x2d, 0x81, 0x5c,
-	0x60, 0x3e, 0x22, 0xe1, 0xc9, 0x82, 0x13, 0xfd, 0x97, 0x02, 0x55, 0xd1, 0xef, 0xda, 0x8f, 0xdf,
-	0x87, 0x4d, 0xe9, 0x26, 0x89, 0xbb, 0xaa, 0xf4, 0x37, 0x8d, 0x65, 0x01, 0xbd, 0x06, 0x15, 0x5b,
-	0xdc, 0xb9, 0xc9, 0x9c, 0x31, 0x2d, 0x4c, 0x6d, 0xc7, 0xc6, 0x9c, 0x88, 0xd6, 0xea, 0xc6, 0x5e,
-	0x82, 0x4b, 0x3f, 0x4e, 0x53, 0x14, 0x3d, 0x86, 0x66, 0xfc, 0xdd, 0x99, 0xa1, 0x49, 0x83, 0xb1,
-	0x59, 0xa9, 0x99, 0xe8, 0x15, 0xd4, 0x33, 0xb8, 0xda, 0xab, 0xf4, 0x1b, 0x07, 0xf7, 0x07, 0xb9,
-	0xf8, 0x0d, 0xf2, 0x46, 0x1b, 0x19, 0x55, 0x3f, 0x82, 0x9d, 0x0f, 0x0e, 0x4b, 0xc6, 0xcb, 0xd2,
-	0xd9, 0x96, 0xcd, 0x50, 0x29, 0x9f, 0xe1, 0x1b, 0x40, 0xf9, 0xf3, 0x49, 0x66, 0xd0, 0x53, 0xa8,
-	0x71, 0x51, 0x51, 0x15, 0xd1, 0x0a, 0x5a, 0x6d, 0xc5, 0x90, 0x0c, 0x7d, 0x1b, 0x9a, 0x17, 0x1c,
-	0xf3, 0xf4, 0xe5, 0xfa, 0x6f, 0x05, 0xb6, 0x64, 0x41, 0xaa, 0xfd, 0xf7, 0x58, 0x3c, 0x07, 0x14,
-	0xf3, 0x2f, 0xb1, 0xe3, 0x12, 0xfb, 0x56, 0x3a, 0xda, 0x34, 0xf2, 0xce, 0x04, 0x90, 0xb2, 0x0f,
-	0x60, 0x37, 0x6f, 0xbe, 0x89, 0xad, 0xeb, 0xc8, 0x09, 0x89, 0x2d, 0xa7, 0xd0, 0xc9, 0x4d, 0xe1,
-	0x58, 0x42, 0xe8, 0x25, 0xec, 0x15, 0xce, 0x90, 0xf9, 0x14, 0x47, 0x8c, 0x13, 0x5b, 0xad, 0x8a,
-	0x43, 0xf7, 0x72, 0x87, 0xde, 0xa5, 0x98, 0xde, 0x82, 0xad, 0x91, 0xef, 0x3a, 0xd6, 0x22, 0xf5,
-	0xe2, 0x3b, 0x6c, 0xa7, 0x85, 0xa5, 0x17, 0x71, 0xa2, 0xa3, 0x20, 0xce, 0x45, 0xe6, 0x85, 0x87,
-	0xe7, 0x9f, 0x93, 0xca, 0x9a, 0x44, 0x6f, 0x94, 0x26, 0xfa, 0xe0, 0x67, 0x05, 0xda, 0x5f, 0x30,
-	0xb7, 0xa6, 0x62, 0x16, 0xa7, 0x62, 0x42, 0xe8, 0x1c, 0xea, 0xe9, 0x8e, 0x41, 0xfb, 0x85, 0xc1,
-	0xdd, 0xda, 0x5f, 0xda, 0xc3, 0x35, 0xa8, 0xec, 0x75, 0x04, 0x8d, 0xdc, 0x42, 0x41, 0xdd, 0x02,
-	0x7b, 0x75, 0x65, 0x69, 0xbd, 0xf5, 0x04, 0xa9, 0xf8, 0x11, 0x60, 0x99, 0x36, 0xf4, 0xa8, 0xc0,
-	0x5f, 0x89, 0xb1, 0xd6, 0x5d, 0x8b, 0x4b, 0xb9, 0xb7, 0xd0, 0xcc, 0xaf, 0x36, 0x54, 0x6c, 0xa0,
-	0x64, 0xeb, 0x69, 0x25, 0x41, 0x46, 0x47, 0x50, 0x15, 0x79, 0x45, 0xc5, 0x3f, 0x5c, 0x3e, 0xd4,
-	0x9a, 0x56, 0x06, 0xc9, 0x2e, 0x8e, 0xa1, 0x96, 0x0c, 0x19, 0x15, 0x59, 0x85, 0x28, 0x68, 0x0f,
-	0x4a, 0xb1, 0x44, 0xe2, 0xe4, 0xf0, 0xdb, 0x8b, 0x89, 0xc3, 0xa7, 0xd1, 0x78, 0x60, 0xf9, 0xde,
-	0xd0, 0x75, 0x26, 0x53, 0x4e, 0x1d, 0x3a, 0xa1, 0x84, 0xcf, 0xfc, 0xf0, 0x6a, 0xe8, 0x52, 0x7b,
-	0xe8, 0xd2, 0xfc, 0x05, 0x15, 0x06, 0xd6, 0xb8, 0x26, 0x2e, 0xa9, 0xc3, 0x3f, 0x01, 0x00, 0x00,
-	0xff, 0xff, 0x5d, 0xba, 0x03, 0x17, 0xc2, 0x06, 0x00, 0x00,
+	// 837 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0xed, 0x6e, 0xe3, 0x44,
+	0x14, 0xc5, 0x49, 0x13, 0x92, 0x9b, 0x34, 0x4d, 0x27, 0xb4, 0x04, 0xb7, 0x25, 0xc1, 0x42, 0x22,
+	0xb4, 0x90, 0x48, 0x01, 0xa4, 0x4a, 0x48, 0x15, 0x69, 0x68, 0x4b, 0xa5, 0x02, 0xc1, 0x2d, 0x02,
+	0xfa, 0x03, 0x6b, 0x62, 0x4f, 0x1b, 0xab, 0xf1, 0x47, 0xed, 0x71, 0x93, 0xbc, 0x00, 0x8f, 0xc2,
+	0xbf, 0xd5, 0x3e, 0xcb, 0xbe, 0xd1, 0xca, 0xe3, 0x19, 0xc7, 0x6e, 0x1c, 0x55, 0xda, 0xdd, 0x7f,
+	0xf6, 0x3d, 0xe7, 0x1e, 0x5f, 0x9f, 0x7b, 0xef, 0xd8, 0x20, 0xcf, 0xa8, 0x3e, 0x35, 0x89, 0x4d,
+	0x3d, 0x57, 0xef, 0x89, 0xeb, 0xae, 0xeb, 0x39, 0xd4, 0x41, 0x95, 0x04, 0xa6, 0x0c, 0x61, 0x6b,
+	0x60, 0x18, 0x37, 0xce, 0x8c, 0x78, 0x2a, 0x79, 0x0c, 0x88, 0x4f, 0xd1, 0x2e, 0x14, 0xdd, 0x60,
+	0xfc, 0x40, 0x16, 0x4d, 0xa9, 0x2d, 0x75, 0xaa, 0x2a, 0xbf, 0x43, 0x4d, 0xf8, 0x18, 0x1b, 0x86,
+	0x47, 0x7c, 0xbf, 0x99, 0x6b, 0x4b, 0x9d, 0xb2, 0x2a, 0x6e, 0x15, 0x04, 0xf5, 0xa5, 0x88, 0xef,
+	0x3a, 0xb6, 0x4f, 0x94, 0x73, 0x40, 0x2a, 0xb1, 0x9c, 0x27, 0xf2, 0x9e, 0xda, 0x3b, 0xd0, 0x48,
+	0xe9, 0x70, 0xf9, 0xbf, 0xa1, 0x71, 0x41, 0x28, 0x8b, 0x5d, 0xda, 0x77, 0xce, 0x4b, 0xfa, 0x5f,
+	0x43, 0xdd, 0xb4, 0xf5, 0x69, 0x60, 0x10, 0xcd, 0x27, 0xbe, 0x6f, 0x3a, 0x76, 0xf4, 0xa0, 0x92,
+	0xba, 0xc5, 0xe3, 0xd7, 0x3c, 0xac, 0xbc, 0x92, 0xa0, 0xca, 0x74, 0x79, 0x04, 0xb5, 0xa0, 0x62,
+	0x07, 0x96, 0x36, 0xc6, 0xfa, 0x43, 0xe0, 0xfa, 0x4c, 0x78, 0x53, 0x05, 0x3b, 0xb0, 0x4e, 0xa3,
+	0x08, 0xea, 0x42, 0x23, 0x24, 0xb8, 0xc4, 0x36, 0x4c, 0xfb, 0x3e, 0x26, 0xe6, 0x18, 0x71, 0xdb,
+	0x0e, 0xac, 0x51, 0x84, 0x08, 0x7e, 0x0b, 0x2a, 0x16, 0x9e, 0xc7, 0xbc, 0x7c, 0x24, 0x68, 0xe1,
+	0xb9, 0x20, 0x1c, 0x01, 0xf2, 0x67, 0x84, 0xb8, 0x9a, 0x8f, 0xa9, 0xe6, 0x12, 0x4f, 0x1b, 0x2f,
+	0x28, 0x69, 0x6e, 0x30, 0xde, 0x16, 0x43, 0xae, 0x31, 0x1d, 0x11, 0xef, 0x74, 0x41, 0x89, 0xf2,
+	0x46, 0x82, 0x02, 0xab, 0x77, 0xed, 0xcb, 0xef, 0x43, 0x99, 0xbb, 0x49, 0xc2, 0xaa, 0xf2, 0x9d,
+	0xb2, 0xba, 0x0c, 0xa0, 0x63, 0x68, 0x62, 0x9d, 0x9a, 0x4f, 0xb1, 0x33, 0x9a, 0x8e, 0x6d, 0xc3,
+	0x34, 0x30, 0x25, 0xac, 0xb4, 0x92, 0xba, 0x1b, 0xe1, 0xdc, 0x8f, 0xa1, 0x40, 0xd1, 0x17, 0x50,
+	0x0d, 0xdf, 0x3b, 0x36, 0x34, 0x2a, 0x30, 0x34, 0x4b, 0x98, 0x89, 0x7e, 0x80, 0x52, 0x0c, 0x17,
+	0xda, 0xf9, 0x4e, 0xa5, 0xff, 0x59, 0x37, 0x31, 0x7e, 0xdd, 0xa4, 0xd1, 0x6a, 0x4c, 0x55, 0x4e,
+	0x60, 0xfb, 0xca, 0xf4, 0xa3, 0xf6, 0xfa, 0xa2, 0xb7, 0x59, 0x3d, 0x94, 0xb2, 0x7b, 0xf8, 0x13,
+	0xa0, 0x64, 0x7e, 0x34, 0x33, 0xe8, 0x10, 0x8a, 0x94, 0x45, 0x9a, 0x12, 0x2b, 0x05, 0xad, 0x96,
+	0xa2, 0x72, 0x86, 0x52, 0x83, 0xea, 0x35, 0xc5, 0x54, 0x3c, 0x5c, 0xf9, 0x3f, 0x07, 0x9b, 0x3c,
+	0xc0, 0xd5, 0x3e, 0xf8, 0x58, 0x7c, 0x03, 0x28, 0xe4, 0xdf, 0x61, 0x73, 0x4a, 0x8c, 0x67, 0xd3,
+	0x51, 0xb7, 0x03, 0xeb, 0x9c, 0x01, 0x82, 0xdd, 0x87, 0x9d, 0xa4, 0xf9, 0x1a, 0xd6, 0x1f, 0x03,
+	0xd3, 0x23, 0x06, 0xef, 0x42, 0x23, 0xd1, 0x85, 0x01, 0x87, 0xd0, 0xf7, 0xb0, 0x9b, 0xca, 0x21,
+	0xf3, 0x09, 0x0e, 0x7c, 0x4a, 0x8c, 0x66, 0x81, 0x25, 0x7d, 0x92, 0x48, 0x3a, 0x13, 0x98, 0xa8,
+	0xeb, 0x31, 0x20, 0x41, 0xa2, 0xae, 0x62, 0x5c, 0xd7, 0x1f, 0x0c, 0xe0, 0x75, 0x29, 0x97, 0xb0,
+	0x39, 0x72, 0xa6, 0xa6, 0xbe, 0x10, 0x6d, 0x3b, 0x86, 0x8a, 0xcb, 0x02, 0x1a, 0x5d, 0xb8, 0x84,
+	0xf9, 0x54, 0xeb, 0x7f, 0x9a, 0xb2, 0x3e, 0x4a, 0xb8, 0x59, 0xb8, 0x44, 0x05, 0x37, 0xbe, 0x56,
+	0xfe, 0x85, 0x9a, 0x90, 0x5a, 0x7a, 0x1e, 0x6e, 0x4e, 0xe0, 0x86, 0xf3, 0x17, 0x7b, 0x6e, 0xe1,
+	0xf9, 0x9f, 0x51, 0x64, 0xcd, 0xe6, 0xe4, 0xb2, 0x37, 0x67, 0x1f, 0xe4, 0x78, 0x4a, 0xe2, 0xa9,
+	0x8e, 0x3b, 0xfe, 0x9f, 0x04, 0xb5, 0x34, 0xf4, 0x8e, 0x0b, 0x16, 0x0e, 0x8a, 0x63, 0x10, 0x8d,
+	0xa7, 0xe6, 0x59, 0x2a, 0x84, 0xa1, 0x51, 0x94, 0x7e, 0x00, 0xec, 0x4e, 0xc3, 0x53, 0x13, 0x47,
+	0x5b, 0x54, 0x56, 0xcb, 0x61, 0x64, 0x10, 0x06, 0x94, 0x5b, 0xd8, 0xcb, 0x2c, 0x93, 0x7b, 0xf2,
+	0x23, 0x40, 0xbc, 0xb0, 0x62, 0xb2, 0xf7, 0x56, 0x27, 0x3b, 0xce, 0x54, 0x13, 0xf4, 0xc3, 0x2f,
+	0x01, 0x96, 0xe6, 0x23, 0x80, 0xe2, 0xd5, 0xd9, 0xc5, 0x60, 0xf8, 0x4f, 0xfd, 0xa3, 0xf0, 0x7a,
+	0xf0, 0xdb, 0xf0, 0x97, 0xdf, 0xd5, 0xba, 0xd4, 0x7f, 0xbd, 0x01, 0xf5, 0xbf, 0x30, 0xd5, 0x27,
+	0x6c, 0x39, 0x86, 0x4c, 0x18, 0x5d, 0x40, 0x49, 0x1c, 0xfa, 0x68, 0x3f, 0xf5, 0xbc, 0x67, 0x1f,
+	0x14, 0xf9, 0x60, 0x0d, 0xca, 0x5f, 0x60, 0x04, 0x95, 0xc4, 0x09, 0x8f, 0x5a, 0x29, 0xf6, 0xea,
+	0x37, 0x44, 0x6e, 0xaf, 0x27, 0x70, 0xc5, 0x5f, 0x01, 0x96, 0xeb, 0x8f, 0x3e, 0x4f, 0xf1, 0x57,
+	0xce, 0x15, 0xb9, 0xb5, 0x16, 0xe7, 0x72, 0x3f, 0x43, 0x35, 0xf9, 0xad, 0x41, 0xe9, 0x02, 0x32,
+	0x3e, 0x43, 0x72, 0xc6, 0xc9, 0x82, 0x4e, 0xa0, 0xc0, 0x0e, 0x10, 0x94, 0x3e, 0x01, 0x93, 0xa7,
+	0x8c, 0x2c, 0x67, 0x41, 0xbc, 0x8a, 0x01, 0x14, 0xa3, 0x56, 0x21, 0x39, 0x63, 0x79, 0x84, 0xc2,
+	0x5e, 0x26, 0xc6, 0x25, 0x26, 0xd0, 0xc8, 0x98, 0x24, 0xf4, 0x55, 0xb6, 0x01, 0x2b, 0x2b, 0x21,
+	0x77, 0x5e, 0x26, 0x46, 0x4f, 0x3a, 0xfd, 0xf6, 0xf6, 0xe8, 0xde, 0xa4, 0x93, 0x60, 0xdc, 0xd5,
+	0x1d, 0xab, 0xa7, 0x7b, 0x0b, 0x97, 0x3a, 0x16, 0x71, 0x66, 0xbd, 0xa9, 0x6d, 0xf4, 0xa6, 0x76,
+	0xf2, 0xaf, 0xc4, 0x73, 0xf5, 0x71, 0x91, 0xfd, 0x99, 0x7c, 0xf7, 0x76, 0x00, 0xc8, 0xab, 0x68,
+	0xdc, 0xb7, 0x08, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -759,6 +962,12 @@ type WatchtowerClientClient interface {
 	Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error)
 	// Policy returns the active watchtower client policy configuration.
 	Policy(ctx context.Context, in *PolicyRequest, opts ...grpc.CallOption) (*PolicyResponse, error)
+	//
+	//ListTowerCandidates returns the public watchtowers advertised in the
+	//channel graph that accept sessions of the client's policy and aren't
+	//registered with the client yet. A candidate is only used once it's added
+	//with AddTower.
+	ListTowerCandidates(ctx context.Context, in *ListTowerCandidatesRequest, opts ...grpc.CallOption) (*ListTowerCandidatesResponse, error)
 }
 
 type watchtowerClientClient struct {
@@ -823,6 +1032,15 @@ func (c *watchtowerClientClient) Policy(ctx context.Context, in *PolicyRequest,
 	return out, nil
 }
 
+func (c *watchtowerClientClient) ListTowerCandidates(ctx context.Context, in *ListTowerCandidatesRequest, opts ...grpc.CallOption) (*ListTowerCandidatesResponse, error) {
+	out := new(ListTowerCandidatesResponse)
+	err := c.cc.Invoke(ctx, "/wtclientrpc.WatchtowerClient/ListTowerCandidates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // WatchtowerClientServer is the server API for WatchtowerClient service.
 type WatchtowerClientServer interface {
 	//
@@ -845,6 +1063,12 @@ type WatchtowerClientServer interface {
 	Stats(context.Context, *StatsRequest) (*StatsResponse, error)
 	// Policy returns the active watchtower client policy configuration.
 	Policy(context.Context, *PolicyRequest) (*PolicyResponse, error)
+	//
+	//ListTowerCandidates returns the public watchtowers advertised in the
+	//channel graph that accept sessions of the client's policy and aren't
+	//registered with the client yet. A candidate is only used once it's added
+	//with AddTower.
+	ListTowerCandidates(context.Context, *ListTowerCandidatesRequest) (*ListTowerCandidatesResponse, error)
 }
 
 // UnimplementedWatchtowerClientServer can be embedded to have forward compatible implementations.
@@ -869,6 +1093,9 @@ func (*UnimplementedWatchtowerClientServer) Stats(ctx context.Context, req *Stat
 func (*UnimplementedWatchtowerClientServer) Policy(ctx context.Context, req *PolicyRequest) (*PolicyResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Policy not implemented")
 }
+func (*UnimplementedWatchtowerClientServer) ListTowerCandidates(ctx context.Context, req *ListTowerCandidatesRequest) (*ListTowerCandidatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTowerCandidates not implemented")
+}
 
 func RegisterWatchtowerClientServer(s *grpc.Server, srv WatchtowerClientServer) {
 	s.RegisterService(&_WatchtowerClient_serviceDesc, srv)
@@ -982,6 +1209,24 @@ func _WatchtowerClient_Policy_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WatchtowerClient_ListTowerCandidates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTowerCandidatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WatchtowerClientServer).ListTowerCandidates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wtclientrpc.WatchtowerClient/ListTowerCandidates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WatchtowerClientServer).ListTowerCandidates(ctx, req.(*ListTowerCandidatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _WatchtowerClient_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "wtclientrpc.WatchtowerClient",
 	HandlerType: (*WatchtowerClientServer)(nil),
@@ -1010,6 +1255,10 @@ var _WatchtowerClient_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Policy",
 			Handler:    _WatchtowerClient_Policy_Handler,
 		},
+		{
+			MethodName: "ListTowerCandidates",
+			Handler:    _WatchtowerClient_ListTowerCandidates_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "wtclientrpc/wtclient.proto",
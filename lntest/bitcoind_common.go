@@ -13,6 +13,7 @@ import (
 
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/btcsuite/btcd/rpcclient"
+	"github.com/cryptomeow/lnd/chainreg"
 )
 
 // logDirPattern is the pattern of the name of the temporary log directory.
@@ -74,8 +75,20 @@ func newBackend(miner string, netParams *chaincfg.Params, extraArgs []string) (
 	*BitcoindBackendConfig, func() error, error) {
 
 	baseLogDir := fmt.Sprintf(logDirPattern, GetLogDir())
-	if netParams != &chaincfg.RegressionNetParams {
-		return nil, nil, fmt.Errorf("only regtest supported")
+
+	// Besides regtest, we also support running against the default signet.
+	// Signets with a custom challenge have a different network magic, so
+	// they can't be run by this backend.
+	var netArg string
+	switch {
+	case netParams == &chaincfg.RegressionNetParams:
+		netArg = "-regtest"
+
+	case netParams.Net == chainreg.BitcoinSigNetParams.Net:
+		netArg = "-signet"
+
+	default:
+		return nil, nil, fmt.Errorf("only regtest and signet supported")
 	}
 
 	if err := os.MkdirAll(baseLogDir, 0700); err != nil {
@@ -109,6 +122,7 @@ func newBackend(miner string, netParams *chaincfg.Params, extraArgs []string) (
 		"-zmqpubrawblock=" + zmqBlockAddr,
 		"-zmqpubrawtx=" + zmqTxAddr,
 		"-debuglogfile=" + logFile,
+		netArg,
 	}
 	cmdArgs = append(cmdArgs, extraArgs...)
 	bitcoind := exec.Command("bitcoind", cmdArgs...)
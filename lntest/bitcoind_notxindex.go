@@ -14,7 +14,6 @@ func NewBackend(miner string, netParams *chaincfg.Params) (
 
 	extraArgs := []string{
 		"-debug",
-		"-regtest",
 		"-disablewallet",
 	}
 
@@ -55,14 +55,14 @@ func testCPFP(net *lntest.NetworkHarness, t *harnessTest) {
 		t.Fatalf("unable to send coins to bob: %v", err)
 	}
 
-	txid, err := waitForTxInMempool(net.Miner.Node, minerMempoolTimeout)
+	txid, err := waitForTxInMempool(net.Miner.Client, minerMempoolTimeout)
 	if err != nil {
 		t.Fatalf("expected one mempool transaction: %v", err)
 	}
 
 	// We'll then extract the raw transaction from the mempool in order to
 	// determine the index of Bob's output.
-	tx, err := net.Miner.Node.GetRawTransaction(txid)
+	tx, err := net.Miner.Client.GetRawTransaction(txid)
 	if err != nil {
 		t.Fatalf("unable to extract raw transaction from mempool: %v",
 			err)
@@ -105,7 +105,7 @@ func testCPFP(net *lntest.NetworkHarness, t *harnessTest) {
 
 	// We should now expect to see two transactions within the mempool, a
 	// parent and its child.
-	_, err = waitForNTxsInMempool(net.Miner.Node, 2, minerMempoolTimeout)
+	_, err = waitForNTxsInMempool(net.Miner.Client, 2, minerMempoolTimeout)
 	if err != nil {
 		t.Fatalf("expected two mempool transactions: %v", err)
 	}
@@ -190,7 +190,7 @@ func testRestAPI(net *lntest.NetworkHarness, ht *harnessTest) {
 		run: func(t *testing.T, a, b *lntest.HarnessNode) {
 			// Find out the current best block so we can subscribe
 			// to the next one.
-			hash, height, err := net.Miner.Node.GetBestBlock()
+			hash, height, err := net.Miner.Client.GetBestBlock()
 			require.Nil(t, err, "get best block")
 
 			// Create a new subscription to get block epoch events.
@@ -257,7 +257,7 @@ func testRestAPI(net *lntest.NetworkHarness, ht *harnessTest) {
 			}()
 
 			// Mine a block and make sure we get a message for it.
-			blockHashes, err := net.Miner.Node.Generate(1)
+			blockHashes, err := net.Miner.Client.Generate(1)
 			require.Nil(t, err, "generate blocks")
 			assert.Equal(t, 1, len(blockHashes), "num blocks")
 			select {
@@ -279,7 +279,7 @@ func testRestAPI(net *lntest.NetworkHarness, ht *harnessTest) {
 		run: func(t *testing.T, a, b *lntest.HarnessNode) {
 			// Find out the current best block so we can subscribe
 			// to the next one.
-			hash, height, err := net.Miner.Node.GetBestBlock()
+			hash, height, err := net.Miner.Client.GetBestBlock()
 			require.Nil(t, err, "get best block")
 
 			// Create a new subscription to get block epoch events.
@@ -299,17 +299,27 @@ func testRestAPI(net *lntest.NetworkHarness, ht *harnessTest) {
 			macBytes, err := mac.MarshalBinary()
 			require.NoError(t, err, "marshal admin mac")
 
+			// Browsers send all offered protocols as a comma
+			// separated list, so we add another one to make sure
+			// the proxy picks the right one.
+			protocol := fmt.Sprintf(
+				"Grpc-Metadata-Macaroon+%s",
+				hex.EncodeToString(macBytes),
+			)
 			customHeader := make(http.Header)
 			customHeader.Set(
-				lnrpc.HeaderWebSocketProtocol, fmt.Sprintf(
-					"Grpc-Metadata-Macaroon+%s",
-					hex.EncodeToString(macBytes),
-				),
+				lnrpc.HeaderWebSocketProtocol,
+				protocol+", some-other-protocol",
 			)
 			c, err := openWebSocket(
 				a, url, "POST", req, customHeader,
 			)
 			require.Nil(t, err, "websocket")
+
+			// The proxy must select the protocol that was used to
+			// transport the macaroon, otherwise browsers abort the
+			// connection.
+			require.Equal(t, protocol, c.Subprotocol(), "protocol")
 			defer func() {
 				_ = c.WriteMessage(
 					websocket.CloseMessage,
@@ -366,7 +376,7 @@ func testRestAPI(net *lntest.NetworkHarness, ht *harnessTest) {
 			}()
 
 			// Mine a block and make sure we get a message for it.
-			blockHashes, err := net.Miner.Node.Generate(1)
+			blockHashes, err := net.Miner.Client.Generate(1)
 			require.Nil(t, err, "generate blocks")
 			assert.Equal(t, 1, len(blockHashes), "num blocks")
 			select {
@@ -379,6 +389,90 @@ func testRestAPI(net *lntest.NetworkHarness, ht *harnessTest) {
 			case err := <-errChan:
 				t.Fatalf("Received error from WS: %v", err)
 
+			case <-timeout:
+				t.Fatalf("Timeout before message was received")
+			}
+		},
+	}, {
+		name: "websocket subscription with GET stream",
+		run: func(t *testing.T, a, b *lntest.HarnessNode) {
+			// Subscribe to invoice updates of the main RPC server.
+			// This is a GET endpoint, so the initial message is
+			// just an empty request.
+			url := "/v1/invoices/subscribe"
+			req := &lnrpc.InvoiceSubscription{}
+			c, err := openWebSocket(a, url, "GET", req, nil)
+			require.Nil(t, err, "websocket")
+			defer func() {
+				_ = c.WriteMessage(
+					websocket.CloseMessage,
+					websocket.FormatCloseMessage(
+						websocket.CloseNormalClosure,
+						"done",
+					),
+				)
+				_ = c.Close()
+			}()
+
+			msgChan := make(chan *lnrpc.Invoice)
+			errChan := make(chan error)
+			timeout := time.After(defaultTimeout)
+
+			// We want to read exactly one message.
+			go func() {
+				defer close(msgChan)
+
+				_, msg, err := c.ReadMessage()
+				if err != nil {
+					errChan <- err
+					return
+				}
+
+				// The chunked/streamed responses come wrapped
+				// in either a {"result":{}} or {"error":{}}
+				// wrapper which we'll get rid of here.
+				msgStr := string(msg)
+				if !strings.Contains(msgStr, "\"result\":") {
+					errChan <- fmt.Errorf("invalid msg: %s",
+						msgStr)
+					return
+				}
+				msgStr = resultPattern.ReplaceAllString(
+					msgStr, "${1}",
+				)
+
+				// Make sure we can parse the unwrapped message
+				// into the expected proto message.
+				protoMsg := &lnrpc.Invoice{}
+				err = jsonpb.UnmarshalString(
+					msgStr, protoMsg,
+				)
+				if err != nil {
+					errChan <- err
+					return
+				}
+
+				select {
+				case msgChan <- protoMsg:
+				case <-timeout:
+				}
+			}()
+
+			// Add an invoice and make sure we get a message for it.
+			ctxb := context.Background()
+			invoice := &lnrpc.Invoice{
+				Memo:  "websocket",
+				Value: 1000,
+			}
+			_, err = a.AddInvoice(ctxb, invoice)
+			require.Nil(t, err, "add invoice")
+			select {
+			case msg := <-msgChan:
+				assert.Equal(t, invoice.Memo, msg.Memo, "memo")
+
+			case err := <-errChan:
+				t.Fatalf("Received error from WS: %v", err)
+
 			case <-timeout:
 				t.Fatalf("Timeout before message was received")
 			}
@@ -11,6 +11,7 @@ import (
 	"github.com/btcsuite/btcd/wire"
 	"github.com/btcsuite/btcutil"
 	"github.com/btcsuite/btcutil/psbt"
+	"github.com/btcsuite/btcwallet/waddrmgr"
 	"github.com/btcsuite/btcwallet/wallet/txauthor"
 	"github.com/btcsuite/btcwallet/wtxmgr"
 
@@ -52,13 +53,15 @@ func (w *WalletController) FetchInputInfo(
 }
 
 // ConfirmedBalance currently returns dummy values.
-func (w *WalletController) ConfirmedBalance(confs int32) (btcutil.Amount, error) {
+func (w *WalletController) ConfirmedBalance(confs int32,
+	_ string) (btcutil.Amount, error) {
+
 	return 0, nil
 }
 
 // NewAddress is called to get new addresses for delivery, change etc.
 func (w *WalletController) NewAddress(addrType lnwallet.AddressType,
-	change bool) (btcutil.Address, error) {
+	change bool, _ string) (btcutil.Address, error) {
 
 	addr, _ := btcutil.NewAddressPubKey(
 		w.RootKey.PubKey().SerializeCompressed(), &chaincfg.MainNetParams,
@@ -66,6 +69,20 @@ func (w *WalletController) NewAddress(addrType lnwallet.AddressType,
 	return addr, nil
 }
 
+// ListAccounts currently returns dummy values.
+func (w *WalletController) ListAccounts() ([]*waddrmgr.AccountProperties,
+	error) {
+
+	return nil, nil
+}
+
+// CreateAccount currently returns dummy values.
+func (w *WalletController) CreateAccount(
+	_ string) (*waddrmgr.AccountProperties, error) {
+
+	return nil, nil
+}
+
 // LastUnusedAddress currently returns dummy values.
 func (w *WalletController) LastUnusedAddress(addrType lnwallet.AddressType) (
 	btcutil.Address, error) {
@@ -79,7 +96,7 @@ func (w *WalletController) IsOurAddress(a btcutil.Address) bool {
 
 // SendOutputs currently returns dummy values.
 func (w *WalletController) SendOutputs(outputs []*wire.TxOut,
-	_ chainfee.SatPerKWeight, _ int32, _ string) (*wire.MsgTx, error) {
+	_ chainfee.SatPerKWeight, _ int32, _, _ string) (*wire.MsgTx, error) {
 
 	return nil, nil
 }
@@ -94,7 +111,7 @@ func (w *WalletController) CreateSimpleTx(outputs []*wire.TxOut,
 // ListUnspentWitness is called by the wallet when doing coin selection. We just
 // need one unspent for the funding transaction.
 func (w *WalletController) ListUnspentWitness(minconfirms,
-	maxconfirms int32) ([]*lnwallet.Utxo, error) {
+	maxconfirms int32, _ string) ([]*lnwallet.Utxo, error) {
 
 	// If the mock already has a list of utxos, return it.
 	if w.Utxos != nil {
@@ -130,9 +147,24 @@ func (w *WalletController) LockOutpoint(o wire.OutPoint) {}
 // UnlockOutpoint currently does nothing.
 func (w *WalletController) UnlockOutpoint(o wire.OutPoint) {}
 
+// LabelOutput currently does nothing.
+func (w *WalletController) LabelOutput(wire.OutPoint, string, bool) error {
+	return nil
+}
+
+// FreezeOutput currently does nothing.
+func (w *WalletController) FreezeOutput(wire.OutPoint) error {
+	return nil
+}
+
+// UnfreezeOutput currently does nothing.
+func (w *WalletController) UnfreezeOutput(wire.OutPoint) error {
+	return nil
+}
+
 // LeaseOutput returns the current time and a nil error.
-func (w *WalletController) LeaseOutput(wtxmgr.LockID, wire.OutPoint) (time.Time,
-	error) {
+func (w *WalletController) LeaseOutput(wtxmgr.LockID, wire.OutPoint,
+	time.Duration) (time.Time, error) {
 
 	return time.Now(), nil
 }
@@ -142,9 +174,16 @@ func (w *WalletController) ReleaseOutput(wtxmgr.LockID, wire.OutPoint) error {
 	return nil
 }
 
+// ListLeasedOutputs returns a nil slice and a nil error.
+func (w *WalletController) ListLeasedOutputs() ([]*lnwallet.LeasedOutput,
+	error) {
+
+	return nil, nil
+}
+
 // FundPsbt currently does nothing.
 func (w *WalletController) FundPsbt(_ *psbt.Packet,
-	_ chainfee.SatPerKWeight) (int32, error) {
+	_ chainfee.SatPerKWeight, _ string) (int32, error) {
 
 	return 0, nil
 }
@@ -184,6 +223,13 @@ func (w *WalletController) GetRecoveryInfo() (bool, float64, error) {
 	return true, float64(1), nil
 }
 
+// Rescan currently does nothing.
+func (w *WalletController) Rescan(int32, func(*lnwallet.RescanProgress),
+	<-chan struct{}) error {
+
+	return nil
+}
+
 // Start currently does nothing.
 func (w *WalletController) Start() error {
 	return nil
@@ -24,6 +24,7 @@ import (
 	"github.com/btcsuite/btcd/wire"
 	"github.com/btcsuite/btcutil"
 	"github.com/go-errors/errors"
+	"github.com/cryptomeow/lnd/chainreg"
 	"github.com/cryptomeow/lnd/chanbackup"
 	"github.com/cryptomeow/lnd/lnrpc"
 	"github.com/cryptomeow/lnd/lnrpc/invoicesrpc"
@@ -159,6 +160,11 @@ type NodeConfig struct {
 	BaseDir    string
 	ExtraArgs  []string
 
+	// SigNetChallenge is the challenge script of the signet the node runs
+	// against if NetParams are signet params. If it's empty, the default
+	// signet is used.
+	SigNetChallenge []byte
+
 	DataDir        string
 	LogDir         string
 	TLSCertPath    string
@@ -219,6 +225,15 @@ func (cfg NodeConfig) genArgs() []string {
 		args = append(args, "--bitcoin.simnet")
 	case &chaincfg.RegressionNetParams:
 		args = append(args, "--bitcoin.regtest")
+	default:
+		if chainreg.IsSigNet(cfg.NetParams) {
+			args = append(args, "--bitcoin.signet")
+		}
+	}
+	if len(cfg.SigNetChallenge) > 0 {
+		args = append(args, fmt.Sprintf(
+			"--bitcoin.signetchallenge=%x", cfg.SigNetChallenge,
+		))
 	}
 
 	backendArgs := cfg.BackendCfg.GenArgs()
@@ -389,7 +404,7 @@ func NewMiner(logDir, logFilename string, netParams *chaincfg.Params,
 		"--trickleinterval=100ms",
 	}
 
-	miner, err := rpctest.New(netParams, handler, args)
+	miner, err := rpctest.New(netParams, handler, args, "")
 	if err != nil {
 		return nil, nil, fmt.Errorf(
 			"unable to create mining node: %v", err,
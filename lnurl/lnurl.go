@@ -0,0 +1,472 @@
+// Package lnurl resolves LNURL-pay and lightning address targets to BOLT 11
+// invoices, so clients that only use the RPC interface can pay them.
+package lnurl
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/cryptomeow/lnd/lnwire"
+	"github.com/cryptomeow/lnd/zpay32"
+)
+
+const (
+	// DefaultTimeout is the default timeout of a request to an LNURL
+	// server.
+	DefaultTimeout = 30 * time.Second
+
+	// lnurlHrp is the human-readable part of a bech32 encoded LNURL.
+	lnurlHrp = "lnurl"
+
+	// payRequestTag is the tag of the response of an LNURL-pay server to
+	// the first request.
+	payRequestTag = "payRequest"
+
+	// maxResponseSize is the maximum size of a response that is read from
+	// an LNURL server.
+	maxResponseSize = 64 * 1024
+
+	// maxRedirects is the maximum number of redirects that are followed
+	// for a single request.
+	maxRedirects = 10
+)
+
+var (
+	// ErrInvalidTarget is returned if a target is neither a bech32
+	// encoded LNURL nor a lightning address.
+	ErrInvalidTarget = errors.New("target is neither an lnurl nor a " +
+		"lightning address")
+
+	// ErrAmountOutOfRange is returned if the amount to pay is outside of
+	// the range the LNURL server accepts.
+	ErrAmountOutOfRange = errors.New("amount outside of sendable range")
+
+	// ErrForbiddenAddress is returned if an LNURL server resolves to an
+	// address that isn't publicly routable, such as a loopback or private
+	// address. This prevents RPC clients from using lnd to reach services
+	// on the local network.
+	ErrForbiddenAddress = errors.New("lnurl server address is not " +
+		"publicly routable")
+
+	// privateNets are the address ranges, besides loopback and link-local
+	// addresses, that LNURL servers must not resolve to.
+	privateNets = mustParseCIDRs(
+		"0.0.0.0/8", "10.0.0.0/8", "100.64.0.0/10", "172.16.0.0/12",
+		"192.168.0.0/16", "fc00::/7",
+	)
+)
+
+// mustParseCIDRs parses the given CIDR notated address ranges and panics if
+// any of them is invalid.
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	nets := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		nets = append(nets, ipNet)
+	}
+
+	return nets
+}
+
+// ParseTarget returns the URL of the first request to an LNURL-pay target. The
+// target is either a bech32 encoded LNURL or a lightning address of the form
+// user@domain, optionally prefixed by "lightning:".
+func ParseTarget(target string) (*url.URL, error) {
+	target = strings.TrimSpace(target)
+	if strings.HasPrefix(strings.ToLower(target), "lightning:") {
+		target = target[len("lightning:"):]
+	}
+
+	var rawURL string
+	if parts := strings.Split(target, "@"); len(parts) == 2 {
+		user, domain := strings.ToLower(parts[0]), parts[1]
+		if user == "" || domain == "" {
+			return nil, ErrInvalidTarget
+		}
+
+		scheme := "https"
+		if isOnion(domain) {
+			scheme = "http"
+		}
+
+		rawURL = fmt.Sprintf("%v://%v/.well-known/lnurlp/%v", scheme,
+			domain, url.PathEscape(user))
+	} else {
+		hrp, data, err := zpay32.DecodeBech32(target)
+		if err != nil || hrp != lnurlHrp {
+			return nil, ErrInvalidTarget
+		}
+		rawURL = string(data)
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid lnurl: %v", err)
+	}
+
+	if !isEncrypted(u) {
+		return nil, fmt.Errorf("lnurl %v must use https", u)
+	}
+
+	return u, nil
+}
+
+// isEncrypted returns true if requests to the URL are encrypted. This is the
+// case for https URLs, and for http URLs of onion services, as Tor already
+// takes care of the encryption.
+func isEncrypted(u *url.URL) bool {
+	switch {
+	case u.Scheme == "https":
+		return true
+
+	case u.Scheme == "http" && isOnion(u.Hostname()):
+		return true
+
+	default:
+		return false
+	}
+}
+
+// checkRedirect is the redirect policy of the http client. Redirects are only
+// followed to encrypted URLs, so a server can't downgrade the request to plain
+// http.
+func checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxRedirects {
+		return fmt.Errorf("stopped after %v redirects", maxRedirects)
+	}
+
+	if !isEncrypted(req.URL) {
+		return fmt.Errorf("redirect to %v must use https", req.URL)
+	}
+
+	return nil
+}
+
+// checkDialAddress rejects connections to addresses that aren't publicly
+// routable. It is called with the resolved address of each connection, so
+// host names resolving to such addresses are rejected too.
+func checkDialAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return fmt.Errorf("invalid address %v", address)
+	}
+
+	if ip.IsLoopback() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsUnspecified() {
+
+		return fmt.Errorf("%w: %v", ErrForbiddenAddress, ip)
+	}
+
+	for _, ipNet := range privateNets {
+		if ipNet.Contains(ip) {
+			return fmt.Errorf("%w: %v", ErrForbiddenAddress, ip)
+		}
+	}
+
+	return nil
+}
+
+// isOnion returns true if the host is a Tor onion service.
+func isOnion(host string) bool {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+
+	return strings.HasSuffix(strings.ToLower(host), ".onion")
+}
+
+// PayParams are the parameters of an LNURL-pay target, as returned by the
+// first request to its server.
+type PayParams struct {
+	// Callback is the URL that invoices are requested from.
+	Callback string `json:"callback"`
+
+	// MinSendable is the minimum amount that can be paid.
+	MinSendable lnwire.MilliSatoshi `json:"minSendable"`
+
+	// MaxSendable is the maximum amount that can be paid.
+	MaxSendable lnwire.MilliSatoshi `json:"maxSendable"`
+
+	// Metadata is the raw metadata of the payment. The description hash
+	// of the invoices commits to it.
+	Metadata string `json:"metadata"`
+
+	// CommentAllowed is the maximum length of a comment that can be sent
+	// along with the payment. Zero if comments aren't supported.
+	CommentAllowed int `json:"commentAllowed"`
+
+	// Tag identifies the type of the LNURL.
+	Tag string `json:"tag"`
+}
+
+// Description returns the plain text description of the payment, if the
+// metadata contains one.
+func (p *PayParams) Description() string {
+	var entries [][]json.RawMessage
+	if err := json.Unmarshal([]byte(p.Metadata), &entries); err != nil {
+		return ""
+	}
+
+	for _, entry := range entries {
+		if len(entry) != 2 {
+			continue
+		}
+
+		var mimeType, content string
+		if json.Unmarshal(entry[0], &mimeType) != nil ||
+			mimeType != "text/plain" {
+
+			continue
+		}
+		if json.Unmarshal(entry[1], &content) == nil {
+			return content
+		}
+	}
+
+	return ""
+}
+
+// Config holds the configuration of a Resolver.
+type Config struct {
+	// Dial connects to an LNURL server over Tor. It is only used if
+	// UseTor is set.
+	Dial func(network, address string, timeout time.Duration) (net.Conn,
+		error)
+
+	// UseTor indicates that the requests are routed over Tor using Dial.
+	// Otherwise, connections are made directly and only to publicly
+	// routable addresses. Over Tor, host names are resolved by the exit
+	// relay, so local services can't be reached.
+	UseTor bool
+
+	// Timeout is the timeout of a single request.
+	Timeout time.Duration
+
+	// ChainParams are the parameters of the chain the invoices must be
+	// valid for.
+	ChainParams *chaincfg.Params
+}
+
+// Resolver resolves LNURL-pay and lightning address targets to invoices.
+type Resolver struct {
+	cfg    *Config
+	client *http.Client
+}
+
+// NewResolver creates a new resolver.
+func NewResolver(cfg *Config) *Resolver {
+	dialer := &net.Dialer{
+		Timeout: cfg.Timeout,
+		Control: checkDialAddress,
+	}
+	transport := &http.Transport{
+		DialContext: func(ctx context.Context, network,
+			address string) (net.Conn, error) {
+
+			if cfg.UseTor {
+				return cfg.Dial(network, address, cfg.Timeout)
+			}
+
+			return dialer.DialContext(ctx, network, address)
+		},
+		TLSHandshakeTimeout: cfg.Timeout,
+	}
+
+	return newResolver(cfg, &http.Client{
+		Transport:     transport,
+		CheckRedirect: checkRedirect,
+		Timeout:       cfg.Timeout,
+	})
+}
+
+// newResolver creates a new resolver that uses the given http client.
+func newResolver(cfg *Config, client *http.Client) *Resolver {
+	return &Resolver{
+		cfg:    cfg,
+		client: client,
+	}
+}
+
+// serverError is the response of an LNURL server if a request failed.
+type serverError struct {
+	Status string `json:"status"`
+	Reason string `json:"reason"`
+}
+
+// get sends a GET request to the URL and decodes the JSON response into the
+// target.
+func (r *Resolver) get(ctx context.Context, u *url.URL,
+	target interface{}) error {
+
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := r.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("request to %v failed: %w", u.Host, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(
+		io.LimitReader(resp.Body, maxResponseSize),
+	)
+	if err != nil {
+		return err
+	}
+
+	// Failures are reported with a status of ERROR, and may come with
+	// any http status code.
+	var errResp serverError
+	if json.Unmarshal(body, &errResp) == nil &&
+		strings.EqualFold(errResp.Status, "ERROR") {
+
+		return fmt.Errorf("lnurl server %v returned error: %v",
+			u.Host, errResp.Reason)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("lnurl server %v returned status %v",
+			u.Host, resp.Status)
+	}
+
+	if err := json.Unmarshal(body, target); err != nil {
+		return fmt.Errorf("invalid response from lnurl server %v: %v",
+			u.Host, err)
+	}
+
+	return nil
+}
+
+// FetchPayParams fetches the parameters of an LNURL-pay target.
+func (r *Resolver) FetchPayParams(ctx context.Context,
+	target string) (*PayParams, error) {
+
+	u, err := ParseTarget(target)
+	if err != nil {
+		return nil, err
+	}
+
+	var params PayParams
+	if err := r.get(ctx, u, &params); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case params.Tag != payRequestTag:
+		return nil, fmt.Errorf("lnurl is not a pay request: %q",
+			params.Tag)
+
+	case params.MinSendable == 0 ||
+		params.MinSendable > params.MaxSendable:
+
+		return nil, fmt.Errorf("invalid sendable range [%v, %v]",
+			params.MinSendable, params.MaxSendable)
+	}
+
+	if _, err := parseCallback(params.Callback); err != nil {
+		return nil, err
+	}
+
+	return &params, nil
+}
+
+// parseCallback parses the callback URL of an LNURL-pay target.
+func parseCallback(callback string) (*url.URL, error) {
+	u, err := url.Parse(callback)
+	if err != nil {
+		return nil, fmt.Errorf("invalid callback: %v", err)
+	}
+
+	if !isEncrypted(u) {
+		return nil, fmt.Errorf("callback %v must use https", u)
+	}
+
+	return u, nil
+}
+
+// FetchInvoice requests an invoice of the given amount from an LNURL-pay
+// target. The comment is optional. The invoice is checked to pay the amount
+// and to commit to the metadata of the target.
+func (r *Resolver) FetchInvoice(ctx context.Context, params *PayParams,
+	amt lnwire.MilliSatoshi, comment string) (string, *zpay32.Invoice,
+	error) {
+
+	if amt < params.MinSendable || amt > params.MaxSendable {
+		return "", nil, fmt.Errorf("%w: %v not in [%v, %v]",
+			ErrAmountOutOfRange, amt, params.MinSendable,
+			params.MaxSendable)
+	}
+
+	if len(comment) > params.CommentAllowed {
+		return "", nil, fmt.Errorf("comment of %v characters "+
+			"exceeds the limit of %v", len(comment),
+			params.CommentAllowed)
+	}
+
+	u, err := parseCallback(params.Callback)
+	if err != nil {
+		return "", nil, err
+	}
+
+	query := u.Query()
+	query.Set("amount", strconv.FormatUint(uint64(amt), 10))
+	if comment != "" {
+		query.Set("comment", comment)
+	}
+	u.RawQuery = query.Encode()
+
+	var resp struct {
+		PaymentRequest string `json:"pr"`
+	}
+	if err := r.get(ctx, u, &resp); err != nil {
+		return "", nil, err
+	}
+
+	invoice, err := zpay32.Decode(resp.PaymentRequest, r.cfg.ChainParams)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid invoice: %v", err)
+	}
+
+	if invoice.MilliSat == nil {
+		return "", nil, errors.New("invoice doesn't specify an amount")
+	}
+	if *invoice.MilliSat != amt {
+		return "", nil, fmt.Errorf("invoice amount %v doesn't match "+
+			"requested amount %v", *invoice.MilliSat, amt)
+	}
+
+	metadataHash := sha256.Sum256([]byte(params.Metadata))
+	if invoice.DescriptionHash == nil ||
+		*invoice.DescriptionHash != metadataHash {
+
+		return "", nil, errors.New("invoice description hash " +
+			"doesn't commit to the lnurl metadata")
+	}
+
+	log.Debugf("Resolved lnurl-pay invoice of %v from %v", amt, u.Host)
+
+	return resp.PaymentRequest, invoice, nil
+}
@@ -0,0 +1,290 @@
+package lnurl
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcutil/bech32"
+	"github.com/cryptomeow/lnd/lnwire"
+	"github.com/cryptomeow/lnd/zpay32"
+	"github.com/stretchr/testify/require"
+)
+
+const testMetadata = `[["text/plain","coffee"]]`
+
+// encodeLNURL bech32 encodes the URL as an LNURL.
+func encodeLNURL(t *testing.T, rawURL string) string {
+	data, err := bech32.ConvertBits([]byte(rawURL), 8, 5, true)
+	require.NoError(t, err)
+
+	lnurl, err := bech32.Encode(lnurlHrp, data)
+	require.NoError(t, err)
+
+	return strings.ToUpper(lnurl)
+}
+
+// TestParseTarget asserts that lightning addresses and LNURLs are parsed to
+// the URL of their first request.
+func TestParseTarget(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		target string
+		url    string
+		valid  bool
+	}{
+		{
+			name:   "lightning address",
+			target: "Alice@example.com",
+			url:    "https://example.com/.well-known/lnurlp/alice",
+			valid:  true,
+		},
+		{
+			name:   "onion lightning address",
+			target: "lightning:bob@abc.onion",
+			url:    "http://abc.onion/.well-known/lnurlp/bob",
+			valid:  true,
+		},
+		{
+			name: "lnurl",
+			target: encodeLNURL(
+				t, "https://example.com/lnurlp?user=alice",
+			),
+			url:   "https://example.com/lnurlp?user=alice",
+			valid: true,
+		},
+		{
+			name:   "lnurl without tls",
+			target: encodeLNURL(t, "http://example.com/lnurlp"),
+		},
+		{
+			name:   "invalid address",
+			target: "@example.com",
+		},
+		{
+			name:   "invoice",
+			target: "lnbc1",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			u, err := ParseTarget(testCase.target)
+			if !testCase.valid {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, testCase.url, u.String())
+		})
+	}
+}
+
+// TestResolver asserts that invoices are fetched from an LNURL-pay server and
+// validated.
+func TestResolver(t *testing.T) {
+	t.Parallel()
+
+	privKey, err := btcec.NewPrivateKey(btcec.S256())
+	require.NoError(t, err)
+
+	// The invoice returned by the callback either commits to the metadata
+	// or to a different description.
+	metadata := testMetadata
+	server := httptest.NewTLSServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			var resp interface{}
+			switch r.URL.Path {
+			case "/.well-known/lnurlp/alice":
+				resp = &PayParams{
+					Callback: "https://" + r.Host +
+						"/callback?id=1",
+					MinSendable:    1000,
+					MaxSendable:    100000,
+					Metadata:       testMetadata,
+					CommentAllowed: 10,
+					Tag:            payRequestTag,
+				}
+
+			case "/callback":
+				require.Equal(t, "1", r.URL.Query().Get("id"))
+
+				amt, err := strconv.ParseUint(
+					r.URL.Query().Get("amount"), 10, 64,
+				)
+				require.NoError(t, err)
+
+				invoice, err := zpay32.NewInvoice(
+					&chaincfg.RegressionNetParams,
+					[32]byte{1}, time.Now(),
+					zpay32.Amount(lnwire.MilliSatoshi(amt)),
+					zpay32.DescriptionHash(
+						sha256.Sum256([]byte(metadata)),
+					),
+				)
+				require.NoError(t, err)
+
+				signer := zpay32.MessageSigner{
+					SignCompact: func(hash []byte) ([]byte,
+						error) {
+
+						return btcec.SignCompact(
+							btcec.S256(), privKey,
+							hash, true,
+						)
+					},
+				}
+				payReq, err := invoice.Encode(signer)
+				require.NoError(t, err)
+
+				resp = map[string]interface{}{
+					"pr":     payReq,
+					"routes": []string{},
+				}
+
+			default:
+				resp = &serverError{
+					Status: "ERROR",
+					Reason: "unknown user",
+				}
+			}
+
+			require.NoError(t, json.NewEncoder(w).Encode(resp))
+		},
+	))
+	defer server.Close()
+
+	resolver := newResolver(&Config{
+		ChainParams: &chaincfg.RegressionNetParams,
+	}, server.Client())
+
+	ctx := context.Background()
+	host := strings.TrimPrefix(server.URL, "https://")
+
+	_, err = resolver.FetchPayParams(ctx, "bob@"+host)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unknown user")
+
+	params, err := resolver.FetchPayParams(ctx, "alice@"+host)
+	require.NoError(t, err)
+	require.Equal(t, lnwire.MilliSatoshi(1000), params.MinSendable)
+	require.Equal(t, lnwire.MilliSatoshi(100000), params.MaxSendable)
+	require.Equal(t, "coffee", params.Description())
+
+	payReq, invoice, err := resolver.FetchInvoice(
+		ctx, params, 5000, "thanks",
+	)
+	require.NoError(t, err)
+	require.NotEmpty(t, payReq)
+	require.Equal(t, lnwire.MilliSatoshi(5000), *invoice.MilliSat)
+
+	// Amounts outside of the sendable range and long comments are
+	// rejected before the callback is requested.
+	_, _, err = resolver.FetchInvoice(ctx, params, 999, "")
+	require.True(t, errors.Is(err, ErrAmountOutOfRange))
+
+	_, _, err = resolver.FetchInvoice(
+		ctx, params, 5000, "a very long comment",
+	)
+	require.Error(t, err)
+
+	// An invoice that doesn't commit to the metadata is rejected.
+	metadata = "other"
+	_, _, err = resolver.FetchInvoice(ctx, params, 5000, "")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "description hash")
+}
+
+// TestCheckRedirect asserts that redirects are only followed to encrypted
+// URLs.
+func TestCheckRedirect(t *testing.T) {
+	t.Parallel()
+
+	newRequest := func(rawURL string) *http.Request {
+		req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+		require.NoError(t, err)
+
+		return req
+	}
+
+	via := []*http.Request{newRequest("https://example.com/lnurlp")}
+
+	err := checkRedirect(newRequest("https://example.org/lnurlp"), via)
+	require.NoError(t, err)
+
+	err = checkRedirect(newRequest("http://example.onion/lnurlp"), via)
+	require.NoError(t, err)
+
+	err = checkRedirect(newRequest("http://example.org/lnurlp"), via)
+	require.Error(t, err)
+
+	err = checkRedirect(newRequest("file:///etc/passwd"), via)
+	require.Error(t, err)
+
+	// Redirect loops are stopped.
+	for len(via) < maxRedirects {
+		via = append(via, via[0])
+	}
+	err = checkRedirect(newRequest("https://example.org/lnurlp"), via)
+	require.Error(t, err)
+}
+
+// TestCheckDialAddress asserts that only publicly routable addresses may be
+// dialed.
+func TestCheckDialAddress(t *testing.T) {
+	t.Parallel()
+
+	forbidden := []string{
+		"127.0.0.1:443", "[::1]:443", "10.1.2.3:443", "172.16.0.1:443",
+		"192.168.1.1:443", "169.254.169.254:80", "100.64.0.1:443",
+		"0.0.0.0:443", "[fe80::1]:443", "[fd00::1]:443",
+	}
+	for _, address := range forbidden {
+		err := checkDialAddress("tcp", address, nil)
+		require.True(
+			t, errors.Is(err, ErrForbiddenAddress),
+			"address %v not rejected", address,
+		)
+	}
+
+	allowed := []string{"1.1.1.1:443", "[2606:4700::1111]:443"}
+	for _, address := range allowed {
+		require.NoError(t, checkDialAddress("tcp", address, nil))
+	}
+}
+
+// TestResolverRefusesLocalServer asserts that the resolver doesn't connect to
+// servers on the loopback interface when Tor isn't used.
+func TestResolverRefusesLocalServer(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			t.Errorf("unexpected request to %v", r.URL)
+		},
+	))
+	defer server.Close()
+
+	resolver := NewResolver(&Config{
+		Timeout:     time.Second,
+		ChainParams: &chaincfg.RegressionNetParams,
+	})
+
+	host := strings.TrimPrefix(server.URL, "https://")
+	_, err := resolver.FetchPayParams(context.Background(), "alice@"+host)
+	require.True(t, errors.Is(err, ErrForbiddenAddress), err)
+}
@@ -5,6 +5,8 @@ import (
 	"encoding/hex"
 	"fmt"
 	"math"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -21,6 +23,7 @@ import (
 	"github.com/btcsuite/btcwallet/wallet/txrules"
 	"github.com/btcsuite/btcwallet/walletdb"
 	"github.com/btcsuite/btcwallet/wtxmgr"
+	"github.com/cryptomeow/lnd/channeldb/kvdb"
 	"github.com/cryptomeow/lnd/keychain"
 	"github.com/cryptomeow/lnd/lnwallet"
 	"github.com/cryptomeow/lnd/lnwallet/chainfee"
@@ -32,9 +35,21 @@ const (
 	// UnconfirmedHeight is the special case end height that is used to
 	// obtain unconfirmed transactions from ListTransactionDetails.
 	UnconfirmedHeight int32 = -1
+
+	// DefaultLockDuration is the duration outputs are leased for if no
+	// duration is given.
+	DefaultLockDuration = 10 * time.Minute
 )
 
 var (
+	// accountScopes are the key scopes that the accounts of the wallet are
+	// created in. Accounts are always created in all of these scopes at
+	// once, so an account has the same number in each of them.
+	accountScopes = []waddrmgr.KeyScope{
+		waddrmgr.KeyScopeBIP0084,
+		waddrmgr.KeyScopeBIP0049Plus,
+	}
+
 	// waddrmgrNamespaceKey is the namespace key that the waddrmgr state is
 	// stored within the top-level waleltdb buckets of btcwallet.
 	waddrmgrNamespaceKey = []byte("waddrmgr")
@@ -65,6 +80,15 @@ type BtcWallet struct {
 	netParams *chaincfg.Params
 
 	chainKeyScope waddrmgr.KeyScope
+
+	// rescanActive is set while a rescan initiated through Rescan is in
+	// progress, as only a single one is allowed at a time.
+	rescanActive int32 // To be used atomically.
+
+	// frozenMtx serializes the coin selections that temporarily lock the
+	// frozen outputs, so one of them can't unlock the frozen outputs
+	// while another one is still selecting coins.
+	frozenMtx sync.Mutex
 }
 
 // A compile time check to ensure that BtcWallet implements the
@@ -98,7 +122,7 @@ func New(cfg Config) (*BtcWallet, error) {
 		}
 		loader := base.NewLoader(
 			cfg.NetParams, netDir, cfg.NoFreelistSync,
-			cfg.RecoveryWindow,
+			kvdb.DefaultDBTimeout, cfg.RecoveryWindow,
 		)
 		walletExists, err := loader.WalletExists()
 		if err != nil {
@@ -216,13 +240,18 @@ func (b *BtcWallet) Stop() error {
 // ConfirmedBalance returns the sum of all the wallet's unspent outputs that
 // have at least confs confirmations. If confs is set to zero, then all unspent
 // outputs, including those currently in the mempool will be included in the
-// final sum.
+// final sum. If accountFilter is not empty, only the outputs of that account
+// are included.
 //
 // This is a part of the WalletController interface.
-func (b *BtcWallet) ConfirmedBalance(confs int32) (btcutil.Amount, error) {
+func (b *BtcWallet) ConfirmedBalance(confs int32,
+	accountFilter string) (btcutil.Amount, error) {
+
 	var balance btcutil.Amount
 
-	witnessOutputs, err := b.ListUnspentWitness(confs, math.MaxInt32)
+	witnessOutputs, err := b.ListUnspentWitness(
+		confs, math.MaxInt32, accountFilter,
+	)
 	if err != nil {
 		return 0, err
 	}
@@ -234,13 +263,33 @@ func (b *BtcWallet) ConfirmedBalance(confs int32) (btcutil.Amount, error) {
 	return balance, nil
 }
 
+// accountNumber returns the number of the account with the given name within
+// the key scope. An empty name refers to the default account.
+func (b *BtcWallet) accountNumber(scope waddrmgr.KeyScope,
+	name string) (uint32, error) {
+
+	if name == "" || name == lnwallet.DefaultAccountName {
+		return defaultAccount, nil
+	}
+
+	account, err := b.wallet.AccountNumber(scope, name)
+	if err != nil {
+		return 0, fmt.Errorf("unknown account %v: %v", name, err)
+	}
+
+	return account, nil
+}
+
 // NewAddress returns the next external or internal address for the wallet
 // dictated by the value of the `change` parameter. If change is true, then an
 // internal address will be returned, otherwise an external address should be
-// returned.
+// returned. The address is derived from the account with the given name, or
+// from the default account if the name is empty.
 //
 // This is a part of the WalletController interface.
-func (b *BtcWallet) NewAddress(t lnwallet.AddressType, change bool) (btcutil.Address, error) {
+func (b *BtcWallet) NewAddress(t lnwallet.AddressType, change bool,
+	accountName string) (btcutil.Address, error) {
+
 	var keyScope waddrmgr.KeyScope
 
 	switch t {
@@ -252,11 +301,16 @@ func (b *BtcWallet) NewAddress(t lnwallet.AddressType, change bool) (btcutil.Add
 		return nil, fmt.Errorf("unknown address type")
 	}
 
+	account, err := b.accountNumber(keyScope, accountName)
+	if err != nil {
+		return nil, err
+	}
+
 	if change {
-		return b.wallet.NewChangeAddress(defaultAccount, keyScope)
+		return b.wallet.NewChangeAddress(account, keyScope)
 	}
 
-	return b.wallet.NewAddress(defaultAccount, keyScope)
+	return b.wallet.NewAddress(account, keyScope)
 }
 
 // LastUnusedAddress returns the last *unused* address known by the wallet. An
@@ -292,13 +346,16 @@ func (b *BtcWallet) IsOurAddress(a btcutil.Address) bool {
 
 // SendOutputs funds, signs, and broadcasts a Bitcoin transaction paying out to
 // the specified outputs. In the case the wallet has insufficient funds, or the
-// outputs are non-standard, a non-nil error will be returned.
+// outputs are non-standard, a non-nil error will be returned. Only the outputs
+// of the given account are spent, or those of the default account if the name
+// is empty.
 //
 // NOTE: This method requires the global coin selection lock to be held.
 //
 // This is a part of the WalletController interface.
 func (b *BtcWallet) SendOutputs(outputs []*wire.TxOut,
-	feeRate chainfee.SatPerKWeight, minconf int32, label string) (*wire.MsgTx, error) {
+	feeRate chainfee.SatPerKWeight, minconf int32, label,
+	accountName string) (*wire.MsgTx, error) {
 
 	// Convert our fee rate from sat/kw to sat/kb since it's required by
 	// SendOutputs.
@@ -314,9 +371,29 @@ func (b *BtcWallet) SendOutputs(outputs []*wire.TxOut,
 		return nil, lnwallet.ErrInvalidMinconf
 	}
 
-	return b.wallet.SendOutputs(
-		outputs, defaultAccount, minconf, feeSatPerKB, label,
-	)
+	// The account has the same number in all of our key scopes, so we can
+	// look it up in any of them.
+	account, err := b.accountNumber(waddrmgr.KeyScopeBIP0084, accountName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Without a key scope, the outputs of the account are selected from
+	// all of our key scopes. Frozen outputs are never selected.
+	var tx *wire.MsgTx
+	err = b.withFrozenOutputsLocked(func() error {
+		var err error
+		tx, err = b.wallet.SendOutputs(
+			outputs, nil, account, minconf, feeSatPerKB,
+			base.CoinSelectionLargest, label,
+		)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tx, nil
 }
 
 // CreateSimpleTx creates a Bitcoin transaction paying to the specified
@@ -357,7 +434,20 @@ func (b *BtcWallet) CreateSimpleTx(outputs []*wire.TxOut,
 		}
 	}
 
-	return b.wallet.CreateSimpleTx(defaultAccount, outputs, 1, feeSatPerKB, dryRun)
+	var tx *txauthor.AuthoredTx
+	err := b.withFrozenOutputsLocked(func() error {
+		var err error
+		tx, err = b.wallet.CreateSimpleTx(
+			nil, defaultAccount, outputs, 1, feeSatPerKB,
+			base.CoinSelectionLargest, dryRun,
+		)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tx, nil
 }
 
 // LockOutpoint marks an outpoint as locked meaning it will no longer be deemed
@@ -382,19 +472,20 @@ func (b *BtcWallet) UnlockOutpoint(o wire.OutPoint) {
 	b.wallet.UnlockOutpoint(o)
 }
 
-// LeaseOutput locks an output to the given ID, preventing it from being
-// available for any future coin selection attempts. The absolute time of the
-// lock's expiration is returned. The expiration of the lock can be extended by
-// successive invocations of this call. Outputs can be unlocked before their
-// expiration through `ReleaseOutput`.
+// LeaseOutput locks an output to the given ID for the given duration,
+// preventing it from being available for any future coin selection attempts.
+// A zero duration leases the output for DefaultLockDuration. The
+// absolute time of the lock's expiration is returned. The expiration of the
+// lock can be changed by successive invocations of this call. Outputs can be
+// unlocked before their expiration through `ReleaseOutput`.
 //
 // If the output is not known, wtxmgr.ErrUnknownOutput is returned. If the
 // output has already been locked to a different ID, then
 // wtxmgr.ErrOutputAlreadyLocked is returned.
 //
 // NOTE: This method requires the global coin selection lock to be held.
-func (b *BtcWallet) LeaseOutput(id wtxmgr.LockID, op wire.OutPoint) (time.Time,
-	error) {
+func (b *BtcWallet) LeaseOutput(id wtxmgr.LockID, op wire.OutPoint,
+	duration time.Duration) (time.Time, error) {
 
 	// Make sure we don't attempt to double lock an output that's been
 	// locked by the in-memory implementation.
@@ -402,7 +493,11 @@ func (b *BtcWallet) LeaseOutput(id wtxmgr.LockID, op wire.OutPoint) (time.Time,
 		return time.Time{}, wtxmgr.ErrOutputAlreadyLocked
 	}
 
-	return b.wallet.LeaseOutput(id, op)
+	if duration == 0 {
+		duration = DefaultLockDuration
+	}
+
+	return b.wallet.LeaseOutput(id, op, duration)
 }
 
 // ReleaseOutput unlocks an output, allowing it to be available for coin
@@ -414,16 +509,51 @@ func (b *BtcWallet) ReleaseOutput(id wtxmgr.LockID, op wire.OutPoint) error {
 	return b.wallet.ReleaseOutput(id, op)
 }
 
+// ListLeasedOutputs returns all outputs that are currently leased, ordered by
+// their expiration.
+//
+// This is a part of the WalletController interface.
+func (b *BtcWallet) ListLeasedOutputs() ([]*lnwallet.LeasedOutput, error) {
+	outputs, err := b.wallet.ListLeasedOutputs()
+	if err != nil {
+		return nil, err
+	}
+
+	leased := make([]*lnwallet.LeasedOutput, 0, len(outputs))
+	for _, output := range outputs {
+		leased = append(leased, &lnwallet.LeasedOutput{
+			LockID:     output.LockID,
+			Outpoint:   output.Outpoint,
+			Expiration: output.Expiration,
+		})
+	}
+
+	sort.Slice(leased, func(i, j int) bool {
+		return leased[i].Expiration.Before(leased[j].Expiration)
+	})
+
+	return leased, nil
+}
+
 // ListUnspentWitness returns a slice of all the unspent outputs the wallet
-// controls which pay to witness programs either directly or indirectly.
+// controls which pay to witness programs either directly or indirectly. If
+// accountFilter is not empty, only the outputs of that account are returned.
 //
 // NOTE: This method requires the global coin selection lock to be held.
 //
 // This is a part of the WalletController interface.
-func (b *BtcWallet) ListUnspentWitness(minConfs, maxConfs int32) (
-	[]*lnwallet.Utxo, error) {
+func (b *BtcWallet) ListUnspentWitness(minConfs, maxConfs int32,
+	accountFilter string) ([]*lnwallet.Utxo, error) {
+
 	// First, grab all the unfiltered currently unspent outputs.
-	unspentOutputs, err := b.wallet.ListUnspent(minConfs, maxConfs, nil)
+	unspentOutputs, err := b.wallet.ListUnspent(minConfs, maxConfs, "")
+	if err != nil {
+		return nil, err
+	}
+
+	// We'll also fetch the metadata the user attached to any of our
+	// outputs, so we can return it alongside each output.
+	meta, err := fetchUtxoMeta(b.db)
 	if err != nil {
 		return nil, err
 	}
@@ -432,6 +562,10 @@ func (b *BtcWallet) ListUnspentWitness(minConfs, maxConfs int32) (
 	// which are p2wkh outputs or a p2wsh output nested within a p2sh output.
 	witnessOutputs := make([]*lnwallet.Utxo, 0, len(unspentOutputs))
 	for _, output := range unspentOutputs {
+		if accountFilter != "" && output.Account != accountFilter {
+			continue
+		}
+
 		pkScript, err := hex.DecodeString(output.ScriptPubKey)
 		if err != nil {
 			return nil, err
@@ -471,7 +605,11 @@ func (b *BtcWallet) ListUnspentWitness(minConfs, maxConfs int32) (
 					Index: output.Vout,
 				},
 				Confirmations: output.Confirmations,
+				Account:       output.Account,
 			}
+			utxo.Label = meta.labels[utxo.OutPoint]
+			_, utxo.Frozen = meta.frozen[utxo.OutPoint]
+
 			witnessOutputs = append(witnessOutputs, utxo)
 		}
 
@@ -480,11 +618,64 @@ func (b *BtcWallet) ListUnspentWitness(minConfs, maxConfs int32) (
 	return witnessOutputs, nil
 }
 
+// ListAccounts returns the properties of all accounts of the wallet.
+//
+// This is a part of the WalletController interface.
+func (b *BtcWallet) ListAccounts() ([]*waddrmgr.AccountProperties, error) {
+	// As accounts are created in all of our key scopes at once, the
+	// accounts of the first scope are the accounts of the wallet.
+	accounts, err := b.wallet.Accounts(accountScopes[0])
+	if err != nil {
+		return nil, err
+	}
+
+	props := make([]*waddrmgr.AccountProperties, 0, len(accounts.Accounts))
+	for _, account := range accounts.Accounts {
+		account := account
+		props = append(props, &account.AccountProperties)
+	}
+
+	return props, nil
+}
+
+// CreateAccount derives a new account with the given name from the wallet's
+// seed and returns its properties. The account is created in all of our key
+// scopes, so that it can hold both p2wkh and np2wkh outputs.
+//
+// This is a part of the WalletController interface.
+func (b *BtcWallet) CreateAccount(name string) (*waddrmgr.AccountProperties,
+	error) {
+
+	if name == "" {
+		return nil, fmt.Errorf("account name must not be empty")
+	}
+
+	var number uint32
+	for i, scope := range accountScopes {
+		account, err := b.wallet.NextAccount(scope, name)
+		if err != nil {
+			return nil, err
+		}
+
+		// Coin selection only knows about account numbers, so an
+		// account must have the same number in all of our scopes.
+		if i > 0 && account != number {
+			return nil, fmt.Errorf("account %v has number %v in "+
+				"scope %v, expected %v", name, account, scope,
+				number)
+		}
+		number = account
+	}
+
+	return b.wallet.AccountProperties(accountScopes[0], number)
+}
+
 // PublishTransaction performs cursory validation (dust checks, etc), then
 // finally broadcasts the passed transaction to the Bitcoin network. If
 // publishing the transaction fails, an error describing the reason is returned
-// (currently ErrDoubleSpend). If the transaction is already published to the
-// network (either in the mempool or chain) no error will be returned.
+// (currently ErrDoubleSpend or ErrMempoolFee). If the transaction is already
+// published to the network (either in the mempool or chain) no error will be
+// returned.
 func (b *BtcWallet) PublishTransaction(tx *wire.MsgTx, label string) error {
 	if err := b.wallet.PublishTransaction(tx, label); err != nil {
 
@@ -504,12 +695,45 @@ func (b *BtcWallet) PublishTransaction(tx *wire.MsgTx, label string) error {
 			return lnwallet.ErrDoubleSpend
 
 		default:
+			if isMempoolFeeErr(err) {
+				return lnwallet.ErrMempoolFee
+			}
+
 			return err
 		}
 	}
 	return nil
 }
 
+// mempoolFeeErrs are the substrings of the errors returned by the different
+// backends when rejecting a transaction that doesn't pay enough fees to be
+// accepted into their mempool.
+var mempoolFeeErrs = []string{
+	// bitcoind's rejection reason for transactions below the minimum
+	// relay fee.
+	"min relay fee not met",
+
+	// bitcoind's rejection reason for transactions below the dynamic
+	// minimum fee of a full mempool.
+	"mempool min fee not met",
+
+	// btcd's rejection reason for transactions below the minimum relay
+	// fee.
+	"under the required amount",
+}
+
+// isMempoolFeeErr returns true if the passed error returned by the backend
+// signals the rejection of a transaction due to insufficient fees.
+func isMempoolFeeErr(err error) bool {
+	for _, feeErr := range mempoolFeeErrs {
+		if strings.Contains(err.Error(), feeErr) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // LabelTransaction adds a label to a transaction. If the tx already
 // has a label, this call will fail unless the overwrite parameter
 // is set. Labels must not be empty, and they are limited to 500 chars.
@@ -658,7 +882,7 @@ func (b *BtcWallet) ListTransactionDetails(startHeight,
 	// We'll attempt to find all transactions from start to end height.
 	start := base.NewBlockIdentifierFromHeight(startHeight)
 	stop := base.NewBlockIdentifierFromHeight(endHeight)
-	txns, err := b.wallet.GetTransactions(start, stop, nil)
+	txns, err := b.wallet.GetTransactions(start, stop, "", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -696,6 +920,8 @@ func (b *BtcWallet) ListTransactionDetails(startHeight,
 // specified fee rate. If there is change left, a change output from the
 // internal wallet is added and the index of the change output is returned.
 // Otherwise no additional output is created and the index -1 is returned.
+// Coins are selected from, and change is sent to, the account with the given
+// name, or the default account if the name is empty.
 //
 // NOTE: If the packet doesn't contain any inputs, coin selection is
 // performed automatically. If the packet does contain any inputs, it is
@@ -708,15 +934,37 @@ func (b *BtcWallet) ListTransactionDetails(startHeight,
 //
 // This is a part of the WalletController interface.
 func (b *BtcWallet) FundPsbt(packet *psbt.Packet,
-	feeRate chainfee.SatPerKWeight) (int32, error) {
+	feeRate chainfee.SatPerKWeight, accountName string) (int32, error) {
+
+	// Accounts have the same number in all of our key scopes, so we can
+	// look it up in any of them.
+	account, err := b.accountNumber(waddrmgr.KeyScopeBIP0084, accountName)
+	if err != nil {
+		return 0, err
+	}
 
 	// The fee rate is passed in using units of sat/kw, so we'll convert
 	// this to sat/KB as the CreateSimpleTx method requires this unit.
 	feeSatPerKB := btcutil.Amount(feeRate.FeePerKVByte())
 
 	// Let the wallet handle coin selection and/or fee estimation based on
-	// the partial TX information in the packet.
-	return b.wallet.FundPsbt(packet, defaultAccount, feeSatPerKB)
+	// the partial TX information in the packet. Without a key scope, the
+	// inputs of the account are selected from all of our key scopes.
+	// Frozen outputs are never selected.
+	var changeIndex int32
+	err = b.withFrozenOutputsLocked(func() error {
+		var err error
+		changeIndex, err = b.wallet.FundPsbt(
+			packet, nil, account, feeSatPerKB,
+			base.CoinSelectionLargest,
+		)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return changeIndex, nil
 }
 
 // FinalizePsbt expects a partial transaction with all inputs and
@@ -733,7 +981,7 @@ func (b *BtcWallet) FundPsbt(packet *psbt.Packet,
 //
 // This is a part of the WalletController interface.
 func (b *BtcWallet) FinalizePsbt(packet *psbt.Packet) error {
-	return b.wallet.FinalizePsbt(packet)
+	return b.wallet.FinalizePsbt(nil, defaultAccount, packet)
 }
 
 // txSubscriptionClient encapsulates the transaction notification client from
@@ -0,0 +1,227 @@
+package btcwallet
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/btcsuite/btcwallet/chain"
+	"github.com/btcsuite/btcwallet/waddrmgr"
+	base "github.com/btcsuite/btcwallet/wallet"
+	"github.com/btcsuite/btcwallet/walletdb"
+	"github.com/btcsuite/btcwallet/wtxmgr"
+	"github.com/cryptomeow/lnd/lnwallet"
+)
+
+// rescanBatchSize is the number of blocks that are filtered at once during a
+// rescan. Progress is reported and cancellation is checked after each batch.
+const rescanBatchSize = 100
+
+// wtxmgrNamespaceKey is the namespace key that the wtxmgr state is stored
+// within the top-level walletdb buckets of btcwallet.
+var wtxmgrNamespaceKey = []byte("wtxmgr")
+
+// Rescan scans the chain for transactions relevant to the wallet, starting at
+// the given height. The blocks are filtered in batches for the wallet's active
+// addresses and unspent outputs, which allows the scan to report its progress
+// and to be canceled in between batches. Once all blocks were scanned, the
+// wallet rescans the chain from the first block that contains a relevant
+// transaction, adding all of them to the wallet. That last step can't be
+// canceled.
+//
+// This is a part of the WalletController interface.
+func (b *BtcWallet) Rescan(startHeight int32,
+	progress func(*lnwallet.RescanProgress), cancel <-chan struct{}) error {
+
+	if !atomic.CompareAndSwapInt32(&b.rescanActive, 0, 1) {
+		return fmt.Errorf("wallet rescan already in progress")
+	}
+	defer atomic.StoreInt32(&b.rescanActive, 0)
+
+	addrs, outPoints, err := b.rescanTargets()
+	if err != nil {
+		return err
+	}
+
+	// The indexes of the addresses are only used to identify the
+	// addresses that were found, which we don't need, so all addresses
+	// are passed as external ones with a made up index.
+	req := &chain.FilterBlocksRequest{
+		ExternalAddrs:    make(map[waddrmgr.ScopedIndex]btcutil.Address),
+		InternalAddrs:    make(map[waddrmgr.ScopedIndex]btcutil.Address),
+		WatchedOutPoints: outPoints,
+	}
+	for i, addr := range addrs {
+		req.ExternalAddrs[waddrmgr.ScopedIndex{
+			Scope: b.chainKeyScope,
+			Index: uint32(i),
+		}] = addr
+	}
+
+	status, err := b.scanBlocks(startHeight, req, progress, cancel)
+	if err != nil {
+		return err
+	}
+
+	// If any relevant transactions were found, we'll let the wallet rescan
+	// the chain from the block before the first one containing them, so
+	// they're added to the wallet.
+	if status.FirstRelevantHeight != 0 {
+		stampHeight := status.FirstRelevantHeight - 1
+		stampHash, err := b.chain.GetBlockHash(int64(stampHeight))
+		if err != nil {
+			return err
+		}
+
+		job := &base.RescanJob{
+			Addrs:     addrs,
+			OutPoints: outPoints,
+			BlockStamp: waddrmgr.BlockStamp{
+				Height: stampHeight,
+				Hash:   *stampHash,
+			},
+		}
+		if err := <-b.wallet.SubmitRescan(job); err != nil {
+			return err
+		}
+	}
+
+	status.Done = true
+	progress(status)
+
+	return nil
+}
+
+// scanBlocks filters the blocks from the given height up to the chain tip for
+// transactions relevant to the request, in batches of rescanBatchSize blocks.
+// The progress is reported after each batch, and the scan is aborted with
+// ErrRescanCanceled if the cancel channel is closed in between batches.
+func (b *BtcWallet) scanBlocks(startHeight int32,
+	req *chain.FilterBlocksRequest, progress func(*lnwallet.RescanProgress),
+	cancel <-chan struct{}) (*lnwallet.RescanProgress, error) {
+
+	_, tipHeight, err := b.chain.GetBestBlock()
+	if err != nil {
+		return nil, err
+	}
+	if startHeight < 0 || startHeight > tipHeight {
+		return nil, fmt.Errorf("start height %v must be between 0 and "+
+			"the chain tip at height %v", startHeight, tipHeight)
+	}
+
+	status := &lnwallet.RescanProgress{
+		StartHeight: startHeight,
+		Height:      startHeight - 1,
+		TipHeight:   tipHeight,
+	}
+	for status.Height < tipHeight {
+		select {
+		case <-cancel:
+			return nil, lnwallet.ErrRescanCanceled
+		default:
+		}
+
+		batchStart := status.Height + 1
+		batchEnd := batchStart + rescanBatchSize - 1
+		if batchEnd > tipHeight {
+			batchEnd = tipHeight
+		}
+
+		firstRelevant, err := b.filterBlocks(req, batchStart, batchEnd)
+		if err != nil {
+			return nil, err
+		}
+		if status.FirstRelevantHeight == 0 && firstRelevant != 0 {
+			status.FirstRelevantHeight = firstRelevant
+		}
+
+		status.Height = batchEnd
+		progress(status)
+	}
+
+	return status, nil
+}
+
+// filterBlocks filters the blocks between the given heights for transactions
+// relevant to the request. The height of the first block that contains a
+// relevant transaction is returned, or zero if there is none.
+func (b *BtcWallet) filterBlocks(req *chain.FilterBlocksRequest,
+	startHeight, endHeight int32) (int32, error) {
+
+	blocks := make([]wtxmgr.BlockMeta, 0, endHeight-startHeight+1)
+	for height := startHeight; height <= endHeight; height++ {
+		hash, err := b.chain.GetBlockHash(int64(height))
+		if err != nil {
+			return 0, err
+		}
+
+		blocks = append(blocks, wtxmgr.BlockMeta{
+			Block: wtxmgr.Block{
+				Hash:   *hash,
+				Height: height,
+			},
+		})
+	}
+
+	req.Blocks = blocks
+	resp, err := b.chain.FilterBlocks(req)
+	if err != nil {
+		return 0, err
+	}
+	if resp == nil {
+		return 0, nil
+	}
+
+	return resp.BlockMeta.Height, nil
+}
+
+// rescanTargets returns all active addresses of the wallet and the unspent
+// outputs paying to them, which a rescan needs to watch for.
+func (b *BtcWallet) rescanTargets() ([]btcutil.Address,
+	map[wire.OutPoint]btcutil.Address, error) {
+
+	var (
+		addrs     []btcutil.Address
+		outPoints = make(map[wire.OutPoint]btcutil.Address)
+	)
+	err := walletdb.View(b.db, func(tx walletdb.ReadTx) error {
+		addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+		err := b.wallet.Manager.ForEachActiveAddress(
+			addrmgrNs, func(addr btcutil.Address) error {
+				addrs = append(addrs, addr)
+				return nil
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+		credits, err := b.wallet.TxStore.UnspentOutputs(txmgrNs)
+		if err != nil {
+			return err
+		}
+		for _, credit := range credits {
+			_, outputAddrs, _, err := txscript.ExtractPkScriptAddrs(
+				credit.PkScript, b.netParams,
+			)
+			if err != nil {
+				return err
+			}
+			if len(outputAddrs) == 0 {
+				continue
+			}
+
+			outPoints[credit.OutPoint] = outputAddrs[0]
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return addrs, outPoints, nil
+}
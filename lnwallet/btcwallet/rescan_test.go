@@ -0,0 +1,201 @@
+package btcwallet
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcwallet/chain"
+	"github.com/btcsuite/btcwallet/wtxmgr"
+	"github.com/cryptomeow/lnd/lnwallet"
+	"github.com/stretchr/testify/require"
+)
+
+// mockChain is a chain backend that knows the hashes of all blocks up to its
+// tip, and finds relevant transactions in the blocks at the given heights.
+type mockChain struct {
+	chain.Interface
+
+	tipHeight int32
+
+	// relevantHeights are the heights of the blocks that contain
+	// transactions relevant to the wallet.
+	relevantHeights []int32
+
+	// filterErr is returned by FilterBlocks if set.
+	filterErr error
+
+	// batches are the heights of the first and last block of each batch
+	// of blocks that was filtered.
+	batches [][2]int32
+}
+
+func (m *mockChain) GetBestBlock() (*chainhash.Hash, int32, error) {
+	hash, err := m.GetBlockHash(int64(m.tipHeight))
+	return hash, m.tipHeight, err
+}
+
+func (m *mockChain) GetBlockHash(height int64) (*chainhash.Hash, error) {
+	if height > int64(m.tipHeight) {
+		return nil, errors.New("unknown block")
+	}
+
+	return &chainhash.Hash{byte(height), byte(height >> 8)}, nil
+}
+
+func (m *mockChain) FilterBlocks(
+	req *chain.FilterBlocksRequest) (*chain.FilterBlocksResponse, error) {
+
+	if m.filterErr != nil {
+		return nil, m.filterErr
+	}
+
+	first := req.Blocks[0]
+	last := req.Blocks[len(req.Blocks)-1]
+	m.batches = append(m.batches, [2]int32{
+		first.Height, last.Height,
+	})
+
+	for idx, block := range req.Blocks {
+		hash, err := m.GetBlockHash(int64(block.Height))
+		if err != nil {
+			return nil, err
+		}
+		if *hash != block.Hash {
+			return nil, errors.New("wrong block hash")
+		}
+
+		for _, height := range m.relevantHeights {
+			if height != block.Height {
+				continue
+			}
+
+			return &chain.FilterBlocksResponse{
+				BatchIndex: uint32(idx),
+				BlockMeta: wtxmgr.BlockMeta{
+					Block: block.Block,
+				},
+			}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// TestScanBlocks asserts that blocks are scanned in batches up to the chain
+// tip, that the progress is reported after each batch, and that the first
+// block containing relevant transactions is found.
+func TestScanBlocks(t *testing.T) {
+	t.Parallel()
+
+	backend := &mockChain{
+		tipHeight:       250,
+		relevantHeights: []int32{150, 230},
+	}
+	b := &BtcWallet{chain: backend}
+
+	var updates []lnwallet.RescanProgress
+	progress := func(p *lnwallet.RescanProgress) {
+		updates = append(updates, *p)
+	}
+
+	status, err := b.scanBlocks(
+		10, &chain.FilterBlocksRequest{}, progress,
+		make(chan struct{}),
+	)
+	require.NoError(t, err)
+
+	require.Equal(t, [][2]int32{
+		{10, 109}, {110, 209}, {210, 250},
+	}, backend.batches)
+
+	require.Equal(t, []lnwallet.RescanProgress{{
+		StartHeight: 10,
+		Height:      109,
+		TipHeight:   250,
+	}, {
+		StartHeight:         10,
+		Height:              209,
+		TipHeight:           250,
+		FirstRelevantHeight: 150,
+	}, {
+		StartHeight:         10,
+		Height:              250,
+		TipHeight:           250,
+		FirstRelevantHeight: 150,
+	}}, updates)
+
+	require.Equal(t, &updates[2], status)
+	require.Equal(t, float64(100), status.Percent())
+	require.False(t, status.Done)
+
+	// Scanning just the tip takes a single batch.
+	backend.batches = nil
+	status, err = b.scanBlocks(
+		250, &chain.FilterBlocksRequest{}, progress,
+		make(chan struct{}),
+	)
+	require.NoError(t, err)
+	require.Equal(t, [][2]int32{{250, 250}}, backend.batches)
+	require.Zero(t, status.FirstRelevantHeight)
+}
+
+// TestScanBlocksCancel asserts that a scan is aborted in between batches once
+// it is canceled.
+func TestScanBlocksCancel(t *testing.T) {
+	t.Parallel()
+
+	backend := &mockChain{tipHeight: 250}
+	b := &BtcWallet{chain: backend}
+
+	// Cancel the scan once the first batch was scanned.
+	var (
+		cancel  = make(chan struct{})
+		req     = &chain.FilterBlocksRequest{}
+		updates []lnwallet.RescanProgress
+	)
+	progress := func(p *lnwallet.RescanProgress) {
+		updates = append(updates, *p)
+		close(cancel)
+	}
+
+	_, err := b.scanBlocks(0, req, progress, cancel)
+	require.Equal(t, lnwallet.ErrRescanCanceled, err)
+	require.Equal(t, [][2]int32{{0, 99}}, backend.batches)
+	require.Len(t, updates, 1)
+	require.InDelta(t, 39.84, updates[0].Percent(), 0.01)
+
+	// A scan that is canceled before it starts doesn't scan anything.
+	backend.batches = nil
+	_, err = b.scanBlocks(0, req, progress, cancel)
+	require.Equal(t, lnwallet.ErrRescanCanceled, err)
+	require.Empty(t, backend.batches)
+}
+
+// TestScanBlocksErrors asserts that invalid start heights are rejected and
+// that errors of the chain backend abort the scan.
+func TestScanBlocksErrors(t *testing.T) {
+	t.Parallel()
+
+	backend := &mockChain{tipHeight: 250}
+	b := &BtcWallet{chain: backend}
+
+	progress := func(p *lnwallet.RescanProgress) {
+		t.Fatalf("unexpected progress: %v", p)
+	}
+
+	for _, height := range []int32{-1, 251} {
+		_, err := b.scanBlocks(
+			height, &chain.FilterBlocksRequest{}, progress,
+			make(chan struct{}),
+		)
+		require.Error(t, err)
+	}
+	require.Empty(t, backend.batches)
+
+	backend.filterErr = errors.New("filter error")
+	_, err := b.scanBlocks(
+		0, &chain.FilterBlocksRequest{}, progress, make(chan struct{}),
+	)
+	require.Equal(t, backend.filterErr, err)
+}
@@ -0,0 +1,274 @@
+package btcwallet
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcwallet/walletdb"
+	"github.com/btcsuite/btcwallet/wtxmgr"
+)
+
+var (
+	// utxoMetaNamespaceKey is the top-level bucket within the wallet's
+	// database that houses all lnd specific metadata about the outputs
+	// controlled by the wallet.
+	utxoMetaNamespaceKey = []byte("lnd-utxo-meta")
+
+	// utxoLabelBucketKey is the sub-bucket that maps an outpoint to the
+	// label the user assigned to it.
+	utxoLabelBucketKey = []byte("utxo-labels")
+
+	// utxoFrozenBucketKey is the sub-bucket that contains the set of
+	// outpoints that were frozen by the user. Frozen outputs are never
+	// selected as inputs by lnd's own coin selection.
+	utxoFrozenBucketKey = []byte("utxo-frozen")
+
+	// ErrEmptyOutputLabel is returned when an attempt is made to label an
+	// output with an empty label.
+	ErrEmptyOutputLabel = errors.New("cannot label output with empty label")
+
+	// ErrOutputLabelExists is returned when an attempt is made to label an
+	// output that already has a label without setting overwrite.
+	ErrOutputLabelExists = errors.New("output already has a label")
+)
+
+// utxoMeta houses the persisted metadata of all outputs known to the wallet.
+type utxoMeta struct {
+	labels map[wire.OutPoint]string
+	frozen map[wire.OutPoint]struct{}
+}
+
+// serializeOutPoint serializes an outpoint into a key suitable for the utxo
+// metadata buckets: the 32-byte txid followed by the big-endian output index.
+func serializeOutPoint(op wire.OutPoint) []byte {
+	var key [chainhash.HashSize + 4]byte
+	copy(key[:], op.Hash[:])
+	binary.BigEndian.PutUint32(key[chainhash.HashSize:], op.Index)
+
+	return key[:]
+}
+
+// deserializeOutPoint is the inverse of serializeOutPoint.
+func deserializeOutPoint(key []byte) (wire.OutPoint, error) {
+	var op wire.OutPoint
+	if len(key) != chainhash.HashSize+4 {
+		return op, fmt.Errorf("invalid outpoint key length: %v",
+			len(key))
+	}
+
+	copy(op.Hash[:], key[:chainhash.HashSize])
+	op.Index = binary.BigEndian.Uint32(key[chainhash.HashSize:])
+
+	return op, nil
+}
+
+// fetchUtxoMeta reads the metadata of all outputs from the wallet database.
+func fetchUtxoMeta(db walletdb.DB) (*utxoMeta, error) {
+	meta := &utxoMeta{
+		labels: make(map[wire.OutPoint]string),
+		frozen: make(map[wire.OutPoint]struct{}),
+	}
+
+	err := walletdb.View(db, func(tx walletdb.ReadTx) error {
+		ns := tx.ReadBucket(utxoMetaNamespaceKey)
+		if ns == nil {
+			return nil
+		}
+
+		if labels := ns.NestedReadBucket(utxoLabelBucketKey); labels != nil {
+			err := labels.ForEach(func(k, v []byte) error {
+				op, err := deserializeOutPoint(k)
+				if err != nil {
+					return err
+				}
+
+				meta.labels[op] = string(v)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		frozen := ns.NestedReadBucket(utxoFrozenBucketKey)
+		if frozen == nil {
+			return nil
+		}
+
+		return frozen.ForEach(func(k, _ []byte) error {
+			op, err := deserializeOutPoint(k)
+			if err != nil {
+				return err
+			}
+
+			meta.frozen[op] = struct{}{}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return meta, nil
+}
+
+// updateUtxoMeta executes the passed closure with the given sub-bucket of the
+// utxo metadata namespace, creating both if they don't exist yet.
+func updateUtxoMeta(db walletdb.DB, bucketKey []byte,
+	f func(walletdb.ReadWriteBucket) error) error {
+
+	return walletdb.Update(db, func(tx walletdb.ReadWriteTx) error {
+		ns, err := tx.CreateTopLevelBucket(utxoMetaNamespaceKey)
+		if err != nil {
+			return err
+		}
+
+		bucket, err := ns.CreateBucketIfNotExists(bucketKey)
+		if err != nil {
+			return err
+		}
+
+		return f(bucket)
+	})
+}
+
+// putOutputLabel stores the label of an output. If the output already has a
+// label, ErrOutputLabelExists is returned unless overwrite is set.
+func putOutputLabel(db walletdb.DB, op wire.OutPoint, label string,
+	overwrite bool) error {
+
+	key := serializeOutPoint(op)
+	return updateUtxoMeta(db, utxoLabelBucketKey,
+		func(labels walletdb.ReadWriteBucket) error {
+			if !overwrite && labels.Get(key) != nil {
+				return ErrOutputLabelExists
+			}
+
+			return labels.Put(key, []byte(label))
+		},
+	)
+}
+
+// setOutputFrozen adds an output to or removes it from the set of frozen
+// outputs.
+func setOutputFrozen(db walletdb.DB, op wire.OutPoint, frozen bool) error {
+	key := serializeOutPoint(op)
+	return updateUtxoMeta(db, utxoFrozenBucketKey,
+		func(bucket walletdb.ReadWriteBucket) error {
+			if !frozen {
+				return bucket.Delete(key)
+			}
+
+			return bucket.Put(key, nil)
+		},
+	)
+}
+
+// outpointLocker is the part of the wallet that is used to exclude outputs from
+// its coin selection.
+type outpointLocker interface {
+	// LockedOutpoint returns whether an outpoint is locked.
+	LockedOutpoint(op wire.OutPoint) bool
+
+	// LockOutpoint excludes an outpoint from coin selection.
+	LockOutpoint(op wire.OutPoint)
+
+	// UnlockOutpoint makes an outpoint eligible for coin selection again.
+	UnlockOutpoint(op wire.OutPoint)
+}
+
+// lockFrozenOutputs locks all frozen outputs, so they're skipped by the coin
+// selection of the wallet. Outputs that are already locked are left untouched.
+// The returned closure unlocks the outputs that were locked by this call.
+func lockFrozenOutputs(db walletdb.DB, locker outpointLocker) (func(),
+	error) {
+
+	meta, err := fetchUtxoMeta(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var locked []wire.OutPoint
+	for op := range meta.frozen {
+		if locker.LockedOutpoint(op) {
+			continue
+		}
+
+		locker.LockOutpoint(op)
+		locked = append(locked, op)
+	}
+
+	return func() {
+		for _, op := range locked {
+			locker.UnlockOutpoint(op)
+		}
+	}, nil
+}
+
+// withFrozenOutputsLocked executes the passed closure, which is expected to
+// select coins from the wallet, while all frozen outputs are locked. The
+// wallet's coin selection doesn't know about frozen outputs, so this makes
+// sure they're never selected.
+func (b *BtcWallet) withFrozenOutputsLocked(f func() error) error {
+	b.frozenMtx.Lock()
+	defer b.frozenMtx.Unlock()
+
+	unlock, err := lockFrozenOutputs(b.db, b.wallet)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return f()
+}
+
+// LabelOutput adds a label to an unspent output controlled by the wallet. If
+// the output already has a label, this call will fail unless the overwrite
+// parameter is set. Labels must not be empty, and they are limited to 500
+// chars.
+//
+// This is a part of the WalletController interface.
+func (b *BtcWallet) LabelOutput(op wire.OutPoint, label string,
+	overwrite bool) error {
+
+	switch {
+	case len(label) == 0:
+		return ErrEmptyOutputLabel
+
+	case len(label) > wtxmgr.TxLabelLimit:
+		return fmt.Errorf("label length: %v exceeds limit of %v",
+			len(label), wtxmgr.TxLabelLimit)
+	}
+
+	// Make sure we only ever store labels for outputs we actually know
+	// of.
+	if _, err := b.FetchInputInfo(&op); err != nil {
+		return err
+	}
+
+	return putOutputLabel(b.db, op, label, overwrite)
+}
+
+// FreezeOutput marks an unspent output controlled by the wallet as frozen.
+// Frozen outputs are persisted across restarts and are never selected as
+// inputs by lnd's own coin selection until they're unfrozen again.
+//
+// This is a part of the WalletController interface.
+func (b *BtcWallet) FreezeOutput(op wire.OutPoint) error {
+	if _, err := b.FetchInputInfo(&op); err != nil {
+		return err
+	}
+
+	return setOutputFrozen(b.db, op, true)
+}
+
+// UnfreezeOutput makes a previously frozen output eligible for coin selection
+// again. Unfreezing an output that isn't frozen is a no-op.
+//
+// This is a part of the WalletController interface.
+func (b *BtcWallet) UnfreezeOutput(op wire.OutPoint) error {
+	return setOutputFrozen(b.db, op, false)
+}
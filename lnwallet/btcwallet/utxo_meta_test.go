@@ -0,0 +1,164 @@
+package btcwallet
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcwallet/walletdb"
+	_ "github.com/btcsuite/btcwallet/walletdb/bdb"
+	"github.com/cryptomeow/lnd/channeldb/kvdb"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestWalletDB creates an empty wallet database, along with a function
+// that closes and removes it.
+func newTestWalletDB(t *testing.T) (walletdb.DB, func()) {
+	tempDir, err := ioutil.TempDir("", "btcwallet")
+	require.NoError(t, err)
+
+	db, err := walletdb.Create(
+		"bdb", filepath.Join(tempDir, "wallet.db"), true,
+		kvdb.DefaultDBTimeout,
+	)
+	if err != nil {
+		os.RemoveAll(tempDir)
+		t.Fatalf("unable to create db: %v", err)
+	}
+
+	return db, func() {
+		db.Close()
+		os.RemoveAll(tempDir)
+	}
+}
+
+// TestOutPointSerialization asserts that outpoints survive a round trip
+// through their serialization as utxo metadata keys.
+func TestOutPointSerialization(t *testing.T) {
+	t.Parallel()
+
+	op := wire.OutPoint{
+		Hash:  chainhash.Hash{1, 2, 3},
+		Index: 0x01020304,
+	}
+
+	key := serializeOutPoint(op)
+	require.Len(t, key, chainhash.HashSize+4)
+
+	decoded, err := deserializeOutPoint(key)
+	require.NoError(t, err)
+	require.Equal(t, op, decoded)
+
+	_, err = deserializeOutPoint(key[1:])
+	require.Error(t, err)
+}
+
+// TestUtxoMeta asserts that the labels and the frozen state of outputs are
+// persisted, and that labels are only overwritten if requested.
+func TestUtxoMeta(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp := newTestWalletDB(t)
+	defer cleanUp()
+
+	// The metadata namespace doesn't exist in a fresh database.
+	meta, err := fetchUtxoMeta(db)
+	require.NoError(t, err)
+	require.Empty(t, meta.labels)
+	require.Empty(t, meta.frozen)
+
+	op1 := wire.OutPoint{Hash: chainhash.Hash{1}, Index: 1}
+	op2 := wire.OutPoint{Hash: chainhash.Hash{2}, Index: 2}
+
+	require.NoError(t, putOutputLabel(db, op1, "first", false))
+	require.NoError(t, putOutputLabel(db, op2, "second", false))
+
+	// An existing label is only replaced if overwrite is set.
+	err = putOutputLabel(db, op1, "replaced", false)
+	require.Equal(t, ErrOutputLabelExists, err)
+	require.NoError(t, putOutputLabel(db, op2, "replaced", true))
+
+	require.NoError(t, setOutputFrozen(db, op1, true))
+	require.NoError(t, setOutputFrozen(db, op2, true))
+
+	meta, err = fetchUtxoMeta(db)
+	require.NoError(t, err)
+	require.Equal(t, map[wire.OutPoint]string{
+		op1: "first",
+		op2: "replaced",
+	}, meta.labels)
+	require.Equal(t, map[wire.OutPoint]struct{}{
+		op1: {},
+		op2: {},
+	}, meta.frozen)
+
+	// Unfreezing an output removes it from the frozen set, and
+	// unfreezing it again is a no-op.
+	require.NoError(t, setOutputFrozen(db, op2, false))
+	require.NoError(t, setOutputFrozen(db, op2, false))
+
+	meta, err = fetchUtxoMeta(db)
+	require.NoError(t, err)
+	require.Equal(t, map[wire.OutPoint]struct{}{
+		op1: {},
+	}, meta.frozen)
+	require.Len(t, meta.labels, 2)
+}
+
+// mockOutpointLocker is a mock implementation of the outpointLocker
+// interface.
+type mockOutpointLocker struct {
+	locked map[wire.OutPoint]struct{}
+}
+
+func (m *mockOutpointLocker) LockedOutpoint(op wire.OutPoint) bool {
+	_, ok := m.locked[op]
+	return ok
+}
+
+func (m *mockOutpointLocker) LockOutpoint(op wire.OutPoint) {
+	m.locked[op] = struct{}{}
+}
+
+func (m *mockOutpointLocker) UnlockOutpoint(op wire.OutPoint) {
+	delete(m.locked, op)
+}
+
+// TestLockFrozenOutputs asserts that all frozen outputs are locked for coin
+// selection, and that only the outputs locked by the call are unlocked again.
+func TestLockFrozenOutputs(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp := newTestWalletDB(t)
+	defer cleanUp()
+
+	op1 := wire.OutPoint{Hash: chainhash.Hash{1}, Index: 1}
+	op2 := wire.OutPoint{Hash: chainhash.Hash{2}, Index: 2}
+	op3 := wire.OutPoint{Hash: chainhash.Hash{3}, Index: 3}
+
+	require.NoError(t, setOutputFrozen(db, op1, true))
+	require.NoError(t, setOutputFrozen(db, op2, true))
+
+	// The second frozen output is already locked, for example because
+	// it is used to fund a channel.
+	locker := &mockOutpointLocker{
+		locked: map[wire.OutPoint]struct{}{
+			op2: {},
+		},
+	}
+
+	unlock, err := lockFrozenOutputs(db, locker)
+	require.NoError(t, err)
+	require.True(t, locker.LockedOutpoint(op1))
+	require.True(t, locker.LockedOutpoint(op2))
+	require.False(t, locker.LockedOutpoint(op3))
+
+	// Unlocking must leave the lock that existed before untouched.
+	unlock()
+	require.Equal(t, map[wire.OutPoint]struct{}{
+		op2: {},
+	}, locker.locked)
+}
@@ -0,0 +1,191 @@
+package chainfee
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+const (
+	// SparseConfEstimatorType is the name of the estimator driver that
+	// queries a web API returning fees in the SparseConfFeeSource format.
+	SparseConfEstimatorType = "sparseconf"
+
+	// MempoolEstimatorType is the name of the estimator driver that
+	// queries a web API returning fees in the MempoolFeeSource format.
+	MempoolEstimatorType = "mempool"
+)
+
+// EstimatorDriver represents a "driver" for a particular external fee
+// estimator. A driver is identified by a globally unique string identifier
+// along with a 'New()' method which is responsible for initializing a
+// particular Estimator concrete implementation.
+type EstimatorDriver struct {
+	// EstimatorType is a string which uniquely identifies the Estimator
+	// that this driver, drives.
+	EstimatorType string
+
+	// New creates a new instance of a concrete Estimator implementation
+	// given a variadic set up arguments. The function takes a variadic
+	// number of interface parameters in order to provide initialization
+	// flexibility, thereby accommodating several potential Estimator
+	// implementations.
+	New func(args ...interface{}) (Estimator, error)
+}
+
+var (
+	estimators  = make(map[string]*EstimatorDriver)
+	registerMtx sync.Mutex
+)
+
+// RegisteredEstimators returns a slice of all currently registered
+// estimators, sorted by their type.
+//
+// NOTE: This function is safe for concurrent access.
+func RegisteredEstimators() []*EstimatorDriver {
+	registerMtx.Lock()
+	defer registerMtx.Unlock()
+
+	drivers := make([]*EstimatorDriver, 0, len(estimators))
+	for _, driver := range estimators {
+		drivers = append(drivers, driver)
+	}
+	sort.Slice(drivers, func(i, j int) bool {
+		return drivers[i].EstimatorType < drivers[j].EstimatorType
+	})
+
+	return drivers
+}
+
+// RegisterEstimator registers an EstimatorDriver which is capable of driving
+// a concrete Estimator interface. In the case that this driver has already
+// been registered, an error is returned.
+//
+// NOTE: This function is safe for concurrent access.
+func RegisterEstimator(driver *EstimatorDriver) error {
+	registerMtx.Lock()
+	defer registerMtx.Unlock()
+
+	if _, ok := estimators[driver.EstimatorType]; ok {
+		return fmt.Errorf("estimator already registered")
+	}
+
+	estimators[driver.EstimatorType] = driver
+
+	return nil
+}
+
+// SupportedEstimators returns a slice of strings that represent the fee
+// estimator drivers that have been registered and are therefore supported,
+// in sorted order.
+//
+// NOTE: This function is safe for concurrent access.
+func SupportedEstimators() []string {
+	registerMtx.Lock()
+	defer registerMtx.Unlock()
+
+	supportedEstimators := make([]string, 0, len(estimators))
+	for driverName := range estimators {
+		supportedEstimators = append(supportedEstimators, driverName)
+	}
+	sort.Strings(supportedEstimators)
+
+	return supportedEstimators
+}
+
+// NewEstimator creates a new instance of the Estimator registered under the
+// given type, passing the variadic arguments through to its driver.
+//
+// NOTE: This function is safe for concurrent access.
+func NewEstimator(estimatorType string, args ...interface{}) (Estimator,
+	error) {
+
+	registerMtx.Lock()
+	driver, ok := estimators[estimatorType]
+	registerMtx.Unlock()
+
+	if !ok {
+		return nil, fmt.Errorf("unknown fee estimator: %v",
+			estimatorType)
+	}
+
+	return driver.New(args...)
+}
+
+// webAPIEstimatorArgs parses the arguments shared by all drivers backed by the
+// WebAPIEstimator: the URL of the API, whether fees should be queried on
+// every request instead of being cached, and the maximum sane fee rate.
+func webAPIEstimatorArgs(args ...interface{}) (string, bool, SatPerKWeight,
+	error) {
+
+	if len(args) != 3 {
+		return "", false, 0, fmt.Errorf("incorrect number of "+
+			"arguments to .New(...), expected 3, instead passed "+
+			"%v", len(args))
+	}
+
+	url, ok := args[0].(string)
+	if !ok {
+		return "", false, 0, errors.New("first argument to New is " +
+			"incorrect, expected a string")
+	}
+
+	noCache, ok := args[1].(bool)
+	if !ok {
+		return "", false, 0, errors.New("second argument to New is " +
+			"incorrect, expected a bool")
+	}
+
+	maxFeeRate, ok := args[2].(SatPerKWeight)
+	if !ok {
+		return "", false, 0, errors.New("third argument to New is " +
+			"incorrect, expected a SatPerKWeight")
+	}
+
+	return url, noCache, maxFeeRate, nil
+}
+
+// init registers the drivers for the web API based estimators that ship with
+// this package.
+func init() {
+	drivers := []*EstimatorDriver{
+		{
+			EstimatorType: SparseConfEstimatorType,
+			New: func(args ...interface{}) (Estimator, error) {
+				url, noCache, maxFeeRate, err :=
+					webAPIEstimatorArgs(args...)
+				if err != nil {
+					return nil, err
+				}
+
+				return NewWebAPIEstimator(
+					SparseConfFeeSource{URL: url}, noCache,
+					maxFeeRate,
+				), nil
+			},
+		},
+		{
+			EstimatorType: MempoolEstimatorType,
+			New: func(args ...interface{}) (Estimator, error) {
+				url, noCache, maxFeeRate, err :=
+					webAPIEstimatorArgs(args...)
+				if err != nil {
+					return nil, err
+				}
+
+				return NewWebAPIEstimator(
+					MempoolFeeSource{URL: url}, noCache,
+					maxFeeRate,
+				), nil
+			},
+		},
+	}
+
+	for _, driver := range drivers {
+		if err := RegisterEstimator(driver); err != nil {
+			panic(fmt.Sprintf("failed to register estimator "+
+				"driver '%s': %v", driver.EstimatorType, err))
+		}
+	}
+}
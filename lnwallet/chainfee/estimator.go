@@ -10,6 +10,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/btcsuite/btcd/btcjson"
 	"github.com/btcsuite/btcd/rpcclient"
 	"github.com/btcsuite/btcutil"
 )
@@ -267,10 +268,49 @@ func (b *BtcdEstimator) fetchEstimate(confTarget uint32) (SatPerKWeight, error)
 	return satPerKw, nil
 }
 
+// FetchMempoolInfo queries btcd for the current state of its mempool. As btcd
+// doesn't limit the size of its mempool, its fee floor is always the minimum
+// relay fee.
+//
+// NOTE: This method is part of the MempoolInfoSource interface.
+func (b *BtcdEstimator) FetchMempoolInfo() (*MempoolInfo, error) {
+	info, err := b.btcdConn.GetInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	relayFee, err := btcutil.NewAmount(info.RelayFee)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := b.btcdConn.RawRequest("getmempoolinfo", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var mempoolInfo btcjson.GetMempoolInfoResult
+	if err := json.Unmarshal(resp, &mempoolInfo); err != nil {
+		return nil, err
+	}
+
+	minRelayFeePerKw := SatPerKVByte(relayFee).FeePerKWeight()
+
+	return &MempoolInfo{
+		MinRelayFee:   minRelayFeePerKw,
+		MempoolMinFee: minRelayFeePerKw,
+		Usage:         mempoolInfo.Bytes,
+	}, nil
+}
+
 // A compile-time assertion to ensure that BtcdEstimator implements the
 // Estimator interface.
 var _ Estimator = (*BtcdEstimator)(nil)
 
+// A compile-time assertion to ensure that BtcdEstimator implements the
+// MempoolInfoSource interface.
+var _ MempoolInfoSource = (*BtcdEstimator)(nil)
+
 // BitcoindEstimator is an implementation of the Estimator interface backed by
 // the RPC interface of an active bitcoind node. This implementation will proxy
 // any fee estimation requests to bitcoind's RPC interface.
@@ -460,10 +500,52 @@ func (b *BitcoindEstimator) fetchEstimate(confTarget uint32) (SatPerKWeight, err
 	return satPerKw, nil
 }
 
+// FetchMempoolInfo queries bitcoind for the current state of its mempool.
+//
+// NOTE: This method is part of the MempoolInfoSource interface.
+func (b *BitcoindEstimator) FetchMempoolInfo() (*MempoolInfo, error) {
+	resp, err := b.bitcoindConn.RawRequest("getmempoolinfo", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse the response to retrieve the fees in BTC/KB and the mempool
+	// size in bytes.
+	info := struct {
+		Usage         int64   `json:"usage"`
+		MaxMempool    int64   `json:"maxmempool"`
+		MempoolMinFee float64 `json:"mempoolminfee"`
+		MinRelayTxFee float64 `json:"minrelaytxfee"`
+	}{}
+	if err := json.Unmarshal(resp, &info); err != nil {
+		return nil, err
+	}
+
+	minRelayFee, err := btcutil.NewAmount(info.MinRelayTxFee)
+	if err != nil {
+		return nil, err
+	}
+	mempoolMinFee, err := btcutil.NewAmount(info.MempoolMinFee)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MempoolInfo{
+		MinRelayFee:   SatPerKVByte(minRelayFee).FeePerKWeight(),
+		MempoolMinFee: SatPerKVByte(mempoolMinFee).FeePerKWeight(),
+		Usage:         info.Usage,
+		MaxMempool:    info.MaxMempool,
+	}, nil
+}
+
 // A compile-time assertion to ensure that BitcoindEstimator implements the
 // Estimator interface.
 var _ Estimator = (*BitcoindEstimator)(nil)
 
+// A compile-time assertion to ensure that BitcoindEstimator implements the
+// MempoolInfoSource interface.
+var _ MempoolInfoSource = (*BitcoindEstimator)(nil)
+
 // WebAPIFeeSource is an interface allows the WebAPIEstimator to query an
 // arbitrary HTTP-based fee estimator. Each new set/network will gain an
 // implementation of this interface in order to allow the WebAPIEstimator to
@@ -523,6 +605,67 @@ func (s SparseConfFeeSource) ParseResponse(r io.Reader) (map[uint32]uint32, erro
 // WebAPIFeeSource interface.
 var _ WebAPIFeeSource = (*SparseConfFeeSource)(nil)
 
+// MempoolFeeSource is an implementation of the WebAPIFeeSource that utilizes
+// a mempool based fee estimation API, such as a self-hosted mempool.space
+// instance. It expects the response to be in the JSON format returned by the
+// `/api/v1/fees/recommended` endpoint, with all fees expressed in sat/vbyte.
+type MempoolFeeSource struct {
+	// URL is the fee estimation API specified by the user.
+	URL string
+}
+
+// GenQueryURL generates the full query URL. The value returned by this
+// method should be able to be used directly as a path for an HTTP GET
+// request.
+//
+// NOTE: Part of the WebAPIFeeSource interface.
+func (s MempoolFeeSource) GenQueryURL() string {
+	return s.URL
+}
+
+// ParseResponse attempts to parse the body of the response generated by the
+// above query URL. The recommended fees are mapped to the block targets they
+// roughly correspond to, and converted to sat/kvbyte.
+//
+// NOTE: Part of the WebAPIFeeSource interface.
+func (s MempoolFeeSource) ParseResponse(r io.Reader) (map[uint32]uint32, error) {
+	type jsonResp struct {
+		FastestFee  uint32 `json:"fastestFee"`
+		HalfHourFee uint32 `json:"halfHourFee"`
+		HourFee     uint32 `json:"hourFee"`
+		EconomyFee  uint32 `json:"economyFee"`
+		MinimumFee  uint32 `json:"minimumFee"`
+	}
+
+	var resp jsonResp
+	jsonReader := json.NewDecoder(r)
+	if err := jsonReader.Decode(&resp); err != nil {
+		return nil, err
+	}
+
+	// Omit any fee the API didn't return, so the WebAPIEstimator will
+	// extrapolate it from the next lower target instead.
+	feeByBlockTarget := make(map[uint32]uint32)
+	addFee := func(target, satPerVByte uint32) {
+		if satPerVByte == 0 {
+			return
+		}
+
+		feeByBlockTarget[target] = satPerVByte * 1000
+	}
+	addFee(minBlockTarget, resp.FastestFee)
+	addFee(3, resp.HalfHourFee)
+	addFee(6, resp.HourFee)
+	addFee(144, resp.EconomyFee)
+	addFee(maxBlockTarget, resp.MinimumFee)
+
+	return feeByBlockTarget, nil
+}
+
+// A compile-time assertion to ensure that MempoolFeeSource implements the
+// WebAPIFeeSource interface.
+var _ WebAPIFeeSource = (*MempoolFeeSource)(nil)
+
 // WebAPIEstimator is an implementation of the Estimator interface that
 // queries an HTTP-based fee estimation from an existing web API.
 type WebAPIEstimator struct {
@@ -546,17 +689,28 @@ type WebAPIEstimator struct {
 	// estimates.
 	noCache bool
 
+	// maxFeePerKW is the highest fee rate the estimator will ever return.
+	// Any estimate above it is considered bogus and clamped to this value,
+	// which protects us from a misbehaving or compromised API. A value of
+	// zero disables the bound.
+	maxFeePerKW SatPerKWeight
+
 	quit chan struct{}
 	wg   sync.WaitGroup
 }
 
-// NewWebAPIEstimator creates a new WebAPIEstimator from a given URL and a
-// fallback default fee. The fees are updated whenever a new block is mined.
-func NewWebAPIEstimator(api WebAPIFeeSource, noCache bool) *WebAPIEstimator {
+// NewWebAPIEstimator creates a new WebAPIEstimator from a given fee source.
+// The fees are refreshed periodically, unless noCache is set in which case
+// the API is queried for every estimate. Estimates above maxFeePerKW are
+// clamped, a value of zero disables this bound.
+func NewWebAPIEstimator(api WebAPIFeeSource, noCache bool,
+	maxFeePerKW SatPerKWeight) *WebAPIEstimator {
+
 	return &WebAPIEstimator{
 		apiSource:        api,
 		feeByBlockTarget: make(map[uint32]uint32),
 		noCache:          noCache,
+		maxFeePerKW:      maxFeePerKW,
 		quit:             make(chan struct{}),
 	}
 }
@@ -590,6 +744,17 @@ func (w *WebAPIEstimator) EstimateFeePerKW(numBlocks uint32) (SatPerKWeight, err
 		satPerKw = FeePerKwFloor
 	}
 
+	// If the result exceeds our sanity bound, we'll clamp it as well, as
+	// we'd rather have a transaction confirm a bit later than pay an
+	// absurd fee because of a faulty API.
+	if w.maxFeePerKW != 0 && satPerKw > w.maxFeePerKW {
+		log.Warnf("Web API returned fee rate of %v sat/kw for conf "+
+			"target of %v, clamping to max fee rate of %v sat/kw",
+			int64(satPerKw), numBlocks, int64(w.maxFeePerKW))
+
+		satPerKw = w.maxFeePerKW
+	}
+
 	log.Debugf("Web API returning %v sat/kw for conf target of %v",
 		int64(satPerKw), numBlocks)
 
@@ -722,6 +887,15 @@ func (w *WebAPIEstimator) updateFeeEstimates() {
 		return
 	}
 
+	// Keep our current cache around if the API didn't return any fees at
+	// all, otherwise a single bad response would leave us without any
+	// estimates until the next update.
+	if len(feesByBlockTarget) == 0 {
+		log.Errorf("web api returned no fee estimates, keeping cached " +
+			"fees")
+		return
+	}
+
 	w.feesMtx.Lock()
 	w.feeByBlockTarget = feesByBlockTarget
 	w.feesMtx.Unlock()
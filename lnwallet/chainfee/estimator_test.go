@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"encoding/json"
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"strings"
 	"testing"
@@ -159,6 +161,83 @@ func TestSparseConfFeeSource(t *testing.T) {
 	}
 }
 
+// TestMempoolFeeSource checks that MempoolFeeSource parses API responses into
+// fees by block target as expected.
+func TestMempoolFeeSource(t *testing.T) {
+	t.Parallel()
+
+	feeSource := MempoolFeeSource{URL: "test"}
+
+	// The hour fee is omitted from the response, so it should be omitted
+	// from the parsed fees as well.
+	resp := `{"fastestFee": 50, "halfHourFee": 40, "economyFee": 5, ` +
+		`"minimumFee": 1}`
+	fees, err := feeSource.ParseResponse(strings.NewReader(resp))
+	if err != nil {
+		t.Fatalf("unable to parse API response: %v", err)
+	}
+
+	expectedFees := map[uint32]uint32{
+		2:    50000,
+		3:    40000,
+		144:  5000,
+		1009: 1000,
+	}
+	if !reflect.DeepEqual(fees, expectedFees) {
+		t.Fatalf("expected %v, got %v", expectedFees, fees)
+	}
+
+	// Test parsing an improperly formatted JSON API response.
+	_, err = feeSource.ParseResponse(strings.NewReader(`{"fastestFee": -1}`))
+	if err == nil {
+		t.Fatalf("expected ParseResponse to fail")
+	}
+}
+
+// TestWebAPIFeeEstimatorMaxFeeRate checks that the WebAPIEstimator clamps any
+// fee estimate exceeding its maximum fee rate.
+func TestWebAPIFeeEstimatorMaxFeeRate(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"fastestFee": 5000, ` +
+				`"economyFee": 10}`))
+		},
+	))
+	defer server.Close()
+
+	maxFeeRate := SatPerKVByte(100 * 1000).FeePerKWeight()
+	estimator, err := NewEstimator(
+		MempoolEstimatorType, server.URL, true, maxFeeRate,
+	)
+	if err != nil {
+		t.Fatalf("unable to create estimator: %v", err)
+	}
+
+	// The estimate for the next block exceeds the max fee rate, so it
+	// should be clamped.
+	feeRate, err := estimator.EstimateFeePerKW(2)
+	if err != nil {
+		t.Fatalf("unable to estimate fee: %v", err)
+	}
+	if feeRate != maxFeeRate {
+		t.Fatalf("expected fee rate %v, got %v", maxFeeRate, feeRate)
+	}
+
+	// The economy estimate is below the max fee rate and should be
+	// returned as is.
+	expectedFeeRate := SatPerKVByte(10 * 1000).FeePerKWeight()
+	feeRate, err = estimator.EstimateFeePerKW(200)
+	if err != nil {
+		t.Fatalf("unable to estimate fee: %v", err)
+	}
+	if feeRate != expectedFeeRate {
+		t.Fatalf("expected fee rate %v, got %v", expectedFeeRate,
+			feeRate)
+	}
+}
+
 // TestWebAPIFeeEstimator checks that the WebAPIFeeEstimator returns fee rates
 // as expected.
 func TestWebAPIFeeEstimator(t *testing.T) {
@@ -192,7 +271,7 @@ func TestWebAPIFeeEstimator(t *testing.T) {
 		fees: testFees,
 	}
 
-	estimator := NewWebAPIEstimator(feeSource, false)
+	estimator := NewWebAPIEstimator(feeSource, false, 0)
 
 	// Test that requesting a fee when no fees have been cached fails.
 	_, err := estimator.EstimateFeePerKW(5)
@@ -233,3 +312,30 @@ func TestWebAPIFeeEstimator(t *testing.T) {
 		})
 	}
 }
+
+// TestSupportedEstimators checks that the registered estimator drivers are
+// returned in a fixed order.
+func TestSupportedEstimators(t *testing.T) {
+	t.Parallel()
+
+	expected := []string{MempoolEstimatorType, SparseConfEstimatorType}
+	for i := 0; i < 10; i++ {
+		supported := SupportedEstimators()
+		if !reflect.DeepEqual(supported, expected) {
+			t.Fatalf("expected estimators %v, got %v", expected,
+				supported)
+		}
+	}
+
+	drivers := RegisteredEstimators()
+	if len(drivers) != len(expected) {
+		t.Fatalf("expected %v drivers, got %v", len(expected),
+			len(drivers))
+	}
+	for i, driver := range drivers {
+		if driver.EstimatorType != expected[i] {
+			t.Fatalf("expected driver %v at index %v, got %v",
+				expected[i], i, driver.EstimatorType)
+		}
+	}
+}
@@ -0,0 +1,288 @@
+package chainfee
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultMempoolPollInterval is the default interval in which the
+	// MempoolMonitor queries its backend for the current state of the
+	// mempool.
+	DefaultMempoolPollInterval = time.Minute
+)
+
+// MempoolInfo describes the state of the backend node's mempool at the time it
+// was queried.
+type MempoolInfo struct {
+	// MinRelayFee is the static minimum fee rate the backend requires for
+	// transactions to be relayed.
+	MinRelayFee SatPerKWeight
+
+	// MempoolMinFee is the dynamic minimum fee rate for transactions to be
+	// accepted into the backend's mempool. Once the mempool is full, this
+	// rises above MinRelayFee.
+	MempoolMinFee SatPerKWeight
+
+	// Usage is the current memory usage of the mempool in bytes.
+	Usage int64
+
+	// MaxMempool is the maximum memory usage of the mempool in bytes. A
+	// value of zero means the backend doesn't report a limit.
+	MaxMempool int64
+}
+
+// FeeFloor returns the lowest fee rate a transaction needs to pay in order to
+// be accepted into the backend's mempool.
+func (m *MempoolInfo) FeeFloor() SatPerKWeight {
+	floor := m.MinRelayFee
+	if m.MempoolMinFee > floor {
+		floor = m.MempoolMinFee
+	}
+
+	return floor
+}
+
+// Congestion returns the fraction of the maximum mempool size that is
+// currently in use. If the backend doesn't report a limit, zero is returned.
+func (m *MempoolInfo) Congestion() float64 {
+	if m.MaxMempool == 0 {
+		return 0
+	}
+
+	return float64(m.Usage) / float64(m.MaxMempool)
+}
+
+// MempoolInfoSource is implemented by fee estimators that are able to query
+// the state of their backend's mempool.
+type MempoolInfoSource interface {
+	// FetchMempoolInfo queries the backend for the current state of its
+	// mempool.
+	FetchMempoolInfo() (*MempoolInfo, error)
+}
+
+// MempoolMonitor is an Estimator that wraps another Estimator and keeps track
+// of the backend's current mempool fee floor. Fee estimates that fall below
+// the floor are bumped to it, which ensures transactions we create will
+// actually be accepted into the mempool, even if the mempool is congested
+// and the backend's static minimum relay fee isn't sufficient anymore.
+type MempoolMonitor struct {
+	started sync.Once
+	stopped sync.Once
+
+	// estimator is the wrapped Estimator we retrieve the fee estimates
+	// from.
+	estimator Estimator
+
+	// source is used to query the state of the backend's mempool. If it
+	// is an Estimator other than the wrapped one, it is started and
+	// stopped along with the monitor.
+	source MempoolInfoSource
+
+	// pollInterval is the interval in which we query the source.
+	pollInterval time.Duration
+
+	// info is the most recent mempool info returned by the source. It is
+	// nil until the first successful query.
+	infoMtx sync.RWMutex
+	info    *MempoolInfo
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewMempoolMonitor creates a new MempoolMonitor that wraps the given
+// estimator and polls the source for the state of the mempool in the given
+// interval. The source may be the wrapped estimator itself, or the estimator of
+// the backend if the fee estimates are retrieved from another service.
+func NewMempoolMonitor(estimator Estimator, source MempoolInfoSource,
+	pollInterval time.Duration) *MempoolMonitor {
+
+	return &MempoolMonitor{
+		estimator:    estimator,
+		source:       source,
+		pollInterval: pollInterval,
+		quit:         make(chan struct{}),
+	}
+}
+
+// Start signals the Estimator to start any processes or goroutines it needs
+// to perform its duty.
+//
+// NOTE: This method is part of the Estimator interface.
+func (m *MempoolMonitor) Start() error {
+	var err error
+	m.started.Do(func() {
+		log.Infof("Starting mempool monitor")
+
+		// The wrapped estimator needs to be started first, as it may
+		// be the one that establishes the connection to the backend.
+		if err = m.estimator.Start(); err != nil {
+			return
+		}
+		if source, ok := m.sourceEstimator(); ok {
+			if err = source.Start(); err != nil {
+				return
+			}
+		}
+
+		m.updateMempoolInfo()
+
+		m.wg.Add(1)
+		go m.pollMempool()
+	})
+
+	return err
+}
+
+// Stop stops any spawned goroutines and cleans up the resources used by the
+// fee estimator.
+//
+// NOTE: This method is part of the Estimator interface.
+func (m *MempoolMonitor) Stop() error {
+	var err error
+	m.stopped.Do(func() {
+		log.Infof("Stopping mempool monitor")
+
+		close(m.quit)
+		m.wg.Wait()
+
+		if source, ok := m.sourceEstimator(); ok {
+			if err = source.Stop(); err != nil {
+				return
+			}
+		}
+
+		err = m.estimator.Stop()
+	})
+
+	return err
+}
+
+// sourceEstimator returns the source as an Estimator if it is one, and isn't
+// the wrapped estimator. Such a source needs to be started and stopped by the
+// monitor, as nothing else does.
+func (m *MempoolMonitor) sourceEstimator() (Estimator, bool) {
+	source, ok := m.source.(Estimator)
+	if !ok || source == m.estimator {
+		return nil, false
+	}
+
+	return source, true
+}
+
+// EstimateFeePerKW takes in a target for the number of blocks until an initial
+// confirmation and returns the estimated fee expressed in sat/kw. If the
+// estimate of the wrapped Estimator is below the current mempool fee floor,
+// the floor is returned instead.
+//
+// NOTE: This method is part of the Estimator interface.
+func (m *MempoolMonitor) EstimateFeePerKW(numBlocks uint32) (SatPerKWeight,
+	error) {
+
+	feeRate, err := m.estimator.EstimateFeePerKW(numBlocks)
+	if err != nil {
+		return 0, err
+	}
+
+	floor := m.FeeFloor()
+	if feeRate < floor {
+		log.Debugf("Bumping fee estimate of %v sat/kw for conf "+
+			"target of %v to mempool fee floor of %v sat/kw",
+			int64(feeRate), numBlocks, int64(floor))
+
+		return floor, nil
+	}
+
+	return feeRate, nil
+}
+
+// RelayFeePerKW returns the minimum fee rate required for transactions to be
+// relayed.
+//
+// NOTE: This method is part of the Estimator interface.
+func (m *MempoolMonitor) RelayFeePerKW() SatPerKWeight {
+	relayFee := m.estimator.RelayFeePerKW()
+
+	m.infoMtx.RLock()
+	defer m.infoMtx.RUnlock()
+
+	if m.info != nil && m.info.MinRelayFee > relayFee {
+		return m.info.MinRelayFee
+	}
+
+	return relayFee
+}
+
+// FeeFloor returns the lowest fee rate a transaction currently needs to pay
+// to be accepted into the backend's mempool.
+func (m *MempoolMonitor) FeeFloor() SatPerKWeight {
+	floor := m.estimator.RelayFeePerKW()
+
+	m.infoMtx.RLock()
+	defer m.infoMtx.RUnlock()
+
+	if m.info != nil && m.info.FeeFloor() > floor {
+		return m.info.FeeFloor()
+	}
+
+	return floor
+}
+
+// Congestion returns the fraction of the backend's maximum mempool size that
+// was in use when the mempool was last polled.
+func (m *MempoolMonitor) Congestion() float64 {
+	m.infoMtx.RLock()
+	defer m.infoMtx.RUnlock()
+
+	if m.info == nil {
+		return 0
+	}
+
+	return m.info.Congestion()
+}
+
+// updateMempoolInfo queries the source for the current state of the mempool
+// and caches the result.
+func (m *MempoolMonitor) updateMempoolInfo() {
+	info, err := m.source.FetchMempoolInfo()
+	if err != nil {
+		log.Errorf("Unable to query mempool info: %v", err)
+		return
+	}
+
+	m.infoMtx.Lock()
+	prevInfo := m.info
+	m.info = info
+	m.infoMtx.Unlock()
+
+	if prevInfo == nil || prevInfo.FeeFloor() != info.FeeFloor() {
+		log.Infof("Mempool fee floor is now %v sat/kw, mempool "+
+			"congestion: %.2f%%", int64(info.FeeFloor()),
+			info.Congestion()*100)
+	}
+}
+
+// pollMempool periodically refreshes the cached mempool info.
+//
+// NOTE: This MUST be run as a goroutine.
+func (m *MempoolMonitor) pollMempool() {
+	defer m.wg.Done()
+
+	ticker := time.NewTicker(m.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			m.updateMempoolInfo()
+
+		case <-m.quit:
+			return
+		}
+	}
+}
+
+// A compile-time assertion to ensure that MempoolMonitor implements the
+// Estimator interface.
+var _ Estimator = (*MempoolMonitor)(nil)
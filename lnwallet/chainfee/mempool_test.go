@@ -0,0 +1,192 @@
+package chainfee
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+type mockMempoolInfoSource struct {
+	info *MempoolInfo
+	err  error
+}
+
+func (m *mockMempoolInfoSource) FetchMempoolInfo() (*MempoolInfo, error) {
+	return m.info, m.err
+}
+
+// TestMempoolMonitor checks that the MempoolMonitor bumps fee estimates that
+// fall below the mempool fee floor.
+func TestMempoolMonitor(t *testing.T) {
+	t.Parallel()
+
+	const (
+		relayFee    = SatPerKWeight(FeePerKwFloor)
+		estimateFee = SatPerKWeight(2000)
+	)
+
+	testCases := []struct {
+		name           string
+		source         *mockMempoolInfoSource
+		expectedFee    SatPerKWeight
+		expectedRelay  SatPerKWeight
+		expectedFloor  SatPerKWeight
+		expectedCongst float64
+	}{
+		{
+			name: "source error",
+			source: &mockMempoolInfoSource{
+				err: errors.New("unavailable"),
+			},
+			expectedFee:   estimateFee,
+			expectedRelay: relayFee,
+			expectedFloor: relayFee,
+		},
+		{
+			name: "empty mempool",
+			source: &mockMempoolInfoSource{
+				info: &MempoolInfo{
+					MinRelayFee:   relayFee,
+					MempoolMinFee: relayFee,
+					Usage:         100,
+					MaxMempool:    1000,
+				},
+			},
+			expectedFee:    estimateFee,
+			expectedRelay:  relayFee,
+			expectedFloor:  relayFee,
+			expectedCongst: 0.1,
+		},
+		{
+			name: "full mempool",
+			source: &mockMempoolInfoSource{
+				info: &MempoolInfo{
+					MinRelayFee:   relayFee,
+					MempoolMinFee: 5000,
+					Usage:         1000,
+					MaxMempool:    1000,
+				},
+			},
+			expectedFee:    5000,
+			expectedRelay:  relayFee,
+			expectedFloor:  5000,
+			expectedCongst: 1,
+		},
+		{
+			name: "raised relay fee",
+			source: &mockMempoolInfoSource{
+				info: &MempoolInfo{
+					MinRelayFee:   3000,
+					MempoolMinFee: 3000,
+				},
+			},
+			expectedFee:   3000,
+			expectedRelay: 3000,
+			expectedFloor: 3000,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			monitor := NewMempoolMonitor(
+				NewStaticEstimator(estimateFee, relayFee),
+				tc.source, time.Hour,
+			)
+			if err := monitor.Start(); err != nil {
+				t.Fatalf("unable to start monitor: %v", err)
+			}
+			defer monitor.Stop()
+
+			fee, err := monitor.EstimateFeePerKW(6)
+			if err != nil {
+				t.Fatalf("unable to estimate fee: %v", err)
+			}
+			if fee != tc.expectedFee {
+				t.Fatalf("expected fee %v, got %v",
+					tc.expectedFee, fee)
+			}
+
+			relay := monitor.RelayFeePerKW()
+			if relay != tc.expectedRelay {
+				t.Fatalf("expected relay fee %v, got %v",
+					tc.expectedRelay, relay)
+			}
+
+			floor := monitor.FeeFloor()
+			if floor != tc.expectedFloor {
+				t.Fatalf("expected fee floor %v, got %v",
+					tc.expectedFloor, floor)
+			}
+
+			congestion := monitor.Congestion()
+			if congestion != tc.expectedCongst {
+				t.Fatalf("expected congestion %v, got %v",
+					tc.expectedCongst, congestion)
+			}
+		})
+	}
+}
+
+// mockSourceEstimator is a fee estimator that is also a source of the mempool
+// state and records whether it is running.
+type mockSourceEstimator struct {
+	*StaticEstimator
+	mockMempoolInfoSource
+
+	running bool
+}
+
+func (m *mockSourceEstimator) Start() error {
+	m.running = true
+	return nil
+}
+
+func (m *mockSourceEstimator) Stop() error {
+	m.running = false
+	return nil
+}
+
+// TestMempoolMonitorSeparateSource checks that the fee floor of the backend
+// is applied to the estimates of another estimator, and that the backend's
+// estimator is started and stopped along with the monitor.
+func TestMempoolMonitorSeparateSource(t *testing.T) {
+	t.Parallel()
+
+	source := &mockSourceEstimator{
+		StaticEstimator: NewStaticEstimator(1000, FeePerKwFloor),
+		mockMempoolInfoSource: mockMempoolInfoSource{
+			info: &MempoolInfo{
+				MinRelayFee:   FeePerKwFloor,
+				MempoolMinFee: 5000,
+			},
+		},
+	}
+
+	monitor := NewMempoolMonitor(
+		NewStaticEstimator(2000, FeePerKwFloor), source, time.Hour,
+	)
+	if err := monitor.Start(); err != nil {
+		t.Fatalf("unable to start monitor: %v", err)
+	}
+	if !source.running {
+		t.Fatalf("source estimator not started")
+	}
+
+	fee, err := monitor.EstimateFeePerKW(6)
+	if err != nil {
+		t.Fatalf("unable to estimate fee: %v", err)
+	}
+	if fee != 5000 {
+		t.Fatalf("expected fee %v, got %v", 5000, fee)
+	}
+
+	if err := monitor.Stop(); err != nil {
+		t.Fatalf("unable to stop monitor: %v", err)
+	}
+	if source.running {
+		t.Fatalf("source estimator not stopped")
+	}
+}
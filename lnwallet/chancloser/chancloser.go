@@ -33,6 +33,18 @@ var (
 	// shutdown script previously set for that party.
 	ErrUpfrontShutdownScriptMismatch = fmt.Errorf("shutdown script does not " +
 		"match upfront shutdown script")
+
+	// ErrNoFeeRangeOverlap is returned when the fee range sent by the remote
+	// party doesn't overlap with our own, so no fee can be agreed on.
+	ErrNoFeeRangeOverlap = fmt.Errorf("remote fee range doesn't overlap " +
+		"with our fee range")
+)
+
+const (
+	// defaultMaxFeeMultiplier is the multiple of our ideal fee that we'll
+	// accept for the close transaction if no maximum fee rate was
+	// specified.
+	defaultMaxFeeMultiplier = 3
 )
 
 // closeState represents all the possible states the channel closer state
@@ -92,6 +104,11 @@ type ChanCloseCfg struct {
 	// Disconnect will disconnect from the remote peer in this close.
 	Disconnect func() error
 
+	// MaxFee is the highest fee rate that we're willing to accept for the
+	// close transaction. If it is zero, a multiple of the ideal fee rate
+	// is used instead.
+	MaxFee chainfee.SatPerKWeight
+
 	// Quit is a channel that should be sent upon in the occasion the state
 	// machine should cease all progress and shutdown.
 	Quit chan struct{}
@@ -125,6 +142,16 @@ type ChanCloser struct {
 	// offer when starting negotiation. This will be used as a baseline.
 	idealFeeSat btcutil.Amount
 
+	// minFeeSat is the lowest fee that we'll accept for the closing
+	// transaction. It is sent to the remote party as the lower bound of
+	// our fee range.
+	minFeeSat btcutil.Amount
+
+	// maxFeeSat is the highest fee that we'll accept for the closing
+	// transaction. It is sent to the remote party as the upper bound of
+	// our fee range.
+	maxFeeSat btcutil.Amount
+
 	// lastFeeProposal is the last fee that we proposed to the remote party.
 	// We'll use this as a pivot point to ratchet our next offer up, down, or
 	// simply accept the remote party's prior offer.
@@ -184,8 +211,30 @@ func NewChanCloser(cfg ChanCloseCfg, deliveryScript []byte,
 		idealFeeSat = channelCommitFee
 	}
 
-	chancloserLog.Infof("Ideal fee for closure of ChannelPoint(%v) is: %v sat",
-		cfg.Channel.ChannelPoint(), int64(idealFeeSat))
+	// Next, we'll determine the highest fee that we're willing to pay. If
+	// the caller didn't specify a maximum fee rate, we'll allow a multiple
+	// of our ideal fee. Our ideal fee can never exceed this maximum.
+	maxFeeSat := idealFeeSat * defaultMaxFeeMultiplier
+	if cfg.MaxFee > 0 {
+		maxFeeSat = cfg.Channel.CalcFee(cfg.MaxFee)
+	}
+	if idealFeeSat > maxFeeSat {
+		chancloserLog.Infof("Ideal starting fee of %v is greater than max "+
+			"fee of %v, clamping", int64(idealFeeSat), int64(maxFeeSat))
+
+		idealFeeSat = maxFeeSat
+	}
+
+	// The lowest fee we'll accept is the one that pays the relay fee
+	// floor, as the closing transaction wouldn't propagate otherwise.
+	minFeeSat := cfg.Channel.CalcFee(chainfee.FeePerKwFloor)
+	if minFeeSat > idealFeeSat {
+		minFeeSat = idealFeeSat
+	}
+
+	chancloserLog.Infof("Ideal fee for closure of ChannelPoint(%v) is: %v sat "+
+		"(range=[%v, %v])", cfg.Channel.ChannelPoint(), int64(idealFeeSat),
+		int64(minFeeSat), int64(maxFeeSat))
 
 	cid := lnwire.NewChanIDFromOutPoint(cfg.Channel.ChannelPoint())
 	return &ChanCloser{
@@ -196,6 +245,8 @@ func NewChanCloser(cfg ChanCloseCfg, deliveryScript []byte,
 		cfg:                 cfg,
 		negotiationHeight:   negotiationHeight,
 		idealFeeSat:         idealFeeSat,
+		minFeeSat:           minFeeSat,
+		maxFeeSat:           maxFeeSat,
 		localDeliveryScript: deliveryScript,
 		priorFeeOffers:      make(map[btcutil.Amount]*lnwire.ClosingSigned),
 		locallyInitiated:    locallyInitiated,
@@ -487,12 +538,36 @@ func (c *ChanCloser) ProcessCloseMsg(msg lnwire.Message) ([]lnwire.Message,
 		// we'll attempt to ratchet the fee closer to
 		remoteProposedFee := closeSignedMsg.FeeSatoshis
 		if _, ok := c.priorFeeOffers[remoteProposedFee]; !ok {
-			// We'll now attempt to ratchet towards a fee deemed acceptable by
-			// both parties, factoring in our ideal fee rate, and the last
-			// proposed fee by both sides.
-			feeProposal := calcCompromiseFee(c.chanPoint, c.idealFeeSat,
-				c.lastFeeProposal, remoteProposedFee,
-			)
+			var feeProposal btcutil.Amount
+			switch {
+			// If the remote party sent us their fee range, we can
+			// settle on a fee within the overlap of both ranges
+			// right away, which the remote party will accept.
+			case closeSignedMsg.FeeRange != nil:
+				var err error
+				feeProposal, err = selectFeeInRange(
+					c.ourFeeRange(), closeSignedMsg.FeeRange,
+					remoteProposedFee,
+				)
+				if err != nil {
+					return nil, false, fmt.Errorf("unable to "+
+						"negotiate fee for ChannelPoint(%v): "+
+						"%w", c.chanPoint, err)
+				}
+
+			// Otherwise, we'll now attempt to ratchet towards a fee
+			// deemed acceptable by both parties, factoring in our
+			// ideal fee rate, and the last proposed fee by both
+			// sides. We'll never go beyond our maximum fee though.
+			default:
+				feeProposal = calcCompromiseFee(c.chanPoint,
+					c.idealFeeSat, c.lastFeeProposal,
+					remoteProposedFee,
+				)
+				if feeProposal > c.maxFeeSat {
+					feeProposal = c.maxFeeSat
+				}
+			}
 
 			// With our new fee proposal calculated, we'll craft a new close
 			// signed signature to send to the other party so we can continue
@@ -614,8 +689,13 @@ func (c *ChanCloser) proposeCloseSigned(fee btcutil.Amount) (*lnwire.ClosingSign
 
 	// We'll assemble a ClosingSigned message using this information and return
 	// it to the caller so we can kick off the final stage of the channel
-	// closure process.
+	// closure process. We'll include our fee range, so the remote party is
+	// able to accept a fee in a single round trip.
 	closeSignedMsg := lnwire.NewClosingSigned(c.cid, fee, parsedSig)
+	closeSignedMsg.FeeRange = c.ourFeeRange()
+	if fee < closeSignedMsg.FeeRange.MinFeeSatoshis {
+		closeSignedMsg.FeeRange.MinFeeSatoshis = fee
+	}
 
 	// We'll also save this close signed, in the case that the remote party
 	// accepts our offer. This way, we don't have to re-sign.
@@ -624,6 +704,49 @@ func (c *ChanCloser) proposeCloseSigned(fee btcutil.Amount) (*lnwire.ClosingSign
 	return closeSignedMsg, nil
 }
 
+// ourFeeRange returns the range of fees that we'll accept for the closing
+// transaction.
+func (c *ChanCloser) ourFeeRange() *lnwire.ClosingFeeRange {
+	return &lnwire.ClosingFeeRange{
+		MinFeeSatoshis: c.minFeeSat,
+		MaxFeeSatoshis: c.maxFeeSat,
+	}
+}
+
+// selectFeeInRange selects a fee within the overlap of our fee range and the
+// fee range of the remote party. If the fee proposed by the remote party lies
+// within the overlap, it is accepted as is. Otherwise, the fee within the
+// overlap that is closest to the remote proposal is selected. An error is
+// returned if the ranges don't overlap.
+func selectFeeInRange(localRange, remoteRange *lnwire.ClosingFeeRange,
+	remoteFee btcutil.Amount) (btcutil.Amount, error) {
+
+	overlap := &lnwire.ClosingFeeRange{
+		MinFeeSatoshis: localRange.MinFeeSatoshis,
+		MaxFeeSatoshis: localRange.MaxFeeSatoshis,
+	}
+	if remoteRange.MinFeeSatoshis > overlap.MinFeeSatoshis {
+		overlap.MinFeeSatoshis = remoteRange.MinFeeSatoshis
+	}
+	if remoteRange.MaxFeeSatoshis < overlap.MaxFeeSatoshis {
+		overlap.MaxFeeSatoshis = remoteRange.MaxFeeSatoshis
+	}
+
+	switch {
+	case overlap.MinFeeSatoshis > overlap.MaxFeeSatoshis:
+		return 0, ErrNoFeeRangeOverlap
+
+	case remoteFee < overlap.MinFeeSatoshis:
+		return overlap.MinFeeSatoshis, nil
+
+	case remoteFee > overlap.MaxFeeSatoshis:
+		return overlap.MaxFeeSatoshis, nil
+
+	default:
+		return remoteFee, nil
+	}
+}
+
 // feeInAcceptableRange returns true if the passed remote fee is deemed to be
 // in an "acceptable" range to our local fee. This is an attempt at a
 // compromise and to ensure that the fee negotiation has a stopping point. We
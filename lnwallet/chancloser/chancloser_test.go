@@ -4,7 +4,13 @@ import (
 	"crypto/rand"
 	"testing"
 
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/cryptomeow/lnd/channeldb"
+	"github.com/cryptomeow/lnd/lnwallet"
+	"github.com/cryptomeow/lnd/lnwallet/chainfee"
 	"github.com/cryptomeow/lnd/lnwire"
+	"github.com/stretchr/testify/require"
 )
 
 // randDeliveryAddress generates a random delivery address for testing.
@@ -74,3 +80,212 @@ func TestMaybeMatchScript(t *testing.T) {
 		})
 	}
 }
+
+// TestSelectFeeInRange tests that a fee is selected within the overlap of two
+// fee ranges, and that an error is returned if the ranges don't overlap.
+func TestSelectFeeInRange(t *testing.T) {
+	t.Parallel()
+
+	localRange := &lnwire.ClosingFeeRange{
+		MinFeeSatoshis: 100,
+		MaxFeeSatoshis: 500,
+	}
+
+	tests := []struct {
+		name        string
+		remoteRange *lnwire.ClosingFeeRange
+		remoteFee   btcutil.Amount
+		expectedFee btcutil.Amount
+		expectedErr error
+	}{
+		{
+			name: "remote fee in overlap",
+			remoteRange: &lnwire.ClosingFeeRange{
+				MinFeeSatoshis: 200,
+				MaxFeeSatoshis: 1000,
+			},
+			remoteFee:   300,
+			expectedFee: 300,
+		},
+		{
+			name: "remote fee above overlap",
+			remoteRange: &lnwire.ClosingFeeRange{
+				MinFeeSatoshis: 200,
+				MaxFeeSatoshis: 1000,
+			},
+			remoteFee:   800,
+			expectedFee: 500,
+		},
+		{
+			name: "remote fee below overlap",
+			remoteRange: &lnwire.ClosingFeeRange{
+				MinFeeSatoshis: 50,
+				MaxFeeSatoshis: 300,
+			},
+			remoteFee:   50,
+			expectedFee: 100,
+		},
+		{
+			name: "no overlap",
+			remoteRange: &lnwire.ClosingFeeRange{
+				MinFeeSatoshis: 600,
+				MaxFeeSatoshis: 1000,
+			},
+			remoteFee:   700,
+			expectedErr: ErrNoFeeRangeOverlap,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			fee, err := selectFeeInRange(
+				localRange, test.remoteRange, test.remoteFee,
+			)
+			require.Equal(t, test.expectedErr, err)
+			require.Equal(t, test.expectedFee, fee)
+		})
+	}
+}
+
+// newTestChanCloser creates a ChanCloser for the passed channel that doesn't
+// broadcast the closing transaction.
+func newTestChanCloser(t *testing.T, channel *lnwallet.LightningChannel,
+	idealFee, maxFee chainfee.SatPerKWeight,
+	initiator bool) *ChanCloser {
+
+	return NewChanCloser(
+		ChanCloseCfg{
+			Channel:           channel,
+			UnregisterChannel: func(lnwire.ChannelID) {},
+			BroadcastTx: func(*wire.MsgTx, string) error {
+				return nil
+			},
+			DisableChannel: func(wire.OutPoint) error {
+				return nil
+			},
+			Disconnect: func() error { return nil },
+			MaxFee:     maxFee,
+		},
+		randDeliveryAddress(t), idealFee, 0, nil, initiator,
+	)
+}
+
+// TestFeeRangeNegotiation tests that two ChanClosers that exchange their fee
+// ranges agree on a closing fee without ratcheting towards each other.
+func TestFeeRangeNegotiation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+
+		// aliceFee and bobFee are the ideal fee rates of the parties.
+		aliceFee chainfee.SatPerKWeight
+		bobFee   chainfee.SatPerKWeight
+
+		// bobMaxFee is the maximum fee rate Bob is willing to accept.
+		bobMaxFee chainfee.SatPerKWeight
+
+		// expectedFee is the fee rate that is expected to be agreed on.
+		expectedFee chainfee.SatPerKWeight
+
+		// expectedRounds is the number of closing_signed messages Bob
+		// sends before the negotiation is over.
+		expectedRounds int
+	}{
+		{
+			// Alice's offer is far below Bob's ideal fee, but still
+			// within his range, so he accepts it right away.
+			name:           "initial offer accepted",
+			aliceFee:       1000,
+			bobFee:         5000,
+			expectedFee:    1000,
+			expectedRounds: 1,
+		},
+		{
+			// Alice's offer exceeds Bob's maximum fee, so he
+			// counters with his maximum which Alice accepts.
+			name:           "counter offer accepted",
+			aliceFee:       5000,
+			bobFee:         1000,
+			bobMaxFee:      2000,
+			expectedFee:    2000,
+			expectedRounds: 2,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			aliceChan, bobChan, cleanUp, err := lnwallet.CreateTestChannels(
+				channeldb.SingleFunderTweaklessBit,
+			)
+			require.NoError(t, err)
+			defer cleanUp()
+
+			alice := newTestChanCloser(
+				t, aliceChan, test.aliceFee, 0, true,
+			)
+			bob := newTestChanCloser(
+				t, bobChan, test.bobFee, test.bobMaxFee, false,
+			)
+
+			// Alice initiates the close, after which both parties
+			// exchange their shutdown messages. As the initiator of
+			// the channel, Alice sends the first offer.
+			shutdown, err := alice.ShutdownChan()
+			require.NoError(t, err)
+
+			msgs, done, err := bob.ProcessCloseMsg(shutdown)
+			require.NoError(t, err)
+			require.False(t, done)
+			require.Len(t, msgs, 1)
+
+			msgs, done, err = alice.ProcessCloseMsg(msgs[0])
+			require.NoError(t, err)
+			require.False(t, done)
+			require.Len(t, msgs, 1)
+
+			// The parties now exchange offers until both of them
+			// are done.
+			var (
+				rounds    int
+				aliceDone bool
+				bobDone   bool
+			)
+			for !aliceDone || !bobDone {
+				require.Len(t, msgs, 1)
+				require.NotNil(
+					t, msgs[0].(*lnwire.ClosingSigned).FeeRange,
+				)
+
+				msgs, bobDone, err = bob.ProcessCloseMsg(msgs[0])
+				require.NoError(t, err)
+				rounds++
+
+				if aliceDone {
+					break
+				}
+
+				msgs, aliceDone, err = alice.ProcessCloseMsg(
+					msgs[0],
+				)
+				require.NoError(t, err)
+			}
+			require.True(t, bobDone)
+			require.Equal(t, test.expectedRounds, rounds)
+
+			aliceTx, err := alice.ClosingTx()
+			require.NoError(t, err)
+			bobTx, err := bob.ClosingTx()
+			require.NoError(t, err)
+			require.Equal(t, aliceTx.TxHash(), bobTx.TxHash())
+
+			expectedFee := aliceChan.CalcFee(test.expectedFee)
+			require.Equal(t, expectedFee, alice.lastFeeProposal)
+			require.Equal(t, expectedFee, bob.lastFeeProposal)
+		})
+	}
+}
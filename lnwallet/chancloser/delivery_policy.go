@@ -0,0 +1,78 @@
+package chancloser
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcutil"
+	"github.com/cryptomeow/lnd/lnwire"
+)
+
+var (
+	// ErrLegacyDeliveryAddress is returned when a delivery script pays to a
+	// legacy P2PKH address while those are rejected by the policy.
+	ErrLegacyDeliveryAddress = errors.New("legacy p2pkh close addresses " +
+		"are not allowed")
+
+	// ErrForeignDeliveryAddress is returned when a delivery script pays to
+	// an address that isn't controlled by our wallet while the policy only
+	// allows addresses of our own wallet.
+	ErrForeignDeliveryAddress = errors.New("close address doesn't belong " +
+		"to the wallet")
+)
+
+// DeliveryAddrPolicy describes the delivery scripts that are allowed as the
+// destination of our funds in a cooperative close. It applies to both close
+// addresses that are supplied when closing a channel, and upfront shutdown
+// scripts that are set when opening one.
+type DeliveryAddrPolicy struct {
+	// RejectLegacy, if set, rejects delivery scripts that pay to a legacy
+	// P2PKH address.
+	RejectLegacy bool
+
+	// WalletOnly, if set, only allows delivery scripts that pay to an
+	// address that is derived from our own wallet.
+	WalletOnly bool
+
+	// IsOurAddress returns true if the passed address belongs to our
+	// wallet. It must be set if WalletOnly is set.
+	IsOurAddress func(btcutil.Address) bool
+
+	// ChainParams are the parameters of the chain the addresses belong
+	// to.
+	ChainParams *chaincfg.Params
+}
+
+// Validate returns an error if the passed delivery script violates the
+// policy. An empty script is always allowed, as it means a fresh address of
+// our wallet will be used.
+func (p *DeliveryAddrPolicy) Validate(script lnwire.DeliveryAddress) error {
+	if len(script) == 0 {
+		return nil
+	}
+
+	class, addrs, _, err := txscript.ExtractPkScriptAddrs(
+		script, p.ChainParams,
+	)
+	if err != nil {
+		return fmt.Errorf("unable to parse close script: %v", err)
+	}
+
+	if p.RejectLegacy && class == txscript.PubKeyHashTy {
+		return ErrLegacyDeliveryAddress
+	}
+
+	if !p.WalletOnly {
+		return nil
+	}
+
+	// A script that doesn't pay to exactly one address can't be one of our
+	// wallet's addresses.
+	if len(addrs) != 1 || !p.IsOurAddress(addrs[0]) {
+		return ErrForeignDeliveryAddress
+	}
+
+	return nil
+}
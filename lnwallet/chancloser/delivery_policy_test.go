@@ -0,0 +1,99 @@
+package chancloser
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcutil"
+	"github.com/cryptomeow/lnd/lnwire"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDeliveryAddrPolicy tests that delivery scripts are validated against
+// the configured close address policy.
+func TestDeliveryAddrPolicy(t *testing.T) {
+	t.Parallel()
+
+	params := &chaincfg.RegressionNetParams
+
+	newScript := func(addr btcutil.Address) lnwire.DeliveryAddress {
+		script, err := txscript.PayToAddrScript(addr)
+		require.NoError(t, err)
+
+		return script
+	}
+
+	pkHash := make([]byte, 20)
+	p2pkh, err := btcutil.NewAddressPubKeyHash(pkHash, params)
+	require.NoError(t, err)
+	p2wkh, err := btcutil.NewAddressWitnessPubKeyHash(pkHash, params)
+	require.NoError(t, err)
+
+	// Only the P2WKH address belongs to our wallet.
+	isOurAddress := func(addr btcutil.Address) bool {
+		return addr.EncodeAddress() == p2wkh.EncodeAddress()
+	}
+
+	tests := []struct {
+		name         string
+		rejectLegacy bool
+		walletOnly   bool
+		script       lnwire.DeliveryAddress
+		expectedErr  error
+	}{
+		{
+			name:   "no policy",
+			script: newScript(p2pkh),
+		},
+		{
+			name:         "empty script",
+			rejectLegacy: true,
+			walletOnly:   true,
+		},
+		{
+			name:         "legacy rejected",
+			rejectLegacy: true,
+			script:       newScript(p2pkh),
+			expectedErr:  ErrLegacyDeliveryAddress,
+		},
+		{
+			name:         "segwit allowed",
+			rejectLegacy: true,
+			script:       newScript(p2wkh),
+		},
+		{
+			name:       "wallet address allowed",
+			walletOnly: true,
+			script:     newScript(p2wkh),
+		},
+		{
+			name:        "foreign address rejected",
+			walletOnly:  true,
+			script:      newScript(p2pkh),
+			expectedErr: ErrForeignDeliveryAddress,
+		},
+		{
+			name:        "non-standard script rejected",
+			walletOnly:  true,
+			script:      []byte{txscript.OP_TRUE},
+			expectedErr: ErrForeignDeliveryAddress,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			policy := &DeliveryAddrPolicy{
+				RejectLegacy: test.rejectLegacy,
+				WalletOnly:   test.walletOnly,
+				IsOurAddress: isOurAddress,
+				ChainParams:  params,
+			}
+
+			err := policy.Validate(test.script)
+			require.Equal(t, test.expectedErr, err)
+		})
+	}
+}
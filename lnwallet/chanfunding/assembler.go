@@ -78,6 +78,17 @@ type Request struct {
 	// ChangeAddr is a closure that will provide the Assembler with a
 	// change address for the funding transaction if needed.
 	ChangeAddr func() (btcutil.Address, error)
+
+	// CoinSelectionStrategy is the strategy that should be used to order
+	// the eligible coins before coin selection. If set to
+	// CoinSelectionDefault, then the default strategy of the Assembler is
+	// used.
+	CoinSelectionStrategy CoinSelectionStrategy
+
+	// Outpoints is an optional list of outpoints that must be used to
+	// fund the channel. If set, no other coins are considered, and all of
+	// the given outpoints are spent unless SubtractFees is set.
+	Outpoints []wire.OutPoint
 }
 
 // Intent is returned by an Assembler and represents the base functionality the
@@ -1,6 +1,7 @@
 package chanfunding
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/btcsuite/btcd/btcec"
@@ -165,6 +166,43 @@ func NewCannedAssembler(thawHeight uint32, chanPoint wire.OutPoint,
 	}
 }
 
+// VerifyFundingTx checks that the given transaction, which was crafted outside
+// of lnd, creates the funding output described by the assembler at its channel
+// point.
+func (c *CannedAssembler) VerifyFundingTx(tx *wire.MsgTx) error {
+	txid := tx.TxHash()
+	if txid != c.chanPoint.Hash {
+		return fmt.Errorf("funding transaction %v doesn't match "+
+			"channel point %v", txid, c.chanPoint)
+	}
+	if int(c.chanPoint.Index) >= len(tx.TxOut) {
+		return fmt.Errorf("funding transaction %v has no output at "+
+			"index %v", txid, c.chanPoint.Index)
+	}
+
+	_, fundingOutput, err := input.GenFundingPkScript(
+		c.localKey.PubKey.SerializeCompressed(),
+		c.remoteKey.SerializeCompressed(), int64(c.fundingAmt),
+	)
+	if err != nil {
+		return err
+	}
+
+	txOut := tx.TxOut[c.chanPoint.Index]
+	if txOut.Value != fundingOutput.Value {
+		return fmt.Errorf("funding output value %v doesn't match "+
+			"funding amount %v", btcutil.Amount(txOut.Value),
+			c.fundingAmt)
+	}
+	if !bytes.Equal(txOut.PkScript, fundingOutput.PkScript) {
+		return fmt.Errorf("funding output script %x doesn't match "+
+			"multisig script %x of the funding keys",
+			txOut.PkScript, fundingOutput.PkScript)
+	}
+
+	return nil
+}
+
 // ProvisionChannel creates a new ShimIntent given the passed funding Request.
 // The returned intent is immediately able to provide the channel point and
 // funding output as they've already been created outside lnd.
@@ -0,0 +1,107 @@
+package chanfunding
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/cryptomeow/lnd/input"
+	"github.com/cryptomeow/lnd/keychain"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCannedAssemblerVerifyFundingTx tests that the CannedAssembler only
+// accepts a funding transaction that creates its funding output at the
+// expected channel point.
+func TestCannedAssemblerVerifyFundingTx(t *testing.T) {
+	t.Parallel()
+
+	_, localPubkey := btcec.PrivKeyFromBytes(btcec.S256(), localPrivkey)
+	_, remotePubkey := btcec.PrivKeyFromBytes(btcec.S256(), remotePrivkey)
+
+	_, fundingOutput, err := input.GenFundingPkScript(
+		localPubkey.SerializeCompressed(),
+		remotePubkey.SerializeCompressed(), int64(chanCapacity),
+	)
+	require.NoError(t, err)
+
+	// newFundingTx creates a transaction that pays to the given output at
+	// index 1.
+	newFundingTx := func(txOut *wire.TxOut) *wire.MsgTx {
+		tx := wire.NewMsgTx(2)
+		tx.AddTxIn(&wire.TxIn{})
+		tx.AddTxOut(&wire.TxOut{Value: 1000, PkScript: p2wkhScript})
+		tx.AddTxOut(txOut)
+		return tx
+	}
+
+	newAssembler := func(tx *wire.MsgTx, index uint32) *CannedAssembler {
+		return NewCannedAssembler(
+			0, wire.OutPoint{Hash: tx.TxHash(), Index: index},
+			chanCapacity, &keychain.KeyDescriptor{
+				PubKey: localPubkey,
+			}, remotePubkey, true,
+		)
+	}
+
+	validTx := newFundingTx(fundingOutput)
+
+	// A transaction paying the wrong amount to the multisig script must be
+	// rejected even if the assembler refers to it by its own txid.
+	wrongValueTx := newFundingTx(&wire.TxOut{
+		Value:    fundingOutput.Value - 1,
+		PkScript: fundingOutput.PkScript,
+	})
+
+	testCases := []struct {
+		name      string
+		assembler *CannedAssembler
+		tx        *wire.MsgTx
+		expectErr bool
+	}{
+		{
+			name:      "valid funding tx",
+			assembler: newAssembler(validTx, 1),
+			tx:        validTx,
+		},
+		{
+			name:      "txid mismatch",
+			assembler: newAssembler(validTx, 1),
+			tx: newFundingTx(&wire.TxOut{
+				Value:    fundingOutput.Value,
+				PkScript: p2wkhScript,
+			}),
+			expectErr: true,
+		},
+		{
+			name:      "missing output index",
+			assembler: newAssembler(validTx, 2),
+			tx:        validTx,
+			expectErr: true,
+		},
+		{
+			name:      "wrong output index",
+			assembler: newAssembler(validTx, 0),
+			tx:        validTx,
+			expectErr: true,
+		},
+		{
+			name:      "wrong value",
+			assembler: newAssembler(wrongValueTx, 1),
+			tx:        wrongValueTx,
+			expectErr: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			err := testCase.assembler.VerifyFundingTx(testCase.tx)
+			if testCase.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -2,6 +2,9 @@ package chanfunding
 
 import (
 	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
 
 	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcd/wire"
@@ -33,6 +36,125 @@ type Coin struct {
 	wire.TxOut
 
 	wire.OutPoint
+
+	// Confirmations is the number of confirmations the transaction that
+	// created this coin has. It is used as a proxy for the age of the coin
+	// by the CoinSelectionOldest strategy.
+	Confirmations int64
+}
+
+// CoinSelectionStrategy determines the order in which the set of eligible
+// coins is considered during coin selection. As coins are selected greedily,
+// the strategy decides which coins end up as inputs of the funding
+// transaction.
+type CoinSelectionStrategy uint8
+
+const (
+	// CoinSelectionDefault signals that no explicit strategy was chosen,
+	// and that the default strategy of the assembler should be used
+	// instead. If the assembler doesn't have a default either, then the
+	// coins are used in the order they're returned by the CoinSource.
+	CoinSelectionDefault CoinSelectionStrategy = iota
+
+	// CoinSelectionLargest selects the coins with the largest value
+	// first. This minimizes the number of inputs, and therefore the fee
+	// of the funding transaction.
+	CoinSelectionLargest
+
+	// CoinSelectionRandom selects coins in a random order. This makes it
+	// harder for an outside observer to link the funding transaction to
+	// the other coins held by the wallet.
+	CoinSelectionRandom
+
+	// CoinSelectionOldest selects the coins with the most confirmations
+	// first, consolidating old (and often small) coins over time.
+	CoinSelectionOldest
+)
+
+// String returns a human readable name of the coin selection strategy.
+func (c CoinSelectionStrategy) String() string {
+	switch c {
+	case CoinSelectionDefault:
+		return "default"
+
+	case CoinSelectionLargest:
+		return "largest"
+
+	case CoinSelectionRandom:
+		return "random"
+
+	case CoinSelectionOldest:
+		return "oldest"
+
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(c))
+	}
+}
+
+// ParseCoinSelectionStrategy parses the human readable name of a coin
+// selection strategy as returned by String.
+func ParseCoinSelectionStrategy(s string) (CoinSelectionStrategy, error) {
+	switch strings.ToLower(s) {
+	case "", "default":
+		return CoinSelectionDefault, nil
+
+	case "largest":
+		return CoinSelectionLargest, nil
+
+	case "random":
+		return CoinSelectionRandom, nil
+
+	case "oldest":
+		return CoinSelectionOldest, nil
+
+	default:
+		return 0, fmt.Errorf("unknown coin selection strategy: %v, "+
+			"must be one of: largest, random, oldest", s)
+	}
+}
+
+// ArrangeCoins returns a copy of the passed coins, ordered according to the
+// given coin selection strategy. The returned slice can be passed directly to
+// CoinSelect or CoinSelectSubtractFees.
+func ArrangeCoins(strategy CoinSelectionStrategy, coins []Coin) ([]Coin,
+	error) {
+
+	arranged := make([]Coin, len(coins))
+	copy(arranged, coins)
+
+	switch strategy {
+	// Without an explicit strategy, we'll leave the order of the coins
+	// untouched.
+	case CoinSelectionDefault:
+
+	case CoinSelectionLargest:
+		sort.SliceStable(arranged, func(i, j int) bool {
+			return arranged[i].Value > arranged[j].Value
+		})
+
+	case CoinSelectionRandom:
+		rand.Shuffle(len(arranged), func(i, j int) {
+			arranged[i], arranged[j] = arranged[j], arranged[i]
+		})
+
+	// For the oldest coins first, we'll break ties by value so we still
+	// prefer spending fewer inputs among coins of the same age.
+	case CoinSelectionOldest:
+		sort.SliceStable(arranged, func(i, j int) bool {
+			ci, cj := arranged[i], arranged[j]
+			if ci.Confirmations != cj.Confirmations {
+				return ci.Confirmations > cj.Confirmations
+			}
+
+			return ci.Value > cj.Value
+		})
+
+	default:
+		return nil, fmt.Errorf("unknown coin selection strategy: %v",
+			strategy)
+	}
+
+	return arranged, nil
 }
 
 // selectInputs selects a slice of inputs necessary to meet the specified
@@ -120,6 +242,46 @@ func CoinSelect(feeRate chainfee.SatPerKWeight, amt btcutil.Amount,
 	}
 }
 
+// CoinSelectManual funds amt satoshis by spending all of the given coins,
+// adhering to the specified fee rate. Whatever is left after paying the fees is
+// returned as change.
+func CoinSelectManual(feeRate chainfee.SatPerKWeight, amt btcutil.Amount,
+	coins []Coin) ([]Coin, btcutil.Amount, error) {
+
+	var (
+		totalSat       btcutil.Amount
+		weightEstimate input.TxWeightEstimator
+	)
+	for _, utxo := range coins {
+		totalSat += btcutil.Amount(utxo.Value)
+
+		switch {
+		case txscript.IsPayToWitnessPubKeyHash(utxo.PkScript):
+			weightEstimate.AddP2WKHInput()
+
+		case txscript.IsPayToScriptHash(utxo.PkScript):
+			weightEstimate.AddNestedP2WKHInput()
+
+		default:
+			return nil, 0, fmt.Errorf("unsupported address type: %x",
+				utxo.PkScript)
+		}
+	}
+
+	// Channel funding multisig output is P2WSH, and we assume that the
+	// change output is a P2WKH output.
+	weightEstimate.AddP2WSHOutput()
+	weightEstimate.AddP2WKHOutput()
+
+	totalWeight := int64(weightEstimate.Weight())
+	requiredFee := feeRate.FeeForWeight(totalWeight)
+	if totalSat < amt+requiredFee {
+		return nil, 0, &ErrInsufficientFunds{amt + requiredFee, totalSat}
+	}
+
+	return coins, totalSat - amt - requiredFee, nil
+}
+
 // CoinSelectSubtractFees attempts to select coins such that we'll spend up to
 // amt in total after fees, adhering to the specified fee rate. The selected
 // coins, the final output and change values are returned.
@@ -427,3 +427,95 @@ func TestCoinSelectSubtractFees(t *testing.T) {
 		})
 	}
 }
+
+// TestArrangeCoins tests that the set of eligible coins is ordered according
+// to the selected coin selection strategy.
+func TestArrangeCoins(t *testing.T) {
+	t.Parallel()
+
+	// newCoin is a helper that creates a coin with the given value and
+	// number of confirmations.
+	newCoin := func(value btcutil.Amount, confs int64) Coin {
+		return Coin{
+			TxOut: wire.TxOut{
+				PkScript: p2wkhScript,
+				Value:    int64(value),
+			},
+			Confirmations: confs,
+		}
+	}
+
+	coins := []Coin{
+		newCoin(1000, 6),
+		newCoin(5000, 1),
+		newCoin(2000, 100),
+		newCoin(3000, 100),
+	}
+
+	type testCase struct {
+		name          string
+		strategy      CoinSelectionStrategy
+		expectedOrder []btcutil.Amount
+	}
+
+	testCases := []testCase{
+		{
+			name:          "default keeps order",
+			strategy:      CoinSelectionDefault,
+			expectedOrder: []btcutil.Amount{1000, 5000, 2000, 3000},
+		},
+		{
+			name:          "largest first",
+			strategy:      CoinSelectionLargest,
+			expectedOrder: []btcutil.Amount{5000, 3000, 2000, 1000},
+		},
+		{
+			// Coins with the same number of confirmations are
+			// ordered by their value.
+			name:          "oldest first",
+			strategy:      CoinSelectionOldest,
+			expectedOrder: []btcutil.Amount{3000, 2000, 1000, 5000},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			arranged, err := ArrangeCoins(test.strategy, coins)
+			if err != nil {
+				t.Fatalf("unable to arrange coins: %v", err)
+			}
+
+			for i, coin := range arranged {
+				if coin.Value != int64(test.expectedOrder[i]) {
+					t.Fatalf("expected coin %v to have "+
+						"value %v, had %v", i,
+						test.expectedOrder[i], coin.Value)
+				}
+			}
+		})
+	}
+
+	// A random ordering must still contain all coins exactly once, and
+	// must not modify the order of the passed slice.
+	arranged, err := ArrangeCoins(CoinSelectionRandom, coins)
+	if err != nil {
+		t.Fatalf("unable to arrange coins: %v", err)
+	}
+	var total int64
+	for _, coin := range arranged {
+		total += coin.Value
+	}
+	if len(arranged) != len(coins) || total != 11000 {
+		t.Fatalf("random ordering lost coins: %v", arranged)
+	}
+	if coins[0].Value != 1000 || coins[3].Value != 3000 {
+		t.Fatalf("input slice was modified")
+	}
+
+	// Finally, an unknown strategy should be rejected.
+	if _, err := ArrangeCoins(CoinSelectionStrategy(99), coins); err == nil {
+		t.Fatalf("expected unknown strategy to fail")
+	}
+}
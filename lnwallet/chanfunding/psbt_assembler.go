@@ -410,20 +410,28 @@ type PsbtAssembler struct {
 	// shouldPublish specifies if the assembler should publish the
 	// transaction once the channel funding has completed.
 	shouldPublish bool
+
+	// thawHeight, if non-zero is the height where this channel will become
+	// a normal channel. Until this height, it's considered frozen, so it
+	// can only be cooperatively closed by the responding party.
+	thawHeight uint32
 }
 
 // NewPsbtAssembler creates a new CannedAssembler from the material required
 // to construct a funding output and channel point. An optional base PSBT can
 // be supplied which will be used to add the channel output to instead of
-// creating a new one.
-func NewPsbtAssembler(fundingAmt btcutil.Amount, basePsbt *psbt.Packet,
-	netParams *chaincfg.Params, shouldPublish bool) *PsbtAssembler {
+// creating a new one. If thawHeight is non-zero, the channel will be frozen
+// until that height.
+func NewPsbtAssembler(thawHeight uint32, fundingAmt btcutil.Amount,
+	basePsbt *psbt.Packet, netParams *chaincfg.Params,
+	shouldPublish bool) *PsbtAssembler {
 
 	return &PsbtAssembler{
 		fundingAmt:    fundingAmt,
 		basePsbt:      basePsbt,
 		netParams:     netParams,
 		shouldPublish: shouldPublish,
+		thawHeight:    thawHeight,
 	}
 }
 
@@ -442,6 +450,7 @@ func (p *PsbtAssembler) ProvisionChannel(req *Request) (Intent, error) {
 	intent := &PsbtIntent{
 		ShimIntent: ShimIntent{
 			localFundingAmt: p.fundingAmt,
+			thawHeight:      p.thawHeight,
 		},
 		State:     PsbtShimRegistered,
 		BasePsbt:  p.basePsbt,
@@ -1,6 +1,7 @@
 package chanfunding
 
 import (
+	"fmt"
 	"math"
 
 	"github.com/btcsuite/btcd/btcec"
@@ -188,6 +189,10 @@ type WalletConfig struct {
 	// DustLimit is the current dust limit. We'll use this to ensure that
 	// we don't make dust outputs on the funding transaction.
 	DustLimit btcutil.Amount
+
+	// CoinSelectionStrategy is the default coin selection strategy that
+	// is used for funding requests that don't specify one explicitly.
+	CoinSelectionStrategy CoinSelectionStrategy
 }
 
 // WalletAssembler is an instance of the Assembler interface that is backed by
@@ -226,55 +231,13 @@ func (w *WalletAssembler) ProvisionChannel(r *Request) (Intent, error) {
 		log.Infof("Performing funding tx coin selection using %v "+
 			"sat/kw as fee rate", int64(r.FeeRate))
 
-		// Find all unlocked unspent witness outputs that satisfy the
-		// minimum number of confirmations required.
-		coins, err := w.cfg.CoinSource.ListCoins(
-			r.MinConfs, math.MaxInt32,
-		)
+		selection, err := w.selectCoins(r)
 		if err != nil {
 			return err
 		}
-
-		var (
-			selectedCoins        []Coin
-			localContributionAmt btcutil.Amount
-			changeAmt            btcutil.Amount
-		)
-
-		// Perform coin selection over our available, unlocked unspent
-		// outputs in order to find enough coins to meet the funding
-		// amount requirements.
-		switch {
-		// If there's no funding amount at all (receiving an inbound
-		// single funder request), then we don't need to perform any
-		// coin selection at all.
-		case r.LocalAmt == 0:
-			break
-
-		// In case this request want the fees subtracted from the local
-		// amount, we'll call the specialized method for that. This
-		// ensures that we won't deduct more that the specified balance
-		// from our wallet.
-		case r.SubtractFees:
-			dustLimit := w.cfg.DustLimit
-			selectedCoins, localContributionAmt, changeAmt, err = CoinSelectSubtractFees(
-				r.FeeRate, r.LocalAmt, dustLimit, coins,
-			)
-			if err != nil {
-				return err
-			}
-
-		// Otherwise do a normal coin selection where we target a given
-		// funding amount.
-		default:
-			localContributionAmt = r.LocalAmt
-			selectedCoins, changeAmt, err = CoinSelect(
-				r.FeeRate, r.LocalAmt, coins,
-			)
-			if err != nil {
-				return err
-			}
-		}
+		selectedCoins := selection.coins
+		localContributionAmt := selection.localAmt
+		changeAmt := selection.changeAmt
 
 		// Record any change output(s) generated as a result of the
 		// coin selection, but only if the addition of the output won't
@@ -331,6 +294,207 @@ func (w *WalletAssembler) ProvisionChannel(r *Request) (Intent, error) {
 	return intent, nil
 }
 
+// coinSelection is the result of a coin selection performed by the
+// WalletAssembler.
+type coinSelection struct {
+	// coins is the set of coins selected as inputs.
+	coins []Coin
+
+	// localAmt is the amount we'll contribute to the channel.
+	localAmt btcutil.Amount
+
+	// changeAmt is the amount left over after funding the channel and
+	// paying the fees, which may be below the dust limit.
+	changeAmt btcutil.Amount
+}
+
+// selectCoins performs coin selection over the available, unlocked unspent
+// outputs of the CoinSource in order to find enough coins to meet the funding
+// amount requirements of the request.
+//
+// NOTE: This method MUST be called while holding the coin select lock.
+func (w *WalletAssembler) selectCoins(r *Request) (*coinSelection, error) {
+	// Find all unlocked unspent witness outputs that satisfy the minimum
+	// number of confirmations required.
+	coins, err := w.cfg.CoinSource.ListCoins(r.MinConfs, math.MaxInt32)
+	if err != nil {
+		return nil, err
+	}
+
+	// Order the coins according to the requested coin selection strategy,
+	// falling back to our default one if the request doesn't specify any.
+	strategy := r.CoinSelectionStrategy
+	if strategy == CoinSelectionDefault {
+		strategy = w.cfg.CoinSelectionStrategy
+	}
+	coins, err = ArrangeCoins(strategy, coins)
+	if err != nil {
+		return nil, err
+	}
+
+	// If the caller requested specific outpoints, we'll only consider
+	// those, making sure they can actually be spent.
+	if len(r.Outpoints) > 0 {
+		coins, err = w.requestedCoins(r, coins)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var selection coinSelection
+	switch {
+	// If there's no funding amount at all (receiving an inbound single
+	// funder request), then we don't need to perform any coin selection at
+	// all.
+	case r.LocalAmt == 0:
+		break
+
+	// In case this request want the fees subtracted from the local amount,
+	// we'll call the specialized method for that. This ensures that we
+	// won't deduct more that the specified balance from our wallet.
+	case r.SubtractFees:
+		selection.coins, selection.localAmt, selection.changeAmt, err =
+			CoinSelectSubtractFees(
+				r.FeeRate, r.LocalAmt, w.cfg.DustLimit, coins,
+			)
+		if err != nil {
+			return nil, err
+		}
+
+	// If the caller picked the coins to use, we'll spend all of them to
+	// target the given funding amount.
+	case len(r.Outpoints) > 0:
+		selection.localAmt = r.LocalAmt
+		selection.coins, selection.changeAmt, err = CoinSelectManual(
+			r.FeeRate, r.LocalAmt, coins,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+	// Otherwise do a normal coin selection where we target a given funding
+	// amount.
+	default:
+		selection.localAmt = r.LocalAmt
+		selection.coins, selection.changeAmt, err = CoinSelect(
+			r.FeeRate, r.LocalAmt, coins,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &selection, nil
+}
+
+// requestedCoins returns the coins matching the outpoints of the request, in
+// the order of the eligible coins. An error is returned if any of the outpoints
+// isn't owned by the CoinSource or isn't eligible for funding.
+func (w *WalletAssembler) requestedCoins(r *Request,
+	eligible []Coin) ([]Coin, error) {
+
+	requested := make(map[wire.OutPoint]struct{}, len(r.Outpoints))
+	for _, op := range r.Outpoints {
+		if _, ok := requested[op]; ok {
+			return nil, fmt.Errorf("outpoint %v requested more "+
+				"than once", op)
+		}
+		requested[op] = struct{}{}
+	}
+
+	coins := make([]Coin, 0, len(requested))
+	for _, coin := range eligible {
+		if _, ok := requested[coin.OutPoint]; !ok {
+			continue
+		}
+
+		coins = append(coins, coin)
+		delete(requested, coin.OutPoint)
+	}
+
+	// Any outpoint that's left either doesn't belong to us or can't be
+	// used for funding at the moment.
+	for _, op := range r.Outpoints {
+		if _, ok := requested[op]; !ok {
+			continue
+		}
+
+		if _, err := w.cfg.CoinSource.CoinFromOutPoint(op); err != nil {
+			return nil, fmt.Errorf("outpoint %v is not an output "+
+				"of the wallet: %v", op, err)
+		}
+
+		return nil, fmt.Errorf("outpoint %v can't be used for "+
+			"funding: it either has less than %v confirmations, "+
+			"is locked, frozen or not a witness output", op,
+			r.MinConfs)
+	}
+
+	return coins, nil
+}
+
+// FundingEstimate is the expected outcome of funding a channel with the coins
+// of the wallet.
+type FundingEstimate struct {
+	// InputCoins are the set of coins that would be selected as inputs to
+	// the funding transaction.
+	InputCoins []Coin
+
+	// LocalFundingAmt is the amount we would contribute to the channel.
+	LocalFundingAmt btcutil.Amount
+
+	// ChangeAmt is the value of the change output of the funding
+	// transaction. This is zero if the change would be dust.
+	ChangeAmt btcutil.Amount
+
+	// FundingFee is the fee paid by the funding transaction, including any
+	// change below the dust limit.
+	FundingFee btcutil.Amount
+}
+
+// EstimateChannel performs the same coin selection as ProvisionChannel for the
+// given request, but without locking any coins or deriving a change address.
+// The returned estimate describes the funding transaction that would be created
+// if the channel was provisioned right away. If the wallet can't afford the
+// channel, an ErrInsufficientFunds error is returned.
+func (w *WalletAssembler) EstimateChannel(r *Request) (*FundingEstimate,
+	error) {
+
+	var estimate *FundingEstimate
+	err := w.cfg.CoinSelectLocker.WithCoinSelectLock(func() error {
+		selection, err := w.selectCoins(r)
+		if err != nil {
+			return err
+		}
+
+		var totalIn btcutil.Amount
+		for _, coin := range selection.coins {
+			totalIn += btcutil.Amount(coin.Value)
+		}
+
+		// Change that would create a dust output is added to the fees
+		// instead.
+		changeAmt := selection.changeAmt
+		if changeAmt <= w.cfg.DustLimit {
+			changeAmt = 0
+		}
+
+		estimate = &FundingEstimate{
+			InputCoins:      selection.coins,
+			LocalFundingAmt: selection.localAmt,
+			ChangeAmt:       changeAmt,
+			FundingFee:      totalIn - selection.localAmt - changeAmt,
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return estimate, nil
+}
+
 // FundingTxAvailable is an empty method that an assembler can implement to
 // signal to callers that its able to provide the funding transaction for the
 // channel via the intent it returns.
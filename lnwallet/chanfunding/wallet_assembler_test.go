@@ -0,0 +1,259 @@
+package chanfunding
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/cryptomeow/lnd/lnwallet/chainfee"
+	"github.com/stretchr/testify/require"
+)
+
+// mockCoinSource is a CoinSource that returns a static set of coins.
+type mockCoinSource struct {
+	coins []Coin
+
+	// ineligible are coins owned by the source that aren't returned by
+	// ListCoins, e.g. because they're not confirmed yet.
+	ineligible []Coin
+}
+
+func (m *mockCoinSource) ListCoins(minConfs, maxConfs int32) ([]Coin, error) {
+	return m.coins, nil
+}
+
+func (m *mockCoinSource) CoinFromOutPoint(op wire.OutPoint) (*Coin, error) {
+	for _, coin := range append(m.coins, m.ineligible...) {
+		if coin.OutPoint == op {
+			coin := coin
+			return &coin, nil
+		}
+	}
+
+	return nil, errors.New("unknown outpoint")
+}
+
+// mockCoinLocker is a CoinSelectionLocker and OutpointLocker that keeps track
+// of the locked outpoints.
+type mockCoinLocker struct {
+	locked map[wire.OutPoint]struct{}
+}
+
+func (m *mockCoinLocker) WithCoinSelectLock(f func() error) error {
+	return f()
+}
+
+func (m *mockCoinLocker) LockOutpoint(o wire.OutPoint) {
+	m.locked[o] = struct{}{}
+}
+
+func (m *mockCoinLocker) UnlockOutpoint(o wire.OutPoint) {
+	delete(m.locked, o)
+}
+
+// TestWalletAssemblerEstimateChannel tests that the WalletAssembler estimates
+// the funding fee and change of a channel without locking any coins.
+func TestWalletAssemblerEstimateChannel(t *testing.T) {
+	t.Parallel()
+
+	const (
+		feeRate   = chainfee.SatPerKWeight(1000)
+		dustLimit = btcutil.Amount(573)
+		coinValue = btcutil.Amount(1000000)
+	)
+
+	coin := Coin{
+		TxOut: wire.TxOut{
+			Value:    int64(coinValue),
+			PkScript: p2wkhScript,
+		},
+		OutPoint: wire.OutPoint{Index: 1},
+	}
+	locker := &mockCoinLocker{
+		locked: make(map[wire.OutPoint]struct{}),
+	}
+	assembler := NewWalletAssembler(WalletConfig{
+		CoinSource:       &mockCoinSource{coins: []Coin{coin}},
+		CoinSelectLocker: locker,
+		CoinLocker:       locker,
+		DustLimit:        dustLimit,
+	})
+
+	feeWithChange := fundingFee(feeRate, 1, true)
+
+	testCases := []struct {
+		name         string
+		localAmt     btcutil.Amount
+		expectedFee  btcutil.Amount
+		expectedChg  btcutil.Amount
+		insufficient bool
+	}{
+		{
+			name:        "change output",
+			localAmt:    500000,
+			expectedFee: feeWithChange,
+			expectedChg: coinValue - 500000 - feeWithChange,
+		},
+		{
+			// The change is dust, so it's added to the fee.
+			name:        "dust change",
+			localAmt:    coinValue - feeWithChange - dustLimit,
+			expectedFee: feeWithChange + dustLimit,
+			expectedChg: 0,
+		},
+		{
+			name:         "insufficient funds",
+			localAmt:     coinValue,
+			insufficient: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			estimate, err := assembler.EstimateChannel(&Request{
+				LocalAmt: testCase.localAmt,
+				MinConfs: 1,
+				FeeRate:  feeRate,
+			})
+			if testCase.insufficient {
+				require.IsType(t, &ErrInsufficientFunds{}, err)
+				return
+			}
+			require.NoError(t, err)
+
+			require.Equal(t, []Coin{coin}, estimate.InputCoins)
+			require.Equal(
+				t, testCase.localAmt, estimate.LocalFundingAmt,
+			)
+			require.Equal(t, testCase.expectedFee, estimate.FundingFee)
+			require.Equal(t, testCase.expectedChg, estimate.ChangeAmt)
+
+			// No coins should have been locked by the estimate.
+			require.Empty(t, locker.locked)
+		})
+	}
+}
+
+// TestWalletAssemblerOutpoints tests that the WalletAssembler funds a channel
+// with exactly the requested outpoints, and rejects outpoints it can't use.
+func TestWalletAssemblerOutpoints(t *testing.T) {
+	t.Parallel()
+
+	const feeRate = chainfee.SatPerKWeight(1000)
+
+	newCoin := func(index uint32, value btcutil.Amount) Coin {
+		return Coin{
+			TxOut: wire.TxOut{
+				Value:    int64(value),
+				PkScript: p2wkhScript,
+			},
+			OutPoint: wire.OutPoint{Index: index},
+		}
+	}
+	coins := []Coin{
+		newCoin(0, 1000000), newCoin(1, 200000), newCoin(2, 300000),
+	}
+	unconfirmed := newCoin(3, 400000)
+
+	locker := &mockCoinLocker{
+		locked: make(map[wire.OutPoint]struct{}),
+	}
+	assembler := NewWalletAssembler(WalletConfig{
+		CoinSource: &mockCoinSource{
+			coins:      coins,
+			ineligible: []Coin{unconfirmed},
+		},
+		CoinSelectLocker: locker,
+		CoinLocker:       locker,
+		DustLimit:        573,
+	})
+
+	testCases := []struct {
+		name      string
+		localAmt  btcutil.Amount
+		outpoints []wire.OutPoint
+		expectErr bool
+	}{
+		{
+			name:     "all outpoints spent",
+			localAmt: 250000,
+			outpoints: []wire.OutPoint{
+				coins[1].OutPoint, coins[2].OutPoint,
+			},
+		},
+		{
+			name:      "insufficient outpoints",
+			localAmt:  500000,
+			outpoints: []wire.OutPoint{coins[1].OutPoint},
+			expectErr: true,
+		},
+		{
+			name:     "duplicate outpoint",
+			localAmt: 100000,
+			outpoints: []wire.OutPoint{
+				coins[1].OutPoint, coins[1].OutPoint,
+			},
+			expectErr: true,
+		},
+		{
+			name:      "unknown outpoint",
+			localAmt:  100000,
+			outpoints: []wire.OutPoint{{Index: 10}},
+			expectErr: true,
+		},
+		{
+			name:      "ineligible outpoint",
+			localAmt:  100000,
+			outpoints: []wire.OutPoint{unconfirmed.OutPoint},
+			expectErr: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			intent, err := assembler.ProvisionChannel(&Request{
+				LocalAmt:  testCase.localAmt,
+				MinConfs:  1,
+				FeeRate:   feeRate,
+				Outpoints: testCase.outpoints,
+				ChangeAddr: func() (btcutil.Address, error) {
+					return btcutil.NewAddressWitnessPubKeyHash(
+						p2wkhScript[2:],
+						&chaincfg.RegressionNetParams,
+					)
+				},
+			})
+			if testCase.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			defer intent.Cancel()
+
+			fullIntent := intent.(*FullIntent)
+			require.Len(t, fullIntent.InputCoins, len(testCase.outpoints))
+			for i, coin := range fullIntent.InputCoins {
+				require.Equal(
+					t, testCase.outpoints[i], coin.OutPoint,
+				)
+			}
+
+			// All of the inputs need to pay for the funding amount,
+			// the fees and the change.
+			var totalIn btcutil.Amount
+			for _, coin := range fullIntent.InputCoins {
+				totalIn += btcutil.Amount(coin.Value)
+			}
+			fee := fundingFee(feeRate, len(testCase.outpoints), true)
+			require.Len(t, fullIntent.ChangeOutputs, 1)
+			require.EqualValues(
+				t, totalIn-testCase.localAmt-fee,
+				fullIntent.ChangeOutputs[0].Value,
+			)
+		})
+	}
+}
@@ -34,6 +34,11 @@ var (
 	// that has already been closed or is in the process of being closed.
 	ErrChanClosing = fmt.Errorf("channel is being closed, operation disallowed")
 
+	// ErrChanUpgradeNotClean is returned when the channel type is to be
+	// upgraded while the channel has pending updates or commitments.
+	ErrChanUpgradeNotClean = fmt.Errorf("channel must be fully synced " +
+		"to upgrade its type")
+
 	// ErrNoWindow is returned when revocation window is exhausted.
 	ErrNoWindow = fmt.Errorf("unable to sign new commitment, the current" +
 		" revocation window is exhausted")
@@ -328,6 +333,14 @@ type PaymentDescriptor struct {
 	// NOTE: Populated only on add payment descriptor entry types.
 	OnionBlob []byte
 
+	// Endorsement is the experimental endorsement signal the remote party
+	// sent along with the HTLC, or nil if it didn't send one. The signal
+	// isn't persisted, so it is lost for HTLCs restored from disk.
+	//
+	// NOTE: Populated only on add payment descriptor entry types of the
+	// remote update log.
+	Endorsement *lnwire.EndorsementSignal
+
 	// ShaOnionBlob is a sha of the onion blob.
 	//
 	// NOTE: Populated only in payment descriptor with MalformedFail type.
@@ -768,6 +781,19 @@ func (c *commitment) toDiskCommit(ourCommit bool) *channeldb.ChannelCommitment {
 	return commit
 }
 
+// commitType returns the channel type our or the remote party's commitment at
+// the given height was created with, as the channel type may have been
+// upgraded in place after the channel was opened.
+func (lc *LightningChannel) commitType(isLocal bool,
+	height uint64) channeldb.ChannelType {
+
+	if isLocal {
+		return lc.channelState.LocalCommitType(height)
+	}
+
+	return lc.channelState.RemoteCommitType(height)
+}
+
 // diskHtlcToPayDesc converts an HTLC previously written to disk within a
 // commitment state to the form required to manipulate in memory within the
 // commitment struct and updateLog. This function is used when we need to
@@ -786,7 +812,9 @@ func (lc *LightningChannel) diskHtlcToPayDesc(feeRate chainfee.SatPerKWeight,
 		ourWitnessScript, theirWitnessScript []byte
 		pd                                   PaymentDescriptor
 		err                                  error
-		chanType                             = lc.channelState.ChanType
+		chanType                             = lc.commitType(
+			isLocal, commitHeight,
+		)
 	)
 
 	// If the either outputs is dust from the local or remote node's
@@ -909,17 +937,19 @@ func (lc *LightningChannel) diskCommitToMemCommit(isLocal bool,
 	// (we extended but weren't able to complete the commitment dance
 	// before shutdown), then the localCommitPoint won't be set as we
 	// haven't yet received a responding commitment from the remote party.
+	chanType := lc.commitType(isLocal, diskCommit.CommitHeight)
+
 	var localCommitKeys, remoteCommitKeys *CommitmentKeyRing
 	if localCommitPoint != nil {
 		localCommitKeys = DeriveCommitmentKeys(
-			localCommitPoint, true, lc.channelState.ChanType,
+			localCommitPoint, true, chanType,
 			&lc.channelState.LocalChanCfg,
 			&lc.channelState.RemoteChanCfg,
 		)
 	}
 	if remoteCommitPoint != nil {
 		remoteCommitKeys = DeriveCommitmentKeys(
-			remoteCommitPoint, false, lc.channelState.ChanType,
+			remoteCommitPoint, false, chanType,
 			&lc.channelState.LocalChanCfg,
 			&lc.channelState.RemoteChanCfg,
 		)
@@ -1299,9 +1329,47 @@ type LightningChannel struct {
 	// log is a channel-specific logging instance.
 	log btclog.Logger
 
+	// opts is the set of options the channel was created with.
+	opts *channelOpts
+
 	sync.RWMutex
 }
 
+// DefaultFeeBufferMultiplier is the default multiple of the current commitment
+// fee rate that the initiator of a channel keeps affordable.
+const DefaultFeeBufferMultiplier = 2
+
+// channelOpts is the set of options used to create a new channel.
+type channelOpts struct {
+	// feeBufferMultiplier is the factor by which the current commitment
+	// fee rate is multiplied to determine the fee that must remain
+	// affordable when we're the initiator of the channel.
+	feeBufferMultiplier uint32
+}
+
+// ChannelOpt is a functional option that lets callers modify how a new channel
+// is created.
+type ChannelOpt func(*channelOpts)
+
+// WithFeeBuffer returns a channel option that makes the initiator retain a fee
+// buffer. The balance that is reported as available for new htlcs is reduced,
+// such that the commitment fee with one more htlc remains affordable even if
+// the fee rate rises to the given multiple of the current fee rate. This
+// ensures there's always headroom for a fee update, so that commitment updates
+// don't get stuck. A multiplier of one or less disables the buffer.
+func WithFeeBuffer(multiplier uint32) ChannelOpt {
+	return func(o *channelOpts) {
+		o.feeBufferMultiplier = multiplier
+	}
+}
+
+// defaultChannelOpts returns the default set of channel options.
+func defaultChannelOpts() *channelOpts {
+	return &channelOpts{
+		feeBufferMultiplier: 1,
+	}
+}
+
 // NewLightningChannel creates a new, active payment channel given an
 // implementation of the chain notifier, channel database, and the current
 // settled channel state. Throughout state transitions, then channel will
@@ -1309,7 +1377,12 @@ type LightningChannel struct {
 // manner.
 func NewLightningChannel(signer input.Signer,
 	state *channeldb.OpenChannel,
-	sigPool *SigPool) (*LightningChannel, error) {
+	sigPool *SigPool, chanOpts ...ChannelOpt) (*LightningChannel, error) {
+
+	opts := defaultChannelOpts()
+	for _, optFunc := range chanOpts {
+		optFunc(opts)
+	}
 
 	localCommit := state.LocalCommitment
 	remoteCommit := state.RemoteCommitment
@@ -1340,6 +1413,7 @@ func NewLightningChannel(signer input.Signer,
 		LocalFundingKey:   state.LocalChanCfg.MultiSigKey.PubKey,
 		RemoteFundingKey:  state.RemoteChanCfg.MultiSigKey.PubKey,
 		log:               build.NewPrefixLog(logPrefix, walletLog),
+		opts:              opts,
 	}
 
 	// With the main channel struct reconstructed, we'll now restore the
@@ -1438,13 +1512,14 @@ func (lc *LightningChannel) logUpdateToPayDesc(logUpdate *channeldb.LogUpdate,
 		pd.OnionBlob = make([]byte, len(wireMsg.OnionBlob))
 		copy(pd.OnionBlob[:], wireMsg.OnionBlob[:])
 
+		chanType := lc.channelState.RemoteCommitType(commitHeight)
 		isDustRemote := htlcIsDust(
-			lc.channelState.ChanType, false, false, feeRate,
+			chanType, false, false, feeRate,
 			wireMsg.Amount.ToSatoshis(), remoteDustLimit,
 		)
 		if !isDustRemote {
 			theirP2WSH, theirWitnessScript, err := genHtlcScript(
-				lc.channelState.ChanType, false, false,
+				chanType, false, false,
 				wireMsg.Expiry, wireMsg.PaymentHash,
 				remoteCommitKeys,
 			)
@@ -1806,7 +1881,10 @@ func (lc *LightningChannel) restoreCommitState(
 		// We'll also re-create the set of commitment keys needed to
 		// fully re-derive the state.
 		pendingRemoteKeyChain = DeriveCommitmentKeys(
-			pendingCommitPoint, false, lc.channelState.ChanType,
+			pendingCommitPoint, false,
+			lc.channelState.RemoteCommitType(
+				pendingRemoteCommit.height,
+			),
 			&lc.channelState.LocalChanCfg, &lc.channelState.RemoteChanCfg,
 		)
 	}
@@ -2293,6 +2371,10 @@ func NewBreachRetribution(chanState *channeldb.OpenChannel, stateNum uint64,
 
 	commitHash := revokedSnapshot.CommitTx.TxHash()
 
+	// The revoked state may have been created before the channel type was
+	// upgraded, so we'll use the type the state was created with.
+	chanType := chanState.RemoteCommitType(stateNum)
+
 	// With the state number broadcast known, we can now derive/restore the
 	// proper revocation preimage necessary to sweep the remote party's
 	// output.
@@ -2307,7 +2389,7 @@ func NewBreachRetribution(chanState *channeldb.OpenChannel, stateNum uint64,
 	// With the commitment point generated, we can now generate the four
 	// keys we'll need to reconstruct the commitment state,
 	keyRing := DeriveCommitmentKeys(
-		commitmentPoint, false, chanState.ChanType,
+		commitmentPoint, false, chanType,
 		&chanState.LocalChanCfg, &chanState.RemoteChanCfg,
 	)
 
@@ -2329,7 +2411,7 @@ func NewBreachRetribution(chanState *channeldb.OpenChannel, stateNum uint64,
 	// Since it is the remote breach we are reconstructing, the output going
 	// to us will be a to-remote script with our local params.
 	ourScript, ourDelay, err := CommitScriptToRemote(
-		chanState.ChanType, keyRing.ToRemoteKey,
+		chanType, keyRing.ToRemoteKey,
 	)
 	if err != nil {
 		return nil, err
@@ -2402,7 +2484,7 @@ func NewBreachRetribution(chanState *channeldb.OpenChannel, stateNum uint64,
 		// If the HTLC is dust, then we'll skip it as it doesn't have
 		// an output on the commitment transaction.
 		if htlcIsDust(
-			chanState.ChanType, htlc.Incoming, false,
+			chanType, htlc.Incoming, false,
 			chainfee.SatPerKWeight(revokedSnapshot.FeePerKw),
 			htlc.Amt.ToSatoshis(), chanState.RemoteChanCfg.DustLimit,
 		) {
@@ -2426,7 +2508,7 @@ func NewBreachRetribution(chanState *channeldb.OpenChannel, stateNum uint64,
 		// an outgoing HTLC that we sent, then from the PoV of the
 		// remote commitment state, they're the receiver of this HTLC.
 		htlcPkScript, htlcWitnessScript, err := genHtlcScript(
-			chanState.ChanType, htlc.Incoming, false,
+			chanType, htlc.Incoming, false,
 			htlc.RefundTimeout, htlc.RHash, keyRing,
 		)
 		if err != nil {
@@ -3549,8 +3631,10 @@ func (lc *LightningChannel) SignNextCommitment() (lnwire.Sig, []lnwire.Sig, []ch
 	// Grab the next commitment point for the remote party. This will be
 	// used within fetchCommitmentView to derive all the keys necessary to
 	// construct the commitment state.
+	nextHeight := lc.remoteCommitChain.tip().height + 1
+	chanType := lc.channelState.RemoteCommitType(nextHeight)
 	keyRing := DeriveCommitmentKeys(
-		commitPoint, false, lc.channelState.ChanType,
+		commitPoint, false, chanType,
 		&lc.channelState.LocalChanCfg, &lc.channelState.RemoteChanCfg,
 	)
 
@@ -3588,7 +3672,7 @@ func (lc *LightningChannel) SignNextCommitment() (lnwire.Sig, []lnwire.Sig, []ch
 	// commitment state. We do so in two phases: first we generate and
 	// submit the set of signature jobs to the worker pool.
 	sigBatch, cancelChan, err := genRemoteHtlcSigJobs(
-		keyRing, lc.channelState.ChanType,
+		keyRing, chanType,
 		&lc.channelState.LocalChanCfg, &lc.channelState.RemoteChanCfg,
 		newCommitView,
 	)
@@ -4320,8 +4404,9 @@ func (lc *LightningChannel) ReceiveNewCommitment(commitSig lnwire.Sig,
 		return err
 	}
 	commitPoint := input.ComputeCommitmentPoint(commitSecret[:])
+	chanType := lc.channelState.LocalCommitType(nextHeight)
 	keyRing := DeriveCommitmentKeys(
-		commitPoint, true, lc.channelState.ChanType,
+		commitPoint, true, chanType,
 		&lc.channelState.LocalChanCfg, &lc.channelState.RemoteChanCfg,
 	)
 
@@ -4371,7 +4456,7 @@ func (lc *LightningChannel) ReceiveNewCommitment(commitSig lnwire.Sig,
 	// generated, we'll submit these jobs to the worker pool.
 	verifyJobs, err := genHtlcSigValidationJobs(
 		localCommitmentView, keyRing, htlcSigs,
-		lc.channelState.ChanType, &lc.channelState.LocalChanCfg,
+		chanType, &lc.channelState.LocalChanCfg,
 		&lc.channelState.RemoteChanCfg,
 	)
 	if err != nil {
@@ -4504,17 +4589,99 @@ func (lc *LightningChannel) oweCommitment(local bool) bool {
 			lastLocalCommit.ourMessageIndex
 	}
 
+	// If the channel type was upgraded, but the commitment chain doesn't
+	// contain a commitment of the new type yet, a commitment signature is
+	// owed even without any pending updates.
+	var upgradePending bool
+	if lc.channelState.IsUpgraded() {
+		if local {
+			upgradePending = lastRemoteCommit.height <
+				lc.channelState.UpgradeRemoteHeight
+		} else {
+			upgradePending = lastLocalCommit.height <
+				lc.channelState.UpgradeLocalHeight
+		}
+	}
+
 	// If any of the conditions above is true, we owe a commitment
 	// signature.
-	oweCommitment := localUpdatesPending || remoteUpdatesPending
+	oweCommitment := localUpdatesPending || remoteUpdatesPending ||
+		upgradePending
 
 	lc.log.Tracef("%v owes commit: %v (local updates: %v, "+
-		"remote updates %v)", perspective, oweCommitment,
-		localUpdatesPending, remoteUpdatesPending)
+		"remote updates %v, upgrade pending: %v)", perspective,
+		oweCommitment, localUpdatesPending, remoteUpdatesPending,
+		upgradePending)
 
 	return oweCommitment
 }
 
+// UpgradeChanType upgrades the commitment format of the channel in place to
+// the given channel type. The channel must be fully synced without any HTLCs,
+// as the next commitments of both parties will use the new type. Once the
+// channel type is upgraded, both parties owe each other a commitment of the
+// new type, which can be checked with UpgradePending.
+func (lc *LightningChannel) UpgradeChanType(
+	newType channeldb.ChannelType) error {
+
+	lc.Lock()
+	defer lc.Unlock()
+
+	if lc.oweCommitment(true) || lc.oweCommitment(false) ||
+		lc.remoteCommitChain.hasUnackedCommitment() ||
+		lc.localCommitChain.tip().height !=
+			lc.localCommitChain.tail().height {
+
+		return ErrChanUpgradeNotClean
+	}
+
+	if !lc.channelState.ChanType.IsValidUpgrade(newType) {
+		return fmt.Errorf("invalid channel type upgrade from %v to %v",
+			lc.channelState.ChanType, newType)
+	}
+
+	// The initiator pays for the commitment fee and the anchors, so we'll
+	// make sure it can afford the commitment of the new type at the
+	// current fee rate.
+	localCommit := lc.channelState.LocalCommitment
+	initiatorBalance := localCommit.RemoteBalance.ToSatoshis()
+	if lc.channelState.IsInitiator {
+		initiatorBalance = localCommit.LocalBalance.ToSatoshis()
+	}
+
+	feePerKw := chainfee.SatPerKWeight(localCommit.FeePerKw)
+	newFee := feePerKw.FeeForWeight(CommitWeight(newType))
+	if newType.HasAnchors() && !lc.channelState.ChanType.HasAnchors() {
+		newFee += 2 * anchorSize
+	}
+	if initiatorBalance+localCommit.CommitFee < newFee {
+		return fmt.Errorf("initiator balance of %v can't pay for "+
+			"upgraded commitment fee of %v", initiatorBalance,
+			newFee)
+	}
+
+	lc.log.Infof("Upgrading channel type from %v to %v",
+		lc.channelState.ChanType, newType)
+
+	return lc.channelState.UpgradeChanType(newType)
+}
+
+// UpgradePending returns true if the channel type was upgraded, but the
+// current commitment of at least one party still uses the prior channel type.
+func (lc *LightningChannel) UpgradePending() bool {
+	lc.RLock()
+	defer lc.RUnlock()
+
+	if !lc.channelState.IsUpgraded() {
+		return false
+	}
+
+	return lc.localCommitChain.tail().height <
+		lc.channelState.UpgradeLocalHeight ||
+		lc.remoteCommitChain.tail().height <
+			lc.channelState.UpgradeRemoteHeight
+}
+
 // PendingLocalUpdateCount returns the number of local updates that still need
 // to be applied to the remote commitment tx.
 func (lc *LightningChannel) PendingLocalUpdateCount() uint64 {
@@ -4957,13 +5124,14 @@ func (lc *LightningChannel) ReceiveHTLC(htlc *lnwire.UpdateAddHTLC) (uint64, err
 	}
 
 	pd := &PaymentDescriptor{
-		EntryType: Add,
-		RHash:     PaymentHash(htlc.PaymentHash),
-		Timeout:   htlc.Expiry,
-		Amount:    htlc.Amount,
-		LogIndex:  lc.remoteUpdateLog.logIndex,
-		HtlcIndex: lc.remoteUpdateLog.htlcCounter,
-		OnionBlob: htlc.OnionBlob[:],
+		EntryType:   Add,
+		RHash:       PaymentHash(htlc.PaymentHash),
+		Timeout:     htlc.Expiry,
+		Amount:      htlc.Amount,
+		LogIndex:    lc.remoteUpdateLog.logIndex,
+		HtlcIndex:   lc.remoteUpdateLog.htlcCounter,
+		OnionBlob:   htlc.OnionBlob[:],
+		Endorsement: htlc.Endorsement,
 	}
 
 	localACKedIndex := lc.remoteCommitChain.tail().ourMessageIndex
@@ -5384,10 +5552,14 @@ func NewUnilateralCloseSummary(chanState *channeldb.OpenChannel, signer input.Si
 	remoteCommit channeldb.ChannelCommitment,
 	commitPoint *btcec.PublicKey) (*UnilateralCloseSummary, error) {
 
+	// The broadcast commitment may have been created before the channel
+	// type was upgraded, so we'll use the type it was created with.
+	chanType := chanState.RemoteCommitType(remoteCommit.CommitHeight)
+
 	// First, we'll generate the commitment point and the revocation point
 	// so we can re-construct the HTLC state and also our payment key.
 	keyRing := DeriveCommitmentKeys(
-		commitPoint, false, chanState.ChanType,
+		commitPoint, false, chanType,
 		&chanState.LocalChanCfg, &chanState.RemoteChanCfg,
 	)
 
@@ -5397,7 +5569,7 @@ func NewUnilateralCloseSummary(chanState *channeldb.OpenChannel, signer input.Si
 		chainfee.SatPerKWeight(remoteCommit.FeePerKw), false, signer,
 		remoteCommit.Htlcs, keyRing, &chanState.LocalChanCfg,
 		&chanState.RemoteChanCfg, *commitSpend.SpenderTxHash,
-		chanState.ChanType,
+		chanType,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create htlc "+
@@ -5410,7 +5582,7 @@ func NewUnilateralCloseSummary(chanState *channeldb.OpenChannel, signer input.Si
 	// locate the output index of our non-delayed output on the commitment
 	// transaction.
 	selfScript, maturityDelay, err := CommitScriptToRemote(
-		chanState.ChanType, keyRing.ToRemoteKey,
+		chanType, keyRing.ToRemoteKey,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create self commit "+
@@ -5481,7 +5653,7 @@ func NewUnilateralCloseSummary(chanState *channeldb.OpenChannel, signer input.Si
 	}
 
 	anchorResolution, err := NewAnchorResolution(
-		chanState, commitTxBroadcast,
+		chanState, chanType, commitTxBroadcast,
 	)
 	if err != nil {
 		return nil, err
@@ -5969,6 +6141,21 @@ type AnchorResolution struct {
 	CommitWeight int64
 }
 
+// AnchorResolutions is a set of anchor resolutions that's being used when
+// sweeping anchors during local channel force close.
+type AnchorResolutions struct {
+	// Local is the anchor resolution for the local commitment tx.
+	Local *AnchorResolution
+
+	// Remote is the anchor resolution for the remote commitment tx.
+	Remote *AnchorResolution
+
+	// RemotePending is the anchor resolution for the remote pending
+	// commitment tx. The value will be non-nil iff we've created a new
+	// commitment tx for the remote party which they haven't ACKed yet.
+	RemotePending *AnchorResolution
+}
+
 // LocalForceCloseSummary describes the final commitment state before the
 // channel is locked-down to initiate a force closure by broadcasting the
 // latest state on-chain. If we intend to broadcast this this state, the
@@ -6058,6 +6245,10 @@ func NewLocalForceCloseSummary(chanState *channeldb.OpenChannel, signer input.Si
 	commitTx *wire.MsgTx, localCommit channeldb.ChannelCommitment) (
 	*LocalForceCloseSummary, error) {
 
+	// Our commitment may have been created before the channel type was
+	// upgraded, so we'll use the type it was created with.
+	chanType := chanState.LocalCommitType(localCommit.CommitHeight)
+
 	// Re-derive the original pkScript for to-self output within the
 	// commitment transaction. We'll need this to find the corresponding
 	// output in the commitment transaction and potentially for creating
@@ -6071,7 +6262,7 @@ func NewLocalForceCloseSummary(chanState *channeldb.OpenChannel, signer input.Si
 	}
 	commitPoint := input.ComputeCommitmentPoint(revocation[:])
 	keyRing := DeriveCommitmentKeys(
-		commitPoint, true, chanState.ChanType,
+		commitPoint, true, chanType,
 		&chanState.LocalChanCfg, &chanState.RemoteChanCfg,
 	)
 
@@ -6138,14 +6329,14 @@ func NewLocalForceCloseSummary(chanState *channeldb.OpenChannel, signer input.Si
 	htlcResolutions, err := extractHtlcResolutions(
 		chainfee.SatPerKWeight(localCommit.FeePerKw), true, signer,
 		localCommit.Htlcs, keyRing, &chanState.LocalChanCfg,
-		&chanState.RemoteChanCfg, txHash, chanState.ChanType,
+		&chanState.RemoteChanCfg, txHash, chanType,
 	)
 	if err != nil {
 		return nil, err
 	}
 
 	anchorResolution, err := NewAnchorResolution(
-		chanState, commitTx,
+		chanState, chanType, commitTx,
 	)
 	if err != nil {
 		return nil, err
@@ -6311,35 +6502,37 @@ func (lc *LightningChannel) CompleteCooperativeClose(
 // NewAnchorResolutions returns the anchor resolutions for all currently valid
 // commitment transactions. Because we have no view on the mempool, we can only
 // blindly anchor all of these txes down.
-func (lc *LightningChannel) NewAnchorResolutions() ([]*AnchorResolution,
+func (lc *LightningChannel) NewAnchorResolutions() (*AnchorResolutions,
 	error) {
 
 	lc.Lock()
 	defer lc.Unlock()
 
-	var resolutions []*AnchorResolution
+	var resolutions AnchorResolutions
 
 	// Add anchor for local commitment tx, if any.
+	localCommit := lc.channelState.LocalCommitment
 	localRes, err := NewAnchorResolution(
-		lc.channelState, lc.channelState.LocalCommitment.CommitTx,
+		lc.channelState,
+		lc.channelState.LocalCommitType(localCommit.CommitHeight),
+		localCommit.CommitTx,
 	)
 	if err != nil {
 		return nil, err
 	}
-	if localRes != nil {
-		resolutions = append(resolutions, localRes)
-	}
+	resolutions.Local = localRes
 
 	// Add anchor for remote commitment tx, if any.
+	remoteCommit := lc.channelState.RemoteCommitment
 	remoteRes, err := NewAnchorResolution(
-		lc.channelState, lc.channelState.RemoteCommitment.CommitTx,
+		lc.channelState,
+		lc.channelState.RemoteCommitType(remoteCommit.CommitHeight),
+		remoteCommit.CommitTx,
 	)
 	if err != nil {
 		return nil, err
 	}
-	if remoteRes != nil {
-		resolutions = append(resolutions, remoteRes)
-	}
+	resolutions.Remote = remoteRes
 
 	// Add anchor for remote pending commitment tx, if any.
 	remotePendingCommit, err := lc.channelState.RemoteCommitChainTip()
@@ -6348,29 +6541,32 @@ func (lc *LightningChannel) NewAnchorResolutions() ([]*AnchorResolution,
 	}
 
 	if remotePendingCommit != nil {
+		pendingCommit := remotePendingCommit.Commitment
 		remotePendingRes, err := NewAnchorResolution(
 			lc.channelState,
-			remotePendingCommit.Commitment.CommitTx,
+			lc.channelState.RemoteCommitType(
+				pendingCommit.CommitHeight,
+			),
+			pendingCommit.CommitTx,
 		)
 		if err != nil {
 			return nil, err
 		}
-
-		if remotePendingRes != nil {
-			resolutions = append(resolutions, remotePendingRes)
-		}
+		resolutions.RemotePending = remotePendingRes
 	}
 
-	return resolutions, nil
+	return &resolutions, nil
 }
 
 // NewAnchorResolution returns the information that is required to sweep the
-// local anchor.
+// local anchor. The passed channel type is the one the commitment transaction
+// was created with.
 func NewAnchorResolution(chanState *channeldb.OpenChannel,
-	commitTx *wire.MsgTx) (*AnchorResolution, error) {
+	chanType channeldb.ChannelType, commitTx *wire.MsgTx) (
+	*AnchorResolution, error) {
 
-	// Return nil resolution if the channel has no anchors.
-	if !chanState.ChanType.HasAnchors() {
+	// Return nil resolution if the commitment has no anchors.
+	if !chanType.HasAnchors() {
 		return nil, nil
 	}
 
@@ -6517,6 +6713,20 @@ func (lc *LightningChannel) availableCommitmentBalance(view *htlcView,
 		feePerKw.FeeForWeight(commitWeight + input.HTLCWeight),
 	)
 
+	// If we're the initiator and a fee buffer is configured, we'll make
+	// sure the commitment fee remains affordable at a multiple of the
+	// current fee rate, leaving headroom for future fee updates.
+	if lc.channelState.IsInitiator && lc.opts.feeBufferMultiplier > 1 {
+		bufferFeePerKw := feePerKw * chainfee.SatPerKWeight(
+			lc.opts.feeBufferMultiplier,
+		)
+		htlcCommitFee = lnwire.NewMSatFromSatoshis(
+			bufferFeePerKw.FeeForWeight(
+				commitWeight + input.HTLCWeight,
+			),
+		)
+	}
+
 	// If we are the channel initiator, we must to subtract this commitment
 	// fee from our available balance in order to ensure we can afford both
 	// the value of the HTLC and the additional commitment fee from adding
@@ -6830,6 +7040,109 @@ func (lc *LightningChannel) CommitFeeRate() chainfee.SatPerKWeight {
 	return chainfee.SatPerKWeight(lc.channelState.LocalCommitment.FeePerKw)
 }
 
+// CommitFeeRateBounds returns the minimum and maximum fee rate the commitment
+// fee rate is pinned to by the user. A bound of zero isn't enforced.
+func (lc *LightningChannel) CommitFeeRateBounds() (chainfee.SatPerKWeight,
+	chainfee.SatPerKWeight) {
+
+	minFeeRate, maxFeeRate := lc.channelState.CommitFeeRateBounds()
+
+	return chainfee.SatPerKWeight(minFeeRate),
+		chainfee.SatPerKWeight(maxFeeRate)
+}
+
+// SetCommitFeeRateBounds persists the minimum and maximum fee rate the
+// commitment fee rate is pinned to. A bound of zero isn't enforced.
+func (lc *LightningChannel) SetCommitFeeRateBounds(minFeeRate,
+	maxFeeRate chainfee.SatPerKWeight) error {
+
+	return lc.channelState.SetCommitFeeRateBounds(
+		btcutil.Amount(minFeeRate), btcutil.Amount(maxFeeRate),
+	)
+}
+
+// IsHtlcDust returns whether an incoming or outgoing htlc of the given amount
+// is dust on the local or remote commitment transaction, given the current fee
+// rate of that commitment.
+func (lc *LightningChannel) IsHtlcDust(amt lnwire.MilliSatoshi, incoming,
+	remote bool) bool {
+
+	lc.RLock()
+	defer lc.RUnlock()
+
+	return lc.isHtlcDust(amt, incoming, remote)
+}
+
+// isHtlcDust returns whether an incoming or outgoing htlc of the given amount
+// is dust on the local or remote commitment transaction.
+//
+// NOTE: This method must be called with the channel's mutex held.
+func (lc *LightningChannel) isHtlcDust(amt lnwire.MilliSatoshi, incoming,
+	remote bool) bool {
+
+	dustLimit := lc.channelState.LocalChanCfg.DustLimit
+	commit := lc.channelState.LocalCommitment
+	if remote {
+		dustLimit = lc.channelState.RemoteChanCfg.DustLimit
+		commit = lc.channelState.RemoteCommitment
+	}
+
+	return htlcIsDust(
+		lc.channelState.ChanType, incoming, !remote,
+		chainfee.SatPerKWeight(commit.FeePerKw), amt.ToSatoshis(),
+		dustLimit,
+	)
+}
+
+// DustSum returns the total amount of the htlcs in the update logs that are
+// dust on the local or remote commitment transaction. Htlcs that are pending
+// removal are still counted, as they remain on the commitment until the
+// removal is locked in, so the returned sum may over-estimate the dust
+// exposure of the channel.
+func (lc *LightningChannel) DustSum(remote bool) lnwire.MilliSatoshi {
+	lc.RLock()
+	defer lc.RUnlock()
+
+	var dustSum lnwire.MilliSatoshi
+	sumLog := func(log *updateLog, incoming bool) {
+		for e := log.Front(); e != nil; e = e.Next() {
+			pd := e.Value.(*PaymentDescriptor)
+			if pd.EntryType != Add {
+				continue
+			}
+
+			if lc.isHtlcDust(pd.Amount, incoming, remote) {
+				dustSum += pd.Amount
+			}
+		}
+	}
+
+	// Our own update log holds the htlcs we offered, the remote update log
+	// the htlcs offered to us.
+	sumLog(lc.localUpdateLog, false)
+	sumLog(lc.remoteUpdateLog, true)
+
+	return dustSum
+}
+
+// NumOutgoingHtlcs returns the number of htlcs offered by us that are in the
+// local update log. Like DustSum, htlcs that are pending removal are still
+// counted, so the returned number may over-estimate the commitment slots
+// occupied by our htlcs.
+func (lc *LightningChannel) NumOutgoingHtlcs() int {
+	lc.RLock()
+	defer lc.RUnlock()
+
+	var numHtlcs int
+	for e := lc.localUpdateLog.Front(); e != nil; e = e.Next() {
+		if e.Value.(*PaymentDescriptor).EntryType == Add {
+			numHtlcs++
+		}
+	}
+
+	return numHtlcs
+}
+
 // IsPending returns true if the channel's funding transaction has been fully
 // confirmed, and false otherwise.
 func (lc *LightningChannel) IsPending() bool {
@@ -917,13 +917,16 @@ func testForceClose(t *testing.T, testCase *forceCloseTestCase) {
 		}
 
 		// Check the pre-confirmation resolutions.
-		resList, err := aliceChannel.NewAnchorResolutions()
+		resolutions, err := aliceChannel.NewAnchorResolutions()
 		if err != nil {
 			t.Fatalf("pre-confirmation resolution error: %v", err)
 		}
 
-		if len(resList) != 2 {
-			t.Fatal("expected two resolutions")
+		if resolutions.Local == nil || resolutions.Remote == nil {
+			t.Fatal("expected local and remote resolutions")
+		}
+		if resolutions.RemotePending != nil {
+			t.Fatal("expected no remote pending resolution")
 		}
 	}
 
@@ -1433,6 +1436,93 @@ func TestHTLCDustLimit(t *testing.T) {
 	}
 }
 
+// TestDustSum tests that the dust sum of a channel includes all htlcs that are
+// dust on the requested commitment.
+func TestDustSum(t *testing.T) {
+	t.Parallel()
+
+	aliceChannel, bobChannel, cleanUp, err := CreateTestChannels(
+		channeldb.SingleFunderTweaklessBit,
+	)
+	require.NoError(t, err)
+	defer cleanUp()
+
+	// We'll add three htlcs from Alice to Bob: one that is dust on both
+	// commitments, one that is only dust on Bob's commitment, as it lies
+	// between the dust limits of Alice and Bob, and one that isn't dust
+	// at all.
+	feeRate := chainfee.SatPerKWeight(
+		aliceChannel.channelState.LocalCommitment.FeePerKw,
+	)
+	timeoutFee := HtlcTimeoutFee(
+		aliceChannel.channelState.ChanType, feeRate,
+	)
+	bothDust := lnwire.NewMSatFromSatoshis(100)
+	bobDust := lnwire.NewMSatFromSatoshis(500 + timeoutFee)
+	noDust := lnwire.NewMSatFromSatoshis(100000)
+
+	for i, amt := range []lnwire.MilliSatoshi{bothDust, bobDust, noDust} {
+		htlc, _ := createHTLC(i, amt)
+		_, err := aliceChannel.AddHTLC(htlc, nil)
+		require.NoError(t, err)
+		_, err = bobChannel.ReceiveHTLC(htlc)
+		require.NoError(t, err)
+	}
+
+	require.True(t, aliceChannel.IsHtlcDust(bobDust, false, true))
+	require.False(t, aliceChannel.IsHtlcDust(bobDust, false, false))
+	require.True(t, bobChannel.IsHtlcDust(bobDust, true, false))
+	require.False(t, bobChannel.IsHtlcDust(bobDust, true, true))
+
+	// The sums must be the same before and after the htlcs are locked in.
+	assertDustSums := func() {
+		require.Equal(t, bothDust, aliceChannel.DustSum(false))
+		require.Equal(t, bothDust+bobDust, aliceChannel.DustSum(true))
+		require.Equal(t, bothDust+bobDust, bobChannel.DustSum(false))
+		require.Equal(t, bothDust, bobChannel.DustSum(true))
+	}
+	assertDustSums()
+
+	require.NoError(t, ForceStateTransition(aliceChannel, bobChannel))
+	assertDustSums()
+}
+
+// TestNumOutgoingHtlcs tests that only the htlcs offered by the local party are
+// counted as outgoing htlcs.
+func TestNumOutgoingHtlcs(t *testing.T) {
+	t.Parallel()
+
+	aliceChannel, bobChannel, cleanUp, err := CreateTestChannels(
+		channeldb.SingleFunderTweaklessBit,
+	)
+	require.NoError(t, err)
+	defer cleanUp()
+
+	amt := lnwire.NewMSatFromSatoshis(100000)
+	for i := 0; i < 2; i++ {
+		htlc, _ := createHTLC(i, amt)
+		_, err := aliceChannel.AddHTLC(htlc, nil)
+		require.NoError(t, err)
+		_, err = bobChannel.ReceiveHTLC(htlc)
+		require.NoError(t, err)
+	}
+
+	htlc, _ := createHTLC(0, amt)
+	_, err = bobChannel.AddHTLC(htlc, nil)
+	require.NoError(t, err)
+	_, err = aliceChannel.ReceiveHTLC(htlc)
+	require.NoError(t, err)
+
+	// The counts must be the same before and after the htlcs are locked
+	// in.
+	require.Equal(t, 2, aliceChannel.NumOutgoingHtlcs())
+	require.Equal(t, 1, bobChannel.NumOutgoingHtlcs())
+
+	require.NoError(t, ForceStateTransition(aliceChannel, bobChannel))
+	require.Equal(t, 2, aliceChannel.NumOutgoingHtlcs())
+	require.Equal(t, 1, bobChannel.NumOutgoingHtlcs())
+}
+
 // TestHTLCSigNumber tests that a received commitment is only accepted if it
 // comes with the exact number of valid HTLC signatures.
 func TestHTLCSigNumber(t *testing.T) {
@@ -5331,6 +5421,60 @@ func TestChanAvailableBalanceNearHtlcFee(t *testing.T) {
 	checkBalance(t, expAliceBalance, expBobBalance)
 }
 
+// TestChanAvailableBalanceFeeBuffer checks that the balance reported as
+// available for new htlcs retains a buffer for a rise of the commitment fee
+// rate if we're the initiator of the channel.
+func TestChanAvailableBalanceFeeBuffer(t *testing.T) {
+	t.Parallel()
+
+	aliceChannel, bobChannel, cleanUp, err := CreateTestChannels(
+		channeldb.SingleFunderTweaklessBit,
+	)
+	require.NoError(t, err)
+	defer cleanUp()
+
+	aliceBalance := lnwire.NewMSatFromSatoshis(5 * btcutil.SatoshiPerBitcoin)
+	bobBalance := lnwire.NewMSatFromSatoshis(5 * btcutil.SatoshiPerBitcoin)
+
+	aliceReserve := lnwire.NewMSatFromSatoshis(
+		aliceChannel.channelState.LocalChanCfg.ChanReserve,
+	)
+	bobReserve := lnwire.NewMSatFromSatoshis(
+		bobChannel.channelState.LocalChanCfg.ChanReserve,
+	)
+	feeRate := chainfee.SatPerKWeight(
+		aliceChannel.channelState.LocalCommitment.FeePerKw,
+	)
+	commitWeight := CommitWeight(aliceChannel.channelState.ChanType)
+
+	// Without a buffer, Alice only needs to afford the commitment fee with
+	// one more htlc at the current fee rate.
+	htlcCommitFee := lnwire.NewMSatFromSatoshis(
+		feeRate.FeeForWeight(commitWeight + input.HTLCWeight),
+	)
+	require.Equal(
+		t, aliceBalance-aliceReserve-htlcCommitFee,
+		aliceChannel.AvailableBalance(),
+	)
+
+	// With a buffer of twice the current fee rate, the commitment fee
+	// must remain affordable at the doubled fee rate.
+	WithFeeBuffer(2)(aliceChannel.opts)
+	WithFeeBuffer(2)(bobChannel.opts)
+
+	bufferCommitFee := lnwire.NewMSatFromSatoshis(
+		(2 * feeRate).FeeForWeight(commitWeight + input.HTLCWeight),
+	)
+	require.Equal(
+		t, aliceBalance-aliceReserve-bufferCommitFee,
+		aliceChannel.AvailableBalance(),
+	)
+
+	// Bob isn't the initiator, so he doesn't pay any fees and his balance
+	// isn't affected by the buffer.
+	require.Equal(t, bobBalance-bobReserve, bobChannel.AvailableBalance())
+}
+
 // TestSignCommitmentFailNotLockedIn tests that a channel will not attempt to
 // create a new state if it doesn't yet know of the next revocation point for
 // the remote party.
@@ -9361,3 +9505,155 @@ func TestChannelLocalUnsignedUpdatesFailure(t *testing.T) {
 	err = newAliceChannel.ReceiveNewCommitment(bobSig, bobHtlcSigs)
 	require.NoError(t, err)
 }
+
+// TestChannelUpgradeChanType tests that a channel can be upgraded to anchor
+// outputs in place, and that the next commitments of both parties use the new
+// channel type.
+func TestChannelUpgradeChanType(t *testing.T) {
+	t.Parallel()
+
+	aliceChannel, bobChannel, cleanUp, err := CreateTestChannels(
+		channeldb.SingleFunderTweaklessBit,
+	)
+	require.NoError(t, err)
+	defer cleanUp()
+
+	newType := channeldb.SingleFunderTweaklessBit |
+		channeldb.AnchorOutputsBit
+
+	// We'll first add an HTLC, which should prevent the channel from being
+	// upgraded until it's fully resolved.
+	htlc, preimage := createHTLC(0, lnwire.NewMSatFromSatoshis(10000))
+	_, err = aliceChannel.AddHTLC(htlc, nil)
+	require.NoError(t, err)
+	_, err = bobChannel.ReceiveHTLC(htlc)
+	require.NoError(t, err)
+
+	err = aliceChannel.UpgradeChanType(newType)
+	require.Equal(t, ErrChanUpgradeNotClean, err)
+
+	err = ForceStateTransition(aliceChannel, bobChannel)
+	require.NoError(t, err)
+
+	err = bobChannel.SettleHTLC(preimage, 0, nil, nil, nil)
+	require.NoError(t, err)
+	err = aliceChannel.ReceiveHTLCSettle(preimage, 0)
+	require.NoError(t, err)
+	err = ForceStateTransition(bobChannel, aliceChannel)
+	require.NoError(t, err)
+
+	// With the channel clean again, both parties can upgrade the channel.
+	require.NoError(t, aliceChannel.UpgradeChanType(newType))
+	require.NoError(t, bobChannel.UpgradeChanType(newType))
+	require.True(t, aliceChannel.UpgradePending())
+	require.True(t, bobChannel.UpgradePending())
+
+	// Both parties now owe each other a commitment of the new type.
+	require.True(t, aliceChannel.OweCommitment(true))
+	require.True(t, bobChannel.OweCommitment(true))
+
+	err = ForceStateTransition(aliceChannel, bobChannel)
+	require.NoError(t, err)
+
+	require.False(t, aliceChannel.UpgradePending())
+	require.False(t, bobChannel.UpgradePending())
+
+	// The new commitments should now carry the two anchor outputs in
+	// addition to the balance outputs of both parties.
+	for _, channel := range []*LightningChannel{aliceChannel, bobChannel} {
+		state := channel.State()
+		require.Len(t, state.LocalCommitment.CommitTx.TxOut, 4)
+		require.Len(t, state.RemoteCommitment.CommitTx.TxOut, 4)
+		require.Equal(
+			t, channeldb.SingleFunderTweaklessBit,
+			state.LocalCommitType(state.UpgradeLocalHeight-1),
+		)
+		require.Equal(
+			t, newType,
+			state.LocalCommitType(state.UpgradeLocalHeight),
+		)
+	}
+
+	// The channel can't be upgraded a second time.
+	err = aliceChannel.UpgradeChanType(newType)
+	require.Error(t, err)
+}
+
+// TestChannelUpgradeRestart tests that a channel that is restarted while its
+// commitments are of different channel types after an upgrade is restored
+// using the channel type of each commitment, and that only the commitments of
+// the upgraded type are anchored down.
+func TestChannelUpgradeRestart(t *testing.T) {
+	t.Parallel()
+
+	aliceChannel, bobChannel, cleanUp, err := CreateTestChannels(
+		channeldb.SingleFunderTweaklessBit,
+	)
+	require.NoError(t, err)
+	defer cleanUp()
+
+	newType := channeldb.SingleFunderTweaklessBit |
+		channeldb.AnchorOutputsBit
+
+	require.NoError(t, aliceChannel.UpgradeChanType(newType))
+	require.NoError(t, bobChannel.UpgradeChanType(newType))
+
+	// Alice adds an HTLC and signs the first commitment of the new type
+	// for Bob, while both current commitments are still of the old type.
+	htlc, _ := createHTLC(0, lnwire.NewMSatFromSatoshis(100000))
+	_, err = aliceChannel.AddHTLC(htlc, nil)
+	require.NoError(t, err)
+	_, err = bobChannel.ReceiveHTLC(htlc)
+	require.NoError(t, err)
+
+	aliceSig, aliceHtlcSigs, _, err := aliceChannel.SignNextCommitment()
+	require.NoError(t, err)
+	err = bobChannel.ReceiveNewCommitment(aliceSig, aliceHtlcSigs)
+	require.NoError(t, err)
+
+	// Only the pending remote commitment carries anchors, so it's the
+	// only one anchored down.
+	resolutions, err := aliceChannel.NewAnchorResolutions()
+	require.NoError(t, err)
+	require.Nil(t, resolutions.Local)
+	require.Nil(t, resolutions.Remote)
+	require.NotNil(t, resolutions.RemotePending)
+
+	pendingCommit, err := aliceChannel.State().RemoteCommitChainTip()
+	require.NoError(t, err)
+	require.Equal(
+		t, pendingCommit.Commitment.CommitTx.TxHash(),
+		resolutions.RemotePending.CommitAnchor.Hash,
+	)
+
+	// Alice now restarts, restoring both the current commitments of the
+	// old type and the pending one of the new type.
+	aliceChannel, err = restartChannel(aliceChannel)
+	require.NoError(t, err)
+
+	// The commitment dance can be completed after the restart, leaving
+	// both parties with commitments of the new type that carry the HTLC.
+	bobRevocation, _, err := bobChannel.RevokeCurrentCommitment()
+	require.NoError(t, err)
+	bobSig, bobHtlcSigs, _, err := bobChannel.SignNextCommitment()
+	require.NoError(t, err)
+
+	_, _, _, _, err = aliceChannel.ReceiveRevocation(bobRevocation)
+	require.NoError(t, err)
+	err = aliceChannel.ReceiveNewCommitment(bobSig, bobHtlcSigs)
+	require.NoError(t, err)
+
+	aliceRevocation, _, err := aliceChannel.RevokeCurrentCommitment()
+	require.NoError(t, err)
+	_, _, _, _, err = bobChannel.ReceiveRevocation(aliceRevocation)
+	require.NoError(t, err)
+
+	require.False(t, aliceChannel.UpgradePending())
+	require.False(t, bobChannel.UpgradePending())
+
+	for _, channel := range []*LightningChannel{aliceChannel, bobChannel} {
+		state := channel.State()
+		require.Len(t, state.LocalCommitment.CommitTx.TxOut, 5)
+		require.Len(t, state.RemoteCommitment.CommitTx.TxOut, 5)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/cryptomeow/lnd/input"
 	"github.com/cryptomeow/lnd/keychain"
 	"github.com/cryptomeow/lnd/lnwallet/chainfee"
+	"github.com/cryptomeow/lnd/lnwallet/chanfunding"
 )
 
 // Config is a struct which houses configuration parameters which modify the
@@ -56,4 +57,9 @@ type Config struct {
 	// NetParams is the set of parameters that tells the wallet which chain
 	// it will be operating on.
 	NetParams chaincfg.Params
+
+	// CoinSelectionStrategy is the default strategy used to order the
+	// wallet's coins when funding a channel, unless the funding request
+	// specifies its own strategy.
+	CoinSelectionStrategy chanfunding.CoinSelectionStrategy
 }
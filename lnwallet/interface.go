@@ -11,6 +11,7 @@ import (
 	"github.com/btcsuite/btcd/wire"
 	"github.com/btcsuite/btcutil"
 	"github.com/btcsuite/btcutil/psbt"
+	"github.com/btcsuite/btcwallet/waddrmgr"
 	"github.com/btcsuite/btcwallet/wallet/txauthor"
 	"github.com/btcsuite/btcwallet/wtxmgr"
 	"github.com/cryptomeow/lnd/input"
@@ -34,6 +35,11 @@ const (
 	NestedWitnessPubKey
 )
 
+// DefaultAccountName is the name of the default account of the wallet. It is
+// used to fund channels and for all other operations that don't explicitly
+// specify an account.
+const DefaultAccountName = "default"
+
 var (
 	// DefaultPublicPassphrase is the default public passphrase used for the
 	// wallet.
@@ -48,9 +54,20 @@ var (
 	// transaction.
 	ErrDoubleSpend = errors.New("transaction rejected: output already spent")
 
+	// ErrMempoolFee is returned from PublishTransaction in case the tx
+	// being published doesn't pay enough fees to be accepted into the
+	// backend's mempool, either because it is below the minimum relay fee
+	// or because the mempool is full.
+	ErrMempoolFee = errors.New("transaction rejected: insufficient fee " +
+		"for mempool acceptance")
+
 	// ErrNotMine is an error denoting that a WalletController instance is
 	// unable to spend a specified output.
 	ErrNotMine = errors.New("the passed output doesn't belong to the wallet")
+
+	// ErrRescanCanceled is returned by Rescan if the rescan was canceled
+	// before all blocks were scanned.
+	ErrRescanCanceled = errors.New("wallet rescan canceled")
 )
 
 // ErrNoOutputs is returned if we try to create a transaction with no outputs
@@ -62,6 +79,37 @@ var ErrNoOutputs = errors.New("no outputs")
 var ErrInvalidMinconf = errors.New("minimum number of confirmations must " +
 	"be a non-negative number")
 
+// RescanProgress describes the progress of a wallet rescan.
+type RescanProgress struct {
+	// StartHeight is the height of the first block that is scanned.
+	StartHeight int32
+
+	// Height is the height of the last block that was scanned.
+	Height int32
+
+	// TipHeight is the height of the chain tip the scan ends at.
+	TipHeight int32
+
+	// FirstRelevantHeight is the height of the first block that contains
+	// a transaction relevant to the wallet, or zero if none was found
+	// yet.
+	FirstRelevantHeight int32
+
+	// Done is true once all blocks were scanned and the relevant
+	// transactions were handed to the wallet.
+	Done bool
+}
+
+// Percent returns the percentage of blocks that were scanned.
+func (p *RescanProgress) Percent() float64 {
+	total := p.TipHeight - p.StartHeight + 1
+	if total <= 0 {
+		return 100
+	}
+
+	return float64(p.Height-p.StartHeight+1) / float64(total) * 100
+}
+
 // Utxo is an unspent output denoted by its outpoint, and output value of the
 // original output.
 type Utxo struct {
@@ -70,6 +118,29 @@ type Utxo struct {
 	Confirmations int64
 	PkScript      []byte
 	wire.OutPoint
+
+	// Label is an optional user provided label of the output.
+	Label string
+
+	// Frozen indicates that the output was frozen by the user and must
+	// not be selected as an input by any of lnd's coin selection.
+	Frozen bool
+
+	// Account is the name of the wallet account the output belongs to.
+	Account string
+}
+
+// LeasedOutput is an output of the wallet that is leased to an ID until its
+// expiration, excluding it from coin selection in the meantime.
+type LeasedOutput struct {
+	// LockID is the ID the output is leased to.
+	LockID wtxmgr.LockID
+
+	// Outpoint is the outpoint of the leased output.
+	Outpoint wire.OutPoint
+
+	// Expiration is the absolute time at which the lease expires.
+	Expiration time.Time
 }
 
 // TransactionDetail describes a transaction with either inputs which belong to
@@ -114,6 +185,11 @@ type TransactionDetail struct {
 
 	// Label is an optional transaction label.
 	Label string
+
+	// WatchOnly is true if this transaction pays to or spends from a
+	// watch-only script rather than the wallet. Its value is then the net
+	// value it added to the watch-only scripts.
+	WatchOnly bool
 }
 
 // TransactionSubscription is an interface which describes an object capable of
@@ -153,20 +229,24 @@ type WalletController interface {
 	// ConfirmedBalance returns the sum of all the wallet's unspent outputs
 	// that have at least confs confirmations. If confs is set to zero,
 	// then all unspent outputs, including those currently in the mempool
-	// will be included in the final sum.
+	// will be included in the final sum. If accountFilter is not empty,
+	// only the outputs of the account with that name are included.
 	//
 	// NOTE: Only witness outputs should be included in the computation of
 	// the total spendable balance of the wallet. We require this as only
 	// witness inputs can be used for funding channels.
-	ConfirmedBalance(confs int32) (btcutil.Amount, error)
+	ConfirmedBalance(confs int32, accountFilter string) (btcutil.Amount,
+		error)
 
 	// NewAddress returns the next external or internal address for the
 	// wallet dictated by the value of the `change` parameter. If change is
 	// true, then an internal address should be used, otherwise an external
 	// address should be returned. The type of address returned is dictated
 	// by the wallet's capabilities, and may be of type: p2sh, p2wkh,
-	// p2wsh, etc.
-	NewAddress(addrType AddressType, change bool) (btcutil.Address, error)
+	// p2wsh, etc. The address is derived from the account with the given
+	// name, or from the default account if the name is empty.
+	NewAddress(addrType AddressType, change bool,
+		account string) (btcutil.Address, error)
 
 	// LastUnusedAddress returns the last *unused* address known by the
 	// wallet. An address is unused if it hasn't received any payments.
@@ -183,11 +263,13 @@ type WalletController interface {
 	// out to the specified outputs. In the case the wallet has insufficient
 	// funds, or the outputs are non-standard, an error should be returned.
 	// This method also takes the target fee expressed in sat/kw that should
-	// be used when crafting the transaction.
+	// be used when crafting the transaction. Only the outputs of the
+	// account with the given name are spent, or those of the default
+	// account if the name is empty.
 	//
 	// NOTE: This method requires the global coin selection lock to be held.
-	SendOutputs(outputs []*wire.TxOut,
-		feeRate chainfee.SatPerKWeight, minconf int32, label string) (*wire.MsgTx, error)
+	SendOutputs(outputs []*wire.TxOut, feeRate chainfee.SatPerKWeight,
+		minconf int32, label, account string) (*wire.MsgTx, error)
 
 	// CreateSimpleTx creates a Bitcoin transaction paying to the specified
 	// outputs. The transaction is not broadcasted to the network. In the
@@ -210,10 +292,20 @@ type WalletController interface {
 	// needs in order to be returned by this method. Passing -1 as
 	// 'minconfirms' indicates that even unconfirmed outputs should be
 	// returned. Using MaxInt32 as 'maxconfirms' implies returning all
-	// outputs with at least 'minconfirms'.
+	// outputs with at least 'minconfirms'. If accountFilter is not empty,
+	// only the outputs of the account with that name are returned.
 	//
 	// NOTE: This method requires the global coin selection lock to be held.
-	ListUnspentWitness(minconfirms, maxconfirms int32) ([]*Utxo, error)
+	ListUnspentWitness(minconfirms, maxconfirms int32,
+		accountFilter string) ([]*Utxo, error)
+
+	// ListAccounts returns the properties of all accounts of the wallet.
+	ListAccounts() ([]*waddrmgr.AccountProperties, error)
+
+	// CreateAccount derives a new account with the given name from the
+	// wallet's seed and returns its properties. Account names must be
+	// unique.
+	CreateAccount(name string) (*waddrmgr.AccountProperties, error)
 
 	// ListTransactionDetails returns a list of all transactions which are
 	// relevant to the wallet over [startHeight;endHeight]. If start height
@@ -239,18 +331,21 @@ type WalletController interface {
 	// NOTE: This method requires the global coin selection lock to be held.
 	UnlockOutpoint(o wire.OutPoint)
 
-	// LeaseOutput locks an output to the given ID, preventing it from being
-	// available for any future coin selection attempts. The absolute time
-	// of the lock's expiration is returned. The expiration of the lock can
-	// be extended by successive invocations of this call. Outputs can be
-	// unlocked before their expiration through `ReleaseOutput`.
+	// LeaseOutput locks an output to the given ID for the given duration,
+	// preventing it from being available for any future coin selection
+	// attempts. A zero duration leases the output for the wallet's default
+	// duration. The absolute time of the lock's expiration is returned.
+	// The expiration of the lock can be changed by successive invocations
+	// of this call. Outputs can be unlocked before their expiration
+	// through `ReleaseOutput`. Leases are persisted across restarts.
 	//
 	// If the output is not known, wtxmgr.ErrUnknownOutput is returned. If
 	// the output has already been locked to a different ID, then
 	// wtxmgr.ErrOutputAlreadyLocked is returned.
 	//
 	// NOTE: This method requires the global coin selection lock to be held.
-	LeaseOutput(id wtxmgr.LockID, op wire.OutPoint) (time.Time, error)
+	LeaseOutput(id wtxmgr.LockID, op wire.OutPoint,
+		duration time.Duration) (time.Time, error)
 
 	// ReleaseOutput unlocks an output, allowing it to be available for coin
 	// selection if it remains unspent. The ID should match the one used to
@@ -259,14 +354,18 @@ type WalletController interface {
 	// NOTE: This method requires the global coin selection lock to be held.
 	ReleaseOutput(id wtxmgr.LockID, op wire.OutPoint) error
 
+	// ListLeasedOutputs returns all outputs that are currently leased.
+	ListLeasedOutputs() ([]*LeasedOutput, error)
+
 	// PublishTransaction performs cursory validation (dust checks, etc),
 	// then finally broadcasts the passed transaction to the Bitcoin network.
 	// If the transaction is rejected because it is conflicting with an
-	// already known transaction, ErrDoubleSpend is returned. If the
-	// transaction is already known (published already), no error will be
-	// returned. Other error returned depends on the currently active chain
-	// backend. It takes an optional label which will save a label with the
-	// published transaction.
+	// already known transaction, ErrDoubleSpend is returned. If it
+	// doesn't pay enough fees to be accepted into the backend's mempool,
+	// ErrMempoolFee is returned. If the transaction is already known
+	// (published already), no error will be returned. Other error returned
+	// depends on the currently active chain backend. It takes an optional
+	// label which will save a label with the published transaction.
 	PublishTransaction(tx *wire.MsgTx, label string) error
 
 	// LabelTransaction adds a label to a transaction. If the tx already
@@ -274,12 +373,29 @@ type WalletController interface {
 	// is set. Labels must not be empty, and they are limited to 500 chars.
 	LabelTransaction(hash chainhash.Hash, label string, overwrite bool) error
 
+	// LabelOutput adds a label to an unspent output controlled by the
+	// wallet. If the output already has a label, this call will fail
+	// unless the overwrite parameter is set. Labels must not be empty, and
+	// they are limited to 500 chars.
+	LabelOutput(op wire.OutPoint, label string, overwrite bool) error
+
+	// FreezeOutput marks an unspent output controlled by the wallet as
+	// frozen. Frozen outputs are persisted across restarts, and are
+	// excluded from coin selection until they're unfrozen again.
+	FreezeOutput(op wire.OutPoint) error
+
+	// UnfreezeOutput makes a previously frozen output eligible for coin
+	// selection again.
+	UnfreezeOutput(op wire.OutPoint) error
+
 	// FundPsbt creates a fully populated PSBT packet that contains enough
 	// inputs to fund the outputs specified in the passed in packet with the
 	// specified fee rate. If there is change left, a change output from the
 	// internal wallet is added and the index of the change output is
 	// returned. Otherwise no additional output is created and the index -1
-	// is returned.
+	// is returned. Coins are selected from, and change is sent to, the
+	// account with the given name, or the default account if the name is
+	// empty.
 	//
 	// NOTE: If the packet doesn't contain any inputs, coin selection is
 	// performed automatically. If the packet does contain any inputs, it is
@@ -289,8 +405,8 @@ type WalletController interface {
 	// No lock lease is acquired for any of the selected/validated inputs.
 	// It is in the caller's responsibility to lock the inputs before
 	// handing them out.
-	FundPsbt(packet *psbt.Packet, feeRate chainfee.SatPerKWeight) (int32,
-		error)
+	FundPsbt(packet *psbt.Packet, feeRate chainfee.SatPerKWeight,
+		accountName string) (int32, error)
 
 	// FinalizePsbt expects a partial transaction with all inputs and
 	// outputs fully declared and tries to sign all inputs that belong to
@@ -327,6 +443,14 @@ type WalletController interface {
 	// recovery progress made so far.
 	GetRecoveryInfo() (bool, float64, error)
 
+	// Rescan scans the chain for transactions relevant to the wallet,
+	// starting at the given height, and hands them to the wallet. The
+	// progress of the scan is reported to the given callback after each
+	// batch of blocks. The scan is aborted with ErrRescanCanceled once the
+	// cancel channel is closed.
+	Rescan(startHeight int32, progress func(*RescanProgress),
+		cancel <-chan struct{}) error
+
 	// Start initializes the wallet, making any necessary connections,
 	// starting up required goroutines etc.
 	Start() error
@@ -101,7 +101,7 @@ var (
 func assertProperBalance(t *testing.T, lw *lnwallet.LightningWallet,
 	numConfirms int32, amount float64) {
 
-	balance, err := lw.ConfirmedBalance(numConfirms)
+	balance, err := lw.ConfirmedBalance(numConfirms, "")
 	if err != nil {
 		t.Fatalf("unable to query for balance: %v", err)
 	}
@@ -130,13 +130,13 @@ func mineAndAssertTxInBlock(t *testing.T, miner *rpctest.Harness,
 	}
 
 	// We'll mined a block to confirm it.
-	blockHashes, err := miner.Node.Generate(1)
+	blockHashes, err := miner.Client.Generate(1)
 	if err != nil {
 		t.Fatalf("unable to generate new block: %v", err)
 	}
 
 	// Finally, we'll check it was actually mined in this block.
-	block, err := miner.Node.GetBlock(blockHashes[0])
+	block, err := miner.Client.GetBlock(blockHashes[0])
 	if err != nil {
 		t.Fatalf("unable to get block %v: %v", blockHashes[0], err)
 	}
@@ -157,7 +157,7 @@ func newPkScript(t *testing.T, w *lnwallet.LightningWallet,
 
 	t.Helper()
 
-	addr, err := w.NewAddress(addrType, false)
+	addr, err := w.NewAddress(addrType, false, lnwallet.DefaultAccountName)
 	if err != nil {
 		t.Fatalf("unable to create new address: %v", err)
 	}
@@ -179,6 +179,7 @@ func sendCoins(t *testing.T, miner *rpctest.Harness,
 
 	tx, err := sender.SendOutputs(
 		[]*wire.TxOut{output}, feeRate, minConf, labels.External,
+		lnwallet.DefaultAccountName,
 	)
 	if err != nil {
 		t.Fatalf("unable to send transaction: %v", err)
@@ -248,7 +249,7 @@ func loadTestCredits(miner *rpctest.Harness, w *lnwallet.LightningWallet,
 	if err != nil {
 		return fmt.Errorf("unable to create amt: %v", err)
 	}
-	expectedBalance, err := w.ConfirmedBalance(1)
+	expectedBalance, err := w.ConfirmedBalance(1, "")
 	if err != nil {
 		return err
 	}
@@ -256,7 +257,7 @@ func loadTestCredits(miner *rpctest.Harness, w *lnwallet.LightningWallet,
 	addrs := make([]btcutil.Address, 0, numOutputs)
 	for i := 0; i < numOutputs; i++ {
 		// Grab a fresh address from the wallet to house this output.
-		walletAddr, err := w.NewAddress(lnwallet.WitnessPubKey, false)
+		walletAddr, err := w.NewAddress(lnwallet.WitnessPubKey, false, lnwallet.DefaultAccountName)
 		if err != nil {
 			return err
 		}
@@ -282,7 +283,7 @@ func loadTestCredits(miner *rpctest.Harness, w *lnwallet.LightningWallet,
 	// Generate 10 blocks with the mining node, this should mine all
 	// numOutputs transactions created above. We generate 10 blocks here
 	// in order to give all the outputs a "sufficient" number of confirmations.
-	if _, err := miner.Node.Generate(10); err != nil {
+	if _, err := miner.Client.Generate(10); err != nil {
 		return err
 	}
 
@@ -291,7 +292,7 @@ func loadTestCredits(miner *rpctest.Harness, w *lnwallet.LightningWallet,
 	timeout := time.After(30 * time.Second)
 
 	for range ticker.C {
-		balance, err := w.ConfirmedBalance(1)
+		balance, err := w.ConfirmedBalance(1, "")
 		if err != nil {
 			return err
 		}
@@ -380,7 +381,7 @@ func testGetRecoveryInfo(miner *rpctest.Harness,
 
 	// Generate 5 blocks and check the recovery process again.
 	const numBlocksMined = 5
-	_, err = miner.Node.Generate(numBlocksMined)
+	_, err = miner.Client.Generate(numBlocksMined)
 	require.NoError(t, err, "unable to mine blocks")
 
 	// Check the recovery process. Once synced, the progress should be 1.
@@ -591,11 +592,11 @@ func testDualFundingReservationWorkflow(miner *rpctest.Harness,
 	if err != nil {
 		t.Fatalf("tx not relayed to miner: %v", err)
 	}
-	blockHashes, err := miner.Node.Generate(1)
+	blockHashes, err := miner.Client.Generate(1)
 	if err != nil {
 		t.Fatalf("unable to generate block: %v", err)
 	}
-	block, err := miner.Node.GetBlock(blockHashes[0])
+	block, err := miner.Client.GetBlock(blockHashes[0])
 	if err != nil {
 		t.Fatalf("unable to find block: %v", err)
 	}
@@ -1091,11 +1092,11 @@ func testSingleFunderReservationWorkflow(miner *rpctest.Harness,
 	if err != nil {
 		t.Fatalf("tx not relayed to miner: %v", err)
 	}
-	blockHashes, err := miner.Node.Generate(1)
+	blockHashes, err := miner.Client.Generate(1)
 	if err != nil {
 		t.Fatalf("unable to generate block: %v", err)
 	}
-	block, err := miner.Node.GetBlock(blockHashes[0])
+	block, err := miner.Client.GetBlock(blockHashes[0])
 	if err != nil {
 		t.Fatalf("unable to find block: %v", err)
 	}
@@ -1138,7 +1139,7 @@ func testListTransactionDetails(miner *rpctest.Harness,
 	const outputAmt = btcutil.SatoshiPerBitcoin
 	txids := make(map[chainhash.Hash]struct{})
 	for i := 0; i < numTxns; i++ {
-		addr, err := alice.NewAddress(lnwallet.WitnessPubKey, false)
+		addr, err := alice.NewAddress(lnwallet.WitnessPubKey, false, lnwallet.DefaultAccountName)
 		if err != nil {
 			t.Fatalf("unable to create new address: %v", err)
 		}
@@ -1159,14 +1160,14 @@ func testListTransactionDetails(miner *rpctest.Harness,
 	}
 
 	// Get the miner's current best block height before we mine blocks.
-	_, startHeight, err := miner.Node.GetBestBlock()
+	_, startHeight, err := miner.Client.GetBestBlock()
 	if err != nil {
 		t.Fatalf("cannot get best block: %v", err)
 	}
 
 	// Generate 10 blocks to mine all the transactions created above.
 	const numBlocksMined = 10
-	blocks, err := miner.Node.Generate(numBlocksMined)
+	blocks, err := miner.Client.Generate(numBlocksMined)
 	if err != nil {
 		t.Fatalf("unable to mine blocks: %v", err)
 	}
@@ -1276,6 +1277,7 @@ func testListTransactionDetails(miner *rpctest.Harness,
 	burnOutput := wire.NewTxOut(outputAmt, outputScript)
 	burnTX, err := alice.SendOutputs(
 		[]*wire.TxOut{burnOutput}, 2500, 1, labels.External,
+		lnwallet.DefaultAccountName,
 	)
 	if err != nil {
 		t.Fatalf("unable to create burn tx: %v", err)
@@ -1339,7 +1341,7 @@ func testListTransactionDetails(miner *rpctest.Harness,
 
 	// Generate one block for our transaction to confirm in.
 	var numBlocks int32 = 1
-	burnBlock, err := miner.Node.Generate(uint32(numBlocks))
+	burnBlock, err := miner.Client.Generate(uint32(numBlocks))
 	if err != nil {
 		t.Fatalf("unable to mine block: %v", err)
 	}
@@ -1392,7 +1394,7 @@ func testListTransactionDetails(miner *rpctest.Harness,
 
 	// Generate a block which has no wallet transactions in it.
 	chainTip += numBlocks
-	_, err = miner.Node.Generate(uint32(numBlocks))
+	_, err = miner.Client.Generate(uint32(numBlocks))
 	if err != nil {
 		t.Fatalf("unable to mine block: %v", err)
 	}
@@ -1465,7 +1467,7 @@ func testTransactionSubscriptions(miner *rpctest.Harness,
 	// Next, fetch a fresh address from the wallet, create 3 new outputs
 	// with the pkScript.
 	for i := 0; i < numTxns; i++ {
-		addr, err := alice.NewAddress(lnwallet.WitnessPubKey, false)
+		addr, err := alice.NewAddress(lnwallet.WitnessPubKey, false, lnwallet.DefaultAccountName)
 		if err != nil {
 			t.Fatalf("unable to create new address: %v", err)
 		}
@@ -1524,7 +1526,7 @@ func testTransactionSubscriptions(miner *rpctest.Harness,
 
 	// Next mine a single block, all the transactions generated above
 	// should be included.
-	if _, err := miner.Node.Generate(1); err != nil {
+	if _, err := miner.Client.Generate(1); err != nil {
 		t.Fatalf("unable to generate block: %v", err)
 	}
 
@@ -1551,6 +1553,7 @@ func testTransactionSubscriptions(miner *rpctest.Harness,
 	burnOutput := wire.NewTxOut(outputAmt, outputScript)
 	tx, err := alice.SendOutputs(
 		[]*wire.TxOut{burnOutput}, 2500, 1, labels.External,
+		lnwallet.DefaultAccountName,
 	)
 	if err != nil {
 		t.Fatalf("unable to create burn tx: %v", err)
@@ -1607,12 +1610,12 @@ func mineAndAssert(r *rpctest.Harness, tx *wire.MsgTx) error {
 		return fmt.Errorf("tx not relayed to miner: %v", err)
 	}
 
-	blockHashes, err := r.Node.Generate(1)
+	blockHashes, err := r.Client.Generate(1)
 	if err != nil {
 		return fmt.Errorf("unable to generate block: %v", err)
 	}
 
-	block, err := r.Node.GetBlock(blockHashes[0])
+	block, err := r.Client.GetBlock(blockHashes[0])
 	if err != nil {
 		return fmt.Errorf("unable to find block: %v", err)
 	}
@@ -1744,6 +1747,7 @@ func newTx(t *testing.T, r *rpctest.Harness, pubKey *btcec.PublicKey,
 	}
 	tx, err := alice.SendOutputs(
 		[]*wire.TxOut{newOutput}, 2500, 1, labels.External,
+		lnwallet.DefaultAccountName,
 	)
 	if err != nil {
 		t.Fatalf("unable to create output: %v", err)
@@ -1803,7 +1807,7 @@ func testPublishTransaction(r *rpctest.Harness,
 	}
 
 	// Mine the transaction.
-	if _, err := r.Node.Generate(1); err != nil {
+	if _, err := r.Client.Generate(1); err != nil {
 		t.Fatalf("unable to generate block: %v", err)
 	}
 
@@ -2035,6 +2039,7 @@ func testSignOutputUsingTweaks(r *rpctest.Harness,
 		}
 		tx, err := alice.SendOutputs(
 			[]*wire.TxOut{newOutput}, 2500, 1, labels.External,
+			lnwallet.DefaultAccountName,
 		)
 		if err != nil {
 			t.Fatalf("unable to create output: %v", err)
@@ -2126,7 +2131,7 @@ func testReorgWalletBalance(r *rpctest.Harness, w *lnwallet.LightningWallet,
 	// reorganization that doesn't invalidate any existing transactions or
 	// create any new non-coinbase transactions. We'll then check if it's
 	// the same after the empty reorg.
-	_, err := r.Node.Generate(5)
+	_, err := r.Client.Generate(5)
 	if err != nil {
 		t.Fatalf("unable to generate blocks on passed node: %v", err)
 	}
@@ -2159,6 +2164,7 @@ func testReorgWalletBalance(r *rpctest.Harness, w *lnwallet.LightningWallet,
 	}
 	tx, err := w.SendOutputs(
 		[]*wire.TxOut{output}, 2500, 1, labels.External,
+		lnwallet.DefaultAccountName,
 	)
 	if err != nil {
 		t.Fatalf("unable to send outputs: %v", err)
@@ -2168,7 +2174,7 @@ func testReorgWalletBalance(r *rpctest.Harness, w *lnwallet.LightningWallet,
 	if err != nil {
 		t.Fatalf("tx not relayed to miner: %v", err)
 	}
-	_, err = r.Node.Generate(50)
+	_, err = r.Client.Generate(50)
 	if err != nil {
 		t.Fatalf("unable to generate blocks on passed node: %v", err)
 	}
@@ -2180,14 +2186,16 @@ func testReorgWalletBalance(r *rpctest.Harness, w *lnwallet.LightningWallet,
 	}
 
 	// Get the original balance.
-	origBalance, err := w.ConfirmedBalance(1)
+	origBalance, err := w.ConfirmedBalance(1, "")
 	if err != nil {
 		t.Fatalf("unable to query for balance: %v", err)
 	}
 
 	// Now we cause a reorganization as follows.
 	// Step 1: create a new miner and start it.
-	r2, err := rpctest.New(r.ActiveNet, nil, []string{"--txindex"})
+	r2, err := rpctest.New(
+		r.ActiveNet, nil, []string{"--txindex"}, "",
+	)
 	if err != nil {
 		t.Fatalf("unable to create mining node: %v", err)
 	}
@@ -2196,7 +2204,7 @@ func testReorgWalletBalance(r *rpctest.Harness, w *lnwallet.LightningWallet,
 		t.Fatalf("unable to set up mining node: %v", err)
 	}
 	defer r2.TearDown()
-	newBalance, err := w.ConfirmedBalance(1)
+	newBalance, err := w.ConfirmedBalance(1, "")
 	if err != nil {
 		t.Fatalf("unable to query for balance: %v", err)
 	}
@@ -2207,7 +2215,7 @@ func testReorgWalletBalance(r *rpctest.Harness, w *lnwallet.LightningWallet,
 
 	// Step 2: connect the miner to the passed miner and wait for
 	// synchronization.
-	err = r2.Node.AddNode(r.P2PAddress(), rpcclient.ANAdd)
+	err = r2.Client.AddNode(r.P2PAddress(), rpcclient.ANAdd)
 	if err != nil {
 		t.Fatalf("unable to connect mining nodes together: %v", err)
 	}
@@ -2232,12 +2240,14 @@ func testReorgWalletBalance(r *rpctest.Harness, w *lnwallet.LightningWallet,
 				t.Fatalf("timeout waiting for miner disconnect")
 			default:
 			}
-			err = r2.Node.AddNode(r.P2PAddress(), rpcclient.ANRemove)
+			err = r2.Client.AddNode(
+				r.P2PAddress(), rpcclient.ANRemove,
+			)
 			if err != nil {
 				t.Fatalf("unable to disconnect mining nodes: %v",
 					err)
 			}
-			peers, err = r2.Node.GetPeerInfo()
+			peers, err = r2.Client.GetPeerInfo()
 			if err != nil {
 				t.Fatalf("unable to get peer info: %v", err)
 			}
@@ -2249,19 +2259,19 @@ func testReorgWalletBalance(r *rpctest.Harness, w *lnwallet.LightningWallet,
 				}
 			}
 		}
-		_, err = r.Node.Generate(2)
+		_, err = r.Client.Generate(2)
 		if err != nil {
 			t.Fatalf("unable to generate blocks on passed node: %v",
 				err)
 		}
-		_, err = r2.Node.Generate(3)
+		_, err = r2.Client.Generate(3)
 		if err != nil {
 			t.Fatalf("unable to generate blocks on created node: %v",
 				err)
 		}
 
 		// Step 5: Reconnect the miners and wait for them to synchronize.
-		err = r2.Node.AddNode(r.P2PAddress(), rpcclient.ANAdd)
+		err = r2.Client.AddNode(r.P2PAddress(), rpcclient.ANAdd)
 		if err != nil {
 			switch err := err.(type) {
 			case *btcjson.RPCError:
@@ -2288,7 +2298,7 @@ func testReorgWalletBalance(r *rpctest.Harness, w *lnwallet.LightningWallet,
 	}
 
 	// Now we check that the wallet balance stays the same.
-	newBalance, err = w.ConfirmedBalance(1)
+	newBalance, err = w.ConfirmedBalance(1, "")
 	if err != nil {
 		t.Fatalf("unable to query for balance: %v", err)
 	}
@@ -2311,7 +2321,7 @@ func testChangeOutputSpendConfirmation(r *rpctest.Harness,
 	// Assuming a balance of 80 BTC and a transaction fee of 2500 sat/kw,
 	// we'll craft the following transaction so that Alice doesn't have any
 	// UTXOs left.
-	aliceBalance, err := alice.ConfirmedBalance(0)
+	aliceBalance, err := alice.ConfirmedBalance(0, "")
 	if err != nil {
 		t.Fatalf("unable to retrieve alice's balance: %v", err)
 	}
@@ -2336,7 +2346,7 @@ func testChangeOutputSpendConfirmation(r *rpctest.Harness,
 
 	// With the transaction sent and confirmed, Alice's balance should now
 	// be 0.
-	aliceBalance, err = alice.ConfirmedBalance(0)
+	aliceBalance, err = alice.ConfirmedBalance(0, "")
 	if err != nil {
 		t.Fatalf("unable to retrieve alice's balance: %v", err)
 	}
@@ -2392,7 +2402,7 @@ func testSpendUnconfirmed(miner *rpctest.Harness,
 
 	// First we will empty out bob's wallet, sending the entire balance
 	// to alice.
-	bobBalance, err := bob.ConfirmedBalance(0)
+	bobBalance, err := bob.ConfirmedBalance(0, "")
 	if err != nil {
 		t.Fatalf("unable to retrieve bob's balance: %v", err)
 	}
@@ -2413,6 +2423,7 @@ func testSpendUnconfirmed(miner *rpctest.Harness,
 	}
 	_, err = bob.SendOutputs(
 		[]*wire.TxOut{output}, txFeeRate, 0, labels.External,
+		lnwallet.DefaultAccountName,
 	)
 	if err == nil {
 		t.Fatalf("should have not been able to pay due to insufficient balance: %v", err)
@@ -2439,6 +2450,7 @@ func testSpendUnconfirmed(miner *rpctest.Harness,
 	// using confirmed outputs only.
 	_, err = bob.SendOutputs(
 		[]*wire.TxOut{output}, txFeeRate, 1, labels.External,
+		lnwallet.DefaultAccountName,
 	)
 	if err == nil {
 		t.Fatalf("should have not been able to pay due to insufficient balance: %v", err)
@@ -2455,7 +2467,7 @@ func testSpendUnconfirmed(miner *rpctest.Harness,
 	if err != nil {
 		t.Fatalf("tx not relayed to miner: %v", err)
 	}
-	if _, err := miner.Node.Generate(1); err != nil {
+	if _, err := miner.Client.Generate(1); err != nil {
 		t.Fatalf("unable to generate block: %v", err)
 	}
 	if err := waitForWalletSync(miner, alice); err != nil {
@@ -2483,7 +2495,7 @@ func testSpendUnconfirmed(miner *rpctest.Harness,
 func testLastUnusedAddr(miner *rpctest.Harness,
 	alice, bob *lnwallet.LightningWallet, t *testing.T) {
 
-	if _, err := miner.Node.Generate(1); err != nil {
+	if _, err := miner.Client.Generate(1); err != nil {
 		t.Fatalf("unable to generate block: %v", err)
 	}
 
@@ -2635,7 +2647,10 @@ func testCreateSimpleTx(r *rpctest.Harness, w *lnwallet.LightningWallet,
 		// _very_ similar to the one we just created being sent. The
 		// only difference is that the dry run tx is not signed, and
 		// that the change output position might be different.
-		tx, sendErr := w.SendOutputs(outputs, feeRate, 1, labels.External)
+		tx, sendErr := w.SendOutputs(
+			outputs, feeRate, 1, labels.External,
+			lnwallet.DefaultAccountName,
+		)
 		switch {
 		case test.valid && sendErr != nil:
 			t.Fatalf("got unexpected error when sending tx: %v",
@@ -2895,7 +2910,7 @@ func waitForMempoolTx(r *rpctest.Harness, txid *chainhash.Hash) error {
 		time.Sleep(100 * time.Millisecond)
 
 		// Check for the harness' knowledge of the txid
-		tx, err = r.Node.GetRawTransaction(txid)
+		tx, err = r.Client.GetRawTransaction(txid)
 		if err != nil {
 			switch e := err.(type) {
 			case *btcjson.RPCError:
@@ -2931,7 +2946,7 @@ func waitForWalletSync(r *rpctest.Harness, w *lnwallet.LightningWallet) error {
 
 		// Check whether the chain source of the wallet is caught up to
 		// the harness it's supposed to be catching up to.
-		bestHash, bestHeight, err = r.Node.GetBestBlock()
+		bestHash, bestHeight, err = r.Client.GetBestBlock()
 		if err != nil {
 			return err
 		}
@@ -2982,7 +2997,7 @@ func testSingleFunderExternalFundingTx(miner *rpctest.Harness,
 	// funding transaction for both parties. Utilizing existing tools,
 	// we'll create a new chanfunding.Assembler hacked by Alice's wallet.
 	aliceChanFunder := chanfunding.NewWalletAssembler(chanfunding.WalletConfig{
-		CoinSource:       lnwallet.NewCoinSource(alice),
+		CoinSource:       lnwallet.NewCoinSource(alice, ""),
 		CoinSelectLocker: alice,
 		CoinLocker:       alice,
 		Signer:           alice.Cfg.Signer,
@@ -2997,7 +3012,10 @@ func testSingleFunderExternalFundingTx(miner *rpctest.Harness,
 		MinConfs: 1,
 		FeeRate:  253,
 		ChangeAddr: func() (btcutil.Address, error) {
-			return alice.NewAddress(lnwallet.WitnessPubKey, true)
+			return alice.NewAddress(
+				lnwallet.WitnessPubKey, true,
+				lnwallet.DefaultAccountName,
+			)
 		},
 	})
 	if err != nil {
@@ -3042,7 +3060,10 @@ func testSingleFunderExternalFundingTx(miner *rpctest.Harness,
 		MinConfs: 1,
 		FeeRate:  253,
 		ChangeAddr: func() (btcutil.Address, error) {
-			return bob.NewAddress(lnwallet.WitnessPubKey, true)
+			return bob.NewAddress(
+				lnwallet.WitnessPubKey, true,
+				lnwallet.DefaultAccountName,
+			)
 		},
 	})
 	if err != nil {
@@ -3093,7 +3114,9 @@ func TestLightningWallet(t *testing.T) {
 	// dedicated miner to generate blocks, cause re-orgs, etc. We'll set
 	// up this node with a chain length of 125, so we have plenty of BTC
 	// to play around with.
-	miningNode, err := rpctest.New(netParams, nil, []string{"--txindex"})
+	miningNode, err := rpctest.New(
+		netParams, nil, []string{"--txindex"}, "",
+	)
 	if err != nil {
 		t.Fatalf("unable to create mining node: %v", err)
 	}
@@ -3105,7 +3128,7 @@ func TestLightningWallet(t *testing.T) {
 	// Next mine enough blocks in order for segwit and the CSV package
 	// soft-fork to activate on RegNet.
 	numBlocks := netParams.MinerConfirmationWindow * 2
-	if _, err := miningNode.Node.Generate(numBlocks); err != nil {
+	if _, err := miningNode.Client.Generate(numBlocks); err != nil {
 		t.Fatalf("unable to generate blocks: %v", err)
 	}
 
@@ -3210,6 +3233,7 @@ func runTests(t *testing.T, walletDriver *lnwallet.WalletDriver,
 			// instance, and initialize a btcwallet driver for it.
 			aliceDB, err := walletdb.Create(
 				"bdb", tempTestDirAlice+"/neutrino.db", true,
+				kvdb.DefaultDBTimeout,
 			)
 			if err != nil {
 				t.Fatalf("unable to create DB: %v", err)
@@ -3238,6 +3262,7 @@ func runTests(t *testing.T, walletDriver *lnwallet.WalletDriver,
 			// instance, and initialize a btcwallet driver for it.
 			bobDB, err := walletdb.Create(
 				"bdb", tempTestDirBob+"/neutrino.db", true,
+				kvdb.DefaultDBTimeout,
 			)
 			if err != nil {
 				t.Fatalf("unable to create DB: %v", err)
@@ -3296,12 +3321,19 @@ func runTests(t *testing.T, walletDriver *lnwallet.WalletDriver,
 			// Wait for the bitcoind instance to start up.
 
 			host := fmt.Sprintf("127.0.0.1:%d", rpcPort)
+			bitcoindCfg := &chain.BitcoindConfig{
+				ChainParams:     netParams,
+				Host:            host,
+				User:            "weks",
+				Pass:            "weks",
+				ZMQBlockHost:    zmqBlockHost,
+				ZMQTxHost:       zmqTxHost,
+				ZMQReadDeadline: 100 * time.Millisecond,
+			}
 			var chainConn *chain.BitcoindConn
 			err = wait.NoError(func() error {
 				chainConn, err = chain.NewBitcoindConn(
-					netParams, host, "weks", "weks",
-					zmqBlockHost, zmqTxHost,
-					100*time.Millisecond,
+					bitcoindCfg,
 				)
 				if err != nil {
 					return err
@@ -131,6 +131,21 @@ type InitFundingReserveMsg struct {
 	// used.
 	ChanFunder chanfunding.Assembler
 
+	// CoinSelectionStrategy is the strategy used to order the wallet's
+	// coins during coin selection. If not specified, then the default
+	// strategy of the wallet will be used.
+	CoinSelectionStrategy chanfunding.CoinSelectionStrategy
+
+	// Outpoints is an optional list of wallet outputs that must be used to
+	// fund the channel. If not specified, then the wallet's coin selection
+	// picks the outputs to use.
+	Outpoints []wire.OutPoint
+
+	// Account is the name of the wallet account the channel is funded
+	// from, and that receives the change of the funding transaction. If
+	// not specified, the default account is used.
+	Account string
+
 	// err is a channel in which all errors will be sent across. Will be
 	// nil if this initial set is successful.
 	//
@@ -380,11 +395,13 @@ func (l *LightningWallet) Shutdown() error {
 // ConfirmedBalance returns the current confirmed balance of the wallet. This
 // methods wraps the interal WalletController method so we're able to properly
 // hold the coin select mutex while we compute the balance.
-func (l *LightningWallet) ConfirmedBalance(confs int32) (btcutil.Amount, error) {
+func (l *LightningWallet) ConfirmedBalance(confs int32,
+	accountFilter string) (btcutil.Amount, error) {
+
 	l.coinSelectMtx.Lock()
 	defer l.coinSelectMtx.Unlock()
 
-	return l.WalletController.ConfirmedBalance(confs)
+	return l.WalletController.ConfirmedBalance(confs, accountFilter)
 }
 
 // LockedOutpoints returns a list of all currently locked outpoint.
@@ -594,6 +611,43 @@ func (l *LightningWallet) CancelFundingIntent(pid [32]byte) error {
 	return nil
 }
 
+// walletAssembler returns a new chanfunding.WalletAssembler that is backed by
+// the wallet's internal coin selection, restricted to the coins of the given
+// account.
+func (l *LightningWallet) walletAssembler(
+	account string) *chanfunding.WalletAssembler {
+
+	cfg := chanfunding.WalletConfig{
+		CoinSource:       &CoinSource{wallet: l, account: account},
+		CoinSelectLocker: l,
+		CoinLocker:       l,
+		Signer:           l.Cfg.Signer,
+		DustLimit:        DefaultDustLimit(),
+
+		CoinSelectionStrategy: l.Cfg.CoinSelectionStrategy,
+	}
+
+	return chanfunding.NewWalletAssembler(cfg)
+}
+
+// EstimateFunding runs coin selection for a channel funded with localAmt from
+// the given account of the wallet at the given fee rate, without locking any
+// coins or creating a reservation. Coins with less than minConfs confirmations
+// aren't considered.
+func (l *LightningWallet) EstimateFunding(localAmt btcutil.Amount,
+	minConfs int32, feeRate chainfee.SatPerKWeight, subtractFees bool,
+	strategy chanfunding.CoinSelectionStrategy, account string) (
+	*chanfunding.FundingEstimate, error) {
+
+	return l.walletAssembler(account).EstimateChannel(&chanfunding.Request{
+		LocalAmt:              localAmt,
+		MinConfs:              minConfs,
+		SubtractFees:          subtractFees,
+		FeeRate:               feeRate,
+		CoinSelectionStrategy: strategy,
+	})
+}
+
 // handleFundingReserveRequest processes a message intending to create, and
 // validate a funding reservation request.
 func (l *LightningWallet) handleFundingReserveRequest(req *InitFundingReserveMsg) {
@@ -619,14 +673,7 @@ func (l *LightningWallet) handleFundingReserveRequest(req *InitFundingReserveMsg
 	// If no chanFunder was provided, then we'll assume the default
 	// assembler, which is backed by the wallet's internal coin selection.
 	if req.ChanFunder == nil {
-		cfg := chanfunding.WalletConfig{
-			CoinSource:       &CoinSource{l},
-			CoinSelectLocker: l,
-			CoinLocker:       l,
-			Signer:           l.Cfg.Signer,
-			DustLimit:        DefaultDustLimit(),
-		}
-		req.ChanFunder = chanfunding.NewWalletAssembler(cfg)
+		req.ChanFunder = l.walletAssembler(req.Account)
 	}
 
 	localFundingAmt := req.LocalFundingAmt
@@ -660,8 +707,12 @@ func (l *LightningWallet) handleFundingReserveRequest(req *InitFundingReserveMsg
 			SubtractFees: req.SubtractFees,
 			FeeRate:      req.FundingFeePerKw,
 			ChangeAddr: func() (btcutil.Address, error) {
-				return l.NewAddress(WitnessPubKey, true)
+				return l.NewAddress(
+					WitnessPubKey, true, req.Account,
+				)
 			},
+			CoinSelectionStrategy: req.CoinSelectionStrategy,
+			Outpoints:             req.Outpoints,
 		}
 		fundingIntent, err = req.ChanFunder.ProvisionChannel(
 			fundingReq,
@@ -707,11 +758,17 @@ func (l *LightningWallet) handleFundingReserveRequest(req *InitFundingReserveMsg
 			KeyRing:    keyRing,
 			ShimIntent: shimIntent,
 		}
+	}
+
+	// If this was a registered shim or PSBT intent, we'll obtain the thaw
+	// height of the intent, if present at all. If this is non-zero, then
+	// we'll mark this as the proper channel type.
+	switch intent := fundingIntent.(type) {
+	case *chanfunding.ShimIntent:
+		thawHeight = intent.ThawHeight()
 
-		// As this was a registered shim intent, we'll obtain the thaw
-		// height of the intent, if present at all. If this is
-		// non-zero, then we'll mark this as the proper channel type.
-		thawHeight = shimIntent.ThawHeight()
+	case *chanfunding.PsbtIntent:
+		thawHeight = intent.ThawHeight()
 	}
 
 	// The total channel capacity will be the size of the funding output we
@@ -1764,11 +1821,17 @@ func (l *LightningWallet) ValidateChannel(channelState *channeldb.OpenChannel,
 // chanfunding.CoinSource interface.
 type CoinSource struct {
 	wallet *LightningWallet
+
+	// account is the name of the account the coins are sourced from. If
+	// empty, the coins of the default account are used.
+	account string
 }
 
-// NewCoinSource creates a new instance of the CoinSource wrapper struct.
-func NewCoinSource(w *LightningWallet) *CoinSource {
-	return &CoinSource{wallet: w}
+// NewCoinSource creates a new instance of the CoinSource wrapper struct that
+// sources the coins of the given account, or those of the default account if
+// the name is empty.
+func NewCoinSource(w *LightningWallet, account string) *CoinSource {
+	return &CoinSource{wallet: w, account: account}
 }
 
 // ListCoins returns all UTXOs from the source that have between
@@ -1776,19 +1839,33 @@ func NewCoinSource(w *LightningWallet) *CoinSource {
 func (c *CoinSource) ListCoins(minConfs int32,
 	maxConfs int32) ([]chanfunding.Coin, error) {
 
-	utxos, err := c.wallet.ListUnspentWitness(minConfs, maxConfs)
+	// Channels are never funded with the coins of other accounts, so the
+	// default account is used if none was specified.
+	account := c.account
+	if account == "" {
+		account = DefaultAccountName
+	}
+
+	utxos, err := c.wallet.ListUnspentWitness(minConfs, maxConfs, account)
 	if err != nil {
 		return nil, err
 	}
 
 	var coins []chanfunding.Coin
 	for _, utxo := range utxos {
+		// Outputs frozen by the user must never be used to fund a
+		// channel.
+		if utxo.Frozen {
+			continue
+		}
+
 		coins = append(coins, chanfunding.Coin{
 			TxOut: wire.TxOut{
 				Value:    int64(utxo.Value),
 				PkScript: utxo.PkScript,
 			},
-			OutPoint: utxo.OutPoint,
+			OutPoint:      utxo.OutPoint,
+			Confirmations: utxo.Confirmations,
 		})
 	}
 
@@ -0,0 +1,86 @@
+package lnwire
+
+import (
+	"io"
+)
+
+// ChannelUpgrade is the message sent to upgrade the commitment format of an
+// existing channel in place. The sender proposes the commitment features the
+// channel should use from now on. The recipient either accepts the proposal by
+// echoing the same features back, or rejects it by replying with the features
+// the channel currently uses. Once the upgrade is accepted, both parties sign
+// new commitments of the upgraded format.
+//
+// A channel can only be upgraded if both parties negotiated the
+// ChannelUpgradeOptional feature bit, and if the channel has no HTLCs or
+// pending updates.
+type ChannelUpgrade struct {
+	// ChanID is the channel that this upgrade is meant for.
+	ChanID ChannelID
+
+	// CommitmentFeatures is the set of commitment related feature bits
+	// the channel should use, such as StaticRemoteKeyRequired and
+	// AnchorsRequired. An empty vector denotes the legacy commitment
+	// format.
+	CommitmentFeatures *RawFeatureVector
+}
+
+// NewChannelUpgrade creates a new ChannelUpgrade message.
+func NewChannelUpgrade(chanID ChannelID,
+	features *RawFeatureVector) *ChannelUpgrade {
+
+	return &ChannelUpgrade{
+		ChanID:             chanID,
+		CommitmentFeatures: features,
+	}
+}
+
+// A compile time check to ensure ChannelUpgrade implements the lnwire.Message
+// interface.
+var _ Message = (*ChannelUpgrade)(nil)
+
+// Decode deserializes a serialized ChannelUpgrade message stored in the passed
+// io.Reader observing the specified protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (c *ChannelUpgrade) Decode(r io.Reader, pver uint32) error {
+	return ReadElements(r,
+		&c.ChanID,
+		&c.CommitmentFeatures,
+	)
+}
+
+// Encode serializes the target ChannelUpgrade into the passed io.Writer
+// observing the protocol version specified.
+//
+// This is part of the lnwire.Message interface.
+func (c *ChannelUpgrade) Encode(w io.Writer, pver uint32) error {
+	return WriteElements(w,
+		c.ChanID,
+		c.CommitmentFeatures,
+	)
+}
+
+// MsgType returns the integer uniquely identifying this message type on the
+// wire.
+//
+// This is part of the lnwire.Message interface.
+func (c *ChannelUpgrade) MsgType() MessageType {
+	return MsgChannelUpgrade
+}
+
+// MaxPayloadLength returns the maximum allowed payload size for a
+// ChannelUpgrade complete message observing the specified protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (c *ChannelUpgrade) MaxPayloadLength(uint32) uint32 {
+	return MaxMessagePayload
+}
+
+// TargetChanID returns the channel id of the link for which this message is
+// intended.
+//
+// NOTE: Part of peer.LinkUpdater interface.
+func (c *ChannelUpgrade) TargetChanID() ChannelID {
+	return c.ChanID
+}
@@ -1,11 +1,87 @@
 package lnwire
 
 import (
+	"bytes"
 	"io"
+	"io/ioutil"
 
 	"github.com/btcsuite/btcutil"
+	"github.com/cryptomeow/lnd/tlv"
 )
 
+const (
+	// feeRangeRecordType is the TLV type of the optional fee range that
+	// can be appended to a ClosingSigned message.
+	feeRangeRecordType tlv.Type = 1
+
+	// feeRangeRecordSize is the size of an encoded fee range record.
+	feeRangeRecordSize = 16
+)
+
+// ClosingFeeRange is the range of fees, in satoshis, that the sender of a
+// ClosingSigned message is willing to pay for the close transaction. If both
+// parties exchange a fee range, the fee negotiation can be completed in a
+// single round trip by settling on a fee within the overlap of both ranges.
+type ClosingFeeRange struct {
+	// MinFeeSatoshis is the minimum fee that the sender will accept.
+	MinFeeSatoshis btcutil.Amount
+
+	// MaxFeeSatoshis is the maximum fee that the sender will accept.
+	MaxFeeSatoshis btcutil.Amount
+}
+
+// InRange returns true if the passed fee lies within the fee range.
+func (f *ClosingFeeRange) InRange(fee btcutil.Amount) bool {
+	return fee >= f.MinFeeSatoshis && fee <= f.MaxFeeSatoshis
+}
+
+// encodeFeeRange is a tlv.Encoder for the ClosingFeeRange type.
+func encodeFeeRange(w io.Writer, val interface{}, buf *[8]byte) error {
+	if f, ok := val.(*ClosingFeeRange); ok {
+		minFee := uint64(f.MinFeeSatoshis)
+		if err := tlv.EUint64(w, &minFee, buf); err != nil {
+			return err
+		}
+
+		maxFee := uint64(f.MaxFeeSatoshis)
+		return tlv.EUint64(w, &maxFee, buf)
+	}
+
+	return tlv.NewTypeForEncodingErr(val, "lnwire.ClosingFeeRange")
+}
+
+// decodeFeeRange is a tlv.Decoder for the ClosingFeeRange type.
+func decodeFeeRange(r io.Reader, val interface{}, buf *[8]byte,
+	l uint64) error {
+
+	if f, ok := val.(*ClosingFeeRange); ok && l == feeRangeRecordSize {
+		var minFee, maxFee uint64
+		if err := tlv.DUint64(r, &minFee, buf, 8); err != nil {
+			return err
+		}
+		if err := tlv.DUint64(r, &maxFee, buf, 8); err != nil {
+			return err
+		}
+
+		f.MinFeeSatoshis = btcutil.Amount(minFee)
+		f.MaxFeeSatoshis = btcutil.Amount(maxFee)
+
+		return nil
+	}
+
+	return tlv.NewTypeForDecodingErr(
+		val, "lnwire.ClosingFeeRange", l, feeRangeRecordSize,
+	)
+}
+
+// newFeeRangeRecord returns the TLV record of the passed fee range.
+func newFeeRangeRecord(feeRange *ClosingFeeRange) tlv.Record {
+	return tlv.MakeStaticRecord(
+		feeRangeRecordType, feeRange, feeRangeRecordSize,
+		encodeFeeRange, decodeFeeRange,
+	)
+}
+
 // ClosingSigned is sent by both parties to a channel once the channel is clear
 // of HTLCs, and is primarily concerned with negotiating fees for the close
 // transaction. Each party provides a signature for a transaction with a fee
@@ -27,6 +103,11 @@ type ClosingSigned struct {
 
 	// Signature is for the proposed channel close transaction.
 	Signature Sig
+
+	// FeeRange is an optional range of fees that the sender is willing to
+	// accept for the close transaction. It is sent as a TLV record after
+	// the signature, so peers that don't understand it will ignore it.
+	FeeRange *ClosingFeeRange
 }
 
 // NewClosingSigned creates a new empty ClosingSigned message.
@@ -49,7 +130,39 @@ var _ Message = (*ClosingSigned)(nil)
 //
 // This is part of the lnwire.Message interface.
 func (c *ClosingSigned) Decode(r io.Reader, pver uint32) error {
-	return ReadElements(r, &c.ChannelID, &c.FeeSatoshis, &c.Signature)
+	err := ReadElements(r, &c.ChannelID, &c.FeeSatoshis, &c.Signature)
+	if err != nil {
+		return err
+	}
+
+	// The remainder of the message, if any, is a TLV stream that may
+	// contain the fee range of the sender.
+	tlvBytes, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if len(tlvBytes) == 0 {
+		return nil
+	}
+
+	var feeRange ClosingFeeRange
+	tlvStream, err := tlv.NewStream(newFeeRangeRecord(&feeRange))
+	if err != nil {
+		return err
+	}
+
+	parsedTypes, err := tlvStream.DecodeWithParsedTypes(
+		bytes.NewReader(tlvBytes),
+	)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := parsedTypes[feeRangeRecordType]; ok {
+		c.FeeRange = &feeRange
+	}
+
+	return nil
 }
 
 // Encode serializes the target ClosingSigned into the passed io.Writer
@@ -57,7 +170,21 @@ func (c *ClosingSigned) Decode(r io.Reader, pver uint32) error {
 //
 // This is part of the lnwire.Message interface.
 func (c *ClosingSigned) Encode(w io.Writer, pver uint32) error {
-	return WriteElements(w, c.ChannelID, c.FeeSatoshis, c.Signature)
+	err := WriteElements(w, c.ChannelID, c.FeeSatoshis, c.Signature)
+	if err != nil {
+		return err
+	}
+
+	if c.FeeRange == nil {
+		return nil
+	}
+
+	tlvStream, err := tlv.NewStream(newFeeRangeRecord(c.FeeRange))
+	if err != nil {
+		return err
+	}
+
+	return tlvStream.Encode(w)
 }
 
 // MsgType returns the integer uniquely identifying this message type on the
@@ -84,5 +211,8 @@ func (c *ClosingSigned) MaxPayloadLength(uint32) uint32 {
 	// Signature - 64 bytes
 	length += 64
 
+	// FeeRange - 1 byte type + 1 byte length + 16 bytes
+	length += 2 + feeRangeRecordSize
+
 	return length
 }
@@ -0,0 +1,93 @@
+package lnwire
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+)
+
+// CustomTypeStart is the start of the message type range reserved for
+// experimental protocol extensions. Messages of a type in this range that
+// aren't known to lnd are decoded as Custom messages.
+const CustomTypeStart MessageType = 32768
+
+// Custom is a message of a type in the custom range that isn't interpreted by
+// lnd itself. It allows applications to exchange messages of experimental
+// protocol extensions with their peers.
+type Custom struct {
+	// Type is the message type of the custom message.
+	Type MessageType
+
+	// Data is the raw payload of the custom message.
+	Data []byte
+}
+
+// NewCustom creates a new custom message of the given type. Only odd types in
+// the custom range can be sent, so that peers that don't understand the
+// message can safely ignore it, and types that are already used by lnd are
+// rejected.
+func NewCustom(msgType MessageType, data []byte) (*Custom, error) {
+	if msgType < CustomTypeStart {
+		return nil, fmt.Errorf("custom message type %v is below the "+
+			"custom range start %v", uint16(msgType),
+			uint16(CustomTypeStart))
+	}
+
+	if msgType%2 == 0 {
+		return nil, fmt.Errorf("custom message type %v must be odd",
+			uint16(msgType))
+	}
+
+	msg, err := makeEmptyMessage(msgType)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := msg.(*Custom); !ok {
+		return nil, fmt.Errorf("message type %v is already used by "+
+			"%v", uint16(msgType), msgType)
+	}
+
+	return &Custom{
+		Type: msgType,
+		Data: data,
+	}, nil
+}
+
+// A compile time check to ensure Custom implements the lnwire.Message
+// interface.
+var _ Message = (*Custom)(nil)
+
+// Decode deserializes a serialized Custom message stored in the passed
+// io.Reader observing the specified protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (c *Custom) Decode(r io.Reader, pver uint32) error {
+	var err error
+	c.Data, err = ioutil.ReadAll(r)
+	return err
+}
+
+// Encode serializes the target Custom message into the passed io.Writer
+// observing the protocol version specified.
+//
+// This is part of the lnwire.Message interface.
+func (c *Custom) Encode(w io.Writer, pver uint32) error {
+	_, err := w.Write(c.Data)
+	return err
+}
+
+// MsgType returns the integer uniquely identifying this message type on the
+// wire.
+//
+// This is part of the lnwire.Message interface.
+func (c *Custom) MsgType() MessageType {
+	return c.Type
+}
+
+// MaxPayloadLength returns the maximum allowed payload size for a Custom
+// complete message observing the specified protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (c *Custom) MaxPayloadLength(uint32) uint32 {
+	return MaxMessagePayload
+}
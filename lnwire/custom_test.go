@@ -0,0 +1,70 @@
+package lnwire
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestNewCustom tests that only odd message types of the custom range that
+// aren't used by lnd are accepted for custom messages.
+func TestNewCustom(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		msgType MessageType
+		valid   bool
+	}{
+		{
+			name:    "below custom range",
+			msgType: MsgGossipTimestampRange,
+		},
+		{
+			name:    "even type",
+			msgType: CustomTypeStart + 2,
+		},
+		{
+			name:    "used by lnd",
+			msgType: MsgChannelUpgrade,
+		},
+		{
+			name:    "odd custom type",
+			msgType: CustomTypeStart + 3,
+			valid:   true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			msg, err := NewCustom(test.msgType, []byte{1, 2, 3})
+			if !test.valid {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.msgType, msg.MsgType())
+		})
+	}
+}
+
+// TestCustomRoundTrip tests that custom messages are read back with the type
+// and payload they were written with.
+func TestCustomRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	msg, err := NewCustom(CustomTypeStart+3, []byte("payload"))
+	require.NoError(t, err)
+
+	var b bytes.Buffer
+	_, err = WriteMessage(&b, msg, 0)
+	require.NoError(t, err)
+
+	readMsg, err := ReadMessage(&b, 0)
+	require.NoError(t, err)
+	require.Equal(t, msg, readMsg)
+}
@@ -23,6 +23,15 @@ const (
 	// FundingOpen request for a channel that is above their current
 	// soft-limit.
 	ErrChanTooLarge FundingError = 3
+
+	// ErrNodeDraining is returned by a remote peer that receives a
+	// FundingOpen request while it's draining its HTLCs before shutting
+	// down.
+	ErrNodeDraining FundingError = 4
+
+	// ErrChanRejected is returned by a remote peer whose channel acceptor
+	// rejected our FundingOpen request.
+	ErrChanRejected FundingError = 5
 )
 
 // String returns a human readable version of the target FundingError.
@@ -34,6 +43,10 @@ func (e FundingError) String() string {
 		return "Synchronizing blockchain"
 	case ErrChanTooLarge:
 		return "channel too large"
+	case ErrNodeDraining:
+		return "node draining before shutdown"
+	case ErrChanRejected:
+		return "open channel request rejected"
 	default:
 		return "unknown error"
 	}
@@ -46,6 +59,19 @@ func (e FundingError) Error() string {
 	return e.String()
 }
 
+// ParseFundingError returns the FundingError of which the human readable
+// version matches the passed error data, as sent by a remote peer. The second
+// return value is false if the data doesn't match any FundingError.
+func ParseFundingError(data []byte) (FundingError, bool) {
+	for e := ErrMaxPendingChannels; e <= ErrChanRejected; e++ {
+		if string(data) == e.String() {
+			return e, true
+		}
+	}
+
+	return 0, false
+}
+
 // ErrorData is a set of bytes associated with a particular sent error. A
 // receiving node SHOULD only print out data verbatim if the string is composed
 // solely of printable ASCII characters. For reference, the printable character
@@ -119,6 +119,26 @@ const (
 	// outputs.
 	AnchorsOptional FeatureBit = 21
 
+	// ChannelUpgradeRequired is a required feature bit that signals that
+	// the node requires support for upgrading the commitment format of
+	// existing channels in place.
+	ChannelUpgradeRequired FeatureBit = 36
+
+	// ChannelUpgradeOptional is an optional feature bit that signals that
+	// the node supports upgrading the commitment format of existing
+	// channels in place.
+	ChannelUpgradeOptional FeatureBit = 37
+
+	// ScidAliasRequired is a required feature bit that signals that the
+	// node requires alias short channel IDs to be exchanged for private
+	// channels.
+	ScidAliasRequired FeatureBit = 46
+
+	// ScidAliasOptional is an optional feature bit that signals that the
+	// node supports exchanging alias short channel IDs for private
+	// channels, and forwards htlcs that are addressed to them.
+	ScidAliasOptional FeatureBit = 47
+
 	// maxAllowedSize is a maximum allowed size of feature vector.
 	//
 	// NOTE: Within the protocol, the maximum allowed message size is 65535
@@ -160,6 +180,10 @@ var Features = map[FeatureBit]string{
 	AnchorsOptional:               "anchor-commitments",
 	WumboChannelsRequired:         "wumbo-channels",
 	WumboChannelsOptional:         "wumbo-channels",
+	ChannelUpgradeRequired:        "channel-upgrade",
+	ChannelUpgradeOptional:        "channel-upgrade",
+	ScidAliasRequired:             "scid-alias",
+	ScidAliasOptional:             "scid-alias",
 }
 
 // RawFeatureVector represents a set of feature bits as defined in BOLT-09.  A
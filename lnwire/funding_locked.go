@@ -1,11 +1,63 @@
 package lnwire
 
 import (
+	"bytes"
 	"io"
+	"io/ioutil"
 
 	"github.com/btcsuite/btcd/btcec"
+	"github.com/cryptomeow/lnd/tlv"
 )
 
+const (
+	// aliasScidRecordType is the TLV type of the optional alias short
+	// channel ID that can be appended to a FundingLocked message.
+	aliasScidRecordType tlv.Type = 1
+
+	// aliasScidRecordSize is the size of an encoded alias short channel ID
+	// record.
+	aliasScidRecordSize = 8
+)
+
+// encodeAliasScid is a tlv.Encoder for the ShortChannelID type.
+func encodeAliasScid(w io.Writer, val interface{}, buf *[8]byte) error {
+	if scid, ok := val.(*ShortChannelID); ok {
+		scidInt := scid.ToUint64()
+		return tlv.EUint64(w, &scidInt, buf)
+	}
+
+	return tlv.NewTypeForEncodingErr(val, "lnwire.ShortChannelID")
+}
+
+// decodeAliasScid is a tlv.Decoder for the ShortChannelID type.
+func decodeAliasScid(r io.Reader, val interface{}, buf *[8]byte,
+	l uint64) error {
+
+	if scid, ok := val.(*ShortChannelID); ok && l == aliasScidRecordSize {
+		var scidInt uint64
+		if err := tlv.DUint64(r, &scidInt, buf, 8); err != nil {
+			return err
+		}
+
+		*scid = NewShortChanIDFromInt(scidInt)
+
+		return nil
+	}
+
+	return tlv.NewTypeForDecodingErr(
+		val, "lnwire.ShortChannelID", l, aliasScidRecordSize,
+	)
+}
+
+// newAliasScidRecord returns the TLV record of the passed alias short channel
+// ID.
+func newAliasScidRecord(aliasScid *ShortChannelID) tlv.Record {
+	return tlv.MakeStaticRecord(
+		aliasScidRecordType, aliasScid, aliasScidRecordSize,
+		encodeAliasScid, decodeAliasScid,
+	)
+}
+
 // FundingLocked is the message that both parties to a new channel creation
 // send once they have observed the funding transaction being confirmed on the
 // blockchain. FundingLocked contains the signatures necessary for the channel
@@ -19,6 +71,14 @@ type FundingLocked struct {
 	// NextPerCommitmentPoint is the secret that can be used to revoke the
 	// next commitment transaction for the channel.
 	NextPerCommitmentPoint *btcec.PublicKey
+
+	// AliasScid is an optional alias short channel ID of a private
+	// channel. The sender forwards htlcs that are addressed to the alias
+	// over the channel, so the receiver can use it in route hints instead
+	// of the real short channel ID. It is sent as a TLV record after the
+	// next per commitment point, so peers that don't understand it will
+	// ignore it.
+	AliasScid *ShortChannelID
 }
 
 // NewFundingLocked creates a new FundingLocked message, populating it with the
@@ -40,9 +100,41 @@ var _ Message = (*FundingLocked)(nil)
 //
 // This is part of the lnwire.Message interface.
 func (c *FundingLocked) Decode(r io.Reader, pver uint32) error {
-	return ReadElements(r,
+	err := ReadElements(r,
 		&c.ChanID,
 		&c.NextPerCommitmentPoint)
+	if err != nil {
+		return err
+	}
+
+	// The remainder of the message, if any, is a TLV stream that may
+	// contain the alias short channel ID of the sender.
+	tlvBytes, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if len(tlvBytes) == 0 {
+		return nil
+	}
+
+	var aliasScid ShortChannelID
+	tlvStream, err := tlv.NewStream(newAliasScidRecord(&aliasScid))
+	if err != nil {
+		return err
+	}
+
+	parsedTypes, err := tlvStream.DecodeWithParsedTypes(
+		bytes.NewReader(tlvBytes),
+	)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := parsedTypes[aliasScidRecordType]; ok {
+		c.AliasScid = &aliasScid
+	}
+
+	return nil
 }
 
 // Encode serializes the target FundingLocked message into the passed io.Writer
@@ -51,9 +143,23 @@ func (c *FundingLocked) Decode(r io.Reader, pver uint32) error {
 //
 // This is part of the lnwire.Message interface.
 func (c *FundingLocked) Encode(w io.Writer, pver uint32) error {
-	return WriteElements(w,
+	err := WriteElements(w,
 		c.ChanID,
 		c.NextPerCommitmentPoint)
+	if err != nil {
+		return err
+	}
+
+	if c.AliasScid == nil {
+		return nil
+	}
+
+	tlvStream, err := tlv.NewStream(newAliasScidRecord(c.AliasScid))
+	if err != nil {
+		return err
+	}
+
+	return tlvStream.Encode(w)
 }
 
 // MsgType returns the uint32 code which uniquely identifies this message as a
@@ -78,6 +184,9 @@ func (c *FundingLocked) MaxPayloadLength(uint32) uint32 {
 	// NextPerCommitmentPoint - 33 bytes
 	length += 33
 
-	// 65 bytes
+	// AliasScid - 1 byte type + 1 byte length + 8 bytes
+	length += 2 + aliasScidRecordSize
+
+	// 75 bytes
 	return length
 }
@@ -240,7 +240,9 @@ func TestMaxOutPointIndex(t *testing.T) {
 func TestEmptyMessageUnknownType(t *testing.T) {
 	t.Parallel()
 
-	fakeType := MessageType(math.MaxUint16)
+	// Unknown types in the custom range are decoded as custom messages,
+	// so we use the type right below it.
+	fakeType := CustomTypeStart - 1
 	if _, err := makeEmptyMessage(fakeType); err == nil {
 		t.Fatalf("should not be able to make an empty message of an " +
 			"unknown type")
@@ -481,6 +483,19 @@ func TestLightningWireProtocol(t *testing.T) {
 
 			v[0] = reflect.ValueOf(req)
 		},
+		MsgChannelUpgrade: func(v []reflect.Value, r *rand.Rand) {
+			var c [32]byte
+			if _, err := r.Read(c[:]); err != nil {
+				t.Fatalf("unable to generate chan id: %v", err)
+				return
+			}
+
+			req := NewChannelUpgrade(
+				ChannelID(c), randRawFeatureVector(r),
+			)
+
+			v[0] = reflect.ValueOf(*req)
+		},
 		MsgFundingLocked: func(v []reflect.Value, r *rand.Rand) {
 
 			var c [32]byte
@@ -497,6 +512,12 @@ func TestLightningWireProtocol(t *testing.T) {
 
 			req := NewFundingLocked(ChannelID(c), pubKey)
 
+			// 1/2 chance the alias short channel ID is set.
+			if r.Intn(2) == 0 {
+				alias := NewShortChanIDFromInt(uint64(r.Int63()))
+				req.AliasScid = &alias
+			}
+
 			v[0] = reflect.ValueOf(*req)
 		},
 		MsgClosingSigned: func(v []reflect.Value, r *rand.Rand) {
@@ -515,6 +536,15 @@ func TestLightningWireProtocol(t *testing.T) {
 				return
 			}
 
+			// Only half of the messages carry the optional fee
+			// range.
+			if r.Int31()%2 == 0 {
+				req.FeeRange = &ClosingFeeRange{
+					MinFeeSatoshis: btcutil.Amount(r.Int63()),
+					MaxFeeSatoshis: btcutil.Amount(r.Int63()),
+				}
+			}
+
 			v[0] = reflect.ValueOf(req)
 		},
 		MsgCommitSig: func(v []reflect.Value, r *rand.Rand) {
@@ -989,6 +1019,12 @@ func TestLightningWireProtocol(t *testing.T) {
 				return mainScenario(&m)
 			},
 		},
+		{
+			msgType: MsgChannelUpgrade,
+			scenario: func(m ChannelUpgrade) bool {
+				return mainScenario(&m)
+			},
+		},
 		{
 			msgType: MsgGossipTimestampRange,
 			scenario: func(m GossipTimestampRange) bool {
@@ -54,6 +54,7 @@ const (
 	MsgQueryChannelRange                   = 263
 	MsgReplyChannelRange                   = 264
 	MsgGossipTimestampRange                = 265
+	MsgChannelUpgrade                      = 32769
 )
 
 // String return the string representation of message type.
@@ -115,6 +116,8 @@ func (t MessageType) String() string {
 		return "ReplyChannelRange"
 	case MsgGossipTimestampRange:
 		return "GossipTimestampRange"
+	case MsgChannelUpgrade:
+		return "ChannelUpgrade"
 	default:
 		return "<unknown>"
 	}
@@ -216,8 +219,15 @@ func makeEmptyMessage(msgType MessageType) (Message, error) {
 		msg = &ReplyChannelRange{}
 	case MsgGossipTimestampRange:
 		msg = &GossipTimestampRange{}
+	case MsgChannelUpgrade:
+		msg = &ChannelUpgrade{}
 	default:
-		return nil, &UnknownMessage{msgType}
+		// Messages in the custom range that we don't know of are
+		// handed to applications as custom messages.
+		if msgType < CustomTypeStart {
+			return nil, &UnknownMessage{msgType}
+		}
+		msg = &Custom{Type: msgType}
 	}
 
 	return msg, nil
@@ -4,6 +4,19 @@ import (
 	"fmt"
 )
 
+const (
+	// AliasStartBlockHeight is the block height of the lowest alias short
+	// channel ID. Aliases are assigned to private channels instead of the
+	// real short channel ID, so the funding outpoint isn't revealed in
+	// invoice route hints. The block heights of aliases are far in the
+	// future, so they never collide with real short channel IDs.
+	AliasStartBlockHeight uint32 = 16000000
+
+	// AliasEndBlockHeight is the block height above the highest alias
+	// short channel ID.
+	AliasEndBlockHeight uint32 = 16250000
+)
+
 // ShortChannelID represents the set of data which is needed to retrieve all
 // necessary data to validate the channel existence.
 type ShortChannelID struct {
@@ -46,3 +59,10 @@ func (c ShortChannelID) ToUint64() uint64 {
 func (c ShortChannelID) String() string {
 	return fmt.Sprintf("%d:%d:%d", c.BlockHeight, c.TxIndex, c.TxPosition)
 }
+
+// IsAlias returns true if the short channel ID is within the range of alias
+// short channel IDs.
+func (c ShortChannelID) IsAlias() bool {
+	return c.BlockHeight >= AliasStartBlockHeight &&
+		c.BlockHeight < AliasEndBlockHeight
+}
@@ -1,14 +1,63 @@
 package lnwire
 
 import (
+	"bytes"
 	"io"
+	"io/ioutil"
+
+	"github.com/cryptomeow/lnd/tlv"
 )
 
-// OnionPacketSize is the size of the serialized Sphinx onion packet included
-// in each UpdateAddHTLC message. The breakdown of the onion packet is as
-// follows: 1-byte version, 33-byte ephemeral public key (for ECDH), 1300-bytes
-// of per-hop data, and a 32-byte HMAC over the entire packet.
-const OnionPacketSize = 1366
+const (
+	// OnionPacketSize is the size of the serialized Sphinx onion packet
+	// included in each UpdateAddHTLC message. The breakdown of the onion
+	// packet is as follows: 1-byte version, 33-byte ephemeral public key
+	// (for ECDH), 1300-bytes of per-hop data, and a 32-byte HMAC over the
+	// entire packet.
+	OnionPacketSize = 1366
+
+	// UpdateAddHTLCBaseSize is the size of the fixed fields of an
+	// UpdateAddHTLC message, without any of its optional TLV records.
+	UpdateAddHTLCBaseSize = 32 + 8 + 8 + 32 + 4 + OnionPacketSize
+
+	// ExperimentalEndorsementType is the TLV type of the experimental
+	// endorsement signal that can be appended to an UpdateAddHTLC message.
+	ExperimentalEndorsementType tlv.Type = 106823
+
+	// endorsementRecordSize is the encoded size of the endorsement record:
+	// a 5 byte type, a 1 byte length and the 1 byte signal.
+	endorsementRecordSize = 7
+)
+
+// EndorsementSignal is the experimental signal with which the sender of an
+// HTLC indicates whether it vouches for the HTLC to resolve quickly. It lays
+// the groundwork for reputation systems that mitigate channel jamming, so
+// nodes can track how the signal of each peer correlates with the outcome of
+// its HTLCs.
+type EndorsementSignal uint8
+
+const (
+	// Unendorsed indicates that the sender doesn't vouch for the HTLC.
+	Unendorsed EndorsementSignal = 0
+
+	// Endorsed indicates that the sender vouches for the HTLC.
+	Endorsed EndorsementSignal = 1
+)
+
+// IsEndorsed returns true if the signal endorses the HTLC. Unknown values are
+// treated as unendorsed.
+func (e EndorsementSignal) IsEndorsed() bool {
+	return e == Endorsed
+}
+
+// String returns a human readable representation of the signal.
+func (e EndorsementSignal) String() string {
+	if e.IsEndorsed() {
+		return "endorsed"
+	}
+
+	return "unendorsed"
+}
 
 // UpdateAddHTLC is the message sent by Alice to Bob when she wishes to add an
 // HTLC to his remote commitment transaction. In addition to information
@@ -52,6 +101,11 @@ type UpdateAddHTLC struct {
 	// should strip off a layer of encryption, exposing the next hop to be
 	// used in the subsequent UpdateAddHTLC message.
 	OnionBlob [OnionPacketSize]byte
+
+	// Endorsement is the optional experimental endorsement signal of the
+	// HTLC. It is sent as a TLV record after the onion blob, so peers that
+	// don't understand it will ignore it. If nil, no signal is sent.
+	Endorsement *EndorsementSignal
 }
 
 // NewUpdateAddHTLC returns a new empty UpdateAddHTLC message.
@@ -68,7 +122,7 @@ var _ Message = (*UpdateAddHTLC)(nil)
 //
 // This is part of the lnwire.Message interface.
 func (c *UpdateAddHTLC) Decode(r io.Reader, pver uint32) error {
-	return ReadElements(r,
+	err := ReadElements(r,
 		&c.ChanID,
 		&c.ID,
 		&c.Amount,
@@ -76,6 +130,43 @@ func (c *UpdateAddHTLC) Decode(r io.Reader, pver uint32) error {
 		&c.Expiry,
 		c.OnionBlob[:],
 	)
+	if err != nil {
+		return err
+	}
+
+	// The remainder of the message, if any, is a TLV stream that may
+	// contain the endorsement signal of the sender.
+	tlvBytes, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if len(tlvBytes) == 0 {
+		return nil
+	}
+
+	var endorsement uint8
+	tlvStream, err := tlv.NewStream(
+		tlv.MakePrimitiveRecord(
+			ExperimentalEndorsementType, &endorsement,
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	parsedTypes, err := tlvStream.DecodeWithParsedTypes(
+		bytes.NewReader(tlvBytes),
+	)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := parsedTypes[ExperimentalEndorsementType]; ok {
+		signal := EndorsementSignal(endorsement)
+		c.Endorsement = &signal
+	}
+
+	return nil
 }
 
 // Encode serializes the target UpdateAddHTLC into the passed io.Writer observing
@@ -83,7 +174,7 @@ func (c *UpdateAddHTLC) Decode(r io.Reader, pver uint32) error {
 //
 // This is part of the lnwire.Message interface.
 func (c *UpdateAddHTLC) Encode(w io.Writer, pver uint32) error {
-	return WriteElements(w,
+	err := WriteElements(w,
 		c.ChanID,
 		c.ID,
 		c.Amount,
@@ -91,6 +182,25 @@ func (c *UpdateAddHTLC) Encode(w io.Writer, pver uint32) error {
 		c.Expiry,
 		c.OnionBlob[:],
 	)
+	if err != nil {
+		return err
+	}
+
+	if c.Endorsement == nil {
+		return nil
+	}
+
+	endorsement := uint8(*c.Endorsement)
+	tlvStream, err := tlv.NewStream(
+		tlv.MakePrimitiveRecord(
+			ExperimentalEndorsementType, &endorsement,
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	return tlvStream.Encode(w)
 }
 
 // MsgType returns the integer uniquely identifying this message type on the
@@ -106,8 +216,7 @@ func (c *UpdateAddHTLC) MsgType() MessageType {
 //
 // This is part of the lnwire.Message interface.
 func (c *UpdateAddHTLC) MaxPayloadLength(uint32) uint32 {
-	// 1450
-	return 32 + 8 + 4 + 8 + 32 + 1366
+	return UpdateAddHTLCBaseSize + endorsementRecordSize
 }
 
 // TargetChanID returns the channel id of the link for which this message is
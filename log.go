@@ -7,15 +7,20 @@ import (
 	"github.com/btcsuite/btclog"
 	"github.com/lightninglabs/neutrino"
 	sphinx "github.com/lightningnetwork/lightning-onion"
+	"github.com/cryptomeow/lnd/acmedns"
 	"github.com/cryptomeow/lnd/autopilot"
+	"github.com/cryptomeow/lnd/blockfetch"
+	"github.com/cryptomeow/lnd/broadcast"
 	"github.com/cryptomeow/lnd/build"
 	"github.com/cryptomeow/lnd/chainntnfs"
 	"github.com/cryptomeow/lnd/chanbackup"
 	"github.com/cryptomeow/lnd/chanfitness"
+	"github.com/cryptomeow/lnd/chanmonitor"
 	"github.com/cryptomeow/lnd/channeldb"
 	"github.com/cryptomeow/lnd/channelnotifier"
 	"github.com/cryptomeow/lnd/contractcourt"
 	"github.com/cryptomeow/lnd/discovery"
+	"github.com/cryptomeow/lnd/eventbus"
 	"github.com/cryptomeow/lnd/healthcheck"
 	"github.com/cryptomeow/lnd/htlcswitch"
 	"github.com/cryptomeow/lnd/invoices"
@@ -26,19 +31,24 @@ import (
 	"github.com/cryptomeow/lnd/lnrpc/signrpc"
 	"github.com/cryptomeow/lnd/lnrpc/verrpc"
 	"github.com/cryptomeow/lnd/lnrpc/walletrpc"
+	"github.com/cryptomeow/lnd/lnurl"
 	"github.com/cryptomeow/lnd/lnwallet"
 	"github.com/cryptomeow/lnd/lnwallet/chancloser"
 	"github.com/cryptomeow/lnd/lnwallet/chanfunding"
 	"github.com/cryptomeow/lnd/monitoring"
 	"github.com/cryptomeow/lnd/netann"
 	"github.com/cryptomeow/lnd/peer"
+	"github.com/cryptomeow/lnd/peerfilter"
 	"github.com/cryptomeow/lnd/peernotifier"
 	"github.com/cryptomeow/lnd/routing"
 	"github.com/cryptomeow/lnd/routing/localchans"
 	"github.com/cryptomeow/lnd/signal"
+	"github.com/cryptomeow/lnd/swaphook"
 	"github.com/cryptomeow/lnd/sweep"
+	"github.com/cryptomeow/lnd/watchonly"
 	"github.com/cryptomeow/lnd/watchtower"
 	"github.com/cryptomeow/lnd/watchtower/wtclient"
+	"github.com/cryptomeow/lnd/webhook"
 	"google.golang.org/grpc"
 )
 
@@ -131,6 +141,16 @@ func SetupLoggers(root *build.RotatingLogWriter) {
 	AddSubLogger(root, chanfitness.Subsystem, chanfitness.UseLogger)
 	AddSubLogger(root, verrpc.Subsystem, verrpc.UseLogger)
 	AddSubLogger(root, healthcheck.Subsystem, healthcheck.UseLogger)
+	AddSubLogger(root, blockfetch.Subsystem, blockfetch.UseLogger)
+	AddSubLogger(root, webhook.Subsystem, webhook.UseLogger)
+	AddSubLogger(root, broadcast.Subsystem, broadcast.UseLogger)
+	AddSubLogger(root, lnurl.Subsystem, lnurl.UseLogger)
+	AddSubLogger(root, eventbus.Subsystem, eventbus.UseLogger)
+	AddSubLogger(root, watchonly.Subsystem, watchonly.UseLogger)
+	AddSubLogger(root, peerfilter.Subsystem, peerfilter.UseLogger)
+	AddSubLogger(root, acmedns.Subsystem, acmedns.UseLogger)
+	AddSubLogger(root, chanmonitor.Subsystem, chanmonitor.UseLogger)
+	AddSubLogger(root, swaphook.Subsystem, swaphook.UseLogger)
 }
 
 // AddSubLogger is a helper method to conveniently create and register the
@@ -12,6 +12,10 @@ import (
 	macaroon "gopkg.in/macaroon.v2"
 )
 
+// observerCondition is the condition of the first party caveat that marks a
+// macaroon as an observer macaroon.
+const observerCondition = "observer"
+
 // Constraint type adds a layer of indirection over macaroon caveats.
 type Constraint func(*macaroon.Macaroon) error
 
@@ -87,3 +91,34 @@ func IPLockChecker() (string, checkers.Func) {
 		return nil
 	}
 }
+
+// ObserverConstraint marks the macaroon as an observer macaroon. Observer
+// macaroons can only be used to call methods that don't modify the state of
+// the daemon, regardless of the permissions they grant.
+func ObserverConstraint() func(*macaroon.Macaroon) error {
+	return func(mac *macaroon.Macaroon) error {
+		return mac.AddFirstPartyCaveat([]byte(observerCondition))
+	}
+}
+
+// ObserverChecker accepts the observer caveat. It is of the `Checker` type.
+// The caveat itself can't be checked without knowing the called method, so
+// it's enforced by the interceptors of the service instead.
+func ObserverChecker() (string, checkers.Func) {
+	return observerCondition, func(ctx context.Context, cond,
+		arg string) error {
+
+		return nil
+	}
+}
+
+// IsObserver returns true if the macaroon is an observer macaroon.
+func IsObserver(mac *macaroon.Macaroon) bool {
+	for _, caveat := range mac.Caveats() {
+		if string(caveat.Id) == observerCondition {
+			return true
+		}
+	}
+
+	return false
+}
@@ -28,6 +28,11 @@ var (
 	// DefaultRootKeyID or the encryptedKeyID.
 	ErrDeletionForbidden = fmt.Errorf("the specified ID cannot be deleted")
 
+	// ErrObserverForbidden is returned when an observer macaroon is used to
+	// call a method that may modify the state of the daemon.
+	ErrObserverForbidden = fmt.Errorf("method not permitted for observer " +
+		"macaroon")
+
 	// PermissionEntityCustomURI is a special entity name for a permission
 	// that does not describe an entity:action pair but instead specifies a
 	// specific URI that needs to be granted access to. This can be used for
@@ -62,6 +67,11 @@ type Service struct {
 	// If no external validator for an URI is specified, the service will
 	// use the internal validator.
 	externalValidators map[string]MacaroonValidator
+
+	// mutatingMethods is the set of absolute gRPC URIs of methods that
+	// modify the state of the daemon even though they only require read
+	// permissions. Observer macaroons are denied access to them.
+	mutatingMethods map[string]struct{}
 }
 
 // NewService returns a service backed by the macaroon Bolt DB stored in the
@@ -83,6 +93,7 @@ func NewService(dir, location string, checks ...Checker) (*Service, error) {
 	// and all generated macaroons+caveats.
 	macaroonDB, err := kvdb.Create(
 		kvdb.BoltBackendName, path.Join(dir, DBFilename), true,
+		kvdb.DefaultDBTimeout,
 	)
 	if err != nil {
 		return nil, err
@@ -118,6 +129,7 @@ func NewService(dir, location string, checks ...Checker) (*Service, error) {
 		Bakery:             *svc,
 		rks:                rootKeyStore,
 		externalValidators: make(map[string]MacaroonValidator),
+		mutatingMethods:    make(map[string]struct{}),
 	}, nil
 }
 
@@ -160,6 +172,40 @@ func (svc *Service) RegisterExternalValidator(fullMethod string,
 	return nil
 }
 
+// RegisterMutatingMethod marks the specified absolute gRPC URI as a method that
+// modifies the state of the daemon, even though it only requires read
+// permissions. Observer macaroons are denied access to such methods.
+func (svc *Service) RegisterMutatingMethod(fullMethod string) {
+	svc.mutatingMethods[fullMethod] = struct{}{}
+}
+
+// checkObserver ensures that an observer macaroon that is passed with a request
+// is only used to call methods that don't modify the state of the daemon. A
+// method may modify the state if it requires any permission other than read,
+// or if it was registered as a mutating method.
+func (svc *Service) checkObserver(ctx context.Context,
+	requiredPermissions []bakery.Op, fullMethod string) error {
+
+	// Missing or malformed macaroons are rejected by the validator, so we
+	// only need to check valid ones.
+	mac, err := MacaroonFromContext(ctx)
+	if err != nil || !IsObserver(mac) {
+		return nil
+	}
+
+	if _, ok := svc.mutatingMethods[fullMethod]; ok {
+		return ErrObserverForbidden
+	}
+
+	for _, op := range requiredPermissions {
+		if op.Action != "read" {
+			return ErrObserverForbidden
+		}
+	}
+
+	return nil
+}
+
 // UnaryServerInterceptor is a GRPC interceptor that checks whether the
 // request is authorized by the included macaroons.
 func (svc *Service) UnaryServerInterceptor(
@@ -175,6 +221,13 @@ func (svc *Service) UnaryServerInterceptor(
 				"required for method", info.FullMethod)
 		}
 
+		// Observer macaroons are never allowed to modify the state,
+		// no matter which validator is used.
+		err := svc.checkObserver(ctx, uriPermissions, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
 		// Find out if there is an external validator registered for
 		// this method. Fall back to the internal one if there isn't.
 		validator, ok := svc.externalValidators[info.FullMethod]
@@ -183,7 +236,7 @@ func (svc *Service) UnaryServerInterceptor(
 		}
 
 		// Now that we know what validator to use, let it do its work.
-		err := validator.ValidateMacaroon(
+		err = validator.ValidateMacaroon(
 			ctx, uriPermissions, info.FullMethod,
 		)
 		if err != nil {
@@ -208,6 +261,15 @@ func (svc *Service) StreamServerInterceptor(
 				"for method", info.FullMethod)
 		}
 
+		// Observer macaroons are never allowed to modify the state,
+		// no matter which validator is used.
+		err := svc.checkObserver(
+			ss.Context(), uriPermissions, info.FullMethod,
+		)
+		if err != nil {
+			return err
+		}
+
 		// Find out if there is an external validator registered for
 		// this method. Fall back to the internal one if there isn't.
 		validator, ok := svc.externalValidators[info.FullMethod]
@@ -216,7 +278,7 @@ func (svc *Service) StreamServerInterceptor(
 		}
 
 		// Now that we know what validator to use, let it do its work.
-		err := validator.ValidateMacaroon(
+		err = validator.ValidateMacaroon(
 			ss.Context(), uriPermissions, info.FullMethod,
 		)
 		if err != nil {
@@ -235,24 +297,7 @@ func (svc *Service) ValidateMacaroon(ctx context.Context,
 	requiredPermissions []bakery.Op, fullMethod string) error {
 
 	// Get macaroon bytes from context and unmarshal into macaroon.
-	md, ok := metadata.FromIncomingContext(ctx)
-	if !ok {
-		return fmt.Errorf("unable to get metadata from context")
-	}
-	if len(md["macaroon"]) != 1 {
-		return fmt.Errorf("expected 1 macaroon, got %d",
-			len(md["macaroon"]))
-	}
-
-	// With the macaroon obtained, we'll now decode the hex-string
-	// encoding, then unmarshal it from binary into its concrete struct
-	// representation.
-	macBytes, err := hex.DecodeString(md["macaroon"][0])
-	if err != nil {
-		return err
-	}
-	mac := &macaroon.Macaroon{}
-	err = mac.UnmarshalBinary(macBytes)
+	mac, err := MacaroonFromContext(ctx)
 	if err != nil {
 		return err
 	}
@@ -278,6 +323,34 @@ func (svc *Service) ValidateMacaroon(ctx context.Context,
 	return err
 }
 
+// MacaroonFromContext extracts the macaroon that is encoded as request metadata
+// using the key "macaroon" from the passed context.
+func MacaroonFromContext(ctx context.Context) (*macaroon.Macaroon, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, fmt.Errorf("unable to get metadata from context")
+	}
+	if len(md["macaroon"]) != 1 {
+		return nil, fmt.Errorf("expected 1 macaroon, got %d",
+			len(md["macaroon"]))
+	}
+
+	// With the macaroon obtained, we'll now decode the hex-string
+	// encoding, then unmarshal it from binary into its concrete struct
+	// representation.
+	macBytes, err := hex.DecodeString(md["macaroon"][0])
+	if err != nil {
+		return nil, err
+	}
+	mac := &macaroon.Macaroon{}
+	err = mac.UnmarshalBinary(macBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return mac, nil
+}
+
 // Close closes the database that underlies the RootKeyStore and zeroes the
 // encryption keys.
 func (svc *Service) Close() error {
@@ -11,9 +11,11 @@ import (
 	"github.com/cryptomeow/lnd/channeldb/kvdb"
 	"github.com/cryptomeow/lnd/macaroons"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 	"gopkg.in/macaroon-bakery.v2/bakery"
 	"gopkg.in/macaroon-bakery.v2/bakery/checkers"
+	macaroon "gopkg.in/macaroon.v2"
 )
 
 var (
@@ -40,6 +42,7 @@ func setupTestRootKeyStorage(t *testing.T) string {
 	}
 	db, err := kvdb.Create(
 		kvdb.BoltBackendName, path.Join(tempDir, "macaroons.db"), true,
+		kvdb.DefaultDBTimeout,
 	)
 	if err != nil {
 		t.Fatalf("Error opening store DB: %v", err)
@@ -252,3 +255,116 @@ func TestDeleteMacaroonID(t *testing.T) {
 	ids, _ := service.ListMacaroonIDs(ctxb)
 	require.Equal(t, expectedIDs[1:], ids, "root key IDs mismatch")
 }
+
+// mockServerStream is a grpc.ServerStream that only carries a context.
+type mockServerStream struct {
+	grpc.ServerStream
+
+	ctx context.Context
+}
+
+// Context returns the context of the stream.
+func (s *mockServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// TestObserverMacaroon tests that observer macaroons are denied access to all
+// methods that may modify the state of the daemon, both by the unary and the
+// stream interceptor.
+func TestObserverMacaroon(t *testing.T) {
+	tempDir := setupTestRootKeyStorage(t)
+	defer os.RemoveAll(tempDir)
+
+	service, err := macaroons.NewService(
+		tempDir, "lnd", macaroons.IPLockChecker,
+		macaroons.ObserverChecker,
+	)
+	require.NoError(t, err)
+	defer service.Close()
+
+	err = service.CreateUnlock(&defaultPw)
+	require.NoError(t, err)
+
+	readOp := bakery.Op{Entity: "offchain", Action: "read"}
+	writeOp := bakery.Op{Entity: "offchain", Action: "write"}
+	permissions := map[string][]bakery.Op{
+		"/test/Read":       {readOp},
+		"/test/Write":      {readOp, writeOp},
+		"/test/DeriveNext": {readOp},
+	}
+	service.RegisterMutatingMethod("/test/DeriveNext")
+
+	// Bake a macaroon that grants all permissions, and derive an observer
+	// macaroon from it.
+	mac, err := service.NewMacaroon(
+		context.Background(), macaroons.DefaultRootKeyID, readOp,
+		writeOp,
+	)
+	require.NoError(t, err)
+	require.False(t, macaroons.IsObserver(mac.M()))
+
+	observerMac, err := macaroons.AddConstraints(
+		mac.M(), macaroons.ObserverConstraint(),
+	)
+	require.NoError(t, err)
+	require.True(t, macaroons.IsObserver(observerMac))
+
+	macContext := func(t *testing.T,
+		m *macaroon.Macaroon) context.Context {
+
+		macBytes, err := m.MarshalBinary()
+		require.NoError(t, err)
+
+		md := metadata.New(map[string]string{
+			"macaroon": hex.EncodeToString(macBytes),
+		})
+		return metadata.NewIncomingContext(context.Background(), md)
+	}
+
+	unary := service.UnaryServerInterceptor(permissions)
+	callUnary := func(ctx context.Context, method string) error {
+		_, err := unary(
+			ctx, nil, &grpc.UnaryServerInfo{FullMethod: method},
+			func(context.Context, interface{}) (interface{},
+				error) {
+
+				return nil, nil
+			},
+		)
+		return err
+	}
+
+	stream := service.StreamServerInterceptor(permissions)
+	callStream := func(ctx context.Context, method string) error {
+		return stream(
+			nil, &mockServerStream{ctx: ctx},
+			&grpc.StreamServerInfo{FullMethod: method},
+			func(interface{}, grpc.ServerStream) error {
+				return nil
+			},
+		)
+	}
+
+	for _, call := range []func(context.Context, string) error{
+		callUnary, callStream,
+	} {
+		// The regular macaroon can call all methods.
+		ctx := macContext(t, mac.M())
+		require.NoError(t, call(ctx, "/test/Read"))
+		require.NoError(t, call(ctx, "/test/Write"))
+		require.NoError(t, call(ctx, "/test/DeriveNext"))
+
+		// The observer macaroon can only call the read-only method,
+		// even though it grants write permissions.
+		ctx = macContext(t, observerMac)
+		require.NoError(t, call(ctx, "/test/Read"))
+		require.Equal(
+			t, macaroons.ErrObserverForbidden,
+			call(ctx, "/test/Write"),
+		)
+		require.Equal(
+			t, macaroons.ErrObserverForbidden,
+			call(ctx, "/test/DeriveNext"),
+		)
+	}
+}
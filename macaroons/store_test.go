@@ -23,6 +23,7 @@ func TestStore(t *testing.T) {
 
 	db, err := kvdb.Create(
 		kvdb.BoltBackendName, path.Join(tempDir, "weks.db"), true,
+		kvdb.DefaultDBTimeout,
 	)
 	if err != nil {
 		t.Fatalf("Error opening store DB: %v", err)
@@ -111,6 +112,7 @@ func TestStore(t *testing.T) {
 	// fail anyway in that case.
 	db, err = kvdb.Create(
 		kvdb.BoltBackendName, path.Join(tempDir, "weks.db"), true,
+		kvdb.DefaultDBTimeout,
 	)
 	if err != nil {
 		t.Fatalf("Error opening store DB: %v", err)
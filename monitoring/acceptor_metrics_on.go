@@ -0,0 +1,38 @@
+// +build monitoring
+
+package monitoring
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	acceptorMetricsRegistered sync.Once
+
+	acceptorLatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: "lnd_channel_acceptor_latency_seconds",
+			Help: "Time taken to decide on inbound channels by " +
+				"RPC channel acceptors, by outcome.",
+			Buckets: prometheus.ExponentialBuckets(0.01, 2, 12),
+		},
+		[]string{"outcome"},
+	)
+)
+
+// ExportAcceptorMetrics registers the channel acceptor metrics so that they
+// are exported.
+func ExportAcceptorMetrics() {
+	acceptorMetricsRegistered.Do(func() {
+		prometheus.MustRegister(acceptorLatency)
+	})
+}
+
+// ObserveAcceptorLatency records the time an RPC channel acceptor took to
+// decide on an inbound channel, along with the outcome of the request.
+func ObserveAcceptorLatency(outcome string, latency time.Duration) {
+	acceptorLatency.WithLabelValues(outcome).Observe(latency.Seconds())
+}
@@ -0,0 +1,23 @@
+package monitoring
+
+// ChannelMetrics holds the htlc slot usage of a single channel that is exported
+// to Prometheus.
+type ChannelMetrics struct {
+	// ChanPoint is the funding outpoint of the channel.
+	ChanPoint string
+
+	// IncomingSlotsUsed is the number of commitment slots occupied by
+	// htlcs offered by the remote node.
+	IncomingSlotsUsed uint32
+
+	// IncomingSlotsMax is the maximum number of htlcs the remote node may
+	// offer.
+	IncomingSlotsMax uint32
+
+	// OutgoingSlotsUsed is the number of commitment slots occupied by
+	// htlcs offered by us.
+	OutgoingSlotsUsed uint32
+
+	// OutgoingSlotsMax is the maximum number of htlcs we may offer.
+	OutgoingSlotsMax uint32
+}
@@ -0,0 +1,76 @@
+// +build monitoring
+
+package monitoring
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	channelMetricsRegistered sync.Once
+
+	htlcSlotsUsedDesc = prometheus.NewDesc(
+		"lnd_channel_htlc_slots_used",
+		"Number of commitment slots occupied by htlcs by direction.",
+		[]string{"chan_point", "direction"}, nil,
+	)
+
+	htlcSlotsMaxDesc = prometheus.NewDesc(
+		"lnd_channel_htlc_slots_max",
+		"Maximum number of htlcs that may be in flight by direction.",
+		[]string{"chan_point", "direction"}, nil,
+	)
+)
+
+// channelCollector is a prometheus.Collector that queries the htlc slot usage
+// of all open channels on every scrape.
+type channelCollector struct {
+	source func() []*ChannelMetrics
+}
+
+// Describe sends the descriptors of all channel metrics to the channel.
+//
+// NOTE: Part of the prometheus.Collector interface.
+func (c *channelCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- htlcSlotsUsedDesc
+	ch <- htlcSlotsMaxDesc
+}
+
+// Collect sends the current htlc slot usage of all open channels to the
+// channel.
+//
+// NOTE: Part of the prometheus.Collector interface.
+func (c *channelCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, channel := range c.source() {
+		ch <- prometheus.MustNewConstMetric(
+			htlcSlotsUsedDesc, prometheus.GaugeValue,
+			float64(channel.IncomingSlotsUsed), channel.ChanPoint,
+			"incoming",
+		)
+		ch <- prometheus.MustNewConstMetric(
+			htlcSlotsMaxDesc, prometheus.GaugeValue,
+			float64(channel.IncomingSlotsMax), channel.ChanPoint,
+			"incoming",
+		)
+		ch <- prometheus.MustNewConstMetric(
+			htlcSlotsUsedDesc, prometheus.GaugeValue,
+			float64(channel.OutgoingSlotsUsed), channel.ChanPoint,
+			"outgoing",
+		)
+		ch <- prometheus.MustNewConstMetric(
+			htlcSlotsMaxDesc, prometheus.GaugeValue,
+			float64(channel.OutgoingSlotsMax), channel.ChanPoint,
+			"outgoing",
+		)
+	}
+}
+
+// ExportChannelMetrics registers a collector that exports the htlc slot usage
+// of the channels returned by the source, which is queried on every scrape.
+func ExportChannelMetrics(source func() []*ChannelMetrics) {
+	channelMetricsRegistered.Do(func() {
+		prometheus.MustRegister(&channelCollector{source: source})
+	})
+}
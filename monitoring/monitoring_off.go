@@ -4,6 +4,7 @@ package monitoring
 
 import (
 	"fmt"
+	"time"
 
 	"google.golang.org/grpc"
 
@@ -23,3 +24,27 @@ func ExportPrometheusMetrics(_ *grpc.Server, _ lncfg.Prometheus) error {
 	return fmt.Errorf("lnd must be built with the monitoring tag to " +
 		"enable exporting Prometheus metrics")
 }
+
+// ExportPeerMetrics is required for lnd to compile so that Prometheus metric
+// exporting can be hidden behind a build tag. It is a no-op.
+func ExportPeerMetrics(_ func() []*PeerMetrics) {}
+
+// ExportAcceptorMetrics is required for lnd to compile so that Prometheus
+// metric exporting can be hidden behind a build tag. It is a no-op.
+func ExportAcceptorMetrics() {}
+
+// ObserveAcceptorLatency is required for lnd to compile so that Prometheus
+// metric exporting can be hidden behind a build tag. It is a no-op.
+func ObserveAcceptorLatency(_ string, _ time.Duration) {}
+
+// ExportReservationMetrics is required for lnd to compile so that Prometheus
+// metric exporting can be hidden behind a build tag. It is a no-op.
+func ExportReservationMetrics(_ func() []*ReservationMetrics) {}
+
+// ExportNotifierMetrics is required for lnd to compile so that Prometheus
+// metric exporting can be hidden behind a build tag. It is a no-op.
+func ExportNotifierMetrics(_ func() *NotifierMetrics) {}
+
+// ExportChannelMetrics is required for lnd to compile so that Prometheus
+// metric exporting can be hidden behind a build tag. It is a no-op.
+func ExportChannelMetrics(_ func() []*ChannelMetrics) {}
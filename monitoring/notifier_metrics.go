@@ -0,0 +1,18 @@
+package monitoring
+
+// NotifierMetrics holds the confirmation registration metrics of the chain
+// notifier that are exported to Prometheus.
+type NotifierMetrics struct {
+	// ConfRegistrations is the total number of confirmation registrations
+	// the notifier has received.
+	ConfRegistrations uint64
+
+	// SharedConfRegistrations is the number of confirmation registrations
+	// that shared the backend subscription of an existing registration
+	// for the same transaction.
+	SharedConfRegistrations uint64
+
+	// HistoricalConfDispatches is the number of historical confirmation
+	// rescans the notifier has dispatched.
+	HistoricalConfDispatches uint64
+}
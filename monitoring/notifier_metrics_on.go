@@ -0,0 +1,75 @@
+// +build monitoring
+
+package monitoring
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	notifierMetricsRegistered sync.Once
+
+	confRegistrationsDesc = prometheus.NewDesc(
+		"lnd_chainntnfs_conf_registrations_total",
+		"Total number of confirmation notification registrations.",
+		nil, nil,
+	)
+
+	sharedConfRegistrationsDesc = prometheus.NewDesc(
+		"lnd_chainntnfs_shared_conf_registrations_total",
+		"Number of confirmation notification registrations that "+
+			"shared an existing backend subscription.",
+		nil, nil,
+	)
+
+	historicalConfDispatchesDesc = prometheus.NewDesc(
+		"lnd_chainntnfs_historical_rescans_total",
+		"Number of historical confirmation rescans dispatched.",
+		nil, nil,
+	)
+)
+
+// notifierCollector is a prometheus.Collector that queries the metrics of the
+// chain notifier on every scrape.
+type notifierCollector struct {
+	source func() *NotifierMetrics
+}
+
+// Describe sends the descriptors of all notifier metrics to the channel.
+//
+// NOTE: Part of the prometheus.Collector interface.
+func (c *notifierCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- confRegistrationsDesc
+	ch <- sharedConfRegistrationsDesc
+	ch <- historicalConfDispatchesDesc
+}
+
+// Collect sends the current metrics of the chain notifier to the channel.
+//
+// NOTE: Part of the prometheus.Collector interface.
+func (c *notifierCollector) Collect(ch chan<- prometheus.Metric) {
+	metrics := c.source()
+
+	ch <- prometheus.MustNewConstMetric(
+		confRegistrationsDesc, prometheus.CounterValue,
+		float64(metrics.ConfRegistrations),
+	)
+	ch <- prometheus.MustNewConstMetric(
+		sharedConfRegistrationsDesc, prometheus.CounterValue,
+		float64(metrics.SharedConfRegistrations),
+	)
+	ch <- prometheus.MustNewConstMetric(
+		historicalConfDispatchesDesc, prometheus.CounterValue,
+		float64(metrics.HistoricalConfDispatches),
+	)
+}
+
+// ExportNotifierMetrics registers a collector that exports the chain notifier
+// metrics returned by the source, which is queried on every scrape.
+func ExportNotifierMetrics(source func() *NotifierMetrics) {
+	notifierMetricsRegistered.Do(func() {
+		prometheus.MustRegister(&notifierCollector{source: source})
+	})
+}
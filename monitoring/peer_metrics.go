@@ -0,0 +1,26 @@
+package monitoring
+
+// PeerMetrics holds the connection metrics of a single peer that are exported
+// to Prometheus.
+type PeerMetrics struct {
+	// PubKey is the hex encoded identity public key of the peer.
+	PubKey string
+
+	// BytesSent is the number of bytes sent to the peer.
+	BytesSent uint64
+
+	// BytesReceived is the number of bytes received from the peer.
+	BytesReceived uint64
+
+	// PingTime is the most recent ping round trip time to the peer in
+	// seconds.
+	PingTime float64
+
+	// MessagesSent holds the number of messages sent to the peer, keyed
+	// by message type name.
+	MessagesSent map[string]uint64
+
+	// MessagesReceived holds the number of messages received from the
+	// peer, keyed by message type name.
+	MessagesReceived map[string]uint64
+}
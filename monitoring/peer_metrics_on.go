@@ -0,0 +1,101 @@
+// +build monitoring
+
+package monitoring
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	peerMetricsRegistered sync.Once
+
+	bytesSentDesc = prometheus.NewDesc(
+		"lnd_peer_bytes_sent_total",
+		"Number of bytes sent to the peer.",
+		[]string{"peer"}, nil,
+	)
+
+	bytesReceivedDesc = prometheus.NewDesc(
+		"lnd_peer_bytes_received_total",
+		"Number of bytes received from the peer.",
+		[]string{"peer"}, nil,
+	)
+
+	pingTimeDesc = prometheus.NewDesc(
+		"lnd_peer_ping_seconds",
+		"Most recent ping round trip time to the peer.",
+		[]string{"peer"}, nil,
+	)
+
+	messagesSentDesc = prometheus.NewDesc(
+		"lnd_peer_messages_sent_total",
+		"Number of messages sent to the peer by message type.",
+		[]string{"peer", "type"}, nil,
+	)
+
+	messagesReceivedDesc = prometheus.NewDesc(
+		"lnd_peer_messages_received_total",
+		"Number of messages received from the peer by message type.",
+		[]string{"peer", "type"}, nil,
+	)
+)
+
+// peerCollector is a prometheus.Collector that queries the connection metrics
+// of all connected peers on every scrape.
+type peerCollector struct {
+	source func() []*PeerMetrics
+}
+
+// Describe sends the descriptors of all peer metrics to the channel.
+//
+// NOTE: Part of the prometheus.Collector interface.
+func (c *peerCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- bytesSentDesc
+	ch <- bytesReceivedDesc
+	ch <- pingTimeDesc
+	ch <- messagesSentDesc
+	ch <- messagesReceivedDesc
+}
+
+// Collect sends the current metrics of all connected peers to the channel.
+//
+// NOTE: Part of the prometheus.Collector interface.
+func (c *peerCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, peer := range c.source() {
+		ch <- prometheus.MustNewConstMetric(
+			bytesSentDesc, prometheus.CounterValue,
+			float64(peer.BytesSent), peer.PubKey,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			bytesReceivedDesc, prometheus.CounterValue,
+			float64(peer.BytesReceived), peer.PubKey,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			pingTimeDesc, prometheus.GaugeValue, peer.PingTime,
+			peer.PubKey,
+		)
+
+		for msgType, count := range peer.MessagesSent {
+			ch <- prometheus.MustNewConstMetric(
+				messagesSentDesc, prometheus.CounterValue,
+				float64(count), peer.PubKey, msgType,
+			)
+		}
+		for msgType, count := range peer.MessagesReceived {
+			ch <- prometheus.MustNewConstMetric(
+				messagesReceivedDesc, prometheus.CounterValue,
+				float64(count), peer.PubKey, msgType,
+			)
+		}
+	}
+}
+
+// ExportPeerMetrics registers a collector that exports the connection metrics
+// returned by the source, which is queried on every scrape.
+func ExportPeerMetrics(source func() []*PeerMetrics) {
+	peerMetricsRegistered.Do(func() {
+		prometheus.MustRegister(&peerCollector{source: source})
+	})
+}
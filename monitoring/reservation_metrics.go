@@ -0,0 +1,16 @@
+package monitoring
+
+// ReservationMetrics holds the state of a single funding reservation that is
+// exported to Prometheus.
+type ReservationMetrics struct {
+	// Psbt is true if the channel is funded through a PSBT.
+	Psbt bool
+
+	// Locked is true if the reservation is locked waiting for the funding
+	// transaction.
+	Locked bool
+
+	// AgeSeconds is the number of seconds since the reservation was
+	// created.
+	AgeSeconds float64
+}
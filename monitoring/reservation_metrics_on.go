@@ -0,0 +1,93 @@
+// +build monitoring
+
+package monitoring
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	reservationMetricsRegistered sync.Once
+
+	reservationsDesc = prometheus.NewDesc(
+		"lnd_funding_reservations",
+		"Number of active funding reservations by funding type and "+
+			"state.",
+		[]string{"funding", "state"}, nil,
+	)
+
+	oldestReservationDesc = prometheus.NewDesc(
+		"lnd_funding_reservation_oldest_age_seconds",
+		"Age of the oldest active funding reservation by funding type.",
+		[]string{"funding"}, nil,
+	)
+)
+
+// reservationCollector is a prometheus.Collector that queries the active
+// funding reservations on every scrape.
+type reservationCollector struct {
+	source func() []*ReservationMetrics
+}
+
+// Describe sends the descriptors of all reservation metrics to the channel.
+//
+// NOTE: Part of the prometheus.Collector interface.
+func (c *reservationCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- reservationsDesc
+	ch <- oldestReservationDesc
+}
+
+// Collect sends the current metrics of all active funding reservations to the
+// channel.
+//
+// NOTE: Part of the prometheus.Collector interface.
+func (c *reservationCollector) Collect(ch chan<- prometheus.Metric) {
+	counts := make(map[[2]string]int)
+	oldest := map[string]float64{
+		"wallet": 0,
+		"psbt":   0,
+	}
+	for _, res := range c.source() {
+		funding := "wallet"
+		if res.Psbt {
+			funding = "psbt"
+		}
+		state := "active"
+		if res.Locked {
+			state = "locked"
+		}
+
+		counts[[2]string{funding, state}]++
+		if res.AgeSeconds > oldest[funding] {
+			oldest[funding] = res.AgeSeconds
+		}
+	}
+
+	// All label combinations are always reported, so the gauges drop to
+	// zero once the last reservation of a kind is gone.
+	for funding, age := range oldest {
+		for _, state := range []string{"active", "locked"} {
+			ch <- prometheus.MustNewConstMetric(
+				reservationsDesc, prometheus.GaugeValue,
+				float64(counts[[2]string{funding, state}]),
+				funding, state,
+			)
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			oldestReservationDesc, prometheus.GaugeValue, age,
+			funding,
+		)
+	}
+}
+
+// ExportReservationMetrics registers a collector that exports the funding
+// reservation metrics returned by the source, which is queried on every
+// scrape.
+func ExportReservationMetrics(source func() []*ReservationMetrics) {
+	reservationMetricsRegistered.Do(func() {
+		prometheus.MustRegister(&reservationCollector{source: source})
+	})
+}
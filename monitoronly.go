@@ -0,0 +1,532 @@
+package lnd
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	proxy "github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/lightninglabs/neutrino"
+	"github.com/cryptomeow/lnd/build"
+	"github.com/cryptomeow/lnd/chainreg"
+	"github.com/cryptomeow/lnd/chanmonitor"
+	"github.com/cryptomeow/lnd/channeldb"
+	"github.com/cryptomeow/lnd/lncfg"
+	"github.com/cryptomeow/lnd/lnrpc"
+	"github.com/cryptomeow/lnd/lnwire"
+	"github.com/cryptomeow/lnd/macaroons"
+	"github.com/cryptomeow/lnd/routing"
+	"github.com/cryptomeow/lnd/routing/route"
+	"github.com/cryptomeow/lnd/signal"
+	"google.golang.org/grpc"
+	"gopkg.in/macaroon-bakery.v2/bakery"
+)
+
+// errNotMonitorOnly is returned when the channel monitor is queried while the
+// daemon isn't running in monitor-only mode.
+var errNotMonitorOnly = errors.New("lnd is not running in monitor-only mode")
+
+// monitorRPCMethods are the methods of the Lightning service that are served
+// in monitor-only mode. They either only read from the channel database or
+// control the daemon itself.
+var monitorRPCMethods = []string{
+	"/lnrpc.Lightning/GetInfo",
+	"/lnrpc.Lightning/ListChannels",
+	"/lnrpc.Lightning/ClosedChannels",
+	"/lnrpc.Lightning/PendingChannels",
+	"/lnrpc.Lightning/ListMonitorEvents",
+	"/lnrpc.Lightning/StopDaemon",
+	"/lnrpc.Lightning/DebugLevel",
+}
+
+// monitorRPCPermissions returns the permissions required to call each of the
+// methods served in monitor-only mode. Calls to any other method are rejected
+// by the macaroon service, as their permissions are unknown.
+func monitorRPCPermissions() map[string][]bakery.Op {
+	permissions := MainRPCServerPermissions()
+
+	monitorPermissions := make(map[string][]bakery.Op)
+	for _, method := range monitorRPCMethods {
+		monitorPermissions[method] = permissions[method]
+	}
+
+	return monitorPermissions
+}
+
+// readPasswordFile reads the wallet password from the given file, ignoring any
+// trailing line break.
+func readPasswordFile(path string) ([]byte, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read password file: %v", err)
+	}
+
+	password := bytes.TrimRight(content, "\r\n")
+	if len(password) == 0 {
+		return nil, errors.New("password file is empty")
+	}
+
+	return password, nil
+}
+
+// runMonitorOnly runs the daemon in monitor-only mode until a shutdown is
+// requested. Only the chain notifier and the channel monitor are started, and
+// the channels are read from the database that was opened read-only. The
+// wallet is never unlocked, so nothing can be signed or published, and the
+// RPC server only serves the calls of monitorRPCServer. The macaroons are
+// unlocked with the wallet password read from the configured password file.
+func runMonitorOnly(cfg *Config, localChanDB, remoteChanDB *channeldb.DB,
+	neutrinoCS *neutrino.ChainService, serverOpts []grpc.ServerOption,
+	restDialOpts []grpc.DialOption, restProxyDest string,
+	tlsCfg *tls.Config, getListeners rpcListeners,
+	shutdownChan <-chan struct{}) error {
+
+	chainControl, err := chainreg.NewChainControl(&chainreg.Config{
+		Bitcoin:                     cfg.Bitcoin,
+		Litecoin:                    cfg.Litecoin,
+		PrimaryChain:                cfg.registeredChains.PrimaryChain,
+		HeightHintCacheQueryDisable: cfg.HeightHintCacheQueryDisable,
+		NeutrinoMode:                cfg.NeutrinoMode,
+		BitcoindMode:                cfg.BitcoindMode,
+		LitecoindMode:               cfg.LitecoindMode,
+		BtcdMode:                    cfg.BtcdMode,
+		LtcdMode:                    cfg.LtcdMode,
+		LocalChanDB:                 localChanDB,
+		RemoteChanDB:                remoteChanDB,
+		NeutrinoCS:                  neutrinoCS,
+		ActiveNetParams:             cfg.ActiveNetParams,
+		NotifierOnly:                true,
+	})
+	if err != nil {
+		err := fmt.Errorf("unable to create chain control: %v", err)
+		ltndLog.Error(err)
+		return err
+	}
+
+	notifier := chainControl.ChainNotifier
+	if err := notifier.Start(); err != nil {
+		err := fmt.Errorf("unable to start chain notifier: %v", err)
+		ltndLog.Error(err)
+		return err
+	}
+	defer func() {
+		if err := notifier.Stop(); err != nil {
+			ltndLog.Errorf("Unable to stop ChainNotifier: %v", err)
+		}
+	}()
+
+	monitor := chanmonitor.New(&chanmonitor.Config{
+		FetchChannels: remoteChanDB.FetchAllChannels,
+		Notifier:      notifier,
+	})
+	if err := monitor.Start(); err != nil {
+		err := fmt.Errorf("unable to start channel monitor: %v", err)
+		ltndLog.Error(err)
+		return err
+	}
+	defer monitor.Stop()
+
+	password, err := readPasswordFile(cfg.MonitorOnlyPasswordFile)
+	if err != nil {
+		ltndLog.Error(err)
+		return err
+	}
+	macaroonService, err := initMacaroonService(
+		context.Background(), cfg, password,
+	)
+	if err != nil {
+		ltndLog.Error(err)
+		return err
+	}
+	defer macaroonService.Close()
+
+	rpcServer := newMonitorRPCServer(
+		cfg, monitor, localChanDB, remoteChanDB,
+	)
+	cleanUp, err := rpcServer.start(
+		serverOpts, macaroonService, restDialOpts, restProxyDest,
+		tlsCfg, getListeners,
+	)
+	if err != nil {
+		err := fmt.Errorf("unable to start RPC server: %v", err)
+		ltndLog.Error(err)
+		return err
+	}
+	defer cleanUp()
+
+	ltndLog.Infof("Monitoring the channels of the channel database")
+
+	<-shutdownChan
+	return nil
+}
+
+// monitorRPCServer is the RPC server of the daemon in monitor-only mode. It
+// only serves the calls of monitorRPCMethods, which query the read-only
+// channel database and the channel monitor or control the daemon itself, all
+// other calls of the Lightning service are unimplemented.
+type monitorRPCServer struct {
+	lnrpc.UnimplementedLightningServer
+
+	cfg          *Config
+	monitor      *chanmonitor.Monitor
+	localChanDB  *channeldb.DB
+	remoteChanDB *channeldb.DB
+}
+
+// newMonitorRPCServer creates the RPC server of the daemon in monitor-only
+// mode, serving the events of the passed monitor and the channels of the
+// passed databases.
+func newMonitorRPCServer(cfg *Config, monitor *chanmonitor.Monitor,
+	localChanDB, remoteChanDB *channeldb.DB) *monitorRPCServer {
+
+	return &monitorRPCServer{
+		cfg:          cfg,
+		monitor:      monitor,
+		localChanDB:  localChanDB,
+		remoteChanDB: remoteChanDB,
+	}
+}
+
+// start starts serving the gRPC server on the passed listeners, and the REST
+// proxy on the REST listeners of the configuration. All calls are
+// authenticated by the passed macaroon service. The returned function stops
+// both servers again.
+func (m *monitorRPCServer) start(serverOpts []grpc.ServerOption,
+	macService *macaroons.Service, restDialOpts []grpc.DialOption,
+	restProxyDest string, tlsCfg *tls.Config,
+	getListeners rpcListeners) (func(), error) {
+
+	listeners, cleanUpListeners, err := getListeners()
+	if err != nil {
+		return nil, err
+	}
+
+	permissions := monitorRPCPermissions()
+	serverOpts = append(
+		serverOpts,
+		grpc_middleware.WithUnaryServerChain(
+			macService.UnaryServerInterceptor(permissions),
+			errorLogUnaryServerInterceptor(rpcsLog),
+		),
+		grpc_middleware.WithStreamServerChain(
+			macService.StreamServerInterceptor(permissions),
+			errorLogStreamServerInterceptor(rpcsLog),
+		),
+	)
+
+	grpcServer := grpc.NewServer(serverOpts...)
+	lnrpc.RegisterLightningServer(grpcServer, m)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cleanUp := func() {
+		cancel()
+		grpcServer.GracefulStop()
+		cleanUpListeners()
+	}
+
+	for _, lis := range listeners {
+		go func(lis *ListenerWithSignal) {
+			rpcsLog.Infof("Monitor RPC server listening on %s",
+				lis.Addr())
+
+			// Close the ready chan to indicate we are listening.
+			close(lis.Ready)
+			_ = grpcServer.Serve(lis)
+		}(lis)
+	}
+
+	mux := proxy.NewServeMux()
+	err = lnrpc.RegisterLightningHandlerFromEndpoint(
+		ctx, mux, restProxyDest, restDialOpts,
+	)
+	if err != nil {
+		cleanUp()
+		return nil, err
+	}
+
+	srv := &http.Server{Handler: allowCORS(mux, m.cfg.RestCORS)}
+	for _, restEndpoint := range m.cfg.RESTListeners {
+		lis, err := lncfg.TLSListenOnAddress(restEndpoint, tlsCfg)
+		if err != nil {
+			ltndLog.Errorf("Monitor gRPC proxy unable to listen "+
+				"on %s", restEndpoint)
+			cleanUp()
+			return nil, err
+		}
+
+		stopServing := cleanUp
+		cleanUp = func() {
+			_ = lis.Close()
+			stopServing()
+		}
+
+		go func() {
+			rpcsLog.Infof("Monitor gRPC proxy started at %s",
+				lis.Addr())
+
+			err := srv.Serve(lis)
+			if err != nil && !lnrpc.IsClosedConnError(err) {
+				rpcsLog.Error(err)
+			}
+		}()
+	}
+
+	return cleanUp, nil
+}
+
+// ListMonitorEvents returns the closes of the monitored channels that were
+// detected on chain.
+func (m *monitorRPCServer) ListMonitorEvents(ctx context.Context,
+	_ *lnrpc.ListMonitorEventsRequest) (*lnrpc.ListMonitorEventsResponse,
+	error) {
+
+	events := m.monitor.Events()
+	resp := &lnrpc.ListMonitorEventsResponse{
+		NumMonitoredChannels: uint32(m.monitor.NumChannels()),
+		Events: make(
+			[]*lnrpc.MonitorEvent, 0, len(events),
+		),
+	}
+	for _, event := range events {
+		closeType, err := marshalMonitorCloseType(event.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Events = append(resp.Events, &lnrpc.MonitorEvent{
+			ChannelPoint:  event.ChanPoint.String(),
+			CloseType:     closeType,
+			ClosingTxHash: event.CloseTxid.String(),
+			CloseHeight:   event.CloseHeight,
+			StateNum:      event.StateNum,
+			KnownStateNum: event.KnownStateNum,
+			DetectTime:    event.DetectTime.Unix(),
+		})
+	}
+
+	return resp, nil
+}
+
+// GetInfo returns general information about the node. As the wallet isn't
+// unlocked, the identity of the node is read from the source node of the
+// channel graph, and no chain or peer information is returned.
+func (m *monitorRPCServer) GetInfo(ctx context.Context,
+	_ *lnrpc.GetInfoRequest) (*lnrpc.GetInfoResponse, error) {
+
+	openChannels, err := m.remoteChanDB.FetchAllOpenChannels()
+	if err != nil {
+		return nil, err
+	}
+
+	pendingChannels, err := m.remoteChanDB.FetchPendingChannels()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get retrieve pending "+
+			"channels: %v", err)
+	}
+
+	sourceNode, err := m.localChanDB.ChannelGraph().SourceNode()
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch source node: %v", err)
+	}
+
+	network := lncfg.NormalizeNetwork(m.cfg.ActiveNetParams.Name)
+	activeChains := make(
+		[]*lnrpc.Chain, m.cfg.registeredChains.NumActiveChains(),
+	)
+	for i, chain := range m.cfg.registeredChains.ActiveChains() {
+		activeChains[i] = &lnrpc.Chain{
+			Chain:   chain.String(),
+			Network: network,
+		}
+	}
+
+	// None of the channels are active, as no peer connections are made.
+	idPub := sourceNode.PubKeyBytes
+	version := build.Version() + " commit=" + build.Commit
+	return &lnrpc.GetInfoResponse{
+		IdentityPubkey:      hex.EncodeToString(idPub[:]),
+		NumPendingChannels:  uint32(len(pendingChannels)),
+		NumInactiveChannels: uint32(len(openChannels)),
+		Testnet:             chainreg.IsTestnet(&m.cfg.ActiveNetParams),
+		Chains:              activeChains,
+		Alias:               sourceNode.Alias,
+		Color:               routing.EncodeHexColor(sourceNode.Color),
+		Version:             version,
+		CommitHash:          build.CommitHash,
+	}, nil
+}
+
+// ListChannels returns a description of all the open channels in the channel
+// database. None of them are active, as no peer connections are made.
+func (m *monitorRPCServer) ListChannels(ctx context.Context,
+	in *lnrpc.ListChannelsRequest) (*lnrpc.ListChannelsResponse, error) {
+
+	if in.ActiveOnly && in.InactiveOnly {
+		return nil, fmt.Errorf("either `active_only` or " +
+			"`inactive_only` can be set, but not both")
+	}
+
+	if in.PublicOnly && in.PrivateOnly {
+		return nil, fmt.Errorf("either `public_only` or " +
+			"`private_only` can be set, but not both")
+	}
+
+	if len(in.Peer) > 0 && len(in.Peer) != 33 {
+		_, err := route.NewVertexFromBytes(in.Peer)
+		return nil, fmt.Errorf("invalid `peer` key: %v", err)
+	}
+
+	resp := &lnrpc.ListChannelsResponse{}
+	if in.ActiveOnly {
+		return resp, nil
+	}
+
+	dbChannels, err := m.remoteChanDB.FetchAllOpenChannels()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dbChannel := range dbChannels {
+		nodePub := dbChannel.IdentityPub.SerializeCompressed()
+		if len(in.Peer) > 0 && !bytes.Equal(nodePub, in.Peer) {
+			continue
+		}
+
+		isPublic := dbChannel.ChannelFlags&lnwire.FFAnnounceChannel != 0
+		switch {
+		case in.PublicOnly && !isPublic:
+			continue
+		case in.PrivateOnly && isPublic:
+			continue
+		}
+
+		// The forwarding details of the HTLCs are omitted, as the
+		// circuits are only known to the switch.
+		channel, err := marshalOpenChannel(
+			dbChannel, false, nil, m.cfg.ActiveNetParams.Params,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Channels = append(resp.Channels, channel)
+	}
+
+	return resp, nil
+}
+
+// ClosedChannels returns a description of all the closed channels in the
+// channel database.
+func (m *monitorRPCServer) ClosedChannels(ctx context.Context,
+	in *lnrpc.ClosedChannelsRequest) (*lnrpc.ClosedChannelsResponse,
+	error) {
+
+	return fetchClosedChannels(
+		m.remoteChanDB, *m.cfg.ActiveNetParams.GenesisHash, in,
+	)
+}
+
+// PendingChannels returns a description of all the pending channels in the
+// channel database. The resolution details of force closed channels are
+// omitted, as neither the nursery nor the contract resolvers are running.
+func (m *monitorRPCServer) PendingChannels(ctx context.Context,
+	_ *lnrpc.PendingChannelsRequest) (*lnrpc.PendingChannelsResponse,
+	error) {
+
+	pendingOpenChannels, err := fetchPendingOpenChannels(m.remoteChanDB)
+	if err != nil {
+		return nil, err
+	}
+
+	forceClosing, forceClosingLimbo, err := fetchForceClosingChannels(
+		m.remoteChanDB, nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	waitingClose, waitingCloseLimbo, err := fetchWaitingCloseChannels(
+		m.remoteChanDB,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	limboBalance := forceClosingLimbo + waitingCloseLimbo
+	return &lnrpc.PendingChannelsResponse{
+		TotalLimboBalance:           limboBalance,
+		PendingOpenChannels:         pendingOpenChannels,
+		PendingForceClosingChannels: forceClosing,
+		WaitingCloseChannels:        waitingClose,
+	}, nil
+}
+
+// StopDaemon will send a shutdown request to the interrupt handler, triggering
+// a graceful shutdown of the daemon.
+func (m *monitorRPCServer) StopDaemon(ctx context.Context,
+	_ *lnrpc.StopRequest) (*lnrpc.StopResponse, error) {
+
+	signal.RequestShutdown()
+	return &lnrpc.StopResponse{}, nil
+}
+
+// DebugLevel allows a caller to programmatically set the logging verbosity of
+// lnd.
+func (m *monitorRPCServer) DebugLevel(ctx context.Context,
+	req *lnrpc.DebugLevelRequest) (*lnrpc.DebugLevelResponse, error) {
+
+	if req.Show {
+		return &lnrpc.DebugLevelResponse{
+			SubSystems: strings.Join(
+				m.cfg.LogWriter.SupportedSubsystems(), " ",
+			),
+		}, nil
+	}
+
+	rpcsLog.Infof("[debuglevel] changing debug level to: %v",
+		req.LevelSpec)
+
+	err := build.ParseAndSetDebugLevels(req.LevelSpec, m.cfg.LogWriter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.DebugLevelResponse{}, nil
+}
+
+// A compile-time check to ensure monitorRPCServer implements the
+// lnrpc.LightningServer interface.
+var _ lnrpc.LightningServer = (*monitorRPCServer)(nil)
+
+// marshalMonitorCloseType converts the type of a close detected by the channel
+// monitor to its RPC counterpart.
+func marshalMonitorCloseType(
+	closeType chanmonitor.CloseType) (lnrpc.MonitorEvent_CloseType, error) {
+
+	switch closeType {
+	case chanmonitor.CloseCooperative:
+		return lnrpc.MonitorEvent_COOPERATIVE, nil
+
+	case chanmonitor.CloseLocalForce:
+		return lnrpc.MonitorEvent_LOCAL_FORCE, nil
+
+	case chanmonitor.CloseRemoteForce:
+		return lnrpc.MonitorEvent_REMOTE_FORCE, nil
+
+	case chanmonitor.CloseBreach:
+		return lnrpc.MonitorEvent_BREACH, nil
+
+	case chanmonitor.CloseFutureState:
+		return lnrpc.MonitorEvent_FUTURE_STATE, nil
+
+	default:
+		return 0, fmt.Errorf("unknown close type: %v", closeType)
+	}
+}
@@ -0,0 +1,168 @@
+package lnd
+
+import (
+	"context"
+	"encoding/hex"
+	"image/color"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/cryptomeow/lnd/chainreg"
+	"github.com/cryptomeow/lnd/chanmonitor"
+	"github.com/cryptomeow/lnd/channeldb"
+	"github.com/cryptomeow/lnd/lnrpc"
+	"github.com/cryptomeow/lnd/lnwire"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// newMonitorTestServer creates the RPC server of the monitor-only mode backed
+// by an empty channel database whose graph has a source node, and returns it
+// along with the source node and a function that removes the database.
+func newMonitorTestServer(t *testing.T) (*monitorRPCServer,
+	*channeldb.LightningNode, func()) {
+
+	tempDir, err := ioutil.TempDir("", "monitoronly")
+	require.NoError(t, err)
+
+	db, err := channeldb.Open(tempDir)
+	require.NoError(t, err)
+
+	cleanUp := func() {
+		db.Close()
+		os.RemoveAll(tempDir)
+	}
+
+	priv, err := btcec.NewPrivateKey(btcec.S256())
+	require.NoError(t, err)
+
+	sourceNode := &channeldb.LightningNode{
+		HaveNodeAnnouncement: true,
+		Alias:                "monitor",
+		Color:                color.RGBA{R: 1, G: 2, B: 3},
+		Features:             lnwire.EmptyFeatureVector(),
+	}
+	copy(sourceNode.PubKeyBytes[:], priv.PubKey().SerializeCompressed())
+	require.NoError(t, db.ChannelGraph().SetSourceNode(sourceNode))
+
+	cfg := &Config{
+		ActiveNetParams:  chainreg.BitcoinRegTestNetParams,
+		registeredChains: chainreg.NewChainRegistry(),
+	}
+	monitor := chanmonitor.New(&chanmonitor.Config{
+		FetchChannels: db.FetchAllChannels,
+	})
+
+	return newMonitorRPCServer(cfg, monitor, db, db), sourceNode, cleanUp
+}
+
+// TestMonitorRPCServer asserts that the RPC server of the monitor-only mode
+// serves the events of the channel monitor, and that none of the calls that
+// modify any state are implemented.
+func TestMonitorRPCServer(t *testing.T) {
+	t.Parallel()
+
+	server, sourceNode, cleanUp := newMonitorTestServer(t)
+	defer cleanUp()
+
+	ctx := context.Background()
+
+	resp, err := server.ListMonitorEvents(
+		ctx, &lnrpc.ListMonitorEventsRequest{},
+	)
+	require.NoError(t, err)
+	require.Zero(t, resp.NumMonitoredChannels)
+	require.Empty(t, resp.Events)
+
+	// The node's identity is read from the graph, and the channels from
+	// the channel database.
+	info, err := server.GetInfo(ctx, &lnrpc.GetInfoRequest{})
+	require.NoError(t, err)
+	require.Equal(
+		t, hex.EncodeToString(sourceNode.PubKeyBytes[:]),
+		info.IdentityPubkey,
+	)
+	require.Equal(t, "monitor", info.Alias)
+	require.Equal(t, "#010203", info.Color)
+	require.Zero(t, info.NumActiveChannels)
+	require.Zero(t, info.NumPendingChannels)
+
+	channels, err := server.ListChannels(
+		ctx, &lnrpc.ListChannelsRequest{},
+	)
+	require.NoError(t, err)
+	require.Empty(t, channels.Channels)
+
+	_, err = server.ListChannels(ctx, &lnrpc.ListChannelsRequest{
+		ActiveOnly:   true,
+		InactiveOnly: true,
+	})
+	require.Error(t, err)
+
+	closed, err := server.ClosedChannels(
+		ctx, &lnrpc.ClosedChannelsRequest{},
+	)
+	require.NoError(t, err)
+	require.Empty(t, closed.Channels)
+
+	pending, err := server.PendingChannels(
+		ctx, &lnrpc.PendingChannelsRequest{},
+	)
+	require.NoError(t, err)
+	require.Empty(t, pending.PendingOpenChannels)
+	require.Empty(t, pending.PendingForceClosingChannels)
+	require.Empty(t, pending.WaitingCloseChannels)
+
+	_, err = server.SendCoins(ctx, &lnrpc.SendCoinsRequest{})
+	require.Equal(t, codes.Unimplemented, status.Code(err))
+
+	_, err = server.OpenChannelSync(ctx, &lnrpc.OpenChannelRequest{})
+	require.Equal(t, codes.Unimplemented, status.Code(err))
+
+	_, err = server.AddInvoice(ctx, &lnrpc.Invoice{})
+	require.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+// TestMonitorRPCPermissions asserts that permissions are only known for the
+// methods served in monitor-only mode, so the macaroon service rejects calls
+// to all other methods.
+func TestMonitorRPCPermissions(t *testing.T) {
+	t.Parallel()
+
+	permissions := monitorRPCPermissions()
+	require.Len(t, permissions, len(monitorRPCMethods))
+	for _, method := range monitorRPCMethods {
+		require.NotEmpty(t, permissions[method], method)
+	}
+
+	require.NotContains(t, permissions, "/lnrpc.Lightning/SendCoins")
+	require.NotContains(t, permissions, "/lnrpc.Lightning/OpenChannel")
+}
+
+// TestReadPasswordFile asserts that the trailing line break of a password file
+// is ignored, and that an empty or missing password file is rejected.
+func TestReadPasswordFile(t *testing.T) {
+	t.Parallel()
+
+	tempDir, err := ioutil.TempDir("", "password")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	path := filepath.Join(tempDir, "password")
+	require.NoError(t, ioutil.WriteFile(path, []byte("secret\n"), 0600))
+
+	password, err := readPasswordFile(path)
+	require.NoError(t, err)
+	require.Equal(t, []byte("secret"), password)
+
+	require.NoError(t, ioutil.WriteFile(path, []byte("\r\n"), 0600))
+	_, err = readPasswordFile(path)
+	require.Error(t, err)
+
+	_, err = readPasswordFile(filepath.Join(tempDir, "missing"))
+	require.Error(t, err)
+}
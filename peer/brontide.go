@@ -5,6 +5,8 @@ import (
 	"container/list"
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"math"
 	"net"
 	"sync"
 	"sync/atomic"
@@ -40,10 +42,12 @@ import (
 	"github.com/cryptomeow/lnd/queue"
 	"github.com/cryptomeow/lnd/ticker"
 	"github.com/cryptomeow/lnd/watchtower/wtclient"
+	"golang.org/x/time/rate"
 )
 
 const (
-	// pingInterval is the interval at which ping messages are sent.
+	// pingInterval is the interval at which ping messages are sent if no
+	// interval is configured.
 	pingInterval = 1 * time.Minute
 
 	// idleTimeout is the duration of inactivity before we time out a peer.
@@ -224,9 +228,10 @@ type Config struct {
 	// transaction.
 	ChainNotifier chainntnfs.ChainNotifier
 
-	// RoutingPolicy is used to set the forwarding policy for links created by
-	// the Brontide.
-	RoutingPolicy htlcswitch.ForwardingPolicy
+	// RoutingPolicy returns the default forwarding policy for links
+	// created by the Brontide. The policy can change while the daemon is
+	// running if its configuration is reloaded.
+	RoutingPolicy func() htlcswitch.ForwardingPolicy
 
 	// Sphinx is used when setting up ChannelLinks so they can decode sphinx
 	// onion blobs.
@@ -251,6 +256,10 @@ type Config struct {
 	// TowerClient is used when creating a ChannelLink.
 	TowerClient wtclient.Client
 
+	// AnchorTowerClient is used when creating a ChannelLink to back up
+	// states of channels with anchor outputs.
+	AnchorTowerClient wtclient.Client
+
 	// DisconnectPeer is used to disconnect this peer if the cooperative close
 	// process fails.
 	DisconnectPeer func(*btcec.PublicKey) error
@@ -272,6 +281,32 @@ type Config struct {
 	// FundingManager is an implementation of the fmgr.Manager interface.
 	FundingManager fmgr.Manager
 
+	// HandleCustomMessage is called whenever a custom message is received
+	// from the peer.
+	HandleCustomMessage func(peer [33]byte, msg *lnwire.Custom) error
+
+	// PingInterval is the interval at which ping messages are sent. If it
+	// is zero, a default interval of one minute is used.
+	PingInterval time.Duration
+
+	// MaxMissedPings is the number of consecutive pings the peer may
+	// leave unanswered before it is evicted. A ping is unanswered if no
+	// pong was received by the time the next ping is due. If it is zero,
+	// unanswered pings don't lead to eviction.
+	MaxMissedPings uint32
+
+	// WriteStallTimeout is the duration a single message may take to be
+	// written to the peer before it is evicted. If it is zero, stalled
+	// writes don't lead to eviction.
+	WriteStallTimeout time.Duration
+
+	// LazyWriteRate is the maximum rate in bytes per second at which
+	// low-priority messages, mostly gossip, are written to the peer. It
+	// keeps a peer requesting large gossip ranges from saturating our
+	// uplink, while channel updates are sent without delay. If it is zero,
+	// the rate isn't limited.
+	LazyWriteRate uint64
+
 	// Hodl is used when creating ChannelLinks to specify HodlFlags as
 	// breakpoints in dev builds.
 	Hodl *hodl.Config
@@ -290,6 +325,22 @@ type Config struct {
 	// commitment fee. This only applies for the initiator of the channel.
 	MaxChannelFeeAllocation float64
 
+	// CommitFeeBufferMultiplier is the multiple of the current commitment
+	// fee rate that must remain affordable for channels we initiated. The
+	// balance available for new outgoing htlcs is reduced accordingly. A
+	// value of one or less disables the fee buffer.
+	CommitFeeBufferMultiplier uint32
+
+	// RemoteFeePolicy is used when creating ChannelLinks and bounds the
+	// commitment fee rates the remote party may set on the channels it
+	// initiated.
+	RemoteFeePolicy htlcswitch.RemoteFeePolicy
+
+	// ExperimentalEndorsement is used when creating ChannelLinks and
+	// indicates whether the experimental endorsement signal is sent along
+	// with outgoing HTLCs.
+	ExperimentalEndorsement bool
+
 	// ServerPubKey is the serialized, compressed public key of our lnd node.
 	// It is used to determine which policy (channel edge) to pass to the
 	// ChannelLink.
@@ -324,6 +375,23 @@ type Brontide struct {
 	// our last ping message. To be used atomically.
 	pingLastSend int64
 
+	// pongPending is set when a ping is queued, and cleared once a pong
+	// is received. To be used atomically.
+	pongPending int32
+
+	// evictionReason describes why the peer was evicted by the dead peer
+	// detection. It is empty if the peer wasn't evicted.
+	evictionReason string
+	evictionMtx    sync.Mutex
+
+	// metrics tracks the ping history and message counts of the
+	// connection.
+	metrics *connMetrics
+
+	// lazyLimiter limits the rate at which low-priority messages are
+	// handed to the writeHandler. It is nil if the rate isn't limited.
+	lazyLimiter *rate.Limiter
+
 	cfg Config
 
 	// activeSignal when closed signals that the peer is now active and
@@ -426,13 +494,34 @@ func NewBrontide(cfg Config) *Brontide {
 		linkFailures:       make(chan linkFailureReport),
 		chanCloseMsgs:      make(chan *closeMsg),
 		resentChanSyncMsg:  make(map[lnwire.ChannelID]struct{}),
+		metrics:            newConnMetrics(),
 		queueQuit:          make(chan struct{}),
 		quit:               make(chan struct{}),
 	}
 
+	if cfg.LazyWriteRate != 0 {
+		p.lazyLimiter = newLazyLimiter(cfg.LazyWriteRate)
+	}
+
 	return p
 }
 
+// newLazyLimiter creates a limiter that allows low-priority messages to be
+// written at the given number of bytes per second. The burst is large enough
+// for any single message to pass, as the brontide transport limits messages
+// to 65535 bytes.
+func newLazyLimiter(bytesPerSec uint64) *rate.Limiter {
+	burst := uint64(math.MaxUint16)
+	if bytesPerSec > burst {
+		burst = bytesPerSec
+	}
+	if burst > math.MaxInt32 {
+		burst = math.MaxInt32
+	}
+
+	return rate.NewLimiter(rate.Limit(bytesPerSec), int(burst))
+}
+
 // Start starts all helper goroutines the peer needs for normal operations.  In
 // the case this peer has already been started, then this function is a loop.
 func (p *Brontide) Start() error {
@@ -602,6 +691,7 @@ func (p *Brontide) loadActiveChannels(chans []*channeldb.OpenChannel) (
 	for _, dbChan := range chans {
 		lnChan, err := lnwallet.NewLightningChannel(
 			p.cfg.Signer, dbChan, p.cfg.SigPool,
+			lnwallet.WithFeeBuffer(p.cfg.CommitFeeBufferMultiplier),
 		)
 		if err != nil {
 			return nil, err
@@ -681,7 +771,8 @@ func (p *Brontide) loadActiveChannels(chans []*channeldb.OpenChannel) (
 			peerLog.Warnf("Unable to find our forwarding policy "+
 				"for channel %v, using default values",
 				chanPoint)
-			forwardingPolicy = &p.cfg.RoutingPolicy
+			defaultPolicy := p.cfg.RoutingPolicy()
+			forwardingPolicy = &defaultPolicy
 		}
 
 		peerLog.Tracef("Using link policy of: %v",
@@ -783,8 +874,11 @@ func (p *Brontide) addLink(chanPoint *wire.OutPoint,
 		MaxFeeUpdateTimeout:     htlcswitch.DefaultMaxLinkFeeUpdateTimeout,
 		OutgoingCltvRejectDelta: p.cfg.OutgoingCltvRejectDelta,
 		TowerClient:             p.cfg.TowerClient,
+		AnchorTowerClient:       p.cfg.AnchorTowerClient,
 		MaxOutgoingCltvExpiry:   p.cfg.MaxOutgoingCltvExpiry,
 		MaxFeeAllocation:        p.cfg.MaxChannelFeeAllocation,
+		RemoteFeePolicy:         p.cfg.RemoteFeePolicy,
+		ExperimentalEndorsement: p.cfg.ExperimentalEndorsement,
 		NotifyActiveLink:        p.cfg.ChannelNotifier.NotifyActiveLinkEvent,
 		NotifyActiveChannel:     p.cfg.ChannelNotifier.NotifyActiveChannelEvent,
 		NotifyInactiveChannel:   p.cfg.ChannelNotifier.NotifyInactiveChannelEvent,
@@ -872,6 +966,31 @@ func (p *Brontide) Disconnect(reason error) {
 	close(p.quit)
 }
 
+// evict disconnects the peer because the dead peer detection considers it
+// unresponsive. The reason is reported through EvictionReason.
+func (p *Brontide) evict(reason string) {
+	// If we're already disconnecting for another reason, this isn't an
+	// eviction.
+	if atomic.LoadInt32(&p.disconnect) != 0 {
+		return
+	}
+
+	p.evictionMtx.Lock()
+	p.evictionReason = reason
+	p.evictionMtx.Unlock()
+
+	p.Disconnect(fmt.Errorf("evicting unresponsive peer: %v", reason))
+}
+
+// EvictionReason returns the reason the peer was evicted by the dead peer
+// detection, or an empty string if it wasn't evicted.
+func (p *Brontide) EvictionReason() string {
+	p.evictionMtx.Lock()
+	defer p.evictionMtx.Unlock()
+
+	return p.evictionReason
+}
+
 // String returns the string representation of this peer.
 func (p *Brontide) String() string {
 	return fmt.Sprintf("%x@%s", p.cfg.PubKeyBytes, p.cfg.Conn.RemoteAddr())
@@ -925,6 +1044,7 @@ func (p *Brontide) readNextMessage() (lnwire.Message, error) {
 	}
 
 	p.logWireMessage(nextMsg, true)
+	p.metrics.countMessage(nextMsg.MsgType(), false)
 
 	return nextMsg, nil
 }
@@ -1307,6 +1427,8 @@ out:
 			pingSendTime := atomic.LoadInt64(&p.pingLastSend)
 			delay := (time.Now().UnixNano() - pingSendTime) / 1000
 			atomic.StoreInt64(&p.pingTime, delay)
+			atomic.StoreInt32(&p.pongPending, 0)
+			p.metrics.addPing(time.Duration(delay) * time.Microsecond)
 
 		case *lnwire.Ping:
 			pongBytes := make([]byte, msg.NumPongBytes)
@@ -1372,6 +1494,13 @@ out:
 
 			discStream.AddMsg(msg)
 
+		case *lnwire.Custom:
+			err := p.handleCustomMessage(msg)
+			if err != nil {
+				p.storeError(err)
+				peerLog.Errorf("peer: %v, %v", p, err)
+			}
+
 		default:
 			// If the message we received is unknown to us, store
 			// the type to track the failure.
@@ -1409,6 +1538,17 @@ out:
 	peerLog.Tracef("readHandler for peer %v done", p)
 }
 
+// handleCustomMessage hands a custom message received from the peer to the
+// application layer.
+func (p *Brontide) handleCustomMessage(msg *lnwire.Custom) error {
+	if p.cfg.HandleCustomMessage == nil {
+		return fmt.Errorf("no custom message handler for "+
+			"message type %v", uint16(msg.MsgType()))
+	}
+
+	return p.cfg.HandleCustomMessage(p.PubKey(), msg)
+}
+
 // isActiveChannel returns true if the provided channel id is active, otherwise
 // returns false.
 func (p *Brontide) isActiveChannel(chanID lnwire.ChannelID) bool {
@@ -1611,6 +1751,9 @@ func messageSummary(msg lnwire.Message) string {
 			time.Unix(int64(msg.FirstTimestamp), 0),
 			msg.TimestampRange)
 
+	case *lnwire.Custom:
+		return fmt.Sprintf("type=%d, len=%d", uint16(msg.Type),
+			len(msg.Data))
 	}
 
 	return ""
@@ -1745,6 +1888,8 @@ func (p *Brontide) writeMessage(msg lnwire.Message) error {
 		return err
 	}
 
+	p.metrics.countMessage(msg.MsgType(), true)
+
 	return flushMsg()
 }
 
@@ -1790,11 +1935,23 @@ out:
 			// after backing off in case the remote peer is just
 			// slow to process messages from the wire.
 			err := p.writeMessage(outMsg.msg)
-			if nerr, ok := err.(net.Error); ok && nerr.Timeout() {
+			nerr, ok := err.(net.Error)
+			stalled := time.Since(startTime)
+			switch {
+			// If the peer hasn't accepted the message for too
+			// long, it's most likely not reading from the
+			// connection anymore, so we'll evict it.
+			case ok && nerr.Timeout() &&
+				p.cfg.WriteStallTimeout != 0 &&
+				stalled >= p.cfg.WriteStallTimeout:
+
+				p.evict(fmt.Sprintf("write stalled for %v",
+					stalled))
+
+			case ok && nerr.Timeout():
 				peerLog.Debugf("Write timeout detected for "+
 					"peer %s, first write for message "+
-					"attempted %v ago", p,
-					time.Since(startTime))
+					"attempted %v ago", p, stalled)
 
 				// If we received a timeout error, this implies
 				// that the message was buffered on the
@@ -1860,11 +2017,16 @@ func (p *Brontide) queueHandler() {
 	// been queued. This predominately includes messages from the gossiper.
 	lazyMsgs := list.New()
 
+	// lazyThrottled fires once the next low-priority message may be sent
+	// after the write rate limit was reached. While it is set, only
+	// high-priority messages are added to the sendQueue.
+	var lazyThrottled <-chan time.Time
+
 	for {
 		// Examine the front of the priority queue, if it is empty check
-		// the low priority queue.
+		// the low priority queue, unless it is throttled.
 		elem := priorityMsgs.Front()
-		if elem == nil {
+		if elem == nil && lazyThrottled == nil {
 			elem = lazyMsgs.Front()
 		}
 
@@ -1882,6 +2044,9 @@ func (p *Brontide) queueHandler() {
 					priorityMsgs.Remove(elem)
 				} else {
 					lazyMsgs.Remove(elem)
+					lazyThrottled = p.throttleLazyMsg(
+						front.msg,
+					)
 				}
 			case msg := <-p.outgoingQueue:
 				if msg.priority {
@@ -1889,6 +2054,8 @@ func (p *Brontide) queueHandler() {
 				} else {
 					lazyMsgs.PushBack(msg)
 				}
+			case <-lazyThrottled:
+				lazyThrottled = nil
 			case <-p.quit:
 				return
 			}
@@ -1903,6 +2070,8 @@ func (p *Brontide) queueHandler() {
 				} else {
 					lazyMsgs.PushBack(msg)
 				}
+			case <-lazyThrottled:
+				lazyThrottled = nil
 			case <-p.quit:
 				return
 			}
@@ -1910,6 +2079,38 @@ func (p *Brontide) queueHandler() {
 	}
 }
 
+// throttleLazyMsg charges the size of a low-priority message that was handed
+// to the writeHandler against the write rate limit. If the limit has been
+// exceeded, a channel is returned that fires once the next low-priority
+// message may be sent. Otherwise nil is returned.
+func (p *Brontide) throttleLazyMsg(msg lnwire.Message) <-chan time.Time {
+	if p.lazyLimiter == nil {
+		return nil
+	}
+
+	// Messages that can't be encoded will fail to be written as well, so
+	// there's nothing to charge for them.
+	size, err := lnwire.WriteMessage(ioutil.Discard, msg, 0)
+	if err != nil {
+		return nil
+	}
+
+	reservation := p.lazyLimiter.ReserveN(time.Now(), size)
+	if !reservation.OK() {
+		return nil
+	}
+
+	delay := reservation.Delay()
+	if delay <= 0 {
+		return nil
+	}
+
+	peerLog.Tracef("Throttling low-priority messages to %v for %v", p,
+		delay)
+
+	return time.After(delay)
+}
+
 // pingHandler is responsible for periodically sending ping messages to the
 // remote peer in order to keep the connection alive and/or determine if the
 // connection is still active.
@@ -1918,17 +2119,45 @@ func (p *Brontide) queueHandler() {
 func (p *Brontide) pingHandler() {
 	defer p.wg.Done()
 
-	pingTicker := time.NewTicker(pingInterval)
+	interval := p.cfg.PingInterval
+	if interval == 0 {
+		interval = pingInterval
+	}
+
+	pingTicker := time.NewTicker(interval)
 	defer pingTicker.Stop()
 
 	// TODO(roasbeef): make dynamic in order to create fake cover traffic
 	const numPingBytes = 16
 
+	// missedPings is the number of consecutive pings the peer hasn't
+	// answered.
+	var missedPings uint32
+
 out:
 	for {
 		select {
 		case <-pingTicker.C:
+			// If the peer hasn't answered our last ping by now,
+			// we'll count it as missed, and evict the peer once it
+			// missed too many in a row.
+			if atomic.LoadInt32(&p.pongPending) == 1 {
+				missedPings++
+			} else {
+				missedPings = 0
+			}
+
+			if p.cfg.MaxMissedPings != 0 &&
+				missedPings >= p.cfg.MaxMissedPings {
+
+				p.evict(fmt.Sprintf("%v consecutive pings "+
+					"unanswered", missedPings))
+				break out
+			}
+
+			atomic.StoreInt32(&p.pongPending, 1)
 			p.queueMsg(lnwire.NewPing(numPingBytes), nil)
+
 		case <-p.quit:
 			break out
 		}
@@ -1940,6 +2169,18 @@ func (p *Brontide) PingTime() int64 {
 	return atomic.LoadInt64(&p.pingTime)
 }
 
+// PingHistory returns the round trip times of the most recent pings to the
+// peer, oldest first.
+func (p *Brontide) PingHistory() []time.Duration {
+	return p.metrics.pings()
+}
+
+// MessageCounts returns the number of messages sent to and received from the
+// peer per message type.
+func (p *Brontide) MessageCounts() map[lnwire.MessageType]MessageCount {
+	return p.metrics.messageCounts()
+}
+
 // queueMsg adds the lnwire.Message to the back of the high priority send queue.
 // If the errChan is non-nil, an error is sent back if the msg failed to queue
 // or failed to write, and nil otherwise.
@@ -2001,7 +2242,7 @@ func (p *Brontide) ChannelSnapshots() []*channeldb.ChannelSnapshot {
 // the case of a cooperative channel close negotiation.
 func (p *Brontide) genDeliveryScript() ([]byte, error) {
 	deliveryAddr, err := p.cfg.Wallet.NewAddress(
-		lnwallet.WitnessPubKey, false,
+		lnwallet.WitnessPubKey, false, lnwallet.DefaultAccountName,
 	)
 	if err != nil {
 		return nil, err
@@ -2076,6 +2317,9 @@ out:
 			// easily according to its channel ID.
 			lnChan, err := lnwallet.NewLightningChannel(
 				p.cfg.Signer, newChan, p.cfg.SigPool,
+				lnwallet.WithFeeBuffer(
+					p.cfg.CommitFeeBufferMultiplier,
+				),
 			)
 			if err != nil {
 				p.activeChanMtx.Unlock()
@@ -2119,7 +2363,7 @@ out:
 			// at initial channel creation. Note that the maximum HTLC value
 			// defaults to the cap on the total value of outstanding HTLCs.
 			fwdMinHtlc := lnChan.FwdMinHtlc()
-			defaultPolicy := p.cfg.RoutingPolicy
+			defaultPolicy := p.cfg.RoutingPolicy()
 			forwardingPolicy := &htlcswitch.ForwardingPolicy{
 				MinHTLCOut:    fwdMinHtlc,
 				MaxHTLC:       newChan.LocalChanCfg.MaxPendingAmount,
@@ -2443,7 +2687,8 @@ func (p *Brontide) handleLocalCloseReq(req *htlcswitch.ChanClose) {
 				Disconnect: func() error {
 					return p.cfg.DisconnectPeer(p.IdentityKey())
 				},
-				Quit: p.quit,
+				MaxFee: req.MaxFee,
+				Quit:   p.quit,
 			},
 			deliveryScript,
 			req.TargetFeePerKw,
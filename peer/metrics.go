@@ -0,0 +1,99 @@
+package peer
+
+import (
+	"sync"
+	"time"
+
+	"github.com/cryptomeow/lnd/lnwire"
+)
+
+// pingHistorySize is the number of ping round trip times that are kept for
+// each peer.
+const pingHistorySize = 20
+
+// MessageCount holds the number of messages of a single type that were
+// exchanged with a peer.
+type MessageCount struct {
+	// Sent is the number of messages sent to the peer.
+	Sent uint64
+
+	// Received is the number of messages received from the peer.
+	Received uint64
+}
+
+// connMetrics tracks the latency and message statistics of a connection to a
+// peer. The byte counters are kept on the Brontide itself, as they're updated
+// atomically.
+type connMetrics struct {
+	// pingHistory holds the most recent ping round trip times, oldest
+	// first.
+	pingHistory []time.Duration
+
+	// msgCounts holds the number of messages sent and received per
+	// message type.
+	msgCounts map[lnwire.MessageType]*MessageCount
+
+	mtx sync.Mutex
+}
+
+// newConnMetrics creates an empty set of connection metrics.
+func newConnMetrics() *connMetrics {
+	return &connMetrics{
+		msgCounts: make(map[lnwire.MessageType]*MessageCount),
+	}
+}
+
+// addPing records the round trip time of a ping, evicting the oldest one if
+// the history is full.
+func (m *connMetrics) addPing(rtt time.Duration) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if len(m.pingHistory) == pingHistorySize {
+		m.pingHistory = m.pingHistory[1:]
+	}
+	m.pingHistory = append(m.pingHistory, rtt)
+}
+
+// pings returns a copy of the recorded ping round trip times, oldest first.
+func (m *connMetrics) pings() []time.Duration {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	pings := make([]time.Duration, len(m.pingHistory))
+	copy(pings, m.pingHistory)
+
+	return pings
+}
+
+// countMessage increments the sent or received counter of the given message
+// type.
+func (m *connMetrics) countMessage(msgType lnwire.MessageType, sent bool) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	count, ok := m.msgCounts[msgType]
+	if !ok {
+		count = &MessageCount{}
+		m.msgCounts[msgType] = count
+	}
+
+	if sent {
+		count.Sent++
+	} else {
+		count.Received++
+	}
+}
+
+// messageCounts returns a copy of the message counters per message type.
+func (m *connMetrics) messageCounts() map[lnwire.MessageType]MessageCount {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	counts := make(map[lnwire.MessageType]MessageCount, len(m.msgCounts))
+	for msgType, count := range m.msgCounts {
+		counts[msgType] = *count
+	}
+
+	return counts
+}
@@ -0,0 +1,50 @@
+package peer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cryptomeow/lnd/lnwire"
+	"github.com/stretchr/testify/require"
+)
+
+// TestConnMetricsPingHistory tests that only the most recent pings are kept,
+// oldest first.
+func TestConnMetricsPingHistory(t *testing.T) {
+	t.Parallel()
+
+	metrics := newConnMetrics()
+	require.Empty(t, metrics.pings())
+
+	var expected []time.Duration
+	for i := 0; i < pingHistorySize+5; i++ {
+		rtt := time.Duration(i) * time.Millisecond
+		metrics.addPing(rtt)
+
+		expected = append(expected, rtt)
+	}
+
+	require.Equal(t, expected[5:], metrics.pings())
+}
+
+// TestConnMetricsMessageCounts tests that sent and received messages are
+// counted per message type.
+func TestConnMetricsMessageCounts(t *testing.T) {
+	t.Parallel()
+
+	metrics := newConnMetrics()
+	metrics.countMessage(lnwire.MsgPing, true)
+	metrics.countMessage(lnwire.MsgPing, true)
+	metrics.countMessage(lnwire.MsgPong, false)
+	metrics.countMessage(lnwire.MsgPing, false)
+
+	counts := metrics.messageCounts()
+	require.Equal(t, map[lnwire.MessageType]MessageCount{
+		lnwire.MsgPing: {Sent: 2, Received: 1},
+		lnwire.MsgPong: {Received: 1},
+	}, counts)
+
+	// The returned counts must not change with later messages.
+	metrics.countMessage(lnwire.MsgPong, false)
+	require.Equal(t, uint64(1), counts[lnwire.MsgPong].Received)
+}
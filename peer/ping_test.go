@@ -0,0 +1,70 @@
+package peer
+
+import (
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cryptomeow/lnd/lnwire"
+	"github.com/stretchr/testify/require"
+)
+
+// stubConn is a MessageConn that only supports the methods needed to
+// disconnect a peer.
+type stubConn struct {
+	MessageConn
+}
+
+// RemoteAddr returns an empty remote address.
+func (c *stubConn) RemoteAddr() net.Addr {
+	return &net.TCPAddr{}
+}
+
+// Close is a no-op.
+func (c *stubConn) Close() error {
+	return nil
+}
+
+// TestPingEviction tests that a peer is evicted once it has missed the
+// configured number of consecutive pings, and that an answered ping resets
+// the count.
+func TestPingEviction(t *testing.T) {
+	t.Parallel()
+
+	p := NewBrontide(Config{
+		Conn:           &stubConn{},
+		PingInterval:   10 * time.Millisecond,
+		MaxMissedPings: 2,
+	})
+
+	p.wg.Add(1)
+	go p.pingHandler()
+
+	// We answer the first ping and ignore all following ones. The peer
+	// should then be evicted at the tick after the third ping.
+	var numPings int
+	for done := false; !done; {
+		select {
+		case msg := <-p.outgoingQueue:
+			_, ok := msg.msg.(*lnwire.Ping)
+			require.True(t, ok, "expected ping, got %T", msg.msg)
+
+			numPings++
+			if numPings == 1 {
+				atomic.StoreInt32(&p.pongPending, 0)
+			}
+
+		case <-p.quit:
+			done = true
+
+		case <-time.After(timeout):
+			t.Fatalf("peer not evicted")
+		}
+	}
+
+	p.wg.Wait()
+
+	require.Equal(t, 3, numPings)
+	require.Equal(t, "2 consecutive pings unanswered", p.EvictionReason())
+}
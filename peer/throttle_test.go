@@ -0,0 +1,67 @@
+package peer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cryptomeow/lnd/lnwire"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+// TestLazyWriteRate tests that low-priority messages are held back once the
+// write rate limit is exceeded, while high-priority messages are still sent
+// without delay.
+func TestLazyWriteRate(t *testing.T) {
+	t.Parallel()
+
+	p := NewBrontide(Config{
+		Conn: &stubConn{},
+	})
+
+	// Each message is 1000 bytes on the wire, so the burst allows a
+	// single message and the next one may follow after 200ms.
+	p.lazyLimiter = rate.NewLimiter(5000, 1000)
+	newMsg := func(msgType lnwire.MessageType) *lnwire.Custom {
+		return &lnwire.Custom{
+			Type: msgType,
+			Data: make([]byte, 998),
+		}
+	}
+
+	p.wg.Add(1)
+	go p.queueHandler()
+	defer func() {
+		close(p.quit)
+		p.wg.Wait()
+	}()
+
+	nextMsg := func() lnwire.MessageType {
+		select {
+		case outMsg := <-p.sendQueue:
+			return outMsg.msg.MsgType()
+
+		case <-time.After(timeout):
+			t.Fatalf("no message sent")
+			return 0
+		}
+	}
+
+	for i := 0; i < 3; i++ {
+		msgType := lnwire.CustomTypeStart + lnwire.MessageType(i)
+		p.queueMsgLazy(newMsg(msgType), nil)
+	}
+
+	// The first two messages are sent right away, as the rate is only
+	// exceeded once the second one is charged.
+	require.Equal(t, lnwire.CustomTypeStart, nextMsg())
+	require.Equal(t, lnwire.CustomTypeStart+1, nextMsg())
+	throttled := time.Now()
+
+	// A high-priority message overtakes the throttled low-priority one.
+	p.queueMsg(newMsg(lnwire.CustomTypeStart+10), nil)
+	require.Equal(t, lnwire.CustomTypeStart+10, nextMsg())
+
+	require.Equal(t, lnwire.CustomTypeStart+2, nextMsg())
+	require.True(t, time.Since(throttled) >= 100*time.Millisecond)
+}
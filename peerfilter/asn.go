@@ -0,0 +1,134 @@
+package peerfilter
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// prefixKey identifies a network of an ASNTable by its prefix length and its
+// masked IP address.
+type prefixKey struct {
+	ones int
+	ip   string
+}
+
+// ASNTable maps IP networks to the AS numbers that announce them, so rules can
+// match the AS numbers of remote addresses.
+type ASNTable struct {
+	// networks maps each network to its AS number.
+	networks map[prefixKey]uint32
+
+	// prefixLens are the prefix lengths of the IPv4 and IPv6 networks of
+	// the table respectively, from the longest to the shortest.
+	prefixLens [2][]int
+}
+
+// LoadASNTable loads an ASN table from a data file. Each line of the file
+// holds a CIDR network followed by the AS number announcing it, separated by
+// whitespace, such as "192.0.2.0/24 AS64496". Empty lines and lines starting
+// with # are ignored.
+func LoadASNTable(path string) (*ASNTable, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	table, err := parseASNTable(f)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ASN data file %v: %v", path,
+			err)
+	}
+
+	return table, nil
+}
+
+// parseASNTable parses an ASN table in the format of LoadASNTable.
+func parseASNTable(r io.Reader) (*ASNTable, error) {
+	table := &ASNTable{
+		networks: make(map[prefixKey]uint32),
+	}
+	prefixLens := [2]map[int]struct{}{
+		make(map[int]struct{}), make(map[int]struct{}),
+	}
+
+	scanner := bufio.NewScanner(r)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("line %d: expected network and "+
+				"AS number", lineNum)
+		}
+
+		_, network, err := net.ParseCIDR(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", lineNum, err)
+		}
+
+		asn, ok := parseASN(fields[1])
+		if !ok {
+			parsed, err := strconv.ParseUint(fields[1], 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid AS "+
+					"number %q", lineNum, fields[1])
+			}
+			asn = uint32(parsed)
+		}
+
+		ones, bits := network.Mask.Size()
+		table.networks[prefixKey{ones, string(network.IP)}] = asn
+		prefixLens[family(bits)][ones] = struct{}{}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	for i, lens := range prefixLens {
+		for ones := range lens {
+			table.prefixLens[i] = append(table.prefixLens[i], ones)
+		}
+		sort.Sort(sort.Reverse(sort.IntSlice(table.prefixLens[i])))
+	}
+
+	return table, nil
+}
+
+// family returns the index of the address family of the given address size in
+// bits within the prefix lengths of an ASNTable.
+func family(bits int) int {
+	if bits == 8*net.IPv4len {
+		return 0
+	}
+
+	return 1
+}
+
+// Lookup returns the AS number announcing the most specific network that
+// contains the given IP address. False is returned if no network contains it.
+func (t *ASNTable) Lookup(ip net.IP) (uint32, bool) {
+	bits := 8 * net.IPv6len
+	if ip4 := ip.To4(); ip4 != nil {
+		ip, bits = ip4, 8*net.IPv4len
+	}
+
+	for _, ones := range t.prefixLens[family(bits)] {
+		masked := ip.Mask(net.CIDRMask(ones, bits))
+		asn, ok := t.networks[prefixKey{ones, string(masked)}]
+		if ok {
+			return asn, true
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,263 @@
+package peerfilter
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/cryptomeow/lnd/channeldb"
+)
+
+var (
+	// ErrStaticRule is returned when a statically configured rule is added
+	// or removed at runtime.
+	ErrStaticRule = errors.New("rule is statically configured")
+
+	// ErrNoASNTable is returned when a rule matching an AS number is used
+	// without an ASN table to look up the AS numbers of remote addresses.
+	ErrNoASNTable = errors.New("rules matching AS numbers require an " +
+		"ASN data file")
+)
+
+// RuleStore persists the rules that are added at runtime.
+type RuleStore interface {
+	// PutPeerFilterRule stores a rule, replacing the action of an
+	// existing rule for the same network or AS number.
+	PutPeerFilterRule(channeldb.PeerFilterRule) error
+
+	// DeletePeerFilterRule removes the rule for the given network or AS
+	// number.
+	DeletePeerFilterRule(string) error
+
+	// FetchPeerFilterRules returns all stored rules.
+	FetchPeerFilterRules() ([]channeldb.PeerFilterRule, error)
+}
+
+// Config holds the configuration of a Filter.
+type Config struct {
+	// Rules are the statically configured rules, which can't be changed
+	// at runtime.
+	Rules []*Rule
+
+	// AllowOnly rejects all connections that don't match an allow rule.
+	AllowOnly bool
+
+	// ASNTable maps remote addresses to their AS numbers. It is required
+	// if any rule matches an AS number.
+	ASNTable *ASNTable
+
+	// Store persists the rules that are added at runtime.
+	Store RuleStore
+}
+
+// Filter decides whether inbound connections are accepted based on their
+// remote address. A connection is accepted if it matches an allow rule, and
+// rejected if it matches a deny rule. Connections that match neither are
+// accepted, unless the filter only accepts connections matching an allow rule.
+type Filter struct {
+	numRejected uint64 // To be used atomically.
+
+	cfg *Config
+
+	mu           sync.RWMutex
+	staticRules  map[string]*Rule
+	runtimeRules map[string]*Rule
+}
+
+// New creates a new filter with the configured rules and the rules that were
+// previously added at runtime.
+func New(cfg *Config) (*Filter, error) {
+	f := &Filter{
+		cfg:          cfg,
+		staticRules:  make(map[string]*Rule),
+		runtimeRules: make(map[string]*Rule),
+	}
+
+	for _, rule := range cfg.Rules {
+		if err := f.checkRule(rule); err != nil {
+			return nil, err
+		}
+		f.staticRules[rule.String()] = rule
+	}
+
+	storedRules, err := cfg.Store.FetchPeerFilterRules()
+	if err != nil {
+		return nil, err
+	}
+	for _, storedRule := range storedRules {
+		rule, err := ParseRule(storedRule.Rule, storedRule.Action)
+		if err != nil {
+			return nil, err
+		}
+
+		// A stored rule that is now configured statically is
+		// overridden by the configuration.
+		if _, ok := f.staticRules[rule.String()]; ok {
+			continue
+		}
+
+		// Rules matching AS numbers stay stored without an ASN table,
+		// but can't be applied.
+		if err := f.checkRule(rule); err != nil {
+			log.Warnf("Ignoring peer filter rule %v: %v", rule, err)
+			continue
+		}
+		f.runtimeRules[rule.String()] = rule
+	}
+
+	log.Infof("Filtering inbound connections with %d static and %d "+
+		"runtime rules", len(f.staticRules), len(f.runtimeRules))
+
+	return f, nil
+}
+
+// checkRule ensures that the rule can be applied.
+func (f *Filter) checkRule(rule *Rule) error {
+	if rule.Network == nil && f.cfg.ASNTable == nil {
+		return ErrNoASNTable
+	}
+
+	return nil
+}
+
+// AddRule adds a rule at runtime and persists it. An existing runtime rule for
+// the same network or AS number is replaced.
+func (f *Filter) AddRule(rule *Rule) error {
+	if err := f.checkRule(rule); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	key := rule.String()
+	if _, ok := f.staticRules[key]; ok {
+		return ErrStaticRule
+	}
+
+	err := f.cfg.Store.PutPeerFilterRule(channeldb.PeerFilterRule{
+		Rule:   key,
+		Action: rule.Action,
+	})
+	if err != nil {
+		return err
+	}
+	f.runtimeRules[key] = rule
+
+	log.Infof("Added peer filter rule %v", key)
+
+	return nil
+}
+
+// RemoveRule removes the runtime rule for the network or AS number of the
+// given rule.
+func (f *Filter) RemoveRule(rule *Rule) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	key := rule.String()
+	if _, ok := f.staticRules[key]; ok {
+		return ErrStaticRule
+	}
+
+	if err := f.cfg.Store.DeletePeerFilterRule(key); err != nil {
+		return err
+	}
+	delete(f.runtimeRules, key)
+
+	log.Infof("Removed peer filter rule %v", key)
+
+	return nil
+}
+
+// Rules returns the static and the runtime rules of the filter, sorted by the
+// networks and AS numbers they match.
+func (f *Filter) Rules() ([]*Rule, []*Rule) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	return sortedRules(f.staticRules), sortedRules(f.runtimeRules)
+}
+
+// sortedRules returns the rules of the map sorted by their keys.
+func sortedRules(rules map[string]*Rule) []*Rule {
+	keys := make([]string, 0, len(rules))
+	for key := range rules {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	sorted := make([]*Rule, 0, len(keys))
+	for _, key := range keys {
+		sorted = append(sorted, rules[key])
+	}
+
+	return sorted
+}
+
+// AllowOnly returns true if the filter only accepts connections that match an
+// allow rule.
+func (f *Filter) AllowOnly() bool {
+	return f.cfg.AllowOnly
+}
+
+// NumRejected returns the number of connections that were rejected since the
+// filter was created.
+func (f *Filter) NumRejected() uint64 {
+	return atomic.LoadUint64(&f.numRejected)
+}
+
+// Accept returns nil if a connection from the given remote address should be
+// accepted, and the reason for rejecting it otherwise. Only TCP addresses can
+// match rules.
+func (f *Filter) Accept(addr net.Addr) error {
+	var ip net.IP
+	if tcpAddr, ok := addr.(*net.TCPAddr); ok {
+		ip = tcpAddr.IP
+	}
+
+	var (
+		asn     uint32
+		haveASN bool
+	)
+	if ip != nil && f.cfg.ASNTable != nil {
+		asn, haveASN = f.cfg.ASNTable.Lookup(ip)
+	}
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	// An allow rule takes precedence over any matching deny rule.
+	var denyRule *Rule
+	for _, rules := range []map[string]*Rule{
+		f.staticRules, f.runtimeRules,
+	} {
+		for _, rule := range rules {
+			if ip == nil || !rule.matches(ip, asn, haveASN) {
+				continue
+			}
+
+			if rule.Action == channeldb.PeerFilterAllow {
+				return nil
+			}
+			denyRule = rule
+		}
+	}
+
+	switch {
+	case denyRule != nil:
+		atomic.AddUint64(&f.numRejected, 1)
+		return fmt.Errorf("address %v matches deny rule %v", addr,
+			denyRule)
+
+	case f.cfg.AllowOnly:
+		atomic.AddUint64(&f.numRejected, 1)
+		return fmt.Errorf("address %v doesn't match any allow rule",
+			addr)
+	}
+
+	return nil
+}
@@ -0,0 +1,201 @@
+package peerfilter
+
+import (
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/cryptomeow/lnd/channeldb"
+	"github.com/stretchr/testify/require"
+)
+
+// mockStore is an in-memory RuleStore.
+type mockStore map[string]channeldb.PeerFilterAction
+
+// PutPeerFilterRule stores a rule.
+func (s mockStore) PutPeerFilterRule(rule channeldb.PeerFilterRule) error {
+	s[rule.Rule] = rule.Action
+	return nil
+}
+
+// DeletePeerFilterRule removes a rule.
+func (s mockStore) DeletePeerFilterRule(rule string) error {
+	if _, ok := s[rule]; !ok {
+		return channeldb.ErrPeerFilterRuleNotFound
+	}
+	delete(s, rule)
+
+	return nil
+}
+
+// FetchPeerFilterRules returns all stored rules.
+func (s mockStore) FetchPeerFilterRules() ([]channeldb.PeerFilterRule,
+	error) {
+
+	var rules []channeldb.PeerFilterRule
+	for rule, action := range s {
+		rules = append(rules, channeldb.PeerFilterRule{
+			Rule:   rule,
+			Action: action,
+		})
+	}
+
+	return rules, nil
+}
+
+// tcpAddr returns a TCP address of the given IP address.
+func tcpAddr(ip string) net.Addr {
+	return &net.TCPAddr{IP: net.ParseIP(ip), Port: 9735}
+}
+
+// TestParseRule asserts that rules are parsed into their canonical form.
+func TestParseRule(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		rule      string
+		canonical string
+		valid     bool
+	}{
+		{rule: "10.1.2.3/8", canonical: "10.0.0.0/8", valid: true},
+		{rule: "10.1.2.3", canonical: "10.1.2.3/32", valid: true},
+		{
+			rule:      "2001:db8::1",
+			canonical: "2001:db8::1/128",
+			valid:     true,
+		},
+		{rule: "as64496", canonical: "AS64496", valid: true},
+		{rule: "10.0.0.0/33"},
+		{rule: "AS"},
+		{rule: "example.com"},
+	}
+
+	for _, testCase := range testCases {
+		rule, err := ParseRule(testCase.rule, channeldb.PeerFilterDeny)
+		if !testCase.valid {
+			require.Error(t, err, testCase.rule)
+			continue
+		}
+
+		require.NoError(t, err, testCase.rule)
+		require.Equal(t, testCase.canonical, rule.String())
+	}
+}
+
+// TestASNTable asserts that the most specific network of an address is found
+// in an ASN table.
+func TestASNTable(t *testing.T) {
+	t.Parallel()
+
+	table, err := parseASNTable(strings.NewReader(`
+# network asn
+192.0.0.0/8 AS1
+192.0.2.0/24 2
+2001:db8::/32 AS3
+`))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		ip  string
+		asn uint32
+		ok  bool
+	}{
+		{ip: "192.0.2.1", asn: 2, ok: true},
+		{ip: "192.1.0.1", asn: 1, ok: true},
+		{ip: "2001:db8::1", asn: 3, ok: true},
+		{ip: "10.0.0.1"},
+	}
+	for _, testCase := range testCases {
+		asn, ok := table.Lookup(net.ParseIP(testCase.ip))
+		require.Equal(t, testCase.ok, ok, testCase.ip)
+		require.Equal(t, testCase.asn, asn, testCase.ip)
+	}
+
+	_, err = parseASNTable(strings.NewReader("192.0.2.0/24"))
+	require.Error(t, err)
+}
+
+// TestFilter asserts that connections are accepted or rejected according to
+// the static and runtime rules of a filter, and that runtime rules are
+// persisted.
+func TestFilter(t *testing.T) {
+	t.Parallel()
+
+	table, err := parseASNTable(strings.NewReader("192.0.2.0/24 AS64496"))
+	require.NoError(t, err)
+
+	mustParse := func(rule string,
+		action channeldb.PeerFilterAction) *Rule {
+
+		parsed, err := ParseRule(rule, action)
+		require.NoError(t, err)
+
+		return parsed
+	}
+
+	store := mockStore{"198.51.100.0/24": channeldb.PeerFilterDeny}
+	filter, err := New(&Config{
+		Rules: []*Rule{
+			mustParse("AS64496", channeldb.PeerFilterDeny),
+			mustParse("192.0.2.7", channeldb.PeerFilterAllow),
+		},
+		ASNTable: table,
+		Store:    store,
+	})
+	require.NoError(t, err)
+
+	// Addresses of the denied AS and the stored network are rejected,
+	// unless they match an allow rule.
+	require.Error(t, filter.Accept(tcpAddr("192.0.2.1")))
+	require.Error(t, filter.Accept(tcpAddr("198.51.100.1")))
+	require.NoError(t, filter.Accept(tcpAddr("192.0.2.7")))
+	require.NoError(t, filter.Accept(tcpAddr("203.0.113.1")))
+	require.Equal(t, uint64(2), filter.NumRejected())
+
+	// Static rules can't be changed at runtime.
+	require.Equal(
+		t, ErrStaticRule,
+		filter.AddRule(mustParse("AS64496", channeldb.PeerFilterAllow)),
+	)
+	require.Equal(t, ErrStaticRule, filter.RemoveRule(
+		mustParse("192.0.2.7", channeldb.PeerFilterDeny),
+	))
+
+	// Runtime rules are persisted.
+	require.NoError(t, filter.AddRule(
+		mustParse("203.0.113.0/24", channeldb.PeerFilterDeny),
+	))
+	require.NoError(t, filter.RemoveRule(
+		mustParse("198.51.100.0/24", channeldb.PeerFilterDeny),
+	))
+	require.Equal(
+		t, mockStore{"203.0.113.0/24": channeldb.PeerFilterDeny}, store,
+	)
+	require.Error(t, filter.Accept(tcpAddr("203.0.113.1")))
+	require.NoError(t, filter.Accept(tcpAddr("198.51.100.1")))
+
+	staticRules, runtimeRules := filter.Rules()
+	require.Len(t, staticRules, 2)
+	require.Len(t, runtimeRules, 1)
+	require.Equal(t, "203.0.113.0/24", runtimeRules[0].String())
+
+	// Rules matching AS numbers can't be used without an ASN table.
+	_, err = New(&Config{
+		Rules: []*Rule{mustParse("AS1", channeldb.PeerFilterDeny)},
+		Store: mockStore{},
+	})
+	require.Equal(t, ErrNoASNTable, err)
+
+	// If only allowed connections are accepted, connections that don't
+	// match any rule are rejected.
+	filter, err = New(&Config{
+		Rules: []*Rule{
+			mustParse("10.0.0.0/8", channeldb.PeerFilterAllow),
+		},
+		AllowOnly: true,
+		Store:     mockStore{},
+	})
+	require.NoError(t, err)
+	require.NoError(t, filter.Accept(tcpAddr("10.1.2.3")))
+	require.Error(t, filter.Accept(tcpAddr("203.0.113.1")))
+}
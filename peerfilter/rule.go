@@ -0,0 +1,99 @@
+// Package peerfilter filters inbound peer connections by the network or the
+// autonomous system of their remote address, to mitigate sybil connection
+// floods.
+package peerfilter
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/cryptomeow/lnd/channeldb"
+)
+
+// Rule matches inbound connections by the network or the AS number of their
+// remote address.
+type Rule struct {
+	// Network is the network the rule matches. It is nil if the rule
+	// matches an AS number.
+	Network *net.IPNet
+
+	// ASN is the AS number the rule matches if Network is nil.
+	ASN uint32
+
+	// Action is the action the rule takes for matching connections.
+	Action channeldb.PeerFilterAction
+}
+
+// ParseRule parses a rule that matches the given IP address, CIDR network or
+// AS number of the form AS64496.
+func ParseRule(rule string, action channeldb.PeerFilterAction) (*Rule,
+	error) {
+
+	rule = strings.TrimSpace(rule)
+
+	if asn, ok := parseASN(rule); ok {
+		return &Rule{ASN: asn, Action: action}, nil
+	}
+
+	if strings.Contains(rule, "/") {
+		_, network, err := net.ParseCIDR(rule)
+		if err != nil {
+			return nil, fmt.Errorf("invalid peer filter rule "+
+				"%q: %v", rule, err)
+		}
+
+		return &Rule{Network: network, Action: action}, nil
+	}
+
+	ip := net.ParseIP(rule)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid peer filter rule %q: expected "+
+			"an IP address, CIDR network or AS number", rule)
+	}
+
+	bits := 8 * net.IPv6len
+	if ip4 := ip.To4(); ip4 != nil {
+		ip, bits = ip4, 8*net.IPv4len
+	}
+
+	return &Rule{
+		Network: &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)},
+		Action:  action,
+	}, nil
+}
+
+// parseASN parses an AS number of the form AS64496.
+func parseASN(s string) (uint32, bool) {
+	if len(s) < 3 || !strings.EqualFold(s[:2], "AS") {
+		return 0, false
+	}
+
+	asn, err := strconv.ParseUint(s[2:], 10, 32)
+	if err != nil {
+		return 0, false
+	}
+
+	return uint32(asn), true
+}
+
+// String returns the canonical form of the network or AS number the rule
+// matches, which identifies the rule.
+func (r *Rule) String() string {
+	if r.Network != nil {
+		return r.Network.String()
+	}
+
+	return fmt.Sprintf("AS%d", r.ASN)
+}
+
+// matches returns true if the rule matches a connection from the given IP
+// address, which belongs to the given AS number if known.
+func (r *Rule) matches(ip net.IP, asn uint32, haveASN bool) bool {
+	if r.Network != nil {
+		return r.Network.Contains(ip)
+	}
+
+	return haveASN && r.ASN == asn
+}
@@ -26,6 +26,10 @@ type PeerOnlineEvent struct {
 type PeerOfflineEvent struct {
 	// PubKey is the peer's compressed public key.
 	PubKey [33]byte
+
+	// EvictionReason describes why we disconnected the peer if it was
+	// evicted for being unresponsive. It is empty otherwise.
+	EvictionReason string
 }
 
 // New creates a new peer notifier which notifies clients of peer online
@@ -75,9 +79,15 @@ func (p *PeerNotifier) NotifyPeerOnline(pubKey [33]byte) {
 }
 
 // NotifyPeerOffline sends a peer offline event to all the clients subscribed
-// to the peer notifier.
-func (p *PeerNotifier) NotifyPeerOffline(pubKey [33]byte) {
-	event := PeerOfflineEvent{PubKey: pubKey}
+// to the peer notifier. If the peer was evicted for being unresponsive, the
+// reason should be passed as well.
+func (p *PeerNotifier) NotifyPeerOffline(pubKey [33]byte,
+	evictionReason string) {
+
+	event := PeerOfflineEvent{
+		PubKey:         pubKey,
+		EvictionReason: evictionReason,
+	}
 
 	log.Debugf("PeerNotifier notifying peer: %x offline", pubKey)
 
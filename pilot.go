@@ -4,17 +4,31 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"time"
 
 	"github.com/btcsuite/btcd/btcec"
 	"github.com/btcsuite/btcd/wire"
 	"github.com/btcsuite/btcutil"
 	"github.com/cryptomeow/lnd/autopilot"
 	"github.com/cryptomeow/lnd/chainreg"
+	"github.com/cryptomeow/lnd/channeldb"
 	"github.com/cryptomeow/lnd/lncfg"
+	"github.com/cryptomeow/lnd/lnwallet"
 	"github.com/cryptomeow/lnd/lnwire"
 	"github.com/cryptomeow/lnd/tor"
 )
 
+const (
+	// routingDemandWindow is the period of forwarding history that is
+	// taken into account as routing demand by the autopilot agent.
+	routingDemandWindow = 30 * 24 * time.Hour
+
+	// maxForwardingEventsQuery is the number of forwarding events that are
+	// read from the forwarding log at once when gathering the routing
+	// demand.
+	maxForwardingEventsQuery = 50000
+)
+
 // validateAtplConfig is a helper method that makes sure the passed
 // configuration is sane. Currently it checks that the heuristic configuration
 // makes sense. In case the config is valid, it will return a list of
@@ -130,6 +144,70 @@ func (c *chanController) CloseChannel(chanPoint *wire.OutPoint) error {
 // autopilot.ChannelController interface.
 var _ autopilot.ChannelController = (*chanController)(nil)
 
+// routingDemand returns the routing demand observed by our node, expressed as
+// the volume sent towards each node. It consists of the volume we forwarded to
+// each of our channel peers within the routing demand window, and the amounts
+// mission control attempted to route towards each node, whether the attempts
+// succeeded or not.
+func routingDemand(svr *server) (map[autopilot.NodeID]btcutil.Amount, error) {
+	demand := make(map[autopilot.NodeID]btcutil.Amount)
+
+	// To attribute the forwarded volume to our peers, we'll need to know
+	// who is on the other end of each channel.
+	channels, err := svr.remoteChanDB.FetchAllChannels()
+	if err != nil {
+		return nil, err
+	}
+	peers := make(map[lnwire.ShortChannelID]autopilot.NodeID, len(channels))
+	for _, channel := range channels {
+		peers[channel.ShortChanID()] = autopilot.NewNodeID(
+			channel.IdentityPub,
+		)
+	}
+
+	now := time.Now()
+	query := channeldb.ForwardingEventQuery{
+		StartTime:    now.Add(-routingDemandWindow),
+		EndTime:      now,
+		NumMaxEvents: maxForwardingEventsQuery,
+	}
+	for {
+		timeSlice, err := svr.remoteChanDB.ForwardingLog().Query(query)
+		if err == channeldb.ErrNoForwardingEvents {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// Forwards over channels that have since been closed can't be
+		// attributed, so they're skipped.
+		for _, event := range timeSlice.ForwardingEvents {
+			peer, ok := peers[event.OutgoingChanID]
+			if !ok {
+				continue
+			}
+
+			demand[peer] += event.AmtOut.ToSatoshis()
+		}
+
+		if uint32(len(timeSlice.ForwardingEvents)) <
+			maxForwardingEventsQuery {
+
+			break
+		}
+		query.IndexOffset = timeSlice.LastIndexOffset
+	}
+
+	for _, pair := range svr.missionControl.GetHistorySnapshot().Pairs {
+		nID := autopilot.NodeID(pair.Pair.To)
+		demand[nID] += pair.SuccessAmt.ToSatoshis() +
+			pair.FailAmt.ToSatoshis()
+	}
+
+	return demand, nil
+}
+
 // initAutoPilot initializes a new autopilot.ManagerCfg to manage an autopilot.
 // Agent instance based on the passed configuration structs. The agent and all
 // interfaces needed to drive it won't be launched before the Manager's
@@ -140,9 +218,10 @@ func initAutoPilot(svr *server, cfg *lncfg.AutoPilot,
 
 	atplLog.Infof("Instantiating autopilot with active=%v, "+
 		"max_channels=%d, allocation=%f, min_chan_size=%d, "+
-		"max_chan_size=%d, private=%t, min_confs=%d, conf_target=%d",
-		cfg.Active, cfg.MaxChannels, cfg.Allocation, cfg.MinChannelSize,
-		cfg.MaxChannelSize, cfg.Private, cfg.MinConfs, cfg.ConfTarget)
+		"max_chan_size=%d, private=%t, min_confs=%d, conf_target=%d, "+
+		"max_opens_per_day=%d", cfg.Active, cfg.MaxChannels,
+		cfg.Allocation, cfg.MinChannelSize, cfg.MaxChannelSize,
+		cfg.Private, cfg.MinConfs, cfg.ConfTarget, cfg.MaxOpensPerDay)
 
 	// Set up the constraints the autopilot heuristics must adhere to.
 	atplConstraints := autopilot.NewConstraints(
@@ -157,6 +236,21 @@ func initAutoPilot(svr *server, cfg *lncfg.AutoPilot,
 		return nil, err
 	}
 
+	// The routing demand heuristic needs access to our forwarding history
+	// and mission control, so we'll hook those up if it's active.
+	for _, h := range heuristics {
+		demand, ok := h.AttachmentHeuristic.(*autopilot.RoutingDemand)
+		if !ok {
+			continue
+		}
+
+		demand.SetDemandSource(
+			func() (map[autopilot.NodeID]btcutil.Amount, error) {
+				return routingDemand(svr)
+			},
+		)
+	}
+
 	weightedAttachment, err := autopilot.NewWeightedCombAttachment(
 		heuristics...,
 	)
@@ -179,10 +273,13 @@ func initAutoPilot(svr *server, cfg *lncfg.AutoPilot,
 			netParams:     netParams,
 		},
 		WalletBalance: func() (btcutil.Amount, error) {
-			return svr.cc.Wallet.ConfirmedBalance(cfg.MinConfs)
+			return svr.cc.Wallet.ConfirmedBalance(
+				cfg.MinConfs, lnwallet.DefaultAccountName,
+			)
 		},
-		Graph:       autopilot.ChannelGraphFromDatabase(svr.localChanDB.ChannelGraph()),
-		Constraints: atplConstraints,
+		Graph:          autopilot.ChannelGraphFromDatabase(svr.localChanDB.ChannelGraph()),
+		Constraints:    atplConstraints,
+		MaxOpensPerDay: cfg.MaxOpensPerDay,
 		ConnectToPeer: func(target *btcec.PublicKey, addrs []net.Addr) (bool, error) {
 			// First, we'll check if we're already connected to the
 			// target peer. If we are, we can exit early. Otherwise,
@@ -0,0 +1,73 @@
+package routing
+
+import (
+	"sync"
+
+	"github.com/btcsuite/btcd/wire"
+)
+
+// blockFetch is a pending request for the block at a certain height. Once the
+// request completes, done is closed and either block or err is set.
+type blockFetch struct {
+	done  chan struct{}
+	block *wire.MsgBlock
+	err   error
+}
+
+// blockFetchGroup deduplicates concurrent requests for the same block. During
+// the initial graph sync many channel announcements referencing the same
+// funding block are validated in parallel, and without deduplication each of
+// them would retrieve the full block from the chain backend. Blocks are only
+// shared between requests that overlap in time, so no blocks are kept in
+// memory once all requests for them have completed.
+type blockFetchGroup struct {
+	// fetch retrieves the block at the given height from the chain
+	// backend.
+	fetch func(height uint32) (*wire.MsgBlock, error)
+
+	// pending maps the heights of all blocks currently being retrieved to
+	// their requests.
+	pending map[uint32]*blockFetch
+
+	mu sync.Mutex
+}
+
+// newBlockFetchGroup creates a new blockFetchGroup that retrieves blocks using
+// the given function.
+func newBlockFetchGroup(
+	fetch func(height uint32) (*wire.MsgBlock, error)) *blockFetchGroup {
+
+	return &blockFetchGroup{
+		fetch:   fetch,
+		pending: make(map[uint32]*blockFetch),
+	}
+}
+
+// FetchBlock returns the block at the given height. If the block is already
+// being retrieved by another caller, we'll wait for that request to complete
+// and return its result instead of retrieving the block again.
+func (g *blockFetchGroup) FetchBlock(height uint32) (*wire.MsgBlock, error) {
+	g.mu.Lock()
+	if req, ok := g.pending[height]; ok {
+		g.mu.Unlock()
+
+		<-req.done
+		return req.block, req.err
+	}
+
+	req := &blockFetch{
+		done: make(chan struct{}),
+	}
+	g.pending[height] = req
+	g.mu.Unlock()
+
+	req.block, req.err = g.fetch(height)
+
+	g.mu.Lock()
+	delete(g.pending, height)
+	g.mu.Unlock()
+
+	close(req.done)
+
+	return req.block, req.err
+}
@@ -0,0 +1,82 @@
+package routing
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBlockFetchGroup asserts that concurrent requests for the same block are
+// served by a single fetch, while requests for different blocks or requests
+// that don't overlap in time are fetched separately.
+func TestBlockFetchGroup(t *testing.T) {
+	t.Parallel()
+
+	var numFetches int32
+	release := make(chan struct{})
+	group := newBlockFetchGroup(func(height uint32) (*wire.MsgBlock,
+		error) {
+
+		atomic.AddInt32(&numFetches, 1)
+		<-release
+
+		return &wire.MsgBlock{
+			Header: wire.BlockHeader{Nonce: height},
+		}, nil
+	})
+
+	// Request the same block from several goroutines, and another block
+	// from one more goroutine.
+	const numRequests = 5
+	var wg sync.WaitGroup
+	blocks := make([]*wire.MsgBlock, numRequests+1)
+	errs := make([]error, numRequests+1)
+	for i := 0; i <= numRequests; i++ {
+		height := uint32(100)
+		if i == numRequests {
+			height = 200
+		}
+
+		wg.Add(1)
+		go func(i int, height uint32) {
+			defer wg.Done()
+
+			blocks[i], errs[i] = group.FetchBlock(height)
+		}(i, height)
+	}
+
+	// Wait for both fetches to be in progress before releasing them. The
+	// remaining requests for the first block must have joined the pending
+	// fetch by then, as they never start a fetch of their own.
+	require.Eventually(t, func() bool {
+		group.mu.Lock()
+		defer group.mu.Unlock()
+
+		return len(group.pending) == 2
+	}, time.Second, 10*time.Millisecond)
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, int32(2), atomic.LoadInt32(&numFetches))
+	for i := 1; i < numRequests; i++ {
+		require.Same(t, blocks[0], blocks[i])
+	}
+	require.Equal(t, uint32(100), blocks[0].Header.Nonce)
+	require.Equal(t, uint32(200), blocks[numRequests].Header.Nonce)
+
+	// Completed fetches aren't cached, so a new request fetches the block
+	// again.
+	_, err := group.FetchBlock(100)
+	require.NoError(t, err)
+	require.Equal(t, int32(3), atomic.LoadInt32(&numFetches))
+	require.Empty(t, group.pending)
+}
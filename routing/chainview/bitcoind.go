@@ -12,6 +12,7 @@ import (
 	"github.com/btcsuite/btcd/wire"
 	"github.com/btcsuite/btcwallet/chain"
 	"github.com/btcsuite/btcwallet/wtxmgr"
+	"github.com/cryptomeow/lnd/blockfetch"
 	"github.com/cryptomeow/lnd/channeldb"
 )
 
@@ -32,6 +33,11 @@ type BitcoindFilteredChainView struct {
 	// NodeFilteredView interface.
 	chainClient *chain.BitcoindClient
 
+	// blockFetcher is used to retrieve blocks from the P2P network that
+	// have been pruned by the bitcoind node. It is nil if the node isn't
+	// pruned.
+	blockFetcher *blockfetch.Fetcher
+
 	// blockEventQueue is the ordered queue used to keep the order
 	// of connected and disconnected blocks sent to the reader of the
 	// chainView.
@@ -59,11 +65,14 @@ type BitcoindFilteredChainView struct {
 var _ FilteredChainView = (*BitcoindFilteredChainView)(nil)
 
 // NewBitcoindFilteredChainView creates a new instance of a FilteredChainView
-// from RPC credentials and a ZMQ socket address for a bitcoind instance.
-func NewBitcoindFilteredChainView(
-	chainConn *chain.BitcoindConn) *BitcoindFilteredChainView {
+// from RPC credentials and a ZMQ socket address for a bitcoind instance. If the
+// node is pruned, a blockFetcher can be passed to retrieve historical blocks
+// from the P2P network.
+func NewBitcoindFilteredChainView(chainConn *chain.BitcoindConn,
+	blockFetcher *blockfetch.Fetcher) *BitcoindFilteredChainView {
 
 	chainView := &BitcoindFilteredChainView{
+		blockFetcher:    blockFetcher,
 		chainFilter:     make(map[wire.OutPoint]struct{}),
 		filterUpdates:   make(chan filterUpdate),
 		filterBlockReqs: make(chan *filterBlockReq),
@@ -390,7 +399,10 @@ func (b *BitcoindFilteredChainView) chainFilterer() {
 		case req := <-b.filterBlockReqs:
 			// First we'll fetch the block itself as well as some
 			// additional information including its height.
-			block, err := b.chainClient.GetBlock(req.blockHash)
+			block, err := blockfetch.GetBlock(
+				req.blockHash, b.chainClient.GetBlock,
+				b.blockFetcher,
+			)
 			if err != nil {
 				req.err <- err
 				req.resp <- nil
@@ -0,0 +1,103 @@
+package routing
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// defaultBlockInterval is the block interval that is assumed if a
+	// chain doesn't specify its target time per block.
+	defaultBlockInterval = 10 * time.Minute
+
+	// MinCLTVDeltaDuration is the minimum time a CLTV delta must leave us
+	// to resolve an HTLC on chain. It corresponds to MinCLTVDelta blocks on
+	// bitcoin.
+	MinCLTVDeltaDuration = MinCLTVDelta * defaultBlockInterval
+
+	// MaxCLTVDeltaDuration is the maximum time a single CLTV delta may lock
+	// up the funds of an HTLC. It corresponds to 2016 blocks, or two weeks,
+	// on bitcoin.
+	MaxCLTVDeltaDuration = 2016 * defaultBlockInterval
+)
+
+// CltvLimits validates CLTV deltas and other block based timeouts against the
+// block time of a chain. The safety margins are expressed as durations, so the
+// same margins apply to chains with different block intervals.
+type CltvLimits struct {
+	blockInterval time.Duration
+}
+
+// NewCltvLimits creates a new CltvLimits instance for a chain with the given
+// target time per block. If the block interval is zero, the bitcoin block
+// interval is assumed.
+func NewCltvLimits(blockInterval time.Duration) *CltvLimits {
+	if blockInterval <= 0 {
+		blockInterval = defaultBlockInterval
+	}
+
+	return &CltvLimits{
+		blockInterval: blockInterval,
+	}
+}
+
+// blocks returns the number of blocks that are expected to be mined within
+// the given duration, rounded up.
+func (c *CltvLimits) blocks(d time.Duration) uint32 {
+	return uint32((d + c.blockInterval - 1) / c.blockInterval)
+}
+
+// Duration returns the time it is expected to take to mine the given number of
+// blocks.
+func (c *CltvLimits) Duration(blocks uint32) time.Duration {
+	return time.Duration(blocks) * c.blockInterval
+}
+
+// MinDelta returns the minimum CLTV delta in blocks that is considered safe on
+// the chain. It is never below MinCLTVDelta.
+func (c *CltvLimits) MinDelta() uint32 {
+	minDelta := c.blocks(MinCLTVDeltaDuration)
+	if minDelta < MinCLTVDelta {
+		return MinCLTVDelta
+	}
+
+	return minDelta
+}
+
+// MaxDelta returns the maximum CLTV delta in blocks that is considered sane on
+// the chain.
+func (c *CltvLimits) MaxDelta() uint32 {
+	return c.blocks(MaxCLTVDeltaDuration)
+}
+
+// ValidateDelta returns an error if the given CLTV delta is either too small
+// to safely resolve an HTLC on chain, or needlessly locks up funds for too
+// long. This applies to the forwarding CLTV deltas of channel policies as well
+// as to the final CLTV deltas of invoices.
+func (c *CltvLimits) ValidateDelta(delta uint32) error {
+	if minDelta := c.MinDelta(); delta < minDelta {
+		return fmt.Errorf("CLTV delta of %v is too small, minimum "+
+			"supported is %v", delta, minDelta)
+	}
+
+	if maxDelta := c.MaxDelta(); delta > maxDelta {
+		return fmt.Errorf("CLTV delta of %v is too large, maximum "+
+			"supported is %v", delta, maxDelta)
+	}
+
+	return nil
+}
+
+// ValidateTimeout returns an error if the given timeout in blocks is expected
+// to expire before the minimum duration has passed.
+func (c *CltvLimits) ValidateTimeout(blocks uint32,
+	minDuration time.Duration) error {
+
+	if minBlocks := c.blocks(minDuration); blocks < minBlocks {
+		return fmt.Errorf("timeout of %v blocks (~%v) is too short, "+
+			"minimum is %v blocks (%v)", blocks, c.Duration(blocks),
+			minBlocks, minDuration)
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package routing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestCltvLimits tests that CLTV deltas and timeouts are validated against the
+// block interval of the chain.
+func TestCltvLimits(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		blockInterval time.Duration
+		minDelta      uint32
+		maxDelta      uint32
+	}{
+		{
+			name:          "bitcoin",
+			blockInterval: 10 * time.Minute,
+			minDelta:      MinCLTVDelta,
+			maxDelta:      2016,
+		},
+		{
+			name:          "unknown block interval",
+			blockInterval: 0,
+			minDelta:      MinCLTVDelta,
+			maxDelta:      2016,
+		},
+		{
+			name:          "litecoin",
+			blockInterval: 150 * time.Second,
+			minDelta:      72,
+			maxDelta:      8064,
+		},
+		{
+			// Chains with slower blocks never go below the
+			// absolute minimum.
+			name:          "slow chain",
+			blockInterval: time.Hour,
+			minDelta:      MinCLTVDelta,
+			maxDelta:      336,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			limits := NewCltvLimits(testCase.blockInterval)
+
+			require.Equal(t, testCase.minDelta, limits.MinDelta())
+			require.Equal(t, testCase.maxDelta, limits.MaxDelta())
+
+			require.NoError(t, limits.ValidateDelta(testCase.minDelta))
+			require.NoError(t, limits.ValidateDelta(testCase.maxDelta))
+			require.Error(t, limits.ValidateDelta(testCase.minDelta-1))
+			require.Error(t, limits.ValidateDelta(testCase.maxDelta+1))
+		})
+	}
+
+	// A timeout must cover at least the minimum duration.
+	limits := NewCltvLimits(150 * time.Second)
+	require.NoError(t, limits.ValidateTimeout(576, 24*time.Hour))
+	require.Error(t, limits.ValidateTimeout(575, 24*time.Hour))
+}
@@ -3,6 +3,7 @@ package localchans
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/btcsuite/btcd/wire"
 	"github.com/cryptomeow/lnd/channeldb"
@@ -34,6 +35,10 @@ type Manager struct {
 	FetchChannel func(chanPoint wire.OutPoint) (*channeldb.OpenChannel,
 		error)
 
+	// AddPolicyChanges is used to record the applied policies in the
+	// policy history of our channels. If nil, no history is recorded.
+	AddPolicyChanges func(changes []*channeldb.PolicyChange) error
+
 	// policyUpdateLock ensures that the database and the link do not fall
 	// out of sync if there are concurrent fee update calls. Without it,
 	// there is a chance that policy A updates the database, then policy B
@@ -42,10 +47,21 @@ type Manager struct {
 	policyUpdateLock sync.Mutex
 }
 
+// UpdateInfo describes the origin of a policy update. It is recorded in the
+// policy history of the updated channels.
+type UpdateInfo struct {
+	// Template is the name of the policy template the new policy was
+	// taken from, if any.
+	Template string
+
+	// Reason is the reason for the update, as given by the operator.
+	Reason string
+}
+
 // UpdatePolicy updates the policy for the specified channels on disk and in the
 // active links.
 func (r *Manager) UpdatePolicy(newSchema routing.ChannelPolicy,
-	chanPoints ...wire.OutPoint) error {
+	info UpdateInfo, chanPoints ...wire.OutPoint) error {
 
 	r.policyUpdateLock.Lock()
 	defer r.policyUpdateLock.Unlock()
@@ -119,6 +135,33 @@ func (r *Manager) UpdatePolicy(newSchema routing.ChannelPolicy,
 	// Update active links.
 	r.UpdateForwardingPolicies(policiesToUpdate)
 
+	// Finally, record the new policies in the policy history, so that
+	// operators can audit when and why they were applied.
+	if r.AddPolicyChanges == nil || len(edgesToUpdate) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	changes := make([]*channeldb.PolicyChange, 0, len(edgesToUpdate))
+	for _, edge := range edgesToUpdate {
+		changes = append(changes, &channeldb.PolicyChange{
+			Timestamp:     now,
+			ChanPoint:     edge.Info.ChannelPoint,
+			BaseFee:       edge.Edge.FeeBaseMSat,
+			FeeRate:       uint32(edge.Edge.FeeProportionalMillionths),
+			TimeLockDelta: uint32(edge.Edge.TimeLockDelta),
+			MinHTLC:       edge.Edge.MinHTLC,
+			MaxHTLC:       edge.Edge.MaxHTLC,
+			Template:      info.Template,
+			Reason:        info.Reason,
+		})
+	}
+
+	if err := r.AddPolicyChanges(changes); err != nil {
+		return fmt.Errorf("policy updated, but unable to record "+
+			"policy history: %v", err)
+	}
+
 	return nil
 }
 
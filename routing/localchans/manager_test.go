@@ -115,22 +115,57 @@ func TestManager(t *testing.T) {
 		}, nil
 	}
 
+	var policyChanges []*channeldb.PolicyChange
+	addPolicyChanges := func(changes []*channeldb.PolicyChange) error {
+		policyChanges = append(policyChanges, changes...)
+		return nil
+	}
+
 	manager := Manager{
 		UpdateForwardingPolicies:  updateForwardingPolicies,
 		PropagateChanPolicyUpdate: propagateChanPolicyUpdate,
 		ForAllOutgoingChannels:    forAllOutgoingChannels,
 		FetchChannel:              fetchChannel,
+		AddPolicyChanges:          addPolicyChanges,
 	}
 
 	// Test updating a specific channels.
-	err := manager.UpdatePolicy(newPolicy, chanPoint)
+	info := UpdateInfo{
+		Template: "default",
+		Reason:   "test",
+	}
+	err := manager.UpdatePolicy(newPolicy, info, chanPoint)
 	if err != nil {
 		t.Fatal(err)
 	}
 
+	// The new policy should be recorded in the policy history.
+	if len(policyChanges) != 1 {
+		t.Fatal("unexpected number of policy changes")
+	}
+	change := policyChanges[0]
+	if change.ChanPoint != chanPoint {
+		t.Fatal("unexpected channel point")
+	}
+	if change.BaseFee != newPolicy.BaseFee {
+		t.Fatal("unexpected base fee")
+	}
+	if change.FeeRate != newPolicy.FeeRate {
+		t.Fatal("unexpected fee rate")
+	}
+	if change.TimeLockDelta != newPolicy.TimeLockDelta {
+		t.Fatal("unexpected time lock delta")
+	}
+	if change.MinHTLC != minHTLC || change.MaxHTLC != newPolicy.MaxHTLC {
+		t.Fatal("unexpected htlc limits")
+	}
+	if change.Template != info.Template || change.Reason != info.Reason {
+		t.Fatal("unexpected update info")
+	}
+
 	// Test updating all channels, which comes down to the same as testing a
 	// specific channel because there is only one channel.
-	err = manager.UpdatePolicy(newPolicy)
+	err = manager.UpdatePolicy(newPolicy, UpdateInfo{})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -141,7 +176,7 @@ func TestManager(t *testing.T) {
 	noMaxHtlcPolicy := newPolicy
 	noMaxHtlcPolicy.MaxHTLC = 0
 
-	err = manager.UpdatePolicy(noMaxHtlcPolicy)
+	err = manager.UpdatePolicy(noMaxHtlcPolicy, UpdateInfo{})
 	if err != nil {
 		t.Fatal(err)
 	}
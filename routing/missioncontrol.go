@@ -86,6 +86,10 @@ type MissionControl struct {
 	// results that mission control collects.
 	estimator *probabilityEstimator
 
+	// overrides holds the manual overrides that are applied on top of the
+	// probability estimates.
+	overrides *missionControlOverrides
+
 	sync.Mutex
 
 	// TODO(roasbeef): further counters, if vertex continually unavailable,
@@ -203,6 +207,7 @@ func NewMissionControl(db kvdb.Backend, cfg *MissionControlConfig) (
 		cfg:       cfg,
 		store:     store,
 		estimator: estimator,
+		overrides: newMissionControlOverrides(),
 	}
 
 	if err := mc.init(); err != nil {
@@ -262,11 +267,18 @@ func (m *MissionControl) GetProbability(fromNode, toNode route.Vertex,
 	results, _ := m.state.getLastPairResult(fromNode)
 
 	// Use a distinct probability estimation function for local channels.
+	var probability float64
 	if fromNode == m.cfg.SelfNode {
-		return m.estimator.getLocalPairProbability(now, results, toNode)
+		probability = m.estimator.getLocalPairProbability(
+			now, results, toNode,
+		)
+	} else {
+		probability = m.estimator.getPairProbability(
+			now, results, toNode, amt,
+		)
 	}
 
-	return m.estimator.getPairProbability(now, results, toNode, amt)
+	return m.applyOverrides(now, fromNode, toNode, probability)
 }
 
 // GetHistorySnapshot takes a snapshot from the current mission control state
@@ -0,0 +1,166 @@
+package routing
+
+import (
+	"math"
+	"time"
+
+	"github.com/cryptomeow/lnd/routing/route"
+)
+
+// MissionControlOverride manually marks a node or a directed node pair as
+// failed or succeeded in mission control, regardless of the payment results
+// that were observed. The effect of the override decays over time, just like
+// the penalty of an observed failure.
+type MissionControlOverride struct {
+	// Node is the node that the override applies to.
+	Node route.Vertex
+
+	// ToNode restricts the override to the pair from Node to ToNode if
+	// non-nil. Otherwise the override applies to all pairs from and to
+	// Node.
+	ToNode *route.Vertex
+
+	// Success marks the node or pair as succeeded instead of failed.
+	Success bool
+
+	// HalfLife defines after how much time the override has lost half of
+	// its effect. If zero, the penalty half-life of mission control is
+	// used.
+	HalfLife time.Duration
+
+	// SetTime is the time at which the override was set.
+	SetTime time.Time
+}
+
+// missionControlOverrides holds the manual overrides of mission control. Note
+// that it isn't thread safe and synchronization needs to be enforced
+// externally.
+type missionControlOverrides struct {
+	// nodes holds the overrides that apply to all pairs of a node.
+	nodes map[route.Vertex]MissionControlOverride
+
+	// pairs holds the overrides that apply to a single directed pair.
+	pairs map[DirectedNodePair]MissionControlOverride
+}
+
+// newMissionControlOverrides instantiates an empty set of overrides.
+func newMissionControlOverrides() *missionControlOverrides {
+	return &missionControlOverrides{
+		nodes: make(map[route.Vertex]MissionControlOverride),
+		pairs: make(map[DirectedNodePair]MissionControlOverride),
+	}
+}
+
+// SetOverride sets a manual override for a node or node pair, replacing any
+// previous override for it. Overrides are kept in memory only, and are not
+// affected by ResetHistory.
+func (m *MissionControl) SetOverride(override MissionControlOverride) {
+	m.Lock()
+	defer m.Unlock()
+
+	if override.HalfLife == 0 {
+		override.HalfLife = m.cfg.PenaltyHalfLife
+	}
+	if override.SetTime.IsZero() {
+		override.SetTime = m.now()
+	}
+
+	if override.ToNode == nil {
+		m.overrides.nodes[override.Node] = override
+	} else {
+		pair := NewDirectedNodePair(override.Node, *override.ToNode)
+		m.overrides.pairs[pair] = override
+	}
+
+	log.Infof("Mission control override set: node=%v, to_node=%v, "+
+		"success=%v, half_life=%v", override.Node, override.ToNode,
+		override.Success, override.HalfLife)
+}
+
+// ClearOverride removes the manual override of a node, or of the pair from
+// node to toNode if toNode is non-nil. It returns false if there was no such
+// override.
+func (m *MissionControl) ClearOverride(node route.Vertex,
+	toNode *route.Vertex) bool {
+
+	m.Lock()
+	defer m.Unlock()
+
+	if toNode == nil {
+		_, ok := m.overrides.nodes[node]
+		delete(m.overrides.nodes, node)
+
+		return ok
+	}
+
+	pair := NewDirectedNodePair(node, *toNode)
+	_, ok := m.overrides.pairs[pair]
+	delete(m.overrides.pairs, pair)
+
+	return ok
+}
+
+// ClearOverrides removes all manual overrides.
+func (m *MissionControl) ClearOverrides() {
+	m.Lock()
+	defer m.Unlock()
+
+	m.overrides = newMissionControlOverrides()
+
+	log.Debugf("Mission control overrides cleared")
+}
+
+// GetOverrides returns all manual overrides.
+func (m *MissionControl) GetOverrides() []MissionControlOverride {
+	m.Lock()
+	defer m.Unlock()
+
+	overrides := make(
+		[]MissionControlOverride, 0,
+		len(m.overrides.nodes)+len(m.overrides.pairs),
+	)
+	for _, override := range m.overrides.nodes {
+		overrides = append(overrides, override)
+	}
+	for _, override := range m.overrides.pairs {
+		overrides = append(overrides, override)
+	}
+
+	return overrides
+}
+
+// applyOverrides adjusts the estimated probability of the pair from fromNode
+// to toNode with the overrides that apply to it. A fresh failure override
+// lowers the probability to zero and a fresh success override raises it to
+// the probability of a previous success. As the overrides decay, the
+// probability moves back to the estimate.
+func (m *MissionControl) applyOverrides(now time.Time, fromNode,
+	toNode route.Vertex, probability float64) float64 {
+
+	apply := func(override MissionControlOverride) {
+		age := now.Sub(override.SetTime)
+		exp := -age.Hours() / override.HalfLife.Hours()
+		weight := math.Pow(2, exp)
+
+		target := 0.0
+		if override.Success {
+			target = m.estimator.prevSuccessProbability
+		}
+
+		probability = weight*target + (1-weight)*probability
+	}
+
+	if override, ok := m.overrides.nodes[fromNode]; ok {
+		apply(override)
+	}
+	if override, ok := m.overrides.nodes[toNode]; ok {
+		apply(override)
+	}
+
+	pair := NewDirectedNodePair(fromNode, toNode)
+	if override, ok := m.overrides.pairs[pair]; ok {
+		apply(override)
+	}
+
+	return probability
+}
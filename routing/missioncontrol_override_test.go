@@ -0,0 +1,62 @@
+package routing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cryptomeow/lnd/lnwire"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMissionControlOverrides tests that manual overrides steer the
+// probability estimates of mission control and decay over time.
+func TestMissionControlOverrides(t *testing.T) {
+	ctx := createMcTestContext(t)
+	defer ctx.cleanup()
+
+	// A fresh failure override of the destination node prunes the pair.
+	ctx.mc.SetOverride(MissionControlOverride{
+		Node:     mcTestNode2,
+		HalfLife: time.Hour,
+	})
+	ctx.expectP(1000, 0)
+
+	// After the half-life, the probability is halfway back to the
+	// estimate.
+	ctx.now = ctx.now.Add(time.Hour)
+	ctx.expectP(1000, testAprioriHopProbability/2)
+
+	require.True(t, ctx.mc.ClearOverride(mcTestNode2, nil))
+	require.False(t, ctx.mc.ClearOverride(mcTestNode2, nil))
+	ctx.expectP(1000, testAprioriHopProbability)
+
+	// A success override of the pair raises the probability, even after
+	// a failure was observed. The override only applies in the direction
+	// of the pair. Without a half-life, the penalty half-life is used.
+	ctx.reportFailure(1000, lnwire.NewTemporaryChannelFailure(nil))
+	ctx.expectP(1000, 0)
+
+	toNode := mcTestNode2
+	ctx.mc.SetOverride(MissionControlOverride{
+		Node:    mcTestNode1,
+		ToNode:  &toNode,
+		Success: true,
+	})
+	ctx.expectP(1000, prevSuccessProbability)
+
+	reverseP := ctx.mc.GetProbability(mcTestNode2, mcTestNode1, 1000)
+	require.Equal(t, testAprioriHopProbability, reverseP)
+
+	overrides := ctx.mc.GetOverrides()
+	require.Len(t, overrides, 1)
+	require.Equal(t, testPenaltyHalfLife, overrides[0].HalfLife)
+	require.Equal(t, ctx.now, overrides[0].SetTime)
+
+	// Overrides are kept when the history is reset, but can be cleared.
+	require.NoError(t, ctx.mc.ResetHistory())
+	require.Len(t, ctx.mc.GetOverrides(), 1)
+
+	ctx.mc.ClearOverrides()
+	require.Empty(t, ctx.mc.GetOverrides())
+	ctx.expectP(1000, testAprioriHopProbability)
+}
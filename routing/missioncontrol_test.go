@@ -63,7 +63,9 @@ func createMcTestContext(t *testing.T) *mcTestContext {
 
 	ctx.dbPath = file.Name()
 
-	ctx.db, err = kvdb.Open(kvdb.BoltBackendName, ctx.dbPath, true)
+	ctx.db, err = kvdb.Open(
+		kvdb.BoltBackendName, ctx.dbPath, true, kvdb.DefaultDBTimeout,
+	)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -0,0 +1,195 @@
+package routing
+
+import (
+	"fmt"
+
+	"github.com/cryptomeow/lnd/lntypes"
+	"github.com/cryptomeow/lnd/lnwire"
+	"github.com/cryptomeow/lnd/routing/route"
+)
+
+// InterceptedPayment describes an outgoing payment that is handed to the
+// payment interceptor before any route is attempted.
+type InterceptedPayment struct {
+	// PaymentHash is the hash of the payment.
+	PaymentHash lntypes.Hash
+
+	// Target is the final destination of the payment.
+	Target route.Vertex
+
+	// Amount is the amount paid to the final destination.
+	Amount lnwire.MilliSatoshi
+
+	// FeeLimit is the maximum fee the payment may pay.
+	FeeLimit lnwire.MilliSatoshi
+
+	// CltvLimit is the maximum time lock of the payment. Zero means no
+	// limit.
+	CltvLimit uint32
+
+	// OutgoingChannelIDs is the list of channels that are allowed for the
+	// first hop. If empty, any channel may be used.
+	OutgoingChannelIDs []uint64
+
+	// PaymentRequest is the payment request the payment completes, if
+	// any.
+	PaymentRequest []byte
+
+	// Route is the route the payment is sent along if the caller chose it,
+	// otherwise nil. The fee limit, cltv limit and outgoing channels of
+	// such a payment are taken from the route.
+	Route *route.Route
+}
+
+// PaymentModification describes how the payment interceptor modifies an
+// outgoing payment. Unset fields leave the payment unchanged.
+type PaymentModification struct {
+	// FeeLimit replaces the fee limit of the payment if non-nil.
+	FeeLimit *lnwire.MilliSatoshi
+
+	// CltvLimit replaces the cltv limit of the payment if non-nil.
+	CltvLimit *uint32
+
+	// OutgoingChannelIDs replaces the list of channels that are allowed
+	// for the first hop if non-empty.
+	OutgoingChannelIDs []uint64
+}
+
+// PaymentInterceptor is called for every outgoing payment, and every attempt
+// along a route chosen by the caller, before anything is sent. It can reject
+// the payment by returning an error, or restrict it by returning a
+// modification. Payments along a route chosen by the caller can't be modified,
+// so they are rejected if the route doesn't satisfy the modification.
+type PaymentInterceptor func(*InterceptedPayment) (*PaymentModification,
+	error)
+
+// ErrPaymentRejected is returned when the payment interceptor rejects an
+// outgoing payment.
+type ErrPaymentRejected struct {
+	reason error
+}
+
+// Error returns a human readable description of the rejection.
+func (e *ErrPaymentRejected) Error() string {
+	return fmt.Sprintf("payment rejected by interceptor: %v", e.reason)
+}
+
+// SetPaymentInterceptor sets the interceptor that is called for every
+// outgoing payment. A nil interceptor lets all payments pass unchanged.
+func (r *ChannelRouter) SetPaymentInterceptor(interceptor PaymentInterceptor) {
+	r.paymentInterceptorMtx.Lock()
+	defer r.paymentInterceptorMtx.Unlock()
+
+	r.paymentInterceptor = interceptor
+}
+
+// callPaymentInterceptor hands the payment to the payment interceptor, if any.
+func (r *ChannelRouter) callPaymentInterceptor(
+	payment *InterceptedPayment) (*PaymentModification, error) {
+
+	r.paymentInterceptorMtx.RLock()
+	interceptor := r.paymentInterceptor
+	r.paymentInterceptorMtx.RUnlock()
+
+	if interceptor == nil {
+		return nil, nil
+	}
+
+	mod, err := interceptor(payment)
+	if err != nil {
+		log.Infof("Payment %v rejected by interceptor: %v",
+			payment.PaymentHash, err)
+
+		return nil, &ErrPaymentRejected{reason: err}
+	}
+
+	return mod, nil
+}
+
+// interceptPayment hands the payment to the payment interceptor and applies
+// its modification to the payment.
+func (r *ChannelRouter) interceptPayment(payment *LightningPayment) error {
+	mod, err := r.callPaymentInterceptor(&InterceptedPayment{
+		PaymentHash:        payment.PaymentHash,
+		Target:             payment.Target,
+		Amount:             payment.Amount,
+		FeeLimit:           payment.FeeLimit,
+		CltvLimit:          payment.CltvLimit,
+		OutgoingChannelIDs: payment.OutgoingChannelIDs,
+		PaymentRequest:     payment.PaymentRequest,
+	})
+	if err != nil || mod == nil {
+		return err
+	}
+
+	if mod.FeeLimit != nil {
+		payment.FeeLimit = *mod.FeeLimit
+	}
+	if mod.CltvLimit != nil {
+		payment.CltvLimit = *mod.CltvLimit
+	}
+	if len(mod.OutgoingChannelIDs) != 0 {
+		payment.OutgoingChannelIDs = mod.OutgoingChannelIDs
+	}
+
+	log.Debugf("Payment %v modified by interceptor: fee_limit=%v, "+
+		"cltv_limit=%v, outgoing_chan_ids=%v", payment.PaymentHash,
+		payment.FeeLimit, payment.CltvLimit, payment.OutgoingChannelIDs)
+
+	return nil
+}
+
+// interceptRoute hands the payment along the given route to the payment
+// interceptor, and checks that the route satisfies its modification.
+func (r *ChannelRouter) interceptRoute(hash lntypes.Hash,
+	rt *route.Route) error {
+
+	firstHopChanID := rt.Hops[0].ChannelID
+	mod, err := r.callPaymentInterceptor(&InterceptedPayment{
+		PaymentHash:        hash,
+		Target:             rt.Hops[len(rt.Hops)-1].PubKeyBytes,
+		Amount:             rt.ReceiverAmt(),
+		FeeLimit:           rt.TotalFees(),
+		CltvLimit:          rt.TotalTimeLock,
+		OutgoingChannelIDs: []uint64{firstHopChanID},
+		Route:              rt,
+	})
+	if err != nil || mod == nil {
+		return err
+	}
+
+	switch {
+	case mod.FeeLimit != nil && rt.TotalFees() > *mod.FeeLimit:
+		err = fmt.Errorf("route fee %v exceeds limit %v",
+			rt.TotalFees(), *mod.FeeLimit)
+
+	case mod.CltvLimit != nil && rt.TotalTimeLock > *mod.CltvLimit:
+		err = fmt.Errorf("route time lock %v exceeds limit %v",
+			rt.TotalTimeLock, *mod.CltvLimit)
+
+	case len(mod.OutgoingChannelIDs) != 0 &&
+		!containsChanID(mod.OutgoingChannelIDs, firstHopChanID):
+
+		err = fmt.Errorf("outgoing channel %v not allowed",
+			firstHopChanID)
+	}
+	if err != nil {
+		log.Infof("Payment %v rejected by interceptor: %v", hash, err)
+
+		return &ErrPaymentRejected{reason: err}
+	}
+
+	return nil
+}
+
+// containsChanID returns true if the channel ID is contained in the given
+// slice.
+func containsChanID(chanIDs []uint64, chanID uint64) bool {
+	for _, id := range chanIDs {
+		if id == chanID {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,99 @@
+package routing
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cryptomeow/lnd/lntypes"
+	"github.com/cryptomeow/lnd/lnwire"
+	"github.com/cryptomeow/lnd/routing/route"
+	"github.com/stretchr/testify/require"
+)
+
+// TestInterceptPayment asserts that the payment interceptor can reject and
+// restrict payments.
+func TestInterceptPayment(t *testing.T) {
+	t.Parallel()
+
+	r := &ChannelRouter{}
+	payment := &LightningPayment{
+		Target:   route.Vertex{1},
+		Amount:   100000,
+		FeeLimit: 1000,
+	}
+
+	// Without an interceptor, payments pass unchanged.
+	require.NoError(t, r.interceptPayment(payment))
+	require.Equal(t, lnwire.MilliSatoshi(1000), payment.FeeLimit)
+
+	feeLimit := lnwire.MilliSatoshi(500)
+	r.SetPaymentInterceptor(func(p *InterceptedPayment) (
+		*PaymentModification, error) {
+
+		if p.Amount > 200000 {
+			return nil, errors.New("amount too large")
+		}
+
+		return &PaymentModification{
+			FeeLimit:           &feeLimit,
+			OutgoingChannelIDs: []uint64{7},
+		}, nil
+	})
+
+	require.NoError(t, r.interceptPayment(payment))
+	require.Equal(t, feeLimit, payment.FeeLimit)
+	require.Equal(t, []uint64{7}, payment.OutgoingChannelIDs)
+	require.Zero(t, payment.CltvLimit)
+
+	payment.Amount = 300000
+	err := r.interceptPayment(payment)
+	require.IsType(t, &ErrPaymentRejected{}, err)
+}
+
+// TestInterceptRoute asserts that payments along a given route are rejected if
+// the route doesn't satisfy the modification of the payment interceptor.
+func TestInterceptRoute(t *testing.T) {
+	t.Parallel()
+
+	feeLimit := lnwire.MilliSatoshi(500)
+	r := &ChannelRouter{}
+	r.SetPaymentInterceptor(func(p *InterceptedPayment) (
+		*PaymentModification, error) {
+
+		require.NotNil(t, p.Route)
+
+		return &PaymentModification{
+			FeeLimit:           &feeLimit,
+			OutgoingChannelIDs: []uint64{7},
+		}, nil
+	})
+
+	newRoute := func(firstHopChanID uint64,
+		fee lnwire.MilliSatoshi) *route.Route {
+
+		return &route.Route{
+			TotalAmount: 100000 + fee,
+			Hops: []*route.Hop{
+				{
+					ChannelID:        firstHopChanID,
+					AmtToForward:     100000,
+					OutgoingTimeLock: 100,
+				},
+				{
+					ChannelID:        8,
+					AmtToForward:     100000,
+					OutgoingTimeLock: 100,
+				},
+			},
+		}
+	}
+
+	hash := lntypes.Hash{1}
+	require.NoError(t, r.interceptRoute(hash, newRoute(7, 400)))
+
+	err := r.interceptRoute(hash, newRoute(7, 600))
+	require.IsType(t, &ErrPaymentRejected{}, err)
+
+	err = r.interceptRoute(hash, newRoute(9, 400))
+	require.IsType(t, &ErrPaymentRejected{}, err)
+}
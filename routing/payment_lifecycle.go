@@ -378,6 +378,7 @@ func (p *shardHandler) launchShard(rt *route.Route) (*channeldb.HTLCAttemptInfo,
 	if err != nil {
 		return nil, nil, err
 	}
+	p.router.cfg.PeerExposure.addAttempt(attempt)
 
 	// Now that the attempt is created and checkpointed to the DB, we send
 	// it.
@@ -574,6 +575,7 @@ func (p *shardHandler) collectResult(attempt *channeldb.HTLCAttemptInfo) (
 		log.Errorf("Unable to succeed payment attempt: %v", err)
 		return nil, err
 	}
+	p.router.cfg.PeerExposure.removeAttempt(attempt.AttemptID)
 
 	return &shardResult{
 		attempt: htlcAttempt,
@@ -706,10 +708,16 @@ func (p *shardHandler) failAttempt(attempt *channeldb.HTLCAttemptInfo,
 		p.router.cfg.Clock.Now(),
 	)
 
-	return p.router.cfg.Control.FailAttempt(
+	htlcAttempt, err := p.router.cfg.Control.FailAttempt(
 		p.paymentHash, attempt.AttemptID,
 		failInfo,
 	)
+	if err != nil {
+		return nil, err
+	}
+	p.router.cfg.PeerExposure.removeAttempt(attempt.AttemptID)
+
+	return htlcAttempt, nil
 }
 
 // marshallError marshall an error as received from the switch to a structure
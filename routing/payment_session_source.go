@@ -38,6 +38,11 @@ type SessionSource struct {
 	// PathFindingConfig defines global parameters that control the
 	// trade-off in path finding between fees and probabiity.
 	PathFindingConfig PathFindingConfig
+
+	// PeerExposure limits the total amount of payment attempts that are in
+	// flight through a single first hop peer. If nil, the exposure isn't
+	// limited.
+	PeerExposure *PeerExposure
 }
 
 // getRoutingGraph returns a routing graph and a clean-up function for
@@ -70,7 +75,21 @@ func (m *SessionSource) NewPaymentSession(p *LightningPayment) (
 	getBandwidthHints := func() (map[uint64]lnwire.MilliSatoshi,
 		error) {
 
-		return generateBandwidthHints(sourceNode, m.QueryBandwidth)
+		bandwidthHints, err := generateBandwidthHints(
+			sourceNode, m.QueryBandwidth,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		// Cap the bandwidth of the channels to peers that already have
+		// a lot of our payments in flight.
+		err = m.PeerExposure.limitBandwidth(sourceNode, bandwidthHints)
+		if err != nil {
+			return nil, err
+		}
+
+		return bandwidthHints, nil
 	}
 
 	session, err := newPaymentSession(
@@ -0,0 +1,163 @@
+package routing
+
+import (
+	"sync"
+
+	"github.com/cryptomeow/lnd/channeldb"
+	"github.com/cryptomeow/lnd/channeldb/kvdb"
+	"github.com/cryptomeow/lnd/lnwire"
+	"github.com/cryptomeow/lnd/routing/route"
+)
+
+// exposedAttempt describes a payment attempt that counts towards the exposure
+// to its first hop peer.
+type exposedAttempt struct {
+	// peer is the first hop peer of the attempt.
+	peer route.Vertex
+
+	// amt is the amount of the htlc that is offered to the peer.
+	amt lnwire.MilliSatoshi
+}
+
+// PeerExposure limits the total amount of payment attempts that are in flight
+// through a single first hop peer. This prevents a single unreliable peer from
+// locking up all outbound liquidity in stuck htlcs. The limit is enforced by
+// capping the bandwidth of the channels with a peer during path finding at the
+// amount that may still be offered to it.
+//
+// NOTE: A nil PeerExposure doesn't track or limit anything.
+type PeerExposure struct {
+	// max is the maximum total amount in flight through a single peer.
+	max lnwire.MilliSatoshi
+
+	// attempts holds the attempts that are currently in flight, keyed by
+	// attempt ID.
+	attempts map[uint64]exposedAttempt
+
+	// inFlight holds the total amount in flight through each peer.
+	inFlight map[route.Vertex]lnwire.MilliSatoshi
+
+	mtx sync.Mutex
+}
+
+// NewPeerExposure returns a new tracker that limits the amount in flight
+// through a single peer to the given maximum.
+func NewPeerExposure(max lnwire.MilliSatoshi) *PeerExposure {
+	return &PeerExposure{
+		max:      max,
+		attempts: make(map[uint64]exposedAttempt),
+		inFlight: make(map[route.Vertex]lnwire.MilliSatoshi),
+	}
+}
+
+// addAttempt records that the given attempt is in flight.
+func (e *PeerExposure) addAttempt(attempt *channeldb.HTLCAttemptInfo) {
+	if e == nil || len(attempt.Route.Hops) == 0 {
+		return
+	}
+
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	if _, ok := e.attempts[attempt.AttemptID]; ok {
+		return
+	}
+
+	exposed := exposedAttempt{
+		peer: attempt.Route.Hops[0].PubKeyBytes,
+		amt:  attempt.Route.TotalAmount,
+	}
+	e.attempts[attempt.AttemptID] = exposed
+	e.inFlight[exposed.peer] += exposed.amt
+}
+
+// removeAttempt records that the attempt with the given ID was resolved.
+func (e *PeerExposure) removeAttempt(attemptID uint64) {
+	if e == nil {
+		return
+	}
+
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	exposed, ok := e.attempts[attemptID]
+	if !ok {
+		return
+	}
+	delete(e.attempts, attemptID)
+
+	e.inFlight[exposed.peer] -= exposed.amt
+	if e.inFlight[exposed.peer] == 0 {
+		delete(e.inFlight, exposed.peer)
+	}
+}
+
+// amtInFlight returns the total amount of payment attempts that are currently
+// in flight through the given peer.
+func (e *PeerExposure) amtInFlight(peer route.Vertex) lnwire.MilliSatoshi {
+	if e == nil {
+		return 0
+	}
+
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	return e.inFlight[peer]
+}
+
+// available returns the amount that may still be offered to the given peer.
+//
+// NOTE: This method must be called with the mutex held.
+func (e *PeerExposure) available(peer route.Vertex) lnwire.MilliSatoshi {
+	inFlight := e.inFlight[peer]
+	if inFlight >= e.max {
+		return 0
+	}
+
+	return e.max - inFlight
+}
+
+// limitBandwidth caps the bandwidth hints of the channels of the source node
+// at the amount that may still be offered to the peer of each channel.
+func (e *PeerExposure) limitBandwidth(sourceNode *channeldb.LightningNode,
+	bandwidthHints map[uint64]lnwire.MilliSatoshi) error {
+
+	if e == nil || e.max == 0 {
+		return nil
+	}
+
+	// Determine the peer of each of the local channels first, so that
+	// the graph isn't read while holding the mutex.
+	chanPeers := make(map[uint64]route.Vertex)
+	err := sourceNode.ForEachChannel(nil, func(tx kvdb.RTx,
+		edgeInfo *channeldb.ChannelEdgeInfo,
+		_, _ *channeldb.ChannelEdgePolicy) error {
+
+		peer := route.Vertex(edgeInfo.NodeKey1Bytes)
+		if peer == sourceNode.PubKeyBytes {
+			peer = edgeInfo.NodeKey2Bytes
+		}
+		chanPeers[edgeInfo.ChannelID] = peer
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	for chanID, peer := range chanPeers {
+		bandwidth, ok := bandwidthHints[chanID]
+		if !ok {
+			continue
+		}
+
+		if available := e.available(peer); bandwidth > available {
+			bandwidthHints[chanID] = available
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package routing
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/cryptomeow/lnd/channeldb"
+	"github.com/cryptomeow/lnd/lnwire"
+	"github.com/cryptomeow/lnd/routing/route"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPeerExposure tests that the bandwidth of the channels to a peer is capped
+// at the amount that may still be offered to it.
+func TestPeerExposure(t *testing.T) {
+	t.Parallel()
+
+	chanCapSat := btcutil.Amount(100000)
+	policy := &testChannelPolicy{
+		Expiry:  144,
+		MinHTLC: 1,
+		MaxHTLC: lnwire.NewMSatFromSatoshis(chanCapSat),
+	}
+	testChannels := []*testChannel{
+		symmetricTestChannel("a", "b", chanCapSat, policy, 1),
+		symmetricTestChannel("a", "b", chanCapSat, policy, 2),
+		symmetricTestChannel("a", "c", chanCapSat, policy, 3),
+	}
+
+	testGraph, err := createTestGraphFromChannels(testChannels, "a")
+	require.NoError(t, err)
+	defer testGraph.cleanUp()
+
+	sourceNode, err := testGraph.graph.SourceNode()
+	require.NoError(t, err)
+
+	const maxExposure = 100000
+	exposure := NewPeerExposure(maxExposure)
+
+	limitBandwidth := func() map[uint64]lnwire.MilliSatoshi {
+		hints := map[uint64]lnwire.MilliSatoshi{
+			1: 150000,
+			2: 20000,
+			3: 150000,
+		}
+		require.NoError(t, exposure.limitBandwidth(sourceNode, hints))
+
+		return hints
+	}
+
+	// Without any attempts in flight, the bandwidth is capped at the
+	// maximum exposure.
+	require.Equal(t, map[uint64]lnwire.MilliSatoshi{
+		1: maxExposure,
+		2: 20000,
+		3: maxExposure,
+	}, limitBandwidth())
+
+	// Add two attempts through b. The exposure is shared by all channels
+	// with b.
+	attempt := func(id uint64, peer string,
+		amt lnwire.MilliSatoshi) *channeldb.HTLCAttemptInfo {
+
+		return &channeldb.HTLCAttemptInfo{
+			AttemptID: id,
+			Route: route.Route{
+				TotalAmount: amt,
+				Hops: []*route.Hop{
+					{PubKeyBytes: testGraph.aliasMap[peer]},
+				},
+			},
+		}
+	}
+	exposure.addAttempt(attempt(1, "b", 60000))
+	exposure.addAttempt(attempt(2, "b", 30000))
+
+	// Adding an attempt twice must not count it twice.
+	exposure.addAttempt(attempt(2, "b", 30000))
+	require.Equal(
+		t, lnwire.MilliSatoshi(90000),
+		exposure.amtInFlight(testGraph.aliasMap["b"]),
+	)
+
+	require.Equal(t, map[uint64]lnwire.MilliSatoshi{
+		1: 10000,
+		2: 10000,
+		3: maxExposure,
+	}, limitBandwidth())
+
+	// Exceeding the maximum, which may happen if the limit is lowered
+	// across restarts, leaves no bandwidth at all.
+	exposure.addAttempt(attempt(3, "b", 20000))
+	require.Equal(t, map[uint64]lnwire.MilliSatoshi{
+		1: 0,
+		2: 0,
+		3: maxExposure,
+	}, limitBandwidth())
+
+	// Resolving the attempts frees up the exposure again. Unknown attempts
+	// are ignored.
+	exposure.removeAttempt(1)
+	exposure.removeAttempt(3)
+	exposure.removeAttempt(4)
+	require.Equal(t, map[uint64]lnwire.MilliSatoshi{
+		1: 70000,
+		2: 20000,
+		3: maxExposure,
+	}, limitBandwidth())
+
+	exposure.removeAttempt(2)
+	require.Zero(t, exposure.amtInFlight(testGraph.aliasMap["b"]))
+
+	// A nil tracker doesn't limit anything.
+	var noExposure *PeerExposure
+	noExposure.addAttempt(attempt(5, "b", 60000))
+	hints := map[uint64]lnwire.MilliSatoshi{1: 150000}
+	require.NoError(t, noExposure.limitBandwidth(sourceNode, hints))
+	require.Equal(t, lnwire.MilliSatoshi(150000), hints[1])
+}
@@ -73,6 +73,10 @@ var (
 	// ErrRouterShuttingDown is returned if the router is in the process of
 	// shutting down.
 	ErrRouterShuttingDown = fmt.Errorf("router shutting down")
+
+	// DefaultNumValidationWorkers is the default number of network
+	// updates that are validated concurrently.
+	DefaultNumValidationWorkers = runtime.NumCPU() * 4
 )
 
 // ChannelGraphSource represents the source of information about the topology
@@ -305,6 +309,21 @@ type Config struct {
 	// should examine the channel graph to garbage collect zombie channels.
 	GraphPruneInterval time.Duration
 
+	// NumValidationWorkers is the maximum number of network updates that
+	// are validated concurrently. Validating a channel announcement
+	// requires retrieving its funding output from the chain backend, so
+	// this also bounds the number of concurrent requests to the backend.
+	// Updates that depend on each other, such as a channel update and the
+	// announcement of its channel, are still processed in the order they
+	// were received. If zero, DefaultNumValidationWorkers is used.
+	NumValidationWorkers int
+
+	// CompactZombies indicates whether the entries of the zombie index
+	// should be compacted after each round of zombie pruning. Compacted
+	// entries still prevent zombie channels from being downloaded again
+	// through gossip, but take up less space on disk.
+	CompactZombies bool
+
 	// QueryBandwidth is a method that allows the router to query the lower
 	// link layer to determine the up to date available bandwidth at a
 	// prospective link to be traversed. If the  link isn't available, then
@@ -327,6 +346,12 @@ type Config struct {
 	// PathFindingConfig defines global path finding parameters.
 	PathFindingConfig PathFindingConfig
 
+	// PeerExposure tracks the payment attempts that are in flight through
+	// each first hop peer. It must be shared with the session source that
+	// enforces the limit during path finding. If nil, the exposure isn't
+	// limited.
+	PeerExposure *PeerExposure
+
 	// Clock is mockable time provider.
 	Clock clock.Clock
 }
@@ -401,6 +426,15 @@ type ChannelRouter struct {
 	// consistency between the various database accesses.
 	channelEdgeMtx *multimutex.Mutex
 
+	// fundingBlocks deduplicates concurrent requests for the funding
+	// blocks of channels being validated.
+	fundingBlocks *blockFetchGroup
+
+	// zombiePruneMtx ensures that only one round of zombie pruning is
+	// executed at a time, as pruning can be triggered both periodically
+	// and manually.
+	zombiePruneMtx sync.Mutex
+
 	// statTicker is a resumable ticker that logs the router's progress as
 	// it discovers channels or receives updates.
 	statTicker ticker.Ticker
@@ -409,6 +443,11 @@ type ChannelRouter struct {
 	// announcements over a window of defaultStatInterval.
 	stats *routerStats
 
+	// paymentInterceptor is called for every outgoing payment before
+	// anything is sent. It is protected by the paymentInterceptorMtx.
+	paymentInterceptorMtx sync.RWMutex
+	paymentInterceptor    PaymentInterceptor
+
 	sync.RWMutex
 
 	quit chan struct{}
@@ -442,6 +481,7 @@ func New(cfg Config) (*ChannelRouter, error) {
 		stats:             new(routerStats),
 		quit:              make(chan struct{}),
 	}
+	r.fundingBlocks = newBlockFetchGroup(r.fetchBlock)
 
 	return r, nil
 }
@@ -486,7 +526,7 @@ func (r *ChannelRouter) Start() error {
 	// channels from the graph based on their spentness, but whether they
 	// are considered zombies or not.
 	if r.cfg.AssumeChannelValid {
-		if err := r.pruneZombieChans(); err != nil {
+		if _, err := r.pruneZombieChans(false); err != nil {
 			return err
 		}
 	} else {
@@ -533,7 +573,7 @@ func (r *ChannelRouter) Start() error {
 		// Finally, before we proceed, we'll prune any unconnected nodes
 		// from the graph in order to ensure we maintain a tight graph
 		// of "useful" nodes.
-		err = r.cfg.Graph.PruneGraphNodes()
+		_, err = r.cfg.Graph.PruneGraphNodes()
 		if err != nil && err != channeldb.ErrGraphNodesNotFound {
 			return err
 		}
@@ -562,6 +602,13 @@ func (r *ChannelRouter) Start() error {
 
 		for _, a := range payment.HTLCs {
 			toKeep[a.AttemptID] = struct{}{}
+
+			// Attempts that are still in flight count towards the
+			// exposure to their first hop peer until they are
+			// resolved.
+			if a.Settle == nil && a.Failure == nil {
+				r.cfg.PeerExposure.addAttempt(&a.HTLCAttemptInfo)
+			}
 		}
 	}
 
@@ -766,13 +813,36 @@ func (r *ChannelRouter) syncGraphWithChain() error {
 	return nil
 }
 
+// ZombiePruneResult summarizes the changes made to the graph by a round of
+// zombie pruning.
+type ZombiePruneResult struct {
+	// NumChannelsPruned is the number of zombie channels that were removed
+	// from the graph.
+	NumChannelsPruned int
+
+	// NumNodesPruned is the number of nodes that were removed from the
+	// graph as they no longer had any channels.
+	NumNodesPruned int
+
+	// NumZombiesCompacted is the number of entries of the zombie index that
+	// were compacted.
+	NumZombiesCompacted int
+}
+
 // pruneZombieChans is a method that will be called periodically to prune out
 // any "zombie" channels. We consider channels zombies if *both* edges haven't
 // been updated since our zombie horizon. If AssumeChannelValid is present,
 // we'll also consider channels zombies if *both* edges are disabled. This
 // usually signals that a channel has been closed on-chain. We do this
-// periodically to keep a healthy, lively routing table.
-func (r *ChannelRouter) pruneZombieChans() error {
+// periodically to keep a healthy, lively routing table. If compact is true or
+// CompactZombies is set, the entries of the zombie index are compacted
+// afterwards.
+func (r *ChannelRouter) pruneZombieChans(compact bool) (*ZombiePruneResult,
+	error) {
+
+	r.zombiePruneMtx.Lock()
+	defer r.zombiePruneMtx.Unlock()
+
 	chansToPrune := make(map[uint64]struct{})
 	chanExpiry := r.cfg.ChannelPruneExpiry
 
@@ -843,14 +913,14 @@ func (r *ChannelRouter) pruneZombieChans() error {
 	if r.cfg.AssumeChannelValid {
 		disabledChanIDs, err := r.cfg.Graph.DisabledChannelIDs()
 		if err != nil {
-			return fmt.Errorf("unable to get disabled channels ids "+
-				"chans: %v", err)
+			return nil, fmt.Errorf("unable to get disabled "+
+				"channels ids chans: %v", err)
 		}
 
 		disabledEdges, err := r.cfg.Graph.FetchChanInfos(disabledChanIDs)
 		if err != nil {
-			return fmt.Errorf("unable to fetch disabled channels edges "+
-				"chans: %v", err)
+			return nil, fmt.Errorf("unable to fetch disabled "+
+				"channels edges chans: %v", err)
 		}
 
 		// Ensuring we won't prune our own channel from the graph.
@@ -865,8 +935,8 @@ func (r *ChannelRouter) pruneZombieChans() error {
 	endTime := time.Now().Add(-1 * chanExpiry)
 	oldEdges, err := r.cfg.Graph.ChanUpdatesInHorizon(startTime, endTime)
 	if err != nil {
-		return fmt.Errorf("unable to fetch expired channel updates "+
-			"chans: %v", err)
+		return nil, fmt.Errorf("unable to fetch expired channel "+
+			"updates chans: %v", err)
 	}
 
 	for _, u := range oldEdges {
@@ -883,17 +953,46 @@ func (r *ChannelRouter) pruneZombieChans() error {
 		log.Tracef("Pruning zombie channel with ChannelID(%v)", chanID)
 	}
 	if err := r.cfg.Graph.DeleteChannelEdges(toPrune...); err != nil {
-		return fmt.Errorf("unable to delete zombie channels: %v", err)
+		return nil, fmt.Errorf("unable to delete zombie channels: %v",
+			err)
+	}
+
+	result := &ZombiePruneResult{
+		NumChannelsPruned: len(toPrune),
 	}
 
 	// With the channels pruned, we'll also attempt to prune any nodes that
 	// were a part of them.
-	err = r.cfg.Graph.PruneGraphNodes()
+	result.NumNodesPruned, err = r.cfg.Graph.PruneGraphNodes()
 	if err != nil && err != channeldb.ErrGraphNodesNotFound {
-		return fmt.Errorf("unable to prune graph nodes: %v", err)
+		return nil, fmt.Errorf("unable to prune graph nodes: %v", err)
 	}
 
-	return nil
+	// Finally, we'll strip the node keys from the zombie index if
+	// requested, so the index only holds the minimum amount of data needed
+	// to prevent the zombies from being downloaded again.
+	if compact || r.cfg.CompactZombies {
+		result.NumZombiesCompacted, err = r.cfg.Graph.CompactZombieIndex()
+		if err != nil {
+			return nil, fmt.Errorf("unable to compact zombie "+
+				"index: %v", err)
+		}
+
+		log.Infof("Compacted %v zombie index entries",
+			result.NumZombiesCompacted)
+	}
+
+	return result, nil
+}
+
+// PruneZombieChans prunes all zombie channels and unconnected nodes from the
+// graph, using the same policy as the periodic graph pruning. If compact is
+// true, the zombie index is compacted afterwards, regardless of the
+// CompactZombies setting.
+func (r *ChannelRouter) PruneZombieChans(compact bool) (*ZombiePruneResult,
+	error) {
+
+	return r.pruneZombieChans(compact)
 }
 
 // networkHandler is the primary goroutine for the ChannelRouter. The roles of
@@ -914,7 +1013,11 @@ func (r *ChannelRouter) networkHandler() {
 
 	// We'll use this validation barrier to ensure that we process all jobs
 	// in the proper order during parallel validation.
-	validationBarrier := NewValidationBarrier(runtime.NumCPU()*4, r.quit)
+	numWorkers := r.cfg.NumValidationWorkers
+	if numWorkers <= 0 {
+		numWorkers = DefaultNumValidationWorkers
+	}
+	validationBarrier := NewValidationBarrier(numWorkers, r.quit)
 
 	for {
 
@@ -1113,7 +1216,7 @@ func (r *ChannelRouter) networkHandler() {
 		// state of the known graph to filter out any zombie channels
 		// for pruning.
 		case <-graphPruneTicker.C:
-			if err := r.pruneZombieChans(); err != nil {
+			if _, err := r.pruneZombieChans(false); err != nil {
 				log.Errorf("Unable to prune zombies: %v", err)
 			}
 
@@ -1408,6 +1511,18 @@ func (r *ChannelRouter) processUpdate(msg interface{}) error {
 	return nil
 }
 
+// fetchBlock fetches the block at the given height from the chain backend.
+func (r *ChannelRouter) fetchBlock(height uint32) (*wire.MsgBlock, error) {
+	// First fetch the block hash by the block number, then use that hash
+	// to fetch the block itself.
+	blockHash, err := r.cfg.Chain.GetBlockHash(int64(height))
+	if err != nil {
+		return nil, err
+	}
+
+	return r.cfg.Chain.GetBlock(blockHash)
+}
+
 // fetchFundingTx returns the funding transaction identified by the passed
 // short channel ID.
 //
@@ -1416,14 +1531,9 @@ func (r *ChannelRouter) processUpdate(msg interface{}) error {
 func (r *ChannelRouter) fetchFundingTx(
 	chanID *lnwire.ShortChannelID) (*wire.MsgTx, error) {
 
-	// First fetch the block hash by the block number encoded, then use
-	// that hash to fetch the block itself.
-	blockNum := int64(chanID.BlockHeight)
-	blockHash, err := r.cfg.Chain.GetBlockHash(blockNum)
-	if err != nil {
-		return nil, err
-	}
-	fundingBlock, err := r.cfg.Chain.GetBlock(blockHash)
+	// Fetch the block by the block number encoded. Concurrent requests
+	// for the same block are served by a single fetch.
+	fundingBlock, err := r.fundingBlocks.FetchBlock(chanID.BlockHeight)
 	if err != nil {
 		return nil, err
 	}
@@ -1681,6 +1791,11 @@ type LightningPayment struct {
 	// MaxParts is the maximum number of partial payments that may be used
 	// to complete the full amount.
 	MaxParts uint32
+
+	// IdempotencyKey is an optional key chosen by the caller that is stored
+	// with the payment. Initiating another payment with the same key fails,
+	// which allows a request to be retried safely.
+	IdempotencyKey []byte
 }
 
 // SendPayment attempts to send a payment as described within the passed
@@ -1766,6 +1881,12 @@ func spewPayment(payment *LightningPayment) logClosure {
 func (r *ChannelRouter) preparePayment(payment *LightningPayment) (
 	PaymentSession, error) {
 
+	// Give the payment interceptor the chance to reject or restrict the
+	// payment before anything is recorded.
+	if err := r.interceptPayment(payment); err != nil {
+		return nil, err
+	}
+
 	// Before starting the HTLC routing attempt, we'll create a fresh
 	// payment session which will report our errors back to mission
 	// control.
@@ -1783,6 +1904,7 @@ func (r *ChannelRouter) preparePayment(payment *LightningPayment) (
 		Value:          payment.Amount,
 		CreationTime:   r.cfg.Clock.Now(),
 		PaymentRequest: payment.PaymentRequest,
+		IdempotencyKey: payment.IdempotencyKey,
 	}
 
 	err = r.cfg.Control.InitPayment(payment.PaymentHash, info)
@@ -1801,6 +1923,41 @@ func (r *ChannelRouter) preparePayment(payment *LightningPayment) (
 func (r *ChannelRouter) SendToRoute(hash lntypes.Hash, rt *route.Route) (
 	*channeldb.HTLCAttempt, error) {
 
+	return r.sendToRoute(hash, rt, false)
+}
+
+// SendToRouteSkipTempErr sends a payment attempt like SendToRoute, but only
+// marks the payment as failed if the attempt fails with a terminal error. After
+// a temporary failure the payment stays in flight, so that the caller can send
+// further attempts or shards of a multi-path payment for the same hash, and
+// decide when to give up using FailPayment.
+func (r *ChannelRouter) SendToRouteSkipTempErr(hash lntypes.Hash,
+	rt *route.Route) (*channeldb.HTLCAttempt, error) {
+
+	return r.sendToRoute(hash, rt, true)
+}
+
+// FailPayment marks an in-flight payment as failed, so that no further
+// attempts can be sent for it. Attempts that are still in flight resolve
+// normally, and the payment succeeds if one of them settles.
+func (r *ChannelRouter) FailPayment(hash lntypes.Hash) error {
+	log.Debugf("Failing payment %v on request", hash)
+
+	return r.cfg.Control.Fail(hash, channeldb.FailureReasonNoRoute)
+}
+
+// sendToRoute attempts to send a payment with the given hash through the
+// provided route. If skipTempErr is set, the payment is only marked as failed
+// if the attempt fails with a terminal error.
+func (r *ChannelRouter) sendToRoute(hash lntypes.Hash, rt *route.Route,
+	skipTempErr bool) (*channeldb.HTLCAttempt, error) {
+
+	// Give the payment interceptor the chance to reject the attempt
+	// before anything is recorded.
+	if err := r.interceptRoute(hash, rt); err != nil {
+		return nil, err
+	}
+
 	// Calculate amount paid to receiver.
 	amt := rt.ReceiverAmt()
 
@@ -1827,6 +1984,12 @@ func (r *ChannelRouter) SendToRoute(hash lntypes.Hash, rt *route.Route) (
 	// the database, we can go on to launch the shard.
 	case err == channeldb.ErrPaymentInFlight && mpp != nil:
 
+	// If temporary errors are skipped, the payment may still be in flight
+	// after a previous attempt failed, so we can launch another one. The
+	// control tower ensures that the attempts don't exceed the payment
+	// amount.
+	case err == channeldb.ErrPaymentInFlight && skipTempErr:
+
 	// Any other error is not tolerated.
 	case err != nil:
 		return nil, err
@@ -1848,9 +2011,10 @@ func (r *ChannelRouter) SendToRoute(hash lntypes.Hash, rt *route.Route) (
 	attempt, outcome, err := sh.launchShard(rt)
 
 	// With SendToRoute, it can happen that the route exceeds protocol
-	// constraints. Mark the payment as failed with an internal error.
-	if err == route.ErrMaxRouteHopsExceeded ||
-		err == sphinx.ErrMaxRoutingInfoSizeExceeded {
+	// constraints. Mark the payment as failed with an internal error,
+	// unless the caller may still send other attempts.
+	if !skipTempErr && (err == route.ErrMaxRouteHopsExceeded ||
+		err == sphinx.ErrMaxRoutingInfoSizeExceeded) {
 
 		log.Debugf("Invalid route provided for payment %x: %v",
 			hash, err)
@@ -1900,6 +2064,12 @@ func (r *ChannelRouter) SendToRoute(hash lntypes.Hash, rt *route.Route) (
 		attempt.AttemptID, &attempt.Route, shardError,
 	)
 	if reason == nil {
+		// Leave the payment in flight for the caller's next attempt
+		// if the error isn't terminal.
+		if skipTempErr {
+			return htlcAttempt, shardError
+		}
+
 		r := channeldb.FailureReasonNoRoute
 		reason = &r
 	}
@@ -2427,16 +2597,117 @@ func (e ErrNoChannel) Error() string {
 		"node %v (%v)", e.position, e.fromNode)
 }
 
+// HopConstraints restricts how a single hop of a route is built by
+// BuildRoute.
+type HopConstraints struct {
+	// ChannelID is the channel that must be taken to reach this hop. If
+	// zero, the channel is selected from all channels between the hop and
+	// its predecessor.
+	ChannelID uint64
+
+	// CltvDelta is the time lock delta this hop applies when forwarding.
+	// It can't be below the delta of the channel policy. For the final
+	// hop, it replaces the final cltv delta. If zero, the delta of the
+	// channel policy is used.
+	CltvDelta uint16
+
+	// CustomRecords are custom tlv records that are added to the payload
+	// of this hop.
+	CustomRecords record.CustomSet
+}
+
+// BuildRouteConstraints holds the optional constraints for BuildRoute.
+type BuildRouteConstraints struct {
+	// Hops holds the constraints for every hop of the route. If set, it
+	// must have the same length as the hop list.
+	Hops []HopConstraints
+
+	// AvoidNodes is a set of nodes that the route must not pass through.
+	AvoidNodes map[route.Vertex]struct{}
+
+	// AvoidChannels is a set of channels that must not be selected for
+	// the route.
+	AvoidChannels map[uint64]struct{}
+}
+
+// hop returns the constraints for the hop at the given position.
+func (c *BuildRouteConstraints) hop(i int) HopConstraints {
+	if c == nil || c.Hops == nil {
+		return HopConstraints{}
+	}
+
+	return c.Hops[i]
+}
+
+// validate checks the constraints against the hop list of the route.
+func (c *BuildRouteConstraints) validate(hops []route.Vertex) error {
+	if c == nil {
+		return nil
+	}
+
+	if c.Hops != nil && len(c.Hops) != len(hops) {
+		return fmt.Errorf("expected constraints for %v hops, got %v",
+			len(hops), len(c.Hops))
+	}
+
+	for i, hop := range hops {
+		if _, ok := c.AvoidNodes[hop]; ok {
+			return fmt.Errorf("hop %v (%v) is in the set of nodes "+
+				"to avoid", i, hop)
+		}
+
+		hopConstraints := c.hop(i)
+		if _, ok := c.AvoidChannels[hopConstraints.ChannelID]; ok {
+			return fmt.Errorf("channel %v of hop %v is in the set "+
+				"of channels to avoid", hopConstraints.ChannelID,
+				i)
+		}
+
+		err := hopConstraints.CustomRecords.Validate()
+		if err != nil {
+			return fmt.Errorf("invalid custom records for hop "+
+				"%v: %v", i, err)
+		}
+	}
+
+	return nil
+}
+
 // BuildRoute returns a fully specified route based on a list of pubkeys. If
 // amount is nil, the minimum routable amount is used. To force a specific
-// outgoing channel, use the outgoingChan parameter.
+// outgoing channel, use the outgoingChan parameter. The optional constraints
+// pin channels, time lock deltas and custom records per hop, and exclude
+// nodes and channels from the route.
 func (r *ChannelRouter) BuildRoute(amt *lnwire.MilliSatoshi,
-	hops []route.Vertex, outgoingChan *uint64,
-	finalCltvDelta int32) (*route.Route, error) {
+	hops []route.Vertex, outgoingChan *uint64, finalCltvDelta int32,
+	constraints *BuildRouteConstraints) (*route.Route, error) {
 
 	log.Tracef("BuildRoute called: hopsCount=%v, amt=%v",
 		len(hops), amt)
 
+	if len(hops) == 0 {
+		return nil, errors.New("no hops specified")
+	}
+
+	if err := constraints.validate(hops); err != nil {
+		return nil, err
+	}
+
+	// A channel pinned for the first hop acts as an outgoing channel
+	// restriction.
+	firstHopChan := constraints.hop(0).ChannelID
+	switch {
+	case firstHopChan == 0:
+
+	case outgoingChan != nil && *outgoingChan != firstHopChan:
+		return nil, fmt.Errorf("outgoing channel %v conflicts with "+
+			"channel %v pinned for the first hop", *outgoingChan,
+			firstHopChan)
+
+	default:
+		outgoingChan = &firstHopChan
+	}
+
 	var outgoingChans map[uint64]struct{}
 	if outgoingChan != nil {
 		outgoingChans = map[uint64]struct{}{
@@ -2444,6 +2715,13 @@ func (r *ChannelRouter) BuildRoute(amt *lnwire.MilliSatoshi,
 		}
 	}
 
+	// The final cltv delta can be overridden by the constraints of the
+	// final hop.
+	finalHop := constraints.hop(len(hops) - 1)
+	if finalHop.CltvDelta != 0 {
+		finalCltvDelta = int32(finalHop.CltvDelta)
+	}
+
 	// If no amount is specified, we need to build a route for the minimum
 	// amount that this route can carry.
 	useMinAmt := amt == nil
@@ -2525,6 +2803,28 @@ func (r *ChannelRouter) BuildRoute(amt *lnwire.MilliSatoshi,
 			}
 		}
 
+		// Only keep the channels that satisfy the constraints.
+		pinnedChan := constraints.hop(i).ChannelID
+		unifiedPolicy.filterEdges(func(edge *unifiedPolicyEdge) bool {
+			chanID := edge.policy.ChannelID
+			if pinnedChan != 0 && chanID != pinnedChan {
+				return false
+			}
+
+			if constraints == nil {
+				return true
+			}
+
+			_, avoid := constraints.AvoidChannels[chanID]
+			return !avoid
+		})
+		if len(unifiedPolicy.edges) == 0 {
+			return nil, ErrNoChannel{
+				fromNode: fromNode,
+				position: i,
+			}
+		}
+
 		// If using min amt, increase amt if needed.
 		if useMinAmt {
 			min := unifiedPolicy.minAmt()
@@ -2573,19 +2873,56 @@ func (r *ChannelRouter) BuildRoute(amt *lnwire.MilliSatoshi,
 			receiverAmt -= policy.ComputeFeeFromIncoming(
 				receiverAmt,
 			)
+
+			// The time lock delta of this policy is applied by the
+			// previous hop, which may raise it.
+			cltvDelta := constraints.hop(i - 1).CltvDelta
+			if cltvDelta != 0 {
+				if cltvDelta < policy.TimeLockDelta {
+					return nil, fmt.Errorf("cltv delta %v "+
+						"of hop %v below policy delta "+
+						"%v", cltvDelta, i-1,
+						policy.TimeLockDelta)
+				}
+
+				policyCopy := *policy
+				policyCopy.TimeLockDelta = cltvDelta
+				policy = &policyCopy
+			}
 		}
 
 		pathEdges = append(pathEdges, policy)
 	}
 
-	// Build and return the final route.
-	return newRoute(
+	// Build the final route. Custom records for the final hop are passed
+	// on, so that they are checked against its features.
+	rt, err := newRoute(
 		source, pathEdges, uint32(height),
 		finalHopParams{
 			amt:       receiverAmt,
 			totalAmt:  receiverAmt,
 			cltvDelta: uint16(finalCltvDelta),
-			records:   nil,
+			records:   finalHop.CustomRecords,
 		},
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Attach the custom records of the intermediate hops, which requires
+	// them to understand the tlv payload.
+	for i, hop := range rt.Hops[:len(rt.Hops)-1] {
+		records := constraints.hop(i).CustomRecords
+		if len(records) == 0 {
+			continue
+		}
+
+		if hop.LegacyPayload {
+			return nil, fmt.Errorf("cannot attach custom records "+
+				"to hop %v without tlv payload", i)
+		}
+		hop.CustomRecords = records
+	}
+
+	return rt, nil
 }
@@ -6,6 +6,7 @@ import (
 	"image/color"
 	"math"
 	"math/rand"
+	"reflect"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -2018,12 +2019,32 @@ func TestPruneChannelGraphStaleEdges(t *testing.T) {
 	assertChannelsPruned(t, ctx.graph, testChannels)
 
 	// Proceed to prune the channels - only the last one should be pruned.
-	if err := ctx.router.pruneZombieChans(); err != nil {
+	// We'll also request the zombie index to be compacted.
+	result, err := ctx.router.PruneZombieChans(true)
+	if err != nil {
 		t.Fatalf("unable to prune zombie channels: %v", err)
 	}
+	if result.NumChannelsPruned != 1 {
+		t.Fatalf("expected 1 pruned channel, got %v",
+			result.NumChannelsPruned)
+	}
+	if result.NumZombiesCompacted != 1 {
+		t.Fatalf("expected 1 compacted zombie, got %v",
+			result.NumZombiesCompacted)
+	}
 
 	prunedChannel := testChannels[len(testChannels)-1].ChannelID
 	assertChannelsPruned(t, ctx.graph, testChannels, prunedChannel)
+
+	// The compacted zombie entry no longer holds the keys of the nodes,
+	// but the channel is still considered a zombie.
+	isZombie, pubKey1, pubKey2 := ctx.graph.IsZombieEdge(prunedChannel)
+	if !isZombie {
+		t.Fatalf("expected channel %v to be a zombie", prunedChannel)
+	}
+	if pubKey1 != ([33]byte{}) || pubKey2 != ([33]byte{}) {
+		t.Fatalf("expected compacted zombie entry without keys")
+	}
 }
 
 // TestPruneChannelGraphDoubleDisabled test that we can properly prune channels
@@ -2135,7 +2156,7 @@ func TestPruneChannelGraphDoubleDisabled(t *testing.T) {
 
 	// If we attempt to prune them without AssumeChannelValid being set,
 	// none should be pruned.
-	if err := ctx.router.pruneZombieChans(); err != nil {
+	if _, err := ctx.router.pruneZombieChans(false); err != nil {
 		t.Fatalf("unable to prune zombie channels: %v", err)
 	}
 
@@ -2144,7 +2165,7 @@ func TestPruneChannelGraphDoubleDisabled(t *testing.T) {
 	// Now that AssumeChannelValid is set, we'll prune the graph again and
 	// the last channel should be the only one pruned.
 	ctx.router.cfg.AssumeChannelValid = true
-	if err := ctx.router.pruneZombieChans(); err != nil {
+	if _, err := ctx.router.pruneZombieChans(false); err != nil {
 		t.Fatalf("unable to prune zombie channels: %v", err)
 	}
 
@@ -2849,6 +2870,116 @@ func TestSendToRouteMultiShardSend(t *testing.T) {
 	}
 }
 
+// TestSendToRouteSkipTempErr asserts that SendToRouteSkipTempErr leaves the
+// payment in flight after a temporary failure, so that further attempts can be
+// sent for it, and that FailPayment then marks it as failed.
+func TestSendToRouteSkipTempErr(t *testing.T) {
+	t.Parallel()
+
+	ctx, cleanup, err := createTestCtxSingleNode(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cleanup()
+
+	const payAmt = lnwire.MilliSatoshi(10000)
+	node, err := createTestNode()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hops := []*route.Hop{
+		{
+			ChannelID:    1,
+			PubKeyBytes:  node.PubKeyBytes,
+			AmtToForward: payAmt,
+		},
+	}
+
+	sourceNode, err := ctx.graph.SourceNode()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rt, err := route.NewRouteFromHops(
+		payAmt, 100, sourceNode.PubKeyBytes, hops,
+	)
+	if err != nil {
+		t.Fatalf("unable to create route: %v", err)
+	}
+
+	// Fail all attempts at our own channel, which isn't terminal for the
+	// payment.
+	payer := ctx.router.cfg.Payer.(*mockPaymentAttemptDispatcher)
+	payer.setPaymentResult(
+		func(firstHop lnwire.ShortChannelID) ([32]byte, error) {
+			return [32]byte{}, htlcswitch.NewForwardingError(
+				&lnwire.FailTemporaryChannelFailure{}, 0,
+			)
+		})
+
+	assertFailureReason := func(hash lntypes.Hash,
+		expected *channeldb.FailureReason) {
+
+		t.Helper()
+
+		payment, err := ctx.router.cfg.Control.FetchPayment(hash)
+		if err != nil {
+			t.Fatalf("unable to fetch payment: %v", err)
+		}
+		if !reflect.DeepEqual(payment.FailureReason, expected) {
+			t.Fatalf("expected failure reason %v, got %v",
+				expected, payment.FailureReason)
+		}
+	}
+
+	// The failed attempt doesn't fail the payment, and we can send
+	// another attempt for it afterwards.
+	var payment lntypes.Hash
+	for i := 0; i < 2; i++ {
+		attempt, err := ctx.router.SendToRouteSkipTempErr(payment, rt)
+		if err == nil {
+			t.Fatalf("expected forwarding error")
+		}
+		if attempt == nil || attempt.Failure == nil {
+			t.Fatalf("expected failed attempt")
+		}
+
+		assertFailureReason(payment, nil)
+	}
+
+	// Explicitly failing the payment marks it as failed.
+	if err := ctx.router.FailPayment(payment); err != nil {
+		t.Fatalf("unable to fail payment: %v", err)
+	}
+
+	reason := channeldb.FailureReasonNoRoute
+	assertFailureReason(payment, &reason)
+
+	// A temporary failure of a different payment can be followed by a
+	// successful attempt.
+	payment[0] = 1
+	if _, err := ctx.router.SendToRouteSkipTempErr(payment, rt); err == nil {
+		t.Fatalf("expected forwarding error")
+	}
+
+	preimage := lntypes.Preimage{1}
+	payer.setPaymentResult(
+		func(firstHop lnwire.ShortChannelID) ([32]byte, error) {
+			return preimage, nil
+		})
+
+	attempt, err := ctx.router.SendToRouteSkipTempErr(payment, rt)
+	if err != nil {
+		t.Fatalf("unable to send payment: %v", err)
+	}
+	if attempt.Settle.Preimage != preimage {
+		t.Fatalf("preimage mismatch")
+	}
+
+	assertFailureReason(payment, nil)
+}
+
 // TestSendToRouteMaxHops asserts that SendToRoute fails when using a route that
 // exceeds the maximum number of hops.
 func TestSendToRouteMaxHops(t *testing.T) {
@@ -2945,10 +3076,11 @@ func TestBuildRoute(t *testing.T) {
 		// nodes are recommended to keep their channel policies towards
 		// the same peer identical.
 		symmetricTestChannel("b", "c", chanCapSat, &testChannelPolicy{
-			Expiry:  144,
-			FeeRate: 50000,
-			MinHTLC: lnwire.NewMSatFromSatoshis(20),
-			MaxHTLC: lnwire.NewMSatFromSatoshis(120),
+			Expiry:   144,
+			FeeRate:  50000,
+			MinHTLC:  lnwire.NewMSatFromSatoshis(20),
+			MaxHTLC:  lnwire.NewMSatFromSatoshis(120),
+			Features: tlvFeatures,
 		}, 2),
 		symmetricTestChannel("b", "c", chanCapSat, &testChannelPolicy{
 			Expiry:  144,
@@ -3010,7 +3142,7 @@ func TestBuildRoute(t *testing.T) {
 
 	// Build the route for the given amount.
 	rt, err := ctx.router.BuildRoute(
-		&amt, hops, nil, 40,
+		&amt, hops, nil, 40, nil,
 	)
 	if err != nil {
 		t.Fatal(err)
@@ -3026,7 +3158,7 @@ func TestBuildRoute(t *testing.T) {
 
 	// Build the route for the minimum amount.
 	rt, err = ctx.router.BuildRoute(
-		nil, hops, nil, 40,
+		nil, hops, nil, 40, nil,
 	)
 	if err != nil {
 		t.Fatal(err)
@@ -3047,7 +3179,7 @@ func TestBuildRoute(t *testing.T) {
 		ctx.aliases["e"], ctx.aliases["c"],
 	}
 	_, err = ctx.router.BuildRoute(
-		nil, hops, nil, 40,
+		nil, hops, nil, 40, nil,
 	)
 	errNoChannel, ok := err.(ErrNoChannel)
 	if !ok {
@@ -3060,4 +3192,79 @@ func TestBuildRoute(t *testing.T) {
 	if errNoChannel.fromNode != ctx.aliases["a"] {
 		t.Fatalf("unexpected no channel error node")
 	}
+
+	// Pin the channels of both hops and raise the time lock delta of hop
+	// b. The pinned channels are used instead of the best ones, and the
+	// time lock of the first hop includes the raised delta.
+	hops = []route.Vertex{
+		ctx.aliases["b"], ctx.aliases["c"],
+	}
+	records := record.CustomSet{65536: []byte{1}}
+	rt, err = ctx.router.BuildRoute(
+		&amt, hops, nil, 40, &BuildRouteConstraints{
+			Hops: []HopConstraints{
+				{ChannelID: 6, CltvDelta: 200},
+				{ChannelID: 2, CustomRecords: records},
+			},
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkHops(rt, []uint64{6, 2})
+	if rt.Hops[0].OutgoingTimeLock != startingBlockHeight+40 {
+		t.Fatalf("unexpected outgoing time lock %v",
+			rt.Hops[0].OutgoingTimeLock)
+	}
+	if rt.TotalTimeLock != startingBlockHeight+40+200 {
+		t.Fatalf("unexpected total time lock %v", rt.TotalTimeLock)
+	}
+	if !reflect.DeepEqual(rt.Hops[1].CustomRecords, records) {
+		t.Fatalf("unexpected custom records %v",
+			rt.Hops[1].CustomRecords)
+	}
+
+	// Custom records can't be attached to hop b, which doesn't support the
+	// tlv payload.
+	_, err = ctx.router.BuildRoute(
+		&amt, hops, nil, 40, &BuildRouteConstraints{
+			Hops: []HopConstraints{{CustomRecords: records}, {}},
+		},
+	)
+	if err == nil {
+		t.Fatal("expected error for custom records without tlv")
+	}
+
+	// A time lock delta below the channel policy is rejected.
+	_, err = ctx.router.BuildRoute(
+		&amt, hops, nil, 40, &BuildRouteConstraints{
+			Hops: []HopConstraints{{CltvDelta: 100}, {}},
+		},
+	)
+	if err == nil {
+		t.Fatal("expected error for cltv delta below policy")
+	}
+
+	// Avoiding channel 7 selects the other channel between b and c.
+	rt, err = ctx.router.BuildRoute(
+		&amt, hops, nil, 40, &BuildRouteConstraints{
+			AvoidChannels: map[uint64]struct{}{7: {}},
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkHops(rt, []uint64{1, 2})
+
+	// Avoiding a node in the hop list fails.
+	_, err = ctx.router.BuildRoute(
+		&amt, hops, nil, 40, &BuildRouteConstraints{
+			AvoidNodes: map[route.Vertex]struct{}{
+				ctx.aliases["b"]: {},
+			},
+		},
+	)
+	if err == nil {
+		t.Fatal("expected error for avoided node")
+	}
 }
@@ -135,6 +135,18 @@ type unifiedPolicy struct {
 	localChan bool
 }
 
+// filterEdges removes all edges for which keep returns false.
+func (u *unifiedPolicy) filterEdges(keep func(*unifiedPolicyEdge) bool) {
+	var edges []*unifiedPolicyEdge
+	for _, edge := range u.edges {
+		if keep(edge) {
+			edges = append(edges, edge)
+		}
+	}
+
+	u.edges = edges
+}
+
 // getPolicy returns the optimal policy to use for this connection given a
 // specific amount to send. It differentiates between local and network
 // channels.
@@ -3,8 +3,11 @@ package lnd
 import (
 	"bytes"
 	"context"
+	"crypto/rand"
 	"crypto/tls"
+	"encoding/binary"
 	"encoding/hex"
+	"encoding/pem"
 	"errors"
 	"fmt"
 	"io"
@@ -28,10 +31,12 @@ import (
 	"github.com/btcsuite/btcutil/psbt"
 	"github.com/btcsuite/btcwallet/wallet/txauthor"
 	"github.com/davecgh/go-spew/spew"
+	"github.com/golang/protobuf/proto"
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	proxy "github.com/grpc-ecosystem/grpc-gateway/runtime"
 	"github.com/cryptomeow/lnd/autopilot"
 	"github.com/cryptomeow/lnd/build"
+	"github.com/cryptomeow/lnd/chainntnfs"
 	"github.com/cryptomeow/lnd/chainreg"
 	"github.com/cryptomeow/lnd/chanacceptor"
 	"github.com/cryptomeow/lnd/chanbackup"
@@ -41,6 +46,7 @@ import (
 	"github.com/cryptomeow/lnd/channelnotifier"
 	"github.com/cryptomeow/lnd/contractcourt"
 	"github.com/cryptomeow/lnd/discovery"
+	"github.com/cryptomeow/lnd/eventbus"
 	"github.com/cryptomeow/lnd/feature"
 	"github.com/cryptomeow/lnd/htlcswitch"
 	"github.com/cryptomeow/lnd/htlcswitch/hop"
@@ -52,6 +58,7 @@ import (
 	"github.com/cryptomeow/lnd/lnrpc"
 	"github.com/cryptomeow/lnd/lnrpc/invoicesrpc"
 	"github.com/cryptomeow/lnd/lnrpc/routerrpc"
+	"github.com/cryptomeow/lnd/lnurl"
 	"github.com/cryptomeow/lnd/lntypes"
 	"github.com/cryptomeow/lnd/lnwallet"
 	"github.com/cryptomeow/lnd/lnwallet/btcwallet"
@@ -61,17 +68,23 @@ import (
 	"github.com/cryptomeow/lnd/macaroons"
 	"github.com/cryptomeow/lnd/monitoring"
 	"github.com/cryptomeow/lnd/peer"
+	"github.com/cryptomeow/lnd/peerfilter"
 	"github.com/cryptomeow/lnd/peernotifier"
 	"github.com/cryptomeow/lnd/record"
 	"github.com/cryptomeow/lnd/routing"
+	"github.com/cryptomeow/lnd/routing/localchans"
 	"github.com/cryptomeow/lnd/routing/route"
 	"github.com/cryptomeow/lnd/signal"
+	"github.com/cryptomeow/lnd/swaphook"
 	"github.com/cryptomeow/lnd/sweep"
 	"github.com/cryptomeow/lnd/watchtower"
 	"github.com/cryptomeow/lnd/zpay32"
 	"github.com/tv42/zbase32"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"gopkg.in/macaroon-bakery.v2/bakery"
+	"gopkg.in/macaroon.v2"
 )
 
 var (
@@ -161,6 +174,14 @@ var (
 		},
 	}
 
+	// observerDeniedMethods is the set of methods that only require read
+	// permissions, but still modify the state of the daemon. Observer
+	// macaroons are denied access to them.
+	observerDeniedMethods = []string{
+		"/walletrpc.WalletKit/DeriveNextKey",
+		"/walletrpc.WalletKit/NextAddr",
+	}
+
 	// invoicePermissions is a slice of all the entities that allows a user
 	// to only access calls that are related to invoices, so: streaming
 	// RPCs, generating, and listening invoices.
@@ -187,6 +208,30 @@ var (
 		},
 	}
 
+	// swapHookPermissions is the set of permissions granted by the payment
+	// macaroons baked for swap hooks. They are limited to the calls needed
+	// for a swap: paying and tracking a payment and deriving an address to
+	// receive the swapped funds on chain. The amounts paid are capped by
+	// the budget of the hook.
+	swapHookPermissions = []bakery.Op{
+		{
+			Entity: macaroons.PermissionEntityCustomURI,
+			Action: swapHookPaymentMethod,
+		},
+		{
+			Entity: macaroons.PermissionEntityCustomURI,
+			Action: "/routerrpc.Router/TrackPaymentV2",
+		},
+		{
+			Entity: macaroons.PermissionEntityCustomURI,
+			Action: "/lnrpc.Lightning/DecodePayReq",
+		},
+		{
+			Entity: macaroons.PermissionEntityCustomURI,
+			Action: "/lnrpc.Lightning/NewAddress",
+		},
+	}
+
 	// TODO(guggero): Refactor into constants that are used for all
 	// permissions in this file. Also expose the list of possible
 	// permissions in an RPC when per RPC permissions are
@@ -235,6 +280,14 @@ func MainRPCServerPermissions() map[string][]bakery.Op {
 			Entity: "address",
 			Action: "write",
 		}},
+		"/lnrpc.Lightning/ImportWatchOnly": {{
+			Entity: "onchain",
+			Action: "write",
+		}},
+		"/lnrpc.Lightning/ListWatchOnly": {{
+			Entity: "onchain",
+			Action: "read",
+		}},
 		"/lnrpc.Lightning/SignMessage": {{
 			Entity: "message",
 			Action: "write",
@@ -272,10 +325,22 @@ func MainRPCServerPermissions() map[string][]bakery.Op {
 			Entity: "offchain",
 			Action: "write",
 		}},
+		"/lnrpc.Lightning/PreviewForceClose": {{
+			Entity: "offchain",
+			Action: "read",
+		}},
+		"/lnrpc.Lightning/PinCommitFeeRate": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
 		"/lnrpc.Lightning/AbandonChannel": {{
 			Entity: "offchain",
 			Action: "write",
 		}},
+		"/lnrpc.Lightning/UpgradeChannel": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
 		"/lnrpc.Lightning/GetInfo": {{
 			Entity: "info",
 			Action: "read",
@@ -284,6 +349,10 @@ func MainRPCServerPermissions() map[string][]bakery.Op {
 			Entity: "info",
 			Action: "read",
 		}},
+		"/lnrpc.Lightning/GetHealth": {{
+			Entity: "info",
+			Action: "read",
+		}},
 		"/lnrpc.Lightning/ListPeers": {{
 			Entity: "peers",
 			Action: "read",
@@ -296,6 +365,25 @@ func MainRPCServerPermissions() map[string][]bakery.Op {
 			Entity: "onchain",
 			Action: "read",
 		}},
+		"/lnrpc.Lightning/EstimateOpenChannel": {{
+			Entity: "onchain",
+			Action: "read",
+		}},
+		"/lnrpc.Lightning/DescribeChannelConstraints": {{
+			Entity: "offchain",
+			Action: "read",
+		}},
+		"/lnrpc.Lightning/ListQueuedChannelOpens": {{
+			Entity: "offchain",
+			Action: "read",
+		}},
+		"/lnrpc.Lightning/CancelQueuedChannelOpen": {{
+			Entity: "onchain",
+			Action: "write",
+		}, {
+			Entity: "offchain",
+			Action: "write",
+		}},
 		"/lnrpc.Lightning/ChannelBalance": {{
 			Entity: "offchain",
 			Action: "read",
@@ -304,6 +392,14 @@ func MainRPCServerPermissions() map[string][]bakery.Op {
 			Entity: "offchain",
 			Action: "read",
 		}},
+		"/lnrpc.Lightning/ListPendingReservations": {{
+			Entity: "offchain",
+			Action: "read",
+		}},
+		"/lnrpc.Lightning/CancelPendingReservation": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
 		"/lnrpc.Lightning/ListChannels": {{
 			Entity: "offchain",
 			Action: "read",
@@ -312,10 +408,42 @@ func MainRPCServerPermissions() map[string][]bakery.Op {
 			Entity: "offchain",
 			Action: "read",
 		}},
+		"/lnrpc.Lightning/SubscribeEvents": {{
+			Entity: "offchain",
+			Action: "read",
+		}, {
+			Entity: "peers",
+			Action: "read",
+		}, {
+			Entity: "invoices",
+			Action: "read",
+		}},
 		"/lnrpc.Lightning/ClosedChannels": {{
 			Entity: "offchain",
 			Action: "read",
 		}},
+		"/lnrpc.Lightning/ChannelUptime": {{
+			Entity: "offchain",
+			Action: "read",
+		}},
+		"/lnrpc.Lightning/LeastReliablePeers": {{
+			Entity: "peers",
+			Action: "read",
+		}},
+		"/lnrpc.Lightning/ListMonitorEvents": {{
+			Entity: "offchain",
+			Action: "read",
+		}},
+		"/lnrpc.Lightning/RegisterSwapHook": {{
+			Entity: "offchain",
+			Action: "read",
+		}, {
+			Entity: "offchain",
+			Action: "write",
+		}, {
+			Entity: "macaroon",
+			Action: "generate",
+		}},
 		"/lnrpc.Lightning/SendPayment": {{
 			Entity: "offchain",
 			Action: "write",
@@ -340,6 +468,10 @@ func MainRPCServerPermissions() map[string][]bakery.Op {
 			Entity: "invoices",
 			Action: "read",
 		}},
+		"/lnrpc.Lightning/RegenerateInvoice": {{
+			Entity: "invoices",
+			Action: "write",
+		}},
 		"/lnrpc.Lightning/ListInvoices": {{
 			Entity: "invoices",
 			Action: "read",
@@ -380,10 +512,26 @@ func MainRPCServerPermissions() map[string][]bakery.Op {
 			Entity: "info",
 			Action: "read",
 		}},
+		"/lnrpc.Lightning/PruneGraph": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
 		"/lnrpc.Lightning/StopDaemon": {{
 			Entity: "info",
 			Action: "write",
 		}},
+		"/lnrpc.Lightning/DrainNode": {{
+			Entity: "info",
+			Action: "write",
+		}},
+		"/lnrpc.Lightning/ReloadConfig": {{
+			Entity: "info",
+			Action: "write",
+		}},
+		"/lnrpc.Lightning/RotateTLSCertificate": {{
+			Entity: "info",
+			Action: "write",
+		}},
 		"/lnrpc.Lightning/SubscribeChannelGraph": {{
 			Entity: "info",
 			Action: "read",
@@ -396,14 +544,26 @@ func MainRPCServerPermissions() map[string][]bakery.Op {
 			Entity: "offchain",
 			Action: "write",
 		}},
+		"/lnrpc.Lightning/PrunePayments": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
 		"/lnrpc.Lightning/DebugLevel": {{
 			Entity: "info",
 			Action: "write",
 		}},
+		"/lnrpc.Lightning/SubsystemLogLevels": {{
+			Entity: "info",
+			Action: "write",
+		}},
 		"/lnrpc.Lightning/DecodePayReq": {{
 			Entity: "offchain",
 			Action: "read",
 		}},
+		"/lnrpc.Lightning/ResolveLnurlPay": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
 		"/lnrpc.Lightning/FeeReport": {{
 			Entity: "offchain",
 			Action: "read",
@@ -412,10 +572,41 @@ func MainRPCServerPermissions() map[string][]bakery.Op {
 			Entity: "offchain",
 			Action: "write",
 		}},
+		"/lnrpc.Lightning/SetPolicyTemplate": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
+		"/lnrpc.Lightning/ListPolicyTemplates": {{
+			Entity: "offchain",
+			Action: "read",
+		}},
+		"/lnrpc.Lightning/DeletePolicyTemplate": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
+		"/lnrpc.Lightning/ChannelPolicyHistory": {{
+			Entity: "offchain",
+			Action: "read",
+		}},
 		"/lnrpc.Lightning/ForwardingHistory": {{
 			Entity: "offchain",
 			Action: "read",
 		}},
+		"/lnrpc.Lightning/ListChannelOpenAttempts": {{
+			Entity: "offchain",
+			Action: "read",
+		}},
+		"/lnrpc.Lightning/SetPeerPendingChanLimit": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
+		"/lnrpc.Lightning/ExportChannelEvents": {{
+			Entity: "offchain",
+			Action: "read",
+		}, {
+			Entity: "onchain",
+			Action: "read",
+		}},
 		"/lnrpc.Lightning/RestoreChannelBackups": {{
 			Entity: "offchain",
 			Action: "write",
@@ -463,6 +654,26 @@ func MainRPCServerPermissions() map[string][]bakery.Op {
 			Entity: "peers",
 			Action: "read",
 		}},
+		"/lnrpc.Lightning/SendCustomMessage": {{
+			Entity: "peers",
+			Action: "write",
+		}},
+		"/lnrpc.Lightning/SubscribeCustomMessages": {{
+			Entity: "peers",
+			Action: "read",
+		}},
+		"/lnrpc.Lightning/AddPeerFilterRule": {{
+			Entity: "peers",
+			Action: "write",
+		}},
+		"/lnrpc.Lightning/RemovePeerFilterRule": {{
+			Entity: "peers",
+			Action: "write",
+		}},
+		"/lnrpc.Lightning/ListPeerFilterRules": {{
+			Entity: "peers",
+			Action: "read",
+		}},
 		"/lnrpc.Lightning/FundingStateStep": {{
 			Entity: "onchain",
 			Action: "write",
@@ -516,6 +727,10 @@ type rpcServer struct {
 	// connect to the main gRPC server to proxy all incoming requests.
 	tlsCfg *tls.Config
 
+	// tlsRotator serves and rotates the TLS certificate of the gRPC and
+	// REST listeners.
+	tlsRotator *tlsRotator
+
 	// routerBackend contains the backend implementation of the router
 	// rpc sub server.
 	routerBackend *routerrpc.RouterBackend
@@ -533,6 +748,14 @@ type rpcServer struct {
 	// selfNode is our own pubkey.
 	selfNode route.Vertex
 
+	// swapHookBudgets caps the payments made with the macaroons baked for
+	// swap hooks.
+	swapHookBudgets *swapHookBudgets
+
+	// lnurlResolver resolves LNURL-pay targets to payment requests. It is
+	// nil if lnd isn't allowed to contact LNURL servers.
+	lnurlResolver *lnurl.Resolver
+
 	// allPermissions is a map of all registered gRPC URIs (including
 	// internal and external subservers) to the permissions they require.
 	allPermissions map[string][]bakery.Op
@@ -552,7 +775,7 @@ func newRPCServer(cfg *Config, s *server, macService *macaroons.Service,
 	restDialOpts []grpc.DialOption, restProxyDest string,
 	atpl *autopilot.Manager, invoiceRegistry *invoices.InvoiceRegistry,
 	tower *watchtower.Standalone, tlsCfg *tls.Config,
-	getListeners rpcListeners,
+	tlsRotator *tlsRotator, getListeners rpcListeners,
 	chanPredicate *chanacceptor.ChainedAcceptor) (*rpcServer, error) {
 
 	// Set up router rpc backend.
@@ -589,6 +812,7 @@ func newRPCServer(cfg *Config, s *server, macService *macaroons.Service,
 			return info.NodeKey1Bytes, info.NodeKey2Bytes, nil
 		},
 		FindRoute:              s.chanRouter.FindRoute,
+		SendToRoute:            s.chanRouter.SendToRoute,
 		MissionControl:         s.missionControl,
 		ActiveNetParams:        cfg.ActiveNetParams.Params,
 		Tower:                  s.controlTower,
@@ -616,8 +840,8 @@ func newRPCServer(cfg *Config, s *server, macService *macaroons.Service,
 		cfg, s.cc, cfg.networkDir, macService, atpl, invoiceRegistry,
 		s.htlcSwitch, cfg.ActiveNetParams.Params, s.chanRouter,
 		routerBackend, s.nodeSigner, s.remoteChanDB, s.sweeper, tower,
-		s.towerClient, cfg.net.ResolveTCPAddr, genInvoiceFeatures,
-		rpcsLog,
+		s.towerClient, s.anchorTowerClient, cfg.net.ResolveTCPAddr,
+		genInvoiceFeatures, rpcsLog,
 	)
 	if err != nil {
 		return nil, err
@@ -707,12 +931,27 @@ func newRPCServer(cfg *Config, s *server, macService *macaroons.Service,
 	// authentication in a single location.
 	macUnaryInterceptors := []grpc.UnaryServerInterceptor{}
 	macStrmInterceptors := []grpc.StreamServerInterceptor{}
+	hookBudgets := &swapHookBudgets{
+		budgets: make(map[string]*swaphook.Budget),
+	}
 	if macService != nil {
+		for _, method := range observerDeniedMethods {
+			macService.RegisterMutatingMethod(method)
+		}
+
 		unaryInterceptor := macService.UnaryServerInterceptor(permissions)
 		macUnaryInterceptors = append(macUnaryInterceptors, unaryInterceptor)
 
 		strmInterceptor := macService.StreamServerInterceptor(permissions)
 		macStrmInterceptors = append(macStrmInterceptors, strmInterceptor)
+
+		// Payments made with the macaroon of a swap hook are charged
+		// to its budget once the macaroon has been validated.
+		macStrmInterceptors = append(
+			macStrmInterceptors, hookBudgets.streamInterceptor(
+				cfg.ActiveNetParams.Params,
+			),
+		)
 	}
 
 	// Get interceptors for Prometheus to gather gRPC performance metrics.
@@ -747,6 +986,17 @@ func newRPCServer(cfg *Config, s *server, macService *macaroons.Service,
 	// Finally, with all the pre-set up complete,  we can create the main
 	// gRPC server, and register the main lnrpc server along side.
 	grpcServer := grpc.NewServer(serverOpts...)
+	// LNURL servers are only contacted if explicitly allowed.
+	var lnurlResolver *lnurl.Resolver
+	if cfg.LnurlResolve {
+		lnurlResolver = lnurl.NewResolver(&lnurl.Config{
+			Dial:        cfg.net.Dial,
+			UseTor:      cfg.Tor.Active,
+			Timeout:     lnurl.DefaultTimeout,
+			ChainParams: cfg.ActiveNetParams.Params,
+		})
+	}
+
 	rootRPCServer := &rpcServer{
 		cfg:             cfg,
 		restDialOpts:    restDialOpts,
@@ -755,6 +1005,7 @@ func newRPCServer(cfg *Config, s *server, macService *macaroons.Service,
 		restProxyDest:   restProxyDest,
 		subServers:      subServers,
 		tlsCfg:          tlsCfg,
+		tlsRotator:      tlsRotator,
 		grpcServer:      grpcServer,
 		server:          s,
 		routerBackend:   routerBackend,
@@ -762,7 +1013,9 @@ func newRPCServer(cfg *Config, s *server, macService *macaroons.Service,
 		quit:            make(chan struct{}, 1),
 		macService:      macService,
 		selfNode:        selfNode.PubKeyBytes,
+		lnurlResolver:   lnurlResolver,
 		allPermissions:  permissions,
+		swapHookBudgets: hookBudgets,
 	}
 	lnrpc.RegisterLightningServer(grpcServer, rootRPCServer)
 
@@ -840,6 +1093,12 @@ func (r *rpcServer) Start() error {
 		if err != nil {
 			return err
 		}
+
+		monitoring.ExportPeerMetrics(r.peerMetrics)
+		monitoring.ExportAcceptorMetrics()
+		monitoring.ExportReservationMetrics(r.reservationMetrics)
+		monitoring.ExportNotifierMetrics(r.notifierMetrics)
+		monitoring.ExportChannelMetrics(r.channelMetrics)
 	}
 
 	// The default JSON marshaler of the REST proxy only sets OrigName to
@@ -862,7 +1121,9 @@ func (r *rpcServer) Start() error {
 	r.listenerCleanUp = append(r.listenerCleanUp, restCancel)
 
 	// Wrap the default grpc-gateway handler with the WebSocket handler.
-	restHandler := lnrpc.NewWebSocketProxy(restMux, rpcsLog)
+	restHandler := lnrpc.NewWebSocketProxy(
+		restMux, rpcsLog, r.cfg.WSPingInterval, r.cfg.WSPongWait,
+	)
 
 	// With our custom REST proxy mux created, register our main RPC and
 	// give all subservers a chance to register as well.
@@ -1045,14 +1306,16 @@ func allowCORS(handler http.Handler, origins []string) http.Handler {
 // address to a specified output value to be sent to that address.
 func (r *rpcServer) sendCoinsOnChain(paymentMap map[string]int64,
 	feeRate chainfee.SatPerKWeight, minconf int32,
-	label string) (*chainhash.Hash, error) {
+	label, account string) (*chainhash.Hash, error) {
 
 	outputs, err := addrPairsToOutputs(paymentMap, r.cfg.ActiveNetParams.Params)
 	if err != nil {
 		return nil, err
 	}
 
-	tx, err := r.server.cc.Wallet.SendOutputs(outputs, feeRate, minconf, label)
+	tx, err := r.server.cc.Wallet.SendOutputs(
+		outputs, feeRate, minconf, label, account,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -1086,7 +1349,7 @@ func (r *rpcServer) ListUnspent(ctx context.Context,
 	var utxos []*lnwallet.Utxo
 	err = r.server.cc.Wallet.WithCoinSelectLock(func() error {
 		utxos, err = r.server.cc.Wallet.ListUnspentWitness(
-			minConfs, maxConfs,
+			minConfs, maxConfs, "",
 		)
 		return err
 	})
@@ -1099,6 +1362,16 @@ func (r *rpcServer) ListUnspent(ctx context.Context,
 		return nil, err
 	}
 
+	// Watch-only outputs are never part of the wallet's coin selection, so
+	// they're added after listing the wallet's outputs.
+	if in.IncludeWatchOnly {
+		watchOnlyUtxos, err := r.watchOnlyUtxos(minConfs, maxConfs)
+		if err != nil {
+			return nil, err
+		}
+		rpcUtxos = append(rpcUtxos, watchOnlyUtxos...)
+	}
+
 	maxStr := ""
 	if maxConfs != math.MaxInt32 {
 		maxStr = " max=" + fmt.Sprintf("%d", maxConfs)
@@ -1112,6 +1385,52 @@ func (r *rpcServer) ListUnspent(ctx context.Context,
 	}, nil
 }
 
+// watchOnlyUtxos returns the unspent outputs paying to watch-only scripts with
+// a number of confirmations between the given minimum and maximum.
+func (r *rpcServer) watchOnlyUtxos(minConfs, maxConfs int32) ([]*lnrpc.Utxo,
+	error) {
+
+	_, bestHeight, err := r.server.cc.ChainIO.GetBestBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	var rpcUtxos []*lnrpc.Utxo
+	for _, output := range r.server.watchOnlyTracker.UnspentOutputs() {
+		confs := bestHeight - int32(output.Height) + 1
+		if confs < minConfs || confs > maxConfs {
+			continue
+		}
+
+		// Only the address types known to lnrpc are set, all other
+		// scripts are identified by their address and pk script.
+		var addrType lnrpc.AddressType
+		switch txscript.GetScriptClass(output.PkScript) {
+		case txscript.WitnessV0PubKeyHashTy:
+			addrType = lnrpc.AddressType_WITNESS_PUBKEY_HASH
+
+		case txscript.ScriptHashTy:
+			addrType = lnrpc.AddressType_NESTED_PUBKEY_HASH
+		}
+
+		rpcUtxos = append(rpcUtxos, &lnrpc.Utxo{
+			AddressType: addrType,
+			Address:     r.scriptAddress(output.PkScript),
+			AmountSat:   int64(output.Value),
+			PkScript:    hex.EncodeToString(output.PkScript),
+			Outpoint: &lnrpc.OutPoint{
+				TxidBytes:   output.OutPoint.Hash[:],
+				TxidStr:     output.OutPoint.Hash.String(),
+				OutputIndex: output.OutPoint.Index,
+			},
+			Confirmations: int64(confs),
+			WatchOnly:     true,
+		})
+	}
+
+	return rpcUtxos, nil
+}
+
 // EstimateFee handles a request for estimating the fee for sending a
 // transaction spending to multiple specified outputs in parallel.
 func (r *rpcServer) EstimateFee(ctx context.Context,
@@ -1225,6 +1544,13 @@ func (r *rpcServer) SendCoins(ctx context.Context,
 		return nil, err
 	}
 
+	// Only the coins of a single account are spent, which is the default
+	// account unless the user specified one.
+	account := in.Account
+	if account == "" {
+		account = lnwallet.DefaultAccountName
+	}
+
 	var txid *chainhash.Hash
 
 	wallet := r.server.cc.Wallet
@@ -1253,7 +1579,7 @@ func (r *rpcServer) SendCoins(ctx context.Context,
 		sweepTxPkg, err := sweep.CraftSweepAllTx(
 			feePerKw, uint32(bestHeight), targetAddr, wallet,
 			wallet.WalletController, wallet.WalletController,
-			r.server.cc.FeeEstimator, r.server.cc.Signer,
+			r.server.cc.FeeEstimator, r.server.cc.Signer, account,
 		)
 		if err != nil {
 			return nil, err
@@ -1284,7 +1610,7 @@ func (r *rpcServer) SendCoins(ctx context.Context,
 		paymentMap := map[string]int64{targetAddr.String(): in.Amount}
 		err := wallet.WithCoinSelectLock(func() error {
 			newTXID, err := r.sendCoinsOnChain(
-				paymentMap, feePerKw, minConfs, label,
+				paymentMap, feePerKw, minConfs, label, account,
 			)
 			if err != nil {
 				return err
@@ -1345,7 +1671,7 @@ func (r *rpcServer) SendMany(ctx context.Context,
 	wallet := r.server.cc.Wallet
 	err = wallet.WithCoinSelectLock(func() error {
 		sendManyTXID, err := r.sendCoinsOnChain(
-			in.AddrToAmount, feePerKw, minConfs, label,
+			in.AddrToAmount, feePerKw, minConfs, label, in.Account,
 		)
 		if err != nil {
 			return err
@@ -1368,6 +1694,16 @@ func (r *rpcServer) SendMany(ctx context.Context,
 func (r *rpcServer) NewAddress(ctx context.Context,
 	in *lnrpc.NewAddressRequest) (*lnrpc.NewAddressResponse, error) {
 
+	// The last unused address is only tracked for the default account.
+	unused := in.Type == lnrpc.AddressType_UNUSED_WITNESS_PUBKEY_HASH ||
+		in.Type == lnrpc.AddressType_UNUSED_NESTED_PUBKEY_HASH
+	if unused && in.Account != "" &&
+		in.Account != lnwallet.DefaultAccountName {
+
+		return nil, fmt.Errorf("unused addresses are only supported " +
+			"for the default account")
+	}
+
 	// Translate the gRPC proto address type to the wallet controller's
 	// available address types.
 	var (
@@ -1377,7 +1713,7 @@ func (r *rpcServer) NewAddress(ctx context.Context,
 	switch in.Type {
 	case lnrpc.AddressType_WITNESS_PUBKEY_HASH:
 		addr, err = r.server.cc.Wallet.NewAddress(
-			lnwallet.WitnessPubKey, false,
+			lnwallet.WitnessPubKey, false, in.Account,
 		)
 		if err != nil {
 			return nil, err
@@ -1385,7 +1721,7 @@ func (r *rpcServer) NewAddress(ctx context.Context,
 
 	case lnrpc.AddressType_NESTED_PUBKEY_HASH:
 		addr, err = r.server.cc.Wallet.NewAddress(
-			lnwallet.NestedWitnessPubKey, false,
+			lnwallet.NestedWitnessPubKey, false, in.Account,
 		)
 		if err != nil {
 			return nil, err
@@ -1412,6 +1748,104 @@ func (r *rpcServer) NewAddress(ctx context.Context,
 	return &lnrpc.NewAddressResponse{Address: addr.String()}, nil
 }
 
+// ImportWatchOnly adds addresses and output scripts to the set of scripts that
+// are watched without holding their private keys.
+func (r *rpcServer) ImportWatchOnly(ctx context.Context,
+	in *lnrpc.ImportWatchOnlyRequest) (*lnrpc.ImportWatchOnlyResponse,
+	error) {
+
+	params := r.cfg.ActiveNetParams.Params
+
+	var scripts []*channeldb.WatchedScript
+	for _, addrStr := range in.Addresses {
+		addr, err := btcutil.DecodeAddress(addrStr, params)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address %v: %v", addrStr,
+				err)
+		}
+		if !addr.IsForNet(params) {
+			return nil, fmt.Errorf("address %v is not for %v",
+				addrStr, params.Name)
+		}
+
+		pkScript, err := txscript.PayToAddrScript(addr)
+		if err != nil {
+			return nil, err
+		}
+
+		scripts = append(scripts, &channeldb.WatchedScript{
+			PkScript:    pkScript,
+			Label:       in.Label,
+			BirthHeight: in.BirthHeight,
+		})
+	}
+	for _, pkScript := range in.Scripts {
+		scripts = append(scripts, &channeldb.WatchedScript{
+			PkScript:    pkScript,
+			Label:       in.Label,
+			BirthHeight: in.BirthHeight,
+		})
+	}
+
+	if len(scripts) == 0 {
+		return nil, fmt.Errorf("no addresses or scripts specified")
+	}
+
+	if err := r.server.watchOnlyTracker.AddScripts(scripts); err != nil {
+		return nil, err
+	}
+
+	rpcsLog.Infof("[importwatchonly] imported %v watch-only scripts with "+
+		"birth height %v", len(scripts), scripts[0].BirthHeight)
+
+	return &lnrpc.ImportWatchOnlyResponse{
+		Scripts: r.marshalWatchedScripts(scripts),
+	}, nil
+}
+
+// ListWatchOnly returns all watched addresses and output scripts.
+func (r *rpcServer) ListWatchOnly(ctx context.Context,
+	in *lnrpc.ListWatchOnlyRequest) (*lnrpc.ListWatchOnlyResponse, error) {
+
+	tracker := r.server.watchOnlyTracker
+
+	return &lnrpc.ListWatchOnlyResponse{
+		Scripts:      r.marshalWatchedScripts(tracker.Scripts()),
+		SyncedHeight: tracker.SyncHeight(),
+	}, nil
+}
+
+// marshalWatchedScripts converts the given watched scripts into their RPC
+// representation.
+func (r *rpcServer) marshalWatchedScripts(
+	scripts []*channeldb.WatchedScript) []*lnrpc.WatchedScript {
+
+	rpcScripts := make([]*lnrpc.WatchedScript, 0, len(scripts))
+	for _, script := range scripts {
+		rpcScripts = append(rpcScripts, &lnrpc.WatchedScript{
+			PkScript:    script.PkScript,
+			Address:     r.scriptAddress(script.PkScript),
+			Label:       script.Label,
+			BirthHeight: script.BirthHeight,
+		})
+	}
+
+	return rpcScripts
+}
+
+// scriptAddress returns the address of the given output script, or an empty
+// string if it doesn't have a standard form paying to a single address.
+func (r *rpcServer) scriptAddress(pkScript []byte) string {
+	_, addrs, _, err := txscript.ExtractPkScriptAddrs(
+		pkScript, r.cfg.ActiveNetParams.Params,
+	)
+	if err != nil || len(addrs) != 1 {
+		return ""
+	}
+
+	return addrs[0].String()
+}
+
 var (
 	// signedMsgPrefix is a special prefix that we'll prepend to any
 	// messages we sign/verify. We do this to ensure that we don't
@@ -1619,9 +2053,6 @@ func newFundingShimAssembler(chanPointShim *lnrpc.ChanPointShim, initiator bool,
 	case chanPointShim.LocalKey == nil:
 		return nil, fmt.Errorf("local key desc not set")
 
-	case chanPointShim.LocalKey.RawKeyBytes == nil:
-		return nil, fmt.Errorf("local raw key bytes not set")
-
 	case chanPointShim.LocalKey.KeyLoc == nil:
 		return nil, fmt.Errorf("local key loc not set")
 
@@ -1649,43 +2080,61 @@ func newFundingShimAssembler(chanPointShim *lnrpc.ChanPointShim, initiator bool,
 		return nil, err
 	}
 
+	// Our multi-sig key is always derived from the key locator, so the
+	// key can be taken from any key family, e.g. the one of an account
+	// that an external wallet holds the xpub of.
 	shimKeyDesc := chanPointShim.LocalKey
-	localKey, err := btcec.ParsePubKey(
-		shimKeyDesc.RawKeyBytes, btcec.S256(),
-	)
-	if err != nil {
-		return nil, err
-	}
-	localKeyDesc := keychain.KeyDescriptor{
-		PubKey: localKey,
-		KeyLocator: keychain.KeyLocator{
-			Family: keychain.KeyFamily(
-				shimKeyDesc.KeyLoc.KeyFamily,
-			),
-			Index: uint32(shimKeyDesc.KeyLoc.KeyIndex),
-		},
+	keyLoc := keychain.KeyLocator{
+		Family: keychain.KeyFamily(shimKeyDesc.KeyLoc.KeyFamily),
+		Index:  uint32(shimKeyDesc.KeyLoc.KeyIndex),
 	}
-
-	// Verify that if we re-derive this key according to the passed
-	// KeyLocator, that we get the exact same key back. Otherwise, we may
-	// end up in a situation where we aren't able to actually sign for this
-	// newly created channel.
-	derivedKey, err := keyRing.DeriveKey(localKeyDesc.KeyLocator)
+	localKeyDesc, err := keyRing.DeriveKey(keyLoc)
 	if err != nil {
 		return nil, err
 	}
-	if !derivedKey.PubKey.IsEqual(localKey) {
-		return nil, fmt.Errorf("KeyLocator does not match attached " +
-			"raw pubkey")
+
+	// If the raw key was passed as well, we verify that it's the exact same
+	// key we derived. Otherwise, we may end up in a situation where we
+	// aren't able to actually sign for this newly created channel.
+	if len(shimKeyDesc.RawKeyBytes) > 0 {
+		localKey, err := btcec.ParsePubKey(
+			shimKeyDesc.RawKeyBytes, btcec.S256(),
+		)
+		if err != nil {
+			return nil, err
+		}
+		if !localKeyDesc.PubKey.IsEqual(localKey) {
+			return nil, fmt.Errorf("KeyLocator does not match " +
+				"attached raw pubkey")
+		}
 	}
 
 	// With all the parts assembled, we can now make the canned assembler
 	// to pass into the wallet.
-	return chanfunding.NewCannedAssembler(
+	assembler := chanfunding.NewCannedAssembler(
 		chanPointShim.ThawHeight, *chanPoint,
 		btcutil.Amount(chanPointShim.Amt), &localKeyDesc,
 		remoteKey, initiator,
-	), nil
+	)
+
+	// If the final funding transaction was passed as well, we make sure it
+	// actually creates the funding output of the channel.
+	if len(chanPointShim.FundingTx) > 0 {
+		var fundingTx wire.MsgTx
+		err := fundingTx.Deserialize(
+			bytes.NewReader(chanPointShim.FundingTx),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse funding tx: %v",
+				err)
+		}
+
+		if err := assembler.VerifyFundingTx(&fundingTx); err != nil {
+			return nil, fmt.Errorf("invalid funding tx: %v", err)
+		}
+	}
+
+	return assembler, nil
 }
 
 // newFundingShimAssembler returns a new fully populated
@@ -1729,8 +2178,8 @@ func newPsbtAssembler(req *lnrpc.OpenChannelRequest, normalizedMinConfs int32,
 	// With all the parts assembled, we can now make the canned assembler
 	// to pass into the wallet.
 	return chanfunding.NewPsbtAssembler(
-		btcutil.Amount(req.LocalFundingAmount), packet, netParams,
-		!psbtShim.NoPublish,
+		psbtShim.ThawHeight, btcutil.Amount(req.LocalFundingAmount),
+		packet, netParams, !psbtShim.NoPublish,
 	), nil
 }
 
@@ -1749,18 +2198,134 @@ func (r *rpcServer) canOpenChannel() error {
 		return err
 	}
 	if !isSynced {
-		return errors.New("channels cannot be created before the " +
-			"wallet is fully synced")
+		return ErrWalletNotSynced
 	}
 
 	return nil
 }
 
-// praseOpenChannelReq parses an OpenChannelRequest message into the server's
-// native openChanReq struct. The logic is abstracted so that it can be shared
-// between OpenChannel and OpenChannelSync.
-func (r *rpcServer) parseOpenChannelReq(in *lnrpc.OpenChannelRequest,
-	isSync bool) (*openChanReq, error) {
+// fundingRPCError converts an error that caused a funding flow to fail into a
+// gRPC status error. The status carries the classified failure as a
+// lnrpc.FundingFailure detail, so clients can react to it programmatically.
+// Errors that can't be classified are returned unmodified.
+func fundingRPCError(err error) error {
+	failCode, remote := fundingFailure(err)
+	if failCode == lnrpc.FundingFailureCode_UNKNOWN_FUNDING_FAILURE {
+		return err
+	}
+
+	var code codes.Code
+	switch failCode {
+	case lnrpc.FundingFailureCode_CHAIN_NOT_SYNCED,
+		lnrpc.FundingFailureCode_MAX_PENDING_CHANNELS,
+		lnrpc.FundingFailureCode_NODE_DRAINING,
+		lnrpc.FundingFailureCode_FEE_RATE_TOO_HIGH:
+
+		code = codes.Unavailable
+
+	case lnrpc.FundingFailureCode_PSBT_TIMEOUT,
+		lnrpc.FundingFailureCode_RESERVATION_TIMEOUT:
+
+		code = codes.DeadlineExceeded
+
+	case lnrpc.FundingFailureCode_INSUFFICIENT_FUNDS:
+		code = codes.ResourceExhausted
+
+	default:
+		code = codes.FailedPrecondition
+	}
+
+	st, detailErr := status.New(code, err.Error()).WithDetails(
+		&lnrpc.FundingFailure{
+			Code:   failCode,
+			Remote: remote,
+		},
+	)
+	if detailErr != nil {
+		rpcsLog.Errorf("Unable to add funding failure details: %v",
+			detailErr)
+		return err
+	}
+
+	return st.Err()
+}
+
+// fundingFailure classifies the error that caused a funding flow to fail. The
+// second return value is true if the flow was failed by the remote peer.
+func fundingFailure(err error) (lnrpc.FundingFailureCode, bool) {
+	var remoteErr *remoteFundingError
+	if errors.As(err, &remoteErr) {
+		fundingErr, ok := lnwire.ParseFundingError(remoteErr.msg.Data)
+		if !ok {
+			return lnrpc.FundingFailureCode_UNKNOWN_FUNDING_FAILURE,
+				true
+		}
+
+		return fundingErrorCode(fundingErr), true
+	}
+
+	// A PSBT flow canceled by the remote is most likely the result of us
+	// not publishing the funding transaction in time.
+	if errors.Is(err, chanfunding.ErrRemoteCanceled) {
+		return lnrpc.FundingFailureCode_PSBT_TIMEOUT, true
+	}
+
+	var (
+		reservationErr  lnwallet.ReservationError
+		fundingErr      lnwire.FundingError
+		insufficientErr *chanfunding.ErrInsufficientFunds
+	)
+	switch {
+	case errors.As(err, &reservationErr):
+		return lnrpc.FundingFailureCode_CONSTRAINTS_REJECTED, false
+
+	case errors.As(err, &fundingErr):
+		return fundingErrorCode(fundingErr), false
+
+	case errors.As(err, &insufficientErr):
+		return lnrpc.FundingFailureCode_INSUFFICIENT_FUNDS, false
+
+	case errors.Is(err, ErrWalletNotSynced):
+		return lnrpc.FundingFailureCode_CHAIN_NOT_SYNCED, false
+
+	case errors.Is(err, ErrFundingFeeTooHigh):
+		return lnrpc.FundingFailureCode_FEE_RATE_TOO_HIGH, false
+
+	case errors.Is(err, ErrPsbtReservationTimeout):
+		return lnrpc.FundingFailureCode_PSBT_TIMEOUT, false
+
+	case errors.Is(err, ErrReservationTimeout):
+		return lnrpc.FundingFailureCode_RESERVATION_TIMEOUT, false
+
+	default:
+		return lnrpc.FundingFailureCode_UNKNOWN_FUNDING_FAILURE, false
+	}
+}
+
+// fundingErrorCode maps a funding error of the wire protocol to its funding
+// failure code.
+func fundingErrorCode(err lnwire.FundingError) lnrpc.FundingFailureCode {
+	switch err {
+	case lnwire.ErrMaxPendingChannels:
+		return lnrpc.FundingFailureCode_MAX_PENDING_CHANNELS
+	case lnwire.ErrSynchronizingChain:
+		return lnrpc.FundingFailureCode_CHAIN_NOT_SYNCED
+	case lnwire.ErrChanTooLarge:
+		return lnrpc.FundingFailureCode_CONSTRAINTS_REJECTED
+	case lnwire.ErrNodeDraining:
+		return lnrpc.FundingFailureCode_NODE_DRAINING
+	case lnwire.ErrChanRejected:
+		return lnrpc.FundingFailureCode_CHANNEL_REJECTED
+	default:
+		return lnrpc.FundingFailureCode_UNKNOWN_FUNDING_FAILURE
+	}
+}
+
+// praseOpenChannelReq parses an OpenChannelRequest message into the server's
+// native openChanReq struct. The logic is abstracted so that it can be shared
+// between OpenChannel and OpenChannelSync.
+func (r *rpcServer) parseOpenChannelReq(in *lnrpc.OpenChannelRequest,
+	isSync bool) (*openChanReq, error) {
 
 	rpcsLog.Debugf("[openchannel] request to NodeKey(%x) "+
 		"allocation(us=%v, them=%v)", in.NodePubkey,
@@ -1860,6 +2425,14 @@ func (r *rpcServer) parseOpenChannelReq(in *lnrpc.OpenChannelRequest,
 		return nil, fmt.Errorf("cannot open channel to self")
 	}
 
+	// Requests shouldn't wait for an offline peer for an unbounded amount
+	// of time.
+	queueTTL := time.Duration(in.QueueTtl) * time.Second
+	if queueTTL > maxOpenChanQueueTTL {
+		return nil, fmt.Errorf("queue ttl of %v exceeds maximum of %v",
+			queueTTL, maxOpenChanQueueTTL)
+	}
+
 	// Based on the passed fee related parameters, we'll determine an
 	// appropriate fee rate for the funding transaction.
 	satPerKw := chainfee.SatPerKVByte(in.SatPerByte * 1000).FeePerKWeight()
@@ -1884,6 +2457,28 @@ func (r *rpcServer) parseOpenChannelReq(in *lnrpc.OpenChannelRequest,
 			err)
 	}
 
+	coinStrategy, err := unmarshallCoinSelectionStrategy(
+		in.CoinSelectionStrategy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// If the caller picked the outputs to fund the channel with, the
+	// funding transaction must be created by our wallet.
+	if len(in.Outpoints) > 0 && in.FundingShim != nil {
+		return nil, fmt.Errorf("outpoints cannot be specified " +
+			"together with a funding shim")
+	}
+	outpoints := make([]wire.OutPoint, 0, len(in.Outpoints))
+	for _, rpcOutpoint := range in.Outpoints {
+		outpoint, err := lnrpc.UnmarshallOutPoint(rpcOutpoint)
+		if err != nil {
+			return nil, fmt.Errorf("invalid outpoint: %v", err)
+		}
+		outpoints = append(outpoints, *outpoint)
+	}
+
 	// Instruct the server to trigger the necessary events to attempt to
 	// open a new channel. A stream is returned in place, this stream will
 	// be used to consume updates of the state of the pending channel.
@@ -1901,16 +2496,46 @@ func (r *rpcServer) parseOpenChannelReq(in *lnrpc.OpenChannelRequest,
 		maxValueInFlight: maxValue,
 		maxHtlcs:         maxHtlcs,
 		maxLocalCsv:      uint16(in.MaxLocalCsv),
+
+		coinSelectionStrategy: coinStrategy,
+		outpoints:             outpoints,
+		fundingAccount:        in.FundingAccount,
+		queueTTL:              queueTTL,
 	}, nil
 }
 
+// unmarshallCoinSelectionStrategy converts a coin selection strategy from its
+// RPC representation into the one used by the chanfunding package.
+func unmarshallCoinSelectionStrategy(
+	strategy lnrpc.CoinSelectionStrategy) (chanfunding.CoinSelectionStrategy,
+	error) {
+
+	switch strategy {
+	case lnrpc.CoinSelectionStrategy_STRATEGY_USE_GLOBAL_CONFIG:
+		return chanfunding.CoinSelectionDefault, nil
+
+	case lnrpc.CoinSelectionStrategy_STRATEGY_LARGEST:
+		return chanfunding.CoinSelectionLargest, nil
+
+	case lnrpc.CoinSelectionStrategy_STRATEGY_RANDOM:
+		return chanfunding.CoinSelectionRandom, nil
+
+	case lnrpc.CoinSelectionStrategy_STRATEGY_OLDEST:
+		return chanfunding.CoinSelectionOldest, nil
+
+	default:
+		return 0, fmt.Errorf("unknown coin selection strategy: %v",
+			strategy)
+	}
+}
+
 // OpenChannel attempts to open a singly funded channel specified in the
 // request to a remote peer.
 func (r *rpcServer) OpenChannel(in *lnrpc.OpenChannelRequest,
 	updateStream lnrpc.Lightning_OpenChannelServer) error {
 
 	if err := r.canOpenChannel(); err != nil {
-		return err
+		return fundingRPCError(err)
 	}
 
 	req, err := r.parseOpenChannelReq(in, false)
@@ -1960,6 +2585,10 @@ func (r *rpcServer) OpenChannel(in *lnrpc.OpenChannelRequest,
 		}
 	}
 
+	// A request that waits for the peer to come online is canceled once
+	// the client disconnects.
+	req.queueCancel = updateStream.Context().Done()
+
 	updateChan, errChan := r.server.OpenChannel(req)
 
 	var outpoint wire.OutPoint
@@ -1969,7 +2598,7 @@ out:
 		case err := <-errChan:
 			rpcsLog.Errorf("unable to open channel to NodeKey(%x): %v",
 				req.targetPubkey.SerializeCompressed(), err)
-			return err
+			return fundingRPCError(err)
 		case fundingUpdate := <-updateChan:
 			rpcsLog.Tracef("[openchannel] sending update: %v",
 				fundingUpdate)
@@ -2012,7 +2641,7 @@ func (r *rpcServer) OpenChannelSync(ctx context.Context,
 	in *lnrpc.OpenChannelRequest) (*lnrpc.ChannelPoint, error) {
 
 	if err := r.canOpenChannel(); err != nil {
-		return nil, err
+		return nil, fundingRPCError(err)
 	}
 
 	req, err := r.parseOpenChannelReq(in, true)
@@ -2020,13 +2649,17 @@ func (r *rpcServer) OpenChannelSync(ctx context.Context,
 		return nil, err
 	}
 
+	// A request that waits for the peer to come online is canceled once
+	// the client disconnects.
+	req.queueCancel = ctx.Done()
+
 	updateChan, errChan := r.server.OpenChannel(req)
 	select {
 	// If an error occurs them immediately return the error to the client.
 	case err := <-errChan:
 		rpcsLog.Errorf("unable to open channel to NodeKey(%x): %v",
 			req.targetPubkey.SerializeCompressed(), err)
-		return nil, err
+		return nil, fundingRPCError(err)
 
 	// Otherwise, wait for the first channel update. The first update sent
 	// is when the funding transaction is broadcast to the network.
@@ -2051,6 +2684,196 @@ func (r *rpcServer) OpenChannelSync(ctx context.Context,
 	}
 }
 
+// openChannelEstimateTargets are the confirmation targets for which
+// EstimateOpenChannel estimates the cost of opening a channel.
+var openChannelEstimateTargets = []uint32{1, 3, 6}
+
+// EstimateOpenChannel estimates the cost of opening a channel funded by the
+// wallet for a set of confirmation targets. The same coin selection as for
+// OpenChannel is performed, but no coins are locked and no reservation is
+// created.
+func (r *rpcServer) EstimateOpenChannel(ctx context.Context,
+	in *lnrpc.EstimateOpenChannelRequest) (
+	*lnrpc.EstimateOpenChannelResponse, error) {
+
+	localFundingAmt := btcutil.Amount(in.LocalFundingAmount)
+	if localFundingAmt < minChanFundingSize {
+		return nil, fmt.Errorf("channel is too small, the minimum "+
+			"channel size is: %v SAT", int64(minChanFundingSize))
+	}
+
+	minConfs, err := lnrpc.ExtractMinConfs(in.MinConfs, in.SpendUnconfirmed)
+	if err != nil {
+		return nil, err
+	}
+
+	coinStrategy, err := unmarshallCoinSelectionStrategy(
+		in.CoinSelectionStrategy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &lnrpc.EstimateOpenChannelResponse{}
+	for _, target := range openChannelEstimateTargets {
+		feeRate, err := sweep.DetermineFeePerKw(
+			r.server.cc.FeeEstimator, sweep.FeePreference{
+				ConfTarget: target,
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		estimate := &lnrpc.OpenChannelEstimate{
+			TargetConf: int32(target),
+			SatPerByte: int64(feeRate.FeePerKVByte() / 1000),
+		}
+		resp.Estimates = append(resp.Estimates, estimate)
+
+		funding, err := r.server.cc.Wallet.EstimateFunding(
+			localFundingAmt, minConfs, feeRate, false, coinStrategy,
+			in.FundingAccount,
+		)
+
+		// If the wallet doesn't hold enough funds, that's a valid
+		// result of the estimate and not an error of the call.
+		if _, ok := err.(*chanfunding.ErrInsufficientFunds); ok {
+			estimate.Error = err.Error()
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		estimate.CanAfford = true
+		estimate.FundingFeeSat = int64(funding.FundingFee)
+		estimate.ChangeSat = int64(funding.ChangeAmt)
+		estimate.NumInputs = uint32(len(funding.InputCoins))
+	}
+
+	rpcsLog.Debugf("[estimateopenchannel] estimates for local amount "+
+		"%v: %v", localFundingAmt, spew.Sdump(resp.Estimates))
+
+	return resp, nil
+}
+
+// DescribeChannelConstraints returns the constraints the funding manager would
+// propose to a peer when opening a channel of the given size with it.
+func (r *rpcServer) DescribeChannelConstraints(ctx context.Context,
+	in *lnrpc.DescribeChannelConstraintsRequest) (
+	*lnrpc.DescribeChannelConstraintsResponse, error) {
+
+	// The channel size is restricted the same way as for OpenChannel.
+	localFundingAmt := btcutil.Amount(in.LocalFundingAmount)
+	globalFeatureSet := r.server.featureMgr.Get(feature.SetNodeAnn)
+	wumboEnabled := globalFeatureSet.HasFeature(
+		lnwire.WumboChannelsOptional,
+	)
+	if !wumboEnabled && localFundingAmt > MaxFundingAmount {
+		return nil, fmt.Errorf("funding amount is too large, the max "+
+			"channel size is: %v", MaxFundingAmount)
+	}
+	if localFundingAmt < minChanFundingSize {
+		return nil, fmt.Errorf("channel is too small, the minimum "+
+			"channel size is: %v SAT", int64(minChanFundingSize))
+	}
+
+	// Determine the features of the peer, either from the connection to
+	// it or from the request.
+	var remoteFeatures *lnwire.FeatureVector
+	if len(in.NodePubkey) > 0 {
+		nodePubKey, err := btcec.ParsePubKey(in.NodePubkey, btcec.S256())
+		if err != nil {
+			return nil, err
+		}
+
+		peer, err := r.server.FindPeer(nodePubKey)
+		if err != nil {
+			return nil, err
+		}
+		remoteFeatures = peer.RemoteFeatures()
+	} else {
+		features, err := routerrpc.UnmarshalFeatures(in.PeerFeatures)
+		if err != nil {
+			return nil, err
+		}
+		remoteFeatures = features
+	}
+	if remoteFeatures == nil {
+		remoteFeatures = lnwire.EmptyFeatureVector()
+	}
+
+	localFeatures := r.server.featureMgr.Get(feature.SetInit)
+
+	fundingMgr := r.server.fundingMgr
+	constraints, commitType := fundingMgr.describeChannelConstraints(
+		localFundingAmt, localFeatures, remoteFeatures,
+	)
+
+	rpcCommitType := lnrpc.CommitmentType_LEGACY
+	switch commitType {
+	case lnwallet.CommitmentTypeAnchors:
+		rpcCommitType = lnrpc.CommitmentType_ANCHORS
+
+	case lnwallet.CommitmentTypeTweakless:
+		rpcCommitType = lnrpc.CommitmentType_STATIC_REMOTE_KEY
+	}
+
+	return &lnrpc.DescribeChannelConstraintsResponse{
+		RemoteConstraints: createChannelConstraint(
+			&channeldb.ChannelConfig{
+				ChannelConstraints: *constraints,
+			},
+		),
+		CommitmentType: rpcCommitType,
+		MaxLocalCsv:    uint32(fundingMgr.cfg.MaxLocalCSVDelay),
+	}, nil
+}
+
+// ListQueuedChannelOpens returns the channel open requests that wait for their
+// target peer to come online.
+func (r *rpcServer) ListQueuedChannelOpens(ctx context.Context,
+	_ *lnrpc.ListQueuedChannelOpensRequest) (
+	*lnrpc.ListQueuedChannelOpensResponse, error) {
+
+	queued := r.server.openChanQueue.list()
+
+	resp := &lnrpc.ListQueuedChannelOpensResponse{
+		Requests: make([]*lnrpc.QueuedChannelOpen, len(queued)),
+	}
+	for i, request := range queued {
+		req := request.req
+		resp.Requests[i] = &lnrpc.QueuedChannelOpen{
+			NodePubkey: req.targetPubkey.SerializeCompressed(),
+			LocalFundingAmount: int64(req.localFundingAmt),
+			PushSat:            int64(req.pushAmt.ToSatoshis()),
+			Private:            req.private,
+			QueuedAt:           request.queuedAt.Unix(),
+			Expiry:             request.expiry.Unix(),
+			Id:                 request.id,
+		}
+	}
+
+	return resp, nil
+}
+
+// CancelQueuedChannelOpen cancels a channel open request that waits for its
+// target peer to come online.
+func (r *rpcServer) CancelQueuedChannelOpen(ctx context.Context,
+	in *lnrpc.CancelQueuedChannelOpenRequest) (
+	*lnrpc.CancelQueuedChannelOpenResponse, error) {
+
+	if err := r.server.openChanQueue.cancel(in.Id); err != nil {
+		return nil, err
+	}
+
+	rpcsLog.Infof("[cancelqueuedchannelopen] canceled queued channel "+
+		"open %v", in.Id)
+
+	return &lnrpc.CancelQueuedChannelOpenResponse{}, nil
+}
+
 // parseUpfrontShutdownScript attempts to parse an upfront shutdown address.
 // If the address is empty, it returns nil. If it successfully decoded the
 // address, it returns a script that pays out to the address.
@@ -2260,6 +3083,17 @@ func (r *rpcServer) CloseChannel(in *lnrpc.CloseChannelRequest,
 		rpcsLog.Debugf("Target sat/kw for closing transaction: %v",
 			int64(feeRate))
 
+		// If the caller specified a maximum fee rate, it must leave
+		// room for the target fee rate of the negotiation.
+		maxFee := chainfee.SatPerKVByte(
+			in.MaxFeePerVbyte * 1000,
+		).FeePerKWeight()
+		if maxFee != 0 && maxFee < feeRate {
+			return fmt.Errorf("max fee rate of %v sat/vbyte is below "+
+				"the target fee rate of %v", in.MaxFeePerVbyte,
+				feeRate.FeePerKVByte())
+		}
+
 		// Before we attempt the cooperative channel closure, we'll
 		// examine the channel to ensure that it doesn't have a
 		// lingering HTLC.
@@ -2292,8 +3126,22 @@ func (r *rpcServer) CloseChannel(in *lnrpc.CloseChannelRequest,
 			}
 		}
 
+		// The funds will be paid out to either the delivery address
+		// or the upfront shutdown script of the channel, so we'll
+		// make sure the one that applies adheres to our close address
+		// policy before initiating the close.
+		closeScript := deliveryScript
+		if len(closeScript) == 0 {
+			closeScript = channel.LocalShutdownScript
+		}
+		err = r.server.closeAddrPolicy.Validate(closeScript)
+		if err != nil {
+			return fmt.Errorf("invalid close address: %v", err)
+		}
+
 		updateChan, errChan = r.server.htlcSwitch.CloseLink(
-			chanPoint, htlcswitch.CloseRegular, feeRate, deliveryScript,
+			chanPoint, htlcswitch.CloseRegular, feeRate, maxFee,
+			deliveryScript,
 		)
 	}
 out:
@@ -2491,86 +3339,279 @@ func (r *rpcServer) AbandonChannel(_ context.Context,
 	return &lnrpc.AbandonChannelResponse{}, nil
 }
 
-// GetInfo returns general information concerning the lightning node including
-// its identity pubkey, alias, the chains it is connected to, and information
-// concerning the number of open+pending channels.
-func (r *rpcServer) GetInfo(ctx context.Context,
-	in *lnrpc.GetInfoRequest) (*lnrpc.GetInfoResponse, error) {
-
-	serverPeers := r.server.Peers()
+// PinCommitFeeRate sets the minimum and maximum commitment fee rate of a channel
+// we initiated, overriding the fee estimate when proposing new commitment fee
+// rates to the remote party.
+func (r *rpcServer) PinCommitFeeRate(_ context.Context,
+	in *lnrpc.PinCommitFeeRateRequest) (*lnrpc.PinCommitFeeRateResponse,
+	error) {
 
-	openChannels, err := r.server.remoteChanDB.FetchAllOpenChannels()
+	txid, err := GetChanPointFundingTxid(in.GetChannelPoint())
 	if err != nil {
 		return nil, err
 	}
+	index := in.ChannelPoint.OutputIndex
+	chanPoint := wire.NewOutPoint(txid, index)
 
-	var activeChannels uint32
-	for _, channel := range openChannels {
-		chanID := lnwire.NewChanIDFromOutPoint(&channel.FundingOutpoint)
-		if r.server.htlcSwitch.HasActiveLink(chanID) {
-			activeChannels++
-		}
-	}
+	minFeeRate := chainfee.SatPerKWeight(in.MinFeePerKw)
+	maxFeeRate := chainfee.SatPerKWeight(in.MaxFeePerKw)
+	switch {
+	case minFeeRate < 0 || maxFeeRate < 0:
+		return nil, fmt.Errorf("commit fee rate bounds must not be " +
+			"negative")
 
-	inactiveChannels := uint32(len(openChannels)) - activeChannels
+	case minFeeRate != 0 && minFeeRate < chainfee.FeePerKwFloor:
+		return nil, fmt.Errorf("minimum commit fee rate %v is below "+
+			"the fee floor of %v", minFeeRate,
+			chainfee.FeePerKwFloor)
 
-	pendingChannels, err := r.server.remoteChanDB.FetchPendingChannels()
-	if err != nil {
-		return nil, fmt.Errorf("unable to get retrieve pending "+
-			"channels: %v", err)
-	}
-	nPendingChannels := uint32(len(pendingChannels))
+	case maxFeeRate != 0 && maxFeeRate < chainfee.FeePerKwFloor:
+		return nil, fmt.Errorf("maximum commit fee rate %v is below "+
+			"the fee floor of %v", maxFeeRate,
+			chainfee.FeePerKwFloor)
 
-	idPub := r.server.identityECDH.PubKey().SerializeCompressed()
-	encodedIDPub := hex.EncodeToString(idPub)
+	case maxFeeRate != 0 && minFeeRate > maxFeeRate:
+		return nil, fmt.Errorf("minimum commit fee rate %v exceeds "+
+			"maximum commit fee rate %v", minFeeRate, maxFeeRate)
+	}
 
-	bestHash, bestHeight, err := r.server.cc.ChainIO.GetBestBlock()
+	dbChan, err := r.server.remoteChanDB.FetchChannel(*chanPoint)
 	if err != nil {
-		return nil, fmt.Errorf("unable to get best block info: %v", err)
+		return nil, err
 	}
 
-	isSynced, bestHeaderTimestamp, err := r.server.cc.Wallet.IsSynced()
-	if err != nil {
-		return nil, fmt.Errorf("unable to sync PoV of the wallet "+
-			"with current best block in the main chain: %v", err)
+	// Only the initiator of a channel proposes commitment fee rates.
+	if !dbChan.IsInitiator {
+		return nil, fmt.Errorf("channel %v wasn't initiated by us",
+			chanPoint)
 	}
 
-	network := lncfg.NormalizeNetwork(r.cfg.ActiveNetParams.Name)
-	activeChains := make([]*lnrpc.Chain, r.cfg.registeredChains.NumActiveChains())
-	for i, chain := range r.cfg.registeredChains.ActiveChains() {
-		activeChains[i] = &lnrpc.Chain{
-			Chain:   chain.String(),
-			Network: network,
-		}
+	rpcsLog.Infof("[pincommitfee] pinning commit fee rate of "+
+		"ChannelPoint(%v) to min=%v, max=%v", chanPoint, minFeeRate,
+		maxFeeRate)
 
+	// If the channel is active, the link takes care of persisting the
+	// bounds and adjusting the commitment fee rate right away. Otherwise,
+	// the bounds are applied once the link is started.
+	chanID := lnwire.NewChanIDFromOutPoint(chanPoint)
+	link, err := r.server.htlcSwitch.GetLink(chanID)
+	if err == nil {
+		err = link.PinCommitFeeRate(minFeeRate, maxFeeRate)
+	} else {
+		err = dbChan.SetCommitFeeRateBounds(
+			btcutil.Amount(minFeeRate), btcutil.Amount(maxFeeRate),
+		)
 	}
-
-	// Check if external IP addresses were provided to lnd and use them
-	// to set the URIs.
-	nodeAnn, err := r.server.genNodeAnnouncement(false)
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve current fully signed "+
-			"node announcement: %v", err)
-	}
-	addrs := nodeAnn.Addresses
-	uris := make([]string, len(addrs))
-	for i, addr := range addrs {
-		uris[i] = fmt.Sprintf("%s@%s", encodedIDPub, addr.String())
+		return nil, err
 	}
 
-	isGraphSynced := r.server.authGossiper.SyncManager().IsGraphSynced()
+	return &lnrpc.PinCommitFeeRateResponse{}, nil
+}
 
-	features := make(map[uint32]*lnrpc.Feature)
-	sets := r.server.featureMgr.ListSets()
+// defaultPreviewConfTarget is the conf target the sweep fees of a force close
+// preview are estimated for if none is given.
+const defaultPreviewConfTarget = 6
 
-	for _, set := range sets {
-		// Get the a list of lnrpc features for each set we support.
-		featureVector := r.server.featureMgr.Get(set)
-		rpcFeatures := invoicesrpc.CreateRPCFeatures(featureVector)
+// PreviewForceClose reports what a force close of an active channel would look
+// like at the current height, without signing or broadcasting anything.
+func (r *rpcServer) PreviewForceClose(_ context.Context,
+	in *lnrpc.PreviewForceCloseRequest) (*lnrpc.PreviewForceCloseResponse,
+	error) {
 
-		// Add the features to our map of features, allowing over writing of
-		// existing values because features in different sets with the same bit
-		// are duplicated across sets.
+	txid, err := GetChanPointFundingTxid(in.GetChannelPoint())
+	if err != nil {
+		return nil, err
+	}
+	index := in.ChannelPoint.OutputIndex
+	chanPoint := wire.NewOutPoint(txid, index)
+
+	confTarget := in.TargetConf
+	switch {
+	case confTarget < 0:
+		return nil, fmt.Errorf("invalid target conf: %v", confTarget)
+
+	case confTarget == 0:
+		confTarget = defaultPreviewConfTarget
+	}
+
+	dbChan, err := r.server.remoteChanDB.FetchChannel(*chanPoint)
+	if err != nil {
+		return nil, err
+	}
+
+	_, bestHeight, err := r.server.cc.ChainIO.GetBestBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	sweepFeeRate, err := r.server.cc.FeeEstimator.EstimateFeePerKW(
+		uint32(confTarget),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	preview, err := contractcourt.PreviewForceClose(
+		dbChan, uint32(bestHeight), sweepFeeRate,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &lnrpc.PreviewForceCloseResponse{
+		BestHeight:         preview.BestHeight,
+		CommitFeeSat:       int64(preview.CommitFee),
+		CommitWeight:       preview.CommitWeight,
+		CommitFeePerKw:     int64(preview.CommitFeeRate),
+		ToLocalSat:         int64(preview.ToLocal),
+		CsvDelay:           preview.CsvDelay,
+		ToLocalSweepFeeSat: int64(preview.ToLocalSweepFee),
+		HasAnchor:          preview.HasAnchor,
+		AnchorCpfpFeeSat:   int64(preview.AnchorCPFPFee),
+		SweepFeePerKw:      int64(preview.SweepFeeRate),
+		TotalSweepFeeSat:   int64(preview.TotalSweepFee()),
+	}
+	for _, htlc := range preview.Htlcs {
+		resp.Htlcs = append(resp.Htlcs, &lnrpc.ForceCloseHTLC{
+			Incoming:          htlc.Htlc.Incoming,
+			Amount:            int64(htlc.Htlc.Amt.ToSatoshis()),
+			HashLock:          htlc.Htlc.RHash[:],
+			ExpirationHeight:  htlc.Htlc.RefundTimeout,
+			Dust:              htlc.Dust,
+			CltvWaitBlocks:    htlc.CltvWait,
+			SecondLevelFeeSat: int64(htlc.SecondLevelFee),
+			SweepFeeSat:       int64(htlc.SweepFee),
+		})
+	}
+
+	return resp, nil
+}
+
+// UpgradeChannel upgrades the commitment format of an existing channel to
+// anchor outputs in place. The call blocks until the remote peer accepted or
+// rejected the upgrade.
+func (r *rpcServer) UpgradeChannel(_ context.Context,
+	in *lnrpc.UpgradeChannelRequest) (*lnrpc.UpgradeChannelResponse, error) {
+
+	txid, err := GetChanPointFundingTxid(in.GetChannelPoint())
+	if err != nil {
+		return nil, err
+	}
+	index := in.ChannelPoint.OutputIndex
+	chanPoint := wire.NewOutPoint(txid, index)
+
+	dbChan, err := r.server.remoteChanDB.FetchChannel(*chanPoint)
+	if err != nil {
+		return nil, err
+	}
+
+	// The only supported upgrade is to anchor outputs, which also requires
+	// the remote key to be static.
+	newType := dbChan.ChanType | channeldb.SingleFunderTweaklessBit |
+		channeldb.AnchorOutputsBit
+	if !dbChan.ChanType.IsValidUpgrade(newType) {
+		return nil, fmt.Errorf("channel %v can't be upgraded from "+
+			"type %v", chanPoint, dbChan.ChanType)
+	}
+
+	chanID := lnwire.NewChanIDFromOutPoint(chanPoint)
+	link, err := r.server.htlcSwitch.GetLink(chanID)
+	if err != nil {
+		return nil, fmt.Errorf("channel %v is not active: %v",
+			chanPoint, err)
+	}
+
+	rpcsLog.Infof("[upgradechannel] upgrading ChannelPoint(%v) to "+
+		"type %v", chanPoint, newType)
+
+	if err := link.UpgradeChannel(newType); err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.UpgradeChannelResponse{}, nil
+}
+
+// GetInfo returns general information concerning the lightning node including
+// its identity pubkey, alias, the chains it is connected to, and information
+// concerning the number of open+pending channels.
+func (r *rpcServer) GetInfo(ctx context.Context,
+	in *lnrpc.GetInfoRequest) (*lnrpc.GetInfoResponse, error) {
+
+	serverPeers := r.server.Peers()
+
+	openChannels, err := r.server.remoteChanDB.FetchAllOpenChannels()
+	if err != nil {
+		return nil, err
+	}
+
+	var activeChannels uint32
+	for _, channel := range openChannels {
+		chanID := lnwire.NewChanIDFromOutPoint(&channel.FundingOutpoint)
+		if r.server.htlcSwitch.HasActiveLink(chanID) {
+			activeChannels++
+		}
+	}
+
+	inactiveChannels := uint32(len(openChannels)) - activeChannels
+
+	pendingChannels, err := r.server.remoteChanDB.FetchPendingChannels()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get retrieve pending "+
+			"channels: %v", err)
+	}
+	nPendingChannels := uint32(len(pendingChannels))
+
+	idPub := r.server.identityECDH.PubKey().SerializeCompressed()
+	encodedIDPub := hex.EncodeToString(idPub)
+
+	bestHash, bestHeight, err := r.server.cc.ChainIO.GetBestBlock()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get best block info: %v", err)
+	}
+
+	isSynced, bestHeaderTimestamp, err := r.server.cc.Wallet.IsSynced()
+	if err != nil {
+		return nil, fmt.Errorf("unable to sync PoV of the wallet "+
+			"with current best block in the main chain: %v", err)
+	}
+
+	network := lncfg.NormalizeNetwork(r.cfg.ActiveNetParams.Name)
+	activeChains := make([]*lnrpc.Chain, r.cfg.registeredChains.NumActiveChains())
+	for i, chain := range r.cfg.registeredChains.ActiveChains() {
+		activeChains[i] = &lnrpc.Chain{
+			Chain:   chain.String(),
+			Network: network,
+		}
+
+	}
+
+	// Check if external IP addresses were provided to lnd and use them
+	// to set the URIs.
+	nodeAnn, err := r.server.genNodeAnnouncement(false)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve current fully signed "+
+			"node announcement: %v", err)
+	}
+	addrs := nodeAnn.Addresses
+	uris := make([]string, len(addrs))
+	for i, addr := range addrs {
+		uris[i] = fmt.Sprintf("%s@%s", encodedIDPub, addr.String())
+	}
+
+	isGraphSynced := r.server.authGossiper.SyncManager().IsGraphSynced()
+
+	features := make(map[uint32]*lnrpc.Feature)
+	sets := r.server.featureMgr.ListSets()
+
+	for _, set := range sets {
+		// Get the a list of lnrpc features for each set we support.
+		featureVector := r.server.featureMgr.Get(set)
+		rpcFeatures := invoicesrpc.CreateRPCFeatures(featureVector)
+
+		// Add the features to our map of features, allowing over writing of
+		// existing values because features in different sets with the same bit
+		// are duplicated across sets.
 		for bit, feature := range rpcFeatures {
 			features[bit] = feature
 		}
@@ -2620,6 +3661,42 @@ func (r *rpcServer) GetRecoveryInfo(ctx context.Context,
 	}, nil
 }
 
+// GetHealth returns the latest result of every health check run by our
+// liveliness monitor.
+func (r *rpcServer) GetHealth(ctx context.Context,
+	in *lnrpc.GetHealthRequest) (*lnrpc.GetHealthResponse, error) {
+
+	// unixTimestamp converts the given time to a unix timestamp, mapping
+	// the zero time to zero.
+	unixTimestamp := func(t time.Time) int64 {
+		if t.IsZero() {
+			return 0
+		}
+		return t.Unix()
+	}
+
+	results := r.server.livelinessMonitor.Results()
+	resp := &lnrpc.GetHealthResponse{
+		Checks: make([]*lnrpc.HealthCheck, 0, len(results)),
+	}
+	for _, result := range results {
+		check := &lnrpc.HealthCheck{
+			Name:                result.Name,
+			Disabled:            result.Disabled,
+			LastCheck:           unixTimestamp(result.LastCheck),
+			LastSuccess:         unixTimestamp(result.LastSuccess),
+			ConsecutiveFailures: uint32(result.ConsecutiveFailures),
+		}
+		if result.Err != nil {
+			check.Error = result.Err.Error()
+		}
+
+		resp.Checks = append(resp.Checks, check)
+	}
+
+	return resp, nil
+}
+
 // ListPeers returns a verbose listing of all currently active peers.
 func (r *rpcServer) ListPeers(ctx context.Context,
 	in *lnrpc.ListPeersRequest) (*lnrpc.ListPeersResponse, error) {
@@ -2631,6 +3708,8 @@ func (r *rpcServer) ListPeers(ctx context.Context,
 		Peers: make([]*lnrpc.Peer, 0, len(serverPeers)),
 	}
 
+	endorsementStats := r.server.htlcSwitch.EndorsementStats()
+
 	for _, serverPeer := range serverPeers {
 		var (
 			satSent int64
@@ -2691,6 +3770,21 @@ func (r *rpcServer) ListPeers(ctx context.Context,
 			PingTime:  serverPeer.PingTime(),
 			SyncType:  lnrpcSyncType,
 			Features:  features,
+			MessageCounts: marshallMessageCounts(
+				serverPeer.MessageCounts(),
+			),
+		}
+
+		for _, rtt := range serverPeer.PingHistory() {
+			rpcPeer.PingHistory = append(
+				rpcPeer.PingHistory, rtt.Microseconds(),
+			)
+		}
+
+		if stats, ok := endorsementStats[nodePub]; ok {
+			rpcPeer.EndorsementStats = marshallEndorsementStats(
+				stats,
+			)
 		}
 
 		var peerErrors []interface{}
@@ -2752,6 +3846,177 @@ func (r *rpcServer) ListPeers(ctx context.Context,
 	return resp, nil
 }
 
+// marshallMessageCounts converts the message counts of a peer to their rpc
+// representation, ordered by message type.
+func marshallMessageCounts(
+	counts map[lnwire.MessageType]peer.MessageCount) []*lnrpc.MessageTypeCount {
+
+	rpcCounts := make([]*lnrpc.MessageTypeCount, 0, len(counts))
+	for msgType, count := range counts {
+		rpcCounts = append(rpcCounts, &lnrpc.MessageTypeCount{
+			Type:     uint32(msgType),
+			Name:     msgType.String(),
+			Sent:     count.Sent,
+			Received: count.Received,
+		})
+	}
+
+	sort.Slice(rpcCounts, func(i, j int) bool {
+		return rpcCounts[i].Type < rpcCounts[j].Type
+	})
+
+	return rpcCounts
+}
+
+// marshallEndorsementStats converts the endorsement stats of a peer to their
+// rpc representation.
+func marshallEndorsementStats(
+	stats htlcswitch.EndorsementStats) *lnrpc.EndorsementStats {
+
+	return &lnrpc.EndorsementStats{
+		Endorsed:   marshallEndorsementOutcomes(stats.Endorsed),
+		Unendorsed: marshallEndorsementOutcomes(stats.Unendorsed),
+	}
+}
+
+// marshallEndorsementOutcomes converts the outcomes of the htlcs with the same
+// endorsement signal to their rpc representation.
+func marshallEndorsementOutcomes(
+	outcomes htlcswitch.EndorsementOutcomes) *lnrpc.EndorsementOutcomes {
+
+	return &lnrpc.EndorsementOutcomes{
+		Forwarded: outcomes.Forwarded,
+		Settled:   outcomes.Settled,
+		Failed:    outcomes.Failed,
+	}
+}
+
+// reservationMetrics returns the metrics of all active funding reservations
+// that are exported to Prometheus.
+func (r *rpcServer) reservationMetrics() []*monitoring.ReservationMetrics {
+	reservations := r.server.fundingMgr.pendingReservations()
+	metrics := make([]*monitoring.ReservationMetrics, 0, len(reservations))
+	for _, res := range reservations {
+		metrics = append(metrics, &monitoring.ReservationMetrics{
+			Psbt:       res.psbt,
+			Locked:     res.locked,
+			AgeSeconds: time.Since(res.createdAt).Seconds(),
+		})
+	}
+
+	return metrics
+}
+
+// channelMetrics returns the htlc slot usage of all open channels for export to
+// Prometheus.
+func (r *rpcServer) channelMetrics() []*monitoring.ChannelMetrics {
+	openChannels, err := r.server.remoteChanDB.FetchAllOpenChannels()
+	if err != nil {
+		rpcsLog.Errorf("Unable to fetch open channels for metrics: %v",
+			err)
+
+		return nil
+	}
+
+	metrics := make([]*monitoring.ChannelMetrics, 0, len(openChannels))
+	for _, dbChannel := range openChannels {
+		if dbChannel.IsPending {
+			continue
+		}
+
+		incoming, outgoing := htlcSlotsUsed(
+			dbChannel.LocalCommitment.Htlcs,
+		)
+		metrics = append(metrics, &monitoring.ChannelMetrics{
+			ChanPoint:         dbChannel.FundingOutpoint.String(),
+			IncomingSlotsUsed: incoming,
+			IncomingSlotsMax: uint32(
+				dbChannel.RemoteChanCfg.MaxAcceptedHtlcs,
+			),
+			OutgoingSlotsUsed: outgoing,
+			OutgoingSlotsMax: uint32(
+				dbChannel.LocalChanCfg.MaxAcceptedHtlcs,
+			),
+		})
+	}
+
+	return metrics
+}
+
+// htlcSlotsUsed returns the number of commitment slots occupied by incoming and
+// outgoing htlcs.
+func htlcSlotsUsed(htlcs []channeldb.HTLC) (uint32, uint32) {
+	var incoming, outgoing uint32
+	for _, htlc := range htlcs {
+		if htlc.Incoming {
+			incoming++
+		} else {
+			outgoing++
+		}
+	}
+
+	return incoming, outgoing
+}
+
+// notifierMetrics returns the confirmation registration metrics of the chain
+// notifier that are exported to Prometheus.
+func (r *rpcServer) notifierMetrics() *monitoring.NotifierMetrics {
+	reporter, ok := r.server.cc.ChainNotifier.(chainntnfs.StatsReporter)
+	if !ok {
+		return &monitoring.NotifierMetrics{}
+	}
+
+	stats := reporter.Stats()
+	return &monitoring.NotifierMetrics{
+		ConfRegistrations:        stats.ConfRegistrations,
+		SharedConfRegistrations:  stats.SharedConfRegistrations,
+		HistoricalConfDispatches: stats.HistoricalConfDispatches,
+	}
+}
+
+// peerMetrics returns the connection metrics of all connected peers for export
+// to Prometheus.
+func (r *rpcServer) peerMetrics() []*monitoring.PeerMetrics {
+	serverPeers := r.server.Peers()
+	metrics := make([]*monitoring.PeerMetrics, 0, len(serverPeers))
+	for _, serverPeer := range serverPeers {
+		nodePub := serverPeer.PubKey()
+		peerMetrics := &monitoring.PeerMetrics{
+			PubKey:           hex.EncodeToString(nodePub[:]),
+			BytesSent:        serverPeer.BytesSent(),
+			BytesReceived:    serverPeer.BytesReceived(),
+			MessagesSent:     make(map[string]uint64),
+			MessagesReceived: make(map[string]uint64),
+		}
+
+		pings := serverPeer.PingHistory()
+		if len(pings) > 0 {
+			peerMetrics.PingTime = pings[len(pings)-1].Seconds()
+		}
+
+		// Peers are free to send messages of any type, so we bucket
+		// custom and unknown types under a single label each to bound
+		// the cardinality of the exported metrics.
+		for msgType, count := range serverPeer.MessageCounts() {
+			name := msgType.String()
+			switch {
+			case msgType >= lnwire.CustomTypeStart:
+				name = "custom"
+
+			case name == "<unknown>":
+				name = "unknown"
+			}
+
+			peerMetrics.MessagesSent[name] += count.Sent
+			peerMetrics.MessagesReceived[name] += count.Received
+		}
+
+		metrics = append(metrics, peerMetrics)
+	}
+
+	return metrics
+}
+
 // SubscribePeerEvents returns a uni-directional stream (server -> client)
 // for notifying the client of peer online and offline events.
 func (r *rpcServer) SubscribePeerEvents(req *lnrpc.PeerEventSubscription,
@@ -2774,8 +4039,9 @@ func (r *rpcServer) SubscribePeerEvents(req *lnrpc.PeerEventSubscription,
 			switch peerEvent := e.(type) {
 			case peernotifier.PeerOfflineEvent:
 				event = &lnrpc.PeerEvent{
-					PubKey: hex.EncodeToString(peerEvent.PubKey[:]),
-					Type:   lnrpc.PeerEvent_PEER_OFFLINE,
+					PubKey:         hex.EncodeToString(peerEvent.PubKey[:]),
+					Type:           lnrpc.PeerEvent_PEER_OFFLINE,
+					EvictionReason: peerEvent.EvictionReason,
 				}
 
 			case peernotifier.PeerOnlineEvent:
@@ -2797,39 +4063,196 @@ func (r *rpcServer) SubscribePeerEvents(req *lnrpc.PeerEventSubscription,
 	}
 }
 
-// WalletBalance returns total unspent outputs(confirmed and unconfirmed), all
-// confirmed unspent outputs and all unconfirmed unspent outputs under control
-// by the wallet. This method can be modified by having the request specify
-// only witness outputs should be factored into the final output sum.
-// TODO(roasbeef): add async hooks into wallet balance changes
-func (r *rpcServer) WalletBalance(ctx context.Context,
-	in *lnrpc.WalletBalanceRequest) (*lnrpc.WalletBalanceResponse, error) {
+// SendCustomMessage sends a custom message to a connected peer.
+func (r *rpcServer) SendCustomMessage(ctx context.Context,
+	req *lnrpc.SendCustomMessageRequest) (*lnrpc.SendCustomMessageResponse,
+	error) {
 
-	// Get total balance, from txs that have >= 0 confirmations.
-	totalBal, err := r.server.cc.Wallet.ConfirmedBalance(0)
+	peer, err := route.NewVertexFromBytes(req.Peer)
 	if err != nil {
 		return nil, err
 	}
 
-	// Get confirmed balance, from txs that have >= 1 confirmations.
-	// TODO(halseth): get both unconfirmed and confirmed balance in one
-	// call, as this is racy.
-	confirmedBal, err := r.server.cc.Wallet.ConfirmedBalance(1)
+	if req.Type > math.MaxUint16 {
+		return nil, fmt.Errorf("message type %v exceeds the maximum "+
+			"of %v", req.Type, math.MaxUint16)
+	}
+
+	err = r.server.SendCustomMessage(
+		peer, lnwire.MessageType(req.Type), req.Data,
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	// Get unconfirmed balance, from txs with 0 confirmations.
-	unconfirmedBal := totalBal - confirmedBal
+	return &lnrpc.SendCustomMessageResponse{}, nil
+}
 
-	rpcsLog.Debugf("[walletbalance] Total balance=%v (confirmed=%v, "+
-		"unconfirmed=%v)", totalBal, confirmedBal, unconfirmedBal)
+// SubscribeCustomMessages returns a uni-directional stream (server -> client)
+// for notifying the client of custom messages received from peers.
+func (r *rpcServer) SubscribeCustomMessages(
+	req *lnrpc.SubscribeCustomMessagesRequest,
+	stream lnrpc.Lightning_SubscribeCustomMessagesServer) error {
 
-	return &lnrpc.WalletBalanceResponse{
-		TotalBalance:       int64(totalBal),
-		ConfirmedBalance:   int64(confirmedBal),
-		UnconfirmedBalance: int64(unconfirmedBal),
-	}, nil
+	client, err := r.server.SubscribeCustomMessages()
+	if err != nil {
+		return err
+	}
+	defer client.Cancel()
+
+	for {
+		select {
+		case update := <-client.Updates():
+			msg, ok := update.(*CustomMessage)
+			if !ok {
+				return fmt.Errorf("unexpected custom message "+
+					"update: %T", update)
+			}
+
+			err := stream.Send(&lnrpc.CustomMessage{
+				Peer: msg.Peer[:],
+				Type: uint32(msg.Msg.Type),
+				Data: msg.Msg.Data,
+			})
+			if err != nil {
+				return err
+			}
+
+		case <-r.quit:
+			return nil
+		}
+	}
+}
+
+// unmarshallPeerFilterAction converts an RPC peer filter action into its
+// database counterpart.
+func unmarshallPeerFilterAction(
+	action lnrpc.PeerFilterAction) (channeldb.PeerFilterAction, error) {
+
+	switch action {
+	case lnrpc.PeerFilterAction_PEER_FILTER_DENY:
+		return channeldb.PeerFilterDeny, nil
+
+	case lnrpc.PeerFilterAction_PEER_FILTER_ALLOW:
+		return channeldb.PeerFilterAllow, nil
+
+	default:
+		return 0, fmt.Errorf("unknown peer filter action: %v", action)
+	}
+}
+
+// AddPeerFilterRule adds a persisted rule that inbound peer connections are
+// filtered with.
+func (r *rpcServer) AddPeerFilterRule(ctx context.Context,
+	req *lnrpc.AddPeerFilterRuleRequest) (*lnrpc.AddPeerFilterRuleResponse,
+	error) {
+
+	action, err := unmarshallPeerFilterAction(req.Action)
+	if err != nil {
+		return nil, err
+	}
+
+	rule, err := peerfilter.ParseRule(req.Rule, action)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.server.peerFilter.AddRule(rule); err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.AddPeerFilterRuleResponse{}, nil
+}
+
+// RemovePeerFilterRule removes a rule that was added with AddPeerFilterRule.
+func (r *rpcServer) RemovePeerFilterRule(ctx context.Context,
+	req *lnrpc.RemovePeerFilterRuleRequest) (
+	*lnrpc.RemovePeerFilterRuleResponse, error) {
+
+	// The action doesn't matter to identify the rule.
+	rule, err := peerfilter.ParseRule(req.Rule, channeldb.PeerFilterDeny)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.server.peerFilter.RemoveRule(rule); err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.RemovePeerFilterRuleResponse{}, nil
+}
+
+// ListPeerFilterRules returns the rules that inbound peer connections are
+// filtered with.
+func (r *rpcServer) ListPeerFilterRules(ctx context.Context,
+	req *lnrpc.ListPeerFilterRulesRequest) (
+	*lnrpc.ListPeerFilterRulesResponse, error) {
+
+	filter := r.server.peerFilter
+	staticRules, runtimeRules := filter.Rules()
+
+	rpcRules := make(
+		[]*lnrpc.PeerFilterRule, 0,
+		len(staticRules)+len(runtimeRules),
+	)
+	marshallRules := func(rules []*peerfilter.Rule, static bool) {
+		for _, rule := range rules {
+			action := lnrpc.PeerFilterAction_PEER_FILTER_DENY
+			if rule.Action == channeldb.PeerFilterAllow {
+				action = lnrpc.PeerFilterAction_PEER_FILTER_ALLOW
+			}
+
+			rpcRules = append(rpcRules, &lnrpc.PeerFilterRule{
+				Rule:   rule.String(),
+				Action: action,
+				Static: static,
+			})
+		}
+	}
+	marshallRules(staticRules, true)
+	marshallRules(runtimeRules, false)
+
+	return &lnrpc.ListPeerFilterRulesResponse{
+		Rules:       rpcRules,
+		AllowOnly:   filter.AllowOnly(),
+		NumRejected: filter.NumRejected(),
+	}, nil
+}
+
+// WalletBalance returns total unspent outputs(confirmed and unconfirmed), all
+// confirmed unspent outputs and all unconfirmed unspent outputs under control
+// by the wallet. This method can be modified by having the request specify
+// only witness outputs should be factored into the final output sum.
+// TODO(roasbeef): add async hooks into wallet balance changes
+func (r *rpcServer) WalletBalance(ctx context.Context,
+	in *lnrpc.WalletBalanceRequest) (*lnrpc.WalletBalanceResponse, error) {
+
+	// Get total balance, from txs that have >= 0 confirmations. If an
+	// account is specified, only its outputs are taken into account.
+	totalBal, err := r.server.cc.Wallet.ConfirmedBalance(0, in.Account)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get confirmed balance, from txs that have >= 1 confirmations.
+	// TODO(halseth): get both unconfirmed and confirmed balance in one
+	// call, as this is racy.
+	confirmedBal, err := r.server.cc.Wallet.ConfirmedBalance(1, in.Account)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get unconfirmed balance, from txs with 0 confirmations.
+	unconfirmedBal := totalBal - confirmedBal
+
+	rpcsLog.Debugf("[walletbalance] Total balance=%v (confirmed=%v, "+
+		"unconfirmed=%v)", totalBal, confirmedBal, unconfirmedBal)
+
+	return &lnrpc.WalletBalanceResponse{
+		TotalBalance:       int64(totalBal),
+		ConfirmedBalance:   int64(confirmedBal),
+		UnconfirmedBalance: int64(unconfirmedBal),
+	}, nil
 }
 
 // ChannelBalance returns the total available channel flow across all open
@@ -2928,25 +4351,87 @@ func (r *rpcServer) PendingChannels(ctx context.Context,
 
 	resp := &lnrpc.PendingChannelsResponse{}
 
-	// rpcInitiator returns the correct lnrpc initiator for channels where
-	// we have a record of the opening channel.
-	rpcInitiator := func(isInitiator bool) lnrpc.Initiator {
-		if isInitiator {
-			return lnrpc.Initiator_INITIATOR_LOCAL
+	// First, we'll populate the response with all the channels that are
+	// soon to be opened.
+	var err error
+	resp.PendingOpenChannels, err = fetchPendingOpenChannels(
+		r.server.remoteChanDB,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	_, currentHeight, err := r.server.cc.ChainIO.GetBestBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	// Next, we'll examine the channels that are soon to be closed so we
+	// can populate these fields within the response. The details of the
+	// force closed channels are queried from both the nursery and the
+	// resolvers. At the moment this is not an atomic snapshot. This is
+	// planned to be resolved when the nursery is removed and channel
+	// arbitrator will be the single source for these kind of reports.
+	populateForceClose := func(chanPoint *wire.OutPoint,
+		closed *lnrpc.PendingChannelsResponse_ForceClosedChannel) error {
+
+		err := r.nurseryPopulateForceCloseResp(
+			chanPoint, currentHeight, closed,
+		)
+		if err != nil {
+			return err
 		}
 
-		return lnrpc.Initiator_INITIATOR_REMOTE
+		return r.arbitratorPopulateForceCloseResp(
+			chanPoint, currentHeight, closed,
+		)
+	}
+	forceClosingChannels, limboBalance, err := fetchForceClosingChannels(
+		r.server.remoteChanDB, populateForceClose,
+	)
+	if err != nil {
+		return nil, err
 	}
+	resp.PendingForceClosingChannels = forceClosingChannels
+	resp.TotalLimboBalance += limboBalance
 
-	// First, we'll populate the response with all the channels that are
-	// soon to be opened. We can easily fetch this data from the database
-	// and map the db struct to the proto response.
-	pendingOpenChannels, err := r.server.remoteChanDB.FetchPendingChannels()
+	// We'll also fetch all channels that are open, but have had their
+	// commitment broadcasted, meaning they are waiting for the closing
+	// transaction to confirm.
+	waitingCloseChannels, limboBalance, err := fetchWaitingCloseChannels(
+		r.server.remoteChanDB,
+	)
+	if err != nil {
+		return nil, err
+	}
+	resp.WaitingCloseChannels = waitingCloseChannels
+	resp.TotalLimboBalance += limboBalance
+
+	return resp, nil
+}
+
+// rpcInitiator returns the correct lnrpc initiator for channels where we have
+// a record of the opening channel.
+func rpcInitiator(isInitiator bool) lnrpc.Initiator {
+	if isInitiator {
+		return lnrpc.Initiator_INITIATOR_LOCAL
+	}
+
+	return lnrpc.Initiator_INITIATOR_REMOTE
+}
+
+// fetchPendingOpenChannels returns the channels of the passed database whose
+// funding transaction isn't confirmed yet. We can easily fetch this data from
+// the database and map the db struct to the proto response.
+func fetchPendingOpenChannels(db *channeldb.DB) (
+	[]*lnrpc.PendingChannelsResponse_PendingOpenChannel, error) {
+
+	pendingOpenChannels, err := db.FetchPendingChannels()
 	if err != nil {
 		rpcsLog.Errorf("unable to fetch pending channels: %v", err)
 		return nil, err
 	}
-	resp.PendingOpenChannels = make([]*lnrpc.PendingChannelsResponse_PendingOpenChannel,
+	result := make([]*lnrpc.PendingChannelsResponse_PendingOpenChannel,
 		len(pendingOpenChannels))
 	for i, pendingChan := range pendingOpenChannels {
 		pub := pendingChan.IdentityPub.SerializeCompressed()
@@ -2963,7 +4448,7 @@ func (r *rpcServer) PendingChannels(ctx context.Context,
 		commitBaseWeight := blockchain.GetTransactionWeight(utx)
 		commitWeight := commitBaseWeight + input.WitnessCommitmentTxWeight
 
-		resp.PendingOpenChannels[i] = &lnrpc.PendingChannelsResponse_PendingOpenChannel{
+		result[i] = &lnrpc.PendingChannelsResponse_PendingOpenChannel{
 			Channel: &lnrpc.PendingChannelsResponse_PendingChannel{
 				RemoteNodePub:        hex.EncodeToString(pub),
 				ChannelPoint:         pendingChan.FundingOutpoint.String(),
@@ -2982,17 +4467,28 @@ func (r *rpcServer) PendingChannels(ctx context.Context,
 		}
 	}
 
-	_, currentHeight, err := r.server.cc.ChainIO.GetBestBlock()
-	if err != nil {
-		return nil, err
-	}
+	return result, nil
+}
 
-	// Next, we'll examine the channels that are soon to be closed so we
-	// can populate these fields within the response.
-	pendingCloseChannels, err := r.server.remoteChanDB.FetchClosedChannels(true)
+// fetchForceClosingChannels returns the channels of the passed database that
+// were force closed and whose closing transaction is confirmed, but whose
+// outputs aren't fully resolved yet, along with their total limbo balance. The
+// details of each channel are populated by the passed function, if it isn't
+// nil.
+func fetchForceClosingChannels(db *channeldb.DB,
+	populate func(*wire.OutPoint,
+		*lnrpc.PendingChannelsResponse_ForceClosedChannel) error) (
+	[]*lnrpc.PendingChannelsResponse_ForceClosedChannel, int64, error) {
+
+	var (
+		result       []*lnrpc.PendingChannelsResponse_ForceClosedChannel
+		limboBalance int64
+	)
+
+	pendingCloseChannels, err := db.FetchClosedChannels(true)
 	if err != nil {
 		rpcsLog.Errorf("unable to fetch closed channels: %v", err)
-		return nil, err
+		return nil, 0, err
 	}
 	for _, pendingClose := range pendingCloseChannels {
 		// First construct the channel struct itself, this will be
@@ -3018,7 +4514,7 @@ func (r *rpcServer) PendingChannels(ctx context.Context,
 		// not found, or the channel itself, this channel was closed
 		// in a version before we started persisting historical
 		// channels, so we silence the error.
-		historical, err := r.server.remoteChanDB.FetchHistoricalChannel(
+		historical, err := db.FetchHistoricalChannel(
 			&pendingClose.ChanPoint,
 		)
 		switch err {
@@ -3036,7 +4532,7 @@ func (r *rpcServer) PendingChannels(ctx context.Context,
 		// If the error is non-nil, and not due to older versions of lnd
 		// not persisting historical channels, return it.
 		default:
-			return nil, err
+			return nil, 0, err
 		}
 
 		closeTXID := pendingClose.ClosingTXID.String()
@@ -3062,42 +4558,39 @@ func (r *rpcServer) PendingChannels(ctx context.Context,
 				ClosingTxid: closeTXID,
 			}
 
-			// Fetch reports from both nursery and resolvers. At the
-			// moment this is not an atomic snapshot. This is
-			// planned to be resolved when the nursery is removed
-			// and channel arbitrator will be the single source for
-			// these kind of reports.
-			err := r.nurseryPopulateForceCloseResp(
-				&chanPoint, currentHeight, forceClose,
-			)
-			if err != nil {
-				return nil, err
-			}
-
-			err = r.arbitratorPopulateForceCloseResp(
-				&chanPoint, currentHeight, forceClose,
-			)
-			if err != nil {
-				return nil, err
+			if populate != nil {
+				err := populate(&chanPoint, forceClose)
+				if err != nil {
+					return nil, 0, err
+				}
 			}
 
-			resp.TotalLimboBalance += int64(forceClose.LimboBalance)
+			limboBalance += int64(forceClose.LimboBalance)
 
-			resp.PendingForceClosingChannels = append(
-				resp.PendingForceClosingChannels,
-				forceClose,
-			)
+			result = append(result, forceClose)
 		}
 	}
 
-	// We'll also fetch all channels that are open, but have had their
-	// commitment broadcasted, meaning they are waiting for the closing
-	// transaction to confirm.
-	waitingCloseChans, err := r.server.remoteChanDB.FetchWaitingCloseChannels()
+	return result, limboBalance, nil
+}
+
+// fetchWaitingCloseChannels returns the channels of the passed database that
+// are open, but have had their commitment broadcasted, meaning they are
+// waiting for the closing transaction to confirm, along with their total limbo
+// balance.
+func fetchWaitingCloseChannels(db *channeldb.DB) (
+	[]*lnrpc.PendingChannelsResponse_WaitingCloseChannel, int64, error) {
+
+	var (
+		result       []*lnrpc.PendingChannelsResponse_WaitingCloseChannel
+		limboBalance int64
+	)
+
+	waitingCloseChans, err := db.FetchWaitingCloseChannels()
 	if err != nil {
 		rpcsLog.Errorf("unable to fetch channels waiting close: %v",
 			err)
-		return nil, err
+		return nil, 0, err
 	}
 
 	for _, waitingClose := range waitingCloseChans {
@@ -3138,7 +4631,7 @@ func (r *rpcServer) PendingChannels(ctx context.Context,
 
 		// An unexpected error occurred.
 		case err != nil:
-			return nil, err
+			return nil, 0, err
 
 		// There is a pending remote commit. Set its hash in the
 		// response.
@@ -3170,14 +4663,12 @@ func (r *rpcServer) PendingChannels(ctx context.Context,
 
 		// A close tx has been broadcasted, all our balance will be in
 		// limbo until it confirms.
-		resp.WaitingCloseChannels = append(
-			resp.WaitingCloseChannels, waitingCloseResp,
-		)
+		result = append(result, waitingCloseResp)
 
-		resp.TotalLimboBalance += channel.LocalBalance
+		limboBalance += channel.LocalBalance
 	}
 
-	return resp, nil
+	return result, limboBalance, nil
 }
 
 // arbitratorPopulateForceCloseResp populates the pending channels response
@@ -3321,6 +4812,17 @@ func (r *rpcServer) ClosedChannels(ctx context.Context,
 	in *lnrpc.ClosedChannelsRequest) (*lnrpc.ClosedChannelsResponse,
 	error) {
 
+	return fetchClosedChannels(
+		r.server.remoteChanDB, *r.cfg.ActiveNetParams.GenesisHash, in,
+	)
+}
+
+// fetchClosedChannels returns the channels of the passed database that were
+// closed and whose closing transaction is confirmed, filtered by the request.
+func fetchClosedChannels(db *channeldb.DB, chainHash chainhash.Hash,
+	in *lnrpc.ClosedChannelsRequest) (*lnrpc.ClosedChannelsResponse,
+	error) {
+
 	// Show all channels when no filter flags are set.
 	filterResults := in.Cooperative || in.LocalForce ||
 		in.RemoteForce || in.Breach || in.FundingCanceled ||
@@ -3328,7 +4830,7 @@ func (r *rpcServer) ClosedChannels(ctx context.Context,
 
 	resp := &lnrpc.ClosedChannelsResponse{}
 
-	dbChannels, err := r.server.remoteChanDB.FetchClosedChannels(false)
+	dbChannels, err := db.FetchClosedChannels(false)
 	if err != nil {
 		return nil, err
 	}
@@ -3372,7 +4874,9 @@ func (r *rpcServer) ClosedChannels(ctx context.Context,
 			}
 		}
 
-		channel, err := r.createRPCClosedChannel(dbChannel)
+		channel, err := createRPCClosedChannel(
+			db, chainHash, dbChannel,
+		)
 		if err != nil {
 			return nil, err
 		}
@@ -3383,6 +4887,506 @@ func (r *rpcServer) ClosedChannels(ctx context.Context,
 	return resp, nil
 }
 
+// unixTimeOrZero converts a unix timestamp in seconds to a time, mapping zero
+// to a zero time so that it leaves a time window open.
+func unixTimeOrZero(timestamp int64) time.Time {
+	if timestamp == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(timestamp, 0)
+}
+
+// ChannelUptime returns how long an open channel has been monitored for within
+// a time window, and how long its remote peer was online during that time.
+func (r *rpcServer) ChannelUptime(ctx context.Context,
+	in *lnrpc.ChannelUptimeRequest) (*lnrpc.ChannelUptimeResponse, error) {
+
+	// The channel event store is only started once the server is, so we
+	// can't serve any requests before that.
+	if !r.server.Started() {
+		return nil, ErrServerNotActive
+	}
+
+	if in.ChanPoint == nil {
+		return nil, fmt.Errorf("chan_point must be set")
+	}
+
+	txid, err := GetChanPointFundingTxid(in.ChanPoint)
+	if err != nil {
+		return nil, err
+	}
+	chanPoint := wire.OutPoint{
+		Hash:  *txid,
+		Index: in.ChanPoint.OutputIndex,
+	}
+
+	// Look up the channel so that we know which peer it is with.
+	dbChannel, err := r.server.remoteChanDB.FetchChannel(chanPoint)
+	if err != nil {
+		return nil, err
+	}
+
+	peer, err := route.NewVertexFromBytes(
+		dbChannel.IdentityPub.SerializeCompressed(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	uptime, err := r.server.chanEventStore.GetChanUptime(
+		chanPoint, peer, unixTimeOrZero(in.StartTime),
+		unixTimeOrZero(in.EndTime),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.ChannelUptimeResponse{
+		MonitoredSeconds: int64(uptime.Monitored.Seconds()),
+		UptimeSeconds:    int64(uptime.Uptime.Seconds()),
+		UptimePercentage: uptime.Percentage(),
+	}, nil
+}
+
+// LeastReliablePeers returns the peers that we have open channels with, ranked
+// by ascending uptime percentage within a time window.
+func (r *rpcServer) LeastReliablePeers(ctx context.Context,
+	in *lnrpc.LeastReliablePeersRequest) (*lnrpc.LeastReliablePeersResponse,
+	error) {
+
+	// The channel event store is only started once the server is, so we
+	// can't serve any requests before that.
+	if !r.server.Started() {
+		return nil, ErrServerNotActive
+	}
+
+	peers, err := r.server.chanEventStore.LeastReliablePeers(
+		unixTimeOrZero(in.StartTime), unixTimeOrZero(in.EndTime),
+		int(in.NumPeers),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &lnrpc.LeastReliablePeersResponse{
+		Peers: make([]*lnrpc.PeerReliability, 0, len(peers)),
+	}
+	for _, peer := range peers {
+		resp.Peers = append(resp.Peers, &lnrpc.PeerReliability{
+			PubKey:           hex.EncodeToString(peer.Peer[:]),
+			MonitoredSeconds: int64(peer.Monitored.Seconds()),
+			UptimeSeconds:    int64(peer.Uptime.Uptime.Seconds()),
+			UptimePercentage: peer.Percentage(),
+			FlapCount:        int32(peer.FlapCount),
+		})
+	}
+
+	return resp, nil
+}
+
+// ListMonitorEvents returns the closes of the monitored channels that were
+// detected on chain in monitor-only mode. The channels are only monitored by
+// the RPC server of that mode, so this always fails.
+func (r *rpcServer) ListMonitorEvents(ctx context.Context,
+	_ *lnrpc.ListMonitorEventsRequest) (*lnrpc.ListMonitorEventsResponse,
+	error) {
+
+	return nil, errNotMonitorOnly
+}
+
+const (
+	// defaultSwapHookMacaroonTimeout is the default number of seconds the
+	// payment macaroon of a swap hook is valid for.
+	defaultSwapHookMacaroonTimeout = 24 * 60 * 60
+
+	// swapHookPaymentMethod is the only method that can spend funds with
+	// the payment macaroon of a swap hook.
+	swapHookPaymentMethod = "/routerrpc.Router/SendPaymentV2"
+
+	// swapHookMaxPayment is the maximum amount, including fees, of a
+	// single payment made with the payment macaroon of a swap hook, 0.1
+	// BTC.
+	swapHookMaxPayment = lnwire.MilliSatoshi(10000000000)
+
+	// swapHookMaxTotal is the maximum amount, including fees, of all
+	// payments made with the payment macaroon of a swap hook, 0.5 BTC.
+	swapHookMaxTotal = lnwire.MilliSatoshi(50000000000)
+)
+
+// RegisterSwapHook registers a hook of an external liquidity management
+// service that is notified when the local balance of a watched channel exceeds
+// a threshold. If requested, a payment macaroon is baked for the hook under a
+// fresh root key, which is deleted once the stream is closed to revoke the
+// macaroon. The payments made with the macaroon are capped by a budget.
+func (r *rpcServer) RegisterSwapHook(req *lnrpc.SwapHookRequest,
+	updateStream lnrpc.Lightning_RegisterSwapHookServer) error {
+
+	rpcsLog.Debugf("[registerswaphook] name=%v, thresholds=%v", req.Name,
+		len(req.Thresholds))
+
+	if !r.server.Started() {
+		return ErrServerNotActive
+	}
+
+	thresholds := make([]swaphook.Threshold, 0, len(req.Thresholds))
+	for _, threshold := range req.Thresholds {
+		if threshold.ChanPoint == nil {
+			return fmt.Errorf("chan_point must be set")
+		}
+
+		txid, err := GetChanPointFundingTxid(threshold.ChanPoint)
+		if err != nil {
+			return err
+		}
+
+		thresholds = append(thresholds, swaphook.Threshold{
+			ChanPoint: wire.OutPoint{
+				Hash:  *txid,
+				Index: threshold.ChanPoint.OutputIndex,
+			},
+			LocalBalance: btcutil.Amount(threshold.LocalBalanceSat),
+		})
+	}
+
+	hook, err := r.server.swapHooks.Register(req.Name, thresholds)
+	if err != nil {
+		return err
+	}
+	defer hook.Cancel()
+
+	registered := &lnrpc.SwapHookRegistered{}
+	if req.PaymentMacaroon {
+		ctx := updateStream.Context()
+		mac, rootKeyID, err := r.bakeSwapHookMacaroon(
+			ctx, req.MacaroonTimeout,
+		)
+		if err != nil {
+			return err
+		}
+
+		budget := swaphook.NewBudget(
+			swapHookMaxPayment, swapHookMaxTotal,
+		)
+		r.swapHookBudgets.add(rootKeyID, budget)
+		defer func() {
+			r.swapHookBudgets.remove(rootKeyID)
+
+			_, err := r.macService.DeleteMacaroonID(
+				context.Background(), rootKeyID,
+			)
+			if err != nil {
+				rpcsLog.Errorf("Unable to revoke macaroon of "+
+					"swap hook %v: %v", req.Name, err)
+			}
+		}()
+
+		registered.Macaroon = mac
+	}
+
+	err = updateStream.Send(&lnrpc.SwapHookUpdate{
+		Update: &lnrpc.SwapHookUpdate_Registered{
+			Registered: registered,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case event := <-hook.Events():
+			exceeded := &lnrpc.BalanceThresholdExceeded{
+				ChannelPoint:    event.ChanPoint.String(),
+				LocalBalanceSat: int64(event.LocalBalance),
+				ThresholdSat:    int64(event.Threshold),
+				CapacitySat:     int64(event.Capacity),
+			}
+			update := &lnrpc.SwapHookUpdate{
+				Update: &lnrpc.SwapHookUpdate_ThresholdExceeded{
+					ThresholdExceeded: exceeded,
+				},
+			}
+			if err := updateStream.Send(update); err != nil {
+				return err
+			}
+
+		case <-hook.Quit():
+			return swaphook.ErrManagerShuttingDown
+
+		case <-updateStream.Context().Done():
+			return updateStream.Context().Err()
+
+		case <-r.quit:
+			return nil
+		}
+	}
+}
+
+// bakeSwapHookMacaroon bakes a payment macaroon for a swap hook under a fresh
+// root key. The hex encoded macaroon and its root key ID are returned.
+func (r *rpcServer) bakeSwapHookMacaroon(ctx context.Context,
+	timeout uint64) (string, []byte, error) {
+
+	if r.macService == nil {
+		return "", nil, errMacaroonDisabled
+	}
+
+	if timeout == 0 {
+		timeout = defaultSwapHookMacaroonTimeout
+	}
+
+	// Pick a random root key ID that isn't used yet, in the numeric
+	// format of all root key IDs.
+	usedIDs, err := r.macService.ListMacaroonIDs(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+	var rootKeyID []byte
+	for rootKeyID == nil {
+		var idBytes [8]byte
+		if _, err := rand.Read(idBytes[:]); err != nil {
+			return "", nil, err
+		}
+		id := []byte(strconv.FormatUint(
+			binary.BigEndian.Uint64(idBytes[:]), 10,
+		))
+
+		rootKeyID = id
+		for _, usedID := range usedIDs {
+			if bytes.Equal(usedID, id) {
+				rootKeyID = nil
+				break
+			}
+		}
+	}
+
+	newMac, err := r.macService.NewMacaroon(
+		ctx, rootKeyID, swapHookPermissions...,
+	)
+	if err != nil {
+		return "", nil, err
+	}
+
+	mac, err := macaroons.AddConstraints(
+		newMac.M(), macaroons.TimeoutConstraint(int64(timeout)),
+	)
+	if err != nil {
+		return "", nil, err
+	}
+
+	macBytes, err := mac.MarshalBinary()
+	if err != nil {
+		return "", nil, err
+	}
+
+	return hex.EncodeToString(macBytes), rootKeyID, nil
+}
+
+// swapHookBudgets holds the budgets of the payment macaroons baked for swap
+// hooks, keyed by their root key IDs.
+type swapHookBudgets struct {
+	mu      sync.Mutex
+	budgets map[string]*swaphook.Budget
+}
+
+// add registers the budget of the macaroons baked under a root key ID.
+func (s *swapHookBudgets) add(rootKeyID []byte, budget *swaphook.Budget) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.budgets[string(rootKeyID)] = budget
+}
+
+// remove removes the budget of the macaroons baked under a root key ID.
+func (s *swapHookBudgets) remove(rootKeyID []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.budgets, string(rootKeyID))
+}
+
+// lookup returns the budget of the macaroons baked under a root key ID, if
+// there is one.
+func (s *swapHookBudgets) lookup(rootKeyID []byte) (*swaphook.Budget, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	budget, ok := s.budgets[string(rootKeyID)]
+	return budget, ok
+}
+
+// streamInterceptor returns a stream interceptor that charges the payments
+// made with the macaroon of a swap hook to its budget. It must follow the
+// macaroon interceptor, as the macaroon isn't validated again.
+func (s *swapHookBudgets) streamInterceptor(
+	params *chaincfg.Params) grpc.StreamServerInterceptor {
+
+	return func(srv interface{}, ss grpc.ServerStream,
+		info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+
+		if info.FullMethod != swapHookPaymentMethod {
+			return handler(srv, ss)
+		}
+
+		mac, err := macaroons.MacaroonFromContext(ss.Context())
+		if err != nil {
+			return err
+		}
+		rootKeyID, err := macaroonRootKeyID(mac)
+		if err != nil {
+			return err
+		}
+
+		budget, ok := s.lookup(rootKeyID)
+		if !ok {
+			return handler(srv, ss)
+		}
+
+		return handler(srv, &swapHookPaymentStream{
+			ServerStream: ss,
+			budget:       budget,
+			params:       params,
+		})
+	}
+}
+
+// swapHookPaymentStream wraps the stream of a payment made with the macaroon
+// of a swap hook to charge the payment to the budget of the hook.
+type swapHookPaymentStream struct {
+	grpc.ServerStream
+
+	budget *swaphook.Budget
+	params *chaincfg.Params
+}
+
+// RecvMsg receives the payment request and charges its amount and maximum fee
+// to the budget. The payment is rejected if the budget doesn't allow it.
+func (s *swapHookPaymentStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	req, ok := m.(*routerrpc.SendPaymentRequest)
+	if !ok {
+		return fmt.Errorf("unexpected payment request type %T", m)
+	}
+
+	amt, err := lnrpc.UnmarshallAmt(req.Amt, req.AmtMsat)
+	if err != nil {
+		return err
+	}
+	if req.PaymentRequest != "" {
+		payReq, err := zpay32.Decode(req.PaymentRequest, s.params)
+		if err != nil {
+			return err
+		}
+		if payReq.MilliSat != nil {
+			amt = *payReq.MilliSat
+		}
+	}
+
+	feeLimit, err := lnrpc.UnmarshallAmt(req.FeeLimitSat, req.FeeLimitMsat)
+	if err != nil {
+		return err
+	}
+
+	return s.budget.Spend(amt + feeLimit)
+}
+
+// macaroonRootKeyID decodes the root key ID a macaroon was baked under from
+// its identifier.
+func macaroonRootKeyID(mac *macaroon.Macaroon) ([]byte, error) {
+	rawID := mac.Id()
+	if len(rawID) == 0 || rawID[0] != byte(bakery.LatestVersion) {
+		return nil, fmt.Errorf("invalid macaroon version: %x", rawID)
+	}
+
+	decodedID := &lnrpc.MacaroonId{}
+	if err := proto.Unmarshal(rawID[1:], decodedID); err != nil {
+		return nil, fmt.Errorf("unable to decode macaroon id: %v", err)
+	}
+
+	return decodedID.StorageId, nil
+}
+
+// ListPendingReservations returns all funding reservations the funding manager
+// currently tracks.
+func (r *rpcServer) ListPendingReservations(ctx context.Context,
+	_ *lnrpc.ListPendingReservationsRequest) (
+	*lnrpc.ListPendingReservationsResponse, error) {
+
+	rpcsLog.Debugf("[pendingreservations]")
+
+	reservations := r.server.fundingMgr.pendingReservations()
+
+	// Sort the reservations by their age, so the oldest reservations,
+	// which are the most likely to be stuck, come first.
+	sort.Slice(reservations, func(i, j int) bool {
+		return reservations[i].createdAt.Before(
+			reservations[j].createdAt,
+		)
+	})
+
+	now := time.Now()
+	resp := &lnrpc.ListPendingReservationsResponse{
+		Reservations: make(
+			[]*lnrpc.PendingReservation, 0, len(reservations),
+		),
+	}
+	for _, res := range reservations {
+		rpcRes := &lnrpc.PendingReservation{
+			RemoteNodePub: res.peerKey.SerializeCompressed(),
+			PendingChanId: res.pendingChanID[:],
+			Capacity:      int64(res.chanAmt),
+			Initiator:     res.initiator,
+			Psbt:          res.psbt,
+			AgeSeconds:    int64(now.Sub(res.createdAt).Seconds()),
+			Locked:        res.locked,
+		}
+		if !res.locked {
+			rpcRes.IdleSeconds = int64(
+				now.Sub(res.lastUpdated).Seconds(),
+			)
+		}
+
+		resp.Reservations = append(resp.Reservations, rpcRes)
+	}
+
+	return resp, nil
+}
+
+// CancelPendingReservation cancels the funding reservation identified by the
+// remote peer and the pending channel ID, and fails the funding flow with the
+// remote peer.
+func (r *rpcServer) CancelPendingReservation(ctx context.Context,
+	in *lnrpc.CancelPendingReservationRequest) (
+	*lnrpc.CancelPendingReservationResponse, error) {
+
+	peerKey, err := btcec.ParsePubKey(in.RemoteNodePub, btcec.S256())
+	if err != nil {
+		return nil, fmt.Errorf("invalid remote node pubkey: %v", err)
+	}
+
+	if len(in.PendingChanId) != 32 {
+		return nil, fmt.Errorf("pending chan ID must be 32 bytes")
+	}
+	var pendingChanID [32]byte
+	copy(pendingChanID[:], in.PendingChanId)
+
+	rpcsLog.Debugf("[cancelreservation] peer=%x, pending_chan_id=%x",
+		in.RemoteNodePub, pendingChanID[:])
+
+	err = r.server.fundingMgr.cancelPendingReservation(
+		peerKey, pendingChanID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.CancelPendingReservationResponse{}, nil
+}
+
 // ListChannels returns a description of all the open channels that this node
 // is a participant in.
 func (r *rpcServer) ListChannels(ctx context.Context,
@@ -3489,19 +5493,73 @@ func rpcCommitmentType(chanType channeldb.ChannelType) lnrpc.CommitmentType {
 func createChannelConstraint(
 	chanCfg *channeldb.ChannelConfig) *lnrpc.ChannelConstraints {
 
-	return &lnrpc.ChannelConstraints{
-		CsvDelay:          uint32(chanCfg.CsvDelay),
-		ChanReserveSat:    uint64(chanCfg.ChanReserve),
-		DustLimitSat:      uint64(chanCfg.DustLimit),
-		MaxPendingAmtMsat: uint64(chanCfg.MaxPendingAmount),
-		MinHtlcMsat:       uint64(chanCfg.MinHTLC),
-		MaxAcceptedHtlcs:  uint32(chanCfg.MaxAcceptedHtlcs),
+	return &lnrpc.ChannelConstraints{
+		CsvDelay:          uint32(chanCfg.CsvDelay),
+		ChanReserveSat:    uint64(chanCfg.ChanReserve),
+		DustLimitSat:      uint64(chanCfg.DustLimit),
+		MaxPendingAmtMsat: uint64(chanCfg.MaxPendingAmount),
+		MinHtlcMsat:       uint64(chanCfg.MinHTLC),
+		MaxAcceptedHtlcs:  uint32(chanCfg.MaxAcceptedHtlcs),
+	}
+}
+
+// createRPCOpenChannel creates an *lnrpc.Channel from the *channeldb.Channel.
+func createRPCOpenChannel(r *rpcServer, graph *channeldb.ChannelGraph,
+	dbChannel *channeldb.OpenChannel, isActive bool) (*lnrpc.Channel, error) {
+
+	channel, err := marshalOpenChannel(
+		dbChannel, isActive, r.server.htlcSwitch.CircuitLookup(),
+		r.cfg.ActiveNetParams.Params,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// If the server hasn't fully started yet, it's possible that the
+	// channel event store hasn't either, so it won't be able to consume any
+	// requests until then. To prevent blocking, we'll just omit the uptime
+	// related fields for now.
+	if !r.server.Started() {
+		return channel, nil
+	}
+
+	nodePub := dbChannel.IdentityPub
+	peer, err := route.NewVertexFromBytes(nodePub.SerializeCompressed())
+	if err != nil {
+		return nil, err
+	}
+
+	// Query the event store for additional information about the channel.
+	// Do not fail if it is not available, because there is a potential
+	// race between a channel being added to our node and the event store
+	// being notified of it.
+	outpoint := dbChannel.FundingOutpoint
+	info, err := r.server.chanEventStore.GetChanInfo(outpoint, peer)
+	switch err {
+	// If the store does not know about the channel, we just log it.
+	case chanfitness.ErrChannelNotFound:
+		rpcsLog.Infof("channel: %v not found by channel event store",
+			outpoint)
+
+	// If we got our channel info, we further populate the channel.
+	case nil:
+		channel.Uptime = int64(info.Uptime.Seconds())
+		channel.Lifetime = int64(info.Lifetime.Seconds())
+
+	// If we get an unexpected error, we return it.
+	default:
+		return nil, err
 	}
+
+	return channel, nil
 }
 
-// createRPCOpenChannel creates an *lnrpc.Channel from the *channeldb.Channel.
-func createRPCOpenChannel(r *rpcServer, graph *channeldb.ChannelGraph,
-	dbChannel *channeldb.OpenChannel, isActive bool) (*lnrpc.Channel, error) {
+// marshalOpenChannel creates an *lnrpc.Channel from the state of the channel
+// in the database. The forwarding details of its HTLCs are looked up in the
+// passed circuits, and omitted if they are nil.
+func marshalOpenChannel(dbChannel *channeldb.OpenChannel, isActive bool,
+	circuits htlcswitch.CircuitLookup,
+	params *chaincfg.Params) (*lnrpc.Channel, error) {
 
 	nodePub := dbChannel.IdentityPub
 	nodeID := hex.EncodeToString(nodePub.SerializeCompressed())
@@ -3560,6 +5618,8 @@ func createRPCOpenChannel(r *rpcServer, graph *channeldb.ChannelGraph,
 		StaticRemoteKey:       commitmentType == lnrpc.CommitmentType_STATIC_REMOTE_KEY,
 		CommitmentType:        commitmentType,
 		ThawHeight:            dbChannel.ThawHeight,
+		MinCommitFeePerKw:     int64(dbChannel.MinCommitFeeRate),
+		MaxCommitFeePerKw:     int64(dbChannel.MaxCommitFeeRate),
 		LocalConstraints: createChannelConstraint(
 			&dbChannel.LocalChanCfg,
 		),
@@ -3576,12 +5636,12 @@ func createRPCOpenChannel(r *rpcServer, graph *channeldb.ChannelGraph,
 		var rHash [32]byte
 		copy(rHash[:], htlc.RHash[:])
 
-		circuitMap := r.server.htlcSwitch.CircuitLookup()
-
 		var forwardingChannel, forwardingHtlcIndex uint64
 		switch {
+		case circuits == nil:
+
 		case htlc.Incoming:
-			circuit := circuitMap.LookupCircuit(
+			circuit := circuits.LookupCircuit(
 				htlcswitch.CircuitKey{
 					ChanID: dbChannel.ShortChannelID,
 					HtlcID: htlc.HtlcIndex,
@@ -3595,7 +5655,7 @@ func createRPCOpenChannel(r *rpcServer, graph *channeldb.ChannelGraph,
 			}
 
 		case !htlc.Incoming:
-			circuit := circuitMap.LookupOpenCircuit(
+			circuit := circuits.LookupOpenCircuit(
 				htlcswitch.CircuitKey{
 					ChanID: dbChannel.ShortChannelID,
 					HtlcID: htlc.HtlcIndex,
@@ -3629,6 +5689,9 @@ func createRPCOpenChannel(r *rpcServer, graph *channeldb.ChannelGraph,
 		channel.UnsettledBalance += channel.PendingHtlcs[i].Amount
 	}
 
+	channel.IncomingHtlcSlotsUsed, channel.OutgoingHtlcSlotsUsed =
+		htlcSlotsUsed(localCommit.Htlcs)
+
 	// Lookup our balances at height 0, because they will reflect any
 	// push amounts that may have been present when this channel was
 	// created.
@@ -3648,7 +5711,7 @@ func createRPCOpenChannel(r *rpcServer, graph *channeldb.ChannelGraph,
 
 	if len(dbChannel.LocalShutdownScript) > 0 {
 		_, addresses, _, err := txscript.ExtractPkScriptAddrs(
-			dbChannel.LocalShutdownScript, r.cfg.ActiveNetParams.Params,
+			dbChannel.LocalShutdownScript, params,
 		)
 		if err != nil {
 			return nil, err
@@ -3665,47 +5728,13 @@ func createRPCOpenChannel(r *rpcServer, graph *channeldb.ChannelGraph,
 		channel.CloseAddress = addresses[0].String()
 	}
 
-	// If the server hasn't fully started yet, it's possible that the
-	// channel event store hasn't either, so it won't be able to consume any
-	// requests until then. To prevent blocking, we'll just omit the uptime
-	// related fields for now.
-	if !r.server.Started() {
-		return channel, nil
-	}
-
-	peer, err := route.NewVertexFromBytes(nodePub.SerializeCompressed())
-	if err != nil {
-		return nil, err
-	}
-
-	// Query the event store for additional information about the channel.
-	// Do not fail if it is not available, because there is a potential
-	// race between a channel being added to our node and the event store
-	// being notified of it.
-	outpoint := dbChannel.FundingOutpoint
-	info, err := r.server.chanEventStore.GetChanInfo(outpoint, peer)
-	switch err {
-	// If the store does not know about the channel, we just log it.
-	case chanfitness.ErrChannelNotFound:
-		rpcsLog.Infof("channel: %v not found by channel event store",
-			outpoint)
-
-	// If we got our channel info, we further populate the channel.
-	case nil:
-		channel.Uptime = int64(info.Uptime.Seconds())
-		channel.Lifetime = int64(info.Lifetime.Seconds())
-
-	// If we get an unexpected error, we return it.
-	default:
-		return nil, err
-	}
-
 	return channel, nil
 }
 
 // createRPCClosedChannel creates an *lnrpc.ClosedChannelSummary from a
-// *channeldb.ChannelCloseSummary.
-func (r *rpcServer) createRPCClosedChannel(
+// *channeldb.ChannelCloseSummary, looking up its initiators and resolutions in
+// the passed database.
+func createRPCClosedChannel(db *channeldb.DB, chainHash chainhash.Hash,
 	dbChannel *channeldb.ChannelCloseSummary) (*lnrpc.ChannelCloseSummary, error) {
 
 	nodePub := dbChannel.RemotePub
@@ -3720,9 +5749,7 @@ func (r *rpcServer) createRPCClosedChannel(
 
 	// Lookup local and remote cooperative initiators. If these values
 	// are not known they will just return unknown.
-	openInit, closeInitiator, err = r.getInitiators(
-		&dbChannel.ChanPoint,
-	)
+	openInit, closeInitiator, err = getInitiators(db, &dbChannel.ChanPoint)
 	if err != nil {
 		return nil, err
 	}
@@ -3758,9 +5785,7 @@ func (r *rpcServer) createRPCClosedChannel(
 		CloseInitiator:    closeInitiator,
 	}
 
-	reports, err := r.server.remoteChanDB.FetchChannelReports(
-		*r.cfg.ActiveNetParams.GenesisHash, &dbChannel.ChanPoint,
-	)
+	reports, err := db.FetchChannelReports(chainHash, &dbChannel.ChanPoint)
 	switch err {
 	// If the channel does not have its resolver outcomes stored,
 	// ignore it.
@@ -3852,7 +5877,7 @@ func rpcChannelResolution(report *channeldb.ResolverReport) (*lnrpc.Resolution,
 // from the historical channel bucket, so unknown values are returned when the
 // channel is not present (which indicates that it was closed before we started
 // writing channels to the historical close bucket).
-func (r *rpcServer) getInitiators(chanPoint *wire.OutPoint) (
+func getInitiators(db *channeldb.DB, chanPoint *wire.OutPoint) (
 	lnrpc.Initiator,
 	lnrpc.Initiator, error) {
 
@@ -3863,7 +5888,7 @@ func (r *rpcServer) getInitiators(chanPoint *wire.OutPoint) (
 
 	// To get the close initiator for cooperative closes, we need
 	// to get the channel status from the historical channel bucket.
-	histChan, err := r.server.remoteChanDB.FetchHistoricalChannel(chanPoint)
+	histChan, err := db.FetchHistoricalChannel(chanPoint)
 	switch {
 	// The node has upgraded from a version where we did not store
 	// historical channels, and has not closed a channel since. Do
@@ -3962,7 +5987,9 @@ func (r *rpcServer) SubscribeChannelEvents(req *lnrpc.ChannelEventSubscription,
 				}
 
 			case channelnotifier.ClosedChannelEvent:
-				closedChannel, err := r.createRPCClosedChannel(
+				closedChannel, err := createRPCClosedChannel(
+					r.server.remoteChanDB,
+					*r.cfg.ActiveNetParams.GenesisHash,
 					event.CloseSummary,
 				)
 				if err != nil {
@@ -4002,6 +6029,38 @@ func (r *rpcServer) SubscribeChannelEvents(req *lnrpc.ChannelEventSubscription,
 					},
 				}
 
+			case channelnotifier.PrunedReservationEvent:
+				update = &lnrpc.ChannelEventUpdate{
+					Type: lnrpc.ChannelEventUpdate_PRUNED_RESERVATION,
+					Channel: &lnrpc.ChannelEventUpdate_PrunedReservation{
+						PrunedReservation: &lnrpc.PendingReservation{
+							RemoteNodePub: event.PeerPubKey[:],
+							PendingChanId: event.PendingChanID[:],
+							Capacity:      int64(event.Capacity),
+							Initiator:     event.Initiator,
+							Psbt:          event.Psbt,
+						},
+					},
+				}
+
+			case channelnotifier.FundingLockedStalledEvent:
+				chanPoint := event.ChannelPoint
+				update = &lnrpc.ChannelEventUpdate{
+					Type: lnrpc.ChannelEventUpdate_FUNDING_LOCKED_STALLED,
+					Channel: &lnrpc.ChannelEventUpdate_FundingLockedStalled{
+						FundingLockedStalled: &lnrpc.FundingLockedStalled{
+							ChannelPoint: &lnrpc.ChannelPoint{
+								FundingTxid: &lnrpc.ChannelPoint_FundingTxidBytes{
+									FundingTxidBytes: chanPoint.Hash[:],
+								},
+								OutputIndex: chanPoint.Index,
+							},
+							RemoteNodePub: event.PeerPubKey[:],
+							Attempts:      event.Attempts,
+						},
+					},
+				}
+
 			// Completely ignore ActiveLinkEvent as this is explicitly not
 			// exposed to the RPC.
 			case channelnotifier.ActiveLinkEvent:
@@ -4020,6 +6079,101 @@ func (r *rpcServer) SubscribeChannelEvents(req *lnrpc.ChannelEventSubscription,
 	}
 }
 
+// SubscribeEvents returns a uni-directional stream (server -> client) of all
+// events in the node's event log. All stored events after the cursor of the
+// request are sent first, followed by all new events.
+func (r *rpcServer) SubscribeEvents(req *lnrpc.NodeEventSubscription,
+	eventStream lnrpc.Lightning_SubscribeEventsServer) error {
+
+	eventSub, err := r.server.eventBus.SubscribeEvents(req.AfterSeqNum)
+	if err != nil {
+		return err
+	}
+	defer eventSub.Cancel()
+
+	for _, event := range eventSub.Backlog {
+		rpcEvent := marshallNodeEvent(event)
+		if err := eventStream.Send(rpcEvent); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case e := <-eventSub.Updates():
+			event, ok := e.(*channeldb.NodeEvent)
+			if !ok {
+				return fmt.Errorf("unexpected node event: %v",
+					e)
+			}
+
+			rpcEvent := marshallNodeEvent(event)
+			if err := eventStream.Send(rpcEvent); err != nil {
+				return err
+			}
+
+		case <-eventSub.Quit():
+			return eventbus.ErrEventBusShuttingDown
+
+		case <-eventStream.Context().Done():
+			return eventStream.Context().Err()
+
+		case <-r.quit:
+			return nil
+		}
+	}
+}
+
+// marshallNodeEvent converts an event of the node's event log into its rpc
+// representation.
+func marshallNodeEvent(event *channeldb.NodeEvent) *lnrpc.NodeEvent {
+	rpcEvent := &lnrpc.NodeEvent{
+		SeqNum:            event.SeqNum,
+		TimestampNs:       uint64(event.Timestamp.UnixNano()),
+		Type:              lnrpc.NodeEvent_EventType(event.Type),
+		AmtMsat:           uint64(event.Amount),
+		IncomingChannelId: event.IncomingCircuit.ChanID.ToUint64(),
+		IncomingHtlcId:    event.IncomingCircuit.HtlcID,
+		OutgoingChannelId: event.OutgoingCircuit.ChanID.ToUint64(),
+		OutgoingHtlcId:    event.OutgoingCircuit.HtlcID,
+		Reason:            event.Reason,
+	}
+
+	switch event.Type {
+	case channeldb.NodeEventChannelPendingOpen,
+		channeldb.NodeEventChannelOpen,
+		channeldb.NodeEventChannelActive,
+		channeldb.NodeEventChannelInactive,
+		channeldb.NodeEventChannelClosed:
+
+		rpcEvent.ChanPoint = &lnrpc.ChannelPoint{
+			FundingTxid: &lnrpc.ChannelPoint_FundingTxidBytes{
+				FundingTxidBytes: event.ChanPoint.Hash[:],
+			},
+			OutputIndex: event.ChanPoint.Index,
+		}
+
+	case channeldb.NodeEventFundingLockedStalled:
+		rpcEvent.ChanPoint = &lnrpc.ChannelPoint{
+			FundingTxid: &lnrpc.ChannelPoint_FundingTxidBytes{
+				FundingTxidBytes: event.ChanPoint.Hash[:],
+			},
+			OutputIndex: event.ChanPoint.Index,
+		}
+		rpcEvent.PubKey = hex.EncodeToString(event.PubKey[:])
+
+	case channeldb.NodeEventPeerOnline, channeldb.NodeEventPeerOffline,
+		channeldb.NodeEventReservationPruned:
+
+		rpcEvent.PubKey = hex.EncodeToString(event.PubKey[:])
+
+	case channeldb.NodeEventInvoiceAdded, channeldb.NodeEventInvoiceSettled:
+		rpcEvent.PaymentHash = event.PaymentHash[:]
+	}
+
+	return rpcEvent
+}
+
 // paymentStream enables different types of payment streams, such as:
 // lnrpc.Lightning_SendPaymentServer and lnrpc.Lightning_SendToRouteServer to
 // execute sendPayment. We use this struct as a sort of bridge to enable code
@@ -4718,18 +6872,15 @@ func (r *rpcServer) sendPaymentSync(ctx context.Context,
 	}, nil
 }
 
-// AddInvoice attempts to add a new invoice to the invoice database. Any
-// duplicated invoices are rejected, therefore all invoices *must* have a
-// unique payment preimage.
-func (r *rpcServer) AddInvoice(ctx context.Context,
-	invoice *lnrpc.Invoice) (*lnrpc.AddInvoiceResponse, error) {
-
+// addInvoiceConfig returns the dependencies for creating and regenerating
+// invoices.
+func (r *rpcServer) addInvoiceConfig() *invoicesrpc.AddInvoiceConfig {
 	defaultDelta := r.cfg.Bitcoin.TimeLockDelta
 	if r.cfg.registeredChains.PrimaryChain() == chainreg.LitecoinChain {
 		defaultDelta = r.cfg.Litecoin.TimeLockDelta
 	}
 
-	addInvoiceCfg := &invoicesrpc.AddInvoiceConfig{
+	return &invoicesrpc.AddInvoiceConfig{
 		AddInvoice:        r.server.invoices.AddInvoice,
 		IsChannelActive:   r.server.htlcSwitch.HasActiveLink,
 		ChainParams:       r.cfg.ActiveNetParams.Params,
@@ -4740,7 +6891,18 @@ func (r *rpcServer) AddInvoice(ctx context.Context,
 		GenInvoiceFeatures: func() *lnwire.FeatureVector {
 			return r.server.featureMgr.Get(feature.SetInvoice)
 		},
+		LookupInvoice:  r.server.invoices.LookupInvoice,
+		ReissueInvoice: r.server.invoices.ReissueInvoice,
 	}
+}
+
+// AddInvoice attempts to add a new invoice to the invoice database. Any
+// duplicated invoices are rejected, therefore all invoices *must* have a
+// unique payment preimage.
+func (r *rpcServer) AddInvoice(ctx context.Context,
+	invoice *lnrpc.Invoice) (*lnrpc.AddInvoiceResponse, error) {
+
+	addInvoiceCfg := r.addInvoiceConfig()
 
 	value, err := lnrpc.UnmarshallAmt(invoice.Value, invoice.ValueMsat)
 	if err != nil {
@@ -4755,6 +6917,8 @@ func (r *rpcServer) AddInvoice(ctx context.Context,
 		FallbackAddr:    invoice.FallbackAddr,
 		CltvExpiry:      invoice.CltvExpiry,
 		Private:         invoice.Private,
+		MppTimeout:      time.Duration(invoice.MppTimeout) * time.Second,
+		RequireApproval: invoice.RequireApproval,
 	}
 
 	if invoice.RPreimage != nil {
@@ -4779,6 +6943,34 @@ func (r *rpcServer) AddInvoice(ctx context.Context,
 	}, nil
 }
 
+// RegenerateInvoice re-issues an open invoice with a new payment request that
+// has fresh route hints and a new expiry, while preserving the payment hash,
+// amount and the other terms of the invoice.
+func (r *rpcServer) RegenerateInvoice(ctx context.Context,
+	req *lnrpc.RegenerateInvoiceRequest) (*lnrpc.RegenerateInvoiceResponse,
+	error) {
+
+	hash, err := lntypes.MakeHash(req.RHash)
+	if err != nil {
+		return nil, err
+	}
+
+	dbInvoice, err := invoicesrpc.RegenerateInvoice(
+		ctx, r.addInvoiceConfig(), hash, req.Expiry,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcsLog.Infof("Regenerated invoice %v", hash)
+
+	return &lnrpc.RegenerateInvoiceResponse{
+		RHash:          hash[:],
+		PaymentRequest: string(dbInvoice.PaymentRequest),
+		AddIndex:       dbInvoice.AddIndex,
+	}, nil
+}
+
 // LookupInvoice attempts to look up an invoice according to its payment hash.
 // The passed payment hash *must* be exactly 32 bytes, if not an error is
 // returned.
@@ -4999,9 +7191,98 @@ func (r *rpcServer) GetTransactions(ctx context.Context,
 		return nil, err
 	}
 
+	// If a label prefix is set, we only return the transactions whose
+	// label matches it.
+	if req.LabelPrefix != "" {
+		filtered := make(
+			[]*lnwallet.TransactionDetail, 0, len(transactions),
+		)
+		for _, tx := range transactions {
+			if !strings.HasPrefix(tx.Label, req.LabelPrefix) {
+				continue
+			}
+
+			filtered = append(filtered, tx)
+		}
+		transactions = filtered
+	}
+
+	if req.IncludeWatchOnly {
+		watchOnlyTxns, err := r.watchOnlyTransactions(
+			req.StartHeight, endHeight,
+		)
+		if err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, watchOnlyTxns...)
+	}
+
 	return lnrpc.RPCTransactionDetails(transactions), nil
 }
 
+// watchOnlyTransactions returns the transactions paying to or spending from
+// watch-only scripts that confirmed between the given heights, inclusive. An
+// end height of -1 includes all transactions up to the chain tip.
+func (r *rpcServer) watchOnlyTransactions(startHeight,
+	endHeight int32) ([]*lnwallet.TransactionDetail, error) {
+
+	_, bestHeight, err := r.server.cc.ChainIO.GetBestBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	if endHeight == btcwallet.UnconfirmedHeight {
+		endHeight = bestHeight
+	}
+	if startHeight > endHeight {
+		startHeight, endHeight = endHeight, startHeight
+	}
+
+	txns, err := r.server.watchOnlyTracker.Transactions()
+	if err != nil {
+		return nil, err
+	}
+
+	var details []*lnwallet.TransactionDetail
+	for _, txn := range txns {
+		height := int32(txn.Height)
+		if height < startHeight || height > endHeight {
+			continue
+		}
+
+		var rawTx bytes.Buffer
+		if err := txn.Tx.Serialize(&rawTx); err != nil {
+			return nil, err
+		}
+
+		var destAddrs []btcutil.Address
+		for _, txOut := range txn.Tx.TxOut {
+			_, addrs, _, err := txscript.ExtractPkScriptAddrs(
+				txOut.PkScript, r.cfg.ActiveNetParams.Params,
+			)
+			if err != nil {
+				continue
+			}
+			destAddrs = append(destAddrs, addrs...)
+		}
+
+		blockHash := txn.BlockHash
+		details = append(details, &lnwallet.TransactionDetail{
+			Hash:             txn.Tx.TxHash(),
+			Value:            txn.Amount,
+			NumConfirmations: bestHeight - height + 1,
+			BlockHash:        &blockHash,
+			BlockHeight:      height,
+			Timestamp:        txn.Timestamp.Unix(),
+			DestAddresses:    destAddrs,
+			RawTx:            rawTx.Bytes(),
+			WatchOnly:        true,
+		})
+	}
+
+	return details, nil
+}
+
 // DescribeGraph returns a description of the latest graph state from the PoV
 // of the node. The graph information is partitioned into two components: all
 // the nodes/vertexes, and all the edges that connect the vertexes themselves.
@@ -5438,13 +7719,169 @@ func (r *rpcServer) GetNetworkInfo(ctx context.Context,
 	return netInfo, nil
 }
 
-// StopDaemon will send a shutdown request to the interrupt handler, triggering
-// a graceful shutdown of the daemon.
-func (r *rpcServer) StopDaemon(ctx context.Context,
-	_ *lnrpc.StopRequest) (*lnrpc.StopResponse, error) {
+// PruneGraph removes all zombie channels and unconnected nodes from the channel
+// graph and returns the number of removed channels and nodes.
+func (r *rpcServer) PruneGraph(ctx context.Context,
+	req *lnrpc.PruneGraphRequest) (*lnrpc.PruneGraphResponse, error) {
+
+	rpcsLog.Infof("[prunegraph] compact_zombies=%v", req.CompactZombies)
+
+	result, err := r.server.chanRouter.PruneZombieChans(req.CompactZombies)
+	if err != nil {
+		return nil, err
+	}
+
+	numZombies, err := r.server.localChanDB.ChannelGraph().NumZombies()
+	if err != nil {
+		return nil, fmt.Errorf("unable to count zombie channels: %v",
+			err)
+	}
+
+	return &lnrpc.PruneGraphResponse{
+		NumPrunedChannels:   uint32(result.NumChannelsPruned),
+		NumPrunedNodes:      uint32(result.NumNodesPruned),
+		NumCompactedZombies: uint32(result.NumZombiesCompacted),
+		NumZombieChans:      numZombies,
+	}, nil
+}
+
+// StopDaemon will send a shutdown request to the interrupt handler, triggering
+// a graceful shutdown of the daemon.
+func (r *rpcServer) StopDaemon(ctx context.Context,
+	_ *lnrpc.StopRequest) (*lnrpc.StopResponse, error) {
+
+	signal.RequestShutdown()
+	return &lnrpc.StopResponse{}, nil
+}
+
+const (
+	// defaultDrainTimeout is the default amount of time we wait for the
+	// HTLCs in flight to be resolved while draining.
+	defaultDrainTimeout = 5 * time.Minute
+
+	// drainPollInterval is the interval at which we check whether all
+	// HTLCs in flight have been resolved while draining.
+	drainPollInterval = time.Second
+)
+
+// DrainNode puts the daemon into drain mode, in which no new HTLCs or channels
+// are accepted, and waits for all HTLCs in flight to be resolved before
+// triggering a graceful shutdown.
+func (r *rpcServer) DrainNode(ctx context.Context,
+	in *lnrpc.DrainNodeRequest) (*lnrpc.DrainNodeResponse, error) {
+
+	timeout := defaultDrainTimeout
+	if in.TimeoutSec != 0 {
+		timeout = time.Duration(in.TimeoutSec) * time.Second
+	}
+
+	rpcsLog.Infof("[drainnode] entering drain mode, waiting up to %v for "+
+		"htlcs in flight to resolve", timeout)
+
+	r.server.htlcSwitch.SetDraining(true)
+
+	numHtlcs, err := r.waitForHtlcDrain(ctx, timeout)
+	if err != nil {
+		r.server.htlcSwitch.SetDraining(false)
+		return nil, err
+	}
+
+	resp := &lnrpc.DrainNodeResponse{
+		PendingHtlcs: uint32(numHtlcs),
+	}
+
+	// If there are still HTLCs in flight, the caller can choose to abort
+	// the shutdown, in which case we resume normal operation.
+	if numHtlcs > 0 && in.AbortOnTimeout {
+		rpcsLog.Warnf("[drainnode] %v htlcs still in flight after %v, "+
+			"leaving drain mode", numHtlcs, timeout)
+
+		r.server.htlcSwitch.SetDraining(false)
+		return resp, nil
+	}
+
+	rpcsLog.Infof("[drainnode] drained with %v htlcs in flight, shutting "+
+		"down", numHtlcs)
+
+	signal.RequestShutdown()
+	resp.ShuttingDown = true
+
+	return resp, nil
+}
+
+// waitForHtlcDrain waits until no HTLCs are in flight on any of our open
+// channels, or until the timeout expires. The number of HTLCs still in flight
+// is returned.
+func (r *rpcServer) waitForHtlcDrain(ctx context.Context,
+	timeout time.Duration) (int, error) {
+
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+
+	deadline := time.After(timeout)
+	for {
+		numHtlcs, err := r.server.numPendingHtlcs()
+		if err != nil {
+			return 0, err
+		}
+		if numHtlcs == 0 {
+			return 0, nil
+		}
+
+		select {
+		case <-ticker.C:
+
+		case <-deadline:
+			return numHtlcs, nil
+
+		case <-ctx.Done():
+			return 0, ctx.Err()
+
+		case <-r.quit:
+			return 0, errors.New("rpc server shutting down")
+		}
+	}
+}
+
+// ReloadConfig re-reads the daemon's configuration and applies the options
+// that can be changed at runtime.
+func (r *rpcServer) ReloadConfig(ctx context.Context,
+	in *lnrpc.ReloadConfigRequest) (*lnrpc.ReloadConfigResponse, error) {
+
+	rpcsLog.Infof("[reloadconfig] reloading runtime config options")
+
+	updated, err := r.server.reloadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.ReloadConfigResponse{
+		UpdatedOptions: updated,
+	}, nil
+}
+
+// RotateTLSCertificate re-generates the TLS certificate of the gRPC and REST
+// listeners and starts serving it to new connections.
+func (r *rpcServer) RotateTLSCertificate(ctx context.Context,
+	_ *lnrpc.RotateTLSCertificateRequest) (
+	*lnrpc.RotateTLSCertificateResponse, error) {
+
+	rpcsLog.Infof("[rotatetlscert] rotating TLS certificate")
+
+	if err := r.tlsRotator.Rotate(); err != nil {
+		return nil, err
+	}
+
+	newCert := r.tlsRotator.Certificate()
+	certPEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: newCert.Raw,
+	})
 
-	signal.RequestShutdown()
-	return &lnrpc.StopResponse{}, nil
+	return &lnrpc.RotateTLSCertificateResponse{
+		Cert:   string(certPEM),
+		Expiry: newCert.NotAfter.Unix(),
+	}, nil
 }
 
 // SubscribeChannelGraph launches a streaming RPC that allows the caller to
@@ -5620,17 +8057,60 @@ func (r *rpcServer) ListPayments(ctx context.Context,
 
 // DeleteAllPayments deletes all outgoing payments from DB.
 func (r *rpcServer) DeleteAllPayments(ctx context.Context,
-	_ *lnrpc.DeleteAllPaymentsRequest) (*lnrpc.DeleteAllPaymentsResponse, error) {
+	req *lnrpc.DeleteAllPaymentsRequest) (*lnrpc.DeleteAllPaymentsResponse, error) {
 
-	rpcsLog.Debugf("[DeleteAllPayments]")
+	rpcsLog.Debugf("[DeleteAllPayments] failed_payments_only=%v, "+
+		"failed_htlcs_only=%v", req.FailedPaymentsOnly,
+		req.FailedHtlcsOnly)
 
-	if err := r.server.remoteChanDB.DeletePayments(); err != nil {
+	_, err := r.server.remoteChanDB.PrunePayments(
+		channeldb.PaymentPruneQuery{
+			FailedOnly:      req.FailedPaymentsOnly,
+			FailedHtlcsOnly: req.FailedHtlcsOnly,
+		},
+	)
+	if err != nil {
 		return nil, err
 	}
 
 	return &lnrpc.DeleteAllPaymentsResponse{}, nil
 }
 
+// PrunePayments deletes the completed outgoing payments, or their failed htlc
+// attempts, that are older than the given age or exceed the given count.
+func (r *rpcServer) PrunePayments(ctx context.Context,
+	req *lnrpc.PrunePaymentsRequest) (*lnrpc.PrunePaymentsResponse, error) {
+
+	rpcsLog.Debugf("[PrunePayments] max_age_sec=%v, keep_last=%v, "+
+		"failed_payments_only=%v, failed_htlcs_only=%v",
+		req.MaxAgeSec, req.KeepLast, req.FailedPaymentsOnly,
+		req.FailedHtlcsOnly)
+
+	if req.MaxAgeSec > math.MaxInt64/uint64(time.Second) {
+		return nil, fmt.Errorf("max age of %v seconds too large",
+			req.MaxAgeSec)
+	}
+
+	query := channeldb.PaymentPruneQuery{
+		FailedOnly:      req.FailedPaymentsOnly,
+		FailedHtlcsOnly: req.FailedHtlcsOnly,
+		KeepLast:        req.KeepLast,
+	}
+	if req.MaxAgeSec != 0 {
+		maxAge := time.Duration(req.MaxAgeSec) * time.Second
+		query.CreatedBefore = time.Now().Add(-maxAge)
+	}
+
+	numPruned, err := r.server.remoteChanDB.PrunePayments(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.PrunePaymentsResponse{
+		NumPruned: uint64(numPruned),
+	}, nil
+}
+
 // DebugLevel allows a caller to programmatically set the logging verbosity of
 // lnd. The logging can be targeted according to a coarse daemon-wide logging
 // level, or in a granular fashion to specify the logging for a target
@@ -5660,6 +8140,68 @@ func (r *rpcServer) DebugLevel(ctx context.Context,
 	return &lnrpc.DebugLevelResponse{}, nil
 }
 
+// SubsystemLogLevels returns the current logging level of every sub-system
+// logger, after optionally changing the logging level of a single sub-system,
+// either permanently or temporarily.
+func (r *rpcServer) SubsystemLogLevels(ctx context.Context,
+	req *lnrpc.SubsystemLogLevelsRequest) (
+	*lnrpc.SubsystemLogLevelsResponse, error) {
+
+	switch {
+	// If no sub-system is specified, we'll only return the current logging
+	// levels.
+	case req.Subsystem == "":
+
+	// If a revert timeout is specified, we'll only change the logging
+	// level of the sub-system temporarily.
+	case req.RevertAfterSec > 0:
+		rpcsLog.Infof("[subsystemloglevels] changing debug level of %v "+
+			"to %v for %v seconds", req.Subsystem, req.Level,
+			req.RevertAfterSec)
+
+		err := r.cfg.LogWriter.SetTemporaryLogLevel(
+			req.Subsystem, req.Level,
+			time.Duration(req.RevertAfterSec)*time.Second,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+	default:
+		rpcsLog.Infof("[subsystemloglevels] changing debug level of %v "+
+			"to %v", req.Subsystem, req.Level)
+
+		err := build.ParseAndSetDebugLevels(
+			fmt.Sprintf("%v=%v", req.Subsystem, req.Level),
+			r.cfg.LogWriter,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	levels := r.cfg.LogWriter.SubsystemLogLevels()
+	resp := &lnrpc.SubsystemLogLevelsResponse{
+		SubSystems: make([]*lnrpc.SubsystemLogLevel, 0, len(levels)),
+	}
+	for _, level := range levels {
+		rpcLevel := &lnrpc.SubsystemLogLevel{
+			Subsystem: level.Subsystem,
+			Level:     build.LogLevelName(level.Level),
+		}
+		if !level.RevertTime.IsZero() {
+			rpcLevel.RevertLevel = build.LogLevelName(
+				level.RevertLevel,
+			)
+			rpcLevel.RevertTime = level.RevertTime.Unix()
+		}
+
+		resp.SubSystems = append(resp.SubSystems, rpcLevel)
+	}
+
+	return resp, nil
+}
+
 // DecodePayReq takes an encoded payment request string and attempts to decode
 // it, returning a full description of the conditions encoded within the
 // payment request.
@@ -5729,6 +8271,60 @@ func (r *rpcServer) DecodePayReq(ctx context.Context,
 	}, nil
 }
 
+// ResolveLnurlPay resolves an LNURL-pay target or a lightning address to a
+// payment request of the given amount. If no amount is given and the target
+// accepts a range of amounts, only the range is returned.
+func (r *rpcServer) ResolveLnurlPay(ctx context.Context,
+	req *lnrpc.ResolveLnurlPayRequest) (*lnrpc.ResolveLnurlPayResponse,
+	error) {
+
+	rpcsLog.Tracef("[resolvelnurl] target=%v, amt_msat=%v", req.Target,
+		req.AmtMsat)
+
+	if r.lnurlResolver == nil {
+		return nil, errors.New("resolving lnurls is disabled, start " +
+			"lnd with --lnurl-resolve to enable it")
+	}
+
+	if req.AmtMsat < 0 {
+		return nil, fmt.Errorf("amount must not be negative, got %v",
+			req.AmtMsat)
+	}
+
+	params, err := r.lnurlResolver.FetchPayParams(ctx, req.Target)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &lnrpc.ResolveLnurlPayResponse{
+		MinSendableMsat: int64(params.MinSendable),
+		MaxSendableMsat: int64(params.MaxSendable),
+		Description:     params.Description(),
+		Metadata:        params.Metadata,
+		CommentAllowed:  uint32(params.CommentAllowed),
+	}
+
+	// Without an amount, an invoice can only be requested if the target
+	// accepts a single amount.
+	amt := lnwire.MilliSatoshi(req.AmtMsat)
+	if amt == 0 {
+		if params.MinSendable != params.MaxSendable {
+			return resp, nil
+		}
+		amt = params.MinSendable
+	}
+
+	payReq, _, err := r.lnurlResolver.FetchInvoice(
+		ctx, params, amt, req.Comment,
+	)
+	if err != nil {
+		return nil, err
+	}
+	resp.PaymentRequest = payReq
+
+	return resp, nil
+}
+
 // feeBase is the fixed point that fee rate computation are performed over.
 // Nodes on the network advertise their fee rate using this point as a base.
 // This means that the minimal possible fee rate if 1e-6, or 0.000001, or
@@ -5738,7 +8334,7 @@ const feeBase = 1000000
 // FeeReport allows the caller to obtain a report detailing the current fee
 // schedule enforced by the node globally for each channel.
 func (r *rpcServer) FeeReport(ctx context.Context,
-	_ *lnrpc.FeeReportRequest) (*lnrpc.FeeReportResponse, error) {
+	req *lnrpc.FeeReportRequest) (*lnrpc.FeeReportResponse, error) {
 
 	// TODO(roasbeef): use UnaryInterceptor to add automated logging
 
@@ -5785,46 +8381,6 @@ func (r *rpcServer) FeeReport(ctx context.Context,
 
 	fwdEventLog := r.server.remoteChanDB.ForwardingLog()
 
-	// computeFeeSum is a helper function that computes the total fees for
-	// a particular time slice described by a forwarding event query.
-	computeFeeSum := func(query channeldb.ForwardingEventQuery) (lnwire.MilliSatoshi, error) {
-
-		var totalFees lnwire.MilliSatoshi
-
-		// We'll continue to fetch the next query and accumulate the
-		// fees until the next query returns no events.
-		for {
-			timeSlice, err := fwdEventLog.Query(query)
-			if err != nil {
-				return 0, err
-			}
-
-			// If the timeslice is empty, then we'll return as
-			// we've retrieved all the entries in this range.
-			if len(timeSlice.ForwardingEvents) == 0 {
-				break
-			}
-
-			// Otherwise, we'll tally up an accumulate the total
-			// fees for this time slice.
-			for _, event := range timeSlice.ForwardingEvents {
-				fee := event.AmtIn - event.AmtOut
-				totalFees += fee
-			}
-
-			// We'll now take the last offset index returned as
-			// part of this response, and modify our query to start
-			// at this index. This has a pagination effect in the
-			// case that our query bounds has more than 100k
-			// entries.
-			query.IndexOffset = timeSlice.LastIndexOffset
-		}
-
-		return totalFees, nil
-	}
-
-	now := time.Now()
-
 	// Before we perform the queries below, we'll instruct the switch to
 	// flush any pending events to disk. This ensure we get a complete
 	// snapshot at this particular time.
@@ -5833,37 +8389,73 @@ func (r *rpcServer) FeeReport(ctx context.Context,
 			"events: %v", err)
 	}
 
+	// computeFeeSum is a helper function that computes the total fees for
+	// a particular time range. The summary of the forwarding log is mostly
+	// read from its hourly rollups, so that large ranges don't require
+	// every single forwarding event to be read.
+	computeFeeSum := func(start, end time.Time) (lnwire.MilliSatoshi,
+		error) {
+
+		summary, err := fwdEventLog.Summarize(start, end)
+		if err != nil {
+			return 0, err
+		}
+
+		return summary.Total.Fees, nil
+	}
+
+	now := time.Now()
+
 	// In addition to returning the current fee schedule for each channel.
 	// We'll also perform a series of queries to obtain the total fees
 	// earned over the past day, week, and month.
-	dayQuery := channeldb.ForwardingEventQuery{
-		StartTime:    now.Add(-time.Hour * 24),
-		EndTime:      now,
-		NumMaxEvents: 1000,
-	}
-	dayFees, err := computeFeeSum(dayQuery)
+	dayFees, err := computeFeeSum(now.Add(-time.Hour*24), now)
 	if err != nil {
 		return nil, fmt.Errorf("unable to retrieve day fees: %v", err)
 	}
 
-	weekQuery := channeldb.ForwardingEventQuery{
-		StartTime:    now.Add(-time.Hour * 24 * 7),
-		EndTime:      now,
-		NumMaxEvents: 1000,
+	weekFees, err := computeFeeSum(now.Add(-time.Hour*24*7), now)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve week fees: %v", err)
 	}
-	weekFees, err := computeFeeSum(weekQuery)
+
+	monthFees, err := computeFeeSum(now.Add(-time.Hour*24*30), now)
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve day fees: %v", err)
+		return nil, fmt.Errorf("unable to retrieve month fees: %v", err)
 	}
 
-	monthQuery := channeldb.ForwardingEventQuery{
-		StartTime:    now.Add(-time.Hour * 24 * 30),
-		EndTime:      now,
-		NumMaxEvents: 1000,
+	// Finally, we'll report the fees of any custom windows the caller
+	// asked for.
+	var chanPoints map[lnwire.ShortChannelID]wire.OutPoint
+	if req.PerChannel && len(req.Windows) > 0 {
+		chanPoints, err = fetchChanPoints(r.server.remoteChanDB)
+		if err != nil {
+			return nil, err
+		}
 	}
-	monthFees, err := computeFeeSum(monthQuery)
-	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve day fees: %v", err)
+
+	windowFees := make([]*lnrpc.WindowFeeReport, 0, len(req.Windows))
+	for _, window := range req.Windows {
+		start := time.Unix(int64(window.StartTime), 0)
+		end := now
+		if window.EndTime != 0 {
+			end = time.Unix(int64(window.EndTime), 0)
+		}
+		if end.Before(start) {
+			return nil, fmt.Errorf("window end time %v before "+
+				"start time %v", window.EndTime,
+				window.StartTime)
+		}
+
+		summary, err := fwdEventLog.Summarize(start, end)
+		if err != nil {
+			return nil, fmt.Errorf("unable to retrieve window "+
+				"fees: %v", err)
+		}
+
+		windowFees = append(windowFees, marshallWindowFeeReport(
+			start, end, summary, req.PerChannel, chanPoints,
+		))
 	}
 
 	return &lnrpc.FeeReportResponse{
@@ -5871,17 +8463,108 @@ func (r *rpcServer) FeeReport(ctx context.Context,
 		DayFeeSum:   uint64(dayFees.ToSatoshis()),
 		WeekFeeSum:  uint64(weekFees.ToSatoshis()),
 		MonthFeeSum: uint64(monthFees.ToSatoshis()),
+		WindowFees:  windowFees,
 	}, nil
 }
 
+// marshallWindowFeeReport converts the forwarding summary of a fee report
+// window into its rpc representation, optionally including the revenue of each
+// outgoing channel.
+func marshallWindowFeeReport(start, end time.Time,
+	summary *channeldb.ForwardingSummary, perChannel bool,
+	chanPoints map[lnwire.ShortChannelID]wire.OutPoint) *lnrpc.WindowFeeReport {
+
+	report := &lnrpc.WindowFeeReport{
+		StartTime:   uint64(start.Unix()),
+		EndTime:     uint64(end.Unix()),
+		NumForwards: summary.Total.NumForwards,
+		AmtOutMsat:  uint64(summary.Total.AmtOut),
+		FeeSumMsat:  uint64(summary.Total.Fees),
+	}
+	if !perChannel {
+		return report
+	}
+
+	for chanID, rollup := range summary.Channels {
+		chanFees := &lnrpc.ChannelWindowFees{
+			ChanId:      chanID.ToUint64(),
+			NumForwards: rollup.NumForwards,
+			AmtOutMsat:  uint64(rollup.AmtOut),
+			FeeSumMsat:  uint64(rollup.Fees),
+		}
+		if chanPoint, ok := chanPoints[chanID]; ok {
+			chanFees.ChannelPoint = chanPoint.String()
+		}
+
+		report.ChannelFees = append(report.ChannelFees, chanFees)
+	}
+
+	// Report the channels with the highest revenue first.
+	sort.Slice(report.ChannelFees, func(i, j int) bool {
+		return report.ChannelFees[i].FeeSumMsat >
+			report.ChannelFees[j].FeeSumMsat
+	})
+
+	return report
+}
+
+// fetchChanPoints maps the short channel ids of all open and closed channels
+// to their channel point.
+func fetchChanPoints(db *channeldb.DB) (map[lnwire.ShortChannelID]wire.OutPoint,
+	error) {
+
+	chanPoints := make(map[lnwire.ShortChannelID]wire.OutPoint)
+
+	openChannels, err := db.FetchAllOpenChannels()
+	if err != nil {
+		return nil, err
+	}
+	for _, channel := range openChannels {
+		chanPoints[channel.ShortChanID()] = channel.FundingOutpoint
+	}
+
+	closeSummaries, err := db.FetchClosedChannels(false)
+	if err != nil {
+		return nil, err
+	}
+	for _, summary := range closeSummaries {
+		chanPoints[summary.ShortChanID] = summary.ChanPoint
+	}
+
+	return chanPoints, nil
+}
+
 // minFeeRate is the smallest permitted fee rate within the network. This is
 // derived by the fact that fee rates are computed using a fixed point of
 // 1,000,000. As a result, the smallest representable fee rate is 1e-6, or
 // 0.000001, or 0.0001%.
 const minFeeRate = 1e-6
 
+// validatePolicy checks that the fee rate and timelock delta of a new channel
+// policy are within the allowed bounds.
+func (r *rpcServer) validatePolicy(feeRate float64, timeLockDelta uint32) error {
+	switch {
+	// As a sanity check, if the fee isn't zero, we'll ensure that the
+	// passed fee rate is below 1e-6, or the lowest allowed non-zero fee
+	// rate expressible within the protocol.
+	case feeRate != 0 && feeRate < minFeeRate:
+		return fmt.Errorf("fee rate of %v is too small, min fee "+
+			"rate is %v", feeRate, minFeeRate)
+	}
+
+	// We'll also ensure that the user isn't setting a CLTV delta that
+	// won't give outgoing HTLCs enough time to fully resolve if needed, or
+	// that locks up the funds of forwarded HTLCs for too long.
+	cltvLimits := routing.NewCltvLimits(
+		r.cfg.ActiveNetParams.TargetTimePerBlock,
+	)
+
+	return cltvLimits.ValidateDelta(timeLockDelta)
+}
+
 // UpdateChannelPolicy allows the caller to update the channel forwarding policy
-// for all channels globally, or a particular channel.
+// for all channels globally, all channels with a particular peer, or a
+// particular channel.
 func (r *rpcServer) UpdateChannelPolicy(ctx context.Context,
 	req *lnrpc.PolicyUpdateRequest) (*lnrpc.PolicyUpdateResponse, error) {
 
@@ -5902,24 +8585,71 @@ func (r *rpcServer) UpdateChannelPolicy(ctx context.Context,
 			Hash:  *txid,
 			Index: scope.ChanPoint.OutputIndex,
 		})
+
+	// If we're targeting a peer, we'll target all open channels with it.
+	case *lnrpc.PolicyUpdateRequest_Peer:
+		peerKey, err := btcec.ParsePubKey(scope.Peer, btcec.S256())
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse peer key: %v",
+				err)
+		}
+
+		channels, err := r.server.remoteChanDB.FetchOpenChannels(
+			peerKey,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		// An empty set of target channels would update all channels,
+		// so we'll bail out if there are no channels with the peer.
+		if len(channels) == 0 {
+			return nil, fmt.Errorf("no open channels with peer %x",
+				scope.Peer)
+		}
+
+		for _, channel := range channels {
+			targetChans = append(
+				targetChans, channel.FundingOutpoint,
+			)
+		}
+
 	default:
 		return nil, fmt.Errorf("unknown scope: %v", scope)
 	}
 
-	switch {
-	// As a sanity check, if the fee isn't zero, we'll ensure that the
-	// passed fee rate is below 1e-6, or the lowest allowed non-zero fee
-	// rate expressible within the protocol.
-	case req.FeeRate != 0 && req.FeeRate < minFeeRate:
-		return nil, fmt.Errorf("fee rate of %v is too small, min fee "+
-			"rate is %v", req.FeeRate, minFeeRate)
+	// If a template is referenced, the policy is taken from it. To avoid
+	// any ambiguity, the policy must not be specified in the request as
+	// well.
+	if req.Template != "" {
+		if req.BaseFeeMsat != 0 || req.FeeRate != 0 ||
+			req.TimeLockDelta != 0 || req.MaxHtlcMsat != 0 ||
+			req.MinHtlcMsatSpecified {
 
-	// We'll also ensure that the user isn't setting a CLTV delta that
-	// won't give outgoing HTLCs enough time to fully resolve if needed.
-	case req.TimeLockDelta < minTimeLockDelta:
-		return nil, fmt.Errorf("time lock delta of %v is too small, "+
-			"minimum supported is %v", req.TimeLockDelta,
-			minTimeLockDelta)
+			return nil, fmt.Errorf("policy must not be specified " +
+				"when applying a template")
+		}
+
+		template, err := r.server.remoteChanDB.FetchPolicyTemplate(
+			req.Template,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch template %v: %v",
+				req.Template, err)
+		}
+
+		req.BaseFeeMsat = int64(template.BaseFee)
+		req.FeeRate = float64(template.FeeRate) / feeBase
+		req.TimeLockDelta = template.TimeLockDelta
+		req.MaxHtlcMsat = uint64(template.MaxHTLC)
+		if template.MinHTLC != nil {
+			req.MinHtlcMsat = uint64(*template.MinHTLC)
+			req.MinHtlcMsatSpecified = true
+		}
+	}
+
+	if err := r.validatePolicy(req.FeeRate, req.TimeLockDelta); err != nil {
+		return nil, err
 	}
 
 	// We'll also need to convert the floating point fee rate we accept
@@ -5950,14 +8680,20 @@ func (r *rpcServer) UpdateChannelPolicy(ctx context.Context,
 
 	rpcsLog.Debugf("[updatechanpolicy] updating channel policy base_fee=%v, "+
 		"rate_float=%v, rate_fixed=%v, time_lock_delta: %v, "+
-		"min_htlc=%v, max_htlc=%v, targets=%v",
+		"min_htlc=%v, max_htlc=%v, template=%v, reason=%v, targets=%v",
 		req.BaseFeeMsat, req.FeeRate, feeRateFixed, req.TimeLockDelta,
-		minHtlc, maxHtlc,
+		minHtlc, maxHtlc, req.Template, req.Reason,
 		spew.Sdump(targetChans))
 
 	// With the scope resolved, we'll now send this to the local channel
 	// manager so it can propagate the new policy for our target channel(s).
-	err := r.server.localChanMgr.UpdatePolicy(chanPolicy, targetChans...)
+	info := localchans.UpdateInfo{
+		Template: req.Template,
+		Reason:   req.Reason,
+	}
+	err := r.server.localChanMgr.UpdatePolicy(
+		chanPolicy, info, targetChans...,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -5965,6 +8701,156 @@ func (r *rpcServer) UpdateChannelPolicy(ctx context.Context,
 	return &lnrpc.PolicyUpdateResponse{}, nil
 }
 
+// SetPolicyTemplate stores a named channel policy template, replacing any
+// existing template with the same name.
+func (r *rpcServer) SetPolicyTemplate(ctx context.Context,
+	req *lnrpc.SetPolicyTemplateRequest) (*lnrpc.SetPolicyTemplateResponse,
+	error) {
+
+	if req.Template == nil {
+		return nil, fmt.Errorf("template must be specified")
+	}
+	rpcTemplate := req.Template
+
+	err := r.validatePolicy(rpcTemplate.FeeRate, rpcTemplate.TimeLockDelta)
+	if err != nil {
+		return nil, err
+	}
+
+	template := &channeldb.PolicyTemplate{
+		Name:          rpcTemplate.Name,
+		BaseFee:       lnwire.MilliSatoshi(rpcTemplate.BaseFeeMsat),
+		FeeRate:       uint32(rpcTemplate.FeeRate * feeBase),
+		TimeLockDelta: rpcTemplate.TimeLockDelta,
+		MaxHTLC:       lnwire.MilliSatoshi(rpcTemplate.MaxHtlcMsat),
+	}
+	if rpcTemplate.MinHtlcMsatSpecified {
+		minHtlc := lnwire.MilliSatoshi(rpcTemplate.MinHtlcMsat)
+		template.MinHTLC = &minHtlc
+	}
+
+	rpcsLog.Debugf("[setpolicytemplate] storing template %v",
+		template.Name)
+
+	err = r.server.remoteChanDB.PutPolicyTemplate(template)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.SetPolicyTemplateResponse{}, nil
+}
+
+// ListPolicyTemplates returns all stored channel policy templates.
+func (r *rpcServer) ListPolicyTemplates(ctx context.Context,
+	req *lnrpc.ListPolicyTemplatesRequest) (
+	*lnrpc.ListPolicyTemplatesResponse, error) {
+
+	rpcsLog.Debugf("[listpolicytemplates]")
+
+	templates, err := r.server.remoteChanDB.FetchPolicyTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &lnrpc.ListPolicyTemplatesResponse{
+		Templates: make([]*lnrpc.PolicyTemplate, len(templates)),
+	}
+	for i, template := range templates {
+		rpcTemplate := &lnrpc.PolicyTemplate{
+			Name:          template.Name,
+			BaseFeeMsat:   int64(template.BaseFee),
+			FeeRate:       float64(template.FeeRate) / feeBase,
+			TimeLockDelta: template.TimeLockDelta,
+			MaxHtlcMsat:   uint64(template.MaxHTLC),
+		}
+		if template.MinHTLC != nil {
+			rpcTemplate.MinHtlcMsat = uint64(*template.MinHTLC)
+			rpcTemplate.MinHtlcMsatSpecified = true
+		}
+
+		resp.Templates[i] = rpcTemplate
+	}
+
+	return resp, nil
+}
+
+// DeletePolicyTemplate deletes a stored channel policy template.
+func (r *rpcServer) DeletePolicyTemplate(ctx context.Context,
+	req *lnrpc.DeletePolicyTemplateRequest) (
+	*lnrpc.DeletePolicyTemplateResponse, error) {
+
+	rpcsLog.Debugf("[deletepolicytemplate] deleting template %v", req.Name)
+
+	err := r.server.remoteChanDB.DeletePolicyTemplate(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.DeletePolicyTemplateResponse{}, nil
+}
+
+// ChannelPolicyHistory returns the history of the forwarding policies that
+// were applied to our channels within the target time range.
+func (r *rpcServer) ChannelPolicyHistory(ctx context.Context,
+	req *lnrpc.ChannelPolicyHistoryRequest) (
+	*lnrpc.ChannelPolicyHistoryResponse, error) {
+
+	rpcsLog.Debugf("[channelpolicyhistory]")
+
+	// The start time defaults to the unix epoch and the end time to now.
+	query := channeldb.PolicyHistoryQuery{
+		StartTime:     time.Unix(int64(req.StartTime), 0),
+		EndTime:       time.Now(),
+		IndexOffset:   req.IndexOffset,
+		NumMaxChanges: req.NumMaxChanges,
+	}
+	if req.EndTime != 0 {
+		query.EndTime = time.Unix(int64(req.EndTime), 0)
+	}
+	if query.NumMaxChanges == 0 {
+		query.NumMaxChanges = 100
+	}
+
+	if req.ChanPoint != nil {
+		txid, err := GetChanPointFundingTxid(req.ChanPoint)
+		if err != nil {
+			return nil, err
+		}
+		query.ChanPoint = &wire.OutPoint{
+			Hash:  *txid,
+			Index: req.ChanPoint.OutputIndex,
+		}
+	}
+
+	timeSlice, err := r.server.remoteChanDB.QueryPolicyHistory(query)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query policy history: %v",
+			err)
+	}
+
+	resp := &lnrpc.ChannelPolicyHistoryResponse{
+		Changes: make(
+			[]*lnrpc.ChannelPolicyChange, len(timeSlice.Changes),
+		),
+		LastOffsetIndex: timeSlice.LastIndexOffset,
+	}
+	for i, change := range timeSlice.Changes {
+		resp.Changes[i] = &lnrpc.ChannelPolicyChange{
+			TimestampNs:   uint64(change.Timestamp.UnixNano()),
+			ChannelPoint:  change.ChanPoint.String(),
+			BaseFeeMsat:   int64(change.BaseFee),
+			FeePerMil:     int64(change.FeeRate),
+			TimeLockDelta: change.TimeLockDelta,
+			MinHtlcMsat:   uint64(change.MinHTLC),
+			MaxHtlcMsat:   uint64(change.MaxHTLC),
+			Template:      change.Template,
+			Reason:        change.Reason,
+		}
+	}
+
+	return resp, nil
+}
+
 // ForwardingHistory allows the caller to query the htlcswitch for a record of
 // all HTLC's forwarded within the target time range, and integer offset within
 // that time range. If no time-range is specified, then the first chunk of the
@@ -6058,6 +8944,511 @@ func (r *rpcServer) ForwardingHistory(ctx context.Context,
 	return resp, nil
 }
 
+// ListChannelOpenAttempts returns the audit log of inbound channel open
+// requests within the target time range, or the request that resulted in the
+// channel with the given channel point.
+func (r *rpcServer) ListChannelOpenAttempts(ctx context.Context,
+	req *lnrpc.ListChannelOpenAttemptsRequest) (
+	*lnrpc.ListChannelOpenAttemptsResponse, error) {
+
+	rpcsLog.Debugf("[listchannelopenattempts]")
+
+	db := r.server.remoteChanDB
+
+	// If a channel point is given, we only look up the attempt that
+	// resulted in this channel.
+	if req.ChanPoint != nil {
+		txid, err := GetChanPointFundingTxid(req.ChanPoint)
+		if err != nil {
+			return nil, err
+		}
+		chanPoint := wire.OutPoint{
+			Hash:  *txid,
+			Index: req.ChanPoint.OutputIndex,
+		}
+
+		attempt, err := db.FetchChannelOpenAttempt(chanPoint)
+		if err != nil {
+			return nil, err
+		}
+
+		return &lnrpc.ListChannelOpenAttemptsResponse{
+			Attempts: []*lnrpc.ChannelOpenAttempt{
+				marshallOpenAttempt(attempt),
+			},
+		}, nil
+	}
+
+	// The start time defaults to the unix epoch and the end time to now.
+	startTime := time.Unix(int64(req.StartTime), 0)
+	endTime := time.Now()
+	if req.EndTime != 0 {
+		endTime = time.Unix(int64(req.EndTime), 0)
+	}
+
+	numAttempts := req.NumMaxAttempts
+	if numAttempts == 0 {
+		numAttempts = 100
+	}
+
+	timeSlice, err := db.QueryChannelOpenAttempts(
+		channeldb.ChannelOpenAttemptQuery{
+			StartTime:      startTime,
+			EndTime:        endTime,
+			IndexOffset:    req.IndexOffset,
+			NumMaxAttempts: numAttempts,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query open attempts: %v", err)
+	}
+
+	resp := &lnrpc.ListChannelOpenAttemptsResponse{
+		Attempts: make(
+			[]*lnrpc.ChannelOpenAttempt, len(timeSlice.Attempts),
+		),
+		LastOffsetIndex: timeSlice.LastIndexOffset,
+	}
+	for i, attempt := range timeSlice.Attempts {
+		resp.Attempts[i] = marshallOpenAttempt(attempt)
+	}
+
+	return resp, nil
+}
+
+// SetPeerPendingChanLimit overrides the maximum number of inbound pending
+// channels of a single peer until the next restart, or removes the override
+// if remove is set.
+func (r *rpcServer) SetPeerPendingChanLimit(ctx context.Context,
+	req *lnrpc.SetPeerPendingChanLimitRequest) (
+	*lnrpc.SetPeerPendingChanLimitResponse, error) {
+
+	pubKey, err := btcec.ParsePubKey(req.PubKey, btcec.S256())
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse pubkey: %v", err)
+	}
+	peer := route.NewVertex(pubKey)
+
+	rpcsLog.Debugf("[setpeerpendingchanlimit] peer=%v, max=%v, remove=%v",
+		peer, req.MaxPendingChannels, req.Remove)
+
+	if req.Remove {
+		if !r.server.fundingMgr.ClearMaxPendingChannels(peer) {
+			return nil, fmt.Errorf("no pending channel limit set "+
+				"for peer %v", peer)
+		}
+	} else {
+		r.server.fundingMgr.SetMaxPendingChannels(
+			peer, int(req.MaxPendingChannels),
+		)
+	}
+
+	maxPending := r.server.fundingMgr.maxPendingChannels(pubKey)
+
+	return &lnrpc.SetPeerPendingChanLimitResponse{
+		MaxPendingChannels: uint32(maxPending),
+	}, nil
+}
+
+// ExportChannelEvents returns the balance-affecting events of the node's
+// channels within the target time range in chronological order. On-chain
+// events are linked to the channel point of the channel they belong to.
+func (r *rpcServer) ExportChannelEvents(ctx context.Context,
+	req *lnrpc.ExportChannelEventsRequest) (
+	*lnrpc.ExportChannelEventsResponse, error) {
+
+	rpcsLog.Debugf("[exportchannelevents]")
+
+	// The start time defaults to the unix epoch and the end time to now.
+	export := &chanEventExport{
+		startTime: time.Unix(int64(req.StartTime), 0),
+		endTime:   time.Now(),
+	}
+	if req.EndTime != 0 {
+		export.endTime = time.Unix(int64(req.EndTime), 0)
+	}
+	if export.endTime.Before(export.startTime) {
+		return nil, fmt.Errorf("end time %v before start time %v",
+			req.EndTime, req.StartTime)
+	}
+
+	if req.ChanPoint != nil {
+		txid, err := GetChanPointFundingTxid(req.ChanPoint)
+		if err != nil {
+			return nil, err
+		}
+		export.chanFilter = &wire.OutPoint{
+			Hash:  *txid,
+			Index: req.ChanPoint.OutputIndex,
+		}
+	}
+
+	// Before we query the forwarding log, we'll instruct the switch to
+	// flush any pending events to disk, so that all forwards up to now are
+	// included.
+	if err := r.server.htlcSwitch.FlushForwardingEvents(); err != nil {
+		return nil, fmt.Errorf("unable to flush forwarding "+
+			"events: %v", err)
+	}
+
+	db := r.server.remoteChanDB
+
+	// Off-chain events only reference the short channel id, so we map the
+	// ids of all our open and closed channels to their channel point.
+	chanPoints, err := fetchChanPoints(db)
+	if err != nil {
+		return nil, err
+	}
+	export.chanPoints = chanPoints
+
+	closeSummaries, err := db.FetchClosedChannels(false)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := export.addInvoices(db); err != nil {
+		return nil, fmt.Errorf("unable to export invoices: %v", err)
+	}
+	if err := export.addPayments(db); err != nil {
+		return nil, fmt.Errorf("unable to export payments: %v", err)
+	}
+	if err := export.addForwards(db); err != nil {
+		return nil, fmt.Errorf("unable to export forwards: %v", err)
+	}
+
+	err = export.addCloses(closeSummaries, r.server.cc.ChainIO)
+	if err != nil {
+		return nil, fmt.Errorf("unable to export closes: %v", err)
+	}
+
+	// Sweeps are found through the labels of our wallet transactions,
+	// which include the channel point of the swept channel.
+	transactions, err := r.server.cc.Wallet.ListTransactionDetails(0, -1)
+	if err != nil {
+		return nil, err
+	}
+	export.addSweeps(transactions)
+
+	sort.SliceStable(export.events, func(i, j int) bool {
+		return export.events[i].Timestamp < export.events[j].Timestamp
+	})
+
+	return &lnrpc.ExportChannelEventsResponse{
+		Events: export.events,
+	}, nil
+}
+
+// chanEventExport collects the accounting events of an ExportChannelEvents
+// call.
+type chanEventExport struct {
+	// startTime and endTime bound the time range of the export.
+	startTime, endTime time.Time
+
+	// chanPoints maps the short channel ids of all our channels to their
+	// channel point.
+	chanPoints map[lnwire.ShortChannelID]wire.OutPoint
+
+	// chanFilter restricts the export to the events of a single channel if
+	// set.
+	chanFilter *wire.OutPoint
+
+	// events holds the events collected so far.
+	events []*lnrpc.ChannelAccountingEvent
+}
+
+// add adds the event if it happened within the time range of the export and
+// belongs to the filtered channel.
+func (c *chanEventExport) add(timestamp time.Time, chanPoint *wire.OutPoint,
+	event *lnrpc.ChannelAccountingEvent) {
+
+	if timestamp.Before(c.startTime) || timestamp.After(c.endTime) {
+		return
+	}
+
+	if c.chanFilter != nil {
+		if chanPoint == nil || *chanPoint != *c.chanFilter {
+			return
+		}
+	}
+
+	event.Timestamp = uint64(timestamp.Unix())
+	if chanPoint != nil {
+		event.ChannelPoint = chanPoint.String()
+	}
+
+	c.events = append(c.events, event)
+}
+
+// addOffChain adds an event of the channel with the given short channel id.
+func (c *chanEventExport) addOffChain(timestamp time.Time,
+	chanID lnwire.ShortChannelID, event *lnrpc.ChannelAccountingEvent) {
+
+	event.ChanId = chanID.ToUint64()
+
+	var chanPoint *wire.OutPoint
+	if op, ok := c.chanPoints[chanID]; ok {
+		chanPoint = &op
+	}
+
+	c.add(timestamp, chanPoint, event)
+}
+
+// addInvoices adds the settled htlcs of all settled invoices.
+func (c *chanEventExport) addInvoices(db *channeldb.DB) error {
+	invoices, err := db.InvoicesSettledSince(0)
+	if err != nil {
+		return err
+	}
+
+	for _, invoice := range invoices {
+		var payHash string
+		if invoice.Terms.PaymentPreimage != nil {
+			payHash = invoice.Terms.PaymentPreimage.Hash().String()
+		}
+
+		for key, htlc := range invoice.Htlcs {
+			if htlc.State != channeldb.HtlcStateSettled {
+				continue
+			}
+
+			c.addOffChain(
+				htlc.ResolveTime, key.ChanID,
+				&lnrpc.ChannelAccountingEvent{
+					Type:        lnrpc.ChannelAccountingEvent_INVOICE_SETTLE,
+					AmountMsat:  int64(htlc.Amt),
+					PaymentHash: payHash,
+				},
+			)
+		}
+	}
+
+	return nil
+}
+
+// addPayments adds the settled htlcs of all our outgoing payments.
+func (c *chanEventExport) addPayments(db *channeldb.DB) error {
+	payments, err := db.FetchPayments()
+	if err != nil {
+		return err
+	}
+
+	for _, payment := range payments {
+		for _, htlc := range payment.HTLCs {
+			if htlc.Settle == nil || len(htlc.Route.Hops) == 0 {
+				continue
+			}
+
+			var (
+				route   = htlc.Route
+				payHash = payment.Info.PaymentHash
+				chanID  = lnwire.NewShortChanIDFromInt(
+					route.Hops[0].ChannelID,
+				)
+			)
+			c.addOffChain(
+				htlc.Settle.SettleTime, chanID,
+				&lnrpc.ChannelAccountingEvent{
+					Type:        lnrpc.ChannelAccountingEvent_PAYMENT_SETTLE,
+					AmountMsat:  -int64(route.TotalAmount),
+					FeeMsat:     -int64(route.TotalFees()),
+					PaymentHash: payHash.String(),
+				},
+			)
+		}
+	}
+
+	return nil
+}
+
+// addForwards adds the incoming and outgoing side of all forwards within the
+// time range.
+func (c *chanEventExport) addForwards(db *channeldb.DB) error {
+	query := channeldb.ForwardingEventQuery{
+		StartTime:    c.startTime,
+		EndTime:      c.endTime,
+		NumMaxEvents: 50000,
+	}
+
+	for {
+		timeSlice, err := db.ForwardingLog().Query(query)
+		if err != nil {
+			return err
+		}
+
+		for _, fwd := range timeSlice.ForwardingEvents {
+			fee := fwd.AmtIn - fwd.AmtOut
+			c.addOffChain(
+				fwd.Timestamp, fwd.IncomingChanID,
+				&lnrpc.ChannelAccountingEvent{
+					Type:       lnrpc.ChannelAccountingEvent_FORWARD_IN,
+					AmountMsat: int64(fwd.AmtIn),
+					FeeMsat:    int64(fee),
+				},
+			)
+			c.addOffChain(
+				fwd.Timestamp, fwd.OutgoingChanID,
+				&lnrpc.ChannelAccountingEvent{
+					Type:       lnrpc.ChannelAccountingEvent_FORWARD_OUT,
+					AmountMsat: -int64(fwd.AmtOut),
+				},
+			)
+		}
+
+		// Continue with the next slice until the remainder of the
+		// time range fits into a single one.
+		if len(timeSlice.ForwardingEvents) < int(query.NumMaxEvents) {
+			return nil
+		}
+		query.IndexOffset = timeSlice.LastIndexOffset
+	}
+}
+
+// addCloses adds the closing transactions of all closed channels. As the close
+// summaries only store the height the channel was closed at, the timestamp of
+// the close is looked up in the block at that height.
+func (c *chanEventExport) addCloses(summaries []*channeldb.ChannelCloseSummary,
+	chainIO lnwallet.BlockChainIO) error {
+
+	blockTimes := make(map[uint32]time.Time)
+	for _, summary := range summaries {
+		var eventType lnrpc.ChannelAccountingEvent_EventType
+		switch summary.CloseType {
+		case channeldb.CooperativeClose:
+			eventType = lnrpc.ChannelAccountingEvent_COOPERATIVE_CLOSE
+
+		case channeldb.LocalForceClose, channeldb.RemoteForceClose,
+			channeldb.BreachClose:
+
+			eventType = lnrpc.ChannelAccountingEvent_FORCE_CLOSE
+
+		// Channels that were abandoned or never confirmed don't have a
+		// closing transaction.
+		default:
+			continue
+		}
+
+		if c.chanFilter != nil && summary.ChanPoint != *c.chanFilter {
+			continue
+		}
+
+		blockTime, ok := blockTimes[summary.CloseHeight]
+		if !ok {
+			blockHash, err := chainIO.GetBlockHash(
+				int64(summary.CloseHeight),
+			)
+			if err != nil {
+				return err
+			}
+
+			block, err := chainIO.GetBlock(blockHash)
+			if err != nil {
+				return err
+			}
+
+			blockTime = block.Header.Timestamp
+			blockTimes[summary.CloseHeight] = blockTime
+		}
+
+		settled := lnwire.NewMSatFromSatoshis(summary.SettledBalance)
+		chanPoint := summary.ChanPoint
+		c.add(blockTime, &chanPoint, &lnrpc.ChannelAccountingEvent{
+			Type:       eventType,
+			ChanId:     summary.ShortChanID.ToUint64(),
+			AmountMsat: int64(settled),
+			Txid:       summary.ClosingTXID.String(),
+		})
+	}
+
+	return nil
+}
+
+// addSweeps adds the confirmed sweeps of channel outputs, which are identified
+// by the labels lnd publishes them with.
+func (c *chanEventExport) addSweeps(
+	transactions []*lnwallet.TransactionDetail) {
+
+	for _, tx := range transactions {
+		if tx.NumConfirmations == 0 {
+			continue
+		}
+
+		label, err := labels.ParseLabel(tx.Label)
+		if err != nil {
+			continue
+		}
+
+		var eventType lnrpc.ChannelAccountingEvent_EventType
+		switch label.Type {
+		case labels.LabelTypeSweepTransaction,
+			labels.LabelTypeConsolidationSweep:
+
+			eventType = lnrpc.ChannelAccountingEvent_SWEEP
+
+		case labels.LabelTypeJusticeTransaction:
+			eventType = lnrpc.ChannelAccountingEvent_JUSTICE_SWEEP
+
+		default:
+			continue
+		}
+
+		event := &lnrpc.ChannelAccountingEvent{
+			Type:       eventType,
+			AmountMsat: int64(lnwire.NewMSatFromSatoshis(tx.Value)),
+			FeeMsat: -int64(lnwire.NewMSatFromSatoshis(
+				btcutil.Amount(tx.TotalFees),
+			)),
+			Txid: tx.Hash.String(),
+		}
+
+		// Link the sweep to its channel, either through the channel
+		// point or the short channel id in the label.
+		chanPoint := label.ChanPoint
+		if label.ShortChanID != nil {
+			event.ChanId = label.ShortChanID.ToUint64()
+			if op, ok := c.chanPoints[*label.ShortChanID]; ok {
+				chanPoint = &op
+			}
+		}
+
+		c.add(time.Unix(tx.Timestamp, 0), chanPoint, event)
+	}
+}
+
+// marshallOpenAttempt converts a channel open attempt of the audit log into
+// its RPC representation.
+func marshallOpenAttempt(
+	attempt *channeldb.ChannelOpenAttempt) *lnrpc.ChannelOpenAttempt {
+
+	rpcAttempt := &lnrpc.ChannelOpenAttempt{
+		TimestampNs:   uint64(attempt.Timestamp.UnixNano()),
+		PendingChanId: attempt.PendingChanID[:],
+		RemotePubkey: hex.EncodeToString(
+			attempt.Peer.SerializeCompressed(),
+		),
+		FundingAmt:  int64(attempt.FundingAmt),
+		PushAmtMsat: uint64(attempt.PushAmt),
+		Accepted:    attempt.Accepted,
+		Reason:      attempt.Reason,
+	}
+
+	switch attempt.AcceptorVerdict {
+	case channeldb.AcceptorAccepted:
+		rpcAttempt.AcceptorVerdict = lnrpc.AcceptorVerdict_ACCEPTOR_ACCEPTED
+
+	case channeldb.AcceptorRejected:
+		rpcAttempt.AcceptorVerdict = lnrpc.AcceptorVerdict_ACCEPTOR_REJECTED
+
+	default:
+		rpcAttempt.AcceptorVerdict = lnrpc.AcceptorVerdict_NOT_CONSULTED
+	}
+
+	if attempt.ChanPoint != nil {
+		rpcAttempt.ChannelPoint = attempt.ChanPoint.String()
+	}
+
+	return rpcAttempt
+}
+
 // ExportChannelBackup attempts to return an encrypted static channel backup
 // for the target channel identified by it channel point. The backup is
 // encrypted with a key generated from the aezeed seed of the user. The
@@ -6399,24 +9790,17 @@ func (r *rpcServer) SubscribeChannelBackups(req *lnrpc.ChannelBackupSubscription
 	}
 }
 
-// chanAcceptInfo is used in the ChannelAcceptor bidirectional stream and
-// encapsulates the request information sent from the RPCAcceptor to the
-// RPCServer.
-type chanAcceptInfo struct {
-	chanReq      *chanacceptor.ChannelAcceptRequest
-	responseChan chan bool
-}
-
 // ChannelAcceptor dispatches a bi-directional streaming RPC in which
 // OpenChannel requests are sent to the client and the client responds with
-// a boolean that tells LND whether or not to accept the channel. This allows
-// node operators to specify their own criteria for accepting inbound channels
+// a boolean that tells LND whether or not to accept the channel, optionally
+// along with the number of confirmations to require for it. This allows node
+// operators to specify their own criteria for accepting inbound channels
 // through a single persistent connection.
 func (r *rpcServer) ChannelAcceptor(stream lnrpc.Lightning_ChannelAcceptorServer) error {
 	chainedAcceptor := r.chanPredicate
 
 	// Create two channels to handle requests and responses respectively.
-	newRequests := make(chan *chanAcceptInfo)
+	newRequests := make(chan *chanacceptor.RPCRequest)
 	responses := make(chan lnrpc.ChannelAcceptResponse)
 
 	// Define a quit channel that will be used to signal to the RPCAcceptor's
@@ -6424,46 +9808,35 @@ func (r *rpcServer) ChannelAcceptor(stream lnrpc.Lightning_ChannelAcceptorServer
 	quit := make(chan struct{})
 	defer close(quit)
 
-	// demultiplexReq is a closure that will be passed to the RPCAcceptor and
-	// acts as an intermediary between the RPCAcceptor and the RPCServer.
-	demultiplexReq := func(req *chanacceptor.ChannelAcceptRequest) bool {
-		respChan := make(chan bool, 1)
+	// Requests the client doesn't respond to in time, or that are still
+	// pending when it disconnects, get the configured default verdict.
+	verdictCfg := &chanacceptor.VerdictConfig{
+		Timeout:       r.cfg.AcceptorTimeout,
+		DefaultAccept: r.cfg.AcceptorDefault == acceptorDefaultAccept,
+		Observe: func(req *chanacceptor.ChannelAcceptRequest,
+			outcome string, latency time.Duration) {
 
-		newRequest := &chanAcceptInfo{
-			chanReq:      req,
-			responseChan: respChan,
-		}
+			monitoring.ObserveAcceptorLatency(outcome, latency)
 
-		// timeout is the time after which ChannelAcceptRequests expire.
-		timeout := time.After(r.cfg.AcceptorTimeout)
+			if outcome == chanacceptor.OutcomeTimeout ||
+				outcome == chanacceptor.OutcomeDisconnect {
 
-		// Send the request to the newRequests channel.
-		select {
-		case newRequests <- newRequest:
-		case <-timeout:
-			rpcsLog.Errorf("RPCAcceptor returned false - reached timeout of %d",
-				r.cfg.AcceptorTimeout)
-			return false
-		case <-quit:
-			return false
-		case <-r.quit:
-			return false
-		}
+				rpcsLog.Warnf("RPCAcceptor returned default "+
+					"verdict %v for pending channel %x: %v",
+					r.cfg.AcceptorDefault,
+					req.OpenChanMsg.PendingChannelID[:],
+					outcome)
+			}
+		},
+	}
 
-		// Receive the response and return it. If no response has been received
-		// in AcceptorTimeout, then return false.
-		select {
-		case resp := <-respChan:
-			return resp
-		case <-timeout:
-			rpcsLog.Errorf("RPCAcceptor returned false - reached timeout of %d",
-				r.cfg.AcceptorTimeout)
-			return false
-		case <-quit:
-			return false
-		case <-r.quit:
-			return false
-		}
+	// demultiplexReq is a closure that will be passed to the RPCAcceptor
+	// and acts as an intermediary between the RPCAcceptor and the
+	// RPCServer.
+	demultiplexReq := func(req *chanacceptor.ChannelAcceptRequest) (
+		resp *chanacceptor.ChannelAcceptResponse) {
+
+		return verdictCfg.RequestVerdict(req, newRequests, quit, r.quit)
 	}
 
 	// Create a new RPCAcceptor via the NewRPCAcceptor method.
@@ -6493,8 +9866,9 @@ func (r *rpcServer) ChannelAcceptor(stream lnrpc.Lightning_ChannelAcceptorServer
 			copy(pendingID[:], resp.PendingChanId)
 
 			openChanResp := lnrpc.ChannelAcceptResponse{
-				Accept:        resp.Accept,
-				PendingChanId: pendingID[:],
+				Accept:         resp.Accept,
+				PendingChanId:  pendingID[:],
+				MinAcceptDepth: resp.MinAcceptDepth,
 			}
 
 			// Now that we have the response from the RPC client, send it to
@@ -6509,16 +9883,18 @@ func (r *rpcServer) ChannelAcceptor(stream lnrpc.Lightning_ChannelAcceptorServer
 		}
 	}()
 
-	acceptRequests := make(map[[32]byte]chan bool)
+	acceptRequests := make(
+		map[[32]byte]chan *chanacceptor.ChannelAcceptResponse,
+	)
 
 	for {
 		select {
 		case newRequest := <-newRequests:
 
-			req := newRequest.chanReq
+			req := newRequest.Request
 			pendingChanID := req.OpenChanMsg.PendingChannelID
 
-			acceptRequests[pendingChanID] = newRequest.responseChan
+			acceptRequests[pendingChanID] = newRequest.Response
 
 			// A ChannelAcceptRequest has been received, send it to the client.
 			chanAcceptReq := &lnrpc.ChannelAcceptRequest{
@@ -6535,6 +9911,7 @@ func (r *rpcServer) ChannelAcceptor(stream lnrpc.Lightning_ChannelAcceptorServer
 				CsvDelay:         uint32(req.OpenChanMsg.CsvDelay),
 				MaxAcceptedHtlcs: uint32(req.OpenChanMsg.MaxAcceptedHTLCs),
 				ChannelFlags:     uint32(req.OpenChanMsg.ChannelFlags),
+				TrustedPeer:      req.TrustedPeer,
 			}
 
 			if err := stream.Send(chanAcceptReq); err != nil {
@@ -6550,8 +9927,26 @@ func (r *rpcServer) ChannelAcceptor(stream lnrpc.Lightning_ChannelAcceptorServer
 				continue
 			}
 
-			// Send the response boolean over the buffered response channel.
-			respChan <- resp.Accept
+			// The number of confirmations is encoded as a uint32
+			// on the wire, but we can't require more than fit into
+			// a uint16. Reject the channel rather than silently
+			// using a different value.
+			chanResp := &chanacceptor.ChannelAcceptResponse{
+				Accept:         resp.Accept,
+				MinAcceptDepth: uint16(resp.MinAcceptDepth),
+			}
+			if resp.MinAcceptDepth > math.MaxUint16 {
+				rpcsLog.Errorf("RPCAcceptor returned "+
+					"invalid min accept depth %v, "+
+					"rejecting channel",
+					resp.MinAcceptDepth)
+
+				chanResp = chanacceptor.
+					NewChannelAcceptResponse(false)
+			}
+
+			// Send the response over the buffered response channel.
+			respChan <- chanResp
 
 			// Delete the channel from the acceptRequests map.
 			delete(acceptRequests, pendingID)
@@ -6633,7 +10028,21 @@ func (r *rpcServer) BakeMacaroon(ctx context.Context,
 	if err != nil {
 		return nil, err
 	}
-	newMacBytes, err := newMac.M().MarshalBinary()
+
+	// If requested, we'll mark the macaroon as an observer macaroon, which
+	// can't be used to modify the state of the daemon regardless of the
+	// permissions it grants.
+	mac := newMac.M()
+	if req.Observer {
+		mac, err = macaroons.AddConstraints(
+			mac, macaroons.ObserverConstraint(),
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	newMacBytes, err := mac.MarshalBinary()
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,96 @@
+package lnd
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/cryptomeow/lnd/lnrpc"
+	"github.com/cryptomeow/lnd/lnrpc/routerrpc"
+	"github.com/cryptomeow/lnd/lnwire"
+	"github.com/cryptomeow/lnd/swaphook"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"gopkg.in/macaroon-bakery.v2/bakery"
+	"gopkg.in/macaroon.v2"
+)
+
+// mockPaymentStream is a server stream that receives a fixed payment request.
+type mockPaymentStream struct {
+	grpc.ServerStream
+
+	req *routerrpc.SendPaymentRequest
+}
+
+func (m *mockPaymentStream) RecvMsg(msg interface{}) error {
+	*msg.(*routerrpc.SendPaymentRequest) = *m.req
+	return nil
+}
+
+// TestSwapHookPaymentStream asserts that the payments made with the macaroon
+// of a swap hook are charged to its budget including the fee limit, and that
+// payments exceeding the budget are rejected.
+func TestSwapHookPaymentStream(t *testing.T) {
+	budget := swaphook.NewBudget(
+		lnwire.NewMSatFromSatoshis(1000),
+		lnwire.NewMSatFromSatoshis(2000),
+	)
+
+	recv := func(req *routerrpc.SendPaymentRequest) error {
+		stream := &swapHookPaymentStream{
+			ServerStream: &mockPaymentStream{req: req},
+			budget:       budget,
+			params:       &chaincfg.RegressionNetParams,
+		}
+
+		return stream.RecvMsg(&routerrpc.SendPaymentRequest{})
+	}
+
+	// The fee limit counts towards the per-payment cap.
+	err := recv(&routerrpc.SendPaymentRequest{
+		Amt:         900,
+		FeeLimitSat: 101,
+	})
+	require.IsType(t, &swaphook.ErrPaymentTooLarge{}, err)
+
+	err = recv(&routerrpc.SendPaymentRequest{
+		AmtMsat:      800000,
+		FeeLimitMsat: 200000,
+	})
+	require.NoError(t, err)
+	require.Equal(t, lnwire.NewMSatFromSatoshis(1000), budget.Spent())
+
+	require.NoError(t, recv(&routerrpc.SendPaymentRequest{Amt: 1000}))
+	require.Equal(
+		t, swaphook.ErrBudgetExhausted,
+		recv(&routerrpc.SendPaymentRequest{Amt: 1}),
+	)
+}
+
+// TestMacaroonRootKeyID asserts that the root key ID is decoded from the
+// identifier of a macaroon.
+func TestMacaroonRootKeyID(t *testing.T) {
+	idProto, err := proto.Marshal(&lnrpc.MacaroonId{
+		StorageId: []byte("1234"),
+	})
+	require.NoError(t, err)
+
+	rawID := append([]byte{byte(bakery.LatestVersion)}, idProto...)
+	mac, err := macaroon.New(
+		[]byte("root key"), rawID, "lnd", macaroon.LatestVersion,
+	)
+	require.NoError(t, err)
+
+	rootKeyID, err := macaroonRootKeyID(mac)
+	require.NoError(t, err)
+	require.Equal(t, []byte("1234"), rootKeyID)
+
+	// Macaroons of an unknown version are rejected.
+	mac, err = macaroon.New(
+		[]byte("root key"), idProto, "lnd", macaroon.LatestVersion,
+	)
+	require.NoError(t, err)
+
+	_, err = macaroonRootKeyID(mac)
+	require.Error(t, err)
+}
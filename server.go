@@ -10,7 +10,9 @@ import (
 	"math/big"
 	prand "math/rand"
 	"net"
+	"os"
 	"path/filepath"
+	"reflect"
 	"regexp"
 	"strconv"
 	"sync"
@@ -27,6 +29,7 @@ import (
 	sphinx "github.com/lightningnetwork/lightning-onion"
 	"github.com/cryptomeow/lnd/autopilot"
 	"github.com/cryptomeow/lnd/brontide"
+	"github.com/cryptomeow/lnd/chainntnfs"
 	"github.com/cryptomeow/lnd/chainreg"
 	"github.com/cryptomeow/lnd/chanacceptor"
 	"github.com/cryptomeow/lnd/chanbackup"
@@ -37,6 +40,7 @@ import (
 	"github.com/cryptomeow/lnd/clock"
 	"github.com/cryptomeow/lnd/contractcourt"
 	"github.com/cryptomeow/lnd/discovery"
+	"github.com/cryptomeow/lnd/eventbus"
 	"github.com/cryptomeow/lnd/feature"
 	"github.com/cryptomeow/lnd/healthcheck"
 	"github.com/cryptomeow/lnd/htlcswitch"
@@ -50,11 +54,13 @@ import (
 	"github.com/cryptomeow/lnd/lnrpc/routerrpc"
 	"github.com/cryptomeow/lnd/lnwallet"
 	"github.com/cryptomeow/lnd/lnwallet/chainfee"
+	"github.com/cryptomeow/lnd/lnwallet/chancloser"
 	"github.com/cryptomeow/lnd/lnwallet/chanfunding"
 	"github.com/cryptomeow/lnd/lnwire"
 	"github.com/cryptomeow/lnd/nat"
 	"github.com/cryptomeow/lnd/netann"
 	"github.com/cryptomeow/lnd/peer"
+	"github.com/cryptomeow/lnd/peerfilter"
 	"github.com/cryptomeow/lnd/peernotifier"
 	"github.com/cryptomeow/lnd/pool"
 	"github.com/cryptomeow/lnd/queue"
@@ -62,13 +68,18 @@ import (
 	"github.com/cryptomeow/lnd/routing/localchans"
 	"github.com/cryptomeow/lnd/routing/route"
 	"github.com/cryptomeow/lnd/subscribe"
+	"github.com/cryptomeow/lnd/swaphook"
 	"github.com/cryptomeow/lnd/sweep"
 	"github.com/cryptomeow/lnd/ticker"
 	"github.com/cryptomeow/lnd/tor"
 	"github.com/cryptomeow/lnd/walletunlocker"
+	"github.com/cryptomeow/lnd/watchonly"
+	"github.com/cryptomeow/lnd/watchtower/blob"
 	"github.com/cryptomeow/lnd/watchtower/wtclient"
+	"github.com/cryptomeow/lnd/watchtower/wtdirectory"
 	"github.com/cryptomeow/lnd/watchtower/wtpolicy"
 	"github.com/cryptomeow/lnd/watchtower/wtserver"
+	"github.com/cryptomeow/lnd/webhook"
 )
 
 const (
@@ -150,6 +161,10 @@ type server struct {
 
 	chanStatusMgr *netann.ChanStatusManager
 
+	// closeAddrPolicy is the policy that user supplied cooperative close
+	// addresses and upfront shutdown scripts must adhere to.
+	closeAddrPolicy *chancloser.DeliveryAddrPolicy
+
 	// listenAddrs is the list of addresses the server is currently
 	// listening on.
 	listenAddrs []net.Addr
@@ -179,6 +194,10 @@ type server struct {
 	peerConnectedListeners    map[string][]chan<- lnpeer.Peer
 	peerDisconnectedListeners map[string][]chan<- struct{}
 
+	// openChanQueue holds the channel open requests that wait for their
+	// target peer to come online.
+	openChanQueue *openChanQueue
+
 	persistentPeers        map[string]bool
 	persistentPeersBackoff map[string]time.Duration
 	persistentConnReqs     map[string][]*connmgr.ConnReq
@@ -221,6 +240,10 @@ type server struct {
 
 	peerNotifier *peernotifier.PeerNotifier
 
+	// customMessageServer is used to dispatch custom messages received
+	// from peers to the subscribed clients.
+	customMessageServer *subscribe.Server
+
 	htlcNotifier *htlcswitch.HtlcNotifier
 
 	witnessBeacon contractcourt.WitnessBeacon
@@ -241,12 +264,40 @@ type server struct {
 
 	sweeper *sweep.UtxoSweeper
 
+	// epochFanout serves the block epoch registrations of the funding
+	// manager, the sweeper, the chain arbitrator and the watch-only
+	// tracker from a single block epoch subscription at the chain
+	// notifier.
+	epochFanout *chainntnfs.BlockEpochFanout
+
+	// watchOnlyTracker scans the chain for outputs paying to watch-only
+	// scripts and spends from them.
+	watchOnlyTracker *watchonly.Tracker
+
 	chainArb *contractcourt.ChainArbitrator
 
 	sphinx *hop.OnionProcessor
 
 	towerClient wtclient.Client
 
+	anchorTowerClient wtclient.Client
+
+	// peerFilter decides whether inbound connections are accepted based on
+	// their remote address.
+	peerFilter *peerfilter.Filter
+
+	// reloadMtx guards the options below, which can be updated while the
+	// server is running by reloading the daemon's configuration.
+	reloadMtx sync.RWMutex
+
+	// routingPolicy is the default forwarding policy for the links of
+	// newly opened channels.
+	routingPolicy htlcswitch.ForwardingPolicy
+
+	// configTowers is the set of watchtowers that were added to the tower
+	// client through the wtclient.tower option, keyed by their URI.
+	configTowers map[string]*lnwire.NetAddress
+
 	connMgr *connmgr.ConnManager
 
 	sigPool *lnwallet.SigPool
@@ -277,6 +328,19 @@ type server struct {
 	// provide insights into their health and performance.
 	chanEventStore *chanfitness.ChannelEventStore
 
+	// swapHooks notifies the hooks registered by external liquidity
+	// management services when the local balances of their channels
+	// exceed a threshold.
+	swapHooks *swaphook.Manager
+
+	// webhookDispatcher posts channel and peer events to the configured
+	// webhook endpoints. It is nil if no endpoints are configured.
+	webhookDispatcher *webhook.Dispatcher
+
+	// eventBus records all channel, peer, invoice and htlc events in a
+	// persistent event log that clients can replay from a cursor.
+	eventBus *eventbus.EventBus
+
 	hostAnn *netann.HostAnnouncer
 
 	// livelinessMonitor monitors that lnd has access to critical resources.
@@ -415,6 +479,9 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 		GcCanceledInvoicesOnStartup: cfg.GcCanceledInvoicesOnStartup,
 		GcCanceledInvoicesOnTheFly:  cfg.GcCanceledInvoicesOnTheFly,
 		KeysendHoldTime:             cfg.KeysendHoldTime,
+		MaxHeldHtlcsPerInvoice:      int(cfg.MaxHeldHtlcsPerInvoice),
+		MaxHeldHtlcs:                int(cfg.MaxHeldHtlcs),
+		HeldHtlcLimitPolicy:         cfg.heldHtlcLimitPolicy,
 	}
 
 	s := &server{
@@ -426,6 +493,15 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 		writePool:      writePool,
 		readPool:       readPool,
 		chansToRestore: chansToRestore,
+		routingPolicy:  cc.RoutingPolicy,
+		configTowers:   make(map[string]*lnwire.NetAddress),
+
+		closeAddrPolicy: &chancloser.DeliveryAddrPolicy{
+			RejectLegacy: cfg.CloseAddress.RejectLegacy,
+			WalletOnly:   cfg.CloseAddress.WalletOnly,
+			IsOurAddress: cc.Wallet.IsOurAddress,
+			ChainParams:  cfg.ActiveNetParams.Params,
+		},
 
 		invoices: invoices.NewRegistry(
 			remoteChanDB, invoices.NewInvoiceExpiryWatcher(clock.NewDefaultClock()),
@@ -457,6 +533,7 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 		inboundPeers:              make(map[string]*peer.Brontide),
 		outboundPeers:             make(map[string]*peer.Brontide),
 		peerConnectedListeners:    make(map[string][]chan<- lnpeer.Peer),
+		openChanQueue:             newOpenChanQueue(),
 		peerDisconnectedListeners: make(map[string][]chan<- struct{}),
 
 		featureMgr: featureMgr,
@@ -491,19 +568,26 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 
 			peer.HandleLocalCloseChanReqs(request)
 		},
-		FwdingLog:              remoteChanDB.ForwardingLog(),
-		SwitchPackager:         channeldb.NewSwitchPackager(),
-		ExtractErrorEncrypter:  s.sphinx.ExtractErrorEncrypter,
-		FetchLastChannelUpdate: s.fetchLastChanUpdate(),
-		Notifier:               s.cc.ChainNotifier,
-		HtlcNotifier:           s.htlcNotifier,
-		FwdEventTicker:         ticker.New(htlcswitch.DefaultFwdEventInterval),
-		LogEventTicker:         ticker.New(htlcswitch.DefaultLogInterval),
-		AckEventTicker:         ticker.New(htlcswitch.DefaultAckInterval),
-		AllowCircularRoute:     cfg.AllowCircularRoute,
-		RejectHTLC:             cfg.RejectHTLC,
-		Clock:                  clock.NewDefaultClock(),
-		HTLCExpiry:             htlcswitch.DefaultHTLCExpiry,
+		FwdingLog:               remoteChanDB.ForwardingLog(),
+		SwitchPackager:          channeldb.NewSwitchPackager(),
+		ExtractErrorEncrypter:   s.sphinx.ExtractErrorEncrypter,
+		FetchLastChannelUpdate:  s.fetchLastChanUpdate(),
+		Notifier:                s.cc.ChainNotifier,
+		HtlcNotifier:            s.htlcNotifier,
+		FwdEventTicker:          ticker.New(htlcswitch.DefaultFwdEventInterval),
+		LogEventTicker:          ticker.New(htlcswitch.DefaultLogInterval),
+		AckEventTicker:          ticker.New(htlcswitch.DefaultAckInterval),
+		AllowCircularRoute:      cfg.AllowCircularRoute,
+		RejectHTLC:              cfg.RejectHTLC,
+		MaxDustExposure:         lnwire.NewMSatFromSatoshis(cfg.MaxDustExposure),
+		ReservedOutgoingSlots:   cfg.ReservedOutgoingHtlcSlots,
+		Clock:                   clock.NewDefaultClock(),
+		HTLCExpiry:              htlcswitch.DefaultHTLCExpiry,
+		PreimageCache:           s.witnessBeacon,
+		ResolveOrphanedCircuits: cfg.ResolveOrphanedCircuits,
+		HtlcHoldAlarm:           cfg.HtlcHoldAlarm,
+		AutoFailHeldHtlcs:       cfg.AutoFailHeldHtlcs,
+		CancelHeldHtlc:          s.invoices.CancelHeldHtlc,
 	}, uint32(currentHeight))
 	if err != nil {
 		return nil, err
@@ -739,11 +823,21 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 		MinProbability: routingConfig.MinRouteProbability,
 	}
 
+	// Payments in flight through a single first hop peer are limited to
+	// the configured maximum, if any.
+	var peerExposure *routing.PeerExposure
+	if routingConfig.MaxPeerExposure > 0 {
+		peerExposure = routing.NewPeerExposure(
+			lnwire.NewMSatFromSatoshis(routingConfig.MaxPeerExposure),
+		)
+	}
+
 	paymentSessionSource := &routing.SessionSource{
 		Graph:             chanGraph,
 		MissionControl:    s.missionControl,
 		QueryBandwidth:    queryBandwidth,
 		PathFindingConfig: pathFindingConfig,
+		PeerExposure:      peerExposure,
 	}
 
 	paymentControl := channeldb.NewPaymentControl(remoteChanDB)
@@ -751,20 +845,23 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 	s.controlTower = routing.NewControlTower(paymentControl)
 
 	s.chanRouter, err = routing.New(routing.Config{
-		Graph:              chanGraph,
-		Chain:              cc.ChainIO,
-		ChainView:          cc.ChainView,
-		Payer:              s.htlcSwitch,
-		Control:            s.controlTower,
-		MissionControl:     s.missionControl,
-		SessionSource:      paymentSessionSource,
-		ChannelPruneExpiry: routing.DefaultChannelPruneExpiry,
-		GraphPruneInterval: time.Duration(time.Hour),
-		QueryBandwidth:     queryBandwidth,
-		AssumeChannelValid: cfg.Routing.AssumeChannelValid,
-		NextPaymentID:      sequencer.NextID,
-		PathFindingConfig:  pathFindingConfig,
-		Clock:              clock.NewDefaultClock(),
+		Graph:                chanGraph,
+		Chain:                cc.ChainIO,
+		ChainView:            cc.ChainView,
+		Payer:                s.htlcSwitch,
+		Control:              s.controlTower,
+		MissionControl:       s.missionControl,
+		SessionSource:        paymentSessionSource,
+		ChannelPruneExpiry:   cfg.Routing.ChannelPruneExpiry,
+		GraphPruneInterval:   cfg.Routing.GraphPruneInterval,
+		CompactZombies:       cfg.Routing.CompactZombies,
+		NumValidationWorkers: cfg.Workers.Validation,
+		QueryBandwidth:       queryBandwidth,
+		AssumeChannelValid:   cfg.Routing.AssumeChannelValid,
+		NextPaymentID:        sequencer.NextID,
+		PathFindingConfig:    pathFindingConfig,
+		PeerExposure:         peerExposure,
+		Clock:                clock.NewDefaultClock(),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("can't create router: %v", err)
@@ -804,6 +901,7 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 		MinimumBatchSize:        10,
 		SubBatchDelay:           time.Second * 5,
 		IgnoreHistoricalFilters: cfg.IgnoreHistoricalGossipFilters,
+		NumValidationWorkers:    cfg.Workers.Validation,
 	},
 		s.identityECDH.PubKey(),
 	)
@@ -813,6 +911,7 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 		PropagateChanPolicyUpdate: s.authGossiper.PropagateChanPolicyUpdate,
 		UpdateForwardingPolicies:  s.htlcSwitch.UpdateForwardingPolicies,
 		FetchChannel:              s.remoteChanDB.FetchChannel,
+		AddPolicyChanges:          s.remoteChanDB.AddPolicyChanges,
 	}
 
 	utxnStore, err := newNurseryStore(s.cfg.ActiveNetParams.GenesisHash, remoteChanDB)
@@ -832,21 +931,50 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 		return nil, err
 	}
 
+	s.epochFanout = chainntnfs.NewBlockEpochFanout(cc.ChainNotifier)
+
+	// If a consolidation address set is configured, matured time-locked
+	// outputs are swept to it rather than to the wallet.
+	consolidationScripts, err := parseConsolidationAddrs(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sweep and justice transactions are published through the alternate
+	// broadcasters as well, so a single backend can't keep them from
+	// confirming.
+	txPublisher, err := newTxPublisher(cfg, cc.Wallet.PublishTransaction)
+	if err != nil {
+		return nil, err
+	}
+
 	s.sweeper = sweep.New(&sweep.UtxoSweeperConfig{
 		FeeEstimator:   cc.FeeEstimator,
 		GenSweepScript: newSweepPkScriptGen(cc.Wallet),
 		Signer:         cc.Wallet.Cfg.Signer,
-		Wallet:         cc.Wallet,
+		Wallet: &sweeperWallet{
+			Wallet:    cc.Wallet,
+			publisher: txPublisher,
+		},
 		NewBatchTimer: func() <-chan time.Time {
 			return time.NewTimer(sweep.DefaultBatchWindowDuration).C
 		},
-		Notifier:             cc.ChainNotifier,
+		Notifier:             s.epochFanout,
 		Store:                sweeperStore,
 		MaxInputsPerTx:       sweep.DefaultMaxInputsPerTx,
 		MaxSweepAttempts:     sweep.DefaultMaxSweepAttempts,
 		NextAttemptDeltaFunc: sweep.DefaultNextAttemptDeltaFunc,
 		MaxFeeRate:           sweep.DefaultMaxFeeRate,
 		FeeRateBucketSize:    sweep.DefaultFeeRateBucketSize,
+		GenConsolidationScript: newConsolidationScriptGen(
+			consolidationScripts,
+		),
+	})
+
+	s.watchOnlyTracker = watchonly.NewTracker(&watchonly.Config{
+		DB:       remoteChanDB,
+		ChainIO:  cc.ChainIO,
+		Notifier: s.epochFanout,
 	})
 
 	s.utxoNursery = newUtxoNursery(&NurseryConfig{
@@ -858,6 +986,8 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 		PublishTransaction:  cc.Wallet.PublishTransaction,
 		Store:               utxnStore,
 		SweepInput:          s.sweeper.SweepInput,
+		Estimator:           cc.FeeEstimator,
+		Budget:              *cfg.Sweeper.Budget,
 	})
 
 	// Construct a closure that wraps the htlcswitch's CloseLink method.
@@ -869,7 +999,7 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 		// Instruct the switch to close the channel.  Provide no close out
 		// delivery script or target fee per kw because user input is not
 		// available when the remote peer closes the channel.
-		s.htlcSwitch.CloseLink(chanPoint, closureType, 0, nil)
+		s.htlcSwitch.CloseLink(chanPoint, closureType, 0, 0, nil)
 	}
 
 	// We will use the following channel to reliably hand off contract
@@ -913,7 +1043,7 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 			)
 		},
 		PreimageDB:   s.witnessBeacon,
-		Notifier:     cc.ChainNotifier,
+		Notifier:     s.epochFanout,
 		Signer:       cc.Wallet.Cfg.Signer,
 		FeeEstimator: cc.FeeEstimator,
 		ChainIO:      cc.ChainIO,
@@ -948,6 +1078,7 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 		},
 		DisableChannel:                s.chanStatusMgr.RequestDisable,
 		Sweeper:                       s.sweeper,
+		Budget:                        *cfg.Sweeper.Budget,
 		Registry:                      s.invoices,
 		NotifyClosedChannel:           s.channelNotifier.NotifyClosedChannelEvent,
 		OnionProcessor:                s.sphinx,
@@ -962,7 +1093,7 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 		Estimator:          s.cc.FeeEstimator,
 		GenSweepScript:     newSweepPkScriptGen(cc.Wallet),
 		Notifier:           cc.ChainNotifier,
-		PublishTransaction: cc.Wallet.PublishTransaction,
+		PublishTransaction: txPublisher.PublishTransaction,
 		ContractBreaches:   contractBreaches,
 		Signer:             cc.Wallet.Cfg.Signer,
 		Store:              newRetributionStore(remoteChanDB),
@@ -972,19 +1103,22 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 	// Litecoin, depending on the primary registered chain.
 	primaryChain := cfg.registeredChains.PrimaryChain()
 	chainCfg := cfg.Bitcoin
-	minRemoteDelay := minBtcRemoteDelay
-	maxRemoteDelay := maxBtcRemoteDelay
 	if primaryChain == chainreg.LitecoinChain {
 		chainCfg = cfg.Litecoin
-		minRemoteDelay = minLtcRemoteDelay
-		maxRemoteDelay = maxLtcRemoteDelay
 	}
+	chainPolicy := cfg.registeredChains.PrimaryChainPolicy()
+	minRemoteDelay := chainPolicy.MinRemoteDelay
+	maxRemoteDelay := chainPolicy.MaxRemoteDelay
 
 	var chanIDSeed [32]byte
 	if _, err := rand.Read(chanIDSeed[:]); err != nil {
 		return nil, err
 	}
 
+	maxFundingFeeRate := chainfee.SatPerKVByte(
+		cfg.MaxFundingFeeRate * 1000,
+	).FeePerKWeight()
+
 	s.fundingMgr, err = newFundingManager(fundingConfig{
 		NoWumboChans:       !cfg.ProtocolOptions.Wumbo(),
 		IDKey:              nodeKeyECDH.PubKey(),
@@ -993,7 +1127,7 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 		UpdateLabel: func(hash chainhash.Hash, label string) error {
 			return cc.Wallet.LabelTransaction(hash, label, true)
 		},
-		Notifier:     cc.ChainNotifier,
+		Notifier:     s.epochFanout,
 		FeeEstimator: cc.FeeEstimator,
 		SignMessage: func(pubKey *btcec.PublicKey,
 			msg []byte) (input.Signature, error) {
@@ -1056,29 +1190,20 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 				return defaultConf
 			}
 
-			minConf := uint64(3)
-			maxConf := uint64(6)
-
 			// If this is a wumbo channel, then we'll require the
 			// max amount of confirmations.
 			if chanAmt > MaxFundingAmount {
-				return uint16(maxConf)
+				return chainCfg.MaxChanConfs
 			}
 
-			// If not we return a value scaled linearly
-			// between 3 and 6, depending on channel size.
-			// TODO(halseth): Use 1 as minimum?
-			maxChannelSize := uint64(
-				lnwire.NewMSatFromSatoshis(MaxFundingAmount))
+			// If not we return a value scaled according to the
+			// configured curve, depending on the channel size.
 			stake := lnwire.NewMSatFromSatoshis(chanAmt) + pushAmt
-			conf := maxConf * uint64(stake) / maxChannelSize
-			if conf < minConf {
-				conf = minConf
-			}
-			if conf > maxConf {
-				conf = maxConf
-			}
-			return uint16(conf)
+			return chainCfg.NumRequiredConfs(stake, MaxFundingAmount)
+		},
+		IsTrustedPeer: func(pubKey *btcec.PublicKey) bool {
+			_, ok := cfg.trustedPeers[route.NewVertex(pubKey)]
+			return ok
 		},
 		RequiredRemoteDelay: func(chanAmt btcutil.Amount) uint16 {
 			// We scale the remote CSV delay (the time the
@@ -1137,6 +1262,17 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 			cid := lnwire.NewChanIDFromOutPoint(&chanPoint)
 			return s.htlcSwitch.UpdateShortChanID(cid)
 		},
+		SuspendChannel: func(chanPoint wire.OutPoint) error {
+			cid := lnwire.NewChanIDFromOutPoint(&chanPoint)
+			s.htlcSwitch.SuspendLink(cid)
+
+			return s.chanStatusMgr.RequestDisable(chanPoint)
+		},
+		ResumeChannel: func(chanPoint wire.OutPoint) error {
+			cid := lnwire.NewChanIDFromOutPoint(&chanPoint)
+			return s.htlcSwitch.ResumeLink(cid)
+		},
+		IsDraining: s.htlcSwitch.IsDraining,
 		RequiredRemoteChanReserve: func(chanAmt,
 			dustLimit btcutil.Amount) btcutil.Amount {
 
@@ -1169,17 +1305,35 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 			return uint16(input.MaxHTLCNumber / 2)
 		},
 		ZombieSweeperInterval:         1 * time.Minute,
-		ReservationTimeout:            10 * time.Minute,
+		ReservationTimeout:            cfg.ReservationTimeout,
+		RemoteReservationTimeout:      cfg.RemoteReservationTimeout,
+		PsbtReservationTimeout:        cfg.PsbtReservationTimeout,
+		MaxFundingFeeRate:             maxFundingFeeRate,
+		FundingFeeRetryTimeout:        cfg.FundingFeeRetryTimeout,
+		FundingFeeRetryInterval:       1 * time.Minute,
+		FundingLockedRetryDelay:       time.Second,
+		FundingLockedMaxRetryDelay:    10 * time.Minute,
+		FundingLockedAlertThreshold:   cfg.FundingLockedAlertThreshold,
 		MinChanSize:                   btcutil.Amount(cfg.MinChanSize),
 		MaxChanSize:                   btcutil.Amount(cfg.MaxChanSize),
 		MaxPendingChannels:            cfg.MaxPendingChannels,
+		PeerMaxPendingChannels:        cfg.peerMaxPendingChannels,
+		TrustedPeerUnlimitedPending:   cfg.TrustedPeerUnlimitedPending,
 		RejectPush:                    cfg.RejectPush,
+		CreateScidAlias:               remoteChanDB.CreateScidAlias,
+		PutPeerScidAlias:              remoteChanDB.PutPeerScidAlias,
+		MaxAnnouncementDelay:          cfg.MaxChanAnnouncementDelay,
+		AnnouncementBatch:             cfg.ChanAnnouncementBatch,
 		MaxLocalCSVDelay:              chainCfg.MaxLocalDelay,
 		NotifyOpenChannelEvent:        s.channelNotifier.NotifyOpenChannelEvent,
 		OpenChannelPredicate:          chanPredicate,
 		NotifyPendingOpenChannelEvent: s.channelNotifier.NotifyPendingOpenChannelEvent,
+		NotifyPrunedReservationEvent:  s.channelNotifier.NotifyPrunedReservationEvent,
 		EnableUpfrontShutdown:         cfg.EnableUpfrontShutdown,
+		CloseAddrPolicy:               s.closeAddrPolicy,
 		RegisteredChains:              cfg.registeredChains,
+		NotifyFundingLockedStalledEvent: s.channelNotifier.
+			NotifyFundingLockedStalledEvent,
 	})
 	if err != nil {
 		return nil, err
@@ -1207,6 +1361,10 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 	// to peer online and offline events.
 	s.peerNotifier = peernotifier.New()
 
+	// The custom message server hands custom messages received from our
+	// peers to the applications that subscribed to them.
+	s.customMessageServer = subscribe.NewServer()
+
 	// Create a channel event store which monitors all open channels.
 	s.chanEventStore = chanfitness.NewChannelEventStore(&chanfitness.Config{
 		SubscribeChannelEvents: func() (subscribe.Subscription, error) {
@@ -1220,10 +1378,81 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 		ReadFlapCount:   s.remoteChanDB.ReadFlapCount,
 		WriteFlapCount:  s.remoteChanDB.WriteFlapCounts,
 		FlapCountTicker: ticker.New(chanfitness.FlapCountFlushRate),
+
+		WriteOnlineHistory: s.remoteChanDB.WriteOnlineHistories,
+		ReadOnlineHistory:  s.remoteChanDB.ReadOnlineHistory,
+		HistoryRetention:   chanfitness.DefaultHistoryRetention,
+	})
+
+	s.swapHooks = swaphook.New(&swaphook.Config{
+		FetchChannels: s.remoteChanDB.FetchAllOpenChannels,
+	})
+
+	// Create the event bus which records all node events in a persistent
+	// event log.
+	s.eventBus = eventbus.New(&eventbus.Config{
+		SubscribeChannelEvents: func() (subscribe.Subscription, error) {
+			return s.channelNotifier.SubscribeChannelEvents()
+		},
+		SubscribePeerEvents: func() (subscribe.Subscription, error) {
+			return s.peerNotifier.SubscribePeerEvents()
+		},
+		SubscribeHtlcEvents: func() (subscribe.Subscription, error) {
+			return s.htlcNotifier.SubscribeHtlcEvents()
+		},
+		SubscribeInvoices: func() (*eventbus.InvoiceSubscription,
+			error) {
+
+			sub, err := s.invoices.SubscribeNotifications(0, 0)
+			if err != nil {
+				return nil, err
+			}
+
+			return &eventbus.InvoiceSubscription{
+				NewInvoices:     sub.NewInvoices,
+				SettledInvoices: sub.SettledInvoices,
+				Cancel:          sub.Cancel,
+			}, nil
+		},
+		AddEvent: func(event *channeldb.NodeEvent) error {
+			return s.remoteChanDB.AddNodeEvent(
+				event, eventbus.DefaultMaxEvents,
+			)
+		},
+		FetchEvents: s.remoteChanDB.FetchNodeEvents,
+		ChainParams: cfg.ActiveNetParams.Params,
+		Clock:       clock.NewDefaultClock(),
 	})
 
+	if len(cfg.Webhook.URLs) != 0 {
+		s.webhookDispatcher = webhook.NewDispatcher(&webhook.Config{
+			URLs:       cfg.Webhook.URLs,
+			Secret:     []byte(cfg.Webhook.Secret),
+			MaxRetries: cfg.Webhook.MaxRetries,
+			RetryDelay: cfg.Webhook.RetryDelay,
+			Timeout:    cfg.Webhook.Timeout,
+			SubscribeChannelEvents: func() (subscribe.Subscription,
+				error) {
+
+				return s.channelNotifier.SubscribeChannelEvents()
+			},
+			SubscribePeerEvents: func() (subscribe.Subscription,
+				error) {
+
+				return s.peerNotifier.SubscribePeerEvents()
+			},
+			Clock: clock.NewDefaultClock(),
+		})
+	}
+
 	if cfg.WtClient.Active {
+		// States of channels with and without anchor outputs are
+		// backed up by separate clients, as their justice transactions
+		// follow different policies.
 		policy := wtpolicy.DefaultPolicy()
+		anchorPolicy := wtpolicy.DefaultPolicyForType(
+			blob.TypeAltruistAnchorCommit,
+		)
 
 		if cfg.WtClient.SweepFeeRate != 0 {
 			// We expose the sweep fee rate in sat/byte, but the
@@ -1232,11 +1461,19 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 				1000 * cfg.WtClient.SweepFeeRate,
 			)
 			policy.SweepFeeRate = sweepRateSatPerByte.FeePerKWeight()
+			anchorPolicy.SweepFeeRate = policy.SweepFeeRate
+		}
+
+		if cfg.WtClient.FeeEscalation {
+			policy.BlobType = blob.TypeAltruistCommitFeeEscalation
 		}
 
 		if err := policy.Validate(); err != nil {
 			return nil, err
 		}
+		if err := anchorPolicy.Validate(); err != nil {
+			return nil, err
+		}
 
 		// authDial is the wrapper around the btrontide.Dial for the
 		// watchtower.
@@ -1249,7 +1486,7 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 			)
 		}
 
-		s.towerClient, err = wtclient.New(&wtclient.Config{
+		wtClientCfg := &wtclient.Config{
 			Signer:         cc.Wallet.Cfg.Signer,
 			NewAddress:     newSweepPkScriptGen(cc.Wallet),
 			SecretKeyRing:  s.cc.KeyRing,
@@ -1261,11 +1498,40 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 			MinBackoff:     10 * time.Second,
 			MaxBackoff:     5 * time.Minute,
 			ForceQuitDelay: wtclient.DefaultForceQuitDelay,
-		})
+		}
+
+		s.towerClient, err = wtclient.New(wtClientCfg)
+		if err != nil {
+			return nil, err
+		}
+
+		wtClientCfg.Policy = anchorPolicy
+		s.anchorTowerClient, err = wtclient.New(wtClientCfg)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	peerFilterRules, err := cfg.PeerFilter.Rules()
+	if err != nil {
+		return nil, err
+	}
+	var asnTable *peerfilter.ASNTable
+	if cfg.PeerFilter.ASNFile != "" {
+		asnTable, err = peerfilter.LoadASNTable(cfg.PeerFilter.ASNFile)
 		if err != nil {
 			return nil, err
 		}
 	}
+	s.peerFilter, err = peerfilter.New(&peerfilter.Config{
+		Rules:     peerFilterRules,
+		AllowOnly: cfg.PeerFilter.AllowOnly,
+		ASNTable:  asnTable,
+		Store:     remoteChanDB,
+	})
+	if err != nil {
+		return nil, err
+	}
 
 	if len(cfg.ExternalHosts) != 0 {
 		advertisedIPs := make(map[string]struct{})
@@ -1293,6 +1559,14 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 	chainHealthCheck := healthcheck.NewObservation(
 		"chain backend",
 		func() error {
+			// If backup chain backends are configured, a failing
+			// primary only causes chain notifications to fail
+			// over, rather than shutting down lnd.
+			failover, ok := cc.ChainNotifier.(*chainntnfs.FailoverNotifier)
+			if ok {
+				return failover.CheckHealth()
+			}
+
 			_, _, err := cc.ChainIO.GetBestBlock()
 			return err
 		},
@@ -1326,13 +1600,43 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 		cfg.HealthChecks.DiskCheck.Attempts,
 	)
 
+	checks := []*healthcheck.Observation{chainHealthCheck, diskCheck}
+
+	// If we manage our onion services through the Tor controller, we'll
+	// also make sure it stays reachable.
+	if torController != nil {
+		torCheck := healthcheck.NewObservation(
+			"tor connection",
+			healthcheck.ReachableCheck(
+				cfg.Tor.Control,
+				cfg.HealthChecks.TorConnection.Timeout,
+			),
+			cfg.HealthChecks.TorConnection.Interval,
+			cfg.HealthChecks.TorConnection.Timeout,
+			cfg.HealthChecks.TorConnection.Backoff,
+			cfg.HealthChecks.TorConnection.Attempts,
+		)
+		checks = append(checks, torCheck)
+	}
+
+	// Add a reachability check for every remote service the user
+	// configured.
+	remoteCfg := cfg.HealthChecks.RemoteCheck
+	for _, addr := range remoteCfg.Addresses {
+		remoteCheck := healthcheck.NewObservation(
+			fmt.Sprintf("remote %v", addr),
+			healthcheck.ReachableCheck(addr, remoteCfg.Timeout),
+			remoteCfg.Interval, remoteCfg.Timeout,
+			remoteCfg.Backoff, remoteCfg.Attempts,
+		)
+		checks = append(checks, remoteCheck)
+	}
+
 	// If we have not disabled all of our health checks, we create a
 	// liveliness monitor with our configured checks.
 	s.livelinessMonitor = healthcheck.NewMonitor(
 		&healthcheck.Config{
-			Checks: []*healthcheck.Observation{
-				chainHealthCheck, diskCheck,
-			},
+			Checks:   checks,
 			Shutdown: srvrLog.Criticalf,
 		},
 	)
@@ -1358,6 +1662,233 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 	return s, nil
 }
 
+// numPendingHtlcs returns the number of HTLCs that are currently in flight on
+// all of our open channels.
+func (s *server) numPendingHtlcs() (int, error) {
+	channels, err := s.remoteChanDB.FetchAllOpenChannels()
+	if err != nil {
+		return 0, err
+	}
+
+	var numHtlcs int
+	for _, channel := range channels {
+		// An HTLC is in flight until it's removed from the commitments
+		// of both parties, so we count the commitment that holds more
+		// of them.
+		localHtlcs := len(channel.LocalCommitment.Htlcs)
+		remoteHtlcs := len(channel.RemoteCommitment.Htlcs)
+		if localHtlcs > remoteHtlcs {
+			numHtlcs += localHtlcs
+		} else {
+			numHtlcs += remoteHtlcs
+		}
+	}
+
+	return numHtlcs, nil
+}
+
+// defaultRoutingPolicy returns the default forwarding policy for the links of
+// newly opened channels.
+func (s *server) defaultRoutingPolicy() htlcswitch.ForwardingPolicy {
+	s.reloadMtx.RLock()
+	defer s.reloadMtx.RUnlock()
+
+	return s.routingPolicy
+}
+
+// reloadConfig re-reads the daemon's configuration and applies the options
+// that can be changed at runtime. The names of the options whose values changed
+// are returned.
+func (s *server) reloadConfig() ([]string, error) {
+	newCfg, err := ReloadConfig(s.cfg, os.Args[1:])
+	if err != nil {
+		return nil, fmt.Errorf("unable to reload config: %v", err)
+	}
+
+	return s.applyConfig(newCfg)
+}
+
+// applyConfig applies the options of a reloaded configuration that can be
+// changed at runtime, i.e. the default routing policy, the channel size limits,
+// the limits on pending channels, the reservation timeouts and the statically
+// configured watchtowers. The names of the options whose values changed are
+// returned.
+func (s *server) applyConfig(newCfg *Config) ([]string, error) {
+	chainCfg := newCfg.Bitcoin
+	if s.cfg.registeredChains.PrimaryChain() == chainreg.LitecoinChain {
+		chainCfg = newCfg.Litecoin
+	}
+
+	policy := htlcswitch.ForwardingPolicy{
+		MinHTLCOut:    chainCfg.MinHTLCOut,
+		BaseFee:       chainCfg.BaseFee,
+		FeeRate:       chainCfg.FeeRate,
+		TimeLockDelta: chainCfg.TimeLockDelta,
+	}
+	limits := &fundingLimits{
+		DefaultRoutingPolicy: policy,
+		MinChanSize:          btcutil.Amount(newCfg.MinChanSize),
+		MaxChanSize:          btcutil.Amount(newCfg.MaxChanSize),
+		MaxPendingChannels:   newCfg.MaxPendingChannels,
+		ReservationTimeout:   newCfg.ReservationTimeout,
+
+		RemoteReservationTimeout:    newCfg.RemoteReservationTimeout,
+		PsbtReservationTimeout:      newCfg.PsbtReservationTimeout,
+		PeerMaxPendingChannels:      newCfg.peerMaxPendingChannels,
+		TrustedPeerUnlimitedPending: newCfg.TrustedPeerUnlimitedPending,
+	}
+
+	s.reloadMtx.Lock()
+	defer s.reloadMtx.Unlock()
+
+	// Gather the options that changed before applying them, so the caller
+	// can report what was updated.
+	var updated []string
+	oldLimits := s.fundingMgr.currentLimits()
+	if oldLimits.DefaultRoutingPolicy != policy {
+		updated = append(updated, "routing policy")
+	}
+	if oldLimits.MinChanSize != limits.MinChanSize {
+		updated = append(updated, "minchansize")
+	}
+	if oldLimits.MaxChanSize != limits.MaxChanSize {
+		updated = append(updated, "maxchansize")
+	}
+	if oldLimits.MaxPendingChannels != limits.MaxPendingChannels {
+		updated = append(updated, "maxpendingchannels")
+	}
+	if !reflect.DeepEqual(
+		oldLimits.PeerMaxPendingChannels, limits.PeerMaxPendingChannels,
+	) {
+
+		updated = append(updated, "peermaxpendingchannels")
+	}
+	if oldLimits.TrustedPeerUnlimitedPending !=
+		limits.TrustedPeerUnlimitedPending {
+
+		updated = append(updated, "trustedpeerunlimitedpending")
+	}
+	if oldLimits.ReservationTimeout != limits.ReservationTimeout {
+		updated = append(updated, "reservationtimeout")
+	}
+	if oldLimits.RemoteReservationTimeout !=
+		limits.RemoteReservationTimeout {
+
+		updated = append(updated, "remotereservationtimeout")
+	}
+	if oldLimits.PsbtReservationTimeout != limits.PsbtReservationTimeout {
+		updated = append(updated, "psbtreservationtimeout")
+	}
+
+	s.routingPolicy = policy
+	s.fundingMgr.UpdateConfig(limits)
+
+	if s.towerClient != nil {
+		towers, err := parseTowerAddrs(newCfg)
+		if err != nil {
+			return nil, err
+		}
+
+		towersChanged, err := s.updateConfigTowersLocked(towers)
+		if err != nil {
+			return nil, err
+		}
+		if towersChanged {
+			updated = append(updated, "wtclient.tower")
+		}
+	}
+
+	srvrLog.Infof("Reloaded config, updated options: %v", updated)
+
+	return updated, nil
+}
+
+// updateConfigTowers registers the given watchtowers with the tower client and
+// removes the ones that were previously configured but are no longer part of
+// the list.
+func (s *server) updateConfigTowers(towers []*lnwire.NetAddress) error {
+	s.reloadMtx.Lock()
+	defer s.reloadMtx.Unlock()
+
+	_, err := s.updateConfigTowersLocked(towers)
+	return err
+}
+
+// updateConfigTowersLocked is the same as updateConfigTowers, but reports
+// whether the set of configured towers changed.
+//
+// NOTE: The reloadMtx MUST be held when calling this method.
+func (s *server) updateConfigTowersLocked(
+	towers []*lnwire.NetAddress) (bool, error) {
+
+	newTowers := make(map[string]*lnwire.NetAddress, len(towers))
+	for _, tower := range towers {
+		newTowers[tower.String()] = tower
+	}
+
+	var changed bool
+	for uri, tower := range s.configTowers {
+		if _, ok := newTowers[uri]; ok {
+			continue
+		}
+
+		// If another configured URI still points to the same tower,
+		// we'll only remove this address from it.
+		var addr net.Addr
+		for _, newTower := range newTowers {
+			if newTower.IdentityKey.IsEqual(tower.IdentityKey) {
+				addr = tower.Address
+				break
+			}
+		}
+
+		srvrLog.Infof("Removing watchtower %v", uri)
+		for _, client := range s.towerClients() {
+			err := client.RemoveTower(tower.IdentityKey, addr)
+			if err != nil {
+				return changed, fmt.Errorf("unable to remove "+
+					"watchtower %v: %v", uri, err)
+			}
+		}
+
+		delete(s.configTowers, uri)
+		changed = true
+	}
+
+	for uri, tower := range newTowers {
+		if _, ok := s.configTowers[uri]; ok {
+			continue
+		}
+
+		srvrLog.Infof("Adding watchtower %v", uri)
+		for _, client := range s.towerClients() {
+			if err := client.AddTower(tower); err != nil {
+				return changed, fmt.Errorf("unable to add "+
+					"watchtower %v: %v", uri, err)
+			}
+		}
+
+		s.configTowers[uri] = tower
+		changed = true
+	}
+
+	return changed, nil
+}
+
+// towerClients returns the active watchtower clients, which back up states of
+// channels without and with anchor outputs respectively.
+func (s *server) towerClients() []wtclient.Client {
+	var clients []wtclient.Client
+	if s.towerClient != nil {
+		clients = append(clients, s.towerClient)
+	}
+	if s.anchorTowerClient != nil {
+		clients = append(clients, s.anchorTowerClient)
+	}
+
+	return clients
+}
+
 // Started returns true if the server has been started, and false otherwise.
 // NOTE: This function is safe for concurrent access.
 func (s *server) Started() bool {
@@ -1382,6 +1913,11 @@ func (s *server) Start() error {
 			go s.watchExternalIP()
 		}
 
+		if s.cfg.Payments.Active() {
+			s.wg.Add(1)
+			go s.prunePayments()
+		}
+
 		if s.hostAnn != nil {
 			if err := s.hostAnn.Start(); err != nil {
 				startErr = err
@@ -1417,6 +1953,10 @@ func (s *server) Start() error {
 			startErr = err
 			return
 		}
+		if err := s.epochFanout.Start(); err != nil {
+			startErr = err
+			return
+		}
 		if err := s.channelNotifier.Start(); err != nil {
 			startErr = err
 			return
@@ -1425,6 +1965,10 @@ func (s *server) Start() error {
 			startErr = err
 			return
 		}
+		if err := s.customMessageServer.Start(); err != nil {
+			startErr = err
+			return
+		}
 		if err := s.htlcNotifier.Start(); err != nil {
 			startErr = err
 			return
@@ -1434,7 +1978,19 @@ func (s *server) Start() error {
 			return
 		}
 		if s.towerClient != nil {
-			if err := s.towerClient.Start(); err != nil {
+			for _, client := range s.towerClients() {
+				if err := client.Start(); err != nil {
+					startErr = err
+					return
+				}
+			}
+
+			towers, err := parseTowerAddrs(s.cfg)
+			if err != nil {
+				startErr = err
+				return
+			}
+			if err := s.updateConfigTowers(towers); err != nil {
 				startErr = err
 				return
 			}
@@ -1447,6 +2003,10 @@ func (s *server) Start() error {
 			startErr = err
 			return
 		}
+		if err := s.watchOnlyTracker.Start(); err != nil {
+			startErr = err
+			return
+		}
 		if err := s.utxoNursery.Start(); err != nil {
 			startErr = err
 			return
@@ -1485,6 +2045,23 @@ func (s *server) Start() error {
 			return
 		}
 
+		if err := s.swapHooks.Start(); err != nil {
+			startErr = err
+			return
+		}
+
+		if err := s.eventBus.Start(); err != nil {
+			startErr = err
+			return
+		}
+
+		if s.webhookDispatcher != nil {
+			if err := s.webhookDispatcher.Start(); err != nil {
+				startErr = err
+				return
+			}
+		}
+
 		// Before we start the connMgr, we'll check to see if we have
 		// any backups to recover. We do this now as we want to ensure
 		// that have all the information we need to handle channel
@@ -1579,6 +2156,14 @@ func (s *server) Stop() error {
 
 		// Shutdown the wallet, funding manager, and the rpc server.
 		s.chanStatusMgr.Stop()
+		if err := s.watchOnlyTracker.Stop(); err != nil {
+			srvrLog.Warnf("Unable to stop watch-only tracker: %v",
+				err)
+		}
+		if err := s.epochFanout.Stop(); err != nil {
+			srvrLog.Warnf("Unable to stop block epoch fanout: %v",
+				err)
+		}
 		if err := s.cc.ChainNotifier.Stop(); err != nil {
 			srvrLog.Warnf("Unable to stop ChainNotifier: %v", err)
 		}
@@ -1592,6 +2177,7 @@ func (s *server) Stop() error {
 		s.sweeper.Stop()
 		s.channelNotifier.Stop()
 		s.peerNotifier.Stop()
+		s.customMessageServer.Stop()
 		s.htlcNotifier.Stop()
 		if err := s.cc.Wallet.Shutdown(); err != nil {
 			srvrLog.Warnf("Unable to stop Wallet: %v", err)
@@ -1607,6 +2193,11 @@ func (s *server) Stop() error {
 		s.fundingMgr.Stop()
 		s.chanSubSwapper.Stop()
 		s.chanEventStore.Stop()
+		s.swapHooks.Stop()
+		s.eventBus.Stop()
+		if s.webhookDispatcher != nil {
+			s.webhookDispatcher.Stop()
+		}
 
 		// Disconnect from each active peers to ensure that
 		// peerTerminationWatchers signal completion to each peer.
@@ -1624,8 +2215,8 @@ func (s *server) Stop() error {
 		// client which will reliably flush all queued states to the
 		// tower. If this is halted for any reason, the force quit timer
 		// will kick in and abort to allow this method to return.
-		if s.towerClient != nil {
-			s.towerClient.Stop()
+		for _, client := range s.towerClients() {
+			client.Stop()
 		}
 
 		if s.hostAnn != nil {
@@ -1700,6 +2291,66 @@ func (s *server) removePortForwarding() {
 	}
 }
 
+// prunePayments deletes the payments that exceed the configured retention
+// limits on startup and then at every prune interval.
+//
+// NOTE: This MUST be run as a goroutine.
+func (s *server) prunePayments() {
+	defer s.wg.Done()
+
+	retention := s.cfg.Payments
+
+	prune := func() {
+		// Payments exceeding either limit are pruned, so each limit
+		// is applied by a separate query.
+		var queries []channeldb.PaymentPruneQuery
+		if retention.MaxAge != 0 {
+			cutoff := time.Now().Add(-retention.MaxAge)
+			queries = append(queries, channeldb.PaymentPruneQuery{
+				FailedOnly:      retention.FailedOnly,
+				FailedHtlcsOnly: retention.FailedHtlcsOnly,
+				CreatedBefore:   cutoff,
+			})
+		}
+		if retention.MaxCount != 0 {
+			queries = append(queries, channeldb.PaymentPruneQuery{
+				FailedOnly:      retention.FailedOnly,
+				FailedHtlcsOnly: retention.FailedHtlcsOnly,
+				KeepLast:        retention.MaxCount,
+			})
+		}
+
+		for _, query := range queries {
+			numPruned, err := s.remoteChanDB.PrunePayments(query)
+			if err != nil {
+				srvrLog.Errorf("Unable to prune payments: %v",
+					err)
+				return
+			}
+
+			if numPruned > 0 {
+				srvrLog.Infof("Pruned %v payments exceeding "+
+					"the retention limits", numPruned)
+			}
+		}
+	}
+
+	prune()
+
+	ticker := time.NewTicker(retention.PruneInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			prune()
+
+		case <-s.quit:
+			return
+		}
+	}
+}
+
 // watchExternalIP continuously checks for an updated external IP address every
 // 15 minutes. Once a new IP address has been detected, it will automatically
 // handle port forwarding rules and send updated node announcements to the
@@ -1861,16 +2512,41 @@ func initNetworkBootstrappers(s *server) ([]discovery.NetworkPeerBootstrapper, e
 	// If this isn't simnet mode, then one of our additional bootstrapping
 	// sources will be the set of running DNS seeds.
 	if !s.cfg.Bitcoin.SimNet || !s.cfg.Litecoin.SimNet {
-		dnsSeeds, ok := chainreg.ChainDNSSeeds[*s.cfg.ActiveNetParams.GenesisHash]
+		chainHash := *s.cfg.ActiveNetParams.GenesisHash
+		dnsSeeds := chainreg.ChainDNSSeeds[chainHash]
+
+		// The configured DNS seeds are used on top of the default ones
+		// of the chain, which we must not modify.
+		customSeeds, err := s.cfg.Bootstrap.ParseDNSSeeds()
+		if err != nil {
+			return nil, err
+		}
+		dnsSeeds = append(dnsSeeds[:len(dnsSeeds):len(dnsSeeds)],
+			customSeeds...)
+
+		// The seeds are queried with the system resolver, or through
+		// the configured DNS-over-HTTPS resolver, which is reached
+		// through the proxy if Tor is active.
+		var resolver discovery.DNSResolver = s.cfg.net
+		if s.cfg.Bootstrap.DoHURL != "" {
+			srvrLog.Infof("Querying DNS seeds with DNS-over-HTTPS "+
+				"resolver %v", s.cfg.Bootstrap.DoHURL)
+
+			resolver = discovery.NewDoHResolver(
+				s.cfg.Bootstrap.DoHURL, s.cfg.net.Dial,
+				s.cfg.ConnectionTimeout,
+			)
+		}
 
 		// If we have a set of DNS seeds for this chain, then we'll add
 		// it as an additional bootstrapping source.
-		if ok {
+		if len(dnsSeeds) > 0 {
 			srvrLog.Infof("Creating DNS peer bootstrapper with "+
 				"seeds: %v", dnsSeeds)
 
 			dnsBootStrapper := discovery.NewDNSSeedBootstrapper(
-				dnsSeeds, s.cfg.net, s.cfg.ConnectionTimeout,
+				dnsSeeds, s.cfg.net, resolver,
+				s.cfg.ConnectionTimeout,
 			)
 			bootStrappers = append(bootStrappers, dnsBootStrapper)
 		}
@@ -2195,6 +2871,83 @@ func (s *server) createNewHiddenService() error {
 	return nil
 }
 
+// announceTower advertises the integrated watchtower as a public altruist
+// tower in our node announcement, so that watchtower clients can discover it
+// in the channel graph.
+func (s *server) announceTower(towerKey *btcec.PublicKey,
+	towerAddrs []net.Addr) error {
+
+	if len(towerAddrs) == 0 {
+		return fmt.Errorf("watchtower has no external addresses to " +
+			"publish")
+	}
+
+	// The tower doesn't accept rewards, so only the altruist session
+	// types are advertised.
+	var blobTypes []blob.Type
+	for _, blobType := range []blob.Type{
+		blob.TypeAltruistCommit, blob.TypeAltruistAnchorCommit,
+		blob.TypeAltruistCommitFeeEscalation,
+	} {
+		if blob.IsSupportedType(blobType) {
+			blobTypes = append(blobTypes, blobType)
+		}
+	}
+
+	tower := &wtdirectory.Tower{
+		PubKey:    towerKey,
+		Addresses: towerAddrs,
+		BlobTypes: blobTypes,
+	}
+	extraOpaqueData, err := tower.ExtraOpaqueData()
+	if err != nil {
+		return err
+	}
+
+	newNodeAnn, err := s.genNodeAnnouncement(
+		true, func(currentAnn *lnwire.NodeAnnouncement) {
+			currentAnn.ExtraOpaqueData = extraOpaqueData
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("unable to generate new node "+
+			"announcement: %v", err)
+	}
+
+	// Update the on-disk version of our announcement, so it's served to
+	// nodes syncing the graph from us, and send it to our current peers.
+	selfNode := &channeldb.LightningNode{
+		HaveNodeAnnouncement: true,
+		LastUpdate:           time.Unix(int64(newNodeAnn.Timestamp), 0),
+		Addresses:            newNodeAnn.Addresses,
+		Alias:                newNodeAnn.Alias.String(),
+		Features: lnwire.NewFeatureVector(
+			newNodeAnn.Features, lnwire.Features,
+		),
+		Color:           newNodeAnn.RGBColor,
+		AuthSigBytes:    newNodeAnn.Signature.ToSignatureBytes(),
+		ExtraOpaqueData: newNodeAnn.ExtraOpaqueData,
+	}
+	copy(
+		selfNode.PubKeyBytes[:],
+		s.identityECDH.PubKey().SerializeCompressed(),
+	)
+	err = s.localChanDB.ChannelGraph().SetSourceNode(selfNode)
+	if err != nil {
+		return fmt.Errorf("can't set self node: %v", err)
+	}
+
+	if err := s.BroadcastMessage(nil, &newNodeAnn); err != nil {
+		return fmt.Errorf("unable to broadcast node announcement: %v",
+			err)
+	}
+
+	srvrLog.Infof("Published watchtower %x@%v in node announcement",
+		towerKey.SerializeCompressed(), towerAddrs)
+
+	return nil
+}
+
 // genNodeAnnouncement generates and returns the current fully signed node
 // announcement. If refresh is true, then the time stamp of the announcement
 // will be updated in order to ensure it propagates through the network.
@@ -2672,6 +3425,15 @@ func (s *server) InboundPeerConnected(conn net.Conn) {
 		return
 	}
 
+	// Reject connections from addresses that aren't accepted by the peer
+	// filter.
+	if err := s.peerFilter.Accept(conn.RemoteAddr()); err != nil {
+		srvrLog.Debugf("Rejecting inbound connection: %v", err)
+
+		conn.Close()
+		return
+	}
+
 	nodePub := conn.(*brontide.Conn).RemotePub()
 	pubStr := string(nodePub.SerializeCompressed())
 
@@ -2930,6 +3692,12 @@ func (s *server) peerConnected(conn net.Conn, connReq *connmgr.ConnReq,
 		ChainNet:    s.cfg.ActiveNetParams.Net,
 	}
 
+	err := brontideConn.SetKeepAlive(s.cfg.Ping.TCPKeepAlive)
+	if err != nil {
+		srvrLog.Warnf("Unable to set keepalive for connection to %v: %v",
+			addr, err)
+	}
+
 	// With the brontide connection established, we'll now craft the feature
 	// vectors to advertise to the remote node.
 	initFeatures := s.featureMgr.Get(feature.SetInit)
@@ -2979,13 +3747,14 @@ func (s *server) peerConnected(conn net.Conn, connReq *connmgr.ConnReq,
 		SigPool:                 s.sigPool,
 		Wallet:                  s.cc.Wallet,
 		ChainNotifier:           s.cc.ChainNotifier,
-		RoutingPolicy:           s.cc.RoutingPolicy,
+		RoutingPolicy:           s.defaultRoutingPolicy,
 		Sphinx:                  s.sphinx,
 		WitnessBeacon:           s.witnessBeacon,
 		Invoices:                s.invoices,
 		ChannelNotifier:         s.channelNotifier,
 		HtlcNotifier:            s.htlcNotifier,
 		TowerClient:             s.towerClient,
+		AnchorTowerClient:       s.anchorTowerClient,
 		DisconnectPeer:          s.DisconnectPeer,
 		GenNodeAnnouncement:     s.genNodeAnnouncement,
 
@@ -2995,10 +3764,24 @@ func (s *server) peerConnected(conn net.Conn, connReq *connmgr.ConnReq,
 
 		FundingManager: s.fundingMgr,
 
-		Hodl:                    s.cfg.Hodl,
-		UnsafeReplay:            s.cfg.UnsafeReplay,
-		MaxOutgoingCltvExpiry:   s.cfg.MaxOutgoingCltvExpiry,
-		MaxChannelFeeAllocation: s.cfg.MaxChannelFeeAllocation,
+		HandleCustomMessage: s.handleCustomMessage,
+
+		PingInterval:      s.cfg.Ping.Interval,
+		MaxMissedPings:    s.cfg.Ping.MaxMissed,
+		WriteStallTimeout: s.cfg.Ping.WriteStallTimeout,
+		LazyWriteRate:     s.cfg.Ping.GossipWriteRate,
+
+		Hodl:                      s.cfg.Hodl,
+		UnsafeReplay:              s.cfg.UnsafeReplay,
+		MaxOutgoingCltvExpiry:     s.cfg.MaxOutgoingCltvExpiry,
+		MaxChannelFeeAllocation:   s.cfg.MaxChannelFeeAllocation,
+		CommitFeeBufferMultiplier: s.cfg.CommitFeeBufferMultiplier,
+		RemoteFeePolicy: htlcswitch.RemoteFeePolicy{
+			MinFeeRatio:   s.cfg.RemoteFee.MinRatio,
+			MaxFeeRatio:   s.cfg.RemoteFee.MaxRatio,
+			MaxViolations: s.cfg.RemoteFee.MaxViolations,
+		},
+		ExperimentalEndorsement: s.cfg.ProtocolOptions.Endorsement,
 		Quit:                    s.quit,
 	}
 
@@ -3345,7 +4128,7 @@ func (s *server) removePeer(p *peer.Brontide) {
 	var pubKey [33]byte
 	copy(pubKey[:], pubSer)
 
-	s.peerNotifier.NotifyPeerOffline(pubKey)
+	s.peerNotifier.NotifyPeerOffline(pubKey, p.EvictionReason())
 }
 
 // openChanReq is a message sent to the server in order to request the
@@ -3396,11 +4179,34 @@ type openChanReq struct {
 	// used.
 	chanFunder chanfunding.Assembler
 
+	// coinSelectionStrategy is the strategy used to order the wallet's
+	// coins during coin selection. If not specified, then the wallet's
+	// default strategy is used.
+	coinSelectionStrategy chanfunding.CoinSelectionStrategy
+
+	// outpoints is an optional list of wallet outputs that must be used to
+	// fund the channel.
+	outpoints []wire.OutPoint
+
+	// fundingAccount is the name of the wallet account the channel is
+	// funded from. If empty, the default account is used.
+	fundingAccount string
+
 	// pendingChanID is not all zeroes (the default value), then this will
 	// be the pending channel ID used for the funding flow within the wire
 	// protocol.
 	pendingChanID [32]byte
 
+	// queueTTL is the maximum time the request waits for the target peer
+	// to come online if it is offline. If zero, the request fails
+	// immediately if the peer is offline.
+	queueTTL time.Duration
+
+	// queueCancel is closed once the caller is no longer interested in
+	// the request, for example because the RPC client disconnected. A
+	// request that is still queued then fails.
+	queueCancel <-chan struct{}
+
 	updates chan *lnrpc.OpenStatusUpdate
 	err     chan error
 }
@@ -3544,6 +4350,63 @@ func (s *server) DisconnectPeer(pubKey *btcec.PublicKey) error {
 	return nil
 }
 
+// CustomMessage is a custom message received from a peer.
+type CustomMessage struct {
+	// Peer is the public key of the peer that sent the message.
+	Peer [33]byte
+
+	// Msg is the custom message itself.
+	Msg *lnwire.Custom
+}
+
+// SendCustomMessage sends a custom message of the given type to a connected
+// peer. Only odd types in the custom message range can be sent.
+//
+// NOTE: This function is safe for concurrent access.
+func (s *server) SendCustomMessage(peerPub [33]byte, msgType lnwire.MessageType,
+	data []byte) error {
+
+	peer, err := s.FindPeerByPubStr(string(peerPub[:]))
+	if err != nil {
+		return err
+	}
+
+	msg, err := lnwire.NewCustom(msgType, data)
+	if err != nil {
+		return err
+	}
+
+	// Wait until the peer is fully started, so that the message isn't
+	// sent before the init message.
+	select {
+	case <-peer.ActiveSignal():
+	case <-peer.QuitSignal():
+		return ErrPeerNotConnected
+	case <-s.quit:
+		return ErrServerShuttingDown
+	}
+
+	return peer.SendMessageLazy(true, msg)
+}
+
+// SubscribeCustomMessages subscribes to the custom messages received from our
+// peers. The updates of the returned client are of type *CustomMessage.
+func (s *server) SubscribeCustomMessages() (*subscribe.Client, error) {
+	return s.customMessageServer.Subscribe()
+}
+
+// handleCustomMessage dispatches a custom message received from a peer to all
+// subscribed clients.
+func (s *server) handleCustomMessage(peer [33]byte, msg *lnwire.Custom) error {
+	srvrLog.Debugf("Custom message of type %v received from peer %x",
+		uint16(msg.MsgType()), peer)
+
+	return s.customMessageServer.SendUpdate(&CustomMessage{
+		Peer: peer,
+		Msg:  msg,
+	})
+}
+
 // OpenChannel sends a request to the server to open a channel to the specified
 // peer identified by nodeKey with the passed channel funding parameters.
 //
@@ -3558,28 +4421,43 @@ func (s *server) OpenChannel(
 	req.err = make(chan error, 1)
 
 	// First attempt to locate the target peer to open a channel with, if
-	// we're unable to locate the peer then this request will fail.
+	// we're unable to locate the peer then this request will fail, unless
+	// it should be queued until the peer comes online.
 	pubKeyBytes := req.targetPubkey.SerializeCompressed()
 	s.mu.RLock()
 	peer, ok := s.peersByPub[string(pubKeyBytes)]
-	if !ok {
-		s.mu.RUnlock()
+	s.mu.RUnlock()
+
+	switch {
+	case ok:
+		s.openChannelWithPeer(peer, req)
 
+	case req.queueTTL != 0:
+		s.wg.Add(1)
+		go s.queueOpenChannel(req)
+
+	default:
 		req.err <- fmt.Errorf("peer %x is not online", pubKeyBytes)
-		return req.updates, req.err
 	}
-	s.mu.RUnlock()
 
+	return req.updates, req.err
+}
+
+// openChannelWithPeer starts the funding flow of the request with the given
+// peer once the peer is active. Any error is sent on the error channel of the
+// request.
+func (s *server) openChannelWithPeer(p *peer.Brontide, req *openChanReq) {
 	// We'll wait until the peer is active before beginning the channel
 	// opening process.
 	select {
-	case <-peer.ActiveSignal():
-	case <-peer.QuitSignal():
-		req.err <- fmt.Errorf("peer %x disconnected", pubKeyBytes)
-		return req.updates, req.err
+	case <-p.ActiveSignal():
+	case <-p.QuitSignal():
+		req.err <- fmt.Errorf("peer %x disconnected",
+			req.targetPubkey.SerializeCompressed())
+		return
 	case <-s.quit:
 		req.err <- ErrServerShuttingDown
-		return req.updates, req.err
+		return
 	}
 
 	// If the fee rate wasn't specified, then we'll use a default
@@ -3589,7 +4467,7 @@ func (s *server) OpenChannel(
 		feeRate, err := estimator.EstimateFeePerKW(6)
 		if err != nil {
 			req.err <- err
-			return req.updates, req.err
+			return
 		}
 		req.fundingFeePerKw = feeRate
 	}
@@ -3598,9 +4476,7 @@ func (s *server) OpenChannel(
 	// manager. This allows the server to continue handling queries instead
 	// of blocking on this request which is exported as a synchronous
 	// request to the outside world.
-	go s.fundingMgr.initFundingWorkflow(peer, req)
-
-	return req.updates, req.err
+	go s.fundingMgr.initFundingWorkflow(p, req)
 }
 
 // Peers returns a slice of all active peers.
@@ -3701,9 +4577,35 @@ func (s *server) fetchLastChanUpdate() func(lnwire.ShortChannelID) (
 			return nil, err
 		}
 
-		return netann.ExtractChannelUpdate(
+		update, err := netann.ExtractChannelUpdate(
 			ourPubKey[:], info, edge1, edge2,
 		)
+		if err != nil {
+			return nil, err
+		}
+
+		// If we handed out an alias for this private channel, senders
+		// only know the channel by its alias. We sign the update for
+		// the alias instead, so the real short channel ID, and with it
+		// the funding outpoint, isn't revealed in failure messages.
+		alias, err := s.remoteChanDB.FetchScidAlias(cid)
+		switch {
+		case err == channeldb.ErrScidAliasNotFound:
+			return update, nil
+
+		case err != nil:
+			return nil, err
+		}
+
+		update.ShortChannelID = alias
+		err = netann.SignChannelUpdate(
+			s.nodeSigner, s.identityECDH.PubKey(), update,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		return update, nil
 	}
 }
 
@@ -3728,7 +4630,9 @@ func newSweepPkScriptGen(
 	wallet lnwallet.WalletController) func() ([]byte, error) {
 
 	return func() ([]byte, error) {
-		sweepAddr, err := wallet.NewAddress(lnwallet.WitnessPubKey, false)
+		sweepAddr, err := wallet.NewAddress(
+			lnwallet.WitnessPubKey, false, lnwallet.DefaultAccountName,
+		)
 		if err != nil {
 			return nil, err
 		}
@@ -3736,3 +4640,26 @@ func newSweepPkScriptGen(
 		return txscript.PayToAddrScript(sweepAddr)
 	}
 }
+
+// newConsolidationScriptGen creates a closure that hands out the scripts of the
+// consolidation address set in turn. If the set is empty, nil is returned so
+// that all outputs are swept into the wallet.
+func newConsolidationScriptGen(scripts [][]byte) func() ([]byte, error) {
+	if len(scripts) == 0 {
+		return nil
+	}
+
+	var (
+		mu   sync.Mutex
+		next int
+	)
+	return func() ([]byte, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		script := scripts[next]
+		next = (next + 1) % len(scripts)
+
+		return script, nil
+	}
+}
@@ -10,13 +10,23 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/hex"
 	"encoding/pem"
+	"errors"
+	"fmt"
 	"io/ioutil"
 	"math/big"
 	"net"
 	"os"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/cryptomeow/lnd/cert"
+	"github.com/cryptomeow/lnd/lnwire"
+	"github.com/cryptomeow/lnd/watchtower/wtclient"
+	"github.com/stretchr/testify/require"
 )
 
 func TestParseHexColor(t *testing.T) {
@@ -119,7 +129,7 @@ func TestTLSAutoRegeneration(t *testing.T) {
 		TLSKeyPath:   keyPath,
 		RPCListeners: rpcListeners,
 	}
-	_, _, _, cleanUp, err := getTLSConfig(cfg)
+	_, _, _, _, cleanUp, err := getTLSConfig(cfg)
 	if err != nil {
 		t.Fatalf("couldn't retrieve TLS config")
 	}
@@ -144,6 +154,168 @@ func TestTLSAutoRegeneration(t *testing.T) {
 	}
 }
 
+// TestTLSRotation tests that a rotated TLS certificate is written to disk and
+// served to new connections without restarting the listener, and that the
+// REST proxy credentials keep accepting the served certificate.
+func TestTLSRotation(t *testing.T) {
+	tempDirPath, err := ioutil.TempDir("", ".testLnd")
+	if err != nil {
+		t.Fatalf("couldn't create temporary cert directory")
+	}
+	defer os.RemoveAll(tempDirPath)
+
+	rpcListener := net.IPAddr{IP: net.ParseIP("127.0.0.1"), Zone: ""}
+	cfg := &Config{
+		TLSCertPath:  tempDirPath + "/tls.cert",
+		TLSKeyPath:   tempDirPath + "/tls.key",
+		RPCListeners: []net.Addr{&rpcListener},
+	}
+	tlsCfg, _, _, rotator, cleanUp, err := getTLSConfig(cfg)
+	if err != nil {
+		t.Fatalf("couldn't retrieve TLS config: %v", err)
+	}
+	defer cleanUp()
+
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", tlsCfg)
+	if err != nil {
+		t.Fatalf("unable to listen: %v", err)
+	}
+	defer listener.Close()
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			// Complete the handshake before closing the connection.
+			_ = conn.(*tls.Conn).Handshake()
+			conn.Close()
+		}
+	}()
+
+	// servedCert connects to the listener with the REST proxy credentials
+	// and returns the certificate presented by it.
+	servedCert := func() []byte {
+		conn, err := tls.Dial(
+			"tcp", listener.Addr().String(),
+			rotator.ClientTLSConfig(),
+		)
+		if err != nil {
+			t.Fatalf("unable to connect: %v", err)
+		}
+		defer conn.Close()
+
+		return conn.ConnectionState().PeerCertificates[0].Raw
+	}
+
+	// diskCert returns the certificate that is written to disk.
+	diskCert := func() []byte {
+		certData, err := tls.LoadX509KeyPair(
+			cfg.TLSCertPath, cfg.TLSKeyPath,
+		)
+		if err != nil {
+			t.Fatalf("couldn't load certificate: %v", err)
+		}
+
+		return certData.Certificate[0]
+	}
+
+	oldCert := servedCert()
+	if !bytes.Equal(oldCert, diskCert()) {
+		t.Fatalf("served certificate doesn't match certificate on disk")
+	}
+
+	if err := rotator.Rotate(); err != nil {
+		t.Fatalf("unable to rotate certificate: %v", err)
+	}
+
+	newCert := servedCert()
+	if bytes.Equal(oldCert, newCert) {
+		t.Fatalf("certificate wasn't rotated")
+	}
+	if !bytes.Equal(newCert, diskCert()) {
+		t.Fatalf("served certificate doesn't match certificate on disk")
+	}
+
+	// Certificates other than the one served are rejected.
+	err = rotator.verifyServerCert([][]byte{oldCert}, nil)
+	if err != errTLSCertMismatch {
+		t.Fatalf("expected certificate mismatch, got: %v", err)
+	}
+}
+
+// TestTLSExternalCert tests that an externally provided TLS certificate is
+// never generated or rotated, and is reloaded once its files change.
+func TestTLSExternalCert(t *testing.T) {
+	tempDirPath, err := ioutil.TempDir("", ".testLnd")
+	if err != nil {
+		t.Fatalf("couldn't create temporary cert directory")
+	}
+	defer os.RemoveAll(tempDirPath)
+
+	rpcListener := net.IPAddr{IP: net.ParseIP("127.0.0.1"), Zone: ""}
+	cfg := &Config{
+		TLSCertPath:     tempDirPath + "/tls.cert",
+		TLSKeyPath:      tempDirPath + "/tls.key",
+		TLSExternalCert: true,
+		RPCListeners:    []net.Addr{&rpcListener},
+	}
+
+	// Without the files, no certificate is generated.
+	if _, _, _, _, _, err := getTLSConfig(cfg); err == nil {
+		t.Fatalf("expected error for missing certificate")
+	}
+
+	genCertPair := func() {
+		err := cert.GenCertPair(
+			"external", cfg.TLSCertPath, cfg.TLSKeyPath, nil, nil,
+			false, cert.DefaultAutogenValidity,
+		)
+		if err != nil {
+			t.Fatalf("unable to generate certificate: %v", err)
+		}
+	}
+	genCertPair()
+
+	_, _, _, rotator, cleanUp, err := getTLSConfig(cfg)
+	if err != nil {
+		t.Fatalf("couldn't retrieve TLS config: %v", err)
+	}
+	defer cleanUp()
+
+	oldCert := rotator.Certificate()
+	if err := rotator.Rotate(); err != errTLSCertExternal {
+		t.Fatalf("expected external certificate error, got: %v", err)
+	}
+
+	// Unchanged files aren't reloaded.
+	rotator.reloadIfChanged()
+	if rotator.Certificate() != oldCert {
+		t.Fatalf("certificate reloaded without change")
+	}
+
+	// Replace the files, making sure their modification time differs
+	// from the previous files.
+	genCertPair()
+	modTime := time.Now().Add(time.Minute)
+	for _, path := range []string{cfg.TLSCertPath, cfg.TLSKeyPath} {
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatalf("unable to set modification time: %v", err)
+		}
+	}
+
+	rotator.reloadIfChanged()
+	newCert := rotator.Certificate()
+	if bytes.Equal(oldCert.Raw, newCert.Raw) {
+		t.Fatalf("certificate wasn't reloaded")
+	}
+	if newCert.Subject.Organization[0] != "external" {
+		t.Fatalf("unexpected certificate: %v", newCert.Subject)
+	}
+}
+
 // genExpiredCertPair generates an expired key/cert pair to test that expired
 // certificates are being regenerated correctly.
 func genExpiredCertPair(t *testing.T, certDirPath string) ([]byte, []byte) {
@@ -202,3 +374,152 @@ func genExpiredCertPair(t *testing.T, certDirPath string) ([]byte, []byte) {
 
 	return certDerBytes, keyBytes
 }
+
+// mockTowerClient is a watchtower client that records the towers that are
+// added and removed.
+type mockTowerClient struct {
+	wtclient.Client
+
+	// added are the URIs of the towers that were added.
+	added []string
+
+	// removed maps the keys of the towers that were removed to the
+	// address that was removed, which is nil if the whole tower was.
+	removed map[string]net.Addr
+
+	// addErr is returned by AddTower if set.
+	addErr error
+}
+
+func (m *mockTowerClient) AddTower(addr *lnwire.NetAddress) error {
+	if m.addErr != nil {
+		return m.addErr
+	}
+
+	m.added = append(m.added, addr.String())
+	return nil
+}
+
+func (m *mockTowerClient) RemoveTower(key *btcec.PublicKey,
+	addr net.Addr) error {
+
+	m.removed[hex.EncodeToString(key.SerializeCompressed())] = addr
+	return nil
+}
+
+// reset forgets the towers that were added and removed so far.
+func (m *mockTowerClient) reset() {
+	m.added = nil
+	m.removed = make(map[string]net.Addr)
+}
+
+// TestServerApplyConfig asserts that the options of a reloaded config are
+// applied to the funding manager and the tower client, and that only the
+// options that changed are reported as updated.
+func TestServerApplyConfig(t *testing.T) {
+	t.Parallel()
+
+	activeCfg, cleanUp := newReloadTestConfig(t)
+	defer cleanUp()
+
+	towerClient := &mockTowerClient{}
+	towerClient.reset()
+	s := &server{
+		cfg:          activeCfg,
+		fundingMgr:   &fundingManager{cfg: &fundingConfig{}},
+		towerClient:  towerClient,
+		configTowers: make(map[string]*lnwire.NetAddress),
+	}
+
+	reload := func(configFile string) ([]string, error) {
+		writeConfigFile(t, activeCfg, configFile)
+
+		newCfg, err := ReloadConfig(activeCfg, nil)
+		require.NoError(t, err)
+
+		return s.applyConfig(newCfg)
+	}
+
+	// Tower A is configured with two addresses.
+	var (
+		towerA1 = newTestTowerURI(t, "127.0.0.1:9911")
+		towerA2 = strings.Replace(towerA1, "127.0.0.1", "127.0.0.2", 1)
+		towerB  = newTestTowerURI(t, "127.0.0.3:9911")
+		towerC  = newTestTowerURI(t, "127.0.0.4:9911")
+	)
+	configFile := fmt.Sprintf(`
+[Application Options]
+minchansize=30000
+
+[wtclient]
+wtclient.tower=%v
+wtclient.tower=%v
+wtclient.tower=%v
+`, towerA1, towerA2, towerB)
+
+	_, err := reload(configFile)
+	require.NoError(t, err)
+	require.ElementsMatch(
+		t, []string{towerA1, towerA2, towerB}, towerClient.added,
+	)
+	require.Empty(t, towerClient.removed)
+
+	// Reloading the same config doesn't change anything.
+	towerClient.reset()
+	updated, err := reload(configFile)
+	require.NoError(t, err)
+	require.Empty(t, updated)
+	require.Empty(t, towerClient.added)
+	require.Empty(t, towerClient.removed)
+
+	// Change the minimum channel size and the base fee, remove the second
+	// address of tower A and tower B, and add tower C.
+	towerClient.reset()
+	updated, err = reload(fmt.Sprintf(`
+[Application Options]
+minchansize=40000
+
+[Bitcoin]
+bitcoin.basefee=2000
+
+[wtclient]
+wtclient.tower=%v
+wtclient.tower=%v
+`, towerA1, towerC))
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"routing policy", "minchansize", "wtclient.tower",
+	}, updated)
+
+	limits := s.fundingMgr.currentLimits()
+	require.EqualValues(t, 40000, limits.MinChanSize)
+	require.EqualValues(t, 2000, limits.DefaultRoutingPolicy.BaseFee)
+	require.Equal(t, limits.DefaultRoutingPolicy, s.defaultRoutingPolicy())
+
+	// Only the second address of tower A is removed, as its first address
+	// is still configured.
+	keyA := strings.Split(towerA1, "@")[0]
+	keyB := strings.Split(towerB, "@")[0]
+	require.Equal(t, []string{towerC}, towerClient.added)
+	require.Len(t, towerClient.removed, 2)
+	require.Equal(t, "127.0.0.2:9911", towerClient.removed[keyA].String())
+	require.Nil(t, towerClient.removed[keyB])
+
+	// A tower that can't be added fails the reload.
+	towerClient.addErr = errors.New("unable to add tower")
+	_, err = reload(fmt.Sprintf(`
+[wtclient]
+wtclient.tower=%v
+`, newTestTowerURI(t, "127.0.0.5:9911")))
+	require.Error(t, err)
+
+	// Without a tower client, the configured towers are ignored.
+	towerClient.reset()
+	s.towerClient = nil
+	_, err = reload(fmt.Sprintf(`
+[wtclient]
+wtclient.tower=%v
+`, towerB))
+	require.NoError(t, err)
+	require.Empty(t, towerClient.added)
+}
@@ -21,6 +21,14 @@ var (
 	// gracefully, similar to when receiving SIGINT.
 	shutdownRequestChannel = make(chan struct{})
 
+	// hangupChannel is used to receive SIGHUP signals, which request the
+	// daemon to reload its configuration.
+	hangupChannel = make(chan os.Signal, 1)
+
+	// reloadChannel is used to notify the daemon that a reload of its
+	// configuration was requested.
+	reloadChannel = make(chan struct{}, 1)
+
 	// started indicates whether we have started our main interrupt handler.
 	// This field should be used atomically.
 	started int32
@@ -46,6 +54,7 @@ func Intercept() error {
 		syscall.SIGQUIT,
 	}
 	signal.Notify(interruptChannel, signalsToCatch...)
+	signal.Notify(hangupChannel, syscall.SIGHUP)
 	go mainInterruptHandler()
 
 	return nil
@@ -89,6 +98,17 @@ func mainInterruptHandler() {
 			log.Infof("Received shutdown request.")
 			shutdown()
 
+		case signal := <-hangupChannel:
+			log.Infof("Received %v, requesting config reload",
+				signal)
+
+			// Don't block if a reload is already pending, as it
+			// will pick up the latest configuration anyway.
+			select {
+			case reloadChannel <- struct{}{}:
+			default:
+			}
+
 		case <-quit:
 			log.Infof("Gracefully shutting down.")
 			close(shutdownChannel)
@@ -129,6 +149,12 @@ func RequestShutdown() {
 	}
 }
 
+// ReloadChannel returns the channel that receives a value whenever a reload of
+// the daemon's configuration is requested through SIGHUP.
+func ReloadChannel() <-chan struct{} {
+	return reloadChannel
+}
+
 // ShutdownChannel returns the channel that will be closed once the main
 // interrupt handler has exited.
 func ShutdownChannel() <-chan struct{} {
@@ -96,6 +96,7 @@ func (s *subRPCServerConfigs) PopulateDependencies(cfg *Config,
 	sweeper *sweep.UtxoSweeper,
 	tower *watchtower.Standalone,
 	towerClient wtclient.Client,
+	anchorTowerClient wtclient.Client,
 	tcpResolver lncfg.TCPResolver,
 	genInvoiceFeatures func() *lnwire.FeatureVector,
 	rpcLogger btclog.Logger) error {
@@ -161,6 +162,9 @@ func (s *subRPCServerConfigs) PopulateDependencies(cfg *Config,
 			subCfgValue.FieldByName("KeyRing").Set(
 				reflect.ValueOf(cc.KeyRing),
 			)
+			subCfgValue.FieldByName("KeyFamilyRegistry").Set(
+				reflect.ValueOf(cc.KeyFamilyRegistry),
+			)
 			subCfgValue.FieldByName("Sweeper").Set(
 				reflect.ValueOf(sweeper),
 			)
@@ -250,10 +254,16 @@ func (s *subRPCServerConfigs) PopulateDependencies(cfg *Config,
 				subCfgValue.FieldByName("Client").Set(
 					reflect.ValueOf(towerClient),
 				)
+				subCfgValue.FieldByName("AnchorClient").Set(
+					reflect.ValueOf(anchorTowerClient),
+				)
 			}
 			subCfgValue.FieldByName("Resolver").Set(
 				reflect.ValueOf(tcpResolver),
 			)
+			subCfgValue.FieldByName("Graph").Set(
+				reflect.ValueOf(chanRouter),
+			)
 			subCfgValue.FieldByName("Log").Set(
 				reflect.ValueOf(rpcLogger),
 			)
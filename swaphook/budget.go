@@ -0,0 +1,81 @@
+package swaphook
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/cryptomeow/lnd/lnwire"
+)
+
+var (
+	// ErrBudgetExhausted is returned when a payment would exceed the total
+	// amount a hook is allowed to pay.
+	ErrBudgetExhausted = errors.New("swap hook payment budget exhausted")
+)
+
+// ErrPaymentTooLarge is returned when a single payment exceeds the amount a
+// hook is allowed to pay at once.
+type ErrPaymentTooLarge struct {
+	// Amount is the amount of the rejected payment.
+	Amount lnwire.MilliSatoshi
+
+	// Max is the maximum amount of a single payment.
+	Max lnwire.MilliSatoshi
+}
+
+// Error returns a human readable string describing the error.
+func (e *ErrPaymentTooLarge) Error() string {
+	return fmt.Sprintf("swap hook payment of %v exceeds maximum of %v",
+		e.Amount, e.Max)
+}
+
+// Budget limits the amounts paid with the payment macaroon of a hook. Each
+// payment is capped, and so is the sum of all payments. Spent amounts are
+// never released, as the outcome of a payment isn't tracked.
+type Budget struct {
+	maxPayment lnwire.MilliSatoshi
+	maxTotal   lnwire.MilliSatoshi
+
+	mu    sync.Mutex
+	spent lnwire.MilliSatoshi
+}
+
+// NewBudget creates a budget that allows payments of at most maxPayment each,
+// up to a total of maxTotal.
+func NewBudget(maxPayment, maxTotal lnwire.MilliSatoshi) *Budget {
+	return &Budget{
+		maxPayment: maxPayment,
+		maxTotal:   maxTotal,
+	}
+}
+
+// Spend reserves the amount of a payment, including the maximum fee, from the
+// budget. An error is returned if the payment isn't allowed, in which case
+// nothing is reserved.
+func (b *Budget) Spend(amt lnwire.MilliSatoshi) error {
+	if amt > b.maxPayment {
+		return &ErrPaymentTooLarge{
+			Amount: amt,
+			Max:    b.maxPayment,
+		}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.spent+amt > b.maxTotal {
+		return ErrBudgetExhausted
+	}
+	b.spent += amt
+
+	return nil
+}
+
+// Spent returns the total amount reserved from the budget so far.
+func (b *Budget) Spent() lnwire.MilliSatoshi {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.spent
+}
@@ -0,0 +1,30 @@
+package swaphook
+
+import (
+	"testing"
+
+	"github.com/cryptomeow/lnd/lnwire"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBudget asserts that a budget enforces both the per-payment and the total
+// cap, and that rejected payments don't use up the budget.
+func TestBudget(t *testing.T) {
+	budget := NewBudget(1000, 2500)
+
+	// A payment above the per-payment cap is rejected.
+	err := budget.Spend(1001)
+	require.IsType(t, &ErrPaymentTooLarge{}, err)
+	require.Equal(t, lnwire.MilliSatoshi(0), budget.Spent())
+
+	// Payments up to the cap are allowed until the total is reached.
+	require.NoError(t, budget.Spend(1000))
+	require.NoError(t, budget.Spend(1000))
+	require.Equal(t, ErrBudgetExhausted, budget.Spend(501))
+	require.Equal(t, lnwire.MilliSatoshi(2000), budget.Spent())
+
+	// The remainder of the budget can still be spent.
+	require.NoError(t, budget.Spend(500))
+	require.Equal(t, ErrBudgetExhausted, budget.Spend(1))
+	require.Equal(t, lnwire.MilliSatoshi(2500), budget.Spent())
+}
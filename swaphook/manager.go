@@ -0,0 +1,352 @@
+// Package swaphook lets external liquidity management services, such as swap
+// clients, register hooks that are notified when the local balance of a channel
+// they watch exceeds a threshold. The services can then move the excess
+// balance out of the channel, e.g. by an off-chain to on-chain swap.
+package swaphook
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/cryptomeow/lnd/channeldb"
+)
+
+const (
+	// DefaultPollInterval is the default interval at which the local
+	// balances of the watched channels are checked.
+	DefaultPollInterval = 30 * time.Second
+
+	// eventBufferSize is the number of events that are buffered for a
+	// hook. If a hook doesn't keep up, further events are delayed until
+	// the following checks.
+	eventBufferSize = 20
+)
+
+var (
+	// ErrNoThresholds is returned when a hook is registered without any
+	// thresholds.
+	ErrNoThresholds = errors.New("at least one threshold must be " +
+		"specified")
+
+	// ErrManagerShuttingDown is returned when a hook is registered while
+	// the manager is shutting down.
+	ErrManagerShuttingDown = errors.New("swap hook manager shutting down")
+)
+
+// Threshold is the local balance of a channel above which a hook is notified.
+type Threshold struct {
+	// ChanPoint is the funding outpoint of the channel.
+	ChanPoint wire.OutPoint
+
+	// LocalBalance is the local balance of the channel above which the
+	// hook is notified.
+	LocalBalance btcutil.Amount
+}
+
+// Event notifies a hook that the local balance of a channel exceeds its
+// threshold.
+type Event struct {
+	// ChanPoint is the funding outpoint of the channel.
+	ChanPoint wire.OutPoint
+
+	// LocalBalance is the current local balance of the channel.
+	LocalBalance btcutil.Amount
+
+	// Threshold is the threshold the local balance exceeds.
+	Threshold btcutil.Amount
+
+	// Capacity is the capacity of the channel.
+	Capacity btcutil.Amount
+}
+
+// Config holds the dependencies of the Manager.
+type Config struct {
+	// FetchChannels returns all open channels.
+	FetchChannels func() ([]*channeldb.OpenChannel, error)
+
+	// PollInterval is the interval at which the local balances of the
+	// watched channels are checked. If zero, DefaultPollInterval is used.
+	PollInterval time.Duration
+}
+
+// Hook is a registered hook. It is notified once each time the local balance
+// of a watched channel rises above its threshold.
+type Hook struct {
+	id   uint64
+	name string
+
+	thresholds map[wire.OutPoint]btcutil.Amount
+
+	// exceeded is the set of channels whose local balance exceeded their
+	// threshold at the latest check, and the hook was notified about. It
+	// is protected by the mutex of the manager.
+	exceeded map[wire.OutPoint]struct{}
+
+	events chan *Event
+
+	cancel     sync.Once
+	quit       chan struct{}
+	unregister func()
+}
+
+// Name returns the name the hook was registered with.
+func (h *Hook) Name() string {
+	return h.name
+}
+
+// Events returns the channel the events of the hook are delivered on.
+func (h *Hook) Events() <-chan *Event {
+	return h.events
+}
+
+// Quit returns a channel that is closed once the hook is canceled, or the
+// manager shuts down.
+func (h *Hook) Quit() <-chan struct{} {
+	return h.quit
+}
+
+// Cancel unregisters the hook.
+func (h *Hook) Cancel() {
+	h.cancel.Do(func() {
+		h.unregister()
+		close(h.quit)
+	})
+}
+
+// Manager keeps track of the registered hooks, and periodically checks the
+// local balances of the channels they watch.
+type Manager struct {
+	started sync.Once
+	stopped sync.Once
+
+	cfg *Config
+
+	// mu protects the registered hooks.
+	mu     sync.Mutex
+	hooks  map[uint64]*Hook
+	nextID uint64
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// New creates a new Manager from the given config.
+func New(cfg *Config) *Manager {
+	return &Manager{
+		cfg:   cfg,
+		hooks: make(map[uint64]*Hook),
+		quit:  make(chan struct{}),
+	}
+}
+
+// Start launches the goroutine that checks the local balances of the watched
+// channels.
+func (m *Manager) Start() error {
+	m.started.Do(func() {
+		log.Infof("Swap hook manager starting")
+
+		m.wg.Add(1)
+		go m.pollHandler()
+	})
+
+	return nil
+}
+
+// Stop stops checking the local balances and cancels all hooks.
+func (m *Manager) Stop() {
+	m.stopped.Do(func() {
+		log.Infof("Swap hook manager shutting down")
+
+		close(m.quit)
+		m.wg.Wait()
+
+		m.mu.Lock()
+		hooks := make([]*Hook, 0, len(m.hooks))
+		for _, hook := range m.hooks {
+			hooks = append(hooks, hook)
+		}
+		m.mu.Unlock()
+
+		for _, hook := range hooks {
+			hook.Cancel()
+		}
+	})
+}
+
+// Register registers a hook with the given name that watches the given
+// channels. All channels must be open, and their thresholds must be below
+// their capacity. The local balances are checked right away, so the hook is
+// notified about channels whose local balance already exceeds the threshold.
+func (m *Manager) Register(name string, thresholds []Threshold) (*Hook,
+	error) {
+
+	if len(thresholds) == 0 {
+		return nil, ErrNoThresholds
+	}
+
+	channels, err := m.cfg.FetchChannels()
+	if err != nil {
+		return nil, err
+	}
+	capacities := make(map[wire.OutPoint]btcutil.Amount, len(channels))
+	for _, channel := range channels {
+		capacities[channel.FundingOutpoint] = channel.Capacity
+	}
+
+	hook := &Hook{
+		name:       name,
+		thresholds: make(map[wire.OutPoint]btcutil.Amount),
+		exceeded:   make(map[wire.OutPoint]struct{}),
+		events:     make(chan *Event, eventBufferSize),
+		quit:       make(chan struct{}),
+	}
+	for _, threshold := range thresholds {
+		capacity, ok := capacities[threshold.ChanPoint]
+		if !ok {
+			return nil, fmt.Errorf("channel %v not found",
+				threshold.ChanPoint)
+		}
+
+		if threshold.LocalBalance <= 0 ||
+			threshold.LocalBalance >= capacity {
+
+			return nil, fmt.Errorf("threshold of channel %v must "+
+				"be between 0 and its capacity %v",
+				threshold.ChanPoint, capacity)
+		}
+
+		hook.thresholds[threshold.ChanPoint] = threshold.LocalBalance
+	}
+
+	select {
+	case <-m.quit:
+		return nil, ErrManagerShuttingDown
+	default:
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	hook.id = m.nextID
+	m.nextID++
+	hook.unregister = func() {
+		m.mu.Lock()
+		delete(m.hooks, hook.id)
+		m.mu.Unlock()
+	}
+	m.hooks[hook.id] = hook
+
+	log.Infof("Registered swap hook %v watching %v channels", name,
+		len(hook.thresholds))
+
+	m.checkHook(hook, channels)
+
+	return hook, nil
+}
+
+// pollHandler periodically checks the local balances of the channels watched
+// by the registered hooks.
+//
+// NOTE: This method MUST be run as a goroutine.
+func (m *Manager) pollHandler() {
+	defer m.wg.Done()
+
+	interval := m.cfg.PollInterval
+	if interval == 0 {
+		interval = DefaultPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := m.checkHooks(); err != nil {
+				log.Errorf("Unable to check channel balances: "+
+					"%v", err)
+			}
+
+		case <-m.quit:
+			return
+		}
+	}
+}
+
+// checkHooks checks the local balances of the channels watched by all
+// registered hooks.
+func (m *Manager) checkHooks() error {
+	m.mu.Lock()
+	numHooks := len(m.hooks)
+	m.mu.Unlock()
+
+	if numHooks == 0 {
+		return nil
+	}
+
+	channels, err := m.cfg.FetchChannels()
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, hook := range m.hooks {
+		m.checkHook(hook, channels)
+	}
+
+	return nil
+}
+
+// checkHook notifies the hook about all watched channels whose local balance
+// rose above their threshold since the previous check. Watched channels that
+// are no longer open are ignored.
+//
+// NOTE: The mutex of the manager MUST be held.
+func (m *Manager) checkHook(hook *Hook, channels []*channeldb.OpenChannel) {
+	for _, channel := range channels {
+		chanPoint := channel.FundingOutpoint
+		threshold, ok := hook.thresholds[chanPoint]
+		if !ok {
+			continue
+		}
+
+		commit := channel.LocalCommitment
+		localBalance := commit.LocalBalance.ToSatoshis()
+		if localBalance <= threshold {
+			delete(hook.exceeded, chanPoint)
+			continue
+		}
+
+		if _, ok := hook.exceeded[chanPoint]; ok {
+			continue
+		}
+
+		event := &Event{
+			ChanPoint:    chanPoint,
+			LocalBalance: localBalance,
+			Threshold:    threshold,
+			Capacity:     channel.Capacity,
+		}
+
+		// If the hook doesn't keep up, we'll try again at the next
+		// check instead of blocking the other hooks.
+		select {
+		case hook.events <- event:
+			hook.exceeded[chanPoint] = struct{}{}
+
+			log.Debugf("Local balance %v of ChannelPoint(%v) "+
+				"exceeds threshold %v of swap hook %v",
+				localBalance, chanPoint, threshold, hook.name)
+
+		default:
+			log.Warnf("Swap hook %v not keeping up, delaying "+
+				"event for ChannelPoint(%v)", hook.name,
+				chanPoint)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package swaphook
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/cryptomeow/lnd/channeldb"
+	"github.com/cryptomeow/lnd/lnwire"
+	"github.com/stretchr/testify/require"
+)
+
+// testChannels is a set of channels whose local balances can be changed.
+type testChannels struct {
+	mu       sync.Mutex
+	channels []*channeldb.OpenChannel
+}
+
+func (c *testChannels) fetch() ([]*channeldb.OpenChannel, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	channels := make([]*channeldb.OpenChannel, 0, len(c.channels))
+	for _, channel := range c.channels {
+		channels = append(channels, &channeldb.OpenChannel{
+			FundingOutpoint: channel.FundingOutpoint,
+			Capacity:        channel.Capacity,
+			LocalCommitment: channel.LocalCommitment,
+		})
+	}
+
+	return channels, nil
+}
+
+func (c *testChannels) setBalance(index int, balance btcutil.Amount) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.channels[index].LocalCommitment.LocalBalance =
+		lnwire.NewMSatFromSatoshis(balance)
+}
+
+func newTestChannels(balances ...btcutil.Amount) *testChannels {
+	c := &testChannels{}
+	for i, balance := range balances {
+		c.channels = append(c.channels, &channeldb.OpenChannel{
+			FundingOutpoint: wire.OutPoint{Index: uint32(i)},
+			Capacity:        100000,
+			LocalCommitment: channeldb.ChannelCommitment{
+				LocalBalance: lnwire.NewMSatFromSatoshis(
+					balance,
+				),
+			},
+		})
+	}
+
+	return c
+}
+
+// TestRegisterValidation asserts that hooks can only be registered for open
+// channels with thresholds below their capacity.
+func TestRegisterValidation(t *testing.T) {
+	t.Parallel()
+
+	channels := newTestChannels(1000)
+	m := New(&Config{FetchChannels: channels.fetch})
+
+	_, err := m.Register("loop", nil)
+	require.Equal(t, ErrNoThresholds, err)
+
+	tests := []Threshold{
+		{ChanPoint: wire.OutPoint{Index: 5}, LocalBalance: 5000},
+		{ChanPoint: wire.OutPoint{Index: 0}, LocalBalance: 0},
+		{ChanPoint: wire.OutPoint{Index: 0}, LocalBalance: 100000},
+	}
+	for _, threshold := range tests {
+		_, err := m.Register("loop", []Threshold{threshold})
+		require.Error(t, err)
+	}
+}
+
+// TestThresholdEvents asserts that a hook is notified once each time the local
+// balance of a channel rises above its threshold.
+func TestThresholdEvents(t *testing.T) {
+	t.Parallel()
+
+	channels := newTestChannels(80000, 10000)
+	m := New(&Config{
+		FetchChannels: channels.fetch,
+		PollInterval:  time.Hour,
+	})
+	require.NoError(t, m.Start())
+	defer m.Stop()
+
+	hook, err := m.Register("loop", []Threshold{
+		{ChanPoint: wire.OutPoint{Index: 0}, LocalBalance: 50000},
+		{ChanPoint: wire.OutPoint{Index: 1}, LocalBalance: 50000},
+	})
+	require.NoError(t, err)
+
+	assertEvent := func(index uint32, balance btcutil.Amount) {
+		t.Helper()
+
+		select {
+		case event := <-hook.Events():
+			require.Equal(t, index, event.ChanPoint.Index)
+			require.Equal(t, balance, event.LocalBalance)
+			require.Equal(t, btcutil.Amount(50000), event.Threshold)
+			require.Equal(t, btcutil.Amount(100000), event.Capacity)
+
+		case <-time.After(time.Second):
+			t.Fatalf("no event received")
+		}
+	}
+	assertNoEvent := func() {
+		t.Helper()
+
+		select {
+		case event := <-hook.Events():
+			t.Fatalf("unexpected event: %v", event)
+		default:
+		}
+	}
+
+	// The first channel already exceeds its threshold when the hook is
+	// registered.
+	assertEvent(0, 80000)
+	assertNoEvent()
+
+	// The hook isn't notified again while the balance stays above the
+	// threshold.
+	require.NoError(t, m.checkHooks())
+	assertNoEvent()
+
+	// Once the second channel exceeds its threshold, only it is reported.
+	channels.setBalance(1, 60000)
+	require.NoError(t, m.checkHooks())
+	assertEvent(1, 60000)
+	assertNoEvent()
+
+	// After the balance of the first channel dropped below the threshold,
+	// it is reported again once it rises above it.
+	channels.setBalance(0, 20000)
+	require.NoError(t, m.checkHooks())
+	assertNoEvent()
+
+	channels.setBalance(0, 70000)
+	require.NoError(t, m.checkHooks())
+	assertEvent(0, 70000)
+
+	// Canceled hooks are no longer notified.
+	hook.Cancel()
+	channels.setBalance(1, 10000)
+	require.NoError(t, m.checkHooks())
+	channels.setBalance(1, 60000)
+	require.NoError(t, m.checkHooks())
+	assertNoEvent()
+
+	select {
+	case <-hook.Quit():
+	default:
+		t.Fatalf("hook not quit")
+	}
+}
@@ -86,8 +86,8 @@ func (b *mockBackend) PublishTransaction(tx *wire.MsgTx, _ string) error {
 	return err
 }
 
-func (b *mockBackend) ListUnspentWitness(minconfirms, maxconfirms int32) (
-	[]*lnwallet.Utxo, error) {
+func (b *mockBackend) ListUnspentWitness(minconfirms, maxconfirms int32,
+	_ string) ([]*lnwallet.Utxo, error) {
 
 	return b.walletUtxos, nil
 }
@@ -14,9 +14,10 @@ type Wallet interface {
 	// ListUnspentWitness returns all unspent outputs which are version 0
 	// witness programs. The 'minconfirms' and 'maxconfirms' parameters
 	// indicate the minimum and maximum number of confirmations an output
-	// needs in order to be returned by this method.
-	ListUnspentWitness(minconfirms, maxconfirms int32) ([]*lnwallet.Utxo,
-		error)
+	// needs in order to be returned by this method. If accountFilter is
+	// not empty, only the outputs of that account are returned.
+	ListUnspentWitness(minconfirms, maxconfirms int32,
+		accountFilter string) ([]*lnwallet.Utxo, error)
 
 	// WithCoinSelectLock will execute the passed function closure in a
 	// synchronized manner preventing any coin selection operations from
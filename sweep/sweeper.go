@@ -15,6 +15,7 @@ import (
 	"github.com/davecgh/go-spew/spew"
 	"github.com/cryptomeow/lnd/chainntnfs"
 	"github.com/cryptomeow/lnd/input"
+	"github.com/cryptomeow/lnd/labels"
 	"github.com/cryptomeow/lnd/lnwallet"
 	"github.com/cryptomeow/lnd/lnwallet/chainfee"
 )
@@ -81,6 +82,17 @@ type Params struct {
 	// ExclusiveGroup is an identifier that, if set, prevents other inputs
 	// with the same identifier from being batched together.
 	ExclusiveGroup *uint64
+
+	// Consolidate indicates that the input is a matured time-locked output
+	// that should be swept into the consolidation address set of the
+	// sweeper, if one is configured. Such inputs are only batched with
+	// other inputs that are consolidated.
+	Consolidate bool
+
+	// ChanPoint is the channel point of the channel the input originates
+	// from, if any. It is used to label the sweep tx with the channel it
+	// belongs to.
+	ChanPoint *wire.OutPoint
 }
 
 // ParamsUpdate contains a new set of parameters to update a pending sweep with.
@@ -97,8 +109,9 @@ type ParamsUpdate struct {
 
 // String returns a human readable interpretation of the sweep parameters.
 func (p Params) String() string {
-	return fmt.Sprintf("fee=%v, force=%v, exclusive_group=%v",
-		p.Fee, p.Force, p.ExclusiveGroup)
+	return fmt.Sprintf("fee=%v, force=%v, exclusive_group=%v, "+
+		"consolidate=%v, chan_point=%v", p.Fee, p.Force,
+		p.ExclusiveGroup, p.Consolidate, p.ChanPoint)
 }
 
 // pendingInput is created when an input reaches the main loop for the first
@@ -146,6 +159,17 @@ type pendingInputs = map[wire.OutPoint]*pendingInput
 type inputCluster struct {
 	sweepFeeRate chainfee.SatPerKWeight
 	inputs       pendingInputs
+
+	// consolidate is true if the inputs of the cluster are swept to the
+	// consolidation address set.
+	consolidate bool
+}
+
+// clusterKey identifies the group of inputs that can be swept together. Inputs
+// are grouped by their fee rate bucket and their destination.
+type clusterKey struct {
+	feeGroup    int
+	consolidate bool
 }
 
 // pendingSweepsReq is an internal message we'll use to represent an external
@@ -228,6 +252,11 @@ type UtxoSweeper struct {
 
 	currentOutputScript []byte
 
+	// currentConsolidationScript is the unused output script of the
+	// consolidation address set, if any. Like the regular output script,
+	// it is kept until a sweep to it was published.
+	currentConsolidationScript []byte
+
 	relayFeeRate chainfee.SatPerKWeight
 
 	quit chan struct{}
@@ -240,6 +269,12 @@ type UtxoSweeperConfig struct {
 	// funds can be swept.
 	GenSweepScript func() ([]byte, error)
 
+	// GenConsolidationScript, if set, returns the next script of the
+	// consolidation address set. Inputs that are offered with the
+	// Consolidate parameter are swept to these scripts instead of the
+	// wallet.
+	GenConsolidationScript func() ([]byte, error)
+
 	// FeeEstimator is used when crafting sweep transactions to estimate
 	// the necessary fee relative to the expected size of the sweep
 	// transaction.
@@ -724,7 +759,10 @@ func (s *UtxoSweeper) sweepCluster(cluster inputCluster,
 
 		// Sweep selected inputs.
 		for _, inputs := range inputLists {
-			err := s.sweep(inputs, cluster.sweepFeeRate, currentHeight)
+			err := s.sweep(
+				inputs, cluster.sweepFeeRate, currentHeight,
+				cluster.consolidate,
+			)
 			if err != nil {
 				return fmt.Errorf("unable to sweep inputs: %v", err)
 			}
@@ -755,7 +793,7 @@ func (s *UtxoSweeper) bucketForFeeRate(
 // sweep fee rate, which is determined by calculating the average fee rate of
 // all inputs within that cluster.
 func (s *UtxoSweeper) clusterBySweepFeeRate() []inputCluster {
-	bucketInputs := make(map[int]*bucketList)
+	bucketInputs := make(map[clusterKey]*bucketList)
 	inputFeeRates := make(map[wire.OutPoint]chainfee.SatPerKWeight)
 
 	// First, we'll group together all inputs with similar fee rates. This
@@ -786,14 +824,21 @@ func (s *UtxoSweeper) clusterBySweepFeeRate() []inputCluster {
 			}
 		}
 
-		feeGroup := s.bucketForFeeRate(feeRate)
+		// Inputs that are consolidated are never batched with inputs
+		// that are swept to the wallet, as they pay to a different
+		// destination.
+		key := clusterKey{
+			feeGroup: s.bucketForFeeRate(feeRate),
+			consolidate: input.params.Consolidate &&
+				s.cfg.GenConsolidationScript != nil,
+		}
 
 		// Create a bucket list for this fee rate if there isn't one
 		// yet.
-		buckets, ok := bucketInputs[feeGroup]
+		buckets, ok := bucketInputs[key]
 		if !ok {
 			buckets = &bucketList{}
-			bucketInputs[feeGroup] = buckets
+			bucketInputs[key] = buckets
 		}
 
 		// Request the bucket list to add this input. The bucket list
@@ -807,7 +852,7 @@ func (s *UtxoSweeper) clusterBySweepFeeRate() []inputCluster {
 	// We'll then determine the sweep fee rate for each set of inputs by
 	// calculating the average fee rate of the inputs within each set.
 	inputClusters := make([]inputCluster, 0, len(bucketInputs))
-	for _, buckets := range bucketInputs {
+	for key, buckets := range bucketInputs {
 		for _, inputs := range buckets.buckets {
 			var sweepFeeRate chainfee.SatPerKWeight
 			for op := range inputs {
@@ -817,6 +862,7 @@ func (s *UtxoSweeper) clusterBySweepFeeRate() []inputCluster {
 			inputClusters = append(inputClusters, inputCluster{
 				sweepFeeRate: sweepFeeRate,
 				inputs:       inputs,
+				consolidate:  key.consolidate,
 			})
 		}
 	}
@@ -939,6 +985,15 @@ func (s *UtxoSweeper) getInputLists(cluster inputCluster,
 		}
 	}
 
+	// Inputs that are consolidated are swept out of the wallet, so we
+	// never add wallet utxos to them. A consolidation set that doesn't
+	// reach the dust limit by itself is left for a later round, once more
+	// inputs have matured.
+	wallet := s.cfg.Wallet
+	if cluster.consolidate {
+		wallet = nil
+	}
+
 	// If there is anything to retry, combine it with the new inputs and
 	// form input sets.
 	var allSets []inputSet
@@ -946,8 +1001,7 @@ func (s *UtxoSweeper) getInputLists(cluster inputCluster,
 		var err error
 		allSets, err = generateInputPartitionings(
 			append(retryInputs, newInputs...), s.relayFeeRate,
-			cluster.sweepFeeRate, s.cfg.MaxInputsPerTx,
-			s.cfg.Wallet,
+			cluster.sweepFeeRate, s.cfg.MaxInputsPerTx, wallet,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("input partitionings: %v", err)
@@ -957,7 +1011,7 @@ func (s *UtxoSweeper) getInputLists(cluster inputCluster,
 	// Create sets for just the new inputs.
 	newSets, err := generateInputPartitionings(
 		newInputs, s.relayFeeRate, cluster.sweepFeeRate,
-		s.cfg.MaxInputsPerTx, s.cfg.Wallet,
+		s.cfg.MaxInputsPerTx, wallet,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("input partitionings: %v", err)
@@ -971,23 +1025,63 @@ func (s *UtxoSweeper) getInputLists(cluster inputCluster,
 	return append(allSets, newSets...), nil
 }
 
+// sweepChanPoint returns the channel point that all of the given inputs
+// originate from. If the inputs don't share a single known channel point, nil
+// is returned.
+func (s *UtxoSweeper) sweepChanPoint(inputs inputSet) *wire.OutPoint {
+	var chanPoint *wire.OutPoint
+	for _, input := range inputs {
+		// Wallet utxos that are added to the sweep don't have a
+		// pending input, and don't belong to a channel.
+		pi, ok := s.pendingInputs[*input.OutPoint()]
+		if !ok {
+			continue
+		}
+
+		switch {
+		case pi.params.ChanPoint == nil:
+			return nil
+
+		case chanPoint == nil:
+			chanPoint = pi.params.ChanPoint
+
+		case *chanPoint != *pi.params.ChanPoint:
+			return nil
+		}
+	}
+
+	return chanPoint
+}
+
 // sweep takes a set of preselected inputs, creates a sweep tx and publishes the
-// tx. The output address is only marked as used if the publish succeeds.
+// tx. The output address is only marked as used if the publish succeeds. If
+// consolidate is true, the inputs are swept to the consolidation address set
+// instead of the wallet.
 func (s *UtxoSweeper) sweep(inputs inputSet, feeRate chainfee.SatPerKWeight,
-	currentHeight int32) error {
+	currentHeight int32, consolidate bool) error {
+
+	// Select the output script and the generator for the destination of
+	// this sweep, and the type of label the sweep tx is published with.
+	outputScript, genScript := &s.currentOutputScript, s.cfg.GenSweepScript
+	labelType := labels.LabelTypeSweepTransaction
+	if consolidate {
+		outputScript = &s.currentConsolidationScript
+		genScript = s.cfg.GenConsolidationScript
+		labelType = labels.LabelTypeConsolidationSweep
+	}
 
 	// Generate an output script if there isn't an unused script available.
-	if s.currentOutputScript == nil {
-		pkScript, err := s.cfg.GenSweepScript()
+	if *outputScript == nil {
+		pkScript, err := genScript()
 		if err != nil {
 			return fmt.Errorf("gen sweep script: %v", err)
 		}
-		s.currentOutputScript = pkScript
+		*outputScript = pkScript
 	}
 
 	// Create sweep tx.
 	tx, err := createSweepTx(
-		inputs, s.currentOutputScript, uint32(currentHeight), feeRate,
+		inputs, *outputScript, uint32(currentHeight), feeRate,
 		s.cfg.Signer,
 	)
 	if err != nil {
@@ -1014,17 +1108,29 @@ func (s *UtxoSweeper) sweep(inputs inputSet, feeRate chainfee.SatPerKWeight,
 		}),
 	)
 
-	err = s.cfg.Wallet.PublishTransaction(tx, "")
+	label := labels.MakeChanPointLabel(labelType, s.sweepChanPoint(inputs))
+	err = s.cfg.Wallet.PublishTransaction(tx, label)
+
+	// If the sweep tx doesn't meet the mempool fee floor right now, we'll
+	// delay broadcasting it by rescheduling its inputs below, like we do
+	// for any other publish attempt. The fee rate of the next attempt
+	// will be based on a fresh estimate that accounts for the floor.
+	if err == lnwallet.ErrMempoolFee {
+		log.Warnf("Sweep tx %v doesn't meet the mempool fee floor, "+
+			"delaying broadcast", tx.TxHash())
+	}
 
 	// In case of an unexpected error, don't try to recover.
-	if err != nil && err != lnwallet.ErrDoubleSpend {
+	if err != nil && err != lnwallet.ErrDoubleSpend &&
+		err != lnwallet.ErrMempoolFee {
+
 		return fmt.Errorf("publish tx: %v", err)
 	}
 
 	// Keep the output script in case of an error, so that it can be reused
 	// for the next transaction and causes no address inflation.
 	if err == nil {
-		s.currentOutputScript = nil
+		*outputScript = nil
 	}
 
 	// Reschedule sweep.
@@ -1376,3 +1376,159 @@ func TestCpfp(t *testing.T) {
 
 	ctx.finish(1)
 }
+
+// TestConsolidate asserts that inputs that are offered with the consolidate
+// parameter are swept to the consolidation address set, separately from the
+// inputs that are swept to the wallet.
+func TestConsolidate(t *testing.T) {
+	ctx := createSweeperTestContext(t)
+
+	// Configure a consolidation address set of two scripts, which are
+	// expected to be used in turn.
+	consolidationScripts := [][]byte{{0xc0}, {0xc1}}
+	var next int
+	ctx.sweeper.cfg.GenConsolidationScript = func() ([]byte, error) {
+		script := consolidationScripts[next%len(consolidationScripts)]
+		next++
+		return script, nil
+	}
+	ctx.restartSweeper()
+
+	// Offer two inputs to be consolidated and one to be swept to the
+	// wallet, all at the same fee preference.
+	consolidateParams := Params{Fee: defaultFeePref.Fee, Consolidate: true}
+	for _, input := range spendableInputs[:2] {
+		_, err := ctx.sweeper.SweepInput(input, consolidateParams)
+		require.NoError(t, err)
+	}
+	_, err := ctx.sweeper.SweepInput(spendableInputs[2], defaultFeePref)
+	require.NoError(t, err)
+
+	// We expect the consolidated inputs to be batched into a single sweep
+	// tx that pays to the first consolidation script, and the other input
+	// to be swept to the wallet in a separate tx.
+	ctx.tick()
+
+	sweepTxes := map[byte]wire.MsgTx{}
+	for i := 0; i < 2; i++ {
+		sweepTx := ctx.receiveTx()
+		require.Len(t, sweepTx.TxOut, 1)
+
+		pkScript := sweepTx.TxOut[0].PkScript
+		require.Len(t, pkScript, 1)
+		sweepTxes[pkScript[0]] = sweepTx
+	}
+
+	consolidationTx, ok := sweepTxes[0xc0]
+	require.True(t, ok, "expected consolidation sweep")
+	assertTxSweepsInputs(
+		t, &consolidationTx, spendableInputs[0], spendableInputs[1],
+	)
+
+	walletTx, ok := sweepTxes[0]
+	require.True(t, ok, "expected wallet sweep")
+	assertTxSweepsInputs(t, &walletTx, spendableInputs[2])
+
+	ctx.backend.mine()
+
+	// A next consolidation sweep should pay to the next script of the set.
+	_, err = ctx.sweeper.SweepInput(spendableInputs[3], consolidateParams)
+	require.NoError(t, err)
+
+	ctx.tick()
+
+	sweepTx := ctx.receiveTx()
+	require.Equal(t, consolidationScripts[1], sweepTx.TxOut[0].PkScript)
+
+	ctx.backend.mine()
+
+	ctx.finish(1)
+}
+
+// TestConsolidateNoWalletUtxo asserts that wallet utxos are never added to a
+// consolidation sweep, and that consolidated inputs that don't reach the dust
+// limit by themselves are left until more inputs are consolidated.
+func TestConsolidateNoWalletUtxo(t *testing.T) {
+	ctx := createSweeperTestContext(t)
+
+	ctx.sweeper.cfg.GenConsolidationScript = func() ([]byte, error) {
+		return []byte{0xc0}, nil
+	}
+	ctx.restartSweeper()
+
+	// Offer an input that yields positively, but doesn't reach the dust
+	// limit of the sweep output by itself. A regular sweep would be
+	// funded by a wallet utxo, but a consolidation sweep isn't, so no
+	// sweep is scheduled.
+	params := Params{
+		Fee:         FeePreference{FeeRate: chainfee.FeePerKwFloor},
+		Consolidate: true,
+	}
+	dustInput := createTestInput(294, input.WitnessKeyHash)
+	_, err := ctx.sweeper.SweepInput(&dustInput, params)
+	require.NoError(t, err)
+
+	ctx.assertNoTick()
+
+	// Once another input is consolidated, both are swept together without
+	// any wallet utxo.
+	largeInput := createTestInput(10000, input.WitnessKeyHash)
+	_, err = ctx.sweeper.SweepInput(&largeInput, params)
+	require.NoError(t, err)
+
+	ctx.tick()
+
+	sweepTx := ctx.receiveTx()
+	require.Equal(t, []byte{0xc0}, sweepTx.TxOut[0].PkScript)
+	assertTxSweepsInputs(t, &sweepTx, &dustInput, &largeInput)
+
+	ctx.backend.mine()
+
+	ctx.finish(1)
+}
+
+// TestSweepChanPoint asserts that a sweep is only associated with a channel
+// point if all of its inputs originate from the same channel.
+func TestSweepChanPoint(t *testing.T) {
+	t.Parallel()
+
+	chanPoint1 := &wire.OutPoint{Hash: chainhash.Hash{1}}
+	chanPoint2 := &wire.OutPoint{Hash: chainhash.Hash{2}}
+
+	s := &UtxoSweeper{
+		pendingInputs: pendingInputs{
+			*spendableInputs[0].OutPoint(): {
+				Input:  spendableInputs[0],
+				params: Params{ChanPoint: chanPoint1},
+			},
+			*spendableInputs[1].OutPoint(): {
+				Input:  spendableInputs[1],
+				params: Params{ChanPoint: chanPoint1},
+			},
+			*spendableInputs[2].OutPoint(): {
+				Input:  spendableInputs[2],
+				params: Params{ChanPoint: chanPoint2},
+			},
+			*spendableInputs[3].OutPoint(): {
+				Input: spendableInputs[3],
+			},
+		},
+	}
+
+	// Inputs of a single channel, optionally with an added wallet utxo
+	// that has no pending input, are associated with that channel.
+	require.Equal(t, chanPoint1, s.sweepChanPoint(inputSet{
+		spendableInputs[0], spendableInputs[1],
+	}))
+	require.Equal(t, chanPoint1, s.sweepChanPoint(inputSet{
+		spendableInputs[0], spendableInputs[4],
+	}))
+
+	// Inputs of different channels, or without a channel, are not.
+	require.Nil(t, s.sweepChanPoint(inputSet{
+		spendableInputs[0], spendableInputs[2],
+	}))
+	require.Nil(t, s.sweepChanPoint(inputSet{
+		spendableInputs[0], spendableInputs[3],
+	}))
+}
@@ -79,7 +79,7 @@ type txInputSet struct {
 	maxInputs int
 
 	// wallet contains wallet functionality required by the input set to
-	// retrieve utxos.
+	// retrieve utxos. If nil, no wallet utxos are added to the set.
 	wallet Wallet
 }
 
@@ -246,19 +246,29 @@ func (t *txInputSet) addPositiveYieldInputs(sweepableInputs []txInput) {
 // tryAddWalletInputsIfNeeded retrieves utxos from the wallet and tries adding as
 // many as required to bring the tx output value above the given minimum.
 func (t *txInputSet) tryAddWalletInputsIfNeeded() error {
-	// If we've already reached the dust limit, no action is needed.
-	if t.dustLimitReached() {
+	// If we've already reached the dust limit, or the set may not be
+	// funded by the wallet, no action is needed.
+	if t.dustLimitReached() || t.wallet == nil {
 		return nil
 	}
 
 	// Retrieve wallet utxos. Only consider confirmed utxos to prevent
-	// problems around RBF rules for unconfirmed inputs.
-	utxos, err := t.wallet.ListUnspentWitness(1, math.MaxInt32)
+	// problems around RBF rules for unconfirmed inputs. The coins of other
+	// accounts than the default one are never used to fund sweeps.
+	utxos, err := t.wallet.ListUnspentWitness(
+		1, math.MaxInt32, lnwallet.DefaultAccountName,
+	)
 	if err != nil {
 		return err
 	}
 
 	for _, utxo := range utxos {
+		// Skip any outputs the user froze, they must not be spent
+		// by the sweeper.
+		if utxo.Frozen {
+			continue
+		}
+
 		input, err := createWalletTxInput(utxo)
 		if err != nil {
 			return err
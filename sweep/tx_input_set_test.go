@@ -105,15 +105,49 @@ func createP2WKHInput(amt btcutil.Amount) input.Input {
 
 type mockWallet struct {
 	Wallet
+
+	frozen bool
 }
 
-func (m *mockWallet) ListUnspentWitness(minconfirms, maxconfirms int32) (
-	[]*lnwallet.Utxo, error) {
+func (m *mockWallet) ListUnspentWitness(minconfirms, maxconfirms int32,
+	_ string) ([]*lnwallet.Utxo, error) {
 
 	return []*lnwallet.Utxo{
 		{
 			AddressType: lnwallet.WitnessPubKey,
 			Value:       10000,
+			Frozen:      m.frozen,
 		},
 	}, nil
 }
+
+// TestTxInputSetFrozenWallet tests that frozen wallet outputs aren't added to
+// a TxInputSet.
+func TestTxInputSetFrozenWallet(t *testing.T) {
+	const (
+		feeRate   = 500
+		relayFee  = 300
+		maxInputs = 10
+	)
+
+	wallet := &mockWallet{frozen: true}
+	set := newTxInputSet(wallet, feeRate, relayFee, maxInputs)
+
+	if !set.add(createP2WKHInput(700), constraintsRegular) {
+		t.Fatal("expected add of positively yielding input to succeed")
+	}
+
+	err := set.tryAddWalletInputsIfNeeded()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The only wallet output is frozen, so the dust limit still isn't
+	// reached.
+	if len(set.inputs) != 1 {
+		t.Fatalf("expected 1 input, got %v", len(set.inputs))
+	}
+	if set.dustLimitReached() {
+		t.Fatal("expected dust limit not to be reached")
+	}
+}
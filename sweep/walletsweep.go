@@ -100,8 +100,11 @@ func DetermineFeePerKw(feeEstimator chainfee.Estimator,
 // to use when crafting sweep transactions.
 type UtxoSource interface {
 	// ListUnspentWitness returns all UTXOs from the source that have
-	// between minConfs and maxConfs number of confirmations.
-	ListUnspentWitness(minConfs, maxConfs int32) ([]*lnwallet.Utxo, error)
+	// between minConfs and maxConfs number of confirmations. If
+	// accountFilter is not empty, only the UTXOs of that account are
+	// returned.
+	ListUnspentWitness(minConfs, maxConfs int32,
+		accountFilter string) ([]*lnwallet.Utxo, error)
 }
 
 // CoinSelectionLocker is an interface that allows the caller to perform an
@@ -152,12 +155,13 @@ type WalletSweepPackage struct {
 // caller to sweep ALL outputs within the wallet to a single UTXO, as specified
 // by the delivery address. The sweep transaction will be crafted with the
 // target fee rate, and will use the utxoSource and outpointLocker as sources
-// for wallet funds.
+// for wallet funds. If account is not empty, only the outputs of that account
+// are swept.
 func CraftSweepAllTx(feeRate chainfee.SatPerKWeight, blockHeight uint32,
 	deliveryAddr btcutil.Address, coinSelectLocker CoinSelectionLocker,
 	utxoSource UtxoSource, outpointLocker OutpointLocker,
-	feeEstimator chainfee.Estimator,
-	signer input.Signer) (*WalletSweepPackage, error) {
+	feeEstimator chainfee.Estimator, signer input.Signer,
+	account string) (*WalletSweepPackage, error) {
 
 	// TODO(roasbeef): turn off ATPL as well when available?
 
@@ -182,7 +186,7 @@ func CraftSweepAllTx(feeRate chainfee.SatPerKWeight, blockHeight uint32,
 		// operations are going on, we can grab a clean snapshot of the
 		// current UTXO state of the wallet.
 		utxos, err := utxoSource.ListUnspentWitness(
-			1, math.MaxInt32,
+			1, math.MaxInt32, account,
 		)
 		if err != nil {
 			return err
@@ -190,13 +194,17 @@ func CraftSweepAllTx(feeRate chainfee.SatPerKWeight, blockHeight uint32,
 
 		// We'll now lock each UTXO to ensure that other callers don't
 		// attempt to use these UTXOs in transactions while we're
-		// crafting out sweep all transaction.
+		// crafting out sweep all transaction. Outputs frozen by the
+		// user are left untouched.
 		for _, utxo := range utxos {
+			if utxo.Frozen {
+				continue
+			}
+
 			outpointLocker.LockOutpoint(utxo.OutPoint)
+			allOutputs = append(allOutputs, utxo)
 		}
 
-		allOutputs = append(allOutputs, utxos...)
-
 		return nil
 	})
 	if err != nil {
@@ -120,9 +120,20 @@ func newMockUtxoSource(utxos []*lnwallet.Utxo) *mockUtxoSource {
 }
 
 func (m *mockUtxoSource) ListUnspentWitness(minConfs int32,
-	maxConfs int32) ([]*lnwallet.Utxo, error) {
+	maxConfs int32, accountFilter string) ([]*lnwallet.Utxo, error) {
 
-	return m.outputs, nil
+	if accountFilter == "" {
+		return m.outputs, nil
+	}
+
+	var outputs []*lnwallet.Utxo
+	for _, output := range m.outputs {
+		if output.Account == accountFilter {
+			outputs = append(outputs, output)
+		}
+	}
+
+	return outputs, nil
 }
 
 type mockCoinSelectionLocker struct {
@@ -289,6 +300,7 @@ func TestCraftSweepAllTxCoinSelectFail(t *testing.T) {
 
 	_, err := CraftSweepAllTx(
 		0, 100, nil, coinSelectLocker, utxoSource, utxoLocker, nil, nil,
+		"",
 	)
 
 	// Since we instructed the coin select locker to fail above, we should
@@ -314,6 +326,7 @@ func TestCraftSweepAllTxUnknownWitnessType(t *testing.T) {
 
 	_, err := CraftSweepAllTx(
 		0, 100, nil, coinSelectLocker, utxoSource, utxoLocker, nil, nil,
+		"",
 	)
 
 	// Since passed in a p2wsh output, which is unknown, we should fail to
@@ -348,7 +361,7 @@ func TestCraftSweepAllTx(t *testing.T) {
 
 	sweepPkg, err := CraftSweepAllTx(
 		0, 100, deliveryAddr, coinSelectLocker, utxoSource, utxoLocker,
-		feeEstimator, signer,
+		feeEstimator, signer, "",
 	)
 	if err != nil {
 		t.Fatalf("unable to make sweep tx: %v", err)
@@ -391,3 +404,94 @@ func TestCraftSweepAllTx(t *testing.T) {
 	sweepPkg.CancelSweepAttempt()
 	assertUtxosUnlocked(t, utxoLocker, testUtxos[:2])
 }
+
+// TestCraftSweepAllTxAccount tests that only the outputs of the given account
+// are swept if an account is specified.
+func TestCraftSweepAllTxAccount(t *testing.T) {
+	t.Parallel()
+
+	signer := &mock.DummySigner{}
+	feeEstimator := newMockFeeEstimator(0, 0)
+
+	// Assign the two sweepable outputs to different accounts.
+	defaultUtxo := *testUtxos[0]
+	defaultUtxo.Account = lnwallet.DefaultAccountName
+	opsUtxo := *testUtxos[1]
+	opsUtxo.Account = "ops"
+
+	utxoSource := newMockUtxoSource(
+		[]*lnwallet.Utxo{&defaultUtxo, &opsUtxo},
+	)
+	coinSelectLocker := &mockCoinSelectionLocker{}
+	utxoLocker := newMockOutpointLocker()
+
+	sweepPkg, err := CraftSweepAllTx(
+		0, 100, deliveryAddr, coinSelectLocker, utxoSource, utxoLocker,
+		feeEstimator, signer, "ops",
+	)
+	if err != nil {
+		t.Fatalf("unable to make sweep tx: %v", err)
+	}
+
+	// Only the output of the ops account should be locked and swept.
+	assertUtxosLocked(t, utxoLocker, []*lnwallet.Utxo{&opsUtxo})
+	if _, ok := utxoLocker.lockedOutpoints[defaultUtxo.OutPoint]; ok {
+		t.Fatalf("output of default account locked")
+	}
+
+	sweepTx := sweepPkg.SweepTx
+	if len(sweepTx.TxIn) != 1 ||
+		sweepTx.TxIn[0].PreviousOutPoint != opsUtxo.OutPoint {
+
+		t.Fatalf("expected sweep of ops output only, got %v inputs",
+			len(sweepTx.TxIn))
+	}
+	if sweepTx.TxOut[0].Value != int64(opsUtxo.Value) {
+		t.Fatalf("expected %v sweep value, instead got %v",
+			opsUtxo.Value, sweepTx.TxOut[0].Value)
+	}
+
+	sweepPkg.CancelSweepAttempt()
+}
+
+// TestCraftSweepAllTxFrozen tests that outputs frozen by the user are neither
+// locked nor swept.
+func TestCraftSweepAllTxFrozen(t *testing.T) {
+	t.Parallel()
+
+	signer := &mock.DummySigner{}
+	feeEstimator := newMockFeeEstimator(0, 0)
+
+	frozenUtxo := *testUtxos[0]
+	frozenUtxo.Frozen = true
+	sweptUtxo := *testUtxos[1]
+
+	utxoSource := newMockUtxoSource(
+		[]*lnwallet.Utxo{&frozenUtxo, &sweptUtxo},
+	)
+	coinSelectLocker := &mockCoinSelectionLocker{}
+	utxoLocker := newMockOutpointLocker()
+
+	sweepPkg, err := CraftSweepAllTx(
+		0, 100, deliveryAddr, coinSelectLocker, utxoSource, utxoLocker,
+		feeEstimator, signer, "",
+	)
+	if err != nil {
+		t.Fatalf("unable to make sweep tx: %v", err)
+	}
+
+	assertUtxosLocked(t, utxoLocker, []*lnwallet.Utxo{&sweptUtxo})
+	if _, ok := utxoLocker.lockedOutpoints[frozenUtxo.OutPoint]; ok {
+		t.Fatalf("frozen output locked")
+	}
+
+	sweepTx := sweepPkg.SweepTx
+	if len(sweepTx.TxIn) != 1 ||
+		sweepTx.TxIn[0].PreviousOutPoint != sweptUtxo.OutPoint {
+
+		t.Fatalf("expected sweep of unfrozen output only, got %v "+
+			"inputs", len(sweepTx.TxIn))
+	}
+
+	sweepPkg.CancelSweepAttempt()
+}
@@ -118,3 +118,22 @@ func (w *weightEstimator) fee() btcutil.Amount {
 
 	return fee
 }
+
+// EstimateSweepFee returns the weight and fee of a transaction that sweeps the
+// given inputs to a single P2WKH output at the given fee rate, as estimated by
+// the sweeper. If inputs have an unconfirmed parent that pays a lower fee
+// rate, the fee includes the fee required to bump the parent to the same fee
+// rate.
+func EstimateSweepFee(inputs []input.Input,
+	feeRate chainfee.SatPerKWeight) (int, btcutil.Amount, error) {
+
+	w := newWeightEstimator(feeRate)
+	for _, inp := range inputs {
+		if err := w.add(inp); err != nil {
+			return 0, 0, err
+		}
+	}
+	w.addP2WKHOutput()
+
+	return w.weight(), w.fee(), nil
+}
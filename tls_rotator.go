@@ -0,0 +1,314 @@
+package lnd
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/cryptomeow/lnd/cert"
+)
+
+const (
+	// tlsRotateCheckInterval is the interval at which the expiry of the
+	// TLS certificate is checked.
+	tlsRotateCheckInterval = time.Hour
+
+	// tlsWatchInterval is the interval at which externally provided TLS
+	// certificate and key files are checked for changes.
+	tlsWatchInterval = 10 * time.Second
+)
+
+var (
+	// errTLSCertMismatch is returned when our own RPC server presents a
+	// TLS certificate other than the one currently served.
+	errTLSCertMismatch = errors.New("TLS certificate doesn't match the " +
+		"certificate currently served")
+
+	// errTLSCertExternal is returned when an externally provided TLS
+	// certificate is requested to be rotated.
+	errTLSCertExternal = errors.New("TLS certificate is provided " +
+		"externally and can't be rotated")
+)
+
+// tlsRotator holds the TLS certificate served by the RPC listeners, and
+// regenerates it before it expires. If the certificate is provided
+// externally, it is reloaded whenever its files change instead. As the
+// certificate is handed to the listeners on each handshake, a new certificate
+// takes effect without restarting them.
+type tlsRotator struct {
+	started sync.Once
+	stopped sync.Once
+
+	cfg *Config
+
+	// rotateMtx serializes rotations, which write the certificate and key
+	// files.
+	rotateMtx sync.Mutex
+
+	mu         sync.RWMutex
+	certData   tls.Certificate
+	parsedCert *x509.Certificate
+
+	// modTime is the latest modification time of the externally provided
+	// certificate and key files when they were last loaded. It is only
+	// accessed by the watchHandler.
+	modTime time.Time
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// newTLSRotator creates a rotator that serves the given certificate, which
+// was loaded from the certificate and key files of the config.
+func newTLSRotator(cfg *Config, certData tls.Certificate,
+	parsedCert *x509.Certificate) *tlsRotator {
+
+	return &tlsRotator{
+		cfg:        cfg,
+		certData:   certData,
+		parsedCert: parsedCert,
+		quit:       make(chan struct{}),
+	}
+}
+
+// Start launches the goroutine that rotates the certificate before it
+// expires, or that reloads an externally provided certificate when it changes.
+// It is a no-op if automatic rotation is disabled.
+func (r *tlsRotator) Start() {
+	r.started.Do(func() {
+		switch {
+		case r.cfg.TLSExternalCert:
+			// The certificate was loaded just before, so it is
+			// only reloaded once the files change after this.
+			modTime, err := tlsFilesModTime(r.cfg)
+			if err != nil {
+				ltndLog.Warnf("Unable to check TLS files: %v",
+					err)
+			}
+			r.modTime = modTime
+
+			r.wg.Add(1)
+			go r.watchHandler()
+
+		case r.cfg.TLSRotateBefore != 0:
+			r.wg.Add(1)
+			go r.rotateHandler()
+		}
+	})
+}
+
+// Stop stops the automatic rotation of the certificate.
+func (r *tlsRotator) Stop() {
+	r.stopped.Do(func() {
+		close(r.quit)
+		r.wg.Wait()
+	})
+}
+
+// rotateHandler periodically checks whether the certificate is about to
+// expire, and rotates it if so.
+//
+// NOTE: This method MUST be run as a goroutine.
+func (r *tlsRotator) rotateHandler() {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(tlsRotateCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		expiry := r.Certificate().NotAfter
+		rotateAt := expiry.Add(-r.cfg.TLSRotateBefore)
+		if !time.Now().Before(rotateAt) {
+			ltndLog.Infof("TLS certificate expires at %v, "+
+				"rotating it", expiry)
+
+			if err := r.Rotate(); err != nil {
+				ltndLog.Errorf("Unable to rotate TLS "+
+					"certificate: %v", err)
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-r.quit:
+			return
+		}
+	}
+}
+
+// watchHandler periodically checks whether the externally provided
+// certificate and key files changed, and reloads them if so.
+//
+// NOTE: This method MUST be run as a goroutine.
+func (r *tlsRotator) watchHandler() {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(tlsWatchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			r.reloadIfChanged()
+
+		case <-r.quit:
+			return
+		}
+	}
+}
+
+// reloadIfChanged reloads the externally provided certificate and key if
+// either file was modified since they were last loaded.
+func (r *tlsRotator) reloadIfChanged() {
+	modTime, err := tlsFilesModTime(r.cfg)
+	if err != nil {
+		ltndLog.Warnf("Unable to check TLS files: %v", err)
+		return
+	}
+	if modTime.Equal(r.modTime) {
+		return
+	}
+
+	// The files may be replaced one after the other, in which case the
+	// certificate and key don't match until both were written. We'll
+	// then keep serving the previous certificate and try again at the
+	// next check.
+	certData, parsedCert, err := cert.LoadCert(
+		r.cfg.TLSCertPath, r.cfg.TLSKeyPath,
+	)
+	if err != nil {
+		ltndLog.Warnf("Unable to reload TLS certificate: %v", err)
+		return
+	}
+	r.modTime = modTime
+
+	r.mu.Lock()
+	r.certData = certData
+	r.parsedCert = parsedCert
+	r.mu.Unlock()
+
+	ltndLog.Infof("Reloaded TLS certificate, expires at %v",
+		parsedCert.NotAfter)
+}
+
+// tlsFilesModTime returns the latest modification time of the TLS certificate
+// and key files.
+func tlsFilesModTime(cfg *Config) (time.Time, error) {
+	var modTime time.Time
+	for _, path := range []string{cfg.TLSCertPath, cfg.TLSKeyPath} {
+		info, err := os.Stat(path)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		if info.ModTime().After(modTime) {
+			modTime = info.ModTime()
+		}
+	}
+
+	return modTime, nil
+}
+
+// Rotate generates a new self-signed certificate and key, replaces the
+// certificate and key files with them and starts serving the new certificate.
+// Existing connections keep using the previous certificate. Externally
+// provided certificates can't be rotated.
+func (r *tlsRotator) Rotate() error {
+	if r.cfg.TLSExternalCert {
+		return errTLSCertExternal
+	}
+
+	r.rotateMtx.Lock()
+	defer r.rotateMtx.Unlock()
+
+	// The new pair is written to temporary files first, so the files in
+	// use are only replaced once the pair was generated successfully.
+	tmpCertPath := r.cfg.TLSCertPath + ".tmp"
+	tmpKeyPath := r.cfg.TLSKeyPath + ".tmp"
+	err := cert.GenCertPair(
+		"lnd autogenerated cert", tmpCertPath, tmpKeyPath,
+		r.cfg.TLSExtraIPs, r.cfg.TLSExtraDomains,
+		r.cfg.TLSDisableAutofill, cert.DefaultAutogenValidity,
+	)
+	if err != nil {
+		return err
+	}
+
+	certData, parsedCert, err := cert.LoadCert(tmpCertPath, tmpKeyPath)
+	if err != nil {
+		os.Remove(tmpCertPath)
+		os.Remove(tmpKeyPath)
+		return err
+	}
+
+	if err := os.Rename(tmpKeyPath, r.cfg.TLSKeyPath); err != nil {
+		os.Remove(tmpCertPath)
+		os.Remove(tmpKeyPath)
+		return err
+	}
+	if err := os.Rename(tmpCertPath, r.cfg.TLSCertPath); err != nil {
+		os.Remove(tmpCertPath)
+		return err
+	}
+
+	r.mu.Lock()
+	r.certData = certData
+	r.parsedCert = parsedCert
+	r.mu.Unlock()
+
+	ltndLog.Infof("Rotated TLS certificate, new certificate expires at %v",
+		parsedCert.NotAfter)
+
+	return nil
+}
+
+// Certificate returns the certificate that is currently served.
+func (r *tlsRotator) Certificate() *x509.Certificate {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.parsedCert
+}
+
+// GetCertificate returns the certificate that is currently served. It is
+// meant to be used as the GetCertificate callback of a TLS config.
+func (r *tlsRotator) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate,
+	error) {
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return &r.certData, nil
+}
+
+// ClientTLSConfig returns a TLS config for connecting to our own RPC server,
+// such as from the REST proxy. Instead of verifying the server certificate
+// against the certificate file, the certificate currently served is pinned,
+// so new connections keep working after a rotation.
+func (r *tlsRotator) ClientTLSConfig() *tls.Config {
+	return &tls.Config{
+		// The certificate is verified by verifyServerCert instead.
+		InsecureSkipVerify:    true,
+		VerifyPeerCertificate: r.verifyServerCert,
+	}
+}
+
+// verifyServerCert checks that the certificate presented by the server is the
+// one that is currently served.
+func (r *tlsRotator) verifyServerCert(rawCerts [][]byte,
+	_ [][]*x509.Certificate) error {
+
+	if len(rawCerts) == 0 {
+		return errTLSCertMismatch
+	}
+
+	if !bytes.Equal(rawCerts[0], r.Certificate().Raw) {
+		return errTLSCertMismatch
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package lnd
+
+import (
+	"net"
+
+	"github.com/btcsuite/btcd/rpcclient"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/cryptomeow/lnd/broadcast"
+	"github.com/cryptomeow/lnd/lncfg"
+	"github.com/cryptomeow/lnd/sweep"
+)
+
+// newTxPublisher creates the publisher that sweep and justice transactions are
+// sent through. Besides the chain backend of the wallet, it hands them to the
+// alternate broadcasters configured in the broadcast section.
+func newTxPublisher(cfg *Config,
+	publish func(*wire.MsgTx, string) error) (*broadcast.Publisher, error) {
+
+	var broadcasters []broadcast.Broadcaster
+	for _, node := range cfg.Broadcast.Bitcoind {
+		user, pass, host, err := lncfg.ParseBroadcastBitcoind(node)
+		if err != nil {
+			return nil, err
+		}
+
+		b, err := broadcast.NewRPCBroadcaster(rpcclient.ConnConfig{
+			Host: host,
+			User: user,
+			Pass: pass,
+		})
+		if err != nil {
+			return nil, err
+		}
+		broadcasters = append(broadcasters, b)
+	}
+
+	// Connections to P2P nodes are made through cfg.net, which routes them
+	// through Tor as it is required to be active.
+	timeout := cfg.Broadcast.Timeout
+	dial := func(addr string) (net.Conn, error) {
+		return cfg.net.Dial("tcp", addr, timeout)
+	}
+	for _, addr := range cfg.Broadcast.Peers {
+		b := broadcast.NewPeerBroadcaster(
+			addr, cfg.ActiveNetParams.Params, dial, timeout,
+		)
+		broadcasters = append(broadcasters, b)
+	}
+
+	return broadcast.NewPublisher(&broadcast.Config{
+		PublishTransaction: publish,
+		Broadcasters:       broadcasters,
+		Timeout:            timeout,
+	}), nil
+}
+
+// sweeperWallet wraps the wallet used by the sweeper, so that sweep
+// transactions are published through the alternate broadcasters as well.
+type sweeperWallet struct {
+	sweep.Wallet
+
+	publisher *broadcast.Publisher
+}
+
+// PublishTransaction publishes the transaction through the publisher.
+//
+// NOTE: This is part of the sweep.Wallet interface.
+func (w *sweeperWallet) PublishTransaction(tx *wire.MsgTx,
+	label string) error {
+
+	return w.publisher.PublishTransaction(tx, label)
+}
+
+// A compile-time check to ensure sweeperWallet implements the sweep.Wallet
+// interface.
+var _ sweep.Wallet = (*sweeperWallet)(nil)
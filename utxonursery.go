@@ -13,9 +13,11 @@ import (
 	"github.com/davecgh/go-spew/spew"
 	"github.com/cryptomeow/lnd/chainntnfs"
 	"github.com/cryptomeow/lnd/channeldb"
+	"github.com/cryptomeow/lnd/contractcourt"
 	"github.com/cryptomeow/lnd/input"
 	"github.com/cryptomeow/lnd/labels"
 	"github.com/cryptomeow/lnd/lnwallet"
+	"github.com/cryptomeow/lnd/lnwallet/chainfee"
 	"github.com/cryptomeow/lnd/sweep"
 )
 
@@ -202,6 +204,13 @@ type NurseryConfig struct {
 
 	// Sweep sweeps an input back to the wallet.
 	SweepInput func(input.Input, sweep.Params) (chan sweep.Result, error)
+
+	// Estimator is used to estimate the fee rate of the conf target of
+	// the sweeps, in order to keep them within the budget.
+	Estimator chainfee.Estimator
+
+	// Budget limits the fees that are paid to sweep the HTLC outputs.
+	Budget contractcourt.BudgetConfig
 }
 
 // utxoNursery is a system dedicated to incubating time-locked outputs created
@@ -767,22 +776,42 @@ func (u *utxoNursery) graduateClass(classHeight uint32) error {
 
 // sweepMatureOutputs generates and broadcasts the transaction that transfers
 // control of funds from a prior channel commitment transaction to the user's
-// wallet. The outputs swept were previously time locked (either absolute or
-// relative), but are not mature enough to sweep into the wallet.
+// wallet, or to the consolidation address set if the sweeper has one. The
+// outputs swept were previously time locked (either absolute or relative), but
+// are not mature enough to sweep into the wallet.
 func (u *utxoNursery) sweepMatureOutputs(classHeight uint32,
 	kgtnOutputs []kidOutput) error {
 
 	utxnLog.Infof("Sweeping %v CSV-delayed outputs with sweep tx for "+
 		"height %v", len(kgtnOutputs), classHeight)
 
-	feePref := sweep.FeePreference{ConfTarget: kgtnOutputConfTarget}
 	for _, output := range kgtnOutputs {
 		// Create local copy to prevent pointer to loop variable to be
 		// passed in with disastrous consequences.
 		local := output
 
+		// The fee rate is capped to keep the fees within our htlc
+		// budget.
+		budget, limited := u.cfg.Budget.HtlcBudget(local.Amount())
+		feePref, capped, err := contractcourt.BudgetFeePreference(
+			u.cfg.Estimator, kgtnOutputConfTarget,
+			local.WitnessType(), budget, limited,
+		)
+		if err != nil {
+			return err
+		}
+		if capped {
+			utxnLog.Infof("Capping sweep fee rate of %v at %v due "+
+				"to htlc budget of %v", local.OutPoint(),
+				feePref.FeeRate, budget)
+		}
+
 		resultChan, err := u.cfg.SweepInput(
-			&local, sweep.Params{Fee: feePref},
+			&local, sweep.Params{
+				Fee:         feePref,
+				Consolidate: true,
+				ChanPoint:   local.OriginChanPoint(),
+			},
 		)
 		if err != nil {
 			return err
@@ -869,7 +898,9 @@ func (u *utxoNursery) sweepCribOutput(classHeight uint32, baby *babyOutput) erro
 
 	// We'll now broadcast the HTLC transaction, then wait for it to be
 	// confirmed before transitioning it to kindergarten.
-	label := labels.MakeLabel(labels.LabelTypeSweepTransaction, nil)
+	label := labels.MakeChanPointLabel(
+		labels.LabelTypeSweepTransaction, baby.OriginChanPoint(),
+	)
 	err := u.cfg.PublishTransaction(baby.timeoutTx, label)
 	if err != nil && err != lnwallet.ErrDoubleSpend {
 		utxnLog.Errorf("Unable to broadcast baby tx: "+
@@ -12,6 +12,7 @@ import (
 	"github.com/btcsuite/btcwallet/wallet"
 	"github.com/cryptomeow/lnd/aezeed"
 	"github.com/cryptomeow/lnd/chanbackup"
+	"github.com/cryptomeow/lnd/channeldb/kvdb"
 	"github.com/cryptomeow/lnd/keychain"
 	"github.com/cryptomeow/lnd/lnrpc"
 	"github.com/cryptomeow/lnd/lnwallet"
@@ -133,7 +134,9 @@ func (u *UnlockerService) GenSeed(ctx context.Context,
 	// Before we start, we'll ensure that the wallet hasn't already created
 	// so we don't show a *new* seed to the user if one already exists.
 	netDir := btcwallet.NetworkDir(u.chainDir, u.netParams)
-	loader := wallet.NewLoader(u.netParams, netDir, u.noFreelistSync, 0)
+	loader := wallet.NewLoader(
+		u.netParams, netDir, u.noFreelistSync, kvdb.DefaultDBTimeout, 0,
+	)
 	walletExists, err := loader.WalletExists()
 	if err != nil {
 		return nil, err
@@ -263,7 +266,8 @@ func (u *UnlockerService) InitWallet(ctx context.Context,
 	// wallet's files so we can check if the wallet already exists.
 	netDir := btcwallet.NetworkDir(u.chainDir, u.netParams)
 	loader := wallet.NewLoader(
-		u.netParams, netDir, u.noFreelistSync, uint32(recoveryWindow),
+		u.netParams, netDir, u.noFreelistSync, kvdb.DefaultDBTimeout,
+		uint32(recoveryWindow),
 	)
 
 	walletExists, err := loader.WalletExists()
@@ -322,7 +326,8 @@ func (u *UnlockerService) UnlockWallet(ctx context.Context,
 
 	netDir := btcwallet.NetworkDir(u.chainDir, u.netParams)
 	loader := wallet.NewLoader(
-		u.netParams, netDir, u.noFreelistSync, recoveryWindow,
+		u.netParams, netDir, u.noFreelistSync, kvdb.DefaultDBTimeout,
+		recoveryWindow,
 	)
 
 	// Check if wallet already exists.
@@ -375,7 +380,9 @@ func (u *UnlockerService) ChangePassword(ctx context.Context,
 	in *lnrpc.ChangePasswordRequest) (*lnrpc.ChangePasswordResponse, error) {
 
 	netDir := btcwallet.NetworkDir(u.chainDir, u.netParams)
-	loader := wallet.NewLoader(u.netParams, netDir, u.noFreelistSync, 0)
+	loader := wallet.NewLoader(
+		u.netParams, netDir, u.noFreelistSync, kvdb.DefaultDBTimeout, 0,
+	)
 
 	// First, we'll make sure the wallet exists for the specific chain and
 	// network.
@@ -14,6 +14,7 @@ import (
 	"github.com/btcsuite/btcwallet/waddrmgr"
 	"github.com/btcsuite/btcwallet/wallet"
 	"github.com/cryptomeow/lnd/aezeed"
+	"github.com/cryptomeow/lnd/channeldb/kvdb"
 	"github.com/cryptomeow/lnd/keychain"
 	"github.com/cryptomeow/lnd/lnrpc"
 	"github.com/cryptomeow/lnd/lnwallet/btcwallet"
@@ -51,7 +52,9 @@ func createTestWallet(t *testing.T, dir string, netParams *chaincfg.Params) {
 
 	// Create a new test wallet that uses fast scrypt as KDF.
 	netDir := btcwallet.NetworkDir(dir, netParams)
-	loader := wallet.NewLoader(netParams, netDir, true, 0)
+	loader := wallet.NewLoader(
+		netParams, netDir, true, kvdb.DefaultDBTimeout, 0,
+	)
 	_, err := loader.CreateNewWallet(
 		testPassword, testPassword, testSeed, time.Time{},
 	)
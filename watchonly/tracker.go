@@ -0,0 +1,516 @@
+package watchonly
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/cryptomeow/lnd/chainntnfs"
+	"github.com/cryptomeow/lnd/channeldb"
+	"github.com/cryptomeow/lnd/lnwallet"
+)
+
+var (
+	// ErrTrackerShuttingDown is returned when an operation is attempted
+	// while the tracker is shutting down.
+	ErrTrackerShuttingDown = errors.New("watch-only tracker shutting down")
+)
+
+// DB is the persistent storage of the watched scripts and everything found
+// for them on chain.
+type DB interface {
+	// AddWatchedScripts adds the given scripts to the set of watched
+	// scripts.
+	AddWatchedScripts(scripts []*channeldb.WatchedScript) error
+
+	// FetchWatchedScripts returns all watched scripts.
+	FetchWatchedScripts() ([]*channeldb.WatchedScript, error)
+
+	// WatchOnlySyncTip returns the last block that was scanned.
+	WatchOnlySyncTip() (uint32, chainhash.Hash, error)
+
+	// ApplyWatchOnlyBlock stores everything a block changed about the
+	// watched scripts and advances the sync tip to it.
+	ApplyWatchOnlyBlock(block *channeldb.WatchOnlyBlock) error
+
+	// RewindWatchOnly removes everything found above the given height and
+	// sets the sync tip to the given block.
+	RewindWatchOnly(height uint32, hash chainhash.Hash) error
+
+	// FetchWatchOnlyOutputs returns all outputs paying to a watched
+	// script, spent or not.
+	FetchWatchOnlyOutputs() ([]*channeldb.WatchOnlyOutput, error)
+
+	// FetchWatchOnlyTxns returns all transactions that pay to or spend
+	// from a watched script.
+	FetchWatchOnlyTxns() ([]*channeldb.WatchOnlyTx, error)
+}
+
+// Config holds the dependencies of the Tracker.
+type Config struct {
+	// DB stores the watched scripts and their outputs and transactions.
+	DB DB
+
+	// ChainIO is used to fetch the blocks that are scanned.
+	ChainIO lnwallet.BlockChainIO
+
+	// Notifier is used to learn about new blocks.
+	Notifier chainntnfs.ChainNotifier
+}
+
+// Tracker scans the chain for outputs paying to a set of watched scripts and
+// transactions spending them. As the wallet holds no keys for the scripts,
+// their outputs are never used for coin selection. Only confirmed
+// transactions are tracked.
+type Tracker struct {
+	started uint32 // To be used atomically.
+	stopped uint32 // To be used atomically.
+
+	cfg *Config
+
+	// mtx guards the watched scripts, the known outputs and the sync tip.
+	// It is held while a block is processed, so scripts are never added
+	// in the middle of a block.
+	mtx sync.Mutex
+
+	// scripts holds the watched scripts, keyed by the output script.
+	scripts map[string]*channeldb.WatchedScript
+
+	// outputs holds all known outputs paying to a watched script, spent
+	// or not, which is used to detect spends from them.
+	outputs map[wire.OutPoint]*channeldb.WatchOnlyOutput
+
+	syncHeight uint32
+	syncHash   chainhash.Hash
+
+	// syncSignal is used to request the chain to be scanned up to the
+	// current tip.
+	syncSignal chan struct{}
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewTracker creates a new watch-only tracker.
+func NewTracker(cfg *Config) *Tracker {
+	return &Tracker{
+		cfg:        cfg,
+		scripts:    make(map[string]*channeldb.WatchedScript),
+		outputs:    make(map[wire.OutPoint]*channeldb.WatchOnlyOutput),
+		syncSignal: make(chan struct{}, 1),
+		quit:       make(chan struct{}),
+	}
+}
+
+// Start loads the watched scripts and starts scanning the chain for them.
+func (t *Tracker) Start() error {
+	if !atomic.CompareAndSwapUint32(&t.started, 0, 1) {
+		return nil
+	}
+
+	log.Info("Watch-only tracker starting")
+
+	scripts, err := t.cfg.DB.FetchWatchedScripts()
+	if err != nil {
+		return err
+	}
+
+	outputs, err := t.cfg.DB.FetchWatchOnlyOutputs()
+	if err != nil {
+		return err
+	}
+
+	syncHeight, syncHash, err := t.cfg.DB.WatchOnlySyncTip()
+	if err != nil {
+		return err
+	}
+
+	t.mtx.Lock()
+	for _, script := range scripts {
+		t.scripts[string(script.PkScript)] = script
+	}
+	for _, output := range outputs {
+		t.outputs[output.OutPoint] = output
+	}
+	t.syncHeight = syncHeight
+	t.syncHash = syncHash
+	t.mtx.Unlock()
+
+	blockEpochs, err := t.cfg.Notifier.RegisterBlockEpochNtfn(nil)
+	if err != nil {
+		return err
+	}
+
+	t.wg.Add(1)
+	go t.syncHandler(blockEpochs)
+
+	return nil
+}
+
+// Stop stops the tracker.
+func (t *Tracker) Stop() error {
+	if !atomic.CompareAndSwapUint32(&t.stopped, 0, 1) {
+		return nil
+	}
+
+	log.Info("Watch-only tracker shutting down")
+
+	close(t.quit)
+	t.wg.Wait()
+
+	return nil
+}
+
+// syncHandler scans the chain up to the new tip whenever a block is connected
+// or new scripts were added.
+//
+// NOTE: This MUST be run as a goroutine.
+func (t *Tracker) syncHandler(blockEpochs *chainntnfs.BlockEpochEvent) {
+	defer t.wg.Done()
+	defer blockEpochs.Cancel()
+
+	for {
+		select {
+		case _, ok := <-blockEpochs.Epochs:
+			if !ok {
+				return
+			}
+
+		case <-t.syncSignal:
+
+		case <-t.quit:
+			return
+		}
+
+		if err := t.sync(); err != nil && err != ErrTrackerShuttingDown {
+			log.Errorf("Unable to scan chain for watched scripts: %v",
+				err)
+		}
+	}
+}
+
+// AddScripts adds the given scripts to the set of watched scripts. A birth
+// height of zero is replaced by the current height of the chain. If any of
+// the scripts was born at or below the last scanned block, everything found
+// above that height is removed and the chain is scanned again from there.
+func (t *Tracker) AddScripts(scripts []*channeldb.WatchedScript) error {
+	if len(scripts) == 0 {
+		return nil
+	}
+
+	_, bestHeight, err := t.cfg.ChainIO.GetBestBlock()
+	if err != nil {
+		return err
+	}
+
+	minBirthHeight := uint32(bestHeight)
+	for _, script := range scripts {
+		if len(script.PkScript) == 0 {
+			return channeldb.ErrEmptyWatchedScript
+		}
+
+		if script.BirthHeight == 0 {
+			script.BirthHeight = uint32(bestHeight)
+		}
+		if script.BirthHeight < minBirthHeight {
+			minBirthHeight = script.BirthHeight
+		}
+	}
+
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	if err := t.cfg.DB.AddWatchedScripts(scripts); err != nil {
+		return err
+	}
+
+	// If no scripts were watched so far, the sync tip is meaningless and
+	// we'll start scanning right before the earliest birth height.
+	// Otherwise we only need to go back if a script may have outputs in
+	// blocks we already scanned.
+	rescanHeight := uint32(0)
+	if minBirthHeight > 0 {
+		rescanHeight = minBirthHeight - 1
+	}
+	if len(t.scripts) == 0 || rescanHeight < t.syncHeight {
+		log.Infof("Rescanning watched scripts from height %v",
+			rescanHeight+1)
+
+		if err := t.rewind(rescanHeight); err != nil {
+			return err
+		}
+	}
+
+	for _, script := range scripts {
+		t.scripts[string(script.PkScript)] = script
+	}
+
+	select {
+	case t.syncSignal <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+// rewind removes everything found above the given height and sets the sync
+// tip to the block at that height.
+//
+// NOTE: The mtx MUST be held when calling this method.
+func (t *Tracker) rewind(height uint32) error {
+	hash, err := t.cfg.ChainIO.GetBlockHash(int64(height))
+	if err != nil {
+		return err
+	}
+
+	if err := t.cfg.DB.RewindWatchOnly(height, *hash); err != nil {
+		return err
+	}
+
+	outputs, err := t.cfg.DB.FetchWatchOnlyOutputs()
+	if err != nil {
+		return err
+	}
+
+	t.outputs = make(map[wire.OutPoint]*channeldb.WatchOnlyOutput)
+	for _, output := range outputs {
+		t.outputs[output.OutPoint] = output
+	}
+
+	t.syncHeight = height
+	t.syncHash = *hash
+
+	return nil
+}
+
+// sync scans all blocks between the last scanned block and the current tip of
+// the chain, one block at a time. If the last scanned block was reorged out,
+// the scan is rewound until it is back on the main chain.
+func (t *Tracker) sync() error {
+	for {
+		select {
+		case <-t.quit:
+			return ErrTrackerShuttingDown
+		default:
+		}
+
+		_, bestHeight, err := t.cfg.ChainIO.GetBestBlock()
+		if err != nil {
+			return err
+		}
+
+		done, err := t.syncStep(uint32(bestHeight))
+		if err != nil || done {
+			return err
+		}
+	}
+}
+
+// syncStep either rewinds the scan by one block if the last scanned block is
+// no longer part of the main chain, or scans the next block. True is returned
+// once the scan has reached the given tip.
+func (t *Tracker) syncStep(bestHeight uint32) (bool, error) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	if len(t.scripts) == 0 {
+		return true, nil
+	}
+
+	onMainChain := t.syncHeight <= bestHeight
+	if onMainChain {
+		hash, err := t.cfg.ChainIO.GetBlockHash(int64(t.syncHeight))
+		if err != nil {
+			return false, err
+		}
+		onMainChain = *hash == t.syncHash
+	}
+	if !onMainChain {
+		log.Infof("Watch-only sync tip %v (height=%v) reorged out, "+
+			"rewinding", t.syncHash, t.syncHeight)
+
+		rewindHeight := uint32(0)
+		if t.syncHeight > 0 {
+			rewindHeight = t.syncHeight - 1
+		}
+
+		return false, t.rewind(rewindHeight)
+	}
+
+	if t.syncHeight == bestHeight {
+		return true, nil
+	}
+
+	height := t.syncHeight + 1
+	hash, err := t.cfg.ChainIO.GetBlockHash(int64(height))
+	if err != nil {
+		return false, err
+	}
+
+	block, err := t.cfg.ChainIO.GetBlock(hash)
+	if err != nil {
+		return false, err
+	}
+
+	watchOnlyBlock := t.scanBlock(block, height, *hash)
+	if err := t.cfg.DB.ApplyWatchOnlyBlock(watchOnlyBlock); err != nil {
+		return false, err
+	}
+
+	// Now that the block is stored, we'll track its outputs in memory,
+	// so spends from them are detected in later blocks.
+	for _, output := range watchOnlyBlock.Outputs {
+		t.outputs[output.OutPoint] = output
+	}
+	for op, spendingTx := range watchOnlyBlock.Spends {
+		spendingTx := spendingTx
+		t.outputs[op].SpendingTx = &spendingTx
+		t.outputs[op].SpendHeight = height
+	}
+
+	if len(watchOnlyBlock.Txns) > 0 {
+		log.Debugf("Found %v watch-only transactions in block %v "+
+			"(height=%v)", len(watchOnlyBlock.Txns), hash, height)
+	}
+
+	t.syncHeight = height
+	t.syncHash = *hash
+
+	return height == bestHeight, nil
+}
+
+// scanBlock collects the outputs of the block paying to a watched script and
+// the spends of known outputs.
+//
+// NOTE: The mtx MUST be held when calling this method.
+func (t *Tracker) scanBlock(block *wire.MsgBlock, height uint32,
+	hash chainhash.Hash) *channeldb.WatchOnlyBlock {
+
+	watchOnlyBlock := &channeldb.WatchOnlyBlock{
+		Height: height,
+		Hash:   hash,
+		Spends: make(map[wire.OutPoint]chainhash.Hash),
+	}
+
+	// Outputs created in the block may already be spent by a later
+	// transaction of the same block.
+	blockOutputs := make(map[wire.OutPoint]*channeldb.WatchOnlyOutput)
+
+	for _, tx := range block.Transactions {
+		var (
+			txHash   = tx.TxHash()
+			amount   btcutil.Amount
+			relevant bool
+		)
+
+		for _, txIn := range tx.TxIn {
+			op := txIn.PreviousOutPoint
+			output, ok := t.outputs[op]
+			if !ok {
+				output, ok = blockOutputs[op]
+			}
+			if !ok {
+				continue
+			}
+
+			watchOnlyBlock.Spends[op] = txHash
+			amount -= output.Value
+			relevant = true
+		}
+
+		for i, txOut := range tx.TxOut {
+			if _, ok := t.scripts[string(txOut.PkScript)]; !ok {
+				continue
+			}
+
+			output := &channeldb.WatchOnlyOutput{
+				OutPoint: wire.OutPoint{
+					Hash:  txHash,
+					Index: uint32(i),
+				},
+				PkScript: txOut.PkScript,
+				Value:    btcutil.Amount(txOut.Value),
+				Height:   height,
+			}
+			watchOnlyBlock.Outputs = append(
+				watchOnlyBlock.Outputs, output,
+			)
+			blockOutputs[output.OutPoint] = output
+
+			amount += output.Value
+			relevant = true
+		}
+
+		if !relevant {
+			continue
+		}
+
+		watchOnlyBlock.Txns = append(
+			watchOnlyBlock.Txns, &channeldb.WatchOnlyTx{
+				Tx:        tx,
+				BlockHash: hash,
+				Height:    height,
+				Timestamp: block.Header.Timestamp,
+				Amount:    amount,
+			},
+		)
+	}
+
+	return watchOnlyBlock
+}
+
+// Scripts returns all watched scripts, ordered by their birth height.
+func (t *Tracker) Scripts() []*channeldb.WatchedScript {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	scripts := make([]*channeldb.WatchedScript, 0, len(t.scripts))
+	for _, script := range t.scripts {
+		scripts = append(scripts, script)
+	}
+	sort.Slice(scripts, func(i, j int) bool {
+		return scripts[i].BirthHeight < scripts[j].BirthHeight
+	})
+
+	return scripts
+}
+
+// SyncHeight returns the height of the last block that was scanned for the
+// watched scripts.
+func (t *Tracker) SyncHeight() uint32 {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	return t.syncHeight
+}
+
+// UnspentOutputs returns all unspent outputs paying to a watched script.
+func (t *Tracker) UnspentOutputs() []*channeldb.WatchOnlyOutput {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	var outputs []*channeldb.WatchOnlyOutput
+	for _, output := range t.outputs {
+		if output.SpendingTx != nil {
+			continue
+		}
+
+		outputCopy := *output
+		outputs = append(outputs, &outputCopy)
+	}
+	sort.Slice(outputs, func(i, j int) bool {
+		return outputs[i].Height < outputs[j].Height
+	})
+
+	return outputs
+}
+
+// Transactions returns all transactions that pay to or spend from a watched
+// script.
+func (t *Tracker) Transactions() ([]*channeldb.WatchOnlyTx, error) {
+	return t.cfg.DB.FetchWatchOnlyTxns()
+}
@@ -0,0 +1,201 @@
+package watchonly
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/cryptomeow/lnd/chainntnfs"
+	"github.com/cryptomeow/lnd/channeldb"
+	"github.com/cryptomeow/lnd/lntest/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockChain is a BlockChainIO backed by an in-memory chain of blocks.
+type mockChain struct {
+	mtx    sync.Mutex
+	blocks []*wire.MsgBlock
+}
+
+// addBlock appends a block with the given transactions to the chain. The
+// nonce allows creating competing blocks at the same height.
+func (m *mockChain) addBlock(nonce uint32, txns ...*wire.MsgTx) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	var prevHash chainhash.Hash
+	if len(m.blocks) > 0 {
+		prevHash = m.blocks[len(m.blocks)-1].BlockHash()
+	}
+
+	m.blocks = append(m.blocks, &wire.MsgBlock{
+		Header: wire.BlockHeader{
+			PrevBlock: prevHash,
+			Nonce:     nonce,
+			Timestamp: time.Unix(int64(len(m.blocks)), 0),
+		},
+		Transactions: txns,
+	})
+}
+
+// disconnect removes the given number of blocks from the tip of the chain.
+func (m *mockChain) disconnect(n int) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.blocks = m.blocks[:len(m.blocks)-n]
+}
+
+func (m *mockChain) GetBestBlock() (*chainhash.Hash, int32, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	hash := m.blocks[len(m.blocks)-1].BlockHash()
+	return &hash, int32(len(m.blocks) - 1), nil
+}
+
+func (m *mockChain) GetUtxo(*wire.OutPoint, []byte, uint32,
+	<-chan struct{}) (*wire.TxOut, error) {
+
+	return nil, nil
+}
+
+func (m *mockChain) GetBlockHash(height int64) (*chainhash.Hash, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if height >= int64(len(m.blocks)) {
+		return nil, fmt.Errorf("no block at height %v", height)
+	}
+
+	hash := m.blocks[height].BlockHash()
+	return &hash, nil
+}
+
+func (m *mockChain) GetBlock(hash *chainhash.Hash) (*wire.MsgBlock, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	for _, block := range m.blocks {
+		if block.BlockHash() == *hash {
+			return block, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown block %v", hash)
+}
+
+// newTx creates a transaction spending the given outpoint to the given
+// script.
+func newTx(prevOut wire.OutPoint, pkScript []byte,
+	value int64) *wire.MsgTx {
+
+	tx := wire.NewMsgTx(2)
+	tx.AddTxIn(wire.NewTxIn(&prevOut, []byte{1}, nil))
+	tx.AddTxOut(wire.NewTxOut(value, pkScript))
+
+	return tx
+}
+
+// TestTracker checks that the tracker finds the outputs paying to watched
+// scripts and the spends from them, rescans blocks when a script with an
+// earlier birth height is added and follows reorgs.
+func TestTracker(t *testing.T) {
+	t.Parallel()
+
+	db, cleanup, err := channeldb.MakeTestDB()
+	require.NoError(t, err)
+	defer cleanup()
+
+	var (
+		chain    = &mockChain{}
+		epochs   = make(chan *chainntnfs.BlockEpoch)
+		script1  = []byte{0x00, 0x14, 0x01}
+		script2  = []byte{0x00, 0x14, 0x02}
+		other    = []byte{0x51}
+		funding1 = newTx(wire.OutPoint{Index: 1}, script1, 1000)
+		funding2 = newTx(wire.OutPoint{Index: 2}, script2, 2000)
+		spend1   = newTx(
+			wire.OutPoint{Hash: funding1.TxHash()}, other, 900,
+		)
+	)
+
+	// Block 1 pays to the first script, block 2 to the second one, and
+	// block 3 spends the output of the first script.
+	chain.addBlock(0)
+	chain.addBlock(0, funding1)
+	chain.addBlock(0, funding2)
+	chain.addBlock(0, spend1)
+
+	tracker := NewTracker(&Config{
+		DB:       db,
+		ChainIO:  chain,
+		Notifier: &mock.ChainNotifier{EpochChan: epochs},
+	})
+	require.NoError(t, tracker.Start())
+	defer tracker.Stop()
+
+	// waitForSync waits until the tracker has scanned the chain up to the
+	// given height.
+	waitForSync := func(height uint32) {
+		t.Helper()
+
+		require.Eventually(t, func() bool {
+			return tracker.SyncHeight() == height
+		}, 5*time.Second, 10*time.Millisecond)
+	}
+
+	// Watching the second script from block 2 only finds its output.
+	require.NoError(t, tracker.AddScripts([]*channeldb.WatchedScript{{
+		PkScript:    script2,
+		BirthHeight: 2,
+	}}))
+	waitForSync(3)
+
+	utxos := tracker.UnspentOutputs()
+	require.Len(t, utxos, 1)
+	require.Equal(t, funding2.TxHash(), utxos[0].OutPoint.Hash)
+
+	// Adding the first script born in block 1 rescans the chain from
+	// there, finding its output along with its spend.
+	require.NoError(t, tracker.AddScripts([]*channeldb.WatchedScript{{
+		PkScript:    script1,
+		Label:       "cold",
+		BirthHeight: 1,
+	}}))
+	waitForSync(3)
+
+	require.Len(t, tracker.Scripts(), 2)
+	require.Len(t, tracker.UnspentOutputs(), 1)
+
+	txns, err := tracker.Transactions()
+	require.NoError(t, err)
+	require.Len(t, txns, 3)
+
+	amounts := make(map[chainhash.Hash]int64)
+	for _, txn := range txns {
+		amounts[txn.Tx.TxHash()] = int64(txn.Amount)
+	}
+	require.Equal(t, int64(1000), amounts[funding1.TxHash()])
+	require.Equal(t, int64(2000), amounts[funding2.TxHash()])
+	require.Equal(t, int64(-1000), amounts[spend1.TxHash()])
+
+	// Reorg out the spend, replacing it with an empty block. The output
+	// of the first script is unspent again.
+	chain.disconnect(1)
+	chain.addBlock(1)
+	chain.addBlock(1)
+	epochs <- &chainntnfs.BlockEpoch{Height: 4}
+	waitForSync(4)
+
+	require.Eventually(t, func() bool {
+		return len(tracker.UnspentOutputs()) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	txns, err = tracker.Transactions()
+	require.NoError(t, err)
+	require.Len(t, txns, 2)
+}
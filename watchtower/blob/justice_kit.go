@@ -38,6 +38,19 @@ const (
 	//    commit to-remote sig:           64 bytes, maybe blank
 	V0PlaintextSize = 274
 
+	// NumFeeEscalations is the number of justice transactions at escalated
+	// fee rates whose signatures are contained in blobs with fee
+	// escalation.
+	NumFeeEscalations = 2
+
+	// FeeEscalationSize is the size of the signatures of the justice
+	// transactions at escalated fee rates that are appended to the
+	// plaintext of blobs with fee escalation.
+	//    escalated to-local revocation sig: 64 bytes
+	//    escalated to-remote sig:           64 bytes, maybe blank
+	//    (repeated NumFeeEscalations times)
+	FeeEscalationSize = NumFeeEscalations * 128
+
 	// MaxSweepAddrSize defines the maximum sweep address size that can be
 	// encoded in a blob.
 	MaxSweepAddrSize = 42
@@ -54,6 +67,8 @@ func Size(blobType Type) int {
 // PlaintextSize returns the size of the encoded-but-unencrypted blob in bytes.
 func PlaintextSize(blobType Type) int {
 	switch {
+	case blobType.Has(FlagCommitOutputs | FlagFeeEscalation):
+		return V0PlaintextSize + FeeEscalationSize
 	case blobType.Has(FlagCommitOutputs):
 		return V0PlaintextSize
 	default:
@@ -148,6 +163,50 @@ type JusticeKit struct {
 	// NOTE: This value is only used if CommitToRemotePubKey contains a valid
 	// compressed public key.
 	CommitToRemoteSig lnwire.Sig
+
+	// FeeEscalations holds the signatures of the justice transactions at
+	// the escalated fee rates of the session's policy, in increasing
+	// order. Blank signatures signal that the justice transaction couldn't
+	// be created at that fee rate.
+	//
+	// NOTE: This value is only encoded if the blob type has
+	// FlagFeeEscalation.
+	FeeEscalations [NumFeeEscalations]EscalatedSigs
+}
+
+// EscalatedSigs are the signatures of a justice transaction at an escalated
+// fee rate.
+type EscalatedSigs struct {
+	// CommitToLocalSig is a signature under RevocationPubKey using
+	// SIGHASH_ALL.
+	CommitToLocalSig lnwire.Sig
+
+	// CommitToRemoteSig is a signature under CommitToRemotePubKey using
+	// SIGHASH_ALL, which is blank if there is no to-remote output.
+	CommitToRemoteSig lnwire.Sig
+}
+
+// Escalate returns a copy of the justice kit that carries the signatures of
+// the justice transaction at the given step of the fee escalation schedule,
+// starting at one. False is returned if the blob type doesn't support fee
+// escalation, or if the client didn't provide signatures for the step.
+func (b *JusticeKit) Escalate(step int) (*JusticeKit, bool) {
+	if !b.BlobType.HasFeeEscalation() || step < 1 ||
+		step > NumFeeEscalations {
+
+		return nil, false
+	}
+
+	sigs := b.FeeEscalations[step-1]
+	if sigs.CommitToLocalSig == (lnwire.Sig{}) {
+		return nil, false
+	}
+
+	escalated := *b
+	escalated.CommitToLocalSig = sigs.CommitToLocalSig
+	escalated.CommitToRemoteSig = sigs.CommitToRemoteSig
+
+	return &escalated, true
 }
 
 // CommitToLocalWitnessScript returns the serialized witness script for the
@@ -324,6 +383,11 @@ func Decrypt(key BreachKey, ciphertext []byte,
 // error if the version is unknown.
 func (b *JusticeKit) encode(w io.Writer, blobType Type) error {
 	switch {
+	case blobType.Has(FlagCommitOutputs | FlagFeeEscalation):
+		if err := b.encodeV0(w); err != nil {
+			return err
+		}
+		return b.encodeFeeEscalations(w)
 	case blobType.Has(FlagCommitOutputs):
 		return b.encodeV0(w)
 	default:
@@ -335,6 +399,11 @@ func (b *JusticeKit) encode(w io.Writer, blobType Type) error {
 // error if the version is unknown.
 func (b *JusticeKit) decode(r io.Reader, blobType Type) error {
 	switch {
+	case blobType.Has(FlagCommitOutputs | FlagFeeEscalation):
+		if err := b.decodeV0(r); err != nil {
+			return err
+		}
+		return b.decodeFeeEscalations(r)
 	case blobType.Has(FlagCommitOutputs):
 		return b.decodeV0(r)
 	default:
@@ -501,3 +570,54 @@ func (b *JusticeKit) decodeV0(r io.Reader) error {
 
 	return nil
 }
+
+// encodeFeeEscalations writes the signatures of the justice transactions at
+// escalated fee rates, which follow the version 0 encoding in blobs with fee
+// escalation.
+func (b *JusticeKit) encodeFeeEscalations(w io.Writer) error {
+	for _, sigs := range b.FeeEscalations {
+		// Write 64-byte revocation signature for commit to-local
+		// output.
+		if _, err := w.Write(sigs.CommitToLocalSig[:]); err != nil {
+			return err
+		}
+
+		// Write 64-byte commit to-remote signature, which may be
+		// blank.
+		if _, err := w.Write(sigs.CommitToRemoteSig[:]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// decodeFeeEscalations reads the signatures of the justice transactions at
+// escalated fee rates. The to-remote signatures are only populated if the
+// justice kit has a commit to-remote output.
+func (b *JusticeKit) decodeFeeEscalations(r io.Reader) error {
+	hasToRemote := b.HasCommitToRemoteOutput()
+	for i := range b.FeeEscalations {
+		sigs := &b.FeeEscalations[i]
+
+		// Read 64-byte revocation signature for commit to-local
+		// output.
+		_, err := io.ReadFull(r, sigs.CommitToLocalSig[:])
+		if err != nil {
+			return err
+		}
+
+		// Read 64-byte commit to-remote signature, which may be
+		// discarded.
+		var commitToRemoteSig lnwire.Sig
+		_, err = io.ReadFull(r, commitToRemoteSig[:])
+		if err != nil {
+			return err
+		}
+		if hasToRemote {
+			sigs.CommitToRemoteSig = commitToRemoteSig
+		}
+	}
+
+	return nil
+}
@@ -53,6 +53,7 @@ type descriptorTest struct {
 	hasCommitToRemote    bool
 	commitToRemotePubKey blob.PubKey
 	commitToRemoteSig    lnwire.Sig
+	feeEscalations       [blob.NumFeeEscalations]blob.EscalatedSigs
 	encErr               error
 	decErr               error
 }
@@ -81,6 +82,39 @@ var descriptorTests = []descriptorTest{
 		commitToRemotePubKey: makePubKey(2),
 		commitToRemoteSig:    makeSig(2),
 	},
+	{
+		name:             "fee escalation to-local only",
+		encVersion:       blob.TypeAltruistCommitFeeEscalation,
+		decVersion:       blob.TypeAltruistCommitFeeEscalation,
+		sweepAddr:        makeAddr(22),
+		revPubKey:        makePubKey(0),
+		delayPubKey:      makePubKey(1),
+		csvDelay:         144,
+		commitToLocalSig: makeSig(1),
+		feeEscalations: [blob.NumFeeEscalations]blob.EscalatedSigs{
+			{CommitToLocalSig: makeSig(3)},
+			{CommitToLocalSig: makeSig(5)},
+		},
+	},
+	{
+		name:                 "fee escalation to-local and p2wkh",
+		encVersion:           blob.TypeAltruistCommitFeeEscalation,
+		decVersion:           blob.TypeAltruistCommitFeeEscalation,
+		sweepAddr:            makeAddr(22),
+		revPubKey:            makePubKey(0),
+		delayPubKey:          makePubKey(1),
+		csvDelay:             144,
+		commitToLocalSig:     makeSig(1),
+		hasCommitToRemote:    true,
+		commitToRemotePubKey: makePubKey(2),
+		commitToRemoteSig:    makeSig(2),
+		feeEscalations: [blob.NumFeeEscalations]blob.EscalatedSigs{
+			{
+				CommitToLocalSig:  makeSig(3),
+				CommitToRemoteSig: makeSig(4),
+			},
+		},
+	},
 	{
 		name:             "unknown encrypt version",
 		encVersion:       0,
@@ -158,6 +192,7 @@ func testBlobJusticeKitEncryptDecrypt(t *testing.T, test descriptorTest) {
 		CommitToLocalSig:     test.commitToLocalSig,
 		CommitToRemotePubKey: test.commitToRemotePubKey,
 		CommitToRemoteSig:    test.commitToRemoteSig,
+		FeeEscalations:       test.feeEscalations,
 	}
 
 	// Generate a random encryption key for the blob. The key is
@@ -215,6 +250,45 @@ func testBlobJusticeKitEncryptDecrypt(t *testing.T, test descriptorTest) {
 	}
 }
 
+// TestJusticeKitEscalate asserts that escalated justice kits carry the
+// signatures of their step, and that steps without signatures can't be
+// escalated to.
+func TestJusticeKitEscalate(t *testing.T) {
+	t.Parallel()
+
+	kit := &blob.JusticeKit{
+		BlobType:          blob.TypeAltruistCommitFeeEscalation,
+		CommitToLocalSig:  makeSig(1),
+		CommitToRemoteSig: makeSig(2),
+		FeeEscalations: [blob.NumFeeEscalations]blob.EscalatedSigs{
+			{
+				CommitToLocalSig:  makeSig(3),
+				CommitToRemoteSig: makeSig(4),
+			},
+		},
+	}
+
+	escalated, ok := kit.Escalate(1)
+	require.True(t, ok)
+	require.Equal(t, makeSig(3), escalated.CommitToLocalSig)
+	require.Equal(t, makeSig(4), escalated.CommitToRemoteSig)
+
+	// The original kit is left untouched.
+	require.Equal(t, makeSig(1), kit.CommitToLocalSig)
+
+	// The client didn't sign the second step.
+	_, ok = kit.Escalate(2)
+	require.False(t, ok)
+
+	_, ok = kit.Escalate(blob.NumFeeEscalations + 1)
+	require.False(t, ok)
+
+	// Blobs without fee escalation can't be escalated.
+	kit.BlobType = blob.TypeAltruistCommit
+	_, ok = kit.Escalate(1)
+	require.False(t, ok)
+}
+
 type remoteWitnessTest struct {
 	name             string
 	blobType         blob.Type
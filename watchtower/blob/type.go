@@ -24,6 +24,12 @@ const (
 	// channel, and therefore must expect a P2WSH-style to-remote output if
 	// one exists.
 	FlagAnchorChannel Flag = 1 << 2
+
+	// FlagFeeEscalation signals that the blob additionally contains the
+	// signatures of justice transactions at escalated fee rates, which the
+	// tower can use to replace the justice transaction if it doesn't
+	// confirm in time.
+	FlagFeeEscalation Flag = 1 << 3
 )
 
 // Type returns a Type consisting solely of this flag enabled.
@@ -40,6 +46,8 @@ func (f Flag) String() string {
 		return "FlagCommitOutputs"
 	case FlagAnchorChannel:
 		return "FlagAnchorChannel"
+	case FlagFeeEscalation:
+		return "FlagFeeEscalation"
 	default:
 		return "FlagUnknown"
 	}
@@ -65,6 +73,14 @@ const (
 	// TypeRewardCommit sweeps only commitment outputs to a sweep address
 	// controlled by the user, and pays a negotiated reward to the tower.
 	TypeRewardCommit = Type(FlagCommitOutputs | FlagReward)
+
+	// TypeAltruistCommitFeeEscalation sweeps only commitment outputs to a
+	// sweep address controlled by the user, and does not give the tower a
+	// reward. The tower may replace the justice transaction with ones at
+	// escalated fee rates if it doesn't confirm in time.
+	TypeAltruistCommitFeeEscalation = Type(
+		FlagCommitOutputs | FlagFeeEscalation,
+	)
 )
 
 // Has returns true if the Type has the passed flag enabled.
@@ -87,11 +103,18 @@ func (t Type) IsAnchorChannel() bool {
 	return t.Has(FlagAnchorChannel)
 }
 
+// HasFeeEscalation returns true if the blob type contains the signatures of
+// justice transactions at escalated fee rates.
+func (t Type) HasFeeEscalation() bool {
+	return t.Has(FlagFeeEscalation)
+}
+
 // knownFlags maps the supported flags to their name.
 var knownFlags = map[Flag]struct{}{
 	FlagReward:        {},
 	FlagCommitOutputs: {},
 	FlagAnchorChannel: {},
+	FlagFeeEscalation: {},
 }
 
 // String returns a human readable description of a Type.
@@ -138,8 +161,10 @@ func (t Type) String() string {
 // supportedTypes is the set of all configurations known to be supported by the
 // package.
 var supportedTypes = map[Type]struct{}{
-	TypeAltruistCommit: {},
-	TypeRewardCommit:   {},
+	TypeAltruistCommit:              {},
+	TypeRewardCommit:                {},
+	TypeAltruistAnchorCommit:        {},
+	TypeAltruistCommitFeeEscalation: {},
 }
 
 // IsSupportedType returns true if the given type is supported by the package.
@@ -18,17 +18,22 @@ var typeStringTests = []typeStringTest{
 	{
 		name:   "commit no-reward",
 		typ:    blob.TypeAltruistCommit,
-		expStr: "[No-FlagAnchorChannel|FlagCommitOutputs|No-FlagReward]",
+		expStr: "[No-FlagFeeEscalation|No-FlagAnchorChannel|FlagCommitOutputs|No-FlagReward]",
 	},
 	{
 		name:   "commit reward",
 		typ:    blob.TypeRewardCommit,
-		expStr: "[No-FlagAnchorChannel|FlagCommitOutputs|FlagReward]",
+		expStr: "[No-FlagFeeEscalation|No-FlagAnchorChannel|FlagCommitOutputs|FlagReward]",
+	},
+	{
+		name:   "commit fee escalation",
+		typ:    blob.TypeAltruistCommitFeeEscalation,
+		expStr: "[FlagFeeEscalation|No-FlagAnchorChannel|FlagCommitOutputs|No-FlagReward]",
 	},
 	{
 		name:   "unknown flag",
 		typ:    unknownFlag.Type(),
-		expStr: "0000000000010000[No-FlagAnchorChannel|No-FlagCommitOutputs|No-FlagReward]",
+		expStr: "0000000000010000[No-FlagFeeEscalation|No-FlagAnchorChannel|No-FlagCommitOutputs|No-FlagReward]",
 	},
 }
 
@@ -42,6 +42,25 @@ type JusticeDescriptor struct {
 	JusticeKit *blob.JusticeKit
 }
 
+// Escalate returns a justice descriptor for the justice transaction at the
+// given step of the fee escalation schedule of the session, starting at one.
+// False is returned if the client didn't provide the signatures for the step.
+func (p *JusticeDescriptor) Escalate(step int) (*JusticeDescriptor, bool) {
+	justiceKit, ok := p.JusticeKit.Escalate(step)
+	if !ok {
+		return nil, false
+	}
+
+	sessionInfo := *p.SessionInfo
+	sessionInfo.Policy = p.SessionInfo.Policy.Escalate(step)
+
+	return &JusticeDescriptor{
+		BreachedCommitTx: p.BreachedCommitTx,
+		SessionInfo:      &sessionInfo,
+		JusticeKit:       justiceKit,
+	}, true
+}
+
 // breachedInput contains the required information to construct and spend
 // breached outputs on a commitment transaction.
 type breachedInput struct {
@@ -51,20 +70,22 @@ type breachedInput struct {
 	sequence uint32
 }
 
-// commitToLocalInput extracts the information required to spend the commit
-// to-local output.
-func (p *JusticeDescriptor) commitToLocalInput() (*breachedInput, error) {
+// findCommitToLocalOutput locates the commit to-local output on the breaching
+// commitment transaction, returning its witness script, outpoint and output.
+func (p *JusticeDescriptor) findCommitToLocalOutput() ([]byte, wire.OutPoint,
+	*wire.TxOut, error) {
+
 	// Retrieve the to-local witness script from the justice kit.
 	toLocalScript, err := p.JusticeKit.CommitToLocalWitnessScript()
 	if err != nil {
-		return nil, err
+		return nil, wire.OutPoint{}, nil, err
 	}
 
 	// Compute the witness script hash, which will be used to locate the
 	// input on the breaching commitment transaction.
 	toLocalWitnessHash, err := input.WitnessScriptHash(toLocalScript)
 	if err != nil {
-		return nil, err
+		return nil, wire.OutPoint{}, nil, err
 	}
 
 	// Locate the to-local output on the breaching commitment transaction.
@@ -72,7 +93,7 @@ func (p *JusticeDescriptor) commitToLocalInput() (*breachedInput, error) {
 		p.BreachedCommitTx, toLocalWitnessHash,
 	)
 	if err != nil {
-		return nil, err
+		return nil, wire.OutPoint{}, nil, err
 	}
 
 	// Construct the to-local outpoint that will be spent in the justice
@@ -82,6 +103,18 @@ func (p *JusticeDescriptor) commitToLocalInput() (*breachedInput, error) {
 		Index: toLocalIndex,
 	}
 
+	return toLocalScript, toLocalOutPoint, toLocalTxOut, nil
+}
+
+// commitToLocalInput extracts the information required to spend the commit
+// to-local output.
+func (p *JusticeDescriptor) commitToLocalInput() (*breachedInput, error) {
+	toLocalScript, toLocalOutPoint, toLocalTxOut, err :=
+		p.findCommitToLocalOutput()
+	if err != nil {
+		return nil, err
+	}
+
 	// Retrieve to-local witness stack, which primarily includes a signature
 	// under the revocation pubkey.
 	witnessStack, err := p.JusticeKit.CommitToLocalRevokeWitnessStack()
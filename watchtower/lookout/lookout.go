@@ -40,6 +40,12 @@ type Lookout struct {
 
 	cfg *Config
 
+	// escalations tracks the breaches whose justice transactions can be
+	// replaced by ones paying a higher fee, keyed by the breached to-local
+	// output. It is only accessed by the watchBlocks goroutine, and isn't
+	// persisted, so fees are no longer escalated after a restart.
+	escalations map[wire.OutPoint]*pendingEscalation
+
 	wg   sync.WaitGroup
 	quit chan struct{}
 }
@@ -47,9 +53,38 @@ type Lookout struct {
 // New constructs a new Lookout from the given LookoutConfig.
 func New(cfg *Config) *Lookout {
 	return &Lookout{
-		cfg:  cfg,
-		quit: make(chan struct{}),
+		cfg:         cfg,
+		escalations: make(map[wire.OutPoint]*pendingEscalation),
+		quit:        make(chan struct{}),
+	}
+}
+
+// pendingEscalation is a breach whose justice transaction hasn't confirmed
+// yet, and for which the client provided justice transactions paying higher
+// fees.
+type pendingEscalation struct {
+	// desc is the justice descriptor of the breach at the base fee rate.
+	desc *JusticeDescriptor
+
+	// breachHeight is the height the breach was detected at.
+	breachHeight int32
+
+	// step is the step of the fee escalation schedule of the last justice
+	// transaction that was published.
+	step int
+}
+
+// nextHeight returns the height at which the justice transaction of the next
+// step is published. The steps are spread evenly over the CSV delay of the
+// breached to-local output, after which the breaching party can sweep it.
+func (p *pendingEscalation) nextHeight() int32 {
+	csvDelay := int32(p.desc.JusticeKit.CSVDelay)
+	delta := csvDelay * int32(p.step+1) / (blob.NumFeeEscalations + 1)
+	if delta == 0 {
+		delta = int32(p.step + 1)
 	}
+
+	return p.breachHeight + delta
 }
 
 // Start safely spins up the Lookout and begins monitoring for breaches.
@@ -150,6 +185,10 @@ func (l *Lookout) watchBlocks(epochs *chainntnfs.BlockEpochEvent) {
 func (l *Lookout) processEpoch(epoch *chainntnfs.BlockEpoch,
 	block *wire.MsgBlock) error {
 
+	// Before looking for new breaches, escalate the fees of the justice
+	// transactions of previous breaches that haven't confirmed yet.
+	l.escalateJustice(epoch, block)
+
 	numTxnsInBlock := len(block.Transactions)
 
 	log.Debugf("Scanning %d transaction in block (height=%d, hash=%s) "+
@@ -242,11 +281,87 @@ func (l *Lookout) processEpoch(epoch *chainntnfs.BlockEpoch,
 	for _, justiceDesc := range successes {
 		l.wg.Add(1)
 		go l.dispatchPunisher(justiceDesc)
+
+		l.trackEscalation(justiceDesc, epoch.Height)
 	}
 
 	return l.cfg.DB.SetLookoutTip(epoch)
 }
 
+// trackEscalation starts tracking the breach of the justice descriptor if the
+// client provided justice transactions paying higher fees, such that they can
+// be published if the justice transaction doesn't confirm in time.
+func (l *Lookout) trackEscalation(desc *JusticeDescriptor, height int32) {
+	if !desc.JusticeKit.BlobType.HasFeeEscalation() {
+		return
+	}
+
+	_, toLocalOutPoint, _, err := desc.findCommitToLocalOutput()
+	if err != nil {
+		log.Errorf("Unable to locate to-local output of breach-txid=%s "+
+			"for %s: %v", desc.BreachedCommitTx.TxHash(),
+			desc.SessionInfo.ID, err)
+		return
+	}
+
+	l.escalations[toLocalOutPoint] = &pendingEscalation{
+		desc:         desc,
+		breachHeight: height,
+	}
+}
+
+// escalateJustice stops tracking the breaches whose to-local outputs are spent
+// in the block, and dispatches the punisher with the justice transaction of
+// the next fee escalation step for the remaining breaches that are due.
+func (l *Lookout) escalateJustice(epoch *chainntnfs.BlockEpoch,
+	block *wire.MsgBlock) {
+
+	if len(l.escalations) == 0 {
+		return
+	}
+
+	// Once the to-local output is spent, either by one of the justice
+	// transactions or by the breaching party, escalating the fee is of no
+	// use anymore.
+	for _, tx := range block.Transactions {
+		for _, txIn := range tx.TxIn {
+			pending, ok := l.escalations[txIn.PreviousOutPoint]
+			if !ok {
+				continue
+			}
+
+			breachTxID := pending.desc.BreachedCommitTx.TxHash()
+			log.Infof("To-local output of breach-txid=%s spent by "+
+				"txid=%s", breachTxID, tx.TxHash())
+
+			delete(l.escalations, txIn.PreviousOutPoint)
+		}
+	}
+
+	for outPoint, pending := range l.escalations {
+		if epoch.Height < pending.nextHeight() {
+			continue
+		}
+
+		pending.step++
+		desc, ok := pending.desc.Escalate(pending.step)
+		if !ok || pending.step == blob.NumFeeEscalations {
+			delete(l.escalations, outPoint)
+		}
+		if !ok {
+			continue
+		}
+
+		log.Infof("Escalating justice transaction for client %s, "+
+			"breach-txid=%s to %v (step %d)", desc.SessionInfo.ID,
+			desc.BreachedCommitTx.TxHash(),
+			desc.SessionInfo.Policy.SweepFeeRate, pending.step)
+
+		l.wg.Add(1)
+		go l.dispatchPunisher(desc)
+	}
+}
+
 // dispatchPunisher accepts a justice descriptor corresponding to a successfully
 // decrypted blob.  The punisher will then construct the witness scripts and
 // witness stacks for the breached outputs. If construction of the justice
@@ -8,8 +8,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/btcsuite/btcd/wire"
 	"github.com/cryptomeow/lnd/chainntnfs"
+	"github.com/cryptomeow/lnd/input"
+	"github.com/cryptomeow/lnd/lnwallet/chainfee"
 	"github.com/cryptomeow/lnd/watchtower/blob"
 	"github.com/cryptomeow/lnd/watchtower/lookout"
 	"github.com/cryptomeow/lnd/watchtower/wtdb"
@@ -269,3 +272,156 @@ func TestLookoutBreachMatching(t *testing.T) {
 	case <-time.After(50 * time.Millisecond):
 	}
 }
+
+// TestLookoutFeeEscalation asserts that the lookout publishes the escalated
+// justice transactions of a breach as long as its to-local output remains
+// unspent.
+func TestLookoutFeeEscalation(t *testing.T) {
+	db := wtmock.NewTowerDB()
+	backend := lookout.NewMockBackend()
+	matches := make(chan *lookout.JusticeDescriptor)
+	punisher := &mockPunisher{matches: matches}
+
+	watcher := lookout.New(&lookout.Config{
+		BlockFetcher:   backend,
+		DB:             db,
+		EpochRegistrar: backend,
+		Punisher:       punisher,
+	})
+	if err := watcher.Start(); err != nil {
+		t.Fatalf("unable to start watcher: %v", err)
+	}
+
+	blobType := blob.TypeAltruistCommitFeeEscalation
+	sessionInfo := &wtdb.SessionInfo{
+		ID: makeArray33(1),
+		Policy: wtpolicy.Policy{
+			TxPolicy: wtpolicy.TxPolicy{
+				BlobType:     blobType,
+				SweepFeeRate: wtpolicy.DefaultSweepFeeRate,
+			},
+			MaxUpdates: 10,
+		},
+	}
+	if err := db.InsertSessionInfo(sessionInfo); err != nil {
+		t.Fatalf("unable to insert session info: %v", err)
+	}
+
+	// The to-local script must be valid for the lookout to locate the
+	// breached output, so the keys have to be on the curve.
+	privKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	var pubKey blob.PubKey
+	copy(pubKey[:], privKey.PubKey().SerializeCompressed())
+
+	justiceKit := &blob.JusticeKit{
+		BlobType:         blobType,
+		SweepAddress:     makeAddrSlice(22),
+		RevocationPubKey: pubKey,
+		LocalDelayPubKey: pubKey,
+		CSVDelay:         144,
+		CommitToLocalSig: makeArray64(1),
+		FeeEscalations: [blob.NumFeeEscalations]blob.EscalatedSigs{
+			{CommitToLocalSig: makeArray64(2)},
+			{CommitToLocalSig: makeArray64(3)},
+		},
+	}
+
+	toLocalScript, err := justiceKit.CommitToLocalWitnessScript()
+	if err != nil {
+		t.Fatalf("unable to create to-local script: %v", err)
+	}
+	toLocalPkScript, err := input.WitnessScriptHash(toLocalScript)
+	if err != nil {
+		t.Fatalf("unable to create to-local pkscript: %v", err)
+	}
+
+	breachTx := wire.NewMsgTx(wire.TxVersion)
+	breachTx.AddTxOut(wire.NewTxOut(100000, toLocalPkScript))
+	breachTxID := breachTx.TxHash()
+
+	encBlob, err := justiceKit.Encrypt(
+		blob.NewBreachKeyFromHash(&breachTxID),
+	)
+	if err != nil {
+		t.Fatalf("unable to encrypt justice kit: %v", err)
+	}
+	_, err = db.InsertStateUpdate(&wtdb.SessionStateUpdate{
+		ID:            sessionInfo.ID,
+		Hint:          blob.NewBreachHintFromHash(&breachTxID),
+		EncryptedBlob: encBlob,
+		SeqNum:        1,
+	})
+	if err != nil {
+		t.Fatalf("unable to add tx to db: %v", err)
+	}
+
+	connectBlock := func(height int32, txns ...*wire.MsgTx) {
+		block := &wire.MsgBlock{
+			Header: wire.BlockHeader{
+				Nonce: uint32(height),
+			},
+			Transactions: txns,
+		}
+		blockHash := block.BlockHash()
+		backend.ConnectEpoch(&chainntnfs.BlockEpoch{
+			Hash:   &blockHash,
+			Height: height,
+		}, block)
+	}
+
+	assertPunished := func(sig [64]byte, feeRate chainfee.SatPerKWeight) {
+		t.Helper()
+
+		select {
+		case desc := <-matches:
+			if desc.JusticeKit.CommitToLocalSig != sig {
+				t.Fatalf("unexpected to-local signature")
+			}
+			policy := desc.SessionInfo.Policy
+			if policy.SweepFeeRate != feeRate {
+				t.Fatalf("expected sweep fee rate %v, got %v",
+					feeRate, policy.SweepFeeRate)
+			}
+
+		case <-time.After(5 * time.Second):
+			t.Fatalf("punisher not dispatched")
+		}
+	}
+
+	assertNotPunished := func() {
+		t.Helper()
+
+		select {
+		case <-matches:
+			t.Fatalf("punisher should not have been dispatched")
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+
+	// The breach is punished at the fee rate of the session.
+	connectBlock(100, breachTx)
+	assertPunished(makeArray64(1), wtpolicy.DefaultSweepFeeRate)
+
+	// The first escalation is due after a third of the CSV delay.
+	connectBlock(147)
+	assertNotPunished()
+
+	connectBlock(148)
+	assertPunished(
+		makeArray64(2),
+		wtpolicy.DefaultSweepFeeRate*wtpolicy.FeeEscalationFactor,
+	)
+
+	// Once the to-local output is spent, the second escalation is no
+	// longer published.
+	spendTx := wire.NewMsgTx(wire.TxVersion)
+	spendTx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: wire.OutPoint{Hash: breachTxID},
+	})
+	connectBlock(149, spendTx)
+	connectBlock(196)
+	assertNotPunished()
+}
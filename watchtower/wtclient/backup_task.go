@@ -7,6 +7,7 @@ import (
 	"github.com/btcsuite/btcd/wire"
 	"github.com/btcsuite/btcutil"
 	"github.com/btcsuite/btcutil/txsort"
+	"github.com/cryptomeow/lnd/channeldb"
 	"github.com/cryptomeow/lnd/input"
 	"github.com/cryptomeow/lnd/lnwallet"
 	"github.com/cryptomeow/lnd/lnwire"
@@ -48,13 +49,19 @@ type backupTask struct {
 
 	blobType blob.Type
 	outputs  []*wire.TxOut
+
+	// escalatedOutputs holds the outputs of the justice transactions at
+	// the escalated fee rates of the session's policy, if the session
+	// supports fee escalation. Steps at which the justice transaction
+	// can't be created are omitted.
+	escalatedOutputs [][]*wire.TxOut
 }
 
 // newBackupTask initializes a new backupTask and populates all state-dependent
 // variables.
 func newBackupTask(chanID *lnwire.ChannelID,
 	breachInfo *lnwallet.BreachRetribution,
-	sweepPkScript []byte, isTweakless bool) *backupTask {
+	sweepPkScript []byte, chanType channeldb.ChannelType) *backupTask {
 
 	// Parse the non-dust outputs from the breach transaction,
 	// simultaneously computing the total amount contained in the inputs
@@ -85,18 +92,35 @@ func newBackupTask(chanID *lnwire.ChannelID,
 		totalAmt += breachInfo.RemoteOutputSignDesc.Output.Value
 	}
 	if breachInfo.LocalOutputSignDesc != nil {
-		witnessType := input.CommitmentNoDelay
-		if isTweakless {
-			witnessType = input.CommitSpendNoDelayTweakless
+		// Anchor channels have a to-remote output that is encumbered
+		// by a CSV delay of one block, while the to-remote output of
+		// legacy channels is a regular p2wkh output.
+		switch {
+		case chanType.HasAnchors():
+			toRemoteInput = input.NewCsvInput(
+				&breachInfo.LocalOutpoint,
+				input.CommitmentToRemoteConfirmed,
+				breachInfo.LocalOutputSignDesc,
+				0, 1,
+			)
+
+		case chanType.IsTweakless():
+			toRemoteInput = input.NewBaseInput(
+				&breachInfo.LocalOutpoint,
+				input.CommitSpendNoDelayTweakless,
+				breachInfo.LocalOutputSignDesc,
+				0,
+			)
+
+		default:
+			toRemoteInput = input.NewBaseInput(
+				&breachInfo.LocalOutpoint,
+				input.CommitmentNoDelay,
+				breachInfo.LocalOutputSignDesc,
+				0,
+			)
 		}
 
-		toRemoteInput = input.NewBaseInput(
-			&breachInfo.LocalOutpoint,
-			witnessType,
-			breachInfo.LocalOutputSignDesc,
-			0,
-		)
-
 		totalAmt += breachInfo.LocalOutputSignDesc.Output.Value
 	}
 
@@ -145,13 +169,28 @@ func (t *backupTask) bindSession(session *wtdb.ClientSessionBody) error {
 		// underestimate the size by one byte. The diferrence in weight
 		// can cause different output values on the sweep transaction,
 		// so we mimic the original bug and create signatures using the
-		// original weight estimate.
-		weightEstimate.AddWitnessInput(
-			input.ToLocalPenaltyWitnessSize - 1,
-		)
+		// original weight estimate. The tower uses the correct witness
+		// size for anchor channels, so we do as well.
+		if session.Policy.IsAnchorChannel() {
+			weightEstimate.AddWitnessInput(
+				input.ToLocalPenaltyWitnessSize,
+			)
+		} else {
+			weightEstimate.AddWitnessInput(
+				input.ToLocalPenaltyWitnessSize - 1,
+			)
+		}
 	}
 	if t.toRemoteInput != nil {
-		weightEstimate.AddWitnessInput(input.P2WKHWitnessSize)
+		// Anchor channels spend a p2wsh to-remote output, legacy
+		// channels a p2wkh one.
+		if session.Policy.IsAnchorChannel() {
+			weightEstimate.AddWitnessInput(
+				input.ToRemoteConfirmedWitnessSize,
+			)
+		} else {
+			weightEstimate.AddWitnessInput(input.P2WKHWitnessSize)
+		}
 	}
 
 	// All justice transactions have a p2wkh output paying to the victim.
@@ -173,8 +212,29 @@ func (t *backupTask) bindSession(session *wtdb.ClientSessionBody) error {
 		return err
 	}
 
+	// If the session supports fee escalation, compute the outputs of the
+	// justice transactions at the escalated fee rates as well. The fee
+	// rates only increase, so we stop at the first step the justice
+	// transaction can't afford.
+	var escalatedOutputs [][]*wire.TxOut
+	if session.Policy.BlobType.HasFeeEscalation() {
+		for step := 1; step <= blob.NumFeeEscalations; step++ {
+			policy := session.Policy.Escalate(step)
+			outputs, err := policy.ComputeJusticeTxOuts(
+				t.totalAmt, int64(weightEstimate.Weight()),
+				t.sweepPkScript, session.RewardPkScript,
+			)
+			if err != nil {
+				break
+			}
+
+			escalatedOutputs = append(escalatedOutputs, outputs)
+		}
+	}
+
 	t.blobType = session.Policy.BlobType
 	t.outputs = outputs
+	t.escalatedOutputs = escalatedOutputs
 
 	return nil
 }
@@ -211,23 +271,75 @@ func (t *backupTask) craftSessionPayload(
 		)
 	}
 
-	// Now, begin construction of the justice transaction. We'll start with
-	// a version 2 transaction.
+	// Sign the justice transaction using the outputs computed when the
+	// task was bound.
+	toLocalSig, toRemoteSig, err := t.signJusticeTxn(signer, t.outputs)
+	if err != nil {
+		return hint, nil, err
+	}
+	justiceKit.CommitToLocalSig = toLocalSig
+	justiceKit.CommitToRemoteSig = toRemoteSig
+
+	// Then, sign the justice transactions at the escalated fee rates, which
+	// the tower can use to replace the justice transaction if it doesn't
+	// confirm in time.
+	for i, outputs := range t.escalatedOutputs {
+		toLocalSig, toRemoteSig, err := t.signJusticeTxn(
+			signer, outputs,
+		)
+		if err != nil {
+			return hint, nil, err
+		}
+
+		justiceKit.FeeEscalations[i] = blob.EscalatedSigs{
+			CommitToLocalSig:  toLocalSig,
+			CommitToRemoteSig: toRemoteSig,
+		}
+	}
+
+	breachTxID := t.breachInfo.BreachTransaction.TxHash()
+
+	// Compute the breach key as SHA256(txid).
+	hint, key := blob.NewBreachHintAndKeyFromHash(&breachTxID)
+
+	// Then, we'll encrypt the computed justice kit using the full breach
+	// transaction id, which will allow the tower to recover the contents
+	// after the transaction is seen in the chain or mempool.
+	encBlob, err := justiceKit.Encrypt(key)
+	if err != nil {
+		return hint, nil, err
+	}
+
+	return hint, encBlob, nil
+}
+
+// signJusticeTxn assembles the justice transaction with the given outputs and
+// signs its inputs. The signatures of the to-local and to-remote inputs are
+// returned, where the to-remote signature is blank if there is no to-remote
+// input.
+func (t *backupTask) signJusticeTxn(signer input.Signer,
+	outputs []*wire.TxOut) (lnwire.Sig, lnwire.Sig, error) {
+
+	var toLocalSig, toRemoteSig lnwire.Sig
+
+	// Begin construction of the justice transaction, starting with a
+	// version 2 transaction.
 	justiceTxn := wire.NewMsgTx(2)
 
 	// Next, add the non-dust inputs that were derived from the breach
 	// information. This will either be contain both the to-local and
 	// to-remote outputs, or only be the to-local output.
 	inputs := t.inputs()
-	for prevOutPoint := range inputs {
+	for prevOutPoint, inp := range inputs {
 		justiceTxn.AddTxIn(&wire.TxIn{
 			PreviousOutPoint: prevOutPoint,
+			Sequence:         inp.BlocksToMaturity(),
 		})
 	}
 
 	// Add the sweep output paying directly to the user and possibly a
-	// reward output, using the outputs computed when the task was bound.
-	justiceTxn.TxOut = t.outputs
+	// reward output.
+	justiceTxn.TxOut = outputs
 
 	// Sort the justice transaction according to BIP69.
 	txsort.InPlaceSort(justiceTxn)
@@ -236,7 +348,7 @@ func (t *backupTask) craftSessionPayload(
 	// before attempting to attach the witnesses.
 	btx := btcutil.NewTx(justiceTxn)
 	if err := blockchain.CheckTransactionSanity(btx); err != nil {
-		return hint, nil, err
+		return toLocalSig, toRemoteSig, err
 	}
 
 	// Construct a sighash cache to improve signing performance.
@@ -251,8 +363,7 @@ func (t *backupTask) craftSessionPayload(
 	}
 
 	// Now, iterate through the list of inputs that were initially added to
-	// the transaction and store the computed witness within the justice
-	// kit.
+	// the transaction and extract the signature from the computed witness.
 	for _, inp := range inputs {
 		// Lookup the input's new post-sort position.
 		i := inputIndex[*inp.OutPoint()]
@@ -262,7 +373,7 @@ func (t *backupTask) craftSessionPayload(
 			signer, justiceTxn, hashCache, i,
 		)
 		if err != nil {
-			return hint, nil, err
+			return toLocalSig, toRemoteSig, err
 		}
 
 		// Parse the DER-encoded signature from the first position of
@@ -275,37 +386,26 @@ func (t *backupTask) craftSessionPayload(
 		// signature.
 		signature, err := lnwire.NewSigFromRawSignature(rawSignature)
 		if err != nil {
-			return hint, nil, err
+			return toLocalSig, toRemoteSig, err
 		}
 
-		// Finally, copy the serialized signature into the justice kit,
-		// using the input's witness type to select the appropriate
-		// field.
+		// Finally, copy the serialized signature into the returned
+		// signatures, using the input's witness type to select the
+		// appropriate one.
 		switch inp.WitnessType() {
 		case input.CommitmentRevoke:
-			copy(justiceKit.CommitToLocalSig[:], signature[:])
+			copy(toLocalSig[:], signature[:])
 
 		case input.CommitSpendNoDelayTweakless:
 			fallthrough
 		case input.CommitmentNoDelay:
-			copy(justiceKit.CommitToRemoteSig[:], signature[:])
+			fallthrough
+		case input.CommitmentToRemoteConfirmed:
+			copy(toRemoteSig[:], signature[:])
 		}
 	}
 
-	breachTxID := t.breachInfo.BreachTransaction.TxHash()
-
-	// Compute the breach key as SHA256(txid).
-	hint, key := blob.NewBreachHintAndKeyFromHash(&breachTxID)
-
-	// Then, we'll encrypt the computed justice kit using the full breach
-	// transaction id, which will allow the tower to recover the contents
-	// after the transaction is seen in the chain or mempool.
-	encBlob, err := justiceKit.Encrypt(key)
-	if err != nil {
-		return hint, nil, err
-	}
-
-	return hint, encBlob, nil
+	return toLocalSig, toRemoteSig, nil
 }
 
 // toBlobPubKey serializes the given pubkey into a blob.PubKey that can be set
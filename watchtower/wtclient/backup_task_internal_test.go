@@ -13,6 +13,7 @@ import (
 	"github.com/btcsuite/btcd/wire"
 	"github.com/btcsuite/btcutil"
 	"github.com/davecgh/go-spew/spew"
+	"github.com/cryptomeow/lnd/channeldb"
 	"github.com/cryptomeow/lnd/input"
 	"github.com/cryptomeow/lnd/keychain"
 	"github.com/cryptomeow/lnd/lnwallet"
@@ -74,7 +75,7 @@ type backupTaskTest struct {
 	bindErr          error
 	expSweepScript   []byte
 	signer           input.Signer
-	tweakless        bool
+	chanType         channeldb.ChannelType
 }
 
 // genTaskTest creates a instance of a backupTaskTest using the passed
@@ -92,7 +93,7 @@ func genTaskTest(
 	expSweepAmt int64,
 	expRewardAmt int64,
 	bindErr error,
-	tweakless bool) backupTaskTest {
+	chanType channeldb.ChannelType) backupTaskTest {
 
 	// Parse the key pairs for all keys used in the test.
 	revSK, revPK := btcec.PrivKeyFromBytes(
@@ -192,17 +193,31 @@ func genTaskTest(
 			Index: index,
 		}
 
-		witnessType := input.CommitmentNoDelay
-		if tweakless {
-			witnessType = input.CommitSpendNoDelayTweakless
+		switch {
+		case chanType.HasAnchors():
+			toRemoteInput = input.NewCsvInput(
+				&breachInfo.LocalOutpoint,
+				input.CommitmentToRemoteConfirmed,
+				breachInfo.LocalOutputSignDesc,
+				0, 1,
+			)
+
+		case chanType.IsTweakless():
+			toRemoteInput = input.NewBaseInput(
+				&breachInfo.LocalOutpoint,
+				input.CommitSpendNoDelayTweakless,
+				breachInfo.LocalOutputSignDesc,
+				0,
+			)
+
+		default:
+			toRemoteInput = input.NewBaseInput(
+				&breachInfo.LocalOutpoint,
+				input.CommitmentNoDelay,
+				breachInfo.LocalOutputSignDesc,
+				0,
+			)
 		}
-
-		toRemoteInput = input.NewBaseInput(
-			&breachInfo.LocalOutpoint,
-			witnessType,
-			breachInfo.LocalOutputSignDesc,
-			0,
-		)
 	}
 
 	return backupTaskTest{
@@ -227,7 +242,7 @@ func genTaskTest(
 		bindErr:        bindErr,
 		expSweepScript: makeAddrSlice(22),
 		signer:         signer,
-		tweakless:      tweakless,
+		chanType:       chanType,
 	}
 }
 
@@ -254,7 +269,10 @@ func TestBackupTask(t *testing.T) {
 	t.Parallel()
 
 	var backupTaskTests []backupTaskTest
-	for _, tweakless := range []bool{true, false} {
+	for _, chanType := range []channeldb.ChannelType{
+		channeldb.SingleFunderTweaklessBit,
+		channeldb.SingleFunderBit,
+	} {
 		backupTaskTests = append(backupTaskTests, []backupTaskTest{
 			genTaskTest(
 				"commit no-reward, both outputs",
@@ -267,7 +285,7 @@ func TestBackupTask(t *testing.T) {
 				299241,                 // expSweepAmt
 				0,                      // expRewardAmt
 				nil,                    // bindErr
-				tweakless,
+				chanType,
 			),
 			genTaskTest(
 				"commit no-reward, to-local output only",
@@ -280,7 +298,7 @@ func TestBackupTask(t *testing.T) {
 				199514,                 // expSweepAmt
 				0,                      // expRewardAmt
 				nil,                    // bindErr
-				tweakless,
+				chanType,
 			),
 			genTaskTest(
 				"commit no-reward, to-remote output only",
@@ -293,7 +311,7 @@ func TestBackupTask(t *testing.T) {
 				99561,                  // expSweepAmt
 				0,                      // expRewardAmt
 				nil,                    // bindErr
-				tweakless,
+				chanType,
 			),
 			genTaskTest(
 				"commit no-reward, to-remote output only, creates dust",
@@ -306,7 +324,7 @@ func TestBackupTask(t *testing.T) {
 				0,                       // expSweepAmt
 				0,                       // expRewardAmt
 				wtpolicy.ErrCreatesDust, // bindErr
-				tweakless,
+				chanType,
 			),
 			genTaskTest(
 				"commit no-reward, no outputs, fee rate exceeds inputs",
@@ -319,7 +337,7 @@ func TestBackupTask(t *testing.T) {
 				0,                            // expSweepAmt
 				0,                            // expRewardAmt
 				wtpolicy.ErrFeeExceedsInputs, // bindErr
-				tweakless,
+				chanType,
 			),
 			genTaskTest(
 				"commit no-reward, no outputs, fee rate of 0 creates dust",
@@ -332,7 +350,7 @@ func TestBackupTask(t *testing.T) {
 				0,                       // expSweepAmt
 				0,                       // expRewardAmt
 				wtpolicy.ErrCreatesDust, // bindErr
-				tweakless,
+				chanType,
 			),
 			genTaskTest(
 				"commit reward, both outputs",
@@ -345,7 +363,7 @@ func TestBackupTask(t *testing.T) {
 				296117,               // expSweepAmt
 				3000,                 // expRewardAmt
 				nil,                  // bindErr
-				tweakless,
+				chanType,
 			),
 			genTaskTest(
 				"commit reward, to-local output only",
@@ -358,7 +376,7 @@ func TestBackupTask(t *testing.T) {
 				197390,               // expSweepAmt
 				2000,                 // expRewardAmt
 				nil,                  // bindErr
-				tweakless,
+				chanType,
 			),
 			genTaskTest(
 				"commit reward, to-remote output only",
@@ -371,7 +389,7 @@ func TestBackupTask(t *testing.T) {
 				98437,                // expSweepAmt
 				1000,                 // expRewardAmt
 				nil,                  // bindErr
-				tweakless,
+				chanType,
 			),
 			genTaskTest(
 				"commit reward, to-remote output only, creates dust",
@@ -384,7 +402,7 @@ func TestBackupTask(t *testing.T) {
 				0,                       // expSweepAmt
 				0,                       // expRewardAmt
 				wtpolicy.ErrCreatesDust, // bindErr
-				tweakless,
+				chanType,
 			),
 			genTaskTest(
 				"commit reward, no outputs, fee rate exceeds inputs",
@@ -397,7 +415,7 @@ func TestBackupTask(t *testing.T) {
 				0,                            // expSweepAmt
 				0,                            // expRewardAmt
 				wtpolicy.ErrFeeExceedsInputs, // bindErr
-				tweakless,
+				chanType,
 			),
 			genTaskTest(
 				"commit reward, no outputs, fee rate of 0 creates dust",
@@ -410,11 +428,57 @@ func TestBackupTask(t *testing.T) {
 				0,                       // expSweepAmt
 				0,                       // expRewardAmt
 				wtpolicy.ErrCreatesDust, // bindErr
-				tweakless,
+				chanType,
 			),
 		}...)
 	}
 
+	// Anchor channels spend p2wsh to-remote outputs and use the correct
+	// to-local witness size, which results in slightly higher fees.
+	anchorChanType := channeldb.SingleFunderTweaklessBit |
+		channeldb.AnchorOutputsBit
+	backupTaskTests = append(backupTaskTests, []backupTaskTest{
+		genTaskTest(
+			"anchor commit no-reward, both outputs",
+			100,                           // stateNum
+			200000,                        // toLocalAmt
+			100000,                        // toRemoteAmt
+			blob.TypeAltruistAnchorCommit, // blobType
+			1000,                          // sweepFeeRate
+			nil,                           // rewardScript
+			299236,                        // expSweepAmt
+			0,                             // expRewardAmt
+			nil,                           // bindErr
+			anchorChanType,
+		),
+		genTaskTest(
+			"anchor commit no-reward, to-local output only",
+			1000,                          // stateNum
+			200000,                        // toLocalAmt
+			0,                             // toRemoteAmt
+			blob.TypeAltruistAnchorCommit, // blobType
+			1000,                          // sweepFeeRate
+			nil,                           // rewardScript
+			199513,                        // expSweepAmt
+			0,                             // expRewardAmt
+			nil,                           // bindErr
+			anchorChanType,
+		),
+		genTaskTest(
+			"anchor commit no-reward, to-remote output only",
+			1,                             // stateNum
+			0,                             // toLocalAmt
+			100000,                        // toRemoteAmt
+			blob.TypeAltruistAnchorCommit, // blobType
+			1000,                          // sweepFeeRate
+			nil,                           // rewardScript
+			99557,                         // expSweepAmt
+			0,                             // expRewardAmt
+			nil,                           // bindErr
+			anchorChanType,
+		),
+	}...)
+
 	for _, test := range backupTaskTests {
 		test := test
 
@@ -430,7 +494,7 @@ func testBackupTask(t *testing.T, test backupTaskTest) {
 	// Create a new backupTask from the channel id and breach info.
 	task := newBackupTask(
 		&test.chanID, test.breachInfo, test.expSweepScript,
-		test.tweakless,
+		test.chanType,
 	)
 
 	// Assert that all parameters set during initialization are properly
@@ -636,3 +700,129 @@ func testBackupTask(t *testing.T, test backupTaskTest) {
 		t.Fatalf("to-remote signature should be empty")
 	}
 }
+
+// TestBackupTaskFeeEscalation asserts that backup tasks bound to sessions with
+// fee escalation sign the justice transactions at the escalated fee rates the
+// justice transaction can afford.
+func TestBackupTaskFeeEscalation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		test           backupTaskTest
+		expSweepAmts   []int64
+		hasToRemoteSig bool
+	}{
+		{
+			test: genTaskTest(
+				"fee escalation, both outputs",
+				100,    // stateNum
+				200000, // toLocalAmt
+				100000, // toRemoteAmt
+				blob.TypeAltruistCommitFeeEscalation,
+				1000,   // sweepFeeRate
+				nil,    // rewardScript
+				299241, // expSweepAmt
+				0,      // expRewardAmt
+				nil,    // bindErr
+				channeldb.SingleFunderTweaklessBit,
+			),
+			expSweepAmts:   []int64{298482, 296964},
+			hasToRemoteSig: true,
+		},
+		{
+			// Only the first escalation step can be afforded.
+			test: genTaskTest(
+				"fee escalation, to-local output only",
+				1000,   // stateNum
+				200000, // toLocalAmt
+				0,      // toRemoteAmt
+				blob.TypeAltruistCommitFeeEscalation,
+				120000, // sweepFeeRate
+				nil,    // rewardScript
+				141680, // expSweepAmt
+				0,      // expRewardAmt
+				nil,    // bindErr
+				channeldb.SingleFunderBit,
+			),
+			expSweepAmts: []int64{83360},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.test.name, func(t *testing.T) {
+			t.Parallel()
+
+			testBackupTask(t, test.test)
+
+			task := newBackupTask(
+				&test.test.chanID, test.test.breachInfo,
+				test.test.expSweepScript, test.test.chanType,
+			)
+			err := task.bindSession(test.test.session)
+			if err != nil {
+				t.Fatalf("unable to bind session: %v", err)
+			}
+
+			var sweepAmts []int64
+			for _, outputs := range task.escalatedOutputs {
+				sweepAmts = append(sweepAmts, outputs[0].Value)
+			}
+			if !reflect.DeepEqual(test.expSweepAmts, sweepAmts) {
+				t.Fatalf("escalated sweep amount mismatch, "+
+					"want: %v, got: %v", test.expSweepAmts,
+					sweepAmts)
+			}
+
+			_, encBlob, err := task.craftSessionPayload(
+				test.test.signer,
+			)
+			if err != nil {
+				t.Fatalf("unable to craft session payload: %v",
+					err)
+			}
+
+			breachTx := test.test.breachInfo.BreachTransaction
+			breachTxID := breachTx.TxHash()
+			key := blob.NewBreachKeyFromHash(&breachTxID)
+			jKit, err := blob.Decrypt(
+				key, encBlob, test.test.session.Policy.BlobType,
+			)
+			if err != nil {
+				t.Fatalf("unable to decrypt blob: %v", err)
+			}
+
+			// The affordable steps are signed, the others are
+			// left blank.
+			for i, sigs := range jKit.FeeEscalations {
+				signed := i < len(test.expSweepAmts)
+
+				emptyToLocalSig := bytes.Equal(
+					sigs.CommitToLocalSig[:], zeroSig[:],
+				)
+				if emptyToLocalSig == signed {
+					t.Fatalf("step %d: expected signed=%v",
+						i+1, signed)
+				}
+
+				emptyToRemoteSig := bytes.Equal(
+					sigs.CommitToRemoteSig[:], zeroSig[:],
+				)
+				if !emptyToRemoteSig !=
+					(signed && test.hasToRemoteSig) {
+
+					t.Fatalf("step %d: unexpected "+
+						"to-remote signature", i+1)
+				}
+
+				if signed && sigs.CommitToLocalSig ==
+					jKit.CommitToLocalSig {
+
+					t.Fatalf("step %d: signature not "+
+						"escalated", i+1)
+				}
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"net"
 	"sync"
 
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/cryptomeow/lnd/watchtower/wtdb"
 )
 
@@ -21,6 +22,10 @@ type TowerCandidateIterator interface {
 	// completely removed from the iterator.
 	RemoveCandidate(wtdb.TowerID, net.Addr)
 
+	// RemoveCandidateByKey completely removes an existing candidate tower
+	// with the given identity key from the iterator.
+	RemoveCandidateByKey(*btcec.PublicKey)
+
 	// IsActive determines whether a given tower is exists within the
 	// iterator.
 	IsActive(wtdb.TowerID) bool
@@ -146,6 +151,19 @@ func (t *towerListIterator) RemoveCandidate(candidate wtdb.TowerID, addr net.Add
 	}
 }
 
+// RemoveCandidateByKey completely removes an existing candidate tower with the
+// given identity key from the iterator.
+func (t *towerListIterator) RemoveCandidateByKey(pubKey *btcec.PublicKey) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for id, tower := range t.candidates {
+		if tower.IdentityKey.IsEqual(pubKey) {
+			delete(t.candidates, id)
+		}
+	}
+}
+
 // IsActive determines whether a given tower is exists within the iterator.
 func (t *towerListIterator) IsActive(tower wtdb.TowerID) bool {
 	t.mu.Lock()
@@ -148,10 +148,15 @@ func TestTowerCandidateIterator(t *testing.T) {
 	towerIterator.AddCandidate(fourthTower)
 	assertNextCandidate(t, towerIterator, fourthTower)
 
-	// Finally, we'll attempt to add a new candidate to the end of the
+	// We'll then attempt to add a new candidate to the end of the
 	// iterator. Since it didn't already exist and we've reached the end, it
 	// should be available as the next candidate.
 	towerIterator.AddCandidate(secondTower)
 	assertActiveCandidate(t, towerIterator, secondTower, true)
 	assertNextCandidate(t, towerIterator, secondTower)
+
+	// Finally, we'll remove the third tower by its identity key, which
+	// should remove it completely from the iterator.
+	towerIterator.RemoveCandidateByKey(thirdTower.IdentityKey)
+	assertActiveCandidate(t, towerIterator, thirdTower, false)
 }
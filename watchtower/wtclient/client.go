@@ -10,6 +10,8 @@ import (
 
 	"github.com/btcsuite/btcd/btcec"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/cryptomeow/lnd/channeldb"
+	"github.com/cryptomeow/lnd/clock"
 	"github.com/cryptomeow/lnd/input"
 	"github.com/cryptomeow/lnd/keychain"
 	"github.com/cryptomeow/lnd/lnwallet"
@@ -48,6 +50,19 @@ var (
 	}
 )
 
+// policySessionFilter returns a filter that ignores any sessions which are not
+// active, or which back up states of a different kind of channel than the
+// given policy. This keeps clients with anchor and legacy policies sharing a
+// database from resuming each other's sessions.
+func policySessionFilter(
+	policy wtpolicy.Policy) func(*wtdb.ClientSession) bool {
+
+	return func(s *wtdb.ClientSession) bool {
+		return activeSessionFilter(s) &&
+			s.Policy.IsAnchorChannel() == policy.IsAnchorChannel()
+	}
+}
+
 // RegisteredTower encompasses information about a registered watchtower with
 // the client.
 type RegisteredTower struct {
@@ -100,10 +115,10 @@ type Client interface {
 	// state. If the method returns nil, the backup is guaranteed to be
 	// successful unless the client is force quit, or the justice
 	// transaction would create dust outputs when trying to abide by the
-	// negotiated policy. If the channel we're trying to back up doesn't
-	// have a tweak for the remote party's output, then isTweakless should
-	// be true.
-	BackupState(*lnwire.ChannelID, *lnwallet.BreachRetribution, bool) error
+	// negotiated policy. The channel type of the revoked state determines
+	// the scripts of the breached outputs.
+	BackupState(*lnwire.ChannelID, *lnwallet.BreachRetribution,
+		channeldb.ChannelType) error
 
 	// Start initializes the watchtower client, allowing it process requests
 	// to backup revoked channel states.
@@ -279,7 +294,8 @@ func New(config *Config) (*TowerClient, error) {
 	// the current policy of the client, otherwise they will be ignored and
 	// new sessions will be requested.
 	candidateSessions, err := getClientSessions(
-		cfg.DB, cfg.SecretKeyRing, nil, activeSessionFilter,
+		cfg.DB, cfg.SecretKeyRing, nil,
+		policySessionFilter(cfg.Policy),
 	)
 	if err != nil {
 		return nil, err
@@ -301,7 +317,7 @@ func New(config *Config) (*TowerClient, error) {
 
 	c := &TowerClient{
 		cfg:               cfg,
-		pipeline:          newTaskPipeline(),
+		pipeline:          newTaskPipeline(clock.NewDefaultClock()),
 		candidateTowers:   newTowerListIterator(candidateTowers...),
 		candidateSessions: candidateSessions,
 		activeSessions:    make(sessionQueueSet),
@@ -570,8 +586,18 @@ func (c *TowerClient) RegisterChannel(chanID lnwire.ChannelID) error {
 
 	// Persist the sweep pkscript so that restarts will not introduce
 	// address inflation when the channel is reregistered after a restart.
+	// If another client sharing the database registered the channel in the
+	// meantime, we'll use its pkscript instead.
 	err = c.cfg.DB.RegisterChannel(chanID, pkScript)
-	if err != nil {
+	switch {
+	case err == wtdb.ErrChannelAlreadyRegistered:
+		summaries, err := c.cfg.DB.FetchChanSummaries()
+		if err != nil {
+			return err
+		}
+		pkScript = summaries[chanID].SweepPkScript
+
+	case err != nil:
 		return err
 	}
 
@@ -592,7 +618,15 @@ func (c *TowerClient) RegisterChannel(chanID lnwire.ChannelID) error {
 //  - breached outputs contain too little value to sweep at the target sweep fee
 //    rate.
 func (c *TowerClient) BackupState(chanID *lnwire.ChannelID,
-	breachInfo *lnwallet.BreachRetribution, isTweakless bool) error {
+	breachInfo *lnwallet.BreachRetribution,
+	chanType channeldb.ChannelType) error {
+
+	// The justice transactions of anchor channels spend different
+	// scripts, so they can only be backed up to sessions with a matching
+	// policy.
+	if chanType.HasAnchors() != c.cfg.Policy.IsAnchorChannel() {
+		return ErrChannelTypeMismatch
+	}
 
 	// Retrieve the cached sweep pkscript used for this channel.
 	c.backupMu.Lock()
@@ -618,10 +652,18 @@ func (c *TowerClient) BackupState(chanID *lnwire.ChannelID,
 	c.backupMu.Unlock()
 
 	task := newBackupTask(
-		chanID, breachInfo, summary.SweepPkScript, isTweakless,
+		chanID, breachInfo, summary.SweepPkScript, chanType,
 	)
 
-	return c.pipeline.QueueBackupTask(task)
+	// The task is counted before it is queued, as the dispatcher may
+	// receive it right away.
+	c.stats.taskQueued()
+	if err := c.pipeline.QueueBackupTask(task); err != nil {
+		c.stats.taskDequeued()
+		return err
+	}
+
+	return nil
 }
 
 // nextSessionQueue attempts to fetch an active session from our set of
@@ -1066,7 +1108,8 @@ func (c *TowerClient) handleNewTower(msg *newTowerMsg) error {
 
 	// Include all of its corresponding sessions to our set of candidates.
 	sessions, err := getClientSessions(
-		c.cfg.DB, c.cfg.SecretKeyRing, &tower.ID, activeSessionFilter,
+		c.cfg.DB, c.cfg.SecretKeyRing, &tower.ID,
+		policySessionFilter(c.cfg.Policy),
 	)
 	if err != nil {
 		return fmt.Errorf("unable to determine sessions for tower %x: "+
@@ -1116,7 +1159,15 @@ func (c *TowerClient) handleStaleTower(msg *staleTowerMsg) error {
 	// We'll load the tower before potentially removing it in order to
 	// retrieve its ID within the database.
 	tower, err := c.cfg.DB.LoadTower(msg.pubKey)
-	if err != nil {
+	switch {
+	// Another client sharing the database may have already removed the
+	// tower, which only happens if it didn't have any sessions, so we'll
+	// only need to remove it from our candidates.
+	case err == wtdb.ErrTowerNotFound && msg.addr == nil:
+		c.candidateTowers.RemoveCandidateByKey(msg.pubKey)
+		return nil
+
+	case err != nil:
 		return err
 	}
 
@@ -32,4 +32,10 @@ var (
 	// revoked state because the channel had not been previously registered
 	// with the client.
 	ErrUnregisteredChannel = errors.New("channel is not registered")
+
+	// ErrChannelTypeMismatch signals that the client was unable to backup
+	// a revoked state because the client's policy doesn't support the
+	// channel's commitment type.
+	ErrChannelTypeMismatch = errors.New("channel type not supported by " +
+		"client policy")
 )
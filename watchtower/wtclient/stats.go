@@ -29,14 +29,35 @@ type ClientStats struct {
 	// NumSessionsExhausted is the total number of watchtower sessions that
 	// have been exhausted.
 	NumSessionsExhausted int
+
+	// NumTasksQueued is the number of backups that are waiting to be
+	// processed by the client.
+	NumTasksQueued int
+}
+
+// taskQueued increments the number of backup requests that are waiting to be
+// processed by the client.
+func (s *ClientStats) taskQueued() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.NumTasksQueued++
+}
+
+// taskDequeued decrements the number of backup requests that are waiting to
+// be processed by the client, if a request couldn't be queued.
+func (s *ClientStats) taskDequeued() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.NumTasksQueued--
 }
 
 // taskReceived increments the number to backup requests the client has received
-// from active channels.
+// from active channels, which are no longer waiting to be processed.
 func (s *ClientStats) taskReceived() {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.NumTasksReceived++
+	s.NumTasksQueued--
 }
 
 // taskAccepted increments the number of tasks that have been assigned to active
@@ -77,9 +98,10 @@ func (s *ClientStats) sessionExhausted() {
 func (s *ClientStats) String() string {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	return fmt.Sprintf("tasks(received=%d accepted=%d ineligible=%d) "+
-		"sessions(acquired=%d exhausted=%d)", s.NumTasksReceived,
-		s.NumTasksAccepted, s.NumTasksIneligible, s.NumSessionsAcquired,
+	return fmt.Sprintf("tasks(queued=%d received=%d accepted=%d "+
+		"ineligible=%d) sessions(acquired=%d exhausted=%d)",
+		s.NumTasksQueued, s.NumTasksReceived, s.NumTasksAccepted,
+		s.NumTasksIneligible, s.NumSessionsAcquired,
 		s.NumSessionsExhausted)
 }
 
@@ -93,5 +115,6 @@ func (s *ClientStats) Copy() ClientStats {
 		NumTasksIneligible:   s.NumTasksIneligible,
 		NumSessionsAcquired:  s.NumSessionsAcquired,
 		NumSessionsExhausted: s.NumSessionsExhausted,
+		NumTasksQueued:       s.NumTasksQueued,
 	}
 }
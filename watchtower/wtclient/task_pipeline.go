@@ -2,25 +2,49 @@ package wtclient
 
 import (
 	"container/list"
+	"math"
 	"sync"
 	"time"
+
+	"github.com/cryptomeow/lnd/clock"
+	"github.com/cryptomeow/lnd/lnwire"
 )
 
-// taskPipeline implements a reliable, in-order queue that ensures its queue
-// fully drained before exiting. Stopping the taskPipeline prevents the pipeline
-// from accepting any further tasks, and will cause the pipeline to exit after
-// all updates have been delivered to the downstream receiver. If this process
-// hangs and is unable to make progress, users can optionally call ForceQuit to
-// abandon the reliable draining of the queue in order to permit shutdown.
+// priorityAgeInterval is the interval after which the priority of a backup
+// task that is waiting to be delivered is increased by its base priority.
+const priorityAgeInterval = time.Minute
+
+// queuedTask is a backup task waiting in the taskPipeline.
+type queuedTask struct {
+	task     *backupTask
+	queuedAt time.Time
+}
+
+// taskPipeline implements a reliable, prioritized queue that ensures its queue
+// fully drained before exiting. Tasks are delivered in order of the balance at
+// risk in their revoked states, weighted by the time their channels haven't
+// been backed up for, such that the most valuable states are backed up first
+// if the tower is slow or sessions are scarce. Stopping the taskPipeline
+// prevents the pipeline from accepting any further tasks, and will cause the
+// pipeline to exit after all updates have been delivered to the downstream
+// receiver. If this process hangs and is unable to make progress, users can
+// optionally call ForceQuit to abandon the reliable draining of the queue in
+// order to permit shutdown.
 type taskPipeline struct {
 	started sync.Once
 	stopped sync.Once
 	forced  sync.Once
 
+	clock clock.Clock
+
 	queueMtx  sync.Mutex
 	queueCond *sync.Cond
 	queue     *list.List
 
+	// lastBackups records when a task of each channel was last delivered.
+	// It is guarded by the queueMtx.
+	lastBackups map[lnwire.ChannelID]time.Time
+
 	newBackupTasks chan *backupTask
 
 	quit      chan struct{}
@@ -29,9 +53,11 @@ type taskPipeline struct {
 }
 
 // newTaskPipeline initializes a new taskPipeline.
-func newTaskPipeline() *taskPipeline {
+func newTaskPipeline(clock clock.Clock) *taskPipeline {
 	rq := &taskPipeline{
+		clock:          clock,
 		queue:          list.New(),
+		lastBackups:    make(map[lnwire.ChannelID]time.Time),
 		newBackupTasks: make(chan *backupTask),
 		quit:           make(chan struct{}),
 		forceQuit:      make(chan struct{}),
@@ -113,7 +139,10 @@ func (q *taskPipeline) QueueBackupTask(task *backupTask) error {
 
 	// Queue the new task and signal the queue's condition variable to wake up
 	// the queueManager for processing.
-	q.queue.PushBack(task)
+	q.queue.PushBack(&queuedTask{
+		task:     task,
+		queuedAt: q.clock.Now(),
+	})
 	q.queueCond.L.Unlock()
 
 	q.queueCond.Signal()
@@ -121,6 +150,53 @@ func (q *taskPipeline) QueueBackupTask(task *backupTask) error {
 	return nil
 }
 
+// taskPriority returns the priority of a queued backup task. The base priority
+// grows logarithmically with the balance at risk in the revoked state, so that
+// tasks of channels with little balance aren't starved, and is increased by
+// itself for every priorityAgeInterval the channel hasn't been backed up for.
+//
+// NOTE: The queueMtx MUST be held when calling this method.
+func (q *taskPipeline) taskPriority(queued *queuedTask, now time.Time) float64 {
+	var atRisk int64
+	if queued.task.toLocalInput != nil {
+		atRisk = queued.task.toLocalInput.SignDesc().Output.Value
+	}
+
+	// The task waits since the last backup of its channel, or since it
+	// was queued if that happened later.
+	lastBackup, ok := q.lastBackups[queued.task.id.ChanID]
+	if !ok || queued.queuedAt.After(lastBackup) {
+		lastBackup = queued.queuedAt
+	}
+	age := float64(now.Sub(lastBackup)) / float64(priorityAgeInterval)
+
+	return math.Log2(float64(atRisk)+2) * (1 + age)
+}
+
+// popTask removes the backup task with the highest priority from the queue.
+// Tasks of equal priority are removed in the order they were queued.
+//
+// NOTE: The queueMtx MUST be held when calling this method.
+func (q *taskPipeline) popTask() *backupTask {
+	var (
+		now          = q.clock.Now()
+		best         *list.Element
+		bestPriority float64
+	)
+	for e := q.queue.Front(); e != nil; e = e.Next() {
+		priority := q.taskPriority(e.Value.(*queuedTask), now)
+		if best == nil || priority > bestPriority {
+			best = e
+			bestPriority = priority
+		}
+	}
+
+	task := q.queue.Remove(best).(*queuedTask).task
+	q.lastBackups[task.id.ChanID] = now
+
+	return task
+}
+
 // queueManager processes all incoming backup requests that get added via
 // QueueBackupTask. The manager will exit
 //
@@ -152,9 +228,8 @@ func (q *taskPipeline) queueManager() {
 			}
 		}
 
-		// Pop the first element from the queue.
-		e := q.queue.Front()
-		task := q.queue.Remove(e).(*backupTask)
+		// Pop the element with the highest priority from the queue.
+		task := q.popTask()
 		q.queueCond.L.Unlock()
 
 		select {
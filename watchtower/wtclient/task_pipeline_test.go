@@ -0,0 +1,88 @@
+package wtclient
+
+import (
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/cryptomeow/lnd/clock"
+	"github.com/cryptomeow/lnd/input"
+	"github.com/cryptomeow/lnd/lnwire"
+	"github.com/cryptomeow/lnd/watchtower/wtdb"
+	"github.com/stretchr/testify/require"
+)
+
+// newPriorityTestTask creates a backup task of the channel with the given
+// balance at risk.
+func newPriorityTestTask(chanID byte, height uint64,
+	atRisk int64) *backupTask {
+
+	task := &backupTask{
+		id: wtdb.BackupID{
+			ChanID:       lnwire.ChannelID{chanID},
+			CommitHeight: height,
+		},
+	}
+	if atRisk > 0 {
+		task.toLocalInput = input.NewBaseInput(
+			&wire.OutPoint{}, input.CommitmentRevoke,
+			&input.SignDescriptor{
+				Output: &wire.TxOut{Value: atRisk},
+			}, 0,
+		)
+	}
+
+	return task
+}
+
+// TestTaskPipelinePriority asserts that the task pipeline delivers the backups
+// with the most balance at risk first, and that backups of channels that
+// haven't been backed up for a while catch up.
+func TestTaskPipelinePriority(t *testing.T) {
+	t.Parallel()
+
+	testClock := clock.NewTestClock(time.Unix(1000, 0))
+	pipeline := newTaskPipeline(testClock)
+
+	small := newPriorityTestTask(1, 1, 1000)
+	noRisk := newPriorityTestTask(2, 1, 0)
+	large := newPriorityTestTask(3, 1, 1000000)
+	large2 := newPriorityTestTask(3, 2, 1000000)
+
+	// Queue the tasks before starting the pipeline, such that they are
+	// ranked against each other.
+	for _, task := range []*backupTask{small, noRisk, large, large2} {
+		require.NoError(t, pipeline.QueueBackupTask(task))
+	}
+
+	nextTask := func() *backupTask {
+		t.Helper()
+
+		select {
+		case task := <-pipeline.NewBackupTasks():
+			return task
+		case <-time.After(5 * time.Second):
+			t.Fatalf("no task delivered")
+			return nil
+		}
+	}
+
+	// The tasks of the large channel are delivered first. The pipeline
+	// pops the second one before blocking on its delivery.
+	pipeline.Start()
+	defer pipeline.ForceQuit()
+
+	require.Equal(t, large, nextTask())
+
+	// Time passes before another task of the large channel is queued. The
+	// channels that haven't been backed up yet now outrank it, and the
+	// channel with more balance at risk is still delivered first.
+	testClock.SetTime(time.Unix(1000, 0).Add(10 * priorityAgeInterval))
+	large3 := newPriorityTestTask(3, 3, 1000000)
+	require.NoError(t, pipeline.QueueBackupTask(large3))
+
+	require.Equal(t, large2, nextTask())
+	require.Equal(t, small, nextTask())
+	require.Equal(t, large3, nextTask())
+	require.Equal(t, noRisk, nextTask())
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/btcsuite/btcd/btcec"
 	"github.com/cryptomeow/lnd/channeldb/kvdb"
 	"github.com/cryptomeow/lnd/lnwire"
+	"github.com/cryptomeow/lnd/watchtower/blob"
 )
 
 const (
@@ -110,6 +111,16 @@ var (
 	ErrIncorrectKeyIndex = errors.New("incorrect key index")
 )
 
+// clientDBBuckets are the top-level buckets of the client database, apart from
+// the metadata bucket.
+var clientDBBuckets = [][]byte{
+	cSessionKeyIndexBkt,
+	cChanSummaryBkt,
+	cSessionBkt,
+	cTowerBkt,
+	cTowerIndexBkt,
+}
+
 // ClientDB is single database providing a persistent storage engine for the
 // wtclient.
 type ClientDB struct {
@@ -130,14 +141,37 @@ func OpenClientDB(dbPath string) (*ClientDB, error) {
 		return nil, err
 	}
 
+	return openClientDB(bdb, dbPath, firstInit)
+}
+
+// OpenClientDBWithBackend opens the client database stored in the given kvdb
+// backend, allowing the database to live in a replicated backend alongside the
+// channel database. The backend is initialized or migrated in the same way as
+// by OpenClientDB, and is closed once the returned database is closed.
+func OpenClientDBWithBackend(db kvdb.Backend) (*ClientDB, error) {
+	metadataExists, err := hasMetadata(db)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return openClientDB(db, "", !metadataExists)
+}
+
+// openClientDB brings the client database stored in the given backend up to
+// the latest version and bucket structure. The backend is closed if this
+// fails.
+func openClientDB(db kvdb.Backend, dbPath string,
+	firstInit bool) (*ClientDB, error) {
+
 	clientDB := &ClientDB{
-		db:     bdb,
+		db:     db,
 		dbPath: dbPath,
 	}
 
-	err = initOrSyncVersions(clientDB, firstInit, clientDBVersions)
+	err := initOrSyncVersions(clientDB, firstInit, clientDBVersions)
 	if err != nil {
-		bdb.Close()
+		db.Close()
 		return nil, err
 	}
 
@@ -148,7 +182,7 @@ func OpenClientDB(dbPath string) (*ClientDB, error) {
 	// missing, this will trigger a ErrUninitializedDB error.
 	err = kvdb.Update(clientDB.db, initClientDBBuckets, func() {})
 	if err != nil {
-		bdb.Close()
+		db.Close()
 		return nil, err
 	}
 
@@ -158,15 +192,7 @@ func OpenClientDB(dbPath string) (*ClientDB, error) {
 // initClientDBBuckets creates all top-level buckets required to handle database
 // operations required by the latest version.
 func initClientDBBuckets(tx kvdb.RwTx) error {
-	buckets := [][]byte{
-		cSessionKeyIndexBkt,
-		cChanSummaryBkt,
-		cSessionBkt,
-		cTowerBkt,
-		cTowerIndexBkt,
-	}
-
-	for _, bucket := range buckets {
+	for _, bucket := range clientDBBuckets {
 		_, err := tx.CreateTopLevelBucket(bucket)
 		if err != nil {
 			return err
@@ -465,11 +491,14 @@ func (c *ClientDB) ListTowers() ([]*Tower, error) {
 }
 
 // NextSessionKeyIndex reserves a new session key derivation index for a
-// particular tower id. The index is reserved for that tower until
-// CreateClientSession is invoked for that tower and index, at which point a new
-// index for that tower can be reserved. Multiple calls to this method before
-// CreateClientSession is invoked should return the same index.
-func (c *ClientDB) NextSessionKeyIndex(towerID TowerID) (uint32, error) {
+// particular tower id and blob type. The index is reserved for that tower and
+// blob type until CreateClientSession is invoked for that tower and index, at
+// which point a new index for that tower can be reserved. Multiple calls to
+// this method before CreateClientSession is invoked should return the same
+// index.
+func (c *ClientDB) NextSessionKeyIndex(towerID TowerID,
+	blobType blob.Type) (uint32, error) {
+
 	var index uint32
 	err := kvdb.Update(c.db, func(tx kvdb.RwTx) error {
 		keyIndex := tx.ReadWriteBucket(cSessionKeyIndexBkt)
@@ -480,8 +509,8 @@ func (c *ClientDB) NextSessionKeyIndex(towerID TowerID) (uint32, error) {
 		// Check the session key index to see if a key has already been
 		// reserved for this tower. If so, we'll deserialize and return
 		// the index directly.
-		towerIDBytes := towerID.Bytes()
-		indexBytes := keyIndex.Get(towerIDBytes)
+		keyIndexKey := createKeyIndexKey(towerID, blobType)
+		indexBytes := keyIndex.Get(keyIndexKey)
 		if len(indexBytes) == 4 {
 			index = byteOrder.Uint32(indexBytes)
 			return nil
@@ -507,7 +536,7 @@ func (c *ClientDB) NextSessionKeyIndex(towerID TowerID) (uint32, error) {
 		byteOrder.PutUint32(indexBuf[:], index)
 
 		// Record the reserved session key index under this tower's id.
-		return keyIndex.Put(towerIDBytes, indexBuf[:])
+		return keyIndex.Put(keyIndexKey, indexBuf[:])
 	}, func() {
 		index = 0
 	})
@@ -518,6 +547,23 @@ func (c *ClientDB) NextSessionKeyIndex(towerID TowerID) (uint32, error) {
 	return index, nil
 }
 
+// createKeyIndexKey returns the key under which the session key index reserved
+// for the tower and blob type is stored. Reservations for legacy sessions are
+// stored under the tower id alone, such that existing reservations remain
+// valid, while other blob types get a reservation of their own. This allows
+// clients with different policies to negotiate with the same tower
+// concurrently.
+func createKeyIndexKey(towerID TowerID, blobType blob.Type) []byte {
+	if blobType == blob.TypeAltruistCommit {
+		return towerID.Bytes()
+	}
+
+	var blobTypeBytes [2]byte
+	byteOrder.PutUint16(blobTypeBytes[:], uint16(blobType))
+
+	return append(towerID.Bytes(), blobTypeBytes[:]...)
+}
+
 // CreateClientSession records a newly negotiated client session in the set of
 // active sessions. The session can be identified by its SessionID.
 func (c *ClientDB) CreateClientSession(session *ClientSession) error {
@@ -540,8 +586,10 @@ func (c *ClientDB) CreateClientSession(session *ClientSession) error {
 		}
 
 		// Check that this tower has a reserved key index.
-		towerIDBytes := session.TowerID.Bytes()
-		keyIndexBytes := keyIndexes.Get(towerIDBytes)
+		keyIndexKey := createKeyIndexKey(
+			session.TowerID, session.Policy.BlobType,
+		)
+		keyIndexBytes := keyIndexes.Get(keyIndexKey)
 		if len(keyIndexBytes) != 4 {
 			return ErrNoReservedKeyIndex
 		}
@@ -554,7 +602,7 @@ func (c *ClientDB) CreateClientSession(session *ClientSession) error {
 		}
 
 		// Remove the key index reservation.
-		err := keyIndexes.Delete(towerIDBytes)
+		err := keyIndexes.Delete(keyIndexKey)
 		if err != nil {
 			return err
 		}
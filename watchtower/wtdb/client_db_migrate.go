@@ -0,0 +1,421 @@
+package wtdb
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/cryptomeow/lnd/channeldb/kvdb"
+)
+
+const (
+	// migrationBatchSize is the maximum number of keys and buckets that are
+	// copied into the backend within a single transaction. Replicated
+	// backends limit the size of their transactions, so larger databases
+	// must be copied in several of them.
+	migrationBatchSize = 1000
+)
+
+var (
+	// clientDBMigrationBkt is a top-level bucket of the backend that tracks
+	// the progress of the migration of a client database file. It is
+	// created along with the first batch of copied data.
+	clientDBMigrationBkt = []byte("client-db-migration")
+
+	// migrationResumeKey stores the path of the last key or bucket that
+	// was copied into the backend, so that an interrupted migration can
+	// continue after it.
+	migrationResumeKey = []byte("resume")
+
+	// migrationCompleteKey is set once all data was copied into the
+	// backend.
+	migrationCompleteKey = []byte("complete")
+
+	// errBatchFull is returned internally once a migration batch reached
+	// its maximum size.
+	errBatchFull = errors.New("migration batch full")
+)
+
+// MigrateClientDB copies the content of an existing client database file in
+// the given directory into the passed backend. This allows nodes that move
+// their databases to a replicated backend to keep their tower sessions. The
+// migration is skipped if no client database file exists, or if the backend
+// already holds a client database that wasn't migrated. The data is copied in
+// bounded batches, and a migration that was interrupted continues where it
+// left off. The database file itself is left in place as a backup.
+func MigrateClientDB(dbPath string, db kvdb.Backend) error {
+	path := filepath.Join(dbPath, clientDBName)
+	if !fileExists(path) {
+		return nil
+	}
+
+	started, complete, err := fetchMigrationState(db)
+	if err != nil {
+		return err
+	}
+	if complete {
+		return nil
+	}
+
+	// A client database in the backend that wasn't created by us must
+	// not be overwritten.
+	if !started {
+		metadataExists, err := hasMetadata(db)
+		if err != nil {
+			return err
+		}
+		if metadataExists {
+			log.Debugf("Client database already present in "+
+				"backend, not migrating %v", path)
+			return nil
+		}
+	}
+
+	// Open the existing database through the regular path, so that it's
+	// brought up to the latest version before it's copied.
+	src, err := OpenClientDB(dbPath)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	if started {
+		log.Infof("Resuming migration of client database %v to new "+
+			"backend", path)
+	} else {
+		log.Infof("Migrating client database %v to new backend", path)
+	}
+
+	for {
+		done, err := copyClientDBBatch(
+			src.db, db, migrationBatchSize,
+		)
+		if err != nil {
+			return err
+		}
+		if done {
+			break
+		}
+	}
+
+	log.Infof("Migration of client database %v complete", path)
+
+	return nil
+}
+
+// fetchMigrationState returns whether a migration into the backend was
+// started, and whether it is complete.
+func fetchMigrationState(db kvdb.Backend) (bool, bool, error) {
+	var started, complete bool
+	err := kvdb.View(db, func(tx kvdb.RTx) error {
+		migration := tx.ReadBucket(clientDBMigrationBkt)
+		if migration == nil {
+			return nil
+		}
+
+		started = true
+		complete = migration.Get(migrationCompleteKey) != nil
+
+		return nil
+	}, func() {
+		started = false
+		complete = false
+	})
+	if err != nil {
+		return false, false, err
+	}
+
+	return started, complete, nil
+}
+
+// copyClientDBBatch copies up to batchSize keys and buckets of the src client
+// database into the dst backend, continuing after the last batch that was
+// copied. The progress is persisted in the same transaction as the copied
+// data. It returns true once all data has been copied.
+func copyClientDBBatch(src, dst kvdb.Backend, batchSize int) (bool, error) {
+	var done bool
+
+	// Sequences are only accessible through read-write buckets, so the
+	// source database is read within a read-write transaction that is
+	// never modified.
+	err := kvdb.Update(src, func(srcTx kvdb.RwTx) error {
+		return kvdb.Update(dst, func(dstTx kvdb.RwTx) error {
+			migration, err := dstTx.CreateTopLevelBucket(
+				clientDBMigrationBkt,
+			)
+			if err != nil {
+				return err
+			}
+
+			resume, err := decodeKeyPath(
+				migration.Get(migrationResumeKey),
+			)
+			if err != nil {
+				return err
+			}
+
+			copier := &batchCopier{
+				resume: resume,
+				budget: batchSize,
+			}
+			err = copier.copyTopLevel(dstTx, srcTx)
+			switch {
+			// The batch is full, remember where to continue.
+			case err == errBatchFull:
+				return migration.Put(
+					migrationResumeKey,
+					encodeKeyPath(copier.last),
+				)
+
+			case err != nil:
+				return err
+			}
+
+			done = true
+			err = migration.Delete(migrationResumeKey)
+			if err != nil {
+				return err
+			}
+
+			return migration.Put(migrationCompleteKey, []byte{1})
+		}, func() {
+			done = false
+		})
+	}, func() {
+		done = false
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return done, nil
+}
+
+// batchCopier copies the keys and buckets of a database in a fixed order,
+// skipping everything up to and including the resume path, until its budget
+// is exhausted. The path of a key is the list of bucket names leading to it,
+// followed by the key itself.
+type batchCopier struct {
+	// resume is the path of the last key or bucket copied by the previous
+	// batch, nil if there was none.
+	resume [][]byte
+
+	// last is the path of the last key or bucket copied by this batch.
+	last [][]byte
+
+	// budget is the number of keys and buckets that can still be copied
+	// within this batch.
+	budget int
+}
+
+// copied returns true if the key or bucket at the given path was copied by a
+// previous batch.
+func (c *batchCopier) copied(path [][]byte) bool {
+	return c.resume != nil && comparePaths(path, c.resume) <= 0
+}
+
+// completed returns true if the bucket at the given path, along with all of
+// its content, was copied by a previous batch.
+func (c *batchCopier) completed(path [][]byte) bool {
+	return c.copied(path) && !hasPathPrefix(c.resume, path)
+}
+
+// take accounts for the copy of the key or bucket at the given path. It
+// returns errBatchFull if the batch can't hold any more data.
+func (c *batchCopier) take(path [][]byte) error {
+	if c.budget == 0 {
+		return errBatchFull
+	}
+	c.budget--
+
+	c.last = make([][]byte, len(path))
+	for i, key := range path {
+		c.last[i] = append([]byte(nil), key...)
+	}
+
+	return nil
+}
+
+// copyTopLevel copies the top-level buckets of the client database. They are
+// copied in the order of their names, just like nested buckets and keys.
+func (c *batchCopier) copyTopLevel(dstTx, srcTx kvdb.RwTx) error {
+	buckets := append([][]byte{metadataBkt}, clientDBBuckets...)
+	sort.Slice(buckets, func(i, j int) bool {
+		return bytes.Compare(buckets[i], buckets[j]) < 0
+	})
+
+	for _, bucket := range buckets {
+		path := [][]byte{bucket}
+		if c.completed(path) {
+			continue
+		}
+
+		srcBucket := srcTx.ReadWriteBucket(bucket)
+		if srcBucket == nil {
+			return ErrUninitializedDB
+		}
+
+		isNew := !c.copied(path)
+		if isNew {
+			if err := c.take(path); err != nil {
+				return err
+			}
+		}
+
+		dstBucket, err := dstTx.CreateTopLevelBucket(bucket)
+		if err != nil {
+			return err
+		}
+
+		if isNew {
+			err := dstBucket.SetSequence(srcBucket.Sequence())
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := c.copyBucket(dstBucket, srcBucket, path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// copyBucket recursively copies all keys, nested buckets and sequence numbers
+// of the src bucket at the given path into the dst bucket.
+func (c *batchCopier) copyBucket(dst, src kvdb.RwBucket,
+	path [][]byte) error {
+
+	// If the previous batch stopped within this bucket, we can directly
+	// jump to the key it stopped at.
+	cursor := src.ReadCursor()
+	k, v := cursor.First()
+	if len(c.resume) > len(path) && hasPathPrefix(c.resume, path) {
+		k, v = cursor.Seek(c.resume[len(path)])
+	}
+
+	for ; k != nil; k, v = cursor.Next() {
+		keyPath := append(path[:len(path):len(path)], k)
+
+		// A nil value indicates a nested bucket.
+		if v != nil {
+			if c.copied(keyPath) {
+				continue
+			}
+
+			if err := c.take(keyPath); err != nil {
+				return err
+			}
+
+			if err := dst.Put(k, v); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if c.completed(keyPath) {
+			continue
+		}
+
+		srcNested := src.NestedReadWriteBucket(k)
+		if srcNested == nil {
+			return ErrUninitializedDB
+		}
+
+		isNew := !c.copied(keyPath)
+		if isNew {
+			if err := c.take(keyPath); err != nil {
+				return err
+			}
+		}
+
+		dstNested, err := dst.CreateBucketIfNotExists(k)
+		if err != nil {
+			return err
+		}
+
+		if isNew {
+			err := dstNested.SetSequence(srcNested.Sequence())
+			if err != nil {
+				return err
+			}
+		}
+
+		err = c.copyBucket(dstNested, srcNested, keyPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// comparePaths compares two key paths component by component. A path that is
+// a prefix of the other one is considered to be smaller, as a bucket is
+// copied before its content.
+func comparePaths(a, b [][]byte) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if cmp := bytes.Compare(a[i], b[i]); cmp != 0 {
+			return cmp
+		}
+	}
+
+	switch {
+	case len(a) < len(b):
+		return -1
+
+	case len(a) > len(b):
+		return 1
+
+	default:
+		return 0
+	}
+}
+
+// hasPathPrefix returns true if the given path starts with the prefix path.
+func hasPathPrefix(path, prefix [][]byte) bool {
+	if len(prefix) > len(path) {
+		return false
+	}
+
+	return comparePaths(path[:len(prefix)], prefix) == 0
+}
+
+// encodeKeyPath serializes a key path as a list of length prefixed keys.
+func encodeKeyPath(path [][]byte) []byte {
+	var b bytes.Buffer
+	for _, key := range path {
+		var length [4]byte
+		byteOrder.PutUint32(length[:], uint32(len(key)))
+		b.Write(length[:])
+		b.Write(key)
+	}
+
+	return b.Bytes()
+}
+
+// decodeKeyPath is the inverse of encodeKeyPath. An empty encoding results in
+// a nil path.
+func decodeKeyPath(b []byte) ([][]byte, error) {
+	var path [][]byte
+	for len(b) > 0 {
+		if len(b) < 4 {
+			return nil, fmt.Errorf("invalid key path length")
+		}
+
+		length := byteOrder.Uint32(b[:4])
+		b = b[4:]
+		if uint32(len(b)) < length {
+			return nil, fmt.Errorf("invalid key path length")
+		}
+
+		path = append(path, append([]byte(nil), b[:length]...))
+		b = b[length:]
+	}
+
+	return path, nil
+}
@@ -0,0 +1,141 @@
+package wtdb
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/cryptomeow/lnd/channeldb/kvdb"
+	"github.com/cryptomeow/lnd/lnwire"
+	"github.com/stretchr/testify/require"
+)
+
+// dumpBucket adds all keys, nested buckets and sequence numbers of the given
+// bucket to the passed map, indexed by their path.
+func dumpBucket(bucket kvdb.RwBucket, prefix string,
+	dump map[string]string) error {
+
+	dump[prefix+"/seq"] = fmt.Sprintf("%d", bucket.Sequence())
+
+	return bucket.ForEach(func(k, v []byte) error {
+		path := fmt.Sprintf("%s/%x", prefix, k)
+		if v != nil {
+			dump[path] = fmt.Sprintf("%x", v)
+			return nil
+		}
+
+		return dumpBucket(bucket.NestedReadWriteBucket(k), path, dump)
+	})
+}
+
+// dumpClientDB returns the content of all buckets of a client database.
+func dumpClientDB(t *testing.T, db kvdb.Backend) map[string]string {
+	dump := make(map[string]string)
+	err := kvdb.Update(db, func(tx kvdb.RwTx) error {
+		buckets := append([][]byte{metadataBkt}, clientDBBuckets...)
+		for _, bucket := range buckets {
+			prefix := fmt.Sprintf("%x", bucket)
+			err := dumpBucket(
+				tx.ReadWriteBucket(bucket), prefix, dump,
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}, func() {})
+	require.NoError(t, err)
+
+	return dump
+}
+
+// TestMigrateClientDBResume asserts that a migration of the client database
+// that was interrupted after a batch continues where it left off, and that
+// the result is an exact copy of the database.
+func TestMigrateClientDBResume(t *testing.T) {
+	t.Parallel()
+
+	path, err := ioutil.TempDir("", "clientdb")
+	require.NoError(t, err)
+	defer os.RemoveAll(path)
+
+	src, err := OpenClientDB(path)
+	require.NoError(t, err)
+
+	// Populate the database with a tower and a couple of channels, so
+	// that it spans several batches.
+	priv, err := btcec.NewPrivateKey(btcec.S256())
+	require.NoError(t, err)
+	_, err = src.CreateTower(&lnwire.NetAddress{
+		IdentityKey: priv.PubKey(),
+		Address: &net.TCPAddr{
+			IP: []byte{0x01, 0x00, 0x00, 0x00}, Port: 9911,
+		},
+	})
+	require.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		chanID := lnwire.ChannelID{byte(i)}
+		err := src.RegisterChannel(chanID, []byte{0x01, byte(i)})
+		require.NoError(t, err)
+	}
+
+	backend, cleanup, err := kvdb.GetTestBackend(path, "backend.db")
+	require.NoError(t, err)
+	defer cleanup()
+
+	// Copy a single small batch, as if we were interrupted after it.
+	done, err := copyClientDBBatch(src.db, backend, 3)
+	require.NoError(t, err)
+	require.False(t, done)
+
+	started, complete, err := fetchMigrationState(backend)
+	require.NoError(t, err)
+	require.True(t, started)
+	require.False(t, complete)
+
+	expected := dumpClientDB(t, src.db)
+	require.NoError(t, src.Close())
+
+	// The migration should be resumed, even though the metadata bucket of
+	// the client database might already exist in the backend.
+	require.NoError(t, MigrateClientDB(path, backend))
+
+	started, complete, err = fetchMigrationState(backend)
+	require.NoError(t, err)
+	require.True(t, started)
+	require.True(t, complete)
+
+	require.Equal(t, expected, dumpClientDB(t, backend))
+}
+
+// TestKeyPath asserts that key paths are ordered with buckets before their
+// content, and that they survive a serialization round trip.
+func TestKeyPath(t *testing.T) {
+	t.Parallel()
+
+	bucket := [][]byte{{0x01}}
+	key := [][]byte{{0x01}, {0x02}}
+	next := [][]byte{{0x02}}
+
+	require.Equal(t, -1, comparePaths(bucket, key))
+	require.Equal(t, -1, comparePaths(key, next))
+	require.Equal(t, 0, comparePaths(key, key))
+	require.True(t, hasPathPrefix(key, bucket))
+	require.False(t, hasPathPrefix(next, bucket))
+
+	decoded, err := decodeKeyPath(encodeKeyPath(key))
+	require.NoError(t, err)
+	require.Equal(t, key, decoded)
+
+	decoded, err = decodeKeyPath(nil)
+	require.NoError(t, err)
+	require.Nil(t, decoded)
+
+	_, err = decodeKeyPath([]byte{0x00, 0x00, 0x00, 0x02, 0x01})
+	require.Error(t, err)
+}
@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"github.com/btcsuite/btcd/btcec"
+	"github.com/cryptomeow/lnd/channeldb/kvdb"
 	"github.com/cryptomeow/lnd/lnwire"
 	"github.com/cryptomeow/lnd/watchtower/blob"
 	"github.com/cryptomeow/lnd/watchtower/wtclient"
@@ -60,10 +61,12 @@ func (h *clientDBHarness) listSessions(id *wtdb.TowerID) map[wtdb.SessionID]*wtd
 	return sessions
 }
 
-func (h *clientDBHarness) nextKeyIndex(id wtdb.TowerID, expErr error) uint32 {
+func (h *clientDBHarness) nextKeyIndex(id wtdb.TowerID, blobType blob.Type,
+	expErr error) uint32 {
+
 	h.t.Helper()
 
-	index, err := h.db.NextSessionKeyIndex(id)
+	index, err := h.db.NextSessionKeyIndex(id, blobType)
 	if err != expErr {
 		h.t.Fatalf("expected next session key index error: %v, got: %v",
 			expErr, err)
@@ -250,7 +253,9 @@ func testCreateClientSession(h *clientDBHarness) {
 	h.insertSession(session, wtdb.ErrNoReservedKeyIndex)
 
 	// Now, reserve a session key for this tower.
-	keyIndex := h.nextKeyIndex(session.TowerID, nil)
+	keyIndex := h.nextKeyIndex(
+		session.TowerID, session.Policy.BlobType, nil,
+	)
 
 	// The client session hasn't been updated with the reserved key index
 	// (since it's still zero). Inserting should fail due to the mismatch.
@@ -259,12 +264,23 @@ func testCreateClientSession(h *clientDBHarness) {
 	// Reserve another key for the same index. Since no session has been
 	// successfully created, it should return the same index to maintain
 	// idempotency across restarts.
-	keyIndex2 := h.nextKeyIndex(session.TowerID, nil)
+	keyIndex2 := h.nextKeyIndex(
+		session.TowerID, session.Policy.BlobType, nil,
+	)
 	if keyIndex != keyIndex2 {
 		h.t.Fatalf("next key index should be idempotent: want: %v, "+
 			"got %v", keyIndex, keyIndex2)
 	}
 
+	// Sessions of a different blob type with the same tower get a
+	// reservation of their own.
+	anchorKeyIndex := h.nextKeyIndex(
+		session.TowerID, blob.TypeAltruistAnchorCommit, nil,
+	)
+	if anchorKeyIndex == keyIndex {
+		h.t.Fatalf("key index of anchor session should differ")
+	}
+
 	// Now, set the client session's key index so that it is proper and
 	// insert it. This should succeed.
 	session.KeyIndex = keyIndex
@@ -281,7 +297,9 @@ func testCreateClientSession(h *clientDBHarness) {
 
 	// Finally, assert that reserving another key index succeeds with a
 	// different key index, now that the first one has been finalized.
-	keyIndex3 := h.nextKeyIndex(session.TowerID, nil)
+	keyIndex3 := h.nextKeyIndex(
+		session.TowerID, session.Policy.BlobType, nil,
+	)
 	if keyIndex == keyIndex3 {
 		h.t.Fatalf("key index still reserved after creating session")
 	}
@@ -299,12 +317,15 @@ func testFilterClientSessions(h *clientDBHarness) {
 		if i == numSessions-1 {
 			towerID = wtdb.TowerID(2)
 		}
-		keyIndex := h.nextKeyIndex(towerID, nil)
+		keyIndex := h.nextKeyIndex(towerID, blob.TypeAltruistCommit, nil)
 		sessionID := wtdb.SessionID([33]byte{byte(i)})
 		h.insertSession(&wtdb.ClientSession{
 			ClientSessionBody: wtdb.ClientSessionBody{
 				TowerID: towerID,
 				Policy: wtpolicy.Policy{
+					TxPolicy: wtpolicy.TxPolicy{
+						BlobType: blob.TypeAltruistCommit,
+					},
 					MaxUpdates: 100,
 				},
 				RewardPkScript: []byte{0x01, 0x02, 0x03},
@@ -462,10 +483,15 @@ func testRemoveTower(h *clientDBHarness) {
 		ClientSessionBody: wtdb.ClientSessionBody{
 			TowerID: tower.ID,
 			Policy: wtpolicy.Policy{
+				TxPolicy: wtpolicy.TxPolicy{
+					BlobType: blob.TypeAltruistCommit,
+				},
 				MaxUpdates: 100,
 			},
 			RewardPkScript: []byte{0x01, 0x02, 0x03},
-			KeyIndex:       h.nextKeyIndex(tower.ID, nil),
+			KeyIndex: h.nextKeyIndex(
+				tower.ID, blob.TypeAltruistCommit, nil,
+			),
 		},
 		ID: wtdb.SessionID([33]byte{0x01}),
 	}
@@ -542,7 +568,9 @@ func testCommitUpdate(h *clientDBHarness) {
 	h.commitUpdate(&session.ID, update1, wtdb.ErrClientSessionNotFound)
 
 	// Reserve a session key index and insert the session.
-	session.KeyIndex = h.nextKeyIndex(session.TowerID, nil)
+	session.KeyIndex = h.nextKeyIndex(
+		session.TowerID, session.Policy.BlobType, nil,
+	)
 	h.insertSession(session, nil)
 
 	// Now, try to commit the update that failed initially which should
@@ -637,7 +665,9 @@ func testAckUpdate(h *clientDBHarness) {
 	h.ackUpdate(&session.ID, 1, 0, wtdb.ErrClientSessionNotFound)
 
 	// Reserve a session key and insert the client session.
-	session.KeyIndex = h.nextKeyIndex(session.TowerID, nil)
+	session.KeyIndex = h.nextKeyIndex(
+		session.TowerID, session.Policy.BlobType, nil,
+	)
 	h.insertSession(session, nil)
 
 	// Now, try to ack update 1. This should fail since update 1 was never
@@ -804,6 +834,87 @@ func TestClientDB(t *testing.T) {
 				return db, cleanup
 			},
 		},
+		{
+			name: "backend clientdb",
+			init: func(t *testing.T) (wtclient.DB, func()) {
+				path, err := ioutil.TempDir("", "clientdb")
+				if err != nil {
+					t.Fatalf("unable to make temp dir: %v",
+						err)
+				}
+
+				backend, backendCleanup, err := kvdb.GetTestBackend(
+					path, "backend.db",
+				)
+				if err != nil {
+					os.RemoveAll(path)
+					t.Fatalf("unable to get backend: %v", err)
+				}
+
+				db, err := wtdb.OpenClientDBWithBackend(backend)
+				if err != nil {
+					backendCleanup()
+					os.RemoveAll(path)
+					t.Fatalf("unable to open db: %v", err)
+				}
+
+				cleanup := func() {
+					db.Close()
+					backendCleanup()
+					os.RemoveAll(path)
+				}
+
+				return db, cleanup
+			},
+		},
+		{
+			name: "migrated clientdb",
+			init: func(t *testing.T) (wtclient.DB, func()) {
+				path, err := ioutil.TempDir("", "clientdb")
+				if err != nil {
+					t.Fatalf("unable to make temp dir: %v",
+						err)
+				}
+
+				db, err := wtdb.OpenClientDB(path)
+				if err != nil {
+					os.RemoveAll(path)
+					t.Fatalf("unable to open db: %v", err)
+				}
+				db.Close()
+
+				backend, backendCleanup, err := kvdb.GetTestBackend(
+					path, "backend.db",
+				)
+				if err != nil {
+					os.RemoveAll(path)
+					t.Fatalf("unable to get backend: %v", err)
+				}
+
+				err = wtdb.MigrateClientDB(path, backend)
+				if err != nil {
+					backend.Close()
+					backendCleanup()
+					os.RemoveAll(path)
+					t.Fatalf("unable to migrate db: %v", err)
+				}
+
+				db, err = wtdb.OpenClientDBWithBackend(backend)
+				if err != nil {
+					backendCleanup()
+					os.RemoveAll(path)
+					t.Fatalf("unable to open db: %v", err)
+				}
+
+				cleanup := func() {
+					db.Close()
+					backendCleanup()
+					os.RemoveAll(path)
+				}
+
+				return db, cleanup
+			},
+		},
 		{
 			name: "mock",
 			init: func(t *testing.T) (wtclient.DB, func()) {
@@ -865,6 +976,116 @@ func TestClientDB(t *testing.T) {
 	}
 }
 
+// TestMigrateClientDB asserts that the content of an existing client database
+// file is carried over when migrating it to a different backend, and that
+// sequences continue where they left off.
+func TestMigrateClientDB(t *testing.T) {
+	t.Parallel()
+
+	path, err := ioutil.TempDir("", "clientdb")
+	if err != nil {
+		t.Fatalf("unable to make temp dir: %v", err)
+	}
+	defer os.RemoveAll(path)
+
+	db, err := wtdb.OpenClientDB(path)
+	if err != nil {
+		t.Fatalf("unable to open db: %v", err)
+	}
+
+	newTowerAddr := func() *lnwire.NetAddress {
+		pk, err := randPubKey()
+		if err != nil {
+			t.Fatalf("unable to generate pubkey: %v", err)
+		}
+
+		return &lnwire.NetAddress{
+			IdentityKey: pk,
+			Address: &net.TCPAddr{
+				IP: []byte{0x01, 0x00, 0x00, 0x00}, Port: 9911,
+			},
+		}
+	}
+
+	// Populate the database with a tower, a reserved session key index
+	// and a registered channel.
+	tower, err := db.CreateTower(newTowerAddr())
+	if err != nil {
+		t.Fatalf("unable to create tower: %v", err)
+	}
+	keyIndex, err := db.NextSessionKeyIndex(
+		tower.ID, blob.TypeAltruistCommit,
+	)
+	if err != nil {
+		t.Fatalf("unable to reserve key index: %v", err)
+	}
+	chanID := lnwire.ChannelID{0x01}
+	err = db.RegisterChannel(chanID, []byte{0x01, 0x02})
+	if err != nil {
+		t.Fatalf("unable to register channel: %v", err)
+	}
+	db.Close()
+
+	backend, cleanup, err := kvdb.GetTestBackend(path, "backend.db")
+	if err != nil {
+		t.Fatalf("unable to get backend: %v", err)
+	}
+	defer cleanup()
+
+	if err := wtdb.MigrateClientDB(path, backend); err != nil {
+		t.Fatalf("unable to migrate db: %v", err)
+	}
+
+	// Migrating a second time should leave the migrated database as is.
+	if err := wtdb.MigrateClientDB(path, backend); err != nil {
+		t.Fatalf("unable to migrate db: %v", err)
+	}
+
+	db, err = wtdb.OpenClientDBWithBackend(backend)
+	if err != nil {
+		t.Fatalf("unable to open db: %v", err)
+	}
+	defer db.Close()
+
+	migratedTower, err := db.LoadTowerByID(tower.ID)
+	if err != nil {
+		t.Fatalf("unable to load tower: %v", err)
+	}
+	if !reflect.DeepEqual(tower, migratedTower) {
+		t.Fatalf("tower mismatch, want: %v, got: %v", tower,
+			migratedTower)
+	}
+
+	migratedIndex, err := db.NextSessionKeyIndex(
+		tower.ID, blob.TypeAltruistCommit,
+	)
+	if err != nil {
+		t.Fatalf("unable to reserve key index: %v", err)
+	}
+	if migratedIndex != keyIndex {
+		t.Fatalf("key index mismatch, want: %d, got: %d", keyIndex,
+			migratedIndex)
+	}
+
+	summaries, err := db.FetchChanSummaries()
+	if err != nil {
+		t.Fatalf("unable to fetch chan summaries: %v", err)
+	}
+	if _, ok := summaries[chanID]; !ok {
+		t.Fatalf("channel %v not migrated", chanID)
+	}
+
+	// New towers should continue the tower id sequence.
+	newTower, err := db.CreateTower(newTowerAddr())
+	if err != nil {
+		t.Fatalf("unable to create tower: %v", err)
+	}
+	if newTower.ID != tower.ID+1 {
+		t.Fatalf("tower id mismatch, want: %d, got: %d", tower.ID+1,
+			newTower.ID)
+	}
+}
+
 // randCommittedUpdate generates a random committed update.
 func randCommittedUpdate(t *testing.T, seqNum uint16) *wtdb.CommittedUpdate {
 	var chanID lnwire.ChannelID
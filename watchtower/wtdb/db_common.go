@@ -65,7 +65,9 @@ func createDBIfNotExist(dbPath, name string) (kvdb.Backend, bool, error) {
 
 	// Specify bbolt freelist options to reduce heap pressure in case the
 	// freelist grows to be very large.
-	bdb, err := kvdb.Create(kvdb.BoltBackendName, path, true)
+	bdb, err := kvdb.Create(
+		kvdb.BoltBackendName, path, true, kvdb.DefaultDBTimeout,
+	)
 	if err != nil {
 		return nil, false, err
 	}
@@ -76,13 +78,7 @@ func createDBIfNotExist(dbPath, name string) (kvdb.Backend, bool, error) {
 	// metadata. If the metadata bucket does not actually exist, we'll
 	// set firstInit to true so that we can treat is initialize the bucket.
 	if !firstInit {
-		var metadataExists bool
-		err = kvdb.View(bdb, func(tx kvdb.RTx) error {
-			metadataExists = tx.ReadBucket(metadataBkt) != nil
-			return nil
-		}, func() {
-			metadataExists = false
-		})
+		metadataExists, err := hasMetadata(bdb)
 		if err != nil {
 			return nil, false, err
 		}
@@ -94,3 +90,19 @@ func createDBIfNotExist(dbPath, name string) (kvdb.Backend, bool, error) {
 
 	return bdb, firstInit, nil
 }
+
+// hasMetadata returns true if the metadata bucket of the database exists.
+func hasMetadata(db kvdb.Backend) (bool, error) {
+	var metadataExists bool
+	err := kvdb.View(db, func(tx kvdb.RTx) error {
+		metadataExists = tx.ReadBucket(metadataBkt) != nil
+		return nil
+	}, func() {
+		metadataExists = false
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return metadataExists, nil
+}
@@ -0,0 +1,54 @@
+package wtdirectory
+
+import (
+	"github.com/cryptomeow/lnd/channeldb"
+	"github.com/cryptomeow/lnd/watchtower/blob"
+)
+
+// NodeSource provides access to the nodes of the channel graph.
+type NodeSource interface {
+	// ForEachNode calls the callback for every node in the graph.
+	ForEachNode(func(*channeldb.LightningNode) error) error
+}
+
+// Candidate is a public watchtower found in the channel graph.
+type Candidate struct {
+	// Tower is the watchtower as advertised by its node.
+	*Tower
+
+	// NodeKey is the identity key of the node that advertises the tower.
+	NodeKey [33]byte
+
+	// Alias is the alias of the node that advertises the tower.
+	Alias string
+}
+
+// FindTowers returns the public watchtowers advertised in the channel graph
+// that accept sessions of the given blob type. Nodes with malformed tower
+// records are skipped.
+func FindTowers(graph NodeSource, blobType blob.Type) ([]*Candidate, error) {
+	var candidates []*Candidate
+	err := graph.ForEachNode(func(node *channeldb.LightningNode) error {
+		if !node.HaveNodeAnnouncement {
+			return nil
+		}
+
+		tower, err := ParseTower(node.ExtraOpaqueData)
+		if err != nil || !tower.Supports(blobType) {
+			return nil
+		}
+
+		candidates = append(candidates, &Candidate{
+			Tower:   tower,
+			NodeKey: node.PubKeyBytes,
+			Alias:   node.Alias,
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return candidates, nil
+}
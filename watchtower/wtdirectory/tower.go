@@ -0,0 +1,139 @@
+// Package wtdirectory allows watchtowers to advertise themselves as public
+// altruist towers in the node announcement of their node, and watchtower
+// clients to discover such towers in the channel graph.
+package wtdirectory
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/cryptomeow/lnd/lnwire"
+	"github.com/cryptomeow/lnd/tlv"
+	"github.com/cryptomeow/lnd/watchtower/blob"
+)
+
+// TowerRecordType is the TLV type of the record that advertises a public
+// watchtower in the extra opaque data of a node announcement. The type is odd,
+// so nodes that don't understand it will ignore it.
+const TowerRecordType tlv.Type = 65537
+
+// ErrNoTowerRecord is returned if the extra opaque data of a node announcement
+// doesn't advertise a watchtower.
+var ErrNoTowerRecord = errors.New("no tower record")
+
+// Tower is a public watchtower as advertised in a node announcement.
+type Tower struct {
+	// PubKey is the identity key of the watchtower, which differs from the
+	// identity key of the node that announces it.
+	PubKey *btcec.PublicKey
+
+	// Addresses are the addresses the watchtower can be reached at.
+	Addresses []net.Addr
+
+	// BlobTypes are the blob types of the sessions the watchtower accepts.
+	BlobTypes []blob.Type
+}
+
+// Supports returns true if the watchtower accepts sessions of the given blob
+// type.
+func (t *Tower) Supports(blobType blob.Type) bool {
+	for _, typ := range t.BlobTypes {
+		if typ == blobType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ExtraOpaqueData encodes the tower as the extra opaque data of a node
+// announcement.
+func (t *Tower) ExtraOpaqueData() ([]byte, error) {
+	if len(t.Addresses) == 0 {
+		return nil, errors.New("tower has no addresses")
+	}
+
+	var value bytes.Buffer
+	err := lnwire.WriteElements(
+		&value, t.PubKey, t.Addresses, uint16(len(t.BlobTypes)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	for _, blobType := range t.BlobTypes {
+		err := lnwire.WriteElement(&value, uint16(blobType))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	record := value.Bytes()
+	tlvStream, err := tlv.NewStream(
+		tlv.MakePrimitiveRecord(TowerRecordType, &record),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	if err := tlvStream.Encode(&b); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// ParseTower parses the tower advertised in the extra opaque data of a node
+// announcement. ErrNoTowerRecord is returned if it doesn't advertise one.
+func ParseTower(extraOpaqueData []byte) (*Tower, error) {
+	if len(extraOpaqueData) == 0 {
+		return nil, ErrNoTowerRecord
+	}
+
+	var record []byte
+	tlvStream, err := tlv.NewStream(
+		tlv.MakePrimitiveRecord(TowerRecordType, &record),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	parsedTypes, err := tlvStream.DecodeWithParsedTypes(
+		bytes.NewReader(extraOpaqueData),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := parsedTypes[TowerRecordType]; !ok {
+		return nil, ErrNoTowerRecord
+	}
+
+	var (
+		tower        Tower
+		numBlobTypes uint16
+		r            = bytes.NewReader(record)
+	)
+	err = lnwire.ReadElements(
+		r, &tower.PubKey, &tower.Addresses, &numBlobTypes,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tower record: %v", err)
+	}
+	if len(tower.Addresses) == 0 {
+		return nil, errors.New("invalid tower record: no addresses")
+	}
+
+	tower.BlobTypes = make([]blob.Type, 0, numBlobTypes)
+	for i := uint16(0); i < numBlobTypes; i++ {
+		var blobType uint16
+		if err := lnwire.ReadElement(r, &blobType); err != nil {
+			return nil, fmt.Errorf("invalid tower record: %v", err)
+		}
+		tower.BlobTypes = append(tower.BlobTypes, blob.Type(blobType))
+	}
+
+	return &tower, nil
+}
@@ -0,0 +1,114 @@
+package wtdirectory
+
+import (
+	"net"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/cryptomeow/lnd/channeldb"
+	"github.com/cryptomeow/lnd/watchtower/blob"
+	"github.com/stretchr/testify/require"
+)
+
+// mockGraph is a NodeSource backed by a list of nodes.
+type mockGraph []*channeldb.LightningNode
+
+// ForEachNode calls the callback for every node in the graph.
+func (g mockGraph) ForEachNode(cb func(*channeldb.LightningNode) error) error {
+	for _, node := range g {
+		if err := cb(node); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// newTestTower creates a tower with a random key.
+func newTestTower(t *testing.T, blobTypes ...blob.Type) *Tower {
+	privKey, err := btcec.NewPrivateKey(btcec.S256())
+	require.NoError(t, err)
+
+	return &Tower{
+		PubKey: privKey.PubKey(),
+		Addresses: []net.Addr{
+			&net.TCPAddr{IP: net.ParseIP("1.2.3.4"), Port: 9911},
+		},
+		BlobTypes: blobTypes,
+	}
+}
+
+// TestTowerRecord asserts that towers are encoded as extra opaque data of a
+// node announcement and parsed back.
+func TestTowerRecord(t *testing.T) {
+	t.Parallel()
+
+	tower := newTestTower(
+		t, blob.TypeAltruistCommit, blob.TypeAltruistAnchorCommit,
+	)
+
+	extra, err := tower.ExtraOpaqueData()
+	require.NoError(t, err)
+
+	parsed, err := ParseTower(extra)
+	require.NoError(t, err)
+	require.Equal(t, tower.PubKey.SerializeCompressed(),
+		parsed.PubKey.SerializeCompressed())
+	require.Equal(t, tower.Addresses[0].String(),
+		parsed.Addresses[0].String())
+	require.Equal(t, tower.BlobTypes, parsed.BlobTypes)
+	require.True(t, parsed.Supports(blob.TypeAltruistAnchorCommit))
+	require.False(t, parsed.Supports(blob.TypeRewardCommit))
+
+	// Extra opaque data without a tower record isn't a tower.
+	_, err = ParseTower(nil)
+	require.Equal(t, ErrNoTowerRecord, err)
+
+	_, err = ParseTower([]byte{0x01, 0x00})
+	require.Equal(t, ErrNoTowerRecord, err)
+
+	// A tower without addresses can't be advertised.
+	tower.Addresses = nil
+	_, err = tower.ExtraOpaqueData()
+	require.Error(t, err)
+}
+
+// TestFindTowers asserts that only the towers supporting the requested blob
+// type are found in the graph.
+func TestFindTowers(t *testing.T) {
+	t.Parallel()
+
+	newNode := func(key byte, tower *Tower) *channeldb.LightningNode {
+		node := &channeldb.LightningNode{
+			HaveNodeAnnouncement: true,
+			Alias:                string([]byte{'a' + key}),
+		}
+		node.PubKeyBytes[0] = key
+
+		if tower != nil {
+			extra, err := tower.ExtraOpaqueData()
+			require.NoError(t, err)
+			node.ExtraOpaqueData = extra
+		}
+
+		return node
+	}
+
+	anchorTower := newTestTower(t, blob.TypeAltruistAnchorCommit)
+	graph := mockGraph{
+		newNode(0, nil),
+		newNode(1, newTestTower(t, blob.TypeAltruistCommit)),
+		newNode(2, anchorTower),
+		{
+			HaveNodeAnnouncement: true,
+			ExtraOpaqueData:      []byte{0xff},
+		},
+	}
+
+	candidates, err := FindTowers(graph, blob.TypeAltruistAnchorCommit)
+	require.NoError(t, err)
+	require.Len(t, candidates, 1)
+	require.Equal(t, byte(2), candidates[0].NodeKey[0])
+	require.Equal(t, "c", candidates[0].Alias)
+	require.True(t, candidates[0].PubKey.IsEqual(anchorTower.PubKey))
+}
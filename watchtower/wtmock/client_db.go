@@ -7,6 +7,7 @@ import (
 
 	"github.com/btcsuite/btcd/btcec"
 	"github.com/cryptomeow/lnd/lnwire"
+	"github.com/cryptomeow/lnd/watchtower/blob"
 	"github.com/cryptomeow/lnd/watchtower/wtdb"
 )
 
@@ -24,7 +25,13 @@ type ClientDB struct {
 	towers         map[wtdb.TowerID]*wtdb.Tower
 
 	nextIndex uint32
-	indexes   map[wtdb.TowerID]uint32
+	indexes   map[keyIndexKey]uint32
+}
+
+// keyIndexKey identifies a session key index reservation.
+type keyIndexKey struct {
+	towerID  wtdb.TowerID
+	blobType blob.Type
 }
 
 // NewClientDB initializes a new mock ClientDB.
@@ -34,7 +41,7 @@ func NewClientDB() *ClientDB {
 		activeSessions: make(map[wtdb.SessionID]wtdb.ClientSession),
 		towerIndex:     make(map[towerPK]wtdb.TowerID),
 		towers:         make(map[wtdb.TowerID]*wtdb.Tower),
-		indexes:        make(map[wtdb.TowerID]uint32),
+		indexes:        make(map[keyIndexKey]uint32),
 	}
 }
 
@@ -227,7 +234,11 @@ func (m *ClientDB) CreateClientSession(session *wtdb.ClientSession) error {
 	}
 
 	// Ensure that a session key index has been reserved for this tower.
-	keyIndex, ok := m.indexes[session.TowerID]
+	key := keyIndexKey{
+		towerID:  session.TowerID,
+		blobType: session.Policy.BlobType,
+	}
+	keyIndex, ok := m.indexes[key]
 	if !ok {
 		return wtdb.ErrNoReservedKeyIndex
 	}
@@ -239,7 +250,7 @@ func (m *ClientDB) CreateClientSession(session *wtdb.ClientSession) error {
 
 	// Remove the key index reservation for this tower. Once committed, this
 	// permits us to create another session with this tower.
-	delete(m.indexes, session.TowerID)
+	delete(m.indexes, key)
 
 	m.activeSessions[session.ID] = wtdb.ClientSession{
 		ID: session.ID,
@@ -259,21 +270,28 @@ func (m *ClientDB) CreateClientSession(session *wtdb.ClientSession) error {
 }
 
 // NextSessionKeyIndex reserves a new session key derivation index for a
-// particular tower id. The index is reserved for that tower until
-// CreateClientSession is invoked for that tower and index, at which point a new
-// index for that tower can be reserved. Multiple calls to this method before
-// CreateClientSession is invoked should return the same index.
-func (m *ClientDB) NextSessionKeyIndex(towerID wtdb.TowerID) (uint32, error) {
+// particular tower id and blob type. The index is reserved for that tower and
+// blob type until CreateClientSession is invoked for that tower and index, at
+// which point a new index for that tower can be reserved. Multiple calls to
+// this method before CreateClientSession is invoked should return the same
+// index.
+func (m *ClientDB) NextSessionKeyIndex(towerID wtdb.TowerID,
+	blobType blob.Type) (uint32, error) {
+
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	if index, ok := m.indexes[towerID]; ok {
+	key := keyIndexKey{
+		towerID:  towerID,
+		blobType: blobType,
+	}
+	if index, ok := m.indexes[key]; ok {
 		return index, nil
 	}
 
 	m.nextIndex++
 	index := m.nextIndex
-	m.indexes[towerID] = index
+	m.indexes[key] = index
 
 	return index, nil
 }
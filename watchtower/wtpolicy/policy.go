@@ -30,9 +30,22 @@ const (
 	// transactions. The value is expressed in satoshis per kilo-weight.
 	DefaultSweepFeeRate = chainfee.SatPerKWeight(2500)
 
+	// DefaultAnchorSweepFeeRate specifies the fee rate used to construct
+	// justice transactions for anchor channels. The breaching party can
+	// use the anchor output to confirm a revoked commitment at any fee
+	// rate, even during congestion, so the justice transaction must be
+	// able to compete. The value is expressed in satoshis per
+	// kilo-weight.
+	DefaultAnchorSweepFeeRate = chainfee.SatPerKWeight(5000)
+
 	// MinSweepFeeRate is the minimum sweep fee rate a client may use in its
 	// policy, the current value is 4 sat/vbyte.
 	MinSweepFeeRate = chainfee.SatPerKWeight(1000)
+
+	// FeeEscalationFactor is the factor by which the sweep fee rate of a
+	// justice transaction is multiplied at each step of the fee escalation
+	// schedule.
+	FeeEscalationFactor = 2
 )
 
 var (
@@ -59,20 +72,40 @@ var (
 	// ErrSweepFeeRateTooLow signals that the policy's fee rate is too low
 	// to get into the mempool during low congestion.
 	ErrSweepFeeRateTooLow = errors.New("sweep fee rate too low")
+
+	// ErrUnsupportedBlobType signals that the policy's blob type isn't
+	// supported.
+	ErrUnsupportedBlobType = errors.New("unsupported blob type")
 )
 
 // DefaultPolicy returns a Policy containing the default parameters that can be
 // used by clients or servers.
 func DefaultPolicy() Policy {
+	return DefaultPolicyForType(blob.TypeAltruistCommit)
+}
+
+// DefaultPolicyForType returns a Policy containing the default parameters for
+// sessions of the given blob type.
+func DefaultPolicyForType(blobType blob.Type) Policy {
 	return Policy{
 		TxPolicy: TxPolicy{
-			BlobType:     blob.TypeAltruistCommit,
-			SweepFeeRate: DefaultSweepFeeRate,
+			BlobType:     blobType,
+			SweepFeeRate: DefaultSweepFeeRateForType(blobType),
 		},
 		MaxUpdates: DefaultMaxUpdates,
 	}
 }
 
+// DefaultSweepFeeRateForType returns the default sweep fee rate of justice
+// transactions for sessions of the given blob type.
+func DefaultSweepFeeRateForType(blobType blob.Type) chainfee.SatPerKWeight {
+	if blobType.IsAnchorChannel() {
+		return DefaultAnchorSweepFeeRate
+	}
+
+	return DefaultSweepFeeRate
+}
+
 // TxPolicy defines the negotiate parameters that determine the form of the
 // justice transaction for a given breached state. Thus, for any given revoked
 // state, an identical key will result in an identical justice transaction
@@ -101,6 +134,12 @@ type TxPolicy struct {
 	SweepFeeRate chainfee.SatPerKWeight
 }
 
+// IsAnchorChannel returns true if the justice transactions of the policy spend
+// the outputs of anchor channels.
+func (p TxPolicy) IsAnchorChannel() bool {
+	return p.BlobType.IsAnchorChannel()
+}
+
 // Policy defines the negotiated parameters for a session between a client and
 // server. In addition to the TxPolicy that governs the shape of the justice
 // transaction, the Policy also includes features which only affect the
@@ -123,6 +162,12 @@ func (p Policy) String() string {
 // Validate ensures that the policy satisfies some minimal correctness
 // constraints.
 func (p Policy) Validate() error {
+	// The blob type must be known, which also ensures that anchor and
+	// legacy channels are not mixed with unsupported features.
+	if !blob.IsSupportedType(p.BlobType) {
+		return ErrUnsupportedBlobType
+	}
+
 	// RewardBase and RewardRate should not be set if the policy doesn't
 	// have a reward.
 	if !p.BlobType.Has(blob.FlagReward) &&
@@ -145,6 +190,18 @@ func (p Policy) Validate() error {
 	return nil
 }
 
+// Escalate returns a copy of the policy whose sweep fee rate is the one of the
+// given step of the fee escalation schedule. The sweep fee rate is multiplied
+// by FeeEscalationFactor at each step, so the escalated fee rates are bounded
+// by the sweep fee rate the client and tower agreed on.
+func (p Policy) Escalate(step int) Policy {
+	for i := 0; i < step; i++ {
+		p.SweepFeeRate *= FeeEscalationFactor
+	}
+
+	return p
+}
+
 // ComputeAltruistOutput computes the lone output value of a justice transaction
 // that pays no reward to the tower. The value is computed using the weight of
 // of the justice transaction and subtracting an amount that satisfies the
@@ -75,6 +75,49 @@ var validationTests = []struct {
 		name:   "valid default policy",
 		policy: wtpolicy.DefaultPolicy(),
 	},
+	{
+		name: "valid default anchor policy",
+		policy: wtpolicy.DefaultPolicyForType(
+			blob.TypeAltruistAnchorCommit,
+		),
+	},
+	{
+		name: "fail unsupported blob type",
+		policy: wtpolicy.Policy{
+			TxPolicy: wtpolicy.TxPolicy{
+				BlobType: blob.TypeFromFlags(
+					blob.FlagCommitOutputs,
+					blob.FlagAnchorChannel,
+					blob.FlagFeeEscalation,
+				),
+				SweepFeeRate: wtpolicy.DefaultSweepFeeRate,
+			},
+			MaxUpdates: 1,
+		},
+		expErr: wtpolicy.ErrUnsupportedBlobType,
+	},
+}
+
+// TestDefaultPolicyForType asserts that the default policies of anchor and
+// legacy channels use their respective default sweep fee rates.
+func TestDefaultPolicyForType(t *testing.T) {
+	policy := wtpolicy.DefaultPolicyForType(blob.TypeAltruistAnchorCommit)
+	if !policy.IsAnchorChannel() {
+		t.Fatalf("expected anchor policy")
+	}
+	if policy.SweepFeeRate != wtpolicy.DefaultAnchorSweepFeeRate {
+		t.Fatalf("expected sweep fee rate %v, got %v",
+			wtpolicy.DefaultAnchorSweepFeeRate, policy.SweepFeeRate)
+	}
+
+	policy = wtpolicy.DefaultPolicy()
+	if policy.IsAnchorChannel() {
+		t.Fatalf("expected legacy policy")
+	}
+	if policy.SweepFeeRate != wtpolicy.DefaultSweepFeeRate {
+		t.Fatalf("expected sweep fee rate %v, got %v",
+			wtpolicy.DefaultSweepFeeRate, policy.SweepFeeRate)
+	}
 }
 
 // TestPolicyValidate asserts that the sanity checks for policies behave as
@@ -91,3 +134,27 @@ func TestPolicyValidate(t *testing.T) {
 		})
 	}
 }
+
+// TestPolicyEscalate asserts that escalated policies multiply the sweep fee
+// rate at each step and leave the rest of the policy untouched.
+func TestPolicyEscalate(t *testing.T) {
+	policy := wtpolicy.DefaultPolicy()
+
+	for step := 0; step <= blob.NumFeeEscalations; step++ {
+		escalated := policy.Escalate(step)
+
+		expFeeRate := policy.SweepFeeRate
+		for i := 0; i < step; i++ {
+			expFeeRate *= wtpolicy.FeeEscalationFactor
+		}
+		if escalated.SweepFeeRate != expFeeRate {
+			t.Fatalf("step %d: expected fee rate %v, got %v",
+				step, expFeeRate, escalated.SweepFeeRate)
+		}
+
+		escalated.SweepFeeRate = policy.SweepFeeRate
+		if escalated != policy {
+			t.Fatalf("step %d: policy was modified", step)
+		}
+	}
+}
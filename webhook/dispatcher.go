@@ -0,0 +1,296 @@
+// Package webhook posts channel and peer events to a set of HTTP endpoints, so
+// that integrators can react to them without holding a persistent gRPC stream
+// open.
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/cryptomeow/lnd/clock"
+	"github.com/cryptomeow/lnd/subscribe"
+)
+
+const (
+	// SignatureHeader is the HTTP header that carries the hex encoded
+	// HMAC-SHA256 signature of the request body.
+	SignatureHeader = "X-Lnd-Signature"
+
+	// EventTypeHeader is the HTTP header that carries the type of the
+	// posted event.
+	EventTypeHeader = "X-Lnd-Event"
+
+	// queueSize is the number of events that are buffered for each
+	// endpoint. If an endpoint falls behind by more events, new events are
+	// dropped for it.
+	queueSize = 100
+)
+
+// Config holds the dependencies and settings of the webhook dispatcher.
+type Config struct {
+	// URLs are the endpoints that events are posted to.
+	URLs []string
+
+	// Secret is used to sign the body of each request. If it is empty,
+	// requests are not signed.
+	Secret []byte
+
+	// MaxRetries is the number of times a failed delivery is retried
+	// before the event is dropped.
+	MaxRetries int
+
+	// RetryDelay is the delay before the first retry of a failed
+	// delivery. It is doubled for every subsequent retry.
+	RetryDelay time.Duration
+
+	// Timeout is the timeout of a single delivery attempt.
+	Timeout time.Duration
+
+	// SubscribeChannelEvents provides a subscription to pending open, open,
+	// closed and funding locked stalled channel events.
+	SubscribeChannelEvents func() (subscribe.Subscription, error)
+
+	// SubscribePeerEvents provides a subscription to peer online and
+	// offline events.
+	SubscribePeerEvents func() (subscribe.Subscription, error)
+
+	// Clock is the time source used to timestamp events.
+	Clock clock.Clock
+}
+
+// Dispatcher consumes channel and peer events and posts them to the configured
+// webhook endpoints. Every endpoint is served by its own goroutine, so a slow
+// or unreachable endpoint doesn't delay deliveries to the others.
+type Dispatcher struct {
+	started sync.Once
+	stopped sync.Once
+
+	cfg *Config
+
+	client *http.Client
+
+	// queues holds the pending events of each endpoint, keyed by URL.
+	queues map[string]chan *Event
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewDispatcher creates a new webhook dispatcher from the given config. Start
+// must be called to begin posting events.
+func NewDispatcher(cfg *Config) *Dispatcher {
+	queues := make(map[string]chan *Event, len(cfg.URLs))
+	for _, url := range cfg.URLs {
+		queues[url] = make(chan *Event, queueSize)
+	}
+
+	return &Dispatcher{
+		cfg: cfg,
+		client: &http.Client{
+			Timeout: cfg.Timeout,
+		},
+		queues: queues,
+		quit:   make(chan struct{}),
+	}
+}
+
+// Start subscribes to channel and peer events and starts delivering them to
+// the webhook endpoints.
+func (d *Dispatcher) Start() error {
+	var err error
+	d.started.Do(func() {
+		log.Infof("Webhook dispatcher starting with %v endpoints",
+			len(d.cfg.URLs))
+
+		err = d.start()
+	})
+
+	return err
+}
+
+// start subscribes to the event sources and launches the goroutines of the
+// dispatcher.
+func (d *Dispatcher) start() error {
+	channelClient, err := d.cfg.SubscribeChannelEvents()
+	if err != nil {
+		return err
+	}
+
+	peerClient, err := d.cfg.SubscribePeerEvents()
+	if err != nil {
+		channelClient.Cancel()
+		return err
+	}
+
+	for url, queue := range d.queues {
+		d.wg.Add(1)
+		go d.deliverEvents(url, queue)
+	}
+
+	d.wg.Add(1)
+	go d.consume(channelClient, peerClient)
+
+	return nil
+}
+
+// Stop stops the dispatcher. Events that haven't been delivered yet are
+// dropped.
+func (d *Dispatcher) Stop() {
+	d.stopped.Do(func() {
+		log.Info("Webhook dispatcher shutting down")
+
+		close(d.quit)
+		d.wg.Wait()
+	})
+}
+
+// consume is the main loop of the dispatcher. It converts the updates of the
+// channel and peer notifiers to webhook events and queues them for delivery.
+//
+// NOTE: This MUST be run as a goroutine.
+func (d *Dispatcher) consume(channelClient,
+	peerClient subscribe.Subscription) {
+
+	defer d.wg.Done()
+	defer channelClient.Cancel()
+	defer peerClient.Cancel()
+
+	for {
+		var event *Event
+		select {
+		case update := <-channelClient.Updates():
+			event = newChannelEvent(update, d.cfg.Clock.Now().Unix())
+
+		case update := <-peerClient.Updates():
+			event = newPeerEvent(update, d.cfg.Clock.Now().Unix())
+
+		case <-channelClient.Quit():
+			return
+
+		case <-peerClient.Quit():
+			return
+
+		case <-d.quit:
+			return
+		}
+
+		if event == nil {
+			continue
+		}
+
+		d.queueEvent(event)
+	}
+}
+
+// queueEvent adds the event to the queue of every endpoint. If the queue of an
+// endpoint is full, the event is dropped for that endpoint.
+func (d *Dispatcher) queueEvent(event *Event) {
+	for url, queue := range d.queues {
+		select {
+		case queue <- event:
+		default:
+			log.Warnf("Webhook queue of %v is full, dropping %v "+
+				"event", url, event.Type)
+		}
+	}
+}
+
+// deliverEvents posts the queued events to a single endpoint in order.
+//
+// NOTE: This MUST be run as a goroutine.
+func (d *Dispatcher) deliverEvents(url string, queue chan *Event) {
+	defer d.wg.Done()
+
+	for {
+		select {
+		case event := <-queue:
+			d.deliverEvent(url, event)
+
+		case <-d.quit:
+			return
+		}
+	}
+}
+
+// deliverEvent posts an event to an endpoint, retrying with an exponential
+// backoff until it is accepted or the maximum number of retries is reached.
+func (d *Dispatcher) deliverEvent(url string, event *Event) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		log.Errorf("Unable to encode %v event: %v", event.Type, err)
+		return
+	}
+
+	delay := d.cfg.RetryDelay
+	for attempt := 0; ; attempt++ {
+		err := d.post(url, event.Type, body)
+		if err == nil {
+			log.Debugf("Delivered %v event to %v", event.Type, url)
+			return
+		}
+
+		if attempt >= d.cfg.MaxRetries {
+			log.Errorf("Unable to deliver %v event to %v, giving up "+
+				"after %v attempts: %v", event.Type, url,
+				attempt+1, err)
+			return
+		}
+
+		log.Warnf("Unable to deliver %v event to %v, retrying in %v: "+
+			"%v", event.Type, url, delay, err)
+
+		select {
+		case <-time.After(delay):
+		case <-d.quit:
+			return
+		}
+
+		delay *= 2
+	}
+}
+
+// post sends a single signed request to an endpoint. Any response status
+// outside of the 2xx range is treated as a failure.
+func (d *Dispatcher) post(url string, eventType EventType,
+	body []byte) error {
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventTypeHeader, string(eventType))
+	if len(d.cfg.Secret) != 0 {
+		req.Header.Set(SignatureHeader, Sign(d.cfg.Secret, body))
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status: %v", resp.Status)
+	}
+
+	return nil
+}
+
+// Sign returns the hex encoded HMAC-SHA256 signature of the body using the
+// given secret. Receivers can use it to verify the SignatureHeader of a
+// request.
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,238 @@
+package webhook
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/cryptomeow/lnd/channeldb"
+	"github.com/cryptomeow/lnd/channelnotifier"
+	"github.com/cryptomeow/lnd/clock"
+	"github.com/cryptomeow/lnd/peernotifier"
+	"github.com/cryptomeow/lnd/subscribe"
+	"github.com/stretchr/testify/require"
+)
+
+const timeout = 5 * time.Second
+
+var (
+	testTime = time.Unix(1600000000, 0)
+
+	testSecret = []byte("secret")
+
+	testPub, _ = btcec.ParsePubKey([]byte{
+		0x02, 0x79, 0xbe, 0x66, 0x7e, 0xf9, 0xdc, 0xbb, 0xac, 0x55,
+		0xa0, 0x62, 0x95, 0xce, 0x87, 0x0b, 0x07, 0x02, 0x9b, 0xfc,
+		0xdb, 0x2d, 0xce, 0x28, 0xd9, 0x59, 0xf2, 0x81, 0x5b, 0x16,
+		0xf8, 0x17, 0x98,
+	}, btcec.S256())
+)
+
+// request is a request received by the test endpoint.
+type request struct {
+	eventType string
+	signature string
+	body      []byte
+}
+
+// newTestEndpoint starts an HTTP server that records all requests. The first
+// failures requests are answered with an internal server error.
+func newTestEndpoint(t *testing.T, failures int) (*httptest.Server,
+	chan *request) {
+
+	requests := make(chan *request, 10)
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			body, err := ioutil.ReadAll(r.Body)
+			require.NoError(t, err)
+
+			requests <- &request{
+				eventType: r.Header.Get(EventTypeHeader),
+				signature: r.Header.Get(SignatureHeader),
+				body:      body,
+			}
+
+			if failures > 0 {
+				failures--
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+		},
+	))
+
+	return server, requests
+}
+
+// receiveEvent waits for a request at the test endpoint, asserts that it is
+// signed correctly and returns the posted event.
+func receiveEvent(t *testing.T, requests chan *request) *Event {
+	var req *request
+	select {
+	case req = <-requests:
+	case <-time.After(timeout):
+		t.Fatalf("no request received")
+	}
+
+	require.Equal(t, Sign(testSecret, req.body), req.signature)
+
+	var event Event
+	require.NoError(t, json.Unmarshal(req.body, &event))
+	require.Equal(t, string(event.Type), req.eventType)
+
+	return &event
+}
+
+// newTestDispatcher creates and starts a dispatcher that posts the events of
+// the returned channel and peer notification servers to the given endpoint.
+// The returned cleanup function stops the dispatcher and the servers.
+func newTestDispatcher(t *testing.T, url string, maxRetries int) (
+	*subscribe.Server, *subscribe.Server, func()) {
+
+	channelServer := subscribe.NewServer()
+	require.NoError(t, channelServer.Start())
+
+	peerServer := subscribe.NewServer()
+	require.NoError(t, peerServer.Start())
+
+	dispatcher := NewDispatcher(&Config{
+		URLs:       []string{url},
+		Secret:     testSecret,
+		MaxRetries: maxRetries,
+		RetryDelay: time.Millisecond,
+		Timeout:    timeout,
+		SubscribeChannelEvents: func() (subscribe.Subscription,
+			error) {
+
+			return channelServer.Subscribe()
+		},
+		SubscribePeerEvents: func() (subscribe.Subscription, error) {
+			return peerServer.Subscribe()
+		},
+		Clock: clock.NewTestClock(testTime),
+	})
+	require.NoError(t, dispatcher.Start())
+
+	cleanup := func() {
+		dispatcher.Stop()
+		channelServer.Stop()
+		peerServer.Stop()
+	}
+
+	return channelServer, peerServer, cleanup
+}
+
+// TestDispatcherEvents tests that channel and peer events are posted to the
+// endpoint in order and signed with the secret.
+func TestDispatcherEvents(t *testing.T) {
+	t.Parallel()
+
+	server, requests := newTestEndpoint(t, 0)
+	defer server.Close()
+
+	channelServer, peerServer, cleanup := newTestDispatcher(
+		t, server.URL, 0,
+	)
+	defer cleanup()
+
+	chanPoint := wire.OutPoint{Index: 1}
+	pubKey := hex.EncodeToString(testPub.SerializeCompressed())
+
+	var peerKey [33]byte
+	copy(peerKey[:], testPub.SerializeCompressed())
+	require.NoError(t, peerServer.SendUpdate(
+		peernotifier.PeerOnlineEvent{PubKey: peerKey},
+	))
+	require.Equal(t, &Event{
+		Type:         EventPeerOnline,
+		Timestamp:    testTime.Unix(),
+		RemotePubKey: pubKey,
+	}, receiveEvent(t, requests))
+
+	channel := &channeldb.OpenChannel{
+		IdentityPub:     testPub,
+		FundingOutpoint: chanPoint,
+		Capacity:        100000,
+	}
+	require.NoError(t, channelServer.SendUpdate(
+		channelnotifier.PendingOpenChannelEvent{
+			ChannelPoint:   &chanPoint,
+			PendingChannel: channel,
+		},
+	))
+	require.Equal(t, &Event{
+		Type:         EventChannelPendingOpen,
+		Timestamp:    testTime.Unix(),
+		RemotePubKey: pubKey,
+		ChannelPoint: chanPoint.String(),
+		CapacitySat:  100000,
+	}, receiveEvent(t, requests))
+
+	// Channel events that aren't posted to webhooks are skipped.
+	require.NoError(t, channelServer.SendUpdate(
+		channelnotifier.ActiveChannelEvent{ChannelPoint: &chanPoint},
+	))
+
+	require.NoError(t, channelServer.SendUpdate(
+		channelnotifier.ClosedChannelEvent{
+			CloseSummary: &channeldb.ChannelCloseSummary{
+				ChanPoint: chanPoint,
+				RemotePub: testPub,
+				Capacity:  100000,
+				CloseType: channeldb.RemoteForceClose,
+			},
+		},
+	))
+	require.Equal(t, &Event{
+		Type:         EventChannelClosed,
+		Timestamp:    testTime.Unix(),
+		RemotePubKey: pubKey,
+		ChannelPoint: chanPoint.String(),
+		CapacitySat:  100000,
+		CloseType:    "remote_force_close",
+	}, receiveEvent(t, requests))
+}
+
+// TestDispatcherRetry tests that failed deliveries are retried until the
+// maximum number of retries is reached.
+func TestDispatcherRetry(t *testing.T) {
+	t.Parallel()
+
+	// The endpoint fails the first four attempts, so with two retries
+	// the first event is given up on, and the second one is delivered on
+	// its second attempt.
+	server, requests := newTestEndpoint(t, 4)
+	defer server.Close()
+
+	_, peerServer, cleanup := newTestDispatcher(t, server.URL, 2)
+	defer cleanup()
+
+	var peerKey [33]byte
+	copy(peerKey[:], testPub.SerializeCompressed())
+
+	require.NoError(t, peerServer.SendUpdate(
+		peernotifier.PeerOnlineEvent{PubKey: peerKey},
+	))
+	require.NoError(t, peerServer.SendUpdate(
+		peernotifier.PeerOfflineEvent{PubKey: peerKey},
+	))
+
+	for i := 0; i < 3; i++ {
+		event := receiveEvent(t, requests)
+		require.Equal(t, EventPeerOnline, event.Type)
+	}
+	for i := 0; i < 2; i++ {
+		event := receiveEvent(t, requests)
+		require.Equal(t, EventPeerOffline, event.Type)
+	}
+
+	select {
+	case <-requests:
+		t.Fatalf("unexpected request")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -0,0 +1,169 @@
+package webhook
+
+import (
+	"encoding/hex"
+
+	"github.com/cryptomeow/lnd/channeldb"
+	"github.com/cryptomeow/lnd/channelnotifier"
+	"github.com/cryptomeow/lnd/peernotifier"
+)
+
+// EventType describes the kind of event that is posted to a webhook.
+type EventType string
+
+const (
+	// EventChannelPendingOpen is posted when the funding transaction of a
+	// new channel was broadcast and the channel is waiting for
+	// confirmation.
+	EventChannelPendingOpen EventType = "channel_pending_open"
+
+	// EventChannelOpen is posted when a channel is fully open.
+	EventChannelOpen EventType = "channel_open"
+
+	// EventChannelClosed is posted when a channel is closed.
+	EventChannelClosed EventType = "channel_closed"
+
+	// EventFundingLockedStalled is posted when the funding_locked message
+	// of a confirmed channel repeatedly failed to be sent to the peer.
+	EventFundingLockedStalled EventType = "funding_locked_stalled"
+
+	// EventPeerOnline is posted when a peer comes online.
+	EventPeerOnline EventType = "peer_online"
+
+	// EventPeerOffline is posted when a peer goes offline.
+	EventPeerOffline EventType = "peer_offline"
+)
+
+// Event is the JSON body of a webhook request.
+type Event struct {
+	// Type is the kind of event.
+	Type EventType `json:"type"`
+
+	// Timestamp is the unix timestamp at which the event was observed.
+	Timestamp int64 `json:"timestamp"`
+
+	// RemotePubKey is the hex encoded public key of the peer the event
+	// relates to.
+	RemotePubKey string `json:"remote_pubkey"`
+
+	// ChannelPoint is the funding outpoint of the channel the event
+	// relates to. It is empty for peer events.
+	ChannelPoint string `json:"channel_point,omitempty"`
+
+	// CapacitySat is the capacity of the channel the event relates to. It
+	// is zero for peer events.
+	CapacitySat int64 `json:"capacity_sat,omitempty"`
+
+	// CloseType describes how a channel was closed. It is only set for
+	// channel closed events.
+	CloseType string `json:"close_type,omitempty"`
+
+	// Attempts is the number of failed attempts to send the
+	// funding_locked message. It is only set for funding locked stalled
+	// events.
+	Attempts uint32 `json:"attempts,omitempty"`
+}
+
+// closeTypeString returns the name of a closure type as used in close events.
+func closeTypeString(closeType channeldb.ClosureType) string {
+	switch closeType {
+	case channeldb.CooperativeClose:
+		return "cooperative_close"
+
+	case channeldb.LocalForceClose:
+		return "local_force_close"
+
+	case channeldb.RemoteForceClose:
+		return "remote_force_close"
+
+	case channeldb.BreachClose:
+		return "breach_close"
+
+	case channeldb.FundingCanceled:
+		return "funding_canceled"
+
+	case channeldb.Abandoned:
+		return "abandoned"
+
+	default:
+		return "unknown"
+	}
+}
+
+// newChannelEvent converts an update of the channel notifier to a webhook
+// event. It returns nil for updates that aren't posted to webhooks.
+func newChannelEvent(update interface{}, timestamp int64) *Event {
+	switch event := update.(type) {
+	case channelnotifier.PendingOpenChannelEvent:
+		channel := event.PendingChannel
+		return &Event{
+			Type:      EventChannelPendingOpen,
+			Timestamp: timestamp,
+			RemotePubKey: hex.EncodeToString(
+				channel.IdentityPub.SerializeCompressed(),
+			),
+			ChannelPoint: event.ChannelPoint.String(),
+			CapacitySat:  int64(channel.Capacity),
+		}
+
+	case channelnotifier.OpenChannelEvent:
+		channel := event.Channel
+		return &Event{
+			Type:      EventChannelOpen,
+			Timestamp: timestamp,
+			RemotePubKey: hex.EncodeToString(
+				channel.IdentityPub.SerializeCompressed(),
+			),
+			ChannelPoint: channel.FundingOutpoint.String(),
+			CapacitySat:  int64(channel.Capacity),
+		}
+
+	case channelnotifier.ClosedChannelEvent:
+		summary := event.CloseSummary
+		return &Event{
+			Type:      EventChannelClosed,
+			Timestamp: timestamp,
+			RemotePubKey: hex.EncodeToString(
+				summary.RemotePub.SerializeCompressed(),
+			),
+			ChannelPoint: summary.ChanPoint.String(),
+			CapacitySat:  int64(summary.Capacity),
+			CloseType:    closeTypeString(summary.CloseType),
+		}
+
+	case channelnotifier.FundingLockedStalledEvent:
+		return &Event{
+			Type:         EventFundingLockedStalled,
+			Timestamp:    timestamp,
+			RemotePubKey: hex.EncodeToString(event.PeerPubKey[:]),
+			ChannelPoint: event.ChannelPoint.String(),
+			Attempts:     event.Attempts,
+		}
+
+	default:
+		return nil
+	}
+}
+
+// newPeerEvent converts an update of the peer notifier to a webhook event. It
+// returns nil for updates that aren't posted to webhooks.
+func newPeerEvent(update interface{}, timestamp int64) *Event {
+	switch event := update.(type) {
+	case peernotifier.PeerOnlineEvent:
+		return &Event{
+			Type:         EventPeerOnline,
+			Timestamp:    timestamp,
+			RemotePubKey: hex.EncodeToString(event.PubKey[:]),
+		}
+
+	case peernotifier.PeerOfflineEvent:
+		return &Event{
+			Type:         EventPeerOffline,
+			Timestamp:    timestamp,
+			RemotePubKey: hex.EncodeToString(event.PubKey[:]),
+		}
+
+	default:
+		return nil
+	}
+}
@@ -3,6 +3,8 @@ package zpay32
 import (
 	"fmt"
 	"strings"
+
+	"github.com/btcsuite/btcutil/bech32"
 )
 
 const charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
@@ -83,6 +85,23 @@ func decodeBech32(bech string) (string, []byte, error) {
 	return hrp, decoded[:len(decoded)-6], nil
 }
 
+// DecodeBech32 decodes a bech32 encoded string that, like an invoice, may be
+// longer than 90 characters, such as an LNURL. It returns the human-readable
+// part and the data part converted to 8-bit representation.
+func DecodeBech32(bech string) (string, []byte, error) {
+	hrp, data, err := decodeBech32(bech)
+	if err != nil {
+		return "", nil, err
+	}
+
+	converted, err := bech32.ConvertBits(data, 5, 8, false)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return hrp, converted, nil
+}
+
 // toBytes converts each character in the string 'chars' to the value of the
 // index of the corresponding character in 'charset'.
 func toBytes(chars string) ([]byte, error) {
@@ -44,9 +44,11 @@ func Decode(invoice string, net *chaincfg.Params) (*Invoice, error) {
 		return nil, fmt.Errorf("prefix should be \"ln\"")
 	}
 
-	// The next characters should be a valid prefix for a segwit BIP173
-	// address that match the active network.
-	if !strings.HasPrefix(hrp[2:], net.Bech32HRPSegwit) {
+	// The next characters should be the invoice prefix of the active
+	// network, which is its BIP173 segwit prefix on all networks except
+	// signet.
+	netPrefix := invoiceNetPrefix(net)
+	if !strings.HasPrefix(hrp[2:], netPrefix) {
 		return nil, fmt.Errorf(
 			"invoice not for current active network '%s'", net.Name)
 	}
@@ -54,7 +56,7 @@ func Decode(invoice string, net *chaincfg.Params) (*Invoice, error) {
 
 	// Optionally, if there's anything left of the HRP after ln + the segwit
 	// prefix, we try to decode this as the payment amount.
-	var netPrefixLength = len(net.Bech32HRPSegwit) + 2
+	var netPrefixLength = len(netPrefix) + 2
 	if len(hrp) > netPrefixLength {
 		amount, err := decodeAmount(hrp[netPrefixLength:])
 		if err != nil {
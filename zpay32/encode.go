@@ -52,7 +52,7 @@ func (invoice *Invoice) Encode(signer MessageSigner) (string, error) {
 	}
 
 	// The human-readable part (hrp) is "ln" + net hrp + optional amount.
-	hrp := "ln" + invoice.Net.Bech32HRPSegwit
+	hrp := "ln" + invoiceNetPrefix(invoice.Net)
 	if invoice.MilliSat != nil {
 		// Encode the amount using the fewest possible characters.
 		am, err := encodeAmount(*invoice.MilliSat)
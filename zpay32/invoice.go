@@ -78,6 +78,14 @@ const (
 	// single QR code: https://en.wikipedia.org/wiki/QR_code#Storage
 	maxInvoiceLength = 7089
 
+	// sigNetName is the name of the signet chain params. Signet shares its
+	// segwit address prefix with testnet, so its invoices use a distinct
+	// network prefix.
+	sigNetName = "signet"
+
+	// sigNetInvoicePrefix is the network prefix of signet invoices.
+	sigNetInvoicePrefix = "tbs"
+
 	// DefaultInvoiceExpiry is the default expiry duration from the creation
 	// timestamp if expiry is set to zero.
 	DefaultInvoiceExpiry = time.Hour
@@ -327,6 +335,16 @@ func (invoice *Invoice) MinFinalCLTVExpiry() uint64 {
 	return DefaultAssumedFinalCLTVDelta
 }
 
+// invoiceNetPrefix returns the network prefix that follows "ln" in the
+// human-readable part of invoices for the given network.
+func invoiceNetPrefix(net *chaincfg.Params) string {
+	if net.Name == sigNetName {
+		return sigNetInvoicePrefix
+	}
+
+	return net.Bech32HRPSegwit
+}
+
 // validateInvoice does a sanity check of the provided Invoice, making sure it
 // has all the necessary fields set for it to be considered valid by BOLT-0011.
 func validateInvoice(invoice *Invoice) error {
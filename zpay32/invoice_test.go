@@ -119,6 +119,7 @@ var (
 
 	ltcTestNetParams chaincfg.Params
 	ltcMainNetParams chaincfg.Params
+	sigNetParams     chaincfg.Params
 )
 
 func init() {
@@ -133,6 +134,11 @@ func init() {
 	ltcMainNetParams = chaincfg.MainNetParams
 	ltcMainNetParams.Net = wire.BitcoinNet(litecoinCfg.MainNetParams.Net)
 	ltcMainNetParams.Bech32HRPSegwit = litecoinCfg.MainNetParams.Bech32HRPSegwit
+
+	// Signet params only differ from testnet params in the fields that
+	// aren't relevant for invoices, apart from their name.
+	sigNetParams = chaincfg.TestNet3Params
+	sigNetParams.Name = sigNetName
 }
 
 // TestDecodeEncode tests that an encoded invoice gets decoded into the expected
@@ -952,6 +958,51 @@ func TestInvoiceChecksumMalleability(t *testing.T) {
 
 }
 
+// TestSigNetInvoice tests that signet invoices use their own network prefix,
+// so they can't be confused with testnet invoices.
+func TestSigNetInvoice(t *testing.T) {
+	t.Parallel()
+
+	var amt lnwire.MilliSatoshi = 2500000000
+	invoice, err := NewInvoice(
+		&sigNetParams, testPaymentHash, time.Unix(1496314658, 0),
+		Amount(amt), Description(testCupOfCoffee),
+	)
+	if err != nil {
+		t.Fatalf("unable to create invoice: %v", err)
+	}
+
+	encoded, err := invoice.Encode(testMessageSigner)
+	if err != nil {
+		t.Fatalf("unable to encode invoice: %v", err)
+	}
+	if !strings.HasPrefix(encoded, "lntbs25m1") {
+		t.Fatalf("unexpected signet invoice prefix: %v", encoded)
+	}
+
+	decoded, err := Decode(encoded, &sigNetParams)
+	if err != nil {
+		t.Fatalf("unable to decode signet invoice: %v", err)
+	}
+	if *decoded.MilliSat != amt {
+		t.Fatalf("expected amount %v, got %v", amt, *decoded.MilliSat)
+	}
+
+	// Neither network accepts the invoices of the other.
+	if _, err := Decode(encoded, &chaincfg.TestNet3Params); err == nil {
+		t.Fatalf("expected signet invoice to be rejected on testnet")
+	}
+
+	invoice.Net = &chaincfg.TestNet3Params
+	encoded, err = invoice.Encode(testMessageSigner)
+	if err != nil {
+		t.Fatalf("unable to encode invoice: %v", err)
+	}
+	if _, err := Decode(encoded, &sigNetParams); err == nil {
+		t.Fatalf("expected testnet invoice to be rejected on signet")
+	}
+}
+
 func compareInvoices(expected, actual *Invoice) error {
 	if !reflect.DeepEqual(expected.Net, actual.Net) {
 		return fmt.Errorf("expected net %v, got %v",